@@ -0,0 +1,118 @@
+package abcicli
+
+import (
+	"context"
+
+	types "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/service"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+)
+
+// SenderFunc returns the key of the sender of a transaction, by which its
+// CheckTx call is ordered, or an empty key if the call needs no ordering.
+type SenderFunc func(tx []byte) string
+
+// concurrentLocalClient is a local client running up to a number of CheckTx
+// calls at the same time. The other calls hold the mutex exclusively.
+type concurrentLocalClient struct {
+	*localClient
+
+	rwMtx *cmtsync.RWMutex
+	cbMtx cmtsync.Mutex
+	slots chan struct{}
+
+	sender    SenderFunc
+	senderMtx cmtsync.Mutex
+	lastCalls map[string]chan struct{} // closed when the last call of the sender returns
+}
+
+var _ Client = (*concurrentLocalClient)(nil)
+
+// NewConcurrentLocalClient creates a local client like [NewLocalClient],
+// except that up to concurrency CheckTx calls run at the same time, which the
+// application must support. The other calls are still serialized with each
+// other and with the CheckTx calls. The CheckTx calls of the transactions
+// with the same sender, as returned by sender, run one at a time, in the
+// order they were made. If sender is nil, the calls are not ordered.
+//
+// If a mutex is not supplied (i.e. if mtx is nil), then one will be created.
+func NewConcurrentLocalClient(mtx *cmtsync.RWMutex, app types.Application, concurrency int, sender SenderFunc) Client {
+	if mtx == nil {
+		mtx = new(cmtsync.RWMutex)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	cli := &concurrentLocalClient{
+		localClient: &localClient{
+			mtx:         mtx,
+			Application: app,
+		},
+		rwMtx:     mtx,
+		slots:     make(chan struct{}, concurrency),
+		sender:    sender,
+		lastCalls: make(map[string]chan struct{}),
+	}
+	cli.BaseService = *service.NewBaseService(nil, "concurrentLocalClient", cli)
+	return cli
+}
+
+func (app *concurrentLocalClient) CheckTxAsync(ctx context.Context, req *types.CheckTxRequest) (*ReqRes, error) {
+	release := app.acquire(req.Tx)
+	defer release()
+
+	res, err := app.Application.CheckTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// The callback is invoked before the next call of the sender runs.
+	app.cbMtx.Lock()
+	defer app.cbMtx.Unlock()
+	return app.callback(
+		types.ToCheckTxRequest(req),
+		types.ToCheckTxResponse(res),
+	), nil
+}
+
+func (app *concurrentLocalClient) CheckTx(ctx context.Context, req *types.CheckTxRequest) (*types.CheckTxResponse, error) {
+	release := app.acquire(req.Tx)
+	defer release()
+
+	return app.Application.CheckTx(ctx, req)
+}
+
+// acquire waits for the CheckTx calls of the sender of tx made before to
+// return, and for a free slot. The returned function releases them.
+func (app *concurrentLocalClient) acquire(tx []byte) func() {
+	var key string
+	if app.sender != nil {
+		key = app.sender(tx)
+	}
+	var turn chan struct{}
+	if key != "" {
+		turn = make(chan struct{})
+		app.senderMtx.Lock()
+		prev := app.lastCalls[key]
+		app.lastCalls[key] = turn
+		app.senderMtx.Unlock()
+		if prev != nil {
+			<-prev
+		}
+	}
+
+	app.slots <- struct{}{}
+	app.rwMtx.RLock()
+	return func() {
+		app.rwMtx.RUnlock()
+		<-app.slots
+		if turn == nil {
+			return
+		}
+		app.senderMtx.Lock()
+		if app.lastCalls[key] == turn {
+			delete(app.lastCalls, key)
+		}
+		app.senderMtx.Unlock()
+		close(turn)
+	}
+}
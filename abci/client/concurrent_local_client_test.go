@@ -0,0 +1,126 @@
+package abcicli_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/types"
+)
+
+// concurrentApp records the CheckTx calls of the transactions "sender/seq".
+type concurrentApp struct {
+	types.BaseApplication
+
+	mtx        sync.Mutex
+	running    int
+	maxRunning int
+	seqs       map[string][]string
+	commits    int
+	overlaps   int
+}
+
+func (app *concurrentApp) CheckTx(_ context.Context, req *types.CheckTxRequest) (*types.CheckTxResponse, error) {
+	app.mtx.Lock()
+	app.running++
+	if app.running > app.maxRunning {
+		app.maxRunning = app.running
+	}
+	sender, seq, _ := strings.Cut(string(req.Tx), "/")
+	app.seqs[sender] = append(app.seqs[sender], seq)
+	app.mtx.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	app.mtx.Lock()
+	app.running--
+	app.mtx.Unlock()
+	return &types.CheckTxResponse{Code: types.CodeTypeOK}, nil
+}
+
+func (app *concurrentApp) Commit(context.Context, *types.CommitRequest) (*types.CommitResponse, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.commits++
+	if app.running > 0 {
+		app.overlaps++
+	}
+	return &types.CommitResponse{}, nil
+}
+
+func txSender(tx []byte) string {
+	sender, _, _ := strings.Cut(string(tx), "/")
+	return sender
+}
+
+func TestConcurrentLocalClient(t *testing.T) {
+	app := &concurrentApp{seqs: make(map[string][]string)}
+	c := abcicli.NewConcurrentLocalClient(nil, app, 3, txSender)
+	c.SetResponseCallback(func(*types.Request, *types.Response) {})
+
+	const senders, txs = 6, 5
+	var wg sync.WaitGroup
+	for s := 0; s < senders; s++ {
+		wg.Add(1)
+		go func(s int) {
+			defer wg.Done()
+			var senderWg sync.WaitGroup
+			for i := 0; i < txs; i++ {
+				senderWg.Add(1)
+				go func(i int) {
+					defer senderWg.Done()
+					tx := []byte(fmt.Sprintf("%d/%d", s, i))
+					_, err := c.CheckTxAsync(context.Background(), &types.CheckTxRequest{Tx: tx})
+					assert.NoError(t, err)
+				}(i)
+				// The calls of the sender are made one after the other.
+				time.Sleep(2 * time.Millisecond)
+			}
+			senderWg.Wait()
+		}(s)
+	}
+	for i := 0; i < 5; i++ {
+		_, err := c.Commit(context.Background(), &types.CommitRequest{})
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+	}
+	wg.Wait()
+
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	assert.Equal(t, 3, app.maxRunning)
+	assert.Equal(t, 5, app.commits)
+	assert.Zero(t, app.overlaps, "Commit ran along with CheckTx")
+	for s := 0; s < senders; s++ {
+		assert.Equal(t, []string{"0", "1", "2", "3", "4"}, app.seqs[fmt.Sprint(s)], "sender %d", s)
+	}
+}
+
+func TestConcurrentLocalClientNoSender(t *testing.T) {
+	app := &concurrentApp{seqs: make(map[string][]string)}
+	c := abcicli.NewConcurrentLocalClient(nil, app, 4, nil)
+
+	// Without a sender, the calls of the same sender are not ordered.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := c.CheckTx(context.Background(), &types.CheckTxRequest{Tx: []byte(fmt.Sprintf("a/%d", i))})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	assert.Equal(t, 4, app.maxRunning)
+	assert.Len(t, app.seqs["a"], 8)
+}
@@ -2,6 +2,7 @@ package abcicli
 
 import (
 	"context"
+	"sync"
 
 	types "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/internal/service"
@@ -15,7 +16,7 @@ import (
 type localClient struct {
 	service.BaseService
 
-	mtx *cmtsync.Mutex
+	mtx sync.Locker
 	types.Application
 	Callback
 }
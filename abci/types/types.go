@@ -97,14 +97,25 @@ type (
 	ExtendedVoteInfo   = v1.ExtendedVoteInfo
 	Event              = v1.Event
 	EventAttribute     = v1.EventAttribute
+	EventSchema        = v1.EventSchema
+	EventTypeSchema    = v1.EventTypeSchema
+	AttributeSchema    = v1.AttributeSchema
 	Misbehavior        = v1.Misbehavior
+	ProposedTx         = v1.ProposedTx
 	Snapshot           = v1.Snapshot
+	TxDiff             = v1.TxDiff
 	TxResult           = v1.TxResult
 	Validator          = v1.Validator
 	ValidatorUpdate    = v1.ValidatorUpdate
 	VoteInfo           = v1.VoteInfo
 )
 
+// Discriminated ProposedTx variants are defined in the latest proto package.
+type (
+	ProposedTx_Index    = v1.ProposedTx_Index
+	ProposedTx_Inserted = v1.ProposedTx_Inserted
+)
+
 type (
 	ABCIServiceClient = v1.ABCIServiceClient
 	ABCIServiceServer = v1.ABCIServiceServer
@@ -121,9 +132,10 @@ func RegisterABCIServer(s grpc.Server, srv ABCIServiceServer) {
 type CheckTxType = v1.CheckTxType
 
 const (
-	CHECK_TX_TYPE_UNKNOWN CheckTxType = v1.CHECK_TX_TYPE_UNKNOWN
-	CHECK_TX_TYPE_CHECK   CheckTxType = v1.CHECK_TX_TYPE_CHECK
-	CHECK_TX_TYPE_RECHECK CheckTxType = v1.CHECK_TX_TYPE_RECHECK
+	CHECK_TX_TYPE_UNKNOWN  CheckTxType = v1.CHECK_TX_TYPE_UNKNOWN
+	CHECK_TX_TYPE_CHECK    CheckTxType = v1.CHECK_TX_TYPE_CHECK
+	CHECK_TX_TYPE_RECHECK  CheckTxType = v1.CHECK_TX_TYPE_RECHECK
+	CHECK_TX_TYPE_SIMULATE CheckTxType = v1.CHECK_TX_TYPE_SIMULATE
 )
 
 type MisbehaviorType = v1.MisbehaviorType
@@ -134,6 +146,16 @@ const (
 	MISBEHAVIOR_TYPE_LIGHT_CLIENT_ATTACK MisbehaviorType = v1.MISBEHAVIOR_TYPE_LIGHT_CLIENT_ATTACK
 )
 
+type AttributeType = v1.AttributeType
+
+const (
+	ATTRIBUTE_TYPE_STRING AttributeType = v1.ATTRIBUTE_TYPE_STRING
+	ATTRIBUTE_TYPE_INT    AttributeType = v1.ATTRIBUTE_TYPE_INT
+	ATTRIBUTE_TYPE_UINT   AttributeType = v1.ATTRIBUTE_TYPE_UINT
+	ATTRIBUTE_TYPE_BOOL   AttributeType = v1.ATTRIBUTE_TYPE_BOOL
+	ATTRIBUTE_TYPE_TIME   AttributeType = v1.ATTRIBUTE_TYPE_TIME
+)
+
 type ApplySnapshotChunkResult = v1.ApplySnapshotChunkResult
 
 const (
@@ -43,18 +43,22 @@ const (
 	CHECK_TX_TYPE_RECHECK CheckTxType = 1
 	// Check (1st time)
 	CHECK_TX_TYPE_CHECK CheckTxType = 2
+	// Simulate (not added to the mempool, state changes are discarded)
+	CHECK_TX_TYPE_SIMULATE CheckTxType = 3
 )
 
 var CheckTxType_name = map[int32]string{
 	0: "CHECK_TX_TYPE_UNKNOWN",
 	1: "CHECK_TX_TYPE_RECHECK",
 	2: "CHECK_TX_TYPE_CHECK",
+	3: "CHECK_TX_TYPE_SIMULATE",
 }
 
 var CheckTxType_value = map[string]int32{
-	"CHECK_TX_TYPE_UNKNOWN": 0,
-	"CHECK_TX_TYPE_RECHECK": 1,
-	"CHECK_TX_TYPE_CHECK":   2,
+	"CHECK_TX_TYPE_UNKNOWN":  0,
+	"CHECK_TX_TYPE_RECHECK":  1,
+	"CHECK_TX_TYPE_CHECK":    2,
+	"CHECK_TX_TYPE_SIMULATE": 3,
 }
 
 func (x CheckTxType) String() string {
@@ -220,6 +224,46 @@ func (VerifyVoteExtensionStatus) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_95dd8f7b670b96e3, []int{4}
 }
 
+// AttributeType is the type of the values of an event attribute.
+type AttributeType int32
+
+const (
+	// Any string
+	ATTRIBUTE_TYPE_STRING AttributeType = 0
+	// A signed 64-bit integer in base 10
+	ATTRIBUTE_TYPE_INT AttributeType = 1
+	// An unsigned 64-bit integer in base 10
+	ATTRIBUTE_TYPE_UINT AttributeType = 2
+	// "true" or "false"
+	ATTRIBUTE_TYPE_BOOL AttributeType = 3
+	// An RFC3339 timestamp
+	ATTRIBUTE_TYPE_TIME AttributeType = 4
+)
+
+var AttributeType_name = map[int32]string{
+	0: "ATTRIBUTE_TYPE_STRING",
+	1: "ATTRIBUTE_TYPE_INT",
+	2: "ATTRIBUTE_TYPE_UINT",
+	3: "ATTRIBUTE_TYPE_BOOL",
+	4: "ATTRIBUTE_TYPE_TIME",
+}
+
+var AttributeType_value = map[string]int32{
+	"ATTRIBUTE_TYPE_STRING": 0,
+	"ATTRIBUTE_TYPE_INT":    1,
+	"ATTRIBUTE_TYPE_UINT":   2,
+	"ATTRIBUTE_TYPE_BOOL":   3,
+	"ATTRIBUTE_TYPE_TIME":   4,
+}
+
+func (x AttributeType) String() string {
+	return proto.EnumName(AttributeType_name, int32(x))
+}
+
+func (AttributeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_95dd8f7b670b96e3, []int{5}
+}
+
 // The type of misbehavior committed by a validator.
 type MisbehaviorType int32
 
@@ -249,7 +293,7 @@ func (x MisbehaviorType) String() string {
 }
 
 func (MisbehaviorType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{5}
+	return fileDescriptor_95dd8f7b670b96e3, []int{6}
 }
 
 // Request represents a request to the ABCI application.
@@ -2037,6 +2081,9 @@ type InfoResponse struct {
 	AppVersion       uint64 `protobuf:"varint,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
 	LastBlockHeight  int64  `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
 	LastBlockAppHash []byte `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+	// The types of the values of the event attributes emitted by the
+	// application, if it declares them.
+	EventSchema *EventSchema `protobuf:"bytes,6,opt,name=event_schema,json=eventSchema,proto3" json:"event_schema,omitempty"`
 }
 
 func (m *InfoResponse) Reset()         { *m = InfoResponse{} }
@@ -2107,6 +2154,13 @@ func (m *InfoResponse) GetLastBlockAppHash() []byte {
 	return nil
 }
 
+func (m *InfoResponse) GetEventSchema() *EventSchema {
+	if m != nil {
+		return m.EventSchema
+	}
+	return nil
+}
+
 // InitChainResponse contains the ABCI application's hash and updates to the
 // validator set and/or the consensus params, if any.
 type InitChainResponse struct {
@@ -2290,6 +2344,20 @@ type CheckTxResponse struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// Weight of the transaction, counted against the max_block_weight of the
+	// mempool configuration when reaping transactions for a proposal. 0 if the
+	// application does not weigh its transactions.
+	Weight int64 `protobuf:"varint,12,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Sender of the transaction, used by the sender queue of the mempool to
+	// order the transactions of a sender. Empty if the application does not
+	// report the senders.
+	Sender string `protobuf:"bytes,9,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Sequence (nonce) of the transaction.
+	Sequence uint64 `protobuf:"varint,13,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Next sequence the application expects from the sender. A valid
+	// transaction whose sequence is greater is held by the sender queue of the
+	// mempool until the transactions before it are added.
+	NextSequence uint64 `protobuf:"varint,14,opt,name=next_sequence,proto3" json:"next_sequence,omitempty"`
 }
 
 func (m *CheckTxResponse) Reset()         { *m = CheckTxResponse{} }
@@ -2381,6 +2449,34 @@ func (m *CheckTxResponse) GetCodespace() string {
 	return ""
 }
 
+func (m *CheckTxResponse) GetWeight() int64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *CheckTxResponse) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *CheckTxResponse) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *CheckTxResponse) GetNextSequence() uint64 {
+	if m != nil {
+		return m.NextSequence
+	}
+	return 0
+}
+
 // CommitResponse indicates how much blocks should CometBFT retain.
 type CommitResponse struct {
 	RetainHeight int64 `protobuf:"varint,3,opt,name=retain_height,json=retainHeight,proto3" json:"retain_height,omitempty"`
@@ -2626,6 +2722,10 @@ func (m *ApplySnapshotChunkResponse) GetRejectSenders() []string {
 // PrepareProposalResponse contains a list of transactions, which will form a block.
 type PrepareProposalResponse struct {
 	Txs [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	// If set, the transactions of the block are described relative to the ones
+	// of the request, and txs must be empty. Supported from ABCI 2.1.0 (see
+	// InfoRequest.abci_version).
+	TxDiff *TxDiff `protobuf:"bytes,2,opt,name=tx_diff,json=txDiff,proto3" json:"tx_diff,omitempty"`
 }
 
 func (m *PrepareProposalResponse) Reset()         { *m = PrepareProposalResponse{} }
@@ -2668,6 +2768,147 @@ func (m *PrepareProposalResponse) GetTxs() [][]byte {
 	return nil
 }
 
+func (m *PrepareProposalResponse) GetTxDiff() *TxDiff {
+	if m != nil {
+		return m.TxDiff
+	}
+	return nil
+}
+
+// TxDiff lists the transactions of a block relative to the transactions of a
+// PrepareProposalRequest.
+type TxDiff struct {
+	// The transactions of the block, in order.
+	Txs []*ProposedTx `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *TxDiff) Reset()         { *m = TxDiff{} }
+func (m *TxDiff) String() string { return proto.CompactTextString(m) }
+func (*TxDiff) ProtoMessage()    {}
+func (*TxDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95dd8f7b670b96e3, []int{31}
+}
+func (m *TxDiff) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxDiff.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxDiff.Merge(m, src)
+}
+func (m *TxDiff) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxDiff proto.InternalMessageInfo
+
+func (m *TxDiff) GetTxs() []*ProposedTx {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+// ProposedTx is either a transaction of the PrepareProposalRequest, referred to
+// by its index, or a transaction inserted by the application.
+type ProposedTx struct {
+	// Types that are valid to be assigned to Tx:
+	//	*ProposedTx_Index
+	//	*ProposedTx_Inserted
+	Tx isProposedTx_Tx `protobuf_oneof:"tx"`
+}
+
+func (m *ProposedTx) Reset()         { *m = ProposedTx{} }
+func (m *ProposedTx) String() string { return proto.CompactTextString(m) }
+func (*ProposedTx) ProtoMessage()    {}
+func (*ProposedTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95dd8f7b670b96e3, []int{32}
+}
+func (m *ProposedTx) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProposedTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProposedTx.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProposedTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProposedTx.Merge(m, src)
+}
+func (m *ProposedTx) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProposedTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProposedTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProposedTx proto.InternalMessageInfo
+
+type isProposedTx_Tx interface {
+	isProposedTx_Tx()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type ProposedTx_Index struct {
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3,oneof" json:"index,omitempty"`
+}
+type ProposedTx_Inserted struct {
+	Inserted []byte `protobuf:"bytes,2,opt,name=inserted,proto3,oneof" json:"inserted,omitempty"`
+}
+
+func (*ProposedTx_Index) isProposedTx_Tx()    {}
+func (*ProposedTx_Inserted) isProposedTx_Tx() {}
+
+func (m *ProposedTx) GetTx() isProposedTx_Tx {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func (m *ProposedTx) GetIndex() uint32 {
+	if x, ok := m.GetTx().(*ProposedTx_Index); ok {
+		return x.Index
+	}
+	return 0
+}
+
+func (m *ProposedTx) GetInserted() []byte {
+	if x, ok := m.GetTx().(*ProposedTx_Inserted); ok {
+		return x.Inserted
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ProposedTx) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ProposedTx_Index)(nil),
+		(*ProposedTx_Inserted)(nil),
+	}
+}
+
 // ProcessProposalResponse indicates the ABCI application's decision whenever
 // the given proposal should be accepted or not.
 type ProcessProposalResponse struct {
@@ -2678,7 +2919,7 @@ func (m *ProcessProposalResponse) Reset()         { *m = ProcessProposalResponse
 func (m *ProcessProposalResponse) String() string { return proto.CompactTextString(m) }
 func (*ProcessProposalResponse) ProtoMessage()    {}
 func (*ProcessProposalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{31}
+	return fileDescriptor_95dd8f7b670b96e3, []int{33}
 }
 func (m *ProcessProposalResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2724,7 +2965,7 @@ func (m *ExtendVoteResponse) Reset()         { *m = ExtendVoteResponse{} }
 func (m *ExtendVoteResponse) String() string { return proto.CompactTextString(m) }
 func (*ExtendVoteResponse) ProtoMessage()    {}
 func (*ExtendVoteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{32}
+	return fileDescriptor_95dd8f7b670b96e3, []int{34}
 }
 func (m *ExtendVoteResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2770,7 +3011,7 @@ func (m *VerifyVoteExtensionResponse) Reset()         { *m = VerifyVoteExtension
 func (m *VerifyVoteExtensionResponse) String() string { return proto.CompactTextString(m) }
 func (*VerifyVoteExtensionResponse) ProtoMessage()    {}
 func (*VerifyVoteExtensionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{33}
+	return fileDescriptor_95dd8f7b670b96e3, []int{35}
 }
 func (m *VerifyVoteExtensionResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2828,7 +3069,7 @@ func (m *FinalizeBlockResponse) Reset()         { *m = FinalizeBlockResponse{} }
 func (m *FinalizeBlockResponse) String() string { return proto.CompactTextString(m) }
 func (*FinalizeBlockResponse) ProtoMessage()    {}
 func (*FinalizeBlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{34}
+	return fileDescriptor_95dd8f7b670b96e3, []int{36}
 }
 func (m *FinalizeBlockResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2902,7 +3143,7 @@ func (m *CommitInfo) Reset()         { *m = CommitInfo{} }
 func (m *CommitInfo) String() string { return proto.CompactTextString(m) }
 func (*CommitInfo) ProtoMessage()    {}
 func (*CommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{35}
+	return fileDescriptor_95dd8f7b670b96e3, []int{37}
 }
 func (m *CommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2960,7 +3201,7 @@ func (m *ExtendedCommitInfo) Reset()         { *m = ExtendedCommitInfo{} }
 func (m *ExtendedCommitInfo) String() string { return proto.CompactTextString(m) }
 func (*ExtendedCommitInfo) ProtoMessage()    {}
 func (*ExtendedCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{36}
+	return fileDescriptor_95dd8f7b670b96e3, []int{38}
 }
 func (m *ExtendedCommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3017,7 +3258,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{37}
+	return fileDescriptor_95dd8f7b670b96e3, []int{39}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3071,7 +3312,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{38}
+	return fileDescriptor_95dd8f7b670b96e3, []int{40}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3121,6 +3362,158 @@ func (m *EventAttribute) GetIndex() bool {
 	return false
 }
 
+// EventSchema declares the types of the values of event attributes. The
+// attributes it does not list are strings.
+type EventSchema struct {
+	EventTypes []EventTypeSchema `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types"`
+}
+
+func (m *EventSchema) Reset()         { *m = EventSchema{} }
+func (m *EventSchema) String() string { return proto.CompactTextString(m) }
+func (*EventSchema) ProtoMessage()    {}
+func (*EventSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95dd8f7b670b96e3, []int{41}
+}
+func (m *EventSchema) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventSchema.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventSchema.Merge(m, src)
+}
+func (m *EventSchema) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventSchema proto.InternalMessageInfo
+
+func (m *EventSchema) GetEventTypes() []EventTypeSchema {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+// EventTypeSchema declares the types of the attributes of an event type.
+type EventTypeSchema struct {
+	Type       string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Attributes []AttributeSchema `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes"`
+}
+
+func (m *EventTypeSchema) Reset()         { *m = EventTypeSchema{} }
+func (m *EventTypeSchema) String() string { return proto.CompactTextString(m) }
+func (*EventTypeSchema) ProtoMessage()    {}
+func (*EventTypeSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95dd8f7b670b96e3, []int{42}
+}
+func (m *EventTypeSchema) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventTypeSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventTypeSchema.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventTypeSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventTypeSchema.Merge(m, src)
+}
+func (m *EventTypeSchema) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventTypeSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventTypeSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventTypeSchema proto.InternalMessageInfo
+
+func (m *EventTypeSchema) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *EventTypeSchema) GetAttributes() []AttributeSchema {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+// AttributeSchema declares the type of the values of an event attribute.
+type AttributeSchema struct {
+	Key  string        `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type AttributeType `protobuf:"varint,2,opt,name=type,proto3,enum=cometbft.abci.v1.AttributeType" json:"type,omitempty"`
+}
+
+func (m *AttributeSchema) Reset()         { *m = AttributeSchema{} }
+func (m *AttributeSchema) String() string { return proto.CompactTextString(m) }
+func (*AttributeSchema) ProtoMessage()    {}
+func (*AttributeSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95dd8f7b670b96e3, []int{43}
+}
+func (m *AttributeSchema) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AttributeSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AttributeSchema.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AttributeSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttributeSchema.Merge(m, src)
+}
+func (m *AttributeSchema) XXX_Size() int {
+	return m.Size()
+}
+func (m *AttributeSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttributeSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttributeSchema proto.InternalMessageInfo
+
+func (m *AttributeSchema) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *AttributeSchema) GetType() AttributeType {
+	if m != nil {
+		return m.Type
+	}
+	return ATTRIBUTE_TYPE_STRING
+}
+
 // ExecTxResult contains results of executing one individual transaction.
 //
 // * Its structure is equivalent to #ResponseDeliverTx which will be deprecated/deleted
@@ -3139,7 +3532,7 @@ func (m *ExecTxResult) Reset()         { *m = ExecTxResult{} }
 func (m *ExecTxResult) String() string { return proto.CompactTextString(m) }
 func (*ExecTxResult) ProtoMessage()    {}
 func (*ExecTxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{39}
+	return fileDescriptor_95dd8f7b670b96e3, []int{44}
 }
 func (m *ExecTxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3238,7 +3631,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{40}
+	return fileDescriptor_95dd8f7b670b96e3, []int{45}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3306,7 +3699,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{41}
+	return fileDescriptor_95dd8f7b670b96e3, []int{46}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3359,7 +3752,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{42}
+	return fileDescriptor_95dd8f7b670b96e3, []int{47}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3412,7 +3805,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{43}
+	return fileDescriptor_95dd8f7b670b96e3, []int{48}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3471,7 +3864,7 @@ func (m *ExtendedVoteInfo) Reset()         { *m = ExtendedVoteInfo{} }
 func (m *ExtendedVoteInfo) String() string { return proto.CompactTextString(m) }
 func (*ExtendedVoteInfo) ProtoMessage()    {}
 func (*ExtendedVoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{44}
+	return fileDescriptor_95dd8f7b670b96e3, []int{49}
 }
 func (m *ExtendedVoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3547,7 +3940,7 @@ func (m *Misbehavior) Reset()         { *m = Misbehavior{} }
 func (m *Misbehavior) String() string { return proto.CompactTextString(m) }
 func (*Misbehavior) ProtoMessage()    {}
 func (*Misbehavior) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{45}
+	return fileDescriptor_95dd8f7b670b96e3, []int{50}
 }
 func (m *Misbehavior) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3624,7 +4017,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_95dd8f7b670b96e3, []int{46}
+	return fileDescriptor_95dd8f7b670b96e3, []int{51}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3694,6 +4087,7 @@ func init() {
 	proto.RegisterEnum("cometbft.abci.v1.ApplySnapshotChunkResult", ApplySnapshotChunkResult_name, ApplySnapshotChunkResult_value)
 	proto.RegisterEnum("cometbft.abci.v1.ProcessProposalStatus", ProcessProposalStatus_name, ProcessProposalStatus_value)
 	proto.RegisterEnum("cometbft.abci.v1.VerifyVoteExtensionStatus", VerifyVoteExtensionStatus_name, VerifyVoteExtensionStatus_value)
+	proto.RegisterEnum("cometbft.abci.v1.AttributeType", AttributeType_name, AttributeType_value)
 	proto.RegisterEnum("cometbft.abci.v1.MisbehaviorType", MisbehaviorType_name, MisbehaviorType_value)
 	proto.RegisterType((*Request)(nil), "cometbft.abci.v1.Request")
 	proto.RegisterType((*EchoRequest)(nil), "cometbft.abci.v1.EchoRequest")
@@ -3726,6 +4120,8 @@ func init() {
 	proto.RegisterType((*LoadSnapshotChunkResponse)(nil), "cometbft.abci.v1.LoadSnapshotChunkResponse")
 	proto.RegisterType((*ApplySnapshotChunkResponse)(nil), "cometbft.abci.v1.ApplySnapshotChunkResponse")
 	proto.RegisterType((*PrepareProposalResponse)(nil), "cometbft.abci.v1.PrepareProposalResponse")
+	proto.RegisterType((*TxDiff)(nil), "cometbft.abci.v1.TxDiff")
+	proto.RegisterType((*ProposedTx)(nil), "cometbft.abci.v1.ProposedTx")
 	proto.RegisterType((*ProcessProposalResponse)(nil), "cometbft.abci.v1.ProcessProposalResponse")
 	proto.RegisterType((*ExtendVoteResponse)(nil), "cometbft.abci.v1.ExtendVoteResponse")
 	proto.RegisterType((*VerifyVoteExtensionResponse)(nil), "cometbft.abci.v1.VerifyVoteExtensionResponse")
@@ -3734,6 +4130,9 @@ func init() {
 	proto.RegisterType((*ExtendedCommitInfo)(nil), "cometbft.abci.v1.ExtendedCommitInfo")
 	proto.RegisterType((*Event)(nil), "cometbft.abci.v1.Event")
 	proto.RegisterType((*EventAttribute)(nil), "cometbft.abci.v1.EventAttribute")
+	proto.RegisterType((*EventSchema)(nil), "cometbft.abci.v1.EventSchema")
+	proto.RegisterType((*EventTypeSchema)(nil), "cometbft.abci.v1.EventTypeSchema")
+	proto.RegisterType((*AttributeSchema)(nil), "cometbft.abci.v1.AttributeSchema")
 	proto.RegisterType((*ExecTxResult)(nil), "cometbft.abci.v1.ExecTxResult")
 	proto.RegisterType((*TxResult)(nil), "cometbft.abci.v1.TxResult")
 	proto.RegisterType((*Validator)(nil), "cometbft.abci.v1.Validator")
@@ -3747,204 +4146,221 @@ func init() {
 func init() { proto.RegisterFile("cometbft/abci/v1/types.proto", fileDescriptor_95dd8f7b670b96e3) }
 
 var fileDescriptor_95dd8f7b670b96e3 = []byte{
-	// 3140 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
-	0xb5, 0xd6, 0xe0, 0x41, 0x02, 0x07, 0x00, 0x39, 0x6c, 0x92, 0x12, 0x44, 0xcb, 0x24, 0x35, 0xb4,
-	0x2c, 0x59, 0xb2, 0xc9, 0x2b, 0xd9, 0xd7, 0x8f, 0xeb, 0x57, 0x81, 0x10, 0x68, 0x92, 0xa2, 0x08,
-	0x78, 0x00, 0xf2, 0x5a, 0xaa, 0x7b, 0x33, 0x1e, 0x00, 0x0d, 0x62, 0x2c, 0x00, 0x33, 0x9e, 0x69,
-	0xd0, 0x60, 0xb2, 0x4a, 0x2a, 0x76, 0xa5, 0xbc, 0xf2, 0x26, 0x9b, 0x54, 0x52, 0x95, 0xaa, 0x54,
-	0x56, 0xa9, 0xca, 0x3a, 0xbf, 0x20, 0xe5, 0x55, 0xe2, 0x65, 0x56, 0x4e, 0xca, 0xde, 0x65, 0x91,
-	0x9d, 0xab, 0xb2, 0x4c, 0xf5, 0x63, 0x5e, 0xc0, 0x0c, 0x49, 0xc9, 0xce, 0x22, 0x95, 0xec, 0xa6,
-	0xbb, 0xbf, 0x73, 0xba, 0xfb, 0x74, 0xf7, 0x79, 0x7c, 0x00, 0x5c, 0x69, 0x99, 0x7d, 0x4c, 0x9a,
-	0x1d, 0xb2, 0xa1, 0x37, 0x5b, 0xc6, 0xc6, 0xf1, 0xed, 0x0d, 0x72, 0x62, 0x61, 0x67, 0xdd, 0xb2,
-	0x4d, 0x62, 0x22, 0xd9, 0x1d, 0x5d, 0xa7, 0xa3, 0xeb, 0xc7, 0xb7, 0x97, 0x9e, 0xf6, 0xf0, 0x2d,
-	0xfb, 0xc4, 0x22, 0x26, 0x95, 0x78, 0x84, 0x4f, 0x84, 0xc0, 0xd2, 0x72, 0xc4, 0xb0, 0x65, 0x9b,
-	0x66, 0x67, 0x62, 0x9c, 0x4d, 0xc3, 0x86, 0x75, 0x5b, 0xef, 0xbb, 0xf2, 0x57, 0x27, 0xc7, 0x8f,
-	0xf5, 0x9e, 0xd1, 0xd6, 0x89, 0x69, 0x0b, 0xc8, 0xc2, 0x91, 0x79, 0x64, 0xb2, 0xcf, 0x0d, 0xfa,
-	0x25, 0x7a, 0x57, 0x8e, 0x4c, 0xf3, 0xa8, 0x87, 0x37, 0x58, 0xab, 0x39, 0xec, 0x6c, 0x10, 0xa3,
-	0x8f, 0x1d, 0xa2, 0xf7, 0x2d, 0x0e, 0x50, 0xfe, 0x98, 0x85, 0x69, 0x15, 0x7f, 0x38, 0xc4, 0x0e,
-	0x41, 0x2f, 0x42, 0x0a, 0xb7, 0xba, 0x66, 0x51, 0x5a, 0x95, 0x6e, 0xe4, 0xee, 0x3c, 0xbd, 0x3e,
-	0xbe, 0xcb, 0xf5, 0x4a, 0xab, 0x6b, 0x0a, 0xf0, 0xf6, 0x05, 0x95, 0x81, 0xd1, 0xcb, 0x90, 0xee,
-	0xf4, 0x86, 0x4e, 0xb7, 0x98, 0x60, 0x52, 0xcb, 0x93, 0x52, 0x5b, 0x74, 0xd8, 0x17, 0xe3, 0x70,
-	0x3a, 0x99, 0x31, 0xe8, 0x98, 0xc5, 0x64, 0xdc, 0x64, 0x3b, 0x83, 0x4e, 0x70, 0x32, 0x0a, 0x46,
-	0x65, 0x00, 0x63, 0x60, 0x10, 0xad, 0xd5, 0xd5, 0x8d, 0x41, 0x31, 0xcd, 0x44, 0x95, 0x28, 0x51,
-	0x83, 0x94, 0x29, 0xc4, 0x97, 0xcf, 0x1a, 0x6e, 0x1f, 0x5d, 0xf1, 0x87, 0x43, 0x6c, 0x9f, 0x14,
-	0xa7, 0xe2, 0x56, 0xfc, 0x2e, 0x1d, 0x0e, 0xac, 0x98, 0xc1, 0xd1, 0x9b, 0x90, 0x69, 0x75, 0x71,
-	0xeb, 0x91, 0x46, 0x46, 0xc5, 0x0c, 0x13, 0x5d, 0x9d, 0x14, 0x2d, 0x53, 0x44, 0x63, 0xe4, 0x0b,
-	0x4f, 0xb7, 0x78, 0x0f, 0x7a, 0x0d, 0xa6, 0x5a, 0x66, 0xbf, 0x6f, 0x90, 0x62, 0x8e, 0x09, 0xaf,
-	0x44, 0x08, 0xb3, 0x71, 0x5f, 0x56, 0x08, 0xa0, 0x2a, 0xcc, 0xf4, 0x0c, 0x87, 0x68, 0xce, 0x40,
-	0xb7, 0x9c, 0xae, 0x49, 0x9c, 0x62, 0x9e, 0xa9, 0x78, 0x76, 0x52, 0xc5, 0x9e, 0xe1, 0x90, 0xba,
-	0x0b, 0xf3, 0x35, 0x15, 0x7a, 0xc1, 0x7e, 0xaa, 0xd0, 0xec, 0x74, 0xb0, 0xed, 0x69, 0x2c, 0x16,
-	0xe2, 0x14, 0x56, 0x29, 0xce, 0x95, 0x0c, 0x28, 0x34, 0x83, 0xfd, 0xe8, 0xff, 0x60, 0xbe, 0x67,
-	0xea, 0x6d, 0x4f, 0x9f, 0xd6, 0xea, 0x0e, 0x07, 0x8f, 0x8a, 0x33, 0x4c, 0xeb, 0xcd, 0x88, 0x65,
-	0x9a, 0x7a, 0xdb, 0x15, 0x2e, 0x53, 0xa8, 0xaf, 0x79, 0xae, 0x37, 0x3e, 0x86, 0x34, 0x58, 0xd0,
-	0x2d, 0xab, 0x77, 0x32, 0xae, 0x7e, 0x96, 0xa9, 0xbf, 0x35, 0xa9, 0xbe, 0x44, 0xd1, 0x31, 0xfa,
-	0x91, 0x3e, 0x31, 0x88, 0x0e, 0x40, 0xb6, 0x6c, 0x6c, 0xe9, 0x36, 0xd6, 0x2c, 0xdb, 0xb4, 0x4c,
-	0x47, 0xef, 0x15, 0x65, 0xa6, 0xfc, 0xc6, 0xa4, 0xf2, 0x1a, 0x47, 0xd6, 0x04, 0xd0, 0xd7, 0x3c,
-	0x6b, 0x85, 0x47, 0xb8, 0x5a, 0xb3, 0x85, 0x1d, 0xc7, 0x57, 0x3b, 0x17, 0xaf, 0x96, 0x21, 0x23,
-	0xd5, 0x86, 0x46, 0xd0, 0x16, 0xe4, 0xf0, 0x88, 0xe0, 0x41, 0x5b, 0x3b, 0x36, 0x09, 0x2e, 0x22,
-	0xa6, 0x71, 0x2d, 0xe2, 0xb9, 0x32, 0xd0, 0xa1, 0x49, 0xb0, 0xaf, 0x0c, 0xb0, 0xd7, 0x89, 0x9a,
-	0xb0, 0x78, 0x8c, 0x6d, 0xa3, 0x73, 0xc2, 0xf4, 0x68, 0x6c, 0xc4, 0x31, 0xcc, 0x41, 0x71, 0x9e,
-	0x69, 0x7c, 0x7e, 0x52, 0xe3, 0x21, 0x83, 0x53, 0xe1, 0x8a, 0x0b, 0xf6, 0x55, 0xcf, 0x1f, 0x4f,
-	0x8e, 0xd2, 0x9b, 0xd6, 0x31, 0x06, 0x7a, 0xcf, 0xf8, 0x3e, 0xd6, 0x9a, 0x3d, 0xb3, 0xf5, 0xa8,
-	0xb8, 0x10, 0x77, 0xd3, 0xb6, 0x04, 0x6e, 0x93, 0xc2, 0x02, 0x37, 0xad, 0x13, 0xec, 0xdf, 0x9c,
-	0x86, 0xf4, 0xb1, 0xde, 0x1b, 0xe2, 0xdd, 0x54, 0x26, 0x25, 0xa7, 0x77, 0x53, 0x99, 0x69, 0x39,
-	0xb3, 0x9b, 0xca, 0x64, 0x65, 0xd8, 0x4d, 0x65, 0x40, 0xce, 0x29, 0xd7, 0x21, 0x17, 0xf0, 0x53,
-	0xa8, 0x08, 0xd3, 0x7d, 0xec, 0x38, 0xfa, 0x11, 0x66, 0x7e, 0x2d, 0xab, 0xba, 0x4d, 0x65, 0x06,
-	0xf2, 0x41, 0xd7, 0xa4, 0x7c, 0x26, 0x41, 0x2e, 0xe0, 0x74, 0xa8, 0xe4, 0x31, 0xb6, 0x99, 0x41,
-	0x84, 0xa4, 0x68, 0xa2, 0x35, 0x28, 0xb0, 0xbd, 0x68, 0xee, 0x38, 0xf5, 0x7d, 0x29, 0x35, 0xcf,
-	0x3a, 0x0f, 0x05, 0x68, 0x05, 0x72, 0xd6, 0x1d, 0xcb, 0x83, 0x24, 0x19, 0x04, 0xac, 0x3b, 0x96,
-	0x0b, 0xb8, 0x0a, 0x79, 0xba, 0x75, 0x0f, 0x91, 0x62, 0x93, 0xe4, 0x68, 0x9f, 0x80, 0x28, 0x7f,
-	0x48, 0x80, 0x3c, 0xee, 0xcc, 0xd0, 0xab, 0x90, 0xa2, 0x5e, 0x5c, 0xb8, 0xe9, 0xa5, 0x75, 0xee,
-	0xe2, 0xd7, 0x5d, 0x17, 0xbf, 0xde, 0x70, 0x5d, 0xfc, 0x66, 0xe6, 0xf3, 0x2f, 0x57, 0x2e, 0x7c,
-	0xf6, 0xe7, 0x15, 0x49, 0x65, 0x12, 0xe8, 0x32, 0xf5, 0x60, 0xba, 0x31, 0xd0, 0x8c, 0x36, 0x5b,
-	0x72, 0x96, 0x7a, 0x27, 0xdd, 0x18, 0xec, 0xb4, 0xd1, 0x7d, 0x90, 0x5b, 0xe6, 0xc0, 0xc1, 0x03,
-	0x67, 0xe8, 0x68, 0x3c, 0xf6, 0x08, 0xd7, 0x1c, 0xf0, 0xaf, 0x3c, 0x06, 0x32, 0x47, 0x25, 0xa0,
-	0x35, 0x86, 0x54, 0x67, 0x5b, 0xe1, 0x0e, 0xf4, 0x0e, 0x80, 0x17, 0xa0, 0x9c, 0x62, 0x6a, 0x35,
-	0x79, 0x23, 0x77, 0xe7, 0x6a, 0xc4, 0x7d, 0x72, 0x31, 0x07, 0x56, 0x5b, 0x27, 0x78, 0x33, 0x45,
-	0x17, 0xac, 0x06, 0x44, 0xd1, 0xb3, 0x30, 0xab, 0x5b, 0x96, 0xe6, 0x10, 0x9d, 0x60, 0xad, 0x79,
-	0x42, 0xb0, 0xc3, 0xdc, 0x7e, 0x5e, 0x2d, 0xe8, 0x96, 0x55, 0xa7, 0xbd, 0x9b, 0xb4, 0x13, 0x5d,
-	0x83, 0x19, 0xea, 0xe1, 0x0d, 0xbd, 0xa7, 0x75, 0xb1, 0x71, 0xd4, 0x25, 0xcc, 0xbb, 0x27, 0xd5,
-	0x82, 0xe8, 0xdd, 0x66, 0x9d, 0x4a, 0x1b, 0xf2, 0x41, 0xe7, 0x8e, 0x10, 0xa4, 0xda, 0x3a, 0xd1,
-	0x99, 0x2d, 0xf3, 0x2a, 0xfb, 0xa6, 0x7d, 0x96, 0x4e, 0xba, 0xc2, 0x42, 0xec, 0x1b, 0x5d, 0x84,
-	0x29, 0xa1, 0x36, 0xc9, 0xd4, 0x8a, 0x16, 0x5a, 0x80, 0xb4, 0x65, 0x9b, 0xc7, 0x98, 0x1d, 0x5e,
-	0x46, 0xe5, 0x0d, 0xe5, 0x01, 0xcc, 0x84, 0xe3, 0x00, 0x9a, 0x81, 0x04, 0x19, 0x89, 0x59, 0x12,
-	0x64, 0x84, 0x6e, 0x43, 0x8a, 0x1a, 0x93, 0x69, 0x9b, 0x89, 0x8a, 0x7e, 0x42, 0xbe, 0x71, 0x62,
-	0x61, 0x95, 0x41, 0x77, 0x53, 0x99, 0x84, 0x9c, 0x54, 0x66, 0xa1, 0x10, 0x8a, 0x12, 0xca, 0x45,
-	0x58, 0x88, 0xf2, 0xf9, 0x8a, 0x01, 0x0b, 0x51, 0xae, 0x1b, 0xbd, 0x0c, 0x19, 0xcf, 0xe9, 0xbb,
-	0x37, 0x68, 0x62, 0x76, 0x4f, 0xc8, 0xc3, 0xd2, 0xbb, 0x43, 0x0f, 0xa2, 0xab, 0x8b, 0x50, 0x9f,
-	0x57, 0xa7, 0x75, 0xcb, 0xda, 0xd6, 0x9d, 0xae, 0xf2, 0x3e, 0x14, 0xe3, 0xfc, 0x79, 0xc0, 0x70,
-	0x12, 0x7b, 0x00, 0xae, 0xe1, 0x2e, 0xc2, 0x54, 0xc7, 0xb4, 0xfb, 0x3a, 0x61, 0xca, 0x0a, 0xaa,
-	0x68, 0x51, 0x83, 0x72, 0xdf, 0x9e, 0x64, 0xdd, 0xbc, 0xa1, 0x68, 0x70, 0x39, 0xd6, 0xa5, 0x53,
-	0x11, 0x63, 0xd0, 0xc6, 0xdc, 0xbc, 0x05, 0x95, 0x37, 0x7c, 0x45, 0x7c, 0xb1, 0xbc, 0x41, 0xa7,
-	0x75, 0xf0, 0xa0, 0x8d, 0x6d, 0xa6, 0x3f, 0xab, 0x8a, 0x96, 0xf2, 0xb3, 0x24, 0x5c, 0x8c, 0xf6,
-	0xeb, 0x68, 0x15, 0xf2, 0x7d, 0x7d, 0xa4, 0x91, 0x91, 0xb8, 0x7e, 0x12, 0xbb, 0x00, 0xd0, 0xd7,
-	0x47, 0x8d, 0x11, 0xbf, 0x7b, 0x32, 0x24, 0xc9, 0xc8, 0x29, 0x26, 0x56, 0x93, 0x37, 0xf2, 0x2a,
-	0xfd, 0x44, 0x87, 0x30, 0xd7, 0x33, 0x5b, 0x7a, 0x4f, 0xeb, 0xe9, 0x0e, 0xd1, 0x44, 0xd8, 0xe7,
-	0xcf, 0xe9, 0x99, 0x38, 0x3f, 0x8d, 0xdb, 0xfc, 0x60, 0xa9, 0x0b, 0x12, 0x0f, 0x61, 0x96, 0x29,
-	0xd9, 0xd3, 0x1d, 0xc2, 0x87, 0x50, 0x05, 0x72, 0x7d, 0xc3, 0x69, 0xe2, 0xae, 0x7e, 0x6c, 0x98,
-	0xb6, 0x78, 0x57, 0x11, 0xb7, 0xe7, 0xbe, 0x0f, 0x12, 0xaa, 0x82, 0x72, 0x81, 0x43, 0x49, 0x87,
-	0x6e, 0xb3, 0xeb, 0x59, 0xa6, 0x1e, 0xdb, 0xb3, 0xfc, 0x17, 0x2c, 0x0c, 0xf0, 0x88, 0x68, 0xfe,
-	0xcb, 0xe5, 0x37, 0x65, 0x9a, 0x19, 0x1f, 0xd1, 0x31, 0xef, 0xad, 0x3b, 0xf4, 0xd2, 0xa0, 0xe7,
-	0x58, 0x6c, 0xb4, 0x4c, 0x07, 0xdb, 0x9a, 0xde, 0x6e, 0xdb, 0xd8, 0x71, 0x58, 0x56, 0x95, 0x67,
-	0xf1, 0x8e, 0xf5, 0x97, 0x78, 0xb7, 0xf2, 0x29, 0x3b, 0x9c, 0xa8, 0xe8, 0xe8, 0x9a, 0x5e, 0xf2,
-	0x4d, 0xdf, 0x80, 0x05, 0x21, 0xdf, 0x0e, 0x59, 0x9f, 0xa7, 0xa7, 0x57, 0xe2, 0x92, 0xae, 0x80,
-	0xd5, 0x91, 0x2b, 0x1f, 0x6f, 0xf8, 0xe4, 0x13, 0x1a, 0x1e, 0x41, 0x8a, 0x99, 0x25, 0xc5, 0xdd,
-	0x0d, 0xfd, 0xfe, 0x57, 0x3b, 0x8c, 0x8f, 0x93, 0x30, 0x37, 0x91, 0x58, 0x78, 0x1b, 0x93, 0x22,
-	0x37, 0x96, 0x88, 0xdc, 0x58, 0xf2, 0xb1, 0x37, 0x26, 0x4e, 0x3b, 0x75, 0xf6, 0x69, 0xa7, 0xbf,
-	0xcb, 0xd3, 0x9e, 0x7a, 0xc2, 0xd3, 0xfe, 0xa7, 0x9e, 0xc3, 0xcf, 0x25, 0x58, 0x8a, 0x4f, 0xc7,
-	0x22, 0x0f, 0xe4, 0x16, 0xcc, 0x79, 0x4b, 0xf1, 0xd4, 0x73, 0xf7, 0x28, 0x7b, 0x03, 0x42, 0x7f,
-	0x6c, 0xc4, 0xbb, 0x06, 0x33, 0x63, 0xd9, 0x22, 0xbf, 0xcc, 0x85, 0xe3, 0xe0, 0x32, 0x94, 0x4f,
-	0x92, 0xb0, 0x10, 0x95, 0xd0, 0x45, 0xbc, 0x58, 0x15, 0xe6, 0xdb, 0xb8, 0x65, 0xb4, 0x9f, 0xf8,
-	0xc1, 0xce, 0x09, 0xf1, 0xff, 0xbc, 0xd7, 0x88, 0x7b, 0xf2, 0x6b, 0x80, 0x8c, 0x8a, 0x1d, 0x8b,
-	0x26, 0x68, 0xa8, 0x0c, 0x59, 0x3c, 0x6a, 0x61, 0x8b, 0xb8, 0x49, 0x6d, 0x4c, 0xdd, 0x20, 0x20,
-	0xae, 0x1c, 0xad, 0x9f, 0x3d, 0x39, 0xf4, 0x92, 0xa0, 0x09, 0x62, 0x0b, 0x7e, 0x9e, 0x7e, 0x7b,
-	0xa2, 0x9c, 0x27, 0x78, 0xc5, 0xe5, 0x09, 0x92, 0x71, 0xd5, 0xaf, 0x48, 0xc6, 0x3d, 0x39, 0x41,
-	0x14, 0xbc, 0x24, 0x88, 0x82, 0x54, 0xdc, 0x74, 0x3c, 0x67, 0xf7, 0xa7, 0x63, 0x4c, 0xc1, 0xdd,
-	0x10, 0x53, 0x30, 0x15, 0xb7, 0xd5, 0x40, 0x72, 0xed, 0x6f, 0xd5, 0xa7, 0x0a, 0x5e, 0x71, 0xa9,
-	0x82, 0xe9, 0xb8, 0x45, 0x8b, 0x6c, 0xd2, 0x5f, 0x34, 0xe7, 0x0a, 0xde, 0x0a, 0x70, 0x05, 0x59,
-	0x26, 0x7b, 0xf5, 0x14, 0xae, 0xc0, 0x93, 0xf6, 0xc8, 0x82, 0xff, 0xf1, 0xc8, 0x82, 0x7c, 0x2c,
-	0xd3, 0x20, 0xd2, 0x40, 0x4f, 0xd8, 0x65, 0x0b, 0x6a, 0x13, 0x6c, 0x01, 0x2f, 0xee, 0xaf, 0x9f,
-	0xc9, 0x16, 0x78, 0xaa, 0xc6, 0xe8, 0x82, 0xda, 0x04, 0x5d, 0x30, 0x13, 0xa7, 0x71, 0x2c, 0xe7,
-	0xf4, 0x35, 0x86, 0xf9, 0x82, 0xff, 0x8f, 0xe6, 0x0b, 0x62, 0x0b, 0xfa, 0x88, 0xfc, 0xd2, 0x53,
-	0x1d, 0x41, 0x18, 0xbc, 0x1f, 0x43, 0x18, 0xc8, 0x71, 0x85, 0x6d, 0x54, 0x76, 0xe9, 0x4d, 0x10,
-	0xc5, 0x18, 0x1c, 0x46, 0x30, 0x06, 0xbc, 0xb4, 0x7f, 0xee, 0x1c, 0x8c, 0x81, 0xa7, 0x7a, 0x82,
-	0x32, 0x38, 0x8c, 0xa0, 0x0c, 0x50, 0xbc, 0xde, 0xb1, 0xa4, 0x28, 0xa8, 0x37, 0xcc, 0x19, 0xbc,
-	0x13, 0xe6, 0x0c, 0xe6, 0x4f, 0xcf, 0x45, 0x79, 0x68, 0xf7, 0xb4, 0x05, 0x49, 0x83, 0x56, 0x1c,
-	0x69, 0xc0, 0xeb, 0xfa, 0x17, 0xce, 0x49, 0x1a, 0x78, 0xba, 0x23, 0x59, 0x83, 0xda, 0x04, 0x6b,
-	0xb0, 0x18, 0x77, 0xe1, 0xc6, 0x82, 0x8c, 0x7f, 0xe1, 0x62, 0x69, 0x83, 0xb4, 0x3c, 0xb5, 0x9b,
-	0xca, 0x64, 0xe4, 0x2c, 0x27, 0x0c, 0x76, 0x53, 0x99, 0x9c, 0x9c, 0x57, 0x9e, 0xa3, 0x69, 0xcd,
-	0x98, 0xdf, 0xa3, 0x45, 0x04, 0xb6, 0x6d, 0xd3, 0x16, 0x04, 0x00, 0x6f, 0x28, 0x37, 0x20, 0x1f,
-	0x74, 0x71, 0xa7, 0x50, 0x0c, 0xb3, 0x50, 0x08, 0x79, 0x35, 0xe5, 0x77, 0x12, 0xe4, 0x83, 0xfe,
-	0x2a, 0x54, 0x80, 0x66, 0x45, 0x01, 0x1a, 0x20, 0x1e, 0x12, 0x61, 0xe2, 0x61, 0x05, 0x72, 0xb4,
-	0x08, 0x1b, 0xe3, 0x14, 0x74, 0xcb, 0xe3, 0x14, 0x6e, 0xc2, 0x1c, 0x8b, 0xa1, 0x9c, 0x9e, 0x10,
-	0x71, 0x2a, 0xc5, 0xe2, 0xd4, 0x2c, 0x1d, 0x60, 0xc6, 0xe0, 0xb5, 0x30, 0x7a, 0x01, 0xe6, 0x03,
-	0x58, 0xaf, 0xb8, 0xe3, 0xe5, 0xb5, 0xec, 0xa1, 0x4b, 0xa2, 0xca, 0xfb, 0xbd, 0x04, 0x73, 0x13,
-	0xee, 0x32, 0x92, 0x37, 0x90, 0xbe, 0x2b, 0xde, 0x20, 0xf1, 0xe4, 0xbc, 0x41, 0xb0, 0x5c, 0x4d,
-	0x86, 0xcb, 0xd5, 0xbf, 0x4b, 0x50, 0x08, 0xb9, 0x6d, 0x7a, 0x08, 0x2d, 0xb3, 0x8d, 0x45, 0x01,
-	0xc9, 0xbe, 0x69, 0x9e, 0xd2, 0x33, 0x8f, 0x44, 0x99, 0x48, 0x3f, 0x29, 0xca, 0x0b, 0x44, 0x59,
-	0x11, 0x66, 0xbc, 0xda, 0x93, 0xe7, 0x02, 0xa2, 0xf6, 0x94, 0x21, 0xf9, 0x08, 0x73, 0x7e, 0x39,
-	0xaf, 0xd2, 0x4f, 0x8a, 0x63, 0xd7, 0x4f, 0xc4, 0x74, 0xde, 0x40, 0xaf, 0x41, 0x96, 0xfd, 0x0a,
-	0xa0, 0x99, 0x96, 0x23, 0x28, 0xe5, 0x40, 0xbe, 0xc3, 0x7f, 0x2a, 0x10, 0xef, 0xdc, 0xec, 0x54,
-	0x2d, 0x47, 0xcd, 0x58, 0xe2, 0x2b, 0x90, 0x85, 0x64, 0x43, 0x59, 0xc8, 0x15, 0xc8, 0xd2, 0xe5,
-	0x3b, 0x96, 0xde, 0xc2, 0x45, 0x60, 0x2b, 0xf5, 0x3b, 0x94, 0xdf, 0x24, 0x60, 0x76, 0x2c, 0xea,
-	0x44, 0x6e, 0xde, 0xbd, 0x95, 0x89, 0x00, 0x2d, 0x72, 0x3e, 0x83, 0x2c, 0x03, 0x1c, 0xe9, 0x8e,
-	0xf6, 0x91, 0x3e, 0x20, 0xb8, 0x2d, 0xac, 0x12, 0xe8, 0x41, 0x4b, 0x90, 0xa1, 0xad, 0xa1, 0x83,
-	0xdb, 0x82, 0xa1, 0xf1, 0xda, 0x68, 0x07, 0xa6, 0xf0, 0x31, 0x1e, 0x10, 0xa7, 0x38, 0xcd, 0x0e,
-	0xfe, 0x52, 0x84, 0x7b, 0xa2, 0xe3, 0x9b, 0x45, 0x7a, 0xdc, 0x7f, 0xfd, 0x72, 0x45, 0xe6, 0xf0,
-	0xe7, 0xcd, 0xbe, 0x41, 0x70, 0xdf, 0x22, 0x27, 0xaa, 0x50, 0x10, 0x36, 0x43, 0x66, 0xcc, 0x0c,
-	0x8c, 0x2e, 0xcc, 0xbb, 0xb5, 0x3f, 0x35, 0xaa, 0x61, 0xda, 0x06, 0x39, 0x51, 0x0b, 0x7d, 0xdc,
-	0xb7, 0x4c, 0xb3, 0xa7, 0xf1, 0x77, 0x5e, 0x82, 0x99, 0x70, 0x90, 0x45, 0x6b, 0x50, 0xb0, 0x31,
-	0xd1, 0x8d, 0x81, 0x16, 0xca, 0x8d, 0xf3, 0xbc, 0x93, 0xbf, 0xab, 0xdd, 0x54, 0x46, 0x92, 0x13,
-	0x82, 0xae, 0x79, 0x17, 0x16, 0x23, 0x63, 0x2c, 0x7a, 0x15, 0xb2, 0x7e, 0x7c, 0x96, 0xd8, 0x76,
-	0x4f, 0xe3, 0x61, 0x7c, 0xb0, 0x72, 0x08, 0x8b, 0x91, 0x41, 0x16, 0xbd, 0x09, 0x53, 0x36, 0x76,
-	0x86, 0x3d, 0x4e, 0xb5, 0xcc, 0xdc, 0xb9, 0x76, 0x76, 0x74, 0x1e, 0xf6, 0x88, 0x2a, 0x84, 0x94,
-	0xdb, 0x70, 0x39, 0x36, 0xca, 0xfa, 0x6c, 0x8a, 0x14, 0x60, 0x53, 0x94, 0xdf, 0x4a, 0xb0, 0x14,
-	0x1f, 0x39, 0xd1, 0xe6, 0xd8, 0x82, 0x6e, 0x9e, 0x33, 0xee, 0x06, 0x56, 0x45, 0xcb, 0x0d, 0x1b,
-	0x77, 0x30, 0x69, 0x75, 0x79, 0x08, 0xe7, 0x4e, 0xa1, 0xa0, 0x16, 0x44, 0x2f, 0x93, 0x71, 0x38,
-	0xec, 0x03, 0xdc, 0x22, 0x1a, 0x3f, 0x54, 0x87, 0xa5, 0xfc, 0x59, 0x0a, 0xa3, 0xbd, 0x75, 0xde,
-	0xa9, 0xdc, 0x82, 0x4b, 0x31, 0xb1, 0x78, 0xb2, 0x2e, 0x51, 0x1e, 0x52, 0x70, 0x64, 0x80, 0x45,
-	0x6f, 0xc3, 0x94, 0x43, 0x74, 0x32, 0x74, 0xc4, 0xce, 0xae, 0x9f, 0x19, 0x9b, 0xeb, 0x0c, 0xae,
-	0x0a, 0x31, 0xe5, 0x75, 0x40, 0x93, 0x91, 0x36, 0xa2, 0xb6, 0x92, 0xa2, 0x6a, 0xab, 0x26, 0x3c,
-	0x75, 0x4a, 0x4c, 0x45, 0xe5, 0xb1, 0xc5, 0xdd, 0x3a, 0x57, 0x48, 0x1e, 0x5b, 0xe0, 0xdf, 0x12,
-	0xb0, 0x18, 0x19, 0x5a, 0x03, 0xaf, 0x54, 0xfa, 0xb6, 0xaf, 0xf4, 0x4d, 0x00, 0x32, 0xd2, 0xf8,
-	0x49, 0xbb, 0xde, 0x3e, 0xaa, 0x9e, 0x18, 0xe1, 0x16, 0x73, 0x58, 0xf4, 0x62, 0x64, 0x89, 0xf8,
-	0xa2, 0xc5, 0x7f, 0xa0, 0x9e, 0x1d, 0xb2, 0x48, 0xe0, 0x88, 0x52, 0xef, 0xdc, 0x31, 0xc3, 0x2f,
-	0x7c, 0x79, 0xb7, 0x83, 0x1e, 0xc2, 0xa5, 0xb1, 0x88, 0xe6, 0xe9, 0x4e, 0x9d, 0x3b, 0xb0, 0x2d,
-	0x86, 0x03, 0x9b, 0xab, 0x3b, 0x18, 0x95, 0xd2, 0xe1, 0xa8, 0xf4, 0x10, 0xc0, 0x2f, 0x6c, 0xe9,
-	0x7b, 0xb3, 0xcd, 0xe1, 0xa0, 0xcd, 0x8e, 0x30, 0xad, 0xf2, 0x06, 0x7a, 0x19, 0xd2, 0xf4, 0x26,
-	0xb8, 0xa6, 0x8a, 0x70, 0x18, 0xf4, 0x48, 0x03, 0x95, 0x31, 0x87, 0x2b, 0x1f, 0xb8, 0xb7, 0x2d,
-	0xc8, 0x31, 0xc6, 0xcc, 0xf1, 0x56, 0x78, 0x0e, 0x25, 0x9e, 0xae, 0x8c, 0x9e, 0xeb, 0x07, 0x90,
-	0x66, 0xc7, 0x4f, 0xa3, 0x03, 0xa3, 0xb8, 0x45, 0x66, 0x43, 0xbf, 0xd1, 0xf7, 0x00, 0x74, 0x42,
-	0x6c, 0xa3, 0x39, 0xf4, 0x67, 0x58, 0x8d, 0xb9, 0x3f, 0x25, 0x17, 0xb8, 0x79, 0x45, 0x5c, 0xa4,
-	0x05, 0x5f, 0x36, 0x70, 0x99, 0x02, 0x1a, 0x95, 0x7d, 0x98, 0x09, 0xcb, 0xba, 0xa1, 0x98, 0x2f,
-	0x22, 0x1c, 0x8a, 0x79, 0x6e, 0x25, 0x42, 0xb1, 0x17, 0xc8, 0x93, 0x9c, 0xc8, 0x67, 0x0d, 0xe5,
-	0x87, 0x09, 0xc8, 0x07, 0x6f, 0xdf, 0xbf, 0x61, 0xb0, 0x54, 0x3e, 0x91, 0x20, 0xe3, 0xed, 0x3f,
-	0x4c, 0xe7, 0x87, 0x7e, 0x07, 0xe1, 0xe6, 0x4b, 0x04, 0x39, 0x78, 0xfe, 0xab, 0x47, 0xd2, 0xfb,
-	0xd5, 0xe3, 0x0d, 0x2f, 0x20, 0xc4, 0x16, 0xf3, 0x41, 0x6b, 0x8b, 0x8b, 0xe5, 0x06, 0xa8, 0xd7,
-	0x21, 0xeb, 0xbd, 0x61, 0x9a, 0x23, 0xbb, 0xc4, 0x87, 0x24, 0x1e, 0x92, 0x20, 0xae, 0x16, 0x20,
-	0x6d, 0x99, 0x1f, 0x09, 0x86, 0x3f, 0xa9, 0xf2, 0x86, 0x82, 0x61, 0x76, 0xcc, 0x01, 0xa0, 0x37,
-	0x60, 0xda, 0x1a, 0x36, 0x35, 0xf7, 0x7a, 0x84, 0xf8, 0xa1, 0x40, 0xee, 0x35, 0x6c, 0xf6, 0x8c,
-	0xd6, 0x3d, 0x7c, 0xe2, 0xae, 0xc6, 0x1a, 0x36, 0xef, 0xf1, 0x6b, 0xc4, 0xa7, 0x49, 0x04, 0xa7,
-	0xf9, 0xa9, 0x04, 0x19, 0xf7, 0x5d, 0xa0, 0xb7, 0x21, 0xeb, 0x79, 0x17, 0x31, 0xc5, 0x53, 0xa7,
-	0xf8, 0x25, 0x31, 0x81, 0x2f, 0x83, 0x36, 0xdd, 0xdf, 0x19, 0x8d, 0xb6, 0xd6, 0xe9, 0xe9, 0x47,
-	0xe2, 0xe7, 0xa2, 0xe5, 0x08, 0x07, 0xc4, 0x7c, 0xf4, 0xce, 0xdd, 0xad, 0x9e, 0x7e, 0xa4, 0xe6,
-	0x98, 0xd0, 0x4e, 0x9b, 0x36, 0x44, 0x1e, 0xf2, 0x8d, 0x04, 0xf2, 0xf8, 0xbb, 0xfd, 0xf6, 0xeb,
-	0x9b, 0x8c, 0x57, 0xc9, 0x88, 0x78, 0x85, 0x36, 0x60, 0xde, 0x43, 0x68, 0x8e, 0x71, 0x34, 0xd0,
-	0xc9, 0xd0, 0xc6, 0x82, 0x54, 0x43, 0xde, 0x50, 0xdd, 0x1d, 0x99, 0xdc, 0x77, 0xfa, 0x49, 0xf7,
-	0xfd, 0x71, 0x02, 0x72, 0x01, 0x8e, 0x0f, 0xfd, 0x77, 0xc0, 0x29, 0xcd, 0x44, 0x45, 0x89, 0x00,
-	0xd8, 0xff, 0xed, 0x2d, 0x6c, 0xa9, 0xc4, 0x13, 0x58, 0x2a, 0x8e, 0x4d, 0x75, 0x49, 0xc3, 0xd4,
-	0x63, 0x93, 0x86, 0xcf, 0x03, 0x22, 0x26, 0xd1, 0x7b, 0xb4, 0x0c, 0x37, 0x06, 0x47, 0x1a, 0xbf,
-	0x8c, 0xdc, 0x87, 0xc8, 0x6c, 0xe4, 0x90, 0x0d, 0xd4, 0xd8, 0xbd, 0xfc, 0x91, 0x04, 0x19, 0x8f,
-	0x7c, 0x79, 0xdc, 0xdf, 0xe4, 0x2e, 0xc2, 0x94, 0xc8, 0xbd, 0xf8, 0x8f, 0x72, 0xa2, 0x15, 0xc9,
-	0x8e, 0x2e, 0x41, 0xa6, 0x8f, 0x89, 0xce, 0x1c, 0x22, 0x8f, 0x70, 0x5e, 0xfb, 0x66, 0x13, 0x72,
-	0x81, 0x9f, 0x35, 0xd1, 0x65, 0x58, 0x2c, 0x6f, 0x57, 0xca, 0xf7, 0xb4, 0xc6, 0x7b, 0x5a, 0xe3,
-	0x41, 0xad, 0xa2, 0x1d, 0xec, 0xdf, 0xdb, 0xaf, 0xfe, 0xef, 0xbe, 0x7c, 0x61, 0x72, 0x48, 0xad,
-	0xb0, 0xb6, 0x2c, 0xa1, 0x4b, 0x30, 0x1f, 0x1e, 0xe2, 0x03, 0x89, 0xa5, 0xd4, 0x4f, 0x7e, 0xb5,
-	0x7c, 0xe1, 0xe6, 0x37, 0x12, 0xcc, 0x47, 0x64, 0xb9, 0xe8, 0x2a, 0x3c, 0x5d, 0xdd, 0xda, 0xaa,
-	0xa8, 0x5a, 0x7d, 0xbf, 0x54, 0xab, 0x6f, 0x57, 0x1b, 0x9a, 0x5a, 0xa9, 0x1f, 0xec, 0x35, 0x02,
-	0x93, 0xae, 0xc2, 0x95, 0x68, 0x48, 0xa9, 0x5c, 0xae, 0xd4, 0x1a, 0xb2, 0x84, 0x56, 0xe0, 0xa9,
-	0x18, 0xc4, 0x66, 0x55, 0x6d, 0xc8, 0x89, 0x78, 0x15, 0x6a, 0x65, 0xb7, 0x52, 0x6e, 0xc8, 0x49,
-	0x74, 0x1d, 0xd6, 0x4e, 0x43, 0x68, 0x5b, 0x55, 0xf5, 0x7e, 0xa9, 0x21, 0xa7, 0xce, 0x04, 0xd6,
-	0x2b, 0xfb, 0x77, 0x2b, 0xaa, 0x9c, 0x16, 0xfb, 0xfe, 0x65, 0x02, 0x8a, 0x71, 0xc9, 0x34, 0xd5,
-	0x55, 0xaa, 0xd5, 0xf6, 0x1e, 0xf8, 0xba, 0xca, 0xdb, 0x07, 0xfb, 0xf7, 0x26, 0x4d, 0xf0, 0x2c,
-	0x28, 0xa7, 0x01, 0x3d, 0x43, 0x5c, 0x83, 0xab, 0xa7, 0xe2, 0x84, 0x39, 0xce, 0x80, 0xa9, 0x95,
-	0x86, 0xfa, 0x40, 0x4e, 0xa2, 0x75, 0xb8, 0x79, 0x26, 0xcc, 0x1b, 0x93, 0x53, 0x68, 0x03, 0x6e,
-	0x9d, 0x8e, 0xe7, 0x06, 0x72, 0x05, 0x5c, 0x13, 0x7d, 0x2a, 0xc1, 0x62, 0x64, 0x56, 0x8e, 0xd6,
-	0x60, 0xa5, 0xa6, 0x56, 0xcb, 0x95, 0x7a, 0x5d, 0xab, 0xa9, 0xd5, 0x5a, 0xb5, 0x5e, 0xda, 0xd3,
-	0xea, 0x8d, 0x52, 0xe3, 0xa0, 0x1e, 0xb0, 0x8d, 0x02, 0xcb, 0x71, 0x20, 0xcf, 0x2e, 0xa7, 0x60,
-	0xc4, 0x0d, 0x70, 0xef, 0xe9, 0x2f, 0x24, 0xb8, 0x1c, 0x9b, 0x85, 0xa3, 0x1b, 0xf0, 0xcc, 0x61,
-	0x45, 0xdd, 0xd9, 0x7a, 0xa0, 0x1d, 0x56, 0x1b, 0x15, 0xad, 0xf2, 0x5e, 0xa3, 0xb2, 0x5f, 0xdf,
-	0xa9, 0xee, 0x4f, 0xae, 0xea, 0x3a, 0xac, 0x9d, 0x8a, 0xf4, 0x96, 0x76, 0x16, 0x70, 0x6c, 0x7d,
-	0x3f, 0x96, 0x60, 0x76, 0xcc, 0x17, 0xa2, 0x2b, 0x50, 0xbc, 0xbf, 0x53, 0xdf, 0xac, 0x6c, 0x97,
-	0x0e, 0x77, 0xaa, 0xea, 0xf8, 0x9b, 0x5d, 0x83, 0x95, 0x89, 0xd1, 0xbb, 0x07, 0xb5, 0xbd, 0x9d,
-	0x72, 0xa9, 0x51, 0x61, 0x93, 0xca, 0x12, 0xdd, 0xd8, 0x04, 0x68, 0x6f, 0xe7, 0x9d, 0xed, 0x86,
-	0x56, 0xde, 0xdb, 0xa9, 0xec, 0x37, 0xb4, 0x52, 0xa3, 0x51, 0xf2, 0x9f, 0xf3, 0xe6, 0xbd, 0xcf,
-	0xbf, 0x5a, 0x96, 0xbe, 0xf8, 0x6a, 0x59, 0xfa, 0xcb, 0x57, 0xcb, 0xd2, 0x67, 0x5f, 0x2f, 0x5f,
-	0xf8, 0xe2, 0xeb, 0xe5, 0x0b, 0x7f, 0xfa, 0x7a, 0xf9, 0xc2, 0xc3, 0xdb, 0x47, 0x06, 0xe9, 0x0e,
-	0x9b, 0xd4, 0x0b, 0x6f, 0xf8, 0xff, 0xae, 0xf4, 0xfe, 0xb5, 0x69, 0x19, 0x1b, 0xe3, 0x7f, 0xe1,
-	0x6c, 0x4e, 0x31, 0xb7, 0xfa, 0xe2, 0x3f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xe3, 0x41, 0x56, 0x8d,
-	0xdd, 0x29, 0x00, 0x00,
+	// 3413 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcb, 0x6f, 0x1b, 0xd7,
+	0xb9, 0xd7, 0x90, 0x23, 0x8a, 0xfc, 0x48, 0x4a, 0xa3, 0xa3, 0x87, 0x69, 0xc5, 0x91, 0xe4, 0x71,
+	0x1c, 0x3b, 0x76, 0x22, 0x5d, 0x3b, 0xf7, 0xe6, 0x71, 0xf3, 0xba, 0x24, 0x4d, 0x59, 0x94, 0x65,
+	0x91, 0x19, 0x8e, 0x94, 0xd8, 0xb8, 0xcd, 0x64, 0x44, 0x1e, 0x8a, 0x13, 0x93, 0x9c, 0x09, 0x67,
+	0x28, 0x53, 0x2d, 0x50, 0xa0, 0x45, 0x13, 0x14, 0x59, 0x05, 0x28, 0xb2, 0x29, 0x5a, 0xa0, 0x40,
+	0xd1, 0x6d, 0xff, 0x8c, 0x22, 0xab, 0x36, 0xcb, 0xae, 0xd2, 0x22, 0xd9, 0x75, 0xd1, 0x5d, 0x80,
+	0xa2, 0xab, 0xe2, 0x3c, 0xe6, 0x45, 0xce, 0x48, 0xb6, 0x93, 0x2e, 0x8a, 0x76, 0x25, 0x9e, 0xf3,
+	0xfd, 0xbe, 0xef, 0x9c, 0xf9, 0xce, 0x99, 0xef, 0xf1, 0x1b, 0xc1, 0x85, 0xa6, 0xd9, 0xc3, 0xce,
+	0x61, 0xdb, 0xd9, 0xd4, 0x0f, 0x9b, 0xc6, 0xe6, 0xf1, 0x8d, 0x4d, 0xe7, 0xc4, 0xc2, 0xf6, 0x86,
+	0x35, 0x30, 0x1d, 0x13, 0x49, 0xae, 0x74, 0x83, 0x48, 0x37, 0x8e, 0x6f, 0xac, 0x3c, 0xed, 0xe1,
+	0x9b, 0x83, 0x13, 0xcb, 0x31, 0x89, 0xc6, 0x03, 0x7c, 0xc2, 0x15, 0x56, 0x56, 0x23, 0xc4, 0xd6,
+	0xc0, 0x34, 0xdb, 0x13, 0x72, 0xba, 0x0c, 0x15, 0xeb, 0x03, 0xbd, 0xe7, 0xea, 0x5f, 0x9c, 0x94,
+	0x1f, 0xeb, 0x5d, 0xa3, 0xa5, 0x3b, 0xe6, 0x80, 0x43, 0x16, 0x8f, 0xcc, 0x23, 0x93, 0xfe, 0xdc,
+	0x24, 0xbf, 0xf8, 0xec, 0xda, 0x91, 0x69, 0x1e, 0x75, 0xf1, 0x26, 0x1d, 0x1d, 0x0e, 0xdb, 0x9b,
+	0x8e, 0xd1, 0xc3, 0xb6, 0xa3, 0xf7, 0x2c, 0x06, 0x90, 0xff, 0x90, 0x81, 0x19, 0x05, 0x7f, 0x38,
+	0xc4, 0xb6, 0x83, 0x5e, 0x04, 0x11, 0x37, 0x3b, 0x66, 0x41, 0x58, 0x17, 0xae, 0x66, 0x6f, 0x3e,
+	0xbd, 0x31, 0xfe, 0x94, 0x1b, 0x95, 0x66, 0xc7, 0xe4, 0xe0, 0xed, 0x29, 0x85, 0x82, 0xd1, 0x4b,
+	0x30, 0xdd, 0xee, 0x0e, 0xed, 0x4e, 0x21, 0x41, 0xb5, 0x56, 0x27, 0xb5, 0xb6, 0x88, 0xd8, 0x57,
+	0x63, 0x70, 0xb2, 0x98, 0xd1, 0x6f, 0x9b, 0x85, 0x64, 0xdc, 0x62, 0xd5, 0x7e, 0x3b, 0xb8, 0x18,
+	0x01, 0xa3, 0x32, 0x80, 0xd1, 0x37, 0x1c, 0xad, 0xd9, 0xd1, 0x8d, 0x7e, 0x61, 0x9a, 0xaa, 0xca,
+	0x51, 0xaa, 0x86, 0x53, 0x26, 0x10, 0x5f, 0x3f, 0x63, 0xb8, 0x73, 0x64, 0xc7, 0x1f, 0x0e, 0xf1,
+	0xe0, 0xa4, 0x90, 0x8a, 0xdb, 0xf1, 0xdb, 0x44, 0x1c, 0xd8, 0x31, 0x85, 0xa3, 0x37, 0x20, 0xdd,
+	0xec, 0xe0, 0xe6, 0x03, 0xcd, 0x19, 0x15, 0xd2, 0x54, 0x75, 0x7d, 0x52, 0xb5, 0x4c, 0x10, 0xea,
+	0xc8, 0x57, 0x9e, 0x69, 0xb2, 0x19, 0xf4, 0x2a, 0xa4, 0x9a, 0x66, 0xaf, 0x67, 0x38, 0x85, 0x2c,
+	0x55, 0x5e, 0x8b, 0x50, 0xa6, 0x72, 0x5f, 0x97, 0x2b, 0xa0, 0x1a, 0xcc, 0x76, 0x0d, 0xdb, 0xd1,
+	0xec, 0xbe, 0x6e, 0xd9, 0x1d, 0xd3, 0xb1, 0x0b, 0x39, 0x6a, 0xe2, 0xd9, 0x49, 0x13, 0xbb, 0x86,
+	0xed, 0x34, 0x5c, 0x98, 0x6f, 0x29, 0xdf, 0x0d, 0xce, 0x13, 0x83, 0x66, 0xbb, 0x8d, 0x07, 0x9e,
+	0xc5, 0x42, 0x3e, 0xce, 0x60, 0x8d, 0xe0, 0x5c, 0xcd, 0x80, 0x41, 0x33, 0x38, 0x8f, 0xfe, 0x1f,
+	0x16, 0xba, 0xa6, 0xde, 0xf2, 0xec, 0x69, 0xcd, 0xce, 0xb0, 0xff, 0xa0, 0x30, 0x4b, 0xad, 0x5e,
+	0x8b, 0xd8, 0xa6, 0xa9, 0xb7, 0x5c, 0xe5, 0x32, 0x81, 0xfa, 0x96, 0xe7, 0xbb, 0xe3, 0x32, 0xa4,
+	0xc1, 0xa2, 0x6e, 0x59, 0xdd, 0x93, 0x71, 0xf3, 0x73, 0xd4, 0xfc, 0xf5, 0x49, 0xf3, 0x45, 0x82,
+	0x8e, 0xb1, 0x8f, 0xf4, 0x09, 0x21, 0xda, 0x07, 0xc9, 0x1a, 0x60, 0x4b, 0x1f, 0x60, 0xcd, 0x1a,
+	0x98, 0x96, 0x69, 0xeb, 0xdd, 0x82, 0x44, 0x8d, 0x5f, 0x9d, 0x34, 0x5e, 0x67, 0xc8, 0x3a, 0x07,
+	0xfa, 0x96, 0xe7, 0xac, 0xb0, 0x84, 0x99, 0x35, 0x9b, 0xd8, 0xb6, 0x7d, 0xb3, 0xf3, 0xf1, 0x66,
+	0x29, 0x32, 0xd2, 0x6c, 0x48, 0x82, 0xb6, 0x20, 0x8b, 0x47, 0x0e, 0xee, 0xb7, 0xb4, 0x63, 0xd3,
+	0xc1, 0x05, 0x44, 0x2d, 0x5e, 0x8a, 0x78, 0x5d, 0x29, 0xe8, 0xc0, 0x74, 0xb0, 0x6f, 0x0c, 0xb0,
+	0x37, 0x89, 0x0e, 0x61, 0xe9, 0x18, 0x0f, 0x8c, 0xf6, 0x09, 0xb5, 0xa3, 0x51, 0x89, 0x6d, 0x98,
+	0xfd, 0xc2, 0x02, 0xb5, 0xf8, 0xfc, 0xa4, 0xc5, 0x03, 0x0a, 0x27, 0xca, 0x15, 0x17, 0xec, 0x9b,
+	0x5e, 0x38, 0x9e, 0x94, 0x92, 0x9b, 0xd6, 0x36, 0xfa, 0x7a, 0xd7, 0xf8, 0x3e, 0xd6, 0x0e, 0xbb,
+	0x66, 0xf3, 0x41, 0x61, 0x31, 0xee, 0xa6, 0x6d, 0x71, 0x5c, 0x89, 0xc0, 0x02, 0x37, 0xad, 0x1d,
+	0x9c, 0x2f, 0xcd, 0xc0, 0xf4, 0xb1, 0xde, 0x1d, 0xe2, 0x1d, 0x31, 0x2d, 0x4a, 0xd3, 0x3b, 0x62,
+	0x7a, 0x46, 0x4a, 0xef, 0x88, 0xe9, 0x8c, 0x04, 0x3b, 0x62, 0x1a, 0xa4, 0xac, 0x7c, 0x05, 0xb2,
+	0x81, 0x38, 0x85, 0x0a, 0x30, 0xd3, 0xc3, 0xb6, 0xad, 0x1f, 0x61, 0x1a, 0xd7, 0x32, 0x8a, 0x3b,
+	0x94, 0x67, 0x21, 0x17, 0x0c, 0x4d, 0xf2, 0xa7, 0x02, 0x64, 0x03, 0x41, 0x87, 0x68, 0x1e, 0xe3,
+	0x01, 0x75, 0x08, 0xd7, 0xe4, 0x43, 0x74, 0x09, 0xf2, 0xf4, 0x59, 0x34, 0x57, 0x4e, 0x62, 0x9f,
+	0xa8, 0xe4, 0xe8, 0xe4, 0x01, 0x07, 0xad, 0x41, 0xd6, 0xba, 0x69, 0x79, 0x90, 0x24, 0x85, 0x80,
+	0x75, 0xd3, 0x72, 0x01, 0x17, 0x21, 0x47, 0x1e, 0xdd, 0x43, 0x88, 0x74, 0x91, 0x2c, 0x99, 0xe3,
+	0x10, 0xf9, 0xf7, 0x09, 0x90, 0xc6, 0x83, 0x19, 0x7a, 0x05, 0x44, 0x12, 0xc5, 0x79, 0x98, 0x5e,
+	0xd9, 0x60, 0x21, 0x7e, 0xc3, 0x0d, 0xf1, 0x1b, 0xaa, 0x1b, 0xe2, 0x4b, 0xe9, 0xcf, 0xbf, 0x5c,
+	0x9b, 0xfa, 0xf4, 0x4f, 0x6b, 0x82, 0x42, 0x35, 0xd0, 0x79, 0x12, 0xc1, 0x74, 0xa3, 0xaf, 0x19,
+	0x2d, 0xba, 0xe5, 0x0c, 0x89, 0x4e, 0xba, 0xd1, 0xaf, 0xb6, 0xd0, 0x5d, 0x90, 0x9a, 0x66, 0xdf,
+	0xc6, 0x7d, 0x7b, 0x68, 0x6b, 0x2c, 0xf7, 0x14, 0x92, 0xe3, 0xf1, 0x95, 0xe5, 0x40, 0x1a, 0xa8,
+	0x38, 0xb4, 0x4e, 0x91, 0xca, 0x5c, 0x33, 0x3c, 0x81, 0x6e, 0x03, 0x78, 0x09, 0xca, 0x2e, 0x88,
+	0xeb, 0xc9, 0xab, 0xd9, 0x9b, 0x17, 0x23, 0xee, 0x93, 0x8b, 0xd9, 0xb7, 0x5a, 0xba, 0x83, 0x4b,
+	0x22, 0xd9, 0xb0, 0x12, 0x50, 0x45, 0xcf, 0xc2, 0x9c, 0x6e, 0x59, 0x9a, 0xed, 0xe8, 0x0e, 0xd6,
+	0x0e, 0x4f, 0x1c, 0x6c, 0xd3, 0xb0, 0x9f, 0x53, 0xf2, 0xba, 0x65, 0x35, 0xc8, 0x6c, 0x89, 0x4c,
+	0xa2, 0xcb, 0x30, 0x4b, 0x22, 0xbc, 0xa1, 0x77, 0xb5, 0x0e, 0x36, 0x8e, 0x3a, 0x0e, 0x8d, 0xee,
+	0x49, 0x25, 0xcf, 0x67, 0xb7, 0xe9, 0xa4, 0xdc, 0x82, 0x5c, 0x30, 0xb8, 0x23, 0x04, 0x62, 0x4b,
+	0x77, 0x74, 0xea, 0xcb, 0x9c, 0x42, 0x7f, 0x93, 0x39, 0x4b, 0x77, 0x3a, 0xdc, 0x43, 0xf4, 0x37,
+	0x5a, 0x86, 0x14, 0x37, 0x9b, 0xa4, 0x66, 0xf9, 0x08, 0x2d, 0xc2, 0xb4, 0x35, 0x30, 0x8f, 0x31,
+	0x3d, 0xbc, 0xb4, 0xc2, 0x06, 0xf2, 0x3d, 0x98, 0x0d, 0xe7, 0x01, 0x34, 0x0b, 0x09, 0x67, 0xc4,
+	0x57, 0x49, 0x38, 0x23, 0x74, 0x03, 0x44, 0xe2, 0x4c, 0x6a, 0x6d, 0x36, 0x2a, 0xfb, 0x71, 0x7d,
+	0xf5, 0xc4, 0xc2, 0x0a, 0x85, 0xee, 0x88, 0xe9, 0x84, 0x94, 0x94, 0xe7, 0x20, 0x1f, 0xca, 0x12,
+	0xf2, 0x32, 0x2c, 0x46, 0xc5, 0x7c, 0xd9, 0x80, 0xc5, 0xa8, 0xd0, 0x8d, 0x5e, 0x82, 0xb4, 0x17,
+	0xf4, 0xdd, 0x1b, 0x34, 0xb1, 0xba, 0xa7, 0xe4, 0x61, 0xc9, 0xdd, 0x21, 0x07, 0xd1, 0xd1, 0x79,
+	0xaa, 0xcf, 0x29, 0x33, 0xba, 0x65, 0x6d, 0xeb, 0x76, 0x47, 0x7e, 0x1f, 0x0a, 0x71, 0xf1, 0x3c,
+	0xe0, 0x38, 0x81, 0xbe, 0x00, 0xae, 0xe3, 0x96, 0x21, 0xd5, 0x36, 0x07, 0x3d, 0xdd, 0xa1, 0xc6,
+	0xf2, 0x0a, 0x1f, 0x11, 0x87, 0xb2, 0xd8, 0x9e, 0xa4, 0xd3, 0x6c, 0x20, 0x6b, 0x70, 0x3e, 0x36,
+	0xa4, 0x13, 0x15, 0xa3, 0xdf, 0xc2, 0xcc, 0xbd, 0x79, 0x85, 0x0d, 0x7c, 0x43, 0x6c, 0xb3, 0x6c,
+	0x40, 0x96, 0xb5, 0x71, 0xbf, 0x85, 0x07, 0xd4, 0x7e, 0x46, 0xe1, 0x23, 0xf9, 0xe7, 0x49, 0x58,
+	0x8e, 0x8e, 0xeb, 0x68, 0x1d, 0x72, 0x3d, 0x7d, 0xa4, 0x39, 0x23, 0x7e, 0xfd, 0x04, 0x7a, 0x01,
+	0xa0, 0xa7, 0x8f, 0xd4, 0x11, 0xbb, 0x7b, 0x12, 0x24, 0x9d, 0x91, 0x5d, 0x48, 0xac, 0x27, 0xaf,
+	0xe6, 0x14, 0xf2, 0x13, 0x1d, 0xc0, 0x7c, 0xd7, 0x6c, 0xea, 0x5d, 0xad, 0xab, 0xdb, 0x8e, 0xc6,
+	0xd3, 0x3e, 0x7b, 0x9d, 0x9e, 0x89, 0x8b, 0xd3, 0xb8, 0xc5, 0x0e, 0x96, 0x84, 0x20, 0xfe, 0x22,
+	0xcc, 0x51, 0x23, 0xbb, 0xba, 0xed, 0x30, 0x11, 0xaa, 0x40, 0xb6, 0x67, 0xd8, 0x87, 0xb8, 0xa3,
+	0x1f, 0x1b, 0xe6, 0x80, 0xbf, 0x57, 0x11, 0xb7, 0xe7, 0xae, 0x0f, 0xe2, 0xa6, 0x82, 0x7a, 0x81,
+	0x43, 0x99, 0x0e, 0xdd, 0x66, 0x37, 0xb2, 0xa4, 0x1e, 0x3b, 0xb2, 0xfc, 0x17, 0x2c, 0xf6, 0xf1,
+	0xc8, 0xd1, 0xfc, 0x37, 0x97, 0xdd, 0x94, 0x19, 0xea, 0x7c, 0x44, 0x64, 0xde, 0xbb, 0x6e, 0x93,
+	0x4b, 0x83, 0x9e, 0xa3, 0xb9, 0xd1, 0x32, 0x6d, 0x3c, 0xd0, 0xf4, 0x56, 0x6b, 0x80, 0x6d, 0x9b,
+	0x56, 0x55, 0x39, 0x65, 0xce, 0x9d, 0x2f, 0xb2, 0x69, 0xf9, 0x13, 0x7a, 0x38, 0x51, 0xd9, 0xd1,
+	0x75, 0xbd, 0xe0, 0xbb, 0x5e, 0x85, 0x45, 0xae, 0xdf, 0x0a, 0x79, 0x9f, 0x95, 0xa7, 0x17, 0xe2,
+	0x8a, 0xae, 0x80, 0xd7, 0x91, 0xab, 0x1f, 0xef, 0xf8, 0xe4, 0x13, 0x3a, 0x1e, 0x81, 0x48, 0xdd,
+	0x22, 0xb2, 0x70, 0x43, 0x7e, 0xff, 0xab, 0x1d, 0xc6, 0x47, 0x49, 0x98, 0x9f, 0x28, 0x2c, 0xbc,
+	0x07, 0x13, 0x22, 0x1f, 0x2c, 0x11, 0xf9, 0x60, 0xc9, 0xc7, 0x7e, 0x30, 0x7e, 0xda, 0xe2, 0xd9,
+	0xa7, 0x3d, 0xfd, 0x5d, 0x9e, 0x76, 0xea, 0x09, 0x4f, 0xfb, 0x9f, 0x7a, 0x0e, 0xbf, 0x10, 0x60,
+	0x25, 0xbe, 0x1c, 0x8b, 0x3c, 0x90, 0xeb, 0x30, 0xef, 0x6d, 0xc5, 0x33, 0xcf, 0xc2, 0xa3, 0xe4,
+	0x09, 0xb8, 0xfd, 0xd8, 0x8c, 0x77, 0x19, 0x66, 0xc7, 0xaa, 0x45, 0x76, 0x99, 0xf3, 0xc7, 0xc1,
+	0x6d, 0xc8, 0x1f, 0x27, 0x61, 0x31, 0xaa, 0xa0, 0x8b, 0x78, 0x63, 0x15, 0x58, 0x68, 0xe1, 0xa6,
+	0xd1, 0x7a, 0xe2, 0x17, 0x76, 0x9e, 0xab, 0xff, 0xe7, 0x7d, 0x8d, 0xb8, 0x27, 0xbf, 0x01, 0x48,
+	0x2b, 0xd8, 0xb6, 0x48, 0x81, 0x86, 0xca, 0x90, 0xc1, 0xa3, 0x26, 0xb6, 0x1c, 0xb7, 0xa8, 0x8d,
+	0xe9, 0x1b, 0x38, 0xc4, 0xd5, 0x23, 0xfd, 0xb3, 0xa7, 0x87, 0xfe, 0x9b, 0xd3, 0x04, 0xb1, 0x0d,
+	0x3f, 0x2b, 0xbf, 0x3d, 0x55, 0x8a, 0x46, 0x2f, 0xbb, 0x3c, 0x41, 0x32, 0xae, 0xfb, 0xe5, 0xc5,
+	0xb8, 0xa7, 0xc7, 0xf0, 0x64, 0x39, 0x4a, 0x14, 0x88, 0x71, 0xcb, 0xb1, 0x9a, 0xdd, 0x5f, 0x8e,
+	0xa0, 0xd1, 0xad, 0x10, 0x53, 0x90, 0x8a, 0x7b, 0xd4, 0x40, 0x71, 0xed, 0x3f, 0xaa, 0x4f, 0x15,
+	0xbc, 0xec, 0x52, 0x05, 0x33, 0x71, 0x9b, 0xe6, 0xd5, 0xa4, 0xbf, 0x69, 0x8a, 0x47, 0x6f, 0x06,
+	0xb8, 0x82, 0xcc, 0xba, 0x10, 0x5d, 0xfd, 0x7a, 0x35, 0xa2, 0xa7, 0xed, 0x91, 0x05, 0xff, 0xeb,
+	0x91, 0x05, 0xb9, 0x58, 0xa6, 0x81, 0x97, 0x81, 0x9e, 0x32, 0xd7, 0x40, 0xf5, 0x09, 0xb6, 0x80,
+	0x35, 0xf7, 0x57, 0xce, 0x64, 0x0b, 0x3c, 0x53, 0x63, 0x74, 0x41, 0x7d, 0x82, 0x2e, 0x98, 0x8d,
+	0xb3, 0x38, 0x56, 0x73, 0xfa, 0x16, 0xc3, 0x7c, 0xc1, 0xf7, 0xa2, 0xf9, 0x82, 0xd8, 0x86, 0x3e,
+	0xa2, 0xbe, 0xf4, 0x4c, 0x47, 0x10, 0x06, 0xef, 0xc7, 0x10, 0x06, 0x52, 0x5c, 0x63, 0x1b, 0x55,
+	0x5d, 0x7a, 0x0b, 0x44, 0x31, 0x06, 0x07, 0x11, 0x8c, 0x01, 0x6b, 0xed, 0x9f, 0x7b, 0x04, 0xc6,
+	0xc0, 0x33, 0x3d, 0x41, 0x19, 0x1c, 0x44, 0x50, 0x06, 0x28, 0xde, 0xee, 0x58, 0x51, 0x14, 0xb4,
+	0x1b, 0x12, 0xa1, 0xdb, 0x61, 0xce, 0x60, 0xe1, 0xf4, 0x5a, 0x94, 0xa5, 0x76, 0xcf, 0x5a, 0x90,
+	0x34, 0x68, 0xc6, 0x91, 0x06, 0xac, 0xaf, 0x7f, 0xe1, 0x11, 0x49, 0x03, 0xcf, 0x76, 0x24, 0x6b,
+	0x50, 0x9f, 0x60, 0x0d, 0x96, 0xe2, 0x2e, 0xdc, 0x58, 0x92, 0xf1, 0x2f, 0x5c, 0x2c, 0x6d, 0x30,
+	0x2d, 0xa5, 0x76, 0xc4, 0x74, 0x5a, 0xca, 0x30, 0xc2, 0x60, 0x47, 0x4c, 0x67, 0xa5, 0x9c, 0xfc,
+	0x1c, 0x29, 0x6b, 0xc6, 0xe2, 0x1e, 0x69, 0x22, 0xf0, 0x60, 0x60, 0x0e, 0x38, 0x01, 0xc0, 0x06,
+	0xf2, 0x55, 0xc8, 0x05, 0x43, 0xdc, 0x29, 0x14, 0xc3, 0x1c, 0xe4, 0x43, 0x51, 0x4d, 0xfe, 0xbb,
+	0x00, 0xb9, 0x60, 0xbc, 0x0a, 0x35, 0xa0, 0x19, 0xde, 0x80, 0x06, 0x88, 0x87, 0x44, 0x98, 0x78,
+	0x58, 0x83, 0x2c, 0x69, 0xc2, 0xc6, 0x38, 0x05, 0xdd, 0xf2, 0x38, 0x85, 0x6b, 0x30, 0x4f, 0x73,
+	0x28, 0xa3, 0x27, 0x78, 0x9e, 0x12, 0x69, 0x9e, 0x9a, 0x23, 0x02, 0xea, 0x0c, 0xd6, 0x0b, 0xa3,
+	0x17, 0x60, 0x21, 0x80, 0xf5, 0x9a, 0x3b, 0xd6, 0x5e, 0x4b, 0x1e, 0xba, 0xc8, 0xba, 0x3c, 0xf4,
+	0x7f, 0x90, 0xc3, 0xc7, 0xb8, 0xef, 0x68, 0x76, 0xb3, 0x83, 0x7b, 0x3a, 0x8f, 0xa9, 0x51, 0x2c,
+	0x31, 0x41, 0x35, 0x28, 0x48, 0xc9, 0x62, 0x7f, 0x20, 0xff, 0x4e, 0x80, 0xf9, 0x89, 0x80, 0x1b,
+	0xc9, 0x3c, 0x08, 0xdf, 0x15, 0xf3, 0x90, 0x78, 0x72, 0xe6, 0x21, 0xd8, 0xf0, 0x26, 0xc3, 0x0d,
+	0xef, 0xdf, 0x04, 0xc8, 0x87, 0x02, 0x3f, 0x39, 0xc6, 0xa6, 0xd9, 0xc2, 0xbc, 0x05, 0xa5, 0xbf,
+	0x49, 0xa5, 0xd3, 0x35, 0x8f, 0x78, 0xa3, 0x49, 0x7e, 0x12, 0x94, 0x97, 0xca, 0x32, 0x3c, 0x51,
+	0x79, 0xdd, 0x2b, 0xab, 0x26, 0xd8, 0x80, 0xe8, 0x3e, 0xc0, 0x8c, 0xa1, 0xce, 0x29, 0xe4, 0x27,
+	0x5a, 0xe4, 0x17, 0x98, 0x57, 0x05, 0x6c, 0x80, 0x5e, 0x85, 0x0c, 0xfd, 0x8e, 0xa0, 0x99, 0x96,
+	0x5d, 0x48, 0x8f, 0x57, 0x4c, 0xec, 0x63, 0x03, 0x8f, 0x14, 0x66, 0xbb, 0x66, 0xd9, 0x4a, 0xda,
+	0xe2, 0xbf, 0x02, 0x75, 0x4c, 0x26, 0x54, 0xc7, 0x5c, 0x80, 0x0c, 0xd9, 0xbe, 0x6d, 0xe9, 0x4d,
+	0x5c, 0x00, 0xba, 0x53, 0x7f, 0x42, 0xfe, 0x2c, 0x09, 0x73, 0x63, 0x79, 0x2b, 0xf2, 0xe1, 0xdd,
+	0x7b, 0x9d, 0x08, 0x10, 0x2b, 0x8f, 0xe6, 0x90, 0x55, 0x80, 0x23, 0xdd, 0xd6, 0x1e, 0xea, 0x7d,
+	0x07, 0xb7, 0xb8, 0x57, 0x02, 0x33, 0x68, 0x05, 0xd2, 0x64, 0x34, 0xb4, 0x71, 0x8b, 0x73, 0x3c,
+	0xde, 0x18, 0x55, 0x21, 0x45, 0x2f, 0x9c, 0x5d, 0x98, 0xa1, 0x07, 0x7f, 0x2e, 0xe6, 0x76, 0x96,
+	0x0a, 0xe4, 0xb8, 0xff, 0xf2, 0xe5, 0x9a, 0xc4, 0xe0, 0xcf, 0x9b, 0x3d, 0xc3, 0xc1, 0x3d, 0xcb,
+	0x39, 0x51, 0xb8, 0x81, 0xb0, 0x1b, 0xd2, 0x63, 0x6e, 0x20, 0xce, 0x7b, 0xc8, 0x9c, 0x97, 0x63,
+	0xce, 0x7b, 0xe8, 0xd1, 0x1a, 0x9c, 0x5f, 0xc8, 0x04, 0xf9, 0x05, 0xb2, 0x69, 0x9b, 0x14, 0xc0,
+	0xfd, 0x26, 0xa6, 0xd9, 0x58, 0x54, 0xbc, 0x31, 0x7a, 0x06, 0xf2, 0xb4, 0xfc, 0xf3, 0x00, 0xb3,
+	0x14, 0x10, 0x9e, 0xa4, 0xb1, 0x2a, 0x47, 0x8e, 0xcf, 0x30, 0x07, 0x86, 0x73, 0xa2, 0xe4, 0x7b,
+	0xb8, 0x67, 0x99, 0x66, 0x57, 0x63, 0x31, 0xa9, 0x08, 0xb3, 0xe1, 0x82, 0x80, 0x90, 0x94, 0x03,
+	0xec, 0x10, 0xb6, 0x2f, 0x54, 0xc7, 0xe7, 0xd8, 0x24, 0x8b, 0x01, 0x3b, 0x62, 0x5a, 0x90, 0x12,
+	0x9c, 0x5a, 0x7a, 0x1b, 0x96, 0x22, 0xeb, 0x01, 0xf4, 0x0a, 0x64, 0xfc, 0x5a, 0x42, 0x58, 0x4f,
+	0x9e, 0xc1, 0x19, 0xf9, 0x60, 0xf9, 0x00, 0x96, 0x22, 0x0b, 0x02, 0xf4, 0x06, 0xa4, 0x06, 0xd8,
+	0x1e, 0x76, 0x19, 0x2d, 0x34, 0x7b, 0xf3, 0xf2, 0xd9, 0x95, 0xc4, 0xb0, 0xeb, 0x28, 0x5c, 0x49,
+	0xbe, 0x01, 0xe7, 0x63, 0x2b, 0x02, 0x9f, 0xf9, 0x11, 0x02, 0xcc, 0x8f, 0xfc, 0x5b, 0x01, 0x56,
+	0xe2, 0xb3, 0x3c, 0x2a, 0x8d, 0x6d, 0xe8, 0xda, 0x23, 0xd6, 0x08, 0x81, 0x5d, 0x91, 0xd6, 0x68,
+	0x80, 0xdb, 0xd8, 0x69, 0x76, 0x58, 0xb9, 0xc1, 0xc2, 0x4f, 0x5e, 0xc9, 0xf3, 0x59, 0xaa, 0x63,
+	0x33, 0xd8, 0x07, 0xb8, 0xe9, 0x68, 0xec, 0x72, 0xd8, 0xb4, 0x3d, 0xc9, 0x28, 0x79, 0x36, 0xdb,
+	0x60, 0x93, 0xf2, 0x7b, 0x70, 0x2e, 0xa6, 0x6e, 0x88, 0xe8, 0xa1, 0x6e, 0xc0, 0x8c, 0x33, 0xd2,
+	0x5a, 0x46, 0xbb, 0xcd, 0xcb, 0xf2, 0xc2, 0xe4, 0xfe, 0xd5, 0xd1, 0x2d, 0xa3, 0xdd, 0x56, 0x52,
+	0x0e, 0xfd, 0x2b, 0xbf, 0x02, 0x29, 0x36, 0x83, 0x36, 0x7c, 0x73, 0x91, 0x0d, 0x57, 0x9d, 0x77,
+	0xc8, 0xea, 0x88, 0x2e, 0x26, 0x6f, 0x03, 0xf8, 0x53, 0x68, 0x39, 0x44, 0xbf, 0x91, 0x12, 0x98,
+	0x0e, 0xd1, 0x05, 0x48, 0x1b, 0x7d, 0x1b, 0x0f, 0xc8, 0x5b, 0x4c, 0xa3, 0xc0, 0xf6, 0x94, 0xe2,
+	0xcd, 0x94, 0x44, 0x42, 0x88, 0xca, 0xf7, 0xc9, 0x33, 0x46, 0xd6, 0x30, 0xe8, 0x2d, 0x48, 0xd9,
+	0x8e, 0xee, 0x0c, 0x6d, 0x7e, 0x20, 0x57, 0xce, 0x2c, 0x7f, 0x1a, 0x14, 0xae, 0x70, 0x35, 0xf9,
+	0x35, 0x40, 0x93, 0xc5, 0x4c, 0x44, 0xfb, 0x2a, 0x44, 0xb5, 0xaf, 0x87, 0xf0, 0xd4, 0x29, 0x65,
+	0x0b, 0x2a, 0x8f, 0x6d, 0xee, 0xfa, 0x23, 0x55, 0x3d, 0x63, 0x1b, 0xfc, 0x6b, 0x02, 0x96, 0x22,
+	0xab, 0x97, 0x40, 0x18, 0x13, 0xbe, 0x6d, 0x18, 0x7b, 0x03, 0xc0, 0x19, 0x69, 0xec, 0x82, 0xba,
+	0xe9, 0x30, 0xaa, 0x65, 0x1b, 0xe1, 0xa6, 0x3a, 0xe2, 0xf7, 0x39, 0xe3, 0xf0, 0x5f, 0x84, 0x5f,
+	0x09, 0x50, 0x06, 0x43, 0x9a, 0x2a, 0xed, 0x42, 0xf2, 0xf1, 0x92, 0xaa, 0x74, 0x1c, 0x9e, 0xb6,
+	0xd1, 0x7d, 0x38, 0x37, 0x96, 0xf2, 0x3d, 0xdb, 0xe2, 0x23, 0x67, 0xfe, 0xa5, 0x70, 0xe6, 0x77,
+	0x6d, 0x07, 0xd3, 0xf6, 0x74, 0x38, 0x6d, 0xdf, 0x07, 0xf0, 0xb9, 0x03, 0x12, 0x26, 0x06, 0xe6,
+	0xb0, 0xdf, 0xa2, 0x47, 0x38, 0xad, 0xb0, 0x01, 0xf9, 0x38, 0x4c, 0x6e, 0x82, 0xeb, 0xaa, 0x88,
+	0x38, 0x47, 0x8e, 0x34, 0x40, 0x3e, 0x30, 0xb8, 0xfc, 0x01, 0xa0, 0x49, 0x1a, 0x37, 0x66, 0x8d,
+	0x37, 0xc3, 0x6b, 0xc8, 0xf1, 0x8c, 0x70, 0xf4, 0x5a, 0x3f, 0x80, 0x69, 0x7a, 0xfc, 0x24, 0x7d,
+	0xd2, 0xaf, 0x08, 0xbc, 0x78, 0x24, 0xbf, 0xd1, 0x7b, 0x00, 0xba, 0xe3, 0x0c, 0x8c, 0xc3, 0xa1,
+	0xbf, 0xc2, 0x7a, 0xcc, 0xfd, 0x29, 0xba, 0xc0, 0xd2, 0x05, 0x7e, 0x91, 0x16, 0x7d, 0xdd, 0xc0,
+	0x65, 0x0a, 0x58, 0x94, 0xf7, 0x60, 0x36, 0xac, 0xeb, 0xd6, 0x2a, 0x6c, 0x13, 0xe1, 0x5a, 0x85,
+	0x95, 0xaf, 0x6c, 0xe0, 0x57, 0x3a, 0x49, 0xf6, 0xad, 0x84, 0x0e, 0xe4, 0x77, 0x20, 0x1b, 0x28,
+	0x18, 0xd1, 0x36, 0xb0, 0x92, 0x51, 0xa3, 0xe7, 0x5e, 0x10, 0xe2, 0xae, 0x1a, 0xd5, 0x21, 0x5f,
+	0x47, 0x98, 0x9e, 0x5b, 0xbf, 0x61, 0x77, 0xda, 0x96, 0xfb, 0x30, 0x37, 0x06, 0x8a, 0xf4, 0xd7,
+	0xed, 0x08, 0x7f, 0x45, 0xac, 0xe7, 0x3d, 0x6e, 0x78, 0xbd, 0x80, 0x63, 0xde, 0x85, 0xb9, 0x31,
+	0x50, 0x84, 0x67, 0x5e, 0xe4, 0x3b, 0x48, 0xd0, 0xb0, 0xb1, 0x76, 0xca, 0x3a, 0xfe, 0x97, 0x1f,
+	0xf9, 0x47, 0x09, 0xc8, 0x05, 0x5f, 0xd0, 0x7f, 0xc3, 0x82, 0x4b, 0xfe, 0x58, 0x80, 0xb4, 0xf7,
+	0xfc, 0xe1, 0x8f, 0x4a, 0xa1, 0xaf, 0x71, 0xec, 0x86, 0x25, 0x82, 0x5f, 0x82, 0xd8, 0xb7, 0xb7,
+	0xa4, 0xf7, 0xed, 0xed, 0x75, 0x2f, 0xd5, 0xc7, 0x52, 0x4a, 0x41, 0x6f, 0xf3, 0xa3, 0x76, 0x4b,
+	0x8f, 0xd7, 0x20, 0xe3, 0x85, 0x39, 0xd2, 0xa9, 0xb9, 0xf4, 0x9b, 0xc0, 0x63, 0x0d, 0x1b, 0x92,
+	0xad, 0x58, 0xe6, 0x43, 0xfe, 0x9d, 0x29, 0xa9, 0xb0, 0x81, 0x8c, 0x61, 0x6e, 0x2c, 0x46, 0xa2,
+	0xd7, 0x61, 0xc6, 0x1a, 0x1e, 0x6a, 0xee, 0x3d, 0x09, 0x75, 0x54, 0x81, 0xfa, 0x7d, 0x78, 0xd8,
+	0x35, 0x9a, 0x77, 0xf0, 0x89, 0xbb, 0x1b, 0x6b, 0x78, 0x78, 0x87, 0xbd, 0x69, 0x6c, 0x99, 0x44,
+	0x70, 0x99, 0xcf, 0x04, 0x48, 0xbb, 0xa1, 0x03, 0xbd, 0x05, 0x19, 0x2f, 0x00, 0xf3, 0x25, 0x9e,
+	0x3a, 0x25, 0x74, 0xf3, 0x05, 0x7c, 0x1d, 0x54, 0x72, 0xbf, 0x76, 0x1b, 0x2d, 0xad, 0xdd, 0xd5,
+	0x8f, 0xf8, 0x47, 0xcb, 0xd5, 0x88, 0x18, 0x4d, 0xd3, 0x58, 0xf5, 0xd6, 0x56, 0x57, 0x3f, 0x52,
+	0xb2, 0x54, 0xa9, 0xda, 0x22, 0x03, 0x5e, 0x61, 0x7e, 0x23, 0x80, 0x34, 0x1e, 0xda, 0xbe, 0xfd,
+	0xfe, 0x26, 0x53, 0x7a, 0x32, 0x22, 0xa5, 0xa3, 0x4d, 0x58, 0xf0, 0x10, 0x9a, 0x6d, 0x1c, 0xf5,
+	0x75, 0x67, 0x38, 0xc0, 0x9c, 0xda, 0x45, 0x9e, 0xa8, 0xe1, 0x4a, 0x26, 0x9f, 0x7b, 0xfa, 0x49,
+	0x9f, 0xfb, 0xa3, 0x04, 0x64, 0x03, 0x4c, 0x33, 0xfa, 0x9f, 0x40, 0x1c, 0x9a, 0x8d, 0x8a, 0x36,
+	0x01, 0xb0, 0x1f, 0x07, 0xc2, 0x9e, 0x4a, 0x3c, 0x81, 0xa7, 0xe2, 0x38, 0x7d, 0x97, 0xba, 0x16,
+	0x1f, 0x9b, 0xba, 0x7e, 0x1e, 0x90, 0x63, 0x3a, 0x7a, 0x97, 0x90, 0x41, 0x46, 0xff, 0x48, 0x63,
+	0x97, 0x91, 0xc5, 0x10, 0x89, 0x4a, 0x0e, 0xa8, 0xa0, 0x4e, 0xef, 0xe5, 0x8f, 0x05, 0x48, 0x7b,
+	0x14, 0xe0, 0xe3, 0x7e, 0x19, 0x5e, 0x86, 0x14, 0xaf, 0xaa, 0xd9, 0xa7, 0x61, 0x3e, 0x8a, 0xe4,
+	0xe8, 0x57, 0x20, 0xdd, 0xc3, 0x8e, 0x4e, 0x03, 0x22, 0x2b, 0x02, 0xbc, 0xf1, 0xb5, 0x1f, 0x42,
+	0x36, 0xf0, 0x71, 0x1d, 0x9d, 0x87, 0xa5, 0xf2, 0x76, 0xa5, 0x7c, 0x47, 0x53, 0xdf, 0xd5, 0xd4,
+	0x7b, 0xf5, 0x8a, 0xb6, 0xbf, 0x77, 0x67, 0xaf, 0xf6, 0xce, 0x9e, 0x34, 0x35, 0x29, 0x52, 0x2a,
+	0x74, 0x2c, 0x09, 0xe8, 0x1c, 0x2c, 0x84, 0x45, 0x4c, 0x90, 0x40, 0x2b, 0xb0, 0x1c, 0x16, 0x34,
+	0xaa, 0x77, 0xf7, 0x77, 0x8b, 0x6a, 0x45, 0x4a, 0xae, 0x88, 0x3f, 0xfd, 0xf5, 0xea, 0xd4, 0xb5,
+	0x6f, 0x04, 0x58, 0x88, 0xe8, 0x6d, 0xd0, 0x45, 0x78, 0xba, 0xb6, 0xb5, 0x55, 0x51, 0xb4, 0xc6,
+	0x5e, 0xb1, 0xde, 0xd8, 0xae, 0xa9, 0x9a, 0x52, 0x69, 0xec, 0xef, 0xaa, 0x81, 0x0d, 0xad, 0xc3,
+	0x85, 0x68, 0x48, 0xb1, 0x5c, 0xae, 0xd4, 0x55, 0x49, 0x40, 0x6b, 0xf0, 0x54, 0x0c, 0xa2, 0x54,
+	0x53, 0x54, 0x29, 0x11, 0x6f, 0x42, 0xa9, 0xec, 0x54, 0xca, 0xaa, 0x94, 0x44, 0x57, 0xe0, 0xd2,
+	0x69, 0x08, 0x6d, 0xab, 0xa6, 0xdc, 0x2d, 0xaa, 0x92, 0x78, 0x26, 0xb0, 0x51, 0xd9, 0xbb, 0x55,
+	0x51, 0xa4, 0x69, 0xfe, 0xdc, 0xbf, 0x4a, 0x40, 0x21, 0xae, 0x85, 0x22, 0xb6, 0x8a, 0xf5, 0xfa,
+	0xee, 0x3d, 0xdf, 0x56, 0x79, 0x7b, 0x7f, 0xef, 0xce, 0xa4, 0x0b, 0x9e, 0x05, 0xf9, 0x34, 0xa0,
+	0xe7, 0x88, 0xcb, 0x70, 0xf1, 0x54, 0x1c, 0x77, 0xc7, 0x19, 0x30, 0xa5, 0xa2, 0x2a, 0xf7, 0xa4,
+	0x24, 0xda, 0x80, 0x6b, 0x67, 0xc2, 0x3c, 0x99, 0x24, 0xa2, 0x4d, 0xb8, 0x7e, 0x3a, 0x9e, 0x39,
+	0xc8, 0x55, 0x70, 0x5d, 0xf4, 0x89, 0x00, 0x4b, 0x91, 0x4d, 0x0d, 0xba, 0x04, 0x6b, 0x75, 0xa5,
+	0x56, 0xae, 0x34, 0x1a, 0x5a, 0x5d, 0xa9, 0xd5, 0x6b, 0x8d, 0xe2, 0xae, 0xd6, 0x50, 0x8b, 0xea,
+	0x7e, 0x23, 0xe0, 0x1b, 0x19, 0x56, 0xe3, 0x40, 0x9e, 0x5f, 0x4e, 0xc1, 0xf0, 0x1b, 0x90, 0xe0,
+	0x9b, 0xf9, 0xa5, 0x00, 0xe7, 0x63, 0x9b, 0x18, 0x74, 0x15, 0x9e, 0x39, 0xa8, 0x28, 0xd5, 0xad,
+	0x7b, 0xda, 0x41, 0x4d, 0xad, 0x68, 0x95, 0x77, 0xd5, 0xca, 0x5e, 0xa3, 0x5a, 0xdb, 0x9b, 0xdc,
+	0xd5, 0x15, 0xb8, 0x74, 0x2a, 0xd2, 0xdb, 0xda, 0x59, 0xc0, 0xb1, 0xfd, 0xfd, 0x4c, 0x80, 0x7c,
+	0xa8, 0x5a, 0x22, 0xef, 0x6b, 0x51, 0x55, 0x95, 0x6a, 0x69, 0x5f, 0xad, 0xf0, 0x97, 0x4f, 0x55,
+	0xaa, 0x7b, 0xb7, 0xa5, 0x29, 0xb4, 0x0c, 0x68, 0x4c, 0x54, 0xdd, 0x53, 0xd9, 0x7b, 0x3c, 0x36,
+	0xbf, 0x4f, 0x04, 0x89, 0x08, 0x41, 0xa9, 0x56, 0xdb, 0x95, 0x92, 0x11, 0x02, 0xb5, 0x7a, 0xb7,
+	0x22, 0x89, 0x7c, 0x57, 0x3f, 0x11, 0x60, 0x6e, 0x2c, 0x7a, 0xa3, 0x0b, 0x50, 0xb8, 0x5b, 0x6d,
+	0x94, 0x2a, 0xdb, 0xc5, 0x83, 0x6a, 0x4d, 0x19, 0x8f, 0x32, 0x97, 0x60, 0x6d, 0x42, 0x7a, 0x6b,
+	0xbf, 0xbe, 0x5b, 0x2d, 0x17, 0xd5, 0x0a, 0x75, 0x85, 0x24, 0x10, 0x77, 0x4f, 0x80, 0x76, 0xab,
+	0xb7, 0xb7, 0x55, 0xad, 0xbc, 0x5b, 0xad, 0xec, 0xa9, 0x5a, 0x51, 0x55, 0x8b, 0x24, 0x00, 0xb1,
+	0x6d, 0x94, 0xee, 0x7c, 0xfe, 0xd5, 0xaa, 0xf0, 0xc5, 0x57, 0xab, 0xc2, 0x9f, 0xbf, 0x5a, 0x15,
+	0x3e, 0xfd, 0x7a, 0x75, 0xea, 0x8b, 0xaf, 0x57, 0xa7, 0xfe, 0xf8, 0xf5, 0xea, 0xd4, 0xfd, 0x1b,
+	0x47, 0x86, 0xd3, 0x19, 0x1e, 0x92, 0xbc, 0xb1, 0xe9, 0xff, 0x57, 0xb2, 0xfb, 0x43, 0xb7, 0x8c,
+	0xcd, 0xf1, 0x7f, 0x7d, 0x3e, 0x4c, 0xd1, 0x44, 0xf0, 0xe2, 0x3f, 0x06, 0x00, 0x6f, 0x5d, 0xf3,
+	0xcd, 0x15, 0x2d, 0x00, 0x00,
 }
 
 func (m *Request) Marshal() (dAtA []byte, err error) {
@@ -5667,6 +6083,18 @@ func (m *InfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.EventSchema != nil {
+		{
+			size, err := m.EventSchema.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
 	if len(m.LastBlockAppHash) > 0 {
 		i -= len(m.LastBlockAppHash)
 		copy(dAtA[i:], m.LastBlockAppHash)
@@ -5862,8 +6290,30 @@ func (m *CheckTxResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if len(m.Codespace) > 0 {
-		i -= len(m.Codespace)
+	if m.NextSequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.NextSequence))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.Weight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Weight))
+		i--
+		dAtA[i] = 0x60
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Codespace) > 0 {
+		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
 		i = encodeVarintTypes(dAtA, i, uint64(len(m.Codespace)))
 		i--
@@ -6075,20 +6525,20 @@ func (m *ApplySnapshotChunkResponse) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA48 := make([]byte, len(m.RefetchChunks)*10)
-		var j47 int
+		dAtA49 := make([]byte, len(m.RefetchChunks)*10)
+		var j48 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA48[j47] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA49[j48] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j47++
+				j48++
 			}
-			dAtA48[j47] = uint8(num)
-			j47++
+			dAtA49[j48] = uint8(num)
+			j48++
 		}
-		i -= j47
-		copy(dAtA[i:], dAtA48[:j47])
-		i = encodeVarintTypes(dAtA, i, uint64(j47))
+		i -= j48
+		copy(dAtA[i:], dAtA49[:j48])
+		i = encodeVarintTypes(dAtA, i, uint64(j48))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -6120,6 +6570,18 @@ func (m *PrepareProposalResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.TxDiff != nil {
+		{
+			size, err := m.TxDiff.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Txs) > 0 {
 		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Txs[iNdEx])
@@ -6132,6 +6594,103 @@ func (m *PrepareProposalResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *TxDiff) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxDiff) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxDiff) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Txs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProposedTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProposedTx) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProposedTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Tx != nil {
+		{
+			size := m.Tx.Size()
+			i -= size
+			if _, err := m.Tx.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProposedTx_Index) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProposedTx_Index) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+func (m *ProposedTx_Inserted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProposedTx_Inserted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Inserted != nil {
+		i -= len(m.Inserted)
+		copy(dAtA[i:], m.Inserted)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Inserted)))
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ProcessProposalResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -6477,6 +7036,122 @@ func (m *EventAttribute) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *EventSchema) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventSchema) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventSchema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EventTypes) > 0 {
+		for iNdEx := len(m.EventTypes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.EventTypes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventTypeSchema) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventTypeSchema) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventTypeSchema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Attributes) > 0 {
+		for iNdEx := len(m.Attributes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Attributes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AttributeSchema) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AttributeSchema) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AttributeSchema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ExecTxResult) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -6795,12 +7470,12 @@ func (m *Misbehavior) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n54, err54 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
-	if err54 != nil {
-		return 0, err54
+	n56, err56 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
+	if err56 != nil {
+		return 0, err56
 	}
-	i -= n54
-	i = encodeVarintTypes(dAtA, i, uint64(n54))
+	i -= n56
+	i = encodeVarintTypes(dAtA, i, uint64(n56))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -7740,6 +8415,10 @@ func (m *InfoResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.EventSchema != nil {
+		l = m.EventSchema.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -7845,6 +8524,19 @@ func (m *CheckTxResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Weight != 0 {
+		n += 1 + sovTypes(uint64(m.Weight))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovTypes(uint64(m.Sequence))
+	}
+	if m.NextSequence != 0 {
+		n += 1 + sovTypes(uint64(m.NextSequence))
+	}
 	return n
 }
 
@@ -7937,9 +8629,61 @@ func (m *PrepareProposalResponse) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if m.TxDiff != nil {
+		l = m.TxDiff.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *TxDiff) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, e := range m.Txs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ProposedTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Tx != nil {
+		n += m.Tx.Size()
+	}
 	return n
 }
 
+func (m *ProposedTx_Index) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovTypes(uint64(m.Index))
+	return n
+}
+func (m *ProposedTx_Inserted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Inserted != nil {
+		l = len(m.Inserted)
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *ProcessProposalResponse) Size() (n int) {
 	if m == nil {
 		return 0
@@ -8087,7 +8831,57 @@ func (m *EventAttribute) Size() (n int) {
 	return n
 }
 
-func (m *ExecTxResult) Size() (n int) {
+func (m *EventSchema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.EventTypes) > 0 {
+		for _, e := range m.EventTypes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *EventTypeSchema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Attributes) > 0 {
+		for _, e := range m.Attributes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AttributeSchema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovTypes(uint64(m.Type))
+	}
+	return n
+}
+
+func (m *ExecTxResult) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -12512,6 +13306,42 @@ func (m *InfoResponse) Unmarshal(dAtA []byte) error {
 				m.LastBlockAppHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventSchema", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EventSchema == nil {
+				m.EventSchema = &EventSchema{}
+			}
+			if err := m.EventSchema.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13244,6 +14074,95 @@ func (m *CheckTxResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+			}
+			m.Weight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Weight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextSequence", wireType)
+			}
+			m.NextSequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NextSequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13809,6 +14728,42 @@ func (m *PrepareProposalResponse) Unmarshal(dAtA []byte) error {
 			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
 			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxDiff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TxDiff == nil {
+				m.TxDiff = &TxDiff{}
+			}
+			if err := m.TxDiff.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13830,7 +14785,7 @@ func (m *PrepareProposalResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProcessProposalResponse) Unmarshal(dAtA []byte) error {
+func (m *TxDiff) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13853,17 +14808,17 @@ func (m *ProcessProposalResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProcessProposalResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TxDiff: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProcessProposalResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TxDiff: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			m.Status = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13873,11 +14828,26 @@ func (m *ProcessProposalResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= ProcessProposalStatus(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Txs = append(m.Txs, &ProposedTx{})
+			if err := m.Txs[len(m.Txs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13899,7 +14869,7 @@ func (m *ProcessProposalResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExtendVoteResponse) Unmarshal(dAtA []byte) error {
+func (m *ProposedTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13922,15 +14892,35 @@ func (m *ExtendVoteResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExtendVoteResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProposedTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExtendVoteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProposedTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Tx = &ProposedTx_Index{v}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VoteExtension", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Inserted", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -13957,10 +14947,9 @@ func (m *ExtendVoteResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.VoteExtension = append(m.VoteExtension[:0], dAtA[iNdEx:postIndex]...)
-			if m.VoteExtension == nil {
-				m.VoteExtension = []byte{}
-			}
+			v := make([]byte, postIndex-iNdEx)
+			copy(v, dAtA[iNdEx:postIndex])
+			m.Tx = &ProposedTx_Inserted{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13983,7 +14972,7 @@ func (m *ExtendVoteResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VerifyVoteExtensionResponse) Unmarshal(dAtA []byte) error {
+func (m *ProcessProposalResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14006,10 +14995,10 @@ func (m *VerifyVoteExtensionResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VerifyVoteExtensionResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProcessProposalResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VerifyVoteExtensionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProcessProposalResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -14026,7 +15015,7 @@ func (m *VerifyVoteExtensionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= VerifyVoteExtensionStatus(b&0x7F) << shift
+				m.Status |= ProcessProposalStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -14052,7 +15041,7 @@ func (m *VerifyVoteExtensionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FinalizeBlockResponse) Unmarshal(dAtA []byte) error {
+func (m *ExtendVoteResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14075,17 +15064,170 @@ func (m *FinalizeBlockResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FinalizeBlockResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExtendVoteResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FinalizeBlockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExtendVoteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VoteExtension", wireType)
 			}
-			var msglen int
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VoteExtension = append(m.VoteExtension[:0], dAtA[iNdEx:postIndex]...)
+			if m.VoteExtension == nil {
+				m.VoteExtension = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyVoteExtensionResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyVoteExtensionResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyVoteExtensionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= VerifyVoteExtensionStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FinalizeBlockResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FinalizeBlockResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FinalizeBlockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14730,6 +15872,307 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *EventSchema) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventSchema: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventSchema: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventTypes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventTypes = append(m.EventTypes, EventTypeSchema{})
+			if err := m.EventTypes[len(m.EventTypes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventTypeSchema) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventTypeSchema: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventTypeSchema: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attributes = append(m.Attributes, AttributeSchema{})
+			if err := m.Attributes[len(m.Attributes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AttributeSchema) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AttributeSchema: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AttributeSchema: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= AttributeType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ExecTxResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -670,6 +670,300 @@ func (m *HasProposalBlockPart) GetIndex() int32 {
 	return 0
 }
 
+// VotesBatch is sent to gossip several votes at once, on the channel for
+// batched votes.
+type VotesBatch struct {
+	Votes []*v1.Vote `protobuf:"bytes,1,rep,name=votes,proto3" json:"votes,omitempty"`
+}
+
+func (m *VotesBatch) Reset()         { *m = VotesBatch{} }
+func (m *VotesBatch) String() string { return proto.CompactTextString(m) }
+func (*VotesBatch) ProtoMessage()    {}
+func (*VotesBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4179ae4c5322abef, []int{10}
+}
+func (m *VotesBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VotesBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VotesBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VotesBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VotesBatch.Merge(m, src)
+}
+func (m *VotesBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *VotesBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_VotesBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VotesBatch proto.InternalMessageInfo
+
+func (m *VotesBatch) GetVotes() []*v1.Vote {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
+// CompactBlock is sent to gossip a proposal block with its transactions
+// replaced by their keys, on the channel for compact blocks.
+type CompactBlock struct {
+	Height int64     `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32     `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Block  *v1.Block `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	TxKeys [][]byte  `protobuf:"bytes,4,rep,name=tx_keys,json=txKeys,proto3" json:"tx_keys,omitempty"`
+}
+
+func (m *CompactBlock) Reset()         { *m = CompactBlock{} }
+func (m *CompactBlock) String() string { return proto.CompactTextString(m) }
+func (*CompactBlock) ProtoMessage()    {}
+func (*CompactBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4179ae4c5322abef, []int{11}
+}
+func (m *CompactBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlock.Merge(m, src)
+}
+func (m *CompactBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlock proto.InternalMessageInfo
+
+func (m *CompactBlock) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CompactBlock) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *CompactBlock) GetBlock() *v1.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetTxKeys() [][]byte {
+	if m != nil {
+		return m.TxKeys
+	}
+	return nil
+}
+
+// CompactBlockTxsRequest is sent to request the transactions of a compact
+// block which are missing from the mempool.
+type CompactBlockTxsRequest struct {
+	Height  int64        `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round   int32        `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Missing v11.BitArray `protobuf:"bytes,3,opt,name=missing,proto3" json:"missing"`
+}
+
+func (m *CompactBlockTxsRequest) Reset()         { *m = CompactBlockTxsRequest{} }
+func (m *CompactBlockTxsRequest) String() string { return proto.CompactTextString(m) }
+func (*CompactBlockTxsRequest) ProtoMessage()    {}
+func (*CompactBlockTxsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4179ae4c5322abef, []int{12}
+}
+func (m *CompactBlockTxsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactBlockTxsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactBlockTxsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactBlockTxsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlockTxsRequest.Merge(m, src)
+}
+func (m *CompactBlockTxsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactBlockTxsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlockTxsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlockTxsRequest proto.InternalMessageInfo
+
+func (m *CompactBlockTxsRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CompactBlockTxsRequest) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *CompactBlockTxsRequest) GetMissing() v11.BitArray {
+	if m != nil {
+		return m.Missing
+	}
+	return v11.BitArray{}
+}
+
+// CompactBlockTxs is sent in response to a CompactBlockTxsRequest, with the
+// requested transactions in the order of their indexes.
+type CompactBlockTxs struct {
+	Height int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32    `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Txs    [][]byte `protobuf:"bytes,3,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *CompactBlockTxs) Reset()         { *m = CompactBlockTxs{} }
+func (m *CompactBlockTxs) String() string { return proto.CompactTextString(m) }
+func (*CompactBlockTxs) ProtoMessage()    {}
+func (*CompactBlockTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4179ae4c5322abef, []int{13}
+}
+func (m *CompactBlockTxs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactBlockTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactBlockTxs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactBlockTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlockTxs.Merge(m, src)
+}
+func (m *CompactBlockTxs) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactBlockTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlockTxs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlockTxs proto.InternalMessageInfo
+
+func (m *CompactBlockTxs) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CompactBlockTxs) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *CompactBlockTxs) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+// CompactBlockFallback is sent when a compact block could not be
+// reconstructed, to request the block parts instead.
+type CompactBlockFallback struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (m *CompactBlockFallback) Reset()         { *m = CompactBlockFallback{} }
+func (m *CompactBlockFallback) String() string { return proto.CompactTextString(m) }
+func (*CompactBlockFallback) ProtoMessage()    {}
+func (*CompactBlockFallback) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4179ae4c5322abef, []int{14}
+}
+func (m *CompactBlockFallback) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactBlockFallback) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactBlockFallback.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactBlockFallback) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlockFallback.Merge(m, src)
+}
+func (m *CompactBlockFallback) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactBlockFallback) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlockFallback.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlockFallback proto.InternalMessageInfo
+
+func (m *CompactBlockFallback) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CompactBlockFallback) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
 // Message is an abstract consensus message.
 type Message struct {
 	// Sum of all possible messages.
@@ -685,6 +979,11 @@ type Message struct {
 	//	*Message_VoteSetMaj23
 	//	*Message_VoteSetBits
 	//	*Message_HasProposalBlockPart
+	//	*Message_VotesBatch
+	//	*Message_CompactBlock
+	//	*Message_CompactBlockTxsRequest
+	//	*Message_CompactBlockTxs
+	//	*Message_CompactBlockFallback
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -692,7 +991,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4179ae4c5322abef, []int{10}
+	return fileDescriptor_4179ae4c5322abef, []int{15}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -757,17 +1056,37 @@ type Message_VoteSetBits struct {
 type Message_HasProposalBlockPart struct {
 	HasProposalBlockPart *HasProposalBlockPart `protobuf:"bytes,10,opt,name=has_proposal_block_part,json=hasProposalBlockPart,proto3,oneof" json:"has_proposal_block_part,omitempty"`
 }
+type Message_VotesBatch struct {
+	VotesBatch *VotesBatch `protobuf:"bytes,11,opt,name=votes_batch,json=votesBatch,proto3,oneof" json:"votes_batch,omitempty"`
+}
+type Message_CompactBlock struct {
+	CompactBlock *CompactBlock `protobuf:"bytes,12,opt,name=compact_block,json=compactBlock,proto3,oneof" json:"compact_block,omitempty"`
+}
+type Message_CompactBlockTxsRequest struct {
+	CompactBlockTxsRequest *CompactBlockTxsRequest `protobuf:"bytes,13,opt,name=compact_block_txs_request,json=compactBlockTxsRequest,proto3,oneof" json:"compact_block_txs_request,omitempty"`
+}
+type Message_CompactBlockTxs struct {
+	CompactBlockTxs *CompactBlockTxs `protobuf:"bytes,14,opt,name=compact_block_txs,json=compactBlockTxs,proto3,oneof" json:"compact_block_txs,omitempty"`
+}
+type Message_CompactBlockFallback struct {
+	CompactBlockFallback *CompactBlockFallback `protobuf:"bytes,15,opt,name=compact_block_fallback,json=compactBlockFallback,proto3,oneof" json:"compact_block_fallback,omitempty"`
+}
 
-func (*Message_NewRoundStep) isMessage_Sum()         {}
-func (*Message_NewValidBlock) isMessage_Sum()        {}
-func (*Message_Proposal) isMessage_Sum()             {}
-func (*Message_ProposalPol) isMessage_Sum()          {}
-func (*Message_BlockPart) isMessage_Sum()            {}
-func (*Message_Vote) isMessage_Sum()                 {}
-func (*Message_HasVote) isMessage_Sum()              {}
-func (*Message_VoteSetMaj23) isMessage_Sum()         {}
-func (*Message_VoteSetBits) isMessage_Sum()          {}
-func (*Message_HasProposalBlockPart) isMessage_Sum() {}
+func (*Message_NewRoundStep) isMessage_Sum()           {}
+func (*Message_NewValidBlock) isMessage_Sum()          {}
+func (*Message_Proposal) isMessage_Sum()               {}
+func (*Message_ProposalPol) isMessage_Sum()            {}
+func (*Message_BlockPart) isMessage_Sum()              {}
+func (*Message_Vote) isMessage_Sum()                   {}
+func (*Message_HasVote) isMessage_Sum()                {}
+func (*Message_VoteSetMaj23) isMessage_Sum()           {}
+func (*Message_VoteSetBits) isMessage_Sum()            {}
+func (*Message_HasProposalBlockPart) isMessage_Sum()   {}
+func (*Message_VotesBatch) isMessage_Sum()             {}
+func (*Message_CompactBlock) isMessage_Sum()           {}
+func (*Message_CompactBlockTxsRequest) isMessage_Sum() {}
+func (*Message_CompactBlockTxs) isMessage_Sum()        {}
+func (*Message_CompactBlockFallback) isMessage_Sum()   {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -846,6 +1165,41 @@ func (m *Message) GetHasProposalBlockPart() *HasProposalBlockPart {
 	return nil
 }
 
+func (m *Message) GetVotesBatch() *VotesBatch {
+	if x, ok := m.GetSum().(*Message_VotesBatch); ok {
+		return x.VotesBatch
+	}
+	return nil
+}
+
+func (m *Message) GetCompactBlock() *CompactBlock {
+	if x, ok := m.GetSum().(*Message_CompactBlock); ok {
+		return x.CompactBlock
+	}
+	return nil
+}
+
+func (m *Message) GetCompactBlockTxsRequest() *CompactBlockTxsRequest {
+	if x, ok := m.GetSum().(*Message_CompactBlockTxsRequest); ok {
+		return x.CompactBlockTxsRequest
+	}
+	return nil
+}
+
+func (m *Message) GetCompactBlockTxs() *CompactBlockTxs {
+	if x, ok := m.GetSum().(*Message_CompactBlockTxs); ok {
+		return x.CompactBlockTxs
+	}
+	return nil
+}
+
+func (m *Message) GetCompactBlockFallback() *CompactBlockFallback {
+	if x, ok := m.GetSum().(*Message_CompactBlockFallback); ok {
+		return x.CompactBlockFallback
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -859,6 +1213,11 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_VoteSetMaj23)(nil),
 		(*Message_VoteSetBits)(nil),
 		(*Message_HasProposalBlockPart)(nil),
+		(*Message_VotesBatch)(nil),
+		(*Message_CompactBlock)(nil),
+		(*Message_CompactBlockTxsRequest)(nil),
+		(*Message_CompactBlockTxs)(nil),
+		(*Message_CompactBlockFallback)(nil),
 	}
 }
 
@@ -873,70 +1232,89 @@ func init() {
 	proto.RegisterType((*VoteSetMaj23)(nil), "cometbft.consensus.v1.VoteSetMaj23")
 	proto.RegisterType((*VoteSetBits)(nil), "cometbft.consensus.v1.VoteSetBits")
 	proto.RegisterType((*HasProposalBlockPart)(nil), "cometbft.consensus.v1.HasProposalBlockPart")
+	proto.RegisterType((*VotesBatch)(nil), "cometbft.consensus.v1.VotesBatch")
+	proto.RegisterType((*CompactBlock)(nil), "cometbft.consensus.v1.CompactBlock")
+	proto.RegisterType((*CompactBlockTxsRequest)(nil), "cometbft.consensus.v1.CompactBlockTxsRequest")
+	proto.RegisterType((*CompactBlockTxs)(nil), "cometbft.consensus.v1.CompactBlockTxs")
+	proto.RegisterType((*CompactBlockFallback)(nil), "cometbft.consensus.v1.CompactBlockFallback")
 	proto.RegisterType((*Message)(nil), "cometbft.consensus.v1.Message")
 }
 
 func init() { proto.RegisterFile("cometbft/consensus/v1/types.proto", fileDescriptor_4179ae4c5322abef) }
 
 var fileDescriptor_4179ae4c5322abef = []byte{
-	// 903 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xcd, 0x6e, 0x23, 0x45,
-	0x10, 0x9e, 0x21, 0x76, 0xec, 0x94, 0xf3, 0x03, 0xad, 0x84, 0xb5, 0xb2, 0xc2, 0x31, 0x03, 0x87,
-	0x88, 0x45, 0xb6, 0xe2, 0x20, 0x38, 0xac, 0x90, 0xd8, 0x01, 0xc1, 0x44, 0xbb, 0xc9, 0x5a, 0xed,
-	0xd5, 0x4a, 0xec, 0x65, 0x34, 0xf6, 0x34, 0x76, 0xc3, 0x78, 0x7a, 0x34, 0xdd, 0x71, 0xc8, 0x99,
-	0x17, 0xe0, 0x05, 0x78, 0x0c, 0x2e, 0x3c, 0xc1, 0x1e, 0xf7, 0xc8, 0x69, 0x85, 0x12, 0xf1, 0x0a,
-	0x70, 0x45, 0x5d, 0xd3, 0x1e, 0x8f, 0xbd, 0xf6, 0x86, 0x70, 0x40, 0xda, 0x5b, 0x4f, 0x57, 0xd5,
-	0xd7, 0xd5, 0x5f, 0x55, 0x7d, 0x3d, 0xf0, 0xfe, 0x40, 0x8c, 0x99, 0xea, 0x7f, 0xa7, 0xda, 0x03,
-	0x11, 0x4b, 0x16, 0xcb, 0x73, 0xd9, 0x9e, 0x1c, 0xb5, 0xd5, 0x65, 0xc2, 0x64, 0x2b, 0x49, 0x85,
-	0x12, 0x64, 0x6f, 0xea, 0xd2, 0xca, 0x5d, 0x5a, 0x93, 0xa3, 0xfd, 0xdd, 0xa1, 0x18, 0x0a, 0xf4,
-	0x68, 0xeb, 0x55, 0xe6, 0xbc, 0x3f, 0xc3, 0x8b, 0x78, 0x5f, 0xb6, 0xfb, 0x5c, 0x2d, 0xe2, 0xed,
-	0xbf, 0x97, 0xbb, 0xe0, 0xee, 0x82, 0xd9, 0xf9, 0xd5, 0x86, 0xcd, 0x33, 0x76, 0x41, 0xc5, 0x79,
-	0x1c, 0xf6, 0x14, 0x4b, 0xc8, 0xbb, 0xb0, 0x3e, 0x62, 0x7c, 0x38, 0x52, 0x75, 0xbb, 0x69, 0x1f,
-	0xae, 0x51, 0xf3, 0x45, 0x76, 0xa1, 0x9c, 0x6a, 0xa7, 0xfa, 0x5b, 0x4d, 0xfb, 0xb0, 0x4c, 0xb3,
-	0x0f, 0x42, 0xa0, 0x24, 0x15, 0x4b, 0xea, 0x6b, 0x4d, 0xfb, 0x70, 0x8b, 0xe2, 0x9a, 0x7c, 0x06,
-	0x75, 0xc9, 0x06, 0x22, 0x0e, 0xa5, 0x2f, 0x79, 0x3c, 0x60, 0xbe, 0x54, 0x41, 0xaa, 0x7c, 0xc5,
-	0xc7, 0xac, 0x5e, 0x42, 0xcc, 0x3d, 0x63, 0xef, 0x69, 0x73, 0x4f, 0x5b, 0x9f, 0xf0, 0x31, 0x23,
-	0x1f, 0xc1, 0x3b, 0x51, 0x20, 0x95, 0x3f, 0x10, 0xe3, 0x31, 0x57, 0x7e, 0x76, 0x5c, 0x19, 0x8f,
-	0xdb, 0xd1, 0x86, 0x2f, 0x71, 0x1f, 0x53, 0x75, 0xfe, 0xb6, 0x61, 0xeb, 0x8c, 0x5d, 0x3c, 0x0d,
-	0x22, 0x1e, 0xba, 0x91, 0x18, 0xfc, 0x70, 0xcb, 0xc4, 0xbf, 0x85, 0xbd, 0xbe, 0x0e, 0xf3, 0x13,
-	0x9d, 0x9b, 0x64, 0xca, 0x1f, 0xb1, 0x20, 0x64, 0x29, 0xde, 0xa4, 0xd6, 0x69, 0xb6, 0xf2, 0x32,
-	0x64, 0x6c, 0x4d, 0x8e, 0x5a, 0xdd, 0x20, 0x55, 0x3d, 0xa6, 0x3c, 0xf4, 0x73, 0x4b, 0xcf, 0x5f,
-	0x1e, 0x58, 0x94, 0x20, 0xc8, 0x9c, 0x85, 0x7c, 0x01, 0xb5, 0x19, 0xb4, 0xc4, 0x2b, 0xd7, 0x3a,
-	0x07, 0x33, 0x40, 0x5d, 0xaa, 0x96, 0x2e, 0x95, 0x06, 0x75, 0xb9, 0x7a, 0x90, 0xa6, 0xc1, 0x25,
-	0x85, 0x1c, 0x49, 0x92, 0xbb, 0xb0, 0xc1, 0xa5, 0xa1, 0x01, 0x09, 0xa8, 0xd2, 0x2a, 0x97, 0xd9,
-	0xf5, 0x9d, 0x13, 0xa8, 0x76, 0x53, 0x91, 0x08, 0x19, 0x44, 0xe4, 0x73, 0xa8, 0x26, 0x66, 0x8d,
-	0xb7, 0xae, 0x75, 0xee, 0x2e, 0x4b, 0xdc, 0xb8, 0x98, 0x9c, 0xf3, 0x10, 0xe7, 0x17, 0x1b, 0x6a,
-	0x53, 0x63, 0xf7, 0xf1, 0xa3, 0x95, 0x14, 0x7e, 0x0c, 0x64, 0x1a, 0xe3, 0x27, 0x22, 0xf2, 0x8b,
-	0x7c, 0xbe, 0x3d, 0xb5, 0x74, 0x45, 0x84, 0xa5, 0x21, 0x1e, 0x6c, 0x16, 0xbd, 0x0d, 0xa3, 0x37,
-	0x11, 0x60, 0x92, 0xab, 0x15, 0xe0, 0x9c, 0x08, 0x36, 0xdc, 0x29, 0x2b, 0xb7, 0xac, 0xef, 0x11,
-	0x94, 0x34, 0xfd, 0xe6, 0xf0, 0x3b, 0x2b, 0xca, 0x69, 0x0e, 0x45, 0x57, 0xe7, 0x18, 0x4a, 0x4f,
-	0x85, 0x62, 0xe4, 0x1e, 0x94, 0x26, 0x42, 0x31, 0x43, 0xe8, 0xb2, 0x50, 0xed, 0x46, 0xd1, 0xc9,
-	0xf9, 0xc9, 0x86, 0x8a, 0x17, 0x48, 0x0c, 0xbc, 0x5d, 0x86, 0x9f, 0x40, 0x49, 0x03, 0x62, 0x86,
-	0xdb, 0x4b, 0x1b, 0xae, 0xc7, 0x87, 0x31, 0x0b, 0x4f, 0xe5, 0xf0, 0xc9, 0x65, 0xc2, 0x28, 0x7a,
-	0x6b, 0x2c, 0x1e, 0x87, 0xec, 0x47, 0x6c, 0xab, 0x32, 0xcd, 0x3e, 0x9c, 0xdf, 0x6c, 0xd8, 0xd4,
-	0x29, 0xf4, 0x98, 0x3a, 0x0d, 0xbe, 0xef, 0x1c, 0xff, 0x2f, 0xa9, 0x7c, 0x0d, 0xd5, 0xac, 0xcf,
-	0x79, 0x68, 0x9a, 0x7c, 0x7f, 0x49, 0x24, 0x16, 0xf0, 0xe4, 0x2b, 0x77, 0x47, 0x33, 0x7d, 0xf5,
-	0xf2, 0xa0, 0x62, 0x36, 0x68, 0x05, 0x83, 0x4f, 0x42, 0xe7, 0x2f, 0x1b, 0x6a, 0x26, 0x79, 0x97,
-	0x2b, 0xf9, 0x26, 0xe5, 0x4e, 0xee, 0x43, 0x59, 0xb7, 0x81, 0xc4, 0x29, 0xfd, 0xd7, 0x4d, 0x9e,
-	0xc5, 0x38, 0xcf, 0x60, 0xd7, 0x0b, 0x64, 0x3e, 0x9d, 0xff, 0xb1, 0xd3, 0xf3, 0x8e, 0x58, 0x2b,
-	0x76, 0xc4, 0x9f, 0x65, 0xa8, 0x9c, 0x32, 0x29, 0x83, 0x21, 0x23, 0x0f, 0x61, 0x3b, 0x66, 0x17,
-	0xd9, 0xd4, 0xfa, 0x28, 0xd7, 0x59, 0x6b, 0x7f, 0xd0, 0x5a, 0xfa, 0xd6, 0xb4, 0x8a, 0xef, 0x81,
-	0x67, 0xd1, 0xcd, 0xb8, 0xf8, 0x3e, 0x9c, 0xc1, 0x8e, 0x06, 0x9b, 0x68, 0xe1, 0xf5, 0x91, 0x06,
-	0x4c, 0xa7, 0xd6, 0xf9, 0x70, 0x35, 0xda, 0x4c, 0xa5, 0x3d, 0x8b, 0x6e, 0xc5, 0x73, 0xb2, 0x5d,
-	0x94, 0xb0, 0x57, 0x94, 0x62, 0x0e, 0x68, 0x4a, 0x94, 0x57, 0x90, 0x30, 0xf2, 0xcd, 0x82, 0xd8,
-	0x64, 0xc5, 0x74, 0x6e, 0x80, 0xe8, 0x3e, 0x7e, 0xe4, 0xcd, 0x6b, 0x0d, 0x79, 0x00, 0x30, 0x53,
-	0x6d, 0x53, 0xce, 0xe6, 0x0a, 0x98, 0xbc, 0x54, 0x9e, 0x45, 0x37, 0x72, 0xdd, 0xd6, 0x9a, 0x83,
-	0xc2, 0xb1, 0xbe, 0xa8, 0xc4, 0x73, 0xc1, 0xba, 0xd5, 0x3d, 0x2b, 0x93, 0x0f, 0x72, 0x1f, 0xaa,
-	0xa3, 0x40, 0xfa, 0x18, 0x56, 0xc1, 0xb0, 0xc6, 0x8a, 0x30, 0x23, 0x32, 0x9e, 0x45, 0x2b, 0x23,
-	0xa3, 0x37, 0x0f, 0x61, 0x5b, 0x07, 0xe2, 0xeb, 0x35, 0xd6, 0x63, 0x5f, 0xaf, 0xbe, 0xb6, 0xae,
-	0x45, 0x85, 0xd0, 0x75, 0x9d, 0x14, 0x15, 0xc3, 0x83, 0xad, 0x1c, 0x4c, 0xb7, 0x6d, 0x7d, 0xe3,
-	0xb5, 0x4c, 0x16, 0x06, 0x56, 0x33, 0x39, 0x29, 0xcc, 0x6f, 0x08, 0x77, 0xf4, 0x9d, 0xf2, 0xb2,
-	0x14, 0x68, 0x05, 0xc4, 0xbc, 0xb7, 0xfa, 0x8a, 0xaf, 0x0c, 0x83, 0x67, 0xd1, 0xdd, 0xd1, 0x92,
-	0x7d, 0xb7, 0x0c, 0x6b, 0xf2, 0x7c, 0xec, 0x76, 0x9f, 0x5f, 0x35, 0xec, 0x17, 0x57, 0x0d, 0xfb,
-	0x8f, 0xab, 0x86, 0xfd, 0xf3, 0x75, 0xc3, 0x7a, 0x71, 0xdd, 0xb0, 0x7e, 0xbf, 0x6e, 0x58, 0xcf,
-	0x3e, 0x1d, 0x72, 0x35, 0x3a, 0xef, 0xeb, 0xb3, 0xda, 0x85, 0xdf, 0x2e, 0xb3, 0x08, 0x12, 0xde,
-	0x5e, 0xfa, 0x33, 0xd6, 0x5f, 0xc7, 0x1f, 0xa3, 0xe3, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x64,
-	0x03, 0x11, 0x71, 0xac, 0x09, 0x00, 0x00,
+	// 1136 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0x4f, 0x6f, 0x1a, 0x47,
+	0x14, 0xdf, 0x0d, 0x60, 0xf0, 0x03, 0x42, 0x32, 0xc2, 0xf6, 0xd6, 0x51, 0x31, 0xd9, 0x56, 0x95,
+	0xd5, 0x34, 0x20, 0xdb, 0x55, 0x7b, 0xb0, 0xaa, 0x36, 0xc4, 0x4a, 0xd7, 0x4d, 0xec, 0xd0, 0xc1,
+	0x8a, 0xd4, 0x5c, 0x56, 0xcb, 0x32, 0x81, 0x4d, 0x96, 0xdd, 0x2d, 0x33, 0x60, 0x38, 0x57, 0x55,
+	0xaf, 0xfd, 0x02, 0xed, 0xb7, 0xe8, 0xa5, 0x9f, 0x20, 0xc7, 0x1c, 0x7b, 0x8a, 0x2a, 0xfb, 0x3b,
+	0xb4, 0xd7, 0x6a, 0x66, 0x87, 0x65, 0xc1, 0x40, 0x4d, 0x0e, 0x95, 0x7a, 0x9b, 0x99, 0xf7, 0xde,
+	0x6f, 0xde, 0xfc, 0xde, 0xbf, 0x5d, 0xb8, 0x6b, 0xfb, 0x5d, 0xc2, 0x9a, 0x2f, 0x58, 0xd5, 0xf6,
+	0x3d, 0x4a, 0x3c, 0xda, 0xa7, 0xd5, 0xc1, 0x5e, 0x95, 0x8d, 0x02, 0x42, 0x2b, 0x41, 0xcf, 0x67,
+	0x3e, 0xda, 0x18, 0xab, 0x54, 0x22, 0x95, 0xca, 0x60, 0x6f, 0xbb, 0xd8, 0xf6, 0xdb, 0xbe, 0xd0,
+	0xa8, 0xf2, 0x55, 0xa8, 0xbc, 0x3d, 0xc1, 0x73, 0x9d, 0x26, 0xad, 0x36, 0x1d, 0x36, 0x8b, 0xb7,
+	0xfd, 0x7e, 0xa4, 0x22, 0x4e, 0xaf, 0x21, 0x6e, 0xba, 0xbe, 0xfd, 0x2a, 0x14, 0xeb, 0xbf, 0xa9,
+	0x90, 0x3b, 0x25, 0xe7, 0xd8, 0xef, 0x7b, 0xad, 0x06, 0x23, 0x01, 0xda, 0x84, 0xb5, 0x0e, 0x71,
+	0xda, 0x1d, 0xa6, 0xa9, 0x65, 0x75, 0x37, 0x81, 0xe5, 0x0e, 0x15, 0x21, 0xd5, 0xe3, 0x4a, 0xda,
+	0x8d, 0xb2, 0xba, 0x9b, 0xc2, 0xe1, 0x06, 0x21, 0x48, 0x52, 0x46, 0x02, 0x2d, 0x51, 0x56, 0x77,
+	0xf3, 0x58, 0xac, 0xd1, 0xe7, 0xa0, 0x51, 0x62, 0xfb, 0x5e, 0x8b, 0x9a, 0xd4, 0xf1, 0x6c, 0x62,
+	0x52, 0x66, 0xf5, 0x98, 0xc9, 0x9c, 0x2e, 0xd1, 0x92, 0x02, 0x73, 0x43, 0xca, 0x1b, 0x5c, 0xdc,
+	0xe0, 0xd2, 0x33, 0xa7, 0x4b, 0xd0, 0xc7, 0x70, 0xdb, 0xb5, 0x28, 0x33, 0x6d, 0xbf, 0xdb, 0x75,
+	0x98, 0x19, 0x5e, 0x97, 0x12, 0xd7, 0x15, 0xb8, 0xe0, 0xa1, 0x38, 0x17, 0xae, 0xea, 0x7f, 0xab,
+	0x90, 0x3f, 0x25, 0xe7, 0xcf, 0x2c, 0xd7, 0x69, 0xd5, 0xf8, 0x7b, 0x56, 0x74, 0xfc, 0x3b, 0xd8,
+	0x10, 0x34, 0x98, 0x01, 0xf7, 0x8d, 0x12, 0x66, 0x76, 0x88, 0xd5, 0x22, 0x3d, 0xf1, 0x92, 0xec,
+	0x7e, 0xb9, 0x12, 0x45, 0x29, 0x24, 0x73, 0xb0, 0x57, 0xa9, 0x5b, 0x3d, 0xd6, 0x20, 0xcc, 0x10,
+	0x7a, 0xb5, 0xe4, 0xeb, 0xb7, 0x3b, 0x0a, 0x46, 0x02, 0x64, 0x4a, 0x82, 0xbe, 0x82, 0xec, 0x04,
+	0x9a, 0x8a, 0x27, 0x67, 0xf7, 0x77, 0x26, 0x80, 0x3c, 0x92, 0x15, 0x1e, 0x49, 0x0e, 0x5a, 0x73,
+	0xd8, 0x83, 0x5e, 0xcf, 0x1a, 0x61, 0x88, 0x90, 0x28, 0xba, 0x03, 0xeb, 0x0e, 0x95, 0x34, 0x08,
+	0x02, 0x32, 0x38, 0xe3, 0xd0, 0xf0, 0xf9, 0xfa, 0x31, 0x64, 0xea, 0x3d, 0x3f, 0xf0, 0xa9, 0xe5,
+	0xa2, 0x2f, 0x20, 0x13, 0xc8, 0xb5, 0x78, 0x75, 0x76, 0xff, 0xce, 0x3c, 0xc7, 0xa5, 0x8a, 0xf4,
+	0x39, 0x32, 0xd1, 0x7f, 0x51, 0x21, 0x3b, 0x16, 0xd6, 0x9f, 0x3e, 0x59, 0x48, 0xe1, 0x27, 0x80,
+	0xc6, 0x36, 0x66, 0xe0, 0xbb, 0x66, 0x9c, 0xcf, 0x5b, 0x63, 0x49, 0xdd, 0x77, 0x45, 0x68, 0x90,
+	0x01, 0xb9, 0xb8, 0xb6, 0x96, 0xb8, 0x16, 0x01, 0xd2, 0xb9, 0x6c, 0x0c, 0x4e, 0x77, 0x61, 0xbd,
+	0x36, 0x66, 0x65, 0xc5, 0xf8, 0xee, 0x41, 0x92, 0xd3, 0x2f, 0x2f, 0xdf, 0x5a, 0x10, 0x4e, 0x79,
+	0xa9, 0x50, 0xd5, 0x0f, 0x20, 0xf9, 0xcc, 0x67, 0x04, 0xdd, 0x83, 0xe4, 0xc0, 0x67, 0x44, 0x53,
+	0x17, 0x9a, 0x72, 0x35, 0x2c, 0x94, 0xf4, 0x1f, 0x54, 0x48, 0x1b, 0x16, 0x15, 0x86, 0xab, 0x79,
+	0xf8, 0x29, 0x24, 0x39, 0xa0, 0xf0, 0xf0, 0xe6, 0xdc, 0x84, 0x6b, 0x38, 0x6d, 0x8f, 0xb4, 0x4e,
+	0x68, 0xfb, 0x6c, 0x14, 0x10, 0x2c, 0xb4, 0x39, 0x96, 0xe3, 0xb5, 0xc8, 0x50, 0xa4, 0x55, 0x0a,
+	0x87, 0x1b, 0xfd, 0x77, 0x15, 0x72, 0xdc, 0x85, 0x06, 0x61, 0x27, 0xd6, 0xcb, 0xfd, 0x83, 0xff,
+	0xc4, 0x95, 0x47, 0x90, 0x09, 0xf3, 0xdc, 0x69, 0xc9, 0x24, 0xdf, 0x9e, 0x63, 0x29, 0x02, 0x78,
+	0x7c, 0x54, 0x2b, 0x70, 0xa6, 0x2f, 0xde, 0xee, 0xa4, 0xe5, 0x01, 0x4e, 0x0b, 0xe3, 0xe3, 0x96,
+	0xfe, 0x97, 0x0a, 0x59, 0xe9, 0x7c, 0xcd, 0x61, 0xf4, 0xff, 0xe4, 0x3b, 0x3a, 0x84, 0x14, 0x4f,
+	0x03, 0xaa, 0xa5, 0x56, 0x49, 0xf2, 0xd0, 0x46, 0x7f, 0x0e, 0x45, 0xc3, 0xa2, 0x51, 0x75, 0xbe,
+	0x63, 0xa6, 0x47, 0x19, 0x91, 0x88, 0x67, 0xc4, 0x21, 0x00, 0xe7, 0x94, 0xd6, 0x2c, 0x66, 0x77,
+	0xd0, 0xfd, 0xb1, 0x9b, 0x6a, 0x39, 0xb1, 0x2c, 0xa7, 0xa5, 0x63, 0x3f, 0xaa, 0x90, 0x7b, 0xe8,
+	0x77, 0x03, 0xcb, 0x66, 0xef, 0xd2, 0x5b, 0x2b, 0x90, 0x12, 0xfc, 0xc8, 0xe2, 0xd3, 0x16, 0x31,
+	0x8b, 0x43, 0x35, 0xb4, 0x05, 0x69, 0x36, 0x34, 0x5f, 0x91, 0x11, 0x6f, 0x96, 0x89, 0xdd, 0x1c,
+	0x5e, 0x63, 0xc3, 0xc7, 0x64, 0x44, 0xf5, 0x9f, 0x54, 0xd8, 0x8c, 0xfb, 0x71, 0x36, 0xa4, 0x98,
+	0x7c, 0xdf, 0x27, 0x74, 0x55, 0x8e, 0xbe, 0x84, 0x74, 0xd7, 0xa1, 0xd4, 0xf1, 0xda, 0xab, 0x75,
+	0xa3, 0xb1, 0x95, 0xfe, 0x2d, 0x14, 0x66, 0x1c, 0x59, 0xd1, 0x83, 0x5b, 0x90, 0x60, 0x43, 0xaa,
+	0x25, 0xc4, 0xfb, 0xf8, 0x52, 0x3f, 0x82, 0x62, 0x1c, 0xf2, 0x91, 0xe5, 0xba, 0x4d, 0x6b, 0x55,
+	0xae, 0xf5, 0x5f, 0xd7, 0x21, 0x7d, 0x42, 0x28, 0xb5, 0xda, 0x04, 0x3d, 0x86, 0x9b, 0x1e, 0x39,
+	0x0f, 0xbb, 0xb3, 0x29, 0xc6, 0x72, 0xd8, 0xc2, 0x3e, 0xa8, 0xcc, 0xfd, 0xe4, 0xa8, 0xc4, 0xe7,
+	0xbe, 0xa1, 0xe0, 0x9c, 0x17, 0xdb, 0xa3, 0x53, 0x28, 0x70, 0xb0, 0x01, 0x1f, 0xb0, 0x66, 0x18,
+	0xce, 0x1b, 0x02, 0xed, 0xc3, 0xc5, 0x68, 0x93, 0x69, 0x6c, 0x28, 0x38, 0xef, 0xc5, 0x0f, 0xa6,
+	0x46, 0xd5, 0x95, 0x18, 0x4c, 0x01, 0x8d, 0x0b, 0xc2, 0x88, 0x8d, 0x2a, 0xf4, 0xf5, 0xcc, 0x50,
+	0x09, 0x8b, 0x56, 0xff, 0x17, 0x88, 0xfa, 0xd3, 0x27, 0xc6, 0xf4, 0x4c, 0x41, 0x0f, 0x00, 0x26,
+	0xd3, 0x59, 0x96, 0x6d, 0x79, 0x01, 0x4c, 0x54, 0x92, 0x86, 0x82, 0xd7, 0xa3, 0xf9, 0xcc, 0x67,
+	0x8b, 0x18, 0x10, 0x6b, 0xb3, 0x13, 0x77, 0xca, 0x98, 0x17, 0x94, 0xa1, 0x84, 0x63, 0x02, 0x1d,
+	0x42, 0xa6, 0x63, 0x51, 0x53, 0x98, 0xa5, 0x85, 0x59, 0x69, 0x81, 0x99, 0x1c, 0x26, 0x86, 0x82,
+	0xd3, 0x9d, 0x70, 0xc9, 0xe3, 0xca, 0x0d, 0xc5, 0x57, 0x4a, 0x97, 0xb7, 0x77, 0x2d, 0xb3, 0x34,
+	0xae, 0xf1, 0x49, 0xc0, 0xe3, 0x3a, 0x88, 0xed, 0x91, 0x01, 0xf9, 0x08, 0x8c, 0x67, 0xbd, 0xb6,
+	0xbe, 0x94, 0xc9, 0x58, 0x63, 0xe6, 0x4c, 0x0e, 0x26, 0x5b, 0xd4, 0x82, 0x2d, 0xfe, 0xa6, 0x28,
+	0x2c, 0x31, 0x5a, 0x41, 0x60, 0xde, 0x5b, 0xfc, 0xc4, 0x2b, 0x4d, 0xcf, 0x50, 0x70, 0xb1, 0x33,
+	0xe7, 0x1c, 0x1d, 0x81, 0xb8, 0x94, 0x9a, 0x4d, 0xde, 0xc9, 0xb4, 0xac, 0x40, 0xbe, 0xbb, 0xc4,
+	0xdb, 0xb0, 0xe5, 0x19, 0x0a, 0x86, 0x41, 0xb4, 0x43, 0xdf, 0x40, 0xde, 0x0e, 0x8b, 0x4d, 0xe6,
+	0x72, 0x6e, 0x29, 0x83, 0xf1, 0xc2, 0xe4, 0x0c, 0xda, 0xb1, 0x3d, 0x7a, 0x09, 0xef, 0x4d, 0x61,
+	0x99, 0x6c, 0x48, 0xcd, 0x5e, 0xd8, 0x97, 0xb4, 0xbc, 0xc0, 0xbd, 0x7f, 0x0d, 0xdc, 0x49, 0x33,
+	0x33, 0x14, 0xbc, 0x69, 0xcf, 0x95, 0xa0, 0x33, 0xb8, 0x7d, 0xe5, 0x2e, 0xed, 0xa6, 0xb8, 0xe3,
+	0xa3, 0xeb, 0xdd, 0x61, 0x28, 0xb8, 0x30, 0x03, 0x8e, 0x6c, 0xd8, 0x9c, 0x46, 0x7d, 0x21, 0x9b,
+	0x8f, 0x56, 0x58, 0x1a, 0xb8, 0x79, 0xfd, 0x8a, 0x07, 0xce, 0x9e, 0x73, 0x5e, 0x4b, 0x41, 0x82,
+	0xf6, 0xbb, 0xb5, 0xfa, 0xeb, 0x8b, 0x92, 0xfa, 0xe6, 0xa2, 0xa4, 0xfe, 0x79, 0x51, 0x52, 0x7f,
+	0xbe, 0x2c, 0x29, 0x6f, 0x2e, 0x4b, 0xca, 0x1f, 0x97, 0x25, 0xe5, 0xf9, 0x67, 0x6d, 0x87, 0x75,
+	0xfa, 0x4d, 0x7e, 0x57, 0x35, 0xf6, 0xdb, 0x24, 0x17, 0x56, 0xe0, 0x54, 0xe7, 0xfe, 0x4c, 0x35,
+	0xd7, 0xc4, 0x9f, 0xcb, 0xc1, 0x3f, 0x03, 0x00, 0x89, 0xb4, 0x14, 0x51, 0x6c, 0x0d, 0x00, 0x00,
 }
 
 func (m *NewRoundStep) Marshal() (dAtA []byte, err error) {
@@ -1393,7 +1771,7 @@ func (m *HasProposalBlockPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Message) Marshal() (dAtA []byte, err error) {
+func (m *VotesBatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1403,59 +1781,65 @@ func (m *Message) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+func (m *VotesBatch) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *VotesBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Sum != nil {
-		{
-			size := m.Sum.Size()
-			i -= size
-			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+	if len(m.Votes) > 0 {
+		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Message_NewRoundStep) MarshalTo(dAtA []byte) (int, error) {
+func (m *CompactBlock) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *Message_NewRoundStep) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.NewRoundStep != nil {
-		{
-			size, err := m.NewRoundStep.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return dAtA[:n], nil
 }
-func (m *Message_NewValidBlock) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *CompactBlock) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message_NewValidBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CompactBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.NewValidBlock != nil {
+	_ = i
+	var l int
+	_ = l
+	if len(m.TxKeys) > 0 {
+		for iNdEx := len(m.TxKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TxKeys[iNdEx])
+			copy(dAtA[i:], m.TxKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.TxKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Block != nil {
 		{
-			size, err := m.NewValidBlock.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -1463,11 +1847,214 @@ func (m *Message_NewValidBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintTypes(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x1a
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Message_Proposal) MarshalTo(dAtA []byte) (int, error) {
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompactBlockTxsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactBlockTxsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactBlockTxsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Missing.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompactBlockTxs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactBlockTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactBlockTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompactBlockFallback) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactBlockFallback) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactBlockFallback) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sum != nil {
+		{
+			size := m.Sum.Size()
+			i -= size
+			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message_NewRoundStep) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_NewRoundStep) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.NewRoundStep != nil {
+		{
+			size, err := m.NewRoundStep.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_NewValidBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_NewValidBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.NewValidBlock != nil {
+		{
+			size, err := m.NewValidBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_Proposal) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
@@ -1635,52 +2222,157 @@ func (m *Message_HasProposalBlockPart) MarshalToSizedBuffer(dAtA []byte) (int, e
 	}
 	return len(dAtA) - i, nil
 }
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (m *Message_VotesBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (m *NewRoundStep) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Height != 0 {
-		n += 1 + sovTypes(uint64(m.Height))
-	}
-	if m.Round != 0 {
-		n += 1 + sovTypes(uint64(m.Round))
-	}
-	if m.Step != 0 {
-		n += 1 + sovTypes(uint64(m.Step))
-	}
-	if m.SecondsSinceStartTime != 0 {
-		n += 1 + sovTypes(uint64(m.SecondsSinceStartTime))
-	}
-	if m.LastCommitRound != 0 {
-		n += 1 + sovTypes(uint64(m.LastCommitRound))
+
+func (m *Message_VotesBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.VotesBatch != nil {
+		{
+			size, err := m.VotesBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
 	}
-	return n
+	return len(dAtA) - i, nil
+}
+func (m *Message_CompactBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NewValidBlock) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Height != 0 {
-		n += 1 + sovTypes(uint64(m.Height))
-	}
-	if m.Round != 0 {
-		n += 1 + sovTypes(uint64(m.Round))
+func (m *Message_CompactBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CompactBlock != nil {
+		{
+			size, err := m.CompactBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_CompactBlockTxsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CompactBlockTxsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CompactBlockTxsRequest != nil {
+		{
+			size, err := m.CompactBlockTxsRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_CompactBlockTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CompactBlockTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CompactBlockTxs != nil {
+		{
+			size, err := m.CompactBlockTxs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_CompactBlockFallback) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CompactBlockFallback) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CompactBlockFallback != nil {
+		{
+			size, err := m.CompactBlockFallback.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *NewRoundStep) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovTypes(uint64(m.Step))
+	}
+	if m.SecondsSinceStartTime != 0 {
+		n += 1 + sovTypes(uint64(m.SecondsSinceStartTime))
+	}
+	if m.LastCommitRound != 0 {
+		n += 1 + sovTypes(uint64(m.LastCommitRound))
+	}
+	return n
+}
+
+func (m *NewValidBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
 	}
 	l = m.BlockPartSetHeader.Size()
 	n += 1 + l + sovTypes(uint64(l))
@@ -1833,6 +2525,99 @@ func (m *HasProposalBlockPart) Size() (n int) {
 	return n
 }
 
+func (m *VotesBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CompactBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.TxKeys) > 0 {
+		for _, b := range m.TxKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CompactBlockTxsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.Missing.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
+func (m *CompactBlockTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CompactBlockFallback) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	return n
+}
+
 func (m *Message) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1963,16 +2748,481 @@ func (m *Message_HasProposalBlockPart) Size() (n int) {
 		l = m.HasProposalBlockPart.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
-	return n
-}
+	return n
+}
+func (m *Message_VotesBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.VotesBatch != nil {
+		l = m.VotesBatch.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_CompactBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactBlock != nil {
+		l = m.CompactBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_CompactBlockTxsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactBlockTxsRequest != nil {
+		l = m.CompactBlockTxsRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_CompactBlockTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactBlockTxs != nil {
+		l = m.CompactBlockTxs.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_CompactBlockFallback) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactBlockFallback != nil {
+		l = m.CompactBlockFallback.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewRoundStep: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewRoundStep: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecondsSinceStartTime", wireType)
+			}
+			m.SecondsSinceStartTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SecondsSinceStartTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommitRound", wireType)
+			}
+			m.LastCommitRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastCommitRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewValidBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewValidBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockPartSetHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BlockPartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockParts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockParts == nil {
+				m.BlockParts = &v11.BitArray{}
+			}
+			if err := m.BlockParts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsCommit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsCommit = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Proposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Proposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Proposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
+func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1995,10 +3245,10 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NewRoundStep: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProposalPOL: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NewRoundStep: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProposalPOL: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2022,9 +3272,9 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPolRound", wireType)
 			}
-			m.Round = 0
+			m.ProposalPolRound = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2034,16 +3284,16 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Round |= int32(b&0x7F) << shift
+				m.ProposalPolRound |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPol", wireType)
 			}
-			m.Step = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2053,49 +3303,25 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Step |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SecondsSinceStartTime", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			m.SecondsSinceStartTime = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SecondsSinceStartTime |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastCommitRound", wireType)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.LastCommitRound = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.LastCommitRound |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.ProposalPol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2117,7 +3343,7 @@ func (m *NewRoundStep) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
+func (m *BlockPart) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2140,10 +3366,10 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NewValidBlock: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockPart: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NewValidBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2186,7 +3412,7 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockPartSetHeader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Part", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2213,13 +3439,63 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.BlockPartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Part.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Vote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Vote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Vote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockParts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2246,18 +3522,125 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.BlockParts == nil {
-				m.BlockParts = &v11.BitArray{}
+			if m.Vote == nil {
+				m.Vote = &v1.Vote{}
 			}
-			if err := m.BlockParts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 5:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HasVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HasVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HasVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= v1.SignedMsgType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsCommit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var v int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2267,12 +3650,11 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Index |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsCommit = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2294,7 +3676,7 @@ func (m *NewValidBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Proposal) Unmarshal(dAtA []byte) error {
+func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2317,15 +3699,72 @@ func (m *Proposal) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Proposal: wiretype end group for non-group")
+			return fmt.Errorf("proto: VoteSetMaj23: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Proposal: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VoteSetMaj23: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= v1.SignedMsgType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2352,7 +3791,7 @@ func (m *Proposal) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2377,7 +3816,7 @@ func (m *Proposal) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
+func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2400,10 +3839,10 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProposalPOL: wiretype end group for non-group")
+			return fmt.Errorf("proto: VoteSetBits: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProposalPOL: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VoteSetBits: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2427,9 +3866,9 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPolRound", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			m.ProposalPolRound = 0
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2439,14 +3878,33 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ProposalPolRound |= int32(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= v1.SignedMsgType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPol", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2473,7 +3931,40 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ProposalPol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Votes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2498,7 +3989,7 @@ func (m *ProposalPOL) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockPart) Unmarshal(dAtA []byte) error {
+func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2521,10 +4012,10 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockPart: wiretype end group for non-group")
+			return fmt.Errorf("proto: HasProposalBlockPart: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HasProposalBlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2566,10 +4057,10 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Part", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2579,25 +4070,11 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Index |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Part.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2619,7 +4096,7 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Vote) Unmarshal(dAtA []byte) error {
+func (m *VotesBatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2642,15 +4119,15 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Vote: wiretype end group for non-group")
+			return fmt.Errorf("proto: VotesBatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Vote: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VotesBatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2677,10 +4154,8 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Vote == nil {
-				m.Vote = &v1.Vote{}
-			}
-			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Votes = append(m.Votes, &v1.Vote{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2705,7 +4180,7 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HasVote) Unmarshal(dAtA []byte) error {
+func (m *CompactBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2728,10 +4203,10 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HasVote: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompactBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HasVote: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompactBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2773,10 +4248,10 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
 			}
-			m.Type = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2786,16 +4261,33 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= v1.SignedMsgType(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &v1.Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxKeys", wireType)
 			}
-			m.Index = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2805,11 +4297,24 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= int32(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxKeys = append(m.TxKeys, make([]byte, postIndex-iNdEx))
+			copy(m.TxKeys[len(m.TxKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2831,7 +4336,7 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
+func (m *CompactBlockTxsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2854,36 +4359,17 @@ func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteSetMaj23: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompactBlockTxsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteSetMaj23: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompactBlockTxsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			m.Height = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
-			}
-			m.Round = 0
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2893,16 +4379,16 @@ func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Round |= int32(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			m.Type = 0
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2912,14 +4398,14 @@ func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= v1.SignedMsgType(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Missing", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2946,7 +4432,7 @@ func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Missing.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2971,7 +4457,7 @@ func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
+func (m *CompactBlockTxs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2994,10 +4480,10 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteSetBits: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompactBlockTxs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteSetBits: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompactBlockTxs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3039,62 +4525,10 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= v1.SignedMsgType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -3104,24 +4538,23 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Votes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3144,7 +4577,7 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
+func (m *CompactBlockFallback) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3167,10 +4600,10 @@ func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HasProposalBlockPart: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompactBlockFallback: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HasProposalBlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompactBlockFallback: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3211,25 +4644,6 @@ func (m *HasProposalBlockPart) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
-			}
-			m.Index = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Index |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -3630,6 +5044,181 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_HasProposalBlockPart{v}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VotesBatch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &VotesBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_VotesBatch{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CompactBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CompactBlock{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactBlockTxsRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CompactBlockTxsRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CompactBlockTxsRequest{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactBlockTxs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CompactBlockTxs{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CompactBlockTxs{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactBlockFallback", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CompactBlockFallback{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CompactBlockFallback{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
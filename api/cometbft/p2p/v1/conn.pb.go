@@ -160,6 +160,60 @@ func (m *PacketMsg) GetData() []byte {
 	return nil
 }
 
+// PacketFlowControl asks the receiver to pause (busy) or resume sending
+// PacketMsgs on the specified channel ID.
+type PacketFlowControl struct {
+	ChannelID int32 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Busy      bool  `protobuf:"varint,2,opt,name=busy,proto3" json:"busy,omitempty"`
+}
+
+func (m *PacketFlowControl) Reset()         { *m = PacketFlowControl{} }
+func (m *PacketFlowControl) String() string { return proto.CompactTextString(m) }
+func (*PacketFlowControl) ProtoMessage()    {}
+func (*PacketFlowControl) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ad66b5863681764, []int{3}
+}
+func (m *PacketFlowControl) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PacketFlowControl) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PacketFlowControl.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PacketFlowControl) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PacketFlowControl.Merge(m, src)
+}
+func (m *PacketFlowControl) XXX_Size() int {
+	return m.Size()
+}
+func (m *PacketFlowControl) XXX_DiscardUnknown() {
+	xxx_messageInfo_PacketFlowControl.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PacketFlowControl proto.InternalMessageInfo
+
+func (m *PacketFlowControl) GetChannelID() int32 {
+	if m != nil {
+		return m.ChannelID
+	}
+	return 0
+}
+
+func (m *PacketFlowControl) GetBusy() bool {
+	if m != nil {
+		return m.Busy
+	}
+	return false
+}
+
 // Packet is an abstract p2p message.
 type Packet struct {
 	// Sum of all possible messages.
@@ -169,6 +223,7 @@ type Packet struct {
 	//	*Packet_PacketPing
 	//	*Packet_PacketPong
 	//	*Packet_PacketMsg
+	//	*Packet_PacketFlowControl
 	Sum isPacket_Sum `protobuf_oneof:"sum"`
 }
 
@@ -176,7 +231,7 @@ func (m *Packet) Reset()         { *m = Packet{} }
 func (m *Packet) String() string { return proto.CompactTextString(m) }
 func (*Packet) ProtoMessage()    {}
 func (*Packet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ad66b5863681764, []int{3}
+	return fileDescriptor_3ad66b5863681764, []int{4}
 }
 func (m *Packet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -220,10 +275,14 @@ type Packet_PacketPong struct {
 type Packet_PacketMsg struct {
 	PacketMsg *PacketMsg `protobuf:"bytes,3,opt,name=packet_msg,json=packetMsg,proto3,oneof" json:"packet_msg,omitempty"`
 }
+type Packet_PacketFlowControl struct {
+	PacketFlowControl *PacketFlowControl `protobuf:"bytes,4,opt,name=packet_flow_control,json=packetFlowControl,proto3,oneof" json:"packet_flow_control,omitempty"`
+}
 
-func (*Packet_PacketPing) isPacket_Sum() {}
-func (*Packet_PacketPong) isPacket_Sum() {}
-func (*Packet_PacketMsg) isPacket_Sum()  {}
+func (*Packet_PacketPing) isPacket_Sum()        {}
+func (*Packet_PacketPong) isPacket_Sum()        {}
+func (*Packet_PacketMsg) isPacket_Sum()         {}
+func (*Packet_PacketFlowControl) isPacket_Sum() {}
 
 func (m *Packet) GetSum() isPacket_Sum {
 	if m != nil {
@@ -253,12 +312,20 @@ func (m *Packet) GetPacketMsg() *PacketMsg {
 	return nil
 }
 
+func (m *Packet) GetPacketFlowControl() *PacketFlowControl {
+	if x, ok := m.GetSum().(*Packet_PacketFlowControl); ok {
+		return x.PacketFlowControl
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Packet) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*Packet_PacketPing)(nil),
 		(*Packet_PacketPong)(nil),
 		(*Packet_PacketMsg)(nil),
+		(*Packet_PacketFlowControl)(nil),
 	}
 }
 
@@ -273,7 +340,7 @@ func (m *AuthSigMessage) Reset()         { *m = AuthSigMessage{} }
 func (m *AuthSigMessage) String() string { return proto.CompactTextString(m) }
 func (*AuthSigMessage) ProtoMessage()    {}
 func (*AuthSigMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3ad66b5863681764, []int{4}
+	return fileDescriptor_3ad66b5863681764, []int{5}
 }
 func (m *AuthSigMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -320,6 +387,7 @@ func init() {
 	proto.RegisterType((*PacketPing)(nil), "cometbft.p2p.v1.PacketPing")
 	proto.RegisterType((*PacketPong)(nil), "cometbft.p2p.v1.PacketPong")
 	proto.RegisterType((*PacketMsg)(nil), "cometbft.p2p.v1.PacketMsg")
+	proto.RegisterType((*PacketFlowControl)(nil), "cometbft.p2p.v1.PacketFlowControl")
 	proto.RegisterType((*Packet)(nil), "cometbft.p2p.v1.Packet")
 	proto.RegisterType((*AuthSigMessage)(nil), "cometbft.p2p.v1.AuthSigMessage")
 }
@@ -327,33 +395,36 @@ func init() {
 func init() { proto.RegisterFile("cometbft/p2p/v1/conn.proto", fileDescriptor_3ad66b5863681764) }
 
 var fileDescriptor_3ad66b5863681764 = []byte{
-	// 402 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xf5, 0xe2, 0x36, 0xc5, 0x93, 0xf0, 0xa1, 0x15, 0x87, 0x60, 0x54, 0x27, 0xf2, 0x29, 0x07,
-	0x64, 0x53, 0x73, 0x04, 0x21, 0x61, 0x3e, 0x44, 0xa9, 0x22, 0x2a, 0x73, 0xe3, 0x62, 0x6c, 0x67,
-	0xbb, 0x5e, 0xa5, 0xd9, 0x5d, 0x75, 0xd7, 0x95, 0xfc, 0x2f, 0xf8, 0x59, 0x3d, 0x70, 0xe8, 0x91,
-	0x53, 0x84, 0x9c, 0x3f, 0x82, 0xbc, 0x4e, 0x9a, 0x10, 0x09, 0x6e, 0xef, 0xcd, 0xcc, 0x7b, 0x33,
-	0xa3, 0x19, 0x70, 0x0b, 0xb1, 0x20, 0x3a, 0xbf, 0xd0, 0xa1, 0x8c, 0x64, 0x78, 0x7d, 0x12, 0x16,
-	0x82, 0xf3, 0x40, 0x5e, 0x09, 0x2d, 0xf0, 0xa3, 0x4d, 0x2e, 0x90, 0x91, 0x0c, 0xae, 0x4f, 0xdc,
-	0x27, 0x54, 0x50, 0x61, 0x72, 0x61, 0x8b, 0xba, 0x32, 0xf7, 0xf8, 0xce, 0xa2, 0xb8, 0xaa, 0xa5,
-	0x16, 0xad, 0xcb, 0x9c, 0xd4, 0xaa, 0x4b, 0xfb, 0x03, 0x80, 0xf3, 0xac, 0x98, 0x13, 0x7d, 0xce,
-	0x38, 0xdd, 0x61, 0x82, 0x53, 0xbf, 0x04, 0xa7, 0x63, 0x53, 0x45, 0xf1, 0x73, 0x80, 0xa2, 0xcc,
-	0x38, 0x27, 0x97, 0x29, 0x9b, 0x0d, 0xd1, 0x18, 0x4d, 0x0e, 0xe3, 0x07, 0xcd, 0x72, 0xe4, 0xbc,
-	0xeb, 0xa2, 0xa7, 0xef, 0x13, 0x67, 0x5d, 0x70, 0x3a, 0xc3, 0x4f, 0xc1, 0x26, 0xe2, 0x62, 0x78,
-	0x6f, 0x8c, 0x26, 0xf7, 0xe3, 0xa3, 0x66, 0x39, 0xb2, 0x3f, 0x7c, 0xf9, 0x98, 0xb4, 0x31, 0x8c,
-	0xe1, 0x60, 0x96, 0xe9, 0x6c, 0x68, 0x8f, 0xd1, 0x64, 0x90, 0x18, 0xec, 0xff, 0x44, 0xd0, 0xeb,
-	0x5a, 0xe1, 0x37, 0xd0, 0x97, 0x06, 0xa5, 0x92, 0x71, 0x6a, 0x1a, 0xf5, 0xa3, 0x67, 0xc1, 0xde,
-	0xb2, 0xc1, 0x76, 0xe8, 0x4f, 0x56, 0x02, 0xf2, 0x8e, 0xed, 0xea, 0x05, 0xa7, 0x66, 0x82, 0xff,
-	0xe8, 0xc5, 0x5f, 0x7a, 0xc1, 0x29, 0x7e, 0x05, 0x6b, 0x96, 0x2e, 0x14, 0x35, 0x43, 0xf6, 0x23,
-	0xf7, 0x1f, 0xf2, 0xa9, 0x6a, 0xd5, 0x8e, 0xdc, 0x90, 0xf8, 0x10, 0x6c, 0x55, 0x2d, 0xfc, 0xef,
-	0xf0, 0xf0, 0x6d, 0xa5, 0xcb, 0xaf, 0x8c, 0x4e, 0x89, 0x52, 0x19, 0x25, 0xf8, 0x35, 0x1c, 0xc9,
-	0x2a, 0x4f, 0xe7, 0xa4, 0x5e, 0x6f, 0x74, 0xbc, 0xb5, 0xec, 0xee, 0x62, 0x5c, 0xab, 0xfc, 0x92,
-	0x15, 0x67, 0xa4, 0x8e, 0x0f, 0x6e, 0x96, 0x23, 0x2b, 0xe9, 0xc9, 0x2a, 0x3f, 0x23, 0x35, 0x7e,
-	0x0c, 0xb6, 0x62, 0xdd, 0x2e, 0x83, 0xa4, 0x85, 0xf1, 0xe7, 0x9b, 0xc6, 0x43, 0xb7, 0x8d, 0x87,
-	0x7e, 0x37, 0x1e, 0xfa, 0xb1, 0xf2, 0xac, 0xdb, 0x95, 0x67, 0xfd, 0x5a, 0x79, 0xd6, 0xb7, 0x17,
-	0x94, 0xe9, 0xb2, 0xca, 0x5b, 0xfb, 0x70, 0x7b, 0xfa, 0x0d, 0xc8, 0x24, 0x0b, 0xf7, 0x7e, 0x2a,
-	0xef, 0x99, 0x4f, 0x78, 0xf9, 0x27, 0x00, 0x00, 0xff, 0xff, 0xa9, 0x7c, 0x1d, 0x4e, 0x6d, 0x02,
-	0x00, 0x00,
+	// 455 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
+	0x18, 0xb4, 0xe3, 0x34, 0x25, 0x5f, 0xc2, 0x4f, 0x17, 0x0e, 0x26, 0xa8, 0x4e, 0xe4, 0x53, 0x0e,
+	0xc8, 0xa6, 0xe1, 0x08, 0x42, 0xc2, 0x85, 0xaa, 0xa5, 0x8a, 0xa8, 0x0c, 0x5c, 0xb8, 0x18, 0xdb,
+	0xd9, 0x6c, 0xac, 0x38, 0xbb, 0xab, 0x78, 0xdd, 0xca, 0x6f, 0xc1, 0x33, 0xf0, 0x34, 0x3d, 0xf6,
+	0xc8, 0x29, 0x42, 0xce, 0x8b, 0x20, 0xef, 0xa6, 0x49, 0x88, 0x54, 0xa4, 0xde, 0x66, 0xbe, 0x6f,
+	0x67, 0x46, 0x1e, 0x7d, 0x86, 0x4e, 0xcc, 0x66, 0x58, 0x44, 0x63, 0xe1, 0xf2, 0x01, 0x77, 0x2f,
+	0x8f, 0xdc, 0x98, 0x51, 0xea, 0xf0, 0x39, 0x13, 0x0c, 0x3d, 0xbe, 0xdd, 0x39, 0x7c, 0xc0, 0x9d,
+	0xcb, 0xa3, 0xce, 0x33, 0xc2, 0x08, 0x93, 0x3b, 0xb7, 0x42, 0xea, 0x59, 0xe7, 0x70, 0x6d, 0x11,
+	0xcf, 0x0b, 0x2e, 0x58, 0xe5, 0x32, 0xc5, 0x45, 0xa6, 0xd6, 0x76, 0x1b, 0xe0, 0x22, 0x8c, 0xa7,
+	0x58, 0x5c, 0x24, 0x94, 0x6c, 0x31, 0x46, 0x89, 0x3d, 0x81, 0xa6, 0x62, 0xc3, 0x8c, 0xa0, 0x97,
+	0x00, 0xf1, 0x24, 0xa4, 0x14, 0xa7, 0x41, 0x32, 0x32, 0xf5, 0x9e, 0xde, 0xdf, 0xf3, 0x1e, 0x96,
+	0x8b, 0x6e, 0xf3, 0x58, 0x4d, 0xcf, 0x3e, 0xf8, 0xcd, 0xd5, 0x83, 0xb3, 0x11, 0x7a, 0x0e, 0x06,
+	0x66, 0x63, 0xb3, 0xd6, 0xd3, 0xfb, 0x0f, 0xbc, 0xfd, 0x72, 0xd1, 0x35, 0x3e, 0x7e, 0x3e, 0xf1,
+	0xab, 0x19, 0x42, 0x50, 0x1f, 0x85, 0x22, 0x34, 0x8d, 0x9e, 0xde, 0x6f, 0xfb, 0x12, 0xdb, 0xdf,
+	0xe0, 0x40, 0x25, 0x9d, 0xa4, 0xec, 0xea, 0x98, 0x51, 0x31, 0x67, 0xe9, 0x3d, 0x13, 0x11, 0xd4,
+	0xa3, 0x3c, 0x2b, 0x54, 0xa4, 0x2f, 0xb1, 0xfd, 0xab, 0x06, 0x0d, 0xe5, 0x8b, 0xde, 0x41, 0x8b,
+	0x4b, 0x14, 0xf0, 0x84, 0x12, 0xe9, 0xd6, 0x1a, 0xbc, 0x70, 0x76, 0x3a, 0x74, 0x36, 0x5d, 0x9c,
+	0x6a, 0x3e, 0xf0, 0x35, 0xdb, 0xd6, 0x33, 0x4a, 0xcc, 0xda, 0xff, 0xf5, 0xec, 0x1f, 0x3d, 0xa3,
+	0x04, 0xbd, 0x81, 0x15, 0x0b, 0x66, 0x19, 0x91, 0xdf, 0xde, 0x1a, 0x74, 0xee, 0x90, 0x0f, 0xb3,
+	0x4a, 0xdd, 0xe4, 0xeb, 0xee, 0xbf, 0xc2, 0xd3, 0x95, 0x78, 0x9c, 0xb2, 0xab, 0x20, 0x56, 0x05,
+	0x99, 0x75, 0xe9, 0x62, 0xdf, 0xe1, 0xb2, 0x55, 0xe5, 0xa9, 0xe6, 0x1f, 0xf0, 0xdd, 0xa1, 0xb7,
+	0x07, 0x46, 0x96, 0xcf, 0xec, 0x1f, 0xf0, 0xe8, 0x7d, 0x2e, 0x26, 0x5f, 0x12, 0x32, 0xc4, 0x59,
+	0x16, 0x12, 0x8c, 0xde, 0xc2, 0x3e, 0xcf, 0xa3, 0x60, 0x8a, 0x8b, 0x55, 0x4f, 0x87, 0x9b, 0x08,
+	0x75, 0x44, 0x32, 0x25, 0x8f, 0xd2, 0x24, 0x3e, 0xc7, 0x85, 0x57, 0xbf, 0x5e, 0x74, 0x35, 0xbf,
+	0xc1, 0xf3, 0xe8, 0x1c, 0x17, 0xe8, 0x09, 0x18, 0x59, 0xa2, 0x1a, 0x6a, 0xfb, 0x15, 0xf4, 0x3e,
+	0x5d, 0x97, 0x96, 0x7e, 0x53, 0x5a, 0xfa, 0x9f, 0xd2, 0xd2, 0x7f, 0x2e, 0x2d, 0xed, 0x66, 0x69,
+	0x69, 0xbf, 0x97, 0x96, 0xf6, 0xfd, 0x15, 0x49, 0xc4, 0x24, 0x8f, 0x2a, 0x7b, 0x77, 0x73, 0xa7,
+	0xb7, 0x20, 0xe4, 0x89, 0xbb, 0xf3, 0x03, 0x44, 0x0d, 0x79, 0xb6, 0xaf, 0xff, 0x0e, 0x00, 0x88,
+	0x23, 0x11, 0x10, 0x1a, 0x03, 0x00, 0x00,
 }
 
 func (m *PacketPing) Marshal() (dAtA []byte, err error) {
@@ -447,6 +518,44 @@ func (m *PacketMsg) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *PacketFlowControl) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PacketFlowControl) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PacketFlowControl) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Busy {
+		i--
+		if m.Busy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ChannelID != 0 {
+		i = encodeVarintConn(dAtA, i, uint64(m.ChannelID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Packet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -542,6 +651,27 @@ func (m *Packet_PacketMsg) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Packet_PacketFlowControl) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Packet_PacketFlowControl) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PacketFlowControl != nil {
+		{
+			size, err := m.PacketFlowControl.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConn(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
 func (m *AuthSigMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -630,6 +760,21 @@ func (m *PacketMsg) Size() (n int) {
 	return n
 }
 
+func (m *PacketFlowControl) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChannelID != 0 {
+		n += 1 + sovConn(uint64(m.ChannelID))
+	}
+	if m.Busy {
+		n += 2
+	}
+	return n
+}
+
 func (m *Packet) Size() (n int) {
 	if m == nil {
 		return 0
@@ -678,6 +823,18 @@ func (m *Packet_PacketMsg) Size() (n int) {
 	}
 	return n
 }
+func (m *Packet_PacketFlowControl) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PacketFlowControl != nil {
+		l = m.PacketFlowControl.Size()
+		n += 1 + l + sovConn(uint64(l))
+	}
+	return n
+}
 func (m *AuthSigMessage) Size() (n int) {
 	if m == nil {
 		return 0
@@ -922,6 +1079,95 @@ func (m *PacketMsg) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PacketFlowControl) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConn
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PacketFlowControl: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PacketFlowControl: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			m.ChannelID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConn
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChannelID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Busy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConn
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Busy = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConn(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConn
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Packet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1056,6 +1302,41 @@ func (m *Packet) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Packet_PacketMsg{v}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PacketFlowControl", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConn
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConn
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConn
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PacketFlowControl{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Packet_PacketFlowControl{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConn(dAtA[iNdEx:])
@@ -7,9 +7,12 @@ import (
 	fmt "fmt"
 	_ "github.com/cosmos/gogoproto/gogoproto"
 	proto "github.com/cosmos/gogoproto/proto"
+	_ "github.com/cosmos/gogoproto/types"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -156,6 +159,9 @@ type DefaultNodeInfo struct {
 	Channels        []byte               `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
 	Moniker         string               `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
 	Other           DefaultNodeInfoOther `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	// Time of the node when it sent its DefaultNodeInfo, letting the peers
+	// estimate the skew of their clocks.
+	Time time.Time `protobuf:"bytes,9,opt,name=time,proto3,stdtime" json:"time"`
 }
 
 func (m *DefaultNodeInfo) Reset()         { *m = DefaultNodeInfo{} }
@@ -247,6 +253,13 @@ func (m *DefaultNodeInfo) GetOther() DefaultNodeInfoOther {
 	return DefaultNodeInfoOther{}
 }
 
+func (m *DefaultNodeInfo) GetTime() time.Time {
+	if m != nil {
+		return m.Time
+	}
+	return time.Time{}
+}
+
 // DefaultNodeInfoOther is the misc. application specific data.
 type DefaultNodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
@@ -310,38 +323,41 @@ func init() {
 func init() { proto.RegisterFile("cometbft/p2p/v1/types.proto", fileDescriptor_b87302e2cbe06eca) }
 
 var fileDescriptor_b87302e2cbe06eca = []byte{
-	// 485 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x4d, 0x8f, 0xda, 0x30,
-	0x10, 0x25, 0x21, 0x7c, 0xec, 0x50, 0xca, 0xd6, 0x42, 0x55, 0x76, 0x2b, 0x25, 0x08, 0xa9, 0x12,
-	0x27, 0xd2, 0xa5, 0xa7, 0x1e, 0x97, 0x72, 0xa1, 0x87, 0x6d, 0x6a, 0x55, 0x3d, 0xf4, 0x82, 0x20,
-	0x36, 0x60, 0xc1, 0xc6, 0x96, 0xe3, 0xa5, 0xf4, 0x5f, 0xf4, 0x67, 0xed, 0x71, 0x8f, 0x3d, 0x45,
-	0x55, 0x38, 0xf7, 0x3f, 0x54, 0x76, 0x02, 0x42, 0xe9, 0xde, 0xe6, 0xcd, 0xf3, 0xcc, 0xbc, 0x79,
-	0x1e, 0x78, 0x13, 0xf1, 0x7b, 0xaa, 0x16, 0x4b, 0x15, 0x88, 0x91, 0x08, 0x76, 0x37, 0x81, 0xfa,
-	0x29, 0x68, 0x32, 0x14, 0x92, 0x2b, 0x8e, 0x3a, 0x47, 0x72, 0x28, 0x46, 0x62, 0xb8, 0xbb, 0xb9,
-	0xee, 0xae, 0xf8, 0x8a, 0x1b, 0x2e, 0xd0, 0x51, 0xfe, 0xac, 0x1f, 0x02, 0xdc, 0x51, 0x75, 0x4b,
-	0x88, 0xa4, 0x49, 0x82, 0x5e, 0x83, 0xcd, 0x88, 0x6b, 0xf5, 0xac, 0xc1, 0xc5, 0xb8, 0x9e, 0xa5,
-	0xbe, 0x3d, 0x9d, 0x60, 0x9b, 0x11, 0x93, 0x17, 0xae, 0x7d, 0x96, 0x0f, 0xb1, 0xcd, 0x04, 0x42,
-	0xe0, 0x08, 0x2e, 0x95, 0x5b, 0xed, 0x59, 0x83, 0x36, 0x36, 0x71, 0xff, 0x2b, 0x74, 0x42, 0xdd,
-	0x3a, 0xe2, 0xdb, 0x6f, 0x54, 0x26, 0x8c, 0xc7, 0xe8, 0x0a, 0xaa, 0x62, 0x24, 0x4c, 0x5f, 0x67,
-	0xdc, 0xc8, 0x52, 0xbf, 0x1a, 0x8e, 0x42, 0xac, 0x73, 0xa8, 0x0b, 0xb5, 0xc5, 0x96, 0x47, 0x1b,
-	0xd3, 0xdc, 0xc1, 0x39, 0x40, 0x97, 0x50, 0x9d, 0x0b, 0x61, 0xda, 0x3a, 0x58, 0x87, 0xfd, 0xbf,
-	0x36, 0x74, 0x26, 0x74, 0x39, 0x7f, 0xd8, 0xaa, 0x3b, 0x4e, 0xe8, 0x34, 0x5e, 0x72, 0xf4, 0x05,
-	0x2e, 0x45, 0x31, 0x69, 0xb6, 0xcb, 0x47, 0x99, 0x19, 0xad, 0x51, 0x6f, 0x58, 0xda, 0x7e, 0x58,
-	0x92, 0x34, 0x76, 0x1e, 0x53, 0xbf, 0x82, 0x3b, 0xa2, 0xa4, 0xf4, 0x03, 0x74, 0x48, 0x3e, 0x65,
-	0x16, 0x73, 0x42, 0x67, 0x8c, 0x14, 0x5b, 0xbf, 0xca, 0x52, 0xbf, 0x7d, 0x2e, 0x60, 0x82, 0xdb,
-	0xe4, 0x0c, 0x12, 0xe4, 0x43, 0x6b, 0xcb, 0x12, 0x45, 0xe3, 0xd9, 0x9c, 0x10, 0x69, 0xb4, 0x5f,
-	0x60, 0xc8, 0x53, 0xda, 0x5f, 0xe4, 0x42, 0x23, 0xa6, 0xea, 0x07, 0x97, 0x1b, 0xd7, 0x31, 0xe4,
-	0x11, 0x6a, 0xe6, 0xa8, 0xbf, 0x96, 0x33, 0x05, 0x44, 0xd7, 0xd0, 0x8c, 0xd6, 0xf3, 0x38, 0xa6,
-	0xdb, 0xc4, 0xad, 0xf7, 0xac, 0xc1, 0x0b, 0x7c, 0xc2, 0xba, 0xea, 0x9e, 0xc7, 0x6c, 0x43, 0xa5,
-	0xdb, 0xc8, 0xab, 0x0a, 0x88, 0x6e, 0xa1, 0xc6, 0xd5, 0x9a, 0x4a, 0xb7, 0x69, 0xdc, 0x78, 0xfb,
-	0x9f, 0x1b, 0x25, 0x27, 0x3f, 0xeb, 0xc7, 0x85, 0x25, 0x79, 0x65, 0x7f, 0x01, 0xdd, 0xe7, 0x1e,
-	0xa1, 0x2b, 0x68, 0xaa, 0xfd, 0x8c, 0xc5, 0x84, 0xee, 0xf3, 0x3b, 0xc1, 0x0d, 0xb5, 0x9f, 0x6a,
-	0x88, 0x02, 0x68, 0x49, 0x11, 0x99, 0xed, 0x69, 0x92, 0x14, 0xbe, 0xbd, 0xcc, 0x52, 0x1f, 0x70,
-	0xf8, 0xb1, 0xb8, 0x30, 0x0c, 0x52, 0x44, 0x45, 0x3c, 0xfe, 0xf4, 0x98, 0x79, 0xd6, 0x53, 0xe6,
-	0x59, 0x7f, 0x32, 0xcf, 0xfa, 0x75, 0xf0, 0x2a, 0x4f, 0x07, 0xaf, 0xf2, 0xfb, 0xe0, 0x55, 0xbe,
-	0xbf, 0x5b, 0x31, 0xb5, 0x7e, 0x58, 0x68, 0xdd, 0xc1, 0xe9, 0xc8, 0x4f, 0xc1, 0x5c, 0xb0, 0xa0,
-	0x74, 0xfa, 0x8b, 0xba, 0xf9, 0xc9, 0xf7, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x50, 0x06, 0xfb,
-	0xcd, 0x14, 0x03, 0x00, 0x00,
+	// 532 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xbd, 0x8e, 0xda, 0x40,
+	0x10, 0xc6, 0x60, 0xfe, 0x86, 0x10, 0x2e, 0x2b, 0x14, 0xf9, 0x88, 0x64, 0x23, 0xa4, 0x48, 0x54,
+	0x76, 0x8e, 0x34, 0x49, 0x79, 0x84, 0x86, 0x14, 0x17, 0x67, 0x75, 0x4a, 0x91, 0x06, 0xd9, 0xde,
+	0x05, 0x2c, 0x8c, 0x77, 0x65, 0x2f, 0x84, 0xbc, 0xc5, 0xbd, 0x45, 0x5e, 0xe5, 0xca, 0x2b, 0x53,
+	0x91, 0xc8, 0xbc, 0x48, 0xb4, 0x6b, 0x83, 0x10, 0xb9, 0x6e, 0xbe, 0x99, 0x9d, 0x6f, 0x66, 0x3e,
+	0x7d, 0x0b, 0x6f, 0x02, 0xb6, 0xa6, 0xc2, 0x9f, 0x0b, 0x87, 0x8f, 0xb8, 0xb3, 0xbd, 0x71, 0xc4,
+	0x4f, 0x4e, 0x53, 0x9b, 0x27, 0x4c, 0x30, 0xd4, 0x39, 0x16, 0x6d, 0x3e, 0xe2, 0xf6, 0xf6, 0xa6,
+	0xd7, 0x5d, 0xb0, 0x05, 0x53, 0x35, 0x47, 0x46, 0xf9, 0xb3, 0x9e, 0xb5, 0x60, 0x6c, 0x11, 0x51,
+	0x47, 0x21, 0x7f, 0x33, 0x77, 0x44, 0xb8, 0xa6, 0xa9, 0xf0, 0xd6, 0x3c, 0x7f, 0x30, 0x70, 0x01,
+	0xee, 0xa8, 0xb8, 0x25, 0x24, 0xa1, 0x69, 0x8a, 0x5e, 0x43, 0x39, 0x24, 0x86, 0xd6, 0xd7, 0x86,
+	0xcd, 0x71, 0x2d, 0xdb, 0x5b, 0xe5, 0xe9, 0x04, 0x97, 0x43, 0xa2, 0xf2, 0xdc, 0x28, 0x9f, 0xe5,
+	0x5d, 0x5c, 0x0e, 0x39, 0x42, 0xa0, 0x73, 0x96, 0x08, 0xa3, 0xd2, 0xd7, 0x86, 0x6d, 0xac, 0xe2,
+	0xc1, 0x3d, 0x74, 0x5c, 0x49, 0x1d, 0xb0, 0xe8, 0x1b, 0x4d, 0xd2, 0x90, 0xc5, 0xe8, 0x1a, 0x2a,
+	0x7c, 0xc4, 0x15, 0xaf, 0x3e, 0xae, 0x67, 0x7b, 0xab, 0xe2, 0x8e, 0x5c, 0x2c, 0x73, 0xa8, 0x0b,
+	0x55, 0x3f, 0x62, 0xc1, 0x4a, 0x91, 0xeb, 0x38, 0x07, 0xe8, 0x0a, 0x2a, 0x1e, 0xe7, 0x8a, 0x56,
+	0xc7, 0x32, 0x1c, 0xfc, 0xaa, 0x40, 0x67, 0x42, 0xe7, 0xde, 0x26, 0x12, 0x77, 0x8c, 0xd0, 0x69,
+	0x3c, 0x67, 0xe8, 0x2b, 0x5c, 0xf1, 0x62, 0xd2, 0x6c, 0x9b, 0x8f, 0x52, 0x33, 0x5a, 0xa3, 0xbe,
+	0x7d, 0x21, 0x8f, 0x7d, 0xb1, 0xd2, 0x58, 0x7f, 0xdc, 0x5b, 0x25, 0xdc, 0xe1, 0x17, 0x9b, 0x7e,
+	0x84, 0x0e, 0xc9, 0xa7, 0xcc, 0x62, 0x46, 0xe8, 0x2c, 0x24, 0xc5, 0xd5, 0xaf, 0xb2, 0xbd, 0xd5,
+	0x3e, 0x5f, 0x60, 0x82, 0xdb, 0xe4, 0x0c, 0x12, 0x64, 0x41, 0x2b, 0x0a, 0x53, 0x41, 0xe3, 0x99,
+	0x47, 0x48, 0xa2, 0x76, 0x6f, 0x62, 0xc8, 0x53, 0x52, 0x5f, 0x64, 0x40, 0x3d, 0xa6, 0xe2, 0x07,
+	0x4b, 0x56, 0x86, 0xae, 0x8a, 0x47, 0x28, 0x2b, 0xc7, 0xfd, 0xab, 0x79, 0xa5, 0x80, 0xa8, 0x07,
+	0x8d, 0x60, 0xe9, 0xc5, 0x31, 0x8d, 0x52, 0xa3, 0xd6, 0xd7, 0x86, 0x2f, 0xf0, 0x09, 0xcb, 0xae,
+	0x35, 0x8b, 0xc3, 0x15, 0x4d, 0x8c, 0x7a, 0xde, 0x55, 0x40, 0x74, 0x0b, 0x55, 0x26, 0x96, 0x34,
+	0x31, 0x1a, 0x4a, 0x8d, 0xb7, 0xff, 0xa9, 0x71, 0xa1, 0xe4, 0x17, 0xf9, 0xb8, 0x90, 0x24, 0xef,
+	0x44, 0x1f, 0x40, 0x97, 0x56, 0x31, 0x9a, 0x8a, 0xa1, 0x67, 0xe7, 0x3e, 0xb2, 0x8f, 0x3e, 0xb2,
+	0xef, 0x8f, 0x3e, 0x1a, 0x37, 0x64, 0xdb, 0xc3, 0x1f, 0x4b, 0xc3, 0xaa, 0x63, 0xe0, 0x43, 0xf7,
+	0x39, 0x7a, 0x74, 0x0d, 0x0d, 0xb1, 0x9b, 0x85, 0x31, 0xa1, 0xbb, 0xdc, 0x61, 0xb8, 0x2e, 0x76,
+	0x53, 0x09, 0x91, 0x03, 0xad, 0x84, 0x07, 0x4a, 0x37, 0x9a, 0xa6, 0x85, 0xe2, 0x2f, 0xb3, 0xbd,
+	0x05, 0xd8, 0xfd, 0x54, 0x78, 0x13, 0x43, 0xc2, 0x83, 0x22, 0x1e, 0x7f, 0x7e, 0xcc, 0x4c, 0xed,
+	0x29, 0x33, 0xb5, 0xbf, 0x99, 0xa9, 0x3d, 0x1c, 0xcc, 0xd2, 0xd3, 0xc1, 0x2c, 0xfd, 0x3e, 0x98,
+	0xa5, 0xef, 0xef, 0x16, 0xa1, 0x58, 0x6e, 0x7c, 0x79, 0xb1, 0x73, 0xfa, 0x3f, 0xa7, 0xc0, 0xe3,
+	0xa1, 0x73, 0xf1, 0xab, 0xfc, 0x9a, 0xba, 0xe9, 0xfd, 0xbf, 0x01, 0x00, 0xc9, 0xa6, 0xcc, 0x95,
+	0x6f, 0x03, 0x00, 0x00,
 }
 
 func (m *NetAddress) Marshal() (dAtA []byte, err error) {
@@ -444,6 +460,14 @@ func (m *DefaultNodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	n1, err1 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintTypes(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x4a
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -629,6 +653,8 @@ func (m *DefaultNodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovTypes(uint64(l))
 	return n
 }
 
@@ -1184,6 +1210,39 @@ func (m *DefaultNodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
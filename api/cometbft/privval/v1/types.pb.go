@@ -388,6 +388,113 @@ func (m *SignedProposalResponse) GetError() *RemoteSignerError {
 	return nil
 }
 
+// SignAttestationRequest is a request to sign an attestation
+type SignAttestationRequest struct {
+	Attestation *v11.Attestation `protobuf:"bytes,1,opt,name=attestation,proto3" json:"attestation,omitempty"`
+	ChainId     string           `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *SignAttestationRequest) Reset()         { *m = SignAttestationRequest{} }
+func (m *SignAttestationRequest) String() string { return proto.CompactTextString(m) }
+func (*SignAttestationRequest) ProtoMessage()    {}
+func (*SignAttestationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00b969dcac92905e, []int{7}
+}
+func (m *SignAttestationRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignAttestationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignAttestationRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignAttestationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignAttestationRequest.Merge(m, src)
+}
+func (m *SignAttestationRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignAttestationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignAttestationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignAttestationRequest proto.InternalMessageInfo
+
+func (m *SignAttestationRequest) GetAttestation() *v11.Attestation {
+	if m != nil {
+		return m.Attestation
+	}
+	return nil
+}
+
+func (m *SignAttestationRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+// SignedAttestationResponse is a response containing a signed attestation or
+// an error
+type SignedAttestationResponse struct {
+	Attestation v11.Attestation    `protobuf:"bytes,1,opt,name=attestation,proto3" json:"attestation"`
+	Error       *RemoteSignerError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *SignedAttestationResponse) Reset()         { *m = SignedAttestationResponse{} }
+func (m *SignedAttestationResponse) String() string { return proto.CompactTextString(m) }
+func (*SignedAttestationResponse) ProtoMessage()    {}
+func (*SignedAttestationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00b969dcac92905e, []int{8}
+}
+func (m *SignedAttestationResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignedAttestationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignedAttestationResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignedAttestationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedAttestationResponse.Merge(m, src)
+}
+func (m *SignedAttestationResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignedAttestationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedAttestationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedAttestationResponse proto.InternalMessageInfo
+
+func (m *SignedAttestationResponse) GetAttestation() v11.Attestation {
+	if m != nil {
+		return m.Attestation
+	}
+	return v11.Attestation{}
+}
+
+func (m *SignedAttestationResponse) GetError() *RemoteSignerError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 // PingRequest is a request to confirm that the connection is alive.
 type PingRequest struct {
 }
@@ -396,7 +503,7 @@ func (m *PingRequest) Reset()         { *m = PingRequest{} }
 func (m *PingRequest) String() string { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()    {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00b969dcac92905e, []int{7}
+	return fileDescriptor_00b969dcac92905e, []int{9}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -433,7 +540,7 @@ func (m *PingResponse) Reset()         { *m = PingResponse{} }
 func (m *PingResponse) String() string { return proto.CompactTextString(m) }
 func (*PingResponse) ProtoMessage()    {}
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00b969dcac92905e, []int{8}
+	return fileDescriptor_00b969dcac92905e, []int{10}
 }
 func (m *PingResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -475,6 +582,8 @@ type Message struct {
 	//	*Message_SignedProposalResponse
 	//	*Message_PingRequest
 	//	*Message_PingResponse
+	//	*Message_SignAttestationRequest
+	//	*Message_SignedAttestationResponse
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -482,7 +591,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00b969dcac92905e, []int{9}
+	return fileDescriptor_00b969dcac92905e, []int{11}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -541,15 +650,23 @@ type Message_PingRequest struct {
 type Message_PingResponse struct {
 	PingResponse *PingResponse `protobuf:"bytes,8,opt,name=ping_response,json=pingResponse,proto3,oneof" json:"ping_response,omitempty"`
 }
+type Message_SignAttestationRequest struct {
+	SignAttestationRequest *SignAttestationRequest `protobuf:"bytes,9,opt,name=sign_attestation_request,json=signAttestationRequest,proto3,oneof" json:"sign_attestation_request,omitempty"`
+}
+type Message_SignedAttestationResponse struct {
+	SignedAttestationResponse *SignedAttestationResponse `protobuf:"bytes,10,opt,name=signed_attestation_response,json=signedAttestationResponse,proto3,oneof" json:"signed_attestation_response,omitempty"`
+}
 
-func (*Message_PubKeyRequest) isMessage_Sum()          {}
-func (*Message_PubKeyResponse) isMessage_Sum()         {}
-func (*Message_SignVoteRequest) isMessage_Sum()        {}
-func (*Message_SignedVoteResponse) isMessage_Sum()     {}
-func (*Message_SignProposalRequest) isMessage_Sum()    {}
-func (*Message_SignedProposalResponse) isMessage_Sum() {}
-func (*Message_PingRequest) isMessage_Sum()            {}
-func (*Message_PingResponse) isMessage_Sum()           {}
+func (*Message_PubKeyRequest) isMessage_Sum()             {}
+func (*Message_PubKeyResponse) isMessage_Sum()            {}
+func (*Message_SignVoteRequest) isMessage_Sum()           {}
+func (*Message_SignedVoteResponse) isMessage_Sum()        {}
+func (*Message_SignProposalRequest) isMessage_Sum()       {}
+func (*Message_SignedProposalResponse) isMessage_Sum()    {}
+func (*Message_PingRequest) isMessage_Sum()               {}
+func (*Message_PingResponse) isMessage_Sum()              {}
+func (*Message_SignAttestationRequest) isMessage_Sum()    {}
+func (*Message_SignedAttestationResponse) isMessage_Sum() {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -614,6 +731,20 @@ func (m *Message) GetPingResponse() *PingResponse {
 	return nil
 }
 
+func (m *Message) GetSignAttestationRequest() *SignAttestationRequest {
+	if x, ok := m.GetSum().(*Message_SignAttestationRequest); ok {
+		return x.SignAttestationRequest
+	}
+	return nil
+}
+
+func (m *Message) GetSignedAttestationResponse() *SignedAttestationResponse {
+	if x, ok := m.GetSum().(*Message_SignedAttestationResponse); ok {
+		return x.SignedAttestationResponse
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -625,6 +756,8 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_SignedProposalResponse)(nil),
 		(*Message_PingRequest)(nil),
 		(*Message_PingResponse)(nil),
+		(*Message_SignAttestationRequest)(nil),
+		(*Message_SignedAttestationResponse)(nil),
 	}
 }
 
@@ -636,6 +769,8 @@ func init() {
 	proto.RegisterType((*SignedVoteResponse)(nil), "cometbft.privval.v1.SignedVoteResponse")
 	proto.RegisterType((*SignProposalRequest)(nil), "cometbft.privval.v1.SignProposalRequest")
 	proto.RegisterType((*SignedProposalResponse)(nil), "cometbft.privval.v1.SignedProposalResponse")
+	proto.RegisterType((*SignAttestationRequest)(nil), "cometbft.privval.v1.SignAttestationRequest")
+	proto.RegisterType((*SignedAttestationResponse)(nil), "cometbft.privval.v1.SignedAttestationResponse")
 	proto.RegisterType((*PingRequest)(nil), "cometbft.privval.v1.PingRequest")
 	proto.RegisterType((*PingResponse)(nil), "cometbft.privval.v1.PingResponse")
 	proto.RegisterType((*Message)(nil), "cometbft.privval.v1.Message")
@@ -644,49 +779,54 @@ func init() {
 func init() { proto.RegisterFile("cometbft/privval/v1/types.proto", fileDescriptor_00b969dcac92905e) }
 
 var fileDescriptor_00b969dcac92905e = []byte{
-	// 659 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x95, 0x4f, 0x4f, 0xd4, 0x40,
-	0x18, 0xc6, 0x5b, 0xd8, 0x65, 0xe1, 0x5d, 0x16, 0xa4, 0x20, 0x22, 0x86, 0x65, 0xad, 0x46, 0x89,
-	0x24, 0xdd, 0x2c, 0x9a, 0x78, 0xc1, 0x0b, 0x09, 0x49, 0x09, 0xfe, 0xd9, 0x8c, 0x89, 0x89, 0x9a,
-	0xb8, 0xd9, 0xed, 0x8e, 0x65, 0x02, 0x74, 0xc6, 0x4e, 0xdb, 0xa4, 0x1f, 0xc0, 0x9b, 0x07, 0x2f,
-	0x7e, 0x19, 0x3f, 0x01, 0x47, 0x8e, 0x9e, 0x8c, 0x81, 0x2f, 0x62, 0x3a, 0x33, 0x6d, 0xb7, 0x4b,
-	0xd9, 0x68, 0xb8, 0xcd, 0x4c, 0xdf, 0xf9, 0xbd, 0xcf, 0x33, 0xf3, 0x34, 0x03, 0x9b, 0x0e, 0x3d,
-	0xc5, 0xc1, 0xe0, 0x73, 0xd0, 0x66, 0x3e, 0x89, 0xa2, 0xfe, 0x49, 0x3b, 0xea, 0xb4, 0x83, 0x98,
-	0x61, 0x6e, 0x31, 0x9f, 0x06, 0xd4, 0x58, 0x4e, 0x0b, 0x2c, 0x55, 0x60, 0x45, 0x9d, 0xf5, 0x8d,
-	0x6c, 0x97, 0xe3, 0xc7, 0x2c, 0xa0, 0xc9, 0xa6, 0x63, 0x1c, 0xab, 0x3d, 0x23, 0x9f, 0x05, 0x69,
-	0x0c, 0xb9, 0xbe, 0xe2, 0x52, 0x97, 0x8a, 0x61, 0x3b, 0x19, 0xc9, 0x55, 0xf3, 0x00, 0x96, 0x10,
-	0x3e, 0xa5, 0x01, 0x7e, 0x4b, 0x5c, 0x0f, 0xfb, 0xfb, 0xbe, 0x4f, 0x7d, 0xc3, 0x80, 0x8a, 0x43,
-	0x87, 0x78, 0x4d, 0x6f, 0xe9, 0x5b, 0x55, 0x24, 0xc6, 0x46, 0x0b, 0xea, 0x43, 0xcc, 0x1d, 0x9f,
-	0xb0, 0x80, 0x50, 0x6f, 0x6d, 0xaa, 0xa5, 0x6f, 0xcd, 0xa1, 0xd1, 0x25, 0xf3, 0x09, 0x34, 0xba,
-	0xe1, 0xe0, 0x10, 0xc7, 0x08, 0x7f, 0x09, 0x31, 0x0f, 0x8c, 0xbb, 0x30, 0xeb, 0x1c, 0xf5, 0x89,
-	0xd7, 0x23, 0x43, 0x81, 0x9a, 0x43, 0x35, 0x31, 0x3f, 0x18, 0x9a, 0xdf, 0x74, 0x58, 0x48, 0x8b,
-	0x39, 0xa3, 0x1e, 0xc7, 0xc6, 0x2e, 0xd4, 0x58, 0x38, 0xe8, 0x1d, 0xe3, 0x58, 0x14, 0xd7, 0x77,
-	0x36, 0xac, 0xec, 0x10, 0xa4, 0x5f, 0x2b, 0xea, 0x58, 0xdd, 0x70, 0x70, 0x42, 0x9c, 0x43, 0x1c,
-	0xef, 0x55, 0xce, 0x7e, 0x6f, 0x6a, 0x68, 0x86, 0x09, 0x8a, 0xb1, 0x0b, 0x55, 0x9c, 0x68, 0x17,
-	0xc2, 0xea, 0x3b, 0x8f, 0xac, 0x92, 0x03, 0xb4, 0xae, 0x38, 0x45, 0x72, 0x93, 0xf9, 0x1e, 0x16,
-	0x93, 0xd5, 0x77, 0x34, 0xc0, 0xa9, 0xf8, 0x6d, 0xa8, 0x44, 0x34, 0xc0, 0x4a, 0xcb, 0x9d, 0x9c,
-	0x27, 0xcf, 0x34, 0xea, 0x58, 0xa2, 0x5a, 0x14, 0x15, 0x9c, 0x4e, 0x15, 0x9d, 0x7e, 0xd5, 0xc1,
-	0x10, 0x1d, 0x87, 0x92, 0xae, 0xdc, 0x76, 0xfe, 0x09, 0xaf, 0x4c, 0xca, 0x26, 0x37, 0xb3, 0x48,
-	0x60, 0x39, 0x59, 0xed, 0xfa, 0x94, 0x51, 0xde, 0x3f, 0x49, 0x6d, 0x3e, 0x87, 0x59, 0xa6, 0x96,
-	0x94, 0x96, 0x7b, 0x25, 0x5a, 0xb2, 0x5d, 0x59, 0xf1, 0x24, 0xcb, 0x3f, 0x74, 0x58, 0x95, 0x96,
-	0xf3, 0x6e, 0xca, 0xf6, 0x8b, 0xff, 0x6a, 0xa7, 0xec, 0xe7, 0x4d, 0x6f, 0x76, 0x04, 0x0d, 0xa8,
-	0x77, 0x89, 0xe7, 0x2a, 0xeb, 0xe6, 0x02, 0xcc, 0xcb, 0xa9, 0xd4, 0x66, 0xfe, 0xac, 0x42, 0xed,
-	0x15, 0xe6, 0xbc, 0xef, 0x62, 0xe3, 0x25, 0x2c, 0xaa, 0x30, 0xf6, 0x7c, 0x59, 0xae, 0xe4, 0x9a,
-	0xa5, 0x2d, 0x0b, 0xb9, 0xb7, 0x35, 0xd4, 0x60, 0x85, 0x1f, 0xe1, 0x0d, 0xdc, 0xca, 0x69, 0xb2,
-	0x9b, 0x72, 0xf0, 0x60, 0x22, 0x4e, 0x96, 0xda, 0x1a, 0x5a, 0x60, 0xc5, 0x7f, 0x05, 0xc1, 0x12,
-	0x27, 0xae, 0xd7, 0x4b, 0x72, 0x91, 0x09, 0x9c, 0x16, 0xc4, 0x87, 0xa5, 0xc4, 0xb1, 0x74, 0xdb,
-	0x1a, 0x5a, 0xe4, 0x63, 0x81, 0xff, 0x08, 0x2b, 0x5c, 0x5c, 0x5a, 0x4a, 0x55, 0x42, 0x2b, 0x02,
-	0xfb, 0xf8, 0x5a, 0x6c, 0x31, 0xd8, 0xb6, 0x86, 0x0c, 0x7e, 0x35, 0xee, 0x9f, 0xe0, 0xb6, 0x10,
-	0x9c, 0xde, 0x64, 0x26, 0xba, 0x2a, 0xe8, 0x5b, 0xd7, 0xd2, 0xc7, 0xf2, 0x6a, 0x6b, 0x68, 0x99,
-	0x97, 0xc4, 0xd8, 0x85, 0x35, 0x25, 0x7e, 0xa4, 0x83, 0x32, 0x30, 0x23, 0x5a, 0x6c, 0x4f, 0x30,
-	0x30, 0x1e, 0x53, 0x5b, 0x43, 0xab, 0xbc, 0x3c, 0xc0, 0xfb, 0x30, 0xcf, 0x88, 0xe7, 0x66, 0xfa,
-	0x6b, 0x02, 0xde, 0x2a, 0xbf, 0xc6, 0x3c, 0x6c, 0xb6, 0x86, 0xea, 0x2c, 0x9f, 0x1a, 0x36, 0x34,
-	0x14, 0x46, 0x89, 0x9c, 0x15, 0x9c, 0xfb, 0x13, 0x38, 0x99, 0xb4, 0x79, 0x36, 0x32, 0xdf, 0xab,
-	0xc2, 0x34, 0x0f, 0x4f, 0xf7, 0x5e, 0x9f, 0x5d, 0x34, 0xf5, 0xf3, 0x8b, 0xa6, 0xfe, 0xe7, 0xa2,
-	0xa9, 0x7f, 0xbf, 0x6c, 0x6a, 0xe7, 0x97, 0x4d, 0xed, 0xd7, 0x65, 0x53, 0xfb, 0xf0, 0xcc, 0x25,
-	0xc1, 0x51, 0x38, 0x48, 0xc8, 0xed, 0xfc, 0x01, 0x49, 0x07, 0x7d, 0x46, 0xda, 0x25, 0x8f, 0xd1,
-	0x60, 0x46, 0x3c, 0x0f, 0x4f, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x17, 0xfc, 0x55, 0x33, 0xaa,
-	0x06, 0x00, 0x00,
+	// 747 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcb, 0x4e, 0xdb, 0x4c,
+	0x14, 0xb6, 0x21, 0x21, 0x70, 0x42, 0xe0, 0x67, 0xe0, 0xe7, 0xe7, 0x22, 0x42, 0xfe, 0xb4, 0x6a,
+	0x51, 0x91, 0x1c, 0x85, 0x56, 0xea, 0x86, 0x4a, 0x2d, 0x12, 0x95, 0x11, 0xbd, 0x44, 0x53, 0xa9,
+	0x52, 0x5b, 0xa9, 0x51, 0x2e, 0x53, 0x63, 0x01, 0x99, 0xa9, 0x67, 0x62, 0x29, 0x0f, 0xd0, 0x5d,
+	0x17, 0xdd, 0xf0, 0x00, 0x7d, 0x1b, 0x96, 0x2c, 0xbb, 0xaa, 0x2a, 0x78, 0x91, 0xca, 0x33, 0xe3,
+	0x5b, 0x62, 0xd2, 0x0b, 0x3b, 0xcf, 0xf1, 0x39, 0xdf, 0xcd, 0x3e, 0x96, 0x61, 0xb3, 0x43, 0x4f,
+	0x89, 0x68, 0x7f, 0x10, 0x35, 0xe6, 0xb9, 0xbe, 0xdf, 0x3a, 0xa9, 0xf9, 0xf5, 0x9a, 0x18, 0x30,
+	0xc2, 0x2d, 0xe6, 0x51, 0x41, 0xd1, 0x62, 0xd8, 0x60, 0xe9, 0x06, 0xcb, 0xaf, 0xaf, 0x6d, 0x44,
+	0x53, 0x1d, 0x6f, 0xc0, 0x04, 0x0d, 0x86, 0x8e, 0xc9, 0x40, 0xcf, 0x24, 0x6e, 0x4b, 0xa4, 0x21,
+	0xc8, 0xb5, 0x25, 0x87, 0x3a, 0x54, 0x5e, 0xd6, 0x82, 0x2b, 0x55, 0xad, 0x1e, 0xc0, 0x02, 0x26,
+	0xa7, 0x54, 0x90, 0x57, 0xae, 0xd3, 0x23, 0xde, 0xbe, 0xe7, 0x51, 0x0f, 0x21, 0xc8, 0x75, 0x68,
+	0x97, 0xac, 0x98, 0x15, 0x73, 0x2b, 0x8f, 0xe5, 0x35, 0xaa, 0x40, 0xb1, 0x4b, 0x78, 0xc7, 0x73,
+	0x99, 0x70, 0x69, 0x6f, 0x65, 0xa2, 0x62, 0x6e, 0xcd, 0xe0, 0x64, 0xa9, 0x7a, 0x0f, 0x4a, 0x8d,
+	0x7e, 0xfb, 0x90, 0x0c, 0x30, 0xf9, 0xd8, 0x27, 0x5c, 0xa0, 0x55, 0x98, 0xee, 0x1c, 0xb5, 0xdc,
+	0x5e, 0xd3, 0xed, 0x4a, 0xa8, 0x19, 0x5c, 0x90, 0xe7, 0x83, 0x6e, 0xf5, 0xb3, 0x09, 0x73, 0x61,
+	0x33, 0x67, 0xb4, 0xc7, 0x09, 0xda, 0x85, 0x02, 0xeb, 0xb7, 0x9b, 0xc7, 0x64, 0x20, 0x9b, 0x8b,
+	0x3b, 0x1b, 0x56, 0x14, 0x82, 0xf2, 0x6b, 0xf9, 0x75, 0xab, 0xd1, 0x6f, 0x9f, 0xb8, 0x9d, 0x43,
+	0x32, 0xd8, 0xcb, 0x9d, 0x7f, 0xdf, 0x34, 0xf0, 0x14, 0x93, 0x28, 0x68, 0x17, 0xf2, 0x24, 0xd0,
+	0x2e, 0x85, 0x15, 0x77, 0xee, 0x58, 0x19, 0x01, 0x5a, 0x23, 0x4e, 0xb1, 0x1a, 0xaa, 0xbe, 0x81,
+	0xf9, 0xa0, 0xfa, 0x9a, 0x0a, 0x12, 0x8a, 0xdf, 0x86, 0x9c, 0x4f, 0x05, 0xd1, 0x5a, 0xfe, 0x8b,
+	0xf1, 0x54, 0xa6, 0x7e, 0xdd, 0x92, 0xdd, 0xb2, 0x29, 0xe5, 0x74, 0x22, 0xed, 0xf4, 0x93, 0x09,
+	0x48, 0x32, 0x76, 0x15, 0xba, 0x76, 0x5b, 0xff, 0x2d, 0x78, 0x6d, 0x52, 0x91, 0xdc, 0xcc, 0xa2,
+	0x0b, 0x8b, 0x41, 0xb5, 0xe1, 0x51, 0x46, 0x79, 0xeb, 0x24, 0xb4, 0xf9, 0x10, 0xa6, 0x99, 0x2e,
+	0x69, 0x2d, 0xeb, 0x19, 0x5a, 0xa2, 0xa9, 0xa8, 0x79, 0x9c, 0xe5, 0x33, 0x13, 0x96, 0x95, 0xe5,
+	0x98, 0x4d, 0xdb, 0x7e, 0xf4, 0x47, 0x74, 0xda, 0x7e, 0x4c, 0x7a, 0xb3, 0x08, 0xfa, 0x4a, 0xd6,
+	0x13, 0x21, 0x08, 0x17, 0xad, 0xe0, 0x9d, 0x0d, 0x53, 0x78, 0x0c, 0xc5, 0x56, 0x5c, 0xd5, 0xca,
+	0xca, 0x19, 0xca, 0x92, 0xb3, 0xc9, 0x91, 0x71, 0x71, 0x7c, 0x35, 0x61, 0x55, 0xc5, 0x91, 0x62,
+	0xd6, 0x89, 0x3c, 0xfd, 0x0b, 0x6a, 0x9d, 0x4b, 0x4a, 0xc0, 0xcd, 0xa2, 0x29, 0x41, 0xb1, 0xe1,
+	0xf6, 0x1c, 0x9d, 0x47, 0x75, 0x0e, 0x66, 0xd5, 0x51, 0x89, 0xac, 0x9e, 0x15, 0xa0, 0xf0, 0x9c,
+	0x70, 0xde, 0x72, 0x08, 0x7a, 0x06, 0xf3, 0x7a, 0x4f, 0x9b, 0x9e, 0x6a, 0xd7, 0xa2, 0xab, 0x99,
+	0x94, 0xa9, 0x4f, 0x82, 0x6d, 0xe0, 0x12, 0x4b, 0x16, 0xd0, 0x4b, 0xf8, 0x27, 0x46, 0x53, 0x6c,
+	0xda, 0xc1, 0xad, 0xb1, 0x70, 0xaa, 0xd5, 0x36, 0xf0, 0x1c, 0x4b, 0x55, 0x10, 0x86, 0x05, 0xee,
+	0x3a, 0xbd, 0x66, 0xb0, 0x32, 0x91, 0xc0, 0x49, 0x89, 0x78, 0x3b, 0x13, 0x71, 0x68, 0xf1, 0x6d,
+	0x03, 0xcf, 0xf3, 0x74, 0x09, 0xbd, 0x83, 0x25, 0x2e, 0x1f, 0x60, 0x88, 0xaa, 0x85, 0xe6, 0x24,
+	0xec, 0xdd, 0x6b, 0x61, 0xd3, 0x3b, 0x6f, 0x1b, 0x18, 0xf1, 0x91, 0x2a, 0x7a, 0x0f, 0xff, 0x4a,
+	0xc1, 0xe1, 0x4b, 0x1e, 0x89, 0xce, 0x4b, 0xf4, 0xad, 0x6b, 0xd1, 0x87, 0x56, 0xd9, 0x36, 0xf0,
+	0x22, 0x1f, 0x2d, 0x23, 0x07, 0x56, 0xb4, 0xf8, 0x04, 0x83, 0x36, 0x30, 0x25, 0x29, 0xb6, 0xc7,
+	0x18, 0x18, 0xde, 0x60, 0xdb, 0xc0, 0xcb, 0x3c, 0x7b, 0xb7, 0xf7, 0x61, 0x96, 0xb9, 0x3d, 0x27,
+	0xd2, 0x5f, 0x90, 0xe0, 0x95, 0xec, 0xc7, 0x18, 0xbf, 0x6c, 0xb6, 0x81, 0x8b, 0x2c, 0x3e, 0x22,
+	0x1b, 0x4a, 0x1a, 0x46, 0x8b, 0x9c, 0x96, 0x38, 0xff, 0x8f, 0xc1, 0x89, 0xa4, 0xcd, 0xb2, 0xc4,
+	0x39, 0x74, 0xde, 0x4c, 0xac, 0x49, 0x24, 0x6e, 0xe6, 0x17, 0xce, 0x47, 0x3f, 0x12, 0xa1, 0xf3,
+	0xd1, 0x3b, 0x88, 0xc1, 0xba, 0x8e, 0x38, 0x4d, 0xa5, 0x0d, 0x80, 0xe4, 0xb2, 0xc6, 0xa4, 0x9c,
+	0xf1, 0x61, 0xb0, 0x0d, 0xbc, 0xca, 0xaf, 0xbb, 0xb9, 0x97, 0x87, 0x49, 0xde, 0x3f, 0xdd, 0x7b,
+	0x71, 0x7e, 0x59, 0x36, 0x2f, 0x2e, 0xcb, 0xe6, 0x8f, 0xcb, 0xb2, 0xf9, 0xe5, 0xaa, 0x6c, 0x5c,
+	0x5c, 0x95, 0x8d, 0x6f, 0x57, 0x65, 0xe3, 0xed, 0x03, 0xc7, 0x15, 0x47, 0xfd, 0x76, 0xc0, 0x59,
+	0x8b, 0x7f, 0x1b, 0xc2, 0x8b, 0x16, 0x73, 0x6b, 0x19, 0xbf, 0x20, 0xed, 0x29, 0xf9, 0x53, 0x70,
+	0xff, 0xe7, 0x00, 0x7a, 0x16, 0x29, 0xf4, 0xa0, 0x08, 0x00, 0x00,
 }
 
 func (m *RemoteSignerError) Marshal() (dAtA []byte, err error) {
@@ -973,6 +1113,93 @@ func (m *SignedProposalResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *SignAttestationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignAttestationRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignAttestationRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Attestation != nil {
+		{
+			size, err := m.Attestation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignedAttestationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignedAttestationResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignedAttestationResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		{
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Attestation.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *PingRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1219,6 +1446,48 @@ func (m *Message_PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_SignAttestationRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_SignAttestationRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignAttestationRequest != nil {
+		{
+			size, err := m.SignAttestationRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_SignedAttestationResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_SignedAttestationResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignedAttestationResponse != nil {
+		{
+			size, err := m.SignedAttestationResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1338,6 +1607,38 @@ func (m *SignedProposalResponse) Size() (n int) {
 	return n
 }
 
+func (m *SignAttestationRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Attestation != nil {
+		l = m.Attestation.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *SignedAttestationResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Attestation.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *PingRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1464,6 +1765,30 @@ func (m *Message_PingResponse) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_SignAttestationRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignAttestationRequest != nil {
+		l = m.SignAttestationRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_SignedAttestationResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignedAttestationResponse != nil {
+		l = m.SignedAttestationResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -2247,7 +2572,7 @@ func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PingRequest) Unmarshal(dAtA []byte) error {
+func (m *SignAttestationRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2270,30 +2595,267 @@ func (m *PingRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PingRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SignAttestationRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PingRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SignAttestationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestation", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Attestation == nil {
+				m.Attestation = &v11.Attestation{}
+			}
+			if err := m.Attestation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignedAttestationResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignedAttestationResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignedAttestationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Attestation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &RemoteSignerError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PingRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PingRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PingRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
@@ -2656,6 +3218,76 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_PingResponse{v}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignAttestationRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignAttestationRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignAttestationRequest{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedAttestationResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignedAttestationResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignedAttestationResponse{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,159 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/services/event_log/v1/event_log_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("cometbft/services/event_log/v1/event_log_service.proto", fileDescriptor_9687b30bdd395f15)
+}
+
+var fileDescriptor_9687b30bdd395f15 = []byte{
+	// 192 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4b, 0xce, 0xcf, 0x4d,
+	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x4f, 0x2d,
+	0x4b, 0xcd, 0x2b, 0x89, 0xcf, 0xc9, 0x4f, 0xd7, 0x2f, 0x33, 0x44, 0x70, 0xe2, 0xa1, 0xf2, 0x7a,
+	0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0x72, 0x30, 0x7d, 0x7a, 0x30, 0x7d, 0x7a, 0x70, 0xa5, 0x7a,
+	0x65, 0x86, 0x52, 0x7a, 0xc4, 0x9a, 0x0b, 0x31, 0xcf, 0xa8, 0x95, 0x91, 0x8b, 0xdf, 0x15, 0x24,
+	0xe6, 0x93, 0x9f, 0x1e, 0x0c, 0xd1, 0x21, 0x54, 0xc4, 0xc5, 0x19, 0x5c, 0x9a, 0x54, 0x9c, 0x5c,
+	0x94, 0x99, 0x94, 0x2a, 0x64, 0xa0, 0x87, 0xdf, 0x46, 0x3d, 0xb8, 0xd2, 0xa0, 0xd4, 0xc2, 0xd2,
+	0xd4, 0xe2, 0x12, 0x29, 0x43, 0x12, 0x74, 0x14, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x1a, 0x30, 0x3a,
+	0x45, 0x9e, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x13, 0x1e,
+	0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x7d, 0x7a, 0x66, 0x49,
+	0x46, 0x69, 0x12, 0xc8, 0x50, 0x7d, 0xb8, 0xe7, 0xe0, 0x8c, 0xc4, 0x82, 0x4c, 0x7d, 0xfc, 0x5e,
+	0x4e, 0x62, 0x03, 0xfb, 0xd4, 0x18, 0x30, 0x00, 0x2a, 0xce, 0x71, 0x4a, 0x73, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// EventLogServiceClient is the client API for EventLogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EventLogServiceClient interface {
+	// Subscribe streams the events of every block from the requested height,
+	// first from the log and then as new blocks are committed. The stream
+	// fails with OutOfRange if the requested height was pruned from the log.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (EventLogService_SubscribeClient, error)
+}
+
+type eventLogServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewEventLogServiceClient(cc grpc1.ClientConn) EventLogServiceClient {
+	return &eventLogServiceClient{cc}
+}
+
+func (c *eventLogServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (EventLogService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventLogService_serviceDesc.Streams[0], "/cometbft.services.event_log.v1.EventLogService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventLogServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventLogService_SubscribeClient interface {
+	Recv() (*SubscribeResponse, error)
+	grpc.ClientStream
+}
+
+type eventLogServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventLogServiceSubscribeClient) Recv() (*SubscribeResponse, error) {
+	m := new(SubscribeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventLogServiceServer is the server API for EventLogService service.
+type EventLogServiceServer interface {
+	// Subscribe streams the events of every block from the requested height,
+	// first from the log and then as new blocks are committed. The stream
+	// fails with OutOfRange if the requested height was pruned from the log.
+	Subscribe(*SubscribeRequest, EventLogService_SubscribeServer) error
+}
+
+// UnimplementedEventLogServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedEventLogServiceServer struct {
+}
+
+func (*UnimplementedEventLogServiceServer) Subscribe(req *SubscribeRequest, srv EventLogService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterEventLogServiceServer(s grpc1.Server, srv EventLogServiceServer) {
+	s.RegisterService(&_EventLogService_serviceDesc, srv)
+}
+
+func _EventLogService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventLogServiceServer).Subscribe(m, &eventLogServiceSubscribeServer{stream})
+}
+
+type EventLogService_SubscribeServer interface {
+	Send(*SubscribeResponse) error
+	grpc.ServerStream
+}
+
+type eventLogServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventLogServiceSubscribeServer) Send(m *SubscribeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _EventLogService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.services.event_log.v1.EventLogService",
+	HandlerType: (*EventLogServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _EventLogService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cometbft/services/event_log/v1/event_log_service.proto",
+}
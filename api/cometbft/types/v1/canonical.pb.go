@@ -386,51 +386,134 @@ func (m *CanonicalVoteExtension) GetChainId() string {
 	return ""
 }
 
+// CanonicalAttestation is a canonical representation of an Attestation, which
+// gets serialized and signed. Unlike the other canonical messages, it starts
+// with a fixed size field, so that its encoding never matches theirs.
+type CanonicalAttestation struct {
+	Height    int64     `protobuf:"fixed64,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash []byte    `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	AppHash   []byte    `protobuf:"bytes,3,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	Timestamp time.Time `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	ChainID   string    `protobuf:"bytes,5,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *CanonicalAttestation) Reset()         { *m = CanonicalAttestation{} }
+func (m *CanonicalAttestation) String() string { return proto.CompactTextString(m) }
+func (*CanonicalAttestation) ProtoMessage()    {}
+func (*CanonicalAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bd60568638662265, []int{5}
+}
+func (m *CanonicalAttestation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CanonicalAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CanonicalAttestation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CanonicalAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CanonicalAttestation.Merge(m, src)
+}
+func (m *CanonicalAttestation) XXX_Size() int {
+	return m.Size()
+}
+func (m *CanonicalAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_CanonicalAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CanonicalAttestation proto.InternalMessageInfo
+
+func (m *CanonicalAttestation) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CanonicalAttestation) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *CanonicalAttestation) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
+func (m *CanonicalAttestation) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *CanonicalAttestation) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*CanonicalBlockID)(nil), "cometbft.types.v1.CanonicalBlockID")
 	proto.RegisterType((*CanonicalPartSetHeader)(nil), "cometbft.types.v1.CanonicalPartSetHeader")
 	proto.RegisterType((*CanonicalProposal)(nil), "cometbft.types.v1.CanonicalProposal")
 	proto.RegisterType((*CanonicalVote)(nil), "cometbft.types.v1.CanonicalVote")
 	proto.RegisterType((*CanonicalVoteExtension)(nil), "cometbft.types.v1.CanonicalVoteExtension")
+	proto.RegisterType((*CanonicalAttestation)(nil), "cometbft.types.v1.CanonicalAttestation")
 }
 
 func init() { proto.RegisterFile("cometbft/types/v1/canonical.proto", fileDescriptor_bd60568638662265) }
 
 var fileDescriptor_bd60568638662265 = []byte{
-	// 526 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x94, 0xdf, 0x6a, 0xd3, 0x50,
-	0x1c, 0xc7, 0x9b, 0x2e, 0x6d, 0x93, 0xb3, 0x55, 0xb7, 0xc3, 0x18, 0xb1, 0x68, 0x12, 0x2b, 0x48,
-	0x77, 0x93, 0xb0, 0xea, 0x13, 0x64, 0x0a, 0x16, 0x27, 0x8e, 0x6c, 0x28, 0x78, 0x53, 0x4e, 0x92,
-	0xb3, 0x24, 0x98, 0xe6, 0x1c, 0x92, 0xd3, 0xe1, 0xae, 0xf6, 0x0a, 0x7b, 0x10, 0x1f, 0x64, 0x97,
-	0xbb, 0x14, 0x84, 0x2a, 0xe9, 0x8b, 0xc8, 0x39, 0x69, 0xd2, 0x42, 0x47, 0x41, 0x94, 0xdd, 0xfd,
-	0xfe, 0xff, 0xbe, 0x7c, 0x7e, 0xc9, 0x01, 0xcf, 0x7d, 0x32, 0xc1, 0xcc, 0xbb, 0x60, 0x36, 0xbb,
-	0xa2, 0x38, 0xb7, 0x2f, 0x8f, 0x6c, 0x1f, 0xa5, 0x24, 0x8d, 0x7d, 0x94, 0x58, 0x34, 0x23, 0x8c,
-	0xc0, 0xbd, 0xaa, 0xc4, 0x12, 0x25, 0xd6, 0xe5, 0x51, 0x6f, 0x3f, 0x24, 0x21, 0x11, 0x59, 0x9b,
-	0x5b, 0x65, 0x61, 0xef, 0xd9, 0xfa, 0xac, 0xb2, 0xa3, 0x4c, 0x1b, 0x21, 0x21, 0x61, 0x82, 0x6d,
-	0xe1, 0x79, 0xd3, 0x0b, 0x9b, 0xc5, 0x13, 0x9c, 0x33, 0x34, 0xa1, 0x65, 0x41, 0xff, 0x1a, 0xec,
-	0x1e, 0x57, 0xbb, 0x9d, 0x84, 0xf8, 0x5f, 0x47, 0x6f, 0x20, 0x04, 0x72, 0x84, 0xf2, 0x48, 0x93,
-	0x4c, 0x69, 0xb0, 0xe3, 0x0a, 0x1b, 0x7e, 0x06, 0x8f, 0x29, 0xca, 0xd8, 0x38, 0xc7, 0x6c, 0x1c,
-	0x61, 0x14, 0xe0, 0x4c, 0x6b, 0x9a, 0xd2, 0x60, 0x7b, 0x78, 0x68, 0xad, 0x49, 0xb5, 0xea, 0x89,
-	0xa7, 0x28, 0x63, 0x67, 0x98, 0xbd, 0x13, 0x0d, 0x8e, 0x7c, 0x3b, 0x33, 0x1a, 0x6e, 0x97, 0xae,
-	0x06, 0xfb, 0x0e, 0x38, 0xb8, 0xbf, 0x1c, 0xee, 0x83, 0x16, 0x23, 0x0c, 0x25, 0x42, 0x47, 0xd7,
-	0x2d, 0x9d, 0x5a, 0x5c, 0x73, 0x29, 0xae, 0xff, 0xb3, 0x09, 0xf6, 0x96, 0x43, 0x32, 0x42, 0x49,
-	0x8e, 0x12, 0xf8, 0x1a, 0xc8, 0x5c, 0x91, 0x68, 0x7f, 0x34, 0x34, 0xef, 0xd1, 0x79, 0x16, 0x87,
-	0x29, 0x0e, 0x3e, 0xe4, 0xe1, 0xf9, 0x15, 0xc5, 0xae, 0xa8, 0x86, 0x07, 0xa0, 0x1d, 0xe1, 0x38,
-	0x8c, 0x98, 0xd8, 0xb0, 0xeb, 0x2e, 0x3c, 0xae, 0x26, 0x23, 0xd3, 0x34, 0xd0, 0xb6, 0x44, 0xb8,
-	0x74, 0xe0, 0x21, 0x50, 0x29, 0x49, 0xc6, 0x65, 0x46, 0x36, 0xa5, 0xc1, 0x96, 0xb3, 0x53, 0xcc,
-	0x0c, 0xe5, 0xf4, 0xe3, 0x89, 0xcb, 0x63, 0xae, 0x42, 0x49, 0x22, 0x2c, 0xf8, 0x1e, 0x28, 0x1e,
-	0x07, 0x3c, 0x8e, 0x03, 0xad, 0x25, 0xd0, 0xbd, 0xd8, 0x84, 0x6e, 0x71, 0x0c, 0x67, 0xbb, 0x98,
-	0x19, 0x9d, 0x85, 0xe3, 0x76, 0xc4, 0x84, 0x51, 0x00, 0x1d, 0xa0, 0xd6, 0x97, 0xd4, 0xda, 0x62,
-	0x5a, 0xcf, 0x2a, 0x6f, 0x6d, 0x55, 0xb7, 0xb6, 0xce, 0xab, 0x0a, 0x47, 0xe1, 0xe4, 0x6f, 0x7e,
-	0x19, 0x92, 0xbb, 0x6c, 0x83, 0x2f, 0x81, 0xe2, 0x47, 0x28, 0x4e, 0xb9, 0xa0, 0x8e, 0x29, 0x0d,
-	0xd4, 0x72, 0xd7, 0x31, 0x8f, 0xf1, 0x5d, 0x22, 0x39, 0x0a, 0xfa, 0xdf, 0x9b, 0xa0, 0x5b, 0xcb,
-	0xfa, 0x44, 0x18, 0x7e, 0x10, 0xb2, 0xab, 0xb8, 0xe4, 0xff, 0x8a, 0xab, 0xf5, 0xef, 0xb8, 0xda,
-	0x1b, 0x70, 0x5d, 0xaf, 0x7c, 0xd0, 0x9c, 0xd6, 0xdb, 0x6f, 0x0c, 0xa7, 0x79, 0x4c, 0x52, 0xf8,
-	0x14, 0xa8, 0xb8, 0x72, 0x16, 0x3f, 0xd7, 0x32, 0xf0, 0x97, 0x78, 0x9e, 0xac, 0xa8, 0xe1, 0x78,
-	0xd4, 0x5a, 0x80, 0x73, 0x72, 0x5b, 0xe8, 0xd2, 0x5d, 0xa1, 0x4b, 0xbf, 0x0b, 0x5d, 0xba, 0x99,
-	0xeb, 0x8d, 0xbb, 0xb9, 0xde, 0xf8, 0x31, 0xd7, 0x1b, 0x5f, 0x86, 0x61, 0xcc, 0xa2, 0xa9, 0xc7,
-	0x39, 0xda, 0xf5, 0xbb, 0x51, 0x1b, 0x88, 0xc6, 0xf6, 0xda, 0x6b, 0xe2, 0xb5, 0x05, 0x9f, 0x57,
-	0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x35, 0xdb, 0x57, 0x1c, 0xb5, 0x04, 0x00, 0x00,
+	// 575 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x94, 0xcf, 0x6a, 0xdb, 0x30,
+	0x1c, 0xc7, 0xa3, 0xd4, 0x49, 0x6c, 0xb5, 0xd9, 0x5a, 0x11, 0x42, 0x1a, 0x56, 0x27, 0xcb, 0x60,
+	0xa4, 0x17, 0x9b, 0x66, 0x7b, 0x81, 0xb9, 0x1b, 0x34, 0xac, 0x63, 0xc5, 0x2d, 0x1b, 0xec, 0x12,
+	0x94, 0x58, 0xb5, 0xcd, 0x1c, 0x4b, 0xd8, 0x4a, 0x59, 0x4f, 0x7d, 0x85, 0x3e, 0xc8, 0x1e, 0xa4,
+	0xc7, 0x1e, 0x76, 0x18, 0x0c, 0xb2, 0x91, 0xbc, 0xc8, 0x90, 0x94, 0x38, 0x09, 0xe9, 0x0a, 0xa3,
+	0x63, 0xb7, 0xdf, 0xff, 0xdf, 0x97, 0xcf, 0xcf, 0x32, 0x7c, 0x3a, 0xa0, 0x43, 0xc2, 0xfb, 0xe7,
+	0xdc, 0xe6, 0x97, 0x8c, 0xa4, 0xf6, 0xc5, 0x81, 0x3d, 0xc0, 0x31, 0x8d, 0xc3, 0x01, 0x8e, 0x2c,
+	0x96, 0x50, 0x4e, 0xd1, 0xce, 0xbc, 0xc4, 0x92, 0x25, 0xd6, 0xc5, 0x41, 0xbd, 0xe2, 0x53, 0x9f,
+	0xca, 0xac, 0x2d, 0x2c, 0x55, 0x58, 0xdf, 0x5b, 0x9f, 0xa5, 0x3a, 0x54, 0xba, 0xe1, 0x53, 0xea,
+	0x47, 0xc4, 0x96, 0x5e, 0x7f, 0x74, 0x6e, 0xf3, 0x70, 0x48, 0x52, 0x8e, 0x87, 0x4c, 0x15, 0xb4,
+	0xae, 0xe0, 0xf6, 0xe1, 0x7c, 0xb7, 0x13, 0xd1, 0xc1, 0xe7, 0xee, 0x6b, 0x84, 0xa0, 0x16, 0xe0,
+	0x34, 0xa8, 0x81, 0x26, 0x68, 0x6f, 0xb9, 0xd2, 0x46, 0x1f, 0xe1, 0x63, 0x86, 0x13, 0xde, 0x4b,
+	0x09, 0xef, 0x05, 0x04, 0x7b, 0x24, 0xa9, 0xe5, 0x9b, 0xa0, 0xbd, 0xd9, 0xd9, 0xb7, 0xd6, 0xa4,
+	0x5a, 0xd9, 0xc4, 0x13, 0x9c, 0xf0, 0x53, 0xc2, 0x8f, 0x64, 0x83, 0xa3, 0xdd, 0x8c, 0x1b, 0x39,
+	0xb7, 0xcc, 0x96, 0x83, 0x2d, 0x07, 0x56, 0xef, 0x2e, 0x47, 0x15, 0x58, 0xe0, 0x94, 0xe3, 0x48,
+	0xea, 0x28, 0xbb, 0xca, 0xc9, 0xc4, 0xe5, 0x17, 0xe2, 0x5a, 0x3f, 0xf2, 0x70, 0x67, 0x31, 0x24,
+	0xa1, 0x8c, 0xa6, 0x38, 0x42, 0x2f, 0xa1, 0x26, 0x14, 0xc9, 0xf6, 0x47, 0x9d, 0xe6, 0x1d, 0x3a,
+	0x4f, 0x43, 0x3f, 0x26, 0xde, 0xbb, 0xd4, 0x3f, 0xbb, 0x64, 0xc4, 0x95, 0xd5, 0xa8, 0x0a, 0x8b,
+	0x01, 0x09, 0xfd, 0x80, 0xcb, 0x0d, 0xdb, 0xee, 0xcc, 0x13, 0x6a, 0x12, 0x3a, 0x8a, 0xbd, 0xda,
+	0x86, 0x0c, 0x2b, 0x07, 0xed, 0x43, 0x83, 0xd1, 0xa8, 0xa7, 0x32, 0x5a, 0x13, 0xb4, 0x37, 0x9c,
+	0xad, 0xc9, 0xb8, 0xa1, 0x9f, 0xbc, 0x3f, 0x76, 0x45, 0xcc, 0xd5, 0x19, 0x8d, 0xa4, 0x85, 0xde,
+	0x42, 0xbd, 0x2f, 0x00, 0xf7, 0x42, 0xaf, 0x56, 0x90, 0xe8, 0x9e, 0xdd, 0x87, 0x6e, 0x76, 0x0c,
+	0x67, 0x73, 0x32, 0x6e, 0x94, 0x66, 0x8e, 0x5b, 0x92, 0x13, 0xba, 0x1e, 0x72, 0xa0, 0x91, 0x5d,
+	0xb2, 0x56, 0x94, 0xd3, 0xea, 0x96, 0xba, 0xb5, 0x35, 0xbf, 0xb5, 0x75, 0x36, 0xaf, 0x70, 0x74,
+	0x41, 0xfe, 0xfa, 0x67, 0x03, 0xb8, 0x8b, 0x36, 0xf4, 0x1c, 0xea, 0x83, 0x00, 0x87, 0xb1, 0x10,
+	0x54, 0x6a, 0x82, 0xb6, 0xa1, 0x76, 0x1d, 0x8a, 0x98, 0xd8, 0x25, 0x93, 0x5d, 0xaf, 0xf5, 0x35,
+	0x0f, 0xcb, 0x99, 0xac, 0x0f, 0x94, 0x93, 0xff, 0x42, 0x76, 0x19, 0x97, 0xf6, 0x4f, 0x71, 0x15,
+	0x1e, 0x8e, 0xab, 0x78, 0x0f, 0xae, 0x2b, 0x58, 0x5d, 0xa1, 0xf5, 0xe6, 0x0b, 0x27, 0x71, 0x1a,
+	0xd2, 0x18, 0x3d, 0x81, 0x06, 0x99, 0x3b, 0xb3, 0xc7, 0xb5, 0x08, 0xfc, 0x25, 0x9e, 0xdd, 0x25,
+	0x35, 0x02, 0x8f, 0xb1, 0x10, 0xf0, 0x0d, 0xc0, 0x4a, 0xa6, 0xe0, 0x15, 0xe7, 0x42, 0x3f, 0x5f,
+	0xdd, 0x00, 0x56, 0x36, 0xec, 0x41, 0xa8, 0x50, 0x2f, 0x3d, 0x2c, 0x43, 0x46, 0x8e, 0xc4, 0xd3,
+	0xdf, 0x85, 0x3a, 0x66, 0x4c, 0x25, 0x37, 0x64, 0xb2, 0x84, 0x19, 0x93, 0xa9, 0x15, 0xae, 0xda,
+	0xc3, 0xb9, 0x16, 0xfe, 0xcc, 0xd5, 0x39, 0xbe, 0x99, 0x98, 0xe0, 0x76, 0x62, 0x82, 0x5f, 0x13,
+	0x13, 0x5c, 0x4f, 0xcd, 0xdc, 0xed, 0xd4, 0xcc, 0x7d, 0x9f, 0x9a, 0xb9, 0x4f, 0x1d, 0x3f, 0xe4,
+	0xc1, 0xa8, 0x2f, 0x3e, 0x0f, 0x3b, 0xfb, 0x1d, 0x66, 0x06, 0x66, 0xa1, 0xbd, 0xf6, 0x93, 0xec,
+	0x17, 0xa5, 0xbc, 0x17, 0xbf, 0x07, 0x00, 0x21, 0xc2, 0xba, 0xd4, 0x8c, 0x05, 0x00, 0x00,
 }
 
 func (m *CanonicalBlockID) Marshal() (dAtA []byte, err error) {
@@ -696,6 +779,64 @@ func (m *CanonicalVoteExtension) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *CanonicalAttestation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CanonicalAttestation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CanonicalAttestation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainID) > 0 {
+		i -= len(m.ChainID)
+		copy(dAtA[i:], m.ChainID)
+		i = encodeVarintCanonical(dAtA, i, uint64(len(m.ChainID)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	n6, err6 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err6 != nil {
+		return 0, err6
+	}
+	i -= n6
+	i = encodeVarintCanonical(dAtA, i, uint64(n6))
+	i--
+	dAtA[i] = 0x22
+	if len(m.AppHash) > 0 {
+		i -= len(m.AppHash)
+		copy(dAtA[i:], m.AppHash)
+		i = encodeVarintCanonical(dAtA, i, uint64(len(m.AppHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintCanonical(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Height))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintCanonical(dAtA []byte, offset int, v uint64) int {
 	offset -= sovCanonical(v)
 	base := offset
@@ -820,6 +961,32 @@ func (m *CanonicalVoteExtension) Size() (n int) {
 	return n
 }
 
+func (m *CanonicalAttestation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 9
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovCanonical(uint64(l))
+	}
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovCanonical(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovCanonical(uint64(l))
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovCanonical(uint64(l))
+	}
+	return n
+}
+
 func sovCanonical(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1581,6 +1748,199 @@ func (m *CanonicalVoteExtension) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CanonicalAttestation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCanonical
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CanonicalAttestation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CanonicalAttestation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Height = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCanonical(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipCanonical(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
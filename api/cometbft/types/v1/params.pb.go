@@ -35,6 +35,7 @@ type ConsensusParams struct {
 	Validator *ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
 	Version   *VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
 	Abci      *ABCIParams      `protobuf:"bytes,5,opt,name=abci,proto3" json:"abci,omitempty"`
+	Mempool   *MempoolParams   `protobuf:"bytes,6,opt,name=mempool,proto3" json:"mempool,omitempty"`
 }
 
 func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
@@ -105,6 +106,13 @@ func (m *ConsensusParams) GetAbci() *ABCIParams {
 	return nil
 }
 
+func (m *ConsensusParams) GetMempool() *MempoolParams {
+	if m != nil {
+		return m.Mempool
+	}
+	return nil
+}
+
 // BlockParams contains limits on the block size.
 type BlockParams struct {
 	// Max block size, in bytes.
@@ -239,6 +247,9 @@ func (m *EvidenceParams) GetMaxBytes() int64 {
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
 	PubKeyTypes []string `protobuf:"bytes,1,rep,name=pub_key_types,json=pubKeyTypes,proto3" json:"pub_key_types,omitempty"`
+	// disable_batch_verification makes the nodes verify the signatures of the
+	// commits and the evidence one by one instead of in batches.
+	DisableBatchVerification bool `protobuf:"varint,2,opt,name=disable_batch_verification,json=disableBatchVerification,proto3" json:"disable_batch_verification,omitempty"`
 }
 
 func (m *ValidatorParams) Reset()         { *m = ValidatorParams{} }
@@ -281,6 +292,13 @@ func (m *ValidatorParams) GetPubKeyTypes() []string {
 	return nil
 }
 
+func (m *ValidatorParams) GetDisableBatchVerification() bool {
+	if m != nil {
+		return m.DisableBatchVerification
+	}
+	return false
+}
+
 // VersionParams contains the ABCI application version.
 type VersionParams struct {
 	// Was named app_version in Tendermint 0.34
@@ -436,6 +454,55 @@ func (m *ABCIParams) GetVoteExtensionsEnableHeight() int64 {
 	return 0
 }
 
+// MempoolParams coordinate the limits of the mempools across the network.
+type MempoolParams struct {
+	// Max size of a transaction, in bytes. The nodes reject the larger
+	// transactions in CheckTx, including the ones received from their peers,
+	// and the proposals including them.
+	// Note: 0 means no limit other than the local config and the block size.
+	MaxTxBytes int64 `protobuf:"varint,1,opt,name=max_tx_bytes,json=maxTxBytes,proto3" json:"max_tx_bytes,omitempty"`
+}
+
+func (m *MempoolParams) Reset()         { *m = MempoolParams{} }
+func (m *MempoolParams) String() string { return proto.CompactTextString(m) }
+func (*MempoolParams) ProtoMessage()    {}
+func (*MempoolParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c2f6d19461b2fe7, []int{7}
+}
+func (m *MempoolParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MempoolParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MempoolParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MempoolParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolParams.Merge(m, src)
+}
+func (m *MempoolParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *MempoolParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolParams proto.InternalMessageInfo
+
+func (m *MempoolParams) GetMaxTxBytes() int64 {
+	if m != nil {
+		return m.MaxTxBytes
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ConsensusParams)(nil), "cometbft.types.v1.ConsensusParams")
 	proto.RegisterType((*BlockParams)(nil), "cometbft.types.v1.BlockParams")
@@ -444,48 +511,54 @@ func init() {
 	proto.RegisterType((*VersionParams)(nil), "cometbft.types.v1.VersionParams")
 	proto.RegisterType((*HashedParams)(nil), "cometbft.types.v1.HashedParams")
 	proto.RegisterType((*ABCIParams)(nil), "cometbft.types.v1.ABCIParams")
+	proto.RegisterType((*MempoolParams)(nil), "cometbft.types.v1.MempoolParams")
 }
 
 func init() { proto.RegisterFile("cometbft/types/v1/params.proto", fileDescriptor_8c2f6d19461b2fe7) }
 
 var fileDescriptor_8c2f6d19461b2fe7 = []byte{
-	// 576 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x3f, 0x6f, 0xd3, 0x40,
-	0x18, 0x87, 0x73, 0x75, 0xda, 0xa6, 0x6f, 0x48, 0x13, 0x4e, 0x48, 0x98, 0xa0, 0x3a, 0xc1, 0x03,
-	0xaa, 0x54, 0xc9, 0x56, 0x0a, 0x53, 0x25, 0x04, 0x49, 0x89, 0xda, 0x82, 0x0a, 0x95, 0x85, 0x18,
-	0xba, 0x58, 0xe7, 0xe4, 0xea, 0x58, 0x8d, 0x7d, 0x56, 0xee, 0x6c, 0x25, 0xdf, 0x82, 0x91, 0xb1,
-	0x23, 0x7c, 0x02, 0xf8, 0x08, 0x1d, 0x3b, 0x32, 0x01, 0x4a, 0x16, 0x3e, 0x06, 0xf2, 0xd9, 0x4e,
-	0x9a, 0x36, 0xdb, 0xf9, 0xde, 0xe7, 0xb9, 0x3f, 0xbf, 0xd7, 0x07, 0x5a, 0x8f, 0xf9, 0x54, 0x38,
-	0x17, 0xc2, 0x14, 0x93, 0x90, 0x72, 0x33, 0x6e, 0x99, 0x21, 0x19, 0x11, 0x9f, 0x1b, 0xe1, 0x88,
-	0x09, 0x86, 0x1f, 0xe6, 0x75, 0x43, 0xd6, 0x8d, 0xb8, 0x55, 0x7f, 0xe4, 0x32, 0x97, 0xc9, 0xaa,
-	0x99, 0x8c, 0x52, 0xb0, 0xae, 0xb9, 0x8c, 0xb9, 0x43, 0x6a, 0xca, 0x2f, 0x27, 0xba, 0x30, 0xfb,
-	0xd1, 0x88, 0x08, 0x8f, 0x05, 0x69, 0x5d, 0xff, 0xb1, 0x06, 0xd5, 0x43, 0x16, 0x70, 0x1a, 0xf0,
-	0x88, 0x9f, 0xc9, 0x2d, 0xf0, 0x4b, 0x58, 0x77, 0x86, 0xac, 0x77, 0xa9, 0xa2, 0x26, 0xda, 0x2d,
-	0xef, 0x6b, 0xc6, 0xbd, 0xcd, 0x8c, 0x4e, 0x52, 0x4f, 0x71, 0x2b, 0x85, 0xf1, 0x2b, 0x28, 0xd1,
-	0xd8, 0xeb, 0xd3, 0xa0, 0x47, 0xd5, 0x35, 0x29, 0x3e, 0x5b, 0x21, 0x76, 0x33, 0x24, 0x73, 0xe7,
-	0x0a, 0x7e, 0x03, 0x5b, 0x31, 0x19, 0x7a, 0x7d, 0x22, 0xd8, 0x48, 0x55, 0xa4, 0xaf, 0xaf, 0xf0,
-	0x3f, 0xe7, 0x4c, 0xb6, 0xc0, 0x42, 0xc2, 0x07, 0xb0, 0x19, 0xd3, 0x11, 0xf7, 0x58, 0xa0, 0x16,
-	0xa5, 0xdf, 0x5c, 0xe5, 0xa7, 0x44, 0x66, 0xe7, 0x02, 0x6e, 0x41, 0x91, 0x38, 0x3d, 0x4f, 0x5d,
-	0x97, 0xe2, 0xce, 0x0a, 0xb1, 0xdd, 0x39, 0x3c, 0xc9, 0x2c, 0x89, 0xea, 0x27, 0x50, 0xbe, 0x95,
-	0x02, 0x7e, 0x0a, 0x5b, 0x3e, 0x19, 0xdb, 0xce, 0x44, 0x50, 0x2e, 0x83, 0x53, 0xac, 0x92, 0x4f,
-	0xc6, 0x9d, 0xe4, 0x1b, 0x3f, 0x86, 0xcd, 0xa4, 0xe8, 0x12, 0x2e, 0xa3, 0x51, 0xac, 0x0d, 0x9f,
-	0x8c, 0x8f, 0x08, 0x7f, 0x57, 0x2c, 0x29, 0xb5, 0xa2, 0xfe, 0x1d, 0xc1, 0xf6, 0x72, 0x30, 0x78,
-	0x0f, 0x70, 0x62, 0x10, 0x97, 0xda, 0x41, 0xe4, 0xdb, 0x32, 0xe2, 0x7c, 0xdd, 0xaa, 0x4f, 0xc6,
-	0x6d, 0x97, 0x7e, 0x88, 0x7c, 0x79, 0x00, 0x8e, 0x4f, 0xa1, 0x96, 0xc3, 0x79, 0x7b, 0xb3, 0x16,
-	0x3c, 0x31, 0xd2, 0xfe, 0x1b, 0x79, 0xff, 0x8d, 0xb7, 0x19, 0xd0, 0x29, 0x5d, 0xff, 0x6e, 0x14,
-	0xbe, 0xfe, 0x69, 0x20, 0x6b, 0x3b, 0x5d, 0x2f, 0xaf, 0x2c, 0x5f, 0x45, 0x59, 0xbe, 0x8a, 0xfe,
-	0x1a, 0xaa, 0x77, 0x7a, 0x80, 0x75, 0xa8, 0x84, 0x91, 0x63, 0x5f, 0xd2, 0x89, 0x2d, 0xe3, 0x52,
-	0x51, 0x53, 0xd9, 0xdd, 0xb2, 0xca, 0x61, 0xe4, 0xbc, 0xa7, 0x93, 0x4f, 0xc9, 0xd4, 0x41, 0xe9,
-	0xe7, 0x55, 0x03, 0xfd, 0xbb, 0x6a, 0x20, 0x7d, 0x0f, 0x2a, 0x4b, 0x4d, 0xc0, 0x35, 0x50, 0x48,
-	0x18, 0xca, 0xbb, 0x15, 0xad, 0x64, 0x78, 0x0b, 0x3e, 0x87, 0x07, 0xc7, 0x84, 0x0f, 0x68, 0x3f,
-	0x63, 0x9f, 0x43, 0x55, 0x46, 0x61, 0xdf, 0xcd, 0xba, 0x22, 0xa7, 0x4f, 0xf3, 0xc0, 0x75, 0xa8,
-	0x2c, 0xb8, 0x45, 0xec, 0xe5, 0x9c, 0x3a, 0x22, 0x5c, 0xff, 0x08, 0xb0, 0x68, 0x2a, 0x6e, 0xc3,
-	0x4e, 0xcc, 0x04, 0xb5, 0xe9, 0x58, 0xd0, 0x20, 0x39, 0x1d, 0xb7, 0x69, 0x40, 0x9c, 0x21, 0xb5,
-	0x07, 0xd4, 0x73, 0x07, 0x22, 0xdb, 0xa7, 0x9e, 0x40, 0xdd, 0x39, 0xd3, 0x95, 0xc8, 0xb1, 0x24,
-	0x3a, 0x67, 0xdf, 0xa6, 0x1a, 0xba, 0x9e, 0x6a, 0xe8, 0x66, 0xaa, 0xa1, 0xbf, 0x53, 0x0d, 0x7d,
-	0x99, 0x69, 0x85, 0x9b, 0x99, 0x56, 0xf8, 0x35, 0xd3, 0x0a, 0xe7, 0xfb, 0xae, 0x27, 0x06, 0x91,
-	0x93, 0xfc, 0x5a, 0xe6, 0xfc, 0x75, 0xcf, 0x07, 0x24, 0xf4, 0xcc, 0x7b, 0x6f, 0xde, 0xd9, 0x90,
-	0x6d, 0x7b, 0xf1, 0x3f, 0x00, 0x00, 0xff, 0xff, 0xdc, 0xea, 0x75, 0xab, 0x0f, 0x04, 0x00, 0x00,
+	// 645 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xc1, 0x4e, 0xdb, 0x4c,
+	0x10, 0xc7, 0x63, 0x1c, 0x20, 0x4c, 0x08, 0xe1, 0x5b, 0x7d, 0x52, 0xdd, 0x54, 0x38, 0xa9, 0x0f,
+	0x15, 0x12, 0x92, 0xad, 0xd0, 0x9e, 0x50, 0x2b, 0x95, 0x50, 0x04, 0xb4, 0xa2, 0x45, 0x16, 0xe2,
+	0xc0, 0xc5, 0x5a, 0x27, 0x8b, 0x63, 0x11, 0x7b, 0xad, 0xec, 0xda, 0x4a, 0x0e, 0x7d, 0x87, 0x1e,
+	0x7b, 0xe4, 0xd8, 0xbe, 0x41, 0x1f, 0x81, 0x23, 0xc7, 0x9e, 0xda, 0x2a, 0x5c, 0x7a, 0xef, 0x0b,
+	0x54, 0xbb, 0xb6, 0x13, 0x0c, 0xe9, 0x6d, 0x77, 0xff, 0xbf, 0xff, 0xce, 0xec, 0xcc, 0x68, 0x41,
+	0xef, 0xd2, 0x80, 0x70, 0xf7, 0x82, 0x5b, 0x7c, 0x1c, 0x11, 0x66, 0x25, 0x6d, 0x2b, 0xc2, 0x43,
+	0x1c, 0x30, 0x33, 0x1a, 0x52, 0x4e, 0xd1, 0x7f, 0xb9, 0x6e, 0x4a, 0xdd, 0x4c, 0xda, 0x8d, 0xff,
+	0x3d, 0xea, 0x51, 0xa9, 0x5a, 0x62, 0x95, 0x82, 0x0d, 0xdd, 0xa3, 0xd4, 0x1b, 0x10, 0x4b, 0xee,
+	0xdc, 0xf8, 0xc2, 0xea, 0xc5, 0x43, 0xcc, 0x7d, 0x1a, 0xa6, 0xba, 0xf1, 0x67, 0x01, 0xea, 0x7b,
+	0x34, 0x64, 0x24, 0x64, 0x31, 0x3b, 0x91, 0x21, 0xd0, 0x0b, 0x58, 0x74, 0x07, 0xb4, 0x7b, 0xa9,
+	0x29, 0x2d, 0x65, 0xb3, 0xba, 0xad, 0x9b, 0x0f, 0x82, 0x99, 0x1d, 0xa1, 0xa7, 0xb8, 0x9d, 0xc2,
+	0xe8, 0x15, 0x54, 0x48, 0xe2, 0xf7, 0x48, 0xd8, 0x25, 0xda, 0x82, 0x34, 0x3e, 0x9d, 0x63, 0xdc,
+	0xcf, 0x90, 0xcc, 0x3b, 0xb5, 0xa0, 0xd7, 0xb0, 0x92, 0xe0, 0x81, 0xdf, 0xc3, 0x9c, 0x0e, 0x35,
+	0x55, 0xfa, 0x8d, 0x39, 0xfe, 0xb3, 0x9c, 0xc9, 0x2e, 0x98, 0x99, 0xd0, 0x0e, 0x2c, 0x27, 0x64,
+	0xc8, 0x7c, 0x1a, 0x6a, 0x65, 0xe9, 0x6f, 0xcd, 0xf3, 0xa7, 0x44, 0xe6, 0xce, 0x0d, 0xa8, 0x0d,
+	0x65, 0xec, 0x76, 0x7d, 0x6d, 0x51, 0x1a, 0x37, 0xe6, 0x18, 0x77, 0x3b, 0x7b, 0x47, 0x99, 0x4b,
+	0xa2, 0x22, 0x5c, 0x40, 0x82, 0x88, 0xd2, 0x81, 0xb6, 0xf4, 0xcf, 0x70, 0xc7, 0x29, 0x91, 0x87,
+	0xcb, 0x0c, 0xc6, 0x11, 0x54, 0xef, 0x54, 0x10, 0x3d, 0x81, 0x95, 0x00, 0x8f, 0x1c, 0x77, 0xcc,
+	0x09, 0x93, 0x45, 0x57, 0xed, 0x4a, 0x80, 0x47, 0x1d, 0xb1, 0x47, 0x8f, 0x60, 0x59, 0x88, 0x1e,
+	0x66, 0xb2, 0xac, 0xaa, 0xbd, 0x14, 0xe0, 0xd1, 0x01, 0x66, 0x6f, 0xcb, 0x15, 0x75, 0xbd, 0x6c,
+	0x7c, 0x55, 0x60, 0xad, 0x58, 0x54, 0xb4, 0x05, 0x48, 0x38, 0xb0, 0x47, 0x9c, 0x30, 0x0e, 0x1c,
+	0xd9, 0x9e, 0xfc, 0xde, 0x7a, 0x80, 0x47, 0xbb, 0x1e, 0x79, 0x1f, 0x07, 0x32, 0x01, 0x86, 0x8e,
+	0x61, 0x3d, 0x87, 0xf3, 0xd1, 0xc8, 0xda, 0xf7, 0xd8, 0x4c, 0x67, 0xc7, 0xcc, 0x67, 0xc7, 0x7c,
+	0x93, 0x01, 0x9d, 0xca, 0xf5, 0x8f, 0x66, 0xe9, 0xf3, 0xcf, 0xa6, 0x62, 0xaf, 0xa5, 0xf7, 0xe5,
+	0x4a, 0xf1, 0x29, 0x6a, 0xf1, 0x29, 0xc6, 0x47, 0xa8, 0xdf, 0xeb, 0x1f, 0x32, 0xa0, 0x16, 0xc5,
+	0xae, 0x73, 0x49, 0xc6, 0x8e, 0x2c, 0x9a, 0xa6, 0xb4, 0xd4, 0xcd, 0x15, 0xbb, 0x1a, 0xc5, 0xee,
+	0x3b, 0x32, 0x3e, 0x15, 0x47, 0xe8, 0x25, 0x34, 0x7a, 0x3e, 0xc3, 0xee, 0x80, 0x38, 0x2e, 0xe6,
+	0xdd, 0xbe, 0x93, 0x90, 0xa1, 0x7f, 0xe1, 0x77, 0x67, 0xc9, 0x56, 0x6c, 0x2d, 0x23, 0x3a, 0x02,
+	0x38, 0xbb, 0xa3, 0xef, 0x54, 0xbe, 0x5d, 0x35, 0x95, 0xdf, 0x57, 0x4d, 0xc5, 0xd8, 0x82, 0x5a,
+	0xa1, 0xfd, 0x68, 0x1d, 0x54, 0x1c, 0x45, 0xb2, 0x32, 0x65, 0x5b, 0x2c, 0xef, 0xc0, 0xe7, 0xb0,
+	0x7a, 0x88, 0x59, 0x9f, 0xf4, 0x32, 0xf6, 0x19, 0xd4, 0x65, 0x21, 0x9d, 0xfb, 0x9d, 0xaa, 0xc9,
+	0xe3, 0xe3, 0xbc, 0x5d, 0x06, 0xd4, 0x66, 0xdc, 0xac, 0x69, 0xd5, 0x9c, 0x3a, 0xc0, 0xcc, 0xf8,
+	0x00, 0x30, 0x1b, 0x27, 0xb4, 0x0b, 0x1b, 0x09, 0xe5, 0xc4, 0x21, 0x23, 0x4e, 0x42, 0x91, 0x1d,
+	0x73, 0x48, 0x28, 0x5f, 0xdb, 0x27, 0xbe, 0xd7, 0xe7, 0x59, 0x9c, 0x86, 0x80, 0xf6, 0xa7, 0xcc,
+	0xbe, 0x44, 0x0e, 0x25, 0x61, 0xb4, 0xa1, 0x56, 0x98, 0x34, 0xd4, 0x82, 0x55, 0x11, 0x9f, 0x17,
+	0x53, 0x85, 0x00, 0x8f, 0x4e, 0xd3, 0x3c, 0x3b, 0x27, 0x5f, 0x26, 0xba, 0x72, 0x3d, 0xd1, 0x95,
+	0x9b, 0x89, 0xae, 0xfc, 0x9a, 0xe8, 0xca, 0xa7, 0x5b, 0xbd, 0x74, 0x73, 0xab, 0x97, 0xbe, 0xdf,
+	0xea, 0xa5, 0xf3, 0x6d, 0xcf, 0xe7, 0xfd, 0xd8, 0x15, 0x13, 0x6d, 0x4d, 0xbf, 0xa2, 0xe9, 0x02,
+	0x47, 0xbe, 0xf5, 0xe0, 0x83, 0x72, 0x97, 0xe4, 0x9c, 0x3c, 0xff, 0x3b, 0x00, 0xcc, 0xc6, 0xc5,
+	0x4b, 0xbc, 0x04, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -522,6 +595,9 @@ func (this *ConsensusParams) Equal(that interface{}) bool {
 	if !this.Abci.Equal(that1.Abci) {
 		return false
 	}
+	if !this.Mempool.Equal(that1.Mempool) {
+		return false
+	}
 	return true
 }
 func (this *BlockParams) Equal(that interface{}) bool {
@@ -608,6 +684,9 @@ func (this *ValidatorParams) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.DisableBatchVerification != that1.DisableBatchVerification {
+		return false
+	}
 	return true
 }
 func (this *VersionParams) Equal(that interface{}) bool {
@@ -685,6 +764,30 @@ func (this *ABCIParams) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *MempoolParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MempoolParams)
+	if !ok {
+		that2, ok := that.(MempoolParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MaxTxBytes != that1.MaxTxBytes {
+		return false
+	}
+	return true
+}
 func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -705,6 +808,18 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Mempool != nil {
+		{
+			size, err := m.Mempool.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.Abci != nil {
 		{
 			size, err := m.Abci.MarshalToSizedBuffer(dAtA[:i])
@@ -862,6 +977,16 @@ func (m *ValidatorParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.DisableBatchVerification {
+		i--
+		if m.DisableBatchVerification {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.PubKeyTypes) > 0 {
 		for iNdEx := len(m.PubKeyTypes) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.PubKeyTypes[iNdEx])
@@ -963,6 +1088,34 @@ func (m *ABCIParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MempoolParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MempoolParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MempoolParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxTxBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxTxBytes))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
 	offset -= sovParams(v)
 	base := offset
@@ -981,6 +1134,7 @@ func NewPopulatedValidatorParams(r randyParams, easy bool) *ValidatorParams {
 	for i := 0; i < v1; i++ {
 		this.PubKeyTypes[i] = string(randStringParams(r))
 	}
+	this.DisableBatchVerification = bool(bool(r.Intn(2) == 0))
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -1092,6 +1246,10 @@ func (m *ConsensusParams) Size() (n int) {
 		l = m.Abci.Size()
 		n += 1 + l + sovParams(uint64(l))
 	}
+	if m.Mempool != nil {
+		l = m.Mempool.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
 	return n
 }
 
@@ -1139,6 +1297,9 @@ func (m *ValidatorParams) Size() (n int) {
 			n += 1 + l + sovParams(uint64(l))
 		}
 	}
+	if m.DisableBatchVerification {
+		n += 2
+	}
 	return n
 }
 
@@ -1181,6 +1342,18 @@ func (m *ABCIParams) Size() (n int) {
 	return n
 }
 
+func (m *MempoolParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxTxBytes != 0 {
+		n += 1 + sovParams(uint64(m.MaxTxBytes))
+	}
+	return n
+}
+
 func sovParams(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1396,6 +1569,42 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mempool", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Mempool == nil {
+				m.Mempool = &MempoolParams{}
+			}
+			if err := m.Mempool.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1687,6 +1896,26 @@ func (m *ValidatorParams) Unmarshal(dAtA []byte) error {
 			}
 			m.PubKeyTypes = append(m.PubKeyTypes, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableBatchVerification", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DisableBatchVerification = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1934,6 +2163,75 @@ func (m *ABCIParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MempoolParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MempoolParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MempoolParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTxBytes", wireType)
+			}
+			m.MaxTxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxTxBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipParams(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
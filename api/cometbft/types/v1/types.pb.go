@@ -1184,6 +1184,93 @@ func (m *TxProof) GetProof() *v1.Proof {
 	return nil
 }
 
+// Attestation is a validator's signed statement that the block with the given
+// hash was committed at the given height and that executing it resulted in the
+// given app hash.
+type Attestation struct {
+	Height           int64     `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash        []byte    `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	AppHash          []byte    `protobuf:"bytes,3,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	Timestamp        time.Time `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	ValidatorAddress []byte    `protobuf:"bytes,5,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
+	Signature        []byte    `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *Attestation) Reset()         { *m = Attestation{} }
+func (m *Attestation) String() string { return proto.CompactTextString(m) }
+func (*Attestation) ProtoMessage()    {}
+func (*Attestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ea20b664d765b5f, []int{15}
+}
+func (m *Attestation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Attestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Attestation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Attestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attestation.Merge(m, src)
+}
+func (m *Attestation) XXX_Size() int {
+	return m.Size()
+}
+func (m *Attestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attestation proto.InternalMessageInfo
+
+func (m *Attestation) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Attestation) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *Attestation) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
+func (m *Attestation) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *Attestation) GetValidatorAddress() []byte {
+	if m != nil {
+		return m.ValidatorAddress
+	}
+	return nil
+}
+
+func (m *Attestation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("cometbft.types.v1.SignedMsgType", SignedMsgType_name, SignedMsgType_value)
 	proto.RegisterType((*PartSetHeader)(nil), "cometbft.types.v1.PartSetHeader")
@@ -1201,95 +1288,98 @@ func init() {
 	proto.RegisterType((*LightBlock)(nil), "cometbft.types.v1.LightBlock")
 	proto.RegisterType((*BlockMeta)(nil), "cometbft.types.v1.BlockMeta")
 	proto.RegisterType((*TxProof)(nil), "cometbft.types.v1.TxProof")
+	proto.RegisterType((*Attestation)(nil), "cometbft.types.v1.Attestation")
 }
 
 func init() { proto.RegisterFile("cometbft/types/v1/types.proto", fileDescriptor_8ea20b664d765b5f) }
 
 var fileDescriptor_8ea20b664d765b5f = []byte{
-	// 1314 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xcf, 0xda, 0xeb, 0x7f, 0xcf, 0x76, 0xe2, 0x2c, 0x11, 0x75, 0xdc, 0xd6, 0x31, 0xe6, 0x5f,
-	0x28, 0xc8, 0x6e, 0x02, 0x08, 0xb8, 0x20, 0xd5, 0x49, 0xda, 0x46, 0x34, 0x89, 0xb5, 0x76, 0x8b,
-	0x80, 0xc3, 0x6a, 0xed, 0x9d, 0xd8, 0xab, 0xda, 0x3b, 0xab, 0xdd, 0xb1, 0x49, 0xfa, 0x09, 0x50,
-	0x4f, 0x3d, 0x72, 0xe9, 0x09, 0x0e, 0x7c, 0x81, 0x1e, 0xb8, 0x22, 0x0e, 0x3d, 0xf6, 0x06, 0xa7,
-	0x82, 0x92, 0x0b, 0x1f, 0x03, 0xcd, 0x9b, 0xd9, 0xdd, 0x38, 0xb6, 0xd5, 0x88, 0x56, 0x20, 0x71,
-	0x9b, 0x79, 0xef, 0xf7, 0xde, 0xbc, 0x7d, 0xbf, 0xdf, 0x8c, 0xde, 0xc2, 0xd5, 0x2e, 0x1d, 0x12,
-	0xd6, 0x39, 0x64, 0x75, 0x76, 0xec, 0x12, 0xbf, 0x3e, 0xde, 0x10, 0x8b, 0x9a, 0xeb, 0x51, 0x46,
-	0xb5, 0xe5, 0xc0, 0x5d, 0x13, 0xd6, 0xf1, 0x46, 0xa9, 0x1c, 0x46, 0x74, 0xbd, 0x63, 0x97, 0x51,
-	0x1e, 0xe2, 0x7a, 0x94, 0x1e, 0x8a, 0x90, 0xd2, 0x1b, 0xd3, 0x19, 0xc7, 0xe6, 0xc0, 0xb6, 0x4c,
-	0x46, 0x3d, 0x09, 0x59, 0x0b, 0x21, 0x63, 0xe2, 0xf9, 0x36, 0x75, 0xce, 0x1d, 0x5b, 0x5a, 0xe9,
-	0xd1, 0x1e, 0xc5, 0x65, 0x9d, 0xaf, 0x82, 0xb0, 0x1e, 0xa5, 0xbd, 0x01, 0xa9, 0xe3, 0xae, 0x33,
-	0x3a, 0xac, 0x33, 0x7b, 0x48, 0x7c, 0x66, 0x0e, 0x5d, 0x01, 0xa8, 0x7e, 0x06, 0xf9, 0xa6, 0xe9,
-	0xb1, 0x16, 0x61, 0xb7, 0x89, 0x69, 0x11, 0x4f, 0x5b, 0x81, 0x04, 0xa3, 0xcc, 0x1c, 0x14, 0x95,
-	0x8a, 0xb2, 0x9e, 0xd7, 0xc5, 0x46, 0xd3, 0x40, 0xed, 0x9b, 0x7e, 0xbf, 0x18, 0xab, 0x28, 0xeb,
-	0x39, 0x1d, 0xd7, 0x55, 0x1b, 0x54, 0x1e, 0xca, 0x23, 0x6c, 0xc7, 0x22, 0x47, 0x41, 0x04, 0x6e,
-	0xb8, 0xb5, 0x73, 0xcc, 0x88, 0x2f, 0x43, 0xc4, 0x46, 0xfb, 0x18, 0x12, 0xf8, 0xe1, 0xc5, 0x78,
-	0x45, 0x59, 0xcf, 0x6e, 0xae, 0xd6, 0xc2, 0x66, 0x89, 0xce, 0xd4, 0xc6, 0x1b, 0xb5, 0x26, 0x07,
-	0x34, 0xd4, 0xa7, 0xcf, 0xd7, 0x16, 0x74, 0x81, 0xae, 0x0e, 0x21, 0xd5, 0x18, 0xd0, 0xee, 0xfd,
-	0xdd, 0xed, 0xb0, 0x12, 0x25, 0xaa, 0x44, 0xdb, 0x87, 0x25, 0xd7, 0xf4, 0x98, 0xe1, 0x13, 0x66,
-	0xf4, 0xf1, 0x33, 0xf0, 0xd4, 0xec, 0x66, 0xa5, 0x36, 0x45, 0x46, 0x6d, 0xe2, 0x73, 0xe5, 0x31,
-	0x79, 0xf7, 0xac, 0xb1, 0xfa, 0x97, 0x0a, 0x49, 0xd9, 0x8e, 0xcf, 0x21, 0x25, 0x1b, 0x8e, 0x27,
-	0x66, 0x37, 0xcb, 0x51, 0x4a, 0xe9, 0xe0, 0x49, 0xb7, 0xa8, 0xe3, 0x13, 0xc7, 0x1f, 0xf9, 0x32,
-	0x61, 0x10, 0xa4, 0xbd, 0x03, 0xe9, 0x6e, 0xdf, 0xb4, 0x1d, 0xc3, 0xb6, 0xb0, 0xa6, 0x4c, 0x23,
-	0x7b, 0xf2, 0x7c, 0x2d, 0xb5, 0xc5, 0x6d, 0xbb, 0xdb, 0x7a, 0x0a, 0x9d, 0xbb, 0x96, 0xf6, 0x3a,
-	0x24, 0xfb, 0xc4, 0xee, 0xf5, 0x19, 0x76, 0x26, 0xae, 0xcb, 0x9d, 0xf6, 0x29, 0xa8, 0x9c, 0xb2,
-	0xa2, 0x8a, 0x87, 0x97, 0x6a, 0x82, 0xcf, 0x5a, 0xc0, 0x67, 0xad, 0x1d, 0xf0, 0xd9, 0x48, 0xf3,
-	0x83, 0x1f, 0xfd, 0xb1, 0xa6, 0xe8, 0x18, 0xa1, 0x6d, 0x43, 0x7e, 0x60, 0xfa, 0xcc, 0xe8, 0xf0,
-	0xc6, 0xf1, 0xe3, 0x13, 0x32, 0xc5, 0x74, 0x4b, 0x64, 0x6f, 0x65, 0xed, 0x59, 0x1e, 0x26, 0x4c,
-	0x96, 0xb6, 0x0e, 0x05, 0xcc, 0xd2, 0xa5, 0xc3, 0xa1, 0xcd, 0x0c, 0x6c, 0x7d, 0x12, 0x5b, 0xbf,
-	0xc8, 0xed, 0x5b, 0x68, 0xbe, 0xcd, 0x49, 0xb8, 0x0c, 0x19, 0xcb, 0x64, 0xa6, 0x80, 0xa4, 0x10,
-	0x92, 0xe6, 0x06, 0x74, 0xbe, 0x0b, 0x4b, 0xa1, 0xa2, 0x7d, 0x01, 0x49, 0x8b, 0x2c, 0x91, 0x19,
-	0x81, 0xd7, 0x61, 0xc5, 0x21, 0x47, 0xcc, 0x38, 0x8f, 0xce, 0x20, 0x5a, 0xe3, 0xbe, 0x7b, 0x93,
-	0x11, 0x6f, 0xc3, 0x62, 0x37, 0xe8, 0xbe, 0xc0, 0x02, 0x62, 0xf3, 0xa1, 0x15, 0x61, 0xab, 0x90,
-	0x36, 0x5d, 0x57, 0x00, 0xb2, 0x08, 0x48, 0x99, 0xae, 0x8b, 0xae, 0x6b, 0xb0, 0x8c, 0xdf, 0xe8,
-	0x11, 0x7f, 0x34, 0x60, 0x32, 0x49, 0x0e, 0x31, 0x4b, 0xdc, 0xa1, 0x0b, 0x3b, 0x62, 0xdf, 0x84,
-	0x3c, 0x19, 0xdb, 0x16, 0x71, 0xba, 0x44, 0xe0, 0xf2, 0x88, 0xcb, 0x05, 0x46, 0x04, 0xbd, 0x07,
-	0x05, 0xd7, 0xa3, 0x2e, 0xf5, 0x89, 0x67, 0x98, 0x96, 0xe5, 0x11, 0xdf, 0x2f, 0x2e, 0x8a, 0x7c,
-	0x81, 0xfd, 0x86, 0x30, 0x57, 0x8b, 0xa0, 0x6e, 0x9b, 0xcc, 0xd4, 0x0a, 0x10, 0x67, 0x47, 0x7e,
-	0x51, 0xa9, 0xc4, 0xd7, 0x73, 0x3a, 0x5f, 0x56, 0x7f, 0x8e, 0x83, 0x7a, 0x8f, 0x32, 0xa2, 0x7d,
-	0x04, 0x2a, 0x67, 0x0a, 0xf5, 0xb7, 0x38, 0x53, 0xd2, 0x2d, 0xbb, 0xe7, 0x10, 0x6b, 0xcf, 0xef,
-	0xb5, 0x8f, 0x5d, 0xa2, 0x23, 0xfa, 0x8c, 0xa0, 0x62, 0x13, 0x82, 0x5a, 0x81, 0x84, 0x47, 0x47,
-	0x8e, 0x85, 0x3a, 0x4b, 0xe8, 0x62, 0xa3, 0xdd, 0x84, 0x74, 0xa8, 0x13, 0xf5, 0x85, 0x3a, 0x59,
-	0xe2, 0x3a, 0xe1, 0x32, 0x96, 0x06, 0x3d, 0xd5, 0x91, 0x72, 0x69, 0x40, 0x26, 0x7c, 0x61, 0x42,
-	0xc1, 0x5d, 0x44, 0xb3, 0x51, 0x98, 0xf6, 0x3e, 0x2c, 0x87, 0xec, 0x87, 0xed, 0x13, 0x9a, 0x2b,
-	0x84, 0x0e, 0xd9, 0xbf, 0x09, 0x61, 0x19, 0xe2, 0x19, 0x4a, 0xe1, 0x87, 0x45, 0xc2, 0xda, 0xc5,
-	0xf7, 0xe8, 0x0a, 0x64, 0x7c, 0xbb, 0xe7, 0x98, 0x6c, 0xe4, 0x11, 0xa9, 0xbd, 0xc8, 0xc0, 0xbd,
-	0xe4, 0x88, 0x11, 0x07, 0x2f, 0xba, 0xd0, 0x5a, 0x64, 0xd0, 0xea, 0xf0, 0x5a, 0xb8, 0x31, 0xa2,
-	0x2c, 0x42, 0x67, 0x5a, 0xe8, 0x6a, 0x05, 0x9e, 0xea, 0x2f, 0x0a, 0x24, 0xc5, 0xd5, 0x38, 0xc3,
-	0x83, 0x32, 0x9b, 0x87, 0xd8, 0x3c, 0x1e, 0xe2, 0x2f, 0xc5, 0x03, 0x84, 0x75, 0xfa, 0x45, 0xb5,
-	0x12, 0x5f, 0xcf, 0x6e, 0x5e, 0x99, 0x91, 0x49, 0x14, 0xd9, 0xb2, 0x7b, 0xf2, 0xee, 0x9f, 0x89,
-	0xaa, 0x3e, 0x57, 0x20, 0x13, 0xfa, 0xb5, 0x06, 0xe4, 0x83, 0xca, 0x8c, 0xc3, 0x81, 0xd9, 0x93,
-	0x72, 0x2c, 0xcf, 0x2f, 0xef, 0xe6, 0xc0, 0xec, 0xe9, 0x59, 0x59, 0x11, 0xdf, 0xcc, 0x66, 0x36,
-	0x36, 0x87, 0xd9, 0x09, 0x29, 0xc5, 0xff, 0x99, 0x94, 0x26, 0x48, 0x57, 0xcf, 0x91, 0x5e, 0x3d,
-	0x55, 0x60, 0x71, 0x87, 0x93, 0x67, 0x11, 0xeb, 0x3f, 0x65, 0xeb, 0x1b, 0xa9, 0x2f, 0x8b, 0x58,
-	0xc6, 0x14, 0x6d, 0x6f, 0xcd, 0x48, 0x39, 0x59, 0x75, 0x44, 0x9f, 0x16, 0xa4, 0x69, 0x45, 0x34,
-	0x3e, 0x89, 0xc1, 0xf2, 0x14, 0xfe, 0x7f, 0x48, 0xe7, 0xe4, 0x1d, 0x4e, 0x5c, 0xf0, 0x0e, 0x27,
-	0xe7, 0xde, 0xe1, 0x27, 0x31, 0x48, 0x37, 0xf1, 0xb5, 0x36, 0x07, 0xff, 0xca, 0x1b, 0x7c, 0x19,
-	0x32, 0x2e, 0x1d, 0x18, 0xc2, 0xa3, 0xa2, 0x27, 0xed, 0xd2, 0x81, 0x3e, 0x25, 0xb5, 0xc4, 0xab,
-	0x7a, 0xa0, 0x93, 0xaf, 0x80, 0x86, 0xd4, 0xf9, 0x5b, 0xc5, 0x20, 0x27, 0x7a, 0x21, 0x27, 0xa8,
-	0x0d, 0xde, 0x04, 0x9c, 0xc9, 0x94, 0xf3, 0x33, 0x5f, 0x58, 0xb7, 0x80, 0xea, 0x12, 0xc8, 0x43,
-	0xc4, 0xbc, 0x21, 0xc7, 0xb8, 0xd5, 0xb9, 0x2f, 0x97, 0x2e, 0x81, 0xd5, 0xef, 0x15, 0x80, 0x3b,
-	0xbc, 0xb9, 0xf8, 0xc5, 0x7c, 0xf8, 0xf1, 0xb1, 0x08, 0x63, 0xe2, 0xec, 0xb5, 0xb9, 0xc4, 0xc9,
-	0x0a, 0x72, 0xfe, 0xd9, 0xd2, 0xb7, 0x21, 0x1f, 0x09, 0xdc, 0x27, 0x41, 0x39, 0xb3, 0xb2, 0x84,
-	0x43, 0x49, 0x8b, 0x30, 0x3d, 0x37, 0x3e, 0xb3, 0xab, 0xfe, 0xaa, 0x40, 0x06, 0xab, 0xda, 0x23,
-	0xcc, 0x9c, 0x20, 0x52, 0x79, 0x09, 0x22, 0xaf, 0x02, 0x88, 0x3c, 0xbe, 0xfd, 0x80, 0x48, 0x7d,
-	0x65, 0xd0, 0xd2, 0xb2, 0x1f, 0x10, 0xed, 0x93, 0xb0, 0xeb, 0xf1, 0x17, 0x74, 0x5d, 0x3e, 0x1d,
-	0x41, 0xef, 0x2f, 0x41, 0xca, 0x19, 0x0d, 0x0d, 0x3e, 0x8c, 0xa8, 0x42, 0xb4, 0xce, 0x68, 0xd8,
-	0x3e, 0xf2, 0xab, 0xf7, 0x21, 0xd5, 0x3e, 0xc2, 0xd9, 0x9c, 0x2b, 0xd5, 0xa3, 0x54, 0x4e, 0x83,
-	0x62, 0x10, 0x4f, 0x73, 0x03, 0x0e, 0x3f, 0x1a, 0xa8, 0x7c, 0xec, 0x0b, 0x7e, 0x15, 0xf8, 0x5a,
-	0xab, 0x5f, 0x74, 0xec, 0x97, 0x03, 0xff, 0xb5, 0xdf, 0x14, 0xc8, 0x4f, 0xdc, 0x28, 0xed, 0x03,
-	0xb8, 0xd4, 0xda, 0xbd, 0xb5, 0xbf, 0xb3, 0x6d, 0xec, 0xb5, 0x6e, 0x19, 0xed, 0xaf, 0x9a, 0x3b,
-	0xc6, 0xdd, 0xfd, 0x2f, 0xf6, 0x0f, 0xbe, 0xdc, 0x2f, 0x2c, 0x94, 0x96, 0x1e, 0x3e, 0xae, 0x64,
-	0xef, 0x3a, 0xf7, 0x1d, 0xfa, 0xad, 0x33, 0x0f, 0xdd, 0xd4, 0x77, 0xee, 0x1d, 0xb4, 0x77, 0x0a,
-	0x8a, 0x40, 0x37, 0x3d, 0x32, 0xa6, 0x8c, 0x20, 0xfa, 0x3a, 0xac, 0xce, 0x40, 0x6f, 0x1d, 0xec,
-	0xed, 0xed, 0xb6, 0x0b, 0xb1, 0xd2, 0xf2, 0xc3, 0xc7, 0x95, 0x7c, 0xd3, 0x23, 0x42, 0x6a, 0x18,
-	0x51, 0x83, 0xe2, 0x74, 0xc4, 0x41, 0xf3, 0xa0, 0x75, 0xe3, 0x4e, 0xa1, 0x52, 0x2a, 0x3c, 0x7c,
-	0x5c, 0xc9, 0x05, 0x6f, 0x07, 0xc7, 0x97, 0xd2, 0xdf, 0xfd, 0x50, 0x5e, 0xf8, 0xe9, 0xc7, 0xb2,
-	0xd2, 0xb8, 0xf3, 0xf4, 0xa4, 0xac, 0x3c, 0x3b, 0x29, 0x2b, 0x7f, 0x9e, 0x94, 0x95, 0x47, 0xa7,
-	0xe5, 0x85, 0x67, 0xa7, 0xe5, 0x85, 0xdf, 0x4f, 0xcb, 0x0b, 0x5f, 0x6f, 0xf6, 0x6c, 0xd6, 0x1f,
-	0x75, 0x78, 0x6f, 0xea, 0xd1, 0x0f, 0x63, 0xb0, 0x30, 0x5d, 0xbb, 0x3e, 0xf5, 0x9b, 0xd8, 0x49,
-	0xe2, 0x9d, 0xfd, 0xf0, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0xdf, 0x98, 0x74, 0x84, 0x94, 0x0e,
-	0x00, 0x00,
+	// 1355 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0xcd, 0x6f, 0x1b, 0x45,
+	0x14, 0xcf, 0xda, 0xeb, 0xaf, 0x67, 0x3b, 0x71, 0x86, 0x88, 0xba, 0x6e, 0xeb, 0x18, 0xf3, 0x15,
+	0x0a, 0xb2, 0x9b, 0x00, 0x02, 0x2e, 0x48, 0x75, 0x92, 0xb6, 0x11, 0x4d, 0x62, 0xad, 0xdd, 0x22,
+	0xe0, 0xb0, 0x5a, 0x7b, 0x27, 0xf6, 0xaa, 0xf6, 0xce, 0x6a, 0x77, 0x6c, 0x92, 0xfe, 0x05, 0xa8,
+	0xa7, 0x1e, 0xb9, 0xf4, 0x04, 0x07, 0xfe, 0x81, 0x1e, 0xb8, 0x22, 0x0e, 0x3d, 0xf6, 0x06, 0xa7,
+	0x82, 0x92, 0x0b, 0x27, 0xfe, 0x06, 0x34, 0x1f, 0xbb, 0xeb, 0xf5, 0x87, 0x5a, 0x68, 0x04, 0x12,
+	0xb7, 0x99, 0xf7, 0x7e, 0xef, 0xcd, 0xdb, 0xf7, 0xfb, 0xcd, 0xe8, 0x2d, 0x5c, 0xe9, 0x92, 0x21,
+	0xa6, 0x9d, 0x23, 0x5a, 0xa7, 0x27, 0x0e, 0xf6, 0xea, 0xe3, 0x4d, 0xb1, 0xa8, 0x39, 0x2e, 0xa1,
+	0x04, 0xad, 0xfa, 0xee, 0x9a, 0xb0, 0x8e, 0x37, 0x4b, 0xe5, 0x20, 0xa2, 0xeb, 0x9e, 0x38, 0x94,
+	0xb0, 0x10, 0xc7, 0x25, 0xe4, 0x48, 0x84, 0x94, 0x5e, 0x9b, 0xcd, 0x38, 0x36, 0x06, 0x96, 0x69,
+	0x50, 0xe2, 0x4a, 0xc8, 0x7a, 0x00, 0x19, 0x63, 0xd7, 0xb3, 0x88, 0x3d, 0x75, 0x6c, 0x69, 0xad,
+	0x47, 0x7a, 0x84, 0x2f, 0xeb, 0x6c, 0xe5, 0x87, 0xf5, 0x08, 0xe9, 0x0d, 0x70, 0x9d, 0xef, 0x3a,
+	0xa3, 0xa3, 0x3a, 0xb5, 0x86, 0xd8, 0xa3, 0xc6, 0xd0, 0x11, 0x80, 0xea, 0x27, 0x90, 0x6f, 0x1a,
+	0x2e, 0x6d, 0x61, 0x7a, 0x0b, 0x1b, 0x26, 0x76, 0xd1, 0x1a, 0x24, 0x28, 0xa1, 0xc6, 0xa0, 0xa8,
+	0x54, 0x94, 0x8d, 0xbc, 0x26, 0x36, 0x08, 0x81, 0xda, 0x37, 0xbc, 0x7e, 0x31, 0x56, 0x51, 0x36,
+	0x72, 0x1a, 0x5f, 0x57, 0x2d, 0x50, 0x59, 0x28, 0x8b, 0xb0, 0x6c, 0x13, 0x1f, 0xfb, 0x11, 0x7c,
+	0xc3, 0xac, 0x9d, 0x13, 0x8a, 0x3d, 0x19, 0x22, 0x36, 0xe8, 0x43, 0x48, 0xf0, 0x0f, 0x2f, 0xc6,
+	0x2b, 0xca, 0x46, 0x76, 0xeb, 0x62, 0x2d, 0x68, 0x96, 0xe8, 0x4c, 0x6d, 0xbc, 0x59, 0x6b, 0x32,
+	0x40, 0x43, 0x7d, 0xf2, 0x6c, 0x7d, 0x49, 0x13, 0xe8, 0xea, 0x10, 0x52, 0x8d, 0x01, 0xe9, 0xde,
+	0xdb, 0xdb, 0x09, 0x2a, 0x51, 0xc2, 0x4a, 0xd0, 0x01, 0xac, 0x38, 0x86, 0x4b, 0x75, 0x0f, 0x53,
+	0xbd, 0xcf, 0x3f, 0x83, 0x9f, 0x9a, 0xdd, 0xaa, 0xd4, 0x66, 0xc8, 0xa8, 0x45, 0x3e, 0x57, 0x1e,
+	0x93, 0x77, 0x26, 0x8d, 0xd5, 0x3f, 0x54, 0x48, 0xca, 0x76, 0x7c, 0x0a, 0x29, 0xd9, 0x70, 0x7e,
+	0x62, 0x76, 0xab, 0x1c, 0xa6, 0x94, 0x0e, 0x96, 0x74, 0x9b, 0xd8, 0x1e, 0xb6, 0xbd, 0x91, 0x27,
+	0x13, 0xfa, 0x41, 0xe8, 0x2d, 0x48, 0x77, 0xfb, 0x86, 0x65, 0xeb, 0x96, 0xc9, 0x6b, 0xca, 0x34,
+	0xb2, 0xa7, 0xcf, 0xd6, 0x53, 0xdb, 0xcc, 0xb6, 0xb7, 0xa3, 0xa5, 0xb8, 0x73, 0xcf, 0x44, 0xaf,
+	0x42, 0xb2, 0x8f, 0xad, 0x5e, 0x9f, 0xf2, 0xce, 0xc4, 0x35, 0xb9, 0x43, 0x1f, 0x83, 0xca, 0x28,
+	0x2b, 0xaa, 0xfc, 0xf0, 0x52, 0x4d, 0xf0, 0x59, 0xf3, 0xf9, 0xac, 0xb5, 0x7d, 0x3e, 0x1b, 0x69,
+	0x76, 0xf0, 0xc3, 0xdf, 0xd6, 0x15, 0x8d, 0x47, 0xa0, 0x1d, 0xc8, 0x0f, 0x0c, 0x8f, 0xea, 0x1d,
+	0xd6, 0x38, 0x76, 0x7c, 0x42, 0xa6, 0x98, 0x6d, 0x89, 0xec, 0xad, 0xac, 0x3d, 0xcb, 0xc2, 0x84,
+	0xc9, 0x44, 0x1b, 0x50, 0xe0, 0x59, 0xba, 0x64, 0x38, 0xb4, 0xa8, 0xce, 0x5b, 0x9f, 0xe4, 0xad,
+	0x5f, 0x66, 0xf6, 0x6d, 0x6e, 0xbe, 0xc5, 0x48, 0xb8, 0x04, 0x19, 0xd3, 0xa0, 0x86, 0x80, 0xa4,
+	0x38, 0x24, 0xcd, 0x0c, 0xdc, 0xf9, 0x36, 0xac, 0x04, 0x8a, 0xf6, 0x04, 0x24, 0x2d, 0xb2, 0x84,
+	0x66, 0x0e, 0xbc, 0x06, 0x6b, 0x36, 0x3e, 0xa6, 0xfa, 0x34, 0x3a, 0xc3, 0xd1, 0x88, 0xf9, 0xee,
+	0x46, 0x23, 0xde, 0x84, 0xe5, 0xae, 0xdf, 0x7d, 0x81, 0x05, 0x8e, 0xcd, 0x07, 0x56, 0x0e, 0xbb,
+	0x08, 0x69, 0xc3, 0x71, 0x04, 0x20, 0xcb, 0x01, 0x29, 0xc3, 0x71, 0xb8, 0xeb, 0x2a, 0xac, 0xf2,
+	0x6f, 0x74, 0xb1, 0x37, 0x1a, 0x50, 0x99, 0x24, 0xc7, 0x31, 0x2b, 0xcc, 0xa1, 0x09, 0x3b, 0xc7,
+	0xbe, 0x0e, 0x79, 0x3c, 0xb6, 0x4c, 0x6c, 0x77, 0xb1, 0xc0, 0xe5, 0x39, 0x2e, 0xe7, 0x1b, 0x39,
+	0xe8, 0x1d, 0x28, 0x38, 0x2e, 0x71, 0x88, 0x87, 0x5d, 0xdd, 0x30, 0x4d, 0x17, 0x7b, 0x5e, 0x71,
+	0x59, 0xe4, 0xf3, 0xed, 0xd7, 0x85, 0xb9, 0x5a, 0x04, 0x75, 0xc7, 0xa0, 0x06, 0x2a, 0x40, 0x9c,
+	0x1e, 0x7b, 0x45, 0xa5, 0x12, 0xdf, 0xc8, 0x69, 0x6c, 0x59, 0xfd, 0x31, 0x0e, 0xea, 0x5d, 0x42,
+	0x31, 0xfa, 0x00, 0x54, 0xc6, 0x14, 0xd7, 0xdf, 0xf2, 0x5c, 0x49, 0xb7, 0xac, 0x9e, 0x8d, 0xcd,
+	0x7d, 0xaf, 0xd7, 0x3e, 0x71, 0xb0, 0xc6, 0xd1, 0x13, 0x82, 0x8a, 0x45, 0x04, 0xb5, 0x06, 0x09,
+	0x97, 0x8c, 0x6c, 0x93, 0xeb, 0x2c, 0xa1, 0x89, 0x0d, 0xba, 0x01, 0xe9, 0x40, 0x27, 0xea, 0x73,
+	0x75, 0xb2, 0xc2, 0x74, 0xc2, 0x64, 0x2c, 0x0d, 0x5a, 0xaa, 0x23, 0xe5, 0xd2, 0x80, 0x4c, 0xf0,
+	0xc2, 0x14, 0x13, 0x7f, 0x43, 0xb3, 0x61, 0x18, 0x7a, 0x17, 0x56, 0x03, 0xf6, 0x83, 0xf6, 0x09,
+	0xcd, 0x15, 0x02, 0x87, 0xec, 0x5f, 0x44, 0x58, 0xba, 0x78, 0x86, 0x52, 0xfc, 0xc3, 0x42, 0x61,
+	0xed, 0x31, 0x2b, 0xba, 0x0c, 0x19, 0xcf, 0xea, 0xd9, 0x06, 0x1d, 0xb9, 0x58, 0x6a, 0x2f, 0x34,
+	0x30, 0x2f, 0x3e, 0xa6, 0xd8, 0xe6, 0x17, 0x5d, 0x68, 0x2d, 0x34, 0xa0, 0x3a, 0xbc, 0x12, 0x6c,
+	0xf4, 0x30, 0x8b, 0xd0, 0x19, 0x0a, 0x5c, 0x2d, 0xdf, 0x53, 0xfd, 0x49, 0x81, 0xa4, 0xb8, 0x1a,
+	0x13, 0x3c, 0x28, 0xf3, 0x79, 0x88, 0x2d, 0xe2, 0x21, 0xfe, 0x52, 0x3c, 0x40, 0x50, 0xa7, 0x57,
+	0x54, 0x2b, 0xf1, 0x8d, 0xec, 0xd6, 0xe5, 0x39, 0x99, 0x44, 0x91, 0x2d, 0xab, 0x27, 0xef, 0xfe,
+	0x44, 0x54, 0xf5, 0x99, 0x02, 0x99, 0xc0, 0x8f, 0x1a, 0x90, 0xf7, 0x2b, 0xd3, 0x8f, 0x06, 0x46,
+	0x4f, 0xca, 0xb1, 0xbc, 0xb8, 0xbc, 0x1b, 0x03, 0xa3, 0xa7, 0x65, 0x65, 0x45, 0x6c, 0x33, 0x9f,
+	0xd9, 0xd8, 0x02, 0x66, 0x23, 0x52, 0x8a, 0xff, 0x33, 0x29, 0x45, 0x48, 0x57, 0xa7, 0x48, 0xaf,
+	0x9e, 0x29, 0xb0, 0xbc, 0xcb, 0xc8, 0x33, 0xb1, 0xf9, 0x9f, 0xb2, 0xf5, 0x95, 0xd4, 0x97, 0x89,
+	0x4d, 0x7d, 0x86, 0xb6, 0x37, 0xe6, 0xa4, 0x8c, 0x56, 0x1d, 0xd2, 0x87, 0xfc, 0x34, 0xad, 0x90,
+	0xc6, 0xc7, 0x31, 0x58, 0x9d, 0xc1, 0xff, 0x0f, 0xe9, 0x8c, 0xde, 0xe1, 0xc4, 0x0b, 0xde, 0xe1,
+	0xe4, 0xc2, 0x3b, 0xfc, 0x38, 0x06, 0xe9, 0x26, 0x7f, 0xad, 0x8d, 0xc1, 0xbf, 0xf2, 0x06, 0x5f,
+	0x82, 0x8c, 0x43, 0x06, 0xba, 0xf0, 0xa8, 0xdc, 0x93, 0x76, 0xc8, 0x40, 0x9b, 0x91, 0x5a, 0xe2,
+	0xbc, 0x1e, 0xe8, 0xe4, 0x39, 0xd0, 0x90, 0x9a, 0xbe, 0x55, 0x14, 0x72, 0xa2, 0x17, 0x72, 0x82,
+	0xda, 0x64, 0x4d, 0x60, 0xab, 0xa2, 0x32, 0x3d, 0xf3, 0x05, 0x75, 0x0b, 0xa8, 0x96, 0xec, 0x07,
+	0x21, 0x62, 0xde, 0x28, 0xc6, 0x16, 0x86, 0x08, 0x29, 0x6b, 0x12, 0x58, 0xfd, 0x56, 0x01, 0xb8,
+	0xcd, 0x9a, 0xcb, 0xbf, 0x98, 0x0d, 0x3f, 0x1e, 0x2f, 0x42, 0x8f, 0x9c, 0xbd, 0xbe, 0x90, 0x38,
+	0x59, 0x41, 0xce, 0x9b, 0x2c, 0x7d, 0x07, 0xf2, 0xa1, 0xc0, 0x3d, 0xec, 0x97, 0x33, 0x2f, 0x4b,
+	0x30, 0x94, 0xb4, 0x30, 0xd5, 0x72, 0xe3, 0x89, 0x5d, 0xf5, 0x67, 0x05, 0x32, 0xbc, 0xaa, 0x7d,
+	0x4c, 0x8d, 0x08, 0x91, 0xca, 0x4b, 0x10, 0x79, 0x05, 0x40, 0xe4, 0xf1, 0xac, 0xfb, 0x58, 0xea,
+	0x2b, 0xc3, 0x2d, 0x2d, 0xeb, 0x3e, 0x46, 0x1f, 0x05, 0x5d, 0x8f, 0x3f, 0xa7, 0xeb, 0xf2, 0xe9,
+	0xf0, 0x7b, 0x7f, 0x01, 0x52, 0xf6, 0x68, 0xa8, 0xb3, 0x61, 0x44, 0x15, 0xa2, 0xb5, 0x47, 0xc3,
+	0xf6, 0xb1, 0x57, 0xbd, 0x07, 0xa9, 0xf6, 0x31, 0x9f, 0xcd, 0x99, 0x52, 0x5d, 0x42, 0xe4, 0x34,
+	0x28, 0x06, 0xf1, 0x34, 0x33, 0xf0, 0xe1, 0x07, 0x81, 0xca, 0xc6, 0x3e, 0xff, 0x57, 0x81, 0xad,
+	0x51, 0xfd, 0x45, 0xc7, 0x7e, 0x7f, 0xe0, 0xff, 0x53, 0x81, 0xec, 0x75, 0x4a, 0x99, 0xe0, 0x28,
+	0xbb, 0xbd, 0x8b, 0xde, 0xe5, 0xa0, 0x0b, 0x13, 0x7f, 0x27, 0xa2, 0x0b, 0x33, 0x43, 0x5f, 0x3c,
+	0x3a, 0xf4, 0x45, 0x2e, 0x82, 0x7a, 0x8e, 0x93, 0x4a, 0x62, 0xc1, 0x03, 0x18, 0xb9, 0x35, 0xc9,
+	0xa9, 0x5b, 0x73, 0xf5, 0x17, 0x05, 0xf2, 0x91, 0x27, 0x04, 0xbd, 0x07, 0x17, 0x5a, 0x7b, 0x37,
+	0x0f, 0x76, 0x77, 0xf4, 0xfd, 0xd6, 0x4d, 0xbd, 0xfd, 0x45, 0x73, 0x57, 0xbf, 0x73, 0xf0, 0xd9,
+	0xc1, 0xe1, 0xe7, 0x07, 0x85, 0xa5, 0xd2, 0xca, 0x83, 0x47, 0x95, 0xec, 0x1d, 0xfb, 0x9e, 0x4d,
+	0xbe, 0xb6, 0x17, 0xa1, 0x9b, 0xda, 0xee, 0xdd, 0xc3, 0xf6, 0x6e, 0x41, 0x11, 0xe8, 0xa6, 0x8b,
+	0xc7, 0x84, 0x62, 0x8e, 0xbe, 0x06, 0x17, 0xe7, 0xa0, 0xb7, 0x0f, 0xf7, 0xf7, 0xf7, 0xda, 0x85,
+	0x58, 0x69, 0xf5, 0xc1, 0xa3, 0x4a, 0xbe, 0xe9, 0x62, 0x71, 0xb7, 0x78, 0x44, 0x0d, 0x8a, 0xb3,
+	0x11, 0x87, 0xcd, 0xc3, 0xd6, 0xf5, 0xdb, 0x85, 0x4a, 0xa9, 0xf0, 0xe0, 0x51, 0x25, 0xe7, 0x3f,
+	0x96, 0x0c, 0x5f, 0x4a, 0x7f, 0xf3, 0x5d, 0x79, 0xe9, 0x87, 0xef, 0xcb, 0x4a, 0xe3, 0xf6, 0x93,
+	0xd3, 0xb2, 0xf2, 0xf4, 0xb4, 0xac, 0xfc, 0x7e, 0x5a, 0x56, 0x1e, 0x9e, 0x95, 0x97, 0x9e, 0x9e,
+	0x95, 0x97, 0x7e, 0x3d, 0x2b, 0x2f, 0x7d, 0xb9, 0xd5, 0xb3, 0x68, 0x7f, 0xd4, 0x61, 0x62, 0xa8,
+	0x87, 0x7f, 0xc8, 0xfe, 0xc2, 0x70, 0xac, 0xfa, 0xcc, 0x7f, 0x71, 0x27, 0xc9, 0xb9, 0x79, 0xff,
+	0xaf, 0x01, 0x00, 0x3a, 0xa5, 0x91, 0x93, 0x85, 0x0f, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -2151,6 +2241,70 @@ func (m *TxProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Attestation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Attestation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Attestation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.ValidatorAddress) > 0 {
+		i -= len(m.ValidatorAddress)
+		copy(dAtA[i:], m.ValidatorAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ValidatorAddress)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	n21, err21 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err21 != nil {
+		return 0, err21
+	}
+	i -= n21
+	i = encodeVarintTypes(dAtA, i, uint64(n21))
+	i--
+	dAtA[i] = 0x22
+	if len(m.AppHash) > 0 {
+		i -= len(m.AppHash)
+		copy(dAtA[i:], m.AppHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AppHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -2526,6 +2680,36 @@ func (m *TxProof) Size() (n int) {
 	return n
 }
 
+func (m *Attestation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.ValidatorAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -5300,6 +5484,244 @@ func (m *TxProof) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Attestation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Attestation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Attestation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorAddress = append(m.ValidatorAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValidatorAddress == nil {
+				m.ValidatorAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
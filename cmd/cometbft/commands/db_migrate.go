@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/spf13/cobra"
+)
+
+// DBMigrateCmd copies the databases of the node to another backend.
+var DBMigrateCmd = &cobra.Command{
+	Use:     "experimental-db-migrate",
+	Aliases: []string{"experimental_db_migrate"},
+	Short:   "Migrate the databases of the node to another database backend",
+	Long: `
+Copy every key of the databases of the node (blockstore, state, evidence,
+tx_index and eventlog) from the --from backend to the --to backend, then read
+both copies again to verify that they hold the same keys and values.
+
+The databases are written to a staging directory in the data directory, and
+replace the former ones only once all of them are verified: the former
+databases are moved to a backup directory, which can be deleted after the node
+was restarted with the new db_backend. An interrupted migration leaves the
+former databases untouched, and can be run again.
+
+The node must be stopped, and both backends must be compiled in the binary.
+`,
+	Example: `
+	cometbft experimental-db-migrate --to pebbledb
+	cometbft experimental-db-migrate --from goleveldb --to rocksdb --batch-size 50000
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to := dbm.BackendType(migrateFrom), dbm.BackendType(migrateTo)
+		if from == "" {
+			from = dbm.BackendType(config.DBBackend)
+		}
+		switch {
+		case to == "":
+			return errors.New("--to is required")
+		case from == to:
+			return fmt.Errorf("the databases already use %s", from)
+		case from == dbm.MemDBBackend || to == dbm.MemDBBackend:
+			return errors.New("memdb databases are not persisted")
+		case migrateBatchSize <= 0:
+			return errors.New("--batch-size must be positive")
+		}
+
+		backupDir, err := migrateDBs(config.DBDir(), from, to, migrateBatchSize, logger)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Migrated the databases to %s: set db_backend = %q in config.toml before starting the node.\n", to, to)
+		fmt.Printf("The former databases were moved to %s.\n", backupDir)
+		return nil
+	},
+}
+
+var (
+	migrateFrom      string
+	migrateTo        string
+	migrateBatchSize int
+)
+
+// migrateProgressInterval is the number of keys copied between two progress
+// reports.
+const migrateProgressInterval = 100000
+
+// migratedStores are the databases of the node, in the data directory.
+var migratedStores = []string{"blockstore", "state", "evidence", "tx_index", "eventlog"}
+
+func init() {
+	DBMigrateCmd.Flags().StringVar(&migrateFrom, "from", "", "backend of the databases; defaults to db_backend")
+	DBMigrateCmd.Flags().StringVar(&migrateTo, "to", "", "backend to migrate the databases to")
+	DBMigrateCmd.Flags().IntVar(&migrateBatchSize, "batch-size", 10000, "number of keys written in each batch")
+}
+
+// migrateDBs copies the databases in dbDir from one backend to another, and
+// replaces them once all of them are verified. It returns the directory the
+// former databases were moved to.
+func migrateDBs(dbDir string, from, to dbm.BackendType, batchSize int, logger log.Logger) (string, error) {
+	stagingDir := filepath.Join(dbDir, "migrate-"+string(to))
+	backupDir := filepath.Join(dbDir, "backup-"+string(from))
+	if cmtos.FileExists(backupDir) {
+		return "", fmt.Errorf("backup directory %s already exists, move it away first", backupDir)
+	}
+	// Left by an interrupted migration.
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return "", err
+	}
+
+	var stores []string
+	for _, name := range migratedStores {
+		if cmtos.FileExists(dbPath(dbDir, name, from)) {
+			stores = append(stores, name)
+		}
+	}
+	if len(stores) == 0 {
+		return "", fmt.Errorf("no %s database found in %s", from, dbDir)
+	}
+
+	for _, name := range stores {
+		if err := migrateDB(name, dbDir, stagingDir, from, to, batchSize, logger); err != nil {
+			return "", fmt.Errorf("failed to migrate %s: %w", name, err)
+		}
+	}
+
+	if err := os.MkdirAll(backupDir, 0o700); err != nil {
+		return "", err
+	}
+	for _, name := range stores {
+		if err := os.Rename(dbPath(dbDir, name, from), dbPath(backupDir, name, from)); err != nil {
+			return "", err
+		}
+		if err := os.Rename(dbPath(stagingDir, name, to), dbPath(dbDir, name, to)); err != nil {
+			return "", err
+		}
+	}
+	return backupDir, os.RemoveAll(stagingDir)
+}
+
+// migrateDB copies the database name from dbDir to stagingDir, and verifies
+// the copy.
+func migrateDB(name, dbDir, stagingDir string, from, to dbm.BackendType, batchSize int, logger log.Logger) error {
+	src, err := dbm.NewDB(name, from, dbDir)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := dbm.NewDB(name, to, stagingDir)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Migrating the database", "store", name, "from", from, "to", to)
+	start := time.Now()
+	keys, err := copyDB(src, dst, batchSize, func(keys, size int64) {
+		logger.Info("Copying the database", "store", name, "keys", keys, "bytes", size)
+	})
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("Copied the database", "store", name, "keys", keys, "duration", time.Since(start))
+
+	// Reopened, to read what was persisted.
+	dst, err = dbm.NewDB(name, to, stagingDir)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	if err := verifyDB(src, dst); err != nil {
+		return err
+	}
+	logger.Info("Verified the database", "store", name, "keys", keys)
+	return nil
+}
+
+// copyDB writes the keys of src to dst, in batches of batchSize keys, and
+// returns the number of keys copied. It calls progress every
+// migrateProgressInterval keys.
+func copyDB(src, dst dbm.DB, batchSize int, progress func(keys, bytes int64)) (int64, error) {
+	it, err := src.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	var keys, size int64
+	batch := dst.NewBatch()
+	defer func() { batch.Close() }()
+	for ; it.Valid(); it.Next() {
+		if err := batch.Set(it.Key(), it.Value()); err != nil {
+			return keys, err
+		}
+		keys++
+		size += int64(len(it.Key()) + len(it.Value()))
+		if keys%int64(batchSize) == 0 {
+			if err := batch.Write(); err != nil {
+				return keys, err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+		if keys%migrateProgressInterval == 0 {
+			progress(keys, size)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return keys, err
+	}
+	return keys, batch.WriteSync()
+}
+
+// verifyDB checks that src and dst hold the same keys and values.
+func verifyDB(src, dst dbm.DB) error {
+	srcIt, err := src.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer srcIt.Close()
+	dstIt, err := dst.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer dstIt.Close()
+
+	for ; srcIt.Valid(); srcIt.Next() {
+		if !dstIt.Valid() {
+			return fmt.Errorf("key %X is missing from the migrated database", srcIt.Key())
+		}
+		if !bytes.Equal(srcIt.Key(), dstIt.Key()) {
+			return fmt.Errorf("migrated database holds key %X instead of %X", dstIt.Key(), srcIt.Key())
+		}
+		if !bytes.Equal(srcIt.Value(), dstIt.Value()) {
+			return fmt.Errorf("value of key %X differs in the migrated database", srcIt.Key())
+		}
+		dstIt.Next()
+	}
+	if dstIt.Valid() {
+		return fmt.Errorf("migrated database holds the extra key %X", dstIt.Key())
+	}
+	if err := srcIt.Error(); err != nil {
+		return err
+	}
+	return dstIt.Error()
+}
+
+// dbPath returns the path of the database name in dir, as created by the
+// backend.
+func dbPath(dir, name string, backend dbm.BackendType) string {
+	if backend == dbm.BadgerDBBackend {
+		return filepath.Join(dir, name)
+	}
+	return filepath.Join(dir, name+".db")
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestMigrateDBs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"blockstore", "state"} {
+		db, err := dbm.NewDB(name, dbm.GoLevelDBBackend, dir)
+		require.NoError(t, err)
+		for i := 0; i < 250; i++ {
+			require.NoError(t, db.Set([]byte(fmt.Sprintf("%s/%03d", name, i)), []byte{byte(i)}))
+		}
+		require.NoError(t, db.Close())
+	}
+
+	// Both databases in the same backend, the only persisted one compiled in
+	// the tests.
+	backupDir, err := migrateDBs(dir, dbm.GoLevelDBBackend, dbm.GoLevelDBBackend, 100, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "backup-goleveldb"), backupDir)
+	require.NoDirExists(t, filepath.Join(dir, "migrate-goleveldb"))
+
+	for _, name := range []string{"blockstore", "state"} {
+		require.DirExists(t, filepath.Join(backupDir, name+".db"))
+		db, err := dbm.NewDB(name, dbm.GoLevelDBBackend, dir)
+		require.NoError(t, err)
+		for i := 0; i < 250; i++ {
+			v, err := db.Get([]byte(fmt.Sprintf("%s/%03d", name, i)))
+			require.NoError(t, err)
+			require.Equal(t, []byte{byte(i)}, v)
+		}
+		require.NoError(t, db.Close())
+	}
+
+	// The backup is kept.
+	_, err = migrateDBs(dir, dbm.GoLevelDBBackend, dbm.GoLevelDBBackend, 100, log.NewNopLogger())
+	require.ErrorContains(t, err, "already exists")
+}
+
+func TestCopyDB(t *testing.T) {
+	src, dst := dbm.NewMemDB(), dbm.NewMemDB()
+	for i := 0; i < 25; i++ {
+		require.NoError(t, src.Set([]byte{byte(i)}, []byte("value")))
+	}
+	keys, err := copyDB(src, dst, 10, func(int64, int64) {})
+	require.NoError(t, err)
+	require.EqualValues(t, 25, keys)
+	require.NoError(t, verifyDB(src, dst))
+}
+
+func TestVerifyDB(t *testing.T) {
+	src := dbm.NewMemDB()
+	require.NoError(t, src.Set([]byte("a"), []byte("1")))
+	require.NoError(t, src.Set([]byte("b"), []byte("2")))
+
+	dst := dbm.NewMemDB()
+	require.NoError(t, dst.Set([]byte("a"), []byte("1")))
+	require.ErrorContains(t, verifyDB(src, dst), "missing")
+
+	require.NoError(t, dst.Set([]byte("b"), []byte("3")))
+	require.ErrorContains(t, verifyDB(src, dst), "differs")
+
+	require.NoError(t, dst.Set([]byte("b"), []byte("2")))
+	require.NoError(t, dst.Set([]byte("c"), []byte("3")))
+	require.ErrorContains(t, verifyDB(src, dst), "extra key")
+
+	require.NoError(t, dst.Delete([]byte("c")))
+	require.NoError(t, verifyDB(src, dst))
+}
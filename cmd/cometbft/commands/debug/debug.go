@@ -2,6 +2,7 @@ package debug
 
 import (
 	"os"
+	"time"
 
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/spf13/cobra"
@@ -10,11 +11,17 @@ import (
 var (
 	nodeRPCAddr string
 	profAddr    string
+	metricsAddr string
 	frequency   uint
+	cpuProfile  time.Duration
+	maxArchives uint
 
 	flagNodeRPCAddr = "rpc-laddr"
 	flagProfAddr    = "pprof-laddr"
+	flagMetricsAddr = "metrics-laddr"
 	flagFrequency   = "frequency"
+	flagCPUProfile  = "cpu-profile"
+	flagMaxArchives = "max-archives"
 
 	logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
 )
@@ -23,7 +30,7 @@ var (
 // debugging running CometBFT processes.
 var DebugCmd = &cobra.Command{
 	Use:   "debug",
-	Short: "A utility to kill or watch a CometBFT process while aggregating debugging data",
+	Short: "A utility to kill or watch a CometBFT process while aggregating debugging data, or to inspect its WAL",
 }
 
 func init() {
@@ -37,4 +44,5 @@ func init() {
 
 	DebugCmd.AddCommand(killCmd)
 	DebugCmd.AddCommand(dumpCmd)
+	DebugCmd.AddCommand(walCmd)
 }
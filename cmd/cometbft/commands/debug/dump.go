@@ -19,8 +19,10 @@ var dumpCmd = &cobra.Command{
 	Short: "Continuously poll a CometBFT process and dump debugging data into a single location",
 	Long: `Continuously poll a CometBFT process and dump debugging data into a single
 location at a specified frequency. At each frequency interval, an archived and compressed
-file will contain node debugging information including the goroutine and heap profiles
-if enabled.`,
+file will contain node debugging information including the goroutine, heap and CPU
+profiles and a snapshot of the metrics if enabled. Only the latest archives are kept
+if a maximum number of archives is set, so that the command can run unattended and
+the archives be attached to incident reports.`,
 	Args: cobra.ExactArgs(1),
 	RunE: dumpCmdHandler,
 }
@@ -39,6 +41,27 @@ func init() {
 		"",
 		"the profiling server address (<host>:<port>)",
 	)
+
+	dumpCmd.Flags().DurationVar(
+		&cpuProfile,
+		flagCPUProfile,
+		0,
+		"the duration of the CPU profile in each archive, which must be shorter than the frequency; 0 disables it",
+	)
+
+	dumpCmd.Flags().StringVar(
+		&metricsAddr,
+		flagMetricsAddr,
+		"",
+		"the Prometheus metrics server address (<host>:<port>)",
+	)
+
+	dumpCmd.Flags().UintVar(
+		&maxArchives,
+		flagMaxArchives,
+		0,
+		"the maximum number of archives to keep in the output directory, removing the oldest ones; 0 keeps all of them",
+	)
 }
 
 func dumpCmdHandler(_ *cobra.Command, args []string) error {
@@ -51,6 +74,15 @@ func dumpCmdHandler(_ *cobra.Command, args []string) error {
 		return errors.New("frequency must be positive")
 	}
 
+	if cpuProfile > 0 {
+		if profAddr == "" {
+			return fmt.Errorf("--%s requires --%s", flagCPUProfile, flagProfAddr)
+		}
+		if cpuProfile < time.Second || cpuProfile >= time.Duration(frequency)*time.Second {
+			return errors.New("CPU profile duration must be at least a second and shorter than the frequency")
+		}
+	}
+
 	if _, err := os.Stat(outDir); os.IsNotExist(err) {
 		if err := os.Mkdir(outDir, os.ModePerm); err != nil {
 			return fmt.Errorf("failed to create output directory: %w", err)
@@ -123,10 +155,33 @@ func dumpDebugData(outDir string, conf *cfg.Config, rpc *rpchttp.HTTP) {
 			logger.Error("failed to dump heap profile", "error", err)
 			return
 		}
+
+		if cpuProfile > 0 {
+			logger.Info("getting node CPU profile...", "duration", cpuProfile)
+			if err := dumpCPUProfile(tmpDir, profAddr, cpuProfile); err != nil {
+				logger.Error("failed to dump CPU profile", "error", err)
+				return
+			}
+		}
+	}
+
+	if metricsAddr != "" {
+		logger.Info("getting node metrics...")
+		if err := dumpMetrics(tmpDir, metricsAddr); err != nil {
+			logger.Error("failed to dump node metrics", "error", err)
+			return
+		}
 	}
 
 	outFile := filepath.Join(outDir, fmt.Sprintf("%s.zip", start.Format(time.RFC3339)))
 	if err := zipDir(tmpDir, outFile); err != nil {
 		logger.Error("failed to create and compress archive", "file", outFile, "error", err)
+		return
+	}
+
+	if maxArchives > 0 {
+		if err := rotateArchives(outDir, int(maxArchives)); err != nil {
+			logger.Error("failed to remove old archives", "dir", outDir, "error", err)
+		}
 	}
 }
@@ -0,0 +1,115 @@
+package debug
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// testNode serves the RPC, profiling and metrics endpoints queried by the
+// dumps, each answering with the name of the endpoint.
+func testNode(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		"status": rpcserver.NewRPCFunc(func(*rpctypes.Context) (*ctypes.ResultStatus, error) {
+			return &ctypes.ResultStatus{}, nil
+		}, ""),
+		"net_info": rpcserver.NewRPCFunc(func(*rpctypes.Context) (*ctypes.ResultNetInfo, error) {
+			return &ctypes.ResultNetInfo{NPeers: 3}, nil
+		}, ""),
+		"dump_consensus_state": rpcserver.NewRPCFunc(func(*rpctypes.Context) (*ctypes.ResultDumpConsensusState, error) {
+			return &ctypes.ResultDumpConsensusState{RoundState: json.RawMessage(`{}`)}, nil
+		}, ""),
+	}, log.TestingLogger())
+	for _, endpoint := range []string{"/debug/pprof/goroutine", "/debug/pprof/heap", "/debug/pprof/profile", "/metrics"} {
+		endpoint := endpoint
+		mux.HandleFunc(endpoint, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, r.URL.String())
+		})
+	}
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDumpDebugData(t *testing.T) {
+	srv := testNode(t)
+
+	conf := cfg.DefaultConfig().SetRoot(t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Dir(conf.Consensus.WalFile()), 0o700))
+	require.NoError(t, os.WriteFile(conf.Consensus.WalFile(), []byte("wal"), 0o600))
+
+	oldProfAddr, oldMetricsAddr, oldCPUProfile, oldMaxArchives := profAddr, metricsAddr, cpuProfile, maxArchives
+	t.Cleanup(func() {
+		profAddr, metricsAddr, cpuProfile, maxArchives = oldProfAddr, oldMetricsAddr, oldCPUProfile, oldMaxArchives
+	})
+	profAddr, metricsAddr, cpuProfile, maxArchives = srv.URL, srv.URL, 5*time.Second, 2
+
+	// Only the newest archive is kept along with the one being dumped.
+	outDir := t.TempDir()
+	for _, old := range []string{"2006-01-02T15:04:05Z.zip", "2006-01-02T15:04:06Z.zip"} {
+		require.NoError(t, os.WriteFile(filepath.Join(outDir, old), nil, 0o600))
+	}
+
+	rpc, err := rpchttp.New(srv.URL)
+	require.NoError(t, err)
+	dumpDebugData(outDir, conf, rpc)
+
+	archives, err := filepath.Glob(filepath.Join(outDir, "*.zip"))
+	require.NoError(t, err)
+	require.Len(t, archives, 2)
+	assert.Equal(t, "2006-01-02T15:04:06Z.zip", filepath.Base(archives[0]))
+
+	files := readArchive(t, archives[1])
+	for _, name := range []string{"status.json", "net_info.json", "consensus_state.json", "wal"} {
+		assert.Contains(t, files, name)
+	}
+	assert.Contains(t, files["net_info.json"], `"n_peers": 3`)
+	assert.Equal(t, "/debug/pprof/goroutine?debug=2", files["goroutine.out"])
+	assert.Equal(t, "/debug/pprof/heap?debug=2", files["heap.out"])
+	assert.Equal(t, "/debug/pprof/profile?seconds=5", files["cpu.pprof"])
+	assert.Equal(t, "/metrics", files["metrics.txt"])
+}
+
+// readArchive returns the contents of the files in a dump archive by name.
+func readArchive(t *testing.T, archive string) map[string]string {
+	t.Helper()
+
+	r, err := zip.OpenReader(archive)
+	require.NoError(t, err)
+	defer r.Close()
+
+	files := make(map[string]string)
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		require.NoError(t, err)
+		bz, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		files[filepath.Base(f.Name)] = string(bz)
+	}
+	return files
+}
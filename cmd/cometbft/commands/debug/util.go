@@ -6,8 +6,10 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"path"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	cfg "github.com/cometbft/cometbft/config"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
@@ -65,19 +67,62 @@ func copyConfig(home, dir string) error {
 }
 
 func dumpProfile(dir, addr, profile string, debug int) error {
-	endpoint := fmt.Sprintf("%s/debug/pprof/%s?debug=%d", addr, profile, debug)
+	endpoint := fmt.Sprintf("/debug/pprof/%s?debug=%d", profile, debug)
+	return dumpHTTP(addr, endpoint, filepath.Join(dir, fmt.Sprintf("%s.out", profile)))
+}
+
+// dumpCPUProfile profiles the CPU usage of the node for the given duration, and
+// writes the profile, in the format of pprof, to a file.
+func dumpCPUProfile(dir, addr string, duration time.Duration) error {
+	endpoint := fmt.Sprintf("/debug/pprof/profile?seconds=%d", int(duration.Seconds()))
+	return dumpHTTP(addr, endpoint, filepath.Join(dir, "cpu.pprof"))
+}
+
+// dumpMetrics gets a snapshot of the Prometheus metrics of the node and writes
+// it to file.
+func dumpMetrics(dir, addr string) error {
+	return dumpHTTP(addr, "/metrics", filepath.Join(dir, "metrics.txt"))
+}
+
+// dumpHTTP gets the given endpoint of the server at addr and writes the
+// response body to file. The scheme of addr defaults to http.
+func dumpHTTP(addr, endpoint, file string) error {
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
 
 	//nolint:gosec,nolintlint,noctx
-	resp, err := http.Get(endpoint)
+	resp, err := http.Get(addr + endpoint)
 	if err != nil {
-		return fmt.Errorf("failed to query for %s profile: %w", profile, err)
+		return fmt.Errorf("failed to query %s: %w", endpoint, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to query %s: %s", endpoint, resp.Status)
+	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read %s profile response body: %w", profile, err)
+		return fmt.Errorf("failed to read %s response body: %w", endpoint, err)
 	}
 
-	return os.WriteFile(path.Join(dir, fmt.Sprintf("%s.out", profile)), body, os.ModePerm)
+	return os.WriteFile(file, body, os.ModePerm)
+}
+
+// rotateArchives removes the oldest archives in dir, so that at most keep are
+// left. The names of the archives, their UTC creation times in the format of
+// RFC 3339, sort chronologically.
+func rotateArchives(dir string, keep int) error {
+	archives, err := filepath.Glob(filepath.Join(dir, "*.zip"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(archives)
+	for len(archives) > keep {
+		if err := os.Remove(archives[0]); err != nil {
+			return err
+		}
+		archives = archives[1:]
+	}
+	return nil
 }
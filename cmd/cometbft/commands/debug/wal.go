@@ -0,0 +1,127 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cs "github.com/cometbft/cometbft/internal/consensus"
+	"github.com/cometbft/cometbft/libs/cli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	walHeight int64
+	walRound  int32
+	walTypes  []string
+	walJSON   bool
+
+	flagHeight = "height"
+	flagRound  = "round"
+	flagType   = "type"
+	flagJSON   = "json"
+)
+
+var walCmd = &cobra.Command{
+	Use:   "wal [wal-file]",
+	Short: "Decode the consensus WAL and print a timeline of its messages",
+	Long: `Decode the consensus WAL, including its rotated files, and print a timeline of
+its messages, optionally filtered by height, round and message type. Each line holds
+the time of the message, the time elapsed since the previous message printed, the
+height and round, the type of the message, the peer it was received from, if any,
+and the message itself. With --json, each message is printed as a JSON object
+instead, one per line.
+
+The types are round_state, timeout, end_height, and the consensus messages, e.g.
+proposal, block_part and vote.
+
+The WAL file defaults to the one of the node in the home directory.
+
+Example:
+$ cometbft debug wal --height 1000 --type vote,timeout`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: walCmdHandler,
+}
+
+func init() {
+	walCmd.Flags().Int64Var(&walHeight, flagHeight, 0, "only print the messages of this height; 0 prints all of them")
+	walCmd.Flags().Int32Var(&walRound, flagRound, -1, "only print the messages of this round; -1 prints all of them")
+	walCmd.Flags().StringSliceVar(&walTypes, flagType, nil, "only print the messages of these types")
+	walCmd.Flags().BoolVar(&walJSON, flagJSON, false, "print the messages as JSON objects")
+}
+
+func walCmdHandler(cmd *cobra.Command, args []string) error {
+	var walFile string
+	if len(args) > 0 {
+		walFile = args[0]
+	} else {
+		conf := cfg.DefaultConfig().SetRoot(viper.GetString(cli.HomeFlag))
+		walFile = conf.Consensus.WalFile()
+	}
+
+	out := cmd.OutOrStdout()
+	var (
+		last   time.Time
+		werr   error
+		filter = func(e cs.WALEntry) bool {
+			return (walHeight == 0 || e.Height == walHeight) &&
+				(walRound == -1 || e.Round == walRound) &&
+				(len(walTypes) == 0 || slices.Contains(walTypes, e.Type))
+		}
+	)
+	corrupted, err := cs.InspectWAL(walFile, func(e cs.WALEntry) {
+		if werr != nil || !filter(e) {
+			return
+		}
+		if walJSON {
+			werr = printWALEntryJSON(out, e)
+		} else {
+			werr = printWALEntry(out, e, last)
+		}
+		last = e.Time
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decode WAL: %w", err)
+	}
+	if werr != nil {
+		return werr
+	}
+	if corrupted {
+		fmt.Fprintln(cmd.ErrOrStderr(), "WAL has corrupted messages; the messages following them in the same file were skipped")
+	}
+	return nil
+}
+
+// printWALEntry prints an entry of the WAL as a line of the timeline, with the
+// time elapsed since the previous entry printed, at last.
+func printWALEntry(w io.Writer, e cs.WALEntry, last time.Time) error {
+	elapsed := ""
+	if !last.IsZero() {
+		elapsed = "+" + e.Time.Sub(last).String()
+	}
+	round := "-"
+	if e.Round >= 0 {
+		round = fmt.Sprint(e.Round)
+	}
+	peer := "self"
+	if e.PeerID != "" {
+		peer = string(e.PeerID)
+	}
+	_, err := fmt.Fprintf(w, "%s %12s  H:%d R:%s  %-12s %-8.8s %s\n",
+		e.Time.UTC().Format("2006-01-02T15:04:05.000Z"), elapsed, e.Height, round, e.Type, peer, e.Msg)
+	return err
+}
+
+// printWALEntryJSON prints an entry of the WAL as a JSON object on a line.
+func printWALEntryJSON(w io.Writer, e cs.WALEntry) error {
+	bz, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal WAL message: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", bz)
+	return err
+}
@@ -30,7 +30,7 @@ func initFilesWithConfig(config *cfg.Config) error {
 	privValStateFile := config.PrivValidatorStateFile()
 	var pv *privval.FilePV
 	if cmtos.FileExists(privValKeyFile) {
-		pv = privval.LoadFilePV(privValKeyFile, privValStateFile)
+		pv = privval.LoadFilePV(privValKeyFile, privValStateFile, privValidatorPassphrase(config))
 		logger.Info("Found private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
 	} else {
@@ -52,9 +52,12 @@ func initFilesWithConfig(config *cfg.Config) error {
 
 	// genesis file
 	genFile := config.GenesisFile()
-	if cmtos.FileExists(genFile) {
+	switch {
+	case cmtos.FileExists(genFile):
 		logger.Info("Found genesis file", "path", genFile)
-	} else {
+	case config.GenesisURL() != "":
+		logger.Info("The node will download the genesis file", "url", config.GenesisURL(), "path", genFile)
+	default:
 		genDoc := types.GenesisDoc{
 			ChainID:         fmt.Sprintf("test-chain-%v", cmtrand.Str(6)),
 			GenesisTime:     cmttime.Now(),
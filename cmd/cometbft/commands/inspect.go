@@ -20,13 +20,17 @@ var InspectCmd = &cobra.Command{
 	Use:   "inspect",
 	Short: "Run an inspect server for investigating CometBFT state",
 	Long: `
-	inspect runs a subset of CometBFT's RPC endpoints that are useful for debugging
-	issues with CometBFT.
+	inspect runs a read-only node, serving the query RPC endpoints of CometBFT
+	from the data directory, without p2p, consensus or application: status,
+	genesis, blocks, headers, commits, block results, validators, consensus
+	parameters, light blocks and the transaction and block searches.
 
 	When the CometBFT detects inconsistent state, it will crash the
 	CometBFT process. CometBFT will not start up while in this inconsistent state.
 	The inspect command can be used to query the block and state store using CometBFT
-	RPC calls to debug issues of inconsistent state.
+	RPC calls to debug issues of inconsistent state, or to extract data from the
+	disk of a stopped node. The node must be stopped, as the databases are locked
+	by the running node.
 	`,
 
 	RunE: runInspect,
@@ -76,7 +80,8 @@ func runInspect(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
-	ins := inspect.New(config.RPC, blockStore, stateStore, txIndexer, blockIndexer)
+	ins := inspect.New(config.RPC, blockStore, stateStore, txIndexer, blockIndexer,
+		inspect.WithGenesisDoc(genDoc), inspect.WithDBDir(config.DBDir()))
 
 	logger.Info("starting inspect server")
 	return ins.Run(ctx)
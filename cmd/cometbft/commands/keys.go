@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/spf13/cobra"
+)
+
+// KeysCmd groups the commands handling this node's keys: the private
+// validator key, and the p2p identity key under the node subcommand.
+var KeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage this node's private validator and p2p keys",
+}
+
+var encryptKeyCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt this node's private validator key file with a passphrase",
+	Long: `
+Encrypt the private validator key file in place, with a key derived from a
+passphrase (argon2id) and XChaCha20-Poly1305. The passphrase is read from the
+file given with --passphrase-file, or else prompted on the terminal.
+
+The node then needs the passphrase to start: it reads it from
+priv_validator_key_passphrase_file, from the priv_validator_key_passphrase
+systemd credential, or else prompts for it.
+`,
+	RunE: encryptKey,
+}
+
+var passphraseFile string
+
+func init() {
+	encryptKeyCmd.Flags().StringVar(&passphraseFile, "passphrase-file", "",
+		"file containing the passphrase to encrypt the key file with")
+	KeysCmd.AddCommand(encryptKeyCmd)
+}
+
+func encryptKey(*cobra.Command, []string) error {
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !cmtos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+
+	passphrase, err := newPassphrase()
+	if err != nil {
+		return err
+	}
+	if err := privval.EncryptKeyFile(keyFilePath, passphrase); err != nil {
+		return err
+	}
+	logger.Info("Encrypted private validator key", "keyFile", keyFilePath)
+	return nil
+}
+
+// newPassphrase reads the passphrase to encrypt the key file with from
+// passphraseFile, or else prompts for it twice.
+func newPassphrase() ([]byte, error) {
+	if passphraseFile != "" {
+		return privval.NewPassphraseFunc(passphraseFile)()
+	}
+	passphrase, err := privval.PromptPassphrase("Enter the new passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	confirmation, err := privval.PromptPassphrase("Repeat the new passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(passphrase, confirmation) {
+		return nil, errors.New("the passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+// privValidatorPassphrase returns the option providing the passphrase of the
+// private validator key, if encrypted, as configured.
+func privValidatorPassphrase(config *cfg.Config) privval.LoadOption {
+	return privval.WithPassphrase(privval.NewPassphraseFunc(config.PrivValidatorKeyPassphraseFile()))
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"fmt"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/spf13/cobra"
+)
+
+// nodeKeyCmd groups the commands handling this node's p2p identity, the
+// node_key.json file, which is independent of the private validator key.
+var nodeKeyCmd = &cobra.Command{
+	Use:   "node",
+	Short: "Manage this node's p2p identity key",
+}
+
+var genNodeKeyCmd = &cobra.Command{
+	Use:   "gen",
+	Short: "Generate a node key for this node and print its ID",
+	RunE:  genNodeKey,
+}
+
+var showNodeKeyCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show this node's ID, public key and dial string",
+	Long: `
+Show the ID of the node, derived from its public key, the public key itself,
+and the dial string other nodes put in their persistent_peers or seeds:
+ID@host:port, where host:port is p2p.external_address, or else p2p.laddr.
+`,
+	RunE: showNodeKey,
+}
+
+var backupNodeKeyCmd = &cobra.Command{
+	Use:   "backup [file]",
+	Short: "Copy this node's key to the given file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if err := backupNodeKey(config, args[0]); err != nil {
+			return err
+		}
+		logger.Info("Backed up node key", "file", args[0])
+		return nil
+	},
+}
+
+var rotateNodeKeyCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Replace this node's key with a new one and print the new ID",
+	Long: `
+Rotate generates a new node key, after moving the current one to the backup
+file (node_key.json.bak next to it by default). Run it while the node is
+stopped. The ID of the node changes: the peers dialing it by its former ID,
+e.g. in their persistent_peers, must be updated.
+`,
+	RunE: func(*cobra.Command, []string) error {
+		backup := nodeKeyBackupFile
+		if backup == "" {
+			backup = config.NodeKeyFile() + ".bak"
+		}
+		nodeKey, err := rotateNodeKey(config, backup)
+		if err != nil {
+			return err
+		}
+		logger.Info("Rotated node key", "backup", backup)
+		fmt.Println(nodeKey.ID())
+		return nil
+	},
+}
+
+var nodeKeyBackupFile string
+
+func init() {
+	rotateNodeKeyCmd.Flags().StringVar(&nodeKeyBackupFile, "backup", "",
+		"file to move the current node key to (default: the node key file with a .bak suffix)")
+	nodeKeyCmd.AddCommand(genNodeKeyCmd, showNodeKeyCmd, backupNodeKeyCmd, rotateNodeKeyCmd)
+	KeysCmd.AddCommand(nodeKeyCmd)
+}
+
+func showNodeKey(*cobra.Command, []string) error {
+	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return err
+	}
+	pubKey, err := cmtjson.Marshal(nodeKey.PubKey())
+	if err != nil {
+		return fmt.Errorf("failed to marshal node pubkey: %w", err)
+	}
+
+	addr := config.P2P.ExternalAddress
+	if addr == "" {
+		addr = config.P2P.ListenAddress
+	}
+	fmt.Printf("ID:          %s\n", nodeKey.ID())
+	fmt.Printf("Public key:  %s\n", pubKey)
+	fmt.Printf("Dial string: %s\n", p2p.IDAddressString(nodeKey.ID(), addr))
+	return nil
+}
+
+// backupNodeKey copies the node key to dst, which must not exist.
+func backupNodeKey(config *cfg.Config, dst string) error {
+	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return err
+	}
+	if cmtos.FileExists(dst) {
+		return fmt.Errorf("%s already exists", dst)
+	}
+	return nodeKey.SaveAs(dst)
+}
+
+// rotateNodeKey moves the node key to backup, which must not exist, and
+// replaces it with a new one.
+func rotateNodeKey(config *cfg.Config, backup string) (*p2p.NodeKey, error) {
+	if err := backupNodeKey(config, backup); err != nil {
+		return nil, err
+	}
+	nodeKey := &p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+	if err := nodeKey.SaveAs(config.NodeKeyFile()); err != nil {
+		return nil, err
+	}
+	return nodeKey, nil
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+func TestRotateNodeKey(t *testing.T) {
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+	require.NoError(t, initFilesWithConfig(config))
+	oldKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+
+	backup := filepath.Join(dir, "node_key_backup.json")
+	require.NoError(t, backupNodeKey(config, backup))
+	require.Error(t, backupNodeKey(config, backup), "expected an error overwriting a backup")
+	backupKey, err := p2p.LoadNodeKey(backup)
+	require.NoError(t, err)
+	assert.Equal(t, oldKey.ID(), backupKey.ID())
+
+	// The rotation does not overwrite an existing backup.
+	_, err = rotateNodeKey(config, backup)
+	require.Error(t, err)
+
+	backup = filepath.Join(dir, "node_key_rotated.json")
+	newKey, err := rotateNodeKey(config, backup)
+	require.NoError(t, err)
+	assert.NotEqual(t, oldKey.ID(), newKey.ID())
+
+	loaded, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	assert.Equal(t, newKey.ID(), loaded.ID())
+	backupKey, err = p2p.LoadNodeKey(backup)
+	require.NoError(t, err)
+	assert.Equal(t, oldKey.ID(), backupKey.ID())
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/cometbft/cometbft/light"
 	lproxy "github.com/cometbft/cometbft/light/proxy"
 	lrpc "github.com/cometbft/cometbft/light/rpc"
+	"github.com/cometbft/cometbft/light/store"
 	dbs "github.com/cometbft/cometbft/light/store/db"
 	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
 	"github.com/spf13/cobra"
@@ -38,6 +39,12 @@ need a primary RPC address, a trusted hash and height and witness RPC addresses
 (if not using sequential verification). To restart the node, thereafter
 only the chainID is required.
 
+The proxy forwards the calls to the primary, and to the witnesses in turn
+while the primary is unavailable, the first one to respond becoming the new
+primary. Set --update-period to keep the light client up to date in the
+background, so that it does not fall out of the trusting period while the
+proxy is idle.
+
 When /abci_query is called, the Merkle key path format is:
 
 	/{store name}/{key}
@@ -65,7 +72,11 @@ var (
 	trustedHash    []byte
 	trustLevelStr  string
 
-	verbose bool
+	updatePeriod    time.Duration
+	attackReportDir string
+
+	verbose   bool
+	dbBackend string
 
 	primaryKey   = []byte("primary")
 	witnessesKey = []byte("witnesses")
@@ -89,7 +100,13 @@ func init() {
 		"trusting period that headers can be verified within. Should be significantly less than the unbonding period")
 	LightCmd.Flags().Int64Var(&trustedHeight, "height", 1, "Trusted header's height")
 	LightCmd.Flags().BytesHexVar(&trustedHash, "hash", []byte{}, "Trusted header's hash")
+	LightCmd.Flags().DurationVar(&updatePeriod, "update-period", 0,
+		"period at which to update the light client to the latest block in the background (0 to disable)")
+	LightCmd.Flags().StringVar(&attackReportDir, "attack-report-dir", "",
+		"directory to save the reports of the attacks detected to (default \"<home-dir>/attack-reports\")")
 	LightCmd.Flags().BoolVar(&verbose, "verbose", false, "Verbose output")
+	LightCmd.Flags().StringVar(&dbBackend, "db-backend", "goleveldb",
+		"database backend of the trusted store: goleveldb | boltdb. boltdb requires building with the boltdb tag")
 	LightCmd.Flags().StringVar(&trustLevelStr, "trust-level", "1/3",
 		"trust level. Must be between 1/3 and 3/3",
 	)
@@ -117,7 +134,7 @@ func runProxy(_ *cobra.Command, args []string) error {
 		witnessesAddrs = strings.Split(witnessAddrsJoined, ",")
 	}
 
-	db, err := dbm.NewGoLevelDB("light-client-db", home)
+	trustedStore, db, err := openLightStore(dbBackend, home, chainID)
 	if err != nil {
 		return fmt.Errorf("can't create a db: %w", err)
 	}
@@ -144,8 +161,13 @@ func runProxy(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("can't parse trust level: %w", err)
 	}
 
+	if attackReportDir == "" {
+		attackReportDir = filepath.Join(home, "attack-reports")
+	}
+
 	options := []light.Option{
 		light.Logger(logger),
+		light.AttackReportDir(attackReportDir),
 		light.ConfirmationFunction(func(action string) bool {
 			fmt.Println(action)
 			scanner := bufio.NewScanner(os.Stdin)
@@ -182,7 +204,7 @@ func runProxy(_ *cobra.Command, args []string) error {
 			},
 			primaryAddr,
 			witnessesAddrs,
-			dbs.New(db, chainID),
+			trustedStore,
 			options...,
 		)
 	} else { // continue from latest state
@@ -191,7 +213,7 @@ func runProxy(_ *cobra.Command, args []string) error {
 			trustingPeriod,
 			primaryAddr,
 			witnessesAddrs,
-			dbs.New(db, chainID),
+			trustedStore,
 			options...,
 		)
 	}
@@ -210,13 +232,26 @@ func runProxy(_ *cobra.Command, args []string) error {
 		cfg.WriteTimeout = config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
-	p, err := lproxy.NewProxy(c, listenAddr, primaryAddr, cfg, logger, lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()))
+	backendAddrs := []string{primaryAddr}
+	for _, addr := range witnessesAddrs {
+		if addr != "" {
+			backendAddrs = append(backendAddrs, addr)
+		}
+	}
+	p, err := lproxy.NewProxyWithBackends(c, listenAddr, backendAddrs, cfg, logger,
+		lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()))
 	if err != nil {
 		return err
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	if updatePeriod > 0 {
+		go updateLightClient(ctx, c, updatePeriod, logger)
+	}
+
 	// Stop upon receiving SIGTERM or CTRL-C.
 	cmtos.TrapSignal(logger, func() {
+		cancel()
 		p.Listener.Close()
 	})
 
@@ -229,6 +264,42 @@ func runProxy(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// updateLightClient updates c to the latest block every period, until ctx is
+// done.
+func updateLightClient(ctx context.Context, c *light.Client, period time.Duration, logger log.Logger) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			lb, err := c.Update(ctx, time.Now())
+			switch {
+			case err != nil:
+				logger.Error("Failed to update the light client", "err", err)
+			case lb != nil:
+				logger.Info("Updated the light client", "height", lb.Height)
+			}
+		}
+	}
+}
+
+// openLightStore opens the trusted store of the light client in dir with the
+// given backend, along with its database, which stores the providers too.
+func openLightStore(backend, dir, chainID string) (store.Store, dbm.DB, error) {
+	switch backend {
+	case string(dbm.GoLevelDBBackend), string(dbm.BoltDBBackend):
+		db, err := dbm.NewDB("light-client-db", dbm.BackendType(backend), dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		return dbs.New(db, chainID), db, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown db backend %q, expected one of goleveldb or boltdb", backend)
+	}
+}
+
 func checkForExistingProviders(db dbm.DB) (string, []string, error) {
 	primaryBytes, err := db.Get(primaryKey)
 	if err != nil {
@@ -1,20 +1,21 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"os"
+	"path/filepath"
+	"sync"
 
-	dbm "github.com/cometbft/cometbft-db"
 	abcitypes "github.com/cometbft/cometbft/abci/types"
 	cmtcfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/internal/progressbar"
 	"github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/state/indexer"
-	blockidxkv "github.com/cometbft/cometbft/internal/state/indexer/block/kv"
-	"github.com/cometbft/cometbft/internal/state/indexer/sink/psql"
+	blockidx "github.com/cometbft/cometbft/internal/state/indexer/block"
 	"github.com/cometbft/cometbft/internal/state/txindex"
-	"github.com/cometbft/cometbft/internal/state/txindex/kv"
+	"github.com/cometbft/cometbft/internal/tempfile"
 	"github.com/cometbft/cometbft/types"
 	"github.com/spf13/cobra"
 )
@@ -30,25 +31,33 @@ var (
 
 // ReIndexEventCmd constructs a command to re-index events in a block height interval.
 var ReIndexEventCmd = &cobra.Command{
-	Use:     "reindex-event",
-	Aliases: []string{"reindex_event"},
+	Use:     "reindex",
+	Aliases: []string{"reindex-event", "reindex_event"},
 	Short:   "reindex events to the event store backends",
 	Long: `
-reindex-event is an offline tooling to re-index block and tx events to the eventsinks,
+reindex is an offline tooling to re-index block and tx events to the eventsinks,
 you can run this command when the event store backend dropped/disconnected or you want to
 replace the backend. The default start-height is 0, meaning the tooling will start
 reindex from the base block height(inclusive); and the default end-height is 0, meaning
 the tooling will reindex until the latest block height(inclusive). User can omit
 either or both arguments.
 
+Progress is recorded in a checkpoint file, so that an interrupted re-index can
+be continued with --resume instead of starting over. Blocks and ABCI responses
+are loaded by --workers goroutines in parallel; events are indexed in height
+order. By default, events are written to the indexers configured in the
+[tx_index] section; use --sink to select other ones.
+
 Note: This operation requires ABCI Responses. Do not set DiscardABCIResponses to true if you
 want to use this command.
 	`,
 	Example: `
-	cometbft reindex-event
-	cometbft reindex-event --start-height 2
-	cometbft reindex-event --end-height 10
-	cometbft reindex-event --start-height 2 --end-height 10
+	cometbft reindex
+	cometbft reindex --start-height 2
+	cometbft reindex --end-height 10
+	cometbft reindex --start-height 2 --end-height 10
+	cometbft reindex --sink psql --workers 8
+	cometbft reindex --resume
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 		bs, ss, err := loadStateAndBlockStore(config)
@@ -63,11 +72,30 @@ want to use this command.
 			return
 		}
 
+		if checkpointFile == "" {
+			checkpointFile = filepath.Join(config.DBDir(), defaultCheckpointFile)
+		}
+		if resume {
+			cp, err := loadReIndexCheckpoint(checkpointFile)
+			if err != nil {
+				fmt.Println(reindexFailed, err)
+				return
+			}
+			fmt.Printf("resuming re-index at height %d\n", cp.NextHeight)
+			startHeight = cp.NextHeight
+			if endHeight == 0 {
+				endHeight = cp.EndHeight
+			}
+		}
+
 		if err := checkValidHeight(bs); err != nil {
 			fmt.Println(reindexFailed, err)
 			return
 		}
 
+		if sinks != "" {
+			config.TxIndex.Indexer = sinks
+		}
 		bi, ti, err := loadEventSinks(config, state.ChainID)
 		if err != nil {
 			fmt.Println(reindexFailed, err)
@@ -75,12 +103,14 @@ want to use this command.
 		}
 
 		riArgs := eventReIndexArgs{
-			startHeight:  startHeight,
-			endHeight:    endHeight,
-			blockIndexer: bi,
-			txIndexer:    ti,
-			blockStore:   bs,
-			stateStore:   ss,
+			startHeight:    startHeight,
+			endHeight:      endHeight,
+			workers:        workers,
+			checkpointFile: checkpointFile,
+			blockIndexer:   bi,
+			txIndexer:      ti,
+			blockStore:     bs,
+			stateStore:     ss,
 		}
 		if err := eventReIndex(cmd, riArgs); err != nil {
 			panic(fmt.Errorf("%s: %w", reindexFailed, err))
@@ -90,112 +120,254 @@ want to use this command.
 	},
 }
 
+const (
+	// defaultCheckpointFile is the name of the checkpoint file in the data
+	// directory.
+	defaultCheckpointFile = "reindex_checkpoint.json"
+	// checkpointInterval is the number of heights indexed between two writes
+	// of the checkpoint file.
+	checkpointInterval = 100
+)
+
 var (
-	startHeight int64
-	endHeight   int64
+	startHeight    int64
+	endHeight      int64
+	workers        int
+	sinks          string
+	checkpointFile string
+	resume         bool
 )
 
 func init() {
 	ReIndexEventCmd.Flags().Int64Var(&startHeight, "start-height", 0, "the block height would like to start for re-index")
 	ReIndexEventCmd.Flags().Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for re-index")
+	ReIndexEventCmd.Flags().IntVar(&workers, "workers", 1, "number of goroutines loading blocks and ABCI responses in parallel")
+	ReIndexEventCmd.Flags().StringVar(&sinks, "sink", "",
+		"comma-separated indexers to write to (kv, psql, kafka, nats); defaults to the indexers in the [tx_index] section")
+	ReIndexEventCmd.Flags().StringVar(&checkpointFile, "checkpoint", "",
+		"file recording the re-index progress; defaults to "+defaultCheckpointFile+" in the data directory")
+	ReIndexEventCmd.Flags().BoolVar(&resume, "resume", false, "continue an interrupted re-index from its checkpoint")
 }
 
 func loadEventSinks(cfg *cmtcfg.Config, chainID string) (indexer.BlockIndexer, txindex.TxIndexer, error) {
-	switch strings.ToLower(cfg.TxIndex.Indexer) {
-	case "null":
-		return nil, nil, errors.New("found null event sink, please check the tx-index section in the config.toml")
-	case "psql":
-		conn := cfg.TxIndex.PsqlConn
-		if conn == "" {
-			return nil, nil, errors.New("the psql connection settings cannot be empty")
-		}
-		es, err := psql.NewEventSink(conn, chainID)
-		if err != nil {
-			return nil, nil, err
-		}
-		return es.BlockIndexer(), es.TxIndexer(), nil
-	case "kv":
-		store, err := dbm.NewDB("tx_index", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
-		if err != nil {
-			return nil, nil, err
+	names := cfg.TxIndex.Sinks()
+	if len(names) == 0 {
+		return nil, nil, errors.New("no event sink configured, please check the tx-index section in the config.toml")
+	}
+	for _, name := range names {
+		switch name {
+		case "null":
+			return nil, nil, errors.New("found null event sink, please check the tx-index section in the config.toml")
+		case "kv", "psql", "kafka", "nats":
+		default:
+			return nil, nil, fmt.Errorf("unsupported event sink type: %s", name)
 		}
+	}
+	ti, bi, err := blockidx.IndexerFromConfig(cfg, cmtcfg.DefaultDBProvider, chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bi, ti, nil
+}
+
+// reIndexCheckpoint records the progress of a re-index.
+type reIndexCheckpoint struct {
+	StartHeight int64 `json:"start_height"`
+	EndHeight   int64 `json:"end_height"`
+	// NextHeight is the first height which has not been indexed yet.
+	NextHeight int64 `json:"next_height"`
+}
+
+func loadReIndexCheckpoint(path string) (reIndexCheckpoint, error) {
+	var cp reIndexCheckpoint
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return cp, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(bz, &cp); err != nil {
+		return cp, fmt.Errorf("decoding checkpoint %s: %w", path, err)
+	}
+	return cp, nil
+}
 
-		txIndexer := kv.NewTxIndex(store)
-		blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
-		return blockIndexer, txIndexer, nil
-	default:
-		return nil, nil, fmt.Errorf("unsupported event sink type: %s", cfg.TxIndex.Indexer)
+func saveReIndexCheckpoint(path string, cp reIndexCheckpoint) error {
+	bz, err := json.Marshal(cp)
+	if err != nil {
+		return err
 	}
+	return tempfile.WriteFileAtomic(path, bz, 0o600)
 }
 
 type eventReIndexArgs struct {
-	startHeight  int64
-	endHeight    int64
-	blockIndexer indexer.BlockIndexer
-	txIndexer    txindex.TxIndexer
-	blockStore   state.BlockStore
-	stateStore   state.Store
+	startHeight int64
+	endHeight   int64
+	// workers is the number of heights loaded in parallel; values below 1
+	// mean 1.
+	workers int
+	// checkpointFile, if set, is where the progress is recorded.
+	checkpointFile string
+	blockIndexer   indexer.BlockIndexer
+	txIndexer      txindex.TxIndexer
+	blockStore     state.BlockStore
+	stateStore     state.Store
 }
 
-func eventReIndex(cmd *cobra.Command, args eventReIndexArgs) error {
+// reIndexHeight holds the events of a height, ready to be indexed.
+type reIndexHeight struct {
+	block *types.EventDataNewBlockEvents
+	batch *txindex.Batch
+}
+
+func eventReIndex(cmd *cobra.Command, args eventReIndexArgs) (err error) {
+	workers := args.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	cp := reIndexCheckpoint{
+		StartHeight: args.startHeight,
+		EndHeight:   args.endHeight,
+		NextHeight:  args.startHeight,
+	}
+	if args.checkpointFile != "" {
+		defer func() {
+			if err != nil {
+				// record how far we got, so that --resume can continue from there
+				if cpErr := saveReIndexCheckpoint(args.checkpointFile, cp); cpErr != nil {
+					fmt.Println("failed to save the re-index checkpoint:", cpErr)
+				}
+				return
+			}
+			if rmErr := os.Remove(args.checkpointFile); rmErr != nil && !os.IsNotExist(rmErr) {
+				fmt.Println("failed to remove the re-index checkpoint:", rmErr)
+			}
+		}()
+	}
+
 	var bar progressbar.Bar
 	bar.NewOption(args.startHeight-1, args.endHeight)
 
 	fmt.Println("start re-indexing events:")
 	defer bar.Finish()
-	for height := args.startHeight; height <= args.endHeight; height++ {
+	lastCheckpoint := args.startHeight
+	for from := args.startHeight; from <= args.endHeight; from += int64(workers) {
 		select {
 		case <-cmd.Context().Done():
-			return fmt.Errorf("event re-index terminated at height %d: %w", height, cmd.Context().Err())
+			return fmt.Errorf("event re-index terminated at height %d: %w", from, cmd.Context().Err())
 		default:
-			block, _ := args.blockStore.LoadBlock(height)
-			if block == nil {
-				return fmt.Errorf("not able to load block at height %d from the blockstore", height)
+		}
+
+		to := from + int64(workers) - 1
+		if to > args.endHeight {
+			to = args.endHeight
+		}
+		loaded, err := loadReIndexHeights(args, from, to)
+		if err != nil {
+			return err
+		}
+
+		for i, h := range loaded {
+			height := from + int64(i)
+			if h.batch != nil {
+				if err := args.txIndexer.AddBatch(h.batch); err != nil {
+					return fmt.Errorf("tx event re-index at height %d failed: %w", height, err)
+				}
 			}
 
-			resp, err := args.stateStore.LoadFinalizeBlockResponse(height)
-			if err != nil {
-				return fmt.Errorf("not able to load ABCI Response at height %d from the statestore", height)
+			if err := args.blockIndexer.Index(*h.block); err != nil {
+				return fmt.Errorf("block event re-index at height %d failed: %w", height, err)
 			}
 
-			e := types.EventDataNewBlockEvents{
-				Height: height,
-				Events: resp.Events,
+			cp.NextHeight = height + 1
+			bar.Play(height)
+		}
+
+		if args.checkpointFile != "" && cp.NextHeight-lastCheckpoint >= checkpointInterval {
+			if err := saveReIndexCheckpoint(args.checkpointFile, cp); err != nil {
+				return fmt.Errorf("saving checkpoint: %w", err)
 			}
+			lastCheckpoint = cp.NextHeight
+		}
+	}
+
+	return nil
+}
 
-			numTxs := len(resp.TxResults)
+// loadReIndexHeights loads the events of the heights from to to (inclusive)
+// concurrently, one goroutine per height. If several heights fail to load,
+// the error of the lowest one is returned.
+func loadReIndexHeights(args eventReIndexArgs, from, to int64) ([]reIndexHeight, error) {
+	n := int(to - from + 1)
+	loaded := make([]reIndexHeight, n)
+	errs := make([]error, n)
+	if n == 1 {
+		loaded[0], errs[0] = loadReIndexHeight(args, from)
+	} else {
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				loaded[i], errs[i] = loadReIndexHeight(args, from+int64(i))
+			}(i)
+		}
+		wg.Wait()
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return loaded, nil
+}
 
-			var batch *txindex.Batch
-			if numTxs > 0 {
-				batch = txindex.NewBatch(int64(numTxs))
+func loadReIndexHeight(args eventReIndexArgs, height int64) (reIndexHeight, error) {
+	block, _ := args.blockStore.LoadBlock(height)
+	if block == nil {
+		return reIndexHeight{}, fmt.Errorf("not able to load block at height %d from the blockstore", height)
+	}
 
-				for idx, txResult := range resp.TxResults {
-					tr := abcitypes.TxResult{
-						Height: height,
-						Index:  uint32(idx),
-						Tx:     block.Txs[idx],
-						Result: *txResult,
-					}
+	resp, err := args.stateStore.LoadFinalizeBlockResponse(height)
+	if err != nil {
+		return reIndexHeight{}, fmt.Errorf("not able to load ABCI Response at height %d from the statestore", height)
+	}
 
-					if err = batch.Add(&tr); err != nil {
-						return fmt.Errorf("adding tx to batch: %w", err)
-					}
-				}
+	h := reIndexHeight{
+		block: &types.EventDataNewBlockEvents{
+			Height:   height,
+			Events:   resp.Events,
+			NumTxs:   int64(len(block.Txs)),
+			Proposer: block.ProposerAddress,
+			Size:     int64(block.Size()),
+		},
+	}
+	if block.LastCommit.Size() > 0 {
+		// The validator sets below the retain height of the state are pruned;
+		// the absent validators are then not indexed.
+		if lastVals, err := args.stateStore.LoadValidators(height - 1); err == nil {
+			h.block.AbsentValidators = block.LastCommit.AbsentValidators(lastVals)
+		}
+	}
 
-				if err := args.txIndexer.AddBatch(batch); err != nil {
-					return fmt.Errorf("tx event re-index at height %d failed: %w", height, err)
-				}
+	numTxs := len(resp.TxResults)
+	if numTxs > 0 {
+		h.batch = txindex.NewBatch(int64(numTxs))
+
+		for idx, txResult := range resp.TxResults {
+			tr := abcitypes.TxResult{
+				Height: height,
+				Index:  uint32(idx),
+				Tx:     block.Txs[idx],
+				Result: *txResult,
 			}
 
-			if err := args.blockIndexer.Index(e); err != nil {
-				return fmt.Errorf("block event re-index at height %d failed: %w", height, err)
+			if err = h.batch.Add(&tr); err != nil {
+				return reIndexHeight{}, fmt.Errorf("adding tx to batch: %w", err)
 			}
 		}
-
-		bar.Play(height)
 	}
 
-	return nil
+	return h, nil
 }
 
 func checkValidHeight(bs state.BlockStore) error {
@@ -3,6 +3,8 @@ package commands
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -107,6 +109,57 @@ func TestLoadEventSink(t *testing.T) {
 	}
 }
 
+func TestReIndexEventCheckpoint(t *testing.T) {
+	mockBlockStore := &mocks.BlockStore{}
+	mockStateStore := &mocks.Store{}
+	mockBlockIndexer := &blockmocks.BlockIndexer{}
+	mockTxIndexer := &txmocks.TxIndexer{}
+
+	abciResp := &abcitypes.FinalizeBlockResponse{
+		TxResults: []*abcitypes.ExecTxResult{
+			{Code: 1},
+		},
+	}
+	mockBlockStore.
+		On("LoadBlock", mock.AnythingOfType("int64")).
+		Return(&types.Block{Data: types.Data{Txs: types.Txs{make(types.Tx, 1)}}}, &types.BlockMeta{})
+	mockStateStore.
+		On("LoadFinalizeBlockResponse", int64(7)).Return(nil, errors.New("")).Once().
+		On("LoadFinalizeBlockResponse", mock.AnythingOfType("int64")).Return(abciResp, nil)
+	mockBlockIndexer.
+		On("Index", mock.AnythingOfType("types.EventDataNewBlockEvents")).Return(nil)
+	mockTxIndexer.
+		On("AddBatch", mock.AnythingOfType("*txindex.Batch")).Return(nil)
+
+	args := eventReIndexArgs{
+		startHeight:    base,
+		endHeight:      height,
+		workers:        2,
+		checkpointFile: filepath.Join(t.TempDir(), defaultCheckpointFile),
+		blockIndexer:   mockBlockIndexer,
+		txIndexer:      mockTxIndexer,
+		blockStore:     mockBlockStore,
+		stateStore:     mockStateStore,
+	}
+
+	// heights are loaded in pairs: 2-3, 4-5, then 6-7 fails
+	err := eventReIndex(setupReIndexEventCmd(), args)
+	require.Error(t, err)
+
+	cp, err := loadReIndexCheckpoint(args.checkpointFile)
+	require.NoError(t, err)
+	require.Equal(t, reIndexCheckpoint{StartHeight: base, EndHeight: height, NextHeight: 6}, cp)
+	mockBlockIndexer.AssertNumberOfCalls(t, "Index", 4)
+
+	// resume from the checkpoint
+	args.startHeight = cp.NextHeight
+	require.NoError(t, eventReIndex(setupReIndexEventCmd(), args))
+	mockBlockIndexer.AssertNumberOfCalls(t, "Index", 9)
+
+	_, err = os.Stat(args.checkpointFile)
+	require.True(t, os.IsNotExist(err))
+}
+
 func TestLoadBlockStore(t *testing.T) {
 	cfg := cmtcfg.TestConfig()
 	cfg.DBPath = t.TempDir()
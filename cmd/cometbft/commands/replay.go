@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replayFrom      int64
+	replayTo        int64
+	replayABCI      string
+	replayTransport string
+)
+
+// ErrAppHashMismatch is returned by replay when the application computes an
+// app hash different from the one stored for a height.
+var ErrAppHashMismatch = errors.New("app hash mismatch")
+
+func init() {
+	ReplayCmd.Flags().Int64Var(&replayFrom, "from", 0, "first height to compare app hashes at (default: store base)")
+	ReplayCmd.Flags().Int64Var(&replayTo, "to", 0, "last height to replay (default: store height)")
+	ReplayCmd.Flags().StringVar(&replayABCI, "abci", "", "address of the application to replay against (default: proxy_app)")
+	ReplayCmd.Flags().StringVar(&replayTransport, "transport", "", "ABCI transport: socket | grpc (default: abci)")
+}
+
+// ReplayCmd replays stored blocks against an application and compares the
+// resulting app hashes with the stored ones.
+var ReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "replay stored blocks against an application and diff app hashes",
+	Long: `
+replay executes the blocks stored by this node against a fresh instance of the
+application, without modifying the node's stores. After each height starting
+at --from, the app hash returned by the application is compared with the one
+stored by the node. Replay stops at the first divergent height and prints the
+first transaction whose result differs.
+
+The application must be at a height lower than --from: blocks between its
+height and --from are executed without being compared. The node must have
+been run with discard_abci_responses = false for the stored results to be
+available; otherwise only app hashes are compared.
+`,
+	Example: `
+	cometbft replay --abci tcp://127.0.0.1:26658
+	cometbft replay --from 100 --to 200 --abci tcp://127.0.0.1:26658
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bs, ss, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = bs.Close()
+			_ = ss.Close()
+		}()
+
+		genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+		if err != nil {
+			return err
+		}
+
+		addr, transport := replayABCI, replayTransport
+		if addr == "" {
+			addr = config.ProxyApp
+		}
+		if transport == "" {
+			transport = config.ABCI
+		}
+		proxyApp := proxy.NewAppConns(proxy.NewRemoteClientCreator(addr, transport, true), proxy.NopMetrics())
+		proxyApp.SetLogger(logger.With("module", "proxy"))
+		if err := proxyApp.Start(); err != nil {
+			return fmt.Errorf("failed to connect to the application: %w", err)
+		}
+		defer func() {
+			if err := proxyApp.Stop(); err != nil {
+				logger.Error("Error stopping proxy app connections", "err", err)
+			}
+		}()
+
+		return replayBlocks(cmd.Context(), replayArgs{
+			proxyApp:   proxyApp,
+			blockStore: bs,
+			stateStore: ss,
+			genDoc:     genDoc,
+			from:       replayFrom,
+			to:         replayTo,
+			logger:     logger,
+			out:        cmd.OutOrStdout(),
+		})
+	},
+}
+
+type replayArgs struct {
+	proxyApp   proxy.AppConns
+	blockStore state.BlockStore
+	stateStore state.Store
+	genDoc     *types.GenesisDoc
+	from, to   int64
+	logger     log.Logger
+	out        io.Writer
+}
+
+// replayBlocks executes the stored blocks up to args.to against the
+// application, comparing app hashes from args.from on.
+func replayBlocks(ctx context.Context, args replayArgs) error {
+	from, to := args.from, args.to
+	if from == 0 {
+		from = args.blockStore.Base()
+	}
+	if to == 0 {
+		to = args.blockStore.Height()
+	}
+	if from < args.blockStore.Base() || to > args.blockStore.Height() || from > to {
+		return fmt.Errorf("%w: heights %d to %d are not in the block store (%d to %d)",
+			ErrInvalidRequest, from, to, args.blockStore.Base(), args.blockStore.Height())
+	}
+
+	info, err := args.proxyApp.Query().Info(ctx, proxy.InfoRequest)
+	if err != nil {
+		return fmt.Errorf("failed to query the application: %w", err)
+	}
+	appHeight := info.LastBlockHeight
+	if appHeight >= from {
+		return fmt.Errorf("%w: application is at height %d, expected a height lower than %d",
+			ErrInvalidRequest, appHeight, from)
+	}
+	if appHeight == 0 {
+		if err := initChain(ctx, args.proxyApp, args.genDoc); err != nil {
+			return fmt.Errorf("failed to initialize the application: %w", err)
+		}
+		appHeight = args.genDoc.InitialHeight - 1
+	}
+	if appHeight+1 < args.blockStore.Base() {
+		return fmt.Errorf("%w: application is at height %d, but the block store starts at height %d",
+			ErrInvalidRequest, appHeight, args.blockStore.Base())
+	}
+
+	for height := appHeight + 1; height <= to; height++ {
+		block, _ := args.blockStore.LoadBlock(height)
+		if block == nil {
+			return fmt.Errorf("%w: block at height %d", ErrHeightNotAvailable, height)
+		}
+		resp, err := state.ExecCommitBlockResponse(args.proxyApp.Consensus(), block, args.logger, args.stateStore, args.genDoc.InitialHeight)
+		if err != nil {
+			return fmt.Errorf("failed to execute block at height %d: %w", height, err)
+		}
+		if height < from {
+			continue
+		}
+
+		expected, expectedHash := expectedResponse(args, height)
+		if expectedHash == nil {
+			fmt.Fprintf(args.out, "height %d: app hash %X (no stored app hash to compare with)\n", height, resp.AppHash)
+			continue
+		}
+		if bytes.Equal(resp.AppHash, expectedHash) {
+			fmt.Fprintf(args.out, "height %d: app hash %X matches\n", height, resp.AppHash)
+			continue
+		}
+
+		fmt.Fprintf(args.out, "height %d: app hash %X, expected %X\n", height, resp.AppHash, expectedHash)
+		if expected != nil {
+			i, err := firstDivergentTx(expected.TxResults, resp.TxResults)
+			switch {
+			case err != nil:
+				return fmt.Errorf("failed to compare tx results at height %d: %w", height, err)
+			case i >= 0 && i < len(expected.TxResults) && i < len(resp.TxResults):
+				fmt.Fprintf(args.out, "first divergent tx: index %d, hash %X\n", i, block.Txs[i].Hash())
+				fmt.Fprintf(args.out, "  expected result: %v\n", abci.DeterministicExecTxResult(expected.TxResults[i]))
+				fmt.Fprintf(args.out, "  actual result:   %v\n", abci.DeterministicExecTxResult(resp.TxResults[i]))
+			case i >= 0:
+				fmt.Fprintf(args.out, "expected %d tx results, got %d\n", len(expected.TxResults), len(resp.TxResults))
+			default:
+				fmt.Fprintln(args.out, "all tx results match; the divergence is in the application state")
+			}
+		}
+		return fmt.Errorf("%w at height %d", ErrAppHashMismatch, height)
+	}
+	return nil
+}
+
+// expectedResponse returns the stored FinalizeBlock response for height, if
+// any, and the app hash the application is expected to compute at height.
+func expectedResponse(args replayArgs, height int64) (*abci.FinalizeBlockResponse, []byte) {
+	resp, err := args.stateStore.LoadFinalizeBlockResponse(height)
+	if err == nil && resp != nil {
+		return resp, resp.AppHash
+	}
+	// fall back to the app hash recorded in the header of the next block
+	if next := args.blockStore.LoadBlockMeta(height + 1); next != nil {
+		return nil, next.Header.AppHash
+	}
+	return nil, nil
+}
+
+// firstDivergentTx returns the index of the first tx whose deterministic
+// result differs between expected and actual, or -1 if there is none.
+func firstDivergentTx(expected, actual []*abci.ExecTxResult) (int, error) {
+	expectedBz, err := abci.MarshalTxResults(expected)
+	if err != nil {
+		return -1, err
+	}
+	actualBz, err := abci.MarshalTxResults(actual)
+	if err != nil {
+		return -1, err
+	}
+	for i := 0; i < len(expectedBz) && i < len(actualBz); i++ {
+		if !bytes.Equal(expectedBz[i], actualBz[i]) {
+			return i, nil
+		}
+	}
+	if len(expectedBz) != len(actualBz) {
+		return min(len(expectedBz), len(actualBz)), nil
+	}
+	return -1, nil
+}
+
+func initChain(ctx context.Context, proxyApp proxy.AppConns, genDoc *types.GenesisDoc) error {
+	validators := make([]*types.Validator, len(genDoc.Validators))
+	for i, val := range genDoc.Validators {
+		validators[i] = types.NewValidator(val.PubKey, val.Power)
+	}
+	validatorSet := types.NewValidatorSet(validators)
+	pbparams := genDoc.ConsensusParams.ToProto()
+	_, err := proxyApp.Consensus().InitChain(ctx, &abci.InitChainRequest{
+		Time:            genDoc.GenesisTime,
+		ChainId:         genDoc.ChainID,
+		InitialHeight:   genDoc.InitialHeight,
+		ConsensusParams: &pbparams,
+		Validators:      types.TM2PB.ValidatorUpdates(validatorSet),
+		AppStateBytes:   genDoc.AppState,
+	})
+	return err
+}
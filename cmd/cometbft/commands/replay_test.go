@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/state/mocks"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+func newReplayApp(t *testing.T) proxy.AppConns {
+	t.Helper()
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewInMemoryApplication()), proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { _ = proxyApp.Stop() })
+	return proxyApp
+}
+
+func TestReplayBlocks(t *testing.T) {
+	genDoc := &types.GenesisDoc{
+		ChainID:         "replay-test",
+		InitialHeight:   1,
+		ConsensusParams: types.DefaultConsensusParams(),
+	}
+	block := &types.Block{
+		Header: types.Header{Height: 1},
+		Data:   types.Data{Txs: types.Txs{types.Tx("a=1"), types.Tx("b=2")}},
+	}
+
+	// compute the app hash and tx results of a correct execution
+	proxyApp := newReplayApp(t)
+	require.NoError(t, initChain(context.Background(), proxyApp, genDoc))
+	correct, err := state.ExecCommitBlockResponse(proxyApp.Consensus(), block, log.NewNopLogger(), &mocks.Store{}, 1)
+	require.NoError(t, err)
+
+	diverged := &abcitypes.FinalizeBlockResponse{
+		TxResults: []*abcitypes.ExecTxResult{correct.TxResults[0], {Code: 1}},
+		AppHash:   []byte("wrong"),
+	}
+
+	testCases := []struct {
+		name     string
+		stored   *abcitypes.FinalizeBlockResponse
+		from, to int64
+		err      error
+		output   string
+	}{
+		{"match", correct, 0, 0, nil, "matches"},
+		{"mismatch", diverged, 1, 1, ErrAppHashMismatch, "first divergent tx: index 1"},
+		{"nothing to compare with", nil, 0, 0, nil, "no stored app hash"},
+		{"out of range", correct, 1, 2, ErrInvalidRequest, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			blockStore := &mocks.BlockStore{}
+			blockStore.
+				On("Base").Return(int64(1)).
+				On("Height").Return(int64(1)).
+				On("LoadBlock", int64(1)).Return(block, &types.BlockMeta{}).
+				On("LoadBlockMeta", int64(2)).Return(nil)
+			stateStore := &mocks.Store{}
+			if tc.stored != nil {
+				stateStore.On("LoadFinalizeBlockResponse", int64(1)).Return(tc.stored, nil)
+			} else {
+				stateStore.On("LoadFinalizeBlockResponse", int64(1)).Return(nil, errors.New("discarded"))
+			}
+
+			var out bytes.Buffer
+			err := replayBlocks(context.Background(), replayArgs{
+				proxyApp:   newReplayApp(t),
+				blockStore: blockStore,
+				stateStore: stateStore,
+				genDoc:     genDoc,
+				from:       tc.from,
+				to:         tc.to,
+				logger:     log.NewNopLogger(),
+				out:        &out,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Contains(t, out.String(), tc.output)
+		})
+	}
+}
+
+func TestFirstDivergentTx(t *testing.T) {
+	ok := &abcitypes.ExecTxResult{Code: 0, Log: "ok"}
+	failed := &abcitypes.ExecTxResult{Code: 1}
+
+	testCases := []struct {
+		expected, actual []*abcitypes.ExecTxResult
+		index            int
+	}{
+		{nil, nil, -1},
+		{[]*abcitypes.ExecTxResult{ok, ok}, []*abcitypes.ExecTxResult{ok, ok}, -1},
+		// non-deterministic fields are ignored
+		{[]*abcitypes.ExecTxResult{ok}, []*abcitypes.ExecTxResult{{Code: 0, Log: "other"}}, -1},
+		{[]*abcitypes.ExecTxResult{ok, ok}, []*abcitypes.ExecTxResult{ok, failed}, 1},
+		{[]*abcitypes.ExecTxResult{ok, ok}, []*abcitypes.ExecTxResult{ok}, 1},
+	}
+	for _, tc := range testCases {
+		index, err := firstDivergentTx(tc.expected, tc.actual)
+		require.NoError(t, err)
+		require.Equal(t, tc.index, index)
+	}
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/internal/consensus"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/spf13/cobra"
+)
+
+var replayWALVerbose bool
+
+func init() {
+	ReplayWALCmd.Flags().BoolVar(&replayWALVerbose, "verbose", false, "print each message before replaying it, along with the consensus logs")
+}
+
+// ReplayWALCmd replays the consensus WAL into a simulated consensus state
+// machine and checks its state transitions.
+var ReplayWALCmd = &cobra.Command{
+	Use:   "replay-wal [wal-file]",
+	Short: "replay the consensus WAL into a simulated state machine and check its transitions",
+	Long: `
+replay-wal replays the messages of the consensus WAL for the height the node is
+at, i.e. the ones following the #ENDHEIGHT marker of its last block, into a
+consensus state machine started from the node's state, and checks that the state
+machine goes through the same steps as recorded in the WAL. It stops at the first
+step that differs, or at the first panic of the state machine, whose stack is
+printed. This reproduces e.g. a "wrong state transition" panic offline, without
+running the node or the application.
+
+The replay has no side effects: the state machine has no private validator, its
+own votes and the timeouts it processed being replayed from the WAL, and runs on
+in-memory copies of the stores. The application is replaced by a stub giving the
+blocks the results stored by the node, if any.
+
+The WAL file defaults to the one of the node. The node must be stopped.
+`,
+	Example: `
+	cometbft replay-wal
+	cometbft replay-wal --verbose data/cs.wal/wal
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		walFile := config.Consensus.WalFile()
+		if len(args) > 0 {
+			walFile = args[0]
+		}
+
+		bs, ss, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = bs.Close()
+			_ = ss.Close()
+		}()
+
+		state, err := ss.Load()
+		if err != nil {
+			return err
+		}
+		if state.IsEmpty() {
+			return errors.New("no state found; the node has not been started yet")
+		}
+
+		out := cmd.OutOrStdout()
+		var (
+			n        int
+			fn       = func(consensus.WALEntry) { n++ }
+			csLogger = log.NewNopLogger()
+		)
+		if replayWALVerbose {
+			fn = func(e consensus.WALEntry) {
+				n++
+				fmt.Fprintf(out, "%s H:%d R:%d %s %s\n", e.Time.UTC().Format("2006-01-02T15:04:05.000Z"), e.Height, e.Round, e.Type, e.Msg)
+			}
+			csLogger = logger
+		}
+
+		err = consensus.ReplayWAL(config.Consensus, walFile, state, ss, bs, fn, csLogger)
+		if err != nil {
+			return fmt.Errorf("failed to replay WAL after %d messages: %w", n, err)
+		}
+		fmt.Fprintf(out, "replayed %d messages from height %d without a mismatch\n", n, state.LastBlockHeight+1)
+		return nil
+	},
+}
@@ -156,7 +156,7 @@ func resetState(dbDir string, logger log.Logger) error {
 
 func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
 	if _, err := os.Stat(privValKeyFile); err == nil {
-		pv := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
+		pv := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile, privValidatorPassphrase(config))
 		pv.Reset()
 		logger.Info(
 			"Reset private validator file to genesis state",
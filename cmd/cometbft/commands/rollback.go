@@ -6,21 +6,28 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/consensus"
 	"github.com/cometbft/cometbft/internal/os"
 	"github.com/cometbft/cometbft/internal/state"
+	blockidxkv "github.com/cometbft/cometbft/internal/state/indexer/block/kv"
+	"github.com/cometbft/cometbft/internal/state/txindex/kv"
 	"github.com/cometbft/cometbft/internal/store"
 	"github.com/spf13/cobra"
 )
 
-var removeBlock = false
+var (
+	removeBlock     = false
+	rollbackHeights int64
+)
 
 func init() {
 	RollbackStateCmd.Flags().BoolVar(&removeBlock, "hard", false, "remove last block as well as state")
+	RollbackStateCmd.Flags().Int64Var(&rollbackHeights, "heights", 1, "number of heights to roll back")
 }
 
 var RollbackStateCmd = &cobra.Command{
 	Use:   "rollback",
-	Short: "rollback CometBFT state by one height",
+	Short: "rollback CometBFT state by one or more heights",
 	Long: `
 A state rollback is performed to recover from an incorrect application state transition,
 when CometBFT has persisted an incorrect app hash and is thus unable to make
@@ -29,9 +36,14 @@ The application should also roll back to height n - 1. If the --hard flag is not
 no blocks will be removed so upon restarting CometBFT the transactions in block n will be
 re-executed against the application. Using --hard will also remove block n. This can
 be done multiple times.
+
+With --heights m, the state at height n is rolled back to height n - m at once.
+The blocks above n - m + 1 are removed (and block n - m + 1 as well with --hard).
+In all cases, events indexed by the kv indexer above the resulting height are
+removed, and the consensus WAL is reset to that height.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		height, hash, err := RollbackState(config, removeBlock)
+		height, hash, err := RollbackStateHeights(config, rollbackHeights, removeBlock)
 		if err != nil {
 			return fmt.Errorf("failed to rollback state: %w", err)
 		}
@@ -64,6 +76,65 @@ func RollbackState(config *cfg.Config, removeBlock bool) (int64, []byte, error)
 	return state.Rollback(blockStore, stateStore, removeBlock)
 }
 
+// RollbackStateHeights rolls back the state by n heights, see
+// state.RollbackHeights. Events indexed above the resulting height by the kv
+// indexer are removed, and the consensus WAL is reset to that height.
+// Returns the latest state height and app hash alongside an error if there was one.
+func RollbackStateHeights(config *cfg.Config, n int64, removeBlock bool) (int64, []byte, error) {
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return -1, nil, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	height, hash, err := state.RollbackHeights(blockStore, stateStore, n, removeBlock)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	if err := deleteIndexedFromHeight(config, height+1); err != nil {
+		return -1, nil, fmt.Errorf("failed to remove indexed events: %w", err)
+	}
+	if err := consensus.ResetWAL(config.Consensus.WalFile(), height); err != nil {
+		return -1, nil, fmt.Errorf("failed to reset the consensus WAL: %w", err)
+	}
+	return height, hash, nil
+}
+
+// deleteIndexedFromHeight removes the events indexed at height and above.
+func deleteIndexedFromHeight(config *cfg.Config, height int64) error {
+	for _, sink := range config.TxIndex.Sinks() {
+		switch sink {
+		case "kv":
+			store, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "tx_index", Config: config})
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			txIndexer := kv.NewTxIndex(store)
+			if err := txIndexer.LoadEventSchema(); err != nil {
+				return err
+			}
+			if err := txIndexer.DeleteFromHeight(height); err != nil {
+				return err
+			}
+			blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+			if err := blockIndexer.DeleteFromHeight(height); err != nil {
+				return err
+			}
+		case "psql":
+			fmt.Printf("Events indexed in PostgreSQL at height %d and above must be removed manually\n", height)
+		case "kafka", "nats":
+			fmt.Printf("Events published to %s at height %d and above were not retracted\n", sink, height)
+		}
+	}
+	return nil
+}
+
 func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store, error) {
 	dbType := dbm.BackendType(config.DBBackend)
 
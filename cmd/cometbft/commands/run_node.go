@@ -3,13 +3,18 @@ package commands
 import (
 	"encoding/hex"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
-	cmtos "github.com/cometbft/cometbft/internal/os"
 	nm "github.com/cometbft/cometbft/node"
 	"github.com/spf13/cobra"
 )
 
-var genesisHash []byte
+var (
+	genesisHash   []byte
+	verifyOnStart bool
+)
 
 // AddNodeFlags exposes some common configuration options on the command-line
 // These are exposed for convenience of commands embedding a CometBFT node.
@@ -29,6 +34,12 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"genesis_hash",
 		[]byte{},
 		"optional SHA-256 hash of the genesis file")
+	cmd.Flags().BoolVar(
+		&verifyOnStart,
+		"verify-on-start",
+		false,
+		"cross-check the block store, state, consensus WAL and private validator state before starting, "+
+			"repairing known-safe inconsistencies and refusing to start on the others")
 	cmd.Flags().Int64("consensus.double_sign_check_height", config.Consensus.DoubleSignCheckHeight,
 		"how many blocks to look back to check existence of the node's "+
 			"consensus votes before joining consensus")
@@ -45,6 +56,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String("rpc.laddr", config.RPC.ListenAddress, "RPC listen address. Port required")
 	cmd.Flags().Bool("rpc.unsafe", config.RPC.Unsafe, "enabled unsafe rpc methods")
 	cmd.Flags().String("rpc.pprof_laddr", config.RPC.PprofListenAddress, "pprof listen address (https://golang.org/pkg/net/http/pprof)")
+	cmd.Flags().String("rpc.admin_laddr", config.RPC.AdminListenAddress, "admin RPC listen address (UNIX socket or localhost-only TCP)")
 
 	// p2p flags
 	cmd.Flags().String(
@@ -92,29 +104,35 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 			if len(genesisHash) != 0 {
 				config.Storage.GenesisHash = hex.EncodeToString(genesisHash)
 			}
+			if verifyOnStart {
+				config.VerifyOnStart = true
+			}
 
 			n, err := nodeProvider(config, logger)
 			if err != nil {
 				return fmt.Errorf("failed to create node: %w", err)
 			}
 
+			// Stop upon receiving SIGTERM or CTRL-C.
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
 			if err := n.Start(); err != nil {
 				return fmt.Errorf("failed to start node: %w", err)
 			}
 
 			logger.Info("Started node", "nodeInfo", n.Switch().NodeInfo())
 
-			// Stop upon receiving SIGTERM or CTRL-C.
-			cmtos.TrapSignal(logger, func() {
-				if n.IsRunning() {
-					if err := n.Stop(); err != nil {
-						logger.Error("unable to stop the node", "error", err)
-					}
+			// Run until stopped. The node may also stop itself, e.g. to
+			// execute an upgrade binary, which replaces the process.
+			<-ctx.Done()
+			logger.Info("Captured signal, stopping the node")
+			if n.IsRunning() {
+				if err := n.Stop(); err != nil {
+					return fmt.Errorf("unable to stop the node: %w", err)
 				}
-			})
-
-			// Run forever.
-			select {}
+			}
+			return nil
 		},
 	}
 
@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/internal/upgrade"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// supervisedBinary is the name of the binaries in the upgrade directory.
+	supervisedBinary = "cometbft"
+	// genesisUpgrade is the directory of the binary the chain started with.
+	genesisUpgrade = "genesis"
+	// currentUpgrade is the symlink to the directory of the running binary.
+	currentUpgrade = "current"
+
+	// upgradeInfoPollInterval is the interval at which the supervisor checks
+	// the upgrade marker file.
+	upgradeInfoPollInterval = time.Second
+)
+
+// SuperviseCmd runs the node as a child process and restarts it with the
+// upgraded binary when it halts for an upgrade.
+var SuperviseCmd = &cobra.Command{
+	Use:   "supervise [-- start flags]",
+	Short: "Run the node, switching to the upgraded binary when it halts for an upgrade",
+	Long: `
+Supervise runs "cometbft start" with the binary of the current upgrade, passing
+it the flags given after "--". The binaries are laid out in upgrade_dir:
+
+  genesis/bin/cometbft   the binary the chain started with
+  <name>/bin/cometbft    the binary of the upgrade <name>
+  current                symlink to the directory of the running binary
+
+When the node, or the application, writes the data/upgrade-info.json marker
+file for an upgrade not applied yet, the supervisor stops the node, points
+current to the directory of the upgrade, and starts the node again. The upgrade
+is named after the "name" field of the marker file if the application set it,
+or else after the halt height. upgrade_binary should be left empty.
+`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(_ *cobra.Command, args []string) error {
+		return supervise(config, args)
+	},
+}
+
+// supervise runs the node until it exits without a pending upgrade, or the
+// supervisor is stopped.
+func supervise(config *cfg.Config, args []string) error {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	ticker := time.NewTicker(upgradeInfoPollInterval)
+	defer ticker.Stop()
+
+	upgradeDir := config.UpgradeDir()
+	for {
+		binary, err := currentBinary(upgradeDir)
+		if err != nil {
+			return err
+		}
+		cmd := exec.Command(binary, append([]string{"start", "--home", config.RootDir}, args...)...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start %s: %w", binary, err)
+		}
+		logger.Info("Started node", "binary", binary, "pid", cmd.Process.Pid)
+
+		exited := make(chan error, 1)
+		go func() { exited <- cmd.Wait() }()
+
+		info, err := waitForUpgrade(config, cmd, exited, sigs, ticker.C)
+		if info == nil {
+			return err
+		}
+		name, err := applyUpgrade(upgradeDir, config.UpgradeInfoFile(), info)
+		if err != nil {
+			return err
+		}
+		logger.Info("Switched to upgrade", "name", name, "height", info.Height)
+	}
+}
+
+// waitForUpgrade waits for the node to exit or to halt for an upgrade,
+// stopping it in the latter case. It returns the pending upgrade, if any.
+func waitForUpgrade(
+	config *cfg.Config,
+	cmd *exec.Cmd,
+	exited <-chan error,
+	sigs <-chan os.Signal,
+	tick <-chan time.Time,
+) (*upgrade.Info, error) {
+	for {
+		select {
+		case sig := <-sigs:
+			logger.Info("Stopping node", "signal", sig)
+			if err := cmd.Process.Signal(sig); err != nil {
+				return nil, err
+			}
+			return nil, <-exited
+
+		case err := <-exited:
+			// The application may exit once it wrote the marker file.
+			info, pendingErr := pendingUpgrade(config.UpgradeDir(), config.UpgradeInfoFile())
+			if pendingErr != nil || info == nil {
+				return nil, errors.Join(err, pendingErr)
+			}
+			return info, nil
+
+		case <-tick:
+			info, err := pendingUpgrade(config.UpgradeDir(), config.UpgradeInfoFile())
+			if err != nil {
+				logger.Error("Failed to read upgrade info", "err", err)
+				continue
+			}
+			if info == nil {
+				continue
+			}
+			logger.Info("Stopping node for upgrade", "name", info.Name, "height", info.Height)
+			if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+				return nil, err
+			}
+			<-exited
+			return info, nil
+		}
+	}
+}
+
+// currentBinary returns the binary of the current upgrade, or else of the
+// genesis one, or else the running executable.
+func currentBinary(upgradeDir string) (string, error) {
+	for _, dir := range []string{currentUpgrade, genesisUpgrade} {
+		binary := filepath.Join(upgradeDir, dir, "bin", supervisedBinary)
+		if cmtos.FileExists(binary) {
+			return binary, nil
+		}
+	}
+	return os.Executable()
+}
+
+// pendingUpgrade returns the upgrade described by the marker file, if it is
+// above the one applied last, or nil.
+func pendingUpgrade(upgradeDir, markerFile string) (*upgrade.Info, error) {
+	if !cmtos.FileExists(markerFile) {
+		return nil, nil
+	}
+	info, err := upgrade.ReadInfo(markerFile)
+	if err != nil {
+		return nil, err
+	}
+	appliedFile := filepath.Join(upgradeDir, currentUpgrade, filepath.Base(markerFile))
+	if cmtos.FileExists(appliedFile) {
+		applied, err := upgrade.ReadInfo(appliedFile)
+		if err != nil {
+			return nil, err
+		}
+		if info.Height <= applied.Height {
+			return nil, nil
+		}
+	}
+	return info, nil
+}
+
+// applyUpgrade points the current symlink to the directory of the upgrade,
+// recording the marker file in it, and returns the name of the upgrade.
+func applyUpgrade(upgradeDir, markerFile string, info *upgrade.Info) (string, error) {
+	name := info.Name
+	if name == "" {
+		name = strconv.FormatInt(info.Height, 10)
+	}
+	if name != filepath.Base(name) || name == currentUpgrade || name == genesisUpgrade {
+		return "", fmt.Errorf("invalid upgrade name %q", name)
+	}
+	dir := filepath.Join(upgradeDir, name)
+	binary := filepath.Join(dir, "bin", supervisedBinary)
+	if !cmtos.FileExists(binary) {
+		return "", fmt.Errorf("binary of upgrade %q not found: %s", name, binary)
+	}
+	if err := cmtos.CopyFile(markerFile, filepath.Join(dir, filepath.Base(markerFile))); err != nil {
+		return "", err
+	}
+
+	// Replace the symlink atomically.
+	tmp := filepath.Join(upgradeDir, currentUpgrade+".tmp")
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := os.Symlink(name, tmp); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, filepath.Join(upgradeDir, currentUpgrade)); err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuperviseUpgrade(t *testing.T) {
+	upgradeDir := t.TempDir()
+	markerFile := filepath.Join(t.TempDir(), "upgrade-info.json")
+	for _, name := range []string{genesisUpgrade, "v2"} {
+		bin := filepath.Join(upgradeDir, name, "bin")
+		require.NoError(t, os.MkdirAll(bin, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(bin, supervisedBinary), nil, 0o755))
+	}
+
+	binary, err := currentBinary(upgradeDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(upgradeDir, genesisUpgrade, "bin", supervisedBinary), binary)
+
+	info, err := pendingUpgrade(upgradeDir, markerFile)
+	require.NoError(t, err)
+	assert.Nil(t, info)
+
+	// The upgrade is named after its height if the marker file has no name.
+	require.NoError(t, os.WriteFile(markerFile, []byte(`{"height":"100"}`), 0o644))
+	info, err = pendingUpgrade(upgradeDir, markerFile)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	_, err = applyUpgrade(upgradeDir, markerFile, info)
+	require.Error(t, err, "expected an error without the binary of the upgrade")
+
+	require.NoError(t, os.WriteFile(markerFile, []byte(`{"name":"v2","height":100}`), 0o644))
+	info, err = pendingUpgrade(upgradeDir, markerFile)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	name, err := applyUpgrade(upgradeDir, markerFile, info)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", name)
+
+	binary, err = currentBinary(upgradeDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(upgradeDir, currentUpgrade, "bin", supervisedBinary), binary)
+	target, err := os.Readlink(filepath.Join(upgradeDir, currentUpgrade))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", target)
+
+	// The applied upgrade is not pending anymore.
+	info, err = pendingUpgrade(upgradeDir, markerFile)
+	require.NoError(t, err)
+	assert.Nil(t, info)
+
+	require.NoError(t, os.WriteFile(markerFile, []byte(`{"name":"../v3","height":200}`), 0o644))
+	info, err = pendingUpgrade(upgradeDir, markerFile)
+	require.NoError(t, err)
+	_, err = applyUpgrade(upgradeDir, markerFile, info)
+	require.Error(t, err)
+}
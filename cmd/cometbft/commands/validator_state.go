@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+	"github.com/spf13/cobra"
+)
+
+// ValidatorStateCmd groups the commands handling this node's private
+// validator state.
+var ValidatorStateCmd = &cobra.Command{
+	Use:     "validator-state",
+	Aliases: []string{"validator_state"},
+	Short:   "Inspect this node's private validator state",
+}
+
+var verifyValidatorStateCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the integrity of this node's private validator state",
+	Long: `
+Verify checks that the private validator state file was not corrupted, that the
+clock of the machine was not set back since the state was last saved, and that
+the state is not stale, i.e. that the block store does not hold a commit signed
+by this validator above the last height of the state, as it happens when an old
+copy of the file is restored. Signing from a stale state may lead to double
+signing.
+`,
+	RunE: verifyValidatorState,
+}
+
+func init() {
+	ValidatorStateCmd.AddCommand(verifyValidatorStateCmd)
+}
+
+func verifyValidatorState(*cobra.Command, []string) error {
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !cmtos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+	stateFilePath := config.PrivValidatorStateFile()
+	pv := privval.LoadFilePVEmptyState(keyFilePath, stateFilePath, privValidatorPassphrase(config))
+
+	state, err := privval.LoadFilePVState(stateFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load the private validator state: %w", err)
+	}
+
+	signedHeight, err := lastSignedHeight(pv.GetAddress())
+	if err != nil {
+		return err
+	}
+	if err := state.Check(cmttime.Now(), signedHeight); err != nil {
+		return err
+	}
+
+	fmt.Printf("Private validator state OK: height %d, round %d, step %d, saved at %v\n",
+		state.Height, state.Round, state.Step, state.SavedAt)
+	return nil
+}
+
+// lastSignedHeight returns the height of the last commit of the block store,
+// if it holds a signature of the validator, or 0.
+func lastSignedHeight(address types.Address) (int64, error) {
+	if !cmtos.FileExists(filepath.Join(config.DBDir(), "blockstore.db")) {
+		return 0, nil
+	}
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.BackendType(config.DBBackend), config.DBDir())
+	if err != nil {
+		return 0, err
+	}
+	blockStore := store.NewBlockStore(blockStoreDB)
+	defer blockStore.Close()
+
+	height := blockStore.Height()
+	if height == 0 {
+		return 0, nil
+	}
+	commit := blockStore.LoadSeenCommit(height)
+	if commit == nil {
+		return 0, nil
+	}
+	for _, sig := range commit.Signatures {
+		if sig.BlockIDFlag != types.BlockIDFlagAbsent && bytes.Equal(sig.ValidatorAddress, address) {
+			return height, nil
+		}
+	}
+	return 0, nil
+}
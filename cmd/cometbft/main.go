@@ -26,8 +26,15 @@ func main() {
 		cmd.GenNodeKeyCmd,
 		cmd.VersionCmd,
 		cmd.RollbackStateCmd,
+		cmd.ReIndexEventCmd,
+		cmd.ReplayCmd,
+		cmd.ReplayWALCmd,
 		cmd.CompactGoLevelDBCmd,
+		cmd.DBMigrateCmd,
 		cmd.InspectCmd,
+		cmd.ValidatorStateCmd,
+		cmd.KeysCmd,
+		cmd.SuperviseCmd,
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
 	)
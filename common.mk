@@ -44,3 +44,8 @@ endif
 ifeq (boltdb,$(findstring boltdb,$(COMETBFT_BUILD_OPTIONS)))
   BUILD_TAGS += boltdb
 endif
+
+# handle byzantine, only for testing: lets the validators be told to misbehave
+ifeq (byzantine,$(findstring byzantine,$(COMETBFT_BUILD_OPTIONS)))
+  BUILD_TAGS += byzantine
+endif
@@ -4,10 +4,14 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -33,12 +37,16 @@ const (
 	DefaultConfigDir = "config"
 	DefaultDataDir   = "data"
 
+	defaultUpgradeDir = "upgrades"
+
 	DefaultConfigFileName  = "config.toml"
 	DefaultGenesisJSONName = "genesis.json"
 
 	DefaultPrivValKeyName   = "priv_validator_key.json"
 	DefaultPrivValStateName = "priv_validator_state.json"
 
+	DefaultPrivValNextKeyName = "priv_validator_next_key.json"
+
 	DefaultNodeKeyName  = "node_key.json"
 	DefaultAddrBookName = "addrbook.json"
 
@@ -50,6 +58,33 @@ const (
 
 	MempoolTypeFlood = "flood"
 	MempoolTypeNop   = "nop"
+
+	// ModeFull runs a full node: it syncs and executes the blocks, and takes
+	// part in consensus if it is a validator.
+	ModeFull = "full"
+	// ModeSeed runs a seed node: it only crawls the network and shares the
+	// addresses of the peers it finds, without any chain data.
+	ModeSeed = "seed"
+	// ModeSentry runs a full node shielding one or more validators from the
+	// network (see Config.ApplySentryProfile).
+	ModeSentry = "sentry"
+
+	// SubscriptionOverflowCancel cancels the subscriptions whose buffer is
+	// full.
+	SubscriptionOverflowCancel = "cancel"
+	// SubscriptionOverflowDropOldest drops the oldest event in the buffer.
+	SubscriptionOverflowDropOldest = "drop_oldest"
+	// SubscriptionOverflowDropNewest drops the event being published.
+	SubscriptionOverflowDropNewest = "drop_newest"
+	// SubscriptionOverflowBlock blocks the publication of events for up to
+	// subscription_block_timeout, then cancels the subscription.
+	SubscriptionOverflowBlock = "block"
+
+	// PruningPolicyDefault prunes all the blocks below the retain height.
+	PruningPolicyDefault = "default"
+	// PruningPolicySparse keeps every sparse_interval-th block below the
+	// retain height.
+	PruningPolicySparse = "sparse"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -64,6 +99,8 @@ var (
 	defaultPrivValKeyPath   = filepath.Join(DefaultConfigDir, DefaultPrivValKeyName)
 	defaultPrivValStatePath = filepath.Join(DefaultDataDir, DefaultPrivValStateName)
 
+	defaultPrivValNextKeyPath = filepath.Join(DefaultConfigDir, DefaultPrivValNextKeyName)
+
 	defaultNodeKeyPath  = filepath.Join(DefaultConfigDir, DefaultNodeKeyName)
 	defaultAddrBookPath = filepath.Join(DefaultConfigDir, DefaultAddrBookName)
 
@@ -132,6 +169,7 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.RPC.RootDir = root
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
+	cfg.BlockSync.RootDir = root
 	cfg.Consensus.RootDir = root
 	return cfg
 }
@@ -166,15 +204,55 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Storage.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [storage] section: %w", err)
 	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return ErrInSection{Section: "tx_index", Err: err}
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return ErrInSection{Section: "instrumentation", Err: err}
 	}
+	if cfg.Mode == ModeSeed && !cfg.P2P.PexReactor {
+		return ErrSeedModeWithoutPEX
+	}
+	if cfg.Mode == ModeSentry && len(splitList(cfg.P2P.ValidatorPeers)) == 0 {
+		return ErrSentryModeWithoutValidator
+	}
+	if cfg.GenesisURL() != "" && cfg.Storage.GenesisHash == "" {
+		return ErrGenesisURLWithoutHash
+	}
 	if !cfg.Consensus.CreateEmptyBlocks && cfg.Mempool.Type == MempoolTypeNop {
 		return fmt.Errorf("`nop` mempool does not support create_empty_blocks = false")
 	}
 	return nil
 }
 
+// ApplySentryProfile configures the node to shield the validators listed in
+// p2p.validator_peers from the network, as expected in sentry mode:
+//   - the validators are persistent and unconditional peers, so that they are
+//     always connected to, regardless of the peer limits;
+//   - their IDs are private, so that their addresses are never gossiped;
+//   - the peer exchange reactor is enabled, but not the seed mode;
+//   - the mempool broadcasts every transaction, and does not keep a WAL, as
+//     it only relays transactions towards the validators.
+//
+// It is idempotent.
+func (cfg *Config) ApplySentryProfile() {
+	validators := splitList(cfg.P2P.ValidatorPeers)
+	ids := make([]string, 0, len(validators))
+	for _, peer := range validators {
+		id, _, _ := strings.Cut(peer, "@")
+		ids = append(ids, id)
+	}
+
+	cfg.P2P.PersistentPeers = mergeLists(cfg.P2P.PersistentPeers, validators)
+	cfg.P2P.UnconditionalPeerIDs = mergeLists(cfg.P2P.UnconditionalPeerIDs, ids)
+	cfg.P2P.PrivatePeerIDs = mergeLists(cfg.P2P.PrivatePeerIDs, ids)
+	cfg.P2P.PexReactor = true
+	cfg.P2P.SeedMode = false
+
+	cfg.Mempool.Broadcast = true
+	cfg.Mempool.WalPath = ""
+}
+
 // CheckDeprecated returns any deprecation warnings. These are printed to the operator on startup.
 func (cfg *Config) CheckDeprecated() []string {
 	var warnings []string
@@ -201,6 +279,20 @@ type BaseConfig struct {
 	// A custom human readable name for this node
 	Moniker string `mapstructure:"moniker"`
 
+	// Mode of the node: full | seed | sentry
+	// * full (default)
+	//   - syncs and executes the blocks, and takes part in consensus if it
+	//     is a validator
+	// * seed
+	//   - only runs the peer exchange reactor and the address book, crawling
+	//     the network and sharing the addresses of the peers it finds
+	//   - does not use the application, the blockstore nor the state
+	// * sentry
+	//   - a full node shielding the validators in p2p.validator_peers: they
+	//     are always connected to and never gossiped, and the mempool only
+	//     relays transactions (see Config.ApplySentryProfile)
+	Mode string `mapstructure:"mode"`
+
 	// Database backend: goleveldb | cleveldb | boltdb | rocksdb
 	// * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 	//   - pure go
@@ -231,46 +323,172 @@ type BaseConfig struct {
 	// Output format: 'plain' (colored text) or 'json'
 	LogFormat string `mapstructure:"log_format"`
 
-	// Path to the JSON file containing the initial validator set and other meta data
+	// Path to the JSON file containing the initial validator set and other meta data,
+	// or HTTP(S) URL to download it from (see GenesisURL)
 	Genesis string `mapstructure:"genesis_file"`
 
+	// Maximum size in bytes of the genesis file downloaded from genesis_file,
+	// if it is a URL.
+	GenesisMaxSize int64 `mapstructure:"genesis_max_size"`
+
+	// Timeout of the download of the genesis file, or of each of its chunks,
+	// from genesis_file, if it is a URL.
+	GenesisDownloadTimeout time.Duration `mapstructure:"genesis_download_timeout"`
+
 	// Path to the JSON file containing the private key to use as a validator in the consensus protocol
 	PrivValidatorKey string `mapstructure:"priv_validator_key_file"`
 
 	// Path to the JSON file containing the last sign state of a validator
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
+	// Path to the JSON file containing the next private key of a validator,
+	// and the height it signs from, while a key rotation is scheduled
+	PrivValidatorNextKey string `mapstructure:"priv_validator_next_key_file"`
+
+	// Path to the file containing the passphrase of an encrypted
+	// priv_validator_key_file. If empty, the passphrase is read from the
+	// priv_validator_key_passphrase systemd credential, if any, or else
+	// prompted on the terminal.
+	PrivValidatorKeyPassphrase string `mapstructure:"priv_validator_key_passphrase_file"`
+
 	// TCP or UNIX socket address for CometBFT to listen on for
 	// connections from an external PrivValidator process
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// URL of an external service consulted before each new signature of the
+	// file-based PrivValidator, e.g. an arbiter shared by redundant instances
+	// of the validator. The node signs only if the service responds 200 OK to
+	// the POSTed JSON request. Empty disables it.
+	PrivValidatorSignGuardURL string `mapstructure:"priv_validator_sign_guard_url"`
+
+	// Timeout of the requests to priv_validator_sign_guard_url, after which the
+	// signature is refused.
+	PrivValidatorSignGuardTimeout time.Duration `mapstructure:"priv_validator_sign_guard_timeout"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// Maximum duration of an outage of the ABCI application (socket | grpc).
+	// If the connection is lost, the node reconnects with backoff, replays the
+	// blocks the application is missing and resumes; it halts if the
+	// application does not come back in time. 0 disables the reconnection.
+	ABCIMaxOutage time.Duration `mapstructure:"abci_max_outage"`
+
+	// Timeout of the calls to the ABCI application, after which they fail
+	// with an error. 0 means no timeout.
+	ABCICallTimeout time.Duration `mapstructure:"abci_call_timeout"`
+
+	// Timeouts of specific ABCI methods, overriding abci_call_timeout, as a
+	// comma-separated list of method=duration pairs, e.g.
+	// "check_tx=5s,finalize_block=1m".
+	ABCIMethodTimeouts string `mapstructure:"abci_method_timeouts"`
+
+	// Number of consecutive timeouts on an ABCI connection after which its calls
+	// fail fast, until one succeeds after abci_circuit_breaker_cooldown. If it
+	// happens on the consensus connection, the node halts. 0 disables it.
+	ABCICircuitBreakerThreshold int `mapstructure:"abci_circuit_breaker_threshold"`
+
+	// Duration for which the calls fail fast once the circuit breaker opened.
+	ABCICircuitBreakerCooldown time.Duration `mapstructure:"abci_circuit_breaker_cooldown"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
+
+	// Height of the last block the node commits before halting, for a
+	// coordinated upgrade: consensus stops before proposing the next block and
+	// the node writes the data/upgrade-info.json marker file. 0 disables it.
+	// It can also be set with the schedule_halt admin RPC endpoint.
+	HaltHeight int64 `mapstructure:"halt_height"`
+
+	// Path to the binary the node executes, with the same arguments, once it
+	// halted at halt_height, e.g. the upgraded cometbft. Empty keeps the node
+	// running, with consensus halted.
+	UpgradeBinary string `mapstructure:"upgrade_binary"`
+
+	// Directory holding the binaries the supervise command switches between:
+	// genesis/bin/cometbft, <name>/bin/cometbft for each upgrade, and the
+	// current symlink to the directory of the running one.
+	UpgradePath string `mapstructure:"upgrade_dir"`
+
+	// Maximum time the node waits, when stopping, for the in-flight RPC
+	// requests to complete, then for each of the services consensus feeds,
+	// e.g. the indexer, to stop. Consensus always finishes its current step,
+	// and never stops in the middle of signing.
+	ShutdownGracePeriod time.Duration `mapstructure:"shutdown_grace_period"`
+
+	// If true, cross-check the block store, the state, the consensus WAL and
+	// the private validator state on start, repairing the inconsistencies
+	// known to be safe to repair and refusing to start on the others.
+	VerifyOnStart bool `mapstructure:"verify_on_start"`
+
+	// Number of workers of the pool verifying the signatures of the votes
+	// received by consensus and of the commits of the blocks fetched by block
+	// sync, shared by both. 0 sizes it to GOMAXPROCS.
+	SigVerifyWorkers int `mapstructure:"sig_verify_workers"`
+
+	// Growth of the heap, in percent of the live heap, which triggers a
+	// garbage collection, like GOGC. 0 keeps the setting of the runtime (100,
+	// or GOGC), and -1 disables the collections until memory_limit is reached.
+	GCPercent int `mapstructure:"gc_percent"`
+
+	// Soft memory limit of the process in bytes, like GOMEMLIMIT: the garbage
+	// collector runs more often as the memory used approaches it. 0 keeps the
+	// setting of the runtime (none, or GOMEMLIMIT).
+	MemoryLimit int64 `mapstructure:"memory_limit"`
+
+	// Size in bytes of a memory ballast, an allocation never written to which
+	// raises the heap goal, so that the garbage collector runs less often when
+	// the live heap is small. It takes no physical memory but counts towards
+	// memory_limit. 0 allocates none.
+	MemoryBallast int64 `mapstructure:"memory_ballast"`
+
+	// Interval at which the size, the number of keys and the compaction
+	// statistics of the databases are sampled in the background, for the
+	// storage metrics and the /storage_info RPC endpoint. Counting the keys
+	// reads the whole databases. 0 disables the sampling.
+	StorageSampleInterval time.Duration `mapstructure:"storage_sample_interval"`
 }
 
 // DefaultBaseConfig returns a default base configuration for a CometBFT node.
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		Version:            version.CMTSemVer,
-		Genesis:            defaultGenesisJSONPath,
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               "socket",
-		LogLevel:           DefaultLogLevel,
-		LogFormat:          LogFormatPlain,
-		FilterPeers:        false,
-		DBBackend:          "goleveldb",
-		DBPath:             DefaultDataDir,
+		Version:                version.CMTSemVer,
+		Genesis:                defaultGenesisJSONPath,
+		GenesisMaxSize:         1 << 30, // 1GB
+		GenesisDownloadTimeout: 10 * time.Minute,
+		PrivValidatorKey:       defaultPrivValKeyPath,
+		PrivValidatorState:     defaultPrivValStatePath,
+		PrivValidatorNextKey:   defaultPrivValNextKeyPath,
+		NodeKey:                defaultNodeKeyPath,
+		Moniker:                defaultMoniker,
+		Mode:                   ModeFull,
+		ProxyApp:               "tcp://127.0.0.1:26658",
+		ABCI:                   "socket",
+		ABCIMaxOutage:          0,
+		ABCICallTimeout:        0,
+		ABCIMethodTimeouts:     "",
+		LogLevel:               DefaultLogLevel,
+		LogFormat:              LogFormatPlain,
+		FilterPeers:            false,
+		DBBackend:              "goleveldb",
+		DBPath:                 DefaultDataDir,
+		UpgradePath:            defaultUpgradeDir,
+		ShutdownGracePeriod:    10 * time.Second,
+		VerifyOnStart:          false,
+		SigVerifyWorkers:       0,
+		GCPercent:              0,
+		MemoryLimit:            0,
+		MemoryBallast:          0,
+		StorageSampleInterval:  10 * time.Minute,
+
+		ABCICircuitBreakerThreshold: 0,
+		ABCICircuitBreakerCooldown:  30 * time.Second,
+
+		PrivValidatorSignGuardTimeout: time.Second,
 	}
 }
 
@@ -282,11 +500,25 @@ func TestBaseConfig() BaseConfig {
 	return cfg
 }
 
-// GenesisFile returns the full path to the genesis.json file.
+// GenesisFile returns the full path to the genesis.json file. If the genesis
+// file is downloaded, it is the path of the local copy.
 func (cfg BaseConfig) GenesisFile() string {
+	if cfg.GenesisURL() != "" {
+		return rootify(defaultGenesisJSONPath, cfg.RootDir)
+	}
 	return rootify(cfg.Genesis, cfg.RootDir)
 }
 
+// GenesisURL returns the URL to download the genesis file from, or "" if the
+// genesis file is local. The node downloads it if GenesisFile does not exist,
+// and checks it against the storage.genesis_hash.
+func (cfg BaseConfig) GenesisURL() string {
+	if strings.HasPrefix(cfg.Genesis, "https://") || strings.HasPrefix(cfg.Genesis, "http://") {
+		return cfg.Genesis
+	}
+	return ""
+}
+
 // PrivValidatorKeyFile returns the full path to the priv_validator_key.json file.
 func (cfg BaseConfig) PrivValidatorKeyFile() string {
 	return rootify(cfg.PrivValidatorKey, cfg.RootDir)
@@ -297,11 +529,38 @@ func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
 }
 
+// PrivValidatorKeyPassphraseFile returns the full path to the file containing
+// the passphrase of the private validator key, or "" if not set.
+func (cfg BaseConfig) PrivValidatorKeyPassphraseFile() string {
+	if cfg.PrivValidatorKeyPassphrase == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorKeyPassphrase, cfg.RootDir)
+}
+
+// PrivValidatorNextKeyFile returns the full path to the
+// priv_validator_next_key.json file.
+func (cfg BaseConfig) PrivValidatorNextKeyFile() string {
+	return rootify(cfg.PrivValidatorNextKey, cfg.RootDir)
+}
+
+// UpgradeInfoFile returns the full path to the upgrade-info.json marker file,
+// written when the node halts for an upgrade.
+func (cfg BaseConfig) UpgradeInfoFile() string {
+	return rootify(filepath.Join(DefaultDataDir, "upgrade-info.json"), cfg.RootDir)
+}
+
 // NodeKeyFile returns the full path to the node_key.json file.
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// UpgradeDir returns the full path to the directory holding the binaries of
+// the upgrades.
+func (cfg BaseConfig) UpgradeDir() string {
+	return rootify(cfg.UpgradePath, cfg.RootDir)
+}
+
 // DBDir returns the full path to the database directory.
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -321,6 +580,61 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+
+	switch cfg.Mode {
+	case ModeFull, ModeSeed, ModeSentry:
+	default:
+		return ErrUnknownMode{Mode: cfg.Mode}
+	}
+
+	if cfg.ABCIMaxOutage < 0 {
+		return cmterrors.ErrNegativeField{Field: "abci_max_outage"}
+	}
+	if cfg.ABCICallTimeout < 0 {
+		return cmterrors.ErrNegativeField{Field: "abci_call_timeout"}
+	}
+	if cfg.ABCICircuitBreakerThreshold < 0 {
+		return cmterrors.ErrNegativeField{Field: "abci_circuit_breaker_threshold"}
+	}
+	if cfg.ABCICircuitBreakerCooldown < 0 {
+		return cmterrors.ErrNegativeField{Field: "abci_circuit_breaker_cooldown"}
+	}
+	if cfg.HaltHeight < 0 {
+		return cmterrors.ErrNegativeField{Field: "halt_height"}
+	}
+	if cfg.ShutdownGracePeriod < 0 {
+		return cmterrors.ErrNegativeField{Field: "shutdown_grace_period"}
+	}
+	if cfg.PrivValidatorSignGuardTimeout < 0 {
+		return cmterrors.ErrNegativeField{Field: "priv_validator_sign_guard_timeout"}
+	}
+	if cfg.SigVerifyWorkers < 0 {
+		return cmterrors.ErrNegativeField{Field: "sig_verify_workers"}
+	}
+	if cfg.GCPercent < -1 {
+		return cmterrors.ErrInvalidField{Field: "gc_percent", Reason: "must be -1 or greater"}
+	}
+	if cfg.MemoryLimit < 0 {
+		return cmterrors.ErrNegativeField{Field: "memory_limit"}
+	}
+	if cfg.MemoryBallast < 0 {
+		return cmterrors.ErrNegativeField{Field: "memory_ballast"}
+	}
+	if cfg.StorageSampleInterval < 0 {
+		return cmterrors.ErrNegativeField{Field: "storage_sample_interval"}
+	}
+	if cfg.GCPercent == -1 && cfg.MemoryLimit == 0 {
+		return cmterrors.ErrInvalidField{Field: "gc_percent", Reason: "-1 requires memory_limit"}
+	}
+	if cfg.MemoryLimit > 0 && cfg.MemoryBallast >= cfg.MemoryLimit {
+		return cmterrors.ErrInvalidField{Field: "memory_ballast", Reason: "must be less than memory_limit"}
+	}
+	if cfg.GenesisMaxSize <= 0 {
+		return cmterrors.ErrInvalidField{Field: "genesis_max_size", Reason: "must be positive"}
+	}
+	if cfg.GenesisDownloadTimeout <= 0 {
+		return cmterrors.ErrInvalidField{Field: "genesis_download_timeout", Reason: "must be positive"}
+	}
 	return nil
 }
 
@@ -346,7 +660,18 @@ type RPCConfig struct {
 	// A list of non simple headers the client is allowed to use with cross-domain requests.
 	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
 
-	// Activate unsafe RPC commands like /dial_persistent_peers and /unsafe_flush_mempool
+	// Serve the REST facade over the RPC methods under /rest/v1/ (see
+	// rpc/rest).
+	RESTEnabled bool `mapstructure:"rest_enabled"`
+
+	// Serve /attestation, which makes the node's private validator sign an
+	// attestation of a committed block. Off by default, since any client
+	// reaching the RPC server can then request signatures of the node's key.
+	AttestationEnabled bool `mapstructure:"attestation_enabled"`
+
+	// Activate unsafe RPC commands like /dial_persistent_peers and /unsafe_flush_mempool.
+	// If AdminListenAddress is set, they are served on the admin listener
+	// instead of ListenAddress.
 	Unsafe bool `mapstructure:"unsafe"`
 
 	// Maximum number of simultaneous connections (including WebSocket).
@@ -371,6 +696,17 @@ type RPCConfig struct {
 	// returning `ErrOutOfCapacity`.
 	SubscriptionBufferSize int `mapstructure:"experimental_subscription_buffer_size"`
 
+	// What happens to the events published to a subscription whose buffer is
+	// full: "cancel" cancels the subscription, "drop_oldest" drops the oldest
+	// event in the buffer, "drop_newest" drops the event, and "block" blocks
+	// the publication of all the events until there is room in the buffer, for
+	// up to SubscriptionBlockTimeout, then cancels the subscription.
+	SubscriptionOverflowPolicy string `mapstructure:"subscription_overflow_policy"`
+
+	// Maximum time the publication of events is blocked on a subscription with
+	// the "block" overflow policy.
+	SubscriptionBlockTimeout time.Duration `mapstructure:"subscription_block_timeout"`
+
 	// The maximum number of responses that can be buffered per WebSocket
 	// client. If clients cannot read from the WebSocket endpoint fast enough,
 	// they will be disconnected, so increasing this parameter may reduce the
@@ -423,6 +759,57 @@ type RPCConfig struct {
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	// FIXME: This should be moved under the instrumentation section
 	PprofListenAddress string `mapstructure:"pprof_laddr"`
+
+	// UNIX socket or localhost-only TCP address for the admin RPC server to
+	// listen on. When set, the pprof handlers and, if Unsafe is true, the
+	// unsafe RPC endpoints (/dial_seeds, /dial_peers, /unsafe_flush_mempool)
+	// are served exclusively on this listener. Cannot be combined with
+	// PprofListenAddress.
+	AdminListenAddress string `mapstructure:"admin_laddr"`
+
+	// Token clients must present in the "Authorization: Bearer <token>"
+	// header to access the admin RPC server. Required if AdminListenAddress
+	// is set.
+	AdminAuthToken string `mapstructure:"admin_auth_token"`
+
+	// API keys accepted by the RPC server. Clients must present one of them
+	// (or a valid JWT, see AuthJWTSecret) in the "Authorization: Bearer
+	// <token>" header. If both AuthAPIKeys and AuthJWTSecret are empty,
+	// authentication is disabled.
+	AuthAPIKeys []string `mapstructure:"auth_api_keys"`
+
+	// Secret used to verify JSON Web Tokens signed with HS256.
+	AuthJWTSecret string `mapstructure:"auth_jwt_secret"`
+
+	// Maximum number of requests per second a single client can make.
+	// Clients are identified by their IP address, and the requests failing
+	// authentication count too. Each call of a batch, and each call made over
+	// a websocket connection, counts. The calls to the methods of
+	// RateLimitMethods count against their own limits instead.
+	// 0 - unlimited.
+	RateLimit float64 `mapstructure:"rate_limit"`
+
+	// Maximum number of requests a single client can burst, to all the
+	// methods or to a method of RateLimitMethods. Must be positive if a rate
+	// limit is set.
+	RateLimitBurst int `mapstructure:"rate_limit_burst"`
+
+	// Per-method limits replacing RateLimit, as "method:rate" entries
+	// (e.g. "tx_search:1"). The methods must be served by the RPC server. A
+	// rate of 0 means unlimited.
+	RateLimitMethods []string `mapstructure:"rate_limit_methods"`
+
+	// Minimum number of peers for /health to report the node as ready.
+	HealthMinPeers int `mapstructure:"health_min_peers"`
+
+	// Maximum age of the latest block for /health to report consensus as
+	// progressing. 0 disables the check, e.g. for chains not creating empty
+	// blocks.
+	HealthMaxBlockAge time.Duration `mapstructure:"health_max_block_age"`
+
+	// Number of last heights over which /validator_signing_info counts the
+	// blocks missed by the validators. 0 disables the endpoint.
+	SigningInfoWindow int64 `mapstructure:"signing_info_window"`
 }
 
 // DefaultRPCConfig returns a default configuration for the RPC server.
@@ -442,11 +829,24 @@ func DefaultRPCConfig() *RPCConfig {
 		TimeoutBroadcastTxCommit:  10 * time.Second,
 		WebSocketWriteBufferSize:  defaultSubscriptionBufferSize,
 
+		SubscriptionOverflowPolicy: SubscriptionOverflowCancel,
+		SubscriptionBlockTimeout:   100 * time.Millisecond,
+
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
 		TLSCertFile: "",
 		TLSKeyFile:  "",
+
+		AuthAPIKeys:      []string{},
+		RateLimit:        0,
+		RateLimitBurst:   10,
+		RateLimitMethods: []string{},
+
+		HealthMinPeers:    0,
+		HealthMaxBlockAge: 0,
+
+		SigningInfoWindow: 1000,
 	}
 }
 
@@ -455,6 +855,7 @@ func TestRPCConfig() *RPCConfig {
 	cfg := DefaultRPCConfig()
 	cfg.ListenAddress = "tcp://127.0.0.1:36657"
 	cfg.Unsafe = true
+	cfg.AttestationEnabled = true
 	return cfg
 }
 
@@ -479,6 +880,16 @@ func (cfg *RPCConfig) ValidateBasic() error {
 			cfg.SubscriptionBufferSize,
 		)
 	}
+	switch cfg.SubscriptionOverflowPolicy {
+	case SubscriptionOverflowCancel, SubscriptionOverflowDropOldest, SubscriptionOverflowDropNewest, SubscriptionOverflowBlock:
+	default:
+		return fmt.Errorf("unknown subscription_overflow_policy %q (must be %q, %q, %q or %q)",
+			cfg.SubscriptionOverflowPolicy, SubscriptionOverflowCancel, SubscriptionOverflowDropOldest,
+			SubscriptionOverflowDropNewest, SubscriptionOverflowBlock)
+	}
+	if cfg.SubscriptionBlockTimeout < 0 {
+		return cmterrors.ErrNegativeField{Field: "subscription_block_timeout"}
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return cmterrors.ErrNegativeField{Field: "timeout_broadcast_tx_commit"}
 	}
@@ -488,9 +899,77 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_header_bytes"}
 	}
+	if cfg.RateLimit < 0 {
+		return cmterrors.ErrNegativeField{Field: "rate_limit"}
+	}
+	if cfg.RateLimitBurst < 0 {
+		return cmterrors.ErrNegativeField{Field: "rate_limit_burst"}
+	}
+	if cfg.HealthMinPeers < 0 {
+		return cmterrors.ErrNegativeField{Field: "health_min_peers"}
+	}
+	if cfg.HealthMaxBlockAge < 0 {
+		return cmterrors.ErrNegativeField{Field: "health_max_block_age"}
+	}
+	if cfg.SigningInfoWindow < 0 {
+		return cmterrors.ErrNegativeField{Field: "signing_info_window"}
+	}
+	limits, err := cfg.RateLimitPerMethod()
+	if err != nil {
+		return err
+	}
+	if cfg.RateLimitBurst == 0 {
+		rateLimited := cfg.RateLimit > 0
+		for _, rate := range limits {
+			rateLimited = rateLimited || rate > 0
+		}
+		if rateLimited {
+			return errors.New("rate_limit_burst must be positive when a rate limit is set, " +
+				"otherwise all the requests are rejected")
+		}
+	}
+	if cfg.IsAdminEnabled() {
+		if cfg.IsPprofEnabled() {
+			return errors.New("pprof_laddr cannot be set together with admin_laddr, " +
+				"which already serves the pprof handlers")
+		}
+		if cfg.AdminAuthToken == "" {
+			return errors.New("admin_auth_token must be set when admin_laddr is set")
+		}
+		if err := validateAdminListenAddress(cfg.AdminListenAddress); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// validateAdminListenAddress ensures the admin RPC server can only be exposed
+// on a UNIX socket or a loopback TCP address.
+func validateAdminListenAddress(addr string) error {
+	proto, hostPort, ok := strings.Cut(addr, "://")
+	if !ok {
+		return fmt.Errorf("admin_laddr %q must include the tcp:// or unix:// prefix", addr)
+	}
+	switch proto {
+	case "unix":
+		return nil
+	case "tcp":
+		host, _, err := net.SplitHostPort(hostPort)
+		if err != nil {
+			return fmt.Errorf("invalid admin_laddr %q: %w", addr, err)
+		}
+		if host != "localhost" {
+			ip := net.ParseIP(host)
+			if ip == nil || !ip.IsLoopback() {
+				return fmt.Errorf("admin_laddr %q must be a loopback address", addr)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("admin_laddr %q has unsupported protocol %q", addr, proto)
+	}
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
 	return len(cfg.CORSAllowedOrigins) != 0
@@ -500,6 +979,42 @@ func (cfg *RPCConfig) IsPprofEnabled() bool {
 	return len(cfg.PprofListenAddress) != 0
 }
 
+// IsAuthEnabled returns true if clients must authenticate to the RPC server.
+func (cfg *RPCConfig) IsAuthEnabled() bool {
+	return len(cfg.AuthAPIKeys) != 0 || cfg.AuthJWTSecret != ""
+}
+
+// IsRateLimitEnabled returns true if any RPC rate limit is set.
+func (cfg *RPCConfig) IsRateLimitEnabled() bool {
+	return cfg.RateLimit > 0 || len(cfg.RateLimitMethods) != 0
+}
+
+// RateLimitPerMethod parses RateLimitMethods into a map from method name to
+// rate (requests per second).
+func (cfg *RPCConfig) RateLimitPerMethod() (map[string]float64, error) {
+	limits := make(map[string]float64, len(cfg.RateLimitMethods))
+	for _, entry := range cfg.RateLimitMethods {
+		method, rateStr, ok := strings.Cut(entry, ":")
+		if !ok || method == "" {
+			return nil, fmt.Errorf("rate_limit_methods entry %q must be of the form \"method:rate\"", entry)
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("rate_limit_methods entry %q has invalid rate: %w", entry, err)
+		}
+		if rate < 0 {
+			return nil, fmt.Errorf("rate_limit_methods entry %q has negative rate", entry)
+		}
+		limits[method] = rate
+	}
+	return limits, nil
+}
+
+// IsAdminEnabled returns true if the admin RPC server is enabled.
+func (cfg *RPCConfig) IsAdminEnabled() bool {
+	return len(cfg.AdminListenAddress) != 0
+}
+
 func (cfg RPCConfig) KeyFile() string {
 	path := cfg.TLSKeyFile
 	if filepath.IsAbs(path) {
@@ -540,6 +1055,10 @@ type GRPCConfig struct {
 	// If no height is provided, the block results of the latest height are returned
 	BlockResultsService *GRPCBlockResultsServiceConfig `mapstructure:"block_results_service"`
 
+	// The gRPC event log service streams the events of the committed blocks
+	// from a durable log, from any retained height
+	EventLogService *GRPCEventLogServiceConfig `mapstructure:"event_log_service"`
+
 	// The "privileged" section provides configuration for the gRPC server
 	// dedicated to privileged clients.
 	Privileged *GRPCPrivilegedConfig `mapstructure:"privileged"`
@@ -551,6 +1070,7 @@ func DefaultGRPCConfig() *GRPCConfig {
 		VersionService:      DefaultGRPCVersionServiceConfig(),
 		BlockService:        DefaultGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		EventLogService:     DefaultGRPCEventLogServiceConfig(),
 		Privileged:          DefaultGRPCPrivilegedConfig(),
 	}
 }
@@ -561,6 +1081,7 @@ func TestGRPCConfig() *GRPCConfig {
 		VersionService:      TestGRPCVersionServiceConfig(),
 		BlockService:        TestGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		EventLogService:     DefaultGRPCEventLogServiceConfig(),
 		Privileged:          TestGRPCPrivilegedConfig(),
 	}
 }
@@ -575,6 +1096,9 @@ func (cfg *GRPCConfig) ValidateBasic() error {
 			)
 		}
 	}
+	if cfg.EventLogService.RetainBlocks < 0 {
+		return cmterrors.ErrNegativeField{Field: "event_log_service.retain_blocks"}
+	}
 	return nil
 }
 
@@ -620,6 +1144,24 @@ func TestGRPCBlockServiceConfig() *GRPCBlockServiceConfig {
 	}
 }
 
+// GRPCEventLogServiceConfig defines the configuration of the event log, which
+// persists the events of the committed blocks, and of the gRPC service
+// streaming them.
+type GRPCEventLogServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// The number of latest blocks whose events are kept in the log. If 0,
+	// the events of all the blocks are kept.
+	RetainBlocks int64 `mapstructure:"retain_blocks"`
+}
+
+func DefaultGRPCEventLogServiceConfig() *GRPCEventLogServiceConfig {
+	return &GRPCEventLogServiceConfig{
+		Enabled:      false,
+		RetainBlocks: 100000,
+	}
+}
+
 //-----------------------------------------------------------------------------
 // GRPCPrivilegedConfig
 
@@ -685,6 +1227,10 @@ type P2PConfig struct { //nolint: maligned
 	// Comma separated list of nodes to keep persistent connections to
 	PersistentPeers string `mapstructure:"persistent_peers"`
 
+	// Nodes to keep persistent connections to, with their own dial policy, in
+	// addition to PersistentPeers
+	PersistentPeerConfigs []PersistentPeerConfig `mapstructure:"persistent_peer"`
+
 	// Path to address book
 	AddrBook string `mapstructure:"addr_book_file"`
 
@@ -729,9 +1275,60 @@ type P2PConfig struct { //nolint: maligned
 	// other peers)
 	PrivatePeerIDs string `mapstructure:"private_peer_ids"`
 
+	// Comma separated list of the validators (ID@host:port) shielded by this
+	// node, when running in sentry mode.
+	ValidatorPeers string `mapstructure:"validator_peers"`
+
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
+	// If true, IPv6 is preferred over IPv4: the host names of the peers
+	// resolving to both are dialed on their IPv6 address, and IPv6 addresses
+	// are picked first from the address book.
+	PreferIPv6 bool `mapstructure:"prefer_ipv6"`
+
+	// If true, the RPC and gRPC servers also accept connections on
+	// ListenAddress, the protocol of each connection being detected from its
+	// first bytes. The privileged gRPC server is never served on it.
+	SharedPort bool `mapstructure:"shared_port"`
+
+	// Regular expressions matched against the moniker of the peers during the
+	// handshake. If AllowedMonikers is not empty, the peers must match one of
+	// them. The peers matching one of DeniedMonikers are rejected.
+	AllowedMonikers []string `mapstructure:"allowed_monikers"`
+	DeniedMonikers  []string `mapstructure:"denied_monikers"`
+
+	// If AllowedAppVersions is not empty, the peers must be on one of these
+	// app versions. The peers on one of DeniedAppVersions are rejected.
+	AllowedAppVersions []uint64 `mapstructure:"allowed_app_versions"`
+	DeniedAppVersions  []uint64 `mapstructure:"denied_app_versions"`
+
+	// If AllowedBlockVersions is not empty, the peers must be on one of these
+	// block versions, instead of ours. The peers on one of
+	// DeniedBlockVersions are rejected.
+	AllowedBlockVersions []uint64 `mapstructure:"allowed_block_versions"`
+	DeniedBlockVersions  []uint64 `mapstructure:"denied_block_versions"`
+
+	// Maximum skew of the local clock relative to the clocks of the peers,
+	// estimated from the times they report in the handshake, above which an
+	// error is logged. On start, the time of the last block must not be more
+	// than MaxClockSkew ahead of the local clock either. 0 disables the checks.
+	MaxClockSkew time.Duration `mapstructure:"max_clock_skew"`
+
+	// If true, the node refuses to start if the time of the last block is
+	// more than MaxClockSkew ahead of the local clock.
+	RefuseClockSkew bool `mapstructure:"refuse_clock_skew"`
+
+	// Names of the reactors, e.g. PEX, a panic of which, while receiving a
+	// message, only stops the reactor, whose state may be corrupted. The peer
+	// the message came from stays connected, and the messages on the channels
+	// of a stopped reactor are dropped. None by default.
+	IsolatedReactors []string `mapstructure:"isolated_reactors"`
+
+	// Time after which an isolated reactor stopped by a panic is restarted.
+	// 0 disables the restart.
+	ReactorRestartDelay time.Duration `mapstructure:"reactor_restart_delay"`
+
 	// Peer connection configuration.
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
@@ -761,6 +1358,12 @@ func DefaultP2PConfig() *P2PConfig {
 		PexReactor:                   true,
 		SeedMode:                     false,
 		AllowDuplicateIP:             false,
+		SharedPort:                   false,
+		PreferIPv6:                   false,
+		MaxClockSkew:                 2 * time.Second,
+		RefuseClockSkew:              false,
+		IsolatedReactors:             []string{},
+		ReactorRestartDelay:          10 * time.Second,
 		HandshakeTimeout:             20 * time.Second,
 		DialTimeout:                  3 * time.Second,
 		TestDialFail:                 false,
@@ -807,6 +1410,112 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return cmterrors.ErrNegativeField{Field: "recv_rate"}
 	}
+	if cfg.MaxClockSkew < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_clock_skew"}
+	}
+	if cfg.ReactorRestartDelay < 0 {
+		return cmterrors.ErrNegativeField{Field: "reactor_restart_delay"}
+	}
+	for _, peer := range splitList(cfg.ValidatorPeers) {
+		if !strings.Contains(peer, "@") {
+			return ErrInvalidValidatorPeer{Peer: peer}
+		}
+	}
+	for _, peer := range cfg.PersistentPeerConfigs {
+		if err := peer.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	for _, pattern := range append(slices.Clone(cfg.AllowedMonikers), cfg.DeniedMonikers...) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return ErrInvalidMonikerPattern{Pattern: pattern, Err: err}
+		}
+	}
+	return nil
+}
+
+// AllPersistentPeers returns the addresses of the persistent peers, listed
+// either in persistent_peers or in the persistent_peer table.
+func (cfg *P2PConfig) AllPersistentPeers() []string {
+	addrs := make([]string, 0, len(cfg.PersistentPeerConfigs))
+	for _, peer := range cfg.PersistentPeerConfigs {
+		addrs = append(addrs, peer.Address)
+	}
+	return splitList(mergeLists(cfg.PersistentPeers, addrs))
+}
+
+// AllUnconditionalPeerIDs returns the IDs of the unconditional peers, listed
+// either in unconditional_peer_ids or in the persistent_peer table.
+func (cfg *P2PConfig) AllUnconditionalPeerIDs() []string {
+	ids := make([]string, 0, len(cfg.PersistentPeerConfigs))
+	for _, peer := range cfg.PersistentPeerConfigs {
+		if peer.Unconditional {
+			ids = append(ids, peer.ID())
+		}
+	}
+	return splitList(mergeLists(cfg.UnconditionalPeerIDs, ids))
+}
+
+// AllPrivatePeerIDs returns the IDs of the private peers, listed either in
+// private_peer_ids or in the persistent_peer table.
+func (cfg *P2PConfig) AllPrivatePeerIDs() []string {
+	ids := make([]string, 0, len(cfg.PersistentPeerConfigs))
+	for _, peer := range cfg.PersistentPeerConfigs {
+		if peer.Private {
+			ids = append(ids, peer.ID())
+		}
+	}
+	return splitList(mergeLists(cfg.PrivatePeerIDs, ids))
+}
+
+// PersistentPeerConfig is the configuration of a persistent peer, with its
+// dial policy.
+type PersistentPeerConfig struct {
+	// Address of the peer (ID@host:port)
+	Address string `mapstructure:"address"`
+
+	// Interval between the attempts to reconnect to the peer, before the
+	// exponential backoff (if zero, the default interval is used)
+	DialInterval time.Duration `mapstructure:"dial_interval"`
+
+	// Connect to the peer ignoring any existing limits
+	Unconditional bool `mapstructure:"unconditional"`
+
+	// Do not gossip the address of the peer
+	Private bool `mapstructure:"private"`
+
+	// IDs of the channels whose messages are sent to the peer in priority
+	PreferredChannels []int `mapstructure:"preferred_channels"`
+
+	// Number of attempts to reconnect to the peer, every dial_interval,
+	// before giving up (if zero, the default reconnection schedule is used,
+	// with exponential backoff)
+	MaxReconnectAttempts int `mapstructure:"max_reconnect_attempts"`
+}
+
+// ID returns the ID of the peer.
+func (cfg PersistentPeerConfig) ID() string {
+	id, _, _ := strings.Cut(cfg.Address, "@")
+	return id
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg PersistentPeerConfig) ValidateBasic() error {
+	if !strings.Contains(cfg.Address, "@") {
+		return ErrInvalidPersistentPeer{Address: cfg.Address}
+	}
+	if cfg.DialInterval < 0 {
+		return cmterrors.ErrNegativeField{Field: "persistent_peer.dial_interval"}
+	}
+	if cfg.MaxReconnectAttempts < 0 {
+		return cmterrors.ErrNegativeField{Field: "persistent_peer.max_reconnect_attempts"}
+	}
+	for _, ch := range cfg.PreferredChannels {
+		if ch < 0 || ch > math.MaxUint8 {
+			return ErrInvalidChannelID{ID: ch}
+		}
+	}
 	return nil
 }
 
@@ -869,6 +1578,8 @@ type MempoolConfig struct {
 	// (WAL) for the mempool. The WAL is disabled by default. To enable, set
 	// WalPath to where you want the WAL to be written (e.g.
 	// "data/mempool.wal").
+	// The transactions in the WAL are checked again when the node restarts,
+	// so that the valid ones are not lost.
 	WalPath string `mapstructure:"wal_dir"`
 	// Maximum number of transactions in the mempool
 	Size int `mapstructure:"size"`
@@ -903,6 +1614,26 @@ type MempoolConfig struct {
 	// performance results using the default P2P configuration.
 	ExperimentalMaxGossipConnectionsToPersistentPeers    int `mapstructure:"experimental_max_gossip_connections_to_persistent_peers"`
 	ExperimentalMaxGossipConnectionsToNonPersistentPeers int `mapstructure:"experimental_max_gossip_connections_to_non_persistent_peers"`
+	// InitialSync (default: false) makes the node request the transactions
+	// in the mempool of the peers it connects to while its own mempool is
+	// empty, e.g. after a restart, instead of waiting for them to be gossiped.
+	InitialSync bool `mapstructure:"initial_sync"`
+	// Maximum number of transactions requested from, and sent to, a peer
+	// during the initial sync.
+	InitialSyncMaxTxs int `mapstructure:"initial_sync_max_txs"`
+	// Maximum total weight of the transactions reaped for a proposal, as
+	// reported by the application in the weight field of the CheckTx
+	// responses. This applies on top of the max_bytes and max_gas of the block.
+	// 0 means no limit.
+	MaxBlockWeight int64 `mapstructure:"max_block_weight"`
+	// SenderQueue (default: false) makes the mempool hold the valid
+	// transactions whose sequence, reported by the application in the CheckTx
+	// response, is ahead of the next sequence of their sender, and check them
+	// again once the transactions before them are added, instead of leaving
+	// the application to reject them.
+	SenderQueue bool `mapstructure:"sender_queue"`
+	// Maximum number of transactions held per sender by the sender queue.
+	SenderQueueMaxTxs int `mapstructure:"sender_queue_max_txs"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the CometBFT mempool.
@@ -920,6 +1651,11 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxBytes:  1024 * 1024, // 1MB
 		ExperimentalMaxGossipConnectionsToNonPersistentPeers: 0,
 		ExperimentalMaxGossipConnectionsToPersistentPeers:    0,
+		InitialSync:       false,
+		InitialSyncMaxTxs: 1000,
+		MaxBlockWeight:    0,
+		SenderQueue:       false,
+		SenderQueueMaxTxs: 16,
 	}
 }
 
@@ -967,6 +1703,15 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.ExperimentalMaxGossipConnectionsToNonPersistentPeers < 0 {
 		return cmterrors.ErrNegativeField{Field: "experimental_max_gossip_connections_to_non_persistent_peers"}
 	}
+	if cfg.InitialSyncMaxTxs < 0 {
+		return cmterrors.ErrNegativeField{Field: "initial_sync_max_txs"}
+	}
+	if cfg.MaxBlockWeight < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_block_weight"}
+	}
+	if cfg.SenderQueueMaxTxs < 0 {
+		return cmterrors.ErrNegativeField{Field: "sender_queue_max_txs"}
+	}
 	return nil
 }
 
@@ -978,6 +1723,7 @@ type StateSyncConfig struct {
 	Enable              bool          `mapstructure:"enable"`
 	TempDir             string        `mapstructure:"temp_dir"`
 	RPCServers          []string      `mapstructure:"rpc_servers"`
+	FetchFromRPCServers bool          `mapstructure:"fetch_from_rpc_servers"`
 	TrustPeriod         time.Duration `mapstructure:"trust_period"`
 	TrustHeight         int64         `mapstructure:"trust_height"`
 	TrustHash           string        `mapstructure:"trust_hash"`
@@ -1065,16 +1811,60 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 
 // BlockSyncConfig (formerly known as FastSync) defines the configuration for the CometBFT block sync service.
 type BlockSyncConfig struct {
+	RootDir string `mapstructure:"home"`
+
 	Version string `mapstructure:"version"`
+
+	// Number of goroutines verifying the commits of the fetched blocks ahead
+	// of the apply loop. If 0, blocks are verified by the apply loop itself.
+	VerifyWorkers int `mapstructure:"verify_workers"`
+
+	// Maximum number of blocks requested ahead of the last applied block.
+	MaxPendingBlocks int `mapstructure:"max_pending_blocks"`
+
+	// Maximum number of pending blocks kept in memory. If smaller than
+	// MaxPendingBlocks, the blocks furthest from the last applied block are
+	// spilled to a database in QueuePath until they are needed.
+	MaxMemoryBlocks int `mapstructure:"max_memory_blocks"`
+
+	// Path to the database holding the pending blocks spilled to disk.
+	QueuePath string `mapstructure:"queue_dir"`
+
+	// If not 0, enables the light mode: the commits of the blocks are only
+	// verified at the heights multiple of CheckpointInterval, and the blocks in
+	// between are authenticated by the chain of hashes leading to the next
+	// checkpoint. Their commits are still verified when applying the next
+	// block. If 0, the default, the commit of every block is verified before
+	// the block is applied.
+	CheckpointInterval int64 `mapstructure:"checkpoint_interval"`
+
+	// RPC servers whose headers the synced blocks are cross-checked against
+	// before being applied, detecting a majority of peers feeding a fork. At
+	// least two are required, if any.
+	Witnesses []string `mapstructure:"witnesses"`
 }
 
 // DefaultBlockSyncConfig returns a default configuration for the block sync service.
 func DefaultBlockSyncConfig() *BlockSyncConfig {
 	return &BlockSyncConfig{
-		Version: "v0",
+		Version:          "v0",
+		VerifyWorkers:    4,
+		MaxPendingBlocks: 600,
+		MaxMemoryBlocks:  600,
+		QueuePath:        filepath.Join(DefaultDataDir, "blocksync.queue"),
 	}
 }
 
+// QueueDir returns the full path to the database of spilled pending blocks.
+func (cfg *BlockSyncConfig) QueueDir() string {
+	return rootify(cfg.QueuePath, cfg.RootDir)
+}
+
+// QueueEnabled returns true if pending blocks are spilled to disk.
+func (cfg *BlockSyncConfig) QueueEnabled() bool {
+	return cfg.MaxPendingBlocks > cfg.MaxMemoryBlocks
+}
+
 // TestBlockSyncConfig returns a default configuration for the block sync.
 func TestBlockSyncConfig() *BlockSyncConfig {
 	return DefaultBlockSyncConfig()
@@ -1084,12 +1874,41 @@ func TestBlockSyncConfig() *BlockSyncConfig {
 func (cfg *BlockSyncConfig) ValidateBasic() error {
 	switch cfg.Version {
 	case v0:
-		return nil
 	case v1, v2:
 		return ErrDeprecatedBlocksyncVersion{Version: cfg.Version, Allowed: []string{v0}}
 	default:
 		return ErrUnknownBlocksyncVersion{cfg.Version}
 	}
+	if cfg.VerifyWorkers < 0 {
+		return cmterrors.ErrNegativeField{Field: "verify_workers"}
+	}
+	if cfg.MaxPendingBlocks <= 0 {
+		return cmterrors.ErrRequiredField{Field: "max_pending_blocks"}
+	}
+	if cfg.MaxMemoryBlocks <= 0 {
+		return cmterrors.ErrRequiredField{Field: "max_memory_blocks"}
+	}
+	if cfg.MaxMemoryBlocks > cfg.MaxPendingBlocks {
+		return ErrMemoryBlocksExceedPending
+	}
+	if cfg.QueueEnabled() && cfg.QueuePath == "" {
+		return cmterrors.ErrRequiredField{Field: "queue_dir"}
+	}
+	if cfg.CheckpointInterval < 0 {
+		return cmterrors.ErrNegativeField{Field: "checkpoint_interval"}
+	}
+	if cfg.CheckpointInterval >= int64(cfg.MaxMemoryBlocks) {
+		return ErrCheckpointIntervalTooLarge
+	}
+	if len(cfg.Witnesses) == 1 {
+		return ErrNotEnoughWitnesses
+	}
+	for _, witness := range cfg.Witnesses {
+		if len(witness) == 0 {
+			return ErrEmptyWitnessEntry
+		}
+	}
+	return nil
 }
 
 //-----------------------------------------------------------------------------
@@ -1123,6 +1942,14 @@ type ConsensusConfig struct {
 	// Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 	SkipTimeoutCommit bool `mapstructure:"skip_timeout_commit"`
 
+	// TargetBlockInterval, if non-zero, is the interval between the starts of
+	// consecutive heights. After committing a block, we wait until the target
+	// interval since the start of its height has elapsed, instead of
+	// TimeoutCommit, so that blocks are produced at a steady cadence. A height
+	// taking longer than the target starts the next one right away.
+	// TimeoutCommit and SkipTimeoutCommit are ignored when set.
+	TargetBlockInterval time.Duration `mapstructure:"target_block_interval"`
+
 	// EmptyBlocks mode and possible interval between empty blocks
 	CreateEmptyBlocks         bool          `mapstructure:"create_empty_blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create_empty_blocks_interval"`
@@ -1147,6 +1974,7 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		TimeoutPrecommitDelta:            500 * time.Millisecond,
 		TimeoutCommit:                    1000 * time.Millisecond,
 		SkipTimeoutCommit:                false,
+		TargetBlockInterval:              0,
 		CreateEmptyBlocks:                true,
 		CreateEmptyBlocksInterval:        0 * time.Second,
 		PeerGossipSleepDuration:          100 * time.Millisecond,
@@ -1206,6 +2034,27 @@ func (cfg *ConsensusConfig) Commit(t time.Time) time.Time {
 	return t.Add(cfg.TimeoutCommit)
 }
 
+// NextStartTime returns when to start the height following the one started at
+// startTime and committed at commitTime: TargetBlockInterval after startTime,
+// but not before commitTime, if TargetBlockInterval is set, and Commit(commitTime)
+// otherwise.
+func (cfg *ConsensusConfig) NextStartTime(startTime, commitTime time.Time) time.Time {
+	if cfg.TargetBlockInterval == 0 || startTime.IsZero() {
+		return cfg.Commit(commitTime)
+	}
+	next := startTime.Add(cfg.TargetBlockInterval)
+	if next.Before(commitTime) {
+		return commitTime
+	}
+	return next
+}
+
+// SkipCommit returns true if the consensus can start the next height as soon
+// as it has all the precommits, without waiting for the start time.
+func (cfg *ConsensusConfig) SkipCommit() bool {
+	return cfg.SkipTimeoutCommit && cfg.TargetBlockInterval == 0
+}
+
 // WalFile returns the full path to the write-ahead log file.
 func (cfg *ConsensusConfig) WalFile() string {
 	if cfg.walFile != "" {
@@ -1243,6 +2092,9 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.TimeoutCommit < 0 {
 		return cmterrors.ErrNegativeField{Field: "timeout_commit"}
 	}
+	if cfg.TargetBlockInterval < 0 {
+		return cmterrors.ErrNegativeField{Field: "target_block_interval"}
+	}
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return cmterrors.ErrNegativeField{Field: "create_empty_blocks_interval"}
 	}
@@ -1328,17 +2180,78 @@ type TxIndexConfig struct {
 	//   2) "kv" (default) - the simplest possible indexer,
 	//      backed by key-value storage (defaults to levelDB; see DBBackend).
 	//   3) "psql" - the indexer services backed by PostgreSQL.
+	//   4) "kafka" - publishes the events to Kafka, see KafkaBrokers.
+	//   5) "nats" - publishes the events to NATS, see NATSURL.
+	//
+	// Several indexers can be listed, separated by commas (e.g. "kv,psql"),
+	// in which case events are written to all of them and the first one
+	// serves queries.
 	Indexer string `mapstructure:"indexer"`
 
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// The Kafka brokers, as host:port, to which the "kafka" indexer publishes
+	// the events.
+	KafkaBrokers []string `mapstructure:"kafka-brokers"`
+
+	// The SASL mechanism the "kafka" indexer authenticates with, if
+	// StreamUsername is set: "PLAIN" (default), "SCRAM-SHA-256" or
+	// "SCRAM-SHA-512".
+	KafkaSASLMechanism string `mapstructure:"kafka-sasl-mechanism"`
+
+	// The URL of the NATS server to which the "nats" indexer publishes the
+	// events: nats://[<user>:<password>@|<token>@]<host>[:<port>].
+	NATSURL string `mapstructure:"nats-url"`
+
+	// The NATS credentials file, holding the JWT and the NKey seed the "nats"
+	// indexer authenticates with.
+	NATSCredentialsFile string `mapstructure:"nats-credentials-file"`
+
+	// The user name and password the "kafka" and "nats" indexers authenticate
+	// with, if set.
+	StreamUsername string `mapstructure:"stream-username"`
+	StreamPassword string `mapstructure:"stream-password"`
+
+	// Whether the "kafka" and "nats" indexers connect to the broker over TLS.
+	// The server certificate is verified against StreamTLSCAFile, or the
+	// system roots if it is empty. StreamTLSCertFile and StreamTLSKeyFile are
+	// the client certificate and key, if the broker requires one.
+	StreamTLS         bool   `mapstructure:"stream-tls"`
+	StreamTLSCAFile   string `mapstructure:"stream-tls-ca-file"`
+	StreamTLSCertFile string `mapstructure:"stream-tls-cert-file"`
+	StreamTLSKeyFile  string `mapstructure:"stream-tls-key-file"`
+
+	// The prefix of the Kafka topics, or NATS subjects, to which the "kafka"
+	// and "nats" indexers publish the events of the blocks ("<prefix>.blocks")
+	// and of the transactions ("<prefix>.txs").
+	StreamTopicPrefix string `mapstructure:"stream-topic-prefix"`
+
+	// The time the "kafka" and "nats" indexers wait for the broker to
+	// acknowledge the events of a block, or of its transactions.
+	StreamTimeout time.Duration `mapstructure:"stream-timeout"`
+
+	// Event types, or composite keys ("type.attribute"), to index. If empty,
+	// all events marked for indexing by the application are indexed. Only
+	// applies to the "kv" indexer.
+	IndexEvents []string `mapstructure:"index_events"`
+
+	// Event types, or composite keys ("type.attribute"), never to index. Takes
+	// precedence over IndexEvents. Only applies to the "kv" indexer.
+	ExcludeEvents []string `mapstructure:"exclude_events"`
+
+	// Indexers, among those listed in Indexer, whose failures are logged and
+	// otherwise ignored, e.g. while migrating to a new indexer.
+	IgnoreFailures []string `mapstructure:"ignore_failures"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
 func DefaultTxIndexConfig() *TxIndexConfig {
 	return &TxIndexConfig{
-		Indexer: "kv",
+		Indexer:           "kv",
+		StreamTopicPrefix: "cometbft",
+		StreamTimeout:     10 * time.Second,
 	}
 }
 
@@ -1347,6 +2260,81 @@ func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
 }
 
+// Sinks returns the names of the indexers listed in Indexer.
+func (cfg *TxIndexConfig) Sinks() []string {
+	var sinks []string
+	for _, s := range strings.Split(cfg.Indexer, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			sinks = append(sinks, s)
+		}
+	}
+	return sinks
+}
+
+// IgnoresFailures reports whether failures of the given indexer are ignored.
+func (cfg *TxIndexConfig) IgnoresFailures(sink string) bool {
+	for _, s := range cfg.IgnoreFailures {
+		if strings.EqualFold(strings.TrimSpace(s), sink) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBasic performs basic validation and returns an error if any check
+// fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	sinks := cfg.Sinks()
+	seen := make(map[string]bool, len(sinks))
+	for _, s := range sinks {
+		if seen[s] {
+			return fmt.Errorf("indexer %q listed more than once", s)
+		}
+		seen[s] = true
+	}
+	for _, s := range cfg.IgnoreFailures {
+		if !seen[strings.ToLower(strings.TrimSpace(s))] {
+			return fmt.Errorf("ignore_failures: indexer %q is not listed in indexer", s)
+		}
+	}
+	for _, e := range cfg.IndexEvents {
+		if e == "" {
+			return errors.New("index_events must not contain empty entries")
+		}
+	}
+	for _, e := range cfg.ExcludeEvents {
+		if e == "" {
+			return errors.New("exclude_events must not contain empty entries")
+		}
+	}
+	if seen["kafka"] || seen["nats"] {
+		if cfg.StreamTopicPrefix == "" {
+			return cmterrors.ErrRequiredField{Field: "stream-topic-prefix"}
+		}
+		if cfg.StreamTimeout <= 0 {
+			return cmterrors.ErrInvalidField{Field: "stream-timeout", Reason: "must be positive"}
+		}
+	}
+	if (cfg.StreamTLSCertFile == "") != (cfg.StreamTLSKeyFile == "") {
+		return errors.New("stream-tls-cert-file and stream-tls-key-file must be set together")
+	}
+	switch cfg.KafkaSASLMechanism {
+	case "", "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512":
+	default:
+		return cmterrors.ErrInvalidField{
+			Field:  "kafka-sasl-mechanism",
+			Reason: "must be one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512",
+		}
+	}
+	if seen["kafka"] && len(cfg.KafkaBrokers) == 0 {
+		return cmterrors.ErrRequiredField{Field: "kafka-brokers"}
+	}
+	if seen["nats"] && cfg.NATSURL == "" {
+		return cmterrors.ErrRequiredField{Field: "nats-url"}
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // InstrumentationConfig
 
@@ -1368,8 +2356,25 @@ type InstrumentationConfig struct {
 
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
+
+	// Labels all the metrics carry, among MetricsLabelChainID,
+	// MetricsLabelMoniker and MetricsLabelNodeID. Whatever the order they are
+	// listed in, they are added in this order.
+	Labels []string `mapstructure:"labels"`
+
+	// When true, the metrics are also served under /metrics on the RPC
+	// server, for the environments where PrometheusListenAddr can't be
+	// exposed.
+	ServeOnRPC bool `mapstructure:"serve_on_rpc"`
 }
 
+// Labels of the metrics.
+const (
+	MetricsLabelChainID = "chain_id"
+	MetricsLabelMoniker = "moniker"
+	MetricsLabelNodeID  = "node_id"
+)
+
 // DefaultInstrumentationConfig returns a default configuration for metrics
 // reporting.
 func DefaultInstrumentationConfig() *InstrumentationConfig {
@@ -1378,6 +2383,8 @@ func DefaultInstrumentationConfig() *InstrumentationConfig {
 		PrometheusListenAddr: ":26660",
 		MaxOpenConnections:   3,
 		Namespace:            "cometbft",
+		Labels:               []string{MetricsLabelChainID},
+		ServeOnRPC:           false,
 	}
 }
 
@@ -1393,6 +2400,13 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_open_connections"}
 	}
+	for _, label := range cfg.Labels {
+		switch label {
+		case MetricsLabelChainID, MetricsLabelMoniker, MetricsLabelNodeID:
+		default:
+			return ErrUnknownMetricsLabel{Label: label}
+		}
+	}
 	return nil
 }
 
@@ -1400,10 +2414,38 @@ func (cfg *InstrumentationConfig) IsPrometheusEnabled() bool {
 	return cfg.Prometheus && cfg.PrometheusListenAddr != ""
 }
 
+// IsMetricsEnabled returns true if the metrics are served, by the Prometheus
+// server or the RPC server.
+func (cfg *InstrumentationConfig) IsMetricsEnabled() bool {
+	return cfg.Prometheus || cfg.ServeOnRPC
+}
+
 //-----------------------------------------------------------------------------
 // Utils
 
 // helper function to make config creation independent of root dir.
+// splitList splits a comma separated list, ignoring empty entries.
+func splitList(list string) []string {
+	var entries []string
+	for _, entry := range strings.Split(list, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// mergeLists adds the entries not in the comma separated list to it.
+func mergeLists(list string, entries []string) string {
+	merged := splitList(list)
+	for _, entry := range entries {
+		if !slices.Contains(merged, entry) {
+			merged = append(merged, entry)
+		}
+	}
+	return strings.Join(merged, ",")
+}
+
 func rootify(path, root string) string {
 	if filepath.IsAbs(path) {
 		return path
@@ -1432,21 +2474,31 @@ func getDefaultMoniker() string {
 type PruningConfig struct {
 	// The time period between automated background pruning operations.
 	Interval time.Duration `mapstructure:"interval"`
+	// Which blocks are pruned below the retain height: "default" prunes all of
+	// them, "sparse" keeps every SparseInterval-th block, along with its
+	// state and its indexed events.
+	Policy string `mapstructure:"policy"`
+	// Interval between two blocks kept by the "sparse" policy.
+	SparseInterval int64 `mapstructure:"sparse_interval"`
 	// Data companion-related pruning configuration.
 	DataCompanion *DataCompanionPruningConfig `mapstructure:"data_companion"`
 }
 
 func DefaultPruningConfig() *PruningConfig {
 	return &PruningConfig{
-		Interval:      DefaultPruningInterval,
-		DataCompanion: DefaultDataCompanionPruningConfig(),
+		Interval:       DefaultPruningInterval,
+		Policy:         PruningPolicyDefault,
+		SparseInterval: 1000,
+		DataCompanion:  DefaultDataCompanionPruningConfig(),
 	}
 }
 
 func TestPruningConfig() *PruningConfig {
 	return &PruningConfig{
-		Interval:      DefaultPruningInterval,
-		DataCompanion: TestDataCompanionPruningConfig(),
+		Interval:       DefaultPruningInterval,
+		Policy:         PruningPolicyDefault,
+		SparseInterval: 1000,
+		DataCompanion:  TestDataCompanionPruningConfig(),
 	}
 }
 
@@ -1454,12 +2506,29 @@ func (cfg *PruningConfig) ValidateBasic() error {
 	if cfg.Interval <= 0 {
 		return errors.New("interval must be > 0")
 	}
+	switch cfg.Policy {
+	case PruningPolicyDefault, PruningPolicySparse:
+	default:
+		return fmt.Errorf("unknown policy %q (must be %q or %q)", cfg.Policy, PruningPolicyDefault, PruningPolicySparse)
+	}
+	if cfg.SparseInterval <= 0 {
+		return cmterrors.ErrInvalidField{Field: "sparse_interval", Reason: "must be positive"}
+	}
 	if err := cfg.DataCompanion.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [data_companion] section: %w", err)
 	}
 	return nil
 }
 
+// SparseRetention returns the interval between two blocks kept below the
+// retain height, or 0 if none is kept.
+func (cfg *PruningConfig) SparseRetention() int64 {
+	if cfg.Policy != PruningPolicySparse {
+		return 0
+	}
+	return cfg.SparseInterval
+}
+
 //-----------------------------------------------------------------------------
 // DataCompanionPruningConfig
 
@@ -43,6 +43,46 @@ func TestConfigValidateBasic(t *testing.T) {
 	cfg.Consensus.CreateEmptyBlocks = false
 	cfg.Mempool.Type = config.MempoolTypeNop
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.Consensus.CreateEmptyBlocks = true
+
+	// seed nodes need the PEX reactor
+	cfg.Mode = config.ModeSeed
+	cfg.P2P.PexReactor = false
+	assert.ErrorIs(t, cfg.ValidateBasic(), config.ErrSeedModeWithoutPEX)
+	cfg.P2P.PexReactor = true
+
+	// sentry nodes need a validator
+	cfg.Mode = config.ModeSentry
+	assert.ErrorIs(t, cfg.ValidateBasic(), config.ErrSentryModeWithoutValidator)
+	cfg.P2P.ValidatorPeers = "abcd@1.2.3.4:26656"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.P2P.ValidatorPeers = "1.2.3.4:26656"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestConfigApplySentryProfile(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Mode = config.ModeSentry
+	cfg.P2P.ValidatorPeers = "val1@1.2.3.4:26656, val2@5.6.7.8:26656"
+	cfg.P2P.PersistentPeers = "sentry@9.9.9.9:26656,val1@1.2.3.4:26656"
+	cfg.P2P.PrivatePeerIDs = "val2"
+	cfg.P2P.PexReactor = false
+	cfg.P2P.SeedMode = true
+	cfg.Mempool.Broadcast = false
+	cfg.Mempool.WalPath = "data/mempool.wal"
+
+	for i := 0; i < 2; i++ {
+		cfg.ApplySentryProfile()
+
+		assert.Equal(t, "sentry@9.9.9.9:26656,val1@1.2.3.4:26656,val2@5.6.7.8:26656", cfg.P2P.PersistentPeers)
+		assert.Equal(t, "val1,val2", cfg.P2P.UnconditionalPeerIDs)
+		assert.Equal(t, "val2,val1", cfg.P2P.PrivatePeerIDs)
+		assert.True(t, cfg.P2P.PexReactor)
+		assert.False(t, cfg.P2P.SeedMode)
+		assert.True(t, cfg.Mempool.Broadcast)
+		assert.False(t, cfg.Mempool.WalEnabled())
+		require.NoError(t, cfg.ValidateBasic())
+	}
 }
 
 func TestTLSConfiguration(t *testing.T) {
@@ -68,6 +108,33 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogFormat = config.LogFormatPlain
+
+	cfg.Mode = config.ModeSeed
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Mode = "validator"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Mode = config.ModeFull
+
+	cfg.SigVerifyWorkers = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SigVerifyWorkers = 0
+
+	cfg.GCPercent = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MemoryLimit = 1 << 30
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.GCPercent = -2
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.GCPercent = 0
+
+	cfg.MemoryBallast = 1 << 30
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MemoryLimit = 0
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.StorageSampleInterval = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -90,6 +157,72 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 	}
 }
 
+func TestRPCConfigRateLimitPerMethod(t *testing.T) {
+	cfg := config.TestRPCConfig()
+	cfg.RateLimitMethods = []string{"tx_search:1", "health:0", "block:2.5"}
+	limits, err := cfg.RateLimitPerMethod()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"tx_search": 1, "health": 0, "block": 2.5}, limits)
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, entry := range []string{"tx_search", ":1", "tx_search:x", "tx_search:-1"} {
+		cfg.RateLimitMethods = []string{entry}
+		assert.Error(t, cfg.ValidateBasic(), entry)
+	}
+
+	cfg.RateLimitMethods = nil
+	cfg.RateLimit = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// a zero burst rejects all the requests of a limited method
+	cfg.RateLimitBurst = 0
+	cfg.RateLimit = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.RateLimit = 1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RateLimit = 0
+	cfg.RateLimitMethods = []string{"tx_search:1"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RateLimitMethods = []string{"tx_search:0"}
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestRPCConfigValidateAdminListenAddress(t *testing.T) {
+	testCases := []struct {
+		addr    string
+		token   string
+		wantErr bool
+	}{
+		{"unix:///tmp/cometbft-admin.sock", "secret", false},
+		{"unix:///tmp/cometbft-admin.sock", "", true},
+		{"tcp://127.0.0.1:26659", "secret", false},
+		{"tcp://localhost:26659", "secret", false},
+		{"tcp://[::1]:26659", "secret", false},
+		{"tcp://127.0.0.1:26659", "", true},
+		{"tcp://0.0.0.0:26659", "secret", true},
+		{"tcp://10.0.0.1:26659", "secret", true},
+		{"127.0.0.1:26659", "secret", true},
+		{"udp://127.0.0.1:26659", "secret", true},
+	}
+	for _, tc := range testCases {
+		cfg := config.TestRPCConfig()
+		cfg.AdminListenAddress = tc.addr
+		cfg.AdminAuthToken = tc.token
+		if tc.wantErr {
+			assert.Error(t, cfg.ValidateBasic(), tc.addr)
+		} else {
+			assert.NoError(t, cfg.ValidateBasic(), tc.addr)
+		}
+	}
+
+	// The admin listener already serves pprof.
+	cfg := config.TestRPCConfig()
+	cfg.AdminListenAddress = "tcp://127.0.0.1:26659"
+	cfg.AdminAuthToken = "secret"
+	cfg.PprofListenAddress = "localhost:6060"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 func TestP2PConfigValidateBasic(t *testing.T) {
 	cfg := config.TestP2PConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -108,6 +241,38 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.AllowedMonikers = []string{"^val-[0-9]+$"}
+	cfg.DeniedMonikers = []string{"legacy"}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.DeniedMonikers = []string{"legacy("}
+	assert.ErrorAs(t, cfg.ValidateBasic(), &config.ErrInvalidMonikerPattern{})
+}
+
+func TestP2PConfigPersistentPeerConfigs(t *testing.T) {
+	cfg := config.TestP2PConfig()
+	cfg.PersistentPeers = "a@1.1.1.1:26656,b@2.2.2.2:26656"
+	cfg.UnconditionalPeerIDs = "a"
+	cfg.PersistentPeerConfigs = []config.PersistentPeerConfig{
+		{Address: "b@2.2.2.2:26656", Unconditional: true},
+		{Address: "c@3.3.3.3:26656", Private: true, PreferredChannels: []int{0x20, 0x21}},
+	}
+	require.NoError(t, cfg.ValidateBasic())
+
+	assert.Equal(t, []string{"a@1.1.1.1:26656", "b@2.2.2.2:26656", "c@3.3.3.3:26656"}, cfg.AllPersistentPeers())
+	assert.Equal(t, []string{"a", "b"}, cfg.AllUnconditionalPeerIDs())
+	assert.Equal(t, []string{"c"}, cfg.AllPrivatePeerIDs())
+
+	testCases := []config.PersistentPeerConfig{
+		{Address: "3.3.3.3:26656"},
+		{Address: "c@3.3.3.3:26656", DialInterval: -time.Second},
+		{Address: "c@3.3.3.3:26656", MaxReconnectAttempts: -1},
+		{Address: "c@3.3.3.3:26656", PreferredChannels: []int{256}},
+	}
+	for _, tc := range testCases {
+		cfg.PersistentPeerConfigs = []config.PersistentPeerConfig{tc}
+		assert.Error(t, cfg.ValidateBasic(), "%+v", tc)
+	}
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -119,6 +284,9 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"InitialSyncMaxTxs",
+		"MaxBlockWeight",
+		"SenderQueueMaxTxs",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -146,6 +314,37 @@ func TestBlockSyncConfigValidateBasic(t *testing.T) {
 
 	cfg.Version = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBlockSyncConfig()
+	cfg.VerifyWorkers = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBlockSyncConfig()
+	cfg.MaxMemoryBlocks = cfg.MaxPendingBlocks + 1
+	assert.ErrorIs(t, cfg.ValidateBasic(), config.ErrMemoryBlocksExceedPending)
+
+	cfg = config.TestBlockSyncConfig()
+	cfg.MaxPendingBlocks = 2 * cfg.MaxMemoryBlocks
+	assert.True(t, cfg.QueueEnabled())
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.QueuePath = ""
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBlockSyncConfig()
+	cfg.CheckpointInterval = 100
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.CheckpointInterval = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.CheckpointInterval = int64(cfg.MaxMemoryBlocks)
+	assert.ErrorIs(t, cfg.ValidateBasic(), config.ErrCheckpointIntervalTooLarge)
+
+	cfg = config.TestBlockSyncConfig()
+	cfg.Witnesses = []string{"127.0.0.1:26657"}
+	assert.ErrorIs(t, cfg.ValidateBasic(), config.ErrNotEnoughWitnesses)
+	cfg.Witnesses = append(cfg.Witnesses, "127.0.0.2:26657")
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Witnesses = append(cfg.Witnesses, "")
+	assert.ErrorIs(t, cfg.ValidateBasic(), config.ErrEmptyWitnessEntry)
 }
 
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
@@ -168,6 +367,8 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"TimeoutPrecommitDelta negative":       {func(c *config.ConsensusConfig) { c.TimeoutPrecommitDelta = -1 }, true},
 		"TimeoutCommit":                        {func(c *config.ConsensusConfig) { c.TimeoutCommit = time.Second }, false},
 		"TimeoutCommit negative":               {func(c *config.ConsensusConfig) { c.TimeoutCommit = -1 }, true},
+		"TargetBlockInterval":                  {func(c *config.ConsensusConfig) { c.TargetBlockInterval = time.Second }, false},
+		"TargetBlockInterval negative":         {func(c *config.ConsensusConfig) { c.TargetBlockInterval = -1 }, true},
 		"PeerGossipSleepDuration":              {func(c *config.ConsensusConfig) { c.PeerGossipSleepDuration = time.Second }, false},
 		"PeerGossipSleepDuration negative":     {func(c *config.ConsensusConfig) { c.PeerGossipSleepDuration = -1 }, true},
 		"PeerQueryMaj23SleepDuration":          {func(c *config.ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
@@ -190,6 +391,25 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestConsensusConfigNextStartTime(t *testing.T) {
+	cfg := config.DefaultConsensusConfig()
+	start := time.Now()
+	commit := start.Add(300 * time.Millisecond)
+	assert.Equal(t, commit.Add(cfg.TimeoutCommit), cfg.NextStartTime(start, commit))
+	assert.False(t, cfg.SkipCommit())
+
+	cfg.SkipTimeoutCommit = true
+	cfg.TargetBlockInterval = 2 * time.Second
+	assert.Equal(t, start.Add(2*time.Second), cfg.NextStartTime(start, commit))
+	// A height taking longer than the target starts the next one right away.
+	late := start.Add(3 * time.Second)
+	assert.Equal(t, late, cfg.NextStartTime(start, late))
+	// Without the start of the last height, e.g. on startup, we wait for
+	// TimeoutCommit.
+	assert.Equal(t, commit.Add(cfg.TimeoutCommit), cfg.NextStartTime(time.Time{}, commit))
+	assert.False(t, cfg.SkipCommit())
+}
+
 func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg := config.TestInstrumentationConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -197,4 +417,94 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	// tamper with maximum open connections
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxOpenConnections = 3
+
+	cfg.Labels = []string{config.MetricsLabelNodeID, config.MetricsLabelMoniker}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Labels = []string{"validator"}
+	assert.ErrorAs(t, cfg.ValidateBasic(), &config.ErrUnknownMetricsLabel{})
+}
+
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.IndexEvents = []string{"transfer", "message.sender"}
+	cfg.ExcludeEvents = []string{"transfer.amount"}
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.IndexEvents = []string{""}
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.IndexEvents = nil
+	cfg.ExcludeEvents = []string{"transfer", ""}
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestTxIndexConfigValidateBasicStream(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	cfg.Indexer = "kv,kafka,nats"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.KafkaBrokers = []string{"127.0.0.1:9092"}
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.NATSURL = "nats://127.0.0.1:4222"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.StreamTopicPrefix = ""
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.StreamTopicPrefix = "chain"
+	cfg.StreamTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.StreamTimeout = time.Second
+	cfg.KafkaSASLMechanism = "SCRAM-SHA-1"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.KafkaSASLMechanism = "SCRAM-SHA-512"
+	cfg.StreamTLSCertFile = "client.pem"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.StreamTLSKeyFile = "client-key.pem"
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestTxIndexConfigSinks(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	assert.Equal(t, []string{"kv"}, cfg.Sinks())
+
+	cfg.Indexer = " kv , PSQL,"
+	assert.Equal(t, []string{"kv", "psql"}, cfg.Sinks())
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.IgnoreFailures = []string{"psql"}
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.True(t, cfg.IgnoresFailures("psql"))
+	assert.False(t, cfg.IgnoresFailures("kv"))
+
+	cfg.IgnoreFailures = []string{"null"}
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.IgnoreFailures = nil
+	cfg.Indexer = "kv,kv"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestPruningConfigValidateBasic(t *testing.T) {
+	cfg := config.TestPruningConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Zero(t, cfg.SparseRetention())
+
+	cfg.Policy = config.PruningPolicySparse
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.EqualValues(t, 1000, cfg.SparseRetention())
+
+	cfg.SparseInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SparseInterval = 1000
+
+	cfg.Policy = "archive"
+	assert.Error(t, cfg.ValidateBasic())
 }
@@ -1,15 +1,14 @@
+//go:build !js && !wasip1 && !nodb
+
 package config
 
 import (
-	"context"
-
 	dbm "github.com/cometbft/cometbft-db"
-	"github.com/cometbft/cometbft/internal/service"
-	"github.com/cometbft/cometbft/libs/log"
 )
 
-// ServiceProvider takes a config and a logger and returns a ready to go Node.
-type ServiceProvider func(context.Context, *Config, log.Logger) (service.Service, error)
+// The database providers are left out of the builds targeting js/wasm and
+// wasip1, where goleveldb does not compile, and of the builds with the nodb
+// tag, e.g. light clients for mobile devices, which do not need them.
 
 // DBContext specifies config information for loading a new DB.
 type DBContext struct {
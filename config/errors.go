@@ -12,6 +12,13 @@ var (
 	ErrInsufficientChunkRequestTimeout = errors.New("timeout for re-requesting a chunk (chunk_request_timeout) is less than 5 seconds")
 	ErrUnknownLogFormat                = errors.New("unknown log_format (must be 'plain' or 'json')")
 	ErrSubscriptionBufferSizeInvalid   = fmt.Errorf("experimental_subscription_buffer_size must be >= %d", minSubscriptionBufferSize)
+	ErrMemoryBlocksExceedPending       = errors.New("max_memory_blocks must not be greater than max_pending_blocks")
+	ErrCheckpointIntervalTooLarge      = errors.New("checkpoint_interval must be smaller than max_memory_blocks")
+	ErrNotEnoughWitnesses              = errors.New("at least two witnesses entries are required")
+	ErrEmptyWitnessEntry               = errors.New("found empty witnesses entry")
+	ErrSeedModeWithoutPEX              = errors.New("seed mode requires the peer exchange reactor (p2p.pex = true)")
+	ErrSentryModeWithoutValidator      = errors.New("sentry mode requires at least one p2p.validator_peers entry")
+	ErrGenesisURLWithoutHash           = errors.New("downloading the genesis file requires storage.genesis_hash")
 )
 
 // ErrInSection is returned if validate basic does not pass for any underlying config service.
@@ -44,3 +51,56 @@ type ErrUnknownBlocksyncVersion struct {
 func (e ErrUnknownBlocksyncVersion) Error() string {
 	return fmt.Sprintf("unknown blocksync version %s", e.Version)
 }
+
+type ErrUnknownMode struct {
+	Mode string
+}
+
+func (e ErrUnknownMode) Error() string {
+	return fmt.Sprintf("unknown mode %q (must be 'full', 'seed' or 'sentry')", e.Mode)
+}
+
+type ErrInvalidValidatorPeer struct {
+	Peer string
+}
+
+func (e ErrInvalidValidatorPeer) Error() string {
+	return fmt.Sprintf("invalid validator_peers entry %q (must be ID@host:port)", e.Peer)
+}
+
+type ErrInvalidPersistentPeer struct {
+	Address string
+}
+
+func (e ErrInvalidPersistentPeer) Error() string {
+	return fmt.Sprintf("invalid persistent_peer address %q (must be ID@host:port)", e.Address)
+}
+
+type ErrInvalidChannelID struct {
+	ID int
+}
+
+func (e ErrInvalidChannelID) Error() string {
+	return fmt.Sprintf("invalid channel ID %d (must be between 0 and 255)", e.ID)
+}
+
+type ErrInvalidMonikerPattern struct {
+	Pattern string
+	Err     error
+}
+
+func (e ErrInvalidMonikerPattern) Error() string {
+	return fmt.Sprintf("invalid moniker pattern %q: %v", e.Pattern, e.Err)
+}
+
+func (e ErrInvalidMonikerPattern) Unwrap() error {
+	return e.Err
+}
+
+type ErrUnknownMetricsLabel struct {
+	Label string
+}
+
+func (e ErrUnknownMetricsLabel) Error() string {
+	return fmt.Sprintf("unknown metrics label %q (must be 'chain_id', 'moniker' or 'node_id')", e.Label)
+}
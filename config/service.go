@@ -0,0 +1,11 @@
+package config
+
+import (
+	"context"
+
+	"github.com/cometbft/cometbft/internal/service"
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// ServiceProvider takes a config and a logger and returns a ready to go Node.
+type ServiceProvider func(context.Context, *Config, log.Logger) (service.Service, error)
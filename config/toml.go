@@ -89,6 +89,20 @@ proxy_app = "{{ .BaseConfig.ProxyApp }}"
 # A custom human readable name for this node
 moniker = "{{ .BaseConfig.Moniker }}"
 
+# Mode of the node: full | seed | sentry
+# * full (default)
+#   - syncs and executes the blocks, and takes part in consensus if it is a
+#     validator
+# * seed
+#   - only runs the peer exchange reactor and the address book, crawling the
+#     network and sharing the addresses of the peers it finds
+#   - does not use the application, the blockstore nor the state
+# * sentry
+#   - a full node shielding the validators in p2p.validator_peers: they are
+#     always connected to and never gossiped, and the mempool only relays
+#     transactions
+mode = "{{ .BaseConfig.Mode }}"
+
 # Database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb
 # * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 #   - pure go
@@ -121,29 +135,138 @@ log_format = "{{ .BaseConfig.LogFormat }}"
 
 ##### additional base config options #####
 
-# Path to the JSON file containing the initial validator set and other meta data
+# Path to the JSON file containing the initial validator set and other meta data.
+# It can also be an HTTPS URL, from which the node downloads the file, or the
+# manifest of a content-addressed chunk set, at startup if config/genesis.json
+# does not exist. storage.genesis_hash is then required, to verify it.
 genesis_file = "{{ js .BaseConfig.Genesis }}"
 
+# Maximum size in bytes of the genesis file downloaded from genesis_file, if it
+# is a URL.
+genesis_max_size = {{ .BaseConfig.GenesisMaxSize }}
+
+# Timeout of the download of the genesis file, or of each of its chunks, from
+# genesis_file, if it is a URL.
+genesis_download_timeout = "{{ .BaseConfig.GenesisDownloadTimeout }}"
+
 # Path to the JSON file containing the private key to use as a validator in the consensus protocol
 priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 
 # Path to the JSON file containing the last sign state of a validator
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
+# Path to the JSON file containing the next private key of a validator, and
+# the height it signs from, while a key rotation is scheduled
+priv_validator_next_key_file = "{{ js .BaseConfig.PrivValidatorNextKey }}"
+
+# Path to the file containing the passphrase of an encrypted
+# priv_validator_key_file. If empty, the passphrase is read from the
+# priv_validator_key_passphrase systemd credential, if any, or else prompted on
+# the terminal.
+priv_validator_key_passphrase_file = "{{ js .BaseConfig.PrivValidatorKeyPassphrase }}"
+
 # TCP or UNIX socket address for CometBFT to listen on for
 # connections from an external PrivValidator process
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# URL of an external service consulted before each new signature of the
+# file-based PrivValidator, e.g. an arbiter shared by redundant instances of
+# the validator. The node signs only if the service responds 200 OK to the
+# POSTed JSON request. Empty disables it.
+priv_validator_sign_guard_url = "{{ .BaseConfig.PrivValidatorSignGuardURL }}"
+
+# Timeout of the requests to priv_validator_sign_guard_url, after which the
+# signature is refused.
+priv_validator_sign_guard_timeout = "{{ .BaseConfig.PrivValidatorSignGuardTimeout }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# Maximum duration of an outage of the ABCI application (socket | grpc).
+# If the connection is lost, the node reconnects with backoff, replays the
+# blocks the application is missing and resumes; it halts if the application
+# does not come back in time. 0 disables the reconnection.
+abci_max_outage = "{{ .BaseConfig.ABCIMaxOutage }}"
+
+# Timeout of the calls to the ABCI application, after which they fail with an
+# error. 0 means no timeout.
+abci_call_timeout = "{{ .BaseConfig.ABCICallTimeout }}"
+
+# Timeouts of specific ABCI methods, overriding abci_call_timeout, as a
+# comma-separated list of method=duration pairs, e.g.
+# "check_tx=5s,finalize_block=1m".
+abci_method_timeouts = "{{ .BaseConfig.ABCIMethodTimeouts }}"
+
+# Number of consecutive timeouts on an ABCI connection after which its calls
+# fail fast, until one succeeds after abci_circuit_breaker_cooldown. If it
+# happens on the consensus connection, the node halts. 0 disables it.
+abci_circuit_breaker_threshold = {{ .BaseConfig.ABCICircuitBreakerThreshold }}
+
+# Duration for which the calls fail fast once the circuit breaker opened.
+abci_circuit_breaker_cooldown = "{{ .BaseConfig.ABCICircuitBreakerCooldown }}"
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter_peers = {{ .BaseConfig.FilterPeers }}
 
+# Height of the last block the node commits before halting, for a coordinated
+# upgrade: consensus stops before proposing the next block and the node writes
+# the data/upgrade-info.json marker file. 0 disables it. It can also be set
+# with the schedule_halt admin RPC endpoint.
+halt_height = {{ .BaseConfig.HaltHeight }}
+
+# Path to the binary the node executes, with the same arguments, once it halted
+# at halt_height, e.g. the upgraded cometbft. Empty keeps the node running, with
+# consensus halted.
+upgrade_binary = "{{ js .BaseConfig.UpgradeBinary }}"
+
+# Directory holding the binaries the supervise command switches between:
+# genesis/bin/cometbft, <name>/bin/cometbft for each upgrade, and the current
+# symlink to the directory of the running one.
+upgrade_dir = "{{ js .BaseConfig.UpgradePath }}"
+
+# Maximum time the node waits, when stopping, for the in-flight RPC requests to
+# complete, then for each of the services consensus feeds, e.g. the indexer, to
+# stop. Consensus always finishes its current step, and never stops in the
+# middle of signing.
+shutdown_grace_period = "{{ .BaseConfig.ShutdownGracePeriod }}"
+
+# If true, cross-check the block store, the state, the consensus WAL and the
+# private validator state on start, repairing the inconsistencies known to be
+# safe to repair (a corrupted WAL tail, a WAL ahead of the block store) and
+# refusing to start on the others.
+verify_on_start = {{ .BaseConfig.VerifyOnStart }}
+
+# Number of workers of the pool verifying the signatures of the votes received
+# by consensus and of the commits of the blocks fetched by block sync, shared by
+# both. 0 sizes it to GOMAXPROCS.
+sig_verify_workers = {{ .BaseConfig.SigVerifyWorkers }}
+
+# Growth of the heap, in percent of the live heap, which triggers a garbage
+# collection, like GOGC. 0 keeps the setting of the runtime (100, or GOGC), and
+# -1 disables the collections until memory_limit is reached.
+gc_percent = {{ .BaseConfig.GCPercent }}
+
+# Soft memory limit of the process in bytes, like GOMEMLIMIT: the garbage
+# collector runs more often as the memory used approaches it. 0 keeps the
+# setting of the runtime (none, or GOMEMLIMIT).
+memory_limit = {{ .BaseConfig.MemoryLimit }}
+
+# Size in bytes of a memory ballast, an allocation never written to which raises
+# the heap goal, so that the garbage collector runs less often when the live
+# heap is small. It takes no physical memory but counts towards memory_limit.
+# 0 allocates none.
+memory_ballast = {{ .BaseConfig.MemoryBallast }}
+
+# Interval at which the size, the number of keys and the compaction statistics
+# of the databases are sampled in the background, for the storage metrics and
+# the /storage_info RPC endpoint. Counting the keys reads the whole databases.
+# 0 disables the sampling.
+storage_sample_interval = "{{ .BaseConfig.StorageSampleInterval }}"
+
 
 #######################################################################
 ###                 Advanced Configuration Options                  ###
@@ -168,7 +291,18 @@ cors_allowed_methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 # A list of non simple headers the client is allowed to use with cross-domain requests
 cors_allowed_headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
-# Activate unsafe RPC commands like /dial_seeds and /unsafe_flush_mempool
+# Serve a REST facade over the RPC methods under /rest/v1/, documented by the
+# OpenAPI specification served at /rest/v1/openapi.yaml.
+rest_enabled = {{ .RPC.RESTEnabled }}
+
+# Serve /attestation, which makes the node's private validator sign an
+# attestation of a committed block. Off by default, since any client reaching
+# the RPC server can then request signatures of the node's key. Attestations
+# are signed at most once per height.
+attestation_enabled = {{ .RPC.AttestationEnabled }}
+
+# Activate unsafe RPC commands like /dial_seeds and /unsafe_flush_mempool.
+# If admin_laddr is set, they are served on the admin listener instead of laddr.
 unsafe = {{ .RPC.Unsafe }}
 
 # Maximum number of simultaneous connections (including WebSocket).
@@ -195,6 +329,19 @@ max_subscriptions_per_client = {{ .RPC.MaxSubscriptionsPerClient }}
 # higher event throughput rates (and will use more memory).
 experimental_subscription_buffer_size = {{ .RPC.SubscriptionBufferSize }}
 
+# What happens to the events published to a subscription whose buffer is full:
+# - "cancel" cancels the subscription
+# - "drop_oldest" drops the oldest event in the buffer
+# - "drop_newest" drops the event
+# - "block" blocks the publication of all the events until there is room in
+#   the buffer, for up to subscription_block_timeout, then cancels the
+#   subscription
+subscription_overflow_policy = "{{ .RPC.SubscriptionOverflowPolicy }}"
+
+# Maximum time the publication of events is blocked on a subscription with the
+# "block" overflow policy.
+subscription_block_timeout = "{{ .RPC.SubscriptionBlockTimeout }}"
+
 # Experimental parameter to specify the maximum number of RPC responses that
 # can be buffered per WebSocket client. If clients cannot read from the
 # WebSocket endpoint fast enough, they will be disconnected, so increasing this
@@ -246,6 +393,54 @@ tls_key_file = "{{ .RPC.TLSKeyFile }}"
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 
+# UNIX socket or localhost-only TCP address for the admin RPC server to listen
+# on. When set, the pprof handlers and, if "unsafe" is true, the unsafe RPC
+# endpoints (/dial_seeds, /dial_peers, /unsafe_flush_mempool) are only served
+# on this listener, so that "laddr" can be safely exposed to the public.
+# Cannot be combined with pprof_laddr.
+admin_laddr = "{{ .RPC.AdminListenAddress }}"
+
+# Token clients must present in the "Authorization: Bearer <token>" header to
+# access the admin RPC server. Required if admin_laddr is set.
+admin_auth_token = "{{ .RPC.AdminAuthToken }}"
+
+# API keys accepted by the RPC server. Clients must present one of them (or a
+# valid JWT, see auth_jwt_secret) in the "Authorization: Bearer <token>"
+# header. If both auth_api_keys and auth_jwt_secret are empty, authentication
+# is disabled.
+auth_api_keys = [{{ range .RPC.AuthAPIKeys }}{{ printf "%q, " . }}{{end}}]
+
+# Secret used to verify JSON Web Tokens signed with HS256.
+auth_jwt_secret = "{{ .RPC.AuthJWTSecret }}"
+
+# Maximum number of requests per second a single client can make. Clients are
+# identified by their IP address, and the requests failing authentication
+# count too. Each call of a batch, and each call made over a websocket
+# connection, counts. The calls to the methods of rate_limit_methods count
+# against their own limits instead.
+# 0 - unlimited.
+rate_limit = {{ .RPC.RateLimit }}
+
+# Maximum number of requests a single client can burst, to all the methods or
+# to a method of rate_limit_methods. Must be positive if a rate limit is set.
+rate_limit_burst = {{ .RPC.RateLimitBurst }}
+
+# Per-method limits replacing rate_limit, as "method:rate" entries
+# (e.g. ["tx_search:1", "broadcast_tx_commit:5"]). The methods must be served
+# by the RPC server. A rate of 0 means unlimited.
+rate_limit_methods = [{{ range .RPC.RateLimitMethods }}{{ printf "%q, " . }}{{end}}]
+
+# Minimum number of peers for /health to report the node as ready.
+health_min_peers = {{ .RPC.HealthMinPeers }}
+
+# Maximum age of the latest block for /health to report consensus as
+# progressing. 0 disables the check, e.g. for chains not creating empty blocks.
+health_max_block_age = "{{ .RPC.HealthMaxBlockAge }}"
+
+# Number of last heights over which /validator_signing_info counts the blocks
+# missed by the validators. 0 disables the endpoint.
+signing_info_window = {{ .RPC.SigningInfoWindow }}
+
 #######################################################
 ###       gRPC Server Configuration Options         ###
 #######################################################
@@ -285,6 +480,17 @@ enabled = {{ .GRPC.BlockService.Enabled }}
 [grpc.block_results_service]
 enabled = {{ .GRPC.BlockResultsService.Enabled }}
 
+# The gRPC event log service streams the events of the committed blocks from a
+# durable log. When enabled, the node writes the events of every block to the
+# log, and consumers, such as external indexers, can subscribe from any
+# retained height and resume where they left off after a restart.
+[grpc.event_log_service]
+enabled = {{ .GRPC.EventLogService.Enabled }}
+
+# The number of latest blocks whose events are kept in the log. Set to 0 to
+# keep the events of all the blocks.
+retain_blocks = {{ .GRPC.EventLogService.RetainBlocks }}
+
 #
 # Configuration for privileged gRPC endpoints, which should **never** be exposed
 # to the public internet.
@@ -368,13 +574,89 @@ seed_mode = {{ .P2P.SeedMode }}
 # Comma separated list of peer IDs to keep private (will not be gossiped to other peers)
 private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 
+# Comma separated list of the validators (ID@host:port) shielded by this node,
+# when running in sentry mode. They are added to the persistent, unconditional
+# and private peers.
+validator_peers = "{{ .P2P.ValidatorPeers }}"
+
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
+# If true, IPv6 is preferred over IPv4: the host names of the peers resolving
+# to both (dual-stack) are dialed on their IPv6 address, and IPv6 addresses are
+# picked first from the address book.
+prefer_ipv6 = {{ .P2P.PreferIPv6 }}
+
+# If true, the RPC and gRPC servers also accept connections on laddr, the
+# protocol of each connection being detected from its first bytes. This allows
+# exposing a single port. The privileged gRPC server is never served on it.
+shared_port = {{ .P2P.SharedPort }}
+
+# Rules the peers must comply with during the handshake, on top of being on the
+# same network and block version, so that upgraded networks can shed the
+# incompatible peers. An empty allowlist allows everything.
+#
+# Regular expressions matched against the moniker of the peers.
+allowed_monikers = [{{ range .P2P.AllowedMonikers }}{{ printf "%q, " . }}{{end}}]
+denied_monikers = [{{ range .P2P.DeniedMonikers }}{{ printf "%q, " . }}{{end}}]
+# App versions of the peers.
+allowed_app_versions = [{{ range .P2P.AllowedAppVersions }}{{ . }}, {{end}}]
+denied_app_versions = [{{ range .P2P.DeniedAppVersions }}{{ . }}, {{end}}]
+# Block versions of the peers. If allowed_block_versions is not empty, the
+# peers may be on any of these block versions, instead of ours.
+allowed_block_versions = [{{ range .P2P.AllowedBlockVersions }}{{ . }}, {{end}}]
+denied_block_versions = [{{ range .P2P.DeniedBlockVersions }}{{ . }}, {{end}}]
+
+# Maximum skew of the local clock relative to the clocks of the peers,
+# estimated from the times they report in the handshake, above which an error
+# is logged. On start, the time of the last block must not be more than
+# max_clock_skew ahead of the local clock either. "0s" disables the checks.
+max_clock_skew = "{{ .P2P.MaxClockSkew }}"
+# If true, the node refuses to start if the time of the last block is more than
+# max_clock_skew ahead of the local clock.
+refuse_clock_skew = {{ .P2P.RefuseClockSkew }}
+
+# Names of the reactors, e.g. "PEX", a panic of which, while receiving a
+# message, only stops the reactor, whose state may be corrupted. The peer the
+# message came from stays connected, and the messages on the channels of a
+# stopped reactor are dropped.
+isolated_reactors = [{{ range .P2P.IsolatedReactors }}{{ printf "%q, " . }}{{end}}]
+# Time after which an isolated reactor stopped by a panic is restarted. "0s"
+# disables the restart.
+reactor_restart_delay = "{{ .P2P.ReactorRestartDelay }}"
+
 # Peer connection configuration.
 handshake_timeout = "{{ .P2P.HandshakeTimeout }}"
 dial_timeout = "{{ .P2P.DialTimeout }}"
 
+# Nodes to keep persistent connections to, with their own dial policy, in
+# addition to persistent_peers. Each of them is a [[p2p.persistent_peer]]
+# table, which must come after the other [p2p] options:
+#
+# [[p2p.persistent_peer]]
+# # Address of the peer.
+# address = "ID@host:port"
+# # Interval between the attempts to reconnect to the peer, before the
+# # exponential backoff. If zero, the default interval is used.
+# dial_interval = "5s"
+# # Connect to the peer ignoring any existing limits.
+# unconditional = true
+# # Do not gossip the address of the peer.
+# private = false
+# # IDs of the channels whose messages are sent to the peer in priority.
+# preferred_channels = [0x20, 0x21, 0x22, 0x23]
+# # Number of attempts to reconnect to the peer, every dial_interval, before
+# # giving up. If zero, the default schedule is used, with exponential backoff.
+# max_reconnect_attempts = 0
+{{ range .P2P.PersistentPeerConfigs }}
+[[p2p.persistent_peer]]
+address = "{{ .Address }}"
+dial_interval = "{{ .DialInterval }}"
+unconditional = {{ .Unconditional }}
+private = {{ .Private }}
+preferred_channels = [{{ range .PreferredChannels }}{{ printf "%d, " . }}{{end}}]
+max_reconnect_attempts = {{ .MaxReconnectAttempts }}
+{{ end }}
 #######################################################
 ###          Mempool Configuration Options          ###
 #######################################################
@@ -408,6 +690,8 @@ broadcast = {{ .Mempool.Broadcast }}
 # (WAL) for the mempool. The WAL is disabled by default. To enable, set
 # wal_dir to where you want the WAL to be written (e.g.
 # "data/mempool.wal").
+# The transactions in the WAL are checked again when the node restarts,
+# so that the valid ones are not lost.
 wal_dir = "{{ js .Mempool.WalPath }}"
 
 # Maximum number of transactions in the mempool
@@ -450,6 +734,30 @@ max_batch_bytes = {{ .Mempool.MaxBatchBytes }}
 experimental_max_gossip_connections_to_persistent_peers = {{ .Mempool.ExperimentalMaxGossipConnectionsToPersistentPeers }}
 experimental_max_gossip_connections_to_non_persistent_peers = {{ .Mempool.ExperimentalMaxGossipConnectionsToNonPersistentPeers }}
 
+# initial_sync (default: false) makes the node request the transactions in the
+# mempool of the peers it connects to while its own mempool is empty, e.g. after
+# a restart, instead of waiting for them to be gossiped.
+initial_sync = {{ .Mempool.InitialSync }}
+
+# Maximum number of transactions requested from, and sent to, a peer during the
+# initial sync.
+initial_sync_max_txs = {{ .Mempool.InitialSyncMaxTxs }}
+
+# Maximum total weight of the transactions reaped for a proposal, as reported by
+# the application in the weight field of the CheckTx responses. This applies on
+# top of the max_bytes and max_gas of the block. 0 means no limit.
+max_block_weight = {{ .Mempool.MaxBlockWeight }}
+
+# sender_queue (default: false) makes the mempool hold the valid transactions
+# whose sequence, reported by the application in the CheckTx response, is ahead
+# of the next sequence of their sender, and check them again once the
+# transactions before them are added, instead of leaving the application to
+# reject them. See the mempool documentation.
+sender_queue = {{ .Mempool.SenderQueue }}
+
+# Maximum number of transactions held per sender by the sender queue.
+sender_queue_max_txs = {{ .Mempool.SenderQueueMaxTxs }}
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -472,6 +780,11 @@ trust_height = {{ .StateSync.TrustHeight }}
 trust_hash = "{{ .StateSync.TrustHash }}"
 trust_period = "{{ .StateSync.TrustPeriod }}"
 
+# Fetch snapshots and chunks from the rpc_servers above instead of p2p peers, e.g. when the node is
+# behind a restrictive firewall or p2p peers don't serve snapshots. Chunks are downloaded in parts,
+# and interrupted downloads are resumed from the last part received.
+fetch_from_rpc_servers = {{ .StateSync.FetchFromRPCServers }}
+
 # Time to spend discovering snapshots before initiating a restore.
 discovery_time = "{{ .StateSync.DiscoveryTime }}"
 
@@ -479,11 +792,14 @@ discovery_time = "{{ .StateSync.DiscoveryTime }}"
 # Will create a new, randomly named directory within, and remove it when done.
 temp_dir = "{{ .StateSync.TempDir }}"
 
-# The timeout duration before re-requesting a chunk, possibly from a different
-# peer (default: 1 minute).
+# The maximum timeout duration before re-requesting a chunk from a different
+# peer (default: 1 minute). Each request times out after a multiple of the time
+# the peer took to send chunks so far, within this limit.
 chunk_request_timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 
-# The number of concurrent chunk fetchers to run (default: 1).
+# The number of concurrent chunk fetchers to run (default: 1). Chunks are
+# requested in parallel from the best scored peers, preferring the ones with
+# the fewest requests in flight.
 chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 
 #######################################################
@@ -499,6 +815,35 @@ chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 #   1) "v0" - the default block sync implementation
 version = "{{ .BlockSync.Version }}"
 
+# Number of goroutines verifying the commits of the fetched blocks ahead of
+# the loop applying them. If 0, blocks are verified by the apply loop itself.
+verify_workers = {{ .BlockSync.VerifyWorkers }}
+
+# Maximum number of blocks requested ahead of the last applied block.
+max_pending_blocks = {{ .BlockSync.MaxPendingBlocks }}
+
+# Maximum number of pending blocks kept in memory. If smaller than
+# max_pending_blocks, the blocks furthest from the last applied block are
+# spilled to a database in queue_dir until they are needed.
+max_memory_blocks = {{ .BlockSync.MaxMemoryBlocks }}
+
+# Location of the database holding the pending blocks spilled to disk.
+# Its content is discarded when the node starts.
+queue_dir = "{{ js .BlockSync.QueuePath }}"
+
+# If not 0, enables the light mode: the commits of the blocks are only
+# verified at the heights multiple of checkpoint_interval, and the blocks in
+# between are authenticated by the chain of hashes leading to the next
+# checkpoint. Their commits are still verified when applying the next block.
+# Must be smaller than max_memory_blocks.
+checkpoint_interval = {{ .BlockSync.CheckpointInterval }}
+
+# Comma-separated list of RPC servers whose headers the synced blocks are
+# cross-checked against before being applied, e.g. to detect a majority of
+# peers feeding a fork. A block is only applied once all the witnesses
+# provided its header. At least two are required, if any.
+witnesses = "{{ StringsJoin .BlockSync.Witnesses "," }}"
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -532,6 +877,13 @@ double_sign_check_height = {{ .Consensus.DoubleSignCheckHeight }}
 # Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 skip_timeout_commit = {{ .Consensus.SkipTimeoutCommit }}
 
+# If non-zero, the interval between the starts of consecutive heights. After
+# committing a block, we wait until the target interval since the start of its
+# height has elapsed, instead of timeout_commit, so that blocks are produced at
+# a steady cadence. A height taking longer than the target starts the next one
+# right away. timeout_commit and skip_timeout_commit are ignored when set.
+target_block_interval = "{{ .Consensus.TargetBlockInterval }}"
+
 # EmptyBlocks mode and possible interval between empty blocks
 create_empty_blocks = {{ .Consensus.CreateEmptyBlocks }}
 create_empty_blocks_interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
@@ -557,6 +909,18 @@ discard_abci_responses = {{ .Storage.DiscardABCIResponses}}
 # The time period between automated background pruning operations.
 interval = "{{ .Storage.Pruning.Interval }}"
 
+# Which blocks are pruned below the retain height, the lowest of the retain
+# heights of the application, the data companion, the operator, the snapshots
+# and the indexer:
+#   1) "default" - prunes all of them.
+#   2) "sparse"  - keeps every sparse_interval-th block, along with its state,
+#                  its results and its indexed events, to serve a sample of the
+#                  history. The blocks pruned before are not restored.
+policy = "{{ .Storage.Pruning.Policy }}"
+
+# Interval between two blocks kept by the "sparse" policy.
+sparse_interval = {{ .Storage.Pruning.SparseInterval }}
+
 #
 # Storage pruning configuration relating only to the data companion.
 #
@@ -603,13 +967,71 @@ genesis_hash = "{{ .Storage.GenesisHash }}"
 #   2) "kv" (default) - the simplest possible indexer, backed by key-value storage (defaults to levelDB; see DBBackend).
 # 		- When "kv" is chosen "tx.height" and "tx.hash" will always be indexed.
 #   3) "psql" - the indexer services backed by PostgreSQL.
+#   4) "kafka" - publishes the events to Kafka, see kafka-brokers.
+#   5) "nats" - publishes the events to NATS, see nats-url.
 # When "kv" or "psql" is chosen "tx.height" and "tx.hash" will always be indexed.
+# The "kafka" and "nats" indexers cannot serve queries.
+#
+# Several indexers can be listed, separated by commas (e.g. "kv,psql"), in
+# which case events are written to all of them and the first one serves queries.
 indexer = "{{ .TxIndex.Indexer }}"
 
 # The PostgreSQL connection configuration, the connection format:
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# The Kafka brokers, as host:port, to which the "kafka" indexer publishes the
+# events, e.g. ["kafka1:9092", "kafka2:9092"].
+kafka-brokers = [{{ range .TxIndex.KafkaBrokers }}{{ printf "%q, " . }}{{end}}]
+
+# The SASL mechanism the "kafka" indexer authenticates with, if stream-username
+# is set: "PLAIN" (default), "SCRAM-SHA-256" or "SCRAM-SHA-512".
+kafka-sasl-mechanism = "{{ .TxIndex.KafkaSASLMechanism }}"
+
+# The URL of the NATS server to which the "nats" indexer publishes the events:
+#   nats://[<user>:<password>@|<token>@]<host>[:<port>]
+nats-url = "{{ .TxIndex.NATSURL }}"
+
+# The NATS credentials file, holding the JWT and the NKey seed the "nats"
+# indexer authenticates with.
+nats-credentials-file = "{{ .TxIndex.NATSCredentialsFile }}"
+
+# The user name and password the "kafka" and "nats" indexers authenticate
+# with, if set.
+stream-username = "{{ .TxIndex.StreamUsername }}"
+stream-password = "{{ .TxIndex.StreamPassword }}"
+
+# Whether the "kafka" and "nats" indexers connect to the broker over TLS. The
+# server certificate is verified against stream-tls-ca-file, or the system
+# roots if it is empty. stream-tls-cert-file and stream-tls-key-file are the
+# client certificate and key, if the broker requires one.
+stream-tls = {{ .TxIndex.StreamTLS }}
+stream-tls-ca-file = "{{ .TxIndex.StreamTLSCAFile }}"
+stream-tls-cert-file = "{{ .TxIndex.StreamTLSCertFile }}"
+stream-tls-key-file = "{{ .TxIndex.StreamTLSKeyFile }}"
+
+# The prefix of the Kafka topics, or NATS subjects, to which the "kafka" and
+# "nats" indexers publish the events of the blocks ("<prefix>.blocks") and of
+# the transactions ("<prefix>.txs"), encoded in JSON.
+stream-topic-prefix = "{{ .TxIndex.StreamTopicPrefix }}"
+
+# The time the "kafka" and "nats" indexers wait for the broker to acknowledge
+# the events of a block, or of its transactions.
+stream-timeout = "{{ .TxIndex.StreamTimeout }}"
+
+# Event types, or composite keys ("type.attribute"), to index, e.g.
+# ["transfer", "message.sender"]. If empty, all events marked for indexing by
+# the application are indexed. Only applies to the "kv" indexer.
+index_events = [{{ range .TxIndex.IndexEvents }}{{ printf "%q, " . }}{{end}}]
+
+# Event types, or composite keys ("type.attribute"), never to index. Takes
+# precedence over index_events. Only applies to the "kv" indexer.
+exclude_events = [{{ range .TxIndex.ExcludeEvents }}{{ printf "%q, " . }}{{end}}]
+
+# Indexers, among those listed in indexer, whose failures are logged and
+# otherwise ignored, e.g. ["psql"] while migrating to PostgreSQL.
+ignore_failures = [{{ range .TxIndex.IgnoreFailures }}{{ printf "%q, " . }}{{end}}]
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -631,4 +1053,11 @@ max_open_connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
+
+# Labels all the metrics carry, among "chain_id", "moniker" and "node_id".
+labels = [{{ range .Instrumentation.Labels }}{{ printf "%q, " . }}{{end}}]
+
+# If true, the metrics are also served under /metrics on the RPC server, for
+# the environments where prometheus_listen_addr can't be exposed.
+serve_on_rpc = {{ .Instrumentation.ServeOnRPC }}
 `
@@ -0,0 +1,67 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"hash"
+)
+
+// Hasher computes the Merkle root of the leaves added to it one at a time, as
+// HashFromByteSlices does for all of them, without holding them. It only keeps
+// the roots of the perfect subtrees of the leaves added so far, at most one
+// per power of 2, and reuses a single SHA-256 state, so that adding a leaf
+// doesn't allocate.
+type Hasher struct {
+	sha  hash.Hash
+	size int
+	// the roots of the perfect subtrees, by decreasing size: their sizes are
+	// the powers of 2 set in size.
+	roots [][sha256.Size]byte
+	node  [sha256.Size]byte // the node being hashed, not to allocate it
+}
+
+// NewHasher returns a Hasher with no leaves.
+func NewHasher() *Hasher {
+	return &Hasher{sha: sha256.New()}
+}
+
+// AddLeaf adds a leaf to the tree.
+func (h *Hasher) AddLeaf(leaf []byte) {
+	h.sha.Reset()
+	h.sha.Write(leafPrefix)
+	h.sha.Write(leaf)
+	h.sha.Sum(h.node[:0])
+	// Each trailing 1 bit of size is a subtree as large as the one ending
+	// with the new leaf, merged with it.
+	for n := h.size; n&1 == 1; n >>= 1 {
+		last := len(h.roots) - 1
+		h.sha.Reset()
+		h.sha.Write(innerPrefix)
+		h.sha.Write(h.roots[last][:])
+		h.sha.Write(h.node[:])
+		h.sha.Sum(h.node[:0])
+		h.roots = h.roots[:last]
+	}
+	h.roots = append(h.roots, h.node)
+	h.size++
+}
+
+// Size returns the number of leaves added.
+func (h *Hasher) Size() int {
+	return h.size
+}
+
+// Root returns the Merkle root of the leaves added so far. More leaves can be
+// added afterwards.
+func (h *Hasher) Root() []byte {
+	if len(h.roots) == 0 {
+		return emptyHash()
+	}
+	// The split point of RFC-6962 is the largest power of 2 smaller than the
+	// number of leaves, so the root is the one of the largest subtree and of
+	// the tree of the smaller ones, from right to left.
+	root := h.roots[len(h.roots)-1][:]
+	for i := len(h.roots) - 2; i >= 0; i-- {
+		root = innerHashOpt(h.sha, h.roots[i][:], root)
+	}
+	return append([]byte(nil), root...)
+}
@@ -0,0 +1,50 @@
+package merkle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+)
+
+func TestHasher(t *testing.T) {
+	items := make([][]byte, 70)
+	for i := range items {
+		items[i] = cmtrand.Bytes(i % 40)
+	}
+
+	h := NewHasher()
+	require.Equal(t, HashFromByteSlices(nil), h.Root())
+	for i, item := range items {
+		h.AddLeaf(item)
+		require.Equal(t, i+1, h.Size())
+		require.Equal(t, HashFromByteSlices(items[:i+1]), h.Root(), "%d leaves", i+1)
+	}
+}
+
+func BenchmarkHasher(b *testing.B) {
+	for _, total := range []int{100, 10000} {
+		items := make([][]byte, total)
+		for i := range items {
+			items[i] = cmtrand.Bytes(32)
+		}
+		b.Run(fmt.Sprintf("slices/%d", total), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = HashFromByteSlices(items)
+			}
+		})
+		b.Run(fmt.Sprintf("hasher/%d", total), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h := NewHasher()
+				for _, item := range items {
+					h.AddLeaf(item)
+				}
+				_ = h.Root()
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Package kafka implements the "kafka" indexer, publishing the events of the
+// blocks and of the transactions to a Kafka cluster with the franz-go client.
+//
+// It is a separate module, so that the client is only built into the binaries
+// using it. Importing the package registers the indexer:
+//
+//	import _ "github.com/cometbft/cometbft/indexer/kafka"
+//
+// The indexer is configured by the kafka-brokers, kafka-sasl-mechanism and
+// stream-* options of the [tx_index] section of the config.
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/state/indexer/sink/stream"
+)
+
+const clientID = "cometbft"
+
+func init() {
+	stream.RegisterProducer("kafka", func(cfg *config.TxIndexConfig) (stream.Producer, error) {
+		return NewProducer(cfg)
+	})
+}
+
+// Producer publishes messages to a Kafka cluster. It sends the messages of a
+// topic with the same key to the same partition, picked like the default
+// partitioner of the Java client, and waits for all the in-sync replicas to
+// acknowledge them.
+type Producer struct {
+	client  *kgo.Client
+	timeout time.Duration
+}
+
+var _ stream.Producer = (*Producer)(nil)
+
+// NewProducer returns a producer publishing to the Kafka cluster reachable at
+// cfg.KafkaBrokers, over TLS if cfg.StreamTLS is set, and authenticating with
+// SASL if cfg.StreamUsername is set. Each publication must complete within
+// cfg.StreamTimeout.
+func NewProducer(cfg *config.TxIndexConfig) (*Producer, error) {
+	if len(cfg.KafkaBrokers) == 0 {
+		return nil, errors.New("no Kafka brokers")
+	}
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.KafkaBrokers...),
+		kgo.ClientID(clientID),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.AllowAutoTopicCreation(),
+		// a nil hasher hashes the keys with murmur2, as the Java client does
+		kgo.RecordPartitioner(kgo.StickyKeyPartitioner(nil)),
+	}
+	tlsCfg, err := stream.TLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		opts = append(opts, kgo.DialTLSConfig(tlsCfg))
+	}
+	if cfg.StreamUsername != "" {
+		mechanism, err := saslMechanism(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating the Kafka client: %w", err)
+	}
+	return &Producer{client: client, timeout: cfg.StreamTimeout}, nil
+}
+
+// saslMechanism returns the SASL mechanism configured by
+// cfg.KafkaSASLMechanism.
+func saslMechanism(cfg *config.TxIndexConfig) (sasl.Mechanism, error) {
+	switch cfg.KafkaSASLMechanism {
+	case "", "PLAIN":
+		return plain.Auth{User: cfg.StreamUsername, Pass: cfg.StreamPassword}.AsMechanism(), nil
+	case "SCRAM-SHA-256":
+		return scram.Auth{User: cfg.StreamUsername, Pass: cfg.StreamPassword}.AsSha256Mechanism(), nil
+	case "SCRAM-SHA-512":
+		return scram.Auth{User: cfg.StreamUsername, Pass: cfg.StreamPassword}.AsSha512Mechanism(), nil
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism %q", cfg.KafkaSASLMechanism)
+	}
+}
+
+// Publish implements stream.Producer.
+func (p *Producer) Publish(msgs []stream.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	records := make([]*kgo.Record, len(msgs))
+	for i, m := range msgs {
+		records[i] = &kgo.Record{Topic: m.Topic, Key: m.Key, Value: m.Value}
+	}
+	return p.client.ProduceSync(ctx, records...).FirstErr()
+}
+
+// Close implements stream.Producer.
+func (p *Producer) Close() error {
+	p.client.Close()
+	return nil
+}
@@ -0,0 +1,43 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+func TestSASLMechanism(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	cfg.StreamUsername = "user"
+	cfg.StreamPassword = "password"
+
+	for mechanism, name := range map[string]string{
+		"":              "PLAIN",
+		"PLAIN":         "PLAIN",
+		"SCRAM-SHA-256": "SCRAM-SHA-256",
+		"SCRAM-SHA-512": "SCRAM-SHA-512",
+	} {
+		cfg.KafkaSASLMechanism = mechanism
+		m, err := saslMechanism(cfg)
+		require.NoError(t, err)
+		require.Equal(t, name, m.Name())
+	}
+
+	cfg.KafkaSASLMechanism = "GSSAPI"
+	_, err := saslMechanism(cfg)
+	require.Error(t, err)
+}
+
+func TestNewProducer(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	_, err := NewProducer(cfg)
+	require.Error(t, err)
+
+	// the client connects on the first publication
+	cfg.KafkaBrokers = []string{"127.0.0.1:9092"}
+	p, err := NewProducer(cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.Close())
+}
@@ -0,0 +1,108 @@
+// Package nats implements the "nats" indexer, publishing the events of the
+// blocks and of the transactions to a NATS server with the nats.go client, the
+// topics being the subjects.
+//
+// It is a separate module, so that the client is only built into the binaries
+// using it. Importing the package registers the indexer:
+//
+//	import _ "github.com/cometbft/cometbft/indexer/nats"
+//
+// The indexer is configured by the nats-url, nats-credentials-file and
+// stream-* options of the [tx_index] section of the config.
+package nats
+
+import (
+	"errors"
+	"fmt"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/state/indexer/sink/stream"
+)
+
+const clientName = "cometbft"
+
+func init() {
+	stream.RegisterProducer("nats", func(cfg *config.TxIndexConfig) (stream.Producer, error) {
+		return NewProducer(cfg)
+	})
+}
+
+// Producer publishes messages to a NATS server. When the server supports
+// headers, the topic and the key of each message are sent as its Nats-Msg-Id
+// header, which lets JetStream discard the messages published twice.
+type Producer struct {
+	conn *natsgo.Conn
+	cfg  *config.TxIndexConfig
+}
+
+var _ stream.Producer = (*Producer)(nil)
+
+// NewProducer returns a producer publishing to the NATS server at
+// cfg.NATSURL, over TLS if cfg.StreamTLS is set, and authenticating with
+// cfg.NATSCredentialsFile or cfg.StreamUsername, if set. Each publication must
+// complete within cfg.StreamTimeout. The server need not be reachable yet:
+// the producer connects, and reconnects, in the background.
+func NewProducer(cfg *config.TxIndexConfig) (*Producer, error) {
+	if cfg.NATSURL == "" {
+		return nil, errors.New("no NATS URL")
+	}
+	opts, err := options(cfg)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := natsgo.Connect(cfg.NATSURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to NATS: %w", err)
+	}
+	return &Producer{conn: conn, cfg: cfg}, nil
+}
+
+func options(cfg *config.TxIndexConfig) ([]natsgo.Option, error) {
+	opts := []natsgo.Option{
+		natsgo.Name(clientName),
+		natsgo.Timeout(cfg.StreamTimeout),
+		natsgo.RetryOnFailedConnect(true),
+		natsgo.MaxReconnects(-1),
+	}
+	tlsCfg, err := stream.TLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		opts = append(opts, natsgo.Secure(tlsCfg))
+	}
+	if cfg.NATSCredentialsFile != "" {
+		opts = append(opts, natsgo.UserCredentials(cfg.NATSCredentialsFile))
+	}
+	if cfg.StreamUsername != "" {
+		opts = append(opts, natsgo.UserInfo(cfg.StreamUsername, cfg.StreamPassword))
+	}
+	return opts, nil
+}
+
+// Publish implements stream.Producer. It returns once the server replied to a
+// PING sent after the messages, that is once it processed them.
+func (p *Producer) Publish(msgs []stream.Message) error {
+	if !p.conn.IsConnected() {
+		return natsgo.ErrNoServers
+	}
+	headers := p.conn.HeadersSupported()
+	for _, m := range msgs {
+		msg := &natsgo.Msg{Subject: m.Topic, Data: m.Value}
+		if headers {
+			msg.Header = natsgo.Header{}
+			msg.Header.Set(natsgo.MsgIdHdr, m.Topic+":"+string(m.Key))
+		}
+		if err := p.conn.PublishMsg(msg); err != nil {
+			return fmt.Errorf("publishing to %s: %w", m.Topic, err)
+		}
+	}
+	return p.conn.FlushTimeout(p.cfg.StreamTimeout)
+}
+
+// Close implements stream.Producer, flushing the pending messages.
+func (p *Producer) Close() error {
+	return p.conn.Drain()
+}
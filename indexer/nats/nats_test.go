@@ -0,0 +1,40 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+func TestOptions(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	opts, err := options(cfg)
+	require.NoError(t, err)
+	plain := len(opts)
+
+	cfg.StreamTLS = true
+	cfg.NATSCredentialsFile = "user.creds"
+	cfg.StreamUsername = "user"
+	opts, err = options(cfg)
+	require.NoError(t, err)
+	require.Len(t, opts, plain+3)
+
+	cfg.StreamTLSCAFile = "missing-ca.pem"
+	_, err = options(cfg)
+	require.Error(t, err)
+}
+
+func TestNewProducer(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	_, err := NewProducer(cfg)
+	require.Error(t, err)
+
+	// the producer connects in the background
+	cfg.NATSURL = "nats://127.0.0.1:1"
+	p, err := NewProducer(cfg)
+	require.NoError(t, err)
+	require.Error(t, p.Publish(nil))
+	p.conn.Close()
+}
@@ -49,3 +49,17 @@ func (e ErrReactorValidation) Error() string {
 func (e ErrReactorValidation) Unwrap() error {
 	return e.Err
 }
+
+// ErrWitnessMismatch is returned when a witness provides a header whose hash
+// differs from the synced block's.
+type ErrWitnessMismatch struct {
+	Height      int64
+	Witness     string
+	Hash        []byte
+	WitnessHash []byte
+}
+
+func (e ErrWitnessMismatch) Error() string {
+	return fmt.Sprintf("witness %s has header %X at height %d, but the synced block is %X",
+		e.Witness, e.WitnessHash, e.Height, e.Hash)
+}
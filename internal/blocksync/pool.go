@@ -30,7 +30,6 @@ eg, L = latency = 0.1s
 const (
 	requestIntervalMS         = 2
 	maxTotalRequesters        = 600
-	maxPendingRequests        = maxTotalRequesters
 	maxPendingRequestsPerPeer = 20
 	requestRetrySeconds       = 30
 
@@ -75,6 +74,11 @@ type BlockPool struct {
 	// atomic
 	numPending int32 // number of requests pending assignment or block response
 
+	// pending blocks
+	maxRequesters   int         // the maximum number of requesters.
+	maxMemoryBlocks int64       // the number of blocks kept in memory past height.
+	queue           *blockQueue // where the other blocks are spilled, if not nil.
+
 	requestsCh chan<- BlockRequest
 	errorsCh   chan<- peerError
 }
@@ -89,6 +93,9 @@ func NewBlockPool(start int64, requestsCh chan<- BlockRequest, errorsCh chan<- p
 		height:     start,
 		numPending: 0,
 
+		maxRequesters:   maxTotalRequesters,
+		maxMemoryBlocks: maxTotalRequesters,
+
 		requestsCh: requestsCh,
 		errorsCh:   errorsCh,
 	}
@@ -96,6 +103,25 @@ func NewBlockPool(start int64, requestsCh chan<- BlockRequest, errorsCh chan<- p
 	return bp
 }
 
+// SetPendingLimits sets the maximum number of blocks requested ahead of the
+// pool's height and the number of them kept in memory. The blocks beyond
+// maxMemory are spilled to the queue until the pool's height gets close
+// enough. The queue may be nil if maxMemory is not smaller than maxPending.
+// It must be called before the pool is started.
+func (pool *BlockPool) SetPendingLimits(maxPending, maxMemory int, queue *blockQueue) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	// The two blocks at the pool's height are needed to verify the first one.
+	if maxMemory < 2 {
+		maxMemory = 2
+	}
+
+	pool.maxRequesters = maxPending
+	pool.maxMemoryBlocks = int64(maxMemory)
+	pool.queue = queue
+}
+
 // OnStart implements service.Service by spawning requesters routine and recording
 // pool's start time.
 func (pool *BlockPool) OnStart() error {
@@ -113,12 +139,12 @@ func (pool *BlockPool) makeRequestersRoutine() {
 
 		_, numPending, lenRequesters := pool.GetStatus()
 		switch {
-		case numPending >= maxPendingRequests:
+		case int(numPending) >= pool.maxRequesters:
 			// sleep for a bit.
 			time.Sleep(requestIntervalMS * time.Millisecond)
 			// check for timed out peers
 			pool.removeTimedoutPeers()
-		case lenRequesters >= maxTotalRequesters:
+		case lenRequesters >= pool.maxRequesters:
 			// sleep for a bit.
 			time.Sleep(requestIntervalMS * time.Millisecond)
 			// check for timed out peers
@@ -207,6 +233,51 @@ func (pool *BlockPool) PeekTwoBlocks() (first, second *types.Block, firstExtComm
 	return
 }
 
+// PeekBlocksAt returns the blocks at height and height+1, if both were
+// received and are kept in memory. It is used to verify blocks ahead of
+// pool.height.
+func (pool *BlockPool) PeekBlocksAt(height int64) (first, second *types.Block) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if height < pool.height || height+1 >= pool.height+pool.maxMemoryBlocks {
+		return nil, nil
+	}
+	r1, r2 := pool.requesters[height], pool.requesters[height+1]
+	if r1 == nil || r2 == nil {
+		return nil, nil
+	}
+	first, second = r1.getBlock(), r2.getBlock()
+	if first == nil || second == nil {
+		return nil, nil
+	}
+	return first, second
+}
+
+// PeekRange returns the blocks from height from to height to, inclusive, if
+// all of them were received and are kept in memory. It returns nil otherwise.
+func (pool *BlockPool) PeekRange(from, to int64) []*types.Block {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if from < pool.height || to >= pool.height+pool.maxMemoryBlocks {
+		return nil
+	}
+	blocks := make([]*types.Block, 0, to-from+1)
+	for h := from; h <= to; h++ {
+		r := pool.requesters[h]
+		if r == nil {
+			return nil
+		}
+		block := r.getBlock()
+		if block == nil {
+			return nil
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
 // PopRequest pops the first block at pool.height.
 // It must have been validated by the second Commit from PeekTwoBlocks.
 // TODO(thane): (?) and its corresponding ExtendedCommit.
@@ -223,8 +294,16 @@ func (pool *BlockPool) PopRequest() {
 		if err := r.Stop(); err != nil {
 			pool.Logger.Error("Error stopping requester", "err", err)
 		}
+		r.discard()
 		delete(pool.requesters, pool.height)
 		pool.height++
+
+		// Bring back in memory the block that just entered the window.
+		if r := pool.requesters[pool.height+pool.maxMemoryBlocks-1]; r != nil {
+			if err := r.unspill(); err != nil {
+				pool.Logger.Error("Error loading spilled block", "height", r.height, "err", err)
+			}
+		}
 	} else {
 		panic(fmt.Sprintf("Expected requester to pop, got nothing at height %v", pool.height))
 	}
@@ -289,6 +368,11 @@ func (pool *BlockPool) AddBlock(peerID p2p.ID, block *types.Block, extCommit *ty
 		if peer != nil {
 			peer.decrPending(blockSize)
 		}
+		if pool.queue != nil && block.Height-pool.height >= pool.maxMemoryBlocks {
+			if err := requester.spill(pool.queue); err != nil {
+				pool.Logger.Error("Error spilling block, keeping it in memory", "height", block.Height, "err", err)
+			}
+		}
 	} else {
 		err := errors.New("requester is different or block already exists")
 		pool.sendError(err, peerID)
@@ -443,7 +527,7 @@ func (pool *BlockPool) debug() string {
 			str += fmt.Sprintf("H(%v):X ", h)
 		} else {
 			str += fmt.Sprintf("H(%v):", h)
-			str += fmt.Sprintf("B?(%v) ", pool.requesters[h].block != nil)
+			str += fmt.Sprintf("B?(%v) ", pool.requesters[h].hasBlock())
 			str += fmt.Sprintf("C?(%v) ", pool.requesters[h].extCommit != nil)
 		}
 	}
@@ -537,6 +621,7 @@ type bpRequester struct {
 	peerID    p2p.ID
 	block     *types.Block
 	extCommit *types.ExtendedCommit
+	queue     *blockQueue // set if the block was spilled to disk.
 }
 
 func newBPRequester(pool *BlockPool, height int64) *bpRequester {
@@ -561,7 +646,7 @@ func (bpr *bpRequester) OnStart() error {
 // Returns true if the peer matches and block doesn't already exist.
 func (bpr *bpRequester) setBlock(block *types.Block, extCommit *types.ExtendedCommit, peerID p2p.ID) bool {
 	bpr.mtx.Lock()
-	if bpr.block != nil || bpr.peerID != peerID {
+	if bpr.hasBlock() || bpr.peerID != peerID {
 		bpr.mtx.Unlock()
 		return false
 	}
@@ -576,6 +661,13 @@ func (bpr *bpRequester) setBlock(block *types.Block, extCommit *types.ExtendedCo
 	return true
 }
 
+// hasBlock returns true if the block was received, whether it is kept in
+// memory or was spilled to disk. The caller must hold bpr.mtx.
+func (bpr *bpRequester) hasBlock() bool {
+	return bpr.block != nil || bpr.queue != nil
+}
+
+// getBlock returns the received block, or nil if it is not kept in memory.
 func (bpr *bpRequester) getBlock() *types.Block {
 	bpr.mtx.Lock()
 	defer bpr.mtx.Unlock()
@@ -588,6 +680,56 @@ func (bpr *bpRequester) getExtendedCommit() *types.ExtendedCommit {
 	return bpr.extCommit
 }
 
+// spill moves the received block to the queue.
+func (bpr *bpRequester) spill(queue *blockQueue) error {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+
+	if bpr.block == nil {
+		return nil
+	}
+	if err := queue.put(bpr.block, bpr.extCommit); err != nil {
+		return err
+	}
+	bpr.block, bpr.extCommit, bpr.queue = nil, nil, queue
+	return nil
+}
+
+// unspill loads back in memory the block spilled to the queue, if any.
+func (bpr *bpRequester) unspill() error {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+
+	if bpr.queue == nil {
+		return nil
+	}
+	block, extCommit, err := bpr.queue.get(bpr.height)
+	if err != nil {
+		return err
+	}
+	bpr.discardSpilled()
+	bpr.block, bpr.extCommit = block, extCommit
+	return nil
+}
+
+// discard drops the block spilled to the queue, if any.
+func (bpr *bpRequester) discard() {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+	bpr.discardSpilled()
+}
+
+// The caller must hold bpr.mtx.
+func (bpr *bpRequester) discardSpilled() {
+	if bpr.queue == nil {
+		return
+	}
+	if err := bpr.queue.delete(bpr.height); err != nil {
+		bpr.Logger.Error("Error deleting spilled block", "height", bpr.height, "err", err)
+	}
+	bpr.queue = nil
+}
+
 func (bpr *bpRequester) getPeerID() p2p.ID {
 	bpr.mtx.Lock()
 	defer bpr.mtx.Unlock()
@@ -599,9 +741,10 @@ func (bpr *bpRequester) reset() {
 	bpr.mtx.Lock()
 	defer bpr.mtx.Unlock()
 
-	if bpr.block != nil {
+	if bpr.hasBlock() {
 		atomic.AddInt32(&bpr.pool.numPending, 1)
 	}
+	bpr.discardSpilled()
 
 	bpr.peerID = ""
 	bpr.block = nil
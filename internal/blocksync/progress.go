@@ -0,0 +1,72 @@
+package blocksync
+
+import (
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+)
+
+// Progress is the progress of a block sync.
+type Progress struct {
+	StartHeight     int64 // of the first block synced
+	Height          int64 // of the last block applied
+	MaxPeerHeight   int64
+	BlocksPerSecond float64   // average since the start
+	StartTime       time.Time // of the block sync
+	ETA             time.Time // estimated time the node catches up, zero if unknown
+}
+
+// progressTracker tracks the progress of a block sync.
+type progressTracker struct {
+	mtx      cmtsync.Mutex
+	progress *Progress // nil until the block sync starts
+}
+
+// Progress returns the current progress, or false if the block sync did not
+// start.
+func (pt *progressTracker) Progress() (Progress, bool) {
+	pt.mtx.Lock()
+	defer pt.mtx.Unlock()
+	if pt.progress == nil {
+		return Progress{}, false
+	}
+	return *pt.progress, true
+}
+
+// Start starts tracking a block sync from the block after height.
+func (pt *progressTracker) Start(height int64) {
+	pt.mtx.Lock()
+	defer pt.mtx.Unlock()
+	pt.progress = &Progress{
+		StartHeight: height + 1,
+		Height:      height,
+		StartTime:   time.Now(),
+	}
+}
+
+// Update records that the block at height was applied, and estimates when the
+// node reaches maxPeerHeight at the average rate since the start.
+func (pt *progressTracker) Update(height, maxPeerHeight int64) {
+	pt.mtx.Lock()
+	defer pt.mtx.Unlock()
+	p := pt.progress
+	if p == nil {
+		return
+	}
+	p.Height = height
+	p.MaxPeerHeight = maxPeerHeight
+	p.ETA = time.Time{}
+
+	now := time.Now()
+	synced := height - p.StartHeight + 1
+	elapsed := now.Sub(p.StartTime)
+	if synced <= 0 || elapsed <= 0 {
+		return
+	}
+	p.BlocksPerSecond = float64(synced) / elapsed.Seconds()
+	if remaining := maxPeerHeight - height; remaining > 0 {
+		p.ETA = now.Add(time.Duration(float64(remaining) / p.BlocksPerSecond * float64(time.Second)))
+	} else {
+		p.ETA = now
+	}
+}
@@ -0,0 +1,35 @@
+package blocksync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressTracker(t *testing.T) {
+	var pt progressTracker
+	_, ok := pt.Progress()
+	require.False(t, ok)
+
+	pt.Start(100)
+	p, ok := pt.Progress()
+	require.True(t, ok)
+	assert.EqualValues(t, 101, p.StartHeight)
+	assert.EqualValues(t, 100, p.Height)
+	assert.True(t, p.ETA.IsZero())
+
+	// 10 blocks in 2 seconds, 20 to go.
+	pt.progress.StartTime = time.Now().Add(-2 * time.Second)
+	pt.Update(110, 130)
+	p, _ = pt.Progress()
+	assert.EqualValues(t, 110, p.Height)
+	assert.EqualValues(t, 130, p.MaxPeerHeight)
+	assert.InDelta(t, 5, p.BlocksPerSecond, 0.1)
+	assert.WithinDuration(t, time.Now().Add(4*time.Second), p.ETA, time.Second)
+
+	pt.Update(130, 130)
+	p, _ = pt.Progress()
+	assert.WithinDuration(t, time.Now(), p.ETA, time.Second)
+}
@@ -0,0 +1,106 @@
+package blocksync
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+	bcproto "github.com/cometbft/cometbft/api/cometbft/blocksync/v1"
+	"github.com/cometbft/cometbft/types"
+)
+
+// blockQueue holds the pending blocks that the pool spilled to disk, so that
+// the number of blocks requested ahead of the pool's height is not bounded by
+// the available memory. Its content is only meaningful to the running pool.
+type blockQueue struct {
+	db dbm.DB
+}
+
+// newBlockQueue returns a queue backed by db, discarding the blocks left in
+// it by a previous run.
+func newBlockQueue(db dbm.DB) (*blockQueue, error) {
+	q := &blockQueue{db: db}
+	if err := q.clear(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func queueKey(height int64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(height))
+	return key
+}
+
+// put stores the block along with its extended commit, if any.
+func (q *blockQueue) put(block *types.Block, extCommit *types.ExtendedCommit) error {
+	pb, err := block.ToProto()
+	if err != nil {
+		return fmt.Errorf("converting block %d to proto: %w", block.Height, err)
+	}
+	bz, err := (&bcproto.BlockResponse{Block: pb, ExtCommit: extCommit.ToProto()}).Marshal()
+	if err != nil {
+		return fmt.Errorf("marshaling block %d: %w", block.Height, err)
+	}
+	return q.db.Set(queueKey(block.Height), bz)
+}
+
+// get loads the block at height and its extended commit, if any.
+func (q *blockQueue) get(height int64) (*types.Block, *types.ExtendedCommit, error) {
+	bz, err := q.db.Get(queueKey(height))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bz) == 0 {
+		return nil, nil, fmt.Errorf("no block at height %d in queue", height)
+	}
+	msg := new(bcproto.BlockResponse)
+	if err := msg.Unmarshal(bz); err != nil {
+		return nil, nil, fmt.Errorf("unmarshaling block %d: %w", height, err)
+	}
+	block, err := types.BlockFromProto(msg.Block)
+	if err != nil {
+		return nil, nil, err
+	}
+	var extCommit *types.ExtendedCommit
+	if msg.ExtCommit != nil {
+		if extCommit, err = types.ExtendedCommitFromProto(msg.ExtCommit); err != nil {
+			return nil, nil, err
+		}
+	}
+	return block, extCommit, nil
+}
+
+// delete removes the block at height, if any.
+func (q *blockQueue) delete(height int64) error {
+	return q.db.Delete(queueKey(height))
+}
+
+func (q *blockQueue) clear() error {
+	it, err := q.db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	iterErr := it.Error()
+	if err := it.Close(); err != nil {
+		return err
+	}
+	if iterErr != nil {
+		return iterErr
+	}
+	for _, key := range keys {
+		if err := q.db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close closes the underlying database.
+func (q *blockQueue) close() error {
+	return q.db.Close()
+}
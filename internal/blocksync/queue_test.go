@@ -0,0 +1,70 @@
+package blocksync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/types"
+)
+
+func makeQueueBlock(height int64) *types.Block {
+	block := types.MakeBlock(height, []types.Tx{types.Tx("tx")}, &types.Commit{}, nil)
+	block.ProposerAddress = crypto.AddressHash([]byte("proposer"))
+	return block
+}
+
+func TestBlockQueue(t *testing.T) {
+	db := dbm.NewMemDB()
+	q, err := newBlockQueue(db)
+	require.NoError(t, err)
+
+	block := makeQueueBlock(7)
+	extCommit := &types.ExtendedCommit{}
+	require.NoError(t, q.put(block, extCommit))
+	require.NoError(t, q.put(makeQueueBlock(8), nil))
+
+	got, gotExtCommit, err := q.get(7)
+	require.NoError(t, err)
+	assert.Equal(t, block.Hash(), got.Hash())
+	assert.NotNil(t, gotExtCommit)
+
+	_, gotExtCommit, err = q.get(8)
+	require.NoError(t, err)
+	assert.Nil(t, gotExtCommit)
+
+	require.NoError(t, q.delete(7))
+	_, _, err = q.get(7)
+	require.Error(t, err)
+
+	// Blocks left by a previous run are discarded.
+	q, err = newBlockQueue(db)
+	require.NoError(t, err)
+	_, _, err = q.get(8)
+	require.Error(t, err)
+}
+
+func TestRequesterSpill(t *testing.T) {
+	q, err := newBlockQueue(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	bpr := newBPRequester(pool, 5)
+	bpr.peerID = "peer"
+	block := makeQueueBlock(5)
+	require.True(t, bpr.setBlock(block, nil, "peer"))
+
+	require.NoError(t, bpr.spill(q))
+	assert.Nil(t, bpr.getBlock())
+	// A spilled block is still received.
+	assert.False(t, bpr.setBlock(block, nil, "peer"))
+
+	require.NoError(t, bpr.unspill())
+	require.NotNil(t, bpr.getBlock())
+	assert.Equal(t, block.Hash(), bpr.getBlock().Hash())
+	_, _, err = q.get(5)
+	require.Error(t, err)
+}
@@ -1,14 +1,19 @@
 package blocksync
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"time"
 
+	dbm "github.com/cometbft/cometbft-db"
 	bcproto "github.com/cometbft/cometbft/api/cometbft/blocksync/v1"
+	"github.com/cometbft/cometbft/internal/sigverify"
 	sm "github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/internal/upgrade"
 	"github.com/cometbft/cometbft/libs/log"
+	lightprovider "github.com/cometbft/cometbft/light/provider"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/types"
 )
@@ -66,12 +71,112 @@ type Reactor struct {
 
 	switchToConsensusMs int
 
+	// verifies the fetched blocks ahead of poolRoutine, if not nil.
+	verifier *blockVerifier
+	// runs the verifications of the verifier, if not nil.
+	sigVerifyPool *sigverify.Pool
+	// holds the pending blocks spilled to disk by the pool, if not nil.
+	queue *blockQueue
+
+	// light mode: if not 0, only the commits of the checkpoints are verified.
+	checkpointInterval int64
+	// the IDs of the pending blocks authenticated by a verified checkpoint.
+	anchoredIDs map[int64]types.BlockID
+
+	// cross-checks the synced blocks against witnesses, if not nil.
+	witnesses *witnessChecker
+
+	// halts the sync for an upgrade, if armed.
+	upgrades *upgrade.Manager
+
+	progress progressTracker
+
 	metrics *Metrics
+
+	// the error of an option which failed to apply, returned by OnStart.
+	optionErr error
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithVerifyWorkers sets the number of goroutines verifying the commits of the
+// fetched blocks ahead of the loop applying them. If 0, the default, the
+// blocks are verified by the apply loop itself.
+func WithVerifyWorkers(workers int) ReactorOption {
+	return func(bcR *Reactor) {
+		if workers > 0 {
+			bcR.verifier = newBlockVerifier(bcR.pool, bcR.initialState.ChainID, workers)
+		}
+	}
+}
+
+// WithSigVerifyPool makes the workers set by WithVerifyWorkers verify the
+// commits on the given pool, shared with the other reactors, so that the
+// signature verifications of the node don't use more than its workers.
+func WithSigVerifyPool(pool *sigverify.Pool) ReactorOption {
+	return func(bcR *Reactor) {
+		bcR.sigVerifyPool = pool
+	}
+}
+
+// WithPendingBlocks sets the maximum number of blocks requested ahead of the
+// last applied block, and the number of them kept in memory. The other ones
+// are spilled to queueDB, which the reactor takes ownership of. queueDB may be
+// nil if maxMemory is not smaller than maxPending. If the queue can't be set
+// up, the reactor fails to start.
+func WithPendingBlocks(maxPending, maxMemory int, queueDB dbm.DB) ReactorOption {
+	return func(bcR *Reactor) {
+		if queueDB != nil && maxMemory < maxPending {
+			queue, err := newBlockQueue(queueDB)
+			if err != nil {
+				bcR.optionErr = fmt.Errorf("failed to initialize the blocksync queue: %w", err)
+				return
+			}
+			bcR.queue = queue
+		} else if maxMemory > maxPending {
+			maxMemory = maxPending
+		}
+		bcR.pool.SetPendingLimits(maxPending, maxMemory, bcR.queue)
+	}
+}
+
+// WithCheckpointInterval enables the light mode, in which the commits of the
+// blocks are only verified at the heights multiple of interval, and the blocks
+// in between are authenticated by the chain of hashes leading to the next
+// checkpoint. Their commits are still verified when the next block is applied.
+// interval must be smaller than the number of pending blocks kept in memory.
+func WithCheckpointInterval(interval int64) ReactorOption {
+	return func(bcR *Reactor) {
+		bcR.checkpointInterval = interval
+	}
+}
+
+// WithWitnesses makes the reactor cross-check the hash of every synced block
+// against the headers provided by the given witnesses, typically light client
+// providers of trusted RPC servers, before applying it. A block is only
+// applied once all the witnesses provided its header. If any of them
+// disagrees, the peers which sent the block and the next one are stopped, as
+// for any invalid block.
+func WithWitnesses(witnesses []lightprovider.Provider) ReactorOption {
+	return func(bcR *Reactor) {
+		if len(witnesses) > 0 {
+			bcR.witnesses = newWitnessChecker(bcR.pool, witnesses)
+		}
+	}
+}
+
+// WithUpgradeManager makes the reactor stop syncing, without switching to
+// consensus, once the block at the halt height of upgrades is applied.
+func WithUpgradeManager(upgrades *upgrade.Manager) ReactorOption {
+	return func(bcR *Reactor) {
+		bcR.upgrades = upgrades
+	}
 }
 
 // NewReactor returns new reactor instance.
 func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
-	blockSync bool, metrics *Metrics, offlineStateSyncHeight int64,
+	blockSync bool, metrics *Metrics, offlineStateSyncHeight int64, options ...ReactorOption,
 ) *Reactor {
 	storeHeight := store.Height()
 	if storeHeight == 0 {
@@ -107,29 +212,82 @@ func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockS
 		requestsCh:   requestsCh,
 		errorsCh:     errorsCh,
 		metrics:      metrics,
+		anchoredIDs:  make(map[int64]types.BlockID),
 	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("Reactor", bcR)
+	for _, option := range options {
+		option(bcR)
+	}
 	return bcR
 }
 
-// SetLogger implements service.Service by setting the logger on reactor and pool.
+// SetLogger implements service.Service by setting the logger on reactor, pool
+// and verifier.
 func (bcR *Reactor) SetLogger(l log.Logger) {
 	bcR.BaseService.Logger = l
 	bcR.pool.Logger = l
+	if bcR.verifier != nil {
+		bcR.verifier.Logger = l
+	}
+	if bcR.witnesses != nil {
+		bcR.witnesses.Logger = l
+	}
+}
+
+// Progress returns the progress of the block sync, or false if the node did
+// not block sync since it started.
+func (bcR *Reactor) Progress() (Progress, bool) {
+	return bcR.progress.Progress()
 }
 
 // OnStart implements service.Service.
 func (bcR *Reactor) OnStart() error {
+	if bcR.optionErr != nil {
+		return bcR.optionErr
+	}
 	if bcR.blockSync {
 		err := bcR.pool.Start()
 		if err != nil {
 			return err
 		}
+		if err := bcR.startVerifier(bcR.initialState); err != nil {
+			return err
+		}
 		go bcR.poolRoutine(false)
 	}
 	return nil
 }
 
+func (bcR *Reactor) startVerifier(state sm.State) error {
+	if bcR.witnesses != nil {
+		if err := bcR.witnesses.Start(); err != nil {
+			return err
+		}
+	}
+	if bcR.verifier == nil {
+		return nil
+	}
+	bcR.verifier.chainID = state.ChainID
+	bcR.verifier.checkpointInterval = bcR.checkpointInterval
+	bcR.verifier.sigVerifyPool = bcR.sigVerifyPool
+	bcR.verifier.setValidators(state.Validators, !state.ConsensusParams.Validator.DisableBatchVerification, state.LastBlockHeight)
+	return bcR.verifier.Start()
+}
+
+func (bcR *Reactor) stopVerifier() {
+	if bcR.witnesses != nil && bcR.witnesses.IsRunning() {
+		if err := bcR.witnesses.Stop(); err != nil {
+			bcR.Logger.Error("Error stopping witness checker", "err", err)
+		}
+	}
+	if bcR.verifier == nil || !bcR.verifier.IsRunning() {
+		return
+	}
+	if err := bcR.verifier.Stop(); err != nil {
+		bcR.Logger.Error("Error stopping verifier", "err", err)
+	}
+}
+
 // SwitchToBlockSync is called by the state sync reactor when switching to block sync.
 func (bcR *Reactor) SwitchToBlockSync(state sm.State) error {
 	bcR.blockSync = true
@@ -140,6 +298,9 @@ func (bcR *Reactor) SwitchToBlockSync(state sm.State) error {
 	if err != nil {
 		return err
 	}
+	if err := bcR.startVerifier(state); err != nil {
+		return err
+	}
 	go bcR.poolRoutine(true)
 	return nil
 }
@@ -151,6 +312,12 @@ func (bcR *Reactor) OnStop() {
 			bcR.Logger.Error("Error stopping pool", "err", err)
 		}
 	}
+	bcR.stopVerifier()
+	if bcR.queue != nil {
+		if err := bcR.queue.close(); err != nil {
+			bcR.Logger.Error("Error closing queue", "err", err)
+		}
+	}
 }
 
 // GetChannels implements Reactor.
@@ -306,6 +473,7 @@ func (bcR *Reactor) poolRoutine(stateSynced bool) {
 
 	lastHundred := time.Now()
 	lastRate := 0.0
+	bcR.progress.Start(state.LastBlockHeight)
 
 	didProcessCh := make(chan struct{}, 1)
 
@@ -393,6 +561,7 @@ FOR_LOOP:
 				if err := bcR.pool.Stop(); err != nil {
 					bcR.Logger.Error("Error stopping pool", "err", err)
 				}
+				bcR.stopVerifier()
 				if memR, ok := bcR.Switch.Reactor("MEMPOOL").(mempoolReactor); ok {
 					memR.EnableInOutTxs()
 				}
@@ -442,26 +611,53 @@ FOR_LOOP:
 				panic(fmt.Errorf("peeked first block without extended commit at height %d - possible node store corruption", first.Height))
 			}
 
+			// Wait for the witnesses to provide the block's header.
+			var witnessErr error
+			if bcR.witnesses != nil {
+				var checked bool
+				checked, witnessErr = bcR.witnesses.check(first.Height, first.Hash())
+				if !checked {
+					continue FOR_LOOP
+				}
+			}
+
 			// Try again quickly next loop.
 			didProcessCh <- struct{}{}
 
-			firstParts, err := first.MakePartSet(types.BlockPartSizeBytes)
-			if err != nil {
-				bcR.Logger.Error("failed to make ",
-					"height", first.Height,
-					"err", err.Error())
-				break FOR_LOOP
+			var (
+				firstParts *types.PartSet
+				firstID    types.BlockID
+				err        error
+			)
+			if verified := bcR.takeVerified(first, second); verified != nil {
+				// The commit was already verified by the verifier with the
+				// validator set of the current state.
+				firstParts, firstID, err = verified.parts, verified.blockID, verified.err
+			} else {
+				firstParts, err = first.MakePartSet(types.BlockPartSizeBytes)
+				if err != nil {
+					bcR.Logger.Error("failed to make ",
+						"height", first.Height,
+						"err", err.Error())
+					break FOR_LOOP
+				}
+				firstPartSetHeader := firstParts.Header()
+				firstID = types.BlockID{Hash: first.Hash(), PartSetHeader: firstPartSetHeader}
+				// Finally, verify the first block using the second's commit
+				// NOTE: we can probably make this more efficient, but note that calling
+				// first.Hash() doesn't verify the tx contents, so MakePartSet() is
+				// currently necessary.
+				// TODO(sergio): Should we also validate against the extended commit?
+				if !bcR.isAnchored(state, first.Height, firstID) {
+					err = state.Validators.VerifyCommitLight(
+						chainID, firstID, first.Height, second.LastCommit,
+						types.WithBatchVerification(!state.ConsensusParams.Validator.DisableBatchVerification))
+				}
 			}
-			firstPartSetHeader := firstParts.Header()
-			firstID := types.BlockID{Hash: first.Hash(), PartSetHeader: firstPartSetHeader}
-			// Finally, verify the first block using the second's commit
-			// NOTE: we can probably make this more efficient, but note that calling
-			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
-			// currently necessary.
-			// TODO(sergio): Should we also validate against the extended commit?
-			err = state.Validators.VerifyCommitLight(
-				chainID, firstID, first.Height, second.LastCommit)
 
+			if err == nil {
+				err = witnessErr
+			}
 			if err == nil {
 				// validate the block before we persist it
 				err = bcR.blockExec.ValidateBlock(state, first)
@@ -513,8 +709,25 @@ FOR_LOOP:
 				// TODO This is bad, are we zombie?
 				panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
 			}
+			if bcR.verifier != nil {
+				bcR.verifier.setValidators(state.Validators, !state.ConsensusParams.Validator.DisableBatchVerification, state.LastBlockHeight)
+			}
+			delete(bcR.anchoredIDs, first.Height)
 			bcR.metrics.recordBlockMetrics(first)
 			blocksSynced++
+			bcR.progress.Update(first.Height, bcR.pool.MaxPeerHeight())
+
+			if bcR.upgrades.ShouldHalt(first.Height) {
+				bcR.Logger.Info("halting block sync for an upgrade", "height", first.Height)
+				if err := bcR.upgrades.Halt(first.Height); err != nil {
+					bcR.Logger.Error("failed to write the upgrade marker file", "err", err)
+				}
+				if err := bcR.pool.Stop(); err != nil {
+					bcR.Logger.Error("Error stopping pool", "err", err)
+				}
+				bcR.stopVerifier()
+				break FOR_LOOP
+			}
 
 			if blocksSynced%100 == 0 {
 				lastRate = 0.9*lastRate + 0.1*(100/time.Since(lastHundred).Seconds())
@@ -531,6 +744,75 @@ FOR_LOOP:
 	}
 }
 
+// takeVerified returns the result of the verification of first by the
+// verifier, if any was made with the validator set of the current state.
+func (bcR *Reactor) takeVerified(first, second *types.Block) *verifiedBlock {
+	if bcR.verifier == nil {
+		return nil
+	}
+	return bcR.verifier.take(first, second)
+}
+
+// isAnchored returns true, in light mode, if the block at height with the
+// given ID is authenticated by a checkpoint whose commit was verified.
+func (bcR *Reactor) isAnchored(state sm.State, height int64, blockID types.BlockID) bool {
+	if bcR.checkpointInterval == 0 {
+		return false
+	}
+	id, ok := bcR.anchoredIDs[height]
+	if !ok || !id.Equals(blockID) {
+		bcR.anchor(state, height)
+		id, ok = bcR.anchoredIDs[height]
+	}
+	return ok && id.Equals(blockID)
+}
+
+// anchor verifies the commit of the first checkpoint at or after height, and
+// records the IDs of the blocks from height to the checkpoint, as linked by
+// the hashes of their headers. It records nothing if the blocks up to the one
+// following the checkpoint are not all in memory, or if the validator set of
+// any of them differs from the current one, since the checkpoint's commit can
+// only be verified with the current validator set.
+func (bcR *Reactor) anchor(state sm.State, height int64) {
+	checkpoint := (height + bcR.checkpointInterval - 1) / bcR.checkpointInterval * bcR.checkpointInterval
+	blocks := bcR.pool.PeekRange(height, checkpoint+1)
+	if blocks == nil {
+		return
+	}
+	valsHash := state.Validators.Hash()
+	for _, block := range blocks[:len(blocks)-1] {
+		if !bytes.Equal(block.ValidatorsHash, valsHash) {
+			return
+		}
+	}
+
+	cp, next := blocks[len(blocks)-2], blocks[len(blocks)-1]
+	var cpID types.BlockID
+	if verified := bcR.takeVerified(cp, next); verified != nil && verified.err == nil {
+		cpID = verified.blockID
+	} else {
+		parts, err := cp.MakePartSet(types.BlockPartSizeBytes)
+		if err != nil {
+			return
+		}
+		cpID = types.BlockID{Hash: cp.Hash(), PartSetHeader: parts.Header()}
+		if err := state.Validators.VerifyCommitLight(state.ChainID, cpID, cp.Height, next.LastCommit,
+			types.WithBatchVerification(!state.ConsensusParams.Validator.DisableBatchVerification)); err != nil {
+			bcR.Logger.Debug("Failed to verify checkpoint", "height", cp.Height, "err", err)
+			return
+		}
+	}
+
+	bcR.anchoredIDs[cp.Height] = cpID
+	for i := len(blocks) - 2; i > 0; i-- {
+		prevID := blocks[i].LastBlockID
+		if !bytes.Equal(blocks[i-1].Hash(), prevID.Hash) {
+			return
+		}
+		bcR.anchoredIDs[blocks[i-1].Height] = prevID
+	}
+}
+
 // BroadcastStatusRequest broadcasts `BlockStore` base and height.
 func (bcR *Reactor) BroadcastStatusRequest() {
 	bcR.Switch.Broadcast(p2p.Envelope{
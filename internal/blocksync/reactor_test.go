@@ -15,6 +15,7 @@ import (
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/sigverify"
 	sm "github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/store"
 	"github.com/cometbft/cometbft/internal/test"
@@ -62,6 +63,7 @@ func newReactor(
 	genDoc *types.GenesisDoc,
 	privVals []types.PrivValidator,
 	maxBlockHeight int64,
+	options ...ReactorOption,
 ) ReactorPair {
 	if len(privVals) != 1 {
 		panic("only support one validator")
@@ -161,7 +163,7 @@ func newReactor(
 		blockStore.SaveBlockWithExtendedCommit(thisBlock, thisParts, seenExtCommit)
 	}
 
-	bcReactor := NewReactor(state.Copy(), blockExec, blockStore, fastSync, NopMetrics(), 0)
+	bcReactor := NewReactor(state.Copy(), blockExec, blockStore, fastSync, NopMetrics(), 0, options...)
 	bcReactor.SetLogger(logger.With("module", "blocksync"))
 
 	return ReactorPair{bcReactor, proxyApp}
@@ -223,6 +225,93 @@ func TestNoBlockResponse(t *testing.T) {
 	}
 }
 
+func TestPipelinedBlockSync(t *testing.T) {
+	r := testSyncWithOptions(t,
+		WithVerifyWorkers(2),
+		WithPendingBlocks(20, 4, dbm.NewMemDB()),
+	)
+	assert.NotNil(t, r.verifier)
+	assert.NotNil(t, r.queue)
+
+	sigVerifyPool := sigverify.NewPool(2)
+	defer sigVerifyPool.Stop()
+	r = testSyncWithOptions(t,
+		WithVerifyWorkers(2),
+		WithSigVerifyPool(sigVerifyPool),
+	)
+	assert.Equal(t, sigVerifyPool, r.verifier.sigVerifyPool)
+}
+
+func TestLightModeBlockSync(t *testing.T) {
+	r := testSyncWithOptions(t, WithCheckpointInterval(10))
+	assert.Equal(t, int64(10), r.checkpointInterval)
+
+	r = testSyncWithOptions(t,
+		WithCheckpointInterval(10),
+		WithVerifyWorkers(2),
+		WithPendingBlocks(40, 20, dbm.NewMemDB()),
+	)
+	assert.Equal(t, int64(10), r.verifier.checkpointInterval)
+}
+
+func TestPendingBlocksQueueError(t *testing.T) {
+	config = test.ResetTestRoot("blocksync_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	queueDB, err := dbm.NewGoLevelDB("queue", t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, queueDB.Close())
+
+	r := newReactor(t, log.TestingLogger(), genDoc, privVals, 0, WithPendingBlocks(20, 4, queueDB)).reactor
+	err = r.Start()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to initialize the blocksync queue")
+}
+
+// testSyncWithOptions syncs a reactor created with the given options from a
+// peer, and checks that it synced the same blocks.
+func testSyncWithOptions(t *testing.T, options ...ReactorOption) *Reactor {
+	t.Helper()
+
+	config = test.ResetTestRoot("blocksync_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	maxBlockHeight := int64(65)
+
+	reactorPairs := make([]ReactorPair, 2)
+
+	reactorPairs[0] = newReactor(t, log.TestingLogger(), genDoc, privVals, maxBlockHeight)
+	reactorPairs[1] = newReactor(t, log.TestingLogger(), genDoc, privVals, 0, options...)
+
+	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("BLOCKSYNC", reactorPairs[i].reactor)
+		return s
+	}, p2p.Connect2Switches)
+
+	defer func() {
+		for _, r := range reactorPairs {
+			err := r.reactor.Stop()
+			require.NoError(t, err)
+			err = r.app.Stop()
+			require.NoError(t, err)
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		return reactorPairs[1].reactor.store.Height() == maxBlockHeight-1
+	}, 30*time.Second, 10*time.Millisecond)
+
+	for h := int64(1); h < maxBlockHeight; h++ {
+		expected := reactorPairs[0].reactor.store.LoadBlockMeta(h)
+		synced := reactorPairs[1].reactor.store.LoadBlockMeta(h)
+		require.NotNil(t, synced, "height %d", h)
+		assert.Equal(t, expected.BlockID, synced.BlockID)
+	}
+	return reactorPairs[1].reactor
+}
+
 // NOTE: This is too hard to test without
 // an easy way to add test peer to switch
 // or without significant refactoring of the module.
@@ -0,0 +1,220 @@
+package blocksync
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/cometbft/cometbft/internal/service"
+	"github.com/cometbft/cometbft/internal/sigverify"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+// maxVerifyAhead is the maximum number of blocks verified ahead of the
+// pool's height.
+const maxVerifyAhead = 100
+
+// verifiedBlock is the outcome of the verification of a block's commit,
+// carried by the next block.
+type verifiedBlock struct {
+	first, second *types.Block // the verified block and the one carrying its commit.
+	valsHash      []byte       // the hash of the validator set the commit was verified with.
+
+	parts   *types.PartSet
+	blockID types.BlockID
+	err     error
+}
+
+// blockVerifier verifies the commits of the blocks fetched by the pool ahead
+// of the apply loop, across several workers. As the validator set of a block
+// is only known once the previous one is applied, the commits are verified
+// with the validator set of the last applied state, provided it matches the
+// one in the block's header. Validator set changes are thus handled by the
+// apply loop, which verifies the blocks itself when no result matches.
+type blockVerifier struct {
+	service.BaseService
+
+	pool    *BlockPool
+	chainID string
+	workers int
+	// if not 0, only the checkpoints are verified (see Reactor.anchor).
+	checkpointInterval int64
+	// runs the verifications of the commits, if not nil.
+	sigVerifyPool *sigverify.Pool
+
+	mtx      cmtsync.Mutex
+	vals     *types.ValidatorSet
+	valsHash []byte
+	// false if the consensus params of the last applied state disable the
+	// batch verification of the signatures.
+	batchVerify bool
+	results     map[int64]*verifiedBlock
+	inFlight    map[int64]bool
+
+	workCh chan int64
+}
+
+func newBlockVerifier(pool *BlockPool, chainID string, workers int) *blockVerifier {
+	v := &blockVerifier{
+		pool:     pool,
+		chainID:  chainID,
+		workers:  workers,
+		results:  make(map[int64]*verifiedBlock),
+		inFlight: make(map[int64]bool),
+		workCh:   make(chan int64, workers),
+	}
+	v.BaseService = *service.NewBaseService(nil, "BlockVerifier", v)
+	return v
+}
+
+// OnStart implements service.Service by spawning the dispatcher and the
+// workers.
+func (v *blockVerifier) OnStart() error {
+	for i := 0; i < v.workers; i++ {
+		go v.workerRoutine()
+	}
+	go v.dispatchRoutine()
+	return nil
+}
+
+// setValidators sets the validator set of the last applied state and whether
+// its consensus params allow batch verification, and drops the results for the
+// heights up to height, which were applied.
+func (v *blockVerifier) setValidators(vals *types.ValidatorSet, batchVerify bool, height int64) {
+	hash := vals.Hash()
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	v.vals = vals.Copy()
+	v.valsHash = hash
+	v.batchVerify = batchVerify
+	for h := range v.results {
+		if h <= height {
+			delete(v.results, h)
+		}
+	}
+}
+
+// take returns and forgets the result of the verification of first with the
+// commit in second, if it was verified with the validator set of the last
+// applied state. It returns nil otherwise.
+func (v *blockVerifier) take(first, second *types.Block) *verifiedBlock {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	res := v.results[first.Height]
+	if res == nil {
+		return nil
+	}
+	delete(v.results, first.Height)
+	if res.first != first || res.second != second || !bytes.Equal(res.valsHash, v.valsHash) {
+		return nil
+	}
+	return res
+}
+
+// dispatchRoutine hands out to the workers the heights for which both the
+// block and the next one were received.
+func (v *blockVerifier) dispatchRoutine() {
+	ticker := time.NewTicker(trySyncIntervalMS * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-v.Quit():
+			return
+		case <-ticker.C:
+		}
+
+		height, _, _ := v.pool.GetStatus()
+	SCAN_LOOP:
+		for h := height; h < height+maxVerifyAhead; h++ {
+			if v.checkpointInterval > 0 && h%v.checkpointInterval != 0 {
+				continue
+			}
+			first, second := v.pool.PeekBlocksAt(h)
+			if first == nil || !v.needsVerification(h, first, second) {
+				continue
+			}
+			select {
+			case v.workCh <- h:
+			default:
+				// All workers are busy, try again on the next tick.
+				v.doneVerification(h, nil)
+				break SCAN_LOOP
+			}
+		}
+	}
+}
+
+// needsVerification returns true, and marks the height as in flight, if the
+// blocks at height were not verified yet with the current validator set.
+func (v *blockVerifier) needsVerification(height int64, first, second *types.Block) bool {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if v.vals == nil || v.inFlight[height] {
+		return false
+	}
+	if res := v.results[height]; res != nil &&
+		res.first == first && res.second == second && bytes.Equal(res.valsHash, v.valsHash) {
+		return false
+	}
+	v.inFlight[height] = true
+	return true
+}
+
+// doneVerification records the result of the verification at height, if
+// any, and marks it as no longer in flight.
+func (v *blockVerifier) doneVerification(height int64, res *verifiedBlock) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	delete(v.inFlight, height)
+	if res != nil {
+		v.results[height] = res
+	}
+}
+
+func (v *blockVerifier) workerRoutine() {
+	for {
+		select {
+		case <-v.Quit():
+			return
+		case height := <-v.workCh:
+			v.doneVerification(height, v.verify(height))
+		}
+	}
+}
+
+// verify verifies the commit of the block at height. It returns nil if the
+// blocks are no longer available, if the block's validator set is not the
+// current one, or if the block's parts could not be made.
+func (v *blockVerifier) verify(height int64) *verifiedBlock {
+	first, second := v.pool.PeekBlocksAt(height)
+	if first == nil {
+		return nil
+	}
+
+	v.mtx.Lock()
+	vals, valsHash, batchVerify := v.vals.Copy(), v.valsHash, v.batchVerify
+	v.mtx.Unlock()
+
+	if !bytes.Equal(first.ValidatorsHash, valsHash) {
+		return nil
+	}
+
+	parts, err := first.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil {
+		// Leave it to the apply loop.
+		return nil
+	}
+	res := &verifiedBlock{first: first, second: second, valsHash: valsHash, parts: parts}
+	res.blockID = types.BlockID{Hash: first.Hash(), PartSetHeader: res.parts.Header()}
+	v.sigVerifyPool.Run(func() {
+		res.err = vals.VerifyCommitLight(v.chainID, res.blockID, first.Height, second.LastCommit,
+			types.WithBatchVerification(batchVerify))
+	})
+	return res
+}
@@ -0,0 +1,175 @@
+package blocksync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/internal/service"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	lightprovider "github.com/cometbft/cometbft/light/provider"
+)
+
+const (
+	// maxWitnessAhead is the maximum number of headers fetched from the
+	// witnesses ahead of the pool's height.
+	maxWitnessAhead = 50
+	// witnessRequestTimeout is the timeout of a request to a witness.
+	witnessRequestTimeout = 10 * time.Second
+	// witnessRetryInterval is the time to wait before fetching again the
+	// headers the witnesses failed to provide.
+	witnessRetryInterval = time.Second
+)
+
+// witnessChecker cross-checks the hashes of the synced blocks against the
+// headers provided by a set of witnesses, typically light client providers
+// of trusted RPC servers. A majority of block sync peers feeding a fork,
+// whose commits verify, e.g. after a light client attack, is thus detected
+// before the blocks are applied.
+type witnessChecker struct {
+	service.BaseService
+
+	pool      *BlockPool
+	witnesses []lightprovider.Provider
+
+	mtx cmtsync.Mutex
+	// hashes[height][i] is the hash of the header at height provided by
+	// witnesses[i].
+	hashes map[int64][][]byte
+}
+
+func newWitnessChecker(pool *BlockPool, witnesses []lightprovider.Provider) *witnessChecker {
+	wc := &witnessChecker{
+		pool:      pool,
+		witnesses: witnesses,
+		hashes:    make(map[int64][][]byte),
+	}
+	wc.BaseService = *service.NewBaseService(nil, "WitnessChecker", wc)
+	return wc
+}
+
+// OnStart implements service.Service.
+func (wc *witnessChecker) OnStart() error {
+	go wc.fetchRoutine()
+	return nil
+}
+
+// check returns whether the witnesses provided the header at height yet, and
+// an error if any of them disagrees with hash. It forgets the headers at and
+// below height once all the witnesses provided it.
+func (wc *witnessChecker) check(height int64, hash []byte) (bool, error) {
+	wc.mtx.Lock()
+	defer wc.mtx.Unlock()
+
+	hashes := wc.hashes[height]
+	for _, h := range hashes {
+		if h == nil {
+			return false, nil
+		}
+	}
+	if hashes == nil {
+		return false, nil
+	}
+	for h := range wc.hashes {
+		if h <= height {
+			delete(wc.hashes, h)
+		}
+	}
+
+	for i, h := range hashes {
+		if !bytes.Equal(h, hash) {
+			return true, ErrWitnessMismatch{
+				Height:      height,
+				Witness:     fmt.Sprint(wc.witnesses[i]),
+				Hash:        hash,
+				WitnessHash: h,
+			}
+		}
+	}
+	return true, nil
+}
+
+// fetchRoutine fetches from the witnesses the headers of the blocks about to
+// be applied.
+func (wc *witnessChecker) fetchRoutine() {
+	for {
+		height, _, _ := wc.pool.GetStatus()
+		maxHeight := wc.pool.MaxPeerHeight()
+		wc.prune(height)
+		fetched := 0
+		for h := height; h < height+maxWitnessAhead && h <= maxHeight; h++ {
+			fetched += wc.fetch(h)
+			if !wc.IsRunning() {
+				return
+			}
+		}
+		if fetched > 0 {
+			continue
+		}
+
+		select {
+		case <-wc.Quit():
+			return
+		case <-time.After(witnessRetryInterval):
+		}
+	}
+}
+
+// prune forgets the headers below height, which were applied.
+func (wc *witnessChecker) prune(height int64) {
+	wc.mtx.Lock()
+	defer wc.mtx.Unlock()
+
+	for h := range wc.hashes {
+		if h < height {
+			delete(wc.hashes, h)
+		}
+	}
+}
+
+// fetch fetches the header at height from the witnesses which did not provide
+// it yet. It returns the number of headers fetched.
+func (wc *witnessChecker) fetch(height int64) int {
+	wc.mtx.Lock()
+	hashes := wc.hashes[height]
+	if hashes == nil {
+		hashes = make([][]byte, len(wc.witnesses))
+		wc.hashes[height] = hashes
+	}
+	missing := make([]int, 0, len(wc.witnesses))
+	for i, h := range hashes {
+		if h == nil {
+			missing = append(missing, i)
+		}
+	}
+	wc.mtx.Unlock()
+
+	var (
+		wg      sync.WaitGroup
+		fetched int
+	)
+	for _, i := range missing {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), witnessRequestTimeout)
+			defer cancel()
+			lb, err := wc.witnesses[i].LightBlock(ctx, height)
+			if err != nil {
+				wc.Logger.Debug("Failed to fetch header from witness",
+					"witness", wc.witnesses[i], "height", height, "err", err)
+				return
+			}
+			wc.mtx.Lock()
+			defer wc.mtx.Unlock()
+			if hashes := wc.hashes[height]; hashes != nil {
+				hashes[i] = lb.Hash()
+				fetched++
+			}
+		}(i)
+	}
+	wg.Wait()
+	return fetched
+}
@@ -0,0 +1,67 @@
+package blocksync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	lightprovider "github.com/cometbft/cometbft/light/provider"
+	"github.com/cometbft/cometbft/types"
+)
+
+// witnessProvider provides headers which only differ by their chain ID.
+type witnessProvider struct {
+	chainID string
+	height  int64
+}
+
+func (p witnessProvider) ChainID() string { return p.chainID }
+
+func (p witnessProvider) LightBlock(_ context.Context, height int64) (*types.LightBlock, error) {
+	if height > p.height {
+		return nil, lightprovider.ErrHeightTooHigh
+	}
+	return &types.LightBlock{SignedHeader: &types.SignedHeader{Header: witnessHeader(p.chainID, height)}}, nil
+}
+
+func (witnessProvider) ReportEvidence(context.Context, types.Evidence) error { return nil }
+
+func witnessHeader(chainID string, height int64) *types.Header {
+	return &types.Header{ChainID: chainID, Height: height, ValidatorsHash: tmhash.Sum([]byte("vals"))}
+}
+
+func TestWitnessChecker(t *testing.T) {
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	wc := newWitnessChecker(pool, []lightprovider.Provider{
+		witnessProvider{chainID: "chain", height: 10},
+		witnessProvider{chainID: "chain", height: 5},
+	})
+	hash := witnessHeader("chain", 3).Hash()
+
+	// Not fetched yet.
+	checked, err := wc.check(3, hash)
+	require.NoError(t, err)
+	assert.False(t, checked)
+
+	assert.Equal(t, 2, wc.fetch(3))
+	checked, err = wc.check(3, hash)
+	require.NoError(t, err)
+	assert.True(t, checked)
+
+	// The second witness is behind.
+	assert.Equal(t, 1, wc.fetch(7))
+	checked, err = wc.check(7, witnessHeader("chain", 7).Hash())
+	require.NoError(t, err)
+	assert.False(t, checked)
+
+	// A fork is detected.
+	assert.Equal(t, 2, wc.fetch(4))
+	checked, err = wc.check(4, witnessHeader("fork", 4).Hash())
+	assert.True(t, checked)
+	var mismatch ErrWitnessMismatch
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, int64(4), mismatch.Height)
+}
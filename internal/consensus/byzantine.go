@@ -0,0 +1,239 @@
+//go:build byzantine
+
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	cmtcons "github.com/cometbft/cometbft/api/cometbft/consensus/v1"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Byzantine holds the misbehaviors of a validator, by height. It is safe for
+// concurrent use, so that misbehaviors can be changed while the node runs.
+//
+// WARNING: only use this for testing. Misbehaving validators get slashed.
+type Byzantine struct {
+	// signer signs the conflicting messages. It must not protect against
+	// double signing, e.g. a types.MockPV with the validator's key.
+	signer types.PrivValidator
+
+	mtx          sync.Mutex
+	misbehaviors map[int64]Misbehavior
+	equivocation *equivocation
+}
+
+type equivocation struct {
+	proposal *types.Proposal
+	parts    *types.PartSet
+}
+
+// NewByzantine returns a Byzantine without misbehaviors, signing conflicting
+// messages with signer.
+func NewByzantine(signer types.PrivValidator) *Byzantine {
+	return &Byzantine{
+		signer:       signer,
+		misbehaviors: make(map[int64]Misbehavior),
+	}
+}
+
+// Set makes the validator adopt misbehavior m at height.
+func (b *Byzantine) Set(height int64, m Misbehavior) error {
+	if height <= 0 {
+		return fmt.Errorf("height must be positive, got %d", height)
+	}
+	if err := m.ValidateBasic(); err != nil {
+		return err
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.misbehaviors[height] = m
+	return nil
+}
+
+// Clear removes the misbehavior at height, if any.
+func (b *Byzantine) Clear(height int64) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	delete(b.misbehaviors, height)
+}
+
+// Misbehaviors returns a copy of the misbehaviors, by height.
+func (b *Byzantine) Misbehaviors() map[int64]Misbehavior {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	misbehaviors := make(map[int64]Misbehavior, len(b.misbehaviors))
+	for height, m := range b.misbehaviors {
+		misbehaviors[height] = m
+	}
+	return misbehaviors
+}
+
+// misbehavior returns the misbehavior at height. It may be called on a nil
+// Byzantine, which never misbehaves.
+func (b *Byzantine) misbehavior(height int64) Misbehavior {
+	if b == nil {
+		return ""
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.misbehaviors[height]
+}
+
+// conflictingVote returns a signed vote conflicting with vote: a vote for nil
+// if vote is for a block, and for a random block otherwise.
+func (b *Byzantine) conflictingVote(vote *types.Vote, chainID string, extensionsEnabled bool) (*types.Vote, error) {
+	conflicting := vote.Copy()
+	conflicting.Extension, conflicting.ExtensionSignature = nil, nil
+	if vote.BlockID.IsNil() {
+		conflicting.BlockID = types.BlockID{
+			Hash:          cmtrand.Bytes(tmhash.Size),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: cmtrand.Bytes(tmhash.Size)},
+		}
+	} else {
+		conflicting.BlockID = types.BlockID{}
+	}
+
+	signExtension := extensionsEnabled && vote.Type == types.PrecommitType && !conflicting.BlockID.IsNil()
+	v := conflicting.ToProto()
+	if err := b.signer.SignVote(chainID, v); err != nil {
+		return nil, err
+	}
+	conflicting.Signature = v.Signature
+	if signExtension {
+		// the signer signs the extension of all non-nil precommits
+		conflicting.ExtensionSignature = v.ExtensionSignature
+	}
+	return conflicting, nil
+}
+
+// conflictingProposal returns a signed proposal conflicting with the one of
+// rs, along with the parts of its block. It is built once per round.
+func (b *Byzantine) conflictingProposal(rs *cstypes.RoundState, chainID string) (*types.Proposal, *types.PartSet, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if e := b.equivocation; e != nil && e.proposal.Height == rs.Height && e.proposal.Round == rs.Round {
+		return e.proposal, e.parts, nil
+	}
+
+	pb, err := rs.ProposalBlock.ToProto()
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := types.BlockFromProto(pb)
+	if err != nil {
+		return nil, nil, err
+	}
+	block.Data.Txs = append(block.Data.Txs, types.Tx(fmt.Sprintf("equivocation-%d-%d", rs.Height, rs.Round)))
+	block.DataHash = nil
+	hash := block.Hash()
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proposal := types.NewProposal(rs.Height, rs.Round, rs.Proposal.POLRound,
+		types.BlockID{Hash: hash, PartSetHeader: parts.Header()})
+	proposal.Timestamp = block.Time
+	p := proposal.ToProto()
+	if err := b.signer.SignProposal(chainID, p); err != nil {
+		return nil, nil, err
+	}
+	proposal.Signature = p.Signature
+
+	b.equivocation = &equivocation{proposal: proposal, parts: parts}
+	return proposal, parts, nil
+}
+
+// signedBy returns true if the proposal of rs was signed by b's signer.
+func (b *Byzantine) signedBy(rs *cstypes.RoundState, chainID string) bool {
+	pubKey, err := b.signer.GetPubKey()
+	if err != nil || rs.Proposal == nil {
+		return false
+	}
+	return pubKey.VerifySignature(types.ProposalSignBytes(chainID, rs.Proposal.ToProto()), rs.Proposal.Signature)
+}
+
+//-----------------------------------------------------------------------------
+
+// byzantineReactor holds the misbehaviors of the validator, if any.
+type byzantineReactor struct {
+	byzantine *Byzantine // nil unless misbehaving for testing
+}
+
+// ReactorByzantine makes the validator misbehave as told by b.
+func ReactorByzantine(b *Byzantine) ReactorOption {
+	return func(conR *Reactor) { conR.byzantine = b }
+}
+
+// withholdsBlockParts reports whether the validator stops gossiping block
+// parts at height.
+func (conR *Reactor) withholdsBlockParts(height int64) bool {
+	return conR.byzantine.misbehavior(height) == MisbehaviorWithholdBlockParts
+}
+
+// broadcastConflictingVote broadcasts a vote conflicting with vote, if vote
+// was signed by this validator at a height where it double signs. It is
+// called by the consensus state's event switch, with the state lock held.
+func (conR *Reactor) broadcastConflictingVote(vote *types.Vote) {
+	switch conR.byzantine.misbehavior(vote.Height) {
+	case MisbehaviorDoubleSign:
+		if vote.Type != types.PrevoteType {
+			return
+		}
+	case MisbehaviorConflictingPrecommits:
+		if vote.Type != types.PrecommitType {
+			return
+		}
+	default:
+		return
+	}
+	pubKey, err := conR.byzantine.signer.GetPubKey()
+	if err != nil || !bytes.Equal(pubKey.Address(), vote.ValidatorAddress) {
+		return
+	}
+
+	// reading the state is safe, as the caller holds the lock
+	state := conR.conS.state
+	conflicting, err := conR.byzantine.conflictingVote(vote, state.ChainID,
+		state.ConsensusParams.ABCI.VoteExtensionsEnabled(vote.Height))
+	if err != nil {
+		conR.Logger.Error("Failed to sign conflicting vote", "err", err)
+		return
+	}
+	conR.Logger.Info("Byzantine: broadcasting conflicting vote", "vote", vote, "conflicting", conflicting)
+	conR.Switch.Broadcast(p2p.Envelope{
+		ChannelID: VoteChannel,
+		Message:   &cmtcons.Vote{Vote: conflicting.ToProto()},
+	})
+}
+
+// proposalFor returns the proposal and block parts to gossip to peer. When
+// equivocating, the validator sends a conflicting proposal of its own to half
+// of its peers.
+func (conR *Reactor) proposalFor(rs *cstypes.RoundState, peer p2p.Peer) (*types.Proposal, *types.PartSet) {
+	if conR.byzantine.misbehavior(rs.Height) != MisbehaviorEquivocate ||
+		rs.Proposal == nil || rs.ProposalBlock == nil {
+		return rs.Proposal, rs.ProposalBlockParts
+	}
+	if id := peer.ID(); id == "" || id[len(id)-1]%2 == 0 {
+		return rs.Proposal, rs.ProposalBlockParts
+	}
+	chainID := conR.conS.GetState().ChainID
+	if !conR.byzantine.signedBy(rs, chainID) {
+		return rs.Proposal, rs.ProposalBlockParts
+	}
+
+	proposal, parts, err := conR.byzantine.conflictingProposal(rs, chainID)
+	if err != nil {
+		conR.Logger.Error("Failed to create conflicting proposal", "err", err)
+		return rs.Proposal, rs.ProposalBlockParts
+	}
+	return proposal, parts
+}
@@ -0,0 +1,22 @@
+//go:build !byzantine
+
+package consensus
+
+import (
+	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+// byzantineReactor is empty unless built with the byzantine tag, so that the
+// validators of production nodes can't be told to misbehave. See
+// byzantine.go.
+type byzantineReactor struct{}
+
+func (*Reactor) broadcastConflictingVote(*types.Vote) {}
+
+func (*Reactor) withholdsBlockParts(int64) bool { return false }
+
+func (*Reactor) proposalFor(rs *cstypes.RoundState, _ p2p.Peer) (*types.Proposal, *types.PartSet) {
+	return rs.Proposal, rs.ProposalBlockParts
+}
@@ -0,0 +1,115 @@
+//go:build byzantine
+
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+func TestByzantineMisbehaviors(t *testing.T) {
+	b := NewByzantine(types.NewMockPV())
+	require.Equal(t, Misbehavior(""), b.misbehavior(2))
+
+	require.NoError(t, b.Set(2, MisbehaviorDoubleSign))
+	require.NoError(t, b.Set(3, MisbehaviorEquivocate))
+	require.Error(t, b.Set(4, Misbehavior("lie")))
+	require.Error(t, b.Set(0, MisbehaviorDoubleSign))
+	require.Equal(t, MisbehaviorDoubleSign, b.misbehavior(2))
+	require.Equal(t, map[int64]Misbehavior{2: MisbehaviorDoubleSign, 3: MisbehaviorEquivocate}, b.Misbehaviors())
+
+	b.Clear(2)
+	require.Equal(t, Misbehavior(""), b.misbehavior(2))
+
+	var nilByzantine *Byzantine
+	require.Equal(t, Misbehavior(""), nilByzantine.misbehavior(3))
+}
+
+func TestByzantineConflictingVote(t *testing.T) {
+	const chainID = "byzantine"
+	pv := types.NewMockPV()
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+	valSet := types.NewValidatorSet([]*types.Validator{types.NewValidator(pubKey, 10)})
+	b := NewByzantine(pv)
+
+	blockID := types.BlockID{
+		Hash:          cmtrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: cmtrand.Bytes(tmhash.Size)},
+	}
+	for _, id := range []types.BlockID{blockID, {}} {
+		vote := &types.Vote{
+			Type:             types.PrevoteType,
+			Height:           2,
+			Round:            0,
+			BlockID:          id,
+			Timestamp:        cmttime.Now(),
+			ValidatorAddress: pubKey.Address(),
+			ValidatorIndex:   0,
+		}
+		v := vote.ToProto()
+		require.NoError(t, pv.SignVote(chainID, v))
+		vote.Signature = v.Signature
+
+		conflicting, err := b.conflictingVote(vote, chainID, false)
+		require.NoError(t, err)
+		require.NotEqual(t, vote.BlockID, conflicting.BlockID)
+
+		// the conflicting vote is valid and detected as such
+		voteSet := types.NewVoteSet(chainID, 2, 0, types.PrevoteType, valSet)
+		added, err := voteSet.AddVote(vote)
+		require.NoError(t, err)
+		require.True(t, added)
+		_, err = voteSet.AddVote(conflicting)
+		var conflictErr *types.ErrVoteConflictingVotes
+		require.ErrorAs(t, err, &conflictErr)
+	}
+}
+
+func TestByzantineConflictingProposal(t *testing.T) {
+	const chainID = "byzantine"
+	pv := types.NewMockPV()
+	b := NewByzantine(pv)
+
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+	block := types.MakeBlock(1, types.Txs{types.Tx("a=1")}, &types.Commit{}, nil)
+	block.ProposerAddress = pubKey.Address()
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	proposal := types.NewProposal(1, 0, -1, types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()})
+	p := proposal.ToProto()
+	require.NoError(t, pv.SignProposal(chainID, p))
+	proposal.Signature = p.Signature
+
+	rs := &cstypes.RoundState{
+		Height:             1,
+		Round:              0,
+		Proposal:           proposal,
+		ProposalBlock:      block,
+		ProposalBlockParts: parts,
+	}
+	require.True(t, b.signedBy(rs, chainID))
+	require.False(t, NewByzantine(types.NewMockPV()).signedBy(rs, chainID))
+
+	conflicting, conflictingParts, err := b.conflictingProposal(rs, chainID)
+	require.NoError(t, err)
+	require.Equal(t, proposal.Height, conflicting.Height)
+	require.Equal(t, proposal.Round, conflicting.Round)
+	require.NotEqual(t, proposal.BlockID, conflicting.BlockID)
+	require.Equal(t, conflicting.BlockID.PartSetHeader, conflictingParts.Header())
+
+	require.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, conflicting.ToProto()), conflicting.Signature))
+
+	// the conflicting proposal is built once per round
+	again, _, err := b.conflictingProposal(rs, chainID)
+	require.NoError(t, err)
+	require.Same(t, conflicting, again)
+}
@@ -253,7 +253,7 @@ func decideProposal(
 
 func addVotes(to *State, votes ...*types.Vote) {
 	for _, vote := range votes {
-		to.peerMsgQueue <- msgInfo{Msg: &VoteMessage{vote}}
+		to.peerMsgQueue <- msgInfo{Msg: &VoteMessage{Vote: vote}}
 	}
 }
 
@@ -0,0 +1,365 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	cmtcons "github.com/cometbft/cometbft/api/cometbft/consensus/v1"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	"github.com/cometbft/cometbft/internal/bits"
+	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Compact block relay.
+//
+// The peers advertising CompactBlockChannel are sent the proposal block as a
+// CompactBlock, i.e. with its transactions replaced by their keys, instead of
+// its parts. A peer takes the transactions from its mempool, requests the
+// missing ones with a CompactBlockTxsRequest and, once it has them all,
+// reconstructs the block parts, which it processes as if it had received them
+// one by one. If the reconstructed parts do not match the proposal, the peer
+// sends a CompactBlockFallback and is sent the block parts as usual.
+//
+// The CompactBlock is sent on DataChannel, so that it is received after the
+// proposal, while the other messages are sent on CompactBlockChannel.
+
+// compactBlockTimeout is how long the block parts are withheld from a peer
+// after sending it a compact block. It bounds the delay for the peers which
+// fail to reconstruct the block without falling back explicitly.
+const compactBlockTimeout = 2 * time.Second
+
+// txFetcher is the interface to the mempool used to reconstruct the compact
+// blocks.
+type txFetcher interface {
+	GetTxByKey(txKey types.TxKey) (types.Tx, bool)
+}
+
+// ReactorMempool sets the mempool the reactor takes the transactions from, to
+// reconstruct the compact blocks sent by the peers. Without it, all the
+// transactions of the compact blocks are requested from the peers.
+func ReactorMempool(mempool txFetcher) ReactorOption {
+	return func(conR *Reactor) { conR.mempool = mempool }
+}
+
+// compactBlockState is the state of the compact block relay with a peer.
+type compactBlockState struct {
+	// Height and round of the compact block sent to the peer, and when it was
+	// sent.
+	sentHeight int64
+	sentRound  int32
+	sentAt     time.Time
+	// fallback is set if the peer is to be sent the block parts of the compact
+	// block instead.
+	fallback bool
+
+	// pending is the compact block received from the peer, waiting for its
+	// missing transactions.
+	pending *pendingCompactBlock
+}
+
+// pendingCompactBlock is a compact block waiting for its missing transactions.
+type pendingCompactBlock struct {
+	msg     *CompactBlockMessage
+	txs     types.Txs
+	missing *bits.BitArray
+}
+
+// gossipCompactBlock sends the proposal block to the peer as a compact block,
+// if the peer supports it and has none of the block parts yet.
+// Returns true if the compact block was sent.
+func (conR *Reactor) gossipCompactBlock(
+	logger log.Logger,
+	rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState,
+	ps *PeerState,
+	proposalBlockParts *types.PartSet,
+) bool {
+	// Do not send the compact block of a conflicting proposal.
+	if rs.ProposalBlock == nil || proposalBlockParts != rs.ProposalBlockParts || !proposalBlockParts.IsComplete() {
+		return false
+	}
+	if rs.Height != prs.Height || rs.Round != prs.Round || !prs.Proposal ||
+		!proposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) || !prs.ProposalBlockParts.IsEmpty() {
+		return false
+	}
+	if !ps.supportsCompactBlocks() || !ps.compactBlockSendable(rs.Height, rs.Round) {
+		return false
+	}
+
+	msg, err := makeCompactBlock(rs.Height, rs.Round, rs.ProposalBlock)
+	if err != nil {
+		logger.Error("Failed to make compact block", "height", rs.Height, "round", rs.Round, "err", err)
+		ps.setCompactBlockSent(rs.Height, rs.Round, true)
+		return false
+	}
+	if (&cmtcons.Message{Sum: &cmtcons.Message_CompactBlock{CompactBlock: msg}}).Size() > maxMsgSize {
+		// Too many transactions, send the block parts.
+		ps.setCompactBlockSent(rs.Height, rs.Round, true)
+		return false
+	}
+
+	logger.Debug("Sending compact block", "height", rs.Height, "round", rs.Round, "txs", len(msg.TxKeys))
+	if !ps.peer.Send(p2p.Envelope{
+		ChannelID: DataChannel,
+		Message:   msg,
+	}) {
+		return false
+	}
+	ps.setCompactBlockSent(rs.Height, rs.Round, false)
+	return true
+}
+
+// makeCompactBlock returns the compact block of the given block.
+func makeCompactBlock(height int64, round int32, block *types.Block) (*cmtcons.CompactBlock, error) {
+	pbb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	pbb.Data.Txs = nil
+
+	txKeys := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		key := tx.Key()
+		txKeys[i] = key[:]
+	}
+	return &cmtcons.CompactBlock{
+		Height: height,
+		Round:  round,
+		Block:  pbb,
+		TxKeys: txKeys,
+	}, nil
+}
+
+// handleCompactBlock reconstructs the block of a compact block received from
+// the peer, or requests its missing transactions.
+func (conR *Reactor) handleCompactBlock(ps *PeerState, msg *CompactBlockMessage) {
+	rs := conR.getRoundState()
+	if msg.Height != rs.Height || (rs.ProposalBlockParts != nil && rs.ProposalBlockParts.IsComplete()) {
+		return
+	}
+
+	txs := make(types.Txs, len(msg.TxKeys))
+	missing := bits.NewBitArray(len(msg.TxKeys))
+	for i, key := range msg.TxKeys {
+		if conR.mempool != nil {
+			if tx, ok := conR.mempool.GetTxByKey(key); ok {
+				txs[i] = tx
+				continue
+			}
+		}
+		missing.SetIndex(i, true)
+	}
+	if missing.IsEmpty() {
+		conR.reconstructCompactBlock(ps, msg, txs)
+		return
+	}
+
+	ps.setPendingCompactBlock(&pendingCompactBlock{msg: msg, txs: txs, missing: missing})
+	ps.peer.TrySend(p2p.Envelope{
+		ChannelID: CompactBlockChannel,
+		Message: &cmtcons.CompactBlockTxsRequest{
+			Height:  msg.Height,
+			Round:   msg.Round,
+			Missing: *missing.ToProto(),
+		},
+	})
+}
+
+// handleCompactBlockTxsRequest sends the peer the requested transactions of
+// the compact block we sent it.
+func (conR *Reactor) handleCompactBlockTxsRequest(ps *PeerState, msg *CompactBlockTxsRequestMessage) {
+	rs := conR.getRoundState()
+	block := rs.ProposalBlock
+	if msg.Height != rs.Height || msg.Round != rs.Round || block == nil || msg.Missing.Size() != len(block.Txs) {
+		// The block is not the one we sent, send the block parts if we can.
+		ps.setCompactBlockFallback(msg.Height, msg.Round)
+		return
+	}
+
+	var (
+		txs  [][]byte
+		size int
+	)
+	for i, tx := range block.Txs {
+		if !msg.Missing.GetIndex(i) {
+			continue
+		}
+		txs = append(txs, tx)
+		size += len(tx) + 16 // leave room for the tag and length prefix
+	}
+	if size > maxMsgSize {
+		ps.setCompactBlockFallback(msg.Height, msg.Round)
+		return
+	}
+
+	ps.peer.TrySend(p2p.Envelope{
+		ChannelID: CompactBlockChannel,
+		Message: &cmtcons.CompactBlockTxs{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Txs:    txs,
+		},
+	})
+}
+
+// handleCompactBlockTxs completes the pending compact block with the
+// transactions received from the peer and reconstructs its block.
+func (conR *Reactor) handleCompactBlockTxs(ps *PeerState, msg *CompactBlockTxsMessage) {
+	pending := ps.takePendingCompactBlock(msg.Height, msg.Round)
+	if pending == nil {
+		return
+	}
+
+	next := 0
+	for i := range pending.txs {
+		if !pending.missing.GetIndex(i) {
+			continue
+		}
+		if next == len(msg.Txs) {
+			conR.sendCompactBlockFallback(ps, msg.Height, msg.Round)
+			return
+		}
+		pending.txs[i] = msg.Txs[next]
+		next++
+	}
+	if next != len(msg.Txs) {
+		conR.sendCompactBlockFallback(ps, msg.Height, msg.Round)
+		return
+	}
+
+	conR.reconstructCompactBlock(ps, pending.msg, pending.txs)
+}
+
+// reconstructCompactBlock makes the block parts of the compact block with the
+// given transactions and, if they match the proposal, processes them as if
+// they were received from the peer. Otherwise, it falls back to the block
+// parts.
+//
+// If the proposal is not processed yet, the parts are checked against it by
+// the consensus state, which processes the messages of the peer in order.
+func (conR *Reactor) reconstructCompactBlock(ps *PeerState, msg *CompactBlockMessage, txs types.Txs) {
+	parts, err := compactBlockParts(msg.Block, txs)
+	if err != nil {
+		conR.Logger.Error("Failed to marshal compact block", "height", msg.Height, "round", msg.Round, "err", err)
+		conR.sendCompactBlockFallback(ps, msg.Height, msg.Round)
+		return
+	}
+
+	rs := conR.getRoundState()
+	if msg.Height != rs.Height || (rs.ProposalBlockParts != nil && !rs.ProposalBlockParts.HasHeader(parts.Header())) {
+		conR.Logger.Debug("Compact block does not match the proposal",
+			"height", msg.Height, "round", msg.Round, "peer", ps.peer.ID())
+		conR.sendCompactBlockFallback(ps, msg.Height, msg.Round)
+		return
+	}
+
+	conR.Logger.Debug("Reconstructed compact block", "height", msg.Height, "round", msg.Round, "peer", ps.peer.ID())
+	for i := 0; i < int(parts.Total()); i++ {
+		ps.SetHasProposalBlockPart(msg.Height, msg.Round, i)
+		conR.conS.peerMsgQueue <- msgInfo{&BlockPartMessage{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Part:   parts.GetPart(i),
+		}, ps.peer.ID()}
+	}
+}
+
+// compactBlockParts returns the block parts of the compact block with the
+// given transactions.
+func compactBlockParts(block *cmtproto.Block, txs types.Txs) (*types.PartSet, error) {
+	pbb := *block
+	pbb.Data.Txs = txs.ToSliceOfBytes()
+	bz, err := proto.Marshal(&pbb)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewPartSetFromData(bz, types.BlockPartSizeBytes), nil
+}
+
+func (*Reactor) sendCompactBlockFallback(ps *PeerState, height int64, round int32) {
+	ps.peer.TrySend(p2p.Envelope{
+		ChannelID: CompactBlockChannel,
+		Message: &cmtcons.CompactBlockFallback{
+			Height: height,
+			Round:  round,
+		},
+	})
+}
+
+//-----------------------------------------------------------------------------
+
+// supportsCompactBlocks returns true if the peer advertises
+// CompactBlockChannel.
+func (ps *PeerState) supportsCompactBlocks() bool {
+	ni, ok := ps.peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && ni.HasChannel(CompactBlockChannel)
+}
+
+// compactBlockSendable returns true if no compact block was sent to the peer
+// for the given height and round.
+func (ps *PeerState) compactBlockSendable(height int64, round int32) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	return ps.compactBlock.sentHeight != height || ps.compactBlock.sentRound != round
+}
+
+// setCompactBlockSent records that the compact block for the given height and
+// round was sent to the peer or, if fallback is set, that the peer is to be
+// sent its block parts instead.
+func (ps *PeerState) setCompactBlockSent(height int64, round int32, fallback bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlock.sentHeight = height
+	ps.compactBlock.sentRound = round
+	ps.compactBlock.sentAt = time.Now()
+	ps.compactBlock.fallback = fallback
+}
+
+// setCompactBlockFallback records that the peer is to be sent the block parts
+// of the compact block sent for the given height and round.
+func (ps *PeerState) setCompactBlockFallback(height int64, round int32) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.compactBlock.sentHeight == height && ps.compactBlock.sentRound == round {
+		ps.compactBlock.fallback = true
+	}
+}
+
+// withholdsBlockParts returns true if the block parts for the given height and
+// round are not to be sent to the peer, because it was sent their compact
+// block.
+func (ps *PeerState) withholdsBlockParts(height int64, round int32) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	return ps.compactBlock.sentHeight == height && ps.compactBlock.sentRound == round &&
+		!ps.compactBlock.fallback && time.Since(ps.compactBlock.sentAt) < compactBlockTimeout
+}
+
+func (ps *PeerState) setPendingCompactBlock(pending *pendingCompactBlock) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlock.pending = pending
+}
+
+// takePendingCompactBlock returns and clears the pending compact block for the
+// given height and round, if any.
+func (ps *PeerState) takePendingCompactBlock(height int64, round int32) *pendingCompactBlock {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	pending := ps.compactBlock.pending
+	if pending == nil || pending.msg.Height != height || pending.msg.Round != round {
+		return nil
+	}
+	ps.compactBlock.pending = nil
+	return pending
+}
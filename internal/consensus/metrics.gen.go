@@ -94,6 +94,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_interval_seconds",
 			Help:      "Time between this and the last block.",
 		}, labels).With(labelsAndValues...),
+		BlockIntervalDriftSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_interval_drift_seconds",
+			Help:      "Difference between the time between this and the last block and the target block interval, if set.",
+		}, labels).With(labelsAndValues...),
 		NumTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -144,6 +150,20 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 			Buckets: stdprometheus.ExponentialBucketsRange(0.1, 100, 8),
 		}, append(labels, "step")).With(labelsAndValues...),
+		PhaseDurationSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "phase_duration_seconds",
+			Help:      "Histogram of durations for each phase of a round: propose, prevote (including the prevote wait), precommit (including the precommit wait) and commit.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.01, 60, 16),
+		}, append(labels, "phase")).With(labelsAndValues...),
+		PhaseEnds: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "phase_ends",
+			Help:      "Number of times each phase of a round ended, by reason: timeout if its timeout expired, quorum otherwise, i.e. the proposal or +2/3 of the votes were received.",
+		}, append(labels, "phase", "reason")).With(labelsAndValues...),
 		BlockGossipPartsReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -160,7 +180,7 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "full_prevote_delay",
-			Help:      "Interval in seconds between the proposal timestamp and the timestamp of the latest prevote in a round where all validators voted.",
+			Help:      "FullPrevoteDelay is the interval in seconds between the proposal timestamp and the timestamp of the latest prevote in a round where 100% of the voting power on the network issued prevotes. metrics:Interval in seconds between the proposal timestamp and the timestamp of the latest prevote in a round where all validators voted.",
 		}, append(labels, "proposer_address")).With(labelsAndValues...),
 		VoteExtensionReceiveCount: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
@@ -210,6 +230,7 @@ func NopMetrics() *Metrics {
 		ByzantineValidators:       discard.NewGauge(),
 		ByzantineValidatorsPower:  discard.NewGauge(),
 		BlockIntervalSeconds:      discard.NewHistogram(),
+		BlockIntervalDriftSeconds: discard.NewGauge(),
 		NumTxs:                    discard.NewGauge(),
 		BlockSizeBytes:            discard.NewGauge(),
 		TotalTxs:                  discard.NewGauge(),
@@ -218,6 +239,8 @@ func NopMetrics() *Metrics {
 		DuplicateBlockPart:        discard.NewCounter(),
 		DuplicateVote:             discard.NewCounter(),
 		StepDurationSeconds:       discard.NewHistogram(),
+		PhaseDurationSeconds:      discard.NewHistogram(),
+		PhaseEnds:                 discard.NewCounter(),
 		BlockGossipPartsReceived:  discard.NewCounter(),
 		QuorumPrevoteDelay:        discard.NewGauge(),
 		FullPrevoteDelay:          discard.NewGauge(),
@@ -50,6 +50,9 @@ type Metrics struct {
 
 	// Time between this and the last block.
 	BlockIntervalSeconds metrics.Histogram
+	// Difference between the time between this and the last block and the
+	// target block interval, if set.
+	BlockIntervalDriftSeconds metrics.Gauge
 
 	// Number of transactions.
 	NumTxs metrics.Gauge
@@ -73,6 +76,18 @@ type Metrics struct {
 	StepDurationSeconds metrics.Histogram `metrics_bucketsizes:"0.1, 100, 8" metrics_buckettype:"exprange" metrics_labels:"step"`
 	stepStart           time.Time
 
+	// Histogram of durations for each phase of a round: propose, prevote
+	// (including the prevote wait), precommit (including the precommit wait)
+	// and commit.
+	PhaseDurationSeconds metrics.Histogram `metrics_bucketsizes:"0.01, 60, 16" metrics_buckettype:"exprange" metrics_labels:"phase"`
+	// Number of times each phase of a round ended, by reason: timeout if its
+	// timeout expired, quorum otherwise, i.e. the proposal or +2/3 of the votes
+	// were received.
+	PhaseEnds  metrics.Counter `metrics_labels:"phase, reason"`
+	phase      string
+	phaseStart time.Time
+	timedOut   bool
+
 	// Number of block parts received by the node, separated by whether the part
 	// was relevant to the block the node is trying to gather or not.
 	BlockGossipPartsReceived metrics.Counter `metrics_labels:"matches_current"`
@@ -164,6 +179,47 @@ func (m *Metrics) MarkLateVote(vt types.SignedMsgType) {
 	m.LateVotes.With("vote_type", n).Add(1)
 }
 
+// MarkTimeout marks the current phase as ending because its timeout expired.
+func (m *Metrics) MarkTimeout() {
+	m.timedOut = true
+}
+
+// MarkPhase records the end of the current phase if step starts another one.
+func (m *Metrics) MarkPhase(step cstypes.RoundStepType) {
+	defer func() { m.timedOut = false }()
+
+	phase := roundPhase(step)
+	if phase == m.phase {
+		return
+	}
+	if m.phase != "" {
+		reason := "quorum"
+		if m.timedOut {
+			reason = "timeout"
+		}
+		m.PhaseDurationSeconds.With("phase", m.phase).Observe(time.Since(m.phaseStart).Seconds())
+		m.PhaseEnds.With("phase", m.phase, "reason", reason).Add(1)
+	}
+	m.phase = phase
+	m.phaseStart = time.Now()
+}
+
+// roundPhase returns the phase of a round step is part of, if any.
+func roundPhase(step cstypes.RoundStepType) string {
+	switch step {
+	case cstypes.RoundStepPropose:
+		return "propose"
+	case cstypes.RoundStepPrevote, cstypes.RoundStepPrevoteWait:
+		return "prevote"
+	case cstypes.RoundStepPrecommit, cstypes.RoundStepPrecommitWait:
+		return "precommit"
+	case cstypes.RoundStepCommit:
+		return "commit"
+	default:
+		return ""
+	}
+}
+
 func (m *Metrics) MarkStep(s cstypes.RoundStepType) {
 	if !m.stepStart.IsZero() {
 		stepTime := time.Since(m.stepStart).Seconds()
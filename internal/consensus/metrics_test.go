@@ -0,0 +1,86 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// phaseMetrics returns metrics whose phase metrics can be read back.
+func phaseMetrics() (*Metrics, *stdprometheus.CounterVec, *stdprometheus.HistogramVec) {
+	ends := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{Name: "phase_ends"}, []string{"phase", "reason"})
+	durations := stdprometheus.NewHistogramVec(stdprometheus.HistogramOpts{Name: "phase_duration_seconds"}, []string{"phase"})
+
+	m := NopMetrics()
+	m.PhaseEnds = prometheus.NewCounter(ends)
+	m.PhaseDurationSeconds = prometheus.NewHistogram(durations)
+	return m, ends, durations
+}
+
+func TestMetricsMarkPhase(t *testing.T) {
+	m, ends, durations := phaseMetrics()
+
+	// Propose ends on the proposal, prevote on its timeout and precommit on
+	// a quorum of precommits. Steps within a phase do not end it.
+	m.MarkPhase(cstypes.RoundStepNewRound)
+	m.MarkPhase(cstypes.RoundStepPropose)
+	m.MarkPhase(cstypes.RoundStepPrevote)
+	m.MarkPhase(cstypes.RoundStepPrevoteWait)
+	m.MarkTimeout()
+	m.MarkPhase(cstypes.RoundStepPrecommit)
+	m.MarkPhase(cstypes.RoundStepPrecommitWait)
+	m.MarkPhase(cstypes.RoundStepCommit)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(ends.WithLabelValues("propose", "quorum")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(ends.WithLabelValues("propose", "timeout")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(ends.WithLabelValues("prevote", "quorum")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(ends.WithLabelValues("prevote", "timeout")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(ends.WithLabelValues("precommit", "quorum")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(ends.WithLabelValues("precommit", "timeout")))
+	// The commit phase has not ended yet.
+	assert.Equal(t, 0.0, testutil.ToFloat64(ends.WithLabelValues("commit", "quorum")))
+
+	for phase, count := range map[string]uint64{"propose": 1, "prevote": 1, "precommit": 1, "commit": 0} {
+		var metric dto.Metric
+		h, err := durations.GetMetricWithLabelValues(phase)
+		require.NoError(t, err)
+		require.NoError(t, h.(stdprometheus.Metric).Write(&metric))
+		assert.Equal(t, count, metric.GetHistogram().GetSampleCount(), phase)
+	}
+
+	// A timeout only applies to the phase that was current when it expired.
+	m.MarkPhase(cstypes.RoundStepNewHeight)
+	m.MarkPhase(cstypes.RoundStepPropose)
+	m.MarkPhase(cstypes.RoundStepPrevote)
+	assert.Equal(t, 2.0, testutil.ToFloat64(ends.WithLabelValues("propose", "quorum")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(ends.WithLabelValues("commit", "quorum")))
+}
+
+// A single validator ends every phase of a round on a quorum of its own.
+func TestStatePhaseMetrics(t *testing.T) {
+	cs, _ := randState(1)
+	height, round := cs.Height, cs.Round
+
+	m, ends, durations := phaseMetrics()
+	cs.metrics = m
+
+	newRoundCh := subscribe(cs.eventBus, types.EventQueryNewRound)
+
+	startTestRound(cs, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewRound(newRoundCh, height+1, 0)
+
+	for _, phase := range []string{"propose", "prevote", "precommit", "commit"} {
+		assert.GreaterOrEqual(t, testutil.ToFloat64(ends.WithLabelValues(phase, "quorum")), 1.0, phase)
+		assert.Equal(t, 0.0, testutil.ToFloat64(ends.WithLabelValues(phase, "timeout")), phase)
+	}
+	assert.Equal(t, 4, testutil.CollectAndCount(durations))
+}
@@ -0,0 +1,34 @@
+package consensus
+
+import "fmt"
+
+// Misbehavior is a byzantine behavior a validator can be told to adopt at a
+// given height, for testing. The validators only adopt them in the builds
+// with the byzantine tag: see Byzantine.
+type Misbehavior string
+
+const (
+	// MisbehaviorDoubleSign makes the validator sign and broadcast a prevote
+	// conflicting with its own.
+	MisbehaviorDoubleSign Misbehavior = "double-sign"
+	// MisbehaviorConflictingPrecommits makes the validator sign and broadcast
+	// a precommit conflicting with its own.
+	MisbehaviorConflictingPrecommits Misbehavior = "conflicting-precommits"
+	// MisbehaviorWithholdBlockParts makes the validator stop gossiping block
+	// parts, including those of its own proposal.
+	MisbehaviorWithholdBlockParts Misbehavior = "withhold-block-parts"
+	// MisbehaviorEquivocate makes the validator, when it is the proposer,
+	// send a conflicting proposal to half of its peers.
+	MisbehaviorEquivocate Misbehavior = "equivocate"
+)
+
+// ValidateBasic returns an error if m is not a known misbehavior.
+func (m Misbehavior) ValidateBasic() error {
+	switch m {
+	case MisbehaviorDoubleSign, MisbehaviorConflictingPrecommits,
+		MisbehaviorWithholdBlockParts, MisbehaviorEquivocate:
+		return nil
+	default:
+		return fmt.Errorf("unknown misbehavior %q", m)
+	}
+}
@@ -75,6 +75,51 @@ func MsgToWrappedProto(msg Message) (cmtcons.Message, error) {
 			Vote: vote,
 		}}
 
+	case *VotesBatchMessage:
+		votes := make([]*cmtproto.Vote, len(msg.Votes))
+		for i, vote := range msg.Votes {
+			votes[i] = vote.ToProto()
+		}
+		pb.Sum = &cmtcons.Message_VotesBatch{VotesBatch: &cmtcons.VotesBatch{
+			Votes: votes,
+		}}
+
+	case *CompactBlockMessage:
+		txKeys := make([][]byte, len(msg.TxKeys))
+		for i := range msg.TxKeys {
+			txKeys[i] = msg.TxKeys[i][:]
+		}
+		pb.Sum = &cmtcons.Message_CompactBlock{CompactBlock: &cmtcons.CompactBlock{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Block:  msg.Block,
+			TxKeys: txKeys,
+		}}
+
+	case *CompactBlockTxsRequestMessage:
+		missing := msg.Missing.ToProto()
+		cbr := &cmtcons.CompactBlockTxsRequest{
+			Height: msg.Height,
+			Round:  msg.Round,
+		}
+		if missing != nil {
+			cbr.Missing = *missing
+		}
+		pb.Sum = &cmtcons.Message_CompactBlockTxsRequest{CompactBlockTxsRequest: cbr}
+
+	case *CompactBlockTxsMessage:
+		pb.Sum = &cmtcons.Message_CompactBlockTxs{CompactBlockTxs: &cmtcons.CompactBlockTxs{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Txs:    msg.Txs.ToSliceOfBytes(),
+		}}
+
+	case *CompactBlockFallbackMessage:
+		pb.Sum = &cmtcons.Message_CompactBlockFallback{CompactBlockFallback: &cmtcons.CompactBlockFallback{
+			Height: msg.Height,
+			Round:  msg.Round,
+		}}
+
 	case *HasVoteMessage:
 		pb.Sum = &cmtcons.Message_HasVote{HasVote: &cmtcons.HasVote{
 			Height: msg.Height,
@@ -198,6 +243,57 @@ func MsgFromProto(p proto.Message) (Message, error) {
 		pb = &VoteMessage{
 			Vote: vote,
 		}
+	case *cmtcons.VotesBatch:
+		// Votes validation will be handled in the votes batch message
+		// ValidateBasic call below.
+		votes := make([]*types.Vote, len(msg.Votes))
+		for i, v := range msg.Votes {
+			vote, err := types.VoteFromProto(v)
+			if err != nil {
+				return nil, cmterrors.ErrMsgToProto{MessageName: "VotesBatch", Err: err}
+			}
+			votes[i] = vote
+		}
+
+		pb = &VotesBatchMessage{
+			Votes: votes,
+		}
+	case *cmtcons.CompactBlock:
+		txKeys := make([]types.TxKey, len(msg.TxKeys))
+		for i, key := range msg.TxKeys {
+			if len(key) != types.TxKeySize {
+				return nil, cmterrors.ErrMsgFromProto{
+					MessageName: "CompactBlock",
+					Err:         fmt.Errorf("tx key %d has %d bytes, expected %d", i, len(key), types.TxKeySize),
+				}
+			}
+			copy(txKeys[i][:], key)
+		}
+		pb = &CompactBlockMessage{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Block:  msg.Block,
+			TxKeys: txKeys,
+		}
+	case *cmtcons.CompactBlockTxsRequest:
+		missing := new(bits.BitArray)
+		missing.FromProto(&msg.Missing)
+		pb = &CompactBlockTxsRequestMessage{
+			Height:  msg.Height,
+			Round:   msg.Round,
+			Missing: missing,
+		}
+	case *cmtcons.CompactBlockTxs:
+		pb = &CompactBlockTxsMessage{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Txs:    types.ToTxs(msg.Txs),
+		}
+	case *cmtcons.CompactBlockFallback:
+		pb = &CompactBlockFallbackMessage{
+			Height: msg.Height,
+			Round:  msg.Round,
+		}
 	case *cmtcons.HasVote:
 		pb = &HasVoteMessage{
 			Height: msg.Height,
@@ -17,6 +17,7 @@ import (
 	cmtrand "github.com/cometbft/cometbft/internal/rand"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
 )
 
 func TestMsgToProto(t *testing.T) {
@@ -32,6 +33,9 @@ func TestMsgToProto(t *testing.T) {
 	pbBi := bi.ToProto()
 	bits := bits.NewBitArray(1)
 	pbBits := bits.ToProto()
+	missing := bits.Copy()
+	missing.SetIndex(0, true)
+	pbMissing := missing.ToProto()
 
 	parts := types.Part{
 		Index: 1,
@@ -57,6 +61,8 @@ func TestMsgToProto(t *testing.T) {
 	}
 	pbProposal := proposal.ToProto()
 
+	txKey := types.Tx("tx").Key()
+
 	vote := types.MakeVoteNoError(
 		t,
 		types.NewMockPV(),
@@ -154,6 +160,67 @@ func TestMsgToProto(t *testing.T) {
 
 			false,
 		},
+		{
+			"successful VotesBatchMessage", &VotesBatchMessage{
+				Votes: []*types.Vote{vote},
+			}, &cmtcons.VotesBatch{
+				Votes: []*cmtproto.Vote{pbVote},
+			},
+
+			false,
+		},
+		{
+			"successful CompactBlockMessage", &CompactBlockMessage{
+				Height: 1,
+				Round:  1,
+				Block:  &cmtproto.Block{Header: cmtproto.Header{Height: 1}},
+				TxKeys: []types.TxKey{types.Tx("tx").Key()},
+			}, &cmtcons.CompactBlock{
+				Height: 1,
+				Round:  1,
+				Block:  &cmtproto.Block{Header: cmtproto.Header{Height: 1}},
+				TxKeys: [][]byte{txKey[:]},
+			},
+
+			false,
+		},
+		{
+			"successful CompactBlockTxsRequestMessage", &CompactBlockTxsRequestMessage{
+				Height:  1,
+				Round:   1,
+				Missing: missing,
+			}, &cmtcons.CompactBlockTxsRequest{
+				Height:  1,
+				Round:   1,
+				Missing: *pbMissing,
+			},
+
+			false,
+		},
+		{
+			"successful CompactBlockTxsMessage", &CompactBlockTxsMessage{
+				Height: 1,
+				Round:  1,
+				Txs:    types.Txs{types.Tx("tx")},
+			}, &cmtcons.CompactBlockTxs{
+				Height: 1,
+				Round:  1,
+				Txs:    [][]byte{[]byte("tx")},
+			},
+
+			false,
+		},
+		{
+			"successful CompactBlockFallbackMessage", &CompactBlockFallbackMessage{
+				Height: 1,
+				Round:  1,
+			}, &cmtcons.CompactBlockFallback{
+				Height: 1,
+				Round:  1,
+			},
+
+			false,
+		},
 		{
 			"successful VoteSetMaj23", &VoteSetMaj23Message{
 				Height:  1,
@@ -427,6 +494,18 @@ func TestConsMsgsVectors(t *testing.T) {
 			}},
 			"327b0a790802100122480a206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d1224080112206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d2a0608c0b89fdc0532146164645f6d6f72655f6578636c616d6174696f6e38014a09657874656e73696f6e",
 		},
+		{
+			"VotesBatch", &cmtcons.Message{Sum: &cmtcons.Message_VotesBatch{
+				VotesBatch: &cmtcons.VotesBatch{Votes: []*cmtproto.Vote{vpb}},
+			}},
+			"5a700a6e0802100122480a206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d1224080112206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d2a0608c0b89fdc0532146164645f6d6f72655f6578636c616d6174696f6e3801",
+		},
+		{
+			"CompactBlockFallback", &cmtcons.Message{Sum: &cmtcons.Message_CompactBlockFallback{
+				CompactBlockFallback: &cmtcons.CompactBlockFallback{Height: 1, Round: 1},
+			}},
+			"7a0408011001",
+		},
 		{
 			"HasVote", &cmtcons.Message{Sum: &cmtcons.Message_HasVote{
 				HasVote: &cmtcons.HasVote{Height: 1, Round: 1, Type: types.PrevoteType, Index: 1},
@@ -466,3 +545,39 @@ func TestConsMsgsVectors(t *testing.T) {
 		})
 	}
 }
+
+// BenchmarkVoteMessageRoundTrip encodes and decodes a vote message as the
+// reactor does, with the proto messages of its pools.
+func BenchmarkVoteMessageRoundTrip(b *testing.B) {
+	vote := &types.Vote{
+		ValidatorAddress: cmtrand.Bytes(20),
+		ValidatorIndex:   1,
+		Height:           1,
+		Round:            0,
+		Timestamp:        cmttime.Now(),
+		Type:             types.PrecommitType,
+		BlockID:          types.BlockID{Hash: cmtrand.Bytes(32), PartSetHeader: types.PartSetHeader{Total: 1, Hash: cmtrand.Bytes(32)}},
+		Signature:        cmtrand.Bytes(64),
+	}
+	bz := make([]byte, 0, 1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		msg := voteMsgPool.Get().(*cmtcons.Vote)
+		msg.Vote = vote.ToProto()
+		var err error
+		bz, err = proto.Marshal(msg.Wrap())
+		require.NoError(b, err)
+		msg.Vote = nil
+		voteMsgPool.Put(msg)
+
+		pb := messagePool.Get().(*cmtcons.Message)
+		require.NoError(b, proto.Unmarshal(bz, pb))
+		unwrapped, err := pb.Unwrap()
+		require.NoError(b, err)
+		_, err = MsgFromProto(unwrapped)
+		require.NoError(b, err)
+		putMessage(pb)
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"time"
 
 	cmtcons "github.com/cometbft/cometbft/api/cometbft/consensus/v1"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/internal/bits"
 	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
 	cmtevents "github.com/cometbft/cometbft/internal/events"
 	cmtrand "github.com/cometbft/cometbft/internal/rand"
+	"github.com/cometbft/cometbft/internal/sigverify"
 	sm "github.com/cometbft/cometbft/internal/state"
 	cmtsync "github.com/cometbft/cometbft/internal/sync"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
@@ -20,6 +23,7 @@ import (
 	"github.com/cometbft/cometbft/types"
 	cmterrors "github.com/cometbft/cometbft/types/errors"
 	cmttime "github.com/cometbft/cometbft/types/time"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 const (
@@ -27,13 +31,38 @@ const (
 	DataChannel        = byte(0x21)
 	VoteChannel        = byte(0x22)
 	VoteSetBitsChannel = byte(0x23)
+	// VoteBatchChannel carries VotesBatch messages. Peers which do not
+	// advertise it in their NodeInfo are sent the votes one by one on
+	// VoteChannel.
+	VoteBatchChannel = byte(0x24)
+	// CompactBlockChannel carries the messages of the compact block relay
+	// other than the compact blocks themselves, see compact_block.go. Peers
+	// which do not advertise it in their NodeInfo are sent the block parts.
+	CompactBlockChannel = byte(0x25)
 
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
+	// maxVotesBatchSize is the maximum number of votes in a VotesBatch.
+	maxVotesBatchSize = 200
+
 	blocksToContributeToBecomeGoodPeer = 10000
 	votesToContributeToBecomeGoodPeer  = 10000
 )
 
+// PeerQuality rates the contribution of a peer to consensus.
+type PeerQuality string
+
+const (
+	// PeerQualityGood is the quality of the peers which sent enough useful
+	// votes or block parts to be marked as good in the address book.
+	PeerQualityGood PeerQuality = "good"
+	// PeerQualityActive is the quality of the peers which sent useful votes or
+	// block parts.
+	PeerQualityActive PeerQuality = "active"
+	// PeerQualityIdle is the quality of the peers which sent none.
+	PeerQualityIdle PeerQuality = "idle"
+)
+
 //-----------------------------------------------------------------------------
 
 // Reactor defines a reactor for the consensus service.
@@ -48,6 +77,15 @@ type Reactor struct {
 	rsMtx cmtsync.Mutex
 	rs    *cstypes.RoundState
 
+	byzantineReactor // misbehaviors, only built with the byzantine tag
+
+	// mempool is used to reconstruct the compact blocks, if set.
+	mempool txFetcher
+
+	// verifies the signatures of the votes for the current height before they
+	// are queued to the consensus state, if set.
+	sigVerifyPool *sigverify.Pool
+
 	Metrics *Metrics
 }
 
@@ -184,6 +222,22 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvMessageCapacity: maxMsgSize,
 			MessageType:         &cmtcons.Message{},
 		},
+		{
+			ID:                  VoteBatchChannel,
+			Priority:            7,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  100 * 100,
+			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &cmtcons.Message{},
+		},
+		{
+			ID:                  CompactBlockChannel,
+			Priority:            10,
+			SendQueueCapacity:   10,
+			RecvBufferCapacity:  50 * 4096,
+			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &cmtcons.Message{},
+		},
 	}
 }
 
@@ -230,6 +284,127 @@ func (conR *Reactor) RemovePeer(p2p.Peer, interface{}) {
 	// ps.Disconnect()
 }
 
+// ReceiveBuffer implements p2p.BufferedReactor. The block parts the consensus
+// state already has, i.e. most of those gossiped by the peers, are dropped
+// without being unmarshaled, saving copying their bytes, and only recorded as
+// had by the peer. The other messages are handed over to Receive.
+func (conR *Reactor) ReceiveBuffer(e p2p.BufferEnvelope) {
+	if e.ChannelID == DataChannel && conR.IsRunning() && !conR.WaitSync() {
+		height, round, index, ok := blockPartFromBytes(e.Buffer.Bytes())
+		if ok && conR.conS.hasProposalBlockPart(height, index) {
+			e.Buffer.Release()
+			ps, ok := e.Src.Get(types.PeerStateKey).(*PeerState)
+			if !ok {
+				panic(fmt.Sprintf("Peer %v has no state", e.Src))
+			}
+			ps.SetHasProposalBlockPart(height, round, int(index))
+			conR.Metrics.BlockParts.With("peer_id", string(e.Src.ID())).Add(1)
+			conR.conS.metrics.BlockGossipPartsReceived.With("matches_current", "true").Add(1)
+			conR.conS.metrics.DuplicateBlockPart.Add(1)
+			return
+		}
+	}
+
+	pb := messagePool.Get().(*cmtcons.Message)
+	defer putMessage(pb)
+	msg, err := e.Unmarshal(pb)
+	if err != nil {
+		conR.Logger.Error("Error decoding message", "src", e.Src, "chId", e.ChannelID, "err", err)
+		conR.Switch.StopPeerForError(e.Src, err)
+		return
+	}
+	conR.Receive(p2p.Envelope{Src: e.Src, Message: msg, ChannelID: e.ChannelID})
+}
+
+// messagePool holds the Messages the received messages are unmarshaled into.
+// Receive converts them, so that they can be reused once it returns.
+var messagePool = sync.Pool{
+	New: func() any { return new(cmtcons.Message) },
+}
+
+func putMessage(pb *cmtcons.Message) {
+	// Don't pin the fields of the message, which the converted one may use.
+	pb.Reset()
+	messagePool.Put(pb)
+}
+
+// voteMsgPool holds the Vote messages the votes are sent in. The peers
+// marshal them before Send returns, so that they can be reused afterwards.
+var voteMsgPool = sync.Pool{
+	New: func() any { return new(cmtcons.Vote) },
+}
+
+// blockPartFromBytes returns the height, round and part index of a Message of
+// type BlockPart, given its bytes, without unmarshaling the part. ok is false
+// if the message is of another type, or is invalid, for it to be unmarshaled.
+func blockPartFromBytes(bz []byte) (height int64, round int32, index uint32, ok bool) {
+	num, typ, tagLen := protowire.ConsumeTag(bz)
+	if tagLen < 0 || num != 5 || typ != protowire.BytesType {
+		return 0, 0, 0, false
+	}
+	msg, n := protowire.ConsumeBytes(bz[tagLen:])
+	if n < 0 || tagLen+n != len(bz) {
+		// several fields are left to the unmarshaling
+		return 0, 0, 0, false
+	}
+
+	var part []byte
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return 0, 0, 0, false
+		}
+		msg = msg[n:]
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(msg)
+			if n < 0 {
+				return 0, 0, 0, false
+			}
+			height, msg = int64(v), msg[n:]
+		case num == 2 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(msg)
+			if n < 0 {
+				return 0, 0, 0, false
+			}
+			round, msg = int32(v), msg[n:]
+		case num == 3 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(msg)
+			if n < 0 {
+				return 0, 0, 0, false
+			}
+			part, msg = v, msg[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, msg)
+			if n < 0 {
+				return 0, 0, 0, false
+			}
+			msg = msg[n:]
+		}
+	}
+	for len(part) > 0 {
+		num, typ, n := protowire.ConsumeTag(part)
+		if n < 0 {
+			return 0, 0, 0, false
+		}
+		part = part[n:]
+		if num == 1 && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(part)
+			if n < 0 {
+				return 0, 0, 0, false
+			}
+			index, part = uint32(v), part[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, part)
+		if n < 0 {
+			return 0, 0, 0, false
+		}
+		part = part[n:]
+	}
+	return height, round, index, height >= 0 && round >= 0
+}
+
 // Receive implements Reactor
 // NOTE: We process these messages even when we're block_syncing.
 // Messages affect either a peer state or the consensus state.
@@ -338,6 +513,8 @@ func (conR *Reactor) Receive(e p2p.Envelope) {
 			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
 			conR.Metrics.BlockParts.With("peer_id", string(e.Src.ID())).Add(1)
 			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+		case *CompactBlockMessage:
+			conR.handleCompactBlock(ps, msg)
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
@@ -357,6 +534,7 @@ func (conR *Reactor) Receive(e p2p.Envelope) {
 			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
 			ps.SetHasVote(msg.Vote)
 
+			conR.preverifyVotes(msg)
 			cs.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
 
 		default:
@@ -364,6 +542,54 @@ func (conR *Reactor) Receive(e p2p.Envelope) {
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
 
+	case VoteBatchChannel:
+		if conR.WaitSync() {
+			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
+			return
+		}
+		switch msg := msg.(type) {
+		case *VotesBatchMessage:
+			cs := conR.conS
+			cs.mtx.RLock()
+			height, valSize, lastCommitSize := cs.Height, cs.Validators.Size(), cs.LastCommit.Size()
+			cs.mtx.RUnlock()
+			ps.EnsureVoteBitArrays(height, valSize)
+			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
+
+			// The votes of the batch are processed one by one, as if they
+			// were received in separate messages, once verified together.
+			voteMsgs := make([]*VoteMessage, len(msg.Votes))
+			for i, vote := range msg.Votes {
+				ps.SetHasVote(vote)
+				voteMsgs[i] = &VoteMessage{Vote: vote}
+			}
+			conR.preverifyVotes(voteMsgs...)
+			for _, voteMsg := range voteMsgs {
+				cs.peerMsgQueue <- msgInfo{voteMsg, e.Src.ID()}
+			}
+
+		default:
+			// don't punish (leave room for soft upgrades)
+			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+		}
+
+	case CompactBlockChannel:
+		if conR.WaitSync() {
+			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
+			return
+		}
+		switch msg := msg.(type) {
+		case *CompactBlockTxsRequestMessage:
+			conR.handleCompactBlockTxsRequest(ps, msg)
+		case *CompactBlockTxsMessage:
+			conR.handleCompactBlockTxs(ps, msg)
+		case *CompactBlockFallbackMessage:
+			ps.setCompactBlockFallback(msg.Height, msg.Round)
+		default:
+			// don't punish (leave room for soft upgrades)
+			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+		}
+
 	case VoteSetBitsChannel:
 		if conR.WaitSync() {
 			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
@@ -435,6 +661,7 @@ func (conR *Reactor) subscribeToBroadcastEvents() {
 	if err := conR.conS.evsw.AddListenerForEvent(subscriber, types.EventVote,
 		func(data cmtevents.EventData) {
 			conR.broadcastHasVoteMessage(data.(*types.Vote))
+			conR.broadcastConflictingVote(data.(*types.Vote))
 		}); err != nil {
 		conR.Logger.Error("Error adding listener for events (Vote)", "err", err)
 	}
@@ -584,11 +811,19 @@ OUTER_LOOP:
 
 		rs := conR.getRoundState()
 		prs := ps.GetRoundState()
+		proposal, proposalBlockParts := conR.proposalFor(rs, peer)
+
+		// Send the proposal block as a compact block, if the peer supports it.
+		if conR.gossipCompactBlock(logger, rs, prs, ps, proposalBlockParts) {
+			continue OUTER_LOOP
+		}
 
 		// Send proposal Block parts?
-		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
-			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
-				part := rs.ProposalBlockParts.GetPart(index)
+		if proposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) &&
+			!conR.withholdsBlockParts(rs.Height) &&
+			!ps.withholdsBlockParts(prs.Height, prs.Round) {
+			if index, ok := proposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
+				part := proposalBlockParts.GetPart(index)
 				parts, err := part.ToProto()
 				if err != nil {
 					panic(err)
@@ -644,16 +879,16 @@ OUTER_LOOP:
 		// Now consider sending other things, like the Proposal itself.
 
 		// Send Proposal && ProposalPOL BitArray?
-		if rs.Proposal != nil && !prs.Proposal {
+		if proposal != nil && !prs.Proposal {
 			// Proposal: share the proposal metadata with peer.
 			{
 				logger.Debug("Sending proposal", "height", prs.Height, "round", prs.Round)
 				if peer.Send(p2p.Envelope{
 					ChannelID: DataChannel,
-					Message:   &cmtcons.Proposal{Proposal: *rs.Proposal.ToProto()},
+					Message:   &cmtcons.Proposal{Proposal: *proposal.ToProto()},
 				}) {
 					// NOTE[ZM]: A peer might have received different proposal msg so this Proposal msg will be rejected!
-					ps.SetHasProposal(rs.Proposal)
+					ps.SetHasProposal(proposal)
 				}
 			}
 			// ProposalPOL: lets peer know which POL votes we have so far.
@@ -1055,6 +1290,28 @@ func ReactorMetrics(metrics *Metrics) ReactorOption {
 	return func(conR *Reactor) { conR.Metrics = metrics }
 }
 
+// ReactorSigVerifyPool sets the pool verifying the signatures of the received
+// votes, in parallel, before the consensus state adds them one at a time.
+func ReactorSigVerifyPool(pool *sigverify.Pool) ReactorOption {
+	return func(conR *Reactor) { conR.sigVerifyPool = pool }
+}
+
+// preverifyVotes verifies the signatures of the votes for the current height
+// on the signature verification pool, if set, so that the consensus state
+// doesn't verify them again. The other votes are left to the consensus state.
+func (conR *Reactor) preverifyVotes(msgs ...*VoteMessage) {
+	if conR.sigVerifyPool == nil {
+		return
+	}
+	fns := make([]func(), 0, len(msgs))
+	for _, msg := range msgs {
+		if fn := conR.conS.voteVerification(msg); fn != nil {
+			fns = append(fns, fn)
+		}
+	}
+	conR.sigVerifyPool.Run(fns...)
+}
+
 //-----------------------------------------------------------------------------
 
 // PeerState contains the known state of a peer, including its connection and
@@ -1068,6 +1325,8 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	compactBlock compactBlockState
 }
 
 // peerStateStats holds internal statistics for a peer.
@@ -1192,17 +1451,24 @@ func (ps *PeerState) setHasProposalBlockPart(height int64, round int32, index in
 	ps.PRS.ProposalBlockParts.SetIndex(index, true)
 }
 
-// PickSendVote picks a vote and sends it to the peer.
+// PickSendVote picks a vote and sends it to the peer. If the peer supports
+// VoteBatchChannel, it picks and sends a batch of votes instead.
 // Returns true if vote was sent.
 func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
+	if ps.supportsVotesBatch() {
+		return ps.pickSendVotesBatch(votes)
+	}
 	if vote, ok := ps.PickVoteToSend(votes); ok {
 		ps.logger.Debug("Sending vote message", "ps", ps, "vote", vote)
-		if ps.peer.Send(p2p.Envelope{
+		msg := voteMsgPool.Get().(*cmtcons.Vote)
+		msg.Vote = vote.ToProto()
+		sent := ps.peer.Send(p2p.Envelope{
 			ChannelID: VoteChannel,
-			Message: &cmtcons.Vote{
-				Vote: vote.ToProto(),
-			},
-		}) {
+			Message:   msg,
+		})
+		msg.Vote = nil
+		voteMsgPool.Put(msg)
+		if sent {
 			ps.SetHasVote(vote)
 			return true
 		}
@@ -1211,6 +1477,38 @@ func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
 	return false
 }
 
+// supportsVotesBatch returns true if the peer advertises VoteBatchChannel.
+func (ps *PeerState) supportsVotesBatch() bool {
+	ni, ok := ps.peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && ni.HasChannel(VoteBatchChannel)
+}
+
+// pickSendVotesBatch picks a batch of votes and sends it to the peer on
+// VoteBatchChannel.
+// Returns true if the batch was sent.
+func (ps *PeerState) pickSendVotesBatch(votes types.VoteSetReader) bool {
+	batch := ps.PickVotesToSend(votes)
+	if len(batch) == 0 {
+		return false
+	}
+
+	ps.logger.Debug("Sending votes batch message", "ps", ps, "votes", len(batch))
+	msg := &cmtcons.VotesBatch{Votes: make([]*cmtproto.Vote, len(batch))}
+	for i, vote := range batch {
+		msg.Votes[i] = vote.ToProto()
+	}
+	if !ps.peer.Send(p2p.Envelope{
+		ChannelID: VoteBatchChannel,
+		Message:   msg,
+	}) {
+		return false
+	}
+	for _, vote := range batch {
+		ps.SetHasVote(vote)
+	}
+	return true
+}
+
 // PickVoteToSend picks a vote to send to the peer.
 // Returns true if a vote was picked.
 // NOTE: `votes` must be the correct Size() for the Height().
@@ -1218,9 +1516,59 @@ func (ps *PeerState) PickVoteToSend(votes types.VoteSetReader) (vote *types.Vote
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
 
-	if votes.Size() == 0 {
+	missing := ps.missingVotes(votes)
+	if missing == nil {
 		return nil, false
 	}
+	if index, ok := missing.PickRandom(); ok {
+		return votes.GetByIndex(int32(index)), true
+	}
+	return nil, false
+}
+
+// PickVotesToSend picks the votes the peer is missing, up to
+// maxVotesBatchSize votes and maxMsgSize bytes once batched.
+// NOTE: `votes` must be the correct Size() for the Height().
+func (ps *PeerState) PickVotesToSend(votes types.VoteSetReader) []*types.Vote {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	missing := ps.missingVotes(votes)
+	if missing == nil {
+		return nil
+	}
+
+	// Leave room for the tag and length prefix of each vote, and for the
+	// wrapping of the batch.
+	const voteOverhead = 16
+	var (
+		batch     []*types.Vote
+		batchSize = voteOverhead
+	)
+	for index := 0; index < missing.Size() && len(batch) < maxVotesBatchSize; index++ {
+		if !missing.GetIndex(index) {
+			continue
+		}
+		vote := votes.GetByIndex(int32(index))
+		if vote == nil {
+			continue
+		}
+		voteSize := vote.ToProto().Size() + voteOverhead
+		if batchSize+voteSize > maxMsgSize {
+			break
+		}
+		batch = append(batch, vote)
+		batchSize += voteSize
+	}
+	return batch
+}
+
+// missingVotes returns the bit array of the votes the peer is missing, or nil
+// if `votes` is not worth sending to the peer.
+func (ps *PeerState) missingVotes(votes types.VoteSetReader) *bits.BitArray {
+	if votes.Size() == 0 {
+		return nil
+	}
 
 	height, round, votesType, size := votes.GetHeight(), votes.GetRound(), types.SignedMsgType(votes.Type()), votes.Size()
 
@@ -1232,12 +1580,9 @@ func (ps *PeerState) PickVoteToSend(votes types.VoteSetReader) (vote *types.Vote
 
 	psVotes := ps.getVoteBitArray(height, round, votesType)
 	if psVotes == nil {
-		return nil, false // Not something worth sending
-	}
-	if index, ok := votes.BitArray().Sub(psVotes).PickRandom(); ok {
-		return votes.GetByIndex(int32(index)), true
+		return nil // Not something worth sending
 	}
-	return nil, false
+	return votes.BitArray().Sub(psVotes)
 }
 
 func (ps *PeerState) getVoteBitArray(height int64, round int32, votesType types.SignedMsgType) *bits.BitArray {
@@ -1384,6 +1729,22 @@ func (ps *PeerState) BlockPartsSent() int {
 	return ps.Stats.BlockParts
 }
 
+// Quality rates the contribution of the peer to consensus so far.
+func (ps *PeerState) Quality() PeerQuality {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	switch {
+	case ps.Stats.Votes >= votesToContributeToBecomeGoodPeer ||
+		ps.Stats.BlockParts >= blocksToContributeToBecomeGoodPeer:
+		return PeerQualityGood
+	case ps.Stats.Votes > 0 || ps.Stats.BlockParts > 0:
+		return PeerQualityActive
+	default:
+		return PeerQualityIdle
+	}
+}
+
 // SetHasVote sets the given vote as known by the peer.
 func (ps *PeerState) SetHasVote(vote *types.Vote) {
 	ps.mtx.Lock()
@@ -1579,6 +1940,11 @@ func init() {
 	cmtjson.RegisterType(&HasProposalBlockPartMessage{}, "tendermint/HasProposalBlockPart")
 	cmtjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
 	cmtjson.RegisterType(&VoteSetBitsMessage{}, "tendermint/VoteSetBits")
+	cmtjson.RegisterType(&VotesBatchMessage{}, "tendermint/VotesBatch")
+	cmtjson.RegisterType(&CompactBlockMessage{}, "tendermint/CompactBlock")
+	cmtjson.RegisterType(&CompactBlockTxsRequestMessage{}, "tendermint/CompactBlockTxsRequest")
+	cmtjson.RegisterType(&CompactBlockTxsMessage{}, "tendermint/CompactBlockTxs")
+	cmtjson.RegisterType(&CompactBlockFallbackMessage{}, "tendermint/CompactBlockFallback")
 }
 
 //-------------------------------------
@@ -1773,6 +2139,25 @@ func (m *BlockPartMessage) String() string {
 // VoteMessage is sent when voting for a proposal (or lack thereof).
 type VoteMessage struct {
 	Vote *types.Vote
+
+	// the key the signature of the vote was verified with by the reactor, if
+	// any, and whether the signature of its extension was verified too.
+	verifiedBy  crypto.PubKey
+	extVerified bool
+}
+
+// verify verifies the signatures of the vote with pubKey, and those of its
+// extension if extEnabled, recording the key on success.
+func (m *VoteMessage) verify(chainID string, pubKey crypto.PubKey, extEnabled bool) {
+	var err error
+	if extEnabled {
+		err = m.Vote.VerifyVoteAndExtension(chainID, pubKey)
+	} else {
+		err = m.Vote.Verify(chainID, pubKey)
+	}
+	if err == nil {
+		m.verifiedBy, m.extVerified = pubKey, extEnabled
+	}
 }
 
 // ValidateBasic checks whether the vote within the message is well-formed.
@@ -1787,6 +2172,156 @@ func (m *VoteMessage) String() string {
 
 //-------------------------------------
 
+// VotesBatchMessage is sent to gossip several votes at once, on
+// VoteBatchChannel.
+type VotesBatchMessage struct {
+	Votes []*types.Vote
+}
+
+// ValidateBasic checks whether every vote within the message is well-formed.
+func (m *VotesBatchMessage) ValidateBasic() error {
+	if len(m.Votes) == 0 {
+		return cmterrors.ErrRequiredField{Field: "Votes"}
+	}
+	if len(m.Votes) > maxVotesBatchSize {
+		return cmterrors.ErrInvalidField{
+			Field:  "Votes",
+			Reason: fmt.Sprintf("has %d votes, more than the maximum of %d", len(m.Votes), maxVotesBatchSize),
+		}
+	}
+	for i, vote := range m.Votes {
+		if err := vote.ValidateBasic(); err != nil {
+			return cmterrors.ErrWrongField{Field: fmt.Sprintf("Votes[%d]", i), Err: err}
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *VotesBatchMessage) String() string {
+	return fmt.Sprintf("[VotesBatch %v]", m.Votes)
+}
+
+//-------------------------------------
+
+// CompactBlockMessage is sent to gossip a proposal block with its transactions
+// replaced by their keys, on DataChannel. The block is kept in its
+// proto form, as it is only valid once its transactions are restored.
+type CompactBlockMessage struct {
+	Height int64
+	Round  int32
+	Block  *cmtproto.Block
+	TxKeys []types.TxKey
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return cmterrors.ErrNegativeField{Field: "Height"}
+	}
+	if m.Round < 0 {
+		return cmterrors.ErrNegativeField{Field: "Round"}
+	}
+	if m.Block == nil {
+		return cmterrors.ErrRequiredField{Field: "Block"}
+	}
+	if len(m.Block.Data.Txs) > 0 {
+		return cmterrors.ErrInvalidField{Field: "Block", Reason: "has transactions"}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockMessage) String() string {
+	return fmt.Sprintf("[CompactBlock H:%v R:%v Txs:%v]", m.Height, m.Round, len(m.TxKeys))
+}
+
+//-------------------------------------
+
+// CompactBlockTxsRequestMessage is sent to request the transactions of a
+// compact block which are missing from the mempool.
+type CompactBlockTxsRequestMessage struct {
+	Height  int64
+	Round   int32
+	Missing *bits.BitArray
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsRequestMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return cmterrors.ErrNegativeField{Field: "Height"}
+	}
+	if m.Round < 0 {
+		return cmterrors.ErrNegativeField{Field: "Round"}
+	}
+	if m.Missing.IsEmpty() {
+		return cmterrors.ErrRequiredField{Field: "Missing"}
+	}
+	if len(m.Missing.Elems) != (m.Missing.Size()+63)/64 {
+		return cmterrors.ErrInvalidField{Field: "Missing", Reason: "does not match its size"}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsRequestMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxsRequest H:%v R:%v M:%v]", m.Height, m.Round, m.Missing)
+}
+
+//-------------------------------------
+
+// CompactBlockTxsMessage is sent in response to a
+// CompactBlockTxsRequestMessage, with the requested transactions in the order
+// of their indexes.
+type CompactBlockTxsMessage struct {
+	Height int64
+	Round  int32
+	Txs    types.Txs
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return cmterrors.ErrNegativeField{Field: "Height"}
+	}
+	if m.Round < 0 {
+		return cmterrors.ErrNegativeField{Field: "Round"}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxs H:%v R:%v Txs:%v]", m.Height, m.Round, len(m.Txs))
+}
+
+//-------------------------------------
+
+// CompactBlockFallbackMessage is sent when a compact block could not be
+// reconstructed, to request the block parts instead.
+type CompactBlockFallbackMessage struct {
+	Height int64
+	Round  int32
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockFallbackMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return cmterrors.ErrNegativeField{Field: "Height"}
+	}
+	if m.Round < 0 {
+		return cmterrors.ErrNegativeField{Field: "Round"}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockFallbackMessage) String() string {
+	return fmt.Sprintf("[CompactBlockFallback H:%v R:%v]", m.Height, m.Round)
+}
+
+//-------------------------------------
+
 // HasVoteMessage is sent to indicate that a particular vote has been received.
 type HasVoteMessage struct {
 	Height int64
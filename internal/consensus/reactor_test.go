@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cosmos/gogoproto/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -24,10 +25,12 @@ import (
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/internal/bits"
 	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
+	"github.com/cometbft/cometbft/internal/sigverify"
 	sm "github.com/cometbft/cometbft/internal/state"
 	statemocks "github.com/cometbft/cometbft/internal/state/mocks"
 	"github.com/cometbft/cometbft/internal/store"
 	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/internal/test"
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
@@ -52,10 +55,17 @@ func startConsensusNet(t *testing.T, css []*State, n int) (
 	reactors := make([]*Reactor, n)
 	blocksSubs := make([]types.Subscription, 0)
 	eventBuses := make([]*types.EventBus, n)
+	// half of the reactors verify the votes on a shared pool
+	sigVerifyPool := sigverify.NewPool(2)
+	t.Cleanup(sigVerifyPool.Stop)
 	for i := 0; i < n; i++ {
 		/*logger, err := cmtflags.ParseLogLevel("consensus:info,*:error", logger, "info")
 		if err != nil {	t.Fatal(err)}*/
-		reactors[i] = NewReactor(css[i], true) // so we dont start the consensus states
+		var options []ReactorOption
+		if i%2 == 0 {
+			options = append(options, ReactorSigVerifyPool(sigVerifyPool))
+		}
+		reactors[i] = NewReactor(css[i], true, options...) // so we dont start the consensus states
 		reactors[i].SetLogger(css[i].Logger)
 
 		// eventBus is already started with the cs
@@ -972,6 +982,35 @@ func TestBlockPartMessageValidateBasic(t *testing.T) {
 	assert.Equal(t, true, message.ValidateBasic() != nil, "Validate Basic had an unexpected result")
 }
 
+func TestBlockPartFromBytes(t *testing.T) {
+	part := &types.Part{Index: 7, Bytes: []byte("part")}
+	part.Proof.LeafHash = tmhash.Sum([]byte("leaf"))
+	msg, err := MsgToWrappedProto(&BlockPartMessage{Height: 10, Round: 2, Part: part})
+	require.NoError(t, err)
+	bz, err := proto.Marshal(&msg)
+	require.NoError(t, err)
+
+	height, round, index, ok := blockPartFromBytes(bz)
+	require.True(t, ok)
+	assert.EqualValues(t, 10, height)
+	assert.EqualValues(t, 2, round)
+	assert.EqualValues(t, 7, index)
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _, _, _ = blockPartFromBytes(bz)
+	})
+	assert.Zero(t, allocs, "expected the part not to be copied")
+
+	_, _, _, ok = blockPartFromBytes(bz[:len(bz)-1])
+	assert.False(t, ok)
+
+	msg, err = MsgToWrappedProto(&HasVoteMessage{Height: 10, Round: 2, Type: types.PrevoteType})
+	require.NoError(t, err)
+	bz, err = proto.Marshal(&msg)
+	require.NoError(t, err)
+	_, _, _, ok = blockPartFromBytes(bz)
+	assert.False(t, ok)
+}
+
 func TestHasVoteMessageValidateBasic(t *testing.T) {
 	const (
 		validSignedMsgType   types.SignedMsgType = 0x01
@@ -1008,6 +1047,141 @@ func TestHasVoteMessageValidateBasic(t *testing.T) {
 	}
 }
 
+func TestVotesBatchMessageValidateBasic(t *testing.T) {
+	_, vss := randState(4)
+	// the first validator stub is the one of the state, still at height 0
+	incrementHeight(vss[0])
+	votes := signVotes(types.PrevoteType, nil, types.PartSetHeader{}, false, vss...)
+
+	invalidVote := votes[1].Copy()
+	invalidVote.ValidatorIndex = -1
+
+	tooManyVotes := make([]*types.Vote, maxVotesBatchSize+1)
+	for i := range tooManyVotes {
+		tooManyVotes[i] = votes[0]
+	}
+
+	testCases := []struct {
+		testName  string
+		votes     []*types.Vote
+		expectErr bool
+	}{
+		{"Valid Message", votes, false},
+		{"Empty Message", nil, true},
+		{"Invalid Vote", []*types.Vote{votes[0], invalidVote}, true},
+		{"Too Many Votes", tooManyVotes, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			message := VotesBatchMessage{Votes: tc.votes}
+
+			assert.Equal(t, tc.expectErr, message.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
+func TestPeerStatePickVotesToSend(t *testing.T) {
+	cs, vss := randState(4)
+	height, round := cs.Height, cs.Round
+	incrementHeight(vss[0])
+
+	voteSet := types.NewVoteSet(test.DefaultTestChainID, height, round, types.PrevoteType, cs.Validators)
+	votes := signVotes(types.PrevoteType, nil, types.PartSetHeader{}, false, vss...)
+	for _, vote := range votes {
+		added, err := voteSet.AddVote(vote)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	ps := NewPeerState(p2pmock.NewPeer(nil))
+	ps.ApplyNewRoundStepMessage(&NewRoundStepMessage{
+		Height: height,
+		Round:  round,
+		Step:   cstypes.RoundStepPrevote,
+	})
+	ps.EnsureVoteBitArrays(height, len(votes))
+	ps.SetHasVote(votes[1])
+
+	// The batch has all the votes the peer is missing.
+	batch := ps.PickVotesToSend(voteSet)
+	require.Len(t, batch, len(votes)-1)
+	for _, vote := range batch {
+		assert.NotEqual(t, votes[1].ValidatorIndex, vote.ValidatorIndex)
+		ps.SetHasVote(vote)
+	}
+
+	assert.Empty(t, ps.PickVotesToSend(voteSet))
+
+	// The mock peer does not advertise VoteBatchChannel.
+	assert.False(t, ps.supportsVotesBatch())
+}
+
+func TestCompactBlockRoundTrip(t *testing.T) {
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")}
+	block := types.MakeBlock(1, txs, &types.Commit{}, nil)
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+
+	pb, err := makeCompactBlock(1, 0, block)
+	require.NoError(t, err)
+	assert.Empty(t, pb.Block.Data.Txs)
+	msg, err := MsgFromProto(pb)
+	require.NoError(t, err)
+	cb := msg.(*CompactBlockMessage)
+	require.NoError(t, cb.ValidateBasic())
+	require.Len(t, cb.TxKeys, len(txs))
+	for i, tx := range txs {
+		assert.Equal(t, tx.Key(), cb.TxKeys[i])
+	}
+
+	// The block parts are reconstructed from the transactions.
+	cbParts, err := compactBlockParts(cb.Block, txs)
+	require.NoError(t, err)
+	assert.Equal(t, parts.Header(), cbParts.Header())
+
+	cbParts, err = compactBlockParts(cb.Block, txs[:2])
+	require.NoError(t, err)
+	assert.NotEqual(t, parts.Header(), cbParts.Header())
+}
+
+func TestCompactBlockTxsRequestMessageValidateBasic(t *testing.T) {
+	missing := bits.NewBitArray(3)
+	missing.SetIndex(1, true)
+	invalidMissing := bits.NewBitArray(3)
+	invalidMissing.SetIndex(1, true)
+	invalidMissing.Elems = append(invalidMissing.Elems, 0)
+
+	testCases := []struct {
+		testName      string
+		messageHeight int64
+		messageRound  int32
+		missing       *bits.BitArray
+		expectErr     bool
+	}{
+		{"Valid Message", 1, 0, missing, false},
+		{"Negative Height", -1, 0, missing, true},
+		{"Negative Round", 1, -1, missing, true},
+		{"Nothing Missing", 1, 0, bits.NewBitArray(3), true},
+		{"Nil Missing", 1, 0, nil, true},
+		{"Invalid Missing", 1, 0, invalidMissing, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			message := CompactBlockTxsRequestMessage{
+				Height:  tc.messageHeight,
+				Round:   tc.messageRound,
+				Missing: tc.missing,
+			}
+
+			assert.Equal(t, tc.expectErr, message.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 func TestVoteSetMaj23MessageValidateBasic(t *testing.T) {
 	const (
 		validSignedMsgType   types.SignedMsgType = 0x01
@@ -31,6 +31,10 @@ func (txmp emptyMempool) RemoveTxByKey(types.TxKey) error {
 
 func (emptyMempool) ReapMaxBytesMaxGas(int64, int64) types.Txs { return types.Txs{} }
 func (emptyMempool) ReapMaxTxs(int) types.Txs                  { return types.Txs{} }
+func (emptyMempool) GetTxByKey(types.TxKey) (types.Tx, bool)   { return nil, false }
+func (emptyMempool) QueryTxs(mempl.TxQuery) (types.Txs, string, error) {
+	return types.Txs{}, "", nil
+}
 func (emptyMempool) Update(
 	int64,
 	types.Txs,
@@ -0,0 +1,250 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
+	cmtevents "github.com/cometbft/cometbft/internal/events"
+	"github.com/cometbft/cometbft/internal/service"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// ErrTransitionMismatch is returned by ReplayWAL when the state machine does
+// not go through the steps recorded in the WAL.
+var ErrTransitionMismatch = errors.New("state transition mismatch")
+
+// ReplayWAL replays the messages of the consensus WAL at walFile, from the
+// #ENDHEIGHT marker of the last block of state, into a consensus state machine
+// started from state, and checks that the state machine goes through the steps
+// recorded in the WAL. It stops at the first mismatch, which it returns as an
+// ErrTransitionMismatch, or at the first panic of the state machine, which it
+// returns as an error along with the stack.
+//
+// The replay is deterministic and has no side effects: the state machine has
+// no private validator, its own messages and the timeouts it processed being
+// replayed from the WAL, and it runs on in-memory copies of the stores.
+// Instead of an application, the blocks it decides are given the results
+// stored in stateStore, so that the next blocks are valid. Blocks without
+// stored results, e.g. the last one or with discard_abci_responses, are given
+// a result of OK for each transaction and the app hash of the previous block.
+// blockStore is only read, for the commit of the last block of state.
+//
+// fn, if not nil, is called with the entry of each message before it is
+// replayed.
+func ReplayWAL(
+	config *cfg.ConsensusConfig,
+	walFile string,
+	state sm.State,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	fn func(WALEntry),
+	logger log.Logger,
+) error {
+	replayBlockStore, err := newReplayBlockStore(state, blockStore)
+	if err != nil {
+		return err
+	}
+	replayStateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: true})
+	if err := replayStateStore.Bootstrap(state); err != nil {
+		return fmt.Errorf("failed to bootstrap state: %w", err)
+	}
+
+	app := &replayApp{stateStore: stateStore, appHash: state.AppHash}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app), proxy.NopMetrics())
+	proxyApp.SetLogger(logger.With("module", "proxy"))
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(logger.With("module", "events"))
+
+	services := service.NewGroup(logger, 0)
+	if err := services.Start(context.Background(), proxyApp, eventBus); err != nil {
+		return err
+	}
+	defer func() {
+		if err := services.Stop(); err != nil {
+			logger.Error("Error stopping services", "err", err)
+		}
+	}()
+
+	mempool := emptyMempool{}
+	evpool := sm.EmptyEvidencePool{}
+	blockExec := sm.NewBlockExecutor(replayStateStore, logger, proxyApp.Consensus(), mempool, evpool, replayBlockStore)
+	cs := NewState(config, state.Copy(), blockExec, replayBlockStore, mempool, evpool)
+	cs.SetLogger(logger.With("module", "consensus"))
+	cs.SetEventBus(eventBus)
+	cs.SetTimeoutTicker(replayTicker{})
+
+	r := &walReplayer{cs: cs}
+	if err := cs.evsw.AddListenerForEvent("walReplayer", types.EventNewRoundStep, func(data cmtevents.EventData) {
+		r.steps = append(r.steps, data.(*cstypes.RoundState).RoundStateEvent())
+	}); err != nil {
+		return err
+	}
+
+	endHeight := state.LastBlockHeight
+	started := false
+	_, err = walkWAL(walFile, false, func(msg *TimedWALMessage) error {
+		if !started {
+			m, ok := msg.Msg.(EndHeightMessage)
+			started = ok && m.Height == endHeight
+			return nil
+		}
+		entry := NewWALEntry(msg)
+		if fn != nil {
+			fn(entry)
+		}
+		if err := r.replay(msg); err != nil {
+			return fmt.Errorf("replaying %s message of height %d, round %d at %v: %w",
+				entry.Type, entry.Height, entry.Round, entry.Time, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !started {
+		return fmt.Errorf("WAL does not contain #ENDHEIGHT %d", endHeight)
+	}
+	return nil
+}
+
+// walReplayer drives a consensus state machine with the messages of a WAL.
+type walReplayer struct {
+	cs *State
+	// steps the state machine went through, not yet checked against the WAL
+	steps []types.EventDataRoundState
+}
+
+func (r *walReplayer) replay(msg *TimedWALMessage) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("state machine panicked: %v\n%s", p, debug.Stack())
+		}
+	}()
+
+	switch m := msg.Msg.(type) {
+	case types.EventDataRoundState:
+		if len(r.steps) == 0 {
+			// The WAL has the step the state machine starts at, and a node
+			// restarting records again the steps it replays from the WAL.
+			if step, ok := parseRoundStep(m.Step); ok &&
+				CompareHRS(m.Height, m.Round, step, r.cs.Height, r.cs.Round, r.cs.Step) <= 0 {
+				return nil
+			}
+			return fmt.Errorf("%w: the WAL has step %d/%d/%s, the state machine is still at %d/%d/%s",
+				ErrTransitionMismatch, m.Height, m.Round, m.Step, r.cs.Height, r.cs.Round, r.cs.Step)
+		}
+		step := r.steps[0]
+		r.steps = r.steps[1:]
+		if step.Height != m.Height || step.Round != m.Round || step.Step != m.Step {
+			return fmt.Errorf("%w: the WAL has step %d/%d/%s, the state machine went to %d/%d/%s",
+				ErrTransitionMismatch, m.Height, m.Round, m.Step, step.Height, step.Round, step.Step)
+		}
+	case EndHeightMessage:
+		if r.cs.Height <= m.Height {
+			return fmt.Errorf("%w: the WAL has height %d committed, the state machine is still at height %d",
+				ErrTransitionMismatch, m.Height, r.cs.Height)
+		}
+	case msgInfo:
+		r.cs.handleMsg(m)
+		// the reactor reads the stats of processed messages; discard them
+		for len(r.cs.statsMsgQueue) > 0 {
+			<-r.cs.statsMsgQueue
+		}
+	case timeoutInfo:
+		r.cs.handleTimeout(m, r.cs.RoundState)
+	default:
+		return fmt.Errorf("unknown WAL message type %T", m)
+	}
+	return nil
+}
+
+// parseRoundStep returns the step of the given name.
+func parseRoundStep(name string) (cstypes.RoundStepType, bool) {
+	for step := cstypes.RoundStepNewHeight; step <= cstypes.RoundStepCommit; step++ {
+		if step.String() == name {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// newReplayBlockStore returns an in-memory block store holding the last block
+// of state from blockStore, along with its commit, so that the state machine
+// can build the last commit of the next block.
+func newReplayBlockStore(state sm.State, blockStore sm.BlockStore) (*store.BlockStore, error) {
+	replayBlockStore := store.NewBlockStore(dbm.NewMemDB())
+	height := state.LastBlockHeight
+	if height == 0 {
+		return replayBlockStore, nil
+	}
+
+	block, _ := blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil {
+		return nil, err
+	}
+	if state.ConsensusParams.ABCI.VoteExtensionsEnabled(height) {
+		extCommit := blockStore.LoadBlockExtendedCommit(height)
+		if extCommit == nil {
+			return nil, fmt.Errorf("extended commit of height %d not found", height)
+		}
+		replayBlockStore.SaveBlockWithExtendedCommit(block, parts, extCommit)
+	} else {
+		seenCommit := blockStore.LoadSeenCommit(height)
+		if seenCommit == nil {
+			return nil, fmt.Errorf("commit of height %d not found", height)
+		}
+		replayBlockStore.SaveBlock(block, parts, seenCommit)
+	}
+	return replayBlockStore, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// replayApp stands in for the application in a replay of the WAL. It accepts
+// all proposals and vote extensions, and gives each block the results stored
+// by the node, if any.
+type replayApp struct {
+	abci.BaseApplication
+	stateStore sm.Store
+	appHash    []byte // app hash of the last block
+}
+
+func (app *replayApp) FinalizeBlock(_ context.Context, req *abci.FinalizeBlockRequest) (*abci.FinalizeBlockResponse, error) {
+	resp, err := app.stateStore.LoadFinalizeBlockResponse(req.Height)
+	if err == nil && resp != nil && len(resp.TxResults) == len(req.Txs) {
+		app.appHash = resp.AppHash
+		return resp, nil
+	}
+
+	txResults := make([]*abci.ExecTxResult, len(req.Txs))
+	for i := range txResults {
+		txResults[i] = &abci.ExecTxResult{Code: abci.CodeTypeOK}
+	}
+	return &abci.FinalizeBlockResponse{TxResults: txResults, AppHash: app.appHash}, nil
+}
+
+// replayTicker is a TimeoutTicker dropping the timeouts, as the ones the state
+// machine processed are replayed from the WAL.
+type replayTicker struct{}
+
+var _ TimeoutTicker = replayTicker{}
+
+func (replayTicker) Start() error                { return nil }
+func (replayTicker) Stop() error                 { return nil }
+func (replayTicker) Chan() <-chan timeoutInfo    { return nil }
+func (replayTicker) ScheduleTimeout(timeoutInfo) {}
+func (replayTicker) SetLogger(log.Logger)        {}
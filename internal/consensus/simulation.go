@@ -0,0 +1,580 @@
+package consensus
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
+	"github.com/cometbft/cometbft/internal/service"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+var (
+	// ErrAgreementViolation is returned by Simulation.Run when two validators
+	// decide different blocks at the same height.
+	ErrAgreementViolation = errors.New("agreement violated")
+	// ErrValidityViolation is returned by Simulation.Run when a validator
+	// decides a block that was never proposed.
+	ErrValidityViolation = errors.New("validity violated")
+	// ErrSimulationStalled is returned by Simulation.Run when the validators
+	// do not reach the target height within the maximum number of steps.
+	ErrSimulationStalled = errors.New("simulation stalled")
+)
+
+// SimConfig configures a consensus Simulation.
+type SimConfig struct {
+	// Seed of the random source deciding message delays and drops. Two
+	// simulations with the same configuration and seed deliver the same
+	// messages and timeouts in the same order.
+	Seed int64
+	// Number of validators, all with the same voting power.
+	Validators int
+	// Height all validators must decide for the simulation to complete.
+	Heights int64
+	// Each message is delivered after a delay chosen uniformly in
+	// [MinDelay, MaxDelay]. Messages sent at the same time may thus be
+	// delivered in a different order.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// Probability with which a message delivery fails. Like the consensus
+	// reactor would gossip it again, a dropped message is sent again after a
+	// new delay.
+	DropRate float64
+	// Maximum number of messages and timeouts processed before the
+	// simulation is considered stalled.
+	MaxSteps int
+	// Consensus timeouts, in simulated time.
+	Consensus *cfg.ConsensusConfig
+	Logger    log.Logger
+}
+
+// DefaultSimConfig returns a configuration simulating 4 validators deciding
+// 10 heights over a network with delays of up to 50ms.
+func DefaultSimConfig() SimConfig {
+	return SimConfig{
+		Seed:       1,
+		Validators: 4,
+		Heights:    10,
+		MinDelay:   1 * time.Millisecond,
+		MaxDelay:   50 * time.Millisecond,
+		MaxSteps:   100000,
+		Consensus:  cfg.TestConsensusConfig(),
+		Logger:     log.NewNopLogger(),
+	}
+}
+
+// SimEvent is a message delivery or timeout processed by a Simulation.
+type SimEvent struct {
+	Time   time.Duration // simulated time since the start
+	Node   int           // validator processing the event
+	From   int           // sender of a message, -1 for timeouts
+	Kind   string        // message type or timeout step
+	Height int64
+	Round  int32
+}
+
+func (e SimEvent) String() string {
+	return fmt.Sprintf("%v node=%d from=%d %s %d/%d", e.Time, e.Node, e.From, e.Kind, e.Height, e.Round)
+}
+
+// Simulation runs a network of validators in-process, on a simulated clock.
+// Instead of being started, the consensus state machine of each validator is
+// driven directly by a discrete-event scheduler delivering messages and
+// timeouts one at a time, in an order decided by the seed. After each event,
+// the agreement and validity invariants are checked.
+type Simulation struct {
+	config SimConfig
+	rng    *rand.Rand
+	nodes  []*simNode
+
+	now    time.Duration
+	seq    uint64
+	queue  simQueue
+	steps  int
+	trace  []SimEvent
+	closed bool
+
+	services *service.Group // of the validators
+
+	proposed map[string]bool  // hashes of proposed blocks
+	decided  map[int64][]byte // hash of the block decided at each height
+	blocks   map[string]*simBlock
+}
+
+// simBlock is a proposed block, by the hash of its part set header.
+type simBlock struct {
+	proposer int
+	parts    []Message
+}
+
+type simNode struct {
+	index     int
+	id        p2p.ID
+	cs        *State
+	proxyApp  proxy.AppConns
+	eventBus  *types.EventBus
+	decided   int64
+	pending   []*simEvent         // messages received ahead of the node's round
+	proposing *simBlock           // block of the node's last proposal
+	catchup   types.PartSetHeader // block whose parts were last sent again
+	timeout   timeoutInfo
+	timeoutID uint64 // seq of the scheduled timeout event
+}
+
+// NewSimulation creates the validators of a simulation. Call Run to run it.
+func NewSimulation(config SimConfig) (*Simulation, error) {
+	if config.Validators < 1 {
+		return nil, errors.New("at least one validator is required")
+	}
+	if config.MaxDelay < config.MinDelay {
+		return nil, errors.New("max delay must not be lower than min delay")
+	}
+	if config.Consensus == nil {
+		config.Consensus = cfg.TestConsensusConfig()
+	}
+	if config.Logger == nil {
+		config.Logger = log.NewNopLogger()
+	}
+
+	sim := &Simulation{
+		config:   config,
+		rng:      rand.New(rand.NewSource(config.Seed)), //nolint:gosec
+		proposed: make(map[string]bool),
+		decided:  make(map[int64][]byte),
+		blocks:   make(map[string]*simBlock),
+		services: service.NewGroup(config.Logger, 0),
+	}
+
+	privVals := make([]types.PrivValidator, config.Validators)
+	genDoc := &types.GenesisDoc{
+		ChainID:         "simulation",
+		GenesisTime:     time.Unix(0, 0).UTC(),
+		InitialHeight:   1,
+		ConsensusParams: types.DefaultConsensusParams(),
+	}
+	for i := range privVals {
+		// keys derive from the seed, so that validators are the same across runs
+		privKey := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("simulation-%d-%d", config.Seed, i)))
+		privVals[i] = types.NewMockPVWithParams(privKey, false, false)
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+			Address: privKey.PubKey().Address(),
+			PubKey:  privKey.PubKey(),
+			Power:   10,
+		})
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+
+	for i, privVal := range privVals {
+		node, err := sim.newNode(i, genDoc, privVal)
+		if err != nil {
+			sim.close()
+			return nil, err
+		}
+		sim.nodes = append(sim.nodes, node)
+	}
+	return sim, nil
+}
+
+func (sim *Simulation) newNode(index int, genDoc *types.GenesisDoc, privVal types.PrivValidator) (*simNode, error) {
+	logger := sim.config.Logger.With("validator", index)
+	node := &simNode{
+		index: index,
+		id:    p2p.ID(fmt.Sprintf("%040x", index)),
+	}
+
+	state, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return nil, err
+	}
+	state.Version.Consensus.App = kvstore.AppVersion
+
+	db := dbm.NewMemDB()
+	stateStore := sm.NewStore(db, sm.StoreOptions{DiscardABCIResponses: false})
+	if err := stateStore.Save(state); err != nil {
+		return nil, err
+	}
+	blockStore := store.NewBlockStore(db)
+
+	node.proxyApp = proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewInMemoryApplication()), proxy.NopMetrics())
+	node.proxyApp.SetLogger(logger.With("module", "proxy"))
+	node.eventBus = types.NewEventBus()
+	node.eventBus.SetLogger(logger.With("module", "events"))
+	if err := sim.services.Start(context.Background(), node.proxyApp, node.eventBus); err != nil {
+		return nil, err
+	}
+
+	mempool := emptyMempool{}
+	evpool := sm.EmptyEvidencePool{}
+	blockExec := sm.NewBlockExecutor(stateStore, logger, node.proxyApp.Consensus(), mempool, evpool, blockStore)
+	cs := NewState(sim.config.Consensus, state, blockExec, blockStore, mempool, evpool)
+	cs.SetLogger(logger.With("module", "consensus"))
+	cs.SetEventBus(node.eventBus)
+	cs.SetPrivValidator(privVal)
+	cs.SetTimeoutTicker(&simTicker{sim: sim, node: node})
+	node.cs = cs
+	return node, nil
+}
+
+// Run runs the simulation until all validators decide config.Heights, an
+// invariant is violated, or config.MaxSteps events have been processed.
+// Resources are released when Run returns, so it can only be called once.
+func (sim *Simulation) Run() (err error) {
+	if sim.closed {
+		return errors.New("simulation already ran")
+	}
+	defer sim.close()
+
+	for _, node := range sim.nodes {
+		node.cs.scheduleRound0(node.cs.GetRoundState())
+	}
+
+	for !sim.done() {
+		if sim.steps >= sim.config.MaxSteps || sim.queue.Len() == 0 {
+			return fmt.Errorf("%w after %d steps (seed %d)", ErrSimulationStalled, sim.steps, sim.config.Seed)
+		}
+		ev := heap.Pop(&sim.queue).(*simEvent)
+		sim.now = ev.time
+
+		if err := sim.process(ev); err != nil {
+			return fmt.Errorf("%w (seed %d, step %d)", err, sim.config.Seed, sim.steps)
+		}
+	}
+	return nil
+}
+
+// Trace returns the events processed so far.
+func (sim *Simulation) Trace() []SimEvent {
+	return sim.trace
+}
+
+// Steps returns the number of events processed so far.
+func (sim *Simulation) Steps() int {
+	return sim.steps
+}
+
+// Time returns the simulated time elapsed so far.
+func (sim *Simulation) Time() time.Duration {
+	return sim.now
+}
+
+func (sim *Simulation) done() bool {
+	for _, node := range sim.nodes {
+		if node.decided < sim.config.Heights {
+			return false
+		}
+	}
+	return true
+}
+
+func (sim *Simulation) close() {
+	sim.closed = true
+	if err := sim.services.Stop(); err != nil {
+		sim.config.Logger.Error("Error stopping services", "err", err)
+	}
+}
+
+// process handles a single event, then the messages generated by the
+// validator in response, and checks the invariants.
+func (sim *Simulation) process(ev *simEvent) (err error) {
+	node := sim.nodes[ev.node]
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("validator %d panicked: %v", node.index, r)
+		}
+	}()
+
+	switch {
+	case ev.flush:
+		sim.steps++
+	case ev.msgs == nil:
+		if ev.seq != node.timeoutID {
+			return nil // replaced by a later timeout
+		}
+		sim.steps++
+		sim.record(ev, ev.timeout.Step.String(), ev.timeout.Height, ev.timeout.Round)
+		node.cs.handleTimeout(ev.timeout, node.cs.RoundState)
+	default:
+		if sim.rng.Float64() < sim.config.DropRate {
+			sim.send(ev.from, ev.node, ev.msgs)
+			return nil
+		}
+		if node.ahead(ev.msgs[0]) {
+			node.pending = append(node.pending, ev)
+			return nil
+		}
+		sim.deliver(node, ev)
+	}
+
+	sim.flush(node)
+	return sim.checkInvariants(node)
+}
+
+func (sim *Simulation) deliver(node *simNode, ev *simEvent) {
+	sim.steps++
+	height, round := msgHeightRound(ev.msgs[0])
+	sim.record(ev, fmt.Sprintf("%T", ev.msgs[0]), height, round)
+	for _, msg := range ev.msgs {
+		node.cs.handleMsg(msgInfo{msg, sim.nodes[ev.from].id})
+	}
+}
+
+func (sim *Simulation) record(ev *simEvent, kind string, height int64, round int32) {
+	sim.trace = append(sim.trace, SimEvent{
+		Time:   sim.now,
+		Node:   ev.node,
+		From:   ev.from,
+		Kind:   kind,
+		Height: height,
+		Round:  round,
+	})
+}
+
+// flush processes the messages generated by node until there are none left,
+// broadcasting them to the other validators, and delivers the pending
+// messages the node has caught up with. Once the node moves to a new height,
+// the rest is left to a later event, as a node deciding on its own (e.g. a
+// single validator skipping timeoutCommit) would otherwise never stop.
+func (sim *Simulation) flush(node *simNode) {
+	height := node.cs.Height
+	for {
+		// the reactor reads the stats of processed messages; discard them
+		for len(node.cs.statsMsgQueue) > 0 {
+			<-node.cs.statsMsgQueue
+		}
+
+		if node.cs.Height > height {
+			sim.push(&simEvent{time: sim.now, node: node.index, from: node.index, flush: true})
+			return
+		}
+
+		select {
+		case mi := <-node.cs.internalMsgQueue:
+			node.cs.handleMsg(mi)
+			sim.broadcast(node, mi.Msg)
+			continue
+		default:
+		}
+
+		delivered := false
+		for i, ev := range node.pending {
+			if !node.ahead(ev.msgs[0]) {
+				node.pending = append(node.pending[:i:i], node.pending[i+1:]...)
+				sim.deliver(node, ev)
+				delivered = true
+				break
+			}
+		}
+		if !delivered {
+			sim.catchUp(node)
+			return
+		}
+	}
+}
+
+// catchUp sends the parts of the block committed by node again if it misses
+// them, e.g. because it was in another round when they arrived, like the
+// reactor gossips them to peers in the commit step.
+func (sim *Simulation) catchUp(node *simNode) {
+	rs := &node.cs.RoundState
+	if rs.Step != cstypes.RoundStepCommit || rs.ProposalBlockParts == nil || rs.ProposalBlockParts.IsComplete() {
+		return
+	}
+	header := rs.ProposalBlockParts.Header()
+	if header.Equals(node.catchup) {
+		return
+	}
+	if block, ok := sim.blocks[string(header.Hash)]; ok {
+		node.catchup = header
+		sim.send(block.proposer, node.index, block.parts)
+	}
+}
+
+// broadcast sends msg from node to all other validators. Block parts are
+// sent along with the proposal preceding them, as the reactor only gossips
+// parts to peers knowing the proposal.
+func (sim *Simulation) broadcast(node *simNode, msg Message) {
+	if part, ok := msg.(*BlockPartMessage); ok {
+		for _, ev := range sim.queue {
+			if ev.from == node.index && len(ev.msgs) > 0 && ev.sentAt == sim.now {
+				if prop, ok := ev.msgs[0].(*ProposalMessage); ok &&
+					prop.Proposal.Height == part.Height && prop.Proposal.Round == part.Round {
+					ev.msgs = append(ev.msgs, part)
+				}
+			}
+		}
+		if node.proposing != nil {
+			node.proposing.parts = append(node.proposing.parts, part)
+		}
+		return
+	}
+	if prop, ok := msg.(*ProposalMessage); ok {
+		sim.proposed[string(prop.Proposal.BlockID.Hash)] = true
+		node.proposing = &simBlock{proposer: node.index}
+		sim.blocks[string(prop.Proposal.BlockID.PartSetHeader.Hash)] = node.proposing
+	}
+	for _, peer := range sim.nodes {
+		if peer != node {
+			sim.send(node.index, peer.index, []Message{msg})
+		}
+	}
+}
+
+func (sim *Simulation) send(from, to int, msgs []Message) {
+	delay := sim.config.MinDelay
+	if spread := sim.config.MaxDelay - sim.config.MinDelay; spread > 0 {
+		delay += time.Duration(sim.rng.Int63n(int64(spread) + 1))
+	}
+	sim.push(&simEvent{time: sim.now + delay, sentAt: sim.now, node: to, from: from, msgs: msgs})
+}
+
+func (sim *Simulation) push(ev *simEvent) {
+	sim.seq++
+	ev.seq = sim.seq
+	heap.Push(&sim.queue, ev)
+}
+
+// checkInvariants checks the blocks decided by node since the last check.
+func (sim *Simulation) checkInvariants(node *simNode) error {
+	for node.decided < node.cs.blockStore.Height() {
+		height := node.decided + 1
+		meta := node.cs.blockStore.LoadBlockMeta(height)
+		if meta == nil {
+			return fmt.Errorf("validator %d: no block meta at height %d", node.index, height)
+		}
+		hash := meta.BlockID.Hash
+		if !sim.proposed[string(hash)] {
+			return fmt.Errorf("%w: validator %d decided block %X at height %d, which was never proposed",
+				ErrValidityViolation, node.index, hash, height)
+		}
+		if decided, ok := sim.decided[height]; ok && !bytes.Equal(decided, hash) {
+			return fmt.Errorf("%w: validator %d decided block %X at height %d, others decided %X",
+				ErrAgreementViolation, node.index, hash, height, decided)
+		}
+		sim.decided[height] = hash
+		node.decided = height
+	}
+	return nil
+}
+
+// ahead returns true if msg is for a later height or round than the node's.
+// Like the reactor, the simulation holds such messages back until the node
+// catches up, as the state machine discards them. Votes for the next round
+// are accepted though, so that the node can skip to it.
+func (node *simNode) ahead(msg Message) bool {
+	height, round := msgHeightRound(msg)
+	rs := &node.cs.RoundState
+	if _, ok := msg.(*VoteMessage); ok {
+		round--
+	}
+	return height > rs.Height || (height == rs.Height && round > rs.Round)
+}
+
+func msgHeightRound(msg Message) (int64, int32) {
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		return msg.Proposal.Height, msg.Proposal.Round
+	case *BlockPartMessage:
+		return msg.Height, msg.Round
+	case *VoteMessage:
+		return msg.Vote.Height, msg.Vote.Round
+	default:
+		return 0, 0
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// simTicker is a TimeoutTicker scheduling timeouts on the simulated clock.
+type simTicker struct {
+	sim  *Simulation
+	node *simNode
+}
+
+var _ TimeoutTicker = (*simTicker)(nil)
+
+func (*simTicker) Start() error             { return nil }
+func (*simTicker) Stop() error              { return nil }
+func (*simTicker) Chan() <-chan timeoutInfo { return nil }
+func (*simTicker) SetLogger(log.Logger)     {}
+
+// ScheduleTimeout replaces the scheduled timeout, unless it is for a later
+// height/round/step than ti, like timeoutTicker does.
+func (t *simTicker) ScheduleTimeout(ti timeoutInfo) {
+	cur := t.node.timeout
+	if ti.Height < cur.Height ||
+		(ti.Height == cur.Height && ti.Round < cur.Round) ||
+		(ti.Height == cur.Height && ti.Round == cur.Round && cur.Step > 0 && ti.Step <= cur.Step) {
+		return
+	}
+
+	duration := ti.Duration
+	if ti.Step == cstypes.RoundStepNewHeight {
+		// the state machine computes this timeout from the wall clock
+		duration = t.sim.config.Consensus.TimeoutCommit
+	}
+	if duration < 0 {
+		duration = 0
+	}
+
+	t.node.timeout = ti
+	ev := &simEvent{time: t.sim.now + duration, node: t.node.index, from: -1, timeout: ti}
+	t.sim.push(ev)
+	t.node.timeoutID = ev.seq
+}
+
+//-----------------------------------------------------------------------------
+
+type simEvent struct {
+	time   time.Duration
+	sentAt time.Duration
+	seq    uint64 // breaks ties between events at the same time
+	node   int
+	from   int
+
+	msgs    []Message // nil for timeouts
+	timeout timeoutInfo
+	flush   bool // resumes flushing the node's messages
+}
+
+// simQueue is a priority queue of events, ordered by time.
+type simQueue []*simEvent
+
+var _ heap.Interface = (*simQueue)(nil)
+
+func (q simQueue) Len() int { return len(q) }
+
+func (q simQueue) Less(i, j int) bool {
+	if q[i].time != q[j].time {
+		return q[i].time < q[j].time
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q simQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *simQueue) Push(x any) { *q = append(*q, x.(*simEvent)) }
+
+func (q *simQueue) Pop() any {
+	old := *q
+	n := len(old)
+	ev := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return ev
+}
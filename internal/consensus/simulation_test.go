@@ -0,0 +1,60 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulation(t *testing.T) {
+	testCases := map[string]func(*SimConfig){
+		"synchronous":      func(c *SimConfig) { c.MinDelay, c.MaxDelay = 0, 0 },
+		"delays":           func(*SimConfig) {},
+		"long delays":      func(c *SimConfig) { c.MaxDelay = 500 * time.Millisecond },
+		"drops":            func(c *SimConfig) { c.DropRate = 0.2 },
+		"single validator": func(c *SimConfig) { c.Validators = 1 },
+		"seven validators": func(c *SimConfig) { c.Validators = 7 },
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for seed := int64(1); seed <= 3; seed++ {
+				config := DefaultSimConfig()
+				config.Seed = seed
+				config.Heights = 5
+				tc(&config)
+
+				sim, err := NewSimulation(config)
+				require.NoError(t, err)
+				require.NoError(t, sim.Run())
+				for _, node := range sim.nodes {
+					require.GreaterOrEqual(t, node.cs.blockStore.Height(), config.Heights)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulationDeterministic(t *testing.T) {
+	run := func(seed int64) []SimEvent {
+		config := DefaultSimConfig()
+		config.Seed = seed
+		config.Heights = 3
+		config.DropRate = 0.1
+		sim, err := NewSimulation(config)
+		require.NoError(t, err)
+		require.NoError(t, sim.Run())
+		return sim.Trace()
+	}
+
+	require.Equal(t, run(42), run(42))
+	require.NotEqual(t, run(42), run(43))
+}
+
+func TestSimulationStalled(t *testing.T) {
+	config := DefaultSimConfig()
+	config.MaxSteps = 10
+	sim, err := NewSimulation(config)
+	require.NoError(t, err)
+	require.ErrorIs(t, sim.Run(), ErrSimulationStalled)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/cometbft/cometbft/internal/service"
 	sm "github.com/cometbft/cometbft/internal/state"
 	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/internal/upgrade"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
@@ -134,6 +135,15 @@ type State struct {
 
 	// offline state sync height indicating to which height the node synced offline
 	offlineStateSyncHeight int64
+
+	// halts consensus for an upgrade, if armed
+	upgrades *upgrade.Manager
+
+	// the last block proposed by this node at the current height, and the
+	// header of its part set, so that it is not decoded and hashed again when
+	// its parts are received.
+	proposedBlock      *types.Block
+	proposedPartHeader types.PartSetHeader
 }
 
 // StateOption sets an optional parameter on the State.
@@ -219,6 +229,12 @@ func OfflineStateSyncHeight(height int64) StateOption {
 	return func(cs *State) { cs.offlineStateSyncHeight = height }
 }
 
+// UpgradeManager sets the upgrade.Manager halting consensus once the block at
+// its halt height is committed.
+func UpgradeManager(upgrades *upgrade.Manager) StateOption {
+	return func(cs *State) { cs.upgrades = upgrades }
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -248,6 +264,15 @@ func (cs *State) GetRoundState() *cstypes.RoundState {
 	return &rs
 }
 
+// hasProposalBlockPart reports whether the part of the given index of the
+// proposal block of the given height was already received.
+func (cs *State) hasProposalBlockPart(height int64, index uint32) bool {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	parts := cs.ProposalBlockParts
+	return cs.Height == height && parts != nil && index < parts.Total() && parts.GetPart(int(index)) != nil
+}
+
 // GetRoundStateJSON returns a json of RoundState.
 func (cs *State) GetRoundStateJSON() ([]byte, error) {
 	cs.mtx.RLock()
@@ -262,6 +287,26 @@ func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	return cmtjson.Marshal(cs.RoundState.RoundStateSimple())
 }
 
+// voteVerification returns the verification of the signatures of the vote of
+// msg, to be run outside of the consensus routine, e.g. on a worker pool, if
+// the vote is for the current height. It returns nil otherwise.
+func (cs *State) voteVerification(msg *VoteMessage) func() {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+
+	vote := msg.Vote
+	if vote.Height != cs.Height {
+		return nil
+	}
+	_, val := cs.Validators.GetByIndex(vote.ValidatorIndex)
+	if val == nil {
+		return nil
+	}
+	chainID, pubKey := cs.state.ChainID, val.PubKey
+	extEnabled := cs.state.ConsensusParams.ABCI.VoteExtensionsEnabled(vote.Height)
+	return func() { msg.verify(chainID, pubKey, extEnabled) }
+}
+
 // GetValidators returns a copy of the current validators.
 func (cs *State) GetValidators() (int64, []*types.Validator) {
 	cs.mtx.RLock()
@@ -269,6 +314,26 @@ func (cs *State) GetValidators() (int64, []*types.Validator) {
 	return cs.state.LastBlockHeight, cs.state.Validators.Copy().Validators
 }
 
+// MsgQueueStatus is the number of messages waiting in a queue of the consensus
+// state, and the capacity of the queue.
+type MsgQueueStatus struct {
+	Name     string
+	Size     int
+	Capacity int
+}
+
+// GetMsgQueues returns the status of the queues of the messages from the
+// peers, of the internal messages, and of the messages counted in the
+// statistics of the peers. A full queue blocks the reactor, or the consensus
+// state itself for the internal messages.
+func (cs *State) GetMsgQueues() []MsgQueueStatus {
+	return []MsgQueueStatus{
+		{Name: "peer", Size: len(cs.peerMsgQueue), Capacity: cap(cs.peerMsgQueue)},
+		{Name: "internal", Size: len(cs.internalMsgQueue), Capacity: cap(cs.internalMsgQueue)},
+		{Name: "stats", Size: len(cs.statsMsgQueue), Capacity: cap(cs.statsMsgQueue)},
+	}
+}
+
 // SetPrivValidator sets the private validator account for signing votes. It
 // immediately requests pubkey and caches it.
 func (cs *State) SetPrivValidator(priv types.PrivValidator) {
@@ -467,9 +532,9 @@ func (cs *State) OpenWAL(walFile string) (WAL, error) {
 // AddVote inputs a vote.
 func (cs *State) AddVote(vote *types.Vote, peerID p2p.ID) (added bool, err error) {
 	if peerID == "" {
-		cs.internalMsgQueue <- msgInfo{&VoteMessage{vote}, ""}
+		cs.internalMsgQueue <- msgInfo{&VoteMessage{Vote: vote}, ""}
 	} else {
-		cs.peerMsgQueue <- msgInfo{&VoteMessage{vote}, peerID}
+		cs.peerMsgQueue <- msgInfo{&VoteMessage{Vote: vote}, peerID}
 	}
 
 	// TODO: wait for event?!
@@ -537,6 +602,7 @@ func (cs *State) updateRoundStep(round int32, step cstypes.RoundStepType) {
 		}
 		if cs.Step != step {
 			cs.metrics.MarkStep(cs.Step)
+			cs.metrics.MarkPhase(step)
 		}
 	}
 	cs.Round = round
@@ -718,7 +784,7 @@ func (cs *State) updateToState(state sm.State) {
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
 		cs.StartTime = cs.config.Commit(cmttime.Now())
 	} else {
-		cs.StartTime = cs.config.Commit(cs.CommitTime)
+		cs.StartTime = cs.config.NextStartTime(cs.StartTime, cs.CommitTime)
 	}
 
 	cs.Validators = validators
@@ -731,6 +797,7 @@ func (cs *State) updateToState(state sm.State) {
 	cs.ValidRound = -1
 	cs.ValidBlock = nil
 	cs.ValidBlockParts = nil
+	cs.proposedBlock = nil
 	if state.ConsensusParams.ABCI.VoteExtensionsEnabled(height) {
 		cs.Votes = cstypes.NewExtendedHeightVoteSet(state.ChainID, height, validators)
 	} else {
@@ -917,7 +984,7 @@ func (cs *State) handleMsg(mi msgInfo) {
 	case *VoteMessage:
 		// attempt to add the vote and dupeout the validator if its a duplicate signature
 		// if the vote gives us a 2/3-any or 2/3-one, we transition
-		added, err = cs.tryAddVote(msg.Vote, peerID)
+		added, err = cs.tryAddVote(msg, peerID)
 		if added {
 			cs.statsMsgQueue <- mi
 		}
@@ -967,6 +1034,10 @@ func (cs *State) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
 
+	if !cs.replayMode {
+		cs.metrics.MarkTimeout()
+	}
+
 	switch ti.Step {
 	case cstypes.RoundStepNewHeight:
 		// NewRound event fired from enterNewRound.
@@ -1032,7 +1103,7 @@ func (cs *State) handleTxsAvailable() {
 // State functions
 // Used internally by handleTimeout and handleMsg to make state transitions
 
-// Enter: `timeoutNewHeight` by startTime (commitTime+timeoutCommit),
+// Enter: `timeoutNewHeight` by startTime (commitTime+timeoutCommit, or the start of the last height+targetBlockInterval),
 //
 //	or, if SkipTimeoutCommit==true, after receiving all precommits from (height,round-1)
 //
@@ -1050,6 +1121,10 @@ func (cs *State) enterNewRound(height int64, round int32) {
 		)
 		return
 	}
+	if cs.upgrades.IsHalted() {
+		logger.Debug("not entering new round: consensus halted for an upgrade")
+		return
+	}
 
 	if now := cmttime.Now(); cs.StartTime.After(now) {
 		logger.Debug("need to set a buffer and log message here for sanity", "start_time", cs.StartTime, "now", now)
@@ -1229,6 +1304,7 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 	p := proposal.ToProto()
 	if err := cs.privValidator.SignProposal(cs.state.ChainID, p); err == nil {
 		proposal.Signature = p.Signature
+		cs.proposedBlock, cs.proposedPartHeader = block, blockParts.Header()
 
 		// send proposal and block parts on internal msg queue
 		cs.sendInternalMessage(msgInfo{&ProposalMessage{proposal}, ""})
@@ -1656,6 +1732,10 @@ func (cs *State) enterCommit(height int64, commitRound int32) {
 		)
 		return
 	}
+	if cs.upgrades.IsHalted() {
+		logger.Debug("not entering commit step: consensus halted for an upgrade")
+		return
+	}
 
 	logger.Debug("entering commit step", "current", log.NewLazySprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
@@ -1849,6 +1929,15 @@ func (cs *State) finalizeCommit(height int64) {
 		logger.Error("failed to get private validator pubkey", "err", err)
 	}
 
+	// Stop before proposing the next block if the chain halts for an upgrade.
+	if cs.upgrades.ShouldHalt(height) {
+		logger.Info("halting consensus for an upgrade", "height", height)
+		if err := cs.upgrades.Halt(height); err != nil {
+			logger.Error("failed to write the upgrade marker file", "err", err)
+		}
+		return
+	}
+
 	// cs.StartTime is already set.
 	// Schedule Round0 to start soon.
 	cs.scheduleRound0(&cs.RoundState)
@@ -1934,9 +2023,11 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 	if height > 1 {
 		lastBlockMeta := cs.blockStore.LoadBlockMeta(height - 1)
 		if lastBlockMeta != nil {
-			cs.metrics.BlockIntervalSeconds.Observe(
-				block.Time.Sub(lastBlockMeta.Header.Time).Seconds(),
-			)
+			interval := block.Time.Sub(lastBlockMeta.Header.Time)
+			cs.metrics.BlockIntervalSeconds.Observe(interval.Seconds())
+			if cs.config.TargetBlockInterval > 0 {
+				cs.metrics.BlockIntervalDriftSeconds.Set((interval - cs.config.TargetBlockInterval).Seconds())
+			}
 		}
 	}
 
@@ -2043,18 +2134,7 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 		)
 	}
 	if added && cs.ProposalBlockParts.IsComplete() {
-		bz, err := io.ReadAll(cs.ProposalBlockParts.GetReader())
-		if err != nil {
-			return added, err
-		}
-
-		pbb := new(cmtproto.Block)
-		err = proto.Unmarshal(bz, pbb)
-		if err != nil {
-			return added, err
-		}
-
-		block, err := types.BlockFromProto(pbb)
+		block, err := cs.proposalBlockFromParts()
 		if err != nil {
 			return added, err
 		}
@@ -2071,6 +2151,29 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 	return added, nil
 }
 
+// proposalBlockFromParts returns the block of the complete proposal block
+// parts. If they are the parts of the block this node proposed, which have the
+// same Merkle root, it returns it instead of decoding them, so that the
+// proposer doesn't hash the txs of its block again.
+func (cs *State) proposalBlockFromParts() (*types.Block, error) {
+	if cs.proposedBlock != nil && cs.ProposalBlockParts.HasHeader(cs.proposedPartHeader) {
+		return cs.proposedBlock, nil
+	}
+
+	bz, err := io.ReadAll(cs.ProposalBlockParts.GetReader())
+	if err != nil {
+		return nil, err
+	}
+
+	pbb := new(cmtproto.Block)
+	err = proto.Unmarshal(bz, pbb)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.BlockFromProto(pbb)
+}
+
 func (cs *State) handleCompleteProposal(blockHeight int64) {
 	// Update Valid* if we can.
 	prevotes := cs.Votes.Prevotes(cs.Round)
@@ -2107,8 +2210,9 @@ func (cs *State) handleCompleteProposal(blockHeight int64) {
 }
 
 // Attempt to add the vote. if its a duplicate signature, dupeout the validator.
-func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
-	added, err := cs.addVote(vote, peerID)
+func (cs *State) tryAddVote(msg *VoteMessage, peerID p2p.ID) (bool, error) {
+	vote := msg.Vote
+	added, err := cs.addVote(msg, peerID)
 	// NOTE: some of these errors are swallowed here
 	if err != nil {
 		// If the vote height is off, we'll just ignore it,
@@ -2158,7 +2262,8 @@ func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 	return added, nil
 }
 
-func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error) {
+func (cs *State) addVote(msg *VoteMessage, peerID p2p.ID) (added bool, err error) {
+	vote := msg.Vote
 	cs.Logger.Debug(
 		"adding vote",
 		"vote_height", vote.Height,
@@ -2199,7 +2304,7 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 		cs.evsw.FireEvent(types.EventVote, vote)
 
 		// if we can skip timeoutCommit and have all the votes now,
-		if cs.config.SkipTimeoutCommit && cs.LastCommit.HasAll() {
+		if cs.config.SkipCommit() && cs.LastCommit.HasAll() {
 			// go straight to new round (skip timeout commit)
 			// cs.scheduleTimeout(time.Duration(0), cs.Height, 0, cstypes.RoundStepNewHeight)
 			cs.enterNewRound(cs.Height, 0)
@@ -2235,8 +2340,10 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 			// Here, we verify the signature of the vote extension included in the vote
 			// message.
 			_, val := cs.state.Validators.GetByIndex(vote.ValidatorIndex)
-			if err := vote.VerifyExtension(cs.state.ChainID, val.PubKey); err != nil {
-				return false, err
+			if !msg.extVerified || !val.PubKey.Equals(msg.verifiedBy) {
+				if err := vote.VerifyExtension(cs.state.ChainID, val.PubKey); err != nil {
+					return false, err
+				}
 			}
 
 			err := cs.blockExec.VerifyVoteExtension(context.TODO(), vote)
@@ -2256,7 +2363,7 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 	}
 
 	height := cs.Height
-	added, err = cs.Votes.AddVote(vote, peerID, extEnabled)
+	added, err = cs.Votes.AddPreverifiedVote(vote, peerID, extEnabled, msg.verifiedBy, msg.extVerified)
 	if !added {
 		// Either duplicate, or error upon cs.Votes.AddByIndex()
 
@@ -2354,7 +2461,7 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 
 			if !blockID.IsNil() {
 				cs.enterCommit(height, vote.Round)
-				if cs.config.SkipTimeoutCommit && precommits.HasAll() {
+				if cs.config.SkipCommit() && precommits.HasAll() {
 					cs.enterNewRound(cs.Height, 0)
 				}
 			} else {
@@ -2455,6 +2562,9 @@ func (cs *State) signAddVote(
 	if cs.privValidator == nil { // the node does not have a key
 		return
 	}
+	if cs.upgrades.IsHalted() {
+		return
+	}
 
 	if cs.privValidatorPubKey == nil {
 		// Vote won't be signed, but it's not critical.
@@ -2479,7 +2589,7 @@ func (cs *State) signAddVote(
 		panic(fmt.Errorf("vote extension absence/presence does not match extensions enabled %t!=%t, height %d, type %v",
 			hasExt, extEnabled, vote.Height, vote.Type))
 	}
-	cs.sendInternalMessage(msgInfo{&VoteMessage{vote}, ""})
+	cs.sendInternalMessage(msgInfo{&VoteMessage{Vote: vote}, ""})
 	cs.Logger.Debug("signed and pushed vote", "height", cs.Height, "round", cs.Round, "vote", vote)
 }
 
@@ -2491,7 +2601,16 @@ func (cs *State) updatePrivValidatorPubKey() error {
 		return nil
 	}
 
-	pubKey, err := cs.privValidator.GetPubKey()
+	var (
+		pubKey crypto.PubKey
+		err    error
+	)
+	// The key of a rotating private validator depends on the height.
+	if rotator, ok := cs.privValidator.(types.KeyRotator); ok {
+		pubKey, err = rotator.GetPubKeyAt(cs.Height)
+	} else {
+		pubKey, err = cs.privValidator.GetPubKey()
+	}
 	if err != nil {
 		return err
 	}
@@ -109,6 +109,35 @@ func TestStateProposerSelection0(t *testing.T) {
 	}
 }
 
+func TestStateProposedBlockNotDecoded(t *testing.T) {
+	cs1, vss := randState(4)
+	height, round := cs1.Height, cs1.Round
+
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewProposal(proposalCh, height, round)
+
+	// The proposer uses the block it proposed, instead of the one decoded
+	// from its parts.
+	cs1.mtx.RLock()
+	proposedBlock, proposalBlock := cs1.proposedBlock, cs1.ProposalBlock
+	cs1.mtx.RUnlock()
+	require.NotNil(t, proposedBlock)
+	require.Same(t, proposedBlock, proposalBlock)
+
+	rs := cs1.GetRoundState()
+	signAddVotes(cs1, types.PrecommitType, rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header(), true, vss[1:]...)
+	ensureNewRound(newRoundCh, height+1, 0)
+
+	// It is forgotten at the next height.
+	cs1.mtx.RLock()
+	defer cs1.mtx.RUnlock()
+	require.Nil(t, cs1.proposedBlock)
+}
+
 // Now let's do it all again, but starting from round 2 instead of 0
 func TestStateProposerSelection2(t *testing.T) {
 	cs1, vss := randState(4) // test needs more work for more than 3 validators
@@ -2696,6 +2725,25 @@ func (n *fakeTxNotifier) Notify() {
 	n.ch <- struct{}{}
 }
 
+// a single validator starts the next height target_block_interval after the
+// start of the last one, not as soon as it has all the precommits
+func TestStateTargetBlockInterval(t *testing.T) {
+	cs1, _ := randState(1)
+	cs1.config.TargetBlockInterval = 100 * time.Millisecond
+	height, round := cs1.Height, cs1.Round
+
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+
+	// the first height is started right away, the next two are each started
+	// target_block_interval after the last one
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	start := time.Now()
+	ensureNewRound(newRoundCh, height+1, 0)
+	ensureNewRound(newRoundCh, height+2, 0)
+	assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+}
+
 // 2 vals precommit votes for a block but node times out waiting for the third. Move to next round
 // and third precommit arrives which leads to the commit of that header and the correct
 // start of the next round
@@ -3024,7 +3072,7 @@ func TestStateOutputVoteStats(t *testing.T) {
 
 	vote := signVote(vss[1], types.PrecommitType, randBytes, types.PartSetHeader{}, true)
 
-	voteMessage := &VoteMessage{vote}
+	voteMessage := &VoteMessage{Vote: vote}
 	cs.handleMsg(msgInfo{voteMessage, peer.ID()})
 
 	statsMessage := <-cs.statsMsgQueue
@@ -3032,13 +3080,13 @@ func TestStateOutputVoteStats(t *testing.T) {
 	require.Equal(t, peer.ID(), statsMessage.PeerID, "")
 
 	// sending the same part from different peer
-	cs.handleMsg(msgInfo{&VoteMessage{vote}, "peer2"})
+	cs.handleMsg(msgInfo{&VoteMessage{Vote: vote}, "peer2"})
 
 	// sending the vote for the bigger height
 	incrementHeight(vss[1])
 	vote = signVote(vss[1], types.PrecommitType, randBytes, types.PartSetHeader{}, true)
 
-	cs.handleMsg(msgInfo{&VoteMessage{vote}, peer.ID()})
+	cs.handleMsg(msgInfo{&VoteMessage{Vote: vote}, peer.ID()})
 
 	select {
 	case <-cs.statsMsgQueue:
@@ -3047,6 +3095,39 @@ func TestStateOutputVoteStats(t *testing.T) {
 	}
 }
 
+func TestStateVoteVerification(t *testing.T) {
+	cs, vss := randState(2)
+	pubKey, err := vss[1].GetPubKey()
+	require.NoError(t, err)
+	randBytes := cmtrand.Bytes(tmhash.Size)
+
+	msg := &VoteMessage{Vote: signVote(vss[1], types.PrevoteType, randBytes, types.PartSetHeader{}, false)}
+	verify := cs.voteVerification(msg)
+	require.NotNil(t, verify)
+	verify()
+	require.True(t, pubKey.Equals(msg.verifiedBy))
+	require.Equal(t, cs.state.ConsensusParams.ABCI.VoteExtensionsEnabled(cs.Height), msg.extVerified)
+
+	added, err := cs.tryAddVote(msg, "peer")
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// A vote with an invalid signature is not marked as verified, and is
+	// rejected by the consensus state.
+	vote := signVote(vss[1], types.PrecommitType, randBytes, types.PartSetHeader{}, true)
+	vote.Signature = cmtrand.Bytes(len(vote.Signature))
+	msg = &VoteMessage{Vote: vote}
+	cs.voteVerification(msg)()
+	require.Nil(t, msg.verifiedBy)
+	_, err = cs.tryAddVote(msg, "peer")
+	require.ErrorIs(t, err, ErrAddingVote)
+
+	// The votes for other heights are left to the consensus state.
+	incrementHeight(vss[1])
+	msg = &VoteMessage{Vote: signVote(vss[1], types.PrevoteType, randBytes, types.PartSetHeader{}, false)}
+	require.Nil(t, cs.voteVerification(msg))
+}
+
 func TestSignSameVoteTwice(t *testing.T) {
 	_, vss := randState(2)
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/cometbft/cometbft/crypto"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	"github.com/cometbft/cometbft/p2p"
@@ -128,6 +129,19 @@ func (hvs *HeightVoteSet) addRound(round int32) {
 // Duplicate votes return added=false, err=nil.
 // By convention, peerID is "" if origin is self.
 func (hvs *HeightVoteSet) AddVote(vote *types.Vote, peerID p2p.ID, extEnabled bool) (added bool, err error) {
+	return hvs.AddPreverifiedVote(vote, peerID, extEnabled, nil, false)
+}
+
+// AddPreverifiedVote adds the vote as AddVote does, skipping the verification
+// of its signatures if they were verified with pubKey (see
+// types.VoteSet.AddPreverifiedVote).
+func (hvs *HeightVoteSet) AddPreverifiedVote(
+	vote *types.Vote,
+	peerID p2p.ID,
+	extEnabled bool,
+	pubKey crypto.PubKey,
+	extVerified bool,
+) (added bool, err error) {
 	hvs.mtx.Lock()
 	defer hvs.mtx.Unlock()
 	if hvs.extensionsEnabled != extEnabled {
@@ -148,7 +162,7 @@ func (hvs *HeightVoteSet) AddVote(vote *types.Vote, peerID p2p.ID, extEnabled bo
 			return
 		}
 	}
-	added, err = voteSet.AddVote(vote)
+	added, err = voteSet.AddPreverifiedVote(vote, pubKey, extVerified)
 	return
 }
 
@@ -1,20 +1,29 @@
 package consensus
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash/crc32"
 	"io"
+	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	cmtcons "github.com/cometbft/cometbft/api/cometbft/consensus/v1"
 	auto "github.com/cometbft/cometbft/internal/autofile"
 	cmtos "github.com/cometbft/cometbft/internal/os"
 	"github.com/cometbft/cometbft/internal/service"
+	"github.com/cometbft/cometbft/internal/tempfile"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
 	cmterrors "github.com/cometbft/cometbft/types/errors"
 	cmttime "github.com/cometbft/cometbft/types/time"
 	"github.com/cosmos/gogoproto/proto"
@@ -419,6 +428,226 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 	return tMsgWal, err
 }
 
+// ResetWAL replaces the WAL at walFile, including its rotated files, with a
+// WAL holding only the #ENDHEIGHT marker of the given height. It is meant to
+// be used when the state is rolled back to height, as the messages of later
+// heights would otherwise be replayed on restart. Nothing is done if there is
+// no WAL at walFile.
+func ResetWAL(walFile string, height int64) error {
+	if !cmtos.FileExists(walFile) {
+		return nil
+	}
+
+	// Remove the rotated files first: if we crash before the head is
+	// rewritten, it still holds the latest heights and ResetWAL can be run
+	// again.
+	rotated, err := filepath.Glob(walFile + ".[0-9][0-9][0-9]*")
+	if err != nil {
+		return err
+	}
+	for _, path := range rotated {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove WAL file: %w", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := NewWALEncoder(&buf).Encode(&TimedWALMessage{cmttime.Now(), EndHeightMessage{height}}); err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(walFile, buf.Bytes(), 0o600)
+}
+
+// WALEndHeight returns the height of the last #ENDHEIGHT marker of the WAL at
+// walFile, including its rotated files, or 0 if there is none. corrupted
+// reports whether some files have corrupted messages; the messages following
+// a corrupted one in a file are ignored.
+func WALEndHeight(walFile string) (height int64, corrupted bool, err error) {
+	if !cmtos.FileExists(walFile) {
+		return 0, false, nil
+	}
+	files, err := walFiles(walFile)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			return 0, false, err
+		}
+		dec := NewWALDecoder(f)
+		for {
+			msg, err := dec.Decode()
+			if err == io.EOF {
+				break
+			}
+			if IsDataCorruptionError(err) {
+				corrupted = true
+				break
+			}
+			if err != nil {
+				f.Close()
+				return 0, false, err
+			}
+			if m, ok := msg.Msg.(EndHeightMessage); ok {
+				height = m.Height
+			}
+		}
+		f.Close()
+	}
+	return height, corrupted, nil
+}
+
+// WALEntry is a message of the WAL, with the height and the round it belongs
+// to, to inspect the WAL.
+type WALEntry struct {
+	Time   time.Time `json:"time"`
+	Height int64     `json:"height"`
+	// Round of the message, or -1 if it belongs to none, e.g. the #ENDHEIGHT
+	// marker.
+	Round int32 `json:"round"`
+	// Type of the message: round_state, timeout, end_height or, for the
+	// consensus messages, the name of the message, e.g. vote or block_part.
+	Type string `json:"type"`
+	// Peer the message was received from, empty for the messages of the node.
+	PeerID p2p.ID `json:"peer_id,omitempty"`
+	Msg    string `json:"msg"`
+}
+
+// NewWALEntry returns the entry of a message of the WAL.
+func NewWALEntry(msg *TimedWALMessage) WALEntry {
+	entry := WALEntry{Time: msg.Time, Round: -1}
+	switch m := msg.Msg.(type) {
+	case types.EventDataRoundState:
+		entry.Height, entry.Round, entry.Type = m.Height, m.Round, "round_state"
+		entry.Msg = m.Step
+	case timeoutInfo:
+		entry.Height, entry.Round, entry.Type = m.Height, m.Round, "timeout"
+		entry.Msg = fmt.Sprintf("%v %v", m.Step, m.Duration)
+	case EndHeightMessage:
+		entry.Height, entry.Type = m.Height, "end_height"
+		entry.Msg = fmt.Sprintf("#ENDHEIGHT %d", m.Height)
+	case msgInfo:
+		switch cm := m.Msg.(type) {
+		case *ProposalMessage:
+			entry.Height, entry.Round = cm.Proposal.Height, cm.Proposal.Round
+		case *BlockPartMessage:
+			entry.Height, entry.Round = cm.Height, cm.Round
+		case *VoteMessage:
+			entry.Height, entry.Round = cm.Vote.Height, cm.Vote.Round
+		}
+		entry.Type = msgTypeName(m.Msg)
+		entry.PeerID = m.PeerID
+		// Some messages, e.g. the block parts, span several lines.
+		entry.Msg = strings.Join(strings.Fields(fmt.Sprint(m.Msg)), " ")
+	default:
+		entry.Type = fmt.Sprintf("%T", m)
+		entry.Msg = fmt.Sprint(m)
+	}
+	return entry
+}
+
+// msgTypeName returns the name of the type of a consensus message in snake
+// case, without the Message suffix, e.g. block_part for BlockPartMessage.
+func msgTypeName(msg Message) string {
+	name := fmt.Sprintf("%T", msg)
+	name = strings.TrimSuffix(name[strings.LastIndex(name, ".")+1:], "Message")
+	var sb strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// InspectWAL decodes the messages of the WAL at walFile, including its rotated
+// files, from the oldest, and calls fn with the entry of each of them.
+// corrupted reports whether some files have corrupted messages; the messages
+// following a corrupted one in a file are skipped.
+func InspectWAL(walFile string, fn func(WALEntry)) (corrupted bool, err error) {
+	return walkWAL(walFile, true, func(msg *TimedWALMessage) error {
+		fn(NewWALEntry(msg))
+		return nil
+	})
+}
+
+// walkWAL decodes the messages of the WAL at walFile, including its rotated
+// files, from the oldest, and calls fn with each of them until it returns an
+// error. If skipCorrupted is true, the messages following a corrupted one in a
+// file are skipped, which corrupted reports; otherwise the data corruption
+// error is returned.
+func walkWAL(walFile string, skipCorrupted bool, fn func(*TimedWALMessage) error) (corrupted bool, err error) {
+	files, err := walFiles(walFile)
+	if err != nil {
+		return false, err
+	}
+
+	for _, path := range files {
+		if err := walkWALFile(path, fn); err != nil {
+			if !skipCorrupted || !IsDataCorruptionError(err) {
+				return false, err
+			}
+			corrupted = true
+		}
+	}
+	return corrupted, nil
+}
+
+func walkWALFile(path string, fn func(*TimedWALMessage) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := NewWALDecoder(f)
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// walFiles returns the rotated files of the WAL at walFile, from the oldest,
+// followed by the head, walFile itself.
+func walFiles(walFile string) ([]string, error) {
+	rotated, err := filepath.Glob(walFile + ".[0-9][0-9][0-9]*")
+	if err != nil {
+		return nil, err
+	}
+	// The rotated files are numbered from the oldest, the head is the latest.
+	index := func(path string) int {
+		i, _ := strconv.Atoi(strings.TrimPrefix(path, walFile+"."))
+		return i
+	}
+	sort.Slice(rotated, func(i, j int) bool { return index(rotated[i]) < index(rotated[j]) })
+	return append(rotated, walFile), nil
+}
+
+// RepairWAL backs up the head file of the WAL at walFile to
+// walFile.CORRUPTED, and rewrites it with the messages preceding the first
+// corrupted one.
+func RepairWAL(walFile string) error {
+	corruptedFile := walFile + ".CORRUPTED"
+	if err := cmtos.CopyFile(walFile, corruptedFile); err != nil {
+		return err
+	}
+	return repairWalFile(corruptedFile, walFile)
+}
+
 type nilWAL struct{}
 
 var _ WAL = nilWAL{}
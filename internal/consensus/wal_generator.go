@@ -29,6 +29,15 @@ import (
 func WALGenerateNBlocks(t *testing.T, wr io.Writer, numBlocks int, config *cfg.Config) (err error) {
 	t.Helper()
 
+	_, err = walGenerateNBlocks(t, wr, numBlocks, config)
+	return err
+}
+
+// walGenerateNBlocks is WALGenerateNBlocks, returning the state store of the
+// node, which holds the results of the blocks.
+func walGenerateNBlocks(t *testing.T, wr io.Writer, numBlocks int, config *cfg.Config) (sm.Store, error) {
+	t.Helper()
+
 	app := kvstore.NewPersistentApplication(filepath.Join(config.DBDir(), "wal_generator"))
 
 	logger := log.TestingLogger().With("wal_generator", "wal_generator")
@@ -42,7 +51,7 @@ func WALGenerateNBlocks(t *testing.T, wr io.Writer, numBlocks int, config *cfg.C
 	privValidator := privval.LoadOrGenFilePV(privValidatorKeyFile, privValidatorStateFile)
 	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
 	if err != nil {
-		return fmt.Errorf("failed to read genesis file: %w", err)
+		return nil, fmt.Errorf("failed to read genesis file: %w", err)
 	}
 	blockStoreDB := db.NewMemDB()
 	stateDB := blockStoreDB
@@ -51,7 +60,7 @@ func WALGenerateNBlocks(t *testing.T, wr io.Writer, numBlocks int, config *cfg.C
 	})
 	state, err := sm.MakeGenesisState(genDoc)
 	if err != nil {
-		return fmt.Errorf("failed to make genesis state: %w", err)
+		return nil, fmt.Errorf("failed to make genesis state: %w", err)
 	}
 	state.Version.Consensus.App = kvstore.AppVersion
 	if err = stateStore.Save(state); err != nil {
@@ -63,7 +72,7 @@ func WALGenerateNBlocks(t *testing.T, wr io.Writer, numBlocks int, config *cfg.C
 	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app), proxy.NopMetrics())
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
-		return fmt.Errorf("failed to start proxy app connections: %w", err)
+		return nil, fmt.Errorf("failed to start proxy app connections: %w", err)
 	}
 	t.Cleanup(func() {
 		if err := proxyApp.Stop(); err != nil {
@@ -74,7 +83,7 @@ func WALGenerateNBlocks(t *testing.T, wr io.Writer, numBlocks int, config *cfg.C
 	eventBus := types.NewEventBus()
 	eventBus.SetLogger(logger.With("module", "events"))
 	if err := eventBus.Start(); err != nil {
-		return fmt.Errorf("failed to start event bus: %w", err)
+		return nil, fmt.Errorf("failed to start event bus: %w", err)
 	}
 	t.Cleanup(func() {
 		if err := eventBus.Stop(); err != nil {
@@ -103,7 +112,7 @@ func WALGenerateNBlocks(t *testing.T, wr io.Writer, numBlocks int, config *cfg.C
 	consensusState.wal = wal
 
 	if err := consensusState.Start(); err != nil {
-		return fmt.Errorf("failed to start consensus state: %w", err)
+		return nil, fmt.Errorf("failed to start consensus state: %w", err)
 	}
 
 	select {
@@ -111,12 +120,12 @@ func WALGenerateNBlocks(t *testing.T, wr io.Writer, numBlocks int, config *cfg.C
 		if err := consensusState.Stop(); err != nil {
 			t.Error(err)
 		}
-		return nil
+		return stateStore, nil
 	case <-time.After(1 * time.Minute):
 		if err := consensusState.Stop(); err != nil {
 			t.Error(err)
 		}
-		return fmt.Errorf("waited too long for CometBFT to produce %d blocks (grep logs for `wal_generator`)", numBlocks)
+		return nil, fmt.Errorf("waited too long for CometBFT to produce %d blocks (grep logs for `wal_generator`)", numBlocks)
 	}
 }
 
@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"crypto/rand"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -13,10 +14,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/internal/autofile"
 	"github.com/cometbft/cometbft/internal/consensus/types"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/store"
 	"github.com/cometbft/cometbft/internal/test"
 	"github.com/cometbft/cometbft/libs/log"
 	cmttypes "github.com/cometbft/cometbft/types"
@@ -177,6 +182,176 @@ func TestWALSearchForEndHeight(t *testing.T) {
 	assert.Equal(t, rs.Height, h+1, "wrong height")
 }
 
+func TestResetWAL(t *testing.T) {
+	walBody, err := WALWithNBlocks(t, 6, getConfig(t))
+	require.NoError(t, err)
+	walFile := tempWALWithData(walBody)
+	defer os.Remove(walFile)
+
+	// a rotated file, which must be removed as well
+	rotated := walFile + ".000"
+	require.NoError(t, os.WriteFile(rotated, walBody, 0o600))
+
+	require.NoError(t, ResetWAL(walFile, 3))
+	assert.NoFileExists(t, rotated)
+
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+
+	gr, found, err := wal.SearchForEndHeight(3, &WALSearchOptions{})
+	require.NoError(t, err)
+	assert.True(t, found, "expected to find end height for 3")
+	require.NoError(t, gr.Close())
+
+	gr, found, err = wal.SearchForEndHeight(4, &WALSearchOptions{})
+	require.NoError(t, err)
+	assert.False(t, found, "expected not to find end height for 4")
+	if gr != nil {
+		require.NoError(t, gr.Close())
+	}
+
+	// no WAL at all
+	require.NoError(t, ResetWAL(filepath.Join(t.TempDir(), "wal"), 3))
+}
+
+func TestWALEndHeight(t *testing.T) {
+	encode := func(heights ...int64) []byte {
+		var buf bytes.Buffer
+		enc := NewWALEncoder(&buf)
+		for _, h := range heights {
+			require.NoError(t, enc.Encode(&TimedWALMessage{cmttime.Now(), EndHeightMessage{h}}))
+		}
+		return buf.Bytes()
+	}
+	walFile := filepath.Join(t.TempDir(), "wal")
+
+	// no WAL at all
+	height, corrupted, err := WALEndHeight(walFile)
+	require.NoError(t, err)
+	assert.Zero(t, height)
+	assert.False(t, corrupted)
+
+	require.NoError(t, os.WriteFile(walFile+".000", encode(1, 2), 0o600))
+	require.NoError(t, os.WriteFile(walFile+".001", encode(3, 4), 0o600))
+	require.NoError(t, os.WriteFile(walFile, encode(5), 0o600))
+	height, corrupted, err = WALEndHeight(walFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, height)
+	assert.False(t, corrupted)
+
+	// a partially written message at the end of the head
+	tail := encode(6)
+	require.NoError(t, os.WriteFile(walFile, append(encode(5), tail[:len(tail)-2]...), 0o600))
+	height, corrupted, err = WALEndHeight(walFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, height)
+	assert.True(t, corrupted)
+
+	require.NoError(t, RepairWAL(walFile))
+	assert.FileExists(t, walFile+".CORRUPTED")
+	height, corrupted, err = WALEndHeight(walFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, height)
+	assert.False(t, corrupted)
+}
+
+func TestInspectWAL(t *testing.T) {
+	now := cmttime.Now()
+	encode := func(msgs ...WALMessage) []byte {
+		var buf bytes.Buffer
+		enc := NewWALEncoder(&buf)
+		for _, msg := range msgs {
+			require.NoError(t, enc.Encode(&TimedWALMessage{now, msg}))
+		}
+		return buf.Bytes()
+	}
+	parts := cmttypes.NewPartSetFromData([]byte("data"), cmttypes.BlockPartSizeBytes)
+	walFile := filepath.Join(t.TempDir(), "wal")
+
+	require.NoError(t, os.WriteFile(walFile+".000", encode(
+		EndHeightMessage{1},
+		cmttypes.EventDataRoundState{Height: 2, Round: 1, Step: "RoundStepPropose"},
+	), 0o600))
+	require.NoError(t, os.WriteFile(walFile, encode(
+		msgInfo{&BlockPartMessage{Height: 2, Round: 1, Part: parts.GetPart(0)}, "peer"},
+		timeoutInfo{Duration: time.Second, Height: 2, Round: 1, Step: types.RoundStepPropose},
+	), 0o600))
+
+	var entries []WALEntry
+	corrupted, err := InspectWAL(walFile, func(e WALEntry) { entries = append(entries, e) })
+	require.NoError(t, err)
+	assert.False(t, corrupted)
+	require.Len(t, entries, 4)
+	assert.Equal(t, WALEntry{Time: now.UTC(), Height: 1, Round: -1, Type: "end_height", Msg: "#ENDHEIGHT 1"}, entries[0])
+	assert.Equal(t, "round_state", entries[1].Type)
+	assert.EqualValues(t, 2, entries[1].Height)
+	assert.EqualValues(t, 1, entries[1].Round)
+	assert.Equal(t, "block_part", entries[2].Type)
+	assert.EqualValues(t, 2, entries[2].Height)
+	assert.EqualValues(t, 1, entries[2].Round)
+	assert.EqualValues(t, "peer", entries[2].PeerID)
+	assert.Equal(t, "timeout", entries[3].Type)
+	assert.Equal(t, "RoundStepPropose 1s", entries[3].Msg)
+}
+
+func TestReplayWAL(t *testing.T) {
+	config := getConfig(t)
+	var b bytes.Buffer
+	stateStore, err := walGenerateNBlocks(t, &b, 3, config)
+	require.NoError(t, err)
+
+	genDoc, err := cmttypes.GenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	state.Version.Consensus.App = kvstore.AppVersion
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	var msgs []*TimedWALMessage
+	dec := NewWALDecoder(&b)
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		msgs = append(msgs, msg)
+	}
+	writeWAL := func(msgs []*TimedWALMessage) string {
+		walFile := filepath.Join(t.TempDir(), "wal")
+		f, err := os.Create(walFile)
+		require.NoError(t, err)
+		enc := NewWALEncoder(f)
+		for _, msg := range msgs {
+			require.NoError(t, enc.Encode(msg))
+		}
+		require.NoError(t, f.Close())
+		return walFile
+	}
+
+	n := 0
+	err = ReplayWAL(config.Consensus, writeWAL(msgs), state, stateStore, blockStore,
+		func(WALEntry) { n++ }, log.TestingLogger())
+	require.NoError(t, err)
+	assert.Equal(t, len(msgs)-1, n, "expected all the messages after #ENDHEIGHT 0 to be replayed")
+
+	// without the first timeout, the state machine does not leave the first step
+	for i, msg := range msgs {
+		if _, ok := msg.Msg.(timeoutInfo); ok {
+			msgs = append(msgs[:i:i], msgs[i+1:]...)
+			break
+		}
+	}
+	err = ReplayWAL(config.Consensus, writeWAL(msgs), state, stateStore, blockStore, nil, log.TestingLogger())
+	require.ErrorIs(t, err, ErrTransitionMismatch)
+
+	// no #ENDHEIGHT of the last block of state
+	err = ReplayWAL(config.Consensus, writeWAL(msgs[1:]), state, stateStore, blockStore, nil, log.TestingLogger())
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrTransitionMismatch)
+}
+
 func TestWALPeriodicSync(t *testing.T) {
 	walDir, err := os.MkdirTemp("", "wal")
 	require.NoError(t, err)
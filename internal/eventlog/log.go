@@ -0,0 +1,317 @@
+// Package eventlog persists the events emitted while finalizing blocks in a
+// durable log, from which consumers read them back in order starting at any
+// retained height.
+//
+// Consumers that remember the height of the last block they processed and
+// resume from the next one receive the events of every block at least once,
+// regardless of restarts of the node or of the consumer.
+package eventlog
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	pbeventlog "github.com/cometbft/cometbft/api/cometbft/services/event_log/v1"
+	"github.com/cometbft/cometbft/internal/service"
+	sm "github.com/cometbft/cometbft/internal/state"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+const subscriber = "EventLog"
+
+// keyPrefix prefixes the keys of the entries, which are followed by the
+// big-endian height so that they sort by height.
+var keyPrefix = []byte("e")
+
+// Log subscribes to the new blocks on the event bus and appends their events
+// to the database, pruning the entries that fall out of the retention window.
+//
+// On start, it first appends the events of the blocks committed since the last
+// entry, loaded from the state store, so that no block is missed while the
+// node was down.
+type Log struct {
+	service.BaseService
+
+	db           dbm.DB
+	retainBlocks int64
+	blockStore   sm.BlockStore
+	stateStore   sm.Store
+	eventBus     *types.EventBus
+
+	mtx      cmtsync.RWMutex
+	base     int64
+	height   int64
+	appended chan struct{}
+
+	done chan struct{}
+}
+
+// NewLog returns a new event log writing to db. If retainBlocks is positive,
+// only the events of the latest retainBlocks blocks are kept.
+func NewLog(
+	db dbm.DB,
+	retainBlocks int64,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	eventBus *types.EventBus,
+) *Log {
+	l := &Log{
+		db:           db,
+		retainBlocks: retainBlocks,
+		blockStore:   blockStore,
+		stateStore:   stateStore,
+		eventBus:     eventBus,
+		appended:     make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	l.BaseService = *service.NewBaseService(nil, "EventLog", l)
+	return l
+}
+
+// OnStart implements service.Service by catching up with the blocks committed
+// since the last entry and following the new ones.
+func (l *Log) OnStart() error {
+	base, height, err := l.bounds()
+	if err != nil {
+		return fmt.Errorf("loading the bounds of the event log: %w", err)
+	}
+	l.base, l.height = base, height
+
+	// Subscribe before catching up, so that no block is missed in between.
+	// Unbuffered, like the indexer, so the subscription is never canceled
+	// for falling behind.
+	sub, err := l.eventBus.SubscribeUnbuffered(context.Background(), subscriber, types.EventQueryNewBlock)
+	if err != nil {
+		return err
+	}
+
+	if err := l.catchUp(); err != nil {
+		_ = l.eventBus.UnsubscribeAll(context.Background(), subscriber)
+		return err
+	}
+
+	go l.follow(sub)
+	return nil
+}
+
+// OnStop implements service.Service by unsubscribing and closing the database
+// once the pending entry is written.
+func (l *Log) OnStop() {
+	if l.eventBus.IsRunning() {
+		_ = l.eventBus.UnsubscribeAll(context.Background(), subscriber)
+	}
+	<-l.done
+	if err := l.db.Close(); err != nil {
+		l.Logger.Error("Failed to close the event log database", "err", err)
+	}
+}
+
+func (l *Log) follow(sub types.Subscription) {
+	defer close(l.done)
+	for {
+		select {
+		case msg := <-sub.Out():
+			data := msg.Data().(types.EventDataNewBlock)
+			if data.Block.Height <= l.Height() {
+				continue
+			}
+			entry := blockEvents(data.Block, &data.ResultFinalizeBlock)
+			if err := l.append(entry); err != nil {
+				l.Logger.Error("Failed to append to the event log", "height", entry.Height, "err", err)
+			}
+		case <-sub.Canceled():
+			// Canceled by OnStop, or when the event bus stops.
+			return
+		}
+	}
+}
+
+func (l *Log) catchUp() error {
+	from := l.Height() + 1
+	if base := l.blockStore.Base(); from < base {
+		from = base
+	}
+	for h := from; h <= l.blockStore.Height(); h++ {
+		resp, err := l.stateStore.LoadFinalizeBlockResponse(h)
+		if err != nil {
+			// The latest block may not have been executed yet, in which case
+			// its events are logged once the handshake replays it.
+			l.Logger.Info("Cannot load the events of a committed block, stopping the catch up",
+				"height", h, "err", err)
+			return nil
+		}
+		block, _ := l.blockStore.LoadBlock(h)
+		if block == nil {
+			return fmt.Errorf("block %d not found", h)
+		}
+		if err := l.append(blockEvents(block, resp)); err != nil {
+			return fmt.Errorf("appending block %d to the event log: %w", h, err)
+		}
+	}
+	return nil
+}
+
+func blockEvents(block *types.Block, resp *abci.FinalizeBlockResponse) *pbeventlog.BlockEvents {
+	entry := &pbeventlog.BlockEvents{
+		Height:              block.Height,
+		FinalizeBlockEvents: eventRefs(resp.Events),
+		TxEvents:            make([]*pbeventlog.TxEvents, 0, len(resp.TxResults)),
+	}
+	for i, res := range resp.TxResults {
+		var hash []byte
+		if i < len(block.Txs) {
+			hash = block.Txs[i].Hash()
+		}
+		entry.TxEvents = append(entry.TxEvents, &pbeventlog.TxEvents{
+			Hash:   hash,
+			Index:  uint32(i),
+			Events: eventRefs(res.Events),
+		})
+	}
+	return entry
+}
+
+func eventRefs(events []abci.Event) []*abci.Event {
+	refs := make([]*abci.Event, len(events))
+	for i := range events {
+		refs[i] = &events[i]
+	}
+	return refs
+}
+
+// append writes entry to the log, pruning the entries outside of the
+// retention window in the same batch.
+func (l *Log) append(entry *pbeventlog.BlockEvents) error {
+	bz, err := entry.Marshal()
+	if err != nil {
+		return err
+	}
+
+	batch := l.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(key(entry.Height), bz); err != nil {
+		return err
+	}
+
+	l.mtx.RLock()
+	base := l.base
+	l.mtx.RUnlock()
+	if base == 0 {
+		base = entry.Height
+	}
+	if l.retainBlocks > 0 && entry.Height-base >= l.retainBlocks {
+		newBase := entry.Height - l.retainBlocks + 1
+		if err := l.deleteRange(batch, base, newBase); err != nil {
+			return err
+		}
+		base = newBase
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+
+	l.mtx.Lock()
+	l.base, l.height = base, entry.Height
+	close(l.appended)
+	l.appended = make(chan struct{})
+	l.mtx.Unlock()
+	return nil
+}
+
+// deleteRange deletes the entries in [from, to) from the log.
+func (l *Log) deleteRange(batch dbm.Batch, from, to int64) error {
+	it, err := l.db.Iterator(key(from), key(to))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// Base returns the height of the oldest block in the log, or 0 if the log is
+// empty.
+func (l *Log) Base() int64 {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.base
+}
+
+// Height returns the height of the latest block in the log, or 0 if the log
+// is empty.
+func (l *Log) Height() int64 {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.height
+}
+
+// Appended returns a channel that is closed once the events of the next block
+// are appended to the log.
+func (l *Log) Appended() <-chan struct{} {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.appended
+}
+
+// Next returns the events of the first block in the log at or above height,
+// or nil if there is none yet.
+func (l *Log) Next(height int64) (*pbeventlog.BlockEvents, error) {
+	it, err := l.db.Iterator(key(height), prefixEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	if !it.Valid() {
+		return nil, it.Error()
+	}
+	entry := new(pbeventlog.BlockEvents)
+	if err := entry.Unmarshal(it.Value()); err != nil {
+		return nil, fmt.Errorf("decoding the event log entry %X: %w", it.Key(), err)
+	}
+	return entry, nil
+}
+
+// bounds returns the heights of the oldest and of the latest entries in the
+// database.
+func (l *Log) bounds() (base, height int64, err error) {
+	first, err := l.db.Iterator(keyPrefix, prefixEnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer first.Close()
+	if !first.Valid() {
+		return 0, 0, first.Error()
+	}
+	last, err := l.db.ReverseIterator(keyPrefix, prefixEnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer last.Close()
+	if !last.Valid() {
+		return 0, 0, errors.New("no last entry while there is a first one")
+	}
+	return heightOf(first.Key()), heightOf(last.Key()), nil
+}
+
+var prefixEnd = []byte{keyPrefix[0] + 1}
+
+func key(height int64) []byte {
+	k := make([]byte, len(keyPrefix)+8)
+	copy(k, keyPrefix)
+	binary.BigEndian.PutUint64(k[len(keyPrefix):], uint64(height))
+	return k
+}
+
+func heightOf(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key[len(keyPrefix):]))
+}
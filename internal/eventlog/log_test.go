@@ -0,0 +1,151 @@
+package eventlog
+
+import (
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func testBlock(height int64) *types.Block {
+	return &types.Block{
+		Header: types.Header{Height: height},
+		Data:   types.Data{Txs: types.Txs{types.Tx("tx")}},
+	}
+}
+
+func testResponse(height int64) *abci.FinalizeBlockResponse {
+	return &abci.FinalizeBlockResponse{
+		Events: []abci.Event{{Type: "block", Attributes: []abci.EventAttribute{
+			{Key: "height", Value: string(rune('0' + height)), Index: true},
+		}}},
+		TxResults: []*abci.ExecTxResult{{Events: []abci.Event{{Type: "tx"}}}},
+	}
+}
+
+// setup returns a started event bus, and a block store and a state store
+// holding the blocks 1 to height.
+func setup(t *testing.T, height int64) (*types.EventBus, *mocks.BlockStore, sm.Store) {
+	t.Helper()
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(height)
+	for h := int64(1); h <= height; h++ {
+		require.NoError(t, stateStore.SaveFinalizeBlockResponse(h, testResponse(h)))
+		blockStore.On("LoadBlock", h).Return(testBlock(h), (*types.BlockMeta)(nil))
+	}
+	return eventBus, blockStore, stateStore
+}
+
+func publish(t *testing.T, l *Log, eventBus *types.EventBus, height int64) {
+	t.Helper()
+	appended := l.Appended()
+	require.NoError(t, eventBus.PublishEventNewBlock(types.EventDataNewBlock{
+		Block:               testBlock(height),
+		ResultFinalizeBlock: *testResponse(height),
+	}))
+	select {
+	case <-appended:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("block %d was not appended to the log", height)
+	}
+}
+
+func TestLogCatchUpAndFollow(t *testing.T) {
+	db := dbm.NewMemDB()
+	eventBus, blockStore, stateStore := setup(t, 3)
+
+	l := NewLog(db, 0, blockStore, stateStore, eventBus)
+	require.NoError(t, l.Start())
+	require.EqualValues(t, 1, l.Base())
+	require.EqualValues(t, 3, l.Height())
+
+	publish(t, l, eventBus, 4)
+	require.EqualValues(t, 4, l.Height())
+
+	for h := int64(1); h <= 4; h++ {
+		entry, err := l.Next(h)
+		require.NoError(t, err)
+		require.NotNil(t, entry)
+		require.Equal(t, h, entry.Height)
+		require.Len(t, entry.FinalizeBlockEvents, 1)
+		require.Equal(t, testResponse(h).Events[0], *entry.FinalizeBlockEvents[0])
+		require.Len(t, entry.TxEvents, 1)
+		require.Equal(t, types.Tx("tx").Hash(), entry.TxEvents[0].Hash)
+		require.Equal(t, "tx", entry.TxEvents[0].Events[0].Type)
+	}
+	entry, err := l.Next(5)
+	require.NoError(t, err)
+	require.Nil(t, entry)
+
+	// A block published again, e.g. when replayed, is not logged twice.
+	require.NoError(t, eventBus.PublishEventNewBlock(types.EventDataNewBlock{
+		Block:               testBlock(4),
+		ResultFinalizeBlock: abci.FinalizeBlockResponse{},
+	}))
+	require.NoError(t, l.Stop())
+	entry, err = l.Next(4)
+	require.NoError(t, err)
+	require.Len(t, entry.FinalizeBlockEvents, 1)
+
+	// On restart, the log resumes from its latest entry and catches up with
+	// the blocks committed in the meantime.
+	eventBus, blockStore, stateStore = setup(t, 6)
+	l = NewLog(db, 0, blockStore, stateStore, eventBus)
+	require.NoError(t, l.Start())
+	t.Cleanup(func() { _ = l.Stop() })
+	require.EqualValues(t, 1, l.Base())
+	require.EqualValues(t, 6, l.Height())
+	blockStore.AssertNotCalled(t, "LoadBlock", int64(4))
+}
+
+func TestLogCatchUpStopsAtUnexecutedBlock(t *testing.T) {
+	eventBus, blockStore, stateStore := setup(t, 2)
+	// The block 3 is saved, but not executed yet.
+	blockStore.ExpectedCalls = nil
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(int64(3))
+	for h := int64(1); h <= 2; h++ {
+		blockStore.On("LoadBlock", h).Return(testBlock(h), (*types.BlockMeta)(nil))
+	}
+
+	l := NewLog(dbm.NewMemDB(), 0, blockStore, stateStore, eventBus)
+	require.NoError(t, l.Start())
+	t.Cleanup(func() { _ = l.Stop() })
+	require.EqualValues(t, 2, l.Height())
+
+	// The block is logged once replayed.
+	publish(t, l, eventBus, 3)
+	require.EqualValues(t, 3, l.Height())
+}
+
+func TestLogRetention(t *testing.T) {
+	eventBus, blockStore, stateStore := setup(t, 0)
+
+	l := NewLog(dbm.NewMemDB(), 2, blockStore, stateStore, eventBus)
+	require.NoError(t, l.Start())
+	t.Cleanup(func() { _ = l.Stop() })
+	require.EqualValues(t, 0, l.Base())
+	require.EqualValues(t, 0, l.Height())
+
+	for h := int64(1); h <= 5; h++ {
+		publish(t, l, eventBus, h)
+	}
+	require.EqualValues(t, 4, l.Base())
+	require.EqualValues(t, 5, l.Height())
+
+	entry, err := l.Next(1)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, entry.Height)
+}
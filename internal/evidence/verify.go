@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/batch"
 	"github.com/cometbft/cometbft/light"
 	"github.com/cometbft/cometbft/types"
 )
@@ -18,9 +20,10 @@ import (
 // - it was properly signed by the alleged equivocator and meets the individual evidence verification requirements.
 func (evpool *Pool) verify(evidence types.Evidence) error {
 	var (
-		state          = evpool.State()
-		height         = state.LastBlockHeight
-		evidenceParams = state.ConsensusParams.Evidence
+		state             = evpool.State()
+		height            = state.LastBlockHeight
+		evidenceParams    = state.ConsensusParams.Evidence
+		batchVerification = types.WithBatchVerification(!state.ConsensusParams.Validator.DisableBatchVerification)
 	)
 
 	// verify the time of the evidence
@@ -52,7 +55,7 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		if err != nil {
 			return err
 		}
-		return VerifyDuplicateVote(ev, state.ChainID, valSet)
+		return VerifyDuplicateVote(ev, state.ChainID, valSet, batchVerification)
 
 	case *types.LightClientAttackEvidence:
 		commonHeader, err := getSignedHeader(evpool.blockStore, evidence.Height())
@@ -87,7 +90,7 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		}
 
 		err = VerifyLightClientAttack(ev, commonHeader, trustedHeader, commonVals, state.LastBlockTime,
-			state.ConsensusParams.Evidence.MaxAgeDuration)
+			state.ConsensusParams.Evidence.MaxAgeDuration, batchVerification)
 		if err != nil {
 			return err
 		}
@@ -105,6 +108,9 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 //   - the nodes trusted header at the same height as the conflicting header has a different hash
 //   - all signatures must be checked as this will be used as evidence
 //
+// The signatures are batch verified when the keys support it, unless opts
+// disable it.
+//
 // CONTRACT: must run ValidateBasic() on the evidence before verifying
 //
 //	must check that the evidence has not expired (i.e. is outside the maximum age threshold)
@@ -114,6 +120,7 @@ func VerifyLightClientAttack(
 	commonVals *types.ValidatorSet,
 	now time.Time,
 	trustPeriod time.Duration,
+	opts ...types.VerifyCommitOption,
 ) error {
 	// TODO: Should the current time and trust period be used in this method?
 	// If not, why were the parameters present?
@@ -121,7 +128,7 @@ func VerifyLightClientAttack(
 	// In the case of lunatic attack there will be a different commonHeader height. Therefore the node perform a single
 	// verification jump between the common header and the conflicting one
 	if commonHeader.Height != e.ConflictingBlock.Height {
-		err := commonVals.VerifyCommitLightTrustingAllSignatures(trustedHeader.ChainID, e.ConflictingBlock.Commit, light.DefaultTrustLevel)
+		err := commonVals.VerifyCommitLightTrustingAllSignatures(trustedHeader.ChainID, e.ConflictingBlock.Commit, light.DefaultTrustLevel, opts...)
 		if err != nil {
 			return ErrConflictingBlock{fmt.Errorf("skipping verification of conflicting block failed: %w", err)}
 		}
@@ -134,7 +141,7 @@ func VerifyLightClientAttack(
 
 	// Verify that the 2/3+ commits from the conflicting validator set were for the conflicting header
 	if err := e.ConflictingBlock.ValidatorSet.VerifyCommitLightAllSignatures(trustedHeader.ChainID, e.ConflictingBlock.Commit.BlockID,
-		e.ConflictingBlock.Height, e.ConflictingBlock.Commit); err != nil {
+		e.ConflictingBlock.Height, e.ConflictingBlock.Commit, opts...); err != nil {
 		return ErrConflictingBlock{fmt.Errorf("invalid commit from conflicting block: %w", err)}
 	}
 
@@ -164,7 +171,15 @@ func VerifyLightClientAttack(
 //   - the height, round, type and validator address of the votes must be the same
 //   - the block ID's must be different
 //   - The signatures must both be valid
-func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet *types.ValidatorSet) error {
+//
+// The signatures are batch verified when the key supports it, unless opts
+// disable it.
+func VerifyDuplicateVote(
+	e *types.DuplicateVoteEvidence,
+	chainID string,
+	valSet *types.ValidatorSet,
+	opts ...types.VerifyCommitOption,
+) error {
 	_, val := valSet.GetByAddress(e.VoteA.ValidatorAddress)
 	if val == nil {
 		return ErrAddressNotValidatorAtHeight{Address: e.VoteA.ValidatorAddress, Height: e.Height()}
@@ -203,19 +218,39 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 		return ErrVotingPowerDoesNotMatch{TrustedVotingPower: valSet.TotalVotingPower(), EvidenceVotingPower: e.TotalVotingPower}
 	}
 
-	va := e.VoteA.ToProto()
-	vb := e.VoteB.ToProto()
-	// Signatures must be valid
-	if !pubKey.VerifySignature(types.VoteSignBytes(chainID, va), e.VoteA.Signature) {
+	signBytesA := types.VoteSignBytes(chainID, e.VoteA.ToProto())
+	signBytesB := types.VoteSignBytes(chainID, e.VoteB.ToProto())
+	// Signatures must be valid. They are batch verified if the key supports it
+	// and opts allow it, and one by one otherwise or to find the invalid one.
+	if types.BatchVerificationEnabled(opts...) && verifyBatch(pubKey, [][]byte{signBytesA, signBytesB}, [][]byte{e.VoteA.Signature, e.VoteB.Signature}) {
+		return nil
+	}
+	if !pubKey.VerifySignature(signBytesA, e.VoteA.Signature) {
 		return fmt.Errorf("verifying VoteA: %w", types.ErrVoteInvalidSignature)
 	}
-	if !pubKey.VerifySignature(types.VoteSignBytes(chainID, vb), e.VoteB.Signature) {
+	if !pubKey.VerifySignature(signBytesB, e.VoteB.Signature) {
 		return fmt.Errorf("verifying VoteB: %w", types.ErrVoteInvalidSignature)
 	}
 
 	return nil
 }
 
+// verifyBatch returns true if pubKey supports batch verification and the
+// signatures of the messages are all valid.
+func verifyBatch(pubKey crypto.PubKey, msgs, sigs [][]byte) bool {
+	bv, ok := batch.CreateBatchVerifier(pubKey)
+	if !ok {
+		return false
+	}
+	for i, msg := range msgs {
+		if err := bv.Add(pubKey, msg, sigs[i]); err != nil {
+			return false
+		}
+	}
+	ok, _ = bv.Verify()
+	return ok
+}
+
 // validateABCIEvidence validates the ABCI component of the light client attack
 // evidence i.e voting power and byzantine validators.
 func validateABCIEvidence(
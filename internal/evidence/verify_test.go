@@ -394,6 +394,7 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 		// a different vote time doesn't matter
 		{vote1, types.MakeVoteNoError(t, val, chainID, 0, 10, 2, 1, blockID2, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), true},
 		{vote1, badVote, false}, // signed by wrong key
+		{badVote, types.MakeVoteNoError(t, val, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime), false},
 	}
 
 	require.NoError(t, err)
@@ -3,7 +3,9 @@ package indexer
 import (
 	"fmt"
 	"math/big"
+	"time"
 
+	"github.com/cometbft/cometbft/internal/pubsub/query/syntax"
 	"github.com/cometbft/cometbft/internal/state/indexer"
 )
 
@@ -116,3 +118,37 @@ func CheckBounds(ranges indexer.QueryRange, v interface{}) (bool, error) {
 	}
 	return true, nil
 }
+
+// TimeToNanos returns the number of nanoseconds elapsed between the Unix epoch
+// and t.
+func TimeToNanos(t time.Time) *big.Int {
+	v := new(big.Int).Mul(big.NewInt(t.Unix()), big.NewInt(int64(time.Second)))
+	return v.Add(v, big.NewInt(int64(t.Nanosecond())))
+}
+
+// TimeRange returns the range with its time bounds converted with TimeToNanos,
+// to be checked with CheckBounds against the values returned by
+// ParseTimeValue.
+func TimeRange(qr indexer.QueryRange) indexer.QueryRange {
+	if t, ok := qr.LowerBound.(time.Time); ok {
+		qr.LowerBound = TimeToNanos(t)
+	}
+	if t, ok := qr.UpperBound.(time.Time); ok {
+		qr.UpperBound = TimeToNanos(t)
+	}
+	return qr
+}
+
+// ParseTimeValue parses an event attribute value as a timestamp, in the
+// format of TIME values, or a date, in the format of DATE values, and returns
+// it converted with TimeToNanos.
+func ParseTimeValue(s string) (*big.Int, error) {
+	t, err := syntax.ParseTime(s)
+	if err != nil {
+		var dateErr error
+		if t, dateErr = syntax.ParseDate(s); dateErr != nil {
+			return nil, err
+		}
+	}
+	return TimeToNanos(t), nil
+}
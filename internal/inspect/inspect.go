@@ -39,6 +39,24 @@ type Inspector struct {
 	// the Inspector to safely close them on shutdown.
 	ss state.Store
 	bs state.BlockStore
+
+	genDoc *types.GenesisDoc
+	dbDir  string
+}
+
+// Option sets an optional parameter of the Inspector.
+type Option func(*Inspector)
+
+// WithGenesisDoc serves the genesis and genesis_chunked RPC endpoints from
+// genDoc.
+func WithGenesisDoc(genDoc *types.GenesisDoc) Option {
+	return func(ins *Inspector) { ins.genDoc = genDoc }
+}
+
+// WithDBDir reports the disk usage of the databases in dir in the status RPC
+// endpoint.
+func WithDBDir(dir string) Option {
+	return func(ins *Inspector) { ins.dbDir = dir }
 }
 
 // New returns an Inspector that serves RPC on the specified BlockStore and StateStore.
@@ -53,17 +71,19 @@ func New(
 	ss state.Store,
 	txidx txindex.TxIndexer,
 	blkidx indexer.BlockIndexer,
+	options ...Option,
 ) *Inspector {
-	routes := rpc.Routes(*cfg, ss, bs, txidx, blkidx, logger)
-	eb := types.NewEventBus()
-	eb.SetLogger(logger.With("module", "events"))
-	return &Inspector{
-		routes: routes,
+	ins := &Inspector{
 		config: cfg,
 		logger: logger,
 		ss:     ss,
 		bs:     bs,
 	}
+	for _, option := range options {
+		option(ins)
+	}
+	ins.routes = rpc.Routes(*cfg, ss, bs, txidx, blkidx, ins.genDoc, ins.dbDir, logger)
+	return ins
 }
 
 // NewFromConfig constructs an Inspector using the values defined in the passed in config.
@@ -86,7 +106,7 @@ func NewFromConfig(cfg *config.Config) (*Inspector, error) {
 		return nil, err
 	}
 	ss := state.NewStore(sDB, state.StoreOptions{})
-	return New(cfg.RPC, bs, ss, txidx, blkidx), nil
+	return New(cfg.RPC, bs, ss, txidx, blkidx, WithGenesisDoc(genDoc), WithDBDir(cfg.DBDir())), nil
 }
 
 // Run starts the Inspector servers and blocks until the servers shut down. The passed
@@ -569,6 +569,62 @@ func TestBlockSearch(t *testing.T) {
 	stateStoreMock.AssertExpectations(t)
 }
 
+func TestStatus(t *testing.T) {
+	testHeight := int64(10)
+	testBlockHash := []byte("test hash")
+	stateStoreMock := &statemocks.Store{}
+	stateStoreMock.On("Close").Return(nil)
+
+	blockStoreMock := &statemocks.BlockStore{}
+	blockStoreMock.On("Close").Return(nil)
+	blockStoreMock.On("Height").Return(testHeight)
+	blockStoreMock.On("LoadBaseMeta").Return(&types.BlockMeta{Header: types.Header{Height: 1}})
+	blockStoreMock.On("LoadBlockMeta", testHeight).Return(&types.BlockMeta{
+		BlockID: types.BlockID{Hash: testBlockHash},
+		Header:  types.Header{Height: testHeight},
+	})
+	txIndexerMock := &txindexmocks.TxIndexer{}
+	blkIdxMock := &indexermocks.BlockIndexer{}
+	rpcConfig := config.TestRPCConfig()
+	genDoc := &types.GenesisDoc{ChainID: "test-chain"}
+	d := inspect.New(rpcConfig, blockStoreMock, stateStoreMock, txIndexerMock, blkIdxMock,
+		inspect.WithGenesisDoc(genDoc))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	startedWG := &sync.WaitGroup{}
+	startedWG.Add(1)
+	go func() {
+		startedWG.Done()
+		defer wg.Done()
+		require.NoError(t, d.Run(ctx))
+	}()
+	// FIXME: used to induce context switch.
+	// Determine more deterministic method for prompting a context switch
+	startedWG.Wait()
+	requireConnect(t, rpcConfig.ListenAddress, 20)
+	cli, err := httpclient.New(rpcConfig.ListenAddress + "/v1")
+	require.NoError(t, err)
+
+	status, err := cli.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, testHeight, status.SyncInfo.LatestBlockHeight)
+	require.Equal(t, testBlockHash, []byte(status.SyncInfo.LatestBlockHash))
+	require.Equal(t, int64(1), status.SyncInfo.EarliestBlockHeight)
+
+	genesis, err := cli.Genesis(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, genDoc.ChainID, genesis.Genesis.ChainID)
+
+	cancel()
+	wg.Wait()
+
+	blockStoreMock.AssertExpectations(t)
+	stateStoreMock.AssertExpectations(t)
+}
+
 func requireConnect(t testing.TB, addr string, retries int) {
 	parts := strings.SplitN(addr, "://", 2)
 	if len(parts) != 2 {
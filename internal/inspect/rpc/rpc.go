@@ -6,12 +6,14 @@ import (
 	"time"
 
 	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/signinginfo"
 	"github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/state/indexer"
 	"github.com/cometbft/cometbft/internal/state/txindex"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/rpc/core"
 	"github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	"github.com/cometbft/cometbft/types"
 	"github.com/rs/cors"
 )
 
@@ -23,8 +25,21 @@ type Server struct {
 	Config  *config.RPCConfig
 }
 
-// Routes returns the set of routes used by the Inspector server.
-func Routes(cfg config.RPCConfig, s state.Store, bs state.BlockStore, txidx txindex.TxIndexer, blkidx indexer.BlockIndexer, logger log.Logger) core.RoutesMap { //nolint: lll
+// Routes returns the set of routes used by the Inspector server: the query
+// routes of the node served from the stores, without p2p, consensus or
+// application. The genesis routes are only served if genDoc is not nil, and
+// the disk usage of the databases in dbDir, if not empty, is reported by
+// status.
+func Routes(
+	cfg config.RPCConfig,
+	s state.Store,
+	bs state.BlockStore,
+	txidx txindex.TxIndexer,
+	blkidx indexer.BlockIndexer,
+	genDoc *types.GenesisDoc,
+	dbDir string,
+	logger log.Logger,
+) core.RoutesMap {
 	env := &core.Environment{
 		Config:           cfg,
 		BlockIndexer:     blkidx,
@@ -32,22 +47,41 @@ func Routes(cfg config.RPCConfig, s state.Store, bs state.BlockStore, txidx txin
 		StateStore:       s,
 		BlockStore:       bs,
 		ConsensusReactor: waitSyncCheckerImpl{},
+		DBDir:            dbDir,
 		Logger:           logger,
 	}
-	return core.RoutesMap{
-		"blockchain":       server.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight"),
-		"consensus_params": server.NewRPCFunc(env.ConsensusParams, "height"),
-		"block":            server.NewRPCFunc(env.Block, "height"),
-		"block_by_hash":    server.NewRPCFunc(env.BlockByHash, "hash"),
-		"block_results":    server.NewRPCFunc(env.BlockResults, "height"),
-		"commit":           server.NewRPCFunc(env.Commit, "height"),
-		"header":           server.NewRPCFunc(env.Header, "height"),
-		"header_by_hash":   server.NewRPCFunc(env.HeaderByHash, "hash"),
-		"validators":       server.NewRPCFunc(env.Validators, "height,page,per_page"),
-		"tx":               server.NewRPCFunc(env.Tx, "hash,prove"),
-		"tx_search":        server.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by"),
-		"block_search":     server.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
+	if cfg.SigningInfoWindow > 0 {
+		env.SigningInfo = signinginfo.NewTracker(bs, s, cfg.SigningInfoWindow)
 	}
+	routes := core.RoutesMap{
+		"status":                 server.NewRPCFunc(env.Status, ""),
+		"blockchain":             server.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight"),
+		"light_blocks":           server.NewRPCFunc(env.LightBlocks, "from,to"),
+		"consensus_params":       server.NewRPCFunc(env.ConsensusParams, "height"),
+		"block":                  server.NewRPCFunc(env.Block, "height"),
+		"block_by_hash":          server.NewRPCFunc(env.BlockByHash, "hash"),
+		"block_results":          server.NewRPCFunc(env.BlockResults, "height"),
+		"commit":                 server.NewRPCFunc(env.Commit, "height"),
+		"header":                 server.NewRPCFunc(env.Header, "height"),
+		"header_by_hash":         server.NewRPCFunc(env.HeaderByHash, "hash"),
+		"header_chain_proof":     server.NewRPCFunc(env.HeaderChainProof, "height,trusted_height"),
+		"validators":             server.NewRPCFunc(env.Validators, "height,page,per_page"),
+		"validator_signing_info": server.NewRPCFunc(env.ValidatorSigningInfo, "address"),
+		"tx":                     server.NewRPCFunc(env.Tx, "hash,prove"),
+		"tx_result_proof":        server.NewRPCFunc(env.TxResultProof, "hash,height"),
+		"tx_search":              server.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by"),
+		"block_search":           server.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
+	}
+	if genDoc != nil {
+		env.GenDoc = genDoc
+		if err := env.InitGenesisChunks(); err != nil {
+			logger.Error("Failed to chunk the genesis file, not serving it", "err", err)
+		} else {
+			routes["genesis"] = server.NewRPCFunc(env.Genesis, "")
+			routes["genesis_chunked"] = server.NewRPCFunc(env.GenesisChunked, "chunk")
+		}
+	}
+	return routes
 }
 
 // Handler returns the http.Handler configured for use with an Inspector server. Handler
@@ -0,0 +1,53 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package memtune
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		GCPauseSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "gc_pause_seconds",
+			Help:      "Duration of the stop-the-world pauses of the garbage collector.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.00001, 1, 16),
+		}, labels).With(labelsAndValues...),
+		GCCycles: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "gc_cycles",
+			Help:      "Number of completed garbage collection cycles.",
+		}, labels).With(labelsAndValues...),
+		HeapGoalBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "heap_goal_bytes",
+			Help:      "Heap size the garbage collector targets for the next cycle.",
+		}, labels).With(labelsAndValues...),
+		MemoryLimitBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "memory_limit_bytes",
+			Help:      "Soft memory limit of the runtime, math.MaxInt64 if none.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		GCPauseSeconds:   discard.NewHistogram(),
+		GCCycles:         discard.NewCounter(),
+		HeapGoalBytes:    discard.NewGauge(),
+		MemoryLimitBytes: discard.NewGauge(),
+	}
+}
@@ -0,0 +1,25 @@
+package memtune
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "runtime"
+)
+
+//go:generate go run ../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains the metrics of the Go runtime memory management.
+type Metrics struct {
+	// Duration of the stop-the-world pauses of the garbage collector.
+	GCPauseSeconds metrics.Histogram `metrics_name:"gc_pause_seconds" metrics_bucketsizes:"0.00001, 1, 16" metrics_buckettype:"exprange"`
+	// Number of completed garbage collection cycles.
+	GCCycles metrics.Counter `metrics_name:"gc_cycles"`
+	// Heap size the garbage collector targets for the next cycle.
+	HeapGoalBytes metrics.Gauge
+	// Soft memory limit of the runtime, math.MaxInt64 if none.
+	MemoryLimitBytes metrics.Gauge
+}
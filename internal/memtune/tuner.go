@@ -0,0 +1,130 @@
+// Package memtune applies the memory management settings of the node to the
+// Go runtime, and reports the pauses of the garbage collector, so that the
+// operators can trade memory for latency without setting GOGC and GOMEMLIMIT
+// in wrapper scripts.
+package memtune
+
+import (
+	"runtime/debug"
+	rtmetrics "runtime/metrics"
+	"time"
+
+	"github.com/cometbft/cometbft/internal/service"
+)
+
+// pollInterval is the interval at which the runtime statistics are read.
+const pollInterval = 5 * time.Second
+
+const heapGoalMetric = "/gc/heap/goal:bytes"
+
+// Config holds the memory management settings applied to the runtime.
+type Config struct {
+	// GCPercent is the growth of the heap, in percent of the live heap, which
+	// triggers a collection, like GOGC. 0 keeps the setting of the runtime,
+	// and -1 disables the collections until the memory limit is reached.
+	GCPercent int
+	// MemoryLimit is the soft memory limit of the runtime in bytes, like
+	// GOMEMLIMIT. 0 keeps the setting of the runtime.
+	MemoryLimit int64
+	// Ballast is the size in bytes of an allocation kept alive to raise the
+	// heap goal, and so space out the collections of a small live heap. It is
+	// never written to, so that it takes no physical memory. 0 allocates none.
+	Ballast int64
+}
+
+// Tuner applies the Config on start, restores the previous settings on stop,
+// and meanwhile records the statistics of the garbage collector.
+type Tuner struct {
+	service.BaseService
+
+	config  Config
+	metrics *Metrics
+
+	ballast         []byte
+	prevGCPercent   int
+	prevMemoryLimit int64
+
+	numGC   int64
+	gcStats debug.GCStats
+	samples []rtmetrics.Sample
+}
+
+// NewTuner returns a Tuner applying config and recording metrics.
+func NewTuner(config Config, metrics *Metrics) *Tuner {
+	t := &Tuner{
+		config:  config,
+		metrics: metrics,
+		samples: []rtmetrics.Sample{{Name: heapGoalMetric}},
+	}
+	t.BaseService = *service.NewBaseService(nil, "MemTuner", t)
+	return t
+}
+
+// OnStart implements service.Service by applying the settings and starting to
+// record the statistics.
+func (t *Tuner) OnStart() error {
+	if t.config.GCPercent != 0 {
+		t.prevGCPercent = debug.SetGCPercent(t.config.GCPercent)
+	}
+	if t.config.MemoryLimit > 0 {
+		t.prevMemoryLimit = debug.SetMemoryLimit(t.config.MemoryLimit)
+	}
+	if t.config.Ballast > 0 {
+		t.ballast = make([]byte, t.config.Ballast)
+	}
+	t.Logger.Info("Applied the memory settings",
+		"gc_percent", t.config.GCPercent, "memory_limit", t.config.MemoryLimit, "ballast", t.config.Ballast)
+
+	t.collect()
+	go t.run()
+	return nil
+}
+
+// OnStop implements service.Service by restoring the previous settings and
+// releasing the ballast.
+func (t *Tuner) OnStop() {
+	if t.config.GCPercent != 0 {
+		debug.SetGCPercent(t.prevGCPercent)
+	}
+	if t.config.MemoryLimit > 0 {
+		debug.SetMemoryLimit(t.prevMemoryLimit)
+	}
+	t.ballast = nil
+}
+
+func (t *Tuner) run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.collect()
+		case <-t.Quit():
+			return
+		}
+	}
+}
+
+// collect records the pauses of the collections completed since the last call,
+// as far as the runtime keeps them, and the current heap goal and memory limit.
+func (t *Tuner) collect() {
+	debug.ReadGCStats(&t.gcStats)
+	cycles := t.gcStats.NumGC - t.numGC
+	pauses := cycles
+	if n := int64(len(t.gcStats.Pause)); pauses > n {
+		pauses = n
+	}
+	// The pauses are the most recent first.
+	for _, pause := range t.gcStats.Pause[:pauses] {
+		t.metrics.GCPauseSeconds.Observe(pause.Seconds())
+	}
+	t.metrics.GCCycles.Add(float64(cycles))
+	t.numGC = t.gcStats.NumGC
+
+	rtmetrics.Read(t.samples)
+	if t.samples[0].Value.Kind() == rtmetrics.KindUint64 {
+		t.metrics.HeapGoalBytes.Set(float64(t.samples[0].Value.Uint64()))
+	}
+	// A negative limit only reads the current one.
+	t.metrics.MemoryLimitBytes.Set(float64(debug.SetMemoryLimit(-1)))
+}
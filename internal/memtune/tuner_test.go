@@ -0,0 +1,38 @@
+package memtune
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTuner(t *testing.T) {
+	prevGCPercent := debug.SetGCPercent(100)
+	prevMemoryLimit := debug.SetMemoryLimit(-1)
+	t.Cleanup(func() {
+		debug.SetGCPercent(prevGCPercent)
+		debug.SetMemoryLimit(prevMemoryLimit)
+	})
+
+	tuner := NewTuner(Config{GCPercent: 50, MemoryLimit: 1 << 40, Ballast: 1 << 20}, NopMetrics())
+	require.NoError(t, tuner.Start())
+	require.Equal(t, 50, debug.SetGCPercent(50))
+	require.Equal(t, int64(1<<40), debug.SetMemoryLimit(-1))
+	require.Len(t, tuner.ballast, 1<<20)
+
+	runtime.GC()
+	tuner.collect()
+	numGC := tuner.numGC
+	require.Positive(t, numGC)
+	runtime.GC()
+	tuner.collect()
+	require.Greater(t, tuner.numGC, numGC)
+
+	// The previous settings are restored on stop.
+	require.NoError(t, tuner.Stop())
+	require.Equal(t, 100, debug.SetGCPercent(100))
+	require.Equal(t, prevMemoryLimit, debug.SetMemoryLimit(-1))
+	require.Nil(t, tuner.ballast)
+}
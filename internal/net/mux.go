@@ -0,0 +1,216 @@
+package net
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Protocol is a protocol served by a Mux.
+type Protocol int
+
+const (
+	// ProtocolP2P is the protocol of the peer-to-peer connections, used for
+	// all the connections which are not recognized as another protocol.
+	ProtocolP2P Protocol = iota
+	// ProtocolHTTP is HTTP/1.x, possibly over TLS, as used by the RPC server.
+	ProtocolHTTP
+	// ProtocolGRPC is HTTP/2 without TLS, as used by the gRPC server.
+	ProtocolGRPC
+)
+
+// sniffLen is the number of bytes read to detect the protocol of a
+// connection.
+const sniffLen = 4
+
+var (
+	// ErrMuxClosed is returned by the listeners of a closed Mux.
+	ErrMuxClosed = errors.New("mux closed")
+
+	http2Preface = []byte("PRI ")
+	httpMethods  = [][]byte{
+		[]byte("GET "), []byte("POST"), []byte("PUT "), []byte("HEAD"), []byte("DELE"),
+		[]byte("OPTI"), []byte("PATC"), []byte("CONN"), []byte("TRAC"),
+	}
+	// TLS handshake record, protocol version 3.x.
+	tlsHandshake = []byte{0x16, 0x03}
+)
+
+// Mux serves several protocols on a single listener, e.g. so that P2P, RPC
+// and gRPC can share a single port. The protocol of every accepted
+// connection is detected from its first bytes, which are then replayed, and
+// the connection is handed over to the listener of this protocol, if any.
+type Mux struct {
+	root         net.Listener
+	sniffTimeout time.Duration
+
+	mtx       sync.Mutex
+	listeners map[Protocol]*muxListener
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMux returns a Mux accepting connections from root. Connections whose
+// protocol is not detected within sniffTimeout are closed.
+func NewMux(root net.Listener, sniffTimeout time.Duration) *Mux {
+	return &Mux{
+		root:         root,
+		sniffTimeout: sniffTimeout,
+		listeners:    make(map[Protocol]*muxListener),
+		done:         make(chan struct{}),
+	}
+}
+
+// Listener returns the listener of the connections using protocol. The
+// connections using a protocol without listener are closed.
+func (m *Mux) Listener(protocol Protocol) net.Listener {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	ln, ok := m.listeners[protocol]
+	if !ok {
+		ln = &muxListener{
+			mux:   m,
+			conns: make(chan net.Conn),
+			done:  make(chan struct{}),
+		}
+		m.listeners[protocol] = ln
+	}
+	return ln
+}
+
+// Serve accepts connections until the root listener fails or the Mux is
+// closed. It blocks.
+func (m *Mux) Serve() error {
+	for {
+		c, err := m.root.Accept()
+		if err != nil {
+			select {
+			case <-m.done:
+				return nil
+			default:
+				return err
+			}
+		}
+		go m.dispatch(c)
+	}
+}
+
+// Close closes the root listener and all the protocol listeners.
+func (m *Mux) Close() error {
+	var err error
+	m.closeOnce.Do(func() {
+		close(m.done)
+		err = m.root.Close()
+	})
+	return err
+}
+
+// Addr returns the address of the root listener.
+func (m *Mux) Addr() net.Addr {
+	return m.root.Addr()
+}
+
+func (m *Mux) dispatch(c net.Conn) {
+	prefix := make([]byte, sniffLen)
+	if err := c.SetReadDeadline(time.Now().Add(m.sniffTimeout)); err != nil {
+		c.Close()
+		return
+	}
+	n, err := io.ReadFull(c, prefix)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		c.Close()
+		return
+	}
+	if err := c.SetReadDeadline(time.Time{}); err != nil {
+		c.Close()
+		return
+	}
+	prefix = prefix[:n]
+
+	m.mtx.Lock()
+	ln, ok := m.listeners[detectProtocol(prefix)]
+	m.mtx.Unlock()
+	if !ok {
+		c.Close()
+		return
+	}
+
+	select {
+	case ln.conns <- &sniffedConn{Conn: c, prefix: prefix}:
+	case <-ln.done:
+		c.Close()
+	case <-m.done:
+		c.Close()
+	}
+}
+
+// detectProtocol returns the protocol of a connection given its first bytes.
+func detectProtocol(prefix []byte) Protocol {
+	if bytes.Equal(prefix, http2Preface) {
+		return ProtocolGRPC
+	}
+	if bytes.HasPrefix(prefix, tlsHandshake) {
+		return ProtocolHTTP
+	}
+	for _, method := range httpMethods {
+		if bytes.Equal(prefix, method) {
+			return ProtocolHTTP
+		}
+	}
+	return ProtocolP2P
+}
+
+// muxListener is the listener of the connections of a protocol.
+type muxListener struct {
+	mux       *Mux
+	conns     chan net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ net.Listener = (*muxListener)(nil)
+
+// Accept implements net.Listener.
+func (ln *muxListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-ln.conns:
+		return c, nil
+	case <-ln.done:
+		return nil, ErrMuxClosed
+	case <-ln.mux.done:
+		return nil, ErrMuxClosed
+	}
+}
+
+// Close implements net.Listener. It does not close the Mux, nor the other
+// protocol listeners.
+func (ln *muxListener) Close() error {
+	ln.closeOnce.Do(func() { close(ln.done) })
+	return nil
+}
+
+// Addr implements net.Listener.
+func (ln *muxListener) Addr() net.Addr {
+	return ln.mux.Addr()
+}
+
+// sniffedConn replays the bytes read to detect the protocol of a connection.
+type sniffedConn struct {
+	net.Conn
+	prefix []byte
+}
+
+// Read implements net.Conn.
+func (c *sniffedConn) Read(b []byte) (int, error) {
+	if len(c.prefix) > 0 {
+		n := copy(b, c.prefix)
+		c.prefix = c.prefix[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
+}
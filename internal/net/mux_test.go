@@ -0,0 +1,80 @@
+package net
+
+import (
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectProtocol(t *testing.T) {
+	testCases := []struct {
+		prefix   []byte
+		protocol Protocol
+	}{
+		{[]byte("GET "), ProtocolHTTP},
+		{[]byte("POST"), ProtocolHTTP},
+		{[]byte{0x16, 0x03, 0x01, 0x02}, ProtocolHTTP},
+		{[]byte("PRI "), ProtocolGRPC},
+		{[]byte{0x22, 0x0a, 0x20, 0x01}, ProtocolP2P},
+		{[]byte("GE"), ProtocolP2P},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.protocol, detectProtocol(tc.prefix), "%q", tc.prefix)
+	}
+}
+
+func TestMux(t *testing.T) {
+	root, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	mux := NewMux(root, time.Second)
+	listeners := map[Protocol]net.Listener{
+		ProtocolP2P:  mux.Listener(ProtocolP2P),
+		ProtocolHTTP: mux.Listener(ProtocolHTTP),
+	}
+	go mux.Serve() //nolint:errcheck // ignore for tests
+	defer mux.Close()
+
+	send := func(msg string) net.Conn {
+		c, err := net.Dial("tcp", root.Addr().String())
+		require.NoError(t, err)
+		_, err = c.Write([]byte(msg))
+		require.NoError(t, err)
+		return c
+	}
+
+	for protocol, msg := range map[Protocol]string{
+		ProtocolHTTP: "GET /status HTTP/1.1\r\n\r\n",
+		ProtocolP2P:  "\x22\x0a\x20some key",
+	} {
+		c := send(msg)
+		defer c.Close()
+
+		sc, err := listeners[protocol].Accept()
+		require.NoError(t, err)
+		// the sniffed bytes are replayed
+		buf := make([]byte, len(msg))
+		_, err = io.ReadFull(sc, buf)
+		require.NoError(t, err)
+		assert.Equal(t, msg, string(buf))
+		assert.Equal(t, c.LocalAddr().String(), sc.RemoteAddr().String())
+		sc.Close()
+	}
+
+	// no gRPC listener: the connection is closed, with a reset if the bytes
+	// past the sniffed ones were not read
+	c := send("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n")
+	defer c.Close()
+	require.NoError(t, c.SetReadDeadline(time.Now().Add(time.Second)))
+	_, err = c.Read(make([]byte, 1))
+	require.Error(t, err)
+	assert.False(t, os.IsTimeout(err), "the connection was not closed")
+
+	require.NoError(t, mux.Close())
+	_, err = listeners[ProtocolP2P].Accept()
+	assert.ErrorIs(t, err, ErrMuxClosed)
+}
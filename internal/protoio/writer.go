@@ -55,7 +55,7 @@ func (w *varintWriter) WriteMsg(msg proto.Message) (int, error) {
 	if m, ok := msg.(marshaler); ok {
 		n, ok := getSize(m)
 		if ok {
-			if n+binary.MaxVarintLen64 >= len(w.buffer) {
+			if n+binary.MaxVarintLen64 > len(w.buffer) {
 				w.buffer = make([]byte, n+binary.MaxVarintLen64)
 			}
 			lenOff := binary.PutUvarint(w.buffer, uint64(n))
@@ -0,0 +1,53 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package pubsub
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		MessagesSent: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "messages_sent",
+			Help:      "Number of messages published to the subscriptions.",
+		}, append(labels, "policy")).With(labelsAndValues...),
+		MessagesDropped: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "messages_dropped",
+			Help:      "Number of messages dropped because the buffer of a subscription was full.",
+		}, append(labels, "policy")).With(labelsAndValues...),
+		SubscriptionsCanceled: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "subscriptions_canceled",
+			Help:      "Number of subscriptions canceled because their buffer was full.",
+		}, append(labels, "policy")).With(labelsAndValues...),
+		PublishBlockedSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "publish_blocked_seconds",
+			Help:      "Time the server waited for the subscribers to make room in the buffer of their subscriptions, during which no message is published.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.001, 10, 8),
+		}, append(labels, "policy")).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		MessagesSent:          discard.NewCounter(),
+		MessagesDropped:       discard.NewCounter(),
+		SubscriptionsCanceled: discard.NewCounter(),
+		PublishBlockedSeconds: discard.NewHistogram(),
+	}
+}
@@ -0,0 +1,28 @@
+package pubsub
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "pubsub"
+)
+
+//go:generate go run ../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains the metrics exposed by the pubsub package, per overflow
+// policy of the subscriptions (see OverflowPolicy), or "unbuffered".
+type Metrics struct {
+	// Number of messages published to the subscriptions.
+	MessagesSent metrics.Counter `metrics_labels:"policy"`
+	// Number of messages dropped because the buffer of a subscription was
+	// full.
+	MessagesDropped metrics.Counter `metrics_labels:"policy"`
+	// Number of subscriptions canceled because their buffer was full.
+	SubscriptionsCanceled metrics.Counter `metrics_labels:"policy"`
+	// Time the server waited for the subscribers to make room in the buffer
+	// of their subscriptions, during which no message is published.
+	PublishBlockedSeconds metrics.Histogram `metrics_bucketsizes:"0.001, 10, 8" metrics_buckettype:"exprange" metrics_labels:"policy"`
+}
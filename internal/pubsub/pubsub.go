@@ -37,6 +37,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cometbft/cometbft/internal/service"
 	cmtsync "github.com/cometbft/cometbft/internal/sync"
@@ -93,6 +94,8 @@ type Server struct {
 	cmds    chan cmd
 	cmdsCap int
 
+	metrics *Metrics
+
 	// check if we have subscription before
 	// subscribing or unsubscribing
 	mtx           cmtsync.RWMutex
@@ -108,6 +111,7 @@ type Option func(*Server)
 func NewServer(options ...Option) *Server {
 	s := &Server{
 		subscriptions: make(map[string]map[string]struct{}),
+		metrics:       NopMetrics(),
 	}
 	s.BaseService = *service.NewBaseService(nil, "PubSub", s)
 
@@ -133,6 +137,13 @@ func BufferCapacity(cap int) Option {
 	}
 }
 
+// WithMetrics sets the metrics of the subscribers.
+func WithMetrics(metrics *Metrics) Option {
+	return func(s *Server) {
+		s.metrics = metrics
+	}
+}
+
 // BufferCapacity returns capacity of the internal server's queue.
 func (s *Server) BufferCapacity() int {
 	return s.cmdsCap
@@ -160,17 +171,42 @@ func (s *Server) Subscribe(
 		outCap = outCapacity[0]
 	}
 
-	return s.subscribe(ctx, clientID, query, outCap)
+	return s.subscribe(ctx, clientID, query, outCap, CancelOnOverflow, 0)
+}
+
+// SubscribeWithPolicy does the same as Subscribe, except that once the buffer
+// of outCapacity messages of the subscription is full, the messages published
+// to it are handled according to policy. blockTimeout is the maximum time the
+// server blocks on the subscription with BlockOnOverflow.
+func (s *Server) SubscribeWithPolicy(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	outCapacity int,
+	policy OverflowPolicy,
+	blockTimeout time.Duration,
+) (*Subscription, error) {
+	if outCapacity <= 0 {
+		panic("Negative or zero capacity. Use SubscribeUnbuffered if you want an unbuffered channel")
+	}
+	return s.subscribe(ctx, clientID, query, outCapacity, policy, blockTimeout)
 }
 
 // SubscribeUnbuffered does the same as Subscribe, except it returns a
 // subscription with unbuffered channel. Use with caution as it can freeze the
 // server.
 func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query Query) (*Subscription, error) {
-	return s.subscribe(ctx, clientID, query, 0)
+	return s.subscribe(ctx, clientID, query, 0, CancelOnOverflow, 0)
 }
 
-func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
+func (s *Server) subscribe(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	outCapacity int,
+	policy OverflowPolicy,
+	blockTimeout time.Duration,
+) (*Subscription, error) {
 	s.mtx.RLock()
 	clientSubscriptions, ok := s.subscriptions[clientID]
 	if ok {
@@ -182,6 +218,8 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 	}
 
 	subscription := NewSubscription(outCapacity)
+	subscription.policy = policy
+	subscription.blockTimeout = blockTimeout
 	select {
 	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
 		s.mtx.Lock()
@@ -296,6 +334,8 @@ type state struct {
 	subscriptions map[string]map[string]*Subscription
 	// query string -> queryPlusRefCount
 	queries map[string]*queryPlusRefCount
+
+	metrics *Metrics
 }
 
 // queryPlusRefCount holds a pointer to a query and reference counter. When
@@ -310,6 +350,7 @@ func (s *Server) OnStart() error {
 	go s.loop(state{
 		subscriptions: make(map[string]map[string]*Subscription),
 		queries:       make(map[string]*queryPlusRefCount),
+		metrics:       s.metrics,
 	})
 	return nil
 }
@@ -415,16 +456,9 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 
 		if match {
 			for clientID, subscription := range clientSubscriptions {
-				if cap(subscription.out) == 0 {
-					// block on unbuffered channel
-					subscription.out <- NewMessage(msg, events)
-				} else {
-					// don't block on buffered channels
-					select {
-					case subscription.out <- NewMessage(msg, events):
-					default:
-						state.remove(clientID, qStr, ErrOutOfCapacity)
-					}
+				if !state.deliver(subscription, NewMessage(msg, events)) {
+					state.metrics.SubscriptionsCanceled.With("policy", subscription.metricsLabel()).Add(1)
+					state.remove(clientID, qStr, ErrOutOfCapacity)
 				}
 			}
 		}
@@ -432,3 +466,58 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 
 	return nil
 }
+
+// deliver pushes msg to the subscription, applying its overflow policy if its
+// buffer is full. It returns false if the subscription must be canceled.
+func (state *state) deliver(subscription *Subscription, msg Message) bool {
+	policy := subscription.metricsLabel()
+	if cap(subscription.out) == 0 {
+		// block on unbuffered channel
+		start := time.Now()
+		subscription.out <- msg
+		state.metrics.PublishBlockedSeconds.With("policy", policy).Observe(time.Since(start).Seconds())
+		state.metrics.MessagesSent.With("policy", policy).Add(1)
+		return true
+	}
+
+	// don't block on buffered channels, unless the policy says so
+	select {
+	case subscription.out <- msg:
+		state.metrics.MessagesSent.With("policy", policy).Add(1)
+		return true
+	default:
+	}
+
+	switch subscription.policy {
+	case DropOldestOnOverflow:
+		select {
+		case <-subscription.out:
+			state.metrics.MessagesDropped.With("policy", policy).Add(1)
+		default:
+			// The subscriber made room in the meantime.
+		}
+		// Only the server sends to out, so there is room for msg.
+		subscription.out <- msg
+		state.metrics.MessagesSent.With("policy", policy).Add(1)
+		return true
+	case DropNewestOnOverflow:
+		state.metrics.MessagesDropped.With("policy", policy).Add(1)
+		return true
+	case BlockOnOverflow:
+		start := time.Now()
+		timer := time.NewTimer(subscription.blockTimeout)
+		defer timer.Stop()
+		defer func() {
+			state.metrics.PublishBlockedSeconds.With("policy", policy).Observe(time.Since(start).Seconds())
+		}()
+		select {
+		case subscription.out <- msg:
+			state.metrics.MessagesSent.With("policy", policy).Add(1)
+			return true
+		case <-timer.C:
+			return false
+		}
+	default:
+		return false
+	}
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/cometbft/cometbft/internal/pubsub"
 	"github.com/cometbft/cometbft/internal/pubsub/query"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -147,6 +150,85 @@ func TestSlowClientIsRemovedWithErrOutOfCapacity(t *testing.T) {
 	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
 }
 
+func TestSubscribeDropOldestOnOverflow(t *testing.T) {
+	dropped := stdprometheus.NewCounterVec(stdprometheus.CounterOpts{Name: "dropped"}, []string{"policy"})
+	metrics := pubsub.NopMetrics()
+	metrics.MessagesDropped = prometheus.NewCounter(dropped)
+	s := startServer(t, pubsub.WithMetrics(metrics))
+
+	ctx := context.Background()
+	subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.All, 2, pubsub.DropOldestOnOverflow, 0)
+	require.NoError(t, err)
+	for _, msg := range []string{"Fat Cobra", "Viper", "Ivan", "Asylum"} {
+		require.NoError(t, s.Publish(ctx, msg))
+	}
+	waitProcessed(t, s)
+
+	assertReceive(t, "Ivan", subscription.Out())
+	assertReceive(t, "Asylum", subscription.Out())
+	assert.Nil(t, subscription.Err())
+	assert.Equal(t, 2.0, testutil.ToFloat64(dropped.WithLabelValues("drop_oldest")))
+}
+
+func TestSubscribeDropNewestOnOverflow(t *testing.T) {
+	s := startServer(t)
+
+	ctx := context.Background()
+	subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.All, 2, pubsub.DropNewestOnOverflow, 0)
+	require.NoError(t, err)
+	for _, msg := range []string{"Fat Cobra", "Viper", "Ivan"} {
+		require.NoError(t, s.Publish(ctx, msg))
+	}
+	waitProcessed(t, s)
+
+	assertReceive(t, "Fat Cobra", subscription.Out())
+	assertReceive(t, "Viper", subscription.Out())
+	require.NoError(t, s.Publish(ctx, "Asylum"))
+	assertReceive(t, "Asylum", subscription.Out())
+	assert.Nil(t, subscription.Err())
+}
+
+func TestSubscribeBlockOnOverflow(t *testing.T) {
+	s := startServer(t)
+
+	ctx := context.Background()
+	subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.All, 1, pubsub.BlockOnOverflow, time.Second)
+	require.NoError(t, err)
+	require.NoError(t, s.Publish(ctx, "Fat Cobra"))
+	require.NoError(t, s.Publish(ctx, "Viper"))
+
+	// The server waits for the subscriber to make room for Viper.
+	time.Sleep(100 * time.Millisecond)
+	assertReceive(t, "Fat Cobra", subscription.Out())
+	assertReceive(t, "Viper", subscription.Out())
+
+	// It cancels the subscription once the timeout expires.
+	require.NoError(t, s.Publish(ctx, "Ivan"))
+	require.NoError(t, s.Publish(ctx, "Asylum"))
+	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
+}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	for name, policy := range map[string]pubsub.OverflowPolicy{
+		"cancel":      pubsub.CancelOnOverflow,
+		"drop_oldest": pubsub.DropOldestOnOverflow,
+		"drop_newest": pubsub.DropNewestOnOverflow,
+		"block":       pubsub.BlockOnOverflow,
+	} {
+		parsed, err := pubsub.ParseOverflowPolicy(name)
+		require.NoError(t, err)
+		assert.Equal(t, policy, parsed)
+	}
+	_, err := pubsub.ParseOverflowPolicy("drop")
+	require.Error(t, err)
+
+	for _, policy := range []pubsub.OverflowPolicy{pubsub.CancelOnOverflow, pubsub.BlockOnOverflow} {
+		parsed, err := pubsub.ParseOverflowPolicy(policy.String())
+		require.NoError(t, err)
+		assert.Equal(t, policy, parsed)
+	}
+}
+
 func TestDifferentClients(t *testing.T) {
 	s := pubsub.NewServer()
 	s.SetLogger(log.TestingLogger())
@@ -490,6 +572,29 @@ func benchmarkNClientsOneQuery(n int, b *testing.B) {
 
 // HELPERS
 
+func startServer(t *testing.T, options ...pubsub.Option) *pubsub.Server {
+	t.Helper()
+	s := pubsub.NewServer(options...)
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return s
+}
+
+// waitProcessed waits for the server to process the messages published so
+// far, as it processes the commands in order.
+func waitProcessed(t *testing.T, s *pubsub.Server) {
+	t.Helper()
+	q := query.MustCompile("tm.events.type='None'")
+	_, err := s.Subscribe(context.Background(), "wait-processed", q)
+	require.NoError(t, err)
+	require.NoError(t, s.UnsubscribeAll(context.Background(), "wait-processed"))
+}
+
 func assertReceive(t *testing.T, expected interface{}, ch <-chan pubsub.Message, msgAndArgs ...interface{}) {
 	select {
 	case actual := <-ch:
@@ -2,6 +2,7 @@
 // subscriptions in CometBFT.
 //
 //	abci.invoice.number=22 AND abci.invoice.owner=Ivan
+//	transfer.sender='X' OR transfer.recipient='X'
 //
 // Query expressions can handle attribute values encoding numbers, strings,
 // dates, and timestamps.  The complete query grammar is described in the
@@ -26,15 +27,21 @@ var All *Query
 type Query struct {
 	ast   syntax.Query
 	conds []condition
+
+	// or holds the alternatives of a query using OR; it is empty otherwise.
+	or []*Query
 }
 
 // New parses and compiles the query expression into an executable query.
 func New(query string) (*Query, error) {
-	ast, err := syntax.Parse(query)
+	ast, err := syntax.ParseDisjunction(query)
 	if err != nil {
 		return nil, err
 	}
-	return Compile(ast)
+	if len(ast) == 1 {
+		return Compile(ast[0])
+	}
+	return CompileDisjunction(ast)
 }
 
 // MustCompile compiles the query expression into an executable query.
@@ -63,6 +70,20 @@ func Compile(ast syntax.Query) (*Query, error) {
 	return &Query{ast: ast, conds: conds}, nil
 }
 
+// CompileDisjunction compiles the given disjunction AST so it can be used to
+// match events. The resulting query matches if any of its alternatives does.
+func CompileDisjunction(ast syntax.Disjunction) (*Query, error) {
+	or := make([]*Query, len(ast))
+	for i, q := range ast {
+		c, err := Compile(q)
+		if err != nil {
+			return nil, err
+		}
+		or[i] = c
+	}
+	return &Query{or: or}, nil
+}
+
 func ExpandEvents(flattenedEvents map[string][]string) []types.Event {
 	events := make([]types.Event, 0)
 
@@ -100,10 +121,18 @@ func (q *Query) String() string {
 	if q == nil {
 		return "<empty>"
 	}
+	if len(q.or) > 0 {
+		ss := make([]string, len(q.or))
+		for i, c := range q.or {
+			ss[i] = c.String()
+		}
+		return strings.Join(ss, " OR ")
+	}
 	return q.ast.String()
 }
 
-// Syntax returns the syntax tree representation of q.
+// Syntax returns the syntax tree representation of q. For a query using OR,
+// Syntax returns nil; use Disjuncts to access its alternatives.
 func (q *Query) Syntax() syntax.Query {
 	if q == nil {
 		return nil
@@ -111,8 +140,26 @@ func (q *Query) Syntax() syntax.Query {
 	return q.ast
 }
 
-// matchesEvents reports whether all the conditions match the given events.
+// Disjuncts returns the alternatives of a query using OR, each of which is a
+// conjunction of conditions. A query without OR is its only alternative.
+func (q *Query) Disjuncts() []*Query {
+	if q == nil || len(q.or) == 0 {
+		return []*Query{q}
+	}
+	return q.or
+}
+
+// matchesEvents reports whether all the conditions match the given events, or
+// for a query using OR, whether any alternative matches.
 func (q *Query) matchesEvents(events []types.Event) bool {
+	if len(q.or) > 0 {
+		for _, c := range q.or {
+			if c.matchesEvents(events) {
+				return true
+			}
+		}
+		return false
+	}
 	for _, cond := range q.conds {
 		if !cond.matchesAny(events) {
 			return false
@@ -221,7 +268,7 @@ func compileCondition(cond syntax.Condition) (condition, error) {
 // We use this regex to support queries of the from "8atom", "6.5stake",
 // which are actively used in production.
 // The regex takes care of removing the non-number suffix.
-var extractNum = regexp.MustCompile(`^\d+(\.\d+)?`)
+var extractNum = regexp.MustCompile(`^-?\d+(\.\d+)?`)
 
 func parseNumber(s string) (*big.Float, error) {
 	intVal := new(big.Int)
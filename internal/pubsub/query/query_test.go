@@ -322,6 +322,27 @@ func TestCompiledMatches(t *testing.T) {
 			newTestEvents(`body|weight=3.5`),
 			true,
 		},
+		{
+			`account.balance < -5`,
+			newTestEvents(`account|balance=-10`),
+			true,
+		},
+		{
+			`account.balance > -5.5 AND account.balance < 0`,
+			newTestEvents(`account|balance=-5stake`),
+			true,
+		},
+		{
+			`account.balance = -5`,
+			newTestEvents(`account|balance=5`),
+			false,
+		},
+		{
+			// Numbers are not compared as strings.
+			`tx.gas_used > 1000000`,
+			newTestEvents(`tx|gas_used=999999`),
+			false,
+		},
 		{
 			`account.balance < 1000.0`,
 			newTestEvents(`account|balance=900`),
@@ -514,6 +535,56 @@ func TestCompiledMatches(t *testing.T) {
 	}
 }
 
+func TestDisjunctionMatches(t *testing.T) {
+	testCases := []struct {
+		s         string
+		events    map[string][]string
+		matches   bool
+		disjuncts int
+	}{
+		{
+			`transfer.sender='X' OR transfer.recipient='X'`,
+			newTestEvents(`transfer|sender=X|recipient=Y`),
+			true, 2,
+		},
+		{
+			`transfer.sender='X' OR transfer.recipient='X'`,
+			newTestEvents(`transfer|sender=Y|recipient=X`),
+			true, 2,
+		},
+		{
+			`transfer.sender='X' OR transfer.recipient='X'`,
+			newTestEvents(`transfer|sender=Y|recipient=Z`),
+			false, 2,
+		},
+		{
+			`transfer.sender='X' AND tx.height > 5 OR transfer.recipient='X'`,
+			newTestEvents(`transfer|sender=X`, `tx|height=3`),
+			false, 2,
+		},
+		{
+			`tx.height BETWEEN 3 AND 5`,
+			newTestEvents(`tx|height=5`),
+			true, 1,
+		},
+		{
+			`tx.height BETWEEN 3 AND 5`,
+			newTestEvents(`tx|height=6`),
+			false, 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		q, err := query.New(tc.s)
+		require.NoError(t, err)
+		require.Len(t, q.Disjuncts(), tc.disjuncts)
+
+		got, err := q.Matches(tc.events)
+		require.NoError(t, err)
+		require.Equal(t, tc.matches, got, "query %#q, events %v", tc.s, tc.events)
+	}
+}
+
 func sortEvents(events []types.Event) []types.Event {
 	sort.Slice(events, func(i, j int) bool {
 		if events[i].Type == events[j].Type {
@@ -5,13 +5,15 @@
 //
 // The grammar of the query language is defined by the following EBNF:
 //
-//	query      = conditions EOF
+//	query      = conditions {"OR" conditions} EOF
 //	conditions = condition {"AND" condition}
 //	condition  = tag comparison
-//	comparison = equal / order / contains / "EXISTS"
+//	comparison = equal / order / contains / range / "EXISTS"
 //	equal      = "=" (date / number / time / value)
 //	order      = cmp (date / number / time)
 //	contains   = "CONTAINS" value
+//	range      = "BETWEEN" bound "AND" bound
+//	bound      = date / number / time
 //	cmp        = "<" / "<=" / ">" / ">="
 //
 // The lexical terms are defined here using RE2 regular expression notation:
@@ -22,12 +24,16 @@
 //	// A datestamp (YYYY-MM-DD)
 //	date   = #'DATE \d{4}-\d{2}-\d{2}'
 //
-//	// A number with optional fractional parts (0, 10, 3.25)
-//	number = #'\d+(\.\d+)?'
+//	// A number with an optional sign and fractional part (0, 10, 3.25, -7)
+//	number = #'-?\d+(\.\d+)?'
 //
 //	// An RFC3339 timestamp (2021-11-23T22:04:19-09:00)
 //	time   = #'TIME \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}([-+]\d{2}:\d{2}|Z)'
 //
 //	// A quoted literal string value ('a b c')
 //	value  = #'\'[^\']*\''
+//
+// AND binds more tightly than OR, so "a AND b OR c" selects events matching
+// both a and b, or matching c. A range "x BETWEEN lo AND hi" is inclusive and
+// equivalent to "x >= lo AND x <= hi"; both bounds must be of the same type.
 package syntax
@@ -14,6 +14,25 @@ func Parse(s string) (Query, error) {
 	return NewParser(strings.NewReader(s)).Parse()
 }
 
+// ParseDisjunction parses the specified query string, which may combine
+// conjunctions with OR. It is shorthand for constructing a parser for s and
+// calling its ParseDisjunction method.
+func ParseDisjunction(s string) (Disjunction, error) {
+	return NewParser(strings.NewReader(s)).ParseDisjunction()
+}
+
+// Disjunction is the root of the parse tree for a query using OR. A
+// disjunction is satisfied if any of its queries is satisfied.
+type Disjunction []Query
+
+func (d Disjunction) String() string {
+	ss := make([]string, len(d))
+	for i, q := range d {
+		ss[i] = q.String()
+	}
+	return strings.Join(ss, " OR ")
+}
+
 // Query is the root of the parse tree for a query.  A query is the conjunction
 // of one or more conditions.
 type Query []Condition
@@ -136,35 +155,70 @@ func NewParser(r io.Reader) *Parser {
 	return &Parser{scanner: NewScanner(r)}
 }
 
-// Parse parses the complete input and returns the resulting query.
+// Parse parses the complete input and returns the resulting query. The input
+// must not contain OR operators; use ParseDisjunction for those.
 func (p *Parser) Parse() (Query, error) {
-	cond, err := p.parseCond()
+	q, more, err := p.parseConjunction()
 	if err != nil {
 		return nil, err
 	}
-	conds := []Condition{cond}
+	if more {
+		return nil, fmt.Errorf("offset %d: got %v, want %v", p.scanner.Pos(), TOr, TAnd)
+	}
+	return q, nil
+}
+
+// ParseDisjunction parses the complete input and returns the resulting
+// disjunction. A query without OR operators yields a single conjunction.
+func (p *Parser) ParseDisjunction() (Disjunction, error) {
+	var d Disjunction
+	for {
+		q, more, err := p.parseConjunction()
+		if err != nil {
+			return nil, err
+		}
+		d = append(d, q)
+		if !more {
+			return d, nil
+		}
+	}
+}
+
+// parseConjunction parses conditions joined by AND. It reports whether the
+// conjunction was terminated by an OR operator rather than the end of input.
+func (p *Parser) parseConjunction() (Query, bool, error) {
+	conds, err := p.parseCond()
+	if err != nil {
+		return nil, false, err
+	}
 	for p.scanner.Next() != io.EOF {
-		if tok := p.scanner.Token(); tok != TAnd {
-			return nil, fmt.Errorf("offset %d: got %v, want %v", p.scanner.Pos(), tok, TAnd)
+		switch tok := p.scanner.Token(); tok {
+		case TAnd:
+		case TOr:
+			return conds, true, nil
+		default:
+			return nil, false, fmt.Errorf("offset %d: got %v, want %v", p.scanner.Pos(), tok, TAnd)
 		}
-		cond, err := p.parseCond()
+		more, err := p.parseCond()
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		conds = append(conds, cond)
+		conds = append(conds, more...)
 	}
-	return conds, nil
+	return conds, false, nil
 }
 
-// parseCond parses a conditional expression: tag OP value.
-func (p *Parser) parseCond() (Condition, error) {
+// parseCond parses a conditional expression: tag OP value. A range expression
+// (tag BETWEEN lo AND hi) is expanded into the equivalent pair of conditions
+// tag >= lo AND tag <= hi.
+func (p *Parser) parseCond() ([]Condition, error) {
 	var cond Condition
 	if err := p.require(TTag); err != nil {
-		return cond, err
+		return nil, err
 	}
 	cond.Tag = p.scanner.Text()
-	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TExists); err != nil {
-		return cond, err
+	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TExists, TBetween); err != nil {
+		return nil, err
 	}
 	cond.Op = p.scanner.Token()
 	cond.opText = p.scanner.Text()
@@ -179,15 +233,37 @@ func (p *Parser) parseCond() (Condition, error) {
 		err = p.require(TString)
 	case TExists:
 		// no argument
-		return cond, nil
+		return []Condition{cond}, nil
+	case TBetween:
+		return p.parseBetween(cond.Tag)
 	default:
-		return cond, fmt.Errorf("offset %d: unexpected operator %v", p.scanner.Pos(), cond.Op)
+		return nil, fmt.Errorf("offset %d: unexpected operator %v", p.scanner.Pos(), cond.Op)
 	}
 	if err != nil {
-		return cond, err
+		return nil, err
 	}
 	cond.Arg = &Arg{Type: p.scanner.Token(), text: p.scanner.Text()}
-	return cond, nil
+	return []Condition{cond}, nil
+}
+
+// parseBetween parses the bounds of a range expression: lo AND hi. Both
+// bounds must be of the same type.
+func (p *Parser) parseBetween(tag string) ([]Condition, error) {
+	if err := p.require(TNumber, TTime, TDate); err != nil {
+		return nil, err
+	}
+	lo := &Arg{Type: p.scanner.Token(), text: p.scanner.Text()}
+	if err := p.require(TAnd); err != nil {
+		return nil, err
+	}
+	if err := p.require(lo.Type); err != nil {
+		return nil, err
+	}
+	hi := &Arg{Type: p.scanner.Token(), text: p.scanner.Text()}
+	return []Condition{
+		{Tag: tag, Op: TGeq, Arg: lo, opText: ">="},
+		{Tag: tag, Op: TLeq, Arg: hi, opText: "<="},
+	}, nil
 }
 
 // require advances the scanner and requires that the resulting token is one of
@@ -17,7 +17,7 @@ const (
 	TInvalid  = iota // invalid or unknown token
 	TTag             // field tag: x.y
 	TString          // string value: 'foo bar'
-	TNumber          // number: 0, 15.5, 100
+	TNumber          // number: 0, 15.5, -100
 	TTime            // timestamp: TIME yyyy-mm-ddThh:mm:ss([-+]hh:mm|Z)
 	TDate            // datestamp: DATE yyyy-mm-dd
 	TAnd             // operator: AND
@@ -28,6 +28,8 @@ const (
 	TLeq             // operator: <=
 	TGt              // operator: >
 	TGeq             // operator: >=
+	TOr              // operator: OR
+	TBetween         // operator: BETWEEN
 
 	// Do not reorder these values without updating the scanner code.
 )
@@ -47,6 +49,8 @@ var tString = [...]string{
 	TLeq:      "<= operator",
 	TGt:       "> operator",
 	TGeq:      ">= operator",
+	TOr:       "OR operator",
+	TBetween:  "BETWEEN operator",
 }
 
 func (t Token) String() string {
@@ -107,6 +111,8 @@ func (s *Scanner) Next() error {
 			return s.scanString(ch)
 		case '<', '>', '=':
 			return s.scanCompare(ch)
+		case '-':
+			return s.scanNegativeNumber(ch)
 		default:
 			return s.invalid(ch)
 		}
@@ -149,6 +155,20 @@ func (s *Scanner) scanNumber(first rune) error {
 	return nil
 }
 
+// scanNegativeNumber scans for numbers preceded by a minus sign.
+// Examples: -1, -3.14.
+func (s *Scanner) scanNegativeNumber(first rune) error {
+	ch, err := s.rune()
+	if err != nil && err != io.EOF {
+		return s.fail(err)
+	}
+	if !isDigit(ch) {
+		return s.invalid(first)
+	}
+	s.buf.WriteRune(first)
+	return s.scanNumber(ch)
+}
+
 func (s *Scanner) scanString(first rune) error {
 	// discard opening quote
 	for {
@@ -224,6 +244,10 @@ func (s *Scanner) scanTagLike(first rune) error {
 		s.tok = TTag
 	case "AND":
 		s.tok = TAnd
+	case "OR":
+		s.tok = TOr
+	case "BETWEEN":
+		s.tok = TBetween
 	case "EXISTS":
 		s.tok = TExists
 	case "CONTAINS":
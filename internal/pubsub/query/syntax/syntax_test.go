@@ -22,6 +22,7 @@ func TestScanner(t *testing.T) {
 		// Numbers
 		{`0 123`, []syntax.Token{syntax.TNumber, syntax.TNumber}},
 		{`0.32 3.14`, []syntax.Token{syntax.TNumber, syntax.TNumber}},
+		{`-23 -0.5`, []syntax.Token{syntax.TNumber, syntax.TNumber}},
 
 		// Tags
 		{`foo foo.bar`, []syntax.Token{syntax.TTag, syntax.TTag}},
@@ -40,6 +41,10 @@ func TestScanner(t *testing.T) {
 		{`x.y CONTAINS 'z'`, []syntax.Token{syntax.TTag, syntax.TContains, syntax.TString}},
 		{`foo EXISTS`, []syntax.Token{syntax.TTag, syntax.TExists}},
 		{`and AND`, []syntax.Token{syntax.TTag, syntax.TAnd}},
+		{`x OR y`, []syntax.Token{syntax.TTag, syntax.TOr, syntax.TTag}},
+		{`x BETWEEN 1 AND 2`, []syntax.Token{
+			syntax.TTag, syntax.TBetween, syntax.TNumber, syntax.TAnd, syntax.TNumber,
+		}},
 
 		// Timestamp
 		{`TIME 2021-11-23T15:16:17Z`, []syntax.Token{syntax.TTime}},
@@ -70,7 +75,9 @@ func TestScannerErrors(t *testing.T) {
 		input string
 	}{
 		{`'incomplete string`},
-		{`-23`},
+		{`-`},
+		{`- 23`},
+		{`-x`},
 		{`&`},
 		{`DATE xyz-pdq`},
 		{`DATE xyzp-dq-zv`},
@@ -156,7 +163,7 @@ func TestParseValid(t *testing.T) {
 
 		{"account.balance=100", true},
 		{"account.balance >= 200", true},
-		{"account.balance >= -300", false},
+		{"account.balance >= -300", true},
 		{"account.balance >>= 400", false},
 		{"account.balance=33.22.1", false},
 
@@ -167,6 +174,16 @@ func TestParseValid(t *testing.T) {
 
 		{"hash='136E18F7E4C348B780CF873A0BF43922E5BAFA63'", true},
 		{"hash=136E18F7E4C348B780CF873A0BF43922E5BAFA63", false},
+
+		{"tx.height BETWEEN 1 AND 10", true},
+		{"tx.date BETWEEN DATE 2013-05-03 AND DATE 2013-06-03", true},
+		{"tx.height BETWEEN 1 AND DATE 2013-05-03", false},
+		{"tx.height BETWEEN 1", false},
+		{"tx.height BETWEEN 1 OR 10", false},
+		{"tx.height BETWEEN 'a' AND 'b'", false},
+
+		// OR is only accepted by ParseDisjunction.
+		{"transfer.sender='X' OR transfer.recipient='X'", false},
 	}
 
 	for _, test := range tests {
@@ -188,3 +205,42 @@ func TestParseValid(t *testing.T) {
 		}
 	}
 }
+
+func TestParseDisjunction(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		valid bool
+	}{
+		{"a.b='x'", "a.b = 'x'", true},
+		{"a.b='x' OR a.c='x'", "a.b = 'x' OR a.c = 'x'", true},
+		{"a.b='x' AND a.c=1 OR a.d EXISTS", "a.b = 'x' AND a.c = 1 OR a.d EXISTS", true},
+		{"a.b='x' OR tx.height BETWEEN 1 AND 5", "a.b = 'x' OR tx.height >= 1 AND tx.height <= 5", true},
+		{"a.b='x' OR", "", false},
+		{"OR a.b='x'", "", false},
+		{"a.b='x' OR OR a.c='x'", "", false},
+		{"a.b='x' AND OR a.c='x'", "", false},
+	}
+
+	for _, test := range tests {
+		d, err := syntax.ParseDisjunction(test.input)
+		if test.valid != (err == nil) {
+			t.Errorf("ParseDisjunction %#q: valid %v got err=%v", test.input, test.valid, err)
+			continue
+		}
+		if !test.valid {
+			continue
+		}
+		if got := d.String(); got != test.want {
+			t.Errorf("ParseDisjunction %#q: got %#q, want %#q", test.input, got, test.want)
+		}
+
+		// Check that the disjunction round-trips.
+		r, err := syntax.ParseDisjunction(d.String())
+		if err != nil {
+			t.Errorf("Reparse %#q failed: %v", d.String(), err)
+		} else if r.String() != d.String() {
+			t.Errorf("Reparse diff\nold: %#q\nnew: %#q", d.String(), r.String())
+		}
+	}
+}
@@ -2,6 +2,8 @@ package pubsub
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	cmtsync "github.com/cometbft/cometbft/internal/sync"
 )
@@ -15,6 +17,51 @@ var (
 	ErrOutOfCapacity = errors.New("internal subscription event buffer is out of capacity")
 )
 
+// OverflowPolicy determines what happens to a message published to a
+// subscription whose buffer is full.
+type OverflowPolicy int
+
+const (
+	// CancelOnOverflow cancels the subscription with ErrOutOfCapacity.
+	CancelOnOverflow OverflowPolicy = iota
+	// DropOldestOnOverflow drops the oldest message in the buffer to make room
+	// for the message.
+	DropOldestOnOverflow
+	// DropNewestOnOverflow drops the message.
+	DropNewestOnOverflow
+	// BlockOnOverflow blocks the server until there is room for the message in
+	// the buffer, and cancels the subscription with ErrOutOfCapacity if there
+	// is none before the timeout of the subscription.
+	BlockOnOverflow
+)
+
+var overflowPolicies = map[string]OverflowPolicy{
+	"cancel":      CancelOnOverflow,
+	"drop_oldest": DropOldestOnOverflow,
+	"drop_newest": DropNewestOnOverflow,
+	"block":       BlockOnOverflow,
+}
+
+// String returns the name of the policy, as parsed by ParseOverflowPolicy.
+func (p OverflowPolicy) String() string {
+	for name, policy := range overflowPolicies {
+		if policy == p {
+			return name
+		}
+	}
+	return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+}
+
+// ParseOverflowPolicy returns the overflow policy named s: cancel,
+// drop_oldest, drop_newest or block.
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	policy, ok := overflowPolicies[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown overflow policy %q", s)
+	}
+	return policy, nil
+}
+
 // A Subscription represents a client subscription for a particular query and
 // consists of three things:
 // 1) channel onto which messages and events are published
@@ -23,6 +70,9 @@ var (
 type Subscription struct {
 	out chan Message
 
+	policy       OverflowPolicy
+	blockTimeout time.Duration
+
 	canceled chan struct{}
 	mtx      cmtsync.RWMutex
 	err      error
@@ -53,7 +103,8 @@ func (s *Subscription) Canceled() <-chan struct{} {
 // If the channel is closed, Err returns a non-nil error explaining why:
 //   - ErrUnsubscribed if the subscriber choose to unsubscribe,
 //   - ErrOutOfCapacity if the subscriber is not pulling messages fast enough
-//     and the channel returned by Out became full,
+//     and the channel returned by Out became full, unless the overflow policy
+//     of the subscription drops messages instead,
 //
 // After Err returns a non-nil error, successive calls to Err return the same
 // error.
@@ -70,6 +121,16 @@ func (s *Subscription) cancel(err error) {
 	close(s.canceled)
 }
 
+// metricsLabel returns the value of the policy label of the metrics of the
+// subscription: its overflow policy, or unbuffered. Unlike the client IDs,
+// these are bounded.
+func (s *Subscription) metricsLabel() string {
+	if cap(s.out) == 0 {
+		return "unbuffered"
+	}
+	return s.policy.String()
+}
+
 // Message glues data and events together.
 type Message struct {
 	data   interface{}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// ErrStopTimeout is returned by Group.Stop when a service does not stop within
+// the stop timeout of the group.
+var ErrStopTimeout = errors.New("service did not stop in time")
+
+// Group supervises the lifecycle of a set of services: it starts them in
+// order, and stops them in the reverse order, once stopped itself or once the
+// context it was started with is canceled. If a service fails to start, the
+// ones started before it are stopped.
+//
+// Unlike stopping the services one by one, stopping a group neither ignores
+// their errors nor blocks forever on a service which does not stop: each
+// service is given the stop timeout of the group to stop, after which it is
+// abandoned and the next one is stopped.
+type Group struct {
+	logger      log.Logger
+	stopTimeout time.Duration
+
+	mtx      sync.Mutex
+	services []Service // started, in order
+	quit     chan struct{}
+	stopped  bool
+}
+
+// NewGroup returns an empty group, giving each service stopTimeout to stop.
+// A stopTimeout of 0 waits for the services to stop, however long it takes.
+func NewGroup(logger log.Logger, stopTimeout time.Duration) *Group {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	return &Group{
+		logger:      logger,
+		stopTimeout: stopTimeout,
+		quit:        make(chan struct{}),
+	}
+}
+
+// Start starts services in order, and adds them to the group. If a service
+// fails to start, the group is stopped and the error is returned. Once ctx is
+// canceled, the group is stopped.
+//
+// Services already started are added as is.
+func (g *Group) Start(ctx context.Context, services ...Service) error {
+	for _, s := range services {
+		if err := s.Start(); err != nil && !errors.Is(err, ErrAlreadyStarted) {
+			if stopErr := g.Stop(); stopErr != nil {
+				g.logger.Error("Error stopping services", "err", stopErr)
+			}
+			return fmt.Errorf("failed to start %v: %w", s, err)
+		}
+		if !g.add(s) {
+			// stopped concurrently, e.g. by ctx
+			if err := g.stop(s); err != nil {
+				g.logger.Error("Error stopping service", "service", s, "err", err)
+			}
+			return fmt.Errorf("failed to start %v: %w", s, ErrAlreadyStopped)
+		}
+	}
+
+	if ctx != nil && ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				if err := g.Stop(); err != nil {
+					g.logger.Error("Error stopping services", "err", err)
+				}
+			case <-g.quit:
+			}
+		}()
+	}
+	return nil
+}
+
+// Add adds services, which were started by the caller, to the group, as if
+// they had been started by Start. They are stopped right away if the group is
+// stopped.
+func (g *Group) Add(services ...Service) {
+	for _, s := range services {
+		if !g.add(s) {
+			if err := g.stop(s); err != nil {
+				g.logger.Error("Error stopping service", "service", s, "err", err)
+			}
+		}
+	}
+}
+
+func (g *Group) add(s Service) bool {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if g.stopped {
+		return false
+	}
+	g.services = append(g.services, s)
+	return true
+}
+
+// Stop stops the services of the group in the reverse order they were
+// started, and returns their errors, if any. Services already stopped are
+// skipped. Stopping a stopped group does nothing.
+func (g *Group) Stop() error {
+	g.mtx.Lock()
+	if g.stopped {
+		g.mtx.Unlock()
+		return nil
+	}
+	g.stopped = true
+	services := g.services
+	g.services = nil
+	close(g.quit)
+	g.mtx.Unlock()
+
+	var errs []error
+	for i := len(services) - 1; i >= 0; i-- {
+		if err := g.stop(services[i]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %v: %w", services[i], err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stop stops s, waiting at most the stop timeout of the group.
+func (g *Group) stop(s Service) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Stop()
+	}()
+
+	var timeout <-chan time.Time
+	if g.stopTimeout > 0 {
+		timer := time.NewTimer(g.stopTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case err := <-done:
+		if errors.Is(err, ErrAlreadyStopped) {
+			return nil
+		}
+		return err
+	case <-timeout:
+		g.logger.Error("Service did not stop in time, abandoning it", "service", s, "timeout", g.stopTimeout)
+		return ErrStopTimeout
+	}
+}
+
+// Quit returns a channel, which is closed once the group is stopped.
+func (g *Group) Quit() <-chan struct{} {
+	return g.quit
+}
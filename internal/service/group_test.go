@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// groupService records the order it is started and stopped in.
+type groupService struct {
+	BaseService
+	events   *[]string
+	startErr error
+	block    chan struct{} // blocks OnStop until closed, if not nil
+}
+
+func newGroupService(name string, events *[]string) *groupService {
+	s := &groupService{events: events}
+	s.BaseService = *NewBaseService(nil, name, s)
+	return s
+}
+
+func (s *groupService) OnStart() error {
+	if s.startErr != nil {
+		return s.startErr
+	}
+	*s.events = append(*s.events, "start "+s.String())
+	return nil
+}
+
+func (s *groupService) OnStop() {
+	if s.block != nil {
+		<-s.block
+	}
+	*s.events = append(*s.events, "stop "+s.String())
+}
+
+func TestGroupStartStop(t *testing.T) {
+	var events []string
+	a, b, c := newGroupService("a", &events), newGroupService("b", &events), newGroupService("c", &events)
+
+	g := NewGroup(nil, 0)
+	require.NoError(t, g.Start(context.Background(), a, b))
+	require.NoError(t, c.Start())
+	g.Add(c)
+	// a service stopped on its own is skipped
+	require.NoError(t, b.Stop())
+	require.NoError(t, g.Stop())
+	require.NoError(t, g.Stop())
+
+	require.Equal(t, []string{"start a", "start b", "start c", "stop b", "stop c", "stop a"}, events)
+	select {
+	case <-g.Quit():
+	default:
+		t.Fatal("expected the group to be stopped")
+	}
+}
+
+func TestGroupStartError(t *testing.T) {
+	var events []string
+	a, b, c := newGroupService("a", &events), newGroupService("b", &events), newGroupService("c", &events)
+	b.startErr = errors.New("boom")
+
+	g := NewGroup(nil, 0)
+	err := g.Start(context.Background(), a, b, c)
+	require.ErrorIs(t, err, b.startErr)
+
+	require.Equal(t, []string{"start a", "stop a"}, events)
+	require.False(t, c.IsRunning())
+}
+
+func TestGroupContextCanceled(t *testing.T) {
+	var events []string
+	a := newGroupService("a", &events)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g := NewGroup(nil, 0)
+	require.NoError(t, g.Start(ctx, a))
+	cancel()
+
+	select {
+	case <-a.Quit():
+	case <-time.After(time.Second):
+		t.Fatal("expected the service to be stopped")
+	}
+	require.Equal(t, []string{"start a", "stop a"}, events)
+}
+
+func TestGroupStopTimeout(t *testing.T) {
+	var events []string
+	a, b := newGroupService("a", &events), newGroupService("b", &events)
+	b.block = make(chan struct{})
+	defer close(b.block)
+
+	g := NewGroup(nil, 10*time.Millisecond)
+	require.NoError(t, g.Start(context.Background(), a, b))
+
+	err := g.Stop()
+	require.ErrorIs(t, err, ErrStopTimeout)
+	// b is abandoned, and a is stopped anyway
+	require.False(t, a.IsRunning())
+}
+
+func TestBaseServiceContext(t *testing.T) {
+	ts := &testService{}
+	ts.BaseService = *NewBaseService(nil, "TestService", ts)
+	require.NoError(t, ts.Start())
+	require.NoError(t, ts.Context().Err())
+
+	require.NoError(t, ts.Stop())
+	require.ErrorIs(t, ts.Context().Err(), context.Canceled)
+
+	require.NoError(t, ts.Reset())
+	require.NoError(t, ts.Context().Err())
+}
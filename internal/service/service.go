@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync/atomic"
@@ -100,23 +101,37 @@ type BaseService struct {
 	started uint32 // atomic
 	stopped uint32 // atomic
 	quit    chan struct{}
+	ctx     atomic.Value // *serviceContext, replaced by Reset
 
 	// The "subclass" of BaseService
 	impl Service
 }
 
+// serviceContext is the context of a BaseService and its cancel function.
+type serviceContext struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newServiceContext() *serviceContext {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &serviceContext{ctx: ctx, cancel: cancel}
+}
+
 // NewBaseService creates a new BaseService.
 func NewBaseService(logger log.Logger, name string, impl Service) *BaseService {
 	if logger == nil {
 		logger = log.NewNopLogger()
 	}
 
-	return &BaseService{
+	bs := &BaseService{
 		Logger: logger,
 		name:   name,
 		quit:   make(chan struct{}),
 		impl:   impl,
 	}
+	bs.ctx.Store(newServiceContext())
+	return bs
 }
 
 // SetLogger implements Service by setting a logger.
@@ -162,8 +177,9 @@ func (bs *BaseService) Start() error {
 // that way users don't need to call BaseService.OnStart().
 func (bs *BaseService) OnStart() error { return nil }
 
-// Stop implements Service by calling OnStop (if defined) and closing quit
-// channel. An error will be returned if the service is already stopped.
+// Stop implements Service by canceling the context of the service, calling
+// OnStop (if defined) and closing quit channel. An error will be returned if
+// the service is already stopped.
 func (bs *BaseService) Stop() error {
 	if atomic.CompareAndSwapUint32(&bs.stopped, 0, 1) {
 		if atomic.LoadUint32(&bs.started) == 0 {
@@ -178,6 +194,9 @@ func (bs *BaseService) Stop() error {
 			log.NewLazySprintf("Stopping %v service", bs.name),
 			"impl",
 			bs.impl)
+		if sc, ok := bs.ctx.Load().(*serviceContext); ok {
+			sc.cancel()
+		}
 		bs.impl.OnStop()
 		close(bs.quit)
 		return nil
@@ -211,6 +230,7 @@ func (bs *BaseService) Reset() error {
 	atomic.CompareAndSwapUint32(&bs.started, 1, 0)
 
 	bs.quit = make(chan struct{})
+	bs.ctx.Store(newServiceContext())
 	return bs.impl.OnReset()
 }
 
@@ -239,3 +259,14 @@ func (bs *BaseService) String() string {
 func (bs *BaseService) Quit() <-chan struct{} {
 	return bs.quit
 }
+
+// Context returns a context, which is canceled once the service is stopped,
+// before OnStop is called. The work of the service, e.g. its requests to other
+// services, should derive its context from it, so that stopping the service
+// does not wait for it.
+func (bs *BaseService) Context() context.Context {
+	if sc, ok := bs.ctx.Load().(*serviceContext); ok {
+		return sc.ctx
+	}
+	return context.Background()
+}
@@ -55,3 +55,27 @@ func TestBaseServiceReset(t *testing.T) {
 	err = ts.Start()
 	require.NoError(t, err)
 }
+
+func TestBaseServiceContextReset(t *testing.T) {
+	ts := &testService{}
+	ts.BaseService = *NewBaseService(nil, "TestService", ts)
+	require.NoError(t, ts.Start())
+	ctx := ts.Context()
+	require.NoError(t, ctx.Err())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = ts.Context()
+		}
+	}()
+
+	require.NoError(t, ts.Stop())
+	require.Error(t, ctx.Err())
+	require.NoError(t, ts.Reset())
+	<-done
+
+	// the reset service gets a new context
+	require.NoError(t, ts.Context().Err())
+}
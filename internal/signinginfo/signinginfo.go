@@ -0,0 +1,207 @@
+// Package signinginfo tracks which validators signed the last blocks, from the
+// commits in the block store, so that operators can alert on the blocks their
+// validators miss without an external indexer.
+package signinginfo
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/cometbft/cometbft/internal/bits"
+	sm "github.com/cometbft/cometbft/internal/state"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+// ErrUnknownValidator is returned when the address was not a validator at any
+// of the heights of the window.
+var ErrUnknownValidator = errors.New("not a validator in the window")
+
+// Info is the signing info of a validator over the window of the last
+// heights.
+type Info struct {
+	Address types.Address
+	// StartHeight and EndHeight are the first and last heights of the window.
+	StartHeight int64
+	EndHeight   int64
+	// ValidatorHeights is the number of heights of the window at which the
+	// address was a validator.
+	ValidatorHeights int64
+	// MissedBlocks is the number of heights of the window whose commit has no
+	// vote of the validator.
+	MissedBlocks int64
+	// LastSignedHeight is the last height of the window whose commit has a
+	// vote of the validator, 0 if none.
+	LastSignedHeight int64
+}
+
+// validatorInfo holds the votes of a validator. The bits of index i are those
+// of the last recorded height h such that h % window == i.
+type validatorInfo struct {
+	// validator tells whether the address was a validator at the height.
+	validator *bits.BitArray
+	// missed tells whether the commit of the height has no vote of the
+	// validator.
+	missed *bits.BitArray
+
+	lastSignedHeight    int64
+	lastValidatorHeight int64
+}
+
+// Tracker records the votes of the validators in the commits of the last
+// window heights of the block store. It catches up with the block store
+// lazily, when queried.
+type Tracker struct {
+	blockStore sm.BlockStore
+	stateStore sm.Store
+	window     int64
+
+	mtx cmtsync.Mutex
+	// lastHeight is the last height whose canonical commit was recorded. The
+	// commit of the latest height of the block store is the seen one, recorded
+	// again once its block is committed.
+	lastHeight int64
+	endHeight  int64
+	validators map[string]*validatorInfo
+	// cache of the validator set of the last recorded height, and its hash
+	vals     *types.ValidatorSet
+	valsHash []byte
+}
+
+// NewTracker returns a Tracker of the votes in the commits of the last window
+// heights of blockStore. The validator sets are loaded from stateStore.
+func NewTracker(blockStore sm.BlockStore, stateStore sm.Store, window int64) *Tracker {
+	return &Tracker{
+		blockStore: blockStore,
+		stateStore: stateStore,
+		window:     window,
+		validators: make(map[string]*validatorInfo),
+	}
+}
+
+// Window returns the number of heights over which the votes are tracked.
+func (t *Tracker) Window() int64 {
+	return t.window
+}
+
+// SigningInfo returns the signing info of the validator with the given address
+// over the window of the last heights, or ErrUnknownValidator.
+func (t *Tracker) SigningInfo(address types.Address) (*Info, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.update()
+	vi, ok := t.validators[string(address)]
+	if !ok {
+		return nil, ErrUnknownValidator
+	}
+	info := &Info{
+		Address:     address,
+		StartHeight: t.startHeight(),
+		EndHeight:   t.endHeight,
+	}
+	if vi.lastSignedHeight >= info.StartHeight {
+		info.LastSignedHeight = vi.lastSignedHeight
+	}
+	for h := info.StartHeight; h <= info.EndHeight; h++ {
+		i := t.index(h)
+		if vi.validator.GetIndex(i) {
+			info.ValidatorHeights++
+			if vi.missed.GetIndex(i) {
+				info.MissedBlocks++
+			}
+		}
+	}
+	return info, nil
+}
+
+func (t *Tracker) startHeight() int64 {
+	start := t.endHeight - t.window + 1
+	if base := t.blockStore.Base(); start < base {
+		start = base
+	}
+	return start
+}
+
+func (t *Tracker) index(height int64) int {
+	return int(height % t.window)
+}
+
+// update records the commits of the heights added to the block store since
+// the last update.
+func (t *Tracker) update() {
+	height := t.blockStore.Height()
+	from := t.lastHeight + 1
+	if start := height - t.window + 1; from < start {
+		from = start
+	}
+	if base := t.blockStore.Base(); from < base {
+		from = base
+	}
+	for h := from; h <= height; h++ {
+		var commit *types.Commit
+		if h < height {
+			commit = t.blockStore.LoadBlockCommit(h)
+		} else {
+			commit = t.blockStore.LoadSeenCommit(h)
+		}
+		t.record(h, commit, t.validatorsAt(h))
+	}
+	if height > 0 {
+		t.lastHeight = height - 1
+		t.endHeight = height
+	}
+}
+
+// validatorsAt returns the validator set which signed the commit of the
+// height, or nil if it cannot be loaded, e.g. pruned from the state store.
+func (t *Tracker) validatorsAt(height int64) *types.ValidatorSet {
+	meta := t.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil
+	}
+	if t.vals != nil && bytes.Equal(t.valsHash, meta.Header.ValidatorsHash) {
+		return t.vals
+	}
+	vals, err := t.stateStore.LoadValidators(height)
+	if err != nil {
+		return nil
+	}
+	t.vals, t.valsHash = vals, vals.Hash()
+	return vals
+}
+
+// record records the votes of the validators in the commit of the height. A
+// nil commit or validator set, e.g. pruned, clears the height.
+func (t *Tracker) record(height int64, commit *types.Commit, vals *types.ValidatorSet) {
+	i := t.index(height)
+	for address, vi := range t.validators {
+		vi.validator.SetIndex(i, false)
+		vi.missed.SetIndex(i, false)
+		if vi.lastValidatorHeight <= height-t.window {
+			delete(t.validators, address)
+		}
+	}
+	if commit == nil || vals == nil || commit.Size() != vals.Size() {
+		return
+	}
+	for j, val := range vals.Validators {
+		vi, ok := t.validators[string(val.Address)]
+		if !ok {
+			vi = &validatorInfo{
+				validator: bits.NewBitArray(int(t.window)),
+				missed:    bits.NewBitArray(int(t.window)),
+			}
+			t.validators[string(val.Address)] = vi
+		}
+		absent := commit.Signatures[j].BlockIDFlag == types.BlockIDFlagAbsent
+		vi.validator.SetIndex(i, true)
+		vi.missed.SetIndex(i, absent)
+		if !absent && height > vi.lastSignedHeight {
+			vi.lastSignedHeight = height
+		}
+		if height > vi.lastValidatorHeight {
+			vi.lastValidatorHeight = height
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package signinginfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/internal/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTracker(t *testing.T) {
+	vals, _ := types.RandValidatorSet(2, 10)
+	addr0, addr1 := vals.Validators[0].Address, vals.Validators[1].Address
+
+	// absent[h] are the indexes of the validators absent from the commit of
+	// height h.
+	absent := map[int64][]int{2: {0}, 3: {0}, 4: {1}, 6: {0}}
+	commit := func(height int64) *types.Commit {
+		sigs := []types.CommitSig{{BlockIDFlag: types.BlockIDFlagCommit}, {BlockIDFlag: types.BlockIDFlagCommit}}
+		for _, i := range absent[height] {
+			sigs[i] = types.NewCommitSigAbsent()
+		}
+		return &types.Commit{Height: height, Signatures: sigs}
+	}
+
+	var height int64 = 4
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(func() int64 { return height })
+	blockStore.On("LoadBlockCommit", mock.AnythingOfType("int64")).Return(commit)
+	blockStore.On("LoadSeenCommit", mock.AnythingOfType("int64")).Return(commit)
+	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(func(int64) *types.BlockMeta {
+		return &types.BlockMeta{Header: types.Header{ValidatorsHash: vals.Hash()}}
+	})
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadValidators", mock.AnythingOfType("int64")).Return(vals, nil)
+
+	tracker := NewTracker(blockStore, stateStore, 3)
+
+	info, err := tracker.SigningInfo(addr0)
+	require.NoError(t, err)
+	require.Equal(t, &Info{
+		Address:          addr0,
+		StartHeight:      2,
+		EndHeight:        4,
+		ValidatorHeights: 3,
+		MissedBlocks:     2,
+		LastSignedHeight: 4,
+	}, info)
+
+	// The window moves with the block store.
+	height = 6
+	info, err = tracker.SigningInfo(addr0)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), info.StartHeight)
+	require.Equal(t, int64(1), info.MissedBlocks)
+	require.Equal(t, int64(5), info.LastSignedHeight)
+
+	info, err = tracker.SigningInfo(addr1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), info.ValidatorHeights)
+	require.Equal(t, int64(1), info.MissedBlocks)
+	require.Equal(t, int64(6), info.LastSignedHeight)
+
+	// The validator set is only loaded when it changes.
+	stateStore.AssertNumberOfCalls(t, "LoadValidators", 1)
+
+	_, err = tracker.SigningInfo(types.Address("unknown"))
+	require.ErrorIs(t, err, ErrUnknownValidator)
+}
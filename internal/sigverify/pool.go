@@ -0,0 +1,91 @@
+// Package sigverify provides a pool of workers verifying signatures in
+// parallel, shared by the reactors, so that a single core does not bottleneck
+// the ingestion of the votes on large networks.
+package sigverify
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Pool is a fixed set of workers running signature verifications. A nil *Pool
+// runs them in the calling goroutine.
+type Pool struct {
+	workers int
+	jobs    chan func()
+
+	stopOnce sync.Once
+	quit     chan struct{}
+}
+
+// NewPool returns a Pool of the given number of workers, or of GOMAXPROCS
+// workers if workers is not positive. It must be stopped with Stop.
+func NewPool(workers int) *Pool {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	p := &Pool{
+		workers: workers,
+		jobs:    make(chan func()),
+		quit:    make(chan struct{}),
+	}
+	for i := 0; i < workers; i++ {
+		go p.workerRoutine()
+	}
+	return p
+}
+
+// Workers returns the number of workers of the pool, 1 if it is nil.
+func (p *Pool) Workers() int {
+	if p == nil {
+		return 1
+	}
+	return p.workers
+}
+
+// Run runs the functions on the workers of the pool, and returns once they
+// all returned. It blocks while all the workers are busy. Once the pool is
+// stopped, the functions run in the calling goroutine.
+func (p *Pool) Run(fns ...func()) {
+	if p == nil {
+		for _, fn := range fns {
+			fn()
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(fns))
+	for _, fn := range fns {
+		fn := fn
+		job := func() {
+			defer wg.Done()
+			fn()
+		}
+		select {
+		case p.jobs <- job:
+		case <-p.quit:
+			job()
+		}
+	}
+	wg.Wait()
+}
+
+// Stop stops the workers. It is safe to call it several times.
+func (p *Pool) Stop() {
+	if p == nil {
+		return
+	}
+	p.stopOnce.Do(func() { close(p.quit) })
+}
+
+func (p *Pool) workerRoutine() {
+	for {
+		select {
+		case <-p.quit:
+			return
+		case job := <-p.jobs:
+			job()
+		}
+	}
+}
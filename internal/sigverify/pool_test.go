@@ -0,0 +1,41 @@
+package sigverify
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolRun(t *testing.T) {
+	for _, p := range []*Pool{nil, NewPool(0), NewPool(2)} {
+		var (
+			running, maxRunning, done atomic.Int32
+			fns                       = make([]func(), 100)
+		)
+		for i := range fns {
+			fns[i] = func() {
+				n := running.Add(1)
+				for m := maxRunning.Load(); n > m && !maxRunning.CompareAndSwap(m, n); m = maxRunning.Load() {
+				}
+				runtime.Gosched()
+				running.Add(-1)
+				done.Add(1)
+			}
+		}
+		p.Run(fns...)
+		require.EqualValues(t, len(fns), done.Load())
+		require.LessOrEqual(t, int(maxRunning.Load()), p.Workers())
+
+		// Once stopped, the functions run in the calling goroutine.
+		p.Stop()
+		p.Stop()
+		p.Run(fns...)
+		require.EqualValues(t, 2*len(fns), done.Load())
+	}
+
+	p := NewPool(0)
+	defer p.Stop()
+	require.Equal(t, runtime.GOMAXPROCS(0), p.Workers())
+}
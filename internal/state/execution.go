@@ -3,6 +3,7 @@ package state
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -45,6 +46,9 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// the types of the event attributes declared by the app, if any
+	eventSchema *types.EventSchema
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -61,6 +65,15 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithEventSchema makes the executor check that the events of the
+// executed blocks match the schema declared by the application, and log the
+// attributes violating it.
+func BlockExecutorWithEventSchema(schema *types.EventSchema) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.eventSchema = schema
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -130,7 +143,10 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 		maxReapBytes = -1
 	}
 
-	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxReapBytes, maxGas)
+	maxTxBytes := state.ConsensusParams.Mempool.MaxTxBytes
+	// The mempool may still hold txs added before the params lowered the
+	// maximum, which ProcessProposal would reject.
+	txs := blockExec.dropOversizedTxs(blockExec.mempool.ReapMaxBytesMaxGas(maxReapBytes, maxGas), maxTxBytes)
 	commit := lastExtCommit.ToCommit()
 	block := state.MakeBlock(height, txs, commit, evidence, proposerAddr)
 	rpp, err := blockExec.proxyApp.PrepareProposal(
@@ -158,7 +174,11 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 		return nil, err
 	}
 
-	txl := types.ToTxs(rpp.Txs)
+	txl, err := proposedTxs(block.Txs, rpp)
+	if err != nil {
+		return nil, err
+	}
+	txl = blockExec.dropOversizedTxs(txl, maxTxBytes)
 	if err := txl.Validate(maxDataBytes); err != nil {
 		return nil, err
 	}
@@ -166,10 +186,70 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	return state.MakeBlock(height, txl, commit, evidence, proposerAddr), nil
 }
 
+// dropOversizedTxs returns txs without the txs larger than maxTxBytes, the
+// mempool.max_tx_bytes consensus param, if set.
+func (blockExec *BlockExecutor) dropOversizedTxs(txs types.Txs, maxTxBytes int64) types.Txs {
+	if maxTxBytes <= 0 {
+		return txs
+	}
+	res := txs[:0:0]
+	for _, tx := range txs {
+		if int64(len(tx)) > maxTxBytes {
+			blockExec.logger.Info("dropping tx exceeding the mempool params from the proposal",
+				"tx", tx.Hash(), "size", len(tx), "max", maxTxBytes)
+			continue
+		}
+		res = append(res, tx)
+	}
+	return res
+}
+
+// proposedTxs returns the transactions of the block proposed by the app,
+// applying its tx_diff to the transactions of the request, if set.
+func proposedTxs(reqTxs types.Txs, rpp *abci.PrepareProposalResponse) (types.Txs, error) {
+	if rpp.TxDiff == nil {
+		return types.ToTxs(rpp.Txs), nil
+	}
+	if len(rpp.Txs) > 0 {
+		return nil, errors.New("PrepareProposal responded with both txs and tx_diff")
+	}
+	txl := make(types.Txs, 0, len(rpp.TxDiff.Txs))
+	seen := make(map[uint32]struct{}, len(reqTxs))
+	for i, ptx := range rpp.TxDiff.Txs {
+		switch tx := ptx.GetTx().(type) {
+		case *abci.ProposedTx_Index:
+			if int64(tx.Index) >= int64(len(reqTxs)) {
+				return nil, fmt.Errorf("tx_diff entry %d: index %d out of range (%d txs in the request)",
+					i, tx.Index, len(reqTxs))
+			}
+			if _, ok := seen[tx.Index]; ok {
+				return nil, fmt.Errorf("tx_diff entry %d: index %d appears twice", i, tx.Index)
+			}
+			seen[tx.Index] = struct{}{}
+			txl = append(txl, reqTxs[tx.Index])
+		case *abci.ProposedTx_Inserted:
+			txl = append(txl, tx.Inserted)
+		default:
+			return nil, fmt.Errorf("tx_diff entry %d: empty", i)
+		}
+	}
+	return txl, nil
+}
+
 func (blockExec *BlockExecutor) ProcessProposal(
 	block *types.Block,
 	state State,
 ) (bool, error) {
+	if maxTxBytes := state.ConsensusParams.Mempool.MaxTxBytes; maxTxBytes > 0 {
+		for _, tx := range block.Data.Txs {
+			if int64(len(tx)) > maxTxBytes {
+				blockExec.logger.Info("rejecting proposal with a tx exceeding the mempool params",
+					"height", block.Height, "tx", tx.Hash(), "size", len(tx), "max", maxTxBytes)
+				return false, nil
+			}
+		}
+	}
+
 	resp, err := blockExec.proxyApp.ProcessProposal(context.TODO(), &abci.ProcessProposalRequest{
 		Hash:               block.Header.Hash(),
 		Height:             block.Header.Height,
@@ -248,6 +328,8 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	blockExec.logger.Info("executed block", "height", block.Height, "app_hash", fmt.Sprintf("%X", abciResponse.AppHash))
 
+	blockExec.validateEvents(block.Height, abciResponse)
+
 	fail.Fail() // XXX
 
 	// Save the results before we commit.
@@ -275,6 +357,12 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		blockExec.metrics.ConsensusParamUpdates.Add(1)
 	}
 
+	// Classify the updates before they are applied to the next validator set.
+	validatorChanges := validatorSetChanges(block.Height, state.NextValidators, validatorUpdates)
+
+	// The last validators are replaced when the state is updated.
+	absentValidators := block.LastCommit.AbsentValidators(state.LastValidators)
+
 	// Update the state with the block and responses.
 	state, err = updateState(state, blockID, &block.Header, abciResponse, validatorUpdates)
 	if err != nil {
@@ -310,11 +398,27 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events won't be fired during replay
-	fireEvents(blockExec.logger, blockExec.eventBus, block, blockID, abciResponse, validatorUpdates)
+	fireEvents(blockExec.logger, blockExec.eventBus, block, blockID, abciResponse, absentValidators, validatorUpdates, validatorChanges)
 
 	return state, nil
 }
 
+// validateEvents logs the event attributes of the block violating the event
+// schema of the application. They are still indexed, as strings.
+func (blockExec *BlockExecutor) validateEvents(height int64, abciResponse *abci.FinalizeBlockResponse) {
+	if blockExec.eventSchema == nil {
+		return
+	}
+	if err := blockExec.eventSchema.ValidateEvents(abciResponse.Events); err != nil {
+		blockExec.logger.Error("block events violate the event schema", "height", height, "err", err)
+	}
+	for i, txResult := range abciResponse.TxResults {
+		if err := blockExec.eventSchema.ValidateEvents(txResult.Events); err != nil {
+			blockExec.logger.Error("tx events violate the event schema", "height", height, "index", i, "err", err)
+		}
+	}
+}
+
 func (blockExec *BlockExecutor) ExtendVote(
 	ctx context.Context,
 	vote *types.Vote,
@@ -661,7 +765,9 @@ func fireEvents(
 	block *types.Block,
 	blockID types.BlockID,
 	abciResponse *abci.FinalizeBlockResponse,
+	absentValidators []types.Address,
 	validatorUpdates []*types.Validator,
+	validatorChanges types.EventDataValidatorSetChanges,
 ) {
 	if err := eventBus.PublishEventNewBlock(types.EventDataNewBlock{
 		Block:               block,
@@ -681,6 +787,10 @@ func fireEvents(
 		Height: block.Height,
 		Events: abciResponse.Events,
 		NumTxs: int64(len(block.Txs)),
+
+		Proposer:         block.ProposerAddress,
+		AbsentValidators: absentValidators,
+		Size:             int64(block.Size()),
 	}); err != nil {
 		logger.Error("failed publishing new block events", "err", err)
 	}
@@ -712,9 +822,45 @@ func fireEvents(
 			types.EventDataValidatorSetUpdates{ValidatorUpdates: validatorUpdates}); err != nil {
 			logger.Error("failed publishing event", "err", err)
 		}
+		if err := eventBus.PublishEventValidatorSetChanges(validatorChanges); err != nil {
+			logger.Error("failed publishing validator set changes", "err", err)
+		}
 	}
 }
 
+// validatorSetChanges classifies the validator updates returned by the
+// application at height against the validator set they apply to. Updates that
+// leave the power of a validator unchanged are dropped.
+func validatorSetChanges(
+	height int64,
+	vals *types.ValidatorSet,
+	updates []*types.Validator,
+) types.EventDataValidatorSetChanges {
+	changes := types.EventDataValidatorSetChanges{Height: height}
+	for _, u := range updates {
+		change := types.ValidatorChange{
+			Address: u.Address,
+			PubKey:  u.PubKey,
+			Power:   u.VotingPower,
+		}
+		_, val := vals.GetByAddress(u.Address)
+		if val != nil {
+			change.PreviousPower = val.VotingPower
+		}
+		switch {
+		case val == nil && u.VotingPower == 0:
+			// removing an unknown validator is rejected by updateState
+		case val == nil:
+			changes.Added = append(changes.Added, change)
+		case u.VotingPower == 0:
+			changes.Removed = append(changes.Removed, change)
+		case u.VotingPower != val.VotingPower:
+			changes.Updated = append(changes.Updated, change)
+		}
+	}
+	return changes
+}
+
 //----------------------------------------------------------------------------------------------------
 // Execute block without state. TODO: eliminate
 
@@ -727,6 +873,22 @@ func ExecCommitBlock(
 	store Store,
 	initialHeight int64,
 ) ([]byte, error) {
+	resp, err := ExecCommitBlockResponse(appConnConsensus, block, logger, store, initialHeight)
+	if err != nil {
+		return nil, err
+	}
+	return resp.AppHash, nil
+}
+
+// ExecCommitBlockResponse is like ExecCommitBlock, but returns the full
+// FinalizeBlock response of the application.
+func ExecCommitBlockResponse(
+	appConnConsensus proxy.AppConnConsensus,
+	block *types.Block,
+	logger log.Logger,
+	store Store,
+	initialHeight int64,
+) (*abci.FinalizeBlockResponse, error) {
 	commitInfo := buildLastCommitInfoFromStore(block, store, initialHeight)
 
 	resp, err := appConnConsensus.FinalizeBlock(context.TODO(), &abci.FinalizeBlockRequest{
@@ -759,5 +921,5 @@ func ExecCommitBlock(
 	}
 
 	// ResponseCommit has no error or log
-	return resp.AppHash, nil
+	return resp, nil
 }
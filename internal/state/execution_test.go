@@ -3,6 +3,7 @@ package state_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -13,19 +14,23 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	abciclientmocks "github.com/cometbft/cometbft/abci/client/mocks"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
 	abci "github.com/cometbft/cometbft/abci/types"
 	abcimocks "github.com/cometbft/cometbft/abci/types/mocks"
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
 	cmtversion "github.com/cometbft/cometbft/api/cometbft/version/v1"
+	"github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
 	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtquery "github.com/cometbft/cometbft/internal/pubsub/query"
 	sm "github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/state/mocks"
 	"github.com/cometbft/cometbft/internal/store"
 	"github.com/cometbft/cometbft/internal/test"
 	"github.com/cometbft/cometbft/libs/log"
+	mempl "github.com/cometbft/cometbft/mempool"
 	mpmocks "github.com/cometbft/cometbft/mempool/mocks"
 	"github.com/cometbft/cometbft/proxy"
 	pmocks "github.com/cometbft/cometbft/proxy/mocks"
@@ -445,6 +450,134 @@ func TestProcessProposal(t *testing.T) {
 	app.AssertCalled(t, "ProcessProposal", context.TODO(), expectedRpp)
 }
 
+// TestProcessProposalMempoolParams tests that ProcessProposal rejects a block
+// with a transaction exceeding the mempool params without asking the app.
+func TestProcessProposalMempoolParams(t *testing.T) {
+	const height = 1
+	app := &abcimocks.Application{}
+
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, height)
+	state.ConsensusParams.Mempool.MaxTxBytes = 10
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.NewNopLogger(),
+		proxyApp.Consensus(),
+		new(mpmocks.Mempool),
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+	)
+
+	block := makeBlock(state, height, new(types.Commit))
+	block.Txs = types.Txs{types.Tx("small"), types.Tx("larger than ten bytes")}
+
+	acceptBlock, err := blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	require.False(t, acceptBlock)
+	app.AssertNotCalled(t, "ProcessProposal", mock.Anything, mock.Anything)
+}
+
+// TestCreateProposalBlockMempoolParams tests that the txs larger than the
+// mempool params allow, added to the mempool before the params lowered the
+// maximum, are neither proposed nor kept in the mempool.
+func TestCreateProposalBlockMempoolParams(t *testing.T) {
+	const height = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, height)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	mp := mempl.NewCListMempool(config.TestMempoolConfig(), proxyApp.Mempool(), height)
+	small, large := types.Tx("small=tx"), types.Tx("larger=than the params allow")
+	for _, tx := range []types.Tx{small, large} {
+		_, err := mp.CheckTx(tx)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, mp.Size())
+
+	state.ConsensusParams.Mempool.MaxTxBytes = int64(len(small))
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.NewNopLogger(),
+		proxyApp.Consensus(),
+		mp,
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+	)
+	pa, _ := state.Validators.GetByIndex(0)
+	commit, _, err := makeValidCommit(height, types.BlockID{}, state.Validators, privVals)
+	require.NoError(t, err)
+	block, err := blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{small}, block.Txs)
+
+	mp.Lock()
+	err = mp.Update(height, types.Txs{}, []*abci.ExecTxResult{}, sm.TxPreCheck(state), nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, 1, mp.Size())
+	require.Equal(t, types.Txs{small}, mp.ReapMaxTxs(-1))
+}
+
+// TestPrepareProposalMempoolParams tests that the txs the app adds to the
+// proposal, larger than the mempool params allow, are dropped.
+func TestPrepareProposalMempoolParams(t *testing.T) {
+	const height = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state, stateDB, privVals := makeState(1, height)
+	state.ConsensusParams.Mempool.MaxTxBytes = 10
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+
+	mp := &mpmocks.Mempool{}
+	mp.On("ReapMaxBytesMaxGas", mock.Anything, mock.Anything).Return(types.Txs{types.Tx("small")})
+
+	app := &abcimocks.Application{}
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(&abci.PrepareProposalResponse{
+		Txs: [][]byte{[]byte("small"), []byte("larger than ten bytes")},
+	}, nil)
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.NewNopLogger(),
+		proxyApp.Consensus(),
+		mp,
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+	)
+	pa, _ := state.Validators.GetByIndex(0)
+	commit, _, err := makeValidCommit(height, types.BlockID{}, state.Validators, privVals)
+	require.NoError(t, err)
+	block, err := blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{types.Tx("small")}, block.Txs)
+}
+
 func TestValidateValidatorUpdates(t *testing.T) {
 	pubkey1 := ed25519.GenPrivKey().PubKey()
 	pubkey2 := ed25519.GenPrivKey().PubKey()
@@ -633,10 +766,22 @@ func TestFinalizeBlockValidatorUpdates(t *testing.T) {
 	pubkey := ed25519.GenPrivKey().PubKey()
 	pk, err := cryptoenc.PubKeyToProto(pubkey)
 	require.NoError(t, err)
+	existing := state.NextValidators.Validators[0]
+	existingPk, err := cryptoenc.PubKeyToProto(existing.PubKey)
+	require.NoError(t, err)
 	app.ValidatorUpdates = []abci.ValidatorUpdate{
 		{PubKey: pk, Power: 10},
+		{PubKey: existingPk, Power: existing.VotingPower + 1},
 	}
 
+	changesSub, err := eventBus.Subscribe(
+		context.Background(),
+		"TestEndBlockValidatorUpdates",
+		cmtquery.MustCompile(fmt.Sprintf("%s='%s' AND %s='%s'",
+			types.EventTypeKey, types.EventValidatorSetChanges, types.ValidatorAddressKey, pubkey.Address())),
+	)
+	require.NoError(t, err)
+
 	state, err = blockExec.ApplyBlock(state, blockID, block)
 	require.NoError(t, err)
 	// test new validator was added to NextValidators
@@ -661,6 +806,28 @@ func TestFinalizeBlockValidatorUpdates(t *testing.T) {
 	case <-time.After(1 * time.Second):
 		t.Fatal("Did not receive EventValidatorSetUpdates within 1 sec.")
 	}
+
+	select {
+	case msg := <-changesSub.Out():
+		event, ok := msg.Data().(types.EventDataValidatorSetChanges)
+		require.True(t, ok, "Expected event of type EventDataValidatorSetChanges, got %T", msg.Data())
+		assert.EqualValues(t, 1, event.Height)
+		assert.Empty(t, event.Removed)
+		if assert.Len(t, event.Added, 1) {
+			assert.Equal(t, pubkey.Address(), event.Added[0].Address)
+			assert.EqualValues(t, 10, event.Added[0].Power)
+			assert.Zero(t, event.Added[0].PreviousPower)
+		}
+		if assert.Len(t, event.Updated, 1) {
+			assert.Equal(t, existing.Address, event.Updated[0].Address)
+			assert.Equal(t, existing.VotingPower+1, event.Updated[0].Power)
+			assert.Equal(t, existing.VotingPower, event.Updated[0].PreviousPower)
+		}
+	case <-changesSub.Canceled():
+		t.Fatalf("changesSub was canceled (reason: %v)", changesSub.Err())
+	case <-time.After(1 * time.Second):
+		t.Fatal("Did not receive EventValidatorSetChanges within 1 sec.")
+	}
 }
 
 // TestFinalizeBlockValidatorUpdatesResultingInEmptySet checks that processing validator updates that
@@ -854,6 +1021,78 @@ func TestPrepareProposalReorderTxs(t *testing.T) {
 	mp.AssertExpectations(t)
 }
 
+// TestPrepareProposalTxDiff tests that CreateBlock applies the tx_diff returned
+// from PrepareProposal to the transactions of the request.
+func TestPrepareProposalTxDiff(t *testing.T) {
+	const height = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state, stateDB, privVals := makeState(1, height)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+
+	evpool := &mocks.EvidencePool{}
+	evpool.On("PendingEvidence", mock.Anything).Return([]types.Evidence{}, int64(0))
+
+	txs := test.MakeNTxs(height, 4)
+	mp := &mpmocks.Mempool{}
+	mp.On("ReapMaxBytesMaxGas", mock.Anything, mock.Anything).Return(txs)
+
+	inserted := types.Tx("inserted")
+	diff := func(ptxs ...*abci.ProposedTx) *abci.PrepareProposalResponse {
+		return &abci.PrepareProposalResponse{TxDiff: &abci.TxDiff{Txs: ptxs}}
+	}
+	index := func(i uint32) *abci.ProposedTx {
+		return &abci.ProposedTx{Tx: &abci.ProposedTx_Index{Index: i}}
+	}
+
+	app := &abcimocks.Application{}
+	// Drop txs[1], insert a tx and swap txs[0] and txs[3].
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(diff(
+		index(3), &abci.ProposedTx{Tx: &abci.ProposedTx_Inserted{Inserted: inserted}}, index(2), index(0),
+	), nil).Once()
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(diff(index(4)), nil).Once()
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(diff(index(0), index(0)), nil).Once()
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(diff(&abci.ProposedTx{}), nil).Once()
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(&abci.PrepareProposalResponse{
+		Txs:    txs.ToSliceOfBytes(),
+		TxDiff: &abci.TxDiff{},
+	}, nil).Once()
+
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mp,
+		evpool,
+		blockStore,
+	)
+	pa, _ := state.Validators.GetByIndex(0)
+	commit, _, err := makeValidCommit(height, types.BlockID{}, state.Validators, privVals)
+	require.NoError(t, err)
+	block, err := blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{txs[3], inserted, txs[2], txs[0]}, block.Data.Txs)
+
+	// Out of range index, duplicate index, empty entry, and both txs and tx_diff.
+	for i := 0; i < 4; i++ {
+		block, err = blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+		require.Error(t, err)
+		require.Nil(t, block)
+	}
+
+	mp.AssertExpectations(t)
+}
+
 // TestPrepareProposalErrorOnTooManyTxs tests that the block creation logic returns
 // an error if the ResponsePrepareProposal returned from the application is invalid.
 func TestPrepareProposalErrorOnTooManyTxs(t *testing.T) {
@@ -9,25 +9,102 @@ import (
 	"github.com/cometbft/cometbft/internal/state/indexer"
 	blockidxkv "github.com/cometbft/cometbft/internal/state/indexer/block/kv"
 	blockidxnull "github.com/cometbft/cometbft/internal/state/indexer/block/null"
+	"github.com/cometbft/cometbft/internal/state/indexer/sink/multi"
 	"github.com/cometbft/cometbft/internal/state/indexer/sink/psql"
+	"github.com/cometbft/cometbft/internal/state/indexer/sink/stream"
 	"github.com/cometbft/cometbft/internal/state/txindex"
 	"github.com/cometbft/cometbft/internal/state/txindex/kv"
 	"github.com/cometbft/cometbft/internal/state/txindex/null"
+	"github.com/cometbft/cometbft/types"
 )
 
 // EventSinksFromConfig constructs a slice of indexer.EventSink using the provided
-// configuration.
+// configuration. If several indexers are configured, events are written to
+// all of them and the first one serves queries.
+//
+// The kv indexer uses the last event schema it was given.
 //
 //nolint:lll
 func IndexerFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID string) (txindex.TxIndexer, indexer.BlockIndexer, error) {
-	switch cfg.TxIndex.Indexer {
+	return indexerFromConfig(cfg, dbProvider, chainID, (*kv.TxIndex).LoadEventSchema)
+}
+
+// IndexerFromConfigWithSchema is like IndexerFromConfig, the kv indexer using
+// the event schema declared by the application, which may be nil.
+//
+//nolint:lll
+func IndexerFromConfigWithSchema(cfg *config.Config, dbProvider config.DBProvider, chainID string, schema *types.EventSchema) (txindex.TxIndexer, indexer.BlockIndexer, error) {
+	return indexerFromConfig(cfg, dbProvider, chainID, func(txi *kv.TxIndex) error {
+		return txi.SetEventSchema(schema)
+	})
+}
+
+// indexerFromConfig constructs the indexers, setting up the event schema of the
+// kv indexer with setupSchema.
+//
+//nolint:lll
+func indexerFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID string, setupSchema func(*kv.TxIndex) error) (txindex.TxIndexer, indexer.BlockIndexer, error) {
+	names := cfg.TxIndex.Sinks()
+	if len(names) <= 1 {
+		name := ""
+		if len(names) == 1 {
+			name = names[0]
+		}
+		return indexerFromName(name, cfg, dbProvider, chainID, setupSchema)
+	}
+
+	var sinks []multi.Sink
+	for _, name := range names {
+		if name == "null" {
+			continue
+		}
+		if name != "kv" && name != "psql" && name != "kafka" && name != "nats" {
+			return nil, nil, fmt.Errorf("unsupported indexer %q", name)
+		}
+		txIndexer, blockIndexer, err := indexerFromName(name, cfg, dbProvider, chainID, setupSchema)
+		if err != nil {
+			return nil, nil, err
+		}
+		sinks = append(sinks, multi.Sink{
+			Name:         name,
+			TxIndexer:    txIndexer,
+			BlockIndexer: blockIndexer,
+			IgnoreErrors: cfg.TxIndex.IgnoresFailures(name),
+		})
+	}
+	switch len(sinks) {
+	case 0:
+		return &null.TxIndex{}, &blockidxnull.BlockerIndexer{}, nil
+	case 1:
+		return sinks[0].TxIndexer, sinks[0].BlockIndexer, nil
+	}
+	es, err := multi.NewEventSink(sinks...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return es.TxIndexer(), es.BlockIndexer(), nil
+}
+
+// indexerFromName constructs the indexers of the given type. Unknown types
+// yield the null indexers.
+//
+//nolint:lll
+func indexerFromName(name string, cfg *config.Config, dbProvider config.DBProvider, chainID string, setupSchema func(*kv.TxIndex) error) (txindex.TxIndexer, indexer.BlockIndexer, error) {
+	switch name {
 	case "kv":
 		store, err := dbProvider(&config.DBContext{ID: "tx_index", Config: cfg})
 		if err != nil {
 			return nil, nil, err
 		}
 
-		return kv.NewTxIndex(store), blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events"))), nil
+		filter := txindex.NewEventFilter(cfg.TxIndex.IndexEvents, cfg.TxIndex.ExcludeEvents)
+		sparseInterval := cfg.Storage.Pruning.SparseRetention()
+		txIndexer := kv.NewTxIndex(store, kv.WithEventFilter(filter), kv.WithSparseRetention(sparseInterval))
+		if err := setupSchema(txIndexer); err != nil {
+			return nil, nil, fmt.Errorf("setting up the event schema of the kv indexer: %w", err)
+		}
+		blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")), blockidxkv.WithSparseRetention(sparseInterval))
+		return txIndexer, blockIndexer, nil
 
 	case "psql":
 		conn := cfg.TxIndex.PsqlConn
@@ -40,6 +117,14 @@ func IndexerFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID
 		}
 		return es.TxIndexer(), es.BlockIndexer(), nil
 
+	case "kafka", "nats":
+		producer, err := stream.NewProducer(name, cfg.TxIndex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating %s indexer: %w", name, err)
+		}
+		es := stream.NewEventSink(producer, chainID, cfg.TxIndex.StreamTopicPrefix)
+		return es.TxIndexer(), es.BlockIndexer(), nil
+
 	default:
 		return &null.TxIndex{}, &blockidxnull.BlockerIndexer{}, nil
 	}
@@ -5,10 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	dbm "github.com/cometbft/cometbft-db"
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -38,12 +40,30 @@ type BlockerIndexer struct {
 	// Matching will be done both on height AND eventSeq
 	eventSeq int64
 	log      log.Logger
+
+	// Heights kept by the sparse pruning policy, 0 if disabled.
+	sparseInterval int64
 }
 
-func New(store dbm.DB) *BlockerIndexer {
-	return &BlockerIndexer{
+// IndexerOption sets an optional parameter on the BlockerIndexer.
+type IndexerOption func(*BlockerIndexer)
+
+// WithSparseRetention keeps the events of every height multiple of interval
+// when pruning. An interval of 0 disables it.
+func WithSparseRetention(interval int64) IndexerOption {
+	return func(idx *BlockerIndexer) {
+		idx.sparseInterval = interval
+	}
+}
+
+func New(store dbm.DB, options ...IndexerOption) *BlockerIndexer {
+	idx := &BlockerIndexer{
 		store: store,
 	}
+	for _, option := range options {
+		option(idx)
+	}
+	return idx
 }
 
 func (idx *BlockerIndexer) SetLogger(l log.Logger) {
@@ -65,6 +85,8 @@ func (idx *BlockerIndexer) Has(height int64) (bool, error) {
 // The following is indexed:
 //
 // primary key: encode(block.height | height) => encode(height)
+// block info: encode(block.proposer|address|height|finalize_block|eventSeq) => encode(height), and
+// likewise for block.absent_validator, block.size and block.num_txs.
 // FinalizeBlock events: encode(eventType.eventAttr|eventValue|height|finalize_block|eventSeq) => encode(height).
 func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockEvents) error {
 	batch := idx.store.NewBatch()
@@ -81,7 +103,13 @@ func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockEvents) error {
 		return err
 	}
 
-	// 2. index block events
+	// 2. index the proposer, the absent validators, the size and the number
+	// of txs of the block
+	if err := idx.indexBlockInfo(batch, bh); err != nil {
+		return fmt.Errorf("failed to index block info: %w", err)
+	}
+
+	// 3. index block events
 	if err := idx.indexEvents(batch, bh.Events, height); err != nil {
 		return fmt.Errorf("failed to index FinalizeBlock events: %w", err)
 	}
@@ -145,11 +173,14 @@ func (idx *BlockerIndexer) Prune(retainHeight int64) (int64, int64, error) {
 	affectedHeights := make(map[int64]struct{})
 	for ; itr.Valid(); itr.Next() {
 		if keyBelongsToHeightRange(itr.Key(), lastRetainHeight, retainHeight) {
+			height := getHeightFromKey(itr.Key())
+			if state.SparseRetained(idx.sparseInterval, height) {
+				continue
+			}
 			err := batch.Delete(itr.Key())
 			if err != nil {
 				return 0, lastRetainHeight, err
 			}
-			height := getHeightFromKey(itr.Key())
 			affectedHeights[height] = struct{}{}
 			deleted++
 		}
@@ -175,6 +206,38 @@ func (idx *BlockerIndexer) Prune(retainHeight int64) (int64, int64, error) {
 	return int64(len(affectedHeights)), retainHeight, err
 }
 
+// DeleteFromHeight removes the events of all blocks indexed at the given
+// height and above, e.g. after the state has been rolled back below height.
+func (idx *BlockerIndexer) DeleteFromHeight(height int64) error {
+	itr, err := idx.store.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; itr.Valid(); itr.Next() {
+		if keyBelongsToHeightRange(itr.Key(), height, math.MaxInt64) {
+			keys = append(keys, itr.Key())
+		}
+	}
+	err = itr.Error()
+	// the iterator must be closed before writing to the store
+	if closeErr := itr.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	batch := idx.store.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
 func (idx *BlockerIndexer) SetRetainHeight(retainHeight int64) error {
 	return idx.store.SetSync(BlockIndexerRetainHeightKey, int64ToBytes(retainHeight))
 }
@@ -220,7 +283,53 @@ func (idx *BlockerIndexer) getLastRetainHeight() (int64, error) {
 // one or more block heights. In the case of height queries, i.e. block.height=H,
 // if the height is indexed, that height alone will be returned. An error and
 // nil slice is returned. Otherwise, a non-nil slice and nil error is returned.
+//
+// For a query using OR, each alternative is searched separately and the
+// union of the resulting heights is returned.
 func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	disjuncts := q.Disjuncts()
+	if len(disjuncts) == 1 {
+		return idx.search(ctx, disjuncts[0].Syntax())
+	}
+
+	results := make([]int64, 0)
+	resultMap := make(map[int64]struct{})
+	for _, d := range disjuncts {
+		heights, err := idx.search(ctx, d.Syntax())
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range heights {
+			if _, ok := resultMap[h]; !ok {
+				resultMap[h] = struct{}{}
+				results = append(results, h)
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+
+	return results, nil
+}
+
+// search performs a query for block heights matching all of the given
+// conditions.
+func (idx *BlockerIndexer) search(ctx context.Context, conditions []syntax.Condition) ([]int64, error) {
+	// The block info is indexed as an event of its own, so its conditions are
+	// matched apart from the conditions on the events of the block, and the
+	// heights matching both are returned.
+	if info, events := splitBlockInfoConditions(conditions); info != nil {
+		infoResults, err := idx.search(ctx, info)
+		if err != nil {
+			return nil, err
+		}
+		eventResults, err := idx.search(ctx, events)
+		if err != nil {
+			return nil, err
+		}
+		return intersectHeights(infoResults, eventResults), nil
+	}
+
 	results := make([]int64, 0)
 	select {
 	case <-ctx.Done():
@@ -229,8 +338,6 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	default:
 	}
 
-	conditions := q.Syntax()
-
 	// conditions to skip because they're handled before "everything else"
 	skipIndexes := make([]int, 0)
 
@@ -398,6 +505,9 @@ func (idx *BlockerIndexer) matchRange(
 
 	tmpHeights := make(map[string][]byte)
 
+	// Timestamps are compared as nanoseconds.
+	timeRange := idxutil.TimeRange(qr)
+
 	it, err := dbm.IteratePrefix(idx.store, startKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create prefix iterator: %w", err)
@@ -464,6 +574,34 @@ LOOP:
 			}
 		}
 
+		if _, ok := qr.AnyBound().(time.Time); ok {
+			v, err := idxutil.ParseTimeValue(eventValue)
+			if err != nil {
+				continue LOOP
+			}
+			if qr.Key != types.BlockHeightKey {
+				keyHeight, err := parseHeightFromEventKey(it.Key())
+				if err != nil {
+					idx.log.Error("failure to parse height from key:", err)
+					continue LOOP
+				}
+				withinHeight, err := checkHeightConditions(heightInfo, keyHeight)
+				if err != nil {
+					idx.log.Error("failure checking for height bounds:", err)
+					continue LOOP
+				}
+				if !withinHeight {
+					continue LOOP
+				}
+			}
+			withinBounds, err := idxutil.CheckBounds(timeRange, v)
+			if err != nil {
+				idx.log.Error("failed to parse bounds:", err)
+			} else if withinBounds {
+				idx.setTmpHeights(tmpHeights, it)
+			}
+		}
+
 		select {
 		case <-ctx.Done():
 			break
@@ -694,6 +832,37 @@ func (idx *BlockerIndexer) match(
 	return filteredHeights, nil
 }
 
+// indexBlockInfo indexes the reserved keys describing the block, as the
+// attributes of a single event, so that they can be matched together. Nothing
+// is indexed for the blocks without a proposer, i.e. not built from a block.
+func (idx *BlockerIndexer) indexBlockInfo(batch dbm.Batch, bh types.EventDataNewBlockEvents) error {
+	if len(bh.Proposer) == 0 {
+		return nil
+	}
+	idx.eventSeq++
+	info := map[string][]string{
+		types.BlockProposerKey: {bh.Proposer.String()},
+		types.BlockSizeKey:     {strconv.FormatInt(bh.Size, 10)},
+		types.BlockNumTxsKey:   {strconv.FormatInt(bh.NumTxs, 10)},
+	}
+	for _, addr := range bh.AbsentValidators {
+		info[types.BlockAbsentValidatorKey] = append(info[types.BlockAbsentValidatorKey], addr.String())
+	}
+	heightBz := int64ToBytes(bh.Height)
+	for compositeKey, values := range info {
+		for _, value := range values {
+			key, err := eventKey(compositeKey, value, bh.Height, idx.eventSeq)
+			if err != nil {
+				return fmt.Errorf("failed to create block index key: %w", err)
+			}
+			if err := batch.Set(key, heightBz); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, height int64) error {
 	heightBz := int64ToBytes(height)
 
@@ -711,7 +880,7 @@ func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, hei
 
 			// index iff the event specified index:true and it's not a reserved event
 			compositeKey := fmt.Sprintf("%s.%s", event.Type, attr.Key)
-			if compositeKey == types.BlockHeightKey {
+			if isReservedKey(compositeKey) {
 				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeKey)
 			}
 
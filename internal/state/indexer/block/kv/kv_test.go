@@ -363,6 +363,22 @@ func TestBlockIndexerMulti(t *testing.T) {
 			q:       query.MustCompile("end_event.baz = 100"),
 			results: []int64{},
 		},
+		"query with OR matches heights of either alternative": {
+			q:       query.MustCompile("end_event.bar = 500 OR end_event.bar = 400"),
+			results: []int64{1, 2},
+		},
+		"query with OR deduplicates heights": {
+			q:       query.MustCompile("end_event.foo = 100 OR end_event.foo = 300 OR end_event.baz = 100"),
+			results: []int64{1, 2},
+		},
+		"query with height range operator": {
+			q:       query.MustCompile("block.height BETWEEN 2 AND 5"),
+			results: []int64{2},
+		},
+		"query with OR and height range operator": {
+			q:       query.MustCompile("end_event.bar = 500 AND block.height BETWEEN 2 AND 3 OR end_event.bar = 400 AND block.height BETWEEN 2 AND 3"),
+			results: []int64{2},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -575,3 +591,110 @@ func setDiff(bigger [][]byte, smaller [][]byte) [][]byte {
 	}
 	return diff
 }
+
+func TestBlockerIndexer_DeleteFromHeight(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	for h := int64(1); h <= 3; h++ {
+		require.NoError(t, indexer.Index(getEventsForTesting(h)))
+	}
+	keys1 := blockidxkv.GetKeys(*indexer)
+
+	require.NoError(t, indexer.DeleteFromHeight(2))
+
+	for h, want := range map[int64]bool{1: true, 2: false, 3: false} {
+		ok, err := indexer.Has(h)
+		require.NoError(t, err)
+		require.Equal(t, want, ok, "height %d", h)
+	}
+	results, err := indexer.Search(context.Background(), query.MustCompile("block.height >= 1"))
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, results)
+	require.Less(t, len(blockidxkv.GetKeys(*indexer)), len(keys1))
+}
+
+func TestBlockIndexerComparisons(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	for i, value := range []struct{ price, expiry string }{
+		{"-20", "2024-03-01T10:00:00Z"},
+		{"-1.5", "2024-03-01T12:00:00+01:00"},
+		{"3", "2024-03-02T00:00:00Z"},
+	} {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockEvents{
+			Height: int64(i + 1),
+			Events: []abci.Event{{
+				Type: "end_event",
+				Attributes: []abci.EventAttribute{
+					{Key: "price", Value: value.price, Index: true},
+					{Key: "expiry", Value: value.expiry, Index: true},
+				},
+			}},
+		}))
+	}
+
+	for q, want := range map[string][]int64{
+		"end_event.price < 0":                                      {1, 2},
+		"end_event.price > -20 AND end_event.price <= 3":           {2, 3},
+		"end_event.expiry > TIME 2024-03-01T10:30:00Z":             {2, 3},
+		"end_event.expiry < DATE 2024-03-02":                       {1, 2},
+		"end_event.expiry <= DATE 2024-03-02 AND block.height > 1": {2, 3},
+	} {
+		results, err := indexer.Search(context.Background(), query.MustCompile(q))
+		require.NoError(t, err)
+		require.Equal(t, want, results, q)
+	}
+}
+
+func TestBlockIndexerBlockInfo(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	proposers := []types.Address{{0xAA}, {0xBB}, {0xAA}}
+	for i, proposer := range proposers {
+		bh := types.EventDataNewBlockEvents{
+			Height:   int64(i + 1),
+			NumTxs:   int64(i * 10),
+			Proposer: proposer,
+			Size:     int64(1000 * (i + 1)),
+			Events: []abci.Event{{
+				Type:       "end_event",
+				Attributes: []abci.EventAttribute{{Key: "foo", Value: "1", Index: true}},
+			}},
+		}
+		if i > 0 {
+			bh.AbsentValidators = []types.Address{{0xCC}, {byte(i)}}
+		}
+		require.NoError(t, indexer.Index(bh))
+	}
+	// Blocks not built from a block have no info to index.
+	require.NoError(t, indexer.Index(types.EventDataNewBlockEvents{Height: 4}))
+
+	for q, want := range map[string][]int64{
+		"block.proposer = 'AA'":                       {1, 3},
+		"block.absent_validator = 'CC'":               {2, 3},
+		"block.absent_validator = '02'":               {3},
+		"block.absent_validator EXISTS":               {2, 3},
+		"block.size > 1500":                           {2, 3},
+		"block.num_txs >= 10 AND block.size < 3000":   {2},
+		"block.proposer = 'AA' AND block.num_txs > 0": {3},
+		"block.proposer = 'AA' AND block.height > 1":  {3},
+		"block.proposer = 'BB' AND end_event.foo = 1": {2},
+	} {
+		results, err := indexer.Search(context.Background(), query.MustCompile(q))
+		require.NoError(t, err)
+		require.Equal(t, want, results, q)
+	}
+
+	// The info keys are reserved.
+	err := indexer.Index(types.EventDataNewBlockEvents{
+		Height: 5,
+		Events: []abci.Event{{
+			Type:       "block",
+			Attributes: []abci.EventAttribute{{Key: "size", Value: "1", Index: true}},
+		}},
+	})
+	require.Error(t, err)
+}
@@ -254,3 +254,59 @@ func checkHeightConditions(heightInfo HeightInfo, keyHeight int64) (bool, error)
 
 	return true, nil
 }
+
+// isReservedKey returns whether the composite key is reserved to the block
+// height and info.
+func isReservedKey(compositeKey string) bool {
+	return compositeKey == types.BlockHeightKey || isBlockInfoKey(compositeKey)
+}
+
+func isBlockInfoKey(compositeKey string) bool {
+	switch compositeKey {
+	case types.BlockProposerKey, types.BlockAbsentValidatorKey, types.BlockSizeKey, types.BlockNumTxsKey:
+		return true
+	}
+	return false
+}
+
+// splitBlockInfoConditions splits the conditions into those on the block info
+// and those on the events, the conditions on the height going to both. It
+// returns nil if the conditions are not on both.
+func splitBlockInfoConditions(conditions []syntax.Condition) (info, events []syntax.Condition) {
+	var hasInfo, hasEvents bool
+	for _, c := range conditions {
+		switch {
+		case c.Tag == types.BlockHeightKey:
+			info = append(info, c)
+			events = append(events, c)
+		case isBlockInfoKey(c.Tag):
+			info = append(info, c)
+			hasInfo = true
+		default:
+			events = append(events, c)
+			hasEvents = true
+		}
+	}
+	if !hasInfo || !hasEvents {
+		return nil, nil
+	}
+	return info, events
+}
+
+// intersectHeights returns the heights in both of the sorted slices.
+func intersectHeights(a, b []int64) []int64 {
+	heights := make([]int64, 0)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			heights = append(heights, a[i])
+			i++
+			j++
+		}
+	}
+	return heights
+}
@@ -0,0 +1,197 @@
+// Package multi implements an event sink writing to several indexer sinks at
+// once, for example to populate a PostgreSQL database while keeping the local
+// kv index queryable.
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/pubsub/query"
+	"github.com/cometbft/cometbft/internal/state/indexer"
+	"github.com/cometbft/cometbft/internal/state/txindex"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Sink is one of the sinks of an EventSink.
+type Sink struct {
+	// Name identifies the sink in logs and errors, e.g. "kv" or "psql".
+	Name string
+
+	TxIndexer    txindex.TxIndexer
+	BlockIndexer indexer.BlockIndexer
+
+	// If IgnoreErrors is set, failures to write to this sink are logged but
+	// not reported to the caller.
+	IgnoreErrors bool
+}
+
+// EventSink writes events to all of its sinks. Reads (Get, Has and Search)
+// are served by the first sink only.
+type EventSink struct {
+	sinks  []Sink
+	logger log.Logger
+}
+
+// NewEventSink returns an EventSink writing to the given sinks. The first
+// sink is the primary one, which serves queries.
+func NewEventSink(sinks ...Sink) (*EventSink, error) {
+	if len(sinks) == 0 {
+		return nil, errors.New("at least one sink is required")
+	}
+	return &EventSink{sinks: sinks, logger: log.NewNopLogger()}, nil
+}
+
+func (es *EventSink) primary() Sink { return es.sinks[0] }
+
+// each calls fn for every sink, returning the errors of the sinks which do
+// not ignore errors.
+func (es *EventSink) each(op string, fn func(Sink) error) error {
+	var errs []error
+	for _, s := range es.sinks {
+		err := fn(s)
+		if err == nil {
+			continue
+		}
+		if s.IgnoreErrors {
+			es.logger.Error("ignoring indexer sink failure", "sink", s.Name, "op", op, "err", err)
+			continue
+		}
+		errs = append(errs, fmt.Errorf("sink %s: %w", s.Name, err))
+	}
+	return errors.Join(errs...)
+}
+
+func (es *EventSink) setLogger(l log.Logger) {
+	es.logger = l
+}
+
+// TxIndexer returns a transaction indexer backed by es.
+func (es *EventSink) TxIndexer() TxIndexer {
+	return TxIndexer{es: es}
+}
+
+// TxIndexer implements the txindex.TxIndexer interface on top of an
+// EventSink.
+type TxIndexer struct{ es *EventSink }
+
+var _ txindex.TxIndexer = TxIndexer{}
+
+// AddBatch indexes a batch of transactions in all sinks.
+func (t TxIndexer) AddBatch(b *txindex.Batch) error {
+	return t.es.each("AddBatch", func(s Sink) error { return s.TxIndexer.AddBatch(b) })
+}
+
+// Index indexes a single transaction in all sinks.
+func (t TxIndexer) Index(result *abci.TxResult) error {
+	return t.es.each("Index", func(s Sink) error { return s.TxIndexer.Index(result) })
+}
+
+// Get returns the transaction with the given hash from the primary sink.
+func (t TxIndexer) Get(hash []byte) (*abci.TxResult, error) {
+	return t.es.primary().TxIndexer.Get(hash)
+}
+
+// Search queries the primary sink.
+func (t TxIndexer) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	return t.es.primary().TxIndexer.Search(ctx, q)
+}
+
+// SetLogger sets the logger of the event sink and of all its sinks.
+func (t TxIndexer) SetLogger(l log.Logger) {
+	t.es.setLogger(l)
+	for _, s := range t.es.sinks {
+		s.TxIndexer.SetLogger(l.With("sink", s.Name))
+	}
+}
+
+// Prune prunes all sinks, reporting the result of the primary one.
+func (t TxIndexer) Prune(retainHeight int64) (int64, int64, error) {
+	var (
+		numPruned, newRetainHeight int64
+		primary                    = true
+	)
+	err := t.es.each("Prune", func(s Sink) error {
+		n, h, err := s.TxIndexer.Prune(retainHeight)
+		if primary {
+			numPruned, newRetainHeight = n, h
+			primary = false
+		}
+		return err
+	})
+	return numPruned, newRetainHeight, err
+}
+
+// GetRetainHeight returns the retain height of the primary sink.
+func (t TxIndexer) GetRetainHeight() (int64, error) {
+	return t.es.primary().TxIndexer.GetRetainHeight()
+}
+
+// SetRetainHeight sets the retain height of all sinks.
+func (t TxIndexer) SetRetainHeight(retainHeight int64) error {
+	return t.es.each("SetRetainHeight", func(s Sink) error { return s.TxIndexer.SetRetainHeight(retainHeight) })
+}
+
+// BlockIndexer returns a block indexer backed by es.
+func (es *EventSink) BlockIndexer() BlockIndexer {
+	return BlockIndexer{es: es}
+}
+
+// BlockIndexer implements the indexer.BlockIndexer interface on top of an
+// EventSink.
+type BlockIndexer struct{ es *EventSink }
+
+var _ indexer.BlockIndexer = BlockIndexer{}
+
+// Has reports whether the primary sink has indexed the given height.
+func (b BlockIndexer) Has(height int64) (bool, error) {
+	return b.es.primary().BlockIndexer.Has(height)
+}
+
+// Index indexes the events of a block in all sinks.
+func (b BlockIndexer) Index(events types.EventDataNewBlockEvents) error {
+	return b.es.each("Index", func(s Sink) error { return s.BlockIndexer.Index(events) })
+}
+
+// Search queries the primary sink.
+func (b BlockIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	return b.es.primary().BlockIndexer.Search(ctx, q)
+}
+
+// SetLogger sets the logger of the event sink and of all its sinks.
+func (b BlockIndexer) SetLogger(l log.Logger) {
+	b.es.setLogger(l)
+	for _, s := range b.es.sinks {
+		s.BlockIndexer.SetLogger(l.With("sink", s.Name))
+	}
+}
+
+// Prune prunes all sinks, reporting the result of the primary one.
+func (b BlockIndexer) Prune(retainHeight int64) (int64, int64, error) {
+	var (
+		numPruned, newRetainHeight int64
+		primary                    = true
+	)
+	err := b.es.each("Prune", func(s Sink) error {
+		n, h, err := s.BlockIndexer.Prune(retainHeight)
+		if primary {
+			numPruned, newRetainHeight = n, h
+			primary = false
+		}
+		return err
+	})
+	return numPruned, newRetainHeight, err
+}
+
+// SetRetainHeight sets the retain height of all sinks.
+func (b BlockIndexer) SetRetainHeight(retainHeight int64) error {
+	return b.es.each("SetRetainHeight", func(s Sink) error { return s.BlockIndexer.SetRetainHeight(retainHeight) })
+}
+
+// GetRetainHeight returns the retain height of the primary sink.
+func (b BlockIndexer) GetRetainHeight() (int64, error) {
+	return b.es.primary().BlockIndexer.GetRetainHeight()
+}
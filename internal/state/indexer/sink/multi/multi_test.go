@@ -0,0 +1,92 @@
+package multi_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/pubsub/query"
+	blockidxkv "github.com/cometbft/cometbft/internal/state/indexer/block/kv"
+	"github.com/cometbft/cometbft/internal/state/indexer/sink/multi"
+	"github.com/cometbft/cometbft/internal/state/txindex"
+	"github.com/cometbft/cometbft/internal/state/txindex/kv"
+	"github.com/cometbft/cometbft/internal/state/txindex/null"
+	"github.com/cometbft/cometbft/types"
+)
+
+// failingTxIndexer fails every write.
+type failingTxIndexer struct{ null.TxIndex }
+
+func (failingTxIndexer) AddBatch(*txindex.Batch) error { return errors.New("boom") }
+func (failingTxIndexer) Index(*abci.TxResult) error    { return errors.New("boom") }
+
+func newKVSink(name string) multi.Sink {
+	store := dbm.NewMemDB()
+	return multi.Sink{
+		Name:         name,
+		TxIndexer:    kv.NewTxIndex(store),
+		BlockIndexer: blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events"))),
+	}
+}
+
+func txResult() *abci.TxResult {
+	return &abci.TxResult{
+		Height: 1,
+		Tx:     types.Tx("HELLO WORLD"),
+		Result: abci.ExecTxResult{
+			Events: []abci.Event{
+				{Type: "account", Attributes: []abci.EventAttribute{{Key: "owner", Value: "Ivan", Index: true}}},
+			},
+		},
+	}
+}
+
+func TestEventSinkWritesAllSinks(t *testing.T) {
+	primary, secondary := newKVSink("kv1"), newKVSink("kv2")
+	es, err := multi.NewEventSink(primary, secondary)
+	require.NoError(t, err)
+
+	txr := txResult()
+	require.NoError(t, es.TxIndexer().Index(txr))
+	require.NoError(t, es.BlockIndexer().Index(types.EventDataNewBlockEvents{Height: 1}))
+
+	hash := types.Tx(txr.Tx).Hash()
+	for _, s := range []multi.Sink{primary, secondary} {
+		res, err := s.TxIndexer.Get(hash)
+		require.NoError(t, err)
+		require.NotNil(t, res, s.Name)
+
+		ok, err := s.BlockIndexer.Has(1)
+		require.NoError(t, err)
+		require.True(t, ok, s.Name)
+	}
+
+	results, err := es.TxIndexer().Search(context.Background(), query.MustCompile("account.owner = 'Ivan'"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+}
+
+func TestEventSinkFailurePolicy(t *testing.T) {
+	failing := newKVSink("psql")
+	failing.TxIndexer = &failingTxIndexer{}
+
+	es, err := multi.NewEventSink(newKVSink("kv"), failing)
+	require.NoError(t, err)
+	err = es.TxIndexer().Index(txResult())
+	require.ErrorContains(t, err, "sink psql")
+
+	failing.IgnoreErrors = true
+	es, err = multi.NewEventSink(newKVSink("kv"), failing)
+	require.NoError(t, err)
+	require.NoError(t, es.TxIndexer().Index(txResult()))
+	require.NoError(t, es.TxIndexer().AddBatch(&txindex.Batch{Ops: []*abci.TxResult{txResult()}}))
+}
+
+func TestNewEventSinkRequiresSink(t *testing.T) {
+	_, err := multi.NewEventSink()
+	require.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package stream
+
+// This file bridges the stream EventSink to the TxIndexer and BlockIndexer
+// interfaces used by the node, like the psql event sink does.
+
+import (
+	"context"
+	"errors"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/pubsub/query"
+	"github.com/cometbft/cometbft/internal/state/txindex"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+// TxIndexer returns a bridge from es to the transaction indexer interface.
+func (es *EventSink) TxIndexer() TxIndexer {
+	return TxIndexer{sink: es}
+}
+
+// TxIndexer implements the txindex.TxIndexer interface by publishing the
+// events of the transactions through the event sink.
+type TxIndexer struct{ sink *EventSink }
+
+func (TxIndexer) GetRetainHeight() (int64, error) {
+	return 0, nil
+}
+
+func (TxIndexer) SetRetainHeight(int64) error {
+	return nil
+}
+
+func (TxIndexer) Prune(int64) (int64, int64, error) {
+	// The published messages are retained by the broker.
+	return 0, 0, nil
+}
+
+// AddBatch publishes the events of a batch of transactions, as part of
+// TxIndexer.
+func (b TxIndexer) AddBatch(batch *txindex.Batch) error {
+	return b.sink.IndexTxEvents(batch.Ops)
+}
+
+// Index publishes the events of a single transaction, as part of TxIndexer.
+func (b TxIndexer) Index(txr *abci.TxResult) error {
+	return b.sink.IndexTxEvents([]*abci.TxResult{txr})
+}
+
+// Get is implemented to satisfy the TxIndexer interface, but is not supported
+// by the stream event sink and reports an error for all inputs.
+func (TxIndexer) Get([]byte) (*abci.TxResult, error) {
+	return nil, errors.New("the TxIndexer.Get method is not supported")
+}
+
+// Search is implemented to satisfy the TxIndexer interface, but it is not
+// supported by the stream event sink and reports an error for all inputs.
+func (TxIndexer) Search(context.Context, *query.Query) ([]*abci.TxResult, error) {
+	return nil, errors.New("the TxIndexer.Search method is not supported")
+}
+
+func (TxIndexer) SetLogger(log.Logger) {}
+
+// BlockIndexer returns a bridge from es to the block indexer interface.
+func (es *EventSink) BlockIndexer() BlockIndexer {
+	return BlockIndexer{sink: es}
+}
+
+// BlockIndexer implements the indexer.BlockIndexer interface by publishing the
+// events of the blocks through the event sink.
+type BlockIndexer struct{ sink *EventSink }
+
+func (BlockIndexer) SetRetainHeight(int64) error {
+	return nil
+}
+
+func (BlockIndexer) GetRetainHeight() (int64, error) {
+	return 0, nil
+}
+
+func (BlockIndexer) Prune(int64) (int64, int64, error) {
+	// The published messages are retained by the broker.
+	return 0, 0, nil
+}
+
+// Has is implemented to satisfy the BlockIndexer interface, but it is not
+// supported by the stream event sink and reports an error for all inputs.
+func (BlockIndexer) Has(int64) (bool, error) {
+	return false, errors.New("the BlockIndexer.Has method is not supported")
+}
+
+// Index publishes the events of the block, as part of BlockIndexer.
+func (b BlockIndexer) Index(block types.EventDataNewBlockEvents) error {
+	return b.sink.IndexBlockEvents(block)
+}
+
+// Search is implemented to satisfy the BlockIndexer interface, but it is not
+// supported by the stream event sink and reports an error for all inputs.
+func (BlockIndexer) Search(context.Context, *query.Query) ([]int64, error) {
+	return nil, errors.New("the BlockIndexer.Search method is not supported")
+}
+
+func (BlockIndexer) SetLogger(log.Logger) {}
@@ -0,0 +1,78 @@
+package stream
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cometbft/cometbft/config"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+)
+
+// NewProducerFunc returns a producer configured by the [tx_index] section of
+// the config.
+type NewProducerFunc func(cfg *config.TxIndexConfig) (Producer, error)
+
+var (
+	producersMtx cmtsync.Mutex
+	producers    = make(map[string]NewProducerFunc)
+)
+
+// RegisterProducer makes the producer of the given indexer, e.g. "kafka",
+// available to the node. The producers are implemented with the clients of the
+// brokers in separate modules, which register them when imported, so that
+// these clients are only built into the binaries using them:
+//
+//	import _ "github.com/cometbft/cometbft/indexer/kafka"
+//
+// It panics if a producer is already registered for the indexer.
+func RegisterProducer(indexer string, fn NewProducerFunc) {
+	producersMtx.Lock()
+	defer producersMtx.Unlock()
+	if _, ok := producers[indexer]; ok {
+		panic(fmt.Sprintf("stream: producer already registered for the %q indexer", indexer))
+	}
+	producers[indexer] = fn
+}
+
+// NewProducer returns the producer registered for the given indexer,
+// configured by cfg.
+func NewProducer(indexer string, cfg *config.TxIndexConfig) (Producer, error) {
+	producersMtx.Lock()
+	fn, ok := producers[indexer]
+	producersMtx.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("the %q indexer is not built into this binary, "+
+			"which must import github.com/cometbft/cometbft/indexer/%s", indexer, indexer)
+	}
+	return fn(cfg)
+}
+
+// TLSConfig returns the TLS configuration of the connections to the broker, or
+// nil if TLS is disabled.
+func TLSConfig(cfg *config.TxIndexConfig) (*tls.Config, error) {
+	if !cfg.StreamTLS {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.StreamTLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.StreamTLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading the CA certificates: %w", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no CA certificate found in " + cfg.StreamTLSCAFile)
+		}
+	}
+	if cfg.StreamTLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.StreamTLSCertFile, cfg.StreamTLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading the client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,59 @@
+package stream
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+func TestRegisterProducer(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+
+	_, err := NewProducer("test-broker", cfg)
+	require.ErrorContains(t, err, "github.com/cometbft/cometbft/indexer/test-broker")
+
+	producer := &recordingProducer{}
+	RegisterProducer("test-broker", func(c *config.TxIndexConfig) (Producer, error) {
+		require.Same(t, cfg, c)
+		return producer, nil
+	})
+	p, err := NewProducer("test-broker", cfg)
+	require.NoError(t, err)
+	require.Same(t, producer, p)
+
+	require.Panics(t, func() {
+		RegisterProducer("test-broker", func(*config.TxIndexConfig) (Producer, error) { return nil, nil })
+	})
+}
+
+func TestTLSConfig(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	tlsCfg, err := TLSConfig(cfg)
+	require.NoError(t, err)
+	require.Nil(t, tlsCfg)
+
+	// the system roots are used by default
+	cfg.StreamTLS = true
+	tlsCfg, err = TLSConfig(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, tlsCfg)
+	require.Nil(t, tlsCfg.RootCAs)
+
+	dir := t.TempDir()
+	cfg.StreamTLSCAFile = filepath.Join(dir, "ca.pem")
+	_, err = TLSConfig(cfg)
+	require.Error(t, err)
+	require.NoError(t, os.WriteFile(cfg.StreamTLSCAFile, []byte("not a certificate"), 0o600))
+	_, err = TLSConfig(cfg)
+	require.ErrorContains(t, err, "no CA certificate")
+
+	cfg.StreamTLSCAFile = ""
+	cfg.StreamTLSCertFile = filepath.Join(dir, "client.pem")
+	cfg.StreamTLSKeyFile = filepath.Join(dir, "client-key.pem")
+	_, err = TLSConfig(cfg)
+	require.ErrorContains(t, err, "client certificate")
+}
@@ -0,0 +1,129 @@
+// Package stream implements an event sink publishing the events of the blocks
+// and of the transactions to a message broker, such as Kafka or NATS, for data
+// pipelines to ingest them.
+//
+// The events of a block are published to the "<prefix>.blocks" topic and the
+// events of each transaction to the "<prefix>.txs" topic, encoded in JSON as
+// BlockMessage and TxMessage. The messages are keyed by height and by
+// transaction hash respectively.
+//
+// The sink is write-only: searching for blocks or transactions through the
+// RPC is not supported.
+//
+// The producers publishing to the brokers are implemented with the clients of
+// the brokers in the github.com/cometbft/cometbft/indexer/kafka and
+// github.com/cometbft/cometbft/indexer/nats modules, which register them with
+// RegisterProducer when imported.
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Message is a message published to a topic.
+type Message struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// Producer publishes messages to a message broker.
+type Producer interface {
+	// Publish publishes the messages, in order, and returns once the broker
+	// acknowledged them.
+	Publish(msgs []Message) error
+
+	// Close closes the connections to the broker.
+	Close() error
+}
+
+// BlockMessage is the message published for the events of a block.
+type BlockMessage struct {
+	ChainID string       `json:"chain_id"`
+	Height  int64        `json:"height"`
+	NumTxs  int64        `json:"num_txs"`
+	Events  []abci.Event `json:"events"`
+}
+
+// TxMessage is the message published for the events of a transaction.
+type TxMessage struct {
+	ChainID string       `json:"chain_id"`
+	Height  int64        `json:"height"`
+	Index   uint32       `json:"index"`
+	Hash    string       `json:"hash"`
+	Tx      []byte       `json:"tx"`
+	Code    uint32       `json:"code"`
+	Events  []abci.Event `json:"events"`
+}
+
+// EventSink publishes the events through a producer.
+type EventSink struct {
+	producer    Producer
+	chainID     string
+	blocksTopic string
+	txsTopic    string
+}
+
+// NewEventSink returns an event sink publishing the events through producer,
+// to the topics prefixed with topicPrefix. Events written to the sink are
+// attributed to the specified chainID.
+func NewEventSink(producer Producer, chainID, topicPrefix string) *EventSink {
+	return &EventSink{
+		producer:    producer,
+		chainID:     chainID,
+		blocksTopic: topicPrefix + ".blocks",
+		txsTopic:    topicPrefix + ".txs",
+	}
+}
+
+// IndexBlockEvents publishes the events of the block.
+func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockEvents) error {
+	bz, err := json.Marshal(BlockMessage{
+		ChainID: es.chainID,
+		Height:  h.Height,
+		NumTxs:  h.NumTxs,
+		Events:  h.Events,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding block message: %w", err)
+	}
+	return es.producer.Publish([]Message{{
+		Topic: es.blocksTopic,
+		Key:   []byte(strconv.FormatInt(h.Height, 10)),
+		Value: bz,
+	}})
+}
+
+// IndexTxEvents publishes the events of the transactions, in one call to the
+// producer.
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	if len(txrs) == 0 {
+		return nil
+	}
+	msgs := make([]Message, 0, len(txrs))
+	for _, txr := range txrs {
+		hash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+		bz, err := json.Marshal(TxMessage{
+			ChainID: es.chainID,
+			Height:  txr.Height,
+			Index:   txr.Index,
+			Hash:    hash,
+			Tx:      txr.Tx,
+			Code:    txr.Result.Code,
+			Events:  txr.Result.Events,
+		})
+		if err != nil {
+			return fmt.Errorf("encoding tx message: %w", err)
+		}
+		msgs = append(msgs, Message{Topic: es.txsTopic, Key: []byte(hash), Value: bz})
+	}
+	return es.producer.Publish(msgs)
+}
+
+// Stop closes the producer.
+func (es *EventSink) Stop() error { return es.producer.Close() }
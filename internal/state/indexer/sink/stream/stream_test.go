@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/state/txindex"
+	"github.com/cometbft/cometbft/types"
+)
+
+type recordingProducer struct {
+	published [][]Message
+	err       error
+	closed    bool
+}
+
+func (p *recordingProducer) Publish(msgs []Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, msgs)
+	return nil
+}
+
+func (p *recordingProducer) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestEventSink(t *testing.T) {
+	producer := &recordingProducer{}
+	es := NewEventSink(producer, "test-chain", "chain")
+
+	events := []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{
+		{Key: "amount", Value: "10", Index: true},
+	}}}
+	require.NoError(t, es.BlockIndexer().Index(types.EventDataNewBlockEvents{
+		Height: 3,
+		Events: events,
+		NumTxs: 2,
+	}))
+	require.Len(t, producer.published, 1)
+	msg := producer.published[0][0]
+	require.Equal(t, "chain.blocks", msg.Topic)
+	require.Equal(t, "3", string(msg.Key))
+	var block BlockMessage
+	require.NoError(t, json.Unmarshal(msg.Value, &block))
+	require.Equal(t, BlockMessage{ChainID: "test-chain", Height: 3, NumTxs: 2, Events: events}, block)
+
+	batch := txindex.NewBatch(2)
+	for i := uint32(0); i < 2; i++ {
+		require.NoError(t, batch.Add(&abci.TxResult{
+			Height: 3,
+			Index:  i,
+			Tx:     types.Tx(fmt.Sprintf("tx%d", i)),
+			Result: abci.ExecTxResult{Code: i, Events: events},
+		}))
+	}
+	require.NoError(t, es.TxIndexer().AddBatch(batch))
+	// The transactions of the batch are published together, in order.
+	require.Len(t, producer.published, 2)
+	require.Len(t, producer.published[1], 2)
+	for i, msg := range producer.published[1] {
+		tx := types.Tx(fmt.Sprintf("tx%d", i))
+		require.Equal(t, "chain.txs", msg.Topic)
+		require.Equal(t, fmt.Sprintf("%X", tx.Hash()), string(msg.Key))
+		var txMsg TxMessage
+		require.NoError(t, json.Unmarshal(msg.Value, &txMsg))
+		require.Equal(t, TxMessage{
+			ChainID: "test-chain",
+			Height:  3,
+			Index:   uint32(i),
+			Hash:    string(msg.Key),
+			Tx:      tx,
+			Code:    uint32(i),
+			Events:  events,
+		}, txMsg)
+	}
+
+	// An empty batch publishes nothing.
+	require.NoError(t, es.TxIndexer().AddBatch(txindex.NewBatch(0)))
+	require.Len(t, producer.published, 2)
+
+	producer.err = errors.New("broker down")
+	require.ErrorIs(t, es.BlockIndexer().Index(types.EventDataNewBlockEvents{Height: 4}), producer.err)
+
+	_, err := es.TxIndexer().Search(context.Background(), nil)
+	require.Error(t, err)
+
+	require.NoError(t, es.Stop())
+	require.True(t, producer.closed)
+}
@@ -164,6 +164,34 @@ func (_m *Store) GetOfflineStateSyncHeight() (int64, error) {
 	return r0, r1
 }
 
+// GetOperatorRetainHeight provides a mock function with given fields:
+func (_m *Store) GetOperatorRetainHeight() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperatorRetainHeight")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Load provides a mock function with given fields:
 func (_m *Store) Load() (state.State, error) {
 	ret := _m.Called()
@@ -336,6 +364,62 @@ func (_m *Store) LoadLastFinalizeBlockResponse(height int64) (*v1.FinalizeBlockR
 	return r0, r1
 }
 
+// LoadLastHeightConsensusParamsChanged provides a mock function with given fields: height
+func (_m *Store) LoadLastHeightConsensusParamsChanged(height int64) (int64, error) {
+	ret := _m.Called(height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadLastHeightConsensusParamsChanged")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(height)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadLastHeightValidatorsChanged provides a mock function with given fields: height
+func (_m *Store) LoadLastHeightValidatorsChanged(height int64) (int64, error) {
+	ret := _m.Called(height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadLastHeightValidatorsChanged")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(height)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadValidators provides a mock function with given fields: height
 func (_m *Store) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	ret := _m.Called(height)
@@ -509,6 +593,24 @@ func (_m *Store) SaveFinalizeBlockResponse(height int64, res *v1.FinalizeBlockRe
 	return r0
 }
 
+// SaveOperatorRetainHeight provides a mock function with given fields: height
+func (_m *Store) SaveOperatorRetainHeight(height int64) error {
+	ret := _m.Called(height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveOperatorRetainHeight")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(height)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetOfflineStateSyncHeight provides a mock function with given fields: height
 func (_m *Store) SetOfflineStateSyncHeight(height int64) error {
 	ret := _m.Called(height)
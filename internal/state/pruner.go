@@ -16,8 +16,36 @@ var (
 	AppRetainHeightKey            = []byte("AppRetainHeightKey")
 	CompanionBlockRetainHeightKey = []byte("DCBlockRetainHeightKey")
 	ABCIResultsRetainHeightKey    = []byte("ABCIResRetainHeightKey")
+	OperatorRetainHeightKey       = []byte("OperatorRetainHeightKey")
 )
 
+// Sources of the block retain heights.
+const (
+	RetainHeightApplication = "application"
+	RetainHeightCompanion   = "companion"
+	RetainHeightOperator    = "operator"
+	RetainHeightSnapshots   = "snapshots"
+	RetainHeightIndexer     = "indexer"
+)
+
+// RetainHeightFunc returns the height from which a component of the node needs
+// the blocks to be retained, or 0 if it needs none of them.
+type RetainHeightFunc func() (int64, error)
+
+// SparseRetained returns whether the sparse pruning policy, keeping every
+// interval-th block below the retain height, keeps height. An interval of 0
+// keeps none.
+func SparseRetained(interval, height int64) bool {
+	return interval > 0 && height%interval == 0
+}
+
+// RetainHeight is the height from which a source needs the blocks to be
+// retained.
+type RetainHeight struct {
+	Source string
+	Height int64
+}
+
 // Pruner is a service that reads the retain heights for blocks, state and ABCI
 // results from the database and prunes the corresponding data based on the
 // minimum retain height set. The service sleeps between each run based on the
@@ -38,13 +66,19 @@ type Pruner struct {
 	interval     time.Duration
 	observer     PrunerObserver
 	metrics      *Metrics
+	// Sources of retain heights registered by the other components, in
+	// registration order.
+	retainHeightSources []string
+	retainHeightFuncs   map[string]RetainHeightFunc
 }
 
 type prunerConfig struct {
-	dcEnabled bool
-	interval  time.Duration
-	observer  PrunerObserver
-	metrics   *Metrics
+	dcEnabled           bool
+	interval            time.Duration
+	observer            PrunerObserver
+	metrics             *Metrics
+	retainHeightSources []string
+	retainHeightFuncs   map[string]RetainHeightFunc
 }
 
 func defaultPrunerConfig() *prunerConfig {
@@ -53,6 +87,8 @@ func defaultPrunerConfig() *prunerConfig {
 		interval:  config.DefaultPruningInterval,
 		observer:  &NoopPrunerObserver{},
 		metrics:   NopMetrics(),
+
+		retainHeightFuncs: make(map[string]RetainHeightFunc),
 	}
 }
 
@@ -84,6 +120,19 @@ func WithPrunerMetrics(metrics *Metrics) PrunerOption {
 	}
 }
 
+// WithPrunerRetainHeight registers a component of the node needing the blocks
+// to be retained: the pruner does not prune the blocks from the height
+// returned by fn, in addition to those retained by the application, the data
+// companion and the operator.
+func WithPrunerRetainHeight(source string, fn RetainHeightFunc) PrunerOption {
+	return func(p *prunerConfig) {
+		if _, ok := p.retainHeightFuncs[source]; !ok {
+			p.retainHeightSources = append(p.retainHeightSources, source)
+		}
+		p.retainHeightFuncs[source] = fn
+	}
+}
+
 // NewPruner creates a service that controls background pruning of node data.
 //
 // Assumes that the initial application and data companion retain heights have
@@ -110,6 +159,9 @@ func NewPruner(
 		observer:     cfg.observer,
 		metrics:      cfg.metrics,
 		dcEnabled:    cfg.dcEnabled,
+
+		retainHeightSources: cfg.retainHeightSources,
+		retainHeightFuncs:   cfg.retainHeightFuncs,
 	}
 	p.BaseService = *service.NewBaseService(logger, "Pruner", p)
 	return p
@@ -194,6 +246,22 @@ func (p *Pruner) SetCompanionBlockRetainHeight(height int64) error {
 	return nil
 }
 
+// SetOperatorRetainHeight sets the retain height of the operator, a floor
+// below which the blocks are not pruned whatever the other retain heights.
+// Unlike the others, it can be lowered; 0 removes it.
+func (p *Pruner) SetOperatorRetainHeight(height int64) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if height < 0 {
+		return ErrInvalidRetainHeight
+	}
+	if height > 0 && !p.heightWithinBounds(height) {
+		return ErrInvalidHeightValue
+	}
+	return p.stateStore.SaveOperatorRetainHeight(height)
+}
+
 // SetABCIResRetainHeight sets the retain height for ABCI responses.
 //
 // If the application has set the DiscardABCIResponses flag to true, nothing
@@ -279,6 +347,49 @@ func (p *Pruner) GetApplicationRetainHeight() (int64, error) {
 	return p.stateStore.GetApplicationRetainHeight()
 }
 
+// GetOperatorRetainHeight returns the retain height set by the operator, or 0
+// if none is set.
+func (p *Pruner) GetOperatorRetainHeight() (int64, error) {
+	height, err := p.stateStore.GetOperatorRetainHeight()
+	if errors.Is(err, ErrKeyNotFound) {
+		return 0, nil
+	}
+	return height, err
+}
+
+// BlockRetainHeights returns the retain heights of the blocks set by each
+// source. The blocks are pruned up to the lowest of them, ignoring the sources
+// other than the application and the data companion at 0.
+func (p *Pruner) BlockRetainHeights() ([]RetainHeight, error) {
+	appRetainHeight, err := p.stateStore.GetApplicationRetainHeight()
+	if err != nil {
+		return nil, ErrPrunerFailedToGetRetainHeight{Which: "application block", Err: err}
+	}
+	heights := []RetainHeight{{Source: RetainHeightApplication, Height: appRetainHeight}}
+	// We only care about the companion retain height if pruning is configured
+	// to respect the companion's retain height.
+	if p.dcEnabled {
+		dcRetainHeight, err := p.stateStore.GetCompanionBlockRetainHeight()
+		if err != nil {
+			return nil, ErrPrunerFailedToGetRetainHeight{Which: "companion block", Err: err}
+		}
+		heights = append(heights, RetainHeight{Source: RetainHeightCompanion, Height: dcRetainHeight})
+	}
+	operatorRetainHeight, err := p.GetOperatorRetainHeight()
+	if err != nil {
+		return nil, ErrPrunerFailedToGetRetainHeight{Which: "operator", Err: err}
+	}
+	heights = append(heights, RetainHeight{Source: RetainHeightOperator, Height: operatorRetainHeight})
+	for _, source := range p.retainHeightSources {
+		height, err := p.retainHeightFuncs[source]()
+		if err != nil {
+			return nil, ErrPrunerFailedToGetRetainHeight{Which: source, Err: err}
+		}
+		heights = append(heights, RetainHeight{Source: source, Height: height})
+	}
+	return heights, nil
+}
+
 // GetCompanionBlockRetainHeight is a convenience method for accessing the
 // GetCompanionBlockRetainHeight method of the underlying state store.
 func (p *Pruner) GetCompanionBlockRetainHeight() (int64, error) {
@@ -462,27 +573,31 @@ func (p *Pruner) pruneABCIResToRetainHeight(lastRetainHeight int64) int64 {
 }
 
 func (p *Pruner) findMinBlockRetainHeight() int64 {
-	appRetainHeight, err := p.stateStore.GetApplicationRetainHeight()
+	heights, err := p.BlockRetainHeights()
 	if err != nil {
-		p.logger.Error("Unexpected error fetching application retain height", "err", err)
+		p.logger.Error("Unexpected error fetching the block retain heights", "err", err)
 		return 0
 	}
-	// We only care about the companion retain height if pruning is configured
-	// to respect the companion's retain height.
-	if !p.dcEnabled {
-		return appRetainHeight
+	return MinBlockRetainHeight(heights)
+}
+
+// MinBlockRetainHeight returns the lowest of the retain heights. The
+// application and the data companion retain all the blocks at 0, the other
+// sources none of them.
+func MinBlockRetainHeight(heights []RetainHeight) int64 {
+	minHeight := int64(-1)
+	for _, h := range heights {
+		if h.Height == 0 && h.Source != RetainHeightApplication && h.Source != RetainHeightCompanion {
+			continue
+		}
+		if minHeight == -1 || h.Height < minHeight {
+			minHeight = h.Height
+		}
 	}
-	dcRetainHeight, err := p.stateStore.GetCompanionBlockRetainHeight()
-	if err != nil {
-		p.logger.Error("Unexpected error fetching data companion retain height", "err", err)
+	if minHeight == -1 {
 		return 0
 	}
-	// If we are here, both heights were set and the companion is enabled, so
-	// we pick the minimum.
-	if appRetainHeight < dcRetainHeight {
-		return appRetainHeight
-	}
-	return dcRetainHeight
+	return minHeight
 }
 
 func (p *Pruner) pruneBlocksToHeight(height int64) (uint64, int64, error) {
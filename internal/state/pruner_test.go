@@ -276,3 +276,62 @@ func TestPruningWithHeight1(t *testing.T) {
 	require.NoError(t, err)
 
 }
+
+func TestPrunerBlockRetainHeights(t *testing.T) {
+	state, bs, txIndexer, blockIndexer, cleanup, stateStore := makeStateAndBlockStoreAndIndexers()
+	defer cleanup()
+	require.NoError(t, initStateStoreRetainHeights(stateStore, 0, 0, 0))
+
+	snapshotsHeight := int64(0)
+	pruner := sm.NewPruner(
+		stateStore,
+		bs,
+		blockIndexer,
+		txIndexer,
+		log.TestingLogger(),
+		sm.WithPrunerCompanionEnabled(),
+		sm.WithPrunerRetainHeight(sm.RetainHeightSnapshots, func() (int64, error) { return snapshotsHeight, nil }),
+	)
+
+	for h := int64(1); h <= 10; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		bs.SaveBlock(block, partSet, &types.Commit{Height: h})
+	}
+	require.NoError(t, pruner.SetApplicationBlockRetainHeight(8))
+	require.NoError(t, pruner.SetCompanionBlockRetainHeight(6))
+
+	heights, err := pruner.BlockRetainHeights()
+	require.NoError(t, err)
+	require.Equal(t, []sm.RetainHeight{
+		{Source: sm.RetainHeightApplication, Height: 8},
+		{Source: sm.RetainHeightCompanion, Height: 6},
+		{Source: sm.RetainHeightOperator, Height: 0},
+		{Source: sm.RetainHeightSnapshots, Height: 0},
+	}, heights)
+	// The operator and the snapshots retain no blocks at 0.
+	require.EqualValues(t, 6, sm.MinBlockRetainHeight(heights))
+
+	snapshotsHeight = 5
+	require.NoError(t, pruner.SetOperatorRetainHeight(3))
+	heights, err = pruner.BlockRetainHeights()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, sm.MinBlockRetainHeight(heights))
+
+	// Unlike the others, the operator retain height can be lowered, or removed.
+	require.NoError(t, pruner.SetOperatorRetainHeight(2))
+	require.NoError(t, pruner.SetOperatorRetainHeight(0))
+	heights, err = pruner.BlockRetainHeights()
+	require.NoError(t, err)
+	require.EqualValues(t, 5, sm.MinBlockRetainHeight(heights))
+
+	require.ErrorIs(t, pruner.SetOperatorRetainHeight(-1), sm.ErrInvalidRetainHeight)
+	require.ErrorIs(t, pruner.SetOperatorRetainHeight(11), sm.ErrInvalidHeightValue)
+
+	// The application retains all the blocks at 0.
+	require.EqualValues(t, 0, sm.MinBlockRetainHeight([]sm.RetainHeight{
+		{Source: sm.RetainHeightApplication, Height: 0},
+		{Source: sm.RetainHeightSnapshots, Height: 5},
+	}))
+}
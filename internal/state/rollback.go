@@ -123,3 +123,135 @@ func Rollback(bs BlockStore, ss Store, removeBlock bool) (int64, []byte, error)
 
 	return rolledBackState.LastBlockHeight, rolledBackState.AppHash, nil
 }
+
+// RollbackHeights rolls back the CometBFT state by n heights, from height h
+// to height h - n. The state at h - n is built in one step from the blocks at
+// h - n and h - n + 1 and from the validator sets and consensus params stored
+// for the heights following h - n, with the heights they last changed
+// recorded there, so that rolling back across changes of either keeps the
+// stored ones loadable. The blocks of all heights above h - n + 1 are then
+// removed from the block store, and block h - n + 1 as well if removeBlock is
+// true. As in Rollback, if the block store is one height ahead of the state,
+// because the node stopped between saving a block and the state, that block
+// counts as the first height rolled back. For n = 1, the result is the same
+// as that of Rollback.
+// Note that this function does not affect application state.
+func RollbackHeights(bs BlockStore, ss Store, n int64, removeBlock bool) (int64, []byte, error) {
+	if n < 1 {
+		return -1, nil, fmt.Errorf("number of heights to roll back must be positive, got %d", n)
+	}
+	st, err := ss.Load()
+	if err != nil {
+		return -1, nil, err
+	}
+	if st.IsEmpty() {
+		return -1, nil, errors.New("no state found")
+	}
+
+	height := bs.Height()
+	if height != st.LastBlockHeight && height != st.LastBlockHeight+1 {
+		return -1, nil, fmt.Errorf("statestore height (%d) is not one below or equal to blockstore height (%d)",
+			st.LastBlockHeight, height)
+	}
+	target := st.LastBlockHeight - n
+	if height == st.LastBlockHeight+1 {
+		target++
+	}
+	if base := bs.Base(); target < base {
+		return -1, nil, fmt.Errorf("cannot roll back to height %d, below the block store base %d", target, base)
+	}
+
+	if target < st.LastBlockHeight {
+		if st, err = loadStateAtHeight(bs, ss, st, target); err != nil {
+			return -1, nil, err
+		}
+		// NOTE: this also persists the validator set and consensus params
+		// following the state over the existing ones, but they are the same
+		if err := ss.Save(st); err != nil {
+			return -1, nil, fmt.Errorf("failed to save rolled back state: %w", err)
+		}
+	}
+
+	// only keep the block following the state, if requested
+	keep := target + 1
+	if removeBlock {
+		keep = target
+	}
+	for bs.Height() > keep {
+		if err := bs.DeleteLatestBlock(); err != nil {
+			return -1, nil, fmt.Errorf("failed to remove block %d from blockstore: %w", bs.Height(), err)
+		}
+	}
+	return st.LastBlockHeight, st.AppHash, nil
+}
+
+// loadStateAtHeight builds the state at the given height, below that of
+// state st, whose immutable fields it keeps.
+func loadStateAtHeight(bs BlockStore, ss Store, st State, height int64) (State, error) {
+	block := bs.LoadBlockMeta(height)
+	if block == nil {
+		return State{}, fmt.Errorf("block at height %d not found", height)
+	}
+	// We also need to retrieve the following block because the app hash and
+	// last results hash are only agreed upon in the following block.
+	nextBlock := bs.LoadBlockMeta(height + 1)
+	if nextBlock == nil {
+		return State{}, fmt.Errorf("block at height %d not found", height+1)
+	}
+
+	lastValidators, err := ss.LoadValidators(height)
+	if err != nil {
+		return State{}, err
+	}
+	validators, err := ss.LoadValidators(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+	nextValidators, err := ss.LoadValidators(height + 2)
+	if err != nil {
+		return State{}, err
+	}
+	// the state saves its next validators with the height they last changed
+	valChangeHeight, err := ss.LoadLastHeightValidatorsChanged(height + 2)
+	if err != nil {
+		return State{}, err
+	}
+
+	params, err := ss.LoadConsensusParams(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+	// the state saves its consensus params with the height they last changed
+	paramsChangeHeight, err := ss.LoadLastHeightConsensusParamsChanged(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+
+	return State{
+		Version: cmtstate.Version{
+			Consensus: cmtversion.Consensus{
+				Block: version.BlockProtocol,
+				App:   params.Version.App,
+			},
+			Software: version.CMTSemVer,
+		},
+		// immutable fields
+		ChainID:       st.ChainID,
+		InitialHeight: st.InitialHeight,
+
+		LastBlockHeight: block.Header.Height,
+		LastBlockID:     block.BlockID,
+		LastBlockTime:   block.Header.Time,
+
+		NextValidators:              nextValidators,
+		Validators:                  validators,
+		LastValidators:              lastValidators,
+		LastHeightValidatorsChanged: valChangeHeight,
+
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: paramsChangeHeight,
+
+		LastResultsHash: nextBlock.Header.LastResultsHash,
+		AppHash:         nextBlock.Header.AppHash,
+	}, nil
+}
@@ -202,6 +202,219 @@ func TestRollbackHard(t *testing.T) {
 	require.Equal(t, rollbackHash, currState.AppHash)
 }
 
+func TestRollbackHeights(t *testing.T) {
+	const height int64 = 100
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	// advance the state by two heights
+	st := initialState
+	for i := 0; i < 2; i++ {
+		next := st.Copy()
+		next.LastBlockHeight++
+		next.LastBlockID = makeBlockIDRandom()
+		next.AppHash = crypto.CRandBytes(tmhash.Size)
+		next.LastValidators = st.Validators
+		next.Validators = st.NextValidators
+		next.NextValidators = st.NextValidators.CopyIncrementProposerPriority(1)
+		require.NoError(t, stateStore.Save(next))
+		st = next
+	}
+
+	metas := make(map[int64]*types.BlockMeta)
+	blockStore := &mocks.BlockStore{}
+	for h := height; h <= height+2; h++ {
+		metas[h] = &types.BlockMeta{
+			BlockID: makeBlockIDRandom(),
+			Header: types.Header{
+				Height:          h,
+				AppHash:         crypto.CRandBytes(tmhash.Size),
+				LastResultsHash: crypto.CRandBytes(tmhash.Size),
+			},
+		}
+		blockStore.On("LoadBlockMeta", h).Return(metas[h])
+	}
+	storeHeight := height + 2
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(func() int64 { return storeHeight })
+	blockStore.On("DeleteLatestBlock").Return(func() error {
+		storeHeight--
+		return nil
+	})
+
+	_, _, err = state.RollbackHeights(blockStore, stateStore, 0, false)
+	require.Error(t, err)
+	_, _, err = state.RollbackHeights(blockStore, stateStore, height+2, false)
+	require.Error(t, err)
+
+	rollbackHeight, rollbackHash, err := state.RollbackHeights(blockStore, stateStore, 2, false)
+	require.NoError(t, err)
+	require.Equal(t, height, rollbackHeight)
+	require.EqualValues(t, metas[height+1].Header.AppHash, rollbackHash)
+	// block height+1 is kept, so that it is replayed on restart
+	require.Equal(t, height+1, storeHeight)
+
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, height, loadedState.LastBlockHeight)
+	require.Equal(t, metas[height].BlockID, loadedState.LastBlockID)
+}
+
+func TestRollbackHeightsOne(t *testing.T) {
+	const height int64 = 100
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	// advance the state by one height
+	st := initialState.Copy()
+	st.LastBlockHeight++
+	st.LastBlockID = makeBlockIDRandom()
+	st.AppHash = crypto.CRandBytes(tmhash.Size)
+	st.LastValidators = initialState.Validators
+	st.Validators = initialState.NextValidators
+	st.NextValidators = initialState.NextValidators.CopyIncrementProposerPriority(1)
+	require.NoError(t, stateStore.Save(st))
+
+	metas := make(map[int64]*types.BlockMeta)
+	blockStore := &mocks.BlockStore{}
+	for h := height; h <= height+2; h++ {
+		metas[h] = &types.BlockMeta{
+			BlockID: makeBlockIDRandom(),
+			Header: types.Header{
+				Height:          h,
+				AppHash:         crypto.CRandBytes(tmhash.Size),
+				LastResultsHash: crypto.CRandBytes(tmhash.Size),
+			},
+		}
+		blockStore.On("LoadBlockMeta", h).Return(metas[h])
+	}
+	// the node stopped between saving block height+2 and the state
+	storeHeight := height + 2
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(func() int64 { return storeHeight })
+	blockStore.On("DeleteLatestBlock").Return(func() error {
+		storeHeight--
+		return nil
+	})
+
+	// as with Rollback, the pending block is the height rolled back
+	rollbackHeight, rollbackHash, err := state.RollbackHeights(blockStore, stateStore, 1, true)
+	require.NoError(t, err)
+	require.Equal(t, height+1, rollbackHeight)
+	require.EqualValues(t, st.AppHash, rollbackHash)
+	require.Equal(t, height+1, storeHeight)
+
+	// then the state is rolled back by one height, keeping its block
+	rollbackHeight, rollbackHash, err = state.RollbackHeights(blockStore, stateStore, 1, false)
+	require.NoError(t, err)
+	require.Equal(t, height, rollbackHeight)
+	require.EqualValues(t, metas[height+1].Header.AppHash, rollbackHash)
+	require.Equal(t, height+1, storeHeight)
+
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, height, loadedState.LastBlockHeight)
+	require.Equal(t, metas[height].BlockID, loadedState.LastBlockID)
+}
+
+func TestRollbackHeightsAcrossChanges(t *testing.T) {
+	const (
+		height             int64 = 10
+		valsChangeHeight   int64 = 20
+		paramsChangeHeight int64 = 25
+		finalHeight        int64 = 30
+		target             int64 = 15
+	)
+	stateStore := setupStateStore(t, height)
+	st, err := stateStore.Load()
+	require.NoError(t, err)
+
+	// advance the state, changing the validators at valsChangeHeight and the
+	// consensus params at paramsChangeHeight, as updateState does
+	states := map[int64]state.State{height: st}
+	for h := height + 1; h <= finalHeight; h++ {
+		next := st.Copy()
+		next.LastBlockHeight = h
+		next.LastBlockID = makeBlockIDRandom()
+		next.LastBlockTime = time.Unix(h, 0).UTC()
+		next.AppHash = crypto.CRandBytes(tmhash.Size)
+		next.LastResultsHash = crypto.CRandBytes(tmhash.Size)
+		next.LastValidators = st.Validators
+		next.Validators = st.NextValidators
+		next.NextValidators = st.NextValidators.CopyIncrementProposerPriority(1)
+		if h+2 == valsChangeHeight {
+			next.NextValidators, _ = types.RandValidatorSet(4, 10)
+			next.LastHeightValidatorsChanged = h + 2
+		}
+		if h+1 == paramsChangeHeight {
+			next.ConsensusParams.Block.MaxBytes = 1000
+			next.LastHeightConsensusParamsChanged = h + 1
+		}
+		require.NoError(t, stateStore.Save(next))
+		states[h] = next
+		st = next
+	}
+	require.NotEqual(t, states[target].Validators.Hash(), states[finalHeight].Validators.Hash())
+	require.NotEqual(t, states[target].ConsensusParams, states[finalHeight].ConsensusParams)
+
+	blockStore := &mocks.BlockStore{}
+	for h := height; h <= finalHeight; h++ {
+		meta := &types.BlockMeta{
+			BlockID: states[h].LastBlockID,
+			Header: types.Header{
+				Height: h,
+				Time:   states[h].LastBlockTime,
+			},
+		}
+		if prev, ok := states[h-1]; ok {
+			meta.Header.AppHash = prev.AppHash
+			meta.Header.LastResultsHash = prev.LastResultsHash
+		}
+		blockStore.On("LoadBlockMeta", h).Return(meta)
+	}
+	storeHeight := finalHeight
+	blockStore.On("Base").Return(height)
+	blockStore.On("Height").Return(func() int64 { return storeHeight })
+	blockStore.On("DeleteLatestBlock").Return(func() error {
+		storeHeight--
+		return nil
+	})
+
+	rollbackHeight, rollbackHash, err := state.RollbackHeights(blockStore, stateStore, finalHeight-target, false)
+	require.NoError(t, err)
+	require.Equal(t, target, rollbackHeight)
+	require.EqualValues(t, states[target].AppHash, rollbackHash)
+	require.Equal(t, target+1, storeHeight)
+
+	want := states[target]
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, want.LastBlockHeight, loadedState.LastBlockHeight)
+	require.Equal(t, want.LastBlockID, loadedState.LastBlockID)
+	require.Equal(t, want.LastBlockTime, loadedState.LastBlockTime)
+	require.EqualValues(t, want.AppHash, loadedState.AppHash)
+	require.EqualValues(t, want.LastResultsHash, loadedState.LastResultsHash)
+	require.Equal(t, want.LastValidators.Hash(), loadedState.LastValidators.Hash())
+	require.Equal(t, want.Validators.Hash(), loadedState.Validators.Hash())
+	require.Equal(t, want.NextValidators.Hash(), loadedState.NextValidators.Hash())
+	require.Equal(t, want.LastHeightValidatorsChanged, loadedState.LastHeightValidatorsChanged)
+	require.Equal(t, want.ConsensusParams, loadedState.ConsensusParams)
+	require.Equal(t, want.LastHeightConsensusParamsChanged, loadedState.LastHeightConsensusParamsChanged)
+
+	// the validators and consensus params of the heights replayed are still
+	// loadable
+	for h := target + 1; h <= finalHeight; h++ {
+		vals, err := stateStore.LoadValidators(h)
+		require.NoError(t, err, "height %d", h)
+		require.Equal(t, states[h-1].Validators.Hash(), vals.Hash(), "height %d", h)
+		params, err := stateStore.LoadConsensusParams(h)
+		require.NoError(t, err, "height %d", h)
+		require.Equal(t, states[h-1].ConsensusParams, params, "height %d", h)
+	}
+}
+
 func TestRollbackNoState(t *testing.T) {
 	stateStore := state.NewStore(dbm.NewMemDB(),
 		state.StoreOptions{
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	cmtstate "github.com/cometbft/cometbft/api/cometbft/state/v1"
@@ -299,15 +298,7 @@ func MakeGenesisStateFromFile(genDocFile string) (State, error) {
 
 // MakeGenesisDocFromFile reads and unmarshals genesis doc from the given file.
 func MakeGenesisDocFromFile(genDocFile string) (*types.GenesisDoc, error) {
-	genDocJSON, err := os.ReadFile(genDocFile)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
-	}
-	genDoc, err := types.GenesisDocFromJSON(genDocJSON)
-	if err != nil {
-		return nil, fmt.Errorf("error reading GenesisDoc: %w", err)
-	}
-	return genDoc, nil
+	return types.GenesisDocFromFile(genDocFile)
 }
 
 // MakeGenesisState creates state from types.GenesisDoc.
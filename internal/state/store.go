@@ -73,6 +73,12 @@ type Store interface {
 	LoadLastFinalizeBlockResponse(height int64) (*abci.FinalizeBlockResponse, error)
 	// LoadConsensusParams loads the consensus params for a given height
 	LoadConsensusParams(height int64) (types.ConsensusParams, error)
+	// LoadLastHeightValidatorsChanged loads the last height the validator set
+	// changed, as of the validator set at a given height
+	LoadLastHeightValidatorsChanged(height int64) (int64, error)
+	// LoadLastHeightConsensusParamsChanged loads the last height the
+	// consensus params changed, as of the consensus params at a given height
+	LoadLastHeightConsensusParamsChanged(height int64) (int64, error)
 	// Save overwrites the previous state with the updated one
 	Save(state State) error
 	// SaveFinalizeBlockResponse saves ABCIResponses for a given height
@@ -91,6 +97,10 @@ type Store interface {
 	SaveCompanionBlockRetainHeight(height int64) error
 	// GetCompanionBlockRetainHeight returns the retain height set by the data companion
 	GetCompanionBlockRetainHeight() (int64, error)
+	// SaveOperatorRetainHeight persists the retain height set by the operator
+	SaveOperatorRetainHeight(height int64) error
+	// GetOperatorRetainHeight returns the retain height set by the operator
+	GetOperatorRetainHeight() (int64, error)
 	// SaveABCIResRetainHeight persists the retain height for ABCI results set by the data companion
 	SaveABCIResRetainHeight(height int64) error
 	// GetABCIResRetainHeight returns the last saved retain height for ABCI results set by the data companion
@@ -116,6 +126,11 @@ type StoreOptions struct {
 	// the store will maintain only the response object from the latest
 	// height.
 	DiscardABCIResponses bool
+
+	// SparseRetention is the interval between two heights whose validators,
+	// consensus params and ABCI responses are kept by the pruning, along with
+	// their blocks, or 0 if none is kept.
+	SparseRetention int64
 }
 
 var _ Store = (*dbStore)(nil)
@@ -328,6 +343,12 @@ func (store dbStore) PruneStates(from int64, to int64, evidenceThresholdHeight i
 	// We have to delete in reverse order, to avoid deleting previous heights that have validator
 	// sets and consensus params that we may need to retrieve.
 	for h := to - 1; h >= from; h-- {
+		sparseRetained := SparseRetained(store.SparseRetention, h)
+		if sparseRetained {
+			keepVals[h] = true
+			keepParams[h] = true
+		}
+
 		// For heights we keep, we must make sure they have the full validator set or consensus
 		// params, otherwise they will panic if they're retrieved directly (instead of
 		// indirectly via a LastHeightChanged pointer).
@@ -396,9 +417,11 @@ func (store dbStore) PruneStates(from int64, to int64, evidenceThresholdHeight i
 			}
 		}
 
-		err = batch.Delete(calcABCIResponsesKey(h))
-		if err != nil {
-			return err
+		if !sparseRetained {
+			err = batch.Delete(calcABCIResponsesKey(h))
+			if err != nil {
+				return err
+			}
 		}
 		pruned++
 
@@ -444,6 +467,9 @@ func (store dbStore) PruneABCIResponses(targetRetainHeight int64) (int64, int64,
 	batchPruned := int64(0)
 
 	for h := lastRetainHeight; h < targetRetainHeight; h++ {
+		if SparseRetained(store.SparseRetention, h) {
+			continue
+		}
 		if err := batch.Delete(calcABCIResponsesKey(h)); err != nil {
 			return pruned, lastRetainHeight + pruned, fmt.Errorf("failed to delete ABCI responses at height %d: %w", h, err)
 		}
@@ -650,6 +676,25 @@ func (store dbStore) GetCompanionBlockRetainHeight() (int64, error) {
 	return height, nil
 }
 
+// OperatorRetainHeight.
+func (store dbStore) SaveOperatorRetainHeight(height int64) error {
+	return store.db.SetSync(OperatorRetainHeightKey, int64ToBytes(height))
+}
+
+func (store dbStore) GetOperatorRetainHeight() (int64, error) {
+	buf, err := store.getValue(OperatorRetainHeightKey)
+	if err != nil {
+		return 0, err
+	}
+	height := int64FromBytes(buf)
+
+	if height < 0 {
+		return 0, ErrInvalidHeightValue
+	}
+
+	return height, nil
+}
+
 // DataCompanionRetainHeight.
 func (store dbStore) SaveABCIResRetainHeight(height int64) error {
 	return store.db.SetSync(ABCIResultsRetainHeightKey, int64ToBytes(height))
@@ -729,6 +774,16 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	return vip, nil
 }
 
+// LoadLastHeightValidatorsChanged returns the last height the validator set
+// changed, as recorded with the validator set of the given height.
+func (store dbStore) LoadLastHeightValidatorsChanged(height int64) (int64, error) {
+	valInfo, err := loadValidatorsInfo(store.db, height)
+	if err != nil {
+		return 0, ErrNoValSetForHeight{height}
+	}
+	return valInfo.LastHeightChanged, nil
+}
+
 func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 	checkpointHeight := height - height%valSetCheckpointInterval
 	return cmtmath.MaxInt64(checkpointHeight, lastHeightChanged)
@@ -824,6 +879,16 @@ func (store dbStore) LoadConsensusParams(height int64) (types.ConsensusParams, e
 	return types.ConsensusParamsFromProto(paramsInfo.ConsensusParams), nil
 }
 
+// LoadLastHeightConsensusParamsChanged returns the last height the consensus
+// params changed, as recorded with the consensus params of the given height.
+func (store dbStore) LoadLastHeightConsensusParamsChanged(height int64) (int64, error) {
+	paramsInfo, err := store.loadConsensusParamsInfo(height)
+	if err != nil {
+		return 0, fmt.Errorf("could not find consensus params for height #%d: %w", height, err)
+	}
+	return paramsInfo.LastHeightChanged, nil
+}
+
 func (store dbStore) loadConsensusParamsInfo(height int64) (*cmtstate.ConsensusParamsInfo, error) {
 	buf, err := store.db.Get(calcConsensusParamsKey(height))
 	if err != nil {
@@ -222,6 +222,58 @@ func TestPruneStates(t *testing.T) {
 	}
 }
 
+func TestPruneStatesSparse(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		SparseRetention: 5,
+	})
+	pk := ed25519.GenPrivKey().PubKey()
+	validator := &types.Validator{Address: pk.Address(), VotingPower: 100, PubKey: pk}
+	validatorSet := &types.ValidatorSet{
+		Validators: []*types.Validator{validator},
+		Proposer:   validator,
+	}
+	for h := int64(1); h <= 20; h++ {
+		state := sm.State{
+			InitialHeight:   1,
+			LastBlockHeight: h - 1,
+			Validators:      validatorSet,
+			NextValidators:  validatorSet,
+			ConsensusParams: types.ConsensusParams{
+				Block: types.BlockParams{MaxBytes: 10e6},
+			},
+			LastHeightValidatorsChanged:      1,
+			LastHeightConsensusParamsChanged: 1,
+		}
+		require.NoError(t, stateStore.Save(state))
+		require.NoError(t, stateStore.SaveFinalizeBlockResponse(h, &abci.FinalizeBlockResponse{
+			TxResults: []*abci.ExecTxResult{{Data: []byte{1}}},
+		}))
+	}
+
+	require.NoError(t, stateStore.PruneStates(1, 18, 18))
+
+	for h := int64(1); h < 18; h++ {
+		_, err := stateStore.LoadFinalizeBlockResponse(h)
+		if h%5 != 0 {
+			require.Error(t, err, "abci height %v", h)
+			continue
+		}
+		require.NoError(t, err, "abci height %v", h)
+		vals, err := stateStore.LoadValidators(h)
+		require.NoError(t, err, "validators height %v", h)
+		require.NotNil(t, vals)
+		params, err := stateStore.LoadConsensusParams(h)
+		require.NoError(t, err, "params height %v", h)
+		require.NotEmpty(t, params)
+	}
+
+	// The responses kept are not pruned with the other ones.
+	_, _, err := stateStore.PruneABCIResponses(20)
+	require.NoError(t, err)
+	_, err = stateStore.LoadFinalizeBlockResponse(15)
+	require.NoError(t, err)
+}
+
 func TestTxResultsHash(t *testing.T) {
 	txResults := []*abci.ExecTxResult{
 		{Code: 32, Data: []byte("Hello"), Log: "Huh?"},
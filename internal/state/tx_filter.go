@@ -1,12 +1,15 @@
 package state
 
 import (
+	"fmt"
+
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/types"
 )
 
 // TxPreCheck returns a function to filter transactions before processing.
-// The function limits the size of a transaction to the block's maximum data size.
+// The function limits the size of a transaction to the block's maximum data size
+// and, if set, to the mempool's maximum transaction size of the consensus params.
 func TxPreCheck(state State) mempl.PreCheckFunc {
 	maxBytes := state.ConsensusParams.Block.MaxBytes
 	if maxBytes == -1 {
@@ -16,7 +19,17 @@ func TxPreCheck(state State) mempl.PreCheckFunc {
 		maxBytes,
 		state.Validators.Size(),
 	)
-	return mempl.PreCheckMaxBytes(maxDataBytes)
+	preCheck := mempl.PreCheckMaxBytes(maxDataBytes)
+	maxTxBytes := state.ConsensusParams.Mempool.MaxTxBytes
+	if maxTxBytes == 0 {
+		return preCheck
+	}
+	return func(tx types.Tx) error {
+		if int64(len(tx)) > maxTxBytes {
+			return fmt.Errorf("tx size is too big for the consensus params: %d, max: %d", len(tx), maxTxBytes)
+		}
+		return preCheck(tx)
+	}
 }
 
 // TxPostCheck returns a function to filter transactions after processing.
@@ -47,3 +47,20 @@ func TestTxFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestTxFilter_MempoolParams(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.ConsensusParams.Mempool.MaxTxBytes = 100
+
+	stateDB, err := dbm.NewDB("state", "memdb", os.TempDir())
+	require.NoError(t, err)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := stateStore.LoadFromDBOrGenesisDoc(genDoc)
+	require.NoError(t, err)
+
+	f := sm.TxPreCheck(state)
+	require.NoError(t, f(types.Tx(cmtrand.Bytes(100))))
+	require.Error(t, f(types.Tx(cmtrand.Bytes(101))))
+}
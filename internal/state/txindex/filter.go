@@ -0,0 +1,54 @@
+package txindex
+
+// EventFilter decides which event attributes are indexed. Entries are either
+// event types ("transfer") or composite keys ("transfer.sender").
+type EventFilter struct {
+	include map[string]struct{}
+	exclude map[string]struct{}
+}
+
+// NewEventFilter returns a filter accepting the event types and composite keys
+// in include (or everything, if include is empty), except those in exclude.
+// If both are empty, it returns nil, which allows everything.
+func NewEventFilter(include, exclude []string) *EventFilter {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil
+	}
+	f := &EventFilter{}
+	if len(include) > 0 {
+		f.include = toSet(include)
+	}
+	if len(exclude) > 0 {
+		f.exclude = toSet(exclude)
+	}
+	return f
+}
+
+// Allow reports whether the attribute attrKey of events of type eventType
+// should be indexed. A nil filter allows everything.
+func (f *EventFilter) Allow(eventType, attrKey string) bool {
+	if f == nil {
+		return true
+	}
+	compositeKey := eventType + "." + attrKey
+	if contains(f.exclude, eventType) || contains(f.exclude, compositeKey) {
+		return false
+	}
+	if f.include == nil {
+		return true
+	}
+	return contains(f.include, eventType) || contains(f.include, compositeKey)
+}
+
+func toSet(ss []string) map[string]struct{} {
+	m := make(map[string]struct{}, len(ss))
+	for _, s := range ss {
+		m[s] = struct{}{}
+	}
+	return m
+}
+
+func contains(set map[string]struct{}, s string) bool {
+	_, ok := set[s]
+	return ok
+}
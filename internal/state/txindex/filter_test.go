@@ -0,0 +1,73 @@
+package txindex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFilter(t *testing.T) {
+	testCases := []struct {
+		name             string
+		include, exclude []string
+		allowed          []string
+		denied           []string
+	}{
+		{
+			name:    "nothing configured",
+			allowed: []string{"transfer.sender", "message.action"},
+		},
+		{
+			name:    "include event type",
+			include: []string{"transfer"},
+			allowed: []string{"transfer.sender", "transfer.amount"},
+			denied:  []string{"message.action"},
+		},
+		{
+			name:    "include composite key",
+			include: []string{"transfer.sender"},
+			allowed: []string{"transfer.sender"},
+			denied:  []string{"transfer.amount", "message.sender"},
+		},
+		{
+			name:    "exclude event type",
+			exclude: []string{"message"},
+			allowed: []string{"transfer.sender"},
+			denied:  []string{"message.action", "message.sender"},
+		},
+		{
+			name:    "exclude takes precedence",
+			include: []string{"transfer"},
+			exclude: []string{"transfer.amount"},
+			allowed: []string{"transfer.sender"},
+			denied:  []string{"transfer.amount", "message.action"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			f := NewEventFilter(tc.include, tc.exclude)
+			for _, key := range tc.allowed {
+				typ, attr := splitKey(key)
+				assert.True(t, f.Allow(typ, attr), key)
+			}
+			for _, key := range tc.denied {
+				typ, attr := splitKey(key)
+				assert.False(t, f.Allow(typ, attr), key)
+			}
+		})
+	}
+
+	var nilFilter *EventFilter
+	assert.True(t, nilFilter.Allow("transfer", "sender"))
+}
+
+func splitKey(key string) (string, string) {
+	for i := len(key) - 1; i >= 0; i-- {
+		if key[i] == '.' {
+			return key[:i], key[i+1:]
+		}
+	}
+	return key, ""
+}
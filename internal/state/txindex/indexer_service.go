@@ -5,6 +5,7 @@ import (
 
 	"github.com/cometbft/cometbft/internal/service"
 	"github.com/cometbft/cometbft/internal/state/indexer"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -23,6 +24,10 @@ type IndexerService struct {
 	blockIdxr        indexer.BlockIndexer
 	eventBus         *types.EventBus
 	terminateOnError bool
+
+	mtx cmtsync.Mutex
+	// lowest height that failed to be indexed, 0 if none
+	failedHeight int64
 }
 
 // NewIndexerService returns a new service instance.
@@ -84,6 +89,7 @@ func (is *IndexerService) OnStart() error {
 							"index", txResult.Index,
 							"err", err,
 						)
+						is.indexFailed(height)
 
 						if is.terminateOnError {
 							if err := is.Stop(); err != nil {
@@ -96,6 +102,7 @@ func (is *IndexerService) OnStart() error {
 
 				if err := is.blockIdxr.Index(eventNewBlockEvents); err != nil {
 					is.Logger.Error("failed to index block", "height", height, "err", err)
+					is.indexFailed(height)
 					if is.terminateOnError {
 						if err := is.Stop(); err != nil {
 							is.Logger.Error("failed to stop", "err", err)
@@ -108,6 +115,7 @@ func (is *IndexerService) OnStart() error {
 
 				if err = is.txIdxr.AddBatch(batch); err != nil {
 					is.Logger.Error("failed to index block txs", "height", height, "err", err)
+					is.indexFailed(height)
 					if is.terminateOnError {
 						if err := is.Stop(); err != nil {
 							is.Logger.Error("failed to stop", "err", err)
@@ -123,6 +131,23 @@ func (is *IndexerService) OnStart() error {
 	return nil
 }
 
+// RetainHeight returns the lowest height the service failed to index since it
+// started, so that the blocks from it are retained until they are re-indexed
+// with `cometbft reindex`, or 0 if it indexed every height.
+func (is *IndexerService) RetainHeight() (int64, error) {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+	return is.failedHeight, nil
+}
+
+func (is *IndexerService) indexFailed(height int64) {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+	if is.failedHeight == 0 || height < is.failedHeight {
+		is.failedHeight = height
+	}
+}
+
 // OnStop implements service.Service by unsubscribing from all transactions.
 func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
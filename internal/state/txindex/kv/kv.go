@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	dbm "github.com/cometbft/cometbft-db"
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -40,6 +41,12 @@ type TxIndex struct {
 	store dbm.DB
 	// Number the events in the event list
 	eventSeq int64
+	// Which event attributes to index; nil indexes all of them.
+	eventFilter *txindex.EventFilter
+	// The types of the event attributes declared by the app, if any.
+	eventSchema *types.EventSchema
+	// Heights kept by the sparse pruning policy, 0 if disabled.
+	sparseInterval int64
 
 	log log.Logger
 }
@@ -64,6 +71,15 @@ func (txi *TxIndex) Prune(retainHeight int64) (int64, int64, error) {
 	if err != nil {
 		return 0, lastRetainHeight, err
 	}
+	if txi.sparseInterval > 0 {
+		kept := results[:0]
+		for _, result := range results {
+			if !state.SparseRetained(txi.sparseInterval, result.Height) {
+				kept = append(kept, result)
+			}
+		}
+		results = kept
+	}
 	if len(results) == 0 {
 		return 0, lastRetainHeight, nil
 	}
@@ -135,6 +151,25 @@ func (txi *TxIndex) Prune(retainHeight int64) (int64, int64, error) {
 	return numHeightsPersistentlyPruned, currentPersistentlyRetainedHeight, nil
 }
 
+// DeleteFromHeight removes all transactions indexed at the given height and
+// above, e.g. after the state has been rolled back below height.
+func (txi *TxIndex) DeleteFromHeight(height int64) error {
+	results, err := txi.Search(context.Background(), query.MustCompile(
+		fmt.Sprintf("tx.height >= %d", height)))
+	if err != nil {
+		return err
+	}
+
+	batch := txi.store.NewBatch()
+	defer batch.Close()
+	for _, result := range results {
+		if err := txi.deleteResult(result, batch); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
 func (txi *TxIndex) SetRetainHeight(retainHeight int64) error {
 	return txi.store.SetSync(TxIndexerRetainHeightKey, int64ToBytes(retainHeight))
 }
@@ -172,11 +207,35 @@ func (txi *TxIndex) getIndexerRetainHeight() (int64, error) {
 	return height, nil
 }
 
-// NewTxIndex creates new KV indexer.
-func NewTxIndex(store dbm.DB) *TxIndex {
-	return &TxIndex{
+// IndexerOption sets an optional parameter on the TxIndex.
+type IndexerOption func(*TxIndex)
+
+// WithEventFilter sets the filter deciding which event attributes are
+// indexed. Attributes rejected by the filter are not searchable.
+func WithEventFilter(f *txindex.EventFilter) IndexerOption {
+	return func(txi *TxIndex) {
+		txi.eventFilter = f
+	}
+}
+
+// WithSparseRetention keeps the transactions of every height multiple of
+// interval when pruning. An interval of 0 disables it.
+func WithSparseRetention(interval int64) IndexerOption {
+	return func(txi *TxIndex) {
+		txi.sparseInterval = interval
+	}
+}
+
+// NewTxIndex creates new KV indexer. It has no event schema until it is given
+// one with SetEventSchema or LoadEventSchema.
+func NewTxIndex(store dbm.DB, options ...IndexerOption) *TxIndex {
+	txi := &TxIndex{
 		store: store,
 	}
+	for _, option := range options {
+		option(txi)
+	}
+	return txi
 }
 
 func (txi *TxIndex) SetLogger(l log.Logger) {
@@ -230,6 +289,12 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 			return err
 		}
 
+		// index by gas used (always)
+		err = storeBatch.Set(keyForGasUsed(result), hash)
+		if err != nil {
+			return err
+		}
+
 		rawBytes, err := proto.Marshal(result)
 		if err != nil {
 			return err
@@ -254,6 +319,10 @@ func (txi *TxIndex) deleteResult(result *abci.TxResult, batch dbm.Batch) error {
 	if err != nil {
 		return err
 	}
+	err = batch.Delete(keyForGasUsed(result))
+	if err != nil {
+		return err
+	}
 	err = batch.Delete(hash)
 	if err != nil {
 		return err
@@ -301,6 +370,12 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 		return err
 	}
 
+	// index by gas used (always)
+	err = b.Set(keyForGasUsed(result), hash)
+	if err != nil {
+		return err
+	}
+
 	rawBytes, err := proto.Marshal(result)
 	if err != nil {
 		return err
@@ -340,6 +415,9 @@ func (txi *TxIndex) deleteEvents(result *abci.TxResult, batch dbm.Batch) error {
 						return err
 					}
 				}
+				if err := txi.deleteTypedEvents(compositeTag, attr.Value, result, batch); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -365,11 +443,14 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 			if compositeTag == types.TxHashKey || compositeTag == types.TxHeightKey {
 				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeTag)
 			}
-			if attr.GetIndex() {
+			if attr.GetIndex() && txi.eventFilter.Allow(event.Type, attr.Key) {
 				err := store.Set(keyForEvent(compositeTag, attr.Value, result, txi.eventSeq), hash)
 				if err != nil {
 					return err
 				}
+				if err := txi.indexTypedEvent(compositeTag, attr.Value, result, hash, store); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -388,7 +469,37 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 //
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
+//
+// For a query using OR, each alternative is searched separately and the
+// union of the resulting transactions is returned.
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	disjuncts := q.Disjuncts()
+	if len(disjuncts) == 1 {
+		return txi.search(ctx, disjuncts[0].Syntax())
+	}
+
+	results := make([]*abci.TxResult, 0)
+	resultMap := make(map[string]struct{})
+	for _, d := range disjuncts {
+		txs, err := txi.search(ctx, d.Syntax())
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range txs {
+			hashString := string(types.Tx(res.Tx).Hash())
+			if _, ok := resultMap[hashString]; !ok {
+				resultMap[hashString] = struct{}{}
+				results = append(results, res)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// search performs a search for transactions matching all of the given
+// conditions (like "tx.height > 5").
+func (txi *TxIndex) search(ctx context.Context, conditions []syntax.Condition) ([]*abci.TxResult, error) {
 	select {
 	case <-ctx.Done():
 		return make([]*abci.TxResult, 0), nil
@@ -399,9 +510,6 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	var hashesInitialized bool
 	filteredHashes := make(map[string][]byte)
 
-	// get a list of conditions (like "tx.height > 5")
-	conditions := q.Syntax()
-
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
 	if err != nil {
@@ -418,6 +526,21 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
+	// The conditions on the gas used are matched against its typed keys if
+	// there are no other conditions, and against the results otherwise.
+	conditions, gasRange, err := gasUsedRange(conditions)
+	if err != nil {
+		return nil, err
+	}
+	if gasRange != nil && len(conditions) == 0 {
+		start, end, ok := typedRangeKeys(abci.ATTRIBUTE_TYPE_INT, *gasRange)
+		if !ok {
+			return []*abci.TxResult{}, nil
+		}
+		filteredHashes = txi.matchTyped(ctx, abci.ATTRIBUTE_TYPE_INT, start, end, gasRange, filteredHashes, true, HeightInfo{})
+		hashesInitialized = true
+	}
+
 	// conditions to skip because they're handled before "everything else"
 	skipIndexes := make([]int, 0)
 	var heightInfo HeightInfo
@@ -491,6 +614,15 @@ RESULTS_LOOP:
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
 		}
+		if gasRange != nil {
+			withinBounds, err := idxutil.CheckBounds(*gasRange, big.NewInt(res.Result.GasUsed))
+			if err != nil {
+				return nil, err
+			}
+			if !withinBounds {
+				continue
+			}
+		}
 		hashString := string(h)
 		if _, ok := resultMap[hashString]; !ok {
 			resultMap[hashString] = struct{}{}
@@ -541,6 +673,12 @@ func (txi *TxIndex) match(
 		return filteredHashes
 	}
 
+	if c.Op == syntax.TEq {
+		if typ, start, end, ok := txi.typedEqualityKeys(c); ok {
+			return txi.matchTyped(ctx, typ, start, end, nil, filteredHashes, firstRun, heightInfo)
+		}
+	}
+
 	tmpHashes := make(map[string][]byte)
 
 	switch {
@@ -714,8 +852,21 @@ func (txi *TxIndex) matchRange(
 		return filteredHashes
 	}
 
+	if typ := txi.eventSchema.AttributeType(qr.Key); isOrderedType(typ) {
+		if typedRange, ok := typedQueryRange(typ, qr); ok {
+			start, end, ok := typedRangeKeys(typ, typedRange)
+			if !ok {
+				return make(map[string][]byte)
+			}
+			return txi.matchTyped(ctx, typ, start, end, &typedRange, filteredHashes, firstRun, heightInfo)
+		}
+	}
+
 	tmpHashes := make(map[string][]byte)
 
+	// Timestamps are compared as nanoseconds.
+	timeRange := idxutil.TimeRange(qr)
+
 	it, err := dbm.IteratePrefix(txi.store, startKey)
 	if err != nil {
 		panic(err)
@@ -766,12 +917,32 @@ LOOP:
 				txi.setTmpHashes(tmpHashes, it)
 			}
 
-			// XXX: passing time in a ABCI Events is not yet implemented
-			// case time.Time:
-			// 	v := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
-			// 	if v == r.upperBound {
-			// 		break
-			// 	}
+		}
+
+		if _, ok := qr.AnyBound().(time.Time); ok {
+			v, err := idxutil.ParseTimeValue(extractValueFromKey(it.Key()))
+			if err != nil {
+				continue LOOP
+			}
+			keyHeight, err := extractHeightFromKey(it.Key())
+			if err != nil {
+				txi.log.Error("failure to parse height from key:", err)
+				continue
+			}
+			withinBounds, err := checkHeightConditions(heightInfo, keyHeight)
+			if err != nil {
+				txi.log.Error("failure checking for height bounds:", err)
+				continue
+			}
+			if !withinBounds {
+				continue
+			}
+			withinBounds, err = idxutil.CheckBounds(timeRange, v)
+			if err != nil {
+				txi.log.Error("failed to parse bounds:", err)
+			} else if withinBounds {
+				txi.setTmpHashes(tmpHashes, it)
+			}
 		}
 
 		// Potentially exit early.
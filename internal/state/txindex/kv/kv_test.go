@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"testing"
 
 	"github.com/cosmos/gogoproto/proto"
@@ -740,6 +741,292 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxSearchOr(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	for i, tc := range []struct {
+		tx                string
+		height            int64
+		sender, recipient string
+	}{
+		{"tx1", 1, "X", "Y"},
+		{"tx2", 2, "Y", "X"},
+		{"tx3", 3, "Y", "Z"},
+		{"tx4", 4, "X", "X"},
+	} {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: "sender", Value: tc.sender, Index: true},
+				{Key: "recipient", Value: tc.recipient, Index: true},
+			}},
+		})
+		txResult.Tx = types.Tx(tc.tx)
+		txResult.Height = tc.height
+		txResult.Index = uint32(i)
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	testCases := []struct {
+		q    string
+		want []string
+	}{
+		{"transfer.sender = 'X' OR transfer.recipient = 'X'", []string{"tx1", "tx2", "tx4"}},
+		{"transfer.sender = 'Y' OR transfer.recipient = 'Z'", []string{"tx2", "tx3"}},
+		{"transfer.sender = 'Q' OR transfer.recipient = 'Q'", nil},
+		{"tx.height BETWEEN 2 AND 3", []string{"tx2", "tx3"}},
+		{"transfer.sender = 'Y' AND tx.height BETWEEN 3 AND 4", []string{"tx3"}},
+		{
+			"transfer.sender = 'X' AND tx.height BETWEEN 2 AND 4 OR transfer.recipient = 'X' AND tx.height BETWEEN 2 AND 4",
+			[]string{"tx2", "tx4"},
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(ctx, query.MustCompile(tc.q))
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(results))
+			for _, res := range results {
+				got = append(got, string(res.Tx))
+			}
+			assert.ElementsMatch(t, tc.want, got)
+		})
+	}
+}
+
+func TestTxIndexEventFilter(t *testing.T) {
+	filter := txindex.NewEventFilter([]string{"transfer", "message.sender"}, []string{"transfer.amount"})
+	indexer := NewTxIndex(db.NewMemDB(), WithEventFilter(filter))
+
+	txResult := txResultWithEvents([]abci.Event{
+		{Type: "transfer", Attributes: []abci.EventAttribute{
+			{Key: "sender", Value: "X", Index: true},
+			{Key: "amount", Value: "100", Index: true},
+		}},
+		{Type: "message", Attributes: []abci.EventAttribute{
+			{Key: "sender", Value: "X", Index: true},
+			{Key: "action", Value: "send", Index: true},
+		}},
+	})
+	require.NoError(t, indexer.Index(txResult))
+
+	testCases := []struct {
+		q             string
+		resultsLength int
+	}{
+		{"transfer.sender = 'X'", 1},
+		{"message.sender = 'X'", 1},
+		{"transfer.amount = 100", 0},
+		{"message.action = 'send'", 0},
+		{"tx.height = 1", 1},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		results, err := indexer.Search(ctx, query.MustCompile(tc.q))
+		require.NoError(t, err)
+		assert.Len(t, results, tc.resultsLength, tc.q)
+	}
+}
+
+func TestTxIndexDeleteFromHeight(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	var hashes [][]byte
+	for h := int64(1); h <= 3; h++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", h))
+		txResult.Height = h
+		require.NoError(t, indexer.Index(txResult))
+		hashes = append(hashes, types.Tx(txResult.Tx).Hash())
+	}
+
+	require.NoError(t, indexer.DeleteFromHeight(2))
+
+	res, err := indexer.Get(hashes[0])
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	for _, hash := range hashes[1:] {
+		res, err := indexer.Get(hash)
+		require.NoError(t, err)
+		require.Nil(t, res)
+	}
+
+	results, err := indexer.Search(context.Background(), query.MustCompile("account.number = 1"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, int64(1), results[0].Height)
+}
+
+func TestTxIndexEventSchema(t *testing.T) {
+	schema, err := types.EventSchemaFromProto(&abci.EventSchema{EventTypes: []abci.EventTypeSchema{
+		{Type: "transfer", Attributes: []abci.AttributeSchema{
+			{Key: "amount", Type: abci.ATTRIBUTE_TYPE_INT},
+			{Key: "fee", Type: abci.ATTRIBUTE_TYPE_UINT},
+		}},
+	}})
+	require.NoError(t, err)
+	store := db.NewMemDB()
+	indexer := NewTxIndex(store)
+	require.NoError(t, indexer.SetEventSchema(schema))
+
+	for i, value := range []struct{ amount, fee string }{
+		{"-5", "0"},
+		{"3", "1"},
+		{"010", "18446744073709551615"},
+		{"200", "2"},
+		{"abc", "3"}, // violates the schema
+	} {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: "amount", Value: value.amount, Index: true},
+				{Key: "fee", Value: value.fee, Index: true},
+			}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Height = int64(i + 1)
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	testCases := []struct {
+		q       string
+		heights []int64
+	}{
+		// The values are compared as integers, not as strings.
+		{"transfer.amount = 10", []int64{3}},
+		{"transfer.amount = '10'", []int64{3}},
+		{"transfer.amount = 10.5", nil},
+		{"transfer.amount > 5", []int64{3, 4}},
+		{"transfer.amount < 10", []int64{1, 2}},
+		{"transfer.amount > 2.5 AND transfer.amount <= 10", []int64{2, 3}},
+		{"transfer.amount > 9223372036854775807", nil},
+		{"transfer.amount > 5 AND tx.height > 3", []int64{4}},
+		{"transfer.amount > 5 AND tx.height = 3", []int64{3}},
+		{"transfer.fee > 18446744073709551614", []int64{3}},
+		{"transfer.fee <= 1", []int64{1, 2}},
+		{"transfer.amount > 0 AND transfer.fee < 5", []int64{2, 4}},
+		// The values violating the schema are only matched as strings.
+		{"transfer.amount = 'abc'", []int64{5}},
+		{"transfer.amount EXISTS", []int64{1, 2, 3, 4, 5}},
+	}
+
+	ctx := context.Background()
+
+	search := func(indexer *TxIndex, q string) []int64 {
+		results, err := indexer.Search(ctx, query.MustCompile(q))
+		require.NoError(t, err)
+		var heights []int64
+		for _, res := range results {
+			heights = append(heights, res.Height)
+		}
+		sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+		return heights
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.heights, search(indexer, tc.q), tc.q)
+	}
+
+	// The indexer loads the last schema it was given.
+	indexer = NewTxIndex(store)
+	require.NoError(t, indexer.LoadEventSchema())
+	require.Equal(t, []int64{3}, search(indexer, "transfer.amount = 10"))
+
+	// The typed keys are deleted with the transactions.
+	require.NoError(t, indexer.DeleteFromHeight(1))
+	for _, key := range getKeys(indexer) {
+		require.False(t, bytes.HasPrefix(key, typedTagPrefix(abci.ATTRIBUTE_TYPE_INT, "transfer.amount")))
+		require.False(t, bytes.HasPrefix(key, typedTagPrefix(abci.ATTRIBUTE_TYPE_UINT, "transfer.fee")))
+	}
+
+	// An application declaring no schema drops it.
+	require.NoError(t, NewTxIndex(store).SetEventSchema(nil))
+	indexer = NewTxIndex(store)
+	require.NoError(t, indexer.LoadEventSchema())
+	require.Nil(t, indexer.eventSchema)
+
+	// The errors of the store are returned.
+	require.NoError(t, store.Set(EventSchemaKey, []byte("not a schema")))
+	require.Error(t, NewTxIndex(store).LoadEventSchema())
+}
+
+func TestTxSearchComparisons(t *testing.T) {
+	schema, err := types.EventSchemaFromProto(&abci.EventSchema{EventTypes: []abci.EventTypeSchema{
+		{Type: "order", Attributes: []abci.AttributeSchema{
+			{Key: "expiry", Type: abci.ATTRIBUTE_TYPE_TIME},
+		}},
+	}})
+	require.NoError(t, err)
+	indexer := NewTxIndex(db.NewMemDB())
+	require.NoError(t, indexer.SetEventSchema(schema))
+
+	for i, value := range []struct {
+		price, expiry string
+		gasUsed       int64
+	}{
+		{"-20", "2024-03-01T10:00:00Z", 500},
+		{"-1.5", "2024-03-01T12:00:00+01:00", 1500},
+		{"3", "2024-03-02T00:00:00Z", 999999},
+		{"40", "2024-04-01T00:00:00Z", 1000001},
+	} {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "order", Attributes: []abci.EventAttribute{
+				{Key: "price", Value: value.price, Index: true},
+				{Key: "expiry", Value: value.expiry, Index: true},
+				// Not in the schema, so compared by parsing the strings.
+				{Key: "created", Value: value.expiry, Index: true},
+			}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Height = int64(i + 1)
+		txResult.Result.GasUsed = value.gasUsed
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	testCases := []struct {
+		q       string
+		heights []int64
+	}{
+		{"order.price < 0", []int64{1, 2}},
+		{"order.price >= -1.5", []int64{2, 3, 4}},
+		{"order.price > -20 AND order.price < 10", []int64{2, 3}},
+		{"order.expiry > TIME 2024-03-01T10:30:00Z", []int64{2, 3, 4}},
+		{"order.expiry >= TIME 2024-03-01T11:00:00Z", []int64{2, 3, 4}},
+		{"order.expiry = TIME 2024-03-01T11:00:00Z", []int64{2}},
+		{"order.expiry < DATE 2024-03-02", []int64{1, 2}},
+		{"order.expiry <= DATE 2024-03-02", []int64{1, 2, 3}},
+		{"order.created > TIME 2024-03-01T11:00:00Z", []int64{3, 4}},
+		{"order.created < DATE 2024-03-02 AND tx.height > 1", []int64{2}},
+		{"tx.gas_used > 1000000", []int64{4}},
+		{"tx.gas_used <= 1500", []int64{1, 2}},
+		{"tx.gas_used = 999999", []int64{3}},
+		{"tx.gas_used EXISTS", []int64{1, 2, 3, 4}},
+		{"tx.gas_used > 1000 AND order.price > 0", []int64{3, 4}},
+		{"tx.gas_used >= 1000 AND tx.gas_used < 1000000 AND tx.height < 3", []int64{2}},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		results, err := indexer.Search(ctx, query.MustCompile(tc.q))
+		require.NoError(t, err, tc.q)
+		var heights []int64
+		for _, res := range results {
+			heights = append(heights, res.Height)
+		}
+		sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+		require.Equal(t, tc.heights, heights, tc.q)
+	}
+
+	_, err = indexer.Search(ctx, query.MustCompile("tx.gas_used = 'abc'"))
+	require.Error(t, err)
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -0,0 +1,452 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	idxutil "github.com/cometbft/cometbft/internal/indexer"
+	"github.com/cometbft/cometbft/internal/pubsub/query/syntax"
+	"github.com/cometbft/cometbft/internal/state/indexer"
+	"github.com/cometbft/cometbft/types"
+	"github.com/google/orderedcode"
+)
+
+// The values of the integer and time attributes of the event schema are also
+// indexed under typed keys, in their order:
+//
+//	typedEventKeyPrefix/<type>/<composite key>/<value>/<height>/<index>/<event seq>
+//
+// encoded with orderedcode, so that the equality and range conditions on these
+// attributes are bounded scans. Times are encoded as nanoseconds since the Unix
+// epoch. The gas used by each transaction is indexed the same way, under
+// tx.gas_used with the event sequence 0.
+const typedEventKeyPrefix = "typed_event"
+
+// EventSchemaKey is the key of the last event schema used by the indexer.
+var EventSchemaKey = []byte("EventSchema")
+
+// SetEventSchema sets the event schema of the application, nil if it declares
+// none. The values of its integer and time attributes are indexed under typed
+// keys, which answer the queries on these attributes.
+//
+// The schema is persisted, for the tools indexing events without the
+// application, like reindex-event, to load it with LoadEventSchema.
+func (txi *TxIndex) SetEventSchema(schema *types.EventSchema) error {
+	if schema == nil {
+		if err := txi.store.DeleteSync(EventSchemaKey); err != nil {
+			return err
+		}
+	} else {
+		bz, err := schema.ToProto().Marshal()
+		if err != nil {
+			return err
+		}
+		if err := txi.store.SetSync(EventSchemaKey, bz); err != nil {
+			return err
+		}
+	}
+	txi.eventSchema = schema
+	return nil
+}
+
+// LoadEventSchema makes the indexer use the last event schema it was given
+// with SetEventSchema, if any.
+func (txi *TxIndex) LoadEventSchema() error {
+	bz, err := txi.store.Get(EventSchemaKey)
+	if err != nil || len(bz) == 0 {
+		return err
+	}
+	pb := new(abci.EventSchema)
+	if err := pb.Unmarshal(bz); err != nil {
+		return err
+	}
+	schema, err := types.EventSchemaFromProto(pb)
+	if err != nil {
+		return err
+	}
+	txi.eventSchema = schema
+	return nil
+}
+
+// isOrderedType reports whether the values of the type are indexed under typed
+// keys.
+func isOrderedType(typ abci.AttributeType) bool {
+	switch typ {
+	case abci.ATTRIBUTE_TYPE_INT, abci.ATTRIBUTE_TYPE_UINT, abci.ATTRIBUTE_TYPE_TIME:
+		return true
+	default:
+		return false
+	}
+}
+
+// integerTypeRange returns the minimum and maximum encoded values of the type.
+func integerTypeRange(typ abci.AttributeType) (minValue, maxValue *big.Int) {
+	if typ == abci.ATTRIBUTE_TYPE_UINT {
+		return new(big.Int), new(big.Int).SetUint64(math.MaxUint64)
+	}
+	return big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64)
+}
+
+func typedTagPrefix(typ abci.AttributeType, compositeKey string) []byte {
+	prefix, err := orderedcode.Append(nil, typedEventKeyPrefix, int64(typ), compositeKey)
+	if err != nil {
+		panic(err)
+	}
+	return prefix
+}
+
+// appendTypedValue returns key followed by the encoding of value, which must be
+// within the range of the type. key is not modified.
+func appendTypedValue(key []byte, typ abci.AttributeType, value *big.Int) []byte {
+	var v interface{} = value.Int64()
+	if typ == abci.ATTRIBUTE_TYPE_UINT {
+		v = value.Uint64()
+	}
+	key, err := orderedcode.Append(key[:len(key):len(key)], v)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// parseTypedValue parses value as a value of the type, returning false if it
+// is not one or cannot be encoded.
+func parseTypedValue(typ abci.AttributeType, value string) (*big.Int, bool) {
+	if types.ValidateAttributeValue(typ, value) != nil {
+		return nil, false
+	}
+	if typ == abci.ATTRIBUTE_TYPE_TIME {
+		v, err := idxutil.ParseTimeValue(value)
+		return v, err == nil && inTypeRange(typ, v)
+	}
+	v, ok := new(big.Int).SetString(value, 10)
+	return v, ok
+}
+
+// inTypeRange reports whether v can be encoded as a value of the type.
+func inTypeRange(typ abci.AttributeType, v *big.Int) bool {
+	minValue, maxValue := integerTypeRange(typ)
+	return v.Cmp(minValue) >= 0 && v.Cmp(maxValue) <= 0
+}
+
+// typedValuePrefix returns the prefix of the typed keys of the value of the
+// attribute, or false if the value is not one of the type.
+func typedValuePrefix(typ abci.AttributeType, compositeKey, value string) ([]byte, bool) {
+	v, ok := parseTypedValue(typ, value)
+	if !ok {
+		return nil, false
+	}
+	return appendTypedValue(typedTagPrefix(typ, compositeKey), typ, v), true
+}
+
+// keyForGasUsed returns the typed key indexing the transaction by its gas used.
+func keyForGasUsed(result *abci.TxResult) []byte {
+	valuePrefix := appendTypedValue(typedTagPrefix(abci.ATTRIBUTE_TYPE_INT, types.TxGasUsedKey),
+		abci.ATTRIBUTE_TYPE_INT, big.NewInt(result.Result.GasUsed))
+	return keyForTypedEvent(valuePrefix, result, 0)
+}
+
+func keyForTypedEvent(valuePrefix []byte, result *abci.TxResult, eventSeq int64) []byte {
+	key, err := orderedcode.Append(valuePrefix[:len(valuePrefix):len(valuePrefix)], result.Height, uint64(result.Index), eventSeq)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// parseTypedEventKey returns the value, the height and the event sequence of a
+// typed key.
+func parseTypedEventKey(typ abci.AttributeType, key []byte) (value *big.Int, height, eventSeq int64, err error) {
+	var (
+		prefix, compositeKey string
+		keyType              int64
+		index                uint64
+		intValue             int64
+		uintValue            uint64
+		v                    interface{} = &intValue
+	)
+	if typ == abci.ATTRIBUTE_TYPE_UINT {
+		v = &uintValue
+	}
+	remaining, err := orderedcode.Parse(string(key), &prefix, &keyType, &compositeKey, v, &height, &index, &eventSeq)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to parse typed event key: %w", err)
+	}
+	if len(remaining) != 0 {
+		return nil, 0, 0, fmt.Errorf("unexpected remainder in typed event key: %s", remaining)
+	}
+	if typ == abci.ATTRIBUTE_TYPE_UINT {
+		return new(big.Int).SetUint64(uintValue), height, eventSeq, nil
+	}
+	return big.NewInt(intValue), height, eventSeq, nil
+}
+
+// gasUsedRange returns the conditions other than those on the gas used by
+// the transactions, and the range of gas used they select, if any. Only the
+// comparisons to numbers and EXISTS are supported.
+func gasUsedRange(conditions []syntax.Condition) ([]syntax.Condition, *indexer.QueryRange, error) {
+	var (
+		rest     []syntax.Condition
+		gasRange *indexer.QueryRange
+	)
+	for _, c := range conditions {
+		if c.Tag != types.TxGasUsedKey {
+			rest = append(rest, c)
+			continue
+		}
+		if gasRange == nil {
+			gasRange = &indexer.QueryRange{Key: c.Tag}
+		}
+		if c.Op == syntax.TExists {
+			continue
+		}
+		if c.Arg == nil || c.Arg.Type != syntax.TNumber {
+			return nil, nil, fmt.Errorf("%s can only be compared to numbers", types.TxGasUsedKey)
+		}
+		switch c.Op {
+		case syntax.TEq:
+			gasRange.LowerBound, gasRange.IncludeLowerBound = c.Arg.Number(), true
+			gasRange.UpperBound, gasRange.IncludeUpperBound = c.Arg.Number(), true
+		case syntax.TGt, syntax.TGeq:
+			gasRange.LowerBound, gasRange.IncludeLowerBound = c.Arg.Number(), c.Op == syntax.TGeq
+		case syntax.TLt, syntax.TLeq:
+			gasRange.UpperBound, gasRange.IncludeUpperBound = c.Arg.Number(), c.Op == syntax.TLeq
+		default:
+			return nil, nil, fmt.Errorf("unsupported operator %v on %s", c.Op, types.TxGasUsedKey)
+		}
+	}
+	return rest, gasRange, nil
+}
+
+// typedQueryRange returns the range with bounds comparable to the encoded
+// values of the type, or false if its bounds are not of the type: numbers for
+// the integers, and timestamps or dates for the times.
+func typedQueryRange(typ abci.AttributeType, qr indexer.QueryRange) (indexer.QueryRange, bool) {
+	if typ == abci.ATTRIBUTE_TYPE_TIME {
+		_, ok := qr.AnyBound().(time.Time)
+		return idxutil.TimeRange(qr), ok
+	}
+	_, ok := qr.AnyBound().(*big.Float)
+	return qr, ok
+}
+
+// typedRangeKeys returns the keys delimiting the typed keys of the attribute
+// which may be within the range, or false if none can be. The bounds of the
+// range are *big.Float or *big.Int.
+func typedRangeKeys(typ abci.AttributeType, qr indexer.QueryRange) (start, end []byte, ok bool) {
+	lower, upper := integerTypeRange(typ)
+	minValue, maxValue := integerTypeRange(typ)
+	if v := integerBound(qr.LowerBound, false); v != nil && v.Cmp(lower) > 0 {
+		lower = v
+	}
+	if v := integerBound(qr.UpperBound, true); v != nil && v.Cmp(upper) < 0 {
+		upper = v
+	}
+	if lower.Cmp(maxValue) > 0 || upper.Cmp(minValue) < 0 || lower.Cmp(upper) > 0 {
+		return nil, nil, false
+	}
+	prefix := typedTagPrefix(typ, qr.Key)
+	return appendTypedValue(prefix, typ, lower), prefixEnd(appendTypedValue(prefix, typ, upper)), true
+}
+
+// integerBound returns the bound, a *big.Int or a *big.Float, as an integer
+// rounded down, or up if roundUp. The exact bound is checked on each value.
+func integerBound(bound interface{}, roundUp bool) *big.Int {
+	switch bound := bound.(type) {
+	case *big.Int:
+		return bound
+	case *big.Float:
+		v, acc := bound.Int(nil)
+		if acc == big.Above && !roundUp {
+			v.Sub(v, big.NewInt(1))
+		} else if acc == big.Below && roundUp {
+			v.Add(v, big.NewInt(1))
+		}
+		return v
+	default:
+		return nil
+	}
+}
+
+// prefixEnd returns the first key after all the keys starting with prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// indexTypedEvent indexes the value of the attribute under a typed key if the
+// event schema declares it an integer or a time, and the value is one.
+func (txi *TxIndex) indexTypedEvent(compositeTag, value string, result *abci.TxResult, hash []byte, store dbm.Batch) error {
+	typ := txi.eventSchema.AttributeType(compositeTag)
+	if !isOrderedType(typ) {
+		return nil
+	}
+	valuePrefix, ok := typedValuePrefix(typ, compositeTag, value)
+	if !ok {
+		// The value violates the schema; it is only indexed as a string.
+		return nil
+	}
+	return store.Set(keyForTypedEvent(valuePrefix, result, txi.eventSeq), hash)
+}
+
+// deleteTypedEvents deletes the typed keys of the value of the attribute, for
+// any type as the schema may have changed since it was indexed.
+func (txi *TxIndex) deleteTypedEvents(compositeTag, value string, result *abci.TxResult, batch dbm.Batch) error {
+	for _, typ := range []abci.AttributeType{abci.ATTRIBUTE_TYPE_INT, abci.ATTRIBUTE_TYPE_UINT, abci.ATTRIBUTE_TYPE_TIME} {
+		valuePrefix, ok := typedValuePrefix(typ, compositeTag, value)
+		if !ok {
+			continue
+		}
+		prefix, err := orderedcode.Append(valuePrefix[:len(valuePrefix):len(valuePrefix)], result.Height, uint64(result.Index))
+		if err != nil {
+			return err
+		}
+		it, err := dbm.IteratePrefix(txi.store, prefix)
+		if err != nil {
+			return err
+		}
+		for ; it.Valid(); it.Next() {
+			if err := batch.Delete(it.Key()); err != nil {
+				it.Close()
+				return err
+			}
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typedEqualityKeys returns the keys delimiting the typed keys matching the
+// equality condition, or false if the attribute is not an integer or a time of
+// the event schema, or the argument not a value of its type. The condition is
+// then matched against the values as strings.
+func (txi *TxIndex) typedEqualityKeys(c syntax.Condition) (typ abci.AttributeType, start, end []byte, ok bool) {
+	typ = txi.eventSchema.AttributeType(c.Tag)
+	if !isOrderedType(typ) {
+		return typ, nil, nil, false
+	}
+	var v *big.Int
+	switch {
+	case c.Arg.Type == syntax.TTime || c.Arg.Type == syntax.TDate:
+		if typ == abci.ATTRIBUTE_TYPE_TIME {
+			v = idxutil.TimeToNanos(c.Arg.Time())
+		}
+	case c.Arg.Type == syntax.TNumber:
+		if n := c.Arg.Number(); typ != abci.ATTRIBUTE_TYPE_TIME && n.IsInt() {
+			v, _ = n.Int(nil)
+		}
+	default:
+		v, _ = parseTypedValue(typ, c.Arg.Value())
+	}
+	if v == nil || !inTypeRange(typ, v) {
+		return typ, nil, nil, false
+	}
+	prefix := appendTypedValue(typedTagPrefix(typ, c.Tag), typ, v)
+	return typ, prefix, prefixEnd(prefix), true
+}
+
+// matchTyped returns all matching txs by hash whose typed keys of the
+// attribute are within [start, end) and, if qr is not nil, whose values are
+// within the range. An already filtered result (filteredHashes) is provided
+// such that any non-intersecting matches are removed.
+func (txi *TxIndex) matchTyped(
+	ctx context.Context,
+	typ abci.AttributeType,
+	start, end []byte,
+	qr *indexer.QueryRange,
+	filteredHashes map[string][]byte,
+	firstRun bool,
+	heightInfo HeightInfo,
+) map[string][]byte {
+	// A previous match was attempted but resulted in no matches, so we return
+	// no matches (assuming AND operand).
+	if !firstRun && len(filteredHashes) == 0 {
+		return filteredHashes
+	}
+
+	tmpHashes := make(map[string][]byte)
+
+	it, err := txi.store.Iterator(start, end)
+	if err != nil {
+		panic(err)
+	}
+	defer it.Close()
+
+LOOP:
+	for ; it.Valid(); it.Next() {
+		v, keyHeight, eventSeq, err := parseTypedEventKey(typ, it.Key())
+		if err != nil {
+			txi.log.Error("failure to parse typed event key:", err)
+			continue
+		}
+		withinBounds, err := checkHeightConditions(heightInfo, keyHeight)
+		if err != nil {
+			txi.log.Error("failure checking for height bounds:", err)
+			continue
+		}
+		if !withinBounds {
+			continue
+		}
+		if qr != nil {
+			withinBounds, err = idxutil.CheckBounds(*qr, v)
+			if err != nil {
+				txi.log.Error("failed to parse bounds:", err)
+				continue
+			}
+			if !withinBounds {
+				continue
+			}
+		}
+		tmpHashes[string(it.Value())+strconv.FormatInt(eventSeq, 10)] = it.Value()
+
+		// Potentially exit early.
+		select {
+		case <-ctx.Done():
+			break LOOP
+		default:
+		}
+	}
+	if err := it.Error(); err != nil {
+		panic(err)
+	}
+
+	if len(tmpHashes) == 0 || firstRun {
+		return tmpHashes
+	}
+
+	// Remove/reduce matches in filteredHashes that were not found in this
+	// match (tmpHashes).
+REMOVE_LOOP:
+	for k, v := range filteredHashes {
+		tmpHash := tmpHashes[k]
+		if tmpHash == nil || !bytes.Equal(tmpHash, v) {
+			delete(filteredHashes, k)
+
+			// Potentially exit early.
+			select {
+			case <-ctx.Done():
+				break REMOVE_LOOP
+			default:
+			}
+		}
+	}
+
+	return filteredHashes
+}
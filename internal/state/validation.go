@@ -90,7 +90,8 @@ func validateBlock(state State, block *types.Block) error {
 	} else {
 		// LastCommit.Signatures length is checked in VerifyCommit.
 		if err := state.LastValidators.VerifyCommit(
-			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
+			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit,
+			types.WithBatchVerification(!state.ConsensusParams.Validator.DisableBatchVerification)); err != nil {
 			return err
 		}
 	}
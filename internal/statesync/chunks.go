@@ -201,6 +201,14 @@ func (q *chunkQueue) Has(index uint32) bool {
 	return q.chunkFiles[index] != ""
 }
 
+// Progress returns the number of chunks in the queue, and the number of chunks returned via
+// Next() which aren't to be retried.
+func (q *chunkQueue) Progress() (fetched, returned uint32) {
+	q.Lock()
+	defer q.Unlock()
+	return uint32(len(q.chunkFiles)), uint32(len(q.chunkReturned))
+}
+
 // load loads a chunk from disk, or nil if the chunk is not in the queue. The caller must hold the
 // mutex lock.
 func (q *chunkQueue) load(index uint32) (*chunk, error) {
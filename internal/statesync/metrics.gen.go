@@ -20,11 +20,53 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "syncing",
 			Help:      "Whether or not a node is state syncing. 1 if yes, 0 if no.",
 		}, labels).With(labelsAndValues...),
+		Stage: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "stage",
+			Help:      "The stage of the state sync. 1 for the current stage, 0 for the others.",
+		}, append(labels, "stage")).With(labelsAndValues...),
+		SnapshotHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_height",
+			Help:      "The height of the snapshot being restored.",
+		}, labels).With(labelsAndValues...),
+		SnapshotChunks: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_chunks",
+			Help:      "The number of chunks of the snapshot being restored.",
+		}, labels).With(labelsAndValues...),
+		ChunksFetched: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunks_fetched",
+			Help:      "The number of chunks of the snapshot fetched so far.",
+		}, labels).With(labelsAndValues...),
+		ChunksApplied: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunks_applied",
+			Help:      "The number of chunks of the snapshot applied so far.",
+		}, labels).With(labelsAndValues...),
+		RemainingSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "remaining_seconds",
+			Help:      "The estimated time until the snapshot is restored, in seconds. 0 if unknown.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Syncing: discard.NewGauge(),
+		Syncing:          discard.NewGauge(),
+		Stage:            discard.NewGauge(),
+		SnapshotHeight:   discard.NewGauge(),
+		SnapshotChunks:   discard.NewGauge(),
+		ChunksFetched:    discard.NewGauge(),
+		ChunksApplied:    discard.NewGauge(),
+		RemainingSeconds: discard.NewGauge(),
 	}
 }
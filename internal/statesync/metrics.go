@@ -16,4 +16,17 @@ const (
 type Metrics struct {
 	// Whether or not a node is state syncing. 1 if yes, 0 if no.
 	Syncing metrics.Gauge
+	// The stage of the state sync. 1 for the current stage, 0 for the others.
+	Stage metrics.Gauge `metrics_labels:"stage"`
+	// The height of the snapshot being restored.
+	SnapshotHeight metrics.Gauge
+	// The number of chunks of the snapshot being restored.
+	SnapshotChunks metrics.Gauge
+	// The number of chunks of the snapshot fetched so far.
+	ChunksFetched metrics.Gauge
+	// The number of chunks of the snapshot applied so far.
+	ChunksApplied metrics.Gauge
+	// The estimated time until the snapshot is restored, in seconds. 0 if
+	// unknown.
+	RemainingSeconds metrics.Gauge
 }
@@ -0,0 +1,201 @@
+package statesync
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+const (
+	// minChunkRequestTimeout is the lowest timeout of a chunk request, however
+	// fast the peer has been so far.
+	minChunkRequestTimeout = time.Second
+	// chunkRequestTimeoutFactor is the factor applied to the average time a
+	// peer took to send chunks to get the timeout of its next chunk request.
+	chunkRequestTimeoutFactor = 4
+
+	// Score changes when a peer sends a chunk, times out, or sends a chunk
+	// which the app asks to refetch (e.g. on a hash mismatch).
+	scoreChunkReceived = 1
+	scoreChunkTimeout  = -2
+	scoreChunkRejected = -5
+)
+
+// peerScore tracks how well a peer serves chunks.
+type peerScore struct {
+	score    int
+	inflight int           // chunk requests awaiting a response
+	latency  time.Duration // moving average of the time to receive a chunk, 0 until measured
+}
+
+// peerScores scores the peers serving the chunks of a snapshot, so that chunks
+// are requested in parallel from the best peers, with a timeout adapted to each
+// peer, and re-requested from other peers when a peer fails to deliver them.
+type peerScores struct {
+	cmtsync.Mutex
+	maxTimeout time.Duration
+	peers      map[p2p.ID]*peerScore
+	failed     map[uint32]map[p2p.ID]bool // peers which failed to deliver a chunk, by chunk index
+}
+
+// newPeerScores creates new peer scores, with chunk request timeouts of at most
+// maxTimeout.
+func newPeerScores(maxTimeout time.Duration) *peerScores {
+	return &peerScores{
+		maxTimeout: maxTimeout,
+		peers:      make(map[p2p.ID]*peerScore),
+		failed:     make(map[uint32]map[p2p.ID]bool),
+	}
+}
+
+// get returns the score of a peer. The caller must hold the mutex lock.
+func (ps *peerScores) get(peerID p2p.ID) *peerScore {
+	s := ps.peers[peerID]
+	if s == nil {
+		s = &peerScore{}
+		ps.peers[peerID] = s
+	}
+	return s
+}
+
+// Pick picks the peer to request a chunk from, or nil if there are no peers.
+// Peers which failed to deliver the chunk are only picked if all did. Peers
+// with a non-negative score are preferred, then the ones with the fewest
+// requests in flight, so that chunks are fetched from several peers in
+// parallel, then the ones with the highest score and lowest latency.
+func (ps *peerScores) Pick(peers []snapshotPeer, index uint32) snapshotPeer {
+	ps.Lock()
+	defer ps.Unlock()
+
+	candidates := make([]snapshotPeer, 0, len(peers))
+	for _, peer := range peers {
+		if !ps.failed[index][peer.ID()] {
+			candidates = append(candidates, peer)
+		}
+	}
+	if len(candidates) == 0 {
+		// All peers failed, give them another chance.
+		delete(ps.failed, index)
+		candidates = append(candidates, peers...)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Shuffle first, so that ties are broken randomly.
+	rand.Shuffle(len(candidates), func(i, j int) { //nolint:gosec // G404: Use of weak random number generator
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := ps.get(candidates[i].ID()), ps.get(candidates[j].ID())
+		switch {
+		case (a.score >= 0) != (b.score >= 0):
+			return a.score >= 0
+		case a.inflight != b.inflight:
+			return a.inflight < b.inflight
+		case a.score != b.score:
+			return a.score > b.score
+		default:
+			return a.latency < b.latency
+		}
+	})
+	return candidates[0]
+}
+
+// Timeout returns the timeout of a chunk request to a peer. It's a multiple of
+// the time the peer took to send chunks so far, so that slow peers are given
+// up on early, but at most maxTimeout.
+func (ps *peerScores) Timeout(peerID p2p.ID) time.Duration {
+	ps.Lock()
+	defer ps.Unlock()
+
+	latency := ps.get(peerID).latency
+	if latency == 0 {
+		return ps.maxTimeout
+	}
+	timeout := chunkRequestTimeoutFactor * latency
+	switch {
+	case timeout < minChunkRequestTimeout:
+		timeout = minChunkRequestTimeout
+	case timeout > ps.maxTimeout:
+		timeout = ps.maxTimeout
+	}
+	return timeout
+}
+
+// Requested records that a chunk was requested from a peer.
+func (ps *peerScores) Requested(peerID p2p.ID) {
+	ps.Lock()
+	defer ps.Unlock()
+	ps.get(peerID).inflight++
+}
+
+// Received records that the peer sent the chunk it was requested after
+// latency.
+func (ps *peerScores) Received(peerID p2p.ID, latency time.Duration) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	s := ps.get(peerID)
+	s.inflight--
+	s.score += scoreChunkReceived
+	if s.latency == 0 {
+		s.latency = latency
+	} else {
+		s.latency = (3*s.latency + latency) / 4
+	}
+}
+
+// Done records that a request to a peer is no longer awaited, e.g. because the
+// chunk was received from another peer.
+func (ps *peerScores) Done(peerID p2p.ID) {
+	ps.Lock()
+	defer ps.Unlock()
+	ps.get(peerID).inflight--
+}
+
+// TimedOut records that a peer did not send a chunk in time. The chunk will be
+// requested from other peers first.
+func (ps *peerScores) TimedOut(peerID p2p.ID, index uint32) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	s := ps.get(peerID)
+	s.inflight--
+	s.score += scoreChunkTimeout
+	ps.fail(peerID, index)
+}
+
+// Rejected records that the app asked to refetch a chunk sent by a peer, e.g.
+// because its hash did not match. The chunk will be requested from other peers
+// first.
+func (ps *peerScores) Rejected(peerID p2p.ID, index uint32) {
+	if peerID == "" {
+		return
+	}
+	ps.Lock()
+	defer ps.Unlock()
+
+	ps.get(peerID).score += scoreChunkRejected
+	ps.fail(peerID, index)
+}
+
+// fail records that a peer failed to deliver a chunk. The caller must hold the
+// mutex lock.
+func (ps *peerScores) fail(peerID p2p.ID, index uint32) {
+	if ps.failed[index] == nil {
+		ps.failed[index] = make(map[p2p.ID]bool)
+	}
+	ps.failed[index][peerID] = true
+}
+
+// ResetChunks forgets which peers failed to deliver which chunks, when moving
+// on to another snapshot. Scores are kept.
+func (ps *peerScores) ResetChunks() {
+	ps.Lock()
+	defer ps.Unlock()
+	ps.failed = make(map[uint32]map[p2p.ID]bool)
+}
@@ -0,0 +1,87 @@
+package statesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/p2p"
+)
+
+func TestPeerScores_Pick(t *testing.T) {
+	peerB := simplePeer("b")
+	peers := []snapshotPeer{simplePeer("a"), peerB, simplePeer("c")}
+	scores := newPeerScores(10 * time.Second)
+
+	assert.Nil(t, scores.Pick(nil, 0))
+
+	// requests are spread across peers
+	seen := make(map[p2p.ID]bool)
+	for i := 0; i < len(peers); i++ {
+		peer := scores.Pick(peers, uint32(i))
+		scores.Requested(peer.ID())
+		seen[peer.ID()] = true
+	}
+	assert.Len(t, seen, len(peers))
+
+	// the peers with the best score are preferred
+	scores.Received("a", time.Second)
+	scores.Received("b", time.Second)
+	scores.Received("c", time.Second)
+	scores.Requested("a")
+	scores.Received("a", time.Second)
+	assert.Equal(t, p2p.ID("a"), scores.Pick(peers, 3).ID())
+
+	// then the fastest ones
+	scores.Requested("b")
+	scores.Received("b", 100*time.Millisecond)
+	assert.Equal(t, p2p.ID("b"), scores.Pick(peers, 3).ID())
+
+	// peers which timed out are avoided for the chunk
+	scores.Requested("b")
+	scores.TimedOut("b", 3)
+	assert.Equal(t, p2p.ID("a"), scores.Pick(peers, 3).ID())
+	assert.Equal(t, p2p.ID("b"), scores.Pick([]snapshotPeer{peerB}, 4).ID())
+
+	// and so are peers with a negative score, for all chunks
+	scores.Rejected("a", 4)
+	scores.Rejected("a", 5)
+	assert.Equal(t, p2p.ID("c"), scores.Pick(peers, 4).ID())
+	assert.Equal(t, p2p.ID("c"), scores.Pick(peers, 3).ID())
+
+	// until all peers failed to deliver the chunk
+	scores.Rejected("c", 3)
+	assert.Equal(t, p2p.ID("a"), scores.Pick(peers, 3).ID())
+	scores.Rejected("a", 3)
+	assert.NotNil(t, scores.Pick(peers, 3))
+	assert.Empty(t, scores.failed[3])
+
+	scores.ResetChunks()
+	assert.Empty(t, scores.failed)
+}
+
+func TestPeerScores_Timeout(t *testing.T) {
+	scores := newPeerScores(10 * time.Second)
+
+	// unknown peers get the maximum timeout
+	assert.Equal(t, 10*time.Second, scores.Timeout("a"))
+
+	scores.Requested("a")
+	scores.Received("a", time.Second)
+	assert.Equal(t, chunkRequestTimeoutFactor*time.Second, scores.Timeout("a"))
+
+	// the timeout follows the average latency
+	scores.Requested("a")
+	scores.Received("a", 5*time.Second)
+	assert.Equal(t, 8*time.Second, scores.Timeout("a"))
+
+	// within bounds
+	scores.Requested("b")
+	scores.Received("b", time.Millisecond)
+	assert.Equal(t, minChunkRequestTimeout, scores.Timeout("b"))
+
+	scores.Requested("c")
+	scores.Received("c", time.Minute)
+	assert.Equal(t, 10*time.Second, scores.Timeout("c"))
+}
@@ -0,0 +1,155 @@
+package statesync
+
+import (
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Stages of a state sync.
+const (
+	// StageDiscovering is the stage while discovering snapshots.
+	StageDiscovering = "discovering"
+	// StageOffering is the stage while verifying a snapshot against the light
+	// client and offering it to the app.
+	StageOffering = "offering"
+	// StageRestoring is the stage while fetching the chunks of a snapshot and
+	// applying them to the app.
+	StageRestoring = "restoring"
+	// StageVerifying is the stage while verifying the restored app.
+	StageVerifying = "verifying"
+	// StageDone is the stage once a snapshot is restored.
+	StageDone = "done"
+	// StageFailed is the stage once the state sync failed.
+	StageFailed = "failed"
+)
+
+var stages = []string{StageDiscovering, StageOffering, StageRestoring, StageVerifying, StageDone, StageFailed}
+
+// Progress is the progress of a state sync.
+type Progress struct {
+	Stage          string
+	SnapshotHeight uint64 // 0 if no snapshot was selected
+	SnapshotFormat uint32
+	SnapshotHash   []byte
+	ChunksTotal    uint32
+	ChunksFetched  uint32
+	ChunksApplied  uint32
+	StartTime      time.Time // of the state sync
+	ETA            time.Time // estimated time the snapshot is restored, zero if unknown
+}
+
+// progressTracker tracks the progress of a state sync, reporting it via metrics,
+// and via events when entering a new stage.
+type progressTracker struct {
+	mtx          cmtsync.Mutex
+	progress     Progress
+	restoreStart time.Time // when the current snapshot started being restored
+
+	metrics  *Metrics
+	eventBus types.StateSyncEventPublisher
+	logger   log.Logger
+}
+
+// newProgressTracker creates a new progress tracker for a state sync starting
+// now.
+func newProgressTracker(metrics *Metrics, eventBus types.StateSyncEventPublisher, logger log.Logger) *progressTracker {
+	return &progressTracker{
+		progress: Progress{Stage: StageDiscovering, StartTime: time.Now()},
+		metrics:  metrics,
+		eventBus: eventBus,
+		logger:   logger,
+	}
+}
+
+// Progress returns the current progress.
+func (pt *progressTracker) Progress() Progress {
+	pt.mtx.Lock()
+	defer pt.mtx.Unlock()
+	return pt.progress
+}
+
+// SetStage enters a new stage, for the given snapshot if not nil, or else for
+// the snapshot of the previous stage unless discovering snapshots. Chunks are
+// counted anew for each snapshot offered.
+func (pt *progressTracker) SetStage(stage string, snapshot *snapshot) {
+	pt.mtx.Lock()
+	defer pt.mtx.Unlock()
+
+	p := &pt.progress
+	p.Stage = stage
+	switch {
+	case snapshot != nil:
+		p.SnapshotHeight = snapshot.Height
+		p.SnapshotFormat = snapshot.Format
+		p.SnapshotHash = snapshot.Hash
+		p.ChunksTotal = snapshot.Chunks
+	case stage == StageDiscovering:
+		p.SnapshotHeight, p.SnapshotFormat, p.SnapshotHash, p.ChunksTotal = 0, 0, nil, 0
+	}
+	switch stage {
+	case StageDiscovering, StageOffering:
+		p.ChunksFetched, p.ChunksApplied = 0, 0
+	case StageRestoring:
+		pt.restoreStart = time.Now()
+	}
+	p.ETA = time.Time{}
+	pt.updateMetrics()
+
+	err := pt.eventBus.PublishEventStateSyncStatus(types.EventDataStateSyncStatus{
+		Stage:          p.Stage,
+		SnapshotHeight: p.SnapshotHeight,
+		SnapshotFormat: p.SnapshotFormat,
+		SnapshotHash:   p.SnapshotHash,
+		ChunksTotal:    p.ChunksTotal,
+		ChunksFetched:  p.ChunksFetched,
+		ChunksApplied:  p.ChunksApplied,
+	})
+	if err != nil {
+		pt.logger.Error("Failed to publish state sync status", "stage", stage, "err", err)
+	}
+}
+
+// SetChunks records the number of chunks fetched and applied so far, updating
+// the estimated time the snapshot is restored based on the rate at which the
+// chunks were applied.
+func (pt *progressTracker) SetChunks(fetched, applied uint32) {
+	pt.mtx.Lock()
+	defer pt.mtx.Unlock()
+
+	p := &pt.progress
+	p.ChunksFetched, p.ChunksApplied = fetched, applied
+	switch {
+	case applied == 0 || applied > p.ChunksTotal:
+		p.ETA = time.Time{}
+	default:
+		now := time.Now()
+		perChunk := now.Sub(pt.restoreStart) / time.Duration(applied)
+		p.ETA = now.Add(perChunk * time.Duration(p.ChunksTotal-applied))
+	}
+	pt.updateMetrics()
+}
+
+// updateMetrics updates the metrics with the current progress. The caller must
+// hold the mutex lock.
+func (pt *progressTracker) updateMetrics() {
+	p := pt.progress
+	for _, stage := range stages {
+		value := 0.0
+		if stage == p.Stage {
+			value = 1
+		}
+		pt.metrics.Stage.With("stage", stage).Set(value)
+	}
+	pt.metrics.SnapshotHeight.Set(float64(p.SnapshotHeight))
+	pt.metrics.SnapshotChunks.Set(float64(p.ChunksTotal))
+	pt.metrics.ChunksFetched.Set(float64(p.ChunksFetched))
+	pt.metrics.ChunksApplied.Set(float64(p.ChunksApplied))
+	remaining := 0.0
+	if !p.ETA.IsZero() {
+		remaining = time.Until(p.ETA).Seconds()
+	}
+	pt.metrics.RemainingSeconds.Set(remaining)
+}
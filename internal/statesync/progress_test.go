@@ -0,0 +1,62 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestProgressTracker(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryStateSyncStatus, 10)
+	require.NoError(t, err)
+
+	pt := newProgressTracker(NopMetrics(), eventBus, log.NewNopLogger())
+	s := &snapshot{Height: 3, Format: 1, Chunks: 4, Hash: []byte{1}}
+
+	pt.SetStage(StageOffering, s)
+	pt.SetStage(StageRestoring, s)
+	pt.SetChunks(2, 0)
+	assert.True(t, pt.Progress().ETA.IsZero())
+
+	pt.restoreStart = time.Now().Add(-2 * time.Second)
+	pt.SetChunks(4, 1)
+	p := pt.Progress()
+	assert.EqualValues(t, 4, p.ChunksFetched)
+	assert.EqualValues(t, 1, p.ChunksApplied)
+	assert.WithinDuration(t, time.Now().Add(6*time.Second), p.ETA, time.Second)
+
+	pt.SetStage(StageVerifying, nil)
+	p = pt.Progress()
+	assert.Equal(t, StageVerifying, p.Stage)
+	assert.EqualValues(t, 3, p.SnapshotHeight)
+	assert.True(t, p.ETA.IsZero())
+
+	pt.SetStage(StageDiscovering, nil)
+	p = pt.Progress()
+	assert.Zero(t, p.SnapshotHeight)
+	assert.Zero(t, p.ChunksFetched)
+
+	var stages []string
+	for i := 0; i < 4; i++ {
+		select {
+		case msg := <-sub.Out():
+			stages = append(stages, msg.Data().(types.EventDataStateSyncStatus).Stage)
+		case <-time.After(time.Second):
+			t.Fatal("did not receive a state sync status after 1 sec.")
+		}
+	}
+	assert.Equal(t, []string{StageOffering, StageRestoring, StageVerifying, StageDiscovering}, stages)
+}
@@ -3,6 +3,7 @@ package statesync
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"time"
 
@@ -35,11 +36,14 @@ type Reactor struct {
 	connQuery proxy.AppConnQuery
 	tempDir   string
 	metrics   *Metrics
+	eventBus  types.StateSyncEventPublisher
 
 	// This will only be set when a state sync is in progress. It is used to feed received
 	// snapshots and chunks into the sync.
 	mtx    cmtsync.RWMutex
 	syncer *syncer
+	// This is set once a state sync is started, and tracks its progress.
+	progress *progressTracker
 }
 
 // NewReactor creates a new state sync reactor.
@@ -54,12 +58,30 @@ func NewReactor(
 		conn:      conn,
 		connQuery: connQuery,
 		metrics:   metrics,
+		eventBus:  types.NopEventBus{},
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r)
 
 	return r
 }
 
+// SetEventBus sets the event bus on which the progress of state sync is
+// published.
+func (r *Reactor) SetEventBus(b types.StateSyncEventPublisher) {
+	r.eventBus = b
+}
+
+// Progress returns the progress of the state sync, or false if no state sync
+// was started.
+func (r *Reactor) Progress() (Progress, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.progress == nil {
+		return Progress{}, false
+	}
+	return r.progress.Progress(), true
+}
+
 // GetChannels implements p2p.Reactor.
 func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
@@ -89,7 +111,7 @@ func (r *Reactor) OnStart() error {
 func (r *Reactor) AddPeer(peer p2p.Peer) {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
-	if r.syncer != nil {
+	if r.syncer != nil && !r.cfg.FetchFromRPCServers {
 		r.syncer.AddPeer(peer)
 	}
 }
@@ -268,7 +290,8 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
 	r.metrics.Syncing.Set(1)
-	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
+	r.progress = newProgressTracker(r.metrics, r.eventBus, r.Logger)
+	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.progress, r.tempDir)
 	r.mtx.Unlock()
 
 	hook := func() {
@@ -281,9 +304,33 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 		})
 	}
 
+	if r.cfg.FetchFromRPCServers {
+		peers, err := r.rpcPeers()
+		if err != nil {
+			r.progress.SetStage(StageFailed, nil)
+			r.mtx.Lock()
+			r.syncer = nil
+			r.metrics.Syncing.Set(0)
+			r.mtx.Unlock()
+			return sm.State{}, nil, err
+		}
+		hook = func() {
+			r.Logger.Debug("Requesting snapshots from RPC servers")
+			for _, peer := range peers {
+				peer.Send(p2p.Envelope{
+					ChannelID: SnapshotChannel,
+					Message:   &ssproto.SnapshotsRequest{},
+				})
+			}
+		}
+	}
+
 	hook()
 
 	state, commit, err := r.syncer.SyncAny(discoveryTime, hook)
+	if err != nil {
+		r.progress.SetStage(StageFailed, nil)
+	}
 
 	r.mtx.Lock()
 	r.syncer = nil
@@ -291,3 +338,18 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 	r.mtx.Unlock()
 	return state, commit, err
 }
+
+// rpcPeers returns the peers fetching snapshots and chunks from the configured
+// RPC servers, in place of p2p peers.
+func (r *Reactor) rpcPeers() ([]*rpcPeer, error) {
+	downloads := newChunkDownloads()
+	peers := make([]*rpcPeer, 0, len(r.cfg.RPCServers))
+	for _, server := range r.cfg.RPCServers {
+		client, err := rpcClient(server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up RPC client: %w", err)
+		}
+		peers = append(peers, newRPCPeer(server, client, r.syncer, downloads, r.cfg.ChunkRequestTimeout, r.Logger))
+	}
+	return peers, nil
+}
@@ -0,0 +1,235 @@
+package statesync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+// snapshotClient fetches snapshots and chunks from an RPC server.
+type snapshotClient interface {
+	Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error)
+	SnapshotChunk(ctx context.Context, height uint64, format, index uint32, offset int) (*ctypes.ResultSnapshotChunk, error)
+}
+
+// rpcPeer answers the snapshot and chunk requests of the syncer by fetching
+// them from an RPC server, so that RPC servers can be used in place of p2p
+// peers. Chunks are downloaded in parts, and interrupted downloads are resumed
+// from the last part received, possibly from another server.
+type rpcPeer struct {
+	id        p2p.ID
+	client    snapshotClient
+	syncer    *syncer
+	downloads *chunkDownloads
+	timeout   time.Duration
+	logger    log.Logger
+}
+
+var _ snapshotPeer = (*rpcPeer)(nil)
+
+// newRPCPeer creates a new peer fetching snapshots from the RPC server at
+// address, and feeding them into the syncer.
+func newRPCPeer(
+	address string,
+	client snapshotClient,
+	syncer *syncer,
+	downloads *chunkDownloads,
+	timeout time.Duration,
+	logger log.Logger,
+) *rpcPeer {
+	return &rpcPeer{
+		id:        p2p.ID(address),
+		client:    client,
+		syncer:    syncer,
+		downloads: downloads,
+		timeout:   timeout,
+		logger:    logger.With("server", address),
+	}
+}
+
+// ID implements snapshotPeer. It's the address of the RPC server.
+func (p *rpcPeer) ID() p2p.ID {
+	return p.id
+}
+
+// Send implements snapshotPeer. The request is served asynchronously, like
+// requests sent to p2p peers.
+func (p *rpcPeer) Send(e p2p.Envelope) bool {
+	switch msg := e.Message.(type) {
+	case *ssproto.SnapshotsRequest:
+		go p.fetchSnapshots()
+	case *ssproto.ChunkRequest:
+		go p.fetchChunk(msg.Height, msg.Format, msg.Index)
+	default:
+		p.logger.Error("Unexpected message to RPC server", "msg", e.Message)
+		return false
+	}
+	return true
+}
+
+// fetchSnapshots fetches the recent snapshots of the server and adds them to
+// the syncer.
+func (p *rpcPeer) fetchSnapshots() {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	res, err := p.client.Snapshots(ctx)
+	if err != nil {
+		p.logger.Error("Failed to fetch snapshots", "err", err)
+		return
+	}
+	for _, s := range res.Snapshots {
+		p.logger.Debug("Received snapshot", "height", s.Height, "format", s.Format)
+		_, err := p.syncer.AddSnapshot(p, &snapshot{
+			Height:   s.Height,
+			Format:   s.Format,
+			Chunks:   s.Chunks,
+			Hash:     s.Hash,
+			Metadata: s.Metadata,
+		})
+		if err != nil {
+			p.logger.Error("Failed to add snapshot", "height", s.Height, "format", s.Format, "err", err)
+		}
+	}
+}
+
+// fetchChunk downloads a chunk, resuming any previous download of it, and
+// adds it to the syncer once complete. If the download is interrupted, what was
+// received so far is kept for the next request of the chunk.
+func (p *rpcPeer) fetchChunk(height uint64, format, index uint32) {
+	key := chunkDownloadKey{Height: height, Format: format, Index: index}
+	d, ok := p.downloads.Begin(key)
+	if !ok {
+		p.logger.Debug("Chunk download already in progress", "height", height, "format", format, "chunk", index)
+		return
+	}
+
+	for !d.complete() {
+		if err := p.fetchChunkPart(d); err != nil {
+			p.logger.Error("Failed to fetch chunk, will resume", "height", height, "format", format,
+				"chunk", index, "offset", len(d.data), "err", err)
+			p.downloads.End(key, d)
+			return
+		}
+	}
+	p.downloads.End(key, nil)
+
+	_, err := p.syncer.AddChunk(&chunk{
+		Height: height,
+		Format: format,
+		Index:  index,
+		Chunk:  d.data,
+		Sender: p.id,
+	})
+	if err != nil {
+		p.logger.Error("Failed to add chunk", "height", height, "format", format, "chunk", index, "err", err)
+	}
+}
+
+// fetchChunkPart fetches the next part of a chunk download.
+func (p *rpcPeer) fetchChunkPart(d *chunkDownload) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	offset := len(d.data)
+	res, err := p.client.SnapshotChunk(ctx, d.key.Height, d.key.Format, d.key.Index, offset)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case res.Height != d.key.Height || res.Format != d.key.Format || res.Index != d.key.Index:
+		return fmt.Errorf("received chunk %d of snapshot %d (format %d)", res.Index, res.Height, res.Format)
+	case res.Offset != offset:
+		return fmt.Errorf("received part at offset %d, expected %d", res.Offset, offset)
+	case d.size >= 0 && res.Size != d.size:
+		// the chunk changed since the download started, so start over.
+		err := fmt.Errorf("chunk size changed from %d to %d", d.size, res.Size)
+		d.reset()
+		return err
+	case offset+len(res.Data) > res.Size:
+		return fmt.Errorf("received %d bytes at offset %d, beyond chunk size %d", len(res.Data), offset, res.Size)
+	case len(res.Data) == 0 && offset < res.Size:
+		return fmt.Errorf("received empty part at offset %d of %d", offset, res.Size)
+	}
+
+	if d.size < 0 {
+		d.size = res.Size
+		d.data = make([]byte, 0, res.Size)
+	}
+	d.data = append(d.data, res.Data...)
+	return nil
+}
+
+// chunkDownloadKey identifies a chunk download.
+type chunkDownloadKey struct {
+	Height uint64
+	Format uint32
+	Index  uint32
+}
+
+// chunkDownload is a possibly partial chunk download.
+type chunkDownload struct {
+	key  chunkDownloadKey
+	size int // -1 until the first part is received
+	data []byte
+}
+
+func (d *chunkDownload) complete() bool {
+	return d.size >= 0 && len(d.data) == d.size
+}
+
+func (d *chunkDownload) reset() {
+	d.size = -1
+	d.data = nil
+}
+
+// chunkDownloads keeps track of the chunk downloads of the RPC peers, so that
+// the download of a chunk is resumed by the next peer it's requested from, and
+// a chunk isn't downloaded concurrently.
+type chunkDownloads struct {
+	cmtsync.Mutex
+	partial map[chunkDownloadKey]*chunkDownload
+	active  map[chunkDownloadKey]bool
+}
+
+func newChunkDownloads() *chunkDownloads {
+	return &chunkDownloads{
+		partial: make(map[chunkDownloadKey]*chunkDownload),
+		active:  make(map[chunkDownloadKey]bool),
+	}
+}
+
+// Begin starts or resumes the download of a chunk. It returns false if the
+// chunk is already being downloaded.
+func (ds *chunkDownloads) Begin(key chunkDownloadKey) (*chunkDownload, bool) {
+	ds.Lock()
+	defer ds.Unlock()
+	if ds.active[key] {
+		return nil, false
+	}
+	ds.active[key] = true
+	d := ds.partial[key]
+	if d == nil {
+		d = &chunkDownload{key: key, size: -1}
+	}
+	delete(ds.partial, key)
+	return d, true
+}
+
+// End ends the download of a chunk, keeping the partial download d to be
+// resumed later, if any.
+func (ds *chunkDownloads) End(key chunkDownloadKey, d *chunkDownload) {
+	ds.Lock()
+	defer ds.Unlock()
+	delete(ds.active, key)
+	if d != nil {
+		ds.partial[key] = d
+	}
+}
@@ -0,0 +1,107 @@
+package statesync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/statesync/mocks"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	proxymocks "github.com/cometbft/cometbft/proxy/mocks"
+	rpcmocks "github.com/cometbft/cometbft/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+func setupRPCPeer(client snapshotClient) (*rpcPeer, *syncer) {
+	cfg := config.DefaultStateSyncConfig()
+	syncer := newSyncer(*cfg, log.NewNopLogger(), &proxymocks.AppConnSnapshot{},
+		&proxymocks.AppConnQuery{}, &mocks.StateProvider{}, nopProgressTracker(), "")
+	peer := newRPCPeer("http://localhost:26657", client, syncer, newChunkDownloads(),
+		cfg.ChunkRequestTimeout, log.NewNopLogger())
+	return peer, syncer
+}
+
+func TestRPCPeer_fetchSnapshots(t *testing.T) {
+	client := &rpcmocks.Client{}
+	client.On("Snapshots", mock.Anything).Return(&ctypes.ResultSnapshots{
+		Snapshots: []*abci.Snapshot{
+			{Height: 2, Format: 1, Chunks: 2, Hash: []byte{2}},
+			{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}},
+		},
+	}, nil)
+	peer, syncer := setupRPCPeer(client)
+
+	peer.fetchSnapshots()
+
+	best := syncer.snapshots.Best()
+	require.NotNil(t, best)
+	assert.EqualValues(t, 2, best.Height)
+	assert.Len(t, syncer.snapshots.Ranked(), 2)
+	assert.Equal(t, []snapshotPeer{peer}, syncer.snapshots.GetPeers(best))
+	assert.Equal(t, p2p.ID("http://localhost:26657"), peer.ID())
+}
+
+func TestRPCPeer_fetchChunk_Resume(t *testing.T) {
+	s := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}
+	part := func(offset int, data []byte) *ctypes.ResultSnapshotChunk {
+		return &ctypes.ResultSnapshotChunk{Height: 1, Format: 1, Index: 0, Offset: offset, Size: 6, Data: data}
+	}
+
+	client := &rpcmocks.Client{}
+	client.On("SnapshotChunk", mock.Anything, uint64(1), uint32(1), uint32(0), 0).
+		Once().Return(part(0, []byte{1, 2}), nil)
+	client.On("SnapshotChunk", mock.Anything, uint64(1), uint32(1), uint32(0), 2).
+		Once().Return(nil, errors.New("connection reset"))
+	client.On("SnapshotChunk", mock.Anything, uint64(1), uint32(1), uint32(0), 2).
+		Once().Return(part(2, []byte{3, 4}), nil)
+	client.On("SnapshotChunk", mock.Anything, uint64(1), uint32(1), uint32(0), 4).
+		Once().Return(part(4, []byte{5, 6}), nil)
+	peer, syncer := setupRPCPeer(client)
+
+	chunks, err := newChunkQueue(s, t.TempDir())
+	require.NoError(t, err)
+	defer chunks.Close()
+	syncer.chunks = chunks
+
+	// the download is interrupted after the first part
+	peer.fetchChunk(1, 1, 0)
+	assert.False(t, chunks.Has(0))
+
+	// and resumed from the second one
+	peer.fetchChunk(1, 1, 0)
+	require.True(t, chunks.Has(0))
+	client.AssertExpectations(t)
+
+	c, err := chunks.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6}, c.Chunk)
+	assert.Equal(t, peer.ID(), c.Sender)
+}
+
+func TestRPCPeer_fetchChunk_SizeChanged(t *testing.T) {
+	client := &rpcmocks.Client{}
+	client.On("SnapshotChunk", mock.Anything, uint64(1), uint32(1), uint32(0), 0).
+		Once().Return(&ctypes.ResultSnapshotChunk{Height: 1, Format: 1, Size: 4, Data: []byte{1, 2}}, nil)
+	client.On("SnapshotChunk", mock.Anything, uint64(1), uint32(1), uint32(0), 2).
+		Once().Return(&ctypes.ResultSnapshotChunk{Height: 1, Format: 1, Offset: 2, Size: 5, Data: []byte{3, 4}}, nil)
+	peer, _ := setupRPCPeer(client)
+
+	peer.fetchChunk(1, 1, 0)
+	client.AssertExpectations(t)
+
+	// the download starts over
+	d, ok := peer.downloads.Begin(chunkDownloadKey{Height: 1, Format: 1, Index: 0})
+	require.True(t, ok)
+	assert.Empty(t, d.data)
+	assert.False(t, d.complete())
+
+	// and can't be started twice
+	_, ok = peer.downloads.Begin(chunkDownloadKey{Height: 1, Format: 1, Index: 0})
+	assert.False(t, ok)
+}
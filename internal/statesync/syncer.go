@@ -56,9 +56,10 @@ type syncer struct {
 	conn          proxy.AppConnSnapshot
 	connQuery     proxy.AppConnQuery
 	snapshots     *snapshotPool
+	scores        *peerScores
+	progress      *progressTracker
 	tempDir       string
 	chunkFetchers int32
-	retryTimeout  time.Duration
 
 	mtx    cmtsync.RWMutex
 	chunks *chunkQueue
@@ -71,6 +72,7 @@ func newSyncer(
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
 	stateProvider StateProvider,
+	progress *progressTracker,
 	tempDir string,
 ) *syncer {
 	return &syncer{
@@ -79,9 +81,10 @@ func newSyncer(
 		conn:          conn,
 		connQuery:     connQuery,
 		snapshots:     newSnapshotPool(),
+		scores:        newPeerScores(cfg.ChunkRequestTimeout),
+		progress:      progress,
 		tempDir:       tempDir,
 		chunkFetchers: cfg.ChunkFetchers,
-		retryTimeout:  cfg.ChunkRequestTimeout,
 	}
 }
 
@@ -98,6 +101,7 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 		return false, err
 	}
 	if added {
+		s.progress.SetChunks(s.chunks.Progress())
 		s.logger.Debug("Added chunk to queue", "height", chunk.Height, "format", chunk.Format,
 			"chunk", chunk.Index)
 	} else {
@@ -109,7 +113,7 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 
 // AddSnapshot adds a snapshot to the snapshot pool. It returns true if a new, previously unseen
 // snapshot was accepted and added.
-func (s *syncer) AddSnapshot(peer p2p.Peer, snapshot *snapshot) (bool, error) {
+func (s *syncer) AddSnapshot(peer snapshotPeer, snapshot *snapshot) (bool, error) {
 	added, err := s.snapshots.Add(peer, snapshot)
 	if err != nil {
 		return false, err
@@ -147,6 +151,7 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 	}
 
 	if discoveryTime > 0 {
+		s.progress.SetStage(StageDiscovering, nil)
 		s.logger.Info("Discovering snapshots", "discoverTime", discoveryTime)
 		time.Sleep(discoveryTime)
 	}
@@ -168,6 +173,7 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 			if discoveryTime == 0 {
 				return sm.State{}, nil, errNoSnapshots
 			}
+			s.progress.SetStage(StageDiscovering, nil)
 			retryHook()
 			s.logger.Info("sync any", "msg", log.NewLazySprintf("Discovering snapshots for %v", discoveryTime))
 			time.Sleep(discoveryTime)
@@ -245,6 +251,8 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 	s.chunks = chunks
 	s.mtx.Unlock()
+	s.scores.ResetChunks()
+	s.progress.SetStage(StageOffering, snapshot)
 	defer func() {
 		s.mtx.Lock()
 		s.chunks = nil
@@ -299,17 +307,21 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 
 	// Restore snapshot
+	s.progress.SetStage(StageRestoring, snapshot)
+	s.progress.SetChunks(chunks.Progress())
 	err = s.applyChunks(chunks)
 	if err != nil {
 		return sm.State{}, nil, err
 	}
 
 	// Verify app and app version
+	s.progress.SetStage(StageVerifying, snapshot)
 	if err := s.verifyApp(snapshot, state.Version.Consensus.App); err != nil {
 		return sm.State{}, nil, err
 	}
 
 	// Done! 🎉
+	s.progress.SetStage(StageDone, snapshot)
 	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
 		"hash", log.NewLazySprintf("%X", snapshot.Hash))
 
@@ -374,8 +386,10 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 		s.logger.Info("Applied snapshot chunk to ABCI app", "height", chunk.Height,
 			"format", chunk.Format, "chunk", chunk.Index, "total", chunks.Size())
 
-		// Discard and refetch any chunks as requested by the app
+		// Discard and refetch any chunks as requested by the app, preferably
+		// from other peers
 		for _, index := range resp.RefetchChunks {
+			s.scores.Rejected(chunks.GetSender(index), index)
 			err := chunks.Discard(index)
 			if err != nil {
 				return fmt.Errorf("failed to discard chunk %v: %w", index, err)
@@ -393,6 +407,8 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 			}
 		}
 
+		s.progress.SetChunks(chunks.Progress())
+
 		switch resp.Result {
 		case abci.APPLY_SNAPSHOT_CHUNK_RESULT_ACCEPT:
 		case abci.APPLY_SNAPSHOT_CHUNK_RESULT_ABORT:
@@ -410,7 +426,8 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 }
 
 // fetchChunks requests chunks from peers, receiving allocations from the chunk queue. Chunks
-// will be received from the reactor via syncer.AddChunks() to chunkQueue.Add().
+// will be received from the reactor via syncer.AddChunks() to chunkQueue.Add(). Each request
+// times out according to the peer's latency, after which the chunk is requested from another peer.
 func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) {
 	var (
 		next  = true
@@ -440,36 +457,57 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
 
-		ticker := time.NewTicker(s.retryTimeout)
-		defer ticker.Stop()
-
-		s.requestChunk(snapshot, index)
+		timeout := s.scores.maxTimeout
+		peer := s.requestChunk(snapshot, index)
+		if peer != nil {
+			timeout = s.scores.Timeout(peer.ID())
+		}
+		start := time.Now()
+		timer := time.NewTimer(timeout)
 
 		select {
 		case <-chunks.WaitFor(index):
+			if peer != nil {
+				if chunks.GetSender(index) == peer.ID() {
+					s.scores.Received(peer.ID(), time.Since(start))
+				} else {
+					s.scores.Done(peer.ID())
+				}
+			}
 			next = true
 
-		case <-ticker.C:
+		case <-timer.C:
+			if peer != nil {
+				s.logger.Debug("Timed out waiting for snapshot chunk", "height", snapshot.Height,
+					"format", snapshot.Format, "chunk", index, "peer", peer.ID(), "timeout", timeout)
+				s.scores.TimedOut(peer.ID(), index)
+			}
 			next = false
 
 		case <-ctx.Done():
+			timer.Stop()
+			if peer != nil {
+				s.scores.Done(peer.ID())
+			}
 			return
 		}
 
-		ticker.Stop()
+		timer.Stop()
 	}
 }
 
-// requestChunk requests a chunk from a peer.
-func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
-	peer := s.snapshots.GetPeer(snapshot)
+// requestChunk requests a chunk from the best scored peer having the snapshot, returning it, or
+// nil if there's none.
+func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) snapshotPeer {
+	peer := s.scores.Pick(s.snapshots.GetPeers(snapshot), chunk)
 	if peer == nil {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", log.NewLazySprintf("%X", snapshot.Hash))
-		return
+		return nil
 	}
 	s.logger.Debug("Requesting snapshot chunk", "height", snapshot.Height,
 		"format", snapshot.Format, "chunk", chunk, "peer", peer.ID())
+	s.scores.Requested(peer.ID())
 	peer.Send(p2p.Envelope{
 		ChannelID: ChunkChannel,
 		Message: &ssproto.ChunkRequest{
@@ -478,6 +516,7 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 			Index:  chunk,
 		},
 	})
+	return peer
 }
 
 // verifyApp verifies the sync, checking the app hash, last block height and app version.
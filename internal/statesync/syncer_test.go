@@ -35,11 +35,16 @@ func setupOfferSyncer() (*syncer, *proxymocks.AppConnSnapshot) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
 	cfg := config.DefaultStateSyncConfig()
-	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, nopProgressTracker(), "")
 
 	return syncer, connSnapshot
 }
 
+// Sets up a progress tracker which doesn't report the progress
+func nopProgressTracker() *progressTracker {
+	return newProgressTracker(NopMetrics(), types.NopEventBus{}, log.NewNopLogger())
+}
+
 // Sets up a simple peer mock with an ID
 func simplePeer(id string) *p2pmocks.Peer {
 	peer := &p2pmocks.Peer{}
@@ -89,7 +94,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 	connQuery := &proxymocks.AppConnQuery{}
 
 	cfg := config.DefaultStateSyncConfig()
-	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, nopProgressTracker(), "")
 
 	// Adding a chunk should error when no sync is in progress
 	_, err := syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1}})
@@ -225,6 +230,13 @@ func TestSyncer_SyncAny(t *testing.T) {
 	assert.Equal(t, expectState, newState)
 	assert.Equal(t, commit, lastCommit)
 
+	progress := syncer.progress.Progress()
+	assert.Equal(t, StageDone, progress.Stage)
+	assert.EqualValues(t, 1, progress.SnapshotHeight)
+	assert.EqualValues(t, 3, progress.ChunksTotal)
+	assert.EqualValues(t, 3, progress.ChunksFetched)
+	assert.EqualValues(t, 3, progress.ChunksApplied)
+
 	connSnapshot.AssertExpectations(t)
 	connQuery.AssertExpectations(t)
 	peerA.AssertExpectations(t)
@@ -432,7 +444,7 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
 
 			cfg := config.DefaultStateSyncConfig()
-			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, nopProgressTracker(), "")
 
 			body := []byte{1, 2, 3}
 			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 1}, "")
@@ -486,7 +498,7 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
 
 			cfg := config.DefaultStateSyncConfig()
-			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, nopProgressTracker(), "")
 
 			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 3}, "")
 			require.NoError(t, err)
@@ -531,6 +543,52 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 	}
 }
 
+func TestSyncer_applyChunks_RefetchFromOtherPeer(t *testing.T) {
+	syncer, connSnapshot := setupOfferSyncer()
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}}
+	peerA := simplePeer("a")
+	peerB := simplePeer("b")
+	peerB.On("Send", mock.MatchedBy(func(i interface{}) bool {
+		e, ok := i.(p2p.Envelope)
+		if !ok {
+			return false
+		}
+		req, ok := e.Message.(*ssproto.ChunkRequest)
+		return ok && e.ChannelID == ChunkChannel && req.Index == 0
+	})).Once().Return(true)
+	_, err := syncer.AddSnapshot(peerA, s)
+	require.NoError(t, err)
+	_, err = syncer.AddSnapshot(peerB, s)
+	require.NoError(t, err)
+
+	chunks, err := newChunkQueue(s, "")
+	require.NoError(t, err)
+	defer chunks.Close()
+	added, err := chunks.Add(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{0}, Sender: "a"})
+	require.True(t, added)
+	require.NoError(t, err)
+
+	// The app finds that chunk 0 from peer a does not match its hash.
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.ApplySnapshotChunkRequest{
+		Index: 0, Chunk: []byte{0}, Sender: "a",
+	}).Once().Return(&abci.ApplySnapshotChunkResponse{
+		Result:        abci.APPLY_SNAPSHOT_CHUNK_RESULT_RETRY,
+		RefetchChunks: []uint32{0},
+	}, nil)
+	go func() {
+		syncer.applyChunks(chunks) //nolint:errcheck // purposefully ignore error
+	}()
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, chunks.Has(0))
+
+	// The chunk is re-requested from peer b.
+	peer := syncer.requestChunk(s, 0)
+	require.NotNil(t, peer)
+	assert.Equal(t, p2p.ID("b"), peer.ID())
+	peerB.AssertExpectations(t)
+}
+
 func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 	// Banning chunks senders via ban_chunk_senders should work the same for all results
 	testcases := map[string]struct {
@@ -551,7 +609,7 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
 
 			cfg := config.DefaultStateSyncConfig()
-			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, nopProgressTracker(), "")
 
 			// Set up three peers across two snapshots, and ask for one of them to be banned.
 			// It should be banned from all snapshots.
@@ -673,7 +731,7 @@ func TestSyncer_verifyApp(t *testing.T) {
 			stateProvider := &mocks.StateProvider{}
 
 			cfg := config.DefaultStateSyncConfig()
-			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, nopProgressTracker(), "")
 
 			connQuery.On("Info", mock.Anything, proxy.InfoRequest).Return(tc.response, tc.err)
 			err := syncer.verifyApp(s, appVersion)
@@ -0,0 +1,65 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package storageinfo
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		SizeBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "size_bytes",
+			Help:      "Size of the files of the database on disk.",
+		}, append(labels, "store")).With(labelsAndValues...),
+		Keys: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "keys",
+			Help:      "Number of keys in the database.",
+		}, append(labels, "store")).With(labelsAndValues...),
+		CompactionSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compaction_seconds",
+			Help:      "Total time the database spent compacting its tables, for the backends reporting it.",
+		}, append(labels, "store")).With(labelsAndValues...),
+		CompactionReadBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compaction_read_bytes",
+			Help:      "Total size of the tables the database read while compacting them, for the backends reporting it.",
+		}, append(labels, "store")).With(labelsAndValues...),
+		CompactionWriteBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compaction_write_bytes",
+			Help:      "Total size of the tables the database wrote while compacting them, for the backends reporting it.",
+		}, append(labels, "store")).With(labelsAndValues...),
+		SampleDurationSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sample_duration_seconds",
+			Help:      "Time taken to sample all the databases.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SizeBytes:             discard.NewGauge(),
+		Keys:                  discard.NewGauge(),
+		CompactionSeconds:     discard.NewGauge(),
+		CompactionReadBytes:   discard.NewGauge(),
+		CompactionWriteBytes:  discard.NewGauge(),
+		SampleDurationSeconds: discard.NewGauge(),
+	}
+}
@@ -0,0 +1,33 @@
+package storageinfo
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "storage"
+)
+
+//go:generate go run ../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains the metrics of the databases of the node, updated by each
+// sample.
+type Metrics struct {
+	// Size of the files of the database on disk.
+	SizeBytes metrics.Gauge `metrics_labels:"store"`
+	// Number of keys in the database.
+	Keys metrics.Gauge `metrics_labels:"store"`
+	// Total time the database spent compacting its tables, for the backends
+	// reporting it.
+	CompactionSeconds metrics.Gauge `metrics_labels:"store"`
+	// Total size of the tables the database read while compacting them, for
+	// the backends reporting it.
+	CompactionReadBytes metrics.Gauge `metrics_labels:"store"`
+	// Total size of the tables the database wrote while compacting them, for
+	// the backends reporting it.
+	CompactionWriteBytes metrics.Gauge `metrics_labels:"store"`
+	// Time taken to sample all the databases.
+	SampleDurationSeconds metrics.Gauge
+}
@@ -0,0 +1,261 @@
+// Package storageinfo samples the usage of the databases of the node in the
+// background: their size on disk, their number of keys and the statistics of
+// their backend, so that the operators can plan the capacity of the nodes
+// without inspecting the database directory.
+package storageinfo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/config"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/internal/service"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+)
+
+// ErrNotSampled is returned when the databases were not sampled yet.
+var ErrNotSampled = errors.New("storage not sampled yet")
+
+// levelDBStats is the goleveldb property holding the compaction statistics.
+const levelDBStats = "leveldb.stats"
+
+// StoreStats are the statistics of a database.
+type StoreStats struct {
+	// Name of the database, e.g. blockstore.
+	Name string
+	// Size of the files of the database on disk.
+	SizeBytes int64
+	// Number of keys and total size of the keys and values.
+	Keys      int64
+	DataBytes int64
+	// Compaction statistics, zero if the backend doesn't report them.
+	Compaction Compaction
+	// Statistics of the backend, e.g. the leveldb properties.
+	Stats map[string]string
+}
+
+// Compaction holds the compaction statistics of a database.
+type Compaction struct {
+	Tables     int64
+	Seconds    float64
+	ReadBytes  int64
+	WriteBytes int64
+}
+
+// Sample holds the statistics of the databases at a point in time.
+type Sample struct {
+	Time time.Time
+	// Time taken to sample the databases.
+	Duration time.Duration
+	Stores   []StoreStats
+}
+
+type store struct {
+	name string
+	db   dbm.DB
+}
+
+// Sampler samples the databases opened through its Provider every interval.
+type Sampler struct {
+	service.BaseService
+
+	dir      string
+	interval time.Duration
+	metrics  *Metrics
+
+	mtx    cmtsync.Mutex
+	stores []store
+	last   *Sample
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+// NewSampler returns a Sampler of the databases in dir, sampling them every
+// interval.
+func NewSampler(dir string, interval time.Duration) *Sampler {
+	s := &Sampler{
+		dir:      dir,
+		interval: interval,
+		metrics:  NopMetrics(),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	s.BaseService = *service.NewBaseService(nil, "StorageSampler", s)
+	return s
+}
+
+// Provider returns a DBProvider opening the databases with next, and adding
+// them to those sampled.
+func (s *Sampler) Provider(next config.DBProvider) config.DBProvider {
+	return func(ctx *config.DBContext) (dbm.DB, error) {
+		db, err := next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.mtx.Lock()
+		s.stores = append(s.stores, store{name: ctx.ID, db: db})
+		s.mtx.Unlock()
+		return db, nil
+	}
+}
+
+// SetMetrics sets the metrics updated by each sample. It must be called before
+// the sampler starts.
+func (s *Sampler) SetMetrics(metrics *Metrics) {
+	s.metrics = metrics
+}
+
+// Interval returns the interval between two samples.
+func (s *Sampler) Interval() time.Duration {
+	return s.interval
+}
+
+// Last returns the last sample, or ErrNotSampled.
+func (s *Sampler) Last() (*Sample, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.last == nil {
+		return nil, ErrNotSampled
+	}
+	return s.last, nil
+}
+
+// OnStart implements service.Service by starting to sample the databases.
+func (s *Sampler) OnStart() error {
+	go s.run()
+	return nil
+}
+
+// OnStop implements service.Service by interrupting the current sample, so
+// that the databases can be closed once it returns.
+func (s *Sampler) OnStop() {
+	close(s.quit)
+	<-s.done
+}
+
+func (s *Sampler) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if sample, ok := s.sample(); ok {
+			s.mtx.Lock()
+			s.last = sample
+			s.mtx.Unlock()
+		}
+		select {
+		case <-ticker.C:
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// sample returns the statistics of the databases, or false if the sampler
+// stopped meanwhile.
+func (s *Sampler) sample() (*Sample, bool) {
+	s.mtx.Lock()
+	stores := s.stores
+	s.mtx.Unlock()
+
+	start := time.Now()
+	sample := &Sample{Time: start, Stores: make([]StoreStats, 0, len(stores))}
+	for _, st := range stores {
+		stats, err := s.storeStats(st)
+		if errors.Is(err, errStopped) {
+			return nil, false
+		}
+		if err != nil {
+			s.Logger.Error("Failed to sample a database", "store", st.name, "err", err)
+			continue
+		}
+		sample.Stores = append(sample.Stores, stats)
+		s.metrics.SizeBytes.With("store", st.name).Set(float64(stats.SizeBytes))
+		s.metrics.Keys.With("store", st.name).Set(float64(stats.Keys))
+		s.metrics.CompactionSeconds.With("store", st.name).Set(stats.Compaction.Seconds)
+		s.metrics.CompactionReadBytes.With("store", st.name).Set(float64(stats.Compaction.ReadBytes))
+		s.metrics.CompactionWriteBytes.With("store", st.name).Set(float64(stats.Compaction.WriteBytes))
+	}
+	sample.Duration = time.Since(start)
+	s.metrics.SampleDurationSeconds.Set(sample.Duration.Seconds())
+	return sample, true
+}
+
+var errStopped = errors.New("sampler stopped")
+
+func (s *Sampler) storeStats(st store) (StoreStats, error) {
+	stats := StoreStats{Name: st.name, Stats: st.db.Stats()}
+	// The in-memory databases have no files.
+	size, err := cmtos.DirSize(filepath.Join(s.dir, st.name+".db"))
+	if err != nil && !os.IsNotExist(err) {
+		return stats, err
+	}
+	stats.SizeBytes = size
+	if stats.Compaction, err = parseLevelDBStats(stats.Stats[levelDBStats]); err != nil {
+		return stats, err
+	}
+
+	it, err := st.db.Iterator(nil, nil)
+	if err != nil {
+		return stats, err
+	}
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		stats.Keys++
+		stats.DataBytes += int64(len(it.Key()) + len(it.Value()))
+		if stats.Keys%10000 == 0 {
+			select {
+			case <-s.quit:
+				return stats, errStopped
+			default:
+			}
+		}
+	}
+	return stats, it.Error()
+}
+
+// parseLevelDBStats returns the total of the compaction statistics of
+// goleveldb, formatted as
+//
+//	Compactions
+//	 Level |   Tables   |    Size(MB)   |    Time(sec)  |    Read(MB)   |   Write(MB)
+//	-------+------------+---------------+---------------+---------------+---------------
+//	   0   |          1 |       0.00000 |       0.00000 |       0.00000 |       0.00000
+//	-------+------------+---------------+---------------+---------------+---------------
+//	 Total |          1 |       0.00000 |       0.00000 |       0.00000 |       0.00000
+//
+// It returns zero statistics if stats is empty, e.g. for the other backends.
+func parseLevelDBStats(stats string) (Compaction, error) {
+	var c Compaction
+	if stats == "" {
+		return c, nil
+	}
+	for _, line := range strings.Split(stats, "\n") {
+		fields := strings.Split(line, "|")
+		if len(fields) != 6 || strings.TrimSpace(fields[0]) != "Total" {
+			continue
+		}
+		var values [5]float64
+		for i, field := range fields[1:] {
+			v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				return c, err
+			}
+			values[i] = v
+		}
+		const mb = 1 << 20
+		c.Tables = int64(values[0])
+		c.Seconds = values[2]
+		c.ReadBytes = int64(values[3] * mb)
+		c.WriteBytes = int64(values[4] * mb)
+		return c, nil
+	}
+	return c, errors.New("no total in the leveldb compaction statistics")
+}
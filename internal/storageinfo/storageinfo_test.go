@@ -0,0 +1,65 @@
+package storageinfo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/config"
+)
+
+func TestSampler(t *testing.T) {
+	dir := t.TempDir()
+	sampler := NewSampler(dir, time.Hour)
+	provider := sampler.Provider(func(ctx *config.DBContext) (dbm.DB, error) {
+		return dbm.NewDB(ctx.ID, dbm.GoLevelDBBackend, dir)
+	})
+	db, err := provider(&config.DBContext{ID: "blockstore"})
+	require.NoError(t, err)
+	defer db.Close()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("key%03d", i)), []byte("value")))
+	}
+
+	_, err = sampler.Last()
+	require.ErrorIs(t, err, ErrNotSampled)
+
+	require.NoError(t, sampler.Start())
+	var sample *Sample
+	require.Eventually(t, func() bool {
+		sample, err = sampler.Last()
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, sampler.Stop())
+
+	require.Len(t, sample.Stores, 1)
+	stats := sample.Stores[0]
+	require.Equal(t, "blockstore", stats.Name)
+	require.EqualValues(t, 100, stats.Keys)
+	require.EqualValues(t, 100*(6+5), stats.DataBytes)
+	require.Positive(t, stats.SizeBytes)
+	require.Contains(t, stats.Stats, levelDBStats)
+}
+
+func TestParseLevelDBStats(t *testing.T) {
+	stats := "Compactions\n" +
+		" Level |   Tables   |    Size(MB)   |    Time(sec)  |    Read(MB)   |   Write(MB)\n" +
+		"-------+------------+---------------+---------------+---------------+---------------\n" +
+		"   0   |          2 |       1.00000 |       0.50000 |       2.00000 |       1.00000\n" +
+		"   1   |          1 |       3.00000 |       1.00000 |       2.00000 |       3.00000\n" +
+		"-------+------------+---------------+---------------+---------------+---------------\n" +
+		" Total |          3 |       4.00000 |       1.50000 |       4.00000 |       4.00000\n"
+	c, err := parseLevelDBStats(stats)
+	require.NoError(t, err)
+	require.Equal(t, Compaction{Tables: 3, Seconds: 1.5, ReadBytes: 4 << 20, WriteBytes: 4 << 20}, c)
+
+	c, err = parseLevelDBStats("")
+	require.NoError(t, err)
+	require.Zero(t, c)
+
+	_, err = parseLevelDBStats("Compactions\n")
+	require.Error(t, err)
+}
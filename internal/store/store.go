@@ -34,7 +34,8 @@ Currently the precommit signatures are duplicated in the Block parts as
 well as the Commit.  In the future this may change, perhaps by moving
 the Commit data outside the Block. (TODO)
 
-The store can be assumed to contain all contiguous blocks between base and height (inclusive).
+The store can be assumed to contain all contiguous blocks between base and height (inclusive),
+and, with the sparse pruning policy, the blocks below base kept by it.
 
 // NOTE: BlockStore methods will panic if they encounter errors
 // deserializing loaded data, indicating probable corruption on disk.
@@ -54,17 +55,33 @@ type BlockStore struct {
 	mtx    cmtsync.RWMutex
 	base   int64
 	height int64
+
+	// interval between two blocks kept by PruneBlocks, 0 if none
+	sparseInterval int64
+}
+
+// BlockStoreOption sets an optional parameter on the BlockStore.
+type BlockStoreOption func(*BlockStore)
+
+// WithSparseRetention makes PruneBlocks keep every interval-th block below the
+// retain height.
+func WithSparseRetention(interval int64) BlockStoreOption {
+	return func(bs *BlockStore) { bs.sparseInterval = interval }
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
 // initialized to the last height that was committed to the DB.
-func NewBlockStore(db dbm.DB) *BlockStore {
-	bs := LoadBlockStoreState(db)
-	return &BlockStore{
-		base:   bs.Base,
-		height: bs.Height,
+func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
+	bss := LoadBlockStoreState(db)
+	bs := &BlockStore{
+		base:   bss.Base,
+		height: bss.Height,
 		db:     db,
 	}
+	for _, option := range options {
+		option(bs)
+	}
+	return bs
 }
 
 func (bs *BlockStore) IsEmpty() bool {
@@ -303,9 +320,10 @@ func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
 	return commit
 }
 
-// PruneBlocks removes block up to (but not including) a height. It returns the
-// number of blocks pruned and the evidence retain height - the height at which
-// data needed to prove evidence must not be removed.
+// PruneBlocks removes block up to (but not including) a height, except those
+// kept by the sparse pruning policy. It returns the number of blocks pruned
+// and the evidence retain height - the height at which data needed to prove
+// evidence must not be removed.
 func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64, error) {
 	if height <= 0 {
 		return 0, -1, fmt.Errorf("height must be greater than 0")
@@ -349,6 +367,11 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 			evidencePoint = h
 		}
 
+		// The blocks kept by the sparse pruning policy remain below the base.
+		if sm.SparseRetained(bs.sparseInterval, h) {
+			continue
+		}
+
 		// if height is beyond the evidence point we dont delete the header
 		if h < evidencePoint {
 			if err := batch.Delete(calcBlockMetaKey(h)); err != nil {
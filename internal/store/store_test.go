@@ -844,6 +844,57 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, meta)
 }
 
+func TestPruneBlocksSparse(t *testing.T) {
+	config := test.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	bs := NewBlockStore(dbm.NewMemDB(), WithSparseRetention(100))
+
+	for h := int64(1); h <= 500; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		seenCommit := makeTestExtCommit(h, cmttime.Now())
+		bs.SaveBlockWithExtendedCommit(block, partSet, seenCommit)
+	}
+	state.LastBlockTime = time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)
+	state.LastBlockHeight = 500
+	state.ConsensusParams.Evidence.MaxAgeNumBlocks = 1
+	state.ConsensusParams.Evidence.MaxAgeDuration = 1 * time.Second
+
+	// The blocks 100, 200 and 300 are kept.
+	pruned, _, err := bs.PruneBlocks(350, state)
+	require.NoError(t, err)
+	assert.EqualValues(t, 346, pruned)
+	assert.EqualValues(t, 350, bs.Base())
+
+	for _, h := range []int64{100, 200, 300} {
+		block, meta := bs.LoadBlock(h)
+		require.NotNil(t, block, h)
+		require.NotNil(t, meta, h)
+		require.NotNil(t, bs.LoadBlockCommit(h), h)
+		block, _ = bs.LoadBlockByHash(block.Hash())
+		require.NotNil(t, block, h)
+	}
+	for _, h := range []int64{1, 99, 101, 349} {
+		block, meta := bs.LoadBlock(h)
+		require.Nil(t, block, h)
+		require.Nil(t, meta, h)
+	}
+
+	// Pruning further keeps them.
+	pruned, _, err = bs.PruneBlocks(450, state)
+	require.NoError(t, err)
+	assert.EqualValues(t, 99, pruned)
+	for _, h := range []int64{100, 200, 300, 400} {
+		require.NotNil(t, bs.LoadBlockMeta(h), h)
+	}
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := newInMemoryBlockStore()
 	height := int64(10)
@@ -0,0 +1,166 @@
+// Package upgrade coordinates the upgrades of the node: it halts the chain at
+// a scheduled height, once the block at this height is committed and before
+// the next one is proposed, so that all the nodes stop at the same state.
+package upgrade
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/internal/tempfile"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// Info is written to the marker file when the node halts for an upgrade.
+type Info struct {
+	// Name of the upgrade, if the marker file was written by the application.
+	Name string `json:"name,omitempty"`
+	// Height of the last block committed before halting.
+	Height int64     `json:"height"`
+	Time   time.Time `json:"time"`
+	// Binary the node executes after halting, if any.
+	Binary string `json:"binary,omitempty"`
+}
+
+// ReadInfo reads the marker file at path, written either by the Manager or by
+// the application, in the cosmovisor format: {"name": ..., "height": ...}.
+func ReadInfo(path string) (*Info, error) {
+	jsonBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// The height is a string when written by the Manager, and a number when
+	// written by the application.
+	var raw struct {
+		Name   string      `json:"name"`
+		Height json.Number `json:"height"`
+		Time   time.Time   `json:"time"`
+		Binary string      `json:"binary"`
+	}
+	if err := json.Unmarshal(jsonBytes, &raw); err != nil {
+		return nil, fmt.Errorf("error reading upgrade info from %v: %w", path, err)
+	}
+	height, err := raw.Height.Int64()
+	if err != nil || height <= 0 {
+		return nil, fmt.Errorf("error reading upgrade info from %v: invalid height %q", path, raw.Height)
+	}
+	return &Info{Name: raw.Name, Height: height, Time: raw.Time, Binary: raw.Binary}, nil
+}
+
+// Manager halts the node at the height it is armed with, from the
+// configuration or the RPC. A nil Manager never halts.
+type Manager struct {
+	markerFile string
+
+	mtx        cmtsync.Mutex
+	haltHeight int64
+	binary     string
+	halted     chan struct{}
+	info       *Info
+}
+
+// NewManager returns a Manager halting after the block at haltHeight, if not
+// 0, and writing the marker file at markerFile when it halts. After halting,
+// the node executes binary, if not empty (see Exec).
+func NewManager(markerFile string, haltHeight int64, binary string) *Manager {
+	return &Manager{
+		markerFile: markerFile,
+		haltHeight: haltHeight,
+		binary:     binary,
+		halted:     make(chan struct{}),
+	}
+}
+
+// SetHaltHeight arms the Manager to halt after the block at height, which must
+// be above lastHeight, the height of the last committed block. 0 disarms it.
+func (m *Manager) SetHaltHeight(height, lastHeight int64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.info != nil {
+		return errors.New("the node halted already")
+	}
+	if height < 0 || (height > 0 && height <= lastHeight) {
+		return fmt.Errorf("halt height %d must be above the last block height %d", height, lastHeight)
+	}
+	m.haltHeight = height
+	return nil
+}
+
+// HaltHeight returns the height the Manager halts after, or 0 if it is not
+// armed.
+func (m *Manager) HaltHeight() int64 {
+	if m == nil {
+		return 0
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.haltHeight
+}
+
+// ShouldHalt returns true if the node must halt once the block at height is
+// committed. Only the halt height itself matches, so that a node restarted
+// after halting, e.g. with the upgraded binary, carries on.
+func (m *Manager) ShouldHalt(height int64) bool {
+	haltHeight := m.HaltHeight()
+	return haltHeight > 0 && height == haltHeight
+}
+
+// Halt records that the node halted after the block at height, writing the
+// marker file. It is idempotent.
+func (m *Manager) Halt(height int64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.info != nil {
+		return nil
+	}
+	m.info = &Info{Height: height, Time: cmttime.Now(), Binary: m.binary}
+	close(m.halted)
+
+	jsonBytes, err := cmtjson.MarshalIndent(m.info, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(m.markerFile, jsonBytes, 0o644)
+}
+
+// Halted returns a channel closed when the node halts.
+func (m *Manager) Halted() <-chan struct{} {
+	return m.halted
+}
+
+// IsHalted returns true if the node halted.
+func (m *Manager) IsHalted() bool {
+	if m == nil {
+		return false
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.info != nil
+}
+
+// Binary returns the binary the node executes after halting, if any.
+func (m *Manager) Binary() string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.binary
+}
+
+// Exec replaces the process with the binary, passing it the arguments and the
+// environment of the current process. It only returns on error, e.g. on
+// Windows, where it is not supported.
+func (m *Manager) Exec() error {
+	binary := m.Binary()
+	if binary == "" {
+		return errors.New("no upgrade binary")
+	}
+	args := append([]string{binary}, os.Args[1:]...)
+	return syscall.Exec(binary, args, os.Environ()) //nolint: gosec // the binary is set by the operator
+}
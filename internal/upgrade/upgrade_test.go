@@ -0,0 +1,61 @@
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager(t *testing.T) {
+	var nilManager *Manager
+	assert.False(t, nilManager.ShouldHalt(10))
+	assert.False(t, nilManager.IsHalted())
+
+	markerFile := filepath.Join(t.TempDir(), "upgrade-info.json")
+	m := NewManager(markerFile, 0, "/usr/local/bin/cometbft-v2")
+	assert.False(t, m.ShouldHalt(10))
+
+	require.Error(t, m.SetHaltHeight(10, 10))
+	require.Error(t, m.SetHaltHeight(-1, 5))
+	require.NoError(t, m.SetHaltHeight(10, 5))
+	assert.EqualValues(t, 10, m.HaltHeight())
+	assert.False(t, m.ShouldHalt(9))
+	assert.True(t, m.ShouldHalt(10))
+	assert.False(t, m.ShouldHalt(11))
+
+	require.NoError(t, m.Halt(10))
+	assert.True(t, m.IsHalted())
+	select {
+	case <-m.Halted():
+	default:
+		t.Fatal("expected the halted channel to be closed")
+	}
+	// Halting again is a no-op.
+	require.NoError(t, m.Halt(11))
+
+	info, err := ReadInfo(markerFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, info.Height)
+	assert.Equal(t, "/usr/local/bin/cometbft-v2", info.Binary)
+
+	// The halt height cannot change once halted.
+	require.Error(t, m.SetHaltHeight(20, 10))
+}
+
+func TestReadInfo(t *testing.T) {
+	markerFile := filepath.Join(t.TempDir(), "upgrade-info.json")
+
+	// Written by the application.
+	require.NoError(t, os.WriteFile(markerFile, []byte(`{"name":"v2","height":1000,"info":""}`), 0o644))
+	info, err := ReadInfo(markerFile)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", info.Name)
+	assert.EqualValues(t, 1000, info.Height)
+
+	require.NoError(t, os.WriteFile(markerFile, []byte(`{"name":"v2"}`), 0o644))
+	_, err = ReadInfo(markerFile)
+	require.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package light
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/types"
+)
+
+// AttackReportDir option can be used to save the report of the attacks the
+// light client detects to the given directory. See SaveAttackReport.
+func AttackReportDir(dir string) Option {
+	return func(c *Client) {
+		c.attackReportDir = dir
+	}
+}
+
+// LastAttackReport returns the report of the last attack the light client
+// detected, or nil.
+func (c *Client) LastAttackReport() *types.LightClientAttackReport {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	return c.lastAttackReport
+}
+
+// reportAttack keeps the report of an attack, and saves it to the attack
+// report directory if set.
+//
+// NOTE: requires a providerMutex lock.
+func (c *Client) reportAttack(report *types.LightClientAttackReport) {
+	c.lastAttackReport = report
+	if c.attackReportDir == "" {
+		return
+	}
+	path, err := SaveAttackReport(c.attackReportDir, report)
+	if err != nil {
+		c.logger.Error("Failed to save the attack report", "dir", c.attackReportDir, "err", err)
+		return
+	}
+	c.logger.Error("Saved the attack report, submit it to a full node with /broadcast_attack_report",
+		"path", path, "type", report.AttackType, "misbehaving_validators", len(report.MisbehavingValidators))
+}
+
+// SaveAttackReport saves the report as JSON to a new file of dir, and returns
+// its path.
+func SaveAttackReport(dir string, report *types.LightClientAttackReport) (string, error) {
+	bz, err := cmtjson.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling the attack report: %w", err)
+	}
+	if err := cmtos.EnsureDir(dir, 0o700); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("attack-report-%d-%d.json",
+		report.EvidenceAgainstPrimary.Height(), report.DetectedAt.UnixNano())
+	path := filepath.Join(dir, name)
+	if err := cmtos.WriteFile(path, bz, 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// LoadAttackReport loads a report saved with SaveAttackReport.
+func LoadAttackReport(path string) (*types.LightClientAttackReport, error) {
+	bz, err := cmtos.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report := new(types.LightClientAttackReport)
+	if err := cmtjson.Unmarshal(bz, report); err != nil {
+		return nil, fmt.Errorf("unmarshaling the attack report: %w", err)
+	}
+	return report, nil
+}
+
+// mergeValidators returns the validators of a and b, without duplicates.
+func mergeValidators(a, b []*types.Validator) []*types.Validator {
+	merged := append([]*types.Validator{}, a...)
+	for _, val := range b {
+		found := false
+		for _, v := range a {
+			if bytes.Equal(v.Address, val.Address) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, val)
+		}
+	}
+	return merged
+}
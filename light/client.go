@@ -56,7 +56,8 @@ func SequentialVerification() Option {
 // SkippingVerification option configures the light client to skip blocks as
 // long as {trustLevel} of the old validator set signed the new header. The
 // verifySkipping algorithm from the specification is used for finding the minimal
-// "trust path".
+// "trust path", through the light blocks chosen by the skipping strategy (see
+// WithSkippingStrategy).
 //
 // trustLevel - fraction of the old validator set (in terms of voting power),
 // which must sign the new header in order for us to trust it. NOTE this only
@@ -134,6 +135,7 @@ type Client struct {
 	chainID          string
 	trustingPeriod   time.Duration // see TrustOptions.Period
 	verificationMode mode
+	skippingStrategy SkippingStrategy
 	trustLevel       cmtmath.Fraction
 	maxRetryAttempts uint16 // see MaxRetryAttempts option
 	maxClockDrift    time.Duration
@@ -155,6 +157,10 @@ type Client struct {
 	pruningSize uint16
 	// See ConfirmationFunction option
 	confirmationFn func(action string) bool
+	// See AttackReportDir option
+	attackReportDir string
+	// Report of the last attack detected
+	lastAttackReport *types.LightClientAttackReport
 
 	quit chan struct{}
 
@@ -221,6 +227,7 @@ func NewClientFromTrustedStore(
 		chainID:          chainID,
 		trustingPeriod:   trustingPeriod,
 		verificationMode: skipping,
+		skippingStrategy: AdaptiveSkipping(),
 		trustLevel:       DefaultTrustLevel,
 		maxRetryAttempts: defaultMaxRetryAttempts,
 		maxClockDrift:    defaultMaxClockDrift,
@@ -702,10 +709,12 @@ func (c *Client) verifySequential(
 // reiterating the action until it verifies a light block. A cache of light blocks
 // requested from source is kept such that when a verification is made, and the
 // light client tries again to verify the new light block in the middle, the light
-// client does not need to ask for all the same light blocks again.
+// client does not need to ask for all the same light blocks again. The
+// middle light blocks are chosen by strategy.
 func (c *Client) verifySkipping(
 	ctx context.Context,
 	source provider.Provider,
+	strategy SkippingStrategy,
 	trustedBlock *types.LightBlock,
 	newLightBlock *types.LightBlock,
 	now time.Time,
@@ -729,6 +738,7 @@ func (c *Client) verifySkipping(
 			blockCache[depth].ValidatorSet, c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
 		switch err.(type) {
 		case nil:
+			strategy.Verified(verifiedBlock, blockCache[depth])
 			// Have we verified the last header
 			if depth == 0 {
 				trace = append(trace, newLightBlock)
@@ -746,8 +756,7 @@ func (c *Client) verifySkipping(
 		case ErrNewValSetCantBeTrusted:
 			// do add another header to the end of the cache
 			if depth == len(blockCache)-1 {
-				pivotHeight := verifiedBlock.Height + (blockCache[depth].Height-verifiedBlock.
-					Height)*verifySkippingNumerator/verifySkippingDenominator
+				pivotHeight := c.pivotHeight(strategy, verifiedBlock, blockCache[depth])
 				interimBlock, providerErr := source.LightBlock(ctx, pivotHeight)
 				switch providerErr {
 				case nil:
@@ -762,7 +771,6 @@ func (c *Client) verifySkipping(
 				default:
 					return nil, ErrVerificationFailed{From: verifiedBlock.Height, To: pivotHeight, Reason: providerErr}
 				}
-				blockCache = append(blockCache, interimBlock)
 			}
 			depth++
 
@@ -780,7 +788,7 @@ func (c *Client) verifySkippingAgainstPrimary(
 	newLightBlock *types.LightBlock,
 	now time.Time,
 ) error {
-	trace, err := c.verifySkipping(ctx, c.primary, trustedBlock, newLightBlock, now)
+	trace, err := c.verifySkipping(ctx, c.primary, c.skippingStrategy, trustedBlock, newLightBlock, now)
 
 	switch errors.Unwrap(err).(type) {
 	case ErrInvalidHeader:
@@ -242,6 +242,19 @@ func (c *Client) handleConflictingHeaders(
 		"primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstPrimary, supportingWitness)
 
+	report := &types.LightClientAttackReport{
+		ChainID:                c.chainID,
+		DetectedAt:             now,
+		AttackType:             types.LightClientAttackType(evidenceAgainstPrimary, trustedBlock),
+		Primary:                fmt.Sprint(c.primary),
+		Witness:                fmt.Sprint(supportingWitness),
+		PrimaryTrace:           primaryTrace,
+		WitnessTrace:           witnessTrace,
+		EvidenceAgainstPrimary: evidenceAgainstPrimary,
+		MisbehavingValidators:  evidenceAgainstPrimary.ByzantineValidators,
+	}
+	defer c.reportAttack(report)
+
 	if primaryBlock.Commit.Round != witnessTrace[len(witnessTrace)-1].Commit.Round {
 		c.logger.Info("The light client has detected, and prevented, an attempted amnesia attack." +
 			" We think this attack is pretty unlikely, so if you see it, that's interesting to us." +
@@ -269,13 +282,19 @@ func (c *Client) handleConflictingHeaders(
 	c.logger.Error("Sending evidence against witness by primary", "ev", evidenceAgainstWitness,
 		"primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstWitness, c.primary)
+
+	report.PrimaryTrace = primaryTrace
+	report.EvidenceAgainstWitness = evidenceAgainstWitness
+	report.MisbehavingValidators = mergeValidators(report.MisbehavingValidators, evidenceAgainstWitness.ByzantineValidators)
 	// We return the error and don't process anymore witnesses
 	return ErrLightClientAttack
 }
 
 // examineConflictingHeaderAgainstTrace takes a trace from one provider and a divergent header that
 // it has received from another and performs verifySkipping at the heights of each of the intermediate
-// headers in the trace until it reaches the divergentHeader. 1 of 2 things can happen.
+// headers in the trace until it reaches the divergentHeader. It bisects as the specification does,
+// rather than following the skipping strategy, which must not learn from the conflicting light
+// blocks. 1 of 2 things can happen.
 //
 //  1. The light client verifies a header that is different to the intermediate header in the trace. This
 //     is the bifurcation point and the light client can create evidence from it
@@ -320,7 +339,7 @@ func (c *Client) examineConflictingHeaderAgainstTrace(
 			// before sending back the divergent block and trace we need to ensure we have verified
 			// the final gap between the previouslyVerifiedBlock and the targetBlock
 			if previouslyVerifiedBlock.Height != targetBlock.Height {
-				sourceTrace, err = c.verifySkipping(ctx, source, previouslyVerifiedBlock, targetBlock, now)
+				sourceTrace, err = c.verifySkipping(ctx, source, BisectionSkipping(), previouslyVerifiedBlock, targetBlock, now)
 				if err != nil {
 					return nil, nil, fmt.Errorf("verifySkipping of conflicting header failed: %w", err)
 				}
@@ -351,7 +370,7 @@ func (c *Client) examineConflictingHeaderAgainstTrace(
 
 		// we check that the source provider can verify a block at the same height of the
 		// intermediate height
-		sourceTrace, err = c.verifySkipping(ctx, source, previouslyVerifiedBlock, sourceBlock, now)
+		sourceTrace, err = c.verifySkipping(ctx, source, BisectionSkipping(), previouslyVerifiedBlock, sourceBlock, now)
 		if err != nil {
 			return nil, nil, fmt.Errorf("verifySkipping of conflicting header failed: %w", err)
 		}
@@ -1,6 +1,7 @@
 package light_test
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 
 	witnessHeaders, witnessValidators, chainKeys := genMockNodeWithKeys(chainID, latestHeight, valSize, 2, bTime)
 	witness := mockp.New(chainID, witnessHeaders, witnessValidators)
+	reportDir := t.TempDir()
 	forgedKeys := chainKeys[divergenceHeight-1].ChangeKeys(3) // we change 3 out of the 5 validators (still 2/5 remain)
 	forgedVals := forgedKeys.ToValidators(2, 0)
 
@@ -57,6 +59,9 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		dbs.New(dbm.NewMemDB(), chainID),
 		light.Logger(log.TestingLogger()),
 		light.MaxRetryAttempts(1),
+		light.AttackReportDir(reportDir),
+		// the heights of the evidence below follow from bisecting
+		light.WithSkippingStrategy(light.BisectionSkipping()),
 	)
 	require.NoError(t, err)
 
@@ -87,6 +92,22 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		CommonHeight: 4,
 	}
 	assert.True(t, primary.HasEvidence(evAgainstWitness))
+
+	// Check the attack report was saved.
+	report := c.LastAttackReport()
+	require.NotNil(t, report)
+	require.NoError(t, report.ValidateBasic())
+	assert.Equal(t, types.LightClientAttackLunatic, report.AttackType)
+	assert.Equal(t, evAgainstPrimary.ConflictingBlock.Hash(), report.EvidenceAgainstPrimary.ConflictingBlock.Hash())
+	assert.Equal(t, evAgainstWitness.ConflictingBlock.Hash(), report.EvidenceAgainstWitness.ConflictingBlock.Hash())
+	assert.NotEmpty(t, report.MisbehavingValidators)
+
+	paths, err := filepath.Glob(filepath.Join(reportDir, "attack-report-*.json"))
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	saved, err := light.LoadAttackReport(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, report.EvidenceAgainstPrimary.Hash(), saved.EvidenceAgainstPrimary.Hash())
 }
 
 func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
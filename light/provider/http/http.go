@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	gohttp "net/http"
 	"regexp"
 	"strings"
 	"time"
@@ -11,6 +12,8 @@ import (
 	"github.com/cometbft/cometbft/light/provider"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -22,8 +25,26 @@ var (
 
 	maxRetryAttempts      = 5
 	timeout          uint = 5 // sec.
+
+	// Connections kept alive to the remote, to be reused by the next requests.
+	maxIdleConns    = 10
+	idleConnTimeout = 90 * time.Second
+)
+
+const (
+	// Since the malicious node could report a massive number of pages, making us
+	// spend a considerable time iterating, we restrict the number of pages here.
+	// => 10000 validators max
+	maxPages          = 100
+	validatorsPerPage = 100
 )
 
+// batchClient is implemented by the clients able to send JSON-RPC batch
+// requests, such as rpchttp.HTTP.
+type batchClient interface {
+	NewBatch() *rpchttp.BatchHTTP
+}
+
 // http provider uses an RPC client to obtain the necessary information.
 type http struct {
 	chainID string
@@ -32,14 +53,26 @@ type http struct {
 
 // New creates a HTTP provider, which is using the rpchttp.HTTP client under
 // the hood. If no scheme is provided in the remote URL, http will be used by
-// default. The 5s timeout is used for all requests.
+// default. The 5s timeout is used for all requests, and the connections are
+// kept alive to be reused.
 func New(chainID, remote string) (provider.Provider, error) {
 	// Ensure URL scheme is set (default HTTP) when not provided.
 	if !strings.Contains(remote, "://") {
 		remote = "http://" + remote
 	}
 
-	httpClient, err := rpchttp.NewWithTimeout(remote, timeout)
+	client, err := jsonrpcclient.DefaultHTTPClient(remote)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(timeout) * time.Second
+	if transport, ok := client.Transport.(*gohttp.Transport); ok {
+		transport.MaxIdleConns = maxIdleConns
+		transport.MaxIdleConnsPerHost = maxIdleConns
+		transport.IdleConnTimeout = idleConnTimeout
+	}
+
+	httpClient, err := rpchttp.NewWithClient(remote, client)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +80,9 @@ func New(chainID, remote string) (provider.Provider, error) {
 	return NewWithClient(chainID, httpClient), nil
 }
 
-// NewWithClient allows you to provide a custom client.
+// NewWithClient allows you to provide a custom client. If the client supports
+// batch requests, as rpchttp.HTTP does, the signed header and the validator set
+// of a light block are fetched in one round trip.
 func NewWithClient(chainID string, client rpcclient.RemoteClient) provider.Provider {
 	return &http{
 		client:  client,
@@ -72,9 +107,20 @@ func (p *http) LightBlock(ctx context.Context, height int64) (*types.LightBlock,
 		return nil, provider.ErrBadLightBlock{Reason: err}
 	}
 
-	sh, err := p.signedHeader(ctx, h)
-	if err != nil {
-		return nil, err
+	var (
+		sh *types.SignedHeader
+		vs *types.ValidatorSet
+	)
+	if client, ok := p.client.(batchClient); ok {
+		sh, vs, err = p.batchedLightBlock(ctx, client, h)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		sh, err = p.signedHeader(ctx, h)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if height != 0 && sh.Height != height {
@@ -83,9 +129,11 @@ func (p *http) LightBlock(ctx context.Context, height int64) (*types.LightBlock,
 		}
 	}
 
-	vs, err := p.validatorSet(ctx, &sh.Height)
-	if err != nil {
-		return nil, err
+	if vs == nil {
+		vs, err = p.validatorSet(ctx, &sh.Height)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	lb := &types.LightBlock{
@@ -108,13 +156,8 @@ func (p *http) ReportEvidence(ctx context.Context, ev types.Evidence) error {
 }
 
 func (p *http) validatorSet(ctx context.Context, height *int64) (*types.ValidatorSet, error) {
-	// Since the malicious node could report a massive number of pages, making us
-	// spend a considerable time iterating, we restrict the number of pages here.
-	// => 10000 validators max
-	const maxPages = 100
-
 	var (
-		perPage = 100
+		perPage = validatorsPerPage
 		vals    = []*types.Validator{}
 		page    = 1
 		total   = -1
@@ -126,18 +169,8 @@ OUTER_LOOP:
 			res, err := p.client.Validators(ctx, height, &page, &perPage)
 			switch {
 			case err == nil:
-				// Validate response.
-				if len(res.Validators) == 0 {
-					return nil, provider.ErrBadLightBlock{
-						Reason: fmt.Errorf("validator set is empty (height: %d, page: %d, per_page: %d)",
-							height, page, perPage),
-					}
-				}
-				if res.Total <= 0 {
-					return nil, provider.ErrBadLightBlock{
-						Reason: fmt.Errorf("total number of vals is <= 0: %d (height: %d, page: %d, per_page: %d)",
-							res.Total, height, page, perPage),
-					}
+				if err := validateValidatorsPage(res, height, page, perPage); err != nil {
+					return nil, err
 				}
 
 				total = res.Total
@@ -174,6 +207,125 @@ OUTER_LOOP:
 	return valSet, nil
 }
 
+// batchedLightBlock fetches the signed header and the first page of the
+// validator set at height in one batch request, and the other pages of the
+// validator set, if any, in another one.
+func (p *http) batchedLightBlock(
+	ctx context.Context,
+	client batchClient,
+	height *int64,
+) (*types.SignedHeader, *types.ValidatorSet, error) {
+	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
+		batch := client.NewBatch()
+		commit, err := batch.Commit(ctx, height)
+		if err != nil {
+			return nil, nil, err
+		}
+		page, perPage := 1, validatorsPerPage
+		res, err := batch.Validators(ctx, height, &page, &perPage)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		_, err = batch.Send(ctx)
+		switch {
+		case err == nil:
+			// See signedHeader.
+			if commit.SignedHeader.IsEmpty() {
+				return nil, nil, provider.ErrHeightTooHigh
+			}
+			sh := &commit.SignedHeader
+
+			// Without a height, a block may have been committed between the
+			// two requests.
+			if res.BlockHeight != sh.Height {
+				vs, err := p.validatorSet(ctx, &sh.Height)
+				return sh, vs, err
+			}
+			if err := validateValidatorsPage(res, &sh.Height, page, perPage); err != nil {
+				return nil, nil, err
+			}
+			vs, err := p.otherValidatorPages(ctx, client, sh.Height, res)
+			return sh, vs, err
+
+		case regexpTooHigh.MatchString(err.Error()):
+			return nil, nil, provider.ErrHeightTooHigh
+
+		case regexpMissingHeight.MatchString(err.Error()):
+			return nil, nil, provider.ErrLightBlockNotFound
+
+		case regexpTimedOut.MatchString(err.Error()):
+			// we wait and try again with exponential backoff
+			time.Sleep(backoffTimeout(uint16(attempt)))
+			continue
+
+		// either context was canceled or connection refused.
+		default:
+			return nil, nil, err
+		}
+	}
+	return nil, nil, provider.ErrNoResponse
+}
+
+// otherValidatorPages fetches the pages of the validator set following the
+// first one in one batch request, and returns the validator set.
+func (p *http) otherValidatorPages(
+	ctx context.Context,
+	client batchClient,
+	height int64,
+	first *ctypes.ResultValidators,
+) (*types.ValidatorSet, error) {
+	vals := first.Validators
+	if len(vals) < first.Total {
+		pages := min((first.Total+validatorsPerPage-1)/validatorsPerPage, maxPages)
+		batch := client.NewBatch()
+		results := make([]*ctypes.ResultValidators, 0, pages-1)
+		for page := 2; page <= pages; page++ {
+			page, perPage := page, validatorsPerPage
+			res, err := batch.Validators(ctx, &height, &page, &perPage)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, res)
+		}
+		if _, err := batch.Send(ctx); err != nil {
+			if regexpTimedOut.MatchString(err.Error()) {
+				return nil, provider.ErrNoResponse
+			}
+			return nil, err
+		}
+		for i, res := range results {
+			if err := validateValidatorsPage(res, &height, i+2, validatorsPerPage); err != nil {
+				return nil, err
+			}
+			vals = append(vals, res.Validators...)
+		}
+	}
+
+	valSet, err := types.ValidatorSetFromExistingValidators(vals)
+	if err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return valSet, nil
+}
+
+// validateValidatorsPage checks a page of a validator set.
+func validateValidatorsPage(res *ctypes.ResultValidators, height *int64, page, perPage int) error {
+	if len(res.Validators) == 0 {
+		return provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("validator set is empty (height: %d, page: %d, per_page: %d)",
+				height, page, perPage),
+		}
+	}
+	if res.Total <= 0 {
+		return provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("total number of vals is <= 0: %d (height: %d, page: %d, per_page: %d)",
+				res.Total, height, page, perPage),
+		}
+	}
+	return nil
+}
+
 func (p *http) signedHeader(ctx context.Context, height *int64) (*types.SignedHeader, error) {
 	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
 		commit, err := p.client.Commit(ctx, height)
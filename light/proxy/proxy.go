@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/light"
 	lrpc "github.com/cometbft/cometbft/light/rpc"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
 )
@@ -32,15 +34,43 @@ func NewProxy(
 	logger log.Logger,
 	opts ...lrpc.Option,
 ) (*Proxy, error) {
-	rpcClient, err := rpchttp.NewWithTimeout(providerAddr, uint(config.WriteTimeout.Seconds()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create http client for %s: %w", providerAddr, err)
+	return NewProxyWithBackends(lightClient, listenAddr, []string{providerAddr}, config, logger, opts...)
+}
+
+// NewProxyWithBackends creates the struct used to run an HTTP server for
+// serving light client rpc requests, forwarding them to the first available
+// of the given backends. See lrpc.MultiClient.
+func NewProxyWithBackends(
+	lightClient *light.Client,
+	listenAddr string,
+	backendAddrs []string,
+	config *rpcserver.Config,
+	logger log.Logger,
+	opts ...lrpc.Option,
+) (*Proxy, error) {
+	if len(backendAddrs) == 0 {
+		return nil, errors.New("no backend address given")
+	}
+	backends := make([]rpcclient.Client, 0, len(backendAddrs))
+	for _, addr := range backendAddrs {
+		rpcClient, err := rpchttp.NewWithTimeout(addr, uint(config.WriteTimeout.Seconds()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create http client for %s: %w", addr, err)
+		}
+		backends = append(backends, rpcClient)
+	}
+
+	var next rpcclient.Client = backends[0]
+	if len(backends) > 1 {
+		multiClient := lrpc.NewMultiClient(backends...)
+		multiClient.SetLogger(logger.With("module", "backends"))
+		next = multiClient
 	}
 
 	return &Proxy{
 		Addr:   listenAddr,
 		Config: config,
-		Client: lrpc.NewClient(rpcClient, lightClient, opts...),
+		Client: lrpc.NewClient(next, lightClient, opts...),
 		Logger: logger,
 	}, nil
 }
@@ -18,32 +18,42 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"unsubscribe_all": rpcserver.NewWSRPCFunc(c.UnsubscribeAllWS, ""),
 
 		// info API
-		"health":               rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
-		"status":               rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
-		"net_info":             rpcserver.NewRPCFunc(makeNetInfoFunc(c), ""),
-		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", rpcserver.Cacheable()),
-		"genesis":              rpcserver.NewRPCFunc(makeGenesisFunc(c), "", rpcserver.Cacheable()),
-		"genesis_chunked":      rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", rpcserver.Cacheable()),
-		"block":                rpcserver.NewRPCFunc(makeBlockFunc(c), "height", rpcserver.Cacheable("height")),
-		"header":               rpcserver.NewRPCFunc(makeHeaderFunc(c), "height", rpcserver.Cacheable("height")),
-		"header_by_hash":       rpcserver.NewRPCFunc(makeHeaderByHashFunc(c), "hash", rpcserver.Cacheable()),
-		"block_by_hash":        rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", rpcserver.Cacheable()),
-		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", rpcserver.Cacheable("height")),
-		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height", rpcserver.Cacheable("height")),
-		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", rpcserver.Cacheable()),
-		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
-		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by"),
-		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", rpcserver.Cacheable("height")),
-		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
-		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
-		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", rpcserver.Cacheable("height")),
-		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
-		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
+		"health":                 rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
+		"status":                 rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
+		"net_info":               rpcserver.NewRPCFunc(makeNetInfoFunc(c), ""),
+		"blockchain":             rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", rpcserver.Cacheable()),
+		"light_blocks":           rpcserver.NewRPCFunc(makeLightBlocksFunc(c), "from,to"),
+		"genesis":                rpcserver.NewRPCFunc(makeGenesisFunc(c), "", rpcserver.Cacheable()),
+		"genesis_chunked":        rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", rpcserver.Cacheable()),
+		"snapshots":              rpcserver.NewRPCFunc(makeSnapshotsFunc(c), ""),
+		"snapshot_chunk":         rpcserver.NewRPCFunc(makeSnapshotChunkFunc(c), "height,format,index,offset", rpcserver.Cacheable()),
+		"block":                  rpcserver.NewRPCFunc(makeBlockFunc(c), "height", rpcserver.Cacheable("height")),
+		"header":                 rpcserver.NewRPCFunc(makeHeaderFunc(c), "height", rpcserver.Cacheable("height")),
+		"header_by_hash":         rpcserver.NewRPCFunc(makeHeaderByHashFunc(c), "hash", rpcserver.Cacheable()),
+		"header_chain_proof":     rpcserver.NewRPCFunc(makeHeaderChainProofFunc(c), "height,trusted_height", rpcserver.Cacheable("trusted_height")),
+		"block_by_hash":          rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", rpcserver.Cacheable()),
+		"block_results":          rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", rpcserver.Cacheable("height")),
+		"commit":                 rpcserver.NewRPCFunc(makeCommitFunc(c), "height", rpcserver.Cacheable("height")),
+		"attestation":            rpcserver.NewRPCFunc(makeAttestationFunc(c), "height", rpcserver.Cacheable("height")),
+		"tx":                     rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", rpcserver.Cacheable()),
+		"tx_result_proof":        rpcserver.NewRPCFunc(makeTxResultProofFunc(c), "hash,height", rpcserver.Cacheable("height")),
+		"tx_search":              rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
+		"block_search":           rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by"),
+		"validators":             rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", rpcserver.Cacheable("height")),
+		"validator_signing_info": rpcserver.NewRPCFunc(makeValidatorSigningInfoFunc(c), "address"),
+		"storage_info":           rpcserver.NewRPCFunc(makeStorageInfoFunc(c), ""),
+		"retain_heights":         rpcserver.NewRPCFunc(makeRetainHeightsFunc(c), ""),
+		"dump_consensus_state":   rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
+		"consensus_state":        rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
+		"consensus_params":       rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", rpcserver.Cacheable("height")),
+		"unconfirmed_txs":        rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
+		"num_unconfirmed_txs":    rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
 
 		// tx broadcast API
-		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx"),
-		"broadcast_tx_sync":   rpcserver.NewRPCFunc(makeBroadcastTxSyncFunc(c), "tx"),
-		"broadcast_tx_async":  rpcserver.NewRPCFunc(makeBroadcastTxAsyncFunc(c), "tx"),
+		"broadcast_tx_commit":       rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx"),
+		"broadcast_tx_commit_proof": rpcserver.NewRPCFunc(makeBroadcastTxCommitProofFunc(c), "tx,timeout_ms"),
+		"broadcast_tx_sync":         rpcserver.NewRPCFunc(makeBroadcastTxSyncFunc(c), "tx"),
+		"broadcast_tx_async":        rpcserver.NewRPCFunc(makeBroadcastTxAsyncFunc(c), "tx"),
 
 		// abci API
 		"abci_query": rpcserver.NewRPCFunc(makeABCIQueryFunc(c), "path,data,height,prove"),
@@ -86,6 +96,14 @@ func makeBlockchainInfoFunc(c *lrpc.Client) rpcBlockchainInfoFunc {
 	}
 }
 
+type rpcLightBlocksFunc func(ctx *rpctypes.Context, from, to int64) (*ctypes.ResultLightBlocks, error)
+
+func makeLightBlocksFunc(c *lrpc.Client) rpcLightBlocksFunc {
+	return func(ctx *rpctypes.Context, from, to int64) (*ctypes.ResultLightBlocks, error) {
+		return c.LightBlocks(ctx.Context(), from, to)
+	}
+}
+
 type rpcGenesisFunc func(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error)
 
 func makeGenesisFunc(c *lrpc.Client) rpcGenesisFunc {
@@ -102,6 +120,34 @@ func makeGenesisChunkedFunc(c *lrpc.Client) rpcGenesisChunkedFunc {
 	}
 }
 
+type rpcSnapshotsFunc func(ctx *rpctypes.Context) (*ctypes.ResultSnapshots, error)
+
+func makeSnapshotsFunc(c *lrpc.Client) rpcSnapshotsFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultSnapshots, error) {
+		return c.Snapshots(ctx.Context())
+	}
+}
+
+type rpcSnapshotChunkFunc func(
+	ctx *rpctypes.Context,
+	height uint64,
+	format,
+	index uint32,
+	offset int,
+) (*ctypes.ResultSnapshotChunk, error)
+
+func makeSnapshotChunkFunc(c *lrpc.Client) rpcSnapshotChunkFunc {
+	return func(
+		ctx *rpctypes.Context,
+		height uint64,
+		format,
+		index uint32,
+		offset int,
+	) (*ctypes.ResultSnapshotChunk, error) {
+		return c.SnapshotChunk(ctx.Context(), height, format, index, offset)
+	}
+}
+
 type rpcBlockFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlock, error)
 
 func makeBlockFunc(c *lrpc.Client) rpcBlockFunc {
@@ -142,6 +188,18 @@ func makeBlockResultsFunc(c *lrpc.Client) rpcBlockResultsFunc {
 	}
 }
 
+type rpcHeaderChainProofFunc func(
+	ctx *rpctypes.Context,
+	height int64,
+	trustedHeight *int64,
+) (*ctypes.ResultHeaderChainProof, error)
+
+func makeHeaderChainProofFunc(c *lrpc.Client) rpcHeaderChainProofFunc {
+	return func(ctx *rpctypes.Context, height int64, trustedHeight *int64) (*ctypes.ResultHeaderChainProof, error) {
+		return c.HeaderChainProof(ctx.Context(), height, trustedHeight)
+	}
+}
+
 type rpcCommitFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommit, error)
 
 func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
@@ -150,6 +208,14 @@ func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
 	}
 }
 
+type rpcAttestationFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultAttestation, error)
+
+func makeAttestationFunc(c *lrpc.Client) rpcAttestationFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultAttestation, error) {
+		return c.Attestation(ctx.Context(), height)
+	}
+}
+
 type rpcTxFunc func(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
 func makeTxFunc(c *lrpc.Client) rpcTxFunc {
@@ -158,6 +224,18 @@ func makeTxFunc(c *lrpc.Client) rpcTxFunc {
 	}
 }
 
+type rpcTxResultProofFunc func(
+	ctx *rpctypes.Context,
+	hash []byte,
+	height *int64,
+) (*ctypes.ResultTxResultProof, error)
+
+func makeTxResultProofFunc(c *lrpc.Client) rpcTxResultProofFunc {
+	return func(ctx *rpctypes.Context, hash []byte, height *int64) (*ctypes.ResultTxResultProof, error) {
+		return c.TxResultProof(ctx.Context(), hash, height)
+	}
+}
+
 type rpcTxSearchFunc func(
 	ctx *rpctypes.Context,
 	query string,
@@ -207,6 +285,30 @@ func makeValidatorsFunc(c *lrpc.Client) rpcValidatorsFunc {
 	}
 }
 
+type rpcValidatorSigningInfoFunc func(ctx *rpctypes.Context, address []byte) (*ctypes.ResultValidatorSigningInfo, error)
+
+func makeValidatorSigningInfoFunc(c *lrpc.Client) rpcValidatorSigningInfoFunc {
+	return func(ctx *rpctypes.Context, address []byte) (*ctypes.ResultValidatorSigningInfo, error) {
+		return c.ValidatorSigningInfo(ctx.Context(), address)
+	}
+}
+
+type rpcStorageInfoFunc func(ctx *rpctypes.Context) (*ctypes.ResultStorageInfo, error)
+
+func makeStorageInfoFunc(c *lrpc.Client) rpcStorageInfoFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultStorageInfo, error) {
+		return c.StorageInfo(ctx.Context())
+	}
+}
+
+type rpcRetainHeightsFunc func(ctx *rpctypes.Context) (*ctypes.ResultRetainHeights, error)
+
+func makeRetainHeightsFunc(c *lrpc.Client) rpcRetainHeightsFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultRetainHeights, error) {
+		return c.RetainHeights(ctx.Context())
+	}
+}
+
 type rpcDumpConsensusStateFunc func(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState, error)
 
 func makeDumpConsensusStateFunc(c *lrpc.Client) rpcDumpConsensusStateFunc {
@@ -255,6 +357,18 @@ func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
 	}
 }
 
+type rpcBroadcastTxCommitProofFunc func(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+	timeoutMs *int,
+) (*ctypes.ResultBroadcastTxCommitProof, error)
+
+func makeBroadcastTxCommitProofFunc(c *lrpc.Client) rpcBroadcastTxCommitProofFunc {
+	return func(ctx *rpctypes.Context, tx types.Tx, timeoutMs *int) (*ctypes.ResultBroadcastTxCommitProof, error) {
+		return c.BroadcastTxCommitProof(ctx.Context(), tx, timeoutMs)
+	}
+}
+
 type rpcBroadcastTxSyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 
 func makeBroadcastTxSyncFunc(c *lrpc.Client) rpcBroadcastTxSyncFunc {
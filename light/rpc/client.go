@@ -194,6 +194,31 @@ func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.Re
 	return c.next.BroadcastTxCommit(ctx, tx)
 }
 
+// BroadcastTxCommitProof calls rpcclient#BroadcastTxCommitProof and then
+// verifies the proof of the inclusion of the tx, if it was committed.
+func (c *Client) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeoutMs *int,
+) (*ctypes.ResultBroadcastTxCommitProof, error) {
+	res, err := c.next.BroadcastTxCommitProof(ctx, tx, timeoutMs)
+	if err != nil || res.Height == 0 {
+		return res, err
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the proof, which must be for the broadcast tx.
+	if !bytes.Equal(res.Proof.Data, tx) {
+		return nil, errors.New("proof is not for the broadcast tx")
+	}
+	return res, res.Proof.Validate(l.DataHash)
+}
+
 func (c *Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return c.next.BroadcastTxAsync(ctx, tx)
 }
@@ -214,6 +239,10 @@ func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckT
 	return c.next.CheckTx(ctx, tx)
 }
 
+func (c *Client) SimulateTx(ctx context.Context, tx types.Tx) (*ctypes.ResultSimulateTx, error) {
+	return c.next.SimulateTx(ctx, tx)
+}
+
 func (c *Client) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	return c.next.NetInfo(ctx)
 }
@@ -259,6 +288,18 @@ func (c *Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	return c.next.Health(ctx)
 }
 
+func (c *Client) ValidatorSigningInfo(ctx context.Context, address []byte) (*ctypes.ResultValidatorSigningInfo, error) {
+	return c.next.ValidatorSigningInfo(ctx, address)
+}
+
+func (c *Client) StorageInfo(ctx context.Context) (*ctypes.ResultStorageInfo, error) {
+	return c.next.StorageInfo(ctx)
+}
+
+func (c *Client) RetainHeights(ctx context.Context) (*ctypes.ResultRetainHeights, error) {
+	return c.next.RetainHeights(ctx)
+}
+
 // BlockchainInfo calls rpcclient#BlockchainInfo and then verifies every header
 // returned.
 func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
@@ -300,6 +341,51 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	return res, nil
 }
 
+// LightBlocks calls rpcclient#LightBlocks and then verifies every light block
+// against the light client's trusted light block at the same height.
+func (c *Client) LightBlocks(ctx context.Context, from, to int64) (*ctypes.ResultLightBlocks, error) {
+	res, err := c.next.LightBlocks(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	for i, lb := range res.LightBlocks {
+		if lb == nil {
+			return nil, fmt.Errorf("nil light block %d", i)
+		}
+		if err := lb.ValidateBasic(c.lc.ChainID()); err != nil {
+			return nil, fmt.Errorf("invalid light block %d: %w", i, err)
+		}
+		if i > 0 && lb.Height != res.LightBlocks[i-1].Height+1 {
+			return nil, fmt.Errorf("light block %d has height %d, expected %d",
+				i, lb.Height, res.LightBlocks[i-1].Height+1)
+		}
+	}
+
+	// Update the light client if we're behind.
+	if len(res.LightBlocks) > 0 {
+		lastHeight := res.LightBlocks[len(res.LightBlocks)-1].Height
+		if _, err := c.updateLightClientIfNeededTo(ctx, &lastHeight); err != nil {
+			return nil, err
+		}
+	}
+
+	// Verify each of the light blocks.
+	for _, lb := range res.LightBlocks {
+		h, err := c.lc.TrustedLightBlock(lb.Height)
+		if err != nil {
+			return nil, fmt.Errorf("trusted header %d: %w", lb.Height, err)
+		}
+		if lbH, tH := lb.Hash(), h.Hash(); !bytes.Equal(lbH, tH) {
+			return nil, fmt.Errorf("light block header %X does not match with trusted header %X",
+				lbH, tH)
+		}
+	}
+
+	return res, nil
+}
+
 func (c *Client) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	return c.next.Genesis(ctx)
 }
@@ -308,6 +394,24 @@ func (c *Client) GenesisChunked(ctx context.Context, id uint) (*ctypes.ResultGen
 	return c.next.GenesisChunked(ctx, id)
 }
 
+// Snapshots calls rpcclient#Snapshots. Snapshots can't be verified until they
+// are restored, so the result is returned as is.
+func (c *Client) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	return c.next.Snapshots(ctx)
+}
+
+// SnapshotChunk calls rpcclient#SnapshotChunk. Like snapshots, chunks are
+// returned as is.
+func (c *Client) SnapshotChunk(
+	ctx context.Context,
+	height uint64,
+	format,
+	index uint32,
+	offset int,
+) (*ctypes.ResultSnapshotChunk, error) {
+	return c.next.SnapshotChunk(ctx, height, format, index, offset)
+}
+
 // Block calls rpcclient#Block and then verifies the result.
 func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
 	res, err := c.next.Block(ctx, height)
@@ -422,6 +526,102 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 	return res, nil
 }
 
+// HeaderChainProof calls rpcclient#HeaderChainProof and verifies the proof
+// against the trusted header at trustedHeight (latest if nil).
+func (c *Client) HeaderChainProof(
+	ctx context.Context,
+	height int64,
+	trustedHeight *int64,
+) (*ctypes.ResultHeaderChainProof, error) {
+	// Update the light client if we're behind.
+	trustedBlock, err := c.updateLightClientIfNeededTo(ctx, trustedHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.next.HeaderChainProof(ctx, height, &trustedBlock.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Proof.TrustedHeight != trustedBlock.Height {
+		return nil, fmt.Errorf("proof trusted height %d does not match trusted height %d",
+			res.Proof.TrustedHeight, trustedBlock.Height)
+	}
+	if err := res.Header.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := res.Proof.Verify(trustedBlock.Hash(), &res.Header); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Attestation calls rpcclient#Attestation and verifies the attestation's
+// signature, block hash and app hash. If no height is provided, the attestation
+// of the block preceding the latest is returned.
+// NOTE: Light client does not verify that the signer is a validator.
+func (c *Client) Attestation(ctx context.Context, height *int64) (*ctypes.ResultAttestation, error) {
+	var h int64
+	if height == nil {
+		res, err := c.next.Status(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("can't get latest height: %w", err)
+		}
+		// Can't return the latest attestation here because we won't be able to
+		// prove its app hash. Return the attestation of the previous block
+		// instead.
+		h = res.SyncInfo.LatestBlockHeight - 1
+	} else {
+		h = *height
+	}
+
+	res, err := c.next.Attestation(ctx, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Attestation.Height != h {
+		return nil, fmt.Errorf("attestation height %d does not match requested height %d",
+			res.Attestation.Height, h)
+	}
+	if res.PubKey == nil {
+		return nil, errors.New("missing public key")
+	}
+	if err := res.Attestation.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := res.Attestation.Verify(c.lc.ChainID(), res.PubKey); err != nil {
+		return nil, err
+	}
+
+	// Update the light client if we're behind.
+	trustedBlock, err := c.updateLightClientIfNeededTo(ctx, &h)
+	if err != nil {
+		return nil, err
+	}
+	nextHeight := h + 1
+	nextTrustedBlock, err := c.updateLightClientIfNeededTo(ctx, &nextHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the attested block.
+	if bH, tH := res.Attestation.BlockHash, trustedBlock.Hash(); !bytes.Equal(bH, tH) {
+		return nil, fmt.Errorf("attested block %X does not match with trusted block %X",
+			bH, tH)
+	}
+	if aH, tH := res.Attestation.AppHash, nextTrustedBlock.AppHash; !bytes.Equal(aH, tH) {
+		return nil, fmt.Errorf("attested app hash %X does not match with trusted app hash %X",
+			aH, tH)
+	}
+
+	return res, nil
+}
+
 // Header fetches and verifies the header directly via the light client.
 func (c *Client) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
 	lb, err := c.updateLightClientIfNeededTo(ctx, height)
@@ -493,6 +693,55 @@ func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.Resul
 	return res, res.Proof.Validate(l.DataHash)
 }
 
+// TxResultProof calls rpcclient#TxResultProof and verifies the proof of the
+// result against the trusted headers of the block of the transaction and of
+// the next one, or the proof of absence against the trusted header of the
+// block.
+func (c *Client) TxResultProof(ctx context.Context, hash []byte, height *int64) (*ctypes.ResultTxResultProof, error) {
+	res, err := c.next.TxResultProof(ctx, hash, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, errNegOrZeroHeight
+	}
+	if height != nil && res.Height != *height {
+		return nil, fmt.Errorf("expected proof at height %d, got %d", *height, res.Height)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the proof.
+	switch {
+	case res.Proof != nil:
+		if !bytes.Equal(res.Proof.TxProof.Leaf(), hash) {
+			return nil, fmt.Errorf("proof of transaction %X instead of %X", res.Proof.TxProof.Leaf(), hash)
+		}
+		// The results of the block are in the next header.
+		nextHeight := res.Height + 1
+		next, err := c.updateLightClientIfNeededTo(ctx, &nextHeight)
+		if err != nil {
+			return nil, err
+		}
+		if err := res.Proof.Verify(l.DataHash, next.LastResultsHash); err != nil {
+			return nil, err
+		}
+	case res.AbsenceProof != nil:
+		if err := res.AbsenceProof.Verify(l.DataHash, hash); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("no proof of the transaction result nor of its absence")
+	}
+	return res, nil
+}
+
 func (c *Client) TxSearch(
 	ctx context.Context,
 	query string,
@@ -0,0 +1,457 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	service "github.com/cometbft/cometbft/internal/service"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// rpcServerErrorCode is the code of the errors returned by a node which can't
+// serve any request, e.g. because it is shutting down or rate limiting.
+const rpcServerErrorCode = -32000
+
+var _ rpcclient.Client = (*MultiClient)(nil)
+
+// MultiClient is an RPC client forwarding the calls to one of several backends,
+// the primary. When the primary is unavailable, the call is retried on the
+// other backends in turn, and the first one to respond becomes the primary.
+//
+// Subscriptions stay on the backend they were made to.
+type MultiClient struct {
+	service.BaseService
+
+	backends []rpcclient.Client
+
+	mtx           cmtsync.Mutex
+	primary       int
+	subscriptions map[string]rpcclient.Client // query -> backend
+}
+
+// NewMultiClient returns a client forwarding the calls to the given backends,
+// the first one being the initial primary. It panics if no backend is given.
+func NewMultiClient(backends ...rpcclient.Client) *MultiClient {
+	if len(backends) == 0 {
+		panic("at least one backend is required")
+	}
+	c := &MultiClient{
+		backends:      backends,
+		subscriptions: make(map[string]rpcclient.Client),
+	}
+	c.BaseService = *service.NewBaseService(nil, "MultiClient", c)
+	return c
+}
+
+// OnStart starts the backends. It only fails if none of them starts, the
+// others being unavailable for subscriptions.
+func (c *MultiClient) OnStart() error {
+	var errs []error
+	for i, backend := range c.backends {
+		if backend.IsRunning() {
+			continue
+		}
+		if err := backend.Start(); err != nil {
+			c.Logger.Error("Failed to start backend", "backend", i, "err", err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == len(c.backends) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func (c *MultiClient) OnStop() {
+	for i, backend := range c.backends {
+		if !backend.IsRunning() {
+			continue
+		}
+		if err := backend.Stop(); err != nil {
+			c.Logger.Error("Error stopping backend", "backend", i, "err", err)
+		}
+	}
+}
+
+// Primary returns the index of the current primary backend.
+func (c *MultiClient) Primary() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.primary
+}
+
+// forward calls fn with the primary backend, then with the next ones while the
+// backend called is unavailable. The first backend to respond becomes the
+// primary.
+func forward[T any](ctx context.Context, c *MultiClient, fn func(rpcclient.Client) (T, error)) (T, error) {
+	first := c.Primary()
+	var (
+		res T
+		err error
+	)
+	for i := 0; i < len(c.backends); i++ {
+		index := (first + i) % len(c.backends)
+		res, err = fn(c.backends[index])
+		if !isUnavailable(ctx, err) {
+			if i > 0 {
+				c.mtx.Lock()
+				if c.primary == first {
+					c.primary = index
+				}
+				c.mtx.Unlock()
+				c.Logger.Info("Rotated the primary backend", "primary", index)
+			}
+			return res, err
+		}
+		c.Logger.Info("Backend unavailable", "backend", index, "err", err)
+	}
+	return res, err
+}
+
+// isUnavailable returns true if err reports that the backend could not be
+// reached or can't serve any request, as opposed to an error of the request
+// itself.
+func isUnavailable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var rpcErr *rpctypes.RPCError
+	if errors.As(err, &rpcErr) {
+		return rpcErr.Code == rpcServerErrorCode
+	}
+	return true
+}
+
+func (c *MultiClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultStatus, error) {
+		return next.Status(ctx)
+	})
+}
+
+func (c *MultiClient) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultABCIInfo, error) {
+		return next.ABCIInfo(ctx)
+	})
+}
+
+func (c *MultiClient) ABCIQuery(ctx context.Context, path string, data cmtbytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultABCIQuery, error) {
+		return next.ABCIQuery(ctx, path, data)
+	})
+}
+
+func (c *MultiClient) ABCIQueryWithOptions(ctx context.Context, path string, data cmtbytes.HexBytes,
+	opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultABCIQuery, error) {
+		return next.ABCIQueryWithOptions(ctx, path, data, opts)
+	})
+}
+
+func (c *MultiClient) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBroadcastTxCommit, error) {
+		return next.BroadcastTxCommit(ctx, tx)
+	})
+}
+
+func (c *MultiClient) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeoutMs *int,
+) (*ctypes.ResultBroadcastTxCommitProof, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBroadcastTxCommitProof, error) {
+		return next.BroadcastTxCommitProof(ctx, tx, timeoutMs)
+	})
+}
+
+func (c *MultiClient) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBroadcastTx, error) {
+		return next.BroadcastTxAsync(ctx, tx)
+	})
+}
+
+func (c *MultiClient) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBroadcastTx, error) {
+		return next.BroadcastTxSync(ctx, tx)
+	})
+}
+
+func (c *MultiClient) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultUnconfirmedTxs, error) {
+		return next.UnconfirmedTxs(ctx, limit)
+	})
+}
+
+func (c *MultiClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultUnconfirmedTxs, error) {
+		return next.NumUnconfirmedTxs(ctx)
+	})
+}
+
+func (c *MultiClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultCheckTx, error) {
+		return next.CheckTx(ctx, tx)
+	})
+}
+
+func (c *MultiClient) SimulateTx(ctx context.Context, tx types.Tx) (*ctypes.ResultSimulateTx, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultSimulateTx, error) {
+		return next.SimulateTx(ctx, tx)
+	})
+}
+
+func (c *MultiClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultNetInfo, error) {
+		return next.NetInfo(ctx)
+	})
+}
+
+func (c *MultiClient) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultDumpConsensusState, error) {
+		return next.DumpConsensusState(ctx)
+	})
+}
+
+func (c *MultiClient) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultConsensusState, error) {
+		return next.ConsensusState(ctx)
+	})
+}
+
+func (c *MultiClient) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultConsensusParams, error) {
+		return next.ConsensusParams(ctx, height)
+	})
+}
+
+func (c *MultiClient) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultHealth, error) {
+		return next.Health(ctx)
+	})
+}
+
+func (c *MultiClient) ValidatorSigningInfo(ctx context.Context, address []byte) (*ctypes.ResultValidatorSigningInfo, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultValidatorSigningInfo, error) {
+		return next.ValidatorSigningInfo(ctx, address)
+	})
+}
+
+func (c *MultiClient) StorageInfo(ctx context.Context) (*ctypes.ResultStorageInfo, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultStorageInfo, error) {
+		return next.StorageInfo(ctx)
+	})
+}
+
+func (c *MultiClient) RetainHeights(ctx context.Context) (*ctypes.ResultRetainHeights, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultRetainHeights, error) {
+		return next.RetainHeights(ctx)
+	})
+}
+
+func (c *MultiClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBlockchainInfo, error) {
+		return next.BlockchainInfo(ctx, minHeight, maxHeight)
+	})
+}
+
+func (c *MultiClient) LightBlocks(ctx context.Context, from, to int64) (*ctypes.ResultLightBlocks, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultLightBlocks, error) {
+		return next.LightBlocks(ctx, from, to)
+	})
+}
+
+func (c *MultiClient) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultGenesis, error) {
+		return next.Genesis(ctx)
+	})
+}
+
+func (c *MultiClient) GenesisChunked(ctx context.Context, id uint) (*ctypes.ResultGenesisChunk, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultGenesisChunk, error) {
+		return next.GenesisChunked(ctx, id)
+	})
+}
+
+func (c *MultiClient) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultSnapshots, error) {
+		return next.Snapshots(ctx)
+	})
+}
+
+func (c *MultiClient) SnapshotChunk(
+	ctx context.Context,
+	height uint64,
+	format, index uint32,
+	offset int,
+) (*ctypes.ResultSnapshotChunk, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultSnapshotChunk, error) {
+		return next.SnapshotChunk(ctx, height, format, index, offset)
+	})
+}
+
+func (c *MultiClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBlock, error) {
+		return next.Block(ctx, height)
+	})
+}
+
+func (c *MultiClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBlock, error) {
+		return next.BlockByHash(ctx, hash)
+	})
+}
+
+func (c *MultiClient) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBlockResults, error) {
+		return next.BlockResults(ctx, height)
+	})
+}
+
+func (c *MultiClient) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultHeader, error) {
+		return next.Header(ctx, height)
+	})
+}
+
+func (c *MultiClient) HeaderByHash(ctx context.Context, hash cmtbytes.HexBytes) (*ctypes.ResultHeader, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultHeader, error) {
+		return next.HeaderByHash(ctx, hash)
+	})
+}
+
+func (c *MultiClient) HeaderChainProof(
+	ctx context.Context,
+	height int64,
+	trustedHeight *int64,
+) (*ctypes.ResultHeaderChainProof, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultHeaderChainProof, error) {
+		return next.HeaderChainProof(ctx, height, trustedHeight)
+	})
+}
+
+func (c *MultiClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultCommit, error) {
+		return next.Commit(ctx, height)
+	})
+}
+
+func (c *MultiClient) Attestation(ctx context.Context, height *int64) (*ctypes.ResultAttestation, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultAttestation, error) {
+		return next.Attestation(ctx, height)
+	})
+}
+
+func (c *MultiClient) Validators(
+	ctx context.Context,
+	height *int64,
+	page, perPage *int,
+) (*ctypes.ResultValidators, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultValidators, error) {
+		return next.Validators(ctx, height, page, perPage)
+	})
+}
+
+func (c *MultiClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultTx, error) {
+		return next.Tx(ctx, hash, prove)
+	})
+}
+
+func (c *MultiClient) TxResultProof(ctx context.Context, hash []byte, height *int64) (*ctypes.ResultTxResultProof, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultTxResultProof, error) {
+		return next.TxResultProof(ctx, hash, height)
+	})
+}
+
+func (c *MultiClient) TxSearch(
+	ctx context.Context,
+	query string,
+	prove bool,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultTxSearch, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultTxSearch, error) {
+		return next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	})
+}
+
+func (c *MultiClient) BlockSearch(
+	ctx context.Context,
+	query string,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearch, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBlockSearch, error) {
+		return next.BlockSearch(ctx, query, page, perPage, orderBy)
+	})
+}
+
+func (c *MultiClient) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
+	return forward(ctx, c, func(next rpcclient.Client) (*ctypes.ResultBroadcastEvidence, error) {
+		return next.BroadcastEvidence(ctx, ev)
+	})
+}
+
+// Subscribe subscribes to query on the first running backend, starting with
+// the primary, which accepts the subscription.
+func (c *MultiClient) Subscribe(ctx context.Context, subscriber, query string,
+	outCapacity ...int,
+) (out <-chan ctypes.ResultEvent, err error) {
+	type subscription struct {
+		backend rpcclient.Client
+		out     <-chan ctypes.ResultEvent
+	}
+	sub, err := forward(ctx, c, func(next rpcclient.Client) (subscription, error) {
+		if !next.IsRunning() {
+			return subscription{}, service.ErrNotStarted
+		}
+		out, err := next.Subscribe(ctx, subscriber, query, outCapacity...)
+		return subscription{backend: next, out: out}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.mtx.Lock()
+	c.subscriptions[query] = sub.backend
+	c.mtx.Unlock()
+	return sub.out, nil
+}
+
+// Unsubscribe unsubscribes from query on the backend holding the subscription.
+func (c *MultiClient) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	c.mtx.Lock()
+	backend, ok := c.subscriptions[query]
+	delete(c.subscriptions, query)
+	c.mtx.Unlock()
+	if !ok {
+		backend = c.backends[c.Primary()]
+	}
+	return backend.Unsubscribe(ctx, subscriber, query)
+}
+
+// UnsubscribeAll unsubscribes from all the queries on the backends holding
+// subscriptions, or on the primary if none does.
+func (c *MultiClient) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	c.mtx.Lock()
+	backends := make(map[rpcclient.Client]struct{})
+	for query, backend := range c.subscriptions {
+		backends[backend] = struct{}{}
+		delete(c.subscriptions, query)
+	}
+	c.mtx.Unlock()
+	if len(backends) == 0 {
+		return c.backends[c.Primary()].UnsubscribeAll(ctx, subscriber)
+	}
+	var errs []error
+	for backend := range backends {
+		if err := backend.UnsubscribeAll(ctx, subscriber); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	rpcmocks "github.com/cometbft/cometbft/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestMultiClient_Rotation(t *testing.T) {
+	down, up, other := &rpcmocks.Client{}, &rpcmocks.Client{}, &rpcmocks.Client{}
+	c := NewMultiClient(down, up, other)
+	ctx := context.Background()
+
+	// The unavailable primary is replaced by the first backend to respond.
+	down.On("Status", mock.Anything).Return(nil, errors.New("connection refused"))
+	up.On("Status", mock.Anything).Return(&ctypes.ResultStatus{}, nil)
+	_, err := c.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.Primary())
+
+	// The errors of the request itself are returned as is.
+	up.On("Block", mock.Anything, mock.Anything).Return(nil, &rpctypes.RPCError{Code: -32603})
+	_, err = c.Block(ctx, nil)
+	require.Error(t, err)
+	assert.Equal(t, 1, c.Primary())
+
+	// A backend shutting down is unavailable.
+	up.On("Health", mock.Anything).Return(nil, &rpctypes.RPCError{Code: rpcServerErrorCode})
+	other.On("Health", mock.Anything).Return(&ctypes.ResultHealth{}, nil)
+	_, err = c.Health(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.Primary())
+
+	// The last error is returned if no backend is available.
+	other.On("NetInfo", mock.Anything).Return(nil, errors.New("connection refused"))
+	down.On("NetInfo", mock.Anything).Return(nil, errors.New("connection refused"))
+	up.On("NetInfo", mock.Anything).Return(nil, errors.New("no route to host"))
+	_, err = c.NetInfo(ctx)
+	require.EqualError(t, err, "no route to host")
+	assert.Equal(t, 2, c.Primary())
+}
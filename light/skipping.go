@@ -0,0 +1,150 @@
+package light
+
+import (
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/types"
+)
+
+// SkippingStrategy chooses the light blocks skipping verification goes through
+// when it can't verify a light block from a trusted one in one step, because
+// the validator set changed too much between them. Each pivot costs a round
+// trip to the provider.
+type SkippingStrategy interface {
+	// PivotHeight returns the height of the light block to verify before the
+	// untrusted one. It must be strictly between the heights of the trusted
+	// and untrusted light blocks; the bisection pivot is used otherwise.
+	PivotHeight(trusted, untrusted *types.LightBlock, trustingPeriod time.Duration) int64
+
+	// Verified is called when the untrusted light block is verified from the
+	// trusted one in one step.
+	Verified(trusted, untrusted *types.LightBlock)
+}
+
+// WithSkippingStrategy option sets the strategy used by skipping verification.
+// Default: AdaptiveSkipping.
+func WithSkippingStrategy(s SkippingStrategy) Option {
+	return func(c *Client) {
+		c.skippingStrategy = s
+	}
+}
+
+// bisectionPivot returns the pivot height of the bisection between the
+// trusted and untrusted heights.
+func bisectionPivot(trustedHeight, untrustedHeight int64) int64 {
+	return trustedHeight + (untrustedHeight-trustedHeight)*verifySkippingNumerator/verifySkippingDenominator
+}
+
+// pivotHeight returns the height of the light block to verify before
+// untrusted, from the skipping strategy.
+func (c *Client) pivotHeight(strategy SkippingStrategy, trusted, untrusted *types.LightBlock) int64 {
+	height := strategy.PivotHeight(trusted, untrusted, c.trustingPeriod)
+	if height <= trusted.Height || height >= untrusted.Height {
+		return bisectionPivot(trusted.Height, untrusted.Height)
+	}
+	return height
+}
+
+type bisectionSkipping struct{}
+
+// BisectionSkipping returns the strategy bisecting the heights between the
+// trusted and untrusted light blocks, as described by the specification.
+// It takes up to a logarithmic number of round trips in the distance between
+// the light blocks.
+func BisectionSkipping() SkippingStrategy {
+	return bisectionSkipping{}
+}
+
+func (bisectionSkipping) PivotHeight(trusted, untrusted *types.LightBlock, _ time.Duration) int64 {
+	return bisectionPivot(trusted.Height, untrusted.Height)
+}
+
+func (bisectionSkipping) Verified(_, _ *types.LightBlock) {}
+
+type fixedStepSkipping struct {
+	step int64
+}
+
+// FixedStepSkipping returns the strategy verifying the light block step
+// heights above the trusted one, or bisecting if the untrusted light block is
+// closer. It suits the chains whose validator set is known to stay
+// trustworthy over step blocks.
+func FixedStepSkipping(step int64) SkippingStrategy {
+	return fixedStepSkipping{step: step}
+}
+
+func (s fixedStepSkipping) PivotHeight(trusted, untrusted *types.LightBlock, _ time.Duration) int64 {
+	if trusted.Height+s.step < untrusted.Height {
+		return trusted.Height + s.step
+	}
+	return bisectionPivot(trusted.Height, untrusted.Height)
+}
+
+func (fixedStepSkipping) Verified(_, _ *types.LightBlock) {}
+
+type trustPeriodSkipping struct {
+	fraction cmtmath.Fraction
+}
+
+// TrustPeriodSkipping returns the strategy verifying the light block
+// estimated to be produced the given fraction of the trusting period after
+// the trusted one, from the average block time between the trusted and
+// untrusted light blocks, or bisecting if the untrusted light block is closer.
+// Since the validators can't leave without notice within the unbonding period,
+// which the trusting period is shorter than, the validator set rarely changes
+// too much over a fraction of it.
+func TrustPeriodSkipping(fraction cmtmath.Fraction) SkippingStrategy {
+	return trustPeriodSkipping{fraction: fraction}
+}
+
+func (s trustPeriodSkipping) PivotHeight(trusted, untrusted *types.LightBlock, trustingPeriod time.Duration) int64 {
+	elapsed := untrusted.Time.Sub(trusted.Time)
+	if elapsed <= 0 || s.fraction.Denominator == 0 {
+		return bisectionPivot(trusted.Height, untrusted.Height)
+	}
+	window := trustingPeriod / time.Duration(s.fraction.Denominator) * time.Duration(s.fraction.Numerator)
+	if window >= elapsed {
+		return bisectionPivot(trusted.Height, untrusted.Height)
+	}
+	blocks := int64(float64(untrusted.Height-trusted.Height) * float64(window) / float64(elapsed))
+	return trusted.Height + max(blocks, 1)
+}
+
+func (trustPeriodSkipping) Verified(_, _ *types.LightBlock) {}
+
+type adaptiveSkipping struct {
+	mtx cmtsync.Mutex
+	// The longest distance verified in one step.
+	jump int64
+}
+
+// AdaptiveSkipping returns the strategy verifying the light block as far above
+// the trusted one as the longest distance verified in one step so far, or
+// bisecting if the untrusted light block is closer or no distance was verified
+// yet. As long as the validator set changes slowly, most verifications then
+// take one round trip per such distance, instead of bisecting from the
+// untrusted light block every time.
+//
+// This is the default strategy.
+func AdaptiveSkipping() SkippingStrategy {
+	return &adaptiveSkipping{}
+}
+
+func (s *adaptiveSkipping) PivotHeight(trusted, untrusted *types.LightBlock, _ time.Duration) int64 {
+	s.mtx.Lock()
+	jump := s.jump
+	s.mtx.Unlock()
+
+	if jump > 0 && trusted.Height+jump < untrusted.Height {
+		return trusted.Height + jump
+	}
+	return bisectionPivot(trusted.Height, untrusted.Height)
+}
+
+func (s *adaptiveSkipping) Verified(trusted, untrusted *types.LightBlock) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.jump = max(s.jump, untrusted.Height-trusted.Height)
+}
@@ -0,0 +1,84 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/libs/log"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	mockp "github.com/cometbft/cometbft/light/provider/mock"
+	dbs "github.com/cometbft/cometbft/light/store/db"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestSkippingStrategies_PivotHeight(t *testing.T) {
+	block := func(height int64) *types.LightBlock {
+		return &types.LightBlock{SignedHeader: &types.SignedHeader{
+			Header: &types.Header{Height: height, Time: bTime.Add(time.Duration(height) * time.Minute)},
+		}}
+	}
+	trusted, untrusted := block(100), block(1100)
+
+	assert.EqualValues(t, 662, light.BisectionSkipping().PivotHeight(trusted, untrusted, time.Hour))
+
+	fixedStep := light.FixedStepSkipping(50)
+	assert.EqualValues(t, 150, fixedStep.PivotHeight(trusted, untrusted, time.Hour))
+	assert.EqualValues(t, 128, fixedStep.PivotHeight(trusted, block(150), time.Hour))
+
+	// A tenth of 10h is 60 blocks of a minute.
+	trustPeriod := light.TrustPeriodSkipping(cmtmath.Fraction{Numerator: 1, Denominator: 10})
+	assert.EqualValues(t, 160, trustPeriod.PivotHeight(trusted, untrusted, 10*time.Hour))
+	assert.EqualValues(t, 128, trustPeriod.PivotHeight(trusted, block(150), 10*time.Hour))
+
+	adaptive := light.AdaptiveSkipping()
+	assert.EqualValues(t, 662, adaptive.PivotHeight(trusted, untrusted, time.Hour))
+	adaptive.Verified(trusted, block(300))
+	adaptive.Verified(block(300), block(400))
+	assert.EqualValues(t, 300, adaptive.PivotHeight(trusted, untrusted, time.Hour))
+	assert.EqualValues(t, 128, adaptive.PivotHeight(trusted, block(150), time.Hour))
+}
+
+func TestClient_SkippingStrategies(t *testing.T) {
+	// A tenth of the validators changes every block, so that skipping
+	// verification needs pivots.
+	node := mockp.New(genMockNode(chainID, 100, 10, 1, bTime))
+	genesis, err := node.LightBlock(ctx, 1)
+	require.NoError(t, err)
+	strategies := map[string]light.SkippingStrategy{
+		"bisection":    light.BisectionSkipping(),
+		"fixed step":   light.FixedStepSkipping(3),
+		"trust period": light.TrustPeriodSkipping(cmtmath.Fraction{Numerator: 1, Denominator: 100}),
+		"adaptive":     light.AdaptiveSkipping(),
+	}
+
+	for name, strategy := range strategies {
+		t.Run(name, func(t *testing.T) {
+			c, err := light.NewClient(
+				ctx,
+				chainID,
+				light.TrustOptions{
+					Period: 4 * time.Hour,
+					Height: 1,
+					Hash:   genesis.Hash(),
+				},
+				node,
+				[]provider.Provider{node},
+				dbs.New(dbm.NewMemDB(), chainID),
+				light.Logger(log.TestingLogger()),
+				light.WithSkippingStrategy(strategy),
+			)
+			require.NoError(t, err)
+
+			lb, err := c.VerifyLightBlockAtHeight(ctx, 100, bTime.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.EqualValues(t, 100, lb.Height)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package sqlite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// testDriverName is the name of testDriver, registered for the tests.
+const testDriverName = "sqlite-test"
+
+func init() {
+	sql.Register(testDriverName, &testDriver{dbs: make(map[string]*testDB)})
+}
+
+// testDriver is an in-memory database/sql driver running the statements of the
+// store, for the tests not to depend on a SQLite driver, which would be a
+// dependency of the module. It checks the store, not the SQL of its
+// statements. The databases are named by the data source name.
+type testDriver struct {
+	mtx sync.Mutex
+	dbs map[string]*testDB
+}
+
+// testDB holds the light blocks by prefix and height.
+type testDB struct {
+	mtx    sync.Mutex
+	blocks map[string]map[int64][]byte
+}
+
+func (d *testDriver) Open(name string) (driver.Conn, error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	db, ok := d.dbs[name]
+	if !ok {
+		db = &testDB{blocks: make(map[string]map[int64][]byte)}
+		d.dbs[name] = db
+	}
+	return &testConn{db: db}, nil
+}
+
+type testConn struct {
+	db *testDB
+	// blocks before the current transaction, if any, restored on rollback
+	snapshot map[string]map[int64][]byte
+}
+
+func (c *testConn) Prepare(query string) (driver.Stmt, error) {
+	return &testStmt{conn: c, query: strings.Join(strings.Fields(query), " ")}, nil
+}
+
+func (*testConn) Close() error { return nil }
+
+func (c *testConn) Begin() (driver.Tx, error) {
+	c.db.mtx.Lock()
+	defer c.db.mtx.Unlock()
+	c.snapshot = make(map[string]map[int64][]byte, len(c.db.blocks))
+	for prefix, blocks := range c.db.blocks {
+		c.snapshot[prefix] = make(map[int64][]byte, len(blocks))
+		for h, bz := range blocks {
+			c.snapshot[prefix][h] = bz
+		}
+	}
+	return c, nil
+}
+
+func (c *testConn) Commit() error {
+	c.snapshot = nil
+	return nil
+}
+
+func (c *testConn) Rollback() error {
+	c.db.mtx.Lock()
+	defer c.db.mtx.Unlock()
+	c.db.blocks, c.snapshot = c.snapshot, nil
+	return nil
+}
+
+type testStmt struct {
+	conn  *testConn
+	query string
+}
+
+func (*testStmt) Close() error  { return nil }
+func (*testStmt) NumInput() int { return -1 }
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	db := s.conn.db
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	switch s.query {
+	case strings.Join(strings.Fields(createTable), " "):
+	case "INSERT OR REPLACE INTO light_blocks (prefix, height, light_block) VALUES (?, ?, ?)":
+		blocks, ok := db.blocks[args[0].(string)]
+		if !ok {
+			blocks = make(map[int64][]byte)
+			db.blocks[args[0].(string)] = blocks
+		}
+		blocks[args[1].(int64)] = append([]byte(nil), args[2].([]byte)...)
+	case "DELETE FROM light_blocks WHERE prefix = ? AND height = ?":
+		delete(db.blocks[args[0].(string)], args[1].(int64))
+	case "DELETE FROM light_blocks WHERE prefix = ? AND height IN ( " +
+		"SELECT height FROM light_blocks WHERE prefix = ? ORDER BY height ASC LIMIT ?)":
+		heights := db.heights(args[0].(string))
+		for i := 0; i < len(heights) && int64(i) < args[2].(int64); i++ {
+			delete(db.blocks[args[0].(string)], heights[i])
+		}
+	default:
+		return nil, fmt.Errorf("unexpected statement %q", s.query)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	db := s.conn.db
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	prefix := args[0].(string)
+	heights := db.heights(prefix)
+	switch s.query {
+	case "SELECT COUNT(*) FROM light_blocks WHERE prefix = ?":
+		return &testRows{values: []driver.Value{int64(len(heights))}}, nil
+	case "SELECT MAX(height) FROM light_blocks WHERE prefix = ?":
+		if len(heights) == 0 {
+			return &testRows{values: []driver.Value{nil}}, nil
+		}
+		return &testRows{values: []driver.Value{heights[len(heights)-1]}}, nil
+	case "SELECT MIN(height) FROM light_blocks WHERE prefix = ?":
+		if len(heights) == 0 {
+			return &testRows{values: []driver.Value{nil}}, nil
+		}
+		return &testRows{values: []driver.Value{heights[0]}}, nil
+	case "SELECT light_block FROM light_blocks WHERE prefix = ? AND height = ?":
+		if bz, ok := db.blocks[prefix][args[1].(int64)]; ok {
+			return &testRows{values: []driver.Value{bz}}, nil
+		}
+		return &testRows{}, nil
+	case "SELECT light_block FROM light_blocks WHERE prefix = ? AND height < ? ORDER BY height DESC LIMIT 1":
+		for i := len(heights) - 1; i >= 0; i-- {
+			if heights[i] < args[1].(int64) {
+				return &testRows{values: []driver.Value{db.blocks[prefix][heights[i]]}}, nil
+			}
+		}
+		return &testRows{}, nil
+	default:
+		return nil, fmt.Errorf("unexpected query %q", s.query)
+	}
+}
+
+// heights returns the heights of the blocks with the given prefix, in
+// ascending order.
+func (db *testDB) heights(prefix string) []int64 {
+	heights := make([]int64, 0, len(db.blocks[prefix]))
+	for h := range db.blocks[prefix] {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+// testRows holds at most one row of one column.
+type testRows struct {
+	values []driver.Value
+}
+
+func (*testRows) Columns() []string { return []string{"value"} }
+func (*testRows) Close() error      { return nil }
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], nil
+	return nil
+}
@@ -0,0 +1,240 @@
+// Package sqlite implements a light client store backed by SQLite, for the
+// environments where embedding goleveldb is too heavy, such as mobile and
+// embedded devices.
+//
+// The package does not depend on any SQLite driver: open the database with
+// the driver of your choice, e.g. modernc.org/sqlite (pure Go) or
+// github.com/mattn/go-sqlite3 (cgo), and pass it to New.
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/light/store"
+	"github.com/cometbft/cometbft/types"
+	cmterrors "github.com/cometbft/cometbft/types/errors"
+)
+
+const createTable = `CREATE TABLE IF NOT EXISTS light_blocks (
+	prefix TEXT NOT NULL,
+	height INTEGER NOT NULL,
+	light_block BLOB NOT NULL,
+	PRIMARY KEY (prefix, height)
+)`
+
+type sqls struct {
+	db     *sql.DB
+	prefix string
+
+	mtx  cmtsync.RWMutex
+	size uint16
+}
+
+// New returns a Store that wraps a SQLite database (with an optional prefix in
+// case you want to use one database with many light clients). It creates the
+// light_blocks table if it does not exist.
+func New(db *sql.DB, prefix string) (store.Store, error) {
+	if _, err := db.Exec(createTable); err != nil {
+		return nil, fmt.Errorf("creating the light_blocks table: %w", err)
+	}
+	s := &sqls{db: db, prefix: prefix}
+	size, err := s.count(db)
+	if err != nil {
+		return nil, err
+	}
+	s.size = size
+	return s, nil
+}
+
+// queryer is implemented by both sql.DB and sql.Tx.
+type queryer interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func (s *sqls) count(q queryer) (uint16, error) {
+	var size uint16
+	err := q.QueryRow(`SELECT COUNT(*) FROM light_blocks WHERE prefix = ?`, s.prefix).Scan(&size)
+	if err != nil {
+		return 0, fmt.Errorf("counting light blocks: %w", err)
+	}
+	return size, nil
+}
+
+// SaveLightBlock persists LightBlock to the db.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *sqls) SaveLightBlock(lb *types.LightBlock) error {
+	if lb.Height <= 0 {
+		panic("negative or zero height")
+	}
+
+	lbpb, err := lb.ToProto()
+	if err != nil {
+		return cmterrors.ErrMsgToProto{MessageName: "LightBlock", Err: err}
+	}
+
+	lbBz, err := lbpb.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshaling LightBlock: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.update(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO light_blocks (prefix, height, light_block) VALUES (?, ?, ?)`,
+			s.prefix, lb.Height, lbBz)
+		return err
+	})
+}
+
+// DeleteLightBlock deletes the LightBlock from the db.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *sqls) DeleteLightBlock(height int64) error {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.update(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`DELETE FROM light_blocks WHERE prefix = ? AND height = ?`, s.prefix, height)
+		return err
+	})
+}
+
+// update runs fn in a transaction and updates the size. It requires a write
+// lock.
+func (s *sqls) update(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	size, err := s.count(tx)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.size = size
+	return nil
+}
+
+// LightBlock retrieves the LightBlock at the given height.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *sqls) LightBlock(height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	return s.queryLightBlock(`SELECT light_block FROM light_blocks WHERE prefix = ? AND height = ?`,
+		s.prefix, height)
+}
+
+// LastLightBlockHeight returns the last LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *sqls) LastLightBlockHeight() (int64, error) {
+	return s.queryHeight(`SELECT MAX(height) FROM light_blocks WHERE prefix = ?`)
+}
+
+// FirstLightBlockHeight returns the first LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *sqls) FirstLightBlockHeight() (int64, error) {
+	return s.queryHeight(`SELECT MIN(height) FROM light_blocks WHERE prefix = ?`)
+}
+
+// LightBlockBefore returns the LightBlock with the highest height below the
+// given one. It returns ErrLightBlockNotFound if no such block exists.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *sqls) LightBlockBefore(height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	return s.queryLightBlock(
+		`SELECT light_block FROM light_blocks WHERE prefix = ? AND height < ? ORDER BY height DESC LIMIT 1`,
+		s.prefix, height)
+}
+
+// Prune prunes header & validator set pairs until there are only size pairs
+// left.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *sqls) Prune(size uint16) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.size <= size { // nothing to prune
+		return nil
+	}
+	numToPrune := s.size - size
+
+	return s.update(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`DELETE FROM light_blocks WHERE prefix = ? AND height IN (
+			SELECT height FROM light_blocks WHERE prefix = ? ORDER BY height ASC LIMIT ?)`,
+			s.prefix, s.prefix, numToPrune)
+		return err
+	})
+}
+
+// Size returns the number of header & validator set pairs.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *sqls) Size() uint16 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.size
+}
+
+func (s *sqls) queryLightBlock(query string, args ...any) (*types.LightBlock, error) {
+	var bz []byte
+	err := s.db.QueryRow(query, args...).Scan(&bz)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrLightBlockNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lbpb cmtproto.LightBlock
+	err = lbpb.Unmarshal(bz)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
+	}
+
+	lightBlock, err := types.LightBlockFromProto(&lbpb)
+	if err != nil {
+		return nil, fmt.Errorf("proto conversion error: %w", err)
+	}
+
+	return lightBlock, nil
+}
+
+// queryHeight returns the height selected by query, or -1 if the store is
+// empty.
+func (s *sqls) queryHeight(query string) (int64, error) {
+	var height sql.NullInt64
+	if err := s.db.QueryRow(query, s.prefix).Scan(&height); err != nil {
+		return -1, err
+	}
+	if !height.Valid {
+		return -1, nil
+	}
+	return height.Int64, nil
+}
@@ -5,14 +5,19 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/cosmos/gogoproto/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	abciserver "github.com/cometbft/cometbft/abci/server"
+	memproto "github.com/cometbft/cometbft/api/cometbft/mempool/v1"
 	cmtrand "github.com/cometbft/cometbft/internal/rand"
 	"github.com/cometbft/cometbft/internal/test"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/conn"
+	"github.com/cometbft/cometbft/p2p/mock"
 	"github.com/cometbft/cometbft/proxy"
 )
 
@@ -37,6 +42,46 @@ func BenchmarkReap(b *testing.B) {
 	}
 }
 
+// BenchmarkReactorReceiveCachedTxs compares receiving Txs messages of cached
+// transactions as unmarshaled messages, as before, and in buffers.
+func BenchmarkReactorReceiveCachedTxs(b *testing.B) {
+	app := kvstore.NewInMemoryApplication()
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	defer cleanup()
+	reactor := NewReactor(mp.config, mp, false)
+	reactor.SetLogger(log.NewNopLogger())
+	peer := mock.NewPeer(nil)
+
+	txs := make([][]byte, 100)
+	for i := range txs {
+		txs[i] = kvstore.NewTxFromID(i)
+		if _, err := mp.CheckTx(txs[i]); err != nil {
+			b.Fatal(err)
+		}
+	}
+	bz, err := proto.Marshal((&memproto.Txs{Txs: txs}).Wrap())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Receive", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			msg, err := p2p.BufferEnvelope{Buffer: conn.NewBuffer(bz)}.Unmarshal(&memproto.Message{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			reactor.Receive(p2p.Envelope{Src: peer, Message: msg, ChannelID: MempoolChannel})
+		}
+	})
+	b.Run("ReceiveBuffer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reactor.ReceiveBuffer(p2p.BufferEnvelope{Src: peer, Buffer: conn.NewBuffer(bz), ChannelID: MempoolChannel})
+		}
+	})
+}
+
 func BenchmarkCheckTx(b *testing.B) {
 	app := kvstore.NewInMemoryApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -27,6 +27,7 @@ type CListMempool struct {
 	// Atomic integers
 	height   int64 // the last block Update()'d to
 	txsBytes int64 // total size of mempool, in bytes
+	lastSeq  int64 // seq of the last tx added to the mempool
 
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
@@ -62,6 +63,16 @@ type CListMempool struct {
 	// This reduces the pressure on the proxyApp.
 	cache TxCache
 
+	// Holds the transactions ahead of the next sequence of their sender, nil
+	// if the sender queue is disabled.
+	senderQueue *senderQueue
+
+	// Write-ahead log of the transactions, nil if disabled.
+	wal *wal
+
+	// Publishes the events of the transactions checked by the application.
+	eventBus types.MempoolEventPublisher
+
 	logger  log.Logger
 	metrics *Metrics
 }
@@ -86,6 +97,7 @@ func NewCListMempool(
 		height:        height,
 		recheckCursor: nil,
 		recheckEnd:    nil,
+		eventBus:      types.NopEventBus{},
 		logger:        log.NewNopLogger(),
 		metrics:       NopMetrics(),
 	}
@@ -96,6 +108,10 @@ func NewCListMempool(
 		mp.cache = NopTxCache{}
 	}
 
+	if cfg.SenderQueue {
+		mp.senderQueue = newSenderQueue(cfg.SenderQueueMaxTxs)
+	}
+
 	proxyAppConn.SetResponseCallback(mp.globalCb)
 
 	for _, option := range options {
@@ -117,6 +133,16 @@ func (mem *CListMempool) InMempool(txKey types.TxKey) bool {
 	return ok
 }
 
+// GetTxByKey returns the transaction with the given key, if it is in the
+// mempool.
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
+	if e, ok := mem.getCElement(txKey); ok {
+		return e.Value.(*mempoolTx).tx, true
+	}
+	return nil, false
+}
+
 func (mem *CListMempool) addToCache(tx types.Tx) bool {
 	return mem.cache.Push(tx)
 }
@@ -147,6 +173,56 @@ func (mem *CListMempool) removeAllTxs() {
 	})
 }
 
+// InitWAL opens the write-ahead log in the directory configured by
+// config.Mempool.WalDir, and checks again the transactions it holds, i.e. the
+// transactions that were in the mempool when the node stopped. The valid ones
+// are added back to the mempool.
+//
+// NOTE: not thread safe - should only be called once, on startup.
+func (mem *CListMempool) InitWAL() error {
+	w, txs, err := openWAL(mem.config.WalDir())
+	if err != nil {
+		return err
+	}
+	mem.wal = w
+
+	if len(txs) > 0 {
+		mem.logger.Info("Checking transactions from the mempool WAL", "numtxs", len(txs))
+		for _, tx := range txs {
+			if _, err := mem.CheckTx(tx); err != nil {
+				mem.logger.Debug("Transaction from the mempool WAL rejected", "tx", tx.Hash(), "err", err)
+			}
+		}
+		if err := mem.proxyAppConn.Flush(context.TODO()); err != nil {
+			return ErrFlushAppConn{Err: err}
+		}
+	}
+	// drop the transactions that are no longer valid
+	return mem.wal.Compact(mem.allTxs)
+}
+
+// CloseWAL closes the write-ahead log, if enabled.
+//
+// NOTE: not thread safe - should only be called once, on shutdown.
+func (mem *CListMempool) CloseWAL() {
+	if mem.wal == nil {
+		return
+	}
+	if err := mem.wal.Close(); err != nil {
+		mem.logger.Error("Error closing the mempool WAL", "err", err)
+	}
+	mem.wal = nil
+}
+
+// allTxs returns the transactions in the mempool, in order.
+func (mem *CListMempool) allTxs() types.Txs {
+	txs := make(types.Txs, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*mempoolTx).tx)
+	}
+	return txs
+}
+
 // NOTE: not thread safe - should only be called once, on startup.
 func (mem *CListMempool) EnableTxsAvailable() {
 	mem.txsAvailable = make(chan struct{}, 1)
@@ -188,6 +264,13 @@ func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
 }
 
+// WithEventBus sets the event bus, on which the mempool publishes the
+// transactions it adds (EventPendingTx), rejects (EventRejectedTx) or evicts
+// after a recheck (EventEvictedTx).
+func WithEventBus(eventBus types.MempoolEventPublisher) CListMempoolOption {
+	return func(mem *CListMempool) { mem.eventBus = eventBus }
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Lock() {
 	mem.updateMtx.Lock()
@@ -227,6 +310,16 @@ func (mem *CListMempool) Flush() {
 	mem.cache.Reset()
 
 	mem.removeAllTxs()
+	if mem.senderQueue != nil {
+		mem.senderQueue.drain()
+		mem.metrics.HeldTxs.Set(0)
+	}
+
+	if mem.wal != nil {
+		if err := mem.wal.Compact(func() types.Txs { return nil }); err != nil {
+			mem.logger.Error("Error clearing the mempool WAL", "err", err)
+		}
+	}
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -322,6 +415,10 @@ func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
 			mem.metrics.RecheckTimes.Add(1)
 			mem.resCbRecheck(req, res)
 
+		case abci.CHECK_TX_TYPE_SIMULATE:
+			// not a mempool check (see the simulate_tx RPC endpoint)
+			return
+
 		default:
 			panic(fmt.Sprintf("unexpected value %d of RequestCheckTx.type", checkType))
 		}
@@ -338,11 +435,18 @@ func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
 // Called from:
 //   - resCbFirstTime (lock not held) if tx is valid
 func (mem *CListMempool) addTx(memTx *mempoolTx) {
+	memTx.seq = atomic.AddInt64(&mem.lastSeq, 1)
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(memTx.tx.Key(), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
 	mem.logger.Debug("Clisted", "tx", memTx.tx)
+
+	if mem.wal != nil {
+		if err := mem.wal.AddTx(memTx.tx); err != nil {
+			mem.logger.Error("Error writing transaction to the mempool WAL", "tx", memTx.tx.Hash(), "err", err)
+		}
+	}
 }
 
 // RemoveTxByKey removes a transaction from the mempool by its TxKey index.
@@ -359,6 +463,11 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 		mem.txsMap.Delete(txKey)
 		tx := elem.Value.(*mempoolTx).tx
 		atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+		if mem.wal != nil {
+			if err := mem.wal.RemoveTx(txKey); err != nil {
+				mem.logger.Error("Error writing transaction removal to the mempool WAL", "key", txKey, "err", err)
+			}
+		}
 		return nil
 	}
 	return ErrTxNotFound
@@ -369,6 +478,10 @@ func (mem *CListMempool) isFull(txSize int) error {
 		memSize  = mem.Size()
 		txsBytes = mem.SizeBytes()
 	)
+	if mem.senderQueue != nil {
+		memSize += mem.senderQueue.len()
+		txsBytes += mem.senderQueue.sizeBytes()
+	}
 
 	if memSize >= mem.config.Size || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
 		return ErrMempoolIsFull{
@@ -382,6 +495,19 @@ func (mem *CListMempool) isFull(txSize int) error {
 	return nil
 }
 
+// runPreCheck runs the pre-check of the mempool, as of its last update, on tx.
+// The reactor runs it on the transactions it receives, before copying them,
+// and gossips, to enforce the limits of the consensus params, such as
+// mempool.max_tx_bytes, even on the transactions added before these changed.
+func (mem *CListMempool) runPreCheck(tx types.Tx) error {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+	if mem.preCheck == nil {
+		return nil
+	}
+	return mem.preCheck(tx)
+}
+
 // callback, which is called after the app checked the tx for the first time.
 //
 // The case where the app checks the tx for the second and subsequent times is
@@ -403,6 +529,9 @@ func (mem *CListMempool) resCbFirstTime(
 			if err := mem.isFull(len(tx)); err != nil {
 				mem.forceRemoveFromCache(tx) // mempool might have space later
 				mem.logger.Error(err.Error())
+				if err := mem.eventBus.PublishEventRejectedTx(mempoolTxEvent(tx, r.CheckTx, err)); err != nil {
+					mem.logger.Error("failed publishing rejected tx", "err", err)
+				}
 				return
 			}
 
@@ -418,10 +547,21 @@ func (mem *CListMempool) resCbFirstTime(
 				return
 			}
 
+			sender, seq := r.CheckTx.Sender, r.CheckTx.Sequence
+			gasPrice := txGasPrice(r.CheckTx.Events)
+			queued := mem.senderQueue != nil && sender != ""
+			if queued && seq > r.CheckTx.NextSequence {
+				mem.holdTx(tx, r.CheckTx, sender, seq)
+				return
+			}
+
 			mem.addTx(&mempoolTx{
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
+				weight:    r.CheckTx.Weight,
 				tx:        tx,
+				sender:    sender,
+				gasPrice:  gasPrice,
 			})
 			mem.logger.Debug(
 				"added valid transaction",
@@ -430,7 +570,13 @@ func (mem *CListMempool) resCbFirstTime(
 				"height", mem.height,
 				"total", mem.Size(),
 			)
+			if err := mem.eventBus.PublishEventPendingTx(mempoolTxEvent(tx, r.CheckTx, nil)); err != nil {
+				mem.logger.Error("failed publishing pending tx", "err", err)
+			}
 			mem.notifyTxsAvailable()
+			if queued {
+				mem.releaseNextTx(sender, seq)
+			}
 		} else {
 			mem.tryRemoveFromCache(tx)
 			mem.logger.Debug(
@@ -440,6 +586,9 @@ func (mem *CListMempool) resCbFirstTime(
 				"err", postCheckErr,
 			)
 			mem.metrics.FailedTxs.Add(1)
+			if err := mem.eventBus.PublishEventRejectedTx(mempoolTxEvent(tx, r.CheckTx, postCheckErr)); err != nil {
+				mem.logger.Error("failed publishing rejected tx", "err", err)
+			}
 		}
 
 	default:
@@ -447,6 +596,70 @@ func (mem *CListMempool) resCbFirstTime(
 	}
 }
 
+// holdTx holds tx, whose sequence seq is ahead of the next sequence of sender,
+// in the sender queue, or rejects it if the queue cannot hold it.
+func (mem *CListMempool) holdTx(tx types.Tx, res *abci.CheckTxResponse, sender string, seq uint64) {
+	if err := mem.senderQueue.hold(sender, seq, tx); err != nil {
+		mem.forceRemoveFromCache(tx) // the queue might have space later
+		mem.logger.Debug("rejected transaction ahead of its sender", "tx", tx.Hash(), "sender", sender, "err", err)
+		mem.metrics.RejectedTxs.Add(1)
+		if err := mem.eventBus.PublishEventRejectedTx(mempoolTxEvent(tx, res, err)); err != nil {
+			mem.logger.Error("failed publishing rejected tx", "err", err)
+		}
+		return
+	}
+	mem.logger.Debug("held transaction ahead of its sender", "tx", tx.Hash(), "sender", sender, "sequence", seq)
+	mem.metrics.HeldTxs.Set(float64(mem.senderQueue.len()))
+}
+
+// releaseNextTx checks again the transaction of sender following the one with
+// sequence seq, just added to the mempool, if it is held.
+//
+// The check runs in its own goroutine, as the local client holds its mutex
+// while calling back the mempool.
+func (mem *CListMempool) releaseNextTx(sender string, seq uint64) {
+	tx, ok := mem.senderQueue.release(sender, seq+1)
+	if !ok {
+		return
+	}
+	mem.metrics.HeldTxs.Set(float64(mem.senderQueue.len()))
+	go func() {
+		mem.updateMtx.RLock()
+		defer mem.updateMtx.RUnlock()
+		mem.checkHeldTx(tx)
+	}()
+}
+
+// checkHeldTx checks again tx, released from the sender queue. The
+// transaction is still in the cache.
+func (mem *CListMempool) checkHeldTx(tx types.Tx) {
+	_, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.CheckTxRequest{
+		Tx:   tx,
+		Type: abci.CHECK_TX_TYPE_CHECK,
+	})
+	if err != nil {
+		mem.logger.Error("Error checking held transaction", "tx", tx.Hash(), "err", err)
+		mem.forceRemoveFromCache(tx)
+	}
+}
+
+// mempoolTxEvent returns the data of the mempool event of tx, checked by the
+// application with response res. err, if not nil, is the reason the mempool
+// rejected or evicted the transaction, and replaces the log of res.
+func mempoolTxEvent(tx types.Tx, res *abci.CheckTxResponse, err error) types.EventDataMempoolTx {
+	data := types.EventDataMempoolTx{
+		Tx:        tx,
+		Code:      res.Code,
+		Codespace: res.Codespace,
+		Log:       res.Log,
+		Events:    res.Events,
+	}
+	if err != nil {
+		data.Log = err.Error()
+	}
+	return data
+}
+
 // callback, which is called after the app rechecked the tx.
 //
 // The case where the app checks the tx for the first time is handled by the
@@ -497,6 +710,9 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 				mem.logger.Debug("Transaction could not be removed from mempool", "err", err)
 			}
 			mem.tryRemoveFromCache(tx)
+			if err := mem.eventBus.PublishEventEvictedTx(mempoolTxEvent(tx, r.CheckTx, postCheckErr)); err != nil {
+				mem.logger.Error("failed publishing evicted tx", "err", err)
+			}
 		}
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
@@ -543,6 +759,7 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 
 	var (
 		totalGas    int64
+		totalWeight int64
 		runningSize int64
 	)
 
@@ -573,6 +790,13 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 			return txs[:len(txs)-1]
 		}
 		totalGas = newTotalGas
+
+		// Check total weight requirement, if the node limits it.
+		newTotalWeight := totalWeight + memTx.weight
+		if mem.config.MaxBlockWeight > 0 && newTotalWeight > mem.config.MaxBlockWeight {
+			return txs[:len(txs)-1]
+		}
+		totalWeight = newTotalWeight
 	}
 	return txs
 }
@@ -594,6 +818,26 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	return txs
 }
 
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) QueryTxs(query TxQuery) (types.Txs, string, error) {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	memTxs := make([]*mempoolTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTxs = append(memTxs, e.Value.(*mempoolTx))
+	}
+	selected, cursor, err := queryTxs(memTxs, query)
+	if err != nil {
+		return nil, "", err
+	}
+	txs := make(types.Txs, len(selected))
+	for i, memTx := range selected {
+		txs[i] = memTx.tx
+	}
+	return txs, cursor, nil
+}
+
 // Lock() must be help by the caller during execution.
 // TODO: this function always returns nil; remove the return value.
 func (mem *CListMempool) Update(
@@ -639,6 +883,16 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	// The new pre-check may be stricter, e.g. if the consensus params lowered
+	// mempool.max_tx_bytes.
+	if preCheck != nil {
+		mem.removeTxsFailingPreCheck()
+	}
+
+	if mem.wal != nil {
+		mem.updateWAL()
+	}
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if mem.Size() > 0 {
@@ -653,6 +907,22 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	// Check again the held transactions, in sequence, as the ones before them
+	// may have been committed. Their responses come after the rechecks.
+	if mem.senderQueue != nil {
+		for _, tx := range mem.senderQueue.drain() {
+			if mem.preCheck != nil {
+				if err := mem.preCheck(tx); err != nil {
+					mem.logger.Debug("Dropped held tx failing the pre-check", "tx", tx.Hash(), "err", err)
+					mem.tryRemoveFromCache(tx)
+					continue
+				}
+			}
+			mem.checkHeldTx(tx)
+		}
+		mem.metrics.HeldTxs.Set(float64(mem.senderQueue.len()))
+	}
+
 	// Update metrics
 	mem.metrics.Size.Set(float64(mem.Size()))
 	mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
@@ -660,6 +930,40 @@ func (mem *CListMempool) Update(
 	return nil
 }
 
+// removeTxsFailingPreCheck evicts the transactions failing the pre-check,
+// which were added before it changed.
+func (mem *CListMempool) removeTxsFailingPreCheck() {
+	for e := mem.txs.Front(); e != nil; {
+		next := e.Next()
+		tx := e.Value.(*mempoolTx).tx
+		if err := mem.preCheck(tx); err != nil {
+			mem.logger.Debug("evicting tx failing the pre-check", "tx", tx.Hash(), "err", err)
+			if err := mem.RemoveTxByKey(tx.Key()); err != nil {
+				mem.logger.Debug("Transaction could not be removed from mempool", "err", err)
+			}
+			mem.tryRemoveFromCache(tx)
+			if err := mem.eventBus.PublishEventEvictedTx(mempoolTxEvent(tx, &abci.CheckTxResponse{}, err)); err != nil {
+				mem.logger.Error("failed publishing evicted tx", "err", err)
+			}
+		}
+		e = next
+	}
+}
+
+// updateWAL syncs the write-ahead log to disk, compacting it first if most of
+// its records are about committed transactions.
+func (mem *CListMempool) updateWAL() {
+	if mem.wal.NeedsCompaction() {
+		if err := mem.wal.Compact(mem.allTxs); err != nil {
+			mem.logger.Error("Error compacting the mempool WAL", "err", err)
+		}
+		return // compacting syncs the WAL
+	}
+	if err := mem.wal.Sync(); err != nil {
+		mem.logger.Error("Error syncing the mempool WAL", "err", err)
+	}
+}
+
 func (mem *CListMempool) recheckTxs() {
 	if mem.Size() == 0 {
 		panic("recheckTxs is called, but the mempool is empty")
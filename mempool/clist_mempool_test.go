@@ -3,9 +3,14 @@ package mempool
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	mrand "math/rand"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -176,6 +181,135 @@ func TestReapMaxBytesMaxGas(t *testing.T) {
 	}
 }
 
+// weightedApp weighs each transaction 3.
+type weightedApp struct {
+	*kvstore.Application
+}
+
+func (app weightedApp) CheckTx(ctx context.Context, req *abci.CheckTxRequest) (*abci.CheckTxResponse, error) {
+	res, err := app.Application.CheckTx(ctx, req)
+	if err == nil {
+		res.Weight = 3
+	}
+	return res, err
+}
+
+func TestReapMaxBytesMaxGasMaxWeight(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(weightedApp{kvstore.NewInMemoryApplication()})
+	conf := test.ResetTestRoot("mempool_test")
+	conf.Mempool.MaxBlockWeight = 10
+	mp, cleanup := newMempoolWithAppAndConfig(cc, conf)
+	defer cleanup()
+
+	checkTxs(t, mp, 5)
+	tx0 := mp.TxsFront().Value.(*mempoolTx)
+	require.Equal(t, int64(3), tx0.weight, "transactions weight was set incorrectly")
+
+	// 3 txs weigh 9, a 4th one would exceed the max weight of 10.
+	assert.Len(t, mp.ReapMaxBytesMaxGas(-1, -1), 3)
+	// The other limits still apply.
+	assert.Len(t, mp.ReapMaxBytesMaxGas(-1, 2), 2)
+
+	conf.Mempool.MaxBlockWeight = 0
+	assert.Len(t, mp.ReapMaxBytesMaxGas(-1, -1), 5)
+}
+
+// sequencedApp checks the transactions "sender/sequence[/...]", accepting
+// those at or ahead of the next sequence of their sender.
+type sequencedApp struct {
+	abci.BaseApplication
+
+	mtx  sync.Mutex
+	next map[string]uint64
+}
+
+func (app *sequencedApp) setNext(sender string, next uint64) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.next[sender] = next
+}
+
+func (app *sequencedApp) CheckTx(_ context.Context, req *abci.CheckTxRequest) (*abci.CheckTxResponse, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	parts := strings.Split(string(req.Tx), "/")
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil || seq < app.next[parts[0]] {
+		return &abci.CheckTxResponse{Code: 1}, nil
+	}
+	next := app.next[parts[0]]
+	if seq == next {
+		app.next[parts[0]]++
+	}
+	return &abci.CheckTxResponse{
+		Code:         abci.CodeTypeOK,
+		Sender:       parts[0],
+		Sequence:     seq,
+		NextSequence: next,
+	}, nil
+}
+
+func TestMempoolSenderQueue(t *testing.T) {
+	app := &sequencedApp{next: make(map[string]uint64)}
+	cc := proxy.NewLocalClientCreator(app)
+	conf := test.ResetTestRoot("mempool_test")
+	conf.Mempool.SenderQueue = true
+	conf.Mempool.SenderQueueMaxTxs = 2
+	mp, cleanup := newMempoolWithAppAndConfig(cc, conf)
+	defer cleanup()
+
+	// Txs ahead of the next sequence are held, up to 2 per sender.
+	callCheckTx(t, mp, types.Txs{types.Tx("a/2"), types.Tx("a/1"), types.Tx("a/3"), types.Tx("a/2/other")})
+	assert.Zero(t, mp.Size())
+	assert.Equal(t, 2, mp.senderQueue.len())
+	assert.True(t, mp.cache.Has(types.Tx("a/2")))
+	assert.False(t, mp.cache.Has(types.Tx("a/3")), "tx rejected by a full queue still cached")
+	assert.False(t, mp.cache.Has(types.Tx("a/2/other")), "tx with a held sequence still cached")
+
+	// The next tx releases the held ones, in sequence.
+	callCheckTx(t, mp, types.Txs{types.Tx("a/0")})
+	require.Eventually(t, func() bool { return mp.Size() == 3 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, types.Txs{types.Tx("a/0"), types.Tx("a/1"), types.Tx("a/2")}, mp.ReapMaxTxs(-1))
+	assert.Zero(t, mp.senderQueue.len())
+
+	// Another sender has its own queue.
+	callCheckTx(t, mp, types.Txs{types.Tx("b/1")})
+	assert.Equal(t, 1, mp.senderQueue.len())
+
+	// The held txs are checked again after a block, as the ones before them
+	// may have been committed elsewhere.
+	app.setNext("a", 0)
+	app.setNext("b", 1)
+	mp.Lock()
+	err := mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	assert.Zero(t, mp.senderQueue.len())
+	assert.Equal(t, types.Txs{types.Tx("a/0"), types.Tx("a/1"), types.Tx("a/2"), types.Tx("b/1")}, mp.ReapMaxTxs(-1))
+}
+
+func TestMempoolSenderQueueFull(t *testing.T) {
+	app := &sequencedApp{next: make(map[string]uint64)}
+	cc := proxy.NewLocalClientCreator(app)
+	conf := test.ResetTestRoot("mempool_test")
+	conf.Mempool.SenderQueue = true
+	conf.Mempool.MaxTxsBytes = 9
+	mp, cleanup := newMempoolWithAppAndConfig(cc, conf)
+	defer cleanup()
+
+	// The held txs count against the size in bytes of the mempool.
+	callCheckTx(t, mp, types.Txs{types.Tx("a/1"), types.Tx("b/1"), types.Tx("c/1")})
+	assert.Equal(t, 3, mp.senderQueue.len())
+	assert.EqualValues(t, 9, mp.senderQueue.sizeBytes())
+	_, err := mp.CheckTx(types.Tx("d/0"))
+	require.ErrorAs(t, err, &ErrMempoolIsFull{})
+
+	mp.Flush()
+	assert.Zero(t, mp.senderQueue.sizeBytes())
+	_, err = mp.CheckTx(types.Tx("d/0"))
+	require.NoError(t, err)
+}
+
 func TestMempoolFilters(t *testing.T) {
 	app := kvstore.NewInMemoryApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -215,6 +349,30 @@ func TestMempoolFilters(t *testing.T) {
 	}
 }
 
+func TestMempoolUpdateStricterPreCheck(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// each tx has 20 bytes
+	txs := checkTxs(t, mp, 10)
+	require.Equal(t, 10, mp.Size())
+
+	// the txs added before the pre-check got stricter are evicted
+	err := mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), PreCheckMaxBytes(10), nil)
+	require.NoError(t, err)
+	require.Zero(t, mp.Size())
+	require.Zero(t, mp.SizeBytes())
+
+	// and may be added again once it is relaxed
+	err = mp.Update(2, types.Txs{}, abciResponses(0, abci.CodeTypeOK), PreCheckMaxBytes(30), nil)
+	require.NoError(t, err)
+	_, err = mp.CheckTx(txs[0])
+	require.NoError(t, err)
+	require.Equal(t, 1, mp.Size())
+}
+
 func TestMempoolUpdate(t *testing.T) {
 	app := kvstore.NewInMemoryApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -256,6 +414,93 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+func TestMempoolWAL(t *testing.T) {
+	conf := test.ResetTestRoot("mempool_test")
+	defer os.RemoveAll(conf.RootDir)
+	conf.Mempool.WalPath = "data/mempool.wal"
+	newMempool := func() *CListMempool {
+		mp, _ := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(kvstore.NewInMemoryApplication()), conf)
+		require.NoError(t, mp.InitWAL())
+		return mp
+	}
+
+	mp := newMempool()
+	txs := types.Txs{kvstore.NewTxFromID(1), kvstore.NewTxFromID(2), kvstore.NewTxFromID(3)}
+	callCheckTx(t, mp, txs)
+	require.NoError(t, mp.Update(1, txs[:1], abciResponses(1, abci.CodeTypeOK), nil, nil))
+	// stop without closing the WAL, as if the node crashed in the middle of
+	// writing a record
+	walPath := filepath.Join(conf.Mempool.WalDir(), walFile)
+	f, err := os.OpenFile(walPath, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write(encodeWALRecord(walRecordAddTx, kvstore.NewTxFromID(4))[:walRecordHeaderSize+2])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// the txs that were not committed are checked again on restart, and the
+	// WAL is compacted
+	mp = newMempool()
+	defer func() { mp.CloseWAL() }()
+	require.Equal(t, txs[1:], mp.ReapMaxTxs(-1))
+	info, err := os.Stat(walPath)
+	require.NoError(t, err)
+	require.EqualValues(t, len(encodeWALRecord(walRecordAddTx, txs[1]))*2, info.Size())
+
+	mp.Flush()
+	require.Zero(t, mp.Size())
+	mp.CloseWAL()
+	mp = newMempool()
+	require.Zero(t, mp.Size())
+}
+
+// mempoolTxEvents records the mempool events published.
+type mempoolTxEvents []string
+
+func (e *mempoolTxEvents) record(eventType string, data types.EventDataMempoolTx) error {
+	*e = append(*e, fmt.Sprintf("%s %s %d %s", eventType, string(data.Tx), data.Code, data.Log))
+	return nil
+}
+
+func (e *mempoolTxEvents) PublishEventPendingTx(data types.EventDataMempoolTx) error {
+	return e.record(types.EventPendingTx, data)
+}
+
+func (e *mempoolTxEvents) PublishEventRejectedTx(data types.EventDataMempoolTx) error {
+	return e.record(types.EventRejectedTx, data)
+}
+
+func (e *mempoolTxEvents) PublishEventEvictedTx(data types.EventDataMempoolTx) error {
+	return e.record(types.EventEvictedTx, data)
+}
+
+func TestMempoolEvents(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	defer cleanup()
+	events := &mempoolTxEvents{}
+	WithEventBus(events)(mp)
+
+	callCheckTx(t, mp, types.Txs{types.Tx("a=1"), types.Tx("invalid"), types.Tx("b=2")})
+	require.Equal(t, &mempoolTxEvents{
+		"PendingTx a=1 0 ",
+		fmt.Sprintf("RejectedTx invalid %d ", kvstore.CodeTypeInvalidTxFormat),
+		"PendingTx b=2 0 ",
+	}, events)
+
+	// b=2 fails the recheck after a block is committed
+	*events = nil
+	postCheck := func(tx types.Tx, _ *abci.CheckTxResponse) error {
+		if string(tx) == "b=2" {
+			return errors.New("expired")
+		}
+		return nil
+	}
+	require.NoError(t, mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, postCheck))
+	require.NoError(t, mp.FlushAppConn())
+	require.Equal(t, &mempoolTxEvents{"EvictedTx b=2 0 expired"}, events)
+	require.Equal(t, types.Txs{types.Tx("a=1")}, mp.ReapMaxTxs(-1))
+}
+
 func TestMempoolUpdateDoesNotPanicWhenApplicationMissedTx(t *testing.T) {
 	var callback abciclient.Callback
 	mockClient := new(abciclimocks.Client)
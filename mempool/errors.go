@@ -94,3 +94,25 @@ func (e ErrFlushAppConn) Error() string {
 func (e ErrFlushAppConn) Unwrap() error {
 	return e.Err
 }
+
+// ErrSenderQueueFull is returned when the sender queue already holds the
+// maximum number of transactions of a sender.
+type ErrSenderQueueFull struct {
+	Sender string
+	Max    int
+}
+
+func (e ErrSenderQueueFull) Error() string {
+	return fmt.Sprintf("sender queue is full: sender %q has %d txs held", e.Sender, e.Max)
+}
+
+// ErrSequenceHeld is returned when the sender queue already holds another
+// transaction with the same sender and sequence.
+type ErrSequenceHeld struct {
+	Sender   string
+	Sequence uint64
+}
+
+func (e ErrSequenceHeld) Error() string {
+	return fmt.Sprintf("sender %q already has a tx with sequence %d held", e.Sender, e.Sequence)
+}
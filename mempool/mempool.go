@@ -11,6 +11,10 @@ import (
 
 const (
 	MempoolChannel = byte(0x30)
+	// MempoolSyncChannel carries the requests for the transactions in the
+	// mempool of a newly connected peer. The transactions themselves are sent
+	// on MempoolChannel.
+	MempoolSyncChannel = byte(0x31)
 
 	// PeerCatchupSleepIntervalMS defines how much time to sleep if a peer is behind.
 	PeerCatchupSleepIntervalMS = 100
@@ -33,7 +37,9 @@ type Mempool interface {
 
 	// ReapMaxBytesMaxGas reaps transactions from the mempool up to maxBytes
 	// bytes total with the condition that the total gasWanted must be less than
-	// maxGas.
+	// maxGas. If the mempool is configured with a max_block_weight, the total
+	// weight of the transactions, reported by the application in CheckTx, must
+	// not exceed it either.
 	//
 	// If both maxes are negative, there is no cap on the size of all returned
 	// transactions (~ all available transactions).
@@ -44,6 +50,14 @@ type Mempool interface {
 	// (~ all available transactions).
 	ReapMaxTxs(max int) types.Txs
 
+	// GetTxByKey returns the transaction with the given key, if it is in the
+	// mempool.
+	GetTxByKey(txKey types.TxKey) (types.Tx, bool)
+
+	// QueryTxs returns the transactions selected by query, along with a cursor
+	// to query the next ones, empty if there are none.
+	QueryTxs(query TxQuery) (types.Txs, string, error)
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely
 	// update.
 	Lock()
@@ -10,7 +10,13 @@ import (
 type mempoolTx struct {
 	height    int64    // height that this tx had been validated in
 	gasWanted int64    // amount of gas this tx states it will require
+	weight    int64    // weight of this tx reported by the application
 	tx        types.Tx // validated by the application
+	seq       int64    // order of arrival in the mempool
+
+	// reported by the application in the CheckTx response, see TxQuery
+	sender   string
+	gasPrice int64
 }
 
 // Height returns the height for this transaction.
@@ -64,6 +64,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "active_outbound_connections",
 			Help:      "Number of connections being actively used for gossiping transactions (experimental feature).",
 		}, labels).With(labelsAndValues...),
+		HeldTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "held_txs",
+			Help:      "Number of transactions held by the sender queue, ahead of the next sequence of their sender.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -77,5 +83,6 @@ func NopMetrics() *Metrics {
 		RecheckTimes:              discard.NewCounter(),
 		AlreadyReceivedTxs:        discard.NewCounter(),
 		ActiveOutboundConnections: discard.NewGauge(),
+		HeldTxs:                   discard.NewGauge(),
 	}
 }
@@ -44,4 +44,8 @@ type Metrics struct {
 	// Number of connections being actively used for gossiping transactions
 	// (experimental feature).
 	ActiveOutboundConnections metrics.Gauge
+
+	// Number of transactions held by the sender queue, ahead of the next
+	// sequence of their sender.
+	HeldTxs metrics.Gauge
 }
@@ -76,11 +76,78 @@ func (_m *Mempool) FlushAppConn() error {
 	return r0
 }
 
+// GetTxByKey provides a mock function with given fields: txKey
+func (_m *Mempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
+	ret := _m.Called(txKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxByKey")
+	}
+
+	var r0 types.Tx
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(types.TxKey) (types.Tx, bool)); ok {
+		return rf(txKey)
+	}
+	if rf, ok := ret.Get(0).(func(types.TxKey) types.Tx); ok {
+		r0 = rf(txKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Tx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(types.TxKey) bool); ok {
+		r1 = rf(txKey)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // Lock provides a mock function with given fields:
 func (_m *Mempool) Lock() {
 	_m.Called()
 }
 
+// QueryTxs provides a mock function with given fields: query
+func (_m *Mempool) QueryTxs(query mempool.TxQuery) (types.Txs, string, error) {
+	ret := _m.Called(query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryTxs")
+	}
+
+	var r0 types.Txs
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(mempool.TxQuery) (types.Txs, string, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(mempool.TxQuery) types.Txs); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Txs)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(mempool.TxQuery) string); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(mempool.TxQuery) error); ok {
+		r2 = rf(query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ReapMaxBytesMaxGas provides a mock function with given fields: maxBytes, maxGas
 func (_m *Mempool) ReapMaxBytesMaxGas(maxBytes int64, maxGas int64) types.Txs {
 	ret := _m.Called(maxBytes, maxGas)
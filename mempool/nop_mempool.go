@@ -35,6 +35,12 @@ func (*NopMempool) ReapMaxBytesMaxGas(int64, int64) types.Txs { return nil }
 // ReapMaxTxs always returns nil.
 func (*NopMempool) ReapMaxTxs(int) types.Txs { return nil }
 
+// GetTxByKey always returns false.
+func (*NopMempool) GetTxByKey(types.TxKey) (types.Tx, bool) { return nil, false }
+
+// QueryTxs always returns nil.
+func (*NopMempool) QueryTxs(TxQuery) (types.Txs, string, error) { return nil, "", nil }
+
 // Lock does nothing.
 func (*NopMempool) Lock() {}
 
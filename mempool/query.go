@@ -0,0 +1,152 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// The application can tell the mempool the gas price of a transaction, so that
+// the transactions can be sorted by it (see TxQuery), by adding to the CheckTx
+// response an event of type EventTypeMempool with the attribute below. The gas
+// price must be an integer. The sender, by which the transactions can be
+// filtered, is the Sender field of the CheckTx response.
+const (
+	EventTypeMempool     = "mempool"
+	AttributeKeyGasPrice = "gas_price"
+)
+
+// TxOrder is the order of the transactions returned by QueryTxs.
+type TxOrder string
+
+const (
+	// TxOrderMempool orders transactions as in the mempool, i.e. by arrival.
+	TxOrderMempool TxOrder = ""
+	// TxOrderGasPrice orders transactions by decreasing gas price, then by
+	// arrival.
+	TxOrderGasPrice TxOrder = "gas_price"
+)
+
+// ErrInvalidCursor is returned when querying transactions with a cursor that
+// was not returned by a previous query.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// TxQuery selects transactions of the mempool.
+type TxQuery struct {
+	// Sender, if not empty, selects the transactions of this sender only.
+	Sender string
+	// OrderBy is the order of the transactions.
+	OrderBy TxOrder
+	// Cursor, if not empty, selects the transactions after the last one
+	// returned by the query which returned it.
+	Cursor string
+	// Limit is the maximum number of transactions to return. Negative means
+	// no limit.
+	Limit int
+}
+
+// txGasPrice returns the gas price of a transaction reported by the
+// application in the events of its CheckTx response, or zero if not reported.
+func txGasPrice(events []abci.Event) (gasPrice int64) {
+	for _, event := range events {
+		if event.Type != EventTypeMempool {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key != AttributeKeyGasPrice {
+				continue
+			}
+			if price, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+				gasPrice = price
+			}
+		}
+	}
+	return gasPrice
+}
+
+// txCursor is the position of a transaction in the results of a query.
+type txCursor struct {
+	gasPrice int64
+	seq      int64
+}
+
+func parseTxCursor(s string) (txCursor, error) {
+	gasPrice, seq, ok := strings.Cut(s, ":")
+	if !ok {
+		return txCursor{}, ErrInvalidCursor
+	}
+	var (
+		c   txCursor
+		err error
+	)
+	if c.gasPrice, err = strconv.ParseInt(gasPrice, 10, 64); err != nil {
+		return txCursor{}, ErrInvalidCursor
+	}
+	if c.seq, err = strconv.ParseInt(seq, 10, 64); err != nil {
+		return txCursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func (c txCursor) String() string {
+	return fmt.Sprintf("%d:%d", c.gasPrice, c.seq)
+}
+
+func cursorOf(memTx *mempoolTx) txCursor {
+	return txCursor{gasPrice: memTx.gasPrice, seq: memTx.seq}
+}
+
+// queryTxs selects the transactions of memTxs, ordered by arrival, matching
+// query. It may reorder memTxs. It returns them along with the cursor of the last one, or an empty
+// cursor if there are no more transactions.
+func queryTxs(memTxs []*mempoolTx, query TxQuery) ([]*mempoolTx, string, error) {
+	var (
+		after    txCursor
+		hasAfter = query.Cursor != ""
+	)
+	if hasAfter {
+		var err error
+		if after, err = parseTxCursor(query.Cursor); err != nil {
+			return nil, "", err
+		}
+	}
+	before := func(a, b txCursor) bool {
+		if query.OrderBy == TxOrderGasPrice && a.gasPrice != b.gasPrice {
+			return a.gasPrice > b.gasPrice
+		}
+		return a.seq < b.seq
+	}
+	switch query.OrderBy {
+	case TxOrderMempool:
+	case TxOrderGasPrice:
+		sort.SliceStable(memTxs, func(i, j int) bool {
+			return memTxs[i].gasPrice > memTxs[j].gasPrice
+		})
+	default:
+		return nil, "", fmt.Errorf("unknown order %q", query.OrderBy)
+	}
+
+	selected := make([]*mempoolTx, 0)
+	for _, memTx := range memTxs {
+		if query.Sender != "" && memTx.sender != query.Sender {
+			continue
+		}
+		if hasAfter && !before(after, cursorOf(memTx)) {
+			continue
+		}
+		if query.Limit >= 0 && len(selected) == query.Limit {
+			// memTx is the first transaction of the next page
+			cursor := query.Cursor
+			if len(selected) > 0 {
+				cursor = cursorOf(selected[len(selected)-1]).String()
+			}
+			return selected, cursor, nil
+		}
+		selected = append(selected, memTx)
+	}
+	return selected, "", nil
+}
@@ -0,0 +1,93 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTxGasPrice(t *testing.T) {
+	gasPrice := txGasPrice([]abci.Event{
+		{Type: "transfer", Attributes: []abci.EventAttribute{{Key: AttributeKeyGasPrice, Value: "10"}}},
+		{Type: EventTypeMempool, Attributes: []abci.EventAttribute{{Key: AttributeKeyGasPrice, Value: "25"}}},
+	})
+	assert.EqualValues(t, 25, gasPrice)
+
+	gasPrice = txGasPrice([]abci.Event{
+		{Type: EventTypeMempool, Attributes: []abci.EventAttribute{{Key: AttributeKeyGasPrice, Value: "cheap"}}},
+	})
+	assert.Zero(t, gasPrice)
+}
+
+func TestQueryTxs(t *testing.T) {
+	memTxs := []*mempoolTx{
+		{tx: types.Tx("a1"), seq: 1, sender: "alice", gasPrice: 10},
+		{tx: types.Tx("b1"), seq: 2, sender: "bob", gasPrice: 30},
+		{tx: types.Tx("a2"), seq: 3, sender: "alice", gasPrice: 30},
+		{tx: types.Tx("a3"), seq: 4, sender: "alice", gasPrice: 20},
+		{tx: types.Tx("b2"), seq: 5, sender: "bob", gasPrice: 5},
+	}
+	// query returns all the pages of txs matching q
+	query := func(q TxQuery) [][]string {
+		var pages [][]string
+		for {
+			selected, cursor, err := queryTxs(append([]*mempoolTx(nil), memTxs...), q)
+			require.NoError(t, err)
+			page := make([]string, 0, len(selected))
+			for _, memTx := range selected {
+				page = append(page, string(memTx.tx))
+			}
+			pages = append(pages, page)
+			if cursor == "" {
+				return pages
+			}
+			q.Cursor = cursor
+		}
+	}
+
+	testCases := []struct {
+		name  string
+		query TxQuery
+		pages [][]string
+	}{
+		{"all", TxQuery{Limit: -1}, [][]string{{"a1", "b1", "a2", "a3", "b2"}}},
+		{"paginated", TxQuery{Limit: 2}, [][]string{{"a1", "b1"}, {"a2", "a3"}, {"b2"}}},
+		{"exact page", TxQuery{Limit: 5}, [][]string{{"a1", "b1", "a2", "a3", "b2"}}},
+		{"by sender", TxQuery{Sender: "alice", Limit: 2}, [][]string{{"a1", "a2"}, {"a3"}}},
+		{"unknown sender", TxQuery{Sender: "carol", Limit: 2}, [][]string{{}}},
+		{
+			"by gas price", TxQuery{OrderBy: TxOrderGasPrice, Limit: 2},
+			[][]string{{"b1", "a2"}, {"a3", "a1"}, {"b2"}},
+		},
+		{
+			"by sender and gas price", TxQuery{Sender: "alice", OrderBy: TxOrderGasPrice, Limit: 1},
+			[][]string{{"a2"}, {"a3"}, {"a1"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.pages, query(tc.query))
+		})
+	}
+
+	// txs removed since the previous page do not affect the next one
+	selected, cursor, err := queryTxs(append([]*mempoolTx(nil), memTxs...), TxQuery{OrderBy: TxOrderGasPrice, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	selected, _, err = queryTxs([]*mempoolTx{memTxs[0], memTxs[3], memTxs[4]}, TxQuery{
+		OrderBy: TxOrderGasPrice,
+		Cursor:  cursor,
+		Limit:   2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*mempoolTx{memTxs[3], memTxs[0]}, selected)
+
+	_, _, err = queryTxs(memTxs, TxQuery{Cursor: "next", Limit: 1})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+	_, _, err = queryTxs(memTxs, TxQuery{OrderBy: "size", Limit: 1})
+	require.Error(t, err)
+}
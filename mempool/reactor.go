@@ -16,6 +16,7 @@ import (
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/types"
 	"golang.org/x/sync/semaphore"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 // Reactor handles mempool tx broadcasting amongst peers.
@@ -41,15 +42,26 @@ type Reactor struct {
 	// connections for different groups of peers.
 	activePersistentPeersSemaphore    *semaphore.Weighted
 	activeNonPersistentPeersSemaphore *semaphore.Weighted
+
+	// Number of peers the initial mempool snapshot was requested from, and
+	// set of peers it was sent to, so that each peer is sent it at most once.
+	syncRequests  atomic.Int32
+	syncServed    map[p2p.ID]struct{}
+	syncServedMtx cmtsync.Mutex
 }
 
+// initialSyncPeers is the maximum number of peers the initial mempool snapshot
+// is requested from.
+const initialSyncPeers = 3
+
 // NewReactor returns a new Reactor with the given config and mempool.
 func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool, waitSync bool) *Reactor {
 	memR := &Reactor{
-		config:    config,
-		mempool:   mempool,
-		waitSync:  atomic.Bool{},
-		txSenders: make(map[types.TxKey]map[p2p.ID]bool),
+		config:     config,
+		mempool:    mempool,
+		waitSync:   atomic.Bool{},
+		txSenders:  make(map[types.TxKey]map[p2p.ID]bool),
+		syncServed: make(map[p2p.ID]struct{}),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
 	if waitSync {
@@ -97,12 +109,23 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvMessageCapacity: batchMsg.Size(),
 			MessageType:         &protomem.Message{},
 		},
+		{
+			ID:                  MempoolSyncChannel,
+			Priority:            1,
+			SendQueueCapacity:   1,
+			RecvMessageCapacity: 64,
+			MessageType:         &protomem.Message{},
+		},
 	}
 }
 
 // AddPeer implements Reactor.
 // It starts a broadcast routine ensuring all txs are forwarded to the given peer.
 func (memR *Reactor) AddPeer(peer p2p.Peer) {
+	if !memR.WaitSync() {
+		memR.requestTxsSnapshot(peer)
+	}
+
 	if memR.config.Broadcast {
 		go func() {
 			// Always forward transactions to unconditional peers.
@@ -118,8 +141,9 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 				if peerSemaphore != nil {
 					for peer.IsRunning() {
 						// Block on the semaphore until a slot is available to start gossiping with this peer.
-						// Do not block indefinitely, in case the peer is disconnected before gossiping starts.
-						ctxTimeout, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+						// Do not block indefinitely, in case the peer is disconnected before gossiping starts,
+						// nor once the reactor is stopped.
+						ctxTimeout, cancel := context.WithTimeout(memR.Context(), 30*time.Second)
 						// Block sending transactions to peer until one of the connections become
 						// available in the semaphore.
 						err := peerSemaphore.Acquire(ctxTimeout, 1)
@@ -143,6 +167,14 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 	}
 }
 
+// RemovePeer implements Reactor.
+func (memR *Reactor) RemovePeer(peer p2p.Peer, _ interface{}) {
+	memR.syncServedMtx.Lock()
+	defer memR.syncServedMtx.Unlock()
+
+	delete(memR.syncServed, peer.ID())
+}
+
 // Receive implements Reactor.
 // It adds any received transactions to the mempool.
 func (memR *Reactor) Receive(e p2p.Envelope) {
@@ -182,6 +214,20 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 				})
 			}
 		}
+	case *protomem.TxsSnapshotRequest:
+		if memR.WaitSync() {
+			memR.Logger.Debug("Ignored message received while syncing", "msg", msg)
+			return
+		}
+		if !memR.config.Broadcast {
+			// We do not relay transactions.
+			return
+		}
+		if !memR.setSyncServed(e.Src.ID()) {
+			memR.Logger.Debug("Ignored repeated mempool snapshot request", "src", e.Src)
+			return
+		}
+		go memR.sendTxsSnapshot(e.Src, int(msg.MaxTxs))
 	default:
 		memR.Logger.Error("unknown message type", "src", e.Src, "chId", e.ChannelID, "msg", e.Message)
 		memR.Switch.StopPeerForError(e.Src, fmt.Errorf("mempool cannot handle message of type: %T", e.Message))
@@ -191,6 +237,102 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 	// broadcasting happens from go routines per peer
 }
 
+// ReceiveBuffer implements p2p.BufferedReactor. The transactions of a Txs
+// message already in the cache of the mempool, i.e. most of them once they are
+// gossiped by several peers, or failing its pre-check, e.g. larger than the
+// mempool.max_tx_bytes consensus param, are dropped without being copied out
+// of the buffer. The other transactions, and the other messages, are handed
+// over to Receive.
+func (memR *Reactor) ReceiveBuffer(e p2p.BufferEnvelope) {
+	txs, ok, err := txsFromBytes(e.Buffer.Bytes())
+	if err != nil {
+		e.Buffer.Release()
+		memR.Logger.Error("Error decoding message", "src", e.Src, "chId", e.ChannelID, "err", err)
+		memR.Switch.StopPeerForError(e.Src, err)
+		return
+	}
+	if !ok {
+		msg, err := e.Unmarshal(&protomem.Message{})
+		if err != nil {
+			memR.Logger.Error("Error decoding message", "src", e.Src, "chId", e.ChannelID, "err", err)
+			memR.Switch.StopPeerForError(e.Src, err)
+			return
+		}
+		memR.Receive(p2p.Envelope{Src: e.Src, Message: msg, ChannelID: e.ChannelID})
+		return
+	}
+	defer e.Buffer.Release()
+	if memR.WaitSync() {
+		memR.Logger.Debug("Ignored message received while syncing", "src", e.Src)
+		return
+	}
+	if len(txs) == 0 {
+		memR.Logger.Error("received empty txs from peer", "src", e.Src)
+		return
+	}
+
+	var newTxs [][]byte
+	for _, tx := range txs {
+		if memR.mempool.cache.Has(tx) {
+			memR.mempool.metrics.AlreadyReceivedTxs.Add(1)
+			continue
+		}
+		if err := memR.mempool.runPreCheck(tx); err != nil {
+			memR.Logger.Debug("Dropped tx failing the pre-check", "tx", tx.Hash(), "src", e.Src, "err", err)
+			continue
+		}
+		newTxs = append(newTxs, append([]byte(nil), tx...))
+	}
+	if len(newTxs) > 0 {
+		memR.Receive(p2p.Envelope{Src: e.Src, Message: &protomem.Txs{Txs: newTxs}, ChannelID: e.ChannelID})
+	}
+}
+
+// txsFromBytes returns the transactions of a Message of type Txs, given its
+// bytes, without copying them. ok is false if the message is of another type.
+func txsFromBytes(bz []byte) (txs []types.Tx, ok bool, err error) {
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return nil, false, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+		if num != 1 || typ != protowire.BytesType {
+			// another type of message, left to the unmarshaling
+			return nil, false, nil
+		}
+		msg, n := protowire.ConsumeBytes(bz)
+		if n < 0 {
+			return nil, false, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+		// the last Txs field wins, as when unmarshaling
+		txs, ok = txs[:0], true
+		for len(msg) > 0 {
+			num, typ, n := protowire.ConsumeTag(msg)
+			if n < 0 {
+				return nil, false, protowire.ParseError(n)
+			}
+			msg = msg[n:]
+			if num == 1 && typ == protowire.BytesType {
+				tx, n := protowire.ConsumeBytes(msg)
+				if n < 0 {
+					return nil, false, protowire.ParseError(n)
+				}
+				msg = msg[n:]
+				txs = append(txs, tx)
+				continue
+			}
+			n = protowire.ConsumeFieldValue(num, typ, msg)
+			if n < 0 {
+				return nil, false, protowire.ParseError(n)
+			}
+			msg = msg[n:]
+		}
+	}
+	return txs, ok, nil
+}
+
 func (memR *Reactor) EnableInOutTxs() {
 	memR.Logger.Info("enabling inbound and outbound transactions")
 	if !memR.waitSync.CompareAndSwap(true, false) {
@@ -201,6 +343,12 @@ func (memR *Reactor) EnableInOutTxs() {
 	if memR.config.Broadcast {
 		close(memR.waitSyncCh)
 	}
+
+	if memR.config.InitialSync {
+		for _, peer := range memR.Switch.Peers().List() {
+			memR.requestTxsSnapshot(peer)
+		}
+	}
 }
 
 func (memR *Reactor) WaitSync() bool {
@@ -275,7 +423,9 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		// NOTE: Transaction batching was disabled due to
 		// https://github.com/tendermint/tendermint/issues/5796
 
-		if !memR.isSender(memTx.tx.Key(), peer.ID()) {
+		// The transactions failing the pre-check, e.g. since the consensus
+		// params lowered mempool.max_tx_bytes, are not gossiped.
+		if !memR.isSender(memTx.tx.Key(), peer.ID()) && memR.mempool.runPreCheck(memTx.tx) == nil {
 			success := peer.Send(p2p.Envelope{
 				ChannelID: MempoolChannel,
 				Message:   &protomem.Txs{Txs: [][]byte{memTx.tx}},
@@ -298,6 +448,71 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	}
 }
 
+// requestTxsSnapshot requests the transactions in the mempool of the peer, if
+// the initial sync is enabled, our mempool is still empty and the peer
+// supports it.
+func (memR *Reactor) requestTxsSnapshot(peer p2p.Peer) {
+	if !memR.config.InitialSync || memR.config.InitialSyncMaxTxs == 0 || memR.mempool.Size() > 0 {
+		return
+	}
+	ni, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	if !ok || !ni.HasChannel(MempoolSyncChannel) {
+		return
+	}
+	if memR.syncRequests.Add(1) > initialSyncPeers {
+		memR.syncRequests.Add(-1)
+		return
+	}
+
+	memR.Logger.Info("Requesting mempool snapshot", "peer", peer.ID())
+	if !peer.Send(p2p.Envelope{
+		ChannelID: MempoolSyncChannel,
+		Message:   &protomem.TxsSnapshotRequest{MaxTxs: int32(memR.config.InitialSyncMaxTxs)},
+	}) {
+		memR.syncRequests.Add(-1)
+	}
+}
+
+// sendTxsSnapshot sends the peer up to maxTxs transactions of the mempool, in
+// the order they were added to it, bounded by the InitialSyncMaxTxs config.
+func (memR *Reactor) sendTxsSnapshot(peer p2p.Peer, maxTxs int) {
+	if maxTxs <= 0 || maxTxs > memR.config.InitialSyncMaxTxs {
+		maxTxs = memR.config.InitialSyncMaxTxs
+	}
+
+	sent := 0
+	for next := memR.mempool.TxsFront(); next != nil && sent < maxTxs; next = next.Next() {
+		if !memR.IsRunning() || !peer.IsRunning() {
+			return
+		}
+		memTx := next.Value.(*mempoolTx)
+		if memR.isSender(memTx.tx.Key(), peer.ID()) {
+			continue
+		}
+		if !peer.Send(p2p.Envelope{
+			ChannelID: MempoolChannel,
+			Message:   &protomem.Txs{Txs: [][]byte{memTx.tx}},
+		}) {
+			break
+		}
+		sent++
+	}
+	memR.Logger.Debug("Sent mempool snapshot", "peer", peer.ID(), "txs", sent)
+}
+
+// setSyncServed records that the mempool snapshot is sent to the peer.
+// Returns false if it was already sent.
+func (memR *Reactor) setSyncServed(peerID p2p.ID) bool {
+	memR.syncServedMtx.Lock()
+	defer memR.syncServedMtx.Unlock()
+
+	if _, ok := memR.syncServed[peerID]; ok {
+		return false
+	}
+	memR.syncServed[peerID] = struct{}{}
+	return true
+}
+
 func (memR *Reactor) isSender(txKey types.TxKey, peerID p2p.ID) bool {
 	memR.txSendersMtx.Lock()
 	defer memR.txSendersMtx.Unlock()
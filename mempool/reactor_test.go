@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cosmos/gogoproto/proto"
 	"github.com/fortytw2/leaktest"
 	"github.com/go-kit/log/term"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,8 @@ import (
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/conn"
+	"github.com/cometbft/cometbft/p2p/mock"
 	"github.com/cometbft/cometbft/proxy"
 	"github.com/cometbft/cometbft/types"
 )
@@ -63,6 +66,45 @@ func TestReactorBroadcastTxsMessage(t *testing.T) {
 	waitForReactors(t, txs, reactors, checkTxsInOrder)
 }
 
+// Check that a peer connecting with an empty mempool is sent the transactions
+// in the mempool of the first reactor, up to InitialSyncMaxTxs.
+func TestReactorInitialSync(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.InitialSync = true
+	config.Mempool.InitialSyncMaxTxs = 10
+
+	const N = 2
+	reactors := make([]*Reactor, N)
+	logger := mempoolLogger()
+	for i := 0; i < N; i++ {
+		app := kvstore.NewInMemoryApplication()
+		cc := proxy.NewLocalClientCreator(app)
+		mempool, cleanup := newMempoolWithApp(cc)
+		defer cleanup()
+
+		reactors[i] = NewReactor(config.Mempool, mempool, false)
+		reactors[i].SetLogger(logger.With("validator", i))
+	}
+	txs := checkTxs(t, reactors[0].mempool, 2*config.Mempool.InitialSyncMaxTxs)
+
+	// The peers have no state, so the transactions are not broadcast.
+	p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("MEMPOOL", reactors[i])
+		return s
+	}, p2p.Connect2Switches)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+
+	waitForReactors(t, txs[:config.Mempool.InitialSyncMaxTxs], reactors[1:], checkTxsInOrder)
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, config.Mempool.InitialSyncMaxTxs, reactors[1].mempool.Size())
+}
+
 // regression test for https://github.com/tendermint/tendermint/issues/5408
 func TestReactorConcurrency(t *testing.T) {
 	config := cfg.TestConfig()
@@ -192,6 +234,86 @@ func TestReactor_MaxTxBytes(t *testing.T) {
 	require.Nil(t, reqRes)
 }
 
+func TestReactorReceiveBuffer(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	defer cleanup()
+	reactor := NewReactor(cfg.TestConfig().Mempool, mp, false)
+	reactor.SetLogger(log.TestingLogger())
+	peer := mock.NewPeer(nil)
+
+	receive := func(msg proto.Message) {
+		bz, err := proto.Marshal(msg)
+		require.NoError(t, err)
+		reactor.ReceiveBuffer(p2p.BufferEnvelope{Src: peer, Buffer: conn.NewBuffer(bz), ChannelID: MempoolChannel})
+	}
+
+	txs := newUniqueTxs(3)
+	receive((&memproto.Txs{Txs: [][]byte{txs[0], txs[1]}}).Wrap())
+	require.Equal(t, 2, mp.Size())
+
+	// the cached tx is dropped, and the new one is checked
+	receive((&memproto.Txs{Txs: [][]byte{txs[1], txs[2]}}).Wrap())
+	require.Equal(t, 3, mp.Size())
+	for _, tx := range txs {
+		assert.True(t, reactor.isSender(tx.Key(), peer.ID()))
+	}
+
+	// the txs failing the pre-check, e.g. larger than the consensus params
+	// allow, are dropped
+	mp.preCheck = PreCheckMaxBytes(10)
+	receive((&memproto.Txs{Txs: [][]byte{kvstore.NewRandomTx(20)}}).Wrap())
+	require.Equal(t, 3, mp.Size())
+}
+
+func TestReactorNoBroadcastFailingPreCheck(t *testing.T) {
+	config := cfg.TestConfig()
+	reactors, _ := makeAndConnectReactors(config, 2)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+
+	// the tx is added before the limit is lowered, e.g. by the consensus
+	// params, and the peers get a state only afterwards, for the tx not to
+	// be gossiped before
+	tx := kvstore.NewRandomTx(20)
+	_, err := reactors[0].mempool.CheckTx(tx)
+	require.NoError(t, err)
+	reactors[0].mempool.updateMtx.Lock()
+	reactors[0].mempool.preCheck = PreCheckMaxBytes(10)
+	reactors[0].mempool.updateMtx.Unlock()
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1})
+		}
+	}
+
+	ensureNoTxs(t, reactors[1], 500*time.Millisecond)
+}
+
+func TestTxsFromBytes(t *testing.T) {
+	txs := newUniqueTxs(3)
+	txsBytes, err := proto.Marshal((&memproto.Txs{Txs: [][]byte{txs[0], txs[1], txs[2]}}).Wrap())
+	require.NoError(t, err)
+	got, ok, err := txsFromBytes(txsBytes)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []types.Tx(txs), got)
+
+	_, _, err = txsFromBytes(txsBytes[:len(txsBytes)-1])
+	require.Error(t, err)
+
+	bz, err := proto.Marshal((&memproto.TxsSnapshotRequest{MaxTxs: 1}).Wrap())
+	require.NoError(t, err)
+	_, ok, err = txsFromBytes(bz)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
 func TestBroadcastTxForPeerStopsWhenPeerStops(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
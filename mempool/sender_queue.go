@@ -0,0 +1,114 @@
+package mempool
+
+import (
+	"sort"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+// senderQueue holds the valid transactions whose sequence is ahead of the
+// next sequence of their sender, until the transactions before them are
+// added to the mempool.
+type senderQueue struct {
+	mtx     cmtsync.Mutex
+	maxTxs  int // per sender
+	senders map[string]map[uint64]types.Tx
+	size    int
+	bytes   int64
+}
+
+func newSenderQueue(maxTxs int) *senderQueue {
+	return &senderQueue{
+		maxTxs:  maxTxs,
+		senders: make(map[string]map[uint64]types.Tx),
+	}
+}
+
+// hold adds tx, with sequence seq, to the transactions held for sender.
+func (q *senderQueue) hold(sender string, seq uint64, tx types.Tx) error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	txs := q.senders[sender]
+	if held, ok := txs[seq]; ok {
+		if held.Key() == tx.Key() {
+			return nil
+		}
+		return ErrSequenceHeld{Sender: sender, Sequence: seq}
+	}
+	if len(txs) >= q.maxTxs {
+		return ErrSenderQueueFull{Sender: sender, Max: q.maxTxs}
+	}
+	if txs == nil {
+		txs = make(map[uint64]types.Tx)
+		q.senders[sender] = txs
+	}
+	txs[seq] = tx
+	q.size++
+	q.bytes += int64(len(tx))
+	return nil
+}
+
+// release removes the transaction with sequence seq held for sender, and
+// returns it, if any.
+func (q *senderQueue) release(sender string, seq uint64) (types.Tx, bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	tx, ok := q.senders[sender][seq]
+	if !ok {
+		return nil, false
+	}
+	delete(q.senders[sender], seq)
+	if len(q.senders[sender]) == 0 {
+		delete(q.senders, sender)
+	}
+	q.size--
+	q.bytes -= int64(len(tx))
+	return tx, true
+}
+
+// drain removes all the held transactions and returns them, ordered by sender
+// then by sequence.
+func (q *senderQueue) drain() types.Txs {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	senders := make([]string, 0, len(q.senders))
+	for sender := range q.senders {
+		senders = append(senders, sender)
+	}
+	sort.Strings(senders)
+
+	txs := make(types.Txs, 0, q.size)
+	for _, sender := range senders {
+		held := q.senders[sender]
+		seqs := make([]uint64, 0, len(held))
+		for seq := range held {
+			seqs = append(seqs, seq)
+		}
+		sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+		for _, seq := range seqs {
+			txs = append(txs, held[seq])
+		}
+	}
+	q.senders = make(map[string]map[uint64]types.Tx)
+	q.size = 0
+	q.bytes = 0
+	return txs
+}
+
+// len returns the number of held transactions.
+func (q *senderQueue) len() int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.size
+}
+
+// sizeBytes returns the total size in bytes of the held transactions.
+func (q *senderQueue) sizeBytes() int64 {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.bytes
+}
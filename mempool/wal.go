@@ -0,0 +1,249 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	walFile = "wal"
+
+	// A record is made of its type, the CRC32 checksum of its type and data,
+	// the length of its data, and its data.
+	walRecordHeaderSize = 1 + 4 + 4
+
+	// walCompactMinRecords is the number of obsolete records the WAL tolerates
+	// before it gets compacted.
+	walCompactMinRecords = 1000
+)
+
+const (
+	walRecordAddTx    = byte(0x01) // data is the transaction
+	walRecordRemoveTx = byte(0x02) // data is the key of the transaction
+)
+
+var walCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// wal is the write-ahead log of the mempool. It records the transactions added
+// to and removed from the mempool, so that the transactions that were not
+// committed can be checked again after a restart.
+//
+// Records are written to the OS as soon as they are logged, so they survive a
+// crash of the process. They are synced to disk on every block.
+type wal struct {
+	mtx  sync.Mutex
+	path string
+	file *os.File
+
+	records int // number of records in the file
+	live    int // number of transactions added and not removed
+}
+
+// openWAL opens the WAL in dir, creating it if needed, and returns the
+// transactions it holds, in the order they were added. A truncated or
+// corrupted record, e.g. after a crash in the middle of a write, ends the log:
+// it and the records after it are discarded.
+func openWAL(dir string) (*wal, []types.Tx, error) {
+	if err := cmtos.EnsureDir(dir, 0o700); err != nil {
+		return nil, nil, err
+	}
+	path := filepath.Join(dir, walFile)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	txs, records, size, err := readWAL(file, info.Size())
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to read mempool WAL %s: %w", path, err)
+	}
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if _, err := file.Seek(size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return &wal{path: path, file: file, records: records, live: len(txs)}, txs, nil
+}
+
+// readWAL reads the records of file, of size fileSize. It returns the
+// transactions added and not removed, the number of valid records, and the
+// size they take.
+func readWAL(file *os.File, fileSize int64) (txs []types.Tx, records int, size int64, err error) {
+	var (
+		r      = bufio.NewReader(file)
+		header = make([]byte, walRecordHeaderSize)
+		added  = make(map[types.TxKey]types.Tx)
+		order  []types.TxKey
+	)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return nil, 0, 0, err
+		}
+		recordType := header[0]
+		checksum := binary.BigEndian.Uint32(header[1:5])
+		length := binary.BigEndian.Uint32(header[5:9])
+		if int64(length) > fileSize-size-walRecordHeaderSize {
+			break // corrupted length
+		}
+		data := make([]byte, length)
+		if _, err := io.ReadFull(r, data); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return nil, 0, 0, err
+		}
+		if walChecksum(recordType, data) != checksum {
+			break
+		}
+
+		switch recordType {
+		case walRecordAddTx:
+			key := types.Tx(data).Key()
+			if _, ok := added[key]; !ok {
+				order = append(order, key)
+			}
+			added[key] = data
+		case walRecordRemoveTx:
+			if len(data) != len(types.TxKey{}) {
+				return nil, 0, 0, fmt.Errorf("invalid transaction key length %d", len(data))
+			}
+			delete(added, types.TxKey(data))
+		default:
+			return nil, 0, 0, fmt.Errorf("unknown record type %#x", recordType)
+		}
+		records++
+		size += int64(walRecordHeaderSize + len(data))
+	}
+
+	for _, key := range order {
+		if tx, ok := added[key]; ok {
+			txs = append(txs, tx)
+			delete(added, key) // the transaction may have been added again
+		}
+	}
+	return txs, records, size, nil
+}
+
+func walChecksum(recordType byte, data []byte) uint32 {
+	checksum := crc32.Update(0, walCRCTable, []byte{recordType})
+	return crc32.Update(checksum, walCRCTable, data)
+}
+
+func encodeWALRecord(recordType byte, data []byte) []byte {
+	record := make([]byte, walRecordHeaderSize+len(data))
+	record[0] = recordType
+	binary.BigEndian.PutUint32(record[1:5], walChecksum(recordType, data))
+	binary.BigEndian.PutUint32(record[5:9], uint32(len(data)))
+	copy(record[walRecordHeaderSize:], data)
+	return record
+}
+
+// AddTx logs that tx was added to the mempool.
+func (w *wal) AddTx(tx types.Tx) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if _, err := w.file.Write(encodeWALRecord(walRecordAddTx, tx)); err != nil {
+		return err
+	}
+	w.records++
+	w.live++
+	return nil
+}
+
+// RemoveTx logs that the transaction with key txKey was removed from the
+// mempool.
+func (w *wal) RemoveTx(txKey types.TxKey) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if _, err := w.file.Write(encodeWALRecord(walRecordRemoveTx, txKey[:])); err != nil {
+		return err
+	}
+	w.records++
+	if w.live > 0 {
+		w.live--
+	}
+	return nil
+}
+
+// Sync commits the records to disk.
+func (w *wal) Sync() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.file.Sync()
+}
+
+// NeedsCompaction returns true if most records of the WAL are obsolete.
+func (w *wal) NeedsCompaction() bool {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.records-w.live > max(w.live, walCompactMinRecords)
+}
+
+// Compact replaces the records of the WAL with a record per transaction
+// returned by txs, which is called with the WAL locked, so that no record is
+// logged in the meantime.
+func (w *wal) Compact(txs func() types.Txs) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	snapshot := txs()
+	tmpPath := w.path + ".compact"
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(tmp)
+	for _, tx := range snapshot {
+		if _, err := bw.Write(encodeWALRecord(walRecordAddTx, tx)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	w.file.Close()
+	w.file = tmp
+	w.records = len(snapshot)
+	w.live = len(snapshot)
+	return nil
+}
+
+// Close closes the WAL, after syncing it to disk.
+func (w *wal) Close() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return errors.Join(w.file.Sync(), w.file.Close())
+}
@@ -0,0 +1,18 @@
+//go:build byzantine
+
+package node
+
+import (
+	cs "github.com/cometbft/cometbft/internal/consensus"
+)
+
+// ConsensusByzantine makes the node's validator misbehave as told by b, so
+// that evidence handling and fork detection can be tested against real
+// misbehavior.
+// It is only built with the byzantine tag.
+// WARNING: only use this for testing; misbehaving validators get slashed.
+func ConsensusByzantine(b *cs.Byzantine) Option {
+	return func(n *Node) {
+		cs.ReactorByzantine(b)(n.consensusReactor)
+	}
+}
@@ -35,5 +35,30 @@ To replace the built-in p2p.Reactor, use the CustomReactors option:
 	)
 
 The list of existing reactors can be found in CustomReactors documentation.
+
+Registering third-party p2p.Reactor(s)
+
+To add the reactor of a custom gossip protocol, without risking to replace
+one of the node, use the RegisterReactor option. Its channels must be from
+p2p.MinCustomChannelID on, with the message type of each, and the reactor can
+be made of its channels and peer lifecycle hooks:
+
+	reactor := p2p.NewHookReactor("CUSTOM", []*conn.ChannelDescriptor{
+		{ID: 0x80, Priority: 1, MessageType: &customproto.Message{}},
+	}, p2p.ReactorHooks{
+		AddPeer: func(peer p2p.Peer) { ... },
+		Receive: func(e p2p.Envelope) { ... },
+	})
+	node, err := NewNode(
+			config,
+			privVal,
+			nodeKey,
+			clientCreator,
+			genesisDocProvider,
+			dbProvider,
+			metricsProvider,
+			logger,
+			RegisterReactor("CUSTOM", reactor),
+	)
 */
 package node
@@ -0,0 +1,172 @@
+package node
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+)
+
+// maxGenesisManifestSize is the maximum size of the manifest of a genesis
+// chunk set.
+const maxGenesisManifestSize = 1 << 20 // 1MB
+
+// genesisManifest lists the chunks of a genesis file split into a
+// content-addressed chunk set: each chunk is served next to the manifest,
+// under the hex-encoded SHA256 checksum of its content.
+type genesisManifest struct {
+	Chunks []string `json:"chunks"`
+}
+
+// genesisDownloader downloads genesis files, failing each request that takes
+// longer than the timeout of its client and each genesis file larger than
+// maxSize bytes.
+type genesisDownloader struct {
+	client  *http.Client
+	maxSize int64
+}
+
+func newGenesisDownloader(timeout time.Duration, maxSize int64) *genesisDownloader {
+	return &genesisDownloader{
+		client:  &http.Client{Timeout: timeout},
+		maxSize: maxSize,
+	}
+}
+
+// downloadGenesis downloads the genesis file from genesisURL to dst, checking
+// that its SHA256 checksum is checksum. genesisURL serves either the genesis
+// file itself, or the manifest of a genesis chunk set. dst is only written
+// once the whole file is verified.
+func (d *genesisDownloader) downloadGenesis(genesisURL, dst string, checksum []byte) error {
+	tmp := dst + ".download"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer f.Close()
+
+	// genesisURL may serve a manifest larger than a small genesis file.
+	maxSize := d.maxSize
+	if maxSize < maxGenesisManifestSize {
+		maxSize = maxGenesisManifestSize
+	}
+	hasher := tmhash.New()
+	n, err := d.download(genesisURL, io.MultiWriter(f, hasher), maxSize)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(hasher.Sum(nil), checksum) {
+		if n > d.maxSize {
+			return fmt.Errorf("genesis file larger than the maximum of %d bytes", d.maxSize)
+		}
+	} else {
+		// A manifest cannot match the checksum of the genesis file.
+		manifest, err := readGenesisManifest(tmp)
+		if err != nil {
+			return fmt.Errorf("genesis file checksum mismatch: expected %X, got %X", checksum, hasher.Sum(nil))
+		}
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		hasher.Reset()
+		if err := d.downloadGenesisChunks(genesisURL, manifest, io.MultiWriter(f, hasher)); err != nil {
+			return err
+		}
+		if !bytes.Equal(hasher.Sum(nil), checksum) {
+			return fmt.Errorf("genesis file checksum mismatch: expected %X, got %X", checksum, hasher.Sum(nil))
+		}
+	}
+
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// readGenesisManifest reads the manifest of a genesis chunk set from path.
+func readGenesisManifest(path string) (*genesisManifest, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() > maxGenesisManifestSize {
+		return nil, errors.New("not a genesis manifest: too large")
+	}
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.DisallowUnknownFields()
+	manifest := &genesisManifest{}
+	if err := dec.Decode(manifest); err != nil {
+		return nil, err
+	}
+	if len(manifest.Chunks) == 0 {
+		return nil, errors.New("genesis manifest without chunks")
+	}
+	return manifest, nil
+}
+
+// downloadGenesisChunks downloads the chunks listed in the manifest served at
+// manifestURL to w, in order, checking the checksum of each chunk.
+func (d *genesisDownloader) downloadGenesisChunks(manifestURL string, manifest *genesisManifest, w io.Writer) error {
+	base, err := url.Parse(manifestURL)
+	if err != nil {
+		return err
+	}
+	remaining := d.maxSize
+	for i, chunk := range manifest.Chunks {
+		chunkChecksum, err := hex.DecodeString(chunk)
+		if err != nil || len(chunkChecksum) != tmhash.Size {
+			return fmt.Errorf("invalid checksum of genesis chunk #%d: %q", i, chunk)
+		}
+		chunkURL := base.ResolveReference(&url.URL{Path: chunk})
+		hasher := tmhash.New()
+		n, err := d.download(chunkURL.String(), io.MultiWriter(w, hasher), remaining)
+		if err != nil {
+			return fmt.Errorf("genesis chunk #%d: %w", i, err)
+		}
+		remaining -= n
+		if !bytes.Equal(hasher.Sum(nil), chunkChecksum) {
+			return fmt.Errorf("genesis chunk #%d checksum mismatch: expected %X, got %X", i, chunkChecksum, hasher.Sum(nil))
+		}
+	}
+	return nil
+}
+
+// download writes the content served at rawURL to w, failing if it is larger
+// than maxSize bytes. It returns the number of bytes written.
+func (d *genesisDownloader) download(rawURL string, w io.Writer, maxSize int64) (int64, error) {
+	resp, err := d.client.Get(rawURL) //nolint: gosec // the content is verified against a checksum
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+	n, err := io.Copy(w, io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return n, err
+	}
+	if n > maxSize {
+		return n, fmt.Errorf("genesis file larger than the maximum of %d bytes", d.maxSize)
+	}
+	return n, nil
+}
@@ -0,0 +1,74 @@
+package node
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+)
+
+func TestDownloadGenesis(t *testing.T) {
+	genesis := []byte(`{"chain_id": "test-chain", "app_state": {"accounts": ["a", "b", "c"]}}`)
+	checksum := tmhash.Sum(genesis)
+
+	// The genesis file is also split into a chunk set.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/genesis.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(genesis)
+	})
+	manifest := genesisManifest{}
+	for _, chunk := range [][]byte{genesis[:20], genesis[20:50], genesis[50:]} {
+		chunk := chunk
+		name := hex.EncodeToString(tmhash.Sum(chunk))
+		manifest.Chunks = append(manifest.Chunks, name)
+		mux.HandleFunc("/chunks/"+name, func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(chunk)
+		})
+	}
+	mux.HandleFunc("/chunks/manifest.json", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(manifest)
+	})
+	mux.HandleFunc("/stalled.json", func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	d := newGenesisDownloader(time.Second, int64(len(genesis)))
+	for _, path := range []string{"/genesis.json", "/chunks/manifest.json"} {
+		dst := filepath.Join(t.TempDir(), "genesis.json")
+		require.NoError(t, d.downloadGenesis(server.URL+path, dst, checksum), path)
+		bz, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		assert.Equal(t, genesis, bz, path)
+	}
+
+	// Nothing is written if the checksum does not match.
+	dst := filepath.Join(t.TempDir(), "genesis.json")
+	require.Error(t, d.downloadGenesis(server.URL+"/genesis.json", dst, tmhash.Sum([]byte("other"))))
+	assert.NoFileExists(t, dst)
+	require.Error(t, d.downloadGenesis(server.URL+"/missing.json", dst, checksum))
+	assert.NoFileExists(t, dst)
+
+	// Nor if the genesis file, or the sum of its chunks, is too large.
+	d = newGenesisDownloader(time.Second, int64(len(genesis))-1)
+	for _, path := range []string{"/genesis.json", "/chunks/manifest.json"} {
+		err := d.downloadGenesis(server.URL+path, dst, checksum)
+		require.ErrorContains(t, err, "larger than the maximum", path)
+		assert.NoFileExists(t, dst)
+	}
+
+	// Nor if the download stalls.
+	d = newGenesisDownloader(100*time.Millisecond, int64(len(genesis)))
+	require.Error(t, d.downloadGenesis(server.URL+"/stalled.json", dst, checksum))
+	assert.NoFileExists(t, dst)
+}
@@ -14,15 +14,22 @@ import (
 	cfg "github.com/cometbft/cometbft/config"
 	bc "github.com/cometbft/cometbft/internal/blocksync"
 	cs "github.com/cometbft/cometbft/internal/consensus"
+	"github.com/cometbft/cometbft/internal/eventlog"
 	"github.com/cometbft/cometbft/internal/evidence"
+	"github.com/cometbft/cometbft/internal/memtune"
+	cmtnet "github.com/cometbft/cometbft/internal/net"
 	cmtpubsub "github.com/cometbft/cometbft/internal/pubsub"
 	"github.com/cometbft/cometbft/internal/service"
+	"github.com/cometbft/cometbft/internal/signinginfo"
+	"github.com/cometbft/cometbft/internal/sigverify"
 	sm "github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/state/indexer"
 	"github.com/cometbft/cometbft/internal/state/txindex"
 	"github.com/cometbft/cometbft/internal/state/txindex/null"
 	"github.com/cometbft/cometbft/internal/statesync"
+	"github.com/cometbft/cometbft/internal/storageinfo"
 	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/internal/upgrade"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/light"
 	mempl "github.com/cometbft/cometbft/mempool"
@@ -33,12 +40,14 @@ import (
 	grpcserver "github.com/cometbft/cometbft/rpc/grpc/server"
 	grpcprivserver "github.com/cometbft/cometbft/rpc/grpc/server/privileged"
 	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	"github.com/cometbft/cometbft/rpc/rest"
 	"github.com/cometbft/cometbft/types"
 	cmttime "github.com/cometbft/cometbft/types/time"
 	"github.com/cometbft/cometbft/version"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
+	"golang.org/x/net/netutil"
 )
 
 // Node is the highest level interface to a full CometBFT node.
@@ -58,6 +67,7 @@ type Node struct {
 	nodeInfo    p2p.NodeInfo
 	nodeKey     *p2p.NodeKey // our node privkey
 	isListening bool
+	portMux     *cmtnet.Mux // shares the P2P port with RPC and gRPC, if enabled
 
 	// services
 	eventBus          *types.EventBus // pub/sub for services
@@ -77,11 +87,20 @@ type Node struct {
 	evidencePool      *evidence.Pool          // tracking evidence
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
+	rpcDrainer        *rpcserver.Drainer      // in-flight rpc requests
 	txIndexer         txindex.TxIndexer
 	blockIndexer      indexer.BlockIndexer
 	indexerService    *txindex.IndexerService
+	eventLog          *eventlog.Log
 	prometheusSrv     *http.Server
 	pprofSrv          *http.Server
+	upgrades          *upgrade.Manager     // halts the node for an upgrade
+	signingInfo       *signinginfo.Tracker // nil if disabled
+	sigVerifyPool     *sigverify.Pool      // shared by consensus and block sync
+	memTuner          *memtune.Tuner       // applies the memory settings
+	storageSampler    *storageinfo.Sampler // nil if disabled
+
+	optionErr error // first error of the options, returned by NewNode
 }
 
 type waitSyncP2PReactor interface {
@@ -114,23 +133,50 @@ func CustomReactors(reactors map[string]p2p.Reactor) Option {
 				n.sw.RemoveReactor(name, existingReactor)
 			}
 			n.sw.AddReactor(name, reactor)
-			// register the new channels to the nodeInfo
-			// NOTE: This is a bit messy now with the type casting but is
-			// cleaned up in the following version when NodeInfo is changed from
-			// and interface to a concrete type
-			if ni, ok := n.nodeInfo.(p2p.DefaultNodeInfo); ok {
-				for _, chDesc := range reactor.GetChannels() {
-					if !ni.HasChannel(chDesc.ID) {
-						ni.Channels = append(ni.Channels, chDesc.ID)
-						n.transport.AddChannel(chDesc.ID)
-					}
-				}
-				n.nodeInfo = ni
-			} else {
-				n.Logger.Error("Node info is not of type DefaultNodeInfo. Custom reactor channels can not be added.")
-			}
+			n.addReactorChannels(reactor)
+		}
+	}
+}
+
+// RegisterReactor adds a reactor of another project, e.g. for a custom gossip
+// protocol, named name, to the node's Switch. The reactor can be made of its
+// channels and peer lifecycle hooks with p2p.NewHookReactor.
+//
+// Unlike CustomReactors, it does not replace the reactors of the node: NewNode
+// returns an error if another reactor has the same name, or if a channel of
+// the reactor is used by another reactor, or is lower than
+// p2p.MinCustomChannelID, the lower ones being reserved for the reactors of
+// CometBFT.
+func RegisterReactor(name string, reactor p2p.Reactor) Option {
+	return func(n *Node) {
+		if n.optionErr != nil {
+			return
+		}
+		if err := n.sw.AddCustomReactor(name, reactor); err != nil {
+			n.optionErr = fmt.Errorf("failed to register reactor %s: %w", name, err)
+			return
+		}
+		n.addReactorChannels(reactor)
+	}
+}
+
+// addReactorChannels registers the channels of reactor to the nodeInfo.
+func (n *Node) addReactorChannels(reactor p2p.Reactor) {
+	// NOTE: This is a bit messy now with the type casting but is
+	// cleaned up in the following version when NodeInfo is changed from
+	// and interface to a concrete type
+	ni, ok := n.nodeInfo.(p2p.DefaultNodeInfo)
+	if !ok {
+		n.Logger.Error("Node info is not of type DefaultNodeInfo. Custom reactor channels can not be added.")
+		return
+	}
+	for _, chDesc := range reactor.GetChannels() {
+		if !ni.HasChannel(chDesc.ID) {
+			ni.Channels = append(ni.Channels, chDesc.ID)
+			n.transport.AddChannel(chDesc.ID)
 		}
 	}
+	n.nodeInfo = ni
 }
 
 // StateProvider overrides the state provider used by state sync to retrieve trusted app hashes and
@@ -142,6 +188,19 @@ func StateProvider(stateProvider statesync.StateProvider) Option {
 	}
 }
 
+// P2PMemoryNetwork makes the node listen and dial peers on the given in-memory
+// network instead of TCP, so that several nodes can be run in a single
+// process. The P2P addresses of the configuration are only used as
+// identifiers on the network, whose links can delay and throttle traffic
+// between nodes. Only the sockets are simulated: the peer connections are
+// still authenticated, encrypted and multiplexed as over TCP.
+// WARNING: only use this for testing.
+func P2PMemoryNetwork(network *p2p.MemoryNetwork) Option {
+	return func(n *Node) {
+		p2p.MultiplexTransportMemoryNetwork(network)(n.transport)
+	}
+}
+
 // BootstrapState synchronizes the stores with the application after state sync
 // has been performed offline. It is expected that the block store and state
 // store are empty at the time the function is called.
@@ -272,6 +331,20 @@ func NewNode(ctx context.Context,
 	logger log.Logger,
 	options ...Option,
 ) (*Node, error) {
+	switch config.Mode {
+	case cfg.ModeSeed:
+		return newSeedNode(config, nodeKey, genesisDocProvider, metricsProvider, logger, options...)
+	case cfg.ModeSentry:
+		config.ApplySentryProfile()
+	}
+
+	// Sample the databases opened from now on.
+	var storageSampler *storageinfo.Sampler
+	if config.StorageSampleInterval > 0 {
+		storageSampler = storageinfo.NewSampler(config.DBDir(), config.StorageSampleInterval)
+		dbProvider = storageSampler.Provider(dbProvider)
+	}
+
 	blockStore, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
@@ -279,6 +352,7 @@ func NewNode(ctx context.Context,
 
 	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
 		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+		SparseRetention:      config.Storage.Pruning.SparseRetention(),
 	})
 
 	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genesisDocProvider, config.Storage.GenesisHash)
@@ -286,6 +360,25 @@ func NewNode(ctx context.Context,
 		return nil, err
 	}
 
+	if config.VerifyOnStart {
+		// The state of a remote signer is out of reach.
+		var pvAddress types.Address
+		if config.PrivValidatorListenAddr == "" && privValidator != nil {
+			pubKey, err := privValidator.GetPubKey()
+			if err != nil {
+				return nil, fmt.Errorf("can't get pubkey: %w", err)
+			}
+			pvAddress = pubKey.Address()
+		}
+		if err := verifyOnStart(config, state, blockStore, pvAddress, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkLastBlockTime(config.P2P, state, logger); err != nil {
+		return nil, err
+	}
+
 	// The key will be deleted if it existed.
 	// Not checking whether the key is there in case the genesis file was larger than
 	// the max size of a value (in rocksDB for example), which would cause the check
@@ -297,10 +390,28 @@ func NewNode(ctx context.Context,
 		logger.Error("Failed to delete genesis doc from DB ", err)
 	}
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics, abciMetrics, bsMetrics, ssMetrics := metricsProvider(genDoc.ChainID)
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, abciMetrics, bsMetrics, ssMetrics, pubsubMetrics := metricsProvider(genDoc.ChainID)
+	if storageSampler != nil {
+		configureStorageSampler(storageSampler, config, genDoc.ChainID, nodeKey.ID(), logger)
+	}
 
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger, abciMetrics)
+	var proxyAppOptions []proxy.AppConnsOption
+	if config.ABCIMaxOutage > 0 {
+		proxyAppOptions = append(proxyAppOptions, proxy.AppConnsRecovery(config.ABCIMaxOutage,
+			recoveryHandshake(stateStore, blockStore, genDoc, logger.With("module", "consensus"))))
+	}
+	methodTimeouts, err := proxy.ParseMethodTimeouts(config.ABCIMethodTimeouts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid abci_method_timeouts: %w", err)
+	}
+	proxyAppOptions = append(proxyAppOptions, proxy.AppConnsTimeouts(proxy.CallTimeouts{
+		Default:          config.ABCICallTimeout,
+		Methods:          methodTimeouts,
+		BreakerThreshold: config.ABCICircuitBreakerThreshold,
+		BreakerCooldown:  config.ABCICircuitBreakerCooldown,
+	}))
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger, abciMetrics, proxyAppOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -309,13 +420,26 @@ func NewNode(ctx context.Context,
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
 	// but before it indexed the txs)
-	eventBus, err := createAndStartEventBus(logger)
+	eventBus, err := createAndStartEventBus(logger, pubsubMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	// The indexer and the block executor use the event schema of the app.
+	eventSchema, err := loadEventSchema(ctx, proxyApp)
 	if err != nil {
 		return nil, err
 	}
 
 	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config,
-		genDoc.ChainID, dbProvider, eventBus, logger)
+		genDoc.ChainID, dbProvider, eventBus, eventSchema, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the indexer, the event log must be started before the handshake to
+	// log the events of the replayed block.
+	eventLog, err := createAndStartEventLog(config, dbProvider, blockStore, stateStore, eventBus, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -366,7 +490,7 @@ func NewNode(ctx context.Context,
 
 	logNodeStartupInfo(state, pubKey, logger, consensusLogger)
 
-	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, waitSync, memplMetrics, logger)
+	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, eventBus, waitSync, memplMetrics, logger)
 
 	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateStore, blockStore, logger)
 	if err != nil {
@@ -381,6 +505,8 @@ func NewNode(ctx context.Context,
 		blockStore,
 		smMetrics,
 		logger.With("module", "state"),
+		sm.WithPrunerRetainHeight(sm.RetainHeightSnapshots, snapshotsRetainHeight(proxyApp.Snapshot())),
+		sm.WithPrunerRetainHeight(sm.RetainHeightIndexer, indexerService.RetainHeight),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pruner: %w", err)
@@ -396,6 +522,7 @@ func NewNode(ctx context.Context,
 		blockStore,
 		sm.BlockExecutorWithPruner(pruner),
 		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithEventSchema(eventSchema),
 	)
 
 	offlineStateSyncHeight := int64(0)
@@ -405,15 +532,19 @@ func NewNode(ctx context.Context,
 			panic(fmt.Sprintf("failed to retrieve statesynced height from store %s; expected state store height to be %v", err, state.LastBlockHeight))
 		}
 	}
+	upgrades := upgrade.NewManager(config.UpgradeInfoFile(), config.HaltHeight, config.UpgradeBinary)
+	sigVerifyPool := sigverify.NewPool(config.SigVerifyWorkers)
+
 	// Don't start block sync if we're doing a state sync first.
-	bcReactor, err := createBlocksyncReactor(config, state, blockExec, blockStore, blockSync && !stateSync, logger, bsMetrics, offlineStateSyncHeight)
+	bcReactor, err := createBlocksyncReactor(config, state, blockExec, blockStore, blockSync && !stateSync, logger, bsMetrics, offlineStateSyncHeight, upgrades, sigVerifyPool)
 	if err != nil {
+		sigVerifyPool.Stop()
 		return nil, fmt.Errorf("could not create blocksync reactor: %w", err)
 	}
 
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, waitSync, eventBus, consensusLogger, offlineStateSyncHeight,
+		privValidator, csMetrics, waitSync, eventBus, consensusLogger, offlineStateSyncHeight, upgrades, sigVerifyPool,
 	)
 
 	err = stateStore.SetOfflineStateSyncHeight(0)
@@ -431,26 +562,38 @@ func NewNode(ctx context.Context,
 		ssMetrics,
 	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
+	stateSyncReactor.SetEventBus(eventBus)
 
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
 	if err != nil {
 		return nil, err
 	}
 
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	if err != nil {
+		return nil, err
+	}
 
 	p2pLogger := logger.With("module", "p2p")
+	if config.P2P.MaxClockSkew > 0 {
+		p2p.MultiplexTransportClockSkewDetector(
+			p2p.NewClockSkewDetector(config.P2P.MaxClockSkew, p2pMetrics, p2pLogger))(transport)
+	}
 	sw := createSwitch(
 		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
 		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
 	)
 
-	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
+	err = sw.AddPersistentPeers(config.P2P.AllPersistentPeers())
 	if err != nil {
 		return nil, fmt.Errorf("could not add peers from persistent_peers field: %w", err)
 	}
 
-	err = sw.AddUnconditionalPeerIDs(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+	if err := setPersistentPeerPolicies(config, sw); err != nil {
+		return nil, err
+	}
+
+	err = sw.AddUnconditionalPeerIDs(config.P2P.AllUnconditionalPeerIDs())
 	if err != nil {
 		return nil, fmt.Errorf("could not add peer ids from unconditional_peer_ids field: %w", err)
 	}
@@ -478,7 +621,7 @@ func NewNode(ctx context.Context,
 	}
 
 	// Add private IDs to addrbook to block those peers being added
-	addrBook.AddPrivateIDs(splitAndTrimEmpty(config.P2P.PrivatePeerIDs, ",", " "))
+	addrBook.AddPrivateIDs(config.P2P.AllPrivatePeerIDs())
 
 	node := &Node{
 		config:        config,
@@ -507,20 +650,37 @@ func NewNode(ctx context.Context,
 		proxyApp:         proxyApp,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
+		eventLog:         eventLog,
 		blockIndexer:     blockIndexer,
 		eventBus:         eventBus,
+		upgrades:         upgrades,
+		sigVerifyPool:    sigVerifyPool,
+		memTuner:         createMemTuner(config, genDoc.ChainID, nodeKey.ID(), logger),
+		storageSampler:   storageSampler,
+		rpcDrainer:       rpcserver.NewDrainer(),
+	}
+	if config.RPC.SigningInfoWindow > 0 {
+		node.signingInfo = signinginfo.NewTracker(blockStore, stateStore, config.RPC.SigningInfoWindow)
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
 	for _, option := range options {
 		option(node)
 	}
+	if node.optionErr != nil {
+		return nil, node.optionErr
+	}
 
 	return node, nil
 }
 
 // OnStart starts the Node. It implements service.Service.
 func (n *Node) OnStart() error {
+	// apply the memory settings before the node allocates its working set
+	if err := n.memTuner.Start(); err != nil {
+		return fmt.Errorf("failed to apply the memory settings: %w", err)
+	}
+
 	now := cmttime.Now()
 	genTime := n.genesisDoc.GenesisTime
 	if genTime.After(now) {
@@ -538,9 +698,34 @@ func (n *Node) OnStart() error {
 		n.prometheusSrv = n.startPrometheusServer()
 	}
 
+	// Check again the txs that were in the mempool when the node stopped,
+	// before any new tx is received.
+	if n.config.Mempool.WalEnabled() {
+		if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+			if err := mp.InitWAL(); err != nil {
+				return fmt.Errorf("failed to initialize the mempool WAL: %w", err)
+			}
+		}
+	}
+
+	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID(), n.config.P2P.ListenAddress))
+	if err != nil {
+		return err
+	}
+
+	// Share the P2P port with the RPC and gRPC servers.
+	if n.config.P2P.SharedPort {
+		ln, err := net.Listen("tcp", addr.DialString())
+		if err != nil {
+			return err
+		}
+		n.portMux = cmtnet.NewMux(ln, n.config.P2P.HandshakeTimeout)
+	}
+
 	// Start the RPC server before the P2P server
-	// so we can eg. receive txs for the first block
-	if n.config.RPC.ListenAddress != "" {
+	// so we can eg. receive txs for the first block.
+	// Seed nodes have no state to serve.
+	if (n.config.RPC.ListenAddress != "" || n.portMux != nil) && n.config.Mode != cfg.ModeSeed {
 		listeners, err := n.startRPC()
 		if err != nil {
 			return err
@@ -549,12 +734,20 @@ func (n *Node) OnStart() error {
 	}
 
 	// Start the transport.
-	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID(), n.config.P2P.ListenAddress))
+	if n.portMux != nil {
+		err = n.transport.ListenOn(*addr, n.portMux.Listener(cmtnet.ProtocolP2P))
+	} else {
+		err = n.transport.Listen(*addr)
+	}
 	if err != nil {
 		return err
 	}
-	if err := n.transport.Listen(*addr); err != nil {
-		return err
+	if n.portMux != nil {
+		go func() {
+			if err := n.portMux.Serve(); err != nil {
+				n.Logger.Error("Error serving shared port", "err", err)
+			}
+		}()
 	}
 
 	n.isListening = true
@@ -566,7 +759,7 @@ func (n *Node) OnStart() error {
 	}
 
 	// Always connect to persistent peers
-	err = n.sw.DialPeersAsync(splitAndTrimEmpty(n.config.P2P.PersistentPeers, ",", " "))
+	err = n.sw.DialPeersAsync(n.config.P2P.AllPersistentPeers())
 	if err != nil {
 		return fmt.Errorf("could not dial peers from persistent_peers field: %w", err)
 	}
@@ -585,55 +778,107 @@ func (n *Node) OnStart() error {
 	}
 
 	// Start background pruning
-	if err := n.pruner.Start(); err != nil {
-		return fmt.Errorf("failed to start background pruning routine: %w", err)
+	if n.pruner != nil {
+		if err := n.pruner.Start(); err != nil {
+			return fmt.Errorf("failed to start background pruning routine: %w", err)
+		}
+	}
+
+	if n.storageSampler != nil {
+		if err := n.storageSampler.Start(); err != nil {
+			return fmt.Errorf("failed to start the storage sampler: %w", err)
+		}
+	}
+
+	// Seed nodes have no blocks to halt at.
+	if n.upgrades != nil {
+		go n.upgradeRoutine()
 	}
 
 	return nil
 }
 
+// upgradeRoutine waits for the node to halt for an upgrade and, if an upgrade
+// binary is configured, stops the node and executes it.
+func (n *Node) upgradeRoutine() {
+	select {
+	case <-n.upgrades.Halted():
+	case <-n.Quit():
+		return
+	}
+	binary := n.upgrades.Binary()
+	if binary == "" {
+		n.Logger.Info("Node halted for an upgrade", "height", n.upgrades.HaltHeight())
+		return
+	}
+	n.Logger.Info("Node halted for an upgrade, executing the upgrade binary",
+		"height", n.upgrades.HaltHeight(), "binary", binary)
+	if err := n.Stop(); err != nil {
+		n.Logger.Error("Error stopping the node", "err", err)
+	}
+	if err := n.upgrades.Exec(); err != nil {
+		n.Logger.Error("Failed to execute the upgrade binary", "binary", binary, "err", err)
+	}
+}
+
 // OnStop stops the Node. It implements service.Service.
 func (n *Node) OnStop() {
 	n.BaseService.OnStop()
 
 	n.Logger.Info("Stopping Node")
 
-	// first stop the non-reactor services
-	if err := n.pruner.Stop(); err != nil {
-		n.Logger.Error("Error stopping the pruning service", "err", err)
-	}
-	if err := n.eventBus.Stop(); err != nil {
-		n.Logger.Error("Error closing eventBus", "err", err)
+	// first stop accepting rpc requests, and let the in-flight ones complete,
+	// e.g. broadcast_tx_commit waiting for the next block
+	for _, l := range n.rpcListeners {
+		n.Logger.Info("Closing rpc listener", "listener", l)
+		if err := l.Close(); err != nil {
+			n.Logger.Error("Error closing listener", "listener", l, "err", err)
+		}
 	}
-	if err := n.indexerService.Stop(); err != nil {
-		n.Logger.Error("Error closing indexerService", "err", err)
+	if n.rpcDrainer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), n.config.ShutdownGracePeriod)
+		if err := n.rpcDrainer.Drain(ctx); err != nil {
+			n.Logger.Error("Timed out waiting for in-flight rpc requests", "err", err)
+		}
+		cancel()
 	}
 
-	// now stop the reactors
+	// now stop the reactors. Consensus finishes its current step, so it never
+	// stops in the middle of signing, and flushes its WAL.
 	if err := n.sw.Stop(); err != nil {
 		n.Logger.Error("Error closing switch", "err", err)
 	}
+	n.sigVerifyPool.Stop()
+
+	// then the non-reactor services, which consensus feeds until it stops, in
+	// the reverse order they were started, each given shutdown_grace_period
+	if err := n.nonReactorServices().Stop(); err != nil {
+		n.Logger.Error("Error stopping services", "err", err)
+	}
 
 	if err := n.transport.Close(); err != nil {
 		n.Logger.Error("Error closing transport", "err", err)
 	}
+	if n.portMux != nil {
+		if err := n.portMux.Close(); err != nil {
+			n.Logger.Error("Error closing shared port", "err", err)
+		}
+	}
 
 	n.isListening = false
 
-	// finally stop the listeners / external services
-	for _, l := range n.rpcListeners {
-		n.Logger.Info("Closing rpc listener", "listener", l)
-		if err := l.Close(); err != nil {
-			n.Logger.Error("Error closing listener", "listener", l, "err", err)
-		}
+	if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+		mp.CloseWAL()
 	}
 
+	// the private validator is only stopped once consensus stopped signing
 	if pvsc, ok := n.privValidator.(service.Service); ok {
 		if err := pvsc.Stop(); err != nil {
 			n.Logger.Error("Error closing private validator", "err", err)
 		}
 	}
 
+	// finally stop the external services and close the databases
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
 			// Error from closing listeners, or context timeout:
@@ -665,6 +910,35 @@ func (n *Node) OnStop() {
 	}
 }
 
+// nonReactorServices returns the group of the services the node started
+// besides the switch, in the order they were started.
+func (n *Node) nonReactorServices() *service.Group {
+	services := service.NewGroup(n.Logger, n.config.ShutdownGracePeriod)
+	if n.memTuner != nil && n.memTuner.IsRunning() {
+		services.Add(n.memTuner)
+	}
+	if n.proxyApp != nil {
+		services.Add(n.proxyApp)
+	}
+	if n.eventBus != nil {
+		services.Add(n.eventBus)
+	}
+	if n.indexerService != nil {
+		services.Add(n.indexerService)
+	}
+	if n.eventLog != nil {
+		services.Add(n.eventLog)
+	}
+	if n.pruner != nil && n.pruner.IsRunning() {
+		services.Add(n.pruner)
+	}
+	// stopped first, as it reads the databases the others close
+	if n.storageSampler != nil && n.storageSampler.IsRunning() {
+		services.Add(n.storageSampler)
+	}
+	return services
+}
+
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
 func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 	pubKey, err := n.privValidator.GetPubKey()
@@ -672,8 +946,9 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
 	}
 	rpcCoreEnv := rpccore.Environment{
-		ProxyAppQuery:   n.proxyApp.Query(),
-		ProxyAppMempool: n.proxyApp.Mempool(),
+		ProxyAppQuery:    n.proxyApp.Query(),
+		ProxyAppMempool:  n.proxyApp.Mempool(),
+		ProxyAppSnapshot: n.proxyApp.Snapshot(),
 
 		StateStore:     n.stateStore,
 		BlockStore:     n.blockStore,
@@ -682,19 +957,30 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		P2PPeers:       n.sw,
 		P2PTransport:   n,
 		PubKey:         pubKey,
+		PrivValidator:  n.privValidator,
 
 		GenDoc:           n.genesisDoc,
 		TxIndexer:        n.txIndexer,
 		BlockIndexer:     n.blockIndexer,
 		ConsensusReactor: n.consensusReactor,
 		MempoolReactor:   n.mempoolReactor,
+		StateSyncReactor: n.stateSyncReactor,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
+		Upgrades:         n.upgrades,
+		SigningInfo:      n.signingInfo,
+		StorageSampler:   n.storageSampler,
+		Pruner:           n.pruner,
+		SparseRetention:  n.config.Storage.Pruning.SparseRetention(),
+		DBDir:            n.config.DBDir(),
 
 		Logger: n.Logger.With("module", "rpc"),
 
 		Config: *n.config.RPC,
 	}
+	if bcR, ok := n.bcReactor.(*bc.Reactor); ok {
+		rpcCoreEnv.BlockSyncReactor = bcR
+	}
 	if err := rpcCoreEnv.InitGenesisChunks(); err != nil {
 		return nil, err
 	}
@@ -710,7 +996,8 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	listenAddrs := splitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
 	routes := env.GetRoutes()
 
-	if n.config.RPC.Unsafe {
+	// When the admin listener is enabled, unsafe routes are only served there.
+	if n.config.RPC.Unsafe && !n.config.RPC.IsAdminEnabled() {
 		env.AddUnsafeRoutes(routes)
 	}
 
@@ -725,9 +1012,66 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
-	// we may expose the rpc over both a unix and tcp socket
-	listeners := make([]net.Listener, 0, len(listenAddrs))
+	var authenticators []rpcserver.Authenticator
+	if len(n.config.RPC.AuthAPIKeys) != 0 {
+		authenticators = append(authenticators, rpcserver.APIKeyAuthenticator(n.config.RPC.AuthAPIKeys...))
+	}
+	if n.config.RPC.AuthJWTSecret != "" {
+		authenticators = append(authenticators, rpcserver.JWTAuthenticator([]byte(n.config.RPC.AuthJWTSecret)))
+	}
+
+	var rateLimiter *rpcserver.RateLimiter
+	if n.config.RPC.IsRateLimitEnabled() {
+		rates, err := n.config.RPC.RateLimitPerMethod()
+		if err != nil {
+			return nil, err
+		}
+		methodLimits := make(map[string]rpcserver.RateLimit, len(rates))
+		for method, rate := range rates {
+			// each limited method adds a bucket per client, so only the
+			// methods served can be limited
+			if _, ok := routes[method]; !ok {
+				return nil, fmt.Errorf("rate_limit_methods: %q is not an RPC method served by the node", method)
+			}
+			methodLimits[method] = rpcserver.RateLimit{Rate: rate, Burst: n.config.RPC.RateLimitBurst}
+		}
+		rateLimiter = rpcserver.NewRateLimiter(
+			rpcserver.RateLimit{Rate: n.config.RPC.RateLimit, Burst: n.config.RPC.RateLimitBurst},
+			methodLimits,
+		)
+	}
+
+	// we may expose the rpc over both a unix and tcp socket, and the shared
+	// port
+	listeners := make([]net.Listener, 0, len(listenAddrs)+1)
 	for _, listenAddr := range listenAddrs {
+		listener, err := rpcserver.Listen(
+			listenAddr,
+			config.MaxOpenConnections,
+		)
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, listener)
+	}
+	if n.portMux != nil {
+		var listener net.Listener = n.portMux.Listener(cmtnet.ProtocolHTTP)
+		if config.MaxOpenConnections > 0 {
+			listener = netutil.LimitListener(listener, config.MaxOpenConnections)
+		}
+		listeners = append(listeners, listener)
+	}
+
+	var metricsHandler http.Handler
+	if n.config.Instrumentation.ServeOnRPC {
+		metricsHandler = promhttp.HandlerFor(
+			prometheus.DefaultGatherer,
+			promhttp.HandlerOpts{MaxRequestsInFlight: n.config.Instrumentation.MaxOpenConnections},
+		)
+	}
+
+	for _, listener := range listeners {
+		listener := listener
 		mux := http.NewServeMux()
 		rpcLogger := n.Logger.With("module", "rpc-server")
 		wmLogger := rpcLogger.With("protocol", "websocket")
@@ -740,27 +1084,33 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 			}),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 			rpcserver.WriteChanCapacity(n.config.RPC.WebSocketWriteBufferSize),
+			rpcserver.CallRateLimiter(rateLimiter),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
 		mux.HandleFunc("/v1/websocket", wm.WebsocketHandler)
 		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
-		listener, err := rpcserver.Listen(
-			listenAddr,
-			config.MaxOpenConnections,
-		)
-		if err != nil {
-			return nil, err
+		if n.config.RPC.RESTEnabled {
+			mux.Handle(rest.Prefix, rest.NewHandler(env, rpcLogger.With("protocol", "rest")))
+		}
+		if metricsHandler != nil {
+			mux.Handle("/metrics", metricsHandler)
 		}
-
 		var rootHandler http.Handler = mux
+		rootHandler = rpcserver.DrainHandler(rootHandler, n.rpcDrainer)
+		rootHandler = rpcserver.AuthHandler(rootHandler, authenticators...)
+		// rate limit before authenticating, so that the failed
+		// authentications are throttled too
+		if rateLimiter != nil {
+			rootHandler = rpcserver.RateLimitHandler(rootHandler, rateLimiter, rest.Methods)
+		}
 		if n.config.RPC.IsCorsEnabled() {
 			corsMiddleware := cors.New(cors.Options{
 				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
 				AllowedMethods: n.config.RPC.CORSAllowedMethods,
 				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
 			})
-			rootHandler = corsMiddleware.Handler(mux)
+			rootHandler = corsMiddleware.Handler(rootHandler)
 		}
 		if n.config.RPC.IsTLSEnabled() {
 			go func() {
@@ -787,15 +1137,28 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 				}
 			}()
 		}
+	}
 
+	if n.config.RPC.IsAdminEnabled() {
+		listener, err := n.startAdminRPC(env, config)
+		if err != nil {
+			return nil, err
+		}
 		listeners = append(listeners, listener)
 	}
 
+	grpcListeners := make([]net.Listener, 0, 2)
 	if n.config.GRPC.ListenAddress != "" {
 		listener, err := grpcserver.Listen(n.config.GRPC.ListenAddress)
 		if err != nil {
 			return nil, err
 		}
+		grpcListeners = append(grpcListeners, listener)
+	}
+	if n.portMux != nil {
+		grpcListeners = append(grpcListeners, n.portMux.Listener(cmtnet.ProtocolGRPC))
+	}
+	if len(grpcListeners) > 0 {
 		opts := []grpcserver.Option{
 			grpcserver.WithLogger(n.Logger),
 		}
@@ -808,12 +1171,17 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		if n.config.GRPC.BlockResultsService.Enabled {
 			opts = append(opts, grpcserver.WithBlockResultsService(n.blockStore, n.stateStore, n.Logger))
 		}
-		go func() {
-			if err := grpcserver.Serve(listener, opts...); err != nil {
-				n.Logger.Error("Error starting gRPC server", "err", err)
-			}
-		}()
-		listeners = append(listeners, listener)
+		if n.eventLog != nil {
+			opts = append(opts, grpcserver.WithEventLogService(n.eventLog, n.Logger))
+		}
+		for _, listener := range grpcListeners {
+			go func(listener net.Listener) {
+				if err := grpcserver.Serve(listener, opts...); err != nil {
+					n.Logger.Error("Error starting gRPC server", "err", err)
+				}
+			}(listener)
+		}
+		listeners = append(listeners, grpcListeners...)
 	}
 
 	if n.config.GRPC.Privileged.ListenAddress != "" {
@@ -838,6 +1206,29 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	return listeners, nil
 }
 
+// startAdminRPC starts the admin RPC server, which serves the pprof handlers
+// and, if enabled, the unsafe routes behind token authentication.
+func (n *Node) startAdminRPC(env *rpccore.Environment, config *rpcserver.Config) (net.Listener, error) {
+	adminLogger := n.Logger.With("module", "rpc-admin-server")
+	mux := http.NewServeMux()
+	mux.Handle("/debug/pprof/", http.DefaultServeMux)
+	if n.config.RPC.Unsafe {
+		rpcserver.RegisterRPCFuncs(mux, env.GetAdminRoutes(), adminLogger)
+	}
+
+	listener, err := rpcserver.Listen(n.config.RPC.AdminListenAddress, config.MaxOpenConnections)
+	if err != nil {
+		return nil, err
+	}
+	handler := rpcserver.TokenAuthHandler(rpcserver.DrainHandler(mux, n.rpcDrainer), n.config.RPC.AdminAuthToken)
+	go func() {
+		if err := rpcserver.Serve(listener, handler, adminLogger, config); err != nil {
+			n.Logger.Error("Error serving admin server", "err", err)
+		}
+	}()
+	return listener, nil
+}
+
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
 // collectors on addr.
 func (n *Node) startPrometheusServer() *http.Server {
@@ -978,7 +1369,8 @@ func makeNodeInfo(
 		Channels: []byte{
 			bc.BlocksyncChannel,
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
-			mempl.MempoolChannel,
+			cs.VoteBatchChannel, cs.CompactBlockChannel,
+			mempl.MempoolChannel, mempl.MempoolSyncChannel,
 			evidence.EvidenceChannel,
 			statesync.SnapshotChannel, statesync.ChunkChannel,
 		},
@@ -1013,6 +1405,7 @@ func createPruner(
 	blockStore *store.BlockStore,
 	metrics *sm.Metrics,
 	logger log.Logger,
+	options ...sm.PrunerOption,
 ) (*sm.Pruner, error) {
 	if err := initApplicationRetainHeight(stateStore); err != nil {
 		return nil, err
@@ -1022,6 +1415,7 @@ func createPruner(
 		sm.WithPrunerInterval(config.Storage.Pruning.Interval),
 		sm.WithPrunerMetrics(metrics),
 	}
+	prunerOpts = append(prunerOpts, options...)
 
 	if config.Storage.Pruning.DataCompanion.Enabled {
 		err := initCompanionRetainHeights(
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -18,6 +19,7 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	"github.com/cometbft/cometbft/abci/example/kvstore"
+	p2pproto "github.com/cometbft/cometbft/api/cometbft/p2p/v1"
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/tmhash"
@@ -33,6 +35,7 @@ import (
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/conn"
 	p2pmock "github.com/cometbft/cometbft/p2p/mock"
+	"github.com/cometbft/cometbft/p2p/pex"
 	"github.com/cometbft/cometbft/privval"
 	"github.com/cometbft/cometbft/proxy"
 	"github.com/cometbft/cometbft/types"
@@ -80,6 +83,37 @@ func TestNodeStartStop(t *testing.T) {
 		fmt.Println(err)
 		t.Fatal("timed out waiting for shutdown")
 	}
+
+	// the services consensus feeds, including the connections to the
+	// application, are stopped along with the node
+	assert.False(t, n.ProxyApp().IsRunning())
+	assert.False(t, n.EventBus().IsRunning())
+	assert.False(t, n.indexerService.IsRunning())
+}
+
+func TestSeedNode(t *testing.T) {
+	config := test.ResetTestRoot("node_seed_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Mode = cfg.ModeSeed
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.Nil(t, n.BlockStore())
+	assert.Len(t, n.Switch().Reactors(), 1)
+	assert.NotNil(t, n.Switch().Reactor("PEX"))
+	assert.Equal(t, []byte{pex.PexChannel}, []byte(n.NodeInfo().(p2p.DefaultNodeInfo).Channels))
+
+	err = n.Start()
+	require.NoError(t, err)
+	assert.Empty(t, n.rpcListeners)
+
+	err = n.Stop()
+	require.NoError(t, err)
+
+	// Peers cannot be filtered without an application.
+	config.FilterPeers = true
+	_, err = DefaultNewNode(config, log.TestingLogger())
+	require.ErrorIs(t, err, errSeedFilterPeers)
 }
 
 func TestSplitAndTrimEmpty(t *testing.T) {
@@ -176,6 +210,50 @@ func TestPprofServer(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+func TestAdminRPCServer(t *testing.T) {
+	config := test.ResetTestRoot("node_admin_rpc_test")
+	defer os.RemoveAll(config.RootDir)
+	config.RPC.ListenAddress = "tcp://" + testFreeAddr(t)
+	config.RPC.Unsafe = true
+	config.RPC.AdminListenAddress = "tcp://" + testFreeAddr(t)
+	config.RPC.AdminAuthToken = "secret"
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer func() {
+		require.NoError(t, n.Stop())
+	}()
+
+	publicAddr := "http://" + strings.TrimPrefix(config.RPC.ListenAddress, "tcp://")
+	adminAddr := "http://" + strings.TrimPrefix(config.RPC.AdminListenAddress, "tcp://")
+
+	get := func(url, token string) int {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// unsafe routes are not served on the public listener
+	assert.Equal(t, http.StatusNotFound, get(publicAddr+"/dial_peers", ""))
+	assert.Equal(t, http.StatusOK, get(publicAddr+"/health", ""))
+
+	// but are on the admin listener, given the right token
+	assert.Equal(t, http.StatusUnauthorized, get(adminAddr+"/dial_peers", ""))
+	assert.Equal(t, http.StatusUnauthorized, get(adminAddr+"/dial_peers", "wrong"))
+	code := get(adminAddr+"/dial_peers", "secret")
+	assert.NotEqual(t, http.StatusNotFound, code)
+	assert.NotEqual(t, http.StatusUnauthorized, code)
+	assert.Equal(t, http.StatusOK, get(adminAddr+"/unsafe_flush_mempool", "secret"))
+	assert.Equal(t, http.StatusOK, get(adminAddr+"/debug/pprof/", "secret"))
+}
+
 func TestNodeSetPrivValTCP(t *testing.T) {
 	addr := "tcp://" + testFreeAddr(t)
 
@@ -445,7 +523,7 @@ func TestNodeNewNodeCustomReactors(t *testing.T) {
 	cr := p2pmock.NewReactor()
 	cr.Channels = []*conn.ChannelDescriptor{
 		{
-			ID:                  byte(0x31),
+			ID:                  byte(0x32),
 			Priority:            5,
 			SendQueueCapacity:   100,
 			RecvMessageCapacity: 100,
@@ -463,7 +541,7 @@ func TestNodeNewNodeCustomReactors(t *testing.T) {
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		cfg.DefaultDBProvider,
-		DefaultMetricsProvider(config.Instrumentation),
+		DefaultMetricsProvider(config, nodeKey.ID()),
 		log.TestingLogger(),
 		CustomReactors(map[string]p2p.Reactor{"FOO": cr, "BLOCKSYNC": customBlocksyncReactor}),
 	)
@@ -484,6 +562,56 @@ func TestNodeNewNodeCustomReactors(t *testing.T) {
 	assert.Contains(t, channels, cr.Channels[0].ID)
 }
 
+func TestNodeRegisterReactor(t *testing.T) {
+	config := test.ResetTestRoot("node_register_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	newNode := func(options ...Option) (*Node, error) {
+		return NewNode(context.Background(),
+			config,
+			privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+			nodeKey,
+			proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+			DefaultGenesisDocProviderFunc(config),
+			cfg.DefaultDBProvider,
+			DefaultMetricsProvider(config, nodeKey.ID()),
+			log.TestingLogger(),
+			options...,
+		)
+	}
+	newReactor := func(chID byte) *p2p.HookReactor {
+		return p2p.NewHookReactor("Custom", []*conn.ChannelDescriptor{
+			{ID: chID, Priority: 5, SendQueueCapacity: 100, RecvMessageCapacity: 100, MessageType: &p2pproto.Message{}},
+		}, p2p.ReactorHooks{})
+	}
+
+	// the channels of the node are reserved
+	_, err = newNode(RegisterReactor("CUSTOM", newReactor(mempl.MempoolSyncChannel)))
+	require.ErrorAs(t, err, &p2p.ErrSwitchReservedChannel{})
+	// and the reactors of the node can't be replaced
+	_, err = newNode(RegisterReactor("MEMPOOL", newReactor(p2p.MinCustomChannelID)))
+	require.ErrorAs(t, err, &p2p.ErrSwitchDuplicateReactor{})
+	// and the messages of its channels must have a type
+	_, err = newNode(RegisterReactor("CUSTOM", p2p.NewHookReactor("Custom", []*conn.ChannelDescriptor{
+		{ID: p2p.MinCustomChannelID, Priority: 5},
+	}, p2p.ReactorHooks{})))
+	require.ErrorAs(t, err, &p2p.ErrSwitchNoMessageType{})
+
+	cr := newReactor(p2p.MinCustomChannelID)
+	n, err := newNode(RegisterReactor("CUSTOM", cr))
+	require.NoError(t, err)
+
+	err = n.Start()
+	require.NoError(t, err)
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	assert.True(t, cr.IsRunning())
+	assert.Equal(t, cr, n.Switch().Reactor("CUSTOM"))
+	assert.Contains(t, n.NodeInfo().(p2p.DefaultNodeInfo).Channels, p2p.MinCustomChannelID)
+}
+
 // Simple test to confirm that an existing genesis file will be deleted from the DB
 // TODO Confirm that the deletion of a very big file does not crash the machine
 func TestNodeNewNodeDeleteGenesisFileFromDB(t *testing.T) {
@@ -514,7 +642,7 @@ func TestNodeNewNodeDeleteGenesisFileFromDB(t *testing.T) {
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		cfg.DefaultDBProvider,
-		DefaultMetricsProvider(config.Instrumentation),
+		DefaultMetricsProvider(config, nodeKey.ID()),
 		log.TestingLogger(),
 	)
 	require.NoError(t, err)
@@ -558,7 +686,7 @@ func TestNodeNewNodeGenesisHashMismatch(t *testing.T) {
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		cfg.DefaultDBProvider,
-		DefaultMetricsProvider(config.Instrumentation),
+		DefaultMetricsProvider(config, nodeKey.ID()),
 		log.TestingLogger(),
 	)
 	require.NoError(t, err)
@@ -600,7 +728,7 @@ func TestNodeNewNodeGenesisHashMismatch(t *testing.T) {
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		cfg.DefaultDBProvider,
-		DefaultMetricsProvider(config.Instrumentation),
+		DefaultMetricsProvider(config, nodeKey.ID()),
 		log.TestingLogger(),
 	)
 	require.Error(t, err, "NewNode should error when genesisDoc is changed")
@@ -629,7 +757,7 @@ func TestNodeGenesisHashFlagMatch(t *testing.T) {
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		cfg.DefaultDBProvider,
-		DefaultMetricsProvider(config.Instrumentation),
+		DefaultMetricsProvider(config, nodeKey.ID()),
 		log.TestingLogger(),
 	)
 	require.NoError(t, err)
@@ -661,7 +789,7 @@ func TestNodeGenesisHashFlagMismatch(t *testing.T) {
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		cfg.DefaultDBProvider,
-		DefaultMetricsProvider(config.Instrumentation),
+		DefaultMetricsProvider(config, nodeKey.ID()),
 		log.TestingLogger(),
 	)
 	require.Error(t, err)
@@ -712,3 +840,16 @@ func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	}
 	return s, stateDB, privVals
 }
+
+func TestMetricsLabels(t *testing.T) {
+	config := cfg.TestInstrumentationConfig()
+	assert.Equal(t, []string{"chain_id", "test-chain"},
+		MetricsLabels(config, "test-chain", "node0", "abcd"))
+
+	config.Labels = []string{cfg.MetricsLabelNodeID, cfg.MetricsLabelChainID, cfg.MetricsLabelMoniker}
+	assert.Equal(t, []string{"chain_id", "test-chain", "moniker", "node0", "node_id", "abcd"},
+		MetricsLabels(config, "test-chain", "node0", "abcd"))
+
+	config.Labels = nil
+	assert.Empty(t, MetricsLabels(config, "test-chain", "node0", "abcd"))
+}
@@ -0,0 +1,163 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/service"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/pex"
+	"github.com/cometbft/cometbft/version"
+)
+
+const (
+	// seedCrawlPeriod is how often a seed node crawls the network.
+	seedCrawlPeriod = 10 * time.Second
+	// seedMinTimeBetweenCrawls is the minimum time between attempts of a seed
+	// node to crawl a peer.
+	seedMinTimeBetweenCrawls = 30 * time.Second
+	// seedDisconnectWaitPeriod is how long a seed node stays connected to a
+	// peer. As a seed node does not run consensus, peers are never marked as
+	// good, so there is no point in waiting for it.
+	seedDisconnectWaitPeriod = 3 * time.Minute
+)
+
+var errSeedFilterPeers = errors.New("filter_peers is not supported in seed mode, as there is no application to query")
+
+// newSeedNode returns a node running only the PEX reactor and the address
+// book (see cfg.ModeSeed). It does not connect to the application nor open
+// the blockstore and the state, and does not serve RPC.
+func newSeedNode(
+	config *cfg.Config,
+	nodeKey *p2p.NodeKey,
+	genesisDocProvider GenesisDocProvider,
+	metricsProvider MetricsProvider,
+	logger log.Logger,
+	options ...Option,
+) (*Node, error) {
+	if config.FilterPeers {
+		return nil, errSeedFilterPeers
+	}
+
+	checksummedGenDoc, err := genesisDocProvider()
+	if err != nil {
+		return nil, err
+	}
+	genDoc := checksummedGenDoc.GenesisDoc
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, fmt.Errorf("invalid genesis doc: %w", err)
+	}
+
+	_, p2pMetrics, _, _, _, _, _, _ := metricsProvider(genDoc.ChainID)
+
+	nodeInfo, err := makeSeedNodeInfo(config, nodeKey, genDoc.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pLogger := logger.With("module", "p2p")
+	sw := p2p.NewSwitch(
+		config.P2P,
+		transport,
+		p2p.WithMetrics(p2pMetrics),
+		p2p.SwitchPeerFilters(peerFilters...),
+	)
+	sw.SetLogger(p2pLogger)
+	sw.SetNodeInfo(nodeInfo)
+	sw.SetNodeKey(nodeKey)
+	p2pLogger.Info("P2P Node ID", "ID", nodeKey.ID(), "file", config.NodeKeyFile())
+
+	err = sw.AddPersistentPeers(config.P2P.AllPersistentPeers())
+	if err != nil {
+		return nil, fmt.Errorf("could not add peers from persistent_peers field: %w", err)
+	}
+
+	if err := setPersistentPeerPolicies(config, sw); err != nil {
+		return nil, err
+	}
+
+	err = sw.AddUnconditionalPeerIDs(config.P2P.AllUnconditionalPeerIDs())
+	if err != nil {
+		return nil, fmt.Errorf("could not add peer ids from unconditional_peer_ids field: %w", err)
+	}
+
+	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not create addrbook: %w", err)
+	}
+	addrBook.AddPrivateIDs(config.P2P.AllPrivatePeerIDs())
+
+	pexReactor := pex.NewReactor(addrBook,
+		&pex.ReactorConfig{
+			Seeds:                        splitAndTrimEmpty(config.P2P.Seeds, ",", " "),
+			SeedMode:                     true,
+			SeedDisconnectWaitPeriod:     seedDisconnectWaitPeriod,
+			PersistentPeersMaxDialPeriod: config.P2P.PersistentPeersMaxDialPeriod,
+			CrawlPeriod:                  seedCrawlPeriod,
+			MinTimeBetweenCrawls:         seedMinTimeBetweenCrawls,
+			PreferIPv6:                   config.P2P.PreferIPv6,
+		})
+	pexReactor.SetLogger(logger.With("module", "pex"))
+	sw.AddReactor("PEX", pexReactor)
+
+	node := &Node{
+		config:     config,
+		genesisDoc: genDoc,
+
+		transport: transport,
+		sw:        sw,
+		addrBook:  addrBook,
+		nodeInfo:  nodeInfo,
+		nodeKey:   nodeKey,
+
+		pexReactor: pexReactor,
+		memTuner:   createMemTuner(config, genDoc.ChainID, nodeKey.ID(), logger),
+	}
+	node.BaseService = *service.NewBaseService(logger, "SeedNode", node)
+
+	for _, option := range options {
+		option(node)
+	}
+	if node.optionErr != nil {
+		return nil, node.optionErr
+	}
+
+	return node, nil
+}
+
+// makeSeedNodeInfo returns the node info of a seed node, which only
+// advertises the PEX channel.
+func makeSeedNodeInfo(config *cfg.Config, nodeKey *p2p.NodeKey, chainID string) (p2p.DefaultNodeInfo, error) {
+	nodeInfo := p2p.DefaultNodeInfo{
+		ProtocolVersion: p2p.NewProtocolVersion(
+			version.P2PProtocol, // global
+			version.BlockProtocol,
+			0,
+		),
+		DefaultNodeID: nodeKey.ID(),
+		Network:       chainID,
+		Version:       version.CMTSemVer,
+		Channels:      []byte{pex.PexChannel},
+		Moniker:       config.Moniker,
+		Other: p2p.DefaultNodeInfoOther{
+			TxIndex: "off",
+		},
+	}
+
+	lAddr := config.P2P.ExternalAddress
+	if lAddr == "" {
+		lAddr = config.P2P.ListenAddress
+	}
+	nodeInfo.ListenAddr = lAddr
+
+	err := nodeInfo.Validate()
+	return nodeInfo, err
+}
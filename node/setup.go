@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"net"
 	_ "net/http/pprof" //nolint: gosec // securely exposed on separate, optional port
-	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,24 +15,33 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/crypto"
-	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/internal/blocksync"
 	cs "github.com/cometbft/cometbft/internal/consensus"
+	"github.com/cometbft/cometbft/internal/eventlog"
 	"github.com/cometbft/cometbft/internal/evidence"
+	"github.com/cometbft/cometbft/internal/memtune"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	cmtpubsub "github.com/cometbft/cometbft/internal/pubsub"
+	"github.com/cometbft/cometbft/internal/sigverify"
 	sm "github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/state/indexer"
 	"github.com/cometbft/cometbft/internal/state/indexer/block"
 	"github.com/cometbft/cometbft/internal/state/txindex"
 	"github.com/cometbft/cometbft/internal/statesync"
+	"github.com/cometbft/cometbft/internal/storageinfo"
 	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/internal/upgrade"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/light"
+	lightprovider "github.com/cometbft/cometbft/light/provider"
+	lighthttp "github.com/cometbft/cometbft/light/provider/http"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/pex"
 	"github.com/cometbft/cometbft/privval"
 	"github.com/cometbft/cometbft/proxy"
 	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
 	"github.com/cometbft/cometbft/version"
 	_ "github.com/lib/pq" // provide the psql db driver
 )
@@ -52,18 +61,27 @@ type ChecksummedGenesisDoc struct {
 type GenesisDocProvider func() (ChecksummedGenesisDoc, error)
 
 // DefaultGenesisDocProviderFunc returns a GenesisDocProvider that loads
-// the GenesisDoc from the config.GenesisFile() on the filesystem.
+// the GenesisDoc from the config.GenesisFile() on the filesystem. If the
+// genesis file is downloaded (see config.GenesisURL()), it is downloaded to
+// config.GenesisFile() first, unless it was already.
 func DefaultGenesisDocProviderFunc(config *cfg.Config) GenesisDocProvider {
 	return func() (ChecksummedGenesisDoc, error) {
-		// FIXME: find a way to stream the file incrementally,
-		// for the JSON	parser and the checksum computation.
-		// https://github.com/cometbft/cometbft/issues/1302
-		jsonBlob, err := os.ReadFile(config.GenesisFile())
-		if err != nil {
-			return ChecksummedGenesisDoc{}, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
+		if genesisURL := config.GenesisURL(); genesisURL != "" && !cmtos.FileExists(config.GenesisFile()) {
+			if config.Storage.GenesisHash == "" {
+				return ChecksummedGenesisDoc{}, cfg.ErrGenesisURLWithoutHash
+			}
+			checksum, err := hex.DecodeString(config.Storage.GenesisHash)
+			if err != nil {
+				return ChecksummedGenesisDoc{}, fmt.Errorf("invalid genesis_hash: %w", err)
+			}
+			downloader := newGenesisDownloader(config.GenesisDownloadTimeout, config.GenesisMaxSize)
+			if err := downloader.downloadGenesis(genesisURL, config.GenesisFile(), checksum); err != nil {
+				return ChecksummedGenesisDoc{}, fmt.Errorf("couldn't download GenesisDoc file from %s: %w", genesisURL, err)
+			}
 		}
-		incomingChecksum := tmhash.Sum(jsonBlob)
-		genDoc, err := types.GenesisDocFromJSON(jsonBlob)
+		// The file is streamed, for the JSON parser and the checksum
+		// computation, so that huge genesis files fit in memory.
+		genDoc, incomingChecksum, err := types.GenesisDocFromFileWithChecksum(config.GenesisFile())
 		if err != nil {
 			return ChecksummedGenesisDoc{}, err
 		}
@@ -83,37 +101,75 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
 	}
 
+	// Seed nodes do not sign anything.
+	var privValidator types.PrivValidator
+	if config.Mode != cfg.ModeSeed {
+		filePV := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(),
+			privval.WithPassphrase(privval.NewPassphraseFunc(config.PrivValidatorKeyPassphraseFile())))
+		if config.PrivValidatorSignGuardURL != "" {
+			filePV.SetSignGuard(privval.NewHTTPSignGuard(config.PrivValidatorSignGuardURL, config.PrivValidatorSignGuardTimeout))
+		}
+		if err := filePV.LoadKeyRotation(config.PrivValidatorNextKeyFile()); err != nil {
+			return nil, fmt.Errorf("failed to load the next private validator key: %w", err)
+		}
+		privValidator = filePV
+	}
+
 	return NewNode(context.Background(), config,
-		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		privValidator,
 		nodeKey,
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		cfg.DefaultDBProvider,
-		DefaultMetricsProvider(config.Instrumentation),
+		DefaultMetricsProvider(config, nodeKey.ID()),
 		logger,
 	)
 }
 
-// MetricsProvider returns a consensus, p2p and mempool Metrics.
-type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics)
+// MetricsProvider returns a consensus, p2p, mempool, state, proxy, blocksync,
+// statesync and pubsub Metrics.
+type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics, *cmtpubsub.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
-// if Prometheus is enabled. Otherwise, it returns no-op Metrics.
-func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
-	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics) {
-		if config.Prometheus {
-			return cs.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				mempl.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				proxy.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				blocksync.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				statesync.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+// if the metrics are enabled. Otherwise, it returns no-op Metrics. The metrics
+// carry the labels of the instrumentation config, from the chain ID, the
+// moniker of config and nodeID.
+func DefaultMetricsProvider(config *cfg.Config, nodeID p2p.ID) MetricsProvider {
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics, *cmtpubsub.Metrics) {
+		if config.Instrumentation.IsMetricsEnabled() {
+			namespace := config.Instrumentation.Namespace
+			labels := MetricsLabels(config.Instrumentation, chainID, config.Moniker, nodeID)
+			return cs.PrometheusMetrics(namespace, labels...),
+				p2p.PrometheusMetrics(namespace, labels...),
+				mempl.PrometheusMetrics(namespace, labels...),
+				sm.PrometheusMetrics(namespace, labels...),
+				proxy.PrometheusMetrics(namespace, labels...),
+				blocksync.PrometheusMetrics(namespace, labels...),
+				statesync.PrometheusMetrics(namespace, labels...),
+				cmtpubsub.PrometheusMetrics(namespace, labels...)
 		}
-		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), proxy.NopMetrics(), blocksync.NopMetrics(), statesync.NopMetrics()
+		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), proxy.NopMetrics(), blocksync.NopMetrics(), statesync.NopMetrics(), cmtpubsub.NopMetrics()
 	}
 }
 
+// MetricsLabels returns the labels and their values, alternately, the metrics
+// carry according to config. They are always in the same order, so that the
+// metrics of the nodes sharing a configuration are comparable.
+func MetricsLabels(config *cfg.InstrumentationConfig, chainID, moniker string, nodeID p2p.ID) []string {
+	values := []struct{ label, value string }{
+		{cfg.MetricsLabelChainID, chainID},
+		{cfg.MetricsLabelMoniker, moniker},
+		{cfg.MetricsLabelNodeID, string(nodeID)},
+	}
+	labelsAndValues := make([]string, 0, 2*len(values))
+	for _, v := range values {
+		if slices.Contains(config.Labels, v.label) {
+			labelsAndValues = append(labelsAndValues, v.label, v.value)
+		}
+	}
+	return labelsAndValues
+}
+
 type blockSyncReactor interface {
 	SwitchToBlockSync(state sm.State) error
 }
@@ -126,7 +182,7 @@ func initDBs(config *cfg.Config, dbProvider cfg.DBProvider) (blockStore *store.B
 	if err != nil {
 		return
 	}
-	blockStore = store.NewBlockStore(blockStoreDB)
+	blockStore = store.NewBlockStore(blockStoreDB, store.WithSparseRetention(config.Storage.Pruning.SparseRetention()))
 
 	stateDB, err = dbProvider(&cfg.DBContext{ID: "state", Config: config})
 	if err != nil {
@@ -136,8 +192,13 @@ func initDBs(config *cfg.Config, dbProvider cfg.DBProvider) (blockStore *store.B
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger, metrics *proxy.Metrics) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator, metrics)
+func createAndStartProxyAppConns(
+	clientCreator proxy.ClientCreator,
+	logger log.Logger,
+	metrics *proxy.Metrics,
+	options ...proxy.AppConnsOption,
+) (proxy.AppConns, error) {
+	proxyApp := proxy.NewAppConns(clientCreator, metrics, options...)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -145,8 +206,8 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
-func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
-	eventBus := types.NewEventBus()
+func createAndStartEventBus(logger log.Logger, metrics *cmtpubsub.Metrics) (*types.EventBus, error) {
+	eventBus := types.NewEventBus(cmtpubsub.WithMetrics(metrics))
 	eventBus.SetLogger(logger.With("module", "events"))
 	if err := eventBus.Start(); err != nil {
 		return nil, err
@@ -154,18 +215,33 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
+// loadEventSchema returns the event schema declared by the application in its
+// InfoResponse, nil if it declares none.
+func loadEventSchema(ctx context.Context, proxyApp proxy.AppConns) (*types.EventSchema, error) {
+	res, err := proxyApp.Query().Info(ctx, proxy.InfoRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error calling Info: %w", err)
+	}
+	schema, err := types.EventSchemaFromProto(res.EventSchema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event schema: %w", err)
+	}
+	return schema, nil
+}
+
 func createAndStartIndexerService(
 	config *cfg.Config,
 	chainID string,
 	dbProvider cfg.DBProvider,
 	eventBus *types.EventBus,
+	eventSchema *types.EventSchema,
 	logger log.Logger,
 ) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
 	var (
 		txIndexer    txindex.TxIndexer
 		blockIndexer indexer.BlockIndexer
 	)
-	txIndexer, blockIndexer, err := block.IndexerFromConfig(config, dbProvider, chainID)
+	txIndexer, blockIndexer, err := block.IndexerFromConfigWithSchema(config, dbProvider, chainID, eventSchema)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -182,6 +258,77 @@ func createAndStartIndexerService(
 	return indexerService, txIndexer, blockIndexer, nil
 }
 
+// createMemTuner returns the tuner applying the memory settings of config, and
+// recording the statistics of the garbage collector if the metrics are enabled.
+func createMemTuner(config *cfg.Config, chainID string, nodeID p2p.ID, logger log.Logger) *memtune.Tuner {
+	metrics := memtune.NopMetrics()
+	if config.Instrumentation.IsMetricsEnabled() {
+		metrics = memtune.PrometheusMetrics(config.Instrumentation.Namespace,
+			MetricsLabels(config.Instrumentation, chainID, config.Moniker, nodeID)...)
+	}
+	tuner := memtune.NewTuner(memtune.Config{
+		GCPercent:   config.GCPercent,
+		MemoryLimit: config.MemoryLimit,
+		Ballast:     config.MemoryBallast,
+	}, metrics)
+	tuner.SetLogger(logger.With("module", "memtune"))
+	return tuner
+}
+
+// configureStorageSampler sets the logger of the storage sampler, and its
+// metrics if they are enabled.
+func configureStorageSampler(sampler *storageinfo.Sampler, config *cfg.Config, chainID string, nodeID p2p.ID, logger log.Logger) {
+	sampler.SetLogger(logger.With("module", "storage"))
+	if config.Instrumentation.IsMetricsEnabled() {
+		sampler.SetMetrics(storageinfo.PrometheusMetrics(config.Instrumentation.Namespace,
+			MetricsLabels(config.Instrumentation, chainID, config.Moniker, nodeID)...))
+	}
+}
+
+// snapshotsRetainHeight returns the retain height of the snapshots of the
+// application: the height of the oldest one, whose light blocks the nodes
+// restoring it fetch.
+func snapshotsRetainHeight(snapshotConn proxy.AppConnSnapshot) sm.RetainHeightFunc {
+	return func() (int64, error) {
+		resp, err := snapshotConn.ListSnapshots(context.TODO(), &abci.ListSnapshotsRequest{})
+		if err != nil {
+			return 0, err
+		}
+		var height int64
+		for _, snapshot := range resp.Snapshots {
+			if h := int64(snapshot.Height); height == 0 || h < height {
+				height = h
+			}
+		}
+		return height, nil
+	}
+}
+
+// createAndStartEventLog returns the started event log, or nil if the event log
+// service is disabled.
+func createAndStartEventLog(
+	config *cfg.Config,
+	dbProvider cfg.DBProvider,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	eventBus *types.EventBus,
+	logger log.Logger,
+) (*eventlog.Log, error) {
+	if !config.GRPC.EventLogService.Enabled {
+		return nil, nil
+	}
+	db, err := dbProvider(&cfg.DBContext{ID: "eventlog", Config: config})
+	if err != nil {
+		return nil, err
+	}
+	eventLog := eventlog.NewLog(db, config.GRPC.EventLogService.RetainBlocks, blockStore, stateStore, eventBus)
+	eventLog.SetLogger(logger.With("module", "eventlog"))
+	if err := eventLog.Start(); err != nil {
+		return nil, err
+	}
+	return eventLog, nil
+}
+
 func doHandshake(
 	ctx context.Context,
 	stateStore sm.Store,
@@ -201,6 +348,51 @@ func doHandshake(
 	return nil
 }
 
+// recoveryHandshake returns the handshake run when the node reconnects to the
+// application after an outage. It replays the blocks the application is
+// missing, without touching the state and block stores, which are owned by the
+// consensus meanwhile.
+func recoveryHandshake(
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	genDoc *types.GenesisDoc,
+	consensusLogger log.Logger,
+) proxy.HandshakeFunc {
+	return func(ctx context.Context, proxyApp proxy.AppConns) error {
+		state, err := stateStore.Load()
+		if err != nil {
+			return fmt.Errorf("error loading state: %w", err)
+		}
+
+		res, err := proxyApp.Query().Info(ctx, proxy.InfoRequest)
+		if err != nil {
+			return fmt.Errorf("error calling Info: %w", err)
+		}
+		if res.LastBlockHeight > state.LastBlockHeight {
+			// The application committed the block being executed before the
+			// outage. Its Commit is retried by the consensus.
+			consensusLogger.Info("Application is ahead of the state, skipping handshake",
+				"app_height", res.LastBlockHeight, "state_height", state.LastBlockHeight)
+			return nil
+		}
+
+		handshaker := cs.NewHandshaker(stateStore, state, cappedBlockStore{blockStore, state.LastBlockHeight}, genDoc)
+		handshaker.SetLogger(consensusLogger)
+		return handshaker.Handshake(ctx, proxyApp)
+	}
+}
+
+// cappedBlockStore is a block store which does not report the blocks above the
+// given height, so that the handshake only replays blocks to the application.
+type cappedBlockStore struct {
+	sm.BlockStore
+	height int64
+}
+
+func (bs cappedBlockStore) Height() int64 {
+	return bs.height
+}
+
 func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusLogger log.Logger) {
 	// Log the version info.
 	logger.Info("Version info",
@@ -241,6 +433,7 @@ func createMempoolAndMempoolReactor(
 	config *cfg.Config,
 	proxyApp proxy.AppConns,
 	state sm.State,
+	eventBus *types.EventBus,
 	waitSync bool,
 	memplMetrics *mempl.Metrics,
 	logger log.Logger,
@@ -256,6 +449,7 @@ func createMempoolAndMempoolReactor(
 			mempl.WithMetrics(memplMetrics),
 			mempl.WithPreCheck(sm.TxPreCheck(state)),
 			mempl.WithPostCheck(sm.TxPostCheck(state)),
+			mempl.WithEventBus(eventBus),
 		)
 		mp.SetLogger(logger)
 		reactor := mempl.NewReactor(
@@ -303,10 +497,34 @@ func createBlocksyncReactor(config *cfg.Config,
 	logger log.Logger,
 	metrics *blocksync.Metrics,
 	offlineStateSyncHeight int64,
+	upgrades *upgrade.Manager,
+	sigVerifyPool *sigverify.Pool,
 ) (bcReactor p2p.Reactor, err error) {
 	switch config.BlockSync.Version {
 	case "v0":
-		bcReactor = blocksync.NewReactor(state.Copy(), blockExec, blockStore, blockSync, metrics, offlineStateSyncHeight)
+		var queueDB dbm.DB
+		if config.BlockSync.QueueEnabled() {
+			queueDB, err = dbm.NewDB("blocksync", dbm.BackendType(config.DBBackend), config.BlockSync.QueueDir())
+			if err != nil {
+				return nil, fmt.Errorf("could not open blocksync queue: %w", err)
+			}
+		}
+		witnesses := make([]lightprovider.Provider, 0, len(config.BlockSync.Witnesses))
+		for _, server := range config.BlockSync.Witnesses {
+			witness, err := lighthttp.New(state.ChainID, server)
+			if err != nil {
+				return nil, fmt.Errorf("could not set up blocksync witness %s: %w", server, err)
+			}
+			witnesses = append(witnesses, witness)
+		}
+		bcReactor = blocksync.NewReactor(state.Copy(), blockExec, blockStore, blockSync, metrics, offlineStateSyncHeight,
+			blocksync.WithVerifyWorkers(config.BlockSync.VerifyWorkers),
+			blocksync.WithSigVerifyPool(sigVerifyPool),
+			blocksync.WithPendingBlocks(config.BlockSync.MaxPendingBlocks, config.BlockSync.MaxMemoryBlocks, queueDB),
+			blocksync.WithCheckpointInterval(config.BlockSync.CheckpointInterval),
+			blocksync.WithWitnesses(witnesses),
+			blocksync.WithUpgradeManager(upgrades),
+		)
 	case "v1", "v2":
 		return nil, fmt.Errorf("block sync version %s has been deprecated. Please use v0", config.BlockSync.Version)
 	default:
@@ -329,6 +547,8 @@ func createConsensusReactor(config *cfg.Config,
 	eventBus *types.EventBus,
 	consensusLogger log.Logger,
 	offlineStateSyncHeight int64,
+	upgrades *upgrade.Manager,
+	sigVerifyPool *sigverify.Pool,
 ) (*cs.Reactor, *cs.State) {
 	consensusState := cs.NewState(
 		config.Consensus,
@@ -339,12 +559,19 @@ func createConsensusReactor(config *cfg.Config,
 		evidencePool,
 		cs.StateMetrics(csMetrics),
 		cs.OfflineStateSyncHeight(offlineStateSyncHeight),
+		cs.UpgradeManager(upgrades),
 	)
 	consensusState.SetLogger(consensusLogger)
 	if privValidator != nil {
 		consensusState.SetPrivValidator(privValidator)
 	}
-	consensusReactor := cs.NewReactor(consensusState, waitSync, cs.ReactorMetrics(csMetrics))
+	consensusReactor := cs.NewReactor(
+		consensusState,
+		waitSync,
+		cs.ReactorMetrics(csMetrics),
+		cs.ReactorMempool(mempool),
+		cs.ReactorSigVerifyPool(sigVerifyPool),
+	)
 	consensusReactor.SetLogger(consensusLogger)
 	// services which will be publishing and/or subscribing for messages (events)
 	// consensusReactor will set it on consensusState and blockExecutor
@@ -360,6 +587,7 @@ func createTransport(
 ) (
 	*p2p.MultiplexTransport,
 	[]p2p.PeerFilterFunc,
+	error,
 ) {
 	var (
 		mConnConfig = p2p.MConnConfig(config.P2P)
@@ -415,10 +643,40 @@ func createTransport(
 	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
 
 	// Limit the number of incoming connections.
-	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+	max := config.P2P.MaxNumInboundPeers + len(config.P2P.AllUnconditionalPeerIDs())
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
 
-	return transport, peerFilters
+	rules, err := p2p.NewHandshakeRules(
+		config.P2P.AllowedMonikers, config.P2P.DeniedMonikers,
+		config.P2P.AllowedAppVersions, config.P2P.DeniedAppVersions,
+		config.P2P.AllowedBlockVersions, config.P2P.DeniedBlockVersions,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create handshake rules: %w", err)
+	}
+	p2p.MultiplexTransportHandshakeRules(rules)(transport)
+
+	return transport, peerFilters, nil
+}
+
+// checkLastBlockTime checks the time of the last block is not more than
+// max_clock_skew ahead of the local clock, which is then behind: it logs an
+// error, or returns one if refuse_clock_skew is set.
+func checkLastBlockTime(config *cfg.P2PConfig, state sm.State, logger log.Logger) error {
+	if config.MaxClockSkew == 0 || state.LastBlockHeight == 0 {
+		return nil
+	}
+	ahead := state.LastBlockTime.Sub(cmttime.Now())
+	if ahead <= config.MaxClockSkew {
+		return nil
+	}
+	if config.RefuseClockSkew {
+		return fmt.Errorf("the time of the last block %d is %v ahead of the local clock, more than max_clock_skew (%v)",
+			state.LastBlockHeight, ahead, config.MaxClockSkew)
+	}
+	logger.Error("Time of the last block ahead of the local clock; check its synchronization",
+		"height", state.LastBlockHeight, "ahead", ahead, "max", config.MaxClockSkew)
+	return nil
 }
 
 func createSwitch(config *cfg.Config,
@@ -456,6 +714,26 @@ func createSwitch(config *cfg.Config,
 	return sw
 }
 
+// setPersistentPeerPolicies sets on the switch the dial policies of the
+// persistent peers listed in the persistent_peer table.
+func setPersistentPeerPolicies(config *cfg.Config, sw *p2p.Switch) error {
+	for _, peer := range config.P2P.PersistentPeerConfigs {
+		chIDs := make([]byte, len(peer.PreferredChannels))
+		for i, ch := range peer.PreferredChannels {
+			chIDs[i] = byte(ch)
+		}
+		err := sw.SetPersistentPeerPolicy(p2p.ID(peer.ID()), p2p.PersistentPeerPolicy{
+			DialInterval:         peer.DialInterval,
+			MaxReconnectAttempts: peer.MaxReconnectAttempts,
+			PreferredChannels:    chIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid persistent peer %s: %w", peer.Address, err)
+		}
+	}
+	return nil
+}
+
 func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 	p2pLogger log.Logger, nodeKey *p2p.NodeKey,
 ) (pex.AddrBook, error) {
@@ -498,6 +776,7 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 			// https://github.com/tendermint/tendermint/issues/3523
 			SeedDisconnectWaitPeriod:     28 * time.Hour,
 			PersistentPeersMaxDialPeriod: config.P2P.PersistentPeersMaxDialPeriod,
+			PreferIPv6:                   config.P2P.PreferIPv6,
 		})
 	pexReactor.SetLogger(logger.With("module", "pex"))
 	sw.AddReactor("PEX", pexReactor)
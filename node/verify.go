@@ -0,0 +1,153 @@
+package node
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cs "github.com/cometbft/cometbft/internal/consensus"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// verifyOnStart cross-checks the block store, the state, the consensus WAL and
+// the private validator state before the node starts. It repairs the
+// inconsistencies known to be safe to repair, and returns an error reporting
+// the others, in which case the node must not start. pvAddress is the address
+// of the private validator if its state is a local file, or nil.
+func verifyOnStart(
+	config *cfg.Config,
+	state sm.State,
+	blockStore sm.BlockStore,
+	pvAddress types.Address,
+	logger log.Logger,
+) error {
+	var problems []error
+	storeBase, storeHeight := blockStore.Base(), blockStore.Height()
+	stateHeight := state.LastBlockHeight
+	logger.Info("Verifying the data on start",
+		"store_base", storeBase, "store_height", storeHeight, "state_height", stateHeight)
+
+	// The block store is one block ahead of the state if the node stopped
+	// before applying its last block, which the handshake replays. It is empty
+	// after a state sync, until the first block is synced.
+	switch {
+	case storeHeight == 0:
+	case stateHeight > storeHeight:
+		problems = append(problems, fmt.Errorf(
+			"the state (height %d) is ahead of the block store (height %d)", stateHeight, storeHeight))
+	case storeHeight > stateHeight+1:
+		problems = append(problems, fmt.Errorf(
+			"the block store (height %d) is more than one block ahead of the state (height %d)", storeHeight, stateHeight))
+	case blockStore.LoadBlockMeta(storeHeight) == nil:
+		problems = append(problems, fmt.Errorf("the block store misses its last block %d", storeHeight))
+	case stateHeight > 0 && stateHeight >= storeBase:
+		if meta := blockStore.LoadBlockMeta(stateHeight); meta != nil && !meta.BlockID.Equals(state.LastBlockID) {
+			problems = append(problems, fmt.Errorf(
+				"the block %d of the block store (%v) is not the last block of the state (%v)",
+				stateHeight, meta.BlockID, state.LastBlockID))
+		}
+	}
+
+	if err := verifyWAL(config.Consensus.WalFile(), max(storeHeight, stateHeight), len(problems) == 0, logger); err != nil {
+		problems = append(problems, err)
+	}
+
+	if pvAddress != nil {
+		if err := verifyPrivValidatorState(config.PrivValidatorStateFile(), blockStore, pvAddress, logger); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("startup verification failed, refusing to start:\n%w", errors.Join(problems...))
+	}
+	logger.Info("Verified the data on start")
+	return nil
+}
+
+// verifyWAL repairs the corrupted tail of the consensus WAL, which a crash
+// while writing may leave. If reset, it also drops the heights the WAL holds
+// above lastHeight, the last height of the block store or state: the WAL marks
+// the end of a height once its block is saved, so it is only ahead if the
+// data was restored or rolled back without it, and its replay would fail. The
+// private validator state still prevents signing these heights again.
+func verifyWAL(walFile string, lastHeight int64, reset bool, logger log.Logger) error {
+	walHeight, corrupted, err := cs.WALEndHeight(walFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the consensus WAL: %w", err)
+	}
+	if corrupted {
+		logger.Info("Repairing the corrupted consensus WAL", "file", walFile)
+		if err := cs.RepairWAL(walFile); err != nil {
+			return fmt.Errorf("failed to repair the consensus WAL: %w", err)
+		}
+		walHeight, corrupted, err = cs.WALEndHeight(walFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the consensus WAL: %w", err)
+		}
+		if corrupted {
+			return fmt.Errorf("the rotated files of the consensus WAL %s are corrupted", walFile)
+		}
+	}
+	if walHeight > lastHeight && reset {
+		logger.Info("Resetting the consensus WAL ahead of the block store",
+			"wal_height", walHeight, "height", lastHeight)
+		if err := cs.ResetWAL(walFile, lastHeight); err != nil {
+			return fmt.Errorf("failed to reset the consensus WAL: %w", err)
+		}
+	}
+	return nil
+}
+
+// verifyPrivValidatorState checks that the private validator state is intact,
+// and that it is not behind the last block the validator signed, as it
+// happens when an old copy of the file is restored: signing from it may lead
+// to double signing.
+func verifyPrivValidatorState(
+	stateFile string,
+	blockStore sm.BlockStore,
+	address types.Address,
+	logger log.Logger,
+) error {
+	if !cmtos.FileExists(stateFile) {
+		return nil
+	}
+	pvState, err := privval.LoadFilePVState(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the private validator state: %w", err)
+	}
+	if err := pvState.Check(cmttime.Now(), lastSignedHeight(blockStore, address)); err != nil {
+		return fmt.Errorf("the private validator state is unsafe to sign from: %w", err)
+	}
+	if storeHeight := blockStore.Height(); storeHeight > 0 && pvState.Height > storeHeight+1 {
+		logger.Error("The private validator state is ahead of the block store; "+
+			"the validator will not sign until the chain reaches its height",
+			"pv_height", pvState.Height, "store_height", storeHeight)
+	}
+	return nil
+}
+
+// lastSignedHeight returns the height of the last commit of the block store,
+// if it holds a signature of the validator, or 0.
+func lastSignedHeight(blockStore sm.BlockStore, address types.Address) int64 {
+	height := blockStore.Height()
+	if height == 0 {
+		return 0
+	}
+	commit := blockStore.LoadSeenCommit(height)
+	if commit == nil {
+		return 0
+	}
+	for _, sig := range commit.Signatures {
+		if sig.BlockIDFlag != types.BlockIDFlagAbsent && bytes.Equal(sig.ValidatorAddress, address) {
+			return height
+		}
+	}
+	return 0
+}
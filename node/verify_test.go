@@ -0,0 +1,57 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cs "github.com/cometbft/cometbft/internal/consensus"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/state/mocks"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestVerifyOnStart(t *testing.T) {
+	config := test.ResetTestRoot("node_verify_test")
+	defer os.RemoveAll(config.RootDir)
+
+	blockID := types.BlockID{Hash: tmhash.Sum([]byte("block"))}
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(int64(5))
+	blockStore.On("LoadBlockMeta", int64(5)).Return(&types.BlockMeta{BlockID: blockID})
+
+	// The WAL is ahead of the block store: it is reset.
+	walFile := config.Consensus.WalFile()
+	require.NoError(t, os.MkdirAll(filepath.Dir(walFile), 0o700))
+	require.NoError(t, os.WriteFile(walFile, nil, 0o600))
+	require.NoError(t, cs.ResetWAL(walFile, 7))
+	state := sm.State{LastBlockHeight: 5, LastBlockID: blockID}
+	require.NoError(t, verifyOnStart(config, state, blockStore, nil, log.TestingLogger()))
+	walHeight, corrupted, err := cs.WALEndHeight(walFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, walHeight)
+	assert.False(t, corrupted)
+
+	// The block store is one block ahead of the state: the handshake replays
+	// the last block.
+	state = sm.State{LastBlockHeight: 4}
+	blockStore.On("LoadBlockMeta", int64(4)).Return(&types.BlockMeta{})
+	require.NoError(t, verifyOnStart(config, state, blockStore, nil, log.TestingLogger()))
+
+	// The state is ahead of the block store.
+	state = sm.State{LastBlockHeight: 6}
+	require.ErrorContains(t, verifyOnStart(config, state, blockStore, nil, log.TestingLogger()),
+		"the state (height 6) is ahead of the block store (height 5)")
+
+	// The last block of the state is not the one of the block store.
+	state = sm.State{LastBlockHeight: 5, LastBlockID: types.BlockID{Hash: tmhash.Sum([]byte("other"))}}
+	require.ErrorContains(t, verifyOnStart(config, state, blockStore, nil, log.TestingLogger()),
+		"is not the last block of the state")
+}
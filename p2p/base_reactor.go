@@ -43,6 +43,21 @@ type Reactor interface {
 	Receive(e Envelope)
 }
 
+// BufferedReactor is a Reactor receiving the bytes of its messages, in pooled
+// buffers, instead of the unmarshaled messages. This saves copying the bytes
+// of the messages the reactor can handle, or discard, without unmarshaling
+// them. ReceiveBuffer is then called instead of Receive.
+type BufferedReactor interface {
+	Reactor
+
+	// ReceiveBuffer is called by the switch when a message is received from
+	// any connected peer on any of the channels registered by the reactor.
+	// The reactor must release the buffer once done with the message bytes,
+	// and must not use them afterwards. Like Receive, it runs in the receive
+	// routine of the peer, so it should not block.
+	ReceiveBuffer(e BufferEnvelope)
+}
+
 //--------------------------------------
 
 type BaseReactor struct {
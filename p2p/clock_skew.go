@@ -0,0 +1,118 @@
+package p2p
+
+import (
+	"slices"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+const (
+	// Number of peers whose latest sample is kept.
+	maxClockSkewSamples = 100
+	// Number of peers needed to report a skew.
+	minClockSkewSamples = 3
+)
+
+// clockSample is the time a peer reported in the handshake, with the local
+// times the handshake started and ended.
+type clockSample struct {
+	remote     time.Time
+	start, end time.Time
+}
+
+// skew returns the skew of the local clock relative to the clock of the peer,
+// assuming the peer reported its time halfway through the handshake. The
+// error is at most half of the duration of the handshake.
+func (s clockSample) skew() time.Duration {
+	return s.start.Add(s.end.Sub(s.start) / 2).Sub(s.remote)
+}
+
+// ClockSkewDetector estimates the skew of the local clock relative to the
+// clocks of the peers, from the times they report in the handshake. The
+// estimate is the median of the latest samples of the last
+// maxClockSkewSamples peers, so that a minority of peers with skewed clocks
+// doesn't affect it.
+//
+// A skewed clock silently breaks BFT time, and gets the proposals of a
+// validator rejected as untimely with proposer-based timestamps.
+type ClockSkewDetector struct {
+	maxSkew time.Duration
+	metrics *Metrics
+	logger  log.Logger
+
+	mtx     cmtsync.Mutex
+	samples map[ID]time.Duration
+	peers   []ID // in the order of their latest sample
+	skewed  bool
+}
+
+// NewClockSkewDetector returns a ClockSkewDetector logging an error when the
+// estimated skew exceeds maxSkew.
+func NewClockSkewDetector(maxSkew time.Duration, metrics *Metrics, logger log.Logger) *ClockSkewDetector {
+	return &ClockSkewDetector{
+		maxSkew: maxSkew,
+		metrics: metrics,
+		logger:  logger,
+		samples: make(map[ID]time.Duration),
+	}
+}
+
+// AddSample records the skew of the local clock relative to the clock of the
+// given peer, replacing the previous sample of the peer.
+func (d *ClockSkewDetector) AddSample(id ID, skew time.Duration) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if _, ok := d.samples[id]; ok {
+		d.peers = slices.DeleteFunc(d.peers, func(p ID) bool { return p == id })
+	} else if len(d.peers) == maxClockSkewSamples {
+		delete(d.samples, d.peers[0])
+		d.peers = d.peers[1:]
+	}
+	d.samples[id] = skew
+	d.peers = append(d.peers, id)
+
+	estimate, n := d.estimate()
+	if n < minClockSkewSamples {
+		return
+	}
+	d.metrics.ClockSkewSeconds.Set(estimate.Seconds())
+
+	skewed := estimate > d.maxSkew || estimate < -d.maxSkew
+	switch {
+	case skewed && !d.skewed:
+		d.logger.Error("Local clock skewed relative to the clocks of the peers; check its synchronization",
+			"skew", estimate, "max", d.maxSkew, "peers", n)
+	case !skewed && d.skewed:
+		d.logger.Info("Local clock back in sync with the clocks of the peers", "skew", estimate, "peers", n)
+	}
+	d.skewed = skewed
+}
+
+// Skew returns the estimated skew of the local clock, positive if it is ahead,
+// and the number of peers it is estimated from.
+func (d *ClockSkewDetector) Skew() (time.Duration, int) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.estimate()
+}
+
+// estimate returns the median of the samples. The caller must hold the mutex
+// lock.
+func (d *ClockSkewDetector) estimate() (time.Duration, int) {
+	n := len(d.peers)
+	if n == 0 {
+		return 0, 0
+	}
+	skews := make([]time.Duration, 0, n)
+	for _, skew := range d.samples {
+		skews = append(skews, skew)
+	}
+	slices.Sort(skews)
+	if n%2 == 1 {
+		return skews[n/2], n
+	}
+	return (skews[n/2-1] + skews[n/2]) / 2, n
+}
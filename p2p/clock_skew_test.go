@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestClockSampleSkew(t *testing.T) {
+	start := time.Now()
+	sample := clockSample{
+		remote: start.Add(-2 * time.Second),
+		start:  start,
+		end:    start.Add(100 * time.Millisecond),
+	}
+	assert.Equal(t, 2050*time.Millisecond, sample.skew())
+}
+
+func TestClockSkewDetector(t *testing.T) {
+	d := NewClockSkewDetector(time.Second, NopMetrics(), log.TestingLogger())
+	skew, n := d.Skew()
+	assert.Zero(t, skew)
+	assert.Zero(t, n)
+
+	// A minority of peers with skewed clocks doesn't affect the estimate.
+	d.AddSample("a", 100*time.Millisecond)
+	d.AddSample("b", -time.Hour)
+	d.AddSample("c", 200*time.Millisecond)
+	skew, n = d.Skew()
+	assert.Equal(t, 100*time.Millisecond, skew)
+	assert.Equal(t, 3, n)
+
+	// The latest sample of a peer replaces the previous one.
+	d.AddSample("b", 300*time.Millisecond)
+	skew, n = d.Skew()
+	assert.Equal(t, 200*time.Millisecond, skew)
+	assert.Equal(t, 3, n)
+
+	// Only the samples of the last peers are kept.
+	for i := 0; i < maxClockSkewSamples; i++ {
+		d.AddSample(ID(fmt.Sprint(i)), 3*time.Second)
+	}
+	skew, n = d.Skew()
+	assert.Equal(t, 3*time.Second, skew)
+	assert.Equal(t, maxClockSkewSamples, n)
+	assert.True(t, d.skewed)
+}
@@ -0,0 +1,66 @@
+package conn
+
+import (
+	"sync"
+)
+
+// maxPooledBufferCapacity is the capacity above which the released buffers
+// are not put back in the pool, so that a few large messages do not pin a lot
+// of memory.
+const maxPooledBufferCapacity = 1 << 20 // 1MB
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return &Buffer{bz: make([]byte, 0, defaultRecvBufferCapacity)}
+	},
+}
+
+// Buffer holds the bytes of a message received on a channel. It is taken from
+// a pool and handed over to the receiver of the message, which must call
+// Release once done with the bytes, for the buffer to be reused.
+type Buffer struct {
+	bz []byte
+}
+
+// newBuffer returns an empty buffer from the pool, with at least the given
+// capacity.
+func newBuffer(capacity int) *Buffer {
+	buf := bufferPool.Get().(*Buffer)
+	if cap(buf.bz) < capacity {
+		buf.bz = make([]byte, 0, capacity)
+	}
+	buf.bz = buf.bz[:0]
+	return buf
+}
+
+// NewBuffer returns a buffer from the pool holding a copy of bz, e.g. to hand a
+// message over to a p2p.BufferedReactor in tests.
+func NewBuffer(bz []byte) *Buffer {
+	buf := newBuffer(len(bz))
+	buf.append(bz)
+	return buf
+}
+
+// Bytes returns the bytes of the message. They must not be used after
+// Release is called.
+func (b *Buffer) Bytes() []byte {
+	return b.bz
+}
+
+// Len returns the number of bytes of the message.
+func (b *Buffer) Len() int {
+	return len(b.bz)
+}
+
+// Release puts the buffer back in the pool. The buffer, and the bytes
+// returned by Bytes, must not be used afterwards.
+func (b *Buffer) Release() {
+	if cap(b.bz) > maxPooledBufferCapacity {
+		return
+	}
+	bufferPool.Put(b)
+}
+
+func (b *Buffer) append(bz []byte) {
+	b.bz = append(b.bz, bz...)
+}
@@ -48,8 +48,9 @@ const (
 )
 
 type (
-	receiveCbFunc func(chID byte, msgBytes []byte)
-	errorCbFunc   func(interface{})
+	receiveCbFunc       func(chID byte, msgBytes []byte)
+	receiveBufferCbFunc func(chID byte, msg *Buffer)
+	errorCbFunc         func(interface{})
 )
 
 /*
@@ -75,7 +76,14 @@ request times out.  The message `msg` is serialized using Protobuf.
 `TrySend(chID, msgBytes)` is a nonblocking call that returns false if the
 channel's queue is full.
 
-Inbound message bytes are handled with an onReceive callback function.
+Inbound message bytes are handled with an onReceive callback function. With
+NewMConnectionWithBuffers, the callback instead receives the messages in pooled
+buffers, which it must release, so that they are not copied.
+
+A channel can be marked busy with `SetChannelBusy(chID, true)`, when the
+messages received on it can not be processed fast enough. The remote end is
+then asked to stop sending messages on this channel, until it is marked not
+busy anymore.
 */
 type MConnection struct {
 	service.BaseService
@@ -83,13 +91,16 @@ type MConnection struct {
 	conn          net.Conn
 	bufConnReader *bufio.Reader
 	bufConnWriter *bufio.Writer
+	protoWriter   protoio.Writer // writes to bufConnWriter, reusing its buffer
 	sendMonitor   *flow.Monitor
 	recvMonitor   *flow.Monitor
 	send          chan struct{}
 	pong          chan struct{}
+	flowControl   chan struct{}
 	channels      []*Channel
 	channelsIdx   map[byte]*Channel
 	onReceive     receiveCbFunc
+	onReceiveBuf  receiveBufferCbFunc
 	onError       errorCbFunc
 	errored       uint32
 	config        MConnConfig
@@ -137,6 +148,10 @@ type MConnConfig struct {
 	// Maximum wait time for pongs
 	PongTimeout time.Duration `mapstructure:"pong_timeout"`
 
+	// If true, the remote end supports flow control, i.e. it stops sending
+	// messages on the channels we mark busy. Set after the handshake.
+	FlowControl bool `mapstructure:"flow_control"`
+
 	// Fuzz connection
 	TestFuzz       bool                   `mapstructure:"test_fuzz"`
 	TestFuzzConfig *config.FuzzConnConfig `mapstructure:"test_fuzz_config"`
@@ -181,14 +196,17 @@ func NewMConnectionWithConfig(
 		panic("pongTimeout must be less than pingInterval (otherwise, next ping will reset pong timer)")
 	}
 
+	bufConnWriter := bufio.NewWriterSize(conn, minWriteBufferSize)
 	mconn := &MConnection{
 		conn:          conn,
 		bufConnReader: bufio.NewReaderSize(conn, minReadBufferSize),
-		bufConnWriter: bufio.NewWriterSize(conn, minWriteBufferSize),
+		bufConnWriter: bufConnWriter,
+		protoWriter:   protoio.NewDelimitedWriter(bufConnWriter),
 		sendMonitor:   flow.New(0, 0),
 		recvMonitor:   flow.New(0, 0),
 		send:          make(chan struct{}, 1),
 		pong:          make(chan struct{}, 1),
+		flowControl:   make(chan struct{}, 1),
 		onReceive:     onReceive,
 		onError:       onError,
 		config:        config,
@@ -215,6 +233,21 @@ func NewMConnectionWithConfig(
 	return mconn
 }
 
+// NewMConnectionWithBuffers wraps net.Conn and creates multiplex connection
+// with a config. The received messages are handed over to onReceive in pooled
+// buffers, which onReceive must release once done with them.
+func NewMConnectionWithBuffers(
+	conn net.Conn,
+	chDescs []*ChannelDescriptor,
+	onReceive receiveBufferCbFunc,
+	onError errorCbFunc,
+	config MConnConfig,
+) *MConnection {
+	mconn := NewMConnectionWithConfig(conn, chDescs, nil, onError, config)
+	mconn.onReceiveBuf = onReceive
+	return mconn
+}
+
 func (c *MConnection) SetLogger(l log.Logger) {
 	c.BaseService.SetLogger(l)
 	for _, ch := range c.channels {
@@ -424,11 +457,39 @@ func (c *MConnection) CanSend(chID byte) bool {
 	return channel.canSend()
 }
 
+// SetChannelBusy marks the channel with the given ID as busy, or not busy
+// anymore. While a channel is busy, the remote end is asked to stop sending
+// messages on it, so that the messages are not buffered while the reactor
+// catches up. The messages already in flight are still received. It returns
+// false if the channel is unknown.
+//
+// If the remote end does not support flow control, it has no effect.
+// Goroutine-safe.
+func (c *MConnection) SetChannelBusy(chID byte, busy bool) bool {
+	channel, ok := c.channelsIdx[chID]
+	if !ok {
+		c.Logger.Error(fmt.Sprintf("Unknown channel %X", chID))
+		return false
+	}
+
+	if channel.busy.Swap(busy) == busy || !c.config.FlowControl {
+		return true
+	}
+
+	// Wake up sendRoutine to send a PacketFlowControl.
+	channel.busyPending.Store(true)
+	select {
+	case c.flowControl <- struct{}{}:
+	default:
+	}
+	return true
+}
+
 // sendRoutine polls for packets to send from channels.
 func (c *MConnection) sendRoutine() {
 	defer c._recover()
 
-	protoWriter := protoio.NewDelimitedWriter(c.bufConnWriter)
+	protoWriter := c.protoWriter
 
 FOR_LOOP:
 	for {
@@ -476,6 +537,11 @@ FOR_LOOP:
 			}
 			c.sendMonitor.Update(_n)
 			c.flush()
+		case <-c.flowControl:
+			err = c.sendFlowControl(protoWriter)
+			if err != nil {
+				c.Logger.Error("Failed to send PacketFlowControl", "err", err)
+			}
 		case <-c.quitSendRoutine:
 			break FOR_LOOP
 		case <-c.send:
@@ -505,6 +571,28 @@ FOR_LOOP:
 	close(c.doneSendRoutine)
 }
 
+// sendFlowControl sends a PacketFlowControl for each channel whose busy state
+// changed since the last call.
+func (c *MConnection) sendFlowControl(w protoio.Writer) error {
+	for _, channel := range c.channels {
+		if !channel.busyPending.CompareAndSwap(true, false) {
+			continue
+		}
+		busy := channel.busy.Load()
+		c.Logger.Debug("Send FlowControl", "chID", channel.desc.ID, "busy", busy)
+		n, err := w.WriteMsg(mustWrapPacket(&tmp2p.PacketFlowControl{
+			ChannelID: int32(channel.desc.ID),
+			Busy:      busy,
+		}))
+		if err != nil {
+			return err
+		}
+		c.sendMonitor.Update(n)
+	}
+	c.flush()
+	return nil
+}
+
 // Returns true if messages from channels were exhausted.
 // Blocks in accordance to .sendMonitor throttling.
 func (c *MConnection) sendSomePacketMsgs() bool {
@@ -529,6 +617,10 @@ func (c *MConnection) sendPacketMsg() bool {
 	var leastRatio float32 = math.MaxFloat32
 	var leastChannel *Channel
 	for _, channel := range c.channels {
+		// If the remote end is busy with this channel, skip it
+		if channel.remoteBusy.Load() {
+			continue
+		}
 		// If nothing to send, skip this channel
 		if !channel.isSendPending() {
 			continue
@@ -548,7 +640,7 @@ func (c *MConnection) sendPacketMsg() bool {
 	// c.Logger.Info("Found a msgPacket to send")
 
 	// Make & send a PacketMsg from this channel
-	_n, err := leastChannel.writePacketMsgTo(c.bufConnWriter)
+	_n, err := leastChannel.writePacketMsgTo(c.protoWriter)
 	if err != nil {
 		c.Logger.Error("Failed to write PacketMsg", "err", err)
 		c.stopForError(err)
@@ -567,6 +659,8 @@ func (c *MConnection) recvRoutine() {
 	defer c._recover()
 
 	protoReader := protoio.NewDelimitedReader(c.bufConnReader, c._maxPacketMsgSize)
+	// reused across the packets, the unmarshaling resets it
+	var packet tmp2p.Packet
 
 FOR_LOOP:
 	for {
@@ -588,8 +682,6 @@ FOR_LOOP:
 		*/
 
 		// Read packet type
-		var packet tmp2p.Packet
-
 		_n, err := protoReader.ReadMsg(&packet)
 		c.recvMonitor.Update(_n)
 		if err != nil {
@@ -639,8 +731,10 @@ FOR_LOOP:
 				c.stopForError(err)
 				break FOR_LOOP
 			}
+			channel.recvMonitor.Update(_n)
+			channel.bytesReceived.Add(int64(_n))
 
-			msgBytes, err := channel.recvPacketMsg(*pkt.PacketMsg)
+			msg, err := channel.recvPacketMsg(*pkt.PacketMsg)
 			if err != nil {
 				if c.IsRunning() {
 					c.Logger.Debug("Connection failed @ recvRoutine", "conn", c, "err", err)
@@ -648,10 +742,30 @@ FOR_LOOP:
 				}
 				break FOR_LOOP
 			}
-			if msgBytes != nil {
-				c.Logger.Debug("Received bytes", "chID", channelID, "msgBytes", msgBytes)
+			if msg != nil {
+				c.Logger.Debug("Received bytes", "chID", channelID, "msgBytes", msg.Bytes())
 				// NOTE: This means the reactor.Receive runs in the same thread as the p2p recv routine
-				c.onReceive(channelID, msgBytes)
+				c.receive(channelID, msg)
+			}
+		case *tmp2p.Packet_PacketFlowControl:
+			channelID := byte(pkt.PacketFlowControl.ChannelID)
+			channel, ok := c.channelsIdx[channelID]
+			if pkt.PacketFlowControl.ChannelID < 0 || pkt.PacketFlowControl.ChannelID > math.MaxUint8 || !ok || channel == nil {
+				err := fmt.Errorf("unknown channel %X", pkt.PacketFlowControl.ChannelID)
+				c.Logger.Debug("Connection failed @ recvRoutine", "conn", c, "err", err)
+				c.stopForError(err)
+				break FOR_LOOP
+			}
+
+			busy := pkt.PacketFlowControl.Busy
+			c.Logger.Debug("Receive FlowControl", "chID", channelID, "busy", busy)
+			channel.remoteBusy.Store(busy)
+			if !busy {
+				// Wake up sendRoutine to resume sending on this channel.
+				select {
+				case c.send <- struct{}{}:
+				default:
+				}
 			}
 		default:
 			err := fmt.Errorf("unknown message type %v", reflect.TypeOf(packet))
@@ -669,6 +783,18 @@ FOR_LOOP:
 	}
 }
 
+// receive hands msg over to the receive callback.
+func (c *MConnection) receive(chID byte, msg *Buffer) {
+	if c.onReceiveBuf != nil {
+		c.onReceiveBuf(chID, msg)
+		return
+	}
+
+	// The bytes are only valid during the call to onReceive.
+	c.onReceive(chID, msg.Bytes())
+	msg.Release()
+}
+
 // not goroutine-safe.
 func (c *MConnection) stopPongTimer() {
 	if c.pongTimer != nil {
@@ -703,6 +829,14 @@ type ChannelStatus struct {
 	SendQueueSize     int
 	Priority          int
 	RecentlySent      int64
+	Busy              bool
+	RemoteBusy        bool
+	SendRate          int64 // bytes/s, moving average
+	RecvRate          int64 // bytes/s, moving average
+	BytesSent         int64
+	BytesReceived     int64
+	MsgsSent          int64
+	MsgsReceived      int64
 }
 
 func (c *MConnection) Status() ConnectionStatus {
@@ -713,12 +847,21 @@ func (c *MConnection) Status() ConnectionStatus {
 	status.Channels = make([]ChannelStatus, len(c.channels))
 	for i, channel := range c.channels {
 		channel := channel
+		sendStatus, recvStatus := channel.sendMonitor.Status(), channel.recvMonitor.Status()
 		status.Channels[i] = ChannelStatus{
 			ID:                channel.desc.ID,
 			SendQueueCapacity: cap(channel.sendQueue),
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
+			Busy:              channel.busy.Load(),
+			RemoteBusy:        channel.remoteBusy.Load(),
+			SendRate:          sendStatus.CurRate,
+			RecvRate:          recvStatus.CurRate,
+			BytesSent:         channel.bytesSent.Load(),
+			BytesReceived:     channel.bytesReceived.Load(),
+			MsgsSent:          channel.msgsSent.Load(),
+			MsgsReceived:      channel.msgsReceived.Load(),
 		}
 	}
 	return status
@@ -755,11 +898,23 @@ type Channel struct {
 	conn          *MConnection
 	desc          ChannelDescriptor
 	sendQueue     chan []byte
-	sendQueueSize int32 // atomic.
-	recving       []byte
+	sendQueueSize int32   // atomic.
+	recving       *Buffer // message being received, nil if none
 	sending       []byte
 	recentlySent  int64 // exponential moving average
 
+	busy        atomic.Bool // we asked the remote end to stop sending
+	busyPending atomic.Bool // busy has to be sent to the remote end
+	remoteBusy  atomic.Bool // the remote end asked us to stop sending
+
+	// bandwidth and messages of the channel, for the connection status.
+	sendMonitor   *flow.Monitor
+	recvMonitor   *flow.Monitor
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+	msgsSent      atomic.Int64
+	msgsReceived  atomic.Int64
+
 	maxPacketMsgPayloadSize int
 
 	Logger log.Logger
@@ -774,7 +929,8 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 		conn:                    conn,
 		desc:                    desc,
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
-		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
+		sendMonitor:             flow.New(0, 0),
+		recvMonitor:             flow.New(0, 0),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 	}
 }
@@ -843,6 +999,7 @@ func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
 		packet.EOF = true
 		ch.sending = nil
 		atomic.AddInt32(&ch.sendQueueSize, -1) // decrement sendQueueSize
+		ch.msgsSent.Add(1)
 	} else {
 		packet.EOF = false
 		ch.sending = ch.sending[cmtmath.MinInt(maxSize, len(ch.sending)):]
@@ -850,34 +1007,40 @@ func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
 	return packet
 }
 
-// Writes next PacketMsg to w and updates c.recentlySent.
+// Writes next PacketMsg to w and updates c.recentlySent. The PacketMsg is
+// taken from a pool, for sending it not to allocate.
 // Not goroutine-safe.
-func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, err error) {
-	packet := ch.nextPacketMsg()
-	n, err = protoio.NewDelimitedWriter(w).WriteMsg(mustWrapPacket(&packet))
+func (ch *Channel) writePacketMsgTo(w protoio.Writer) (n int, err error) {
+	packet, packetMsg := getPacketMsg()
+	*packetMsg = ch.nextPacketMsg()
+	n, err = w.WriteMsg(packet)
+	putPacketMsg(packet)
 	atomic.AddInt64(&ch.recentlySent, int64(n))
+	ch.sendMonitor.Update(n)
+	ch.bytesSent.Add(int64(n))
 	return
 }
 
-// Handles incoming PacketMsgs. It returns a message bytes if message is
-// complete. NOTE message bytes may change on next call to recvPacketMsg.
+// Handles incoming PacketMsgs. It returns the message if it is complete, in a
+// buffer the caller has to release.
 // Not goroutine-safe.
-func (ch *Channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
+func (ch *Channel) recvPacketMsg(packet tmp2p.PacketMsg) (*Buffer, error) {
 	ch.Logger.Debug("Read PacketMsg", "conn", ch.conn, "packet", packet)
-	recvCap, recvReceived := ch.desc.RecvMessageCapacity, len(ch.recving)+len(packet.Data)
+	if ch.recving == nil {
+		ch.recving = newBuffer(ch.desc.RecvBufferCapacity)
+	}
+	recvCap, recvReceived := ch.desc.RecvMessageCapacity, ch.recving.Len()+len(packet.Data)
 	if recvCap < recvReceived {
 		return nil, fmt.Errorf("received message exceeds available capacity: %v < %v", recvCap, recvReceived)
 	}
-	ch.recving = append(ch.recving, packet.Data...)
+	ch.recving.append(packet.Data)
 	if packet.EOF {
-		msgBytes := ch.recving
-
-		// clear the slice without re-allocating.
-		// http://stackoverflow.com/questions/16971741/how-do-you-clear-a-slice-in-go
-		//   suggests this could be a memory leak, but we might as well keep the memory for the channel until it closes,
-		//	at which point the recving slice stops being used and should be garbage collected
-		ch.recving = ch.recving[:0] // make([]byte, 0, ch.desc.RecvBufferCapacity)
-		return msgBytes, nil
+		// The buffer is handed over to the receiver, the next message is
+		// received in a new one.
+		msg := ch.recving
+		ch.recving = nil
+		ch.msgsReceived.Add(1)
+		return msg, nil
 	}
 	return nil, nil
 }
@@ -918,6 +1081,12 @@ func mustWrapPacket(pb proto.Message) *tmp2p.Packet {
 				PacketMsg: pb,
 			},
 		}
+	case *tmp2p.PacketFlowControl:
+		msg = tmp2p.Packet{
+			Sum: &tmp2p.Packet_PacketFlowControl{
+				PacketFlowControl: pb,
+			},
+		}
 	default:
 		panic(fmt.Errorf("unknown packet type %T", pb))
 	}
@@ -2,6 +2,7 @@ package conn
 
 import (
 	"encoding/hex"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	tmp2p "github.com/cometbft/cometbft/api/cometbft/p2p/v1"
 	pbtypes "github.com/cometbft/cometbft/api/cometbft/types/v1"
 	"github.com/cometbft/cometbft/internal/protoio"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cosmos/gogoproto/proto"
 	"github.com/fortytw2/leaktest"
@@ -147,6 +149,111 @@ func TestMConnectionReceive(t *testing.T) {
 	}
 }
 
+func TestMConnectionReceiveBuffer(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	receivedCh := make(chan *Buffer)
+	errorsCh := make(chan interface{})
+	onReceive := func(chID byte, msg *Buffer) {
+		receivedCh <- msg
+	}
+	onError := func(r interface{}) {
+		errorsCh <- r
+	}
+	cfg := DefaultMConnConfig()
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1, SendQueueCapacity: 1}}
+	mconn1 := NewMConnectionWithBuffers(client, chDescs, onReceive, onError, cfg)
+	mconn1.SetLogger(log.TestingLogger())
+	err := mconn1.Start()
+	require.Nil(t, err)
+	defer mconn1.Stop() //nolint:errcheck // ignore for tests
+
+	mconn2 := createTestMConnection(server)
+	err = mconn2.Start()
+	require.Nil(t, err)
+	defer mconn2.Stop() //nolint:errcheck // ignore for tests
+
+	// the buffers are owned by the receiver until released
+	msgs := [][]byte{[]byte("Wolverine"), []byte("Storm")}
+	for _, msg := range msgs {
+		assert.True(t, mconn2.Send(0x01, msg))
+	}
+	received := make([]*Buffer, 0, len(msgs))
+	for _, msg := range msgs {
+		select {
+		case buf := <-receivedCh:
+			received = append(received, buf)
+		case err := <-errorsCh:
+			t.Fatalf("Expected %s, got %+v", msg, err)
+		case <-time.After(500 * time.Millisecond):
+			t.Fatalf("Did not receive %s message in 500ms", msg)
+		}
+	}
+	for i, buf := range received {
+		assert.Equal(t, msgs[i], buf.Bytes())
+		buf.Release()
+	}
+}
+
+func TestMConnectionSetChannelBusy(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	receivedCh := make(chan []byte)
+	errorsCh := make(chan interface{})
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- msgBytes
+	}
+	onError := func(r interface{}) {
+		errorsCh <- r
+	}
+	mconn1 := createMConnectionWithCallbacks(client, onReceive, onError)
+	mconn1.config.FlowControl = true
+	err := mconn1.Start()
+	require.Nil(t, err)
+	defer mconn1.Stop() //nolint:errcheck // ignore for tests
+
+	mconn2 := createTestMConnection(server)
+	mconn2.config.FlowControl = true
+	err = mconn2.Start()
+	require.Nil(t, err)
+	defer mconn2.Stop() //nolint:errcheck // ignore for tests
+
+	assert.False(t, mconn1.SetChannelBusy(0x05, true), "SetChannelBusy should return false because channel is unknown")
+
+	// the remote end stops sending on the busy channel
+	require.True(t, mconn1.SetChannelBusy(0x01, true))
+	assert.True(t, mconn1.Status().Channels[0].Busy)
+	require.Eventually(t, func() bool {
+		return mconn2.Status().Channels[0].RemoteBusy
+	}, time.Second, 10*time.Millisecond)
+
+	msg := []byte("Hulk")
+	assert.True(t, mconn2.Send(0x01, msg))
+	select {
+	case receivedBytes := <-receivedCh:
+		t.Fatalf("Received %s on a busy channel", receivedBytes)
+	case err := <-errorsCh:
+		t.Fatalf("Expected no message, got %+v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// and resumes once it is not busy anymore
+	require.True(t, mconn1.SetChannelBusy(0x01, false))
+	select {
+	case receivedBytes := <-receivedCh:
+		assert.Equal(t, msg, receivedBytes)
+	case err := <-errorsCh:
+		t.Fatalf("Expected %s, got %+v", msg, err)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("Did not receive %s message in 500ms", msg)
+	}
+	assert.False(t, mconn2.Status().Channels[0].RemoteBusy)
+}
+
 func TestMConnectionStatus(t *testing.T) {
 	server, client := NetPipe()
 	defer server.Close()
@@ -573,6 +680,7 @@ func TestConnVectors(t *testing.T) {
 		{"PacketPing", &tmp2p.PacketPing{}, "0a00"},
 		{"PacketPong", &tmp2p.PacketPong{}, "1200"},
 		{"PacketMsg", &tmp2p.PacketMsg{ChannelID: 1, EOF: false, Data: []byte("data transmitted over the wire")}, "1a2208011a1e64617461207472616e736d6974746564206f766572207468652077697265"},
+		{"PacketFlowControl", &tmp2p.PacketFlowControl{ChannelID: 1, Busy: true}, "220408011001"},
 	}
 
 	for _, tc := range testCases {
@@ -628,3 +736,19 @@ func stopAll(t *testing.T, stoppers ...stopper) func() {
 		}
 	}
 }
+
+func BenchmarkChannelWritePacketMsg(b *testing.B) {
+	mconn := createTestMConnection(nil)
+	ch := mconn.channels[0]
+	w := protoio.NewDelimitedWriter(io.Discard)
+	msg := cmtrand.Bytes(mconn.config.MaxPacketMsgPayloadSize)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ch.sending = msg
+		if _, err := ch.writePacketMsgTo(w); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package conn
+
+import (
+	"sync"
+
+	tmp2p "github.com/cometbft/cometbft/api/cometbft/p2p/v1"
+)
+
+// packetMsgPool holds the Packets wrapping a PacketMsg, reused to send the
+// PacketMsgs without allocating them.
+var packetMsgPool = sync.Pool{
+	New: func() any {
+		return &tmp2p.Packet{Sum: &tmp2p.Packet_PacketMsg{PacketMsg: &tmp2p.PacketMsg{}}}
+	},
+}
+
+// getPacketMsg returns a Packet wrapping a PacketMsg from the pool, and the
+// PacketMsg. The Packet must be put back with putPacketMsg once written.
+func getPacketMsg() (*tmp2p.Packet, *tmp2p.PacketMsg) {
+	packet := packetMsgPool.Get().(*tmp2p.Packet)
+	return packet, packet.GetPacketMsg()
+}
+
+// putPacketMsg puts back in the pool a Packet returned by getPacketMsg.
+func putPacketMsg(packet *tmp2p.Packet) {
+	// Don't pin the bytes of the message.
+	packet.GetPacketMsg().Data = nil
+	packetMsgPool.Put(packet)
+}
@@ -138,6 +138,47 @@ func (e ErrSwitchAuthenticationFailure) Error() string {
 	)
 }
 
+// ErrSwitchDuplicateReactor is returned when adding a custom reactor under the
+// name of another reactor.
+type ErrSwitchDuplicateReactor struct {
+	Name string
+}
+
+func (e ErrSwitchDuplicateReactor) Error() string {
+	return fmt.Sprintf("duplicate reactor %s", e.Name)
+}
+
+// ErrSwitchReservedChannel is returned when adding a custom reactor using a
+// channel reserved for the reactors of CometBFT.
+type ErrSwitchReservedChannel struct {
+	ChID byte
+}
+
+func (e ErrSwitchReservedChannel) Error() string {
+	return fmt.Sprintf("channel %#x is reserved, custom reactors must use channels from %#x on", e.ChID, MinCustomChannelID)
+}
+
+// ErrSwitchDuplicateChannel is returned when adding a custom reactor using a
+// channel of another reactor.
+type ErrSwitchDuplicateChannel struct {
+	ChID byte
+}
+
+func (e ErrSwitchDuplicateChannel) Error() string {
+	return fmt.Sprintf("channel %#x is used by another reactor", e.ChID)
+}
+
+// ErrSwitchNoMessageType is returned when adding a custom reactor using a
+// channel without a message type, which the messages received on it could not
+// be unmarshaled into.
+type ErrSwitchNoMessageType struct {
+	ChID byte
+}
+
+func (e ErrSwitchNoMessageType) Error() string {
+	return fmt.Sprintf("channel %#x has no message type", e.ChID)
+}
+
 // ErrTransportClosed is raised when the Transport has been closed.
 type ErrTransportClosed struct{}
 
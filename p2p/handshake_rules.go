@@ -0,0 +1,142 @@
+package p2p
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+)
+
+// HandshakeRules are the operator-configured rules the peers must comply
+// with, on top of the compatibility checks of the NodeInfo, to pass the
+// handshake. They allow upgraded networks to shed incompatible peers before
+// they are added to the switch.
+//
+// For each field, an empty allowlist allows everything, and the denylist is
+// checked after the allowlist.
+type HandshakeRules struct {
+	// The moniker of the peers must match one of AllowedMonikers and none of
+	// DeniedMonikers.
+	AllowedMonikers []*regexp.Regexp
+	DeniedMonikers  []*regexp.Regexp
+
+	// The app version of the peers must be one of AllowedAppVersions and none
+	// of DeniedAppVersions.
+	AllowedAppVersions []uint64
+	DeniedAppVersions  []uint64
+
+	// The block version of the peers must be one of AllowedBlockVersions and
+	// none of DeniedBlockVersions. If AllowedBlockVersions is not empty, it
+	// replaces the requirement that the peers use our block version.
+	AllowedBlockVersions []uint64
+	DeniedBlockVersions  []uint64
+}
+
+// NewHandshakeRules returns the HandshakeRules compiled from the given
+// moniker patterns and versions, or nil if there is no rule.
+func NewHandshakeRules(
+	allowedMonikers, deniedMonikers []string,
+	allowedAppVersions, deniedAppVersions []uint64,
+	allowedBlockVersions, deniedBlockVersions []uint64,
+) (*HandshakeRules, error) {
+	if len(allowedMonikers) == 0 && len(deniedMonikers) == 0 &&
+		len(allowedAppVersions) == 0 && len(deniedAppVersions) == 0 &&
+		len(allowedBlockVersions) == 0 && len(deniedBlockVersions) == 0 {
+		return nil, nil
+	}
+
+	allowed, err := compilePatterns(allowedMonikers)
+	if err != nil {
+		return nil, err
+	}
+	denied, err := compilePatterns(deniedMonikers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HandshakeRules{
+		AllowedMonikers:      allowed,
+		DeniedMonikers:       denied,
+		AllowedAppVersions:   allowedAppVersions,
+		DeniedAppVersions:    deniedAppVersions,
+		AllowedBlockVersions: allowedBlockVersions,
+		DeniedBlockVersions:  deniedBlockVersions,
+	}, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid moniker pattern %q: %w", pattern, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// Check returns an error if the peer with the given NodeInfo does not comply
+// with the rules.
+func (r *HandshakeRules) Check(otherInfo NodeInfo) error {
+	other, ok := otherInfo.(DefaultNodeInfo)
+	if !ok {
+		return fmt.Errorf("wrong NodeInfo type. Expected DefaultNodeInfo, got %v", reflect.TypeOf(otherInfo))
+	}
+
+	if len(r.AllowedMonikers) > 0 && !matchAny(r.AllowedMonikers, other.Moniker) {
+		return fmt.Errorf("peer moniker %q is not allowed", other.Moniker)
+	}
+	if matchAny(r.DeniedMonikers, other.Moniker) {
+		return fmt.Errorf("peer moniker %q is denied", other.Moniker)
+	}
+
+	app := other.ProtocolVersion.App
+	if len(r.AllowedAppVersions) > 0 && !slices.Contains(r.AllowedAppVersions, app) {
+		return fmt.Errorf("peer is on an App version which is not allowed. Got %v, expected one of %v",
+			app, r.AllowedAppVersions)
+	}
+	if slices.Contains(r.DeniedAppVersions, app) {
+		return fmt.Errorf("peer is on a denied App version %v", app)
+	}
+
+	block := other.ProtocolVersion.Block
+	if len(r.AllowedBlockVersions) > 0 && !slices.Contains(r.AllowedBlockVersions, block) {
+		return fmt.Errorf("peer is on a Block version which is not allowed. Got %v, expected one of %v",
+			block, r.AllowedBlockVersions)
+	}
+	if slices.Contains(r.DeniedBlockVersions, block) {
+		return fmt.Errorf("peer is on a denied Block version %v", block)
+	}
+
+	return nil
+}
+
+func matchAny(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// compatibleWith checks that other is compatible with ours and, if rules is
+// not nil, that it complies with the rules.
+func compatibleWith(ours, other NodeInfo, rules *HandshakeRules) error {
+	if rules == nil {
+		return ours.CompatibleWith(other)
+	}
+	if err := rules.Check(other); err != nil {
+		return err
+	}
+
+	// The block versions allowed by the rules replace ours, which the peers
+	// otherwise have to share.
+	ourInfo, ok := ours.(DefaultNodeInfo)
+	if ok && len(rules.AllowedBlockVersions) > 0 {
+		ourInfo.ProtocolVersion.Block = other.(DefaultNodeInfo).ProtocolVersion.Block
+		return ourInfo.CompatibleWith(other)
+	}
+	return ours.CompatibleWith(other)
+}
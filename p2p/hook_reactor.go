@@ -0,0 +1,100 @@
+package p2p
+
+import (
+	"github.com/cometbft/cometbft/p2p/conn"
+)
+
+// MinCustomChannelID is the lowest channel ID the reactors of other projects
+// may use. The lower ones are reserved for the reactors of CometBFT.
+const MinCustomChannelID = byte(0x80)
+
+// ReactorHooks are the functions a HookReactor calls on its own lifecycle, on
+// the one of the peers, and on their messages. The hooks left nil are
+// skipped.
+type ReactorHooks struct {
+	// OnStart and OnStop are called when the reactor is started and stopped.
+	OnStart func() error
+	OnStop  func()
+
+	// InitPeer, AddPeer and RemovePeer are called like the ones of Reactor.
+	// Without InitPeer, the peer is left as is.
+	InitPeer   func(peer Peer) Peer
+	AddPeer    func(peer Peer)
+	RemovePeer func(peer Peer, reason interface{})
+
+	// Receive is called like the one of Reactor, with the messages received
+	// on the channels of the reactor. Without Receive, they are dropped.
+	Receive func(e Envelope)
+}
+
+// HookReactor is a Reactor made of channels and hooks, for other projects to
+// add their own gossip protocol to a node without implementing a Reactor.
+// The messages are sent with the Switch of the reactor, or with the peers.
+type HookReactor struct {
+	BaseReactor
+
+	channels []*conn.ChannelDescriptor
+	hooks    ReactorHooks
+}
+
+var _ Reactor = (*HookReactor)(nil)
+
+// NewHookReactor returns a reactor named name, receiving the messages of
+// channels, and calling hooks.
+func NewHookReactor(name string, channels []*conn.ChannelDescriptor, hooks ReactorHooks) *HookReactor {
+	r := &HookReactor{
+		channels: channels,
+		hooks:    hooks,
+	}
+	r.BaseReactor = *NewBaseReactor(name, r)
+	return r
+}
+
+// OnStart implements Service.
+func (r *HookReactor) OnStart() error {
+	if r.hooks.OnStart != nil {
+		return r.hooks.OnStart()
+	}
+	return nil
+}
+
+// OnStop implements Service.
+func (r *HookReactor) OnStop() {
+	if r.hooks.OnStop != nil {
+		r.hooks.OnStop()
+	}
+}
+
+// GetChannels implements Reactor.
+func (r *HookReactor) GetChannels() []*conn.ChannelDescriptor {
+	return r.channels
+}
+
+// InitPeer implements Reactor.
+func (r *HookReactor) InitPeer(peer Peer) Peer {
+	if r.hooks.InitPeer != nil {
+		return r.hooks.InitPeer(peer)
+	}
+	return peer
+}
+
+// AddPeer implements Reactor.
+func (r *HookReactor) AddPeer(peer Peer) {
+	if r.hooks.AddPeer != nil {
+		r.hooks.AddPeer(peer)
+	}
+}
+
+// RemovePeer implements Reactor.
+func (r *HookReactor) RemovePeer(peer Peer, reason interface{}) {
+	if r.hooks.RemovePeer != nil {
+		r.hooks.RemovePeer(peer, reason)
+	}
+}
+
+// Receive implements Reactor.
+func (r *HookReactor) Receive(e Envelope) {
+	if r.hooks.Receive != nil {
+		r.hooks.Receive(e)
+	}
+}
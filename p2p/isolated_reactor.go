@@ -0,0 +1,168 @@
+package p2p
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+)
+
+// isolatedReactor wraps a reactor listed in isolated_reactors, for the peers
+// to hand the messages of its channels over to. A panic of the reactor while
+// receiving a message only stops the reactor: the peer the message came from
+// stays connected, and the messages are dropped until the reactor is
+// restarted, after reactor_restart_delay. The peers are neither added to
+// nor removed from the reactor while it is stopped; on restart, it is given
+// the current peers of the switch.
+type isolatedReactor struct {
+	Reactor
+	name string
+	sw   *Switch
+
+	// set from a panic until the reactor is restarted
+	stopped atomic.Bool
+
+	mtx   cmtsync.Mutex
+	peers map[ID]struct{} // added to the reactor since it was last started
+}
+
+// isolatedBufferedReactor is an isolatedReactor wrapping a BufferedReactor.
+type isolatedBufferedReactor struct {
+	*isolatedReactor
+	buffered BufferedReactor
+}
+
+func newIsolatedReactor(name string, reactor Reactor, sw *Switch) Reactor {
+	r := &isolatedReactor{Reactor: reactor, name: name, sw: sw, peers: make(map[ID]struct{})}
+	if br, ok := reactor.(BufferedReactor); ok {
+		return &isolatedBufferedReactor{isolatedReactor: r, buffered: br}
+	}
+	return r
+}
+
+// Receive implements Reactor.
+func (r *isolatedReactor) Receive(e Envelope) {
+	if r.stopped.Load() {
+		return
+	}
+	defer r.recoverPanic(e.Src)
+	r.Reactor.Receive(e)
+}
+
+// ReceiveBuffer implements BufferedReactor.
+func (r *isolatedBufferedReactor) ReceiveBuffer(e BufferEnvelope) {
+	if r.stopped.Load() {
+		e.Buffer.Release()
+		return
+	}
+	defer r.recoverPanic(e.Src)
+	r.buffered.ReceiveBuffer(e)
+}
+
+// AddPeer implements Reactor. The peer is not added while the reactor is
+// stopped, nor added twice if it was given to the reactor on restart.
+func (r *isolatedReactor) AddPeer(peer Peer) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.peers[peer.ID()]; ok || r.stopped.Load() {
+		return
+	}
+	r.peers[peer.ID()] = struct{}{}
+	r.Reactor.AddPeer(peer)
+}
+
+// RemovePeer implements Reactor. Only the peers added to the reactor since it
+// was last started are removed from it.
+func (r *isolatedReactor) RemovePeer(peer Peer, reason interface{}) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.peers[peer.ID()]; !ok {
+		return
+	}
+	delete(r.peers, peer.ID())
+	r.Reactor.RemovePeer(peer, reason)
+}
+
+func (r *isolatedReactor) recoverPanic(src Peer) {
+	if p := recover(); p != nil {
+		r.panicked(p, src)
+	}
+}
+
+// panicked stops the reactor after a panic caused by a message of src, and
+// restarts it after reactor_restart_delay, if any.
+func (r *isolatedReactor) panicked(p interface{}, src Peer) {
+	// the reactor may panic on several peers at once
+	r.mtx.Lock()
+	stopping := r.stopped.CompareAndSwap(false, true)
+	if stopping {
+		r.peers = make(map[ID]struct{})
+	}
+	r.mtx.Unlock()
+	if !stopping {
+		return
+	}
+	var peerID ID
+	if src != nil {
+		peerID = src.ID()
+	}
+	r.sw.Logger.Error("Reactor panicked, stopping it", "reactor", r.name, "peer", peerID, "err", p,
+		"stack", string(debug.Stack()))
+	r.sw.metrics.ReactorPanics.With("reactor", r.name).Add(1)
+
+	go func() {
+		if err := r.Reactor.Stop(); err != nil {
+			r.sw.Logger.Error("Error stopping reactor", "reactor", r.name, "err", err)
+		}
+		if delay := r.sw.config.ReactorRestartDelay; delay > 0 {
+			r.restart(delay)
+		}
+	}()
+}
+
+// restart restarts the reactor after delay, unless the switch is stopped in
+// the meantime, and adds the current peers of the switch to it. The reactor is
+// left stopped if it can't be reset.
+func (r *isolatedReactor) restart(delay time.Duration) {
+	select {
+	case <-time.After(delay):
+	case <-r.sw.Quit():
+		return
+	}
+	if !r.sw.IsRunning() {
+		return
+	}
+
+	if err := r.reset(); err != nil {
+		r.sw.Logger.Error("Can't restart reactor", "reactor", r.name, "err", err)
+		return
+	}
+	if err := r.Reactor.Start(); err != nil {
+		r.sw.Logger.Error("Error restarting reactor", "reactor", r.name, "err", err)
+		return
+	}
+	r.mtx.Lock()
+	r.stopped.Store(false)
+	for _, peer := range r.sw.peers.List() {
+		if peer.IsRunning() {
+			r.peers[peer.ID()] = struct{}{}
+			r.Reactor.AddPeer(peer)
+		}
+	}
+	r.mtx.Unlock()
+	r.sw.metrics.ReactorRestarts.With("reactor", r.name).Add(1)
+	r.sw.Logger.Info("Restarted reactor", "reactor", r.name)
+}
+
+// reset resets the reactor, whose OnReset panics unless the reactor supports
+// being reset.
+func (r *isolatedReactor) reset() (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("reset panicked: %v", p)
+		}
+	}()
+	return r.Reactor.Reset()
+}
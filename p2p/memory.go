@@ -0,0 +1,430 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrMemoryConnRefused is returned when dialing an address of a MemoryNetwork
+// nobody listens on.
+var ErrMemoryConnRefused = errors.New("connection refused")
+
+const memoryFirstEphemeralPort = 32768
+
+// MemoryLink describes the link from a host of a MemoryNetwork to another.
+type MemoryLink struct {
+	// Latency delays the delivery of all data.
+	Latency time.Duration
+	// Bandwidth limits the throughput, in bytes per second. Zero means
+	// unlimited.
+	Bandwidth int64
+}
+
+// transmissionTime returns the time it takes to send n bytes over the link.
+func (l MemoryLink) transmissionTime(n int) time.Duration {
+	if l.Bandwidth <= 0 {
+		return 0
+	}
+	return time.Duration(int64(n) * int64(time.Second) / l.Bandwidth)
+}
+
+// MemoryNetwork connects the transports of a single process in memory,
+// without opening any socket. Addresses are only used as identifiers, so
+// transports can listen on any IP and port. Hosts, identified by their IP,
+// are connected by links with configurable latency and bandwidth. It is meant
+// for testing.
+//
+// MemoryNetwork only replaces the sockets: the connections are upgraded to
+// peers by MultiplexTransport as TCP connections are, so the secret connection
+// handshake, the encryption and the framing of the multiplexed connection all
+// run as usual, and their overhead is included in the simulated traffic.
+type MemoryNetwork struct {
+	mtx         sync.Mutex
+	listeners   map[string]*memoryListener
+	nextPort    int
+	defaultLink MemoryLink
+	links       map[string]MemoryLink
+}
+
+// NewMemoryNetwork returns an empty in-memory network, whose links have no
+// latency and unlimited bandwidth.
+func NewMemoryNetwork() *MemoryNetwork {
+	return &MemoryNetwork{
+		listeners: make(map[string]*memoryListener),
+		nextPort:  memoryFirstEphemeralPort,
+		links:     make(map[string]MemoryLink),
+	}
+}
+
+// SetDefaultLink sets the link between hosts without a link of their own.
+func (mn *MemoryNetwork) SetDefaultLink(link MemoryLink) {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+	mn.defaultLink = link
+}
+
+// SetLink sets the link from the host with IP from to the host with IP to.
+// It applies to the data written from then on, including on existing
+// connections.
+func (mn *MemoryNetwork) SetLink(from, to net.IP, link MemoryLink) {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+	mn.links[memoryLinkKey(from, to)] = link
+}
+
+func (mn *MemoryNetwork) link(from, to net.IP) MemoryLink {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+	if link, ok := mn.links[memoryLinkKey(from, to)]; ok {
+		return link
+	}
+	return mn.defaultLink
+}
+
+func memoryLinkKey(from, to net.IP) string {
+	return from.String() + "|" + to.String()
+}
+
+// Listen returns a listener accepting the connections dialed to addr.
+func (mn *MemoryNetwork) Listen(addr NetAddress) (net.Listener, error) {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+
+	key := addr.DialString()
+	if _, ok := mn.listeners[key]; ok {
+		return nil, fmt.Errorf("listen on %v: address already in use", key)
+	}
+	ln := &memoryListener{
+		network: mn,
+		key:     key,
+		addr:    &net.TCPAddr{IP: addr.IP, Port: int(addr.Port)},
+		connc:   make(chan net.Conn),
+		closec:  make(chan struct{}),
+	}
+	mn.listeners[key] = ln
+	return ln, nil
+}
+
+// Dial connects from, from an ephemeral port, to the listener on to.
+func (mn *MemoryNetwork) Dial(from, to NetAddress) (net.Conn, error) {
+	mn.mtx.Lock()
+	ln, ok := mn.listeners[to.DialString()]
+	port := mn.nextPort
+	mn.nextPort++
+	mn.mtx.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("dial %v: %w", to.DialString(), ErrMemoryConnRefused)
+	}
+
+	fromAddr := &net.TCPAddr{IP: from.IP, Port: port}
+	outgoing := newMemoryPipe(mn, fromAddr.IP, ln.addr.IP)
+	incoming := newMemoryPipe(mn, ln.addr.IP, fromAddr.IP)
+	select {
+	case ln.connc <- &memoryConn{r: outgoing, w: incoming, local: ln.addr, remote: fromAddr}:
+		return &memoryConn{r: incoming, w: outgoing, local: fromAddr, remote: ln.addr}, nil
+	case <-ln.closec:
+		return nil, fmt.Errorf("dial %v: %w", to.DialString(), ErrMemoryConnRefused)
+	}
+}
+
+func (mn *MemoryNetwork) removeListener(ln *memoryListener) {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+	if mn.listeners[ln.key] == ln {
+		delete(mn.listeners, ln.key)
+	}
+}
+
+// memoryListener implements net.Listener for a MemoryNetwork.
+type memoryListener struct {
+	network   *MemoryNetwork
+	key       string
+	addr      *net.TCPAddr
+	connc     chan net.Conn
+	closec    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ net.Listener = (*memoryListener)(nil)
+
+// Accept implements net.Listener.
+func (ln *memoryListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-ln.connc:
+		return c, nil
+	case <-ln.closec:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close implements net.Listener.
+func (ln *memoryListener) Close() error {
+	ln.closeOnce.Do(func() {
+		close(ln.closec)
+		ln.network.removeListener(ln)
+	})
+	return nil
+}
+
+// Addr implements net.Listener.
+func (ln *memoryListener) Addr() net.Addr {
+	return ln.addr
+}
+
+// memoryConn is one end of an in-memory connection, reporting TCP addresses
+// so that it can be used like a real connection.
+type memoryConn struct {
+	r, w          *memoryPipe
+	local, remote *net.TCPAddr
+}
+
+var _ net.Conn = (*memoryConn)(nil)
+
+// Read implements net.Conn.
+func (c *memoryConn) Read(b []byte) (int, error) {
+	return c.r.read(b)
+}
+
+// Write implements net.Conn.
+func (c *memoryConn) Write(b []byte) (int, error) {
+	return c.w.write(b)
+}
+
+// Close implements net.Conn. The remote end reads the data written so far,
+// then io.EOF.
+func (c *memoryConn) Close() error {
+	c.r.closeReader()
+	c.w.closeWriter()
+	return nil
+}
+
+// LocalAddr implements net.Conn.
+func (c *memoryConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+// RemoteAddr implements net.Conn.
+func (c *memoryConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// SetDeadline implements net.Conn.
+func (c *memoryConn) SetDeadline(t time.Time) error {
+	c.r.setReadDeadline(t)
+	c.w.setWriteDeadline(t)
+	return nil
+}
+
+// SetReadDeadline implements net.Conn.
+func (c *memoryConn) SetReadDeadline(t time.Time) error {
+	c.r.setReadDeadline(t)
+	return nil
+}
+
+// SetWriteDeadline implements net.Conn.
+func (c *memoryConn) SetWriteDeadline(t time.Time) error {
+	c.w.setWriteDeadline(t)
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// memorySegment is data written to a memoryPipe, readable from deliverAt on.
+type memorySegment struct {
+	data      []byte
+	deliverAt time.Time
+}
+
+// memoryPipe carries the data of a connection in one direction, delaying it
+// as configured by the link between its ends.
+type memoryPipe struct {
+	network  *MemoryNetwork
+	from, to net.IP
+
+	mtx           sync.Mutex
+	segments      []memorySegment
+	busyUntil     time.Time // end of the transmission of the last write
+	readerClosed  bool
+	writerClosed  bool
+	readDeadline  time.Time
+	writeDeadline time.Time
+	changed       chan struct{} // closed and replaced on every change
+}
+
+func newMemoryPipe(network *MemoryNetwork, from, to net.IP) *memoryPipe {
+	return &memoryPipe{
+		network: network,
+		from:    from,
+		to:      to,
+		changed: make(chan struct{}),
+	}
+}
+
+// notify wakes up the goroutines waiting on the pipe. The caller must hold
+// the lock.
+func (p *memoryPipe) notify() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// write queues b for delivery after the latency of the link. With a limited
+// bandwidth, it blocks until b has been transmitted, as a socket with a full
+// buffer would.
+func (p *memoryPipe) write(b []byte) (int, error) {
+	link := p.network.link(p.from, p.to)
+
+	p.mtx.Lock()
+	if p.writerClosed {
+		p.mtx.Unlock()
+		return 0, net.ErrClosed
+	}
+	if p.readerClosed {
+		p.mtx.Unlock()
+		return 0, io.ErrClosedPipe
+	}
+	if !p.writeDeadline.IsZero() && !time.Now().Before(p.writeDeadline) {
+		p.mtx.Unlock()
+		return 0, os.ErrDeadlineExceeded
+	}
+	start := time.Now()
+	if p.busyUntil.After(start) {
+		start = p.busyUntil
+	}
+	p.busyUntil = start.Add(link.transmissionTime(len(b)))
+	transmitted := p.busyUntil
+	p.segments = append(p.segments, memorySegment{
+		data:      append([]byte(nil), b...),
+		deliverAt: transmitted.Add(link.Latency),
+	})
+	p.notify()
+	p.mtx.Unlock()
+
+	if link.Bandwidth > 0 {
+		if err := p.waitUntil(transmitted, func() time.Time { return p.writeDeadline }); err != nil {
+			return len(b), err
+		}
+	}
+	return len(b), nil
+}
+
+// waitUntil blocks until t, or until the deadline returned by deadline, read
+// with the lock held, expires.
+func (p *memoryPipe) waitUntil(t time.Time, deadline func() time.Time) error {
+	for {
+		p.mtx.Lock()
+		now := time.Now()
+		if !now.Before(t) {
+			p.mtx.Unlock()
+			return nil
+		}
+		until := t
+		if d := deadline(); !d.IsZero() {
+			if !now.Before(d) {
+				p.mtx.Unlock()
+				return os.ErrDeadlineExceeded
+			}
+			if d.Before(until) {
+				until = d
+			}
+		}
+		changed := p.changed
+		p.mtx.Unlock()
+
+		timer := time.NewTimer(until.Sub(now))
+		select {
+		case <-timer.C:
+		case <-changed:
+			timer.Stop()
+		}
+	}
+}
+
+// read reads the data delivered so far, blocking until some is.
+func (p *memoryPipe) read(b []byte) (int, error) {
+	for {
+		p.mtx.Lock()
+		if p.readerClosed {
+			p.mtx.Unlock()
+			return 0, net.ErrClosed
+		}
+		now := time.Now()
+		if len(p.segments) > 0 && !now.Before(p.segments[0].deliverAt) {
+			n := 0
+			for len(p.segments) > 0 && n < len(b) && !now.Before(p.segments[0].deliverAt) {
+				seg := &p.segments[0]
+				copied := copy(b[n:], seg.data)
+				n += copied
+				seg.data = seg.data[copied:]
+				if len(seg.data) == 0 {
+					p.segments = p.segments[1:]
+				}
+			}
+			p.mtx.Unlock()
+			return n, nil
+		}
+		if len(p.segments) == 0 && p.writerClosed {
+			p.mtx.Unlock()
+			return 0, io.EOF
+		}
+		var until time.Time
+		if len(p.segments) > 0 {
+			until = p.segments[0].deliverAt
+		}
+		if d := p.readDeadline; !d.IsZero() {
+			if !now.Before(d) {
+				p.mtx.Unlock()
+				return 0, os.ErrDeadlineExceeded
+			}
+			if until.IsZero() || d.Before(until) {
+				until = d
+			}
+		}
+		changed := p.changed
+		p.mtx.Unlock()
+
+		if until.IsZero() {
+			<-changed
+			continue
+		}
+		timer := time.NewTimer(until.Sub(now))
+		select {
+		case <-timer.C:
+		case <-changed:
+			timer.Stop()
+		}
+	}
+}
+
+func (p *memoryPipe) closeReader() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.readerClosed = true
+	p.segments = nil
+	p.notify()
+}
+
+func (p *memoryPipe) closeWriter() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.writerClosed = true
+	p.notify()
+}
+
+func (p *memoryPipe) setReadDeadline(t time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.readDeadline = t
+	p.notify()
+}
+
+func (p *memoryPipe) setWriteDeadline(t time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.writeDeadline = t
+	p.notify()
+}
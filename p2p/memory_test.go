@@ -0,0 +1,179 @@
+package p2p
+
+import (
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+func TestMemoryNetwork(t *testing.T) {
+	mn := NewMemoryNetwork()
+	from := NewNetAddressIPPort(net.IPv4(127, 0, 0, 1), 26656)
+	to := NewNetAddressIPPort(net.IPv4(127, 0, 0, 2), 26656)
+
+	_, err := mn.Dial(*from, *to)
+	require.ErrorIs(t, err, ErrMemoryConnRefused)
+
+	ln, err := mn.Listen(*to)
+	require.NoError(t, err)
+	_, err = mn.Listen(*to)
+	require.Error(t, err, "listening twice on the same address")
+
+	acceptc := make(chan net.Conn, 1)
+	go func() {
+		c, err := ln.Accept()
+		assert.NoError(t, err)
+		acceptc <- c
+	}()
+	dialed, err := mn.Dial(*from, *to)
+	require.NoError(t, err)
+	accepted := <-acceptc
+
+	// the connection reports the addresses of both ends
+	assert.Equal(t, to.DialString(), dialed.RemoteAddr().String())
+	assert.Equal(t, dialed.LocalAddr().String(), accepted.RemoteAddr().String())
+	assert.True(t, from.IP.Equal(accepted.RemoteAddr().(*net.TCPAddr).IP))
+
+	go func() {
+		_, err := dialed.Write([]byte("ping"))
+		assert.NoError(t, err)
+	}()
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(accepted, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+
+	require.NoError(t, ln.Close())
+	_, err = ln.Accept()
+	require.ErrorIs(t, err, net.ErrClosed)
+	_, err = mn.Dial(*from, *to)
+	require.ErrorIs(t, err, ErrMemoryConnRefused)
+
+	// the address can be reused once the listener is closed
+	ln, err = mn.Listen(*to)
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+}
+
+func TestTransportMultiplexMemoryNetwork(t *testing.T) {
+	mn := NewMemoryNetwork()
+	newTransport := func(ip net.IP) (*MultiplexTransport, *NetAddress) {
+		pv := ed25519.GenPrivKey()
+		id := PubKeyToID(pv.PubKey())
+		mt := newMultiplexTransport(testNodeInfo(id, "transport"), NodeKey{PrivKey: pv})
+		MultiplexTransportMemoryNetwork(mn)(mt)
+		addr := NewNetAddressIPPort(ip, 26656)
+		addr.ID = id
+		require.NoError(t, mt.Listen(*addr))
+		t.Cleanup(func() { _ = mt.Close() })
+		return mt, addr
+	}
+	listener, listenerAddr := newTransport(net.IPv4(127, 0, 0, 1))
+	dialer, dialerAddr := newTransport(net.IPv4(127, 0, 0, 2))
+
+	errc := make(chan error, 1)
+	go func() {
+		p, err := dialer.Dial(*listenerAddr, peerConfig{})
+		if err == nil {
+			assert.Equal(t, listenerAddr.ID, p.ID())
+		}
+		errc <- err
+	}()
+
+	p, err := listener.Accept(peerConfig{})
+	require.NoError(t, err)
+	require.NoError(t, <-errc)
+	assert.Equal(t, dialerAddr.ID, p.ID())
+	assert.True(t, dialerAddr.IP.Equal(p.SocketAddr().IP))
+}
+
+// memoryConnPair returns both ends of a connection between 127.0.0.1 and
+// 127.0.0.2 on mn.
+func memoryConnPair(t *testing.T, mn *MemoryNetwork) (dialed, accepted net.Conn) {
+	t.Helper()
+	from := NewNetAddressIPPort(net.IPv4(127, 0, 0, 1), 26656)
+	to := NewNetAddressIPPort(net.IPv4(127, 0, 0, 2), 26656)
+	ln, err := mn.Listen(*to)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	acceptc := make(chan net.Conn, 1)
+	go func() {
+		c, err := ln.Accept()
+		assert.NoError(t, err)
+		acceptc <- c
+	}()
+	dialed, err = mn.Dial(*from, *to)
+	require.NoError(t, err)
+	return dialed, <-acceptc
+}
+
+func TestMemoryConnLink(t *testing.T) {
+	mn := NewMemoryNetwork()
+	mn.SetLink(net.IPv4(127, 0, 0, 1), net.IPv4(127, 0, 0, 2), MemoryLink{
+		Latency:   50 * time.Millisecond,
+		Bandwidth: 10000,
+	})
+	dialed, accepted := memoryConnPair(t, mn)
+
+	// 1000 bytes take 100ms to transmit at 10000 B/s, then 50ms to arrive
+	start := time.Now()
+	_, err := dialed.Write(make([]byte, 1000))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	buf := make([]byte, 1000)
+	_, err = io.ReadFull(accepted, buf)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	// the link in the other direction is the default one
+	start = time.Now()
+	_, err = accepted.Write(make([]byte, 1000))
+	require.NoError(t, err)
+	_, err = io.ReadFull(dialed, buf)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestMemoryConnDeadlines(t *testing.T) {
+	mn := NewMemoryNetwork()
+	mn.SetDefaultLink(MemoryLink{Bandwidth: 1000})
+	dialed, accepted := memoryConnPair(t, mn)
+
+	require.NoError(t, accepted.SetReadDeadline(time.Now().Add(10*time.Millisecond)))
+	_, err := accepted.Read(make([]byte, 1))
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+	// writing 1000 bytes takes a second
+	require.NoError(t, dialed.SetWriteDeadline(time.Now().Add(10*time.Millisecond)))
+	_, err = dialed.Write(make([]byte, 1000))
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+}
+
+func TestMemoryConnClose(t *testing.T) {
+	dialed, accepted := memoryConnPair(t, NewMemoryNetwork())
+
+	_, err := dialed.Write([]byte("bye"))
+	require.NoError(t, err)
+	require.NoError(t, dialed.Close())
+
+	// the remote end reads the pending data, then EOF
+	buf, err := io.ReadAll(accepted)
+	require.NoError(t, err)
+	assert.Equal(t, "bye", string(buf))
+	_, err = accepted.Write([]byte("hello?"))
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+
+	_, err = dialed.Read(make([]byte, 1))
+	require.ErrorIs(t, err, net.ErrClosed)
+	_, err = dialed.Write([]byte("again"))
+	require.ErrorIs(t, err, net.ErrClosed)
+}
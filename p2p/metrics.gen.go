@@ -56,6 +56,24 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "message_send_bytes_total",
 			Help:      "Number of bytes of each message type sent.",
 		}, append(labels, "message_type")).With(labelsAndValues...),
+		ClockSkewSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "clock_skew_seconds",
+			Help:      "Estimated skew of the local clock relative to the clocks of the peers, in seconds. It is positive if the local clock is ahead.",
+		}, labels).With(labelsAndValues...),
+		ReactorPanics: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reactor_panics",
+			Help:      "Number of panics of each isolated reactor while receiving a message.",
+		}, append(labels, "reactor")).With(labelsAndValues...),
+		ReactorRestarts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reactor_restarts",
+			Help:      "Number of restarts of each isolated reactor after a panic.",
+		}, append(labels, "reactor")).With(labelsAndValues...),
 	}
 }
 
@@ -68,5 +86,8 @@ func NopMetrics() *Metrics {
 		NumTxs:                   discard.NewGauge(),
 		MessageReceiveBytesTotal: discard.NewCounter(),
 		MessageSendBytesTotal:    discard.NewCounter(),
+		ClockSkewSeconds:         discard.NewGauge(),
+		ReactorPanics:            discard.NewCounter(),
+		ReactorRestarts:          discard.NewCounter(),
 	}
 }
@@ -38,6 +38,13 @@ type Metrics struct {
 	MessageReceiveBytesTotal metrics.Counter `metrics_labels:"message_type"`
 	// Number of bytes of each message type sent.
 	MessageSendBytesTotal metrics.Counter `metrics_labels:"message_type"`
+	// Estimated skew of the local clock relative to the clocks of the peers,
+	// in seconds. It is positive if the local clock is ahead.
+	ClockSkewSeconds metrics.Gauge
+	// Number of panics of each isolated reactor while receiving a message.
+	ReactorPanics metrics.Counter `metrics_labels:"reactor"`
+	// Number of restarts of each isolated reactor after a panic.
+	ReactorRestarts metrics.Counter `metrics_labels:"reactor"`
 }
 
 type metricsLabelCache struct {
@@ -42,9 +42,10 @@ func NewPeer(ip net.IP) *Peer {
 	return mp
 }
 
-func (mp *Peer) FlushStop()                  { mp.Stop() } //nolint:errcheck //ignore error
-func (mp *Peer) TrySend(_ p2p.Envelope) bool { return true }
-func (mp *Peer) Send(_ p2p.Envelope) bool    { return true }
+func (mp *Peer) FlushStop()                     { mp.Stop() } //nolint:errcheck //ignore error
+func (mp *Peer) TrySend(_ p2p.Envelope) bool    { return true }
+func (mp *Peer) SetChannelBusy(byte, bool) bool { return true }
+func (mp *Peer) Send(_ p2p.Envelope) bool       { return true }
 func (mp *Peer) NodeInfo() p2p.NodeInfo {
 	return p2p.DefaultNodeInfo{
 		DefaultNodeID: mp.addr.ID,
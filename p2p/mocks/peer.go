@@ -313,6 +313,24 @@ func (_m *Peer) Set(key string, value interface{}) {
 	_m.Called(key, value)
 }
 
+// SetChannelBusy provides a mock function with given fields: chID, busy
+func (_m *Peer) SetChannelBusy(chID byte, busy bool) bool {
+	ret := _m.Called(chID, busy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChannelBusy")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(byte, bool) bool); ok {
+		r0 = rf(chID, busy)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // SetLogger provides a mock function with given fields: l
 func (_m *Peer) SetLogger(l log.Logger) {
 	_m.Called(l)
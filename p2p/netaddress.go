@@ -65,9 +65,19 @@ func NewNetAddress(id ID, addr net.Addr) *NetAddress {
 
 // NewNetAddressString returns a new NetAddress using the provided address in
 // the form of "ID@IP:Port".
-// Also resolves the host if host is not an IP.
+// Also resolves the host if host is not an IP, preferring its IPv4 address
+// if it has both (see ResolveNetAddressString).
 // Errors are of type ErrNetAddressXxx where Xxx is in (NoID, Invalid, Lookup).
 func NewNetAddressString(addr string) (*NetAddress, error) {
+	return ResolveNetAddressString(addr, false)
+}
+
+// ResolveNetAddressString returns a new NetAddress using the provided address
+// in the form of "ID@IP:Port", like NewNetAddressString. If the host is not an
+// IP and resolves to both IPv4 and IPv6 addresses (dual-stack), the first
+// IPv6 address is used if preferIPv6 is true, the first IPv4 address
+// otherwise.
+func ResolveNetAddressString(addr string, preferIPv6 bool) (*NetAddress, error) {
 	addrWithoutProtocol := removeProtocolIfDefined(addr)
 	spl := strings.Split(addrWithoutProtocol, "@")
 	if len(spl) != 2 {
@@ -99,7 +109,7 @@ func NewNetAddressString(addr string) (*NetAddress, error) {
 		if err != nil {
 			return nil, ErrNetAddressLookup{host, err}
 		}
-		ip = ips[0]
+		ip = pickIP(ips, preferIPv6)
 	}
 
 	port, err := strconv.ParseUint(portStr, 10, 16)
@@ -112,13 +122,31 @@ func NewNetAddressString(addr string) (*NetAddress, error) {
 	return na, nil
 }
 
+// pickIP returns the first IP of the preferred family, or the first IP if
+// there is none.
+func pickIP(ips []net.IP, preferIPv6 bool) net.IP {
+	for _, ip := range ips {
+		if (ip.To4() == nil) == preferIPv6 {
+			return ip
+		}
+	}
+	return ips[0]
+}
+
 // NewNetAddressStrings returns an array of NetAddress'es build using
 // the provided strings.
 func NewNetAddressStrings(addrs []string) ([]*NetAddress, []error) {
+	return ResolveNetAddressStrings(addrs, false)
+}
+
+// ResolveNetAddressStrings returns an array of NetAddress'es build using
+// the provided strings, resolving dual-stack hosts to their IPv6 address if
+// preferIPv6 is true (see ResolveNetAddressString).
+func ResolveNetAddressStrings(addrs []string, preferIPv6 bool) ([]*NetAddress, []error) {
 	netAddrs := make([]*NetAddress, 0)
 	errs := make([]error, 0)
 	for _, addr := range addrs {
-		netAddr, err := NewNetAddressString(addr)
+		netAddr, err := ResolveNetAddressString(addr, preferIPv6)
 		if err != nil {
 			errs = append(errs, err)
 		} else {
@@ -257,7 +285,7 @@ func (na *NetAddress) Routable() bool {
 	}
 	// TODO(oga) bitcoind doesn't include RFC3849 here, but should we?
 	return !(na.RFC1918() || na.RFC3927() || na.RFC4862() ||
-		na.RFC4193() || na.RFC4843() || na.Local())
+		na.RFC4193() || na.RFC4843() || na.Local() || na.IP.IsMulticast())
 }
 
 // For IPv4 these are either a 0 or all bits set address. For IPv6 a zero
@@ -276,6 +304,12 @@ func (na *NetAddress) Valid() error {
 	return nil
 }
 
+// IPv6 returns true if the address is an IPv6 address, as opposed to an IPv4
+// or IPv4-mapped IPv6 address.
+func (na *NetAddress) IPv6() bool {
+	return na.IP != nil && na.IP.To4() == nil
+}
+
 // HasID returns true if the address has an ID.
 // NOTE: It does not check whether the ID is valid or not.
 func (na *NetAddress) HasID() bool {
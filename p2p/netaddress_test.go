@@ -165,6 +165,33 @@ func TestNetAddressProperties(t *testing.T) {
 	}
 }
 
+func TestNetAddressIPv6(t *testing.T) {
+	testCases := []struct {
+		ip   string
+		ipv6 bool
+	}{
+		{"1.2.3.4", false},
+		{"::ffff:1.2.3.4", false},
+		{"2602:100::1", true},
+		{"2002:0c01:0203::", true},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.ipv6, NewNetAddressIPPort(net.ParseIP(tc.ip), 26656).IPv6(), tc.ip)
+	}
+}
+
+func TestPickIP(t *testing.T) {
+	v4, v6 := net.ParseIP("1.2.3.4"), net.ParseIP("2602:100::1")
+
+	// dual-stack
+	assert.Equal(t, v4, pickIP([]net.IP{v6, v4}, false))
+	assert.Equal(t, v6, pickIP([]net.IP{v4, v6}, true))
+
+	// single stack
+	assert.Equal(t, v6, pickIP([]net.IP{v6}, false))
+	assert.Equal(t, v4, pickIP([]net.IP{v4}, true))
+}
+
 func TestNetAddressReachabilityTo(t *testing.T) {
 	// TODO add more test cases
 	testCases := []struct {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
 )
@@ -126,3 +127,63 @@ func TestNodeInfoCompatible(t *testing.T) {
 		assert.Error(t, ni1.CompatibleWith(ni))
 	}
 }
+
+func TestNodeInfoCompatibleWithHandshakeRules(t *testing.T) {
+	nodeKey1 := NodeKey{PrivKey: ed25519.GenPrivKey()}
+	nodeKey2 := NodeKey{PrivKey: ed25519.GenPrivKey()}
+
+	ni1 := testNodeInfo(nodeKey1.ID(), "ours").(DefaultNodeInfo)
+	ni1.ProtocolVersion.App = 2
+
+	_, err := NewHandshakeRules([]string{"("}, nil, nil, nil, nil, nil)
+	require.Error(t, err)
+
+	rules, err := NewHandshakeRules(nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+
+	block := ni1.ProtocolVersion.Block
+	testCases := []struct {
+		testName         string
+		allowedMonikers  []string
+		deniedMonikers   []string
+		allowedApp       []uint64
+		deniedApp        []uint64
+		allowedBlock     []uint64
+		deniedBlock      []uint64
+		malleateNodeInfo func(*DefaultNodeInfo)
+		expectErr        bool
+	}{
+		{"No rule", nil, nil, nil, nil, nil, nil, func(*DefaultNodeInfo) {}, false},
+		{"Allowed moniker", []string{"^val-"}, nil, nil, nil, nil, nil, func(ni *DefaultNodeInfo) { ni.Moniker = "val-1" }, false},
+		{"Moniker not allowed", []string{"^val-"}, nil, nil, nil, nil, nil, func(ni *DefaultNodeInfo) { ni.Moniker = "full-1" }, true},
+		{"Denied moniker", nil, []string{"legacy"}, nil, nil, nil, nil, func(ni *DefaultNodeInfo) { ni.Moniker = "legacy-node" }, true},
+		{"Allowed app version", nil, nil, []uint64{2, 3}, nil, nil, nil, func(ni *DefaultNodeInfo) { ni.ProtocolVersion.App = 3 }, false},
+		{"App version not allowed", nil, nil, []uint64{2, 3}, nil, nil, nil, func(ni *DefaultNodeInfo) { ni.ProtocolVersion.App = 1 }, true},
+		{"Denied app version", nil, nil, nil, []uint64{1}, nil, nil, func(ni *DefaultNodeInfo) { ni.ProtocolVersion.App = 1 }, true},
+		{"Allowed other block version", nil, nil, nil, nil, []uint64{block, block + 1}, nil, func(ni *DefaultNodeInfo) { ni.ProtocolVersion.Block++ }, false},
+		{"Other block version", nil, nil, nil, nil, nil, nil, func(ni *DefaultNodeInfo) { ni.ProtocolVersion.Block++ }, true},
+		{"Block version not allowed", nil, nil, nil, nil, []uint64{block + 1}, nil, func(*DefaultNodeInfo) {}, true},
+		{"Denied block version", nil, nil, nil, nil, nil, []uint64{block}, func(*DefaultNodeInfo) {}, true},
+		{"Wrong network", nil, nil, []uint64{2}, nil, nil, nil, func(ni *DefaultNodeInfo) { ni.Network += "-wrong" }, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			rules, err := NewHandshakeRules(tc.allowedMonikers, tc.deniedMonikers,
+				tc.allowedApp, tc.deniedApp, tc.allowedBlock, tc.deniedBlock)
+			require.NoError(t, err)
+
+			ni := testNodeInfo(nodeKey2.ID(), "theirs").(DefaultNodeInfo)
+			ni.ProtocolVersion.App = 2
+			tc.malleateNodeInfo(&ni)
+
+			err = compatibleWith(ni1, ni, rules)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
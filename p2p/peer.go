@@ -39,6 +39,10 @@ type Peer interface {
 	Send(e Envelope) bool
 	TrySend(e Envelope) bool
 
+	// SetChannelBusy asks the peer to stop (busy) or resume sending messages
+	// on the channel. It returns false if the channel is unknown.
+	SetChannelBusy(chID byte, busy bool) bool
+
 	Set(key string, value interface{})
 	Get(key string) interface{}
 
@@ -122,6 +126,7 @@ type peer struct {
 	metrics       *Metrics
 	metricsTicker *time.Ticker
 	mlc           *metricsLabelCache
+	msgCounters   *messageCounters
 
 	// When removal of a peer fails, we set this flag
 	removalAttemptFailed bool
@@ -148,6 +153,7 @@ func newPeer(
 		metricsTicker: time.NewTicker(metricsTickerDuration),
 		metrics:       NopMetrics(),
 		mlc:           mlc,
+		msgCounters:   newMessageCounters(),
 	}
 
 	p.mconn = createMConnection(
@@ -253,6 +259,12 @@ func (p *peer) Status() cmtconn.ConnectionStatus {
 	return p.mconn.Status()
 }
 
+// MessageStats returns the number and size of the messages exchanged with the
+// peer, by channel and message type.
+func (p *peer) MessageStats() []MessageStats {
+	return p.msgCounters.List()
+}
+
 // Send msg bytes to the channel identified by chID byte. Returns false if the
 // send queue is full after timeout, specified by MConnection.
 func (p *peer) Send(e Envelope) bool {
@@ -265,6 +277,13 @@ func (p *peer) TrySend(e Envelope) bool {
 	return p.send(e.ChannelID, e.Message, p.mconn.TrySend)
 }
 
+// SetChannelBusy marks the channel identified by chID byte as busy, or not
+// busy anymore, asking the peer to stop or resume sending messages on it.
+// See MConnection.SetChannelBusy.
+func (p *peer) SetChannelBusy(chID byte, busy bool) bool {
+	return p.mconn.SetChannelBusy(chID, busy)
+}
+
 func (p *peer) send(chID byte, msg proto.Message, sendFunc func(byte, []byte) bool) bool {
 	if !p.IsRunning() {
 		return false
@@ -272,6 +291,7 @@ func (p *peer) send(chID byte, msg proto.Message, sendFunc func(byte, []byte) bo
 		return false
 	}
 	metricLabelValue := p.mlc.ValueToMetricLabel(msg)
+	unwrapped := msg
 	if w, ok := msg.(types.Wrapper); ok {
 		msg = w.Wrap()
 	}
@@ -288,6 +308,7 @@ func (p *peer) send(chID byte, msg proto.Message, sendFunc func(byte, []byte) bo
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
 		p.metrics.MessageSendBytesTotal.With("message_type", metricLabelValue).Add(float64(len(msgBytes)))
+		p.msgCounters.Sent(chID, unwrapped, len(msgBytes))
 	}
 	return res
 }
@@ -394,7 +415,7 @@ func createMConnection(
 	onPeerError func(Peer, interface{}),
 	config cmtconn.MConnConfig,
 ) *cmtconn.MConnection {
-	onReceive := func(chID byte, msgBytes []byte) {
+	onReceive := func(chID byte, buf *cmtconn.Buffer) {
 		reactor := reactorsByCh[chID]
 		if reactor == nil {
 			// Note that its ok to panic here as it's caught in the conn._recover,
@@ -402,23 +423,43 @@ func createMConnection(
 			panic(fmt.Sprintf("Unknown channel %X", chID))
 		}
 		mt := msgTypeByChID[chID]
+		labels := []string{
+			"peer_id", string(p.ID()),
+			"chID", fmt.Sprintf("%#x", chID),
+		}
+
+		if r, ok := reactor.(BufferedReactor); ok {
+			// The reactor is in charge of releasing the buffer.
+			p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(buf.Len()))
+			p.metrics.MessageReceiveBytesTotal.With("message_type", p.mlc.ValueToMetricLabel(mt)).Add(float64(buf.Len()))
+			p.msgCounters.Received(chID, mt, buf.Len())
+			r.ReceiveBuffer(BufferEnvelope{
+				ChannelID: chID,
+				Src:       p,
+				Buffer:    buf,
+			})
+			return
+		}
+
+		// Unmarshaling copies the message bytes, so the buffer can be
+		// released before calling Receive.
+		msgBytes := buf.Bytes()
 		msg := proto.Clone(mt)
 		err := proto.Unmarshal(msgBytes, msg)
+		msgLen := len(msgBytes)
+		buf.Release()
 		if err != nil {
 			panic(fmt.Sprintf("unmarshaling message: %v into type: %s", err, reflect.TypeOf(mt)))
 		}
-		labels := []string{
-			"peer_id", string(p.ID()),
-			"chID", fmt.Sprintf("%#x", chID),
-		}
 		if w, ok := msg.(types.Unwrapper); ok {
 			msg, err = w.Unwrap()
 			if err != nil {
 				panic(fmt.Sprintf("unwrapping message: %v", err))
 			}
 		}
-		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
-		p.metrics.MessageReceiveBytesTotal.With("message_type", p.mlc.ValueToMetricLabel(msg)).Add(float64(len(msgBytes)))
+		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(msgLen))
+		p.metrics.MessageReceiveBytesTotal.With("message_type", p.mlc.ValueToMetricLabel(msg)).Add(float64(msgLen))
+		p.msgCounters.Received(chID, msg, msgLen)
 		reactor.Receive(Envelope{
 			ChannelID: chID,
 			Src:       p,
@@ -430,7 +471,7 @@ func createMConnection(
 		onPeerError(p, r)
 	}
 
-	return cmtconn.NewMConnectionWithConfig(
+	return cmtconn.NewMConnectionWithBuffers(
 		conn,
 		chDescs,
 		onReceive,
@@ -0,0 +1,96 @@
+package p2p
+
+import (
+	"reflect"
+	"sort"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// MessageStats counts the messages of a type exchanged with a peer on a
+// channel, and their size.
+type MessageStats struct {
+	ChannelID     byte
+	MessageType   string
+	MsgsSent      int64
+	BytesSent     int64
+	MsgsReceived  int64
+	BytesReceived int64
+}
+
+type messageKey struct {
+	chID    byte
+	msgType string
+}
+
+// messageCounters counts the messages exchanged with a peer, by channel and
+// type.
+type messageCounters struct {
+	mtx   cmtsync.Mutex
+	stats map[messageKey]*MessageStats
+}
+
+func newMessageCounters() *messageCounters {
+	return &messageCounters{stats: make(map[messageKey]*MessageStats)}
+}
+
+// get returns the stats of a message type. The caller must hold the mutex
+// lock.
+func (mc *messageCounters) get(chID byte, msg proto.Message) *MessageStats {
+	key := messageKey{chID: chID, msgType: messageTypeName(msg)}
+	s := mc.stats[key]
+	if s == nil {
+		s = &MessageStats{ChannelID: chID, MessageType: key.msgType}
+		mc.stats[key] = s
+	}
+	return s
+}
+
+// Sent counts a message of n bytes sent on the channel.
+func (mc *messageCounters) Sent(chID byte, msg proto.Message, n int) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+	s := mc.get(chID, msg)
+	s.MsgsSent++
+	s.BytesSent += int64(n)
+}
+
+// Received counts a message of n bytes received on the channel.
+func (mc *messageCounters) Received(chID byte, msg proto.Message, n int) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+	s := mc.get(chID, msg)
+	s.MsgsReceived++
+	s.BytesReceived += int64(n)
+}
+
+// List returns the stats of all message types, by channel and type.
+func (mc *messageCounters) List() []MessageStats {
+	mc.mtx.Lock()
+	list := make([]MessageStats, 0, len(mc.stats))
+	for _, s := range mc.stats {
+		list = append(list, *s)
+	}
+	mc.mtx.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ChannelID != list[j].ChannelID {
+			return list[i].ChannelID < list[j].ChannelID
+		}
+		return list[i].MessageType < list[j].MessageType
+	})
+	return list
+}
+
+// messageTypeName returns the name of the type of msg, without its package.
+func messageTypeName(msg proto.Message) string {
+	t := reflect.TypeOf(msg)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
@@ -73,6 +73,18 @@ func TestPeerSend(t *testing.T) {
 
 	assert.True(p.CanSend(testCh))
 	assert.True(p.Send(Envelope{ChannelID: testCh, Message: &p2p.Message{}}))
+
+	stats := p.MessageStats()
+	require.Len(stats, 1)
+	assert.EqualValues(testCh, stats[0].ChannelID)
+	assert.Equal("Message", stats[0].MessageType)
+	assert.EqualValues(1, stats[0].MsgsSent)
+	assert.Zero(stats[0].MsgsReceived)
+
+	require.Eventually(func() bool {
+		return p.Status().Channels[0].MsgsSent == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Positive(p.Status().Channels[0].BytesSent)
 }
 
 func createOutboundPeerAndPerformHandshake(
@@ -94,7 +106,7 @@ func createOutboundPeerAndPerformHandshake(
 	}
 	timeout := 1 * time.Second
 	ourNodeInfo := testNodeInfo(addr.ID, "host_peer")
-	peerNodeInfo, err := handshake(pc.conn, timeout, ourNodeInfo)
+	peerNodeInfo, _, err := handshake(pc.conn, timeout, ourNodeInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +206,7 @@ func (rp *remotePeer) Dial(addr *NetAddress) (net.Conn, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = handshake(pc.conn, time.Second, rp.nodeInfo())
+	_, _, err = handshake(pc.conn, time.Second, rp.nodeInfo())
 	if err != nil {
 		return nil, err
 	}
@@ -219,7 +231,7 @@ func (rp *remotePeer) accept() {
 			golog.Fatalf("Failed to create a peer: %+v", err)
 		}
 
-		_, err = handshake(pc.conn, time.Second, rp.nodeInfo())
+		_, _, err = handshake(pc.conn, time.Second, rp.nodeInfo())
 		if err != nil {
 			golog.Fatalf("Failed to perform handshake: %+v", err)
 		}
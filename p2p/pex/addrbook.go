@@ -161,7 +161,7 @@ func (a *addrBook) OnStart() error {
 	// wg.Add to ensure that any invocation of .Wait()
 	// later on will wait for saveRoutine to terminate.
 	a.wg.Add(1)
-	go a.saveRoutine()
+	go a.saveRoutine(a.Quit())
 
 	return nil
 }
@@ -171,6 +171,20 @@ func (a *addrBook) OnStop() {
 	a.BaseService.OnStop()
 }
 
+// OnReset implements Service. The addresses are loaded again on the next
+// start, from the file saved when the book was stopped.
+func (a *addrBook) OnReset() error {
+	// wait for saveRoutine to save the file
+	a.wg.Wait()
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.addrLookup = make(map[p2p.ID]*knownAddress)
+	a.nOld, a.nNew = 0, 0
+	a.init()
+	return nil
+}
+
 func (a *addrBook) Wait() {
 	a.wg.Wait()
 }
@@ -493,7 +507,9 @@ func (a *addrBook) Save() {
 	a.saveToFile(a.filePath) // thread safe
 }
 
-func (a *addrBook) saveRoutine() {
+// saveRoutine saves the book periodically until quit is closed, the quit
+// channel of the book being replaced when the book is reset.
+func (a *addrBook) saveRoutine(quit <-chan struct{}) {
 	defer a.wg.Done()
 
 	saveFileTicker := time.NewTicker(dumpAddressInterval)
@@ -502,7 +518,7 @@ out:
 		select {
 		case <-saveFileTicker.C:
 			a.saveToFile(a.filePath)
-		case <-a.Quit():
+		case <-quit:
 			break out
 		}
 	}
@@ -691,7 +707,10 @@ func (a *addrBook) addAddress(addr, src *p2p.NetAddress) error {
 		ka = newKnownAddress(addr, src)
 	}
 
-	bucket, err := a.calcNewBucket(addr, src)
+	// The known address keeps its first address, e.g. the IPv4 address of a
+	// dual-stack peer also advertised with its IPv6 address, so it must be
+	// bucketed by it.
+	bucket, err := a.calcNewBucket(ka.Addr, src)
 	if err != nil {
 		return err
 	}
@@ -643,6 +643,36 @@ func TestAddrBookAddDoesNotOverwriteOldIP(t *testing.T) {
 	}
 }
 
+func TestAddrBookDualStackBucketing(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	// A dual-stack peer is first added with its IPv4 address, then with its
+	// IPv6 address. It keeps its IPv4 address, by which it must be bucketed.
+	peerID := "678503e6c8f50db7279c7da3cb9b072aac4bc0d5"
+	v4Addr, err := p2p.NewNetAddressString(peerID + "@1.1.1.1:26656")
+	require.NoError(t, err)
+	v6Addr, err := p2p.NewNetAddressString(peerID + "@[2602:100::1]:26656")
+	require.NoError(t, err)
+
+	src, err := p2p.NewNetAddressString("b0dd378c3fbc4c156cd6d302a799f0d2e4227201@159.89.121.174:26656")
+	require.NoError(t, err)
+
+	book := NewAddrBook(fname, true).(*addrBook)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.AddAddress(v4Addr, src))
+	for i := 0; i < 100; i++ {
+		require.NoError(t, book.AddAddress(v6Addr, src))
+	}
+
+	ka := book.addrLookup[v4Addr.ID]
+	require.NotNil(t, ka)
+	assert.Equal(t, v4Addr.IP, ka.Addr.IP)
+	bucket, err := book.calcNewBucket(v4Addr, src)
+	require.NoError(t, err)
+	assert.Equal(t, []int{bucket}, ka.Buckets)
+}
+
 func TestAddrBookGroupKey(t *testing.T) {
 	// non-strict routability
 	testCases := []struct {
@@ -701,6 +731,8 @@ func TestAddrBookGroupKey(t *testing.T) {
 		{"ipv6 rfc4193 fc00::/7", "fc00::1234", "unroutable"},
 		{"ipv6 rfc4843 2001:10::/28", "2001:10::1234", "unroutable"},
 		{"ipv6 rfc4862 fe80::/64", "fe80::1234", "unroutable"},
+		{"ipv4 multicast 224/4", "224.0.0.1", "unroutable"},
+		{"ipv6 multicast ff00::/8", "ff02::1", "unroutable"},
 	}
 
 	for i, tc := range testCases {
@@ -116,9 +116,20 @@ type ReactorConfig struct {
 	// Maximum pause when redialing a persistent peer (if zero, exponential backoff is used)
 	PersistentPeersMaxDialPeriod time.Duration
 
+	// How often the network is crawled in seed mode (if zero, 30 seconds).
+	CrawlPeriod time.Duration
+
+	// Minimum time between attempts to crawl a peer in seed mode (if zero,
+	// 2 minutes).
+	MinTimeBetweenCrawls time.Duration
+
 	// Seeds is a list of addresses reactor may use
 	// if it can't connect to peers in the addrbook.
 	Seeds []string
+
+	// Prefer IPv6 addresses when picking the peers to dial and resolving the
+	// seeds.
+	PreferIPv6 bool
 }
 
 type _attemptsToDial struct {
@@ -173,6 +184,16 @@ func (r *Reactor) OnStop() {
 	}
 }
 
+// OnReset implements Service, for the switch to restart the reactor after a
+// panic. The requests sent to and received from the peers are forgotten, as
+// the peers are added to the reactor again once restarted.
+func (r *Reactor) OnReset() error {
+	r.requestsSent.Clear()
+	r.lastReceivedRequests.Clear()
+	r.crawlPeerInfos = make(map[p2p.ID]crawlPeerInfo)
+	return r.book.Reset()
+}
+
 // GetChannels implements Reactor.
 func (r *Reactor) GetChannels() []*conn.ChannelDescriptor {
 	return []*conn.ChannelDescriptor{
@@ -479,6 +500,11 @@ func (r *Reactor) ensurePeers() {
 		if try == nil {
 			continue
 		}
+		// Only pick IPv6 addresses in the first half of the attempts, if
+		// preferred.
+		if r.config.PreferIPv6 && !try.IPv6() && i < maxAttempts/2 {
+			continue
+		}
 		if _, selected := toDial[try.ID]; selected {
 			continue
 		}
@@ -600,7 +626,7 @@ func (r *Reactor) checkSeeds() (numOnline int, netAddrs []*p2p.NetAddress, err e
 	if lSeeds == 0 {
 		return -1, nil, nil
 	}
-	netAddrs, errs := p2p.NewNetAddressStrings(r.config.Seeds)
+	netAddrs, errs := p2p.ResolveNetAddressStrings(r.config.Seeds, r.config.PreferIPv6)
 	numOnline = lSeeds - len(errs)
 	for _, err := range errs {
 		switch e := err.(type) {
@@ -659,7 +685,11 @@ func (r *Reactor) crawlPeersRoutine() {
 	}
 
 	// Fire periodically
-	ticker := time.NewTicker(crawlPeerPeriod)
+	period := r.config.CrawlPeriod
+	if period == 0 {
+		period = crawlPeerPeriod
+	}
+	ticker := time.NewTicker(period)
 
 	for {
 		select {
@@ -691,12 +721,16 @@ type crawlPeerInfo struct {
 // crawlPeers will crawl the network looking for new peer addresses.
 func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 	now := time.Now()
+	minInterval := r.config.MinTimeBetweenCrawls
+	if minInterval == 0 {
+		minInterval = minTimeBetweenCrawls
+	}
 
 	for _, addr := range addrs {
 		peerInfo, ok := r.crawlPeerInfos[addr.ID]
 
 		// Do not attempt to connect with peers we recently crawled.
-		if ok && now.Sub(peerInfo.LastCrawled) < minTimeBetweenCrawls {
+		if ok && now.Sub(peerInfo.LastCrawled) < minInterval {
 			continue
 		}
 
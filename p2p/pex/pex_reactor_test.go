@@ -137,6 +137,34 @@ func TestPEXReactorReceive(t *testing.T) {
 	r.Receive(p2p.Envelope{ChannelID: PexChannel, Src: peer, Message: &tmp2p.PexRequest{}})
 }
 
+func TestPEXReactorRestart(t *testing.T) {
+	r, book := createReactor(&ReactorConfig{})
+	defer teardownReactor(book)
+	peer := p2p.CreateRandomPeer(false)
+	r.AddPeer(peer)
+	size := book.Size()
+
+	sw := createSwitchAndAddReactors(r)
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	r.requestsSent.Set(string(peer.ID()), struct{}{})
+	r.lastReceivedRequests.Set(string(peer.ID()), time.Now())
+
+	// as done by the switch after a panic
+	require.NoError(t, r.Stop())
+	require.NoError(t, r.Reset())
+	require.NoError(t, r.Start())
+	assert.True(t, book.IsRunning())
+	assert.Equal(t, size, book.Size(), "expected the addresses to be loaded again from the file")
+	assert.Zero(t, r.requestsSent.Size())
+	assert.Zero(t, r.lastReceivedRequests.Size())
+}
+
 func TestPEXReactorRequestMessageAbuse(t *testing.T) {
 	r, book := createReactor(&ReactorConfig{})
 	defer teardownReactor(book)
@@ -1,8 +1,11 @@
 package p2p
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"math"
+	"slices"
 	"sync"
 	"time"
 
@@ -28,6 +31,10 @@ const (
 	// ie. 3**10 = 16hrs.
 	reconnectBackOffAttempts    = 10
 	reconnectBackOffBaseSeconds = 3
+
+	// preferredChannelPriorityFactor is the factor the priority of the
+	// preferred channels of a persistent peer is multiplied by.
+	preferredChannelPriorityFactor = 10
 )
 
 // MConnConfig returns an MConnConfig with fields updated
@@ -73,6 +80,9 @@ type Switch struct {
 
 	config        *config.P2PConfig
 	reactors      map[string]Reactor
+	reactorNames  []string       // in the order the reactors were added
+	reactorGroup  *service.Group // started reactors
+	isolated      map[string]*isolatedReactor
 	chDescs       []*conn.ChannelDescriptor
 	reactorsByCh  map[byte]Reactor
 	msgTypeByChID map[byte]proto.Message
@@ -85,6 +95,12 @@ type Switch struct {
 	// peers addresses with whom we'll maintain constant connection
 	persistentPeersAddrs []*NetAddress
 	unconditionalPeerIDs map[ID]struct{}
+	// dial policies of some of the persistent peers
+	persistentPeerPolicies map[ID]PersistentPeerPolicy
+
+	// channels marked busy by the reactors, on all the peers
+	busyChannelsMtx sync.Mutex
+	busyChannels    map[byte]struct{}
 
 	transport Transport
 
@@ -117,6 +133,7 @@ func NewSwitch(
 		reactors:             make(map[string]Reactor),
 		chDescs:              make([]*conn.ChannelDescriptor, 0),
 		reactorsByCh:         make(map[byte]Reactor),
+		isolated:             make(map[string]*isolatedReactor),
 		msgTypeByChID:        make(map[byte]proto.Message),
 		peers:                NewPeerSet(),
 		dialing:              cmap.NewCMap(),
@@ -127,6 +144,9 @@ func NewSwitch(
 		persistentPeersAddrs: make([]*NetAddress, 0),
 		unconditionalPeerIDs: make(map[ID]struct{}),
 		mlc:                  newMetricsLabelCache(),
+
+		persistentPeerPolicies: make(map[ID]PersistentPeerPolicy),
+		busyChannels:           make(map[byte]struct{}),
 	}
 
 	// Ensure we have a completely undeterministic PRNG.
@@ -159,9 +179,21 @@ func WithMetrics(metrics *Metrics) SwitchOption {
 //---------------------------------------------------------------------
 // Switch setup
 
-// AddReactor adds the given reactor to the switch.
+// AddReactor adds the given reactor to the switch. If the reactor is listed in
+// isolated_reactors, a panic of it while receiving a message only stops the
+// reactor, until it is restarted, instead of the node.
 // NOTE: Not goroutine safe.
 func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
+	receiver := reactor
+	if slices.Contains(sw.config.IsolatedReactors, name) {
+		receiver = newIsolatedReactor(name, reactor, sw)
+		switch r := receiver.(type) {
+		case *isolatedReactor:
+			sw.isolated[name] = r
+		case *isolatedBufferedReactor:
+			sw.isolated[name] = r.isolatedReactor
+		}
+	}
 	for _, chDesc := range reactor.GetChannels() {
 		chID := chDesc.ID
 		// No two reactors can share the same channel.
@@ -169,9 +201,12 @@ func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
 			panic(fmt.Sprintf("Channel %X has multiple reactors %v & %v", chID, sw.reactorsByCh[chID], reactor))
 		}
 		sw.chDescs = append(sw.chDescs, chDesc)
-		sw.reactorsByCh[chID] = reactor
+		sw.reactorsByCh[chID] = receiver
 		sw.msgTypeByChID[chID] = chDesc.MessageType
 	}
+	if _, ok := sw.reactors[name]; !ok {
+		sw.reactorNames = append(sw.reactorNames, name)
+	}
 	sw.reactors[name] = reactor
 	reactor.SetSwitch(sw)
 	return reactor
@@ -192,9 +227,48 @@ func (sw *Switch) RemoveReactor(name string, reactor Reactor) {
 		delete(sw.msgTypeByChID, chDesc.ID)
 	}
 	delete(sw.reactors, name)
+	delete(sw.isolated, name)
+	if i := slices.Index(sw.reactorNames, name); i >= 0 {
+		sw.reactorNames = slices.Delete(sw.reactorNames, i, i+1)
+	}
 	reactor.SetSwitch(nil)
 }
 
+// AddCustomReactor adds the given reactor of another project to the switch,
+// like AddReactor. Unlike AddReactor, it returns an error if another reactor
+// has the same name, or if a channel of the reactor is reserved, i.e. lower
+// than MinCustomChannelID, used by another reactor, or has no message type.
+// NOTE: Not goroutine safe.
+func (sw *Switch) AddCustomReactor(name string, reactor Reactor) error {
+	if sw.reactors[name] != nil {
+		return ErrSwitchDuplicateReactor{Name: name}
+	}
+	channels := make(map[byte]struct{})
+	for _, chDesc := range reactor.GetChannels() {
+		_, dup := channels[chDesc.ID]
+		switch {
+		case chDesc.ID < MinCustomChannelID:
+			return ErrSwitchReservedChannel{ChID: chDesc.ID}
+		case dup || sw.reactorsByCh[chDesc.ID] != nil:
+			return ErrSwitchDuplicateChannel{ChID: chDesc.ID}
+		case chDesc.MessageType == nil:
+			return ErrSwitchNoMessageType{ChID: chDesc.ID}
+		}
+		channels[chDesc.ID] = struct{}{}
+	}
+	sw.AddReactor(name, reactor)
+	return nil
+}
+
+// peerReactor returns the reactor with the given name, wrapped if isolated,
+// for the peers to be added to and removed from.
+func (sw *Switch) peerReactor(name string) Reactor {
+	if r, ok := sw.isolated[name]; ok {
+		return r
+	}
+	return sw.reactors[name]
+}
+
 // Reactors returns a map of reactors registered on the switch.
 // NOTE: Not goroutine safe.
 func (sw *Switch) Reactors() map[string]Reactor {
@@ -230,12 +304,15 @@ func (sw *Switch) SetNodeKey(nodeKey *NodeKey) {
 
 // OnStart implements BaseService. It starts all the reactors and peers.
 func (sw *Switch) OnStart() error {
-	// Start reactors
-	for _, reactor := range sw.reactors {
-		err := reactor.Start()
-		if err != nil {
-			return fmt.Errorf("failed to start %v: %w", reactor, err)
-		}
+	// Start reactors in the order they were added. If one fails to start, the
+	// ones started are stopped.
+	reactors := make([]service.Service, 0, len(sw.reactorNames))
+	for _, name := range sw.reactorNames {
+		reactors = append(reactors, sw.reactors[name])
+	}
+	sw.reactorGroup = service.NewGroup(sw.Logger, 0)
+	if err := sw.reactorGroup.Start(context.Background(), reactors...); err != nil {
+		return err
 	}
 
 	// Start accepting Peers.
@@ -252,11 +329,11 @@ func (sw *Switch) OnStop() {
 	}
 
 	// Stop reactors
+	// in the reverse order they were started; an isolated reactor may have
+	// been stopped by a panic
 	sw.Logger.Debug("Switch: Stopping reactors")
-	for _, reactor := range sw.reactors {
-		if err := reactor.Stop(); err != nil {
-			sw.Logger.Error("error while stopped reactor", "reactor", reactor, "error", err)
-		}
+	if err := sw.reactorGroup.Stop(); err != nil {
+		sw.Logger.Error("error while stopping reactors", "error", err)
 	}
 }
 
@@ -293,6 +370,25 @@ func (sw *Switch) Broadcast(e Envelope) chan bool {
 	return successChan
 }
 
+// SetChannelBusy marks the channel with the given ID as busy, or not busy
+// anymore, on all the peers, including the ones added later. A reactor marks
+// its channel busy when it can not keep up with the messages received on it,
+// so that the peers stop sending them, instead of the messages being
+// buffered. Use Peer.SetChannelBusy to mark a channel busy on a single peer.
+func (sw *Switch) SetChannelBusy(chID byte, busy bool) {
+	sw.busyChannelsMtx.Lock()
+	defer sw.busyChannelsMtx.Unlock()
+
+	if busy {
+		sw.busyChannels[chID] = struct{}{}
+	} else {
+		delete(sw.busyChannels, chID)
+	}
+	for _, peer := range sw.peers.List() {
+		peer.SetChannelBusy(chID, busy)
+	}
+}
+
 // NumPeers returns the count of outbound/inbound and outbound-dialing peers.
 // unconditional peers are not counted here.
 func (sw *Switch) NumPeers() (outbound, inbound, dialing int) {
@@ -368,8 +464,8 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 		sw.Logger.Error("error while stopping peer", "error", err) // TODO: should return error to be handled accordingly
 	}
 
-	for _, reactor := range sw.reactors {
-		reactor.RemovePeer(peer, reason)
+	for name := range sw.reactors {
+		sw.peerReactor(name).RemovePeer(peer, reason)
 	}
 
 	// Removing a peer should go last to avoid a situation where a peer
@@ -388,7 +484,9 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 // reconnectToPeer tries to reconnect to the addr, first repeatedly
 // with a fixed interval, then with exponential backoff.
 // If no success after all that, it stops trying, and leaves it
-// to the PEX/Addrbook to find the peer with the addr again
+// to the PEX/Addrbook to find the peer with the addr again.
+// The interval and the number of attempts can be overridden by the dial
+// policy of the peer (see PersistentPeerPolicy).
 // NOTE: this will keep trying even if the handshake or auth fails.
 // TODO: be more explicit with error types so we only retry on certain failures
 //   - ie. if we're getting ErrDuplicatePeer we can stop
@@ -400,9 +498,18 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 	sw.reconnecting.Set(string(addr.ID), addr)
 	defer sw.reconnecting.Delete(string(addr.ID))
 
+	policy := sw.persistentPeerPolicy(addr.ID)
+	interval, attempts, backOffAttempts := reconnectInterval, reconnectAttempts, reconnectBackOffAttempts
+	if policy.DialInterval > 0 {
+		interval = policy.DialInterval
+	}
+	if policy.MaxReconnectAttempts > 0 {
+		attempts, backOffAttempts = policy.MaxReconnectAttempts, 0
+	}
+
 	start := time.Now()
 	sw.Logger.Info("Reconnecting to peer", "addr", addr)
-	for i := 0; i < reconnectAttempts; i++ {
+	for i := 0; i < attempts; i++ {
 		if !sw.IsRunning() {
 			return
 		}
@@ -416,13 +523,17 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
 		// sleep a set amount
-		sw.randomSleep(reconnectInterval)
+		sw.randomSleep(interval)
 		continue
 	}
+	if backOffAttempts == 0 {
+		sw.Logger.Error("Failed to reconnect to peer. Giving up", "addr", addr, "elapsed", time.Since(start))
+		return
+	}
 
 	sw.Logger.Error("Failed to reconnect to peer. Beginning exponential backoff",
 		"addr", addr, "elapsed", time.Since(start))
-	for i := 0; i < reconnectBackOffAttempts; i++ {
+	for i := 0; i < backOffAttempts; i++ {
 		if !sw.IsRunning() {
 			return
 		}
@@ -469,11 +580,12 @@ func isPrivateAddr(err error) bool {
 
 // DialPeersAsync dials a list of peers asynchronously in random order.
 // Used to dial peers from config on startup or from unsafe-RPC (trusted sources).
+// Dual-stack hosts are dialed on their IPv6 address if p2p.prefer_ipv6 is set.
 // It ignores ErrNetAddressLookup. However, if there are other errors, first
 // encounter is returned.
 // Nop if there are no peers.
 func (sw *Switch) DialPeersAsync(peers []string) error {
-	netAddrs, errs := NewNetAddressStrings(peers)
+	netAddrs, errs := ResolveNetAddressStrings(peers, sw.config.PreferIPv6)
 	// report all the errors
 	for _, err := range errs {
 		sw.Logger.Error("Error in peer's address", "err", err)
@@ -576,7 +688,7 @@ func (sw *Switch) IsDialingOrExistingAddress(addr *NetAddress) bool {
 // returned.
 func (sw *Switch) AddPersistentPeers(addrs []string) error {
 	sw.Logger.Info("Adding persistent peers", "addrs", addrs)
-	netAddrs, errs := NewNetAddressStrings(addrs)
+	netAddrs, errs := ResolveNetAddressStrings(addrs, sw.config.PreferIPv6)
 	// report all the errors
 	for _, err := range errs {
 		sw.Logger.Error("Error in peer's address", "err", err)
@@ -619,6 +731,61 @@ func (sw *Switch) AddPrivatePeerIDs(ids []string) error {
 	return nil
 }
 
+// PersistentPeerPolicy is the dial policy of a persistent peer. The zero
+// value keeps the default behavior.
+type PersistentPeerPolicy struct {
+	// DialInterval is the interval between the attempts to reconnect to the
+	// peer, before the exponential backoff.
+	DialInterval time.Duration
+	// MaxReconnectAttempts, if not 0, is the number of attempts to reconnect
+	// to the peer, every DialInterval, before giving up. There is no
+	// exponential backoff then.
+	MaxReconnectAttempts int
+	// PreferredChannels are the channels whose messages are sent to the peer
+	// in priority.
+	PreferredChannels []byte
+}
+
+// SetPersistentPeerPolicy sets the dial policy of the peer with the given ID,
+// which should be one of the persistent peers.
+func (sw *Switch) SetPersistentPeerPolicy(id ID, policy PersistentPeerPolicy) error {
+	if err := validateID(id); err != nil {
+		return err
+	}
+	if policy.DialInterval < 0 {
+		return fmt.Errorf("negative dial interval %v", policy.DialInterval)
+	}
+	if policy.MaxReconnectAttempts < 0 {
+		return fmt.Errorf("negative max reconnect attempts %d", policy.MaxReconnectAttempts)
+	}
+	sw.persistentPeerPolicies[id] = policy
+	return nil
+}
+
+func (sw *Switch) persistentPeerPolicy(id ID) PersistentPeerPolicy {
+	return sw.persistentPeerPolicies[id]
+}
+
+// channelsFor returns the channel descriptors of the connection to the peer
+// with the given ID, with the priority of its preferred channels raised.
+func (sw *Switch) channelsFor(id ID) []*conn.ChannelDescriptor {
+	preferred := sw.persistentPeerPolicy(id).PreferredChannels
+	if len(preferred) == 0 {
+		return sw.chDescs
+	}
+
+	chDescs := make([]*conn.ChannelDescriptor, len(sw.chDescs))
+	for i, chDesc := range sw.chDescs {
+		chDescs[i] = chDesc
+		if bytes.IndexByte(preferred, chDesc.ID) >= 0 {
+			boosted := *chDesc
+			boosted.Priority *= preferredChannelPriorityFactor
+			chDescs[i] = &boosted
+		}
+	}
+	return chDescs
+}
+
 func (sw *Switch) IsPeerPersistent(na *NetAddress) bool {
 	for _, pa := range sw.persistentPeersAddrs {
 		if pa.Equals(na) {
@@ -632,6 +799,7 @@ func (sw *Switch) acceptRoutine() {
 	for {
 		p, err := sw.transport.Accept(peerConfig{
 			chDescs:       sw.chDescs,
+			channelsFor:   sw.channelsFor,
 			onPeerError:   sw.StopPeerForError,
 			reactorsByCh:  sw.reactorsByCh,
 			msgTypeByChID: sw.msgTypeByChID,
@@ -705,9 +873,7 @@ func (sw *Switch) acceptRoutine() {
 
 		if err := sw.addPeer(p); err != nil {
 			sw.transport.Cleanup(p)
-			if p.IsRunning() {
-				_ = p.Stop()
-			}
+			sw.stopFailedPeer(p)
 			sw.Logger.Info(
 				"Ignoring inbound connection: error while adding peer",
 				"err", err,
@@ -736,6 +902,7 @@ func (sw *Switch) addOutboundPeerWithConfig(
 
 	p, err := sw.transport.Dial(*addr, peerConfig{
 		chDescs:       sw.chDescs,
+		channelsFor:   sw.channelsFor,
 		onPeerError:   sw.StopPeerForError,
 		isPersistent:  sw.IsPeerPersistent,
 		reactorsByCh:  sw.reactorsByCh,
@@ -766,9 +933,7 @@ func (sw *Switch) addOutboundPeerWithConfig(
 
 	if err := sw.addPeer(p); err != nil {
 		sw.transport.Cleanup(p)
-		if p.IsRunning() {
-			_ = p.Stop()
-		}
+		sw.stopFailedPeer(p)
 		return err
 	}
 
@@ -803,6 +968,16 @@ func (sw *Switch) filterPeer(p Peer) error {
 	return nil
 }
 
+// stopFailedPeer stops a peer which could not be added, if it was started.
+func (sw *Switch) stopFailedPeer(p Peer) {
+	if !p.IsRunning() {
+		return
+	}
+	if err := p.Stop(); err != nil {
+		sw.Logger.Error("Error stopping peer", "peer", p, "err", err)
+	}
+}
+
 // addPeer starts up the Peer and adds it to the Switch. Error is returned if
 // the peer is filtered out or failed to start or can't be added.
 func (sw *Switch) addPeer(p Peer) error {
@@ -848,9 +1023,15 @@ func (sw *Switch) addPeer(p Peer) error {
 	}
 	sw.metrics.Peers.Add(float64(1))
 
+	sw.busyChannelsMtx.Lock()
+	for chID := range sw.busyChannels {
+		p.SetChannelBusy(chID, true)
+	}
+	sw.busyChannelsMtx.Unlock()
+
 	// Start all the reactor protocols on the peer.
-	for _, reactor := range sw.reactors {
-		reactor.AddPeer(p)
+	for name := range sw.reactors {
+		sw.peerReactor(name).AddPeer(p)
 	}
 
 	sw.Logger.Debug("Added peer", "peer", p)
@@ -173,6 +173,218 @@ func TestSwitches(t *testing.T) {
 		s2.Reactor("bar").(*TestReactor), 200*time.Millisecond, 5*time.Second)
 }
 
+// testBufferedReactor is a TestReactor receiving the message bytes.
+type testBufferedReactor struct {
+	*TestReactor
+}
+
+func (tr testBufferedReactor) ReceiveBuffer(e BufferEnvelope) {
+	msg, err := e.Unmarshal(&p2pproto.Message{})
+	if err != nil {
+		panic(err)
+	}
+	tr.Receive(Envelope{ChannelID: e.ChannelID, Src: e.Src, Message: msg})
+}
+
+func TestSwitchBufferedReactor(t *testing.T) {
+	s1, s2 := MakeSwitchPair(func(_ int, sw *Switch) *Switch {
+		sw.AddReactor("foo", testBufferedReactor{NewTestReactor([]*conn.ChannelDescriptor{
+			{ID: byte(0x00), Priority: 10, MessageType: &p2pproto.Message{}},
+		}, true)})
+		return sw
+	})
+	t.Cleanup(func() {
+		if err := s1.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Cleanup(func() {
+		if err := s2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	msg := &p2pproto.PexAddrs{
+		Addrs: []p2pproto.NetAddress{
+			{
+				ID: "1",
+			},
+		},
+	}
+	s1.Broadcast(Envelope{ChannelID: byte(0x00), Message: msg})
+	assertMsgReceivedWithTimeout(t,
+		msg,
+		byte(0x00),
+		s2.Reactor("foo").(testBufferedReactor).TestReactor, 200*time.Millisecond, 5*time.Second)
+}
+
+// panickingReactor is a TestReactor panicking on the first message it
+// receives, and counting its peers.
+type panickingReactor struct {
+	*TestReactor
+	panicked atomic.Bool
+	peers    atomic.Int32 // since reset
+	added    atomic.Int32 // in total
+}
+
+func newPanickingReactor(channels []*conn.ChannelDescriptor) *panickingReactor {
+	r := &panickingReactor{TestReactor: NewTestReactor(channels, true)}
+	r.BaseReactor = *NewBaseReactor("PanickingReactor", r)
+	return r
+}
+
+func (r *panickingReactor) Receive(e Envelope) {
+	if r.panicked.CompareAndSwap(false, true) {
+		panic("test panic")
+	}
+	r.TestReactor.Receive(e)
+}
+
+func (r *panickingReactor) AddPeer(Peer) {
+	r.peers.Add(1)
+	r.added.Add(1)
+}
+
+func (r *panickingReactor) RemovePeer(Peer, interface{}) { r.peers.Add(-1) }
+
+func (r *panickingReactor) OnReset() error {
+	r.peers.Store(0)
+	return nil
+}
+
+func TestSwitchIsolatedReactorPanic(t *testing.T) {
+	conf := *cfg
+	conf.IsolatedReactors = []string{"foo"}
+	conf.ReactorRestartDelay = time.Second
+	switches := MakeConnectedSwitches(&conf, 2, func(_ int, sw *Switch) *Switch {
+		sw.AddReactor("foo", newPanickingReactor([]*conn.ChannelDescriptor{
+			{ID: byte(0x00), Priority: 10, MessageType: &p2pproto.Message{}},
+		}))
+		return sw
+	}, Connect2Switches)
+	s1, s2 := switches[0], switches[1]
+	t.Cleanup(func() {
+		if err := s1.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Cleanup(func() {
+		if err := s2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	reactor := s2.Reactor("foo").(*panickingReactor)
+	isolated := s2.reactorsByCh[0x00].(*isolatedReactor)
+	require.EqualValues(t, 1, reactor.peers.Load())
+
+	msg := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: "1"}}}
+	s1.Broadcast(Envelope{ChannelID: byte(0x00), Message: msg})
+	require.Eventually(t, reactor.panicked.Load, 5*time.Second, 10*time.Millisecond)
+
+	// the peer which caused the panic stays connected
+	require.True(t, isolated.stopped.Load())
+	require.Equal(t, 1, s1.Peers().Size())
+	require.Equal(t, 1, s2.Peers().Size())
+
+	// the peers are not added to the stopped reactor
+	s1.StopPeerGracefully(s1.Peers().List()[0])
+	require.Eventually(t, func() bool {
+		return s1.Peers().Size() == 0 && s2.Peers().Size() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	Connect2Switches(switches, 0, 1)
+	require.Equal(t, 1, s2.Peers().Size())
+	require.True(t, isolated.stopped.Load(), "expected the reactor to be restarted after the peer is connected")
+	assert.EqualValues(t, 1, reactor.added.Load())
+
+	// the reactor is restarted, with the current peers
+	require.Eventually(t, func() bool {
+		return reactor.IsRunning() && !isolated.stopped.Load()
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, 1, reactor.peers.Load())
+	assert.EqualValues(t, 2, reactor.added.Load())
+
+	s1.Broadcast(Envelope{ChannelID: byte(0x00), Message: msg})
+	assertMsgReceivedWithTimeout(t, msg, byte(0x00), reactor.TestReactor, 10*time.Millisecond, 5*time.Second)
+}
+
+func TestSwitchAddCustomReactor(t *testing.T) {
+	received := make(chan proto.Message, 1)
+	s1, s2 := MakeSwitchPair(func(_ int, sw *Switch) *Switch {
+		sw = initSwitchFunc(0, sw)
+		err := sw.AddCustomReactor("custom", NewHookReactor("custom", []*conn.ChannelDescriptor{
+			{ID: MinCustomChannelID, Priority: 10, MessageType: &p2pproto.Message{}},
+		}, ReactorHooks{
+			Receive: func(e Envelope) { received <- e.Message },
+		}))
+		require.NoError(t, err)
+		return sw
+	})
+	t.Cleanup(func() {
+		if err := s1.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Cleanup(func() {
+		if err := s2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	msg := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: "1"}}}
+	s1.Broadcast(Envelope{ChannelID: MinCustomChannelID, Message: msg})
+	select {
+	case got := <-received:
+		assert.True(t, proto.Equal(msg, got), "unexpected message %v", got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the custom reactor to receive the message")
+	}
+
+	newReactor := func(chID byte) Reactor {
+		return NewHookReactor("other", []*conn.ChannelDescriptor{
+			{ID: chID, Priority: 10, MessageType: &p2pproto.Message{}},
+		}, ReactorHooks{})
+	}
+	err := s1.AddCustomReactor("custom", newReactor(MinCustomChannelID+1))
+	require.ErrorAs(t, err, &ErrSwitchDuplicateReactor{})
+	err = s1.AddCustomReactor("other", newReactor(0x02))
+	require.ErrorAs(t, err, &ErrSwitchReservedChannel{})
+	err = s1.AddCustomReactor("other", newReactor(MinCustomChannelID))
+	require.ErrorAs(t, err, &ErrSwitchDuplicateChannel{})
+	err = s1.AddCustomReactor("other", NewHookReactor("other", []*conn.ChannelDescriptor{
+		{ID: MinCustomChannelID + 1, Priority: 10},
+	}, ReactorHooks{}))
+	require.ErrorAs(t, err, &ErrSwitchNoMessageType{})
+}
+
+func TestSwitchReactorsStartStopOrder(t *testing.T) {
+	var events []string
+	names := []string{"c", "a", "d", "b"}
+	sw := MakeSwitch(cfg, 1, func(_ int, sw *Switch) *Switch {
+		for i, name := range names {
+			name := name
+			sw.AddReactor(name, NewHookReactor(name, []*conn.ChannelDescriptor{
+				{ID: byte(0x10 + i), Priority: 10, MessageType: &p2pproto.Message{}},
+			}, ReactorHooks{
+				OnStart: func() error {
+					events = append(events, "start "+name)
+					return nil
+				},
+				OnStop: func() { events = append(events, "stop "+name) },
+			}))
+		}
+		return sw
+	})
+
+	// the reactors are started in the order they were added, and stopped in
+	// the reverse order
+	require.NoError(t, sw.Start())
+	require.NoError(t, sw.Stop())
+	assert.Equal(t, []string{
+		"start c", "start a", "start d", "start b",
+		"stop b", "stop d", "stop a", "stop c",
+	}, events)
+}
+
 func assertMsgReceivedWithTimeout(
 	t *testing.T,
 	msg proto.Message,
@@ -186,11 +398,11 @@ func assertMsgReceivedWithTimeout(
 		select {
 		case <-ticker.C:
 			msgs := reactor.getMsgs(channel)
-			expectedBytes, err := proto.Marshal(msgs[0].Contents)
-			require.NoError(t, err)
-			gotBytes, err := proto.Marshal(msg)
-			require.NoError(t, err)
 			if len(msgs) > 0 {
+				expectedBytes, err := proto.Marshal(msgs[0].Contents)
+				require.NoError(t, err)
+				gotBytes, err := proto.Marshal(msg)
+				require.NoError(t, err)
 				if !bytes.Equal(expectedBytes, gotBytes) {
 					t.Fatalf("Unexpected message bytes. Wanted: %X, Got: %X", msg, msgs[0].Counter)
 				}
@@ -544,6 +756,57 @@ func TestSwitchReconnectsToInboundPersistentPeer(t *testing.T) {
 	assert.Equal(t, 1, sw.Peers().Size())
 }
 
+func TestSwitchPersistentPeerPolicy(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, initSwitchFunc)
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// nobody listens at this address
+	addr, err := NewNetAddressString(IDAddressString(PubKeyToID(ed25519.GenPrivKey().PubKey()), "127.0.0.1:1"))
+	require.NoError(t, err)
+	err = sw.AddPersistentPeers([]string{addr.String()})
+	require.NoError(t, err)
+
+	err = sw.SetPersistentPeerPolicy(addr.ID, PersistentPeerPolicy{MaxReconnectAttempts: -1})
+	require.Error(t, err)
+	err = sw.SetPersistentPeerPolicy(addr.ID, PersistentPeerPolicy{
+		DialInterval:         10 * time.Millisecond,
+		MaxReconnectAttempts: 1,
+		PreferredChannels:    []byte{0x01},
+	})
+	require.NoError(t, err)
+
+	// the preferred channels are sent in priority
+	chDescs := sw.channelsFor(addr.ID)
+	require.Len(t, chDescs, len(sw.chDescs))
+	for i, chDesc := range chDescs {
+		if chDesc.ID == 0x01 {
+			assert.Equal(t, 10*preferredChannelPriorityFactor, chDesc.Priority)
+		} else {
+			assert.Same(t, sw.chDescs[i], chDesc)
+		}
+	}
+	assert.Equal(t, 10, sw.chDescs[1].Priority)
+	assert.Equal(t, sw.chDescs, sw.channelsFor(PubKeyToID(ed25519.GenPrivKey().PubKey())))
+
+	// gives up after max_reconnect_attempts, without exponential backoff
+	done := make(chan struct{})
+	go func() {
+		sw.reconnectToPeer(addr)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * dialRandomizerIntervalMilliseconds * time.Millisecond):
+		t.Fatal("expected the switch to give up reconnecting")
+	}
+}
+
 func TestSwitchDialPeersAsync(t *testing.T) {
 	if testing.Short() {
 		return
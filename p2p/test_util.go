@@ -38,9 +38,10 @@ func CreateRandomPeer(outbound bool) Peer {
 			outbound:   outbound,
 			socketAddr: netAddr,
 		},
-		nodeInfo: mockNodeInfo{netAddr},
-		mconn:    &conn.MConnection{},
-		metrics:  NopMetrics(),
+		nodeInfo:    mockNodeInfo{netAddr},
+		mconn:       &conn.MConnection{},
+		metrics:     NopMetrics(),
+		msgCounters: newMessageCounters(),
 	}
 	p.SetLogger(log.TestingLogger().With("peer", addr))
 	return p
@@ -169,7 +170,7 @@ func (sw *Switch) addPeerWithConnection(conn net.Conn) error {
 		return err
 	}
 
-	ni, err := handshake(conn, time.Second, sw.nodeInfo)
+	ni, _, err := handshake(conn, time.Second, sw.nodeInfo)
 	if err != nil {
 		if err := conn.Close(); err != nil {
 			sw.Logger.Error("Error closing connection", "err", err)
@@ -18,6 +18,10 @@ const (
 	defaultDialTimeout      = time.Second
 	defaultFilterTimeout    = 5 * time.Second
 	defaultHandshakeTimeout = 3 * time.Second
+
+	// flowControlP2PProtocol is the first P2P protocol version supporting
+	// flow control (PacketFlowControl).
+	flowControlP2PProtocol uint64 = 10
 )
 
 // IPResolver is a behavior subset of net.Resolver.
@@ -41,7 +45,10 @@ type accept struct {
 // events.
 // TODO(xla): Refactor out with more static Reactor setup and PeerBehaviour.
 type peerConfig struct {
-	chDescs     []*conn.ChannelDescriptor
+	chDescs []*conn.ChannelDescriptor
+	// channelsFor, if set, returns the channel descriptors to use for the
+	// peer with the given ID, instead of chDescs.
+	channelsFor func(ID) []*conn.ChannelDescriptor
 	onPeerError func(Peer, interface{})
 	outbound    bool
 	// isPersistent allows you to set a function, which, given socket address
@@ -132,12 +139,35 @@ func MultiplexTransportMaxIncomingConnections(n int) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.maxIncomingConnections = n }
 }
 
+// MultiplexTransportHandshakeRules sets the rules the peers must comply with
+// to pass the handshake. Default: nil (no rule).
+func MultiplexTransportHandshakeRules(rules *HandshakeRules) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.handshakeRules = rules }
+}
+
+// MultiplexTransportClockSkewDetector sets the detector the times reported by
+// the peers in the handshake are fed to.
+func MultiplexTransportClockSkewDetector(d *ClockSkewDetector) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.clockSkew = d }
+}
+
+// MultiplexTransportMemoryNetwork makes the transport listen and dial on the
+// given in-memory network instead of TCP. Only the socket layer is replaced:
+// the connections still go through the secret connection handshake and the
+// multiplexed connection framing.
+func MultiplexTransportMemoryNetwork(network *MemoryNetwork) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.memoryNetwork = network }
+}
+
 // MultiplexTransport accepts and dials tcp connections and upgrades them to
 // multiplexed peers.
 type MultiplexTransport struct {
 	netAddr                NetAddress
 	listener               net.Listener
-	maxIncomingConnections int // see MaxIncomingConnections
+	maxIncomingConnections int            // see MaxIncomingConnections
+	memoryNetwork          *MemoryNetwork // replaces TCP, if set
+	handshakeRules         *HandshakeRules
+	clockSkew              *ClockSkewDetector
 
 	acceptc chan accept
 	closec  chan struct{}
@@ -213,7 +243,15 @@ func (mt *MultiplexTransport) Dial(
 	addr NetAddress,
 	cfg peerConfig,
 ) (Peer, error) {
-	c, err := addr.DialTimeout(mt.dialTimeout)
+	var (
+		c   net.Conn
+		err error
+	)
+	if mt.memoryNetwork != nil {
+		c, err = mt.memoryNetwork.Dial(mt.netAddr, addr)
+	} else {
+		c, err = addr.DialTimeout(mt.dialTimeout)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -253,11 +291,25 @@ func (mt *MultiplexTransport) Close() error {
 
 // Listen implements transportLifecycle.
 func (mt *MultiplexTransport) Listen(addr NetAddress) error {
-	ln, err := net.Listen("tcp", addr.DialString())
+	var (
+		ln  net.Listener
+		err error
+	)
+	if mt.memoryNetwork != nil {
+		ln, err = mt.memoryNetwork.Listen(addr)
+	} else {
+		ln, err = net.Listen("tcp", addr.DialString())
+	}
 	if err != nil {
 		return err
 	}
 
+	return mt.ListenOn(addr, ln)
+}
+
+// ListenOn makes the transport accept the peers connecting to ln, e.g. when
+// the port of addr is shared with other services.
+func (mt *MultiplexTransport) ListenOn(addr NetAddress, ln net.Listener) error {
 	if mt.maxIncomingConnections > 0 {
 		ln = netutil.LimitListener(ln, mt.maxIncomingConnections)
 	}
@@ -443,7 +495,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
+	nodeInfo, clock, err := handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -484,7 +536,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
+	if err := compatibleWith(mt.nodeInfo, nodeInfo, mt.handshakeRules); err != nil {
 		return nil, nil, ErrRejected{
 			conn:           c,
 			err:            err,
@@ -493,6 +545,11 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
+	// The peers before v1 don't report their time.
+	if mt.clockSkew != nil && !clock.remote.IsZero() {
+		mt.clockSkew.AddSample(nodeInfo.ID(), clock.skew())
+	}
+
 	return secretConn, nodeInfo, nil
 }
 
@@ -521,13 +578,24 @@ func (mt *MultiplexTransport) wrapPeer(
 		socketAddr,
 	)
 
+	chDescs := cfg.chDescs
+	if cfg.channelsFor != nil {
+		chDescs = cfg.channelsFor(ni.ID())
+	}
+
+	// Only send PacketFlowControls to the peers able to decode them.
+	mConfig := mt.mConfig
+	if dni, ok := ni.(DefaultNodeInfo); ok {
+		mConfig.FlowControl = dni.ProtocolVersion.P2P >= flowControlP2PProtocol
+	}
+
 	p := newPeer(
 		peerConn,
-		mt.mConfig,
+		mConfig,
 		ni,
 		cfg.reactorsByCh,
 		cfg.msgTypeByChID,
-		cfg.chDescs,
+		chDescs,
 		cfg.onPeerError,
 		cfg.mlc,
 		PeerMetrics(cfg.metrics),
@@ -540,9 +608,9 @@ func handshake(
 	c net.Conn,
 	timeout time.Duration,
 	nodeInfo NodeInfo,
-) (NodeInfo, error) {
+) (NodeInfo, clockSample, error) {
 	if err := c.SetDeadline(time.Now().Add(timeout)); err != nil {
-		return nil, err
+		return nil, clockSample{}, err
 	}
 
 	var (
@@ -551,10 +619,13 @@ func handshake(
 		pbpeerNodeInfo tmp2p.DefaultNodeInfo
 		peerNodeInfo   DefaultNodeInfo
 		ourNodeInfo    = nodeInfo.(DefaultNodeInfo)
+		clock          = clockSample{start: time.Now()}
 	)
 
 	go func(errc chan<- error, c net.Conn) {
-		_, err := protoio.NewDelimitedWriter(c).WriteMsg(ourNodeInfo.ToProto())
+		pbNodeInfo := ourNodeInfo.ToProto()
+		pbNodeInfo.Time = clock.start
+		_, err := protoio.NewDelimitedWriter(c).WriteMsg(pbNodeInfo)
 		errc <- err
 	}(errc, c)
 	go func(errc chan<- error, c net.Conn) {
@@ -566,16 +637,18 @@ func handshake(
 	for i := 0; i < cap(errc); i++ {
 		err := <-errc
 		if err != nil {
-			return nil, err
+			return nil, clockSample{}, err
 		}
 	}
+	clock.end = time.Now()
+	clock.remote = pbpeerNodeInfo.Time
 
 	peerNodeInfo, err := DefaultNodeInfoFromToProto(&pbpeerNodeInfo)
 	if err != nil {
-		return nil, err
+		return nil, clockSample{}, err
 	}
 
-	return peerNodeInfo, c.SetDeadline(time.Time{})
+	return peerNodeInfo, clock, c.SetDeadline(time.Time{})
 }
 
 func upgradeSecretConn(
@@ -19,6 +19,29 @@ type Envelope struct {
 	ChannelID byte
 }
 
+// BufferEnvelope contains the bytes of a received message, in a pooled
+// buffer, with sender routing info. See BufferedReactor.
+type BufferEnvelope struct {
+	Src       Peer         // sender
+	Buffer    *conn.Buffer // message bytes, to be released
+	ChannelID byte
+}
+
+// Unmarshal unmarshals the message bytes into msg, unwrapping it if needed,
+// and releases the buffer. It is a shortcut for the reactors handling only
+// some of their messages without unmarshaling them.
+func (e BufferEnvelope) Unmarshal(msg proto.Message) (proto.Message, error) {
+	err := proto.Unmarshal(e.Buffer.Bytes(), msg)
+	e.Buffer.Release()
+	if err != nil {
+		return nil, err
+	}
+	if w, ok := msg.(types.Unwrapper); ok {
+		return w.Unwrap()
+	}
+	return msg, nil
+}
+
 var (
 	_ types.Wrapper = &tmp2p.PexRequest{}
 	_ types.Wrapper = &tmp2p.PexAddrs{}
@@ -21,6 +21,20 @@ var (
 	ErrWriteTimeout      = errors.New("endpoint write timed out")
 )
 
+// Last sign state errors.
+var (
+	ErrStateChecksum = errors.New("last sign state checksum mismatch: the file is corrupted")
+	ErrClockRollback = errors.New("clock rollback: the last sign state was saved in the future")
+	ErrStaleState    = errors.New("stale last sign state")
+)
+
+// ErrSignRefused is returned when the SignGuard refuses a signature.
+var ErrSignRefused = errors.New("signature refused by the sign guard")
+
+// ErrKeyPassphrase is returned when an encrypted key file cannot be decrypted
+// with the given passphrase.
+var ErrKeyPassphrase = errors.New("wrong passphrase for the private validator key")
+
 // RemoteSignerError allows (remote) validators to include meaningful error
 // descriptions in their reply.
 type RemoteSignerError struct {
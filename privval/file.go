@@ -2,6 +2,7 @@ package privval
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -10,8 +11,11 @@ import (
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtos "github.com/cometbft/cometbft/internal/os"
 	"github.com/cometbft/cometbft/internal/protoio"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
 	"github.com/cometbft/cometbft/internal/tempfile"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
@@ -47,11 +51,32 @@ type FilePVKey struct {
 	Address types.Address  `json:"address"`
 	PubKey  crypto.PubKey  `json:"pub_key"`
 	PrivKey crypto.PrivKey `json:"priv_key"`
+	// FromHeight is the first height signed by the key, if it replaced a
+	// previous key in a rotation. The key does not sign below it.
+	FromHeight int64 `json:"from_height,omitempty"`
 
 	filePath string
+	// passphrase encrypts the file if not nil.
+	passphrase []byte
 }
 
-// Save persists the FilePVKey to its filePath.
+// parseFilePVKey parses the content of a key file which is not encrypted.
+func parseFilePVKey(jsonBytes []byte) (FilePVKey, error) {
+	pvKey := FilePVKey{}
+	if err := cmtjson.Unmarshal(jsonBytes, &pvKey); err != nil {
+		return pvKey, err
+	}
+	if pvKey.PrivKey == nil {
+		return pvKey, errors.New("missing private key")
+	}
+	// overwrite pubkey and address for convenience
+	pvKey.PubKey = pvKey.PrivKey.PubKey()
+	pvKey.Address = pvKey.PubKey.Address()
+	return pvKey, nil
+}
+
+// Save persists the FilePVKey to its filePath, encrypted if it was loaded from
+// an encrypted file.
 func (pvKey FilePVKey) Save() {
 	outFile := pvKey.filePath
 	if outFile == "" {
@@ -62,6 +87,11 @@ func (pvKey FilePVKey) Save() {
 	if err != nil {
 		panic(err)
 	}
+	if pvKey.passphrase != nil {
+		if jsonBytes, err = encryptKeyFile(jsonBytes, pvKey.passphrase); err != nil {
+			panic(err)
+		}
+	}
 
 	if err := tempfile.WriteFileAtomic(outFile, jsonBytes, 0o600); err != nil {
 		panic(err)
@@ -70,6 +100,35 @@ func (pvKey FilePVKey) Save() {
 
 //-------------------------------------------------------------------------------
 
+// FilePVKeyRotation stores the next key of a FilePV and the first height it
+// signs.
+type FilePVKeyRotation struct {
+	Height int64     `json:"height"`
+	Key    FilePVKey `json:"key"`
+
+	filePath string
+}
+
+// Save persists the FilePVKeyRotation to its filePath, encrypted with the
+// passphrase of the next key, if any.
+func (rot FilePVKeyRotation) Save() error {
+	if rot.filePath == "" {
+		return errors.New("cannot save FilePVKeyRotation: filePath not set")
+	}
+	jsonBytes, err := cmtjson.MarshalIndent(rot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if rot.Key.passphrase != nil {
+		if jsonBytes, err = encryptKeyFile(jsonBytes, rot.Key.passphrase); err != nil {
+			return err
+		}
+	}
+	return tempfile.WriteFileAtomic(rot.filePath, jsonBytes, 0o600)
+}
+
+//-------------------------------------------------------------------------------
+
 // FilePVLastSignState stores the mutable part of PrivValidator.
 type FilePVLastSignState struct {
 	Height    int64             `json:"height"`
@@ -77,6 +136,12 @@ type FilePVLastSignState struct {
 	Step      int8              `json:"step"`
 	Signature []byte            `json:"signature,omitempty"`
 	SignBytes cmtbytes.HexBytes `json:"signbytes,omitempty"`
+	// SavedAt is the time at which the state was last saved, used to detect a
+	// rollback of the clock.
+	SavedAt time.Time `json:"saved_at,omitempty"`
+	// Checksum is the SHA-256 checksum of the other fields, used to detect a
+	// corrupted file. The files written by older versions have none.
+	Checksum cmtbytes.HexBytes `json:"checksum,omitempty"`
 
 	filePath string
 }
@@ -129,13 +194,58 @@ func (lss *FilePVLastSignState) CheckHRS(height int64, round int32, step int8) (
 	return false, nil
 }
 
-// Save persists the FilePvLastSignState to its filePath.
+// checksum returns the checksum of the state, computed over all its fields
+// but the Checksum itself.
+func (lss FilePVLastSignState) checksum() []byte {
+	lss.Checksum = nil
+	jsonBytes, err := cmtjson.Marshal(lss)
+	if err != nil {
+		panic(err)
+	}
+	return tmhash.Sum(jsonBytes)
+}
+
+// VerifyChecksum returns ErrStateChecksum if the state does not match its
+// Checksum. A state without a Checksum, written by an older version, is
+// accepted.
+func (lss *FilePVLastSignState) VerifyChecksum() error {
+	if lss.Checksum == nil {
+		return nil
+	}
+	if !bytes.Equal(lss.Checksum, lss.checksum()) {
+		return ErrStateChecksum
+	}
+	return nil
+}
+
+// Check verifies the checksum of the state, and returns ErrClockRollback if the
+// state was saved after now, and ErrStaleState if the validator is known to
+// have signed at signedHeight, above the height of the state, e.g. because an
+// old copy of the file was restored.
+func (lss *FilePVLastSignState) Check(now time.Time, signedHeight int64) error {
+	if err := lss.VerifyChecksum(); err != nil {
+		return err
+	}
+	if now.Before(lss.SavedAt) {
+		return fmt.Errorf("%w: saved at %v, now is %v", ErrClockRollback, lss.SavedAt, now)
+	}
+	if signedHeight > lss.Height {
+		return fmt.Errorf("%w: last height %v, but signed at height %v", ErrStaleState, lss.Height, signedHeight)
+	}
+	return nil
+}
+
+// Save persists the FilePvLastSignState to its filePath, along with the time
+// and its checksum. The file is replaced atomically and synced to disk.
 func (lss *FilePVLastSignState) Save() {
 	outFile := lss.filePath
 	if outFile == "" {
 		panic("cannot save FilePVLastSignState: filePath not set")
 	}
-	jsonBytes, err := cmtjson.MarshalIndent(lss, "", "  ")
+	saved := *lss
+	saved.SavedAt = cmttime.Now()
+	saved.Checksum = saved.checksum()
+	jsonBytes, err := cmtjson.MarshalIndent(saved, "", "  ")
 	if err != nil {
 		panic(err)
 	}
@@ -145,6 +255,24 @@ func (lss *FilePVLastSignState) Save() {
 	}
 }
 
+// LoadFilePVState loads a FilePVLastSignState from the stateFilePath and
+// verifies its checksum.
+func LoadFilePVState(stateFilePath string) (*FilePVLastSignState, error) {
+	stateJSONBytes, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	pvState := &FilePVLastSignState{}
+	if err := cmtjson.Unmarshal(stateJSONBytes, pvState); err != nil {
+		return nil, err
+	}
+	if err := pvState.VerifyChecksum(); err != nil {
+		return nil, err
+	}
+	pvState.filePath = stateFilePath
+	return pvState, nil
+}
+
 //-------------------------------------------------------------------------------
 
 // FilePV implements PrivValidator using data persisted to disk
@@ -155,8 +283,21 @@ func (lss *FilePVLastSignState) Save() {
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	guard SignGuard
+
+	rotationMtx      cmtsync.Mutex
+	rotation         *FilePVKeyRotation
+	rotationFilePath string
+	// the highest height the validator signed at or was asked the key of.
+	height int64
 }
 
+var (
+	_ types.KeyRotator        = (*FilePV)(nil)
+	_ types.AttestationSigner = (*FilePV)(nil)
+)
+
 // NewFilePV generates a new validator from the given key and paths.
 func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FilePV {
 	return &FilePV{
@@ -179,47 +320,71 @@ func GenFilePV(keyFilePath, stateFilePath string) *FilePV {
 	return NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath)
 }
 
+// LoadOption configures the loading of a FilePV.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	passphrase PassphraseFunc
+}
+
+// WithPassphrase sets the function returning the passphrase of the key file,
+// called if the file is encrypted. The key file is encrypted with the same
+// passphrase whenever it is saved again.
+func WithPassphrase(passphrase PassphraseFunc) LoadOption {
+	return func(opts *loadOptions) { opts.passphrase = passphrase }
+}
+
 // LoadFilePV loads a FilePV from the filePaths.  The FilePV handles double
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
-func LoadFilePV(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+func LoadFilePV(keyFilePath, stateFilePath string, options ...LoadOption) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, true, options)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
-func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+func LoadFilePVEmptyState(keyFilePath, stateFilePath string, options ...LoadOption) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, false, options)
 }
 
 // If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool, options []LoadOption) *FilePV {
+	opts := loadOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
 	keyJSONBytes, err := os.ReadFile(keyFilePath)
 	if err != nil {
 		cmtos.Exit(err.Error())
 	}
-	pvKey := FilePVKey{}
-	err = cmtjson.Unmarshal(keyJSONBytes, &pvKey)
+	var passphrase []byte
+	if IsEncryptedKeyFile(keyJSONBytes) {
+		if opts.passphrase == nil {
+			cmtos.Exit(fmt.Sprintf("PrivValidator key %v is encrypted, but no passphrase was provided\n", keyFilePath))
+		}
+		if passphrase, err = opts.passphrase(); err != nil {
+			cmtos.Exit(fmt.Sprintf("Error getting the passphrase of PrivValidator key %v: %v\n", keyFilePath, err))
+		}
+		if keyJSONBytes, err = decryptKeyFile(keyJSONBytes, passphrase); err != nil {
+			cmtos.Exit(fmt.Sprintf("Error decrypting PrivValidator key from %v: %v\n", keyFilePath, err))
+		}
+	}
+	pvKey, err := parseFilePVKey(keyJSONBytes)
 	if err != nil {
 		cmtos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
 	}
-
-	// overwrite pubkey and address for convenience
-	pvKey.PubKey = pvKey.PrivKey.PubKey()
-	pvKey.Address = pvKey.PubKey.Address()
 	pvKey.filePath = keyFilePath
+	pvKey.passphrase = passphrase
 
 	pvState := FilePVLastSignState{}
 
 	if loadState {
-		stateJSONBytes, err := os.ReadFile(stateFilePath)
-		if err != nil {
-			cmtos.Exit(err.Error())
-		}
-		err = cmtjson.Unmarshal(stateJSONBytes, &pvState)
+		state, err := LoadFilePVState(stateFilePath)
 		if err != nil {
 			cmtos.Exit(fmt.Sprintf("Error reading PrivValidator state from %v: %v\n", stateFilePath, err))
 		}
+		pvState = *state
 	}
 
 	pvState.filePath = stateFilePath
@@ -232,10 +397,11 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
-func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
+// A generated key file is not encrypted.
+func LoadOrGenFilePV(keyFilePath, stateFilePath string, options ...LoadOption) *FilePV {
 	var pv *FilePV
 	if cmtos.FileExists(keyFilePath) {
-		pv = LoadFilePV(keyFilePath, stateFilePath)
+		pv = LoadFilePV(keyFilePath, stateFilePath, options...)
 	} else {
 		pv = GenFilePV(keyFilePath, stateFilePath)
 		pv.Save()
@@ -249,9 +415,17 @@ func (pv *FilePV) GetAddress() types.Address {
 	return pv.Key.Address
 }
 
-// GetPubKey returns the public key of the validator.
+// GetPubKey returns the public key of the validator, at the highest height
+// it signed at or was asked the key of with GetPubKeyAt, so that it agrees
+// with the key consensus signs with during a rotation.
 // Implements PrivValidator.
 func (pv *FilePV) GetPubKey() (crypto.PubKey, error) {
+	pv.rotationMtx.Lock()
+	defer pv.rotationMtx.Unlock()
+
+	if pv.rotation != nil && max(pv.height, pv.LastSignState.Height) >= pv.rotation.Height {
+		return pv.rotation.Key.PubKey, nil
+	}
 	return pv.Key.PubKey, nil
 }
 
@@ -259,7 +433,7 @@ func (pv *FilePV) GetPubKey() (crypto.PubKey, error) {
 // chainID. Implements PrivValidator.
 func (pv *FilePV) SignVote(chainID string, vote *cmtproto.Vote) error {
 	if err := pv.signVote(chainID, vote); err != nil {
-		return fmt.Errorf("error signing vote: %v", err)
+		return fmt.Errorf("error signing vote: %w", err)
 	}
 	return nil
 }
@@ -268,11 +442,184 @@ func (pv *FilePV) SignVote(chainID string, vote *cmtproto.Vote) error {
 // the chainID. Implements PrivValidator.
 func (pv *FilePV) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
 	if err := pv.signProposal(chainID, proposal); err != nil {
-		return fmt.Errorf("error signing proposal: %v", err)
+		return fmt.Errorf("error signing proposal: %w", err)
+	}
+	return nil
+}
+
+// SignAttestation signs a canonical representation of the attestation, along
+// with the chainID, with the key signing at the attested height. Implements
+// types.AttestationSigner.
+//
+// Attestations only refer to committed blocks, so they can't lead to double
+// signing and don't update the last sign state. Heights below a completed key
+// rotation can't be attested, since the key signing them was replaced.
+func (pv *FilePV) SignAttestation(chainID string, attestation *cmtproto.Attestation) error {
+	key, err := pv.keyAt(attestation.Height)
+	if err != nil {
+		return err
+	}
+	sig, err := key.PrivKey.Sign(types.AttestationSignBytes(chainID, attestation))
+	if err != nil {
+		return fmt.Errorf("error signing attestation: %w", err)
 	}
+	attestation.Signature = sig
 	return nil
 }
 
+// LoadKeyRotation loads the key rotation scheduled in filePath, if the file
+// exists, and saves the rotations scheduled later to it.
+func (pv *FilePV) LoadKeyRotation(filePath string) error {
+	pv.rotationMtx.Lock()
+	defer pv.rotationMtx.Unlock()
+
+	pv.rotationFilePath = filePath
+	if !cmtos.FileExists(filePath) {
+		return nil
+	}
+	jsonBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if IsEncryptedKeyFile(jsonBytes) {
+		if pv.Key.passphrase == nil {
+			return fmt.Errorf("PrivValidator next key %v is encrypted, but the current key is not", filePath)
+		}
+		if jsonBytes, err = decryptKeyFile(jsonBytes, pv.Key.passphrase); err != nil {
+			return fmt.Errorf("error decrypting PrivValidator next key from %v: %w", filePath, err)
+		}
+	}
+	rot := &FilePVKeyRotation{}
+	if err := cmtjson.Unmarshal(jsonBytes, rot); err != nil {
+		return fmt.Errorf("error reading PrivValidator next key from %v: %w", filePath, err)
+	}
+	rot.Key.PubKey = rot.Key.PrivKey.PubKey()
+	rot.Key.Address = rot.Key.PubKey.Address()
+	rot.Key.filePath = pv.Key.filePath
+	rot.Key.passphrase = pv.Key.passphrase
+	rot.filePath = filePath
+	if bytes.Equal(rot.Key.Address, pv.Key.Address) {
+		// The rotation completed, but the node stopped before removing the file.
+		return os.Remove(filePath)
+	}
+	pv.rotation = rot
+	return nil
+}
+
+// ScheduleKeyRotation generates the next key of the validator, which signs
+// from the given height on, and saves it to the file given to LoadKeyRotation.
+// Implements types.KeyRotator.
+//
+// The validator set does not follow by itself: for the network to accept the
+// signatures of the next key, the application must return, in the
+// FinalizeBlockResponse of height-2 at the latest (the validator updates of a
+// block take effect two heights later), a ValidatorUpdate removing the current
+// public key (power 0) and one adding the next public key with the same power.
+// Once the validator signed with the next key, the next key replaces the
+// current one in the key file.
+func (pv *FilePV) ScheduleKeyRotation(height int64) (crypto.PubKey, error) {
+	pv.rotationMtx.Lock()
+	defer pv.rotationMtx.Unlock()
+
+	if pv.rotationFilePath == "" {
+		return nil, errors.New("no file to save the next key to")
+	}
+	if height <= pv.LastSignState.Height {
+		return nil, fmt.Errorf("rotation height %d must be above the last signed height %d",
+			height, pv.LastSignState.Height)
+	}
+	var privKey crypto.PrivKey
+	switch pv.Key.PrivKey.Type() {
+	case ed25519.KeyType:
+		privKey = ed25519.GenPrivKey()
+	case secp256k1.KeyType:
+		privKey = secp256k1.GenPrivKey()
+	default:
+		return nil, fmt.Errorf("cannot generate a next key of type %s", pv.Key.PrivKey.Type())
+	}
+	rot := &FilePVKeyRotation{
+		Height: height,
+		Key: FilePVKey{
+			Address:    privKey.PubKey().Address(),
+			PubKey:     privKey.PubKey(),
+			PrivKey:    privKey,
+			filePath:   pv.Key.filePath,
+			passphrase: pv.Key.passphrase,
+		},
+		filePath: pv.rotationFilePath,
+	}
+	if err := rot.Save(); err != nil {
+		return nil, err
+	}
+	pv.rotation = rot
+	return rot.Key.PubKey, nil
+}
+
+// NextPubKey returns the next public key and the first height it signs, or nil
+// if no rotation is scheduled. Implements types.KeyRotator.
+func (pv *FilePV) NextPubKey() (crypto.PubKey, int64) {
+	pv.rotationMtx.Lock()
+	defer pv.rotationMtx.Unlock()
+
+	if pv.rotation == nil {
+		return nil, 0
+	}
+	return pv.rotation.Key.PubKey, pv.rotation.Height
+}
+
+// GetPubKeyAt returns the public key signing at the given height, or an error
+// if the height is below a completed key rotation. Implements
+// types.KeyRotator.
+func (pv *FilePV) GetPubKeyAt(height int64) (crypto.PubKey, error) {
+	key, err := pv.keyAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return key.PubKey, nil
+}
+
+// keyAt returns the key signing at the given height, and records the height
+// for GetPubKey. The key which signed below a completed rotation is gone, so
+// these heights return an error.
+func (pv *FilePV) keyAt(height int64) (FilePVKey, error) {
+	pv.rotationMtx.Lock()
+	defer pv.rotationMtx.Unlock()
+
+	if height < pv.Key.FromHeight {
+		return FilePVKey{}, fmt.Errorf("the key signing at height %d was rotated out at height %d",
+			height, pv.Key.FromHeight)
+	}
+	pv.height = max(pv.height, height)
+	if pv.rotation != nil && height >= pv.rotation.Height {
+		return pv.rotation.Key, nil
+	}
+	return pv.Key, nil
+}
+
+// completeKeyRotation replaces the current key with the next one, once the
+// latter signed at the given height.
+func (pv *FilePV) completeKeyRotation(height int64) {
+	pv.rotationMtx.Lock()
+	defer pv.rotationMtx.Unlock()
+
+	if pv.rotation == nil || height < pv.rotation.Height {
+		return
+	}
+	pv.Key = pv.rotation.Key
+	pv.Key.FromHeight = pv.rotation.Height
+	pv.Key.Save()
+	if err := os.Remove(pv.rotation.filePath); err != nil && !os.IsNotExist(err) {
+		panic(err)
+	}
+	pv.rotation = nil
+}
+
+// SetSignGuard sets the SignGuard consulted before each new signature of a
+// vote or a proposal.
+func (pv *FilePV) SetSignGuard(guard SignGuard) {
+	pv.guard = guard
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
@@ -305,6 +652,10 @@ func (pv *FilePV) String() string {
 // Extension signatures are always signed for non-nil precommits (even if the data is empty).
 func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
 	height, round, step := vote.Height, vote.Round, voteToStep(vote)
+	key, err := pv.keyAt(height)
+	if err != nil {
+		return err
+	}
 
 	lss := pv.LastSignState
 
@@ -323,7 +674,7 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
 	var extSig []byte
 	if vote.Type == types.PrecommitType && !types.ProtoBlockIDIsNil(&vote.BlockID) {
 		extSignBytes := types.VoteExtensionSignBytes(chainID, vote)
-		extSig, err = pv.Key.PrivKey.Sign(extSignBytes)
+		extSig, err = key.PrivKey.Sign(extSignBytes)
 		if err != nil {
 			return err
 		}
@@ -353,8 +704,12 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
 		return err
 	}
 
+	if err := pv.checkSign(chainID, key.Address, height, round, step, signBytes); err != nil {
+		return err
+	}
+
 	// It passed the checks. Sign the vote
-	sig, err := pv.Key.PrivKey.Sign(signBytes)
+	sig, err := key.PrivKey.Sign(signBytes)
 	if err != nil {
 		return err
 	}
@@ -370,6 +725,10 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
 // a previously signed proposal ie. we crashed after signing but before the proposal hit the WAL).
 func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) error {
 	height, round, step := proposal.Height, proposal.Round, stepPropose
+	key, err := pv.keyAt(height)
+	if err != nil {
+		return err
+	}
 
 	lss := pv.LastSignState
 
@@ -397,8 +756,12 @@ func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) erro
 		return err
 	}
 
+	if err := pv.checkSign(chainID, key.Address, height, round, step, signBytes); err != nil {
+		return err
+	}
+
 	// It passed the checks. Sign the proposal
-	sig, err := pv.Key.PrivKey.Sign(signBytes)
+	sig, err := key.PrivKey.Sign(signBytes)
 	if err != nil {
 		return err
 	}
@@ -407,6 +770,21 @@ func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) erro
 	return nil
 }
 
+// checkSign consults the SignGuard, if any, before a new signature.
+func (pv *FilePV) checkSign(chainID string, address types.Address, height int64, round int32, step int8, signBytes []byte) error {
+	if pv.guard == nil {
+		return nil
+	}
+	return pv.guard.CheckSign(context.Background(), SignRequest{
+		ChainID:   chainID,
+		Address:   address,
+		Height:    height,
+		Round:     round,
+		Step:      stepName(step),
+		SignBytes: signBytes,
+	})
+}
+
 // Persist height/round/step and signature.
 func (pv *FilePV) saveSigned(height int64, round int32, step int8,
 	signBytes []byte, sig []byte,
@@ -417,6 +795,7 @@ func (pv *FilePV) saveSigned(height int64, round int32, step int8,
 	pv.LastSignState.Signature = sig
 	pv.LastSignState.SignBytes = signBytes
 	pv.LastSignState.Save()
+	pv.completeKeyRotation(height)
 }
 
 //-----------------------------------------------------------------------------------------
@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -78,6 +80,46 @@ func TestLoadOrGenValidator(t *testing.T) {
 	assert.Equal(addr, privVal.GetAddress(), "expected privval addr to be the same")
 }
 
+func TestLastSignStateChecksum(t *testing.T) {
+	privVal, _, tempStateFileName := newTestFilePV(t)
+	privVal.LastSignState.Height = 100
+	privVal.Save()
+
+	state, err := LoadFilePVState(tempStateFileName)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, state.Height)
+	assert.NotEmpty(t, state.Checksum)
+	assert.False(t, state.SavedAt.IsZero())
+
+	// Corrupt the height.
+	bz, err := os.ReadFile(tempStateFileName)
+	require.NoError(t, err)
+	bz = []byte(strings.Replace(string(bz), `"100"`, `"10"`, 1))
+	require.NoError(t, os.WriteFile(tempStateFileName, bz, 0o600))
+	_, err = LoadFilePVState(tempStateFileName)
+	require.ErrorIs(t, err, ErrStateChecksum)
+
+	// The files written by older versions have no checksum.
+	legacy := `{"height": "10", "round": 0, "step": 0}`
+	require.NoError(t, os.WriteFile(tempStateFileName, []byte(legacy), 0o600))
+	state, err = LoadFilePVState(tempStateFileName)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, state.Height)
+}
+
+func TestLastSignStateCheck(t *testing.T) {
+	privVal, _, tempStateFileName := newTestFilePV(t)
+	privVal.LastSignState.Height = 100
+	privVal.Save()
+
+	state, err := LoadFilePVState(tempStateFileName)
+	require.NoError(t, err)
+	now := cmttime.Now()
+	require.NoError(t, state.Check(now, 100))
+	require.ErrorIs(t, state.Check(now, 101), ErrStaleState)
+	require.ErrorIs(t, state.Check(state.SavedAt.Add(-time.Minute), 100), ErrClockRollback)
+}
+
 func TestUnmarshalValidatorState(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
@@ -365,6 +407,84 @@ func TestVoteExtensionsAreAlwaysSigned(t *testing.T) {
 	assert.False(t, pubKey.VerifySignature(vesb1, vpb2.ExtensionSignature))
 }
 
+func TestKeyRotation(t *testing.T) {
+	privVal, tempKeyFileName, tempStateFileName := newTestFilePV(t)
+	privVal.Save()
+	nextKeyFileName := filepath.Join(t.TempDir(), "priv_validator_next_key.json")
+	oldPubKey := privVal.Key.PubKey
+
+	// A rotation needs a file to save the next key to.
+	_, err := privVal.ScheduleKeyRotation(10)
+	require.Error(t, err)
+	require.NoError(t, privVal.LoadKeyRotation(nextKeyFileName))
+
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	vote := newVote(oldPubKey.Address(), 0, 5, 0, types.PrevoteType, blockID, nil).ToProto()
+	require.NoError(t, privVal.SignVote("mychainid", vote))
+
+	_, err = privVal.ScheduleKeyRotation(5)
+	require.Error(t, err, "expected an error scheduling a rotation at a signed height")
+	nextPubKey, err := privVal.ScheduleKeyRotation(10)
+	require.NoError(t, err)
+	assert.NotEqual(t, oldPubKey, nextPubKey)
+	assert.FileExists(t, nextKeyFileName)
+
+	// The rotation survives a restart.
+	privVal = LoadFilePV(tempKeyFileName, tempStateFileName)
+	require.NoError(t, privVal.LoadKeyRotation(nextKeyFileName))
+	pubKey, height := privVal.NextPubKey()
+	assert.Equal(t, nextPubKey, pubKey)
+	assert.EqualValues(t, 10, height)
+
+	pubKey, err = privVal.GetPubKeyAt(9)
+	require.NoError(t, err)
+	assert.Equal(t, oldPubKey, pubKey)
+	pubKey, err = privVal.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, oldPubKey, pubKey)
+
+	// Once consensus gets the key of the rotation height, GetPubKey agrees
+	// with it.
+	pubKey, err = privVal.GetPubKeyAt(10)
+	require.NoError(t, err)
+	assert.Equal(t, nextPubKey, pubKey)
+	pubKey, err = privVal.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, nextPubKey, pubKey)
+
+	// The current key signs below the rotation height.
+	vote = newVote(oldPubKey.Address(), 0, 9, 0, types.PrevoteType, blockID, nil).ToProto()
+	require.NoError(t, privVal.SignVote("mychainid", vote))
+	assert.True(t, oldPubKey.VerifySignature(types.VoteSignBytes("mychainid", vote), vote.Signature))
+	assert.Equal(t, oldPubKey, privVal.Key.PubKey)
+
+	// The next key signs from the rotation height on, and replaces the
+	// current one.
+	proposal := newProposal(10, 0, blockID).ToProto()
+	require.NoError(t, privVal.SignProposal("mychainid", proposal))
+	assert.True(t, nextPubKey.VerifySignature(types.ProposalSignBytes("mychainid", proposal), proposal.Signature))
+	assert.Equal(t, nextPubKey, privVal.Key.PubKey)
+	pubKey, _ = privVal.NextPubKey()
+	assert.Nil(t, pubKey)
+	assert.NoFileExists(t, nextKeyFileName)
+
+	// The heights below the rotation can't be signed anymore, even after a
+	// restart, while the attestations of the next heights are signed with the
+	// next key.
+	for _, privVal := range []*FilePV{privVal, LoadFilePV(tempKeyFileName, tempStateFileName)} {
+		assert.Equal(t, nextPubKey, privVal.Key.PubKey)
+		_, err = privVal.GetPubKeyAt(9)
+		require.Error(t, err)
+
+		attestation := types.NewAttestation(9, blockID.Hash, cmtrand.Bytes(tmhash.Size), cmttime.Now(), oldPubKey.Address()).ToProto()
+		require.Error(t, privVal.SignAttestation("mychainid", attestation))
+
+		attestation = types.NewAttestation(10, blockID.Hash, cmtrand.Bytes(tmhash.Size), cmttime.Now(), nextPubKey.Address()).ToProto()
+		require.NoError(t, privVal.SignAttestation("mychainid", attestation))
+		assert.True(t, nextPubKey.VerifySignature(types.AttestationSignBytes("mychainid", attestation), attestation.Signature))
+	}
+}
+
 func newVote(addr types.Address, idx int32, height int64, round int32,
 	typ types.SignedMsgType, blockID types.BlockID, extension []byte,
 ) *types.Vote {
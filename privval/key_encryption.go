@@ -0,0 +1,195 @@
+package privval
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/term"
+
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/internal/tempfile"
+)
+
+const (
+	keyEncryptionKDF    = "argon2id"
+	keyEncryptionCipher = "xchacha20-poly1305"
+
+	// Argon2id parameters of the newly encrypted key files, as recommended by
+	// RFC 9106 for memory-constrained environments.
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024 // KiB
+	argon2Threads = 4
+	argon2SaltLen = 16
+
+	// CredentialName is the name of the systemd credential holding the
+	// passphrase of an encrypted key file.
+	CredentialName = "priv_validator_key_passphrase"
+)
+
+// PassphraseFunc returns the passphrase of an encrypted key file. It is only
+// called if the key file is encrypted.
+type PassphraseFunc func() ([]byte, error)
+
+// encryptedFile is the format of an encrypted key file. The ciphertext is the
+// encryption of the JSON the file holds when not encrypted.
+type encryptedFile struct {
+	Encryption keyEncryption `json:"encryption"`
+	Ciphertext []byte        `json:"ciphertext"`
+}
+
+type keyEncryption struct {
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	Cipher  string `json:"cipher"`
+	Nonce   []byte `json:"nonce"`
+}
+
+// IsEncryptedKeyFile returns true if jsonBytes is the content of an encrypted
+// key file.
+func IsEncryptedKeyFile(jsonBytes []byte) bool {
+	var f encryptedFile
+	return json.Unmarshal(jsonBytes, &f) == nil && f.Encryption.KDF != ""
+}
+
+// encryptKeyFile encrypts the content of a key file with passphrase.
+func encryptKeyFile(jsonBytes, passphrase []byte) ([]byte, error) {
+	enc := keyEncryption{
+		KDF:     keyEncryptionKDF,
+		Salt:    make([]byte, argon2SaltLen),
+		Time:    argon2Time,
+		Memory:  argon2Memory,
+		Threads: argon2Threads,
+		Cipher:  keyEncryptionCipher,
+		Nonce:   make([]byte, chacha20poly1305.NonceSizeX),
+	}
+	if _, err := rand.Read(enc.Salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(enc.Nonce); err != nil {
+		return nil, err
+	}
+	aead, err := enc.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encryptedFile{
+		Encryption: enc,
+		Ciphertext: aead.Seal(nil, enc.Nonce, jsonBytes, nil),
+	}, "", "  ")
+}
+
+// decryptKeyFile decrypts the content of an encrypted key file with
+// passphrase.
+func decryptKeyFile(jsonBytes, passphrase []byte) ([]byte, error) {
+	var f encryptedFile
+	if err := json.Unmarshal(jsonBytes, &f); err != nil {
+		return nil, err
+	}
+	if f.Encryption.KDF != keyEncryptionKDF {
+		return nil, fmt.Errorf("unsupported key derivation function %q", f.Encryption.KDF)
+	}
+	if f.Encryption.Cipher != keyEncryptionCipher {
+		return nil, fmt.Errorf("unsupported cipher %q", f.Encryption.Cipher)
+	}
+	aead, err := f.Encryption.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Encryption.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length %d", len(f.Encryption.Nonce))
+	}
+	plaintext, err := aead.Open(nil, f.Encryption.Nonce, f.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrKeyPassphrase
+	}
+	return plaintext, nil
+}
+
+func (enc keyEncryption) aead(passphrase []byte) (cipher.AEAD, error) {
+	if enc.Time == 0 || enc.Threads == 0 {
+		return nil, errors.New("invalid argon2id parameters")
+	}
+	key := argon2.IDKey(passphrase, enc.Salt, enc.Time, enc.Memory, enc.Threads, chacha20poly1305.KeySize)
+	return chacha20poly1305.NewX(key)
+}
+
+// EncryptKeyFile encrypts the key file at keyFilePath with passphrase, in
+// place. It returns an error if the file is encrypted already.
+func EncryptKeyFile(keyFilePath string, passphrase []byte) error {
+	if len(passphrase) == 0 {
+		return errors.New("empty passphrase")
+	}
+	jsonBytes, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return err
+	}
+	if IsEncryptedKeyFile(jsonBytes) {
+		return fmt.Errorf("%s is encrypted already", keyFilePath)
+	}
+	// Make sure the file holds a key before encrypting it.
+	if _, err := parseFilePVKey(jsonBytes); err != nil {
+		return fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
+	}
+	encrypted, err := encryptKeyFile(jsonBytes, passphrase)
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(keyFilePath, encrypted, 0o600)
+}
+
+// NewPassphraseFunc returns a PassphraseFunc reading the passphrase from the
+// first available source:
+//   - passphraseFile, if not empty;
+//   - the CredentialName systemd credential, if the process was given one;
+//   - the terminal, prompting the user, if the standard input is one.
+//
+// A trailing newline is trimmed from the passphrase read from a file.
+func NewPassphraseFunc(passphraseFile string) PassphraseFunc {
+	return func() ([]byte, error) {
+		if passphraseFile != "" {
+			return readPassphraseFile(passphraseFile)
+		}
+		if dir := os.Getenv("CREDENTIALS_DIRECTORY"); dir != "" {
+			credFile := filepath.Join(dir, CredentialName)
+			if cmtos.FileExists(credFile) {
+				return readPassphraseFile(credFile)
+			}
+		}
+		return PromptPassphrase("Enter the passphrase of the private validator key: ")
+	}
+}
+
+// PromptPassphrase prompts the user for a passphrase on the terminal, without
+// echoing it.
+func PromptPassphrase(prompt string) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, errors.New("cannot prompt for the passphrase: the standard input is not a terminal")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return passphrase, nil
+}
+
+func readPassphraseFile(path string) ([]byte, error) {
+	passphrase, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return bytes.TrimRight(passphrase, "\r\n"), nil
+}
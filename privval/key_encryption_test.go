@@ -0,0 +1,45 @@
+package privval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptKeyFile(t *testing.T) {
+	privVal, tempKeyFileName, tempStateFileName := newTestFilePV(t)
+	privVal.Save()
+	passphrase := []byte("correct horse battery staple")
+
+	require.Error(t, EncryptKeyFile(tempKeyFileName, nil), "expected an error with an empty passphrase")
+	require.NoError(t, EncryptKeyFile(tempKeyFileName, passphrase))
+	require.Error(t, EncryptKeyFile(tempKeyFileName, passphrase), "expected an error encrypting twice")
+
+	jsonBytes, err := os.ReadFile(tempKeyFileName)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedKeyFile(jsonBytes))
+	assert.NotContains(t, string(jsonBytes), "priv_key")
+
+	_, err = decryptKeyFile(jsonBytes, []byte("wrong"))
+	require.ErrorIs(t, err, ErrKeyPassphrase)
+
+	passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, append(passphrase, '\n'), 0o600))
+	loaded := LoadFilePV(tempKeyFileName, tempStateFileName, WithPassphrase(NewPassphraseFunc(passphraseFile)))
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+	assert.Equal(t, privVal.Key.Address, loaded.Key.Address)
+
+	// Saving the key keeps it encrypted.
+	loaded.Save()
+	jsonBytes, err = os.ReadFile(tempKeyFileName)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedKeyFile(jsonBytes))
+	plaintext, err := decryptKeyFile(jsonBytes, passphrase)
+	require.NoError(t, err)
+	pvKey, err := parseFilePVKey(plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, privVal.Key.PrivKey, pvKey.PrivKey)
+}
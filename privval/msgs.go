@@ -27,6 +27,10 @@ func mustWrapMsg(pb proto.Message) pvproto.Message {
 		msg.Sum = &pvproto.Message_SignedProposalResponse{SignedProposalResponse: pb}
 	case *pvproto.SignProposalRequest:
 		msg.Sum = &pvproto.Message_SignProposalRequest{SignProposalRequest: pb}
+	case *pvproto.SignAttestationRequest:
+		msg.Sum = &pvproto.Message_SignAttestationRequest{SignAttestationRequest: pb}
+	case *pvproto.SignedAttestationResponse:
+		msg.Sum = &pvproto.Message_SignedAttestationResponse{SignedAttestationResponse: pb}
 	case *pvproto.PingRequest:
 		msg.Sum = &pvproto.Message_PingRequest{PingRequest: pb}
 	case *pvproto.PingResponse:
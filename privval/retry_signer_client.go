@@ -23,7 +23,10 @@ func NewRetrySignerClient(sc *SignerClient, retries int, timeout time.Duration)
 	return &RetrySignerClient{sc, retries, timeout}
 }
 
-var _ types.PrivValidator = (*RetrySignerClient)(nil)
+var (
+	_ types.PrivValidator     = (*RetrySignerClient)(nil)
+	_ types.AttestationSigner = (*RetrySignerClient)(nil)
+)
 
 func (sc *RetrySignerClient) Close() error {
 	return sc.next.Close()
@@ -94,3 +97,19 @@ func (sc *RetrySignerClient) SignProposal(chainID string, proposal *cmtproto.Pro
 	}
 	return fmt.Errorf("exhausted all attempts to sign proposal: %w", err)
 }
+
+func (sc *RetrySignerClient) SignAttestation(chainID string, attestation *cmtproto.Attestation) error {
+	var err error
+	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+		err = sc.next.SignAttestation(chainID, attestation)
+		if err == nil {
+			return nil
+		}
+		// If remote signer errors, we don't retry.
+		if _, ok := err.(*RemoteSignerError); ok {
+			return err
+		}
+		time.Sleep(sc.timeout)
+	}
+	return fmt.Errorf("exhausted all attempts to sign attestation: %w", err)
+}
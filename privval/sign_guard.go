@@ -0,0 +1,92 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/types"
+)
+
+// SignRequest describes a signature the validator is about to produce.
+type SignRequest struct {
+	ChainID   string            `json:"chain_id"`
+	Address   types.Address     `json:"address"`
+	Height    int64             `json:"height"`
+	Round     int32             `json:"round"`
+	Step      string            `json:"step"` // propose, prevote or precommit
+	SignBytes cmtbytes.HexBytes `json:"sign_bytes"`
+}
+
+// SignGuard is consulted by FilePV before each new signature of a vote or a
+// proposal. It lets an external arbiter, which tracks the signatures of the
+// redundant instances of a validator, refuse the ones that could lead to
+// double signing, enabling active-active setups.
+//
+// Re-signing the same height, round and step, e.g. after a crash, reuses the
+// last signature and does not consult the SignGuard.
+type SignGuard interface {
+	// CheckSign returns an error if the validator must not sign req.
+	CheckSign(ctx context.Context, req SignRequest) error
+}
+
+// HTTPSignGuard is a SignGuard POSTing the SignRequest, as JSON, to an
+// external service, which must respond 200 OK to allow the signature. Any
+// other response, or a failure to reach the service, refuses it.
+type HTTPSignGuard struct {
+	url    string
+	client *http.Client
+}
+
+var _ SignGuard = (*HTTPSignGuard)(nil)
+
+// NewHTTPSignGuard returns an HTTPSignGuard consulting the service at url,
+// whose requests time out after timeout (0 means no timeout).
+func NewHTTPSignGuard(url string, timeout time.Duration) *HTTPSignGuard {
+	return &HTTPSignGuard{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// CheckSign implements SignGuard.
+func (g *HTTPSignGuard) CheckSign(ctx context.Context, req SignRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSignRefused, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: %s: %s", ErrSignRefused, resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+func stepName(step int8) string {
+	switch step {
+	case stepPropose:
+		return "propose"
+	case stepPrevote:
+		return "prevote"
+	case stepPrecommit:
+		return "precommit"
+	default:
+		return "none"
+	}
+}
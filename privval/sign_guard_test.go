@@ -0,0 +1,83 @@
+package privval
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestHTTPSignGuard(t *testing.T) {
+	var (
+		mtx      sync.Mutex
+		requests []SignRequest
+	)
+	signRequests := func() []SignRequest {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return requests
+	}
+	// The arbiter allows a single signature per height, round and step.
+	arbiter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, prev := range requests {
+			if prev.Height == req.Height && prev.Round == req.Round && prev.Step == req.Step {
+				http.Error(w, "already signed", http.StatusConflict)
+				return
+			}
+		}
+		requests = append(requests, req)
+	}))
+	defer arbiter.Close()
+
+	guard := NewHTTPSignGuard(arbiter.URL, time.Second)
+	privVal1, _, _ := newTestFilePV(t)
+	privVal1.SetSignGuard(guard)
+	privVal2, _, _ := newTestFilePV(t)
+	privVal2.SetSignGuard(guard)
+
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+	vote := newVote(privVal1.Key.Address, 0, 10, 1, types.PrevoteType, blockID, nil)
+	require.NoError(t, privVal1.SignVote("mychainid", vote.ToProto()))
+	require.Len(t, signRequests(), 1)
+	assert.Equal(t, SignRequest{
+		ChainID:   "mychainid",
+		Address:   privVal1.Key.Address,
+		Height:    10,
+		Round:     1,
+		Step:      "prevote",
+		SignBytes: types.VoteSignBytes("mychainid", vote.ToProto()),
+	}, signRequests()[0])
+
+	// Re-signing the same vote reuses the last signature.
+	require.NoError(t, privVal1.SignVote("mychainid", vote.ToProto()))
+	require.Len(t, signRequests(), 1)
+
+	// Another instance cannot sign at the same height, round and step.
+	err := privVal2.SignVote("mychainid", vote.ToProto())
+	require.ErrorIs(t, err, ErrSignRefused)
+	assert.EqualValues(t, 0, privVal2.LastSignState.Height)
+
+	proposal := newProposal(10, 1, blockID)
+	require.NoError(t, privVal2.SignProposal("mychainid", proposal.ToProto()))
+
+	// The signatures are refused if the arbiter is unreachable.
+	arbiter.Close()
+	vote = newVote(privVal1.Key.Address, 0, 11, 0, types.PrevoteType, blockID, nil)
+	require.ErrorIs(t, privVal1.SignVote("mychainid", vote.ToProto()), ErrSignRefused)
+}
@@ -19,7 +19,10 @@ type SignerClient struct {
 	chainID  string
 }
 
-var _ types.PrivValidator = (*SignerClient)(nil)
+var (
+	_ types.PrivValidator     = (*SignerClient)(nil)
+	_ types.AttestationSigner = (*SignerClient)(nil)
+)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started).
@@ -132,3 +135,25 @@ func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal
 
 	return nil
 }
+
+// SignAttestation requests a remote signer to sign an attestation.
+func (sc *SignerClient) SignAttestation(chainID string, attestation *cmtproto.Attestation) error {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(
+		&pvproto.SignAttestationRequest{Attestation: attestation, ChainId: chainID},
+	))
+	if err != nil {
+		return err
+	}
+
+	resp := response.GetSignedAttestationResponse()
+	if resp == nil {
+		return cmterrors.ErrRequiredField{Field: "response"}
+	}
+	if resp.Error != nil {
+		return &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	*attestation = resp.Attestation
+
+	return nil
+}
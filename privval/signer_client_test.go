@@ -154,6 +154,35 @@ func TestSignerProposal(t *testing.T) {
 	}
 }
 
+func TestSignerAttestation(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		ts := time.Now()
+		hash := cmtrand.Bytes(tmhash.Size)
+		appHash := cmtrand.Bytes(tmhash.Size)
+		valAddr := cmtrand.Bytes(crypto.AddressSize)
+		want := types.NewAttestation(1, hash, appHash, ts, valAddr).ToProto()
+		have := types.NewAttestation(1, hash, appHash, ts, valAddr).ToProto()
+
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		require.NoError(t, tc.mockPV.(types.AttestationSigner).SignAttestation(tc.chainID, want))
+		require.NoError(t, tc.signerClient.SignAttestation(tc.chainID, have))
+
+		assert.NotEmpty(t, have.Signature)
+		assert.Equal(t, want.Signature, have.Signature)
+	}
+}
+
 func TestSignerVote(t *testing.T) {
 	for _, tc := range getSignerTestCases(t) {
 		ts := time.Now()
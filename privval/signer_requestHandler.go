@@ -1,6 +1,7 @@
 package privval
 
 import (
+	"errors"
 	"fmt"
 
 	cryptoproto "github.com/cometbft/cometbft/api/cometbft/crypto/v1"
@@ -92,6 +93,33 @@ func DefaultValidationRequestHandler(
 		} else {
 			res = mustWrapMsg(&pvproto.SignedProposalResponse{Proposal: *proposal, Error: nil})
 		}
+
+	case *pvproto.Message_SignAttestationRequest:
+		if r.SignAttestationRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&pvproto.SignedAttestationResponse{
+				Attestation: cmtproto.Attestation{}, Error: &pvproto.RemoteSignerError{
+					Code:        0,
+					Description: "unable to sign attestation",
+				},
+			})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.SignAttestationRequest.GetChainId(), chainID)
+		}
+
+		attestation := r.SignAttestationRequest.Attestation
+
+		if signer, ok := privVal.(types.AttestationSigner); ok {
+			err = signer.SignAttestation(chainID, attestation)
+		} else {
+			err = errors.New("attestations are not supported by this signer")
+		}
+		if err != nil {
+			res = mustWrapMsg(&pvproto.SignedAttestationResponse{
+				Attestation: cmtproto.Attestation{}, Error: &pvproto.RemoteSignerError{Code: 0, Description: err.Error()},
+			})
+		} else {
+			res = mustWrapMsg(&pvproto.SignedAttestationResponse{Attestation: *attestation, Error: nil})
+		}
+
 	case *pvproto.Message_PingRequest:
 		err, res = nil, mustWrapMsg(&pvproto.PingResponse{})
 
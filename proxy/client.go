@@ -71,6 +71,58 @@ func (l *localClientCreator) newABCIClient() (abcicli.Client, error) {
 	return abcicli.NewLocalClient(l.mtx, l.app), nil
 }
 
+//-------------------------------------------------------------------------
+// concurrent local proxy uses a read-write mutex on an in-proc app, read
+// locked by the CheckTx calls
+
+type concurrentLocalClientCreator struct {
+	mtx         *cmtsync.RWMutex
+	app         types.Application
+	concurrency int
+	sender      abcicli.SenderFunc
+}
+
+// NewConcurrentLocalClientCreator returns a [ClientCreator] for the given app,
+// which will be running locally, like [NewLocalClientCreator], except that up
+// to concurrency CheckTx calls run at the same time. The CheckTx calls of the
+// transactions with the same sender, as returned by sender, run in the order
+// they were made. See [abcicli.NewConcurrentLocalClient].
+//
+// Maintains a single read-write mutex over all new clients created with
+// NewABCIClient.
+func NewConcurrentLocalClientCreator(app types.Application, concurrency int, sender abcicli.SenderFunc) ClientCreator {
+	return &concurrentLocalClientCreator{
+		mtx:         new(cmtsync.RWMutex),
+		app:         app,
+		concurrency: concurrency,
+		sender:      sender,
+	}
+}
+
+// NewABCIConsensusClient implements ClientCreator.
+func (c *concurrentLocalClientCreator) NewABCIConsensusClient() (abcicli.Client, error) {
+	return c.newABCIClient()
+}
+
+// NewABCIMempoolClient implements ClientCreator.
+func (c *concurrentLocalClientCreator) NewABCIMempoolClient() (abcicli.Client, error) {
+	return c.newABCIClient()
+}
+
+// NewABCIQueryClient implements ClientCreator.
+func (c *concurrentLocalClientCreator) NewABCIQueryClient() (abcicli.Client, error) {
+	return c.newABCIClient()
+}
+
+// NewABCISnapshotClient implements ClientCreator.
+func (c *concurrentLocalClientCreator) NewABCISnapshotClient() (abcicli.Client, error) {
+	return c.newABCIClient()
+}
+
+func (c *concurrentLocalClientCreator) newABCIClient() (abcicli.Client, error) {
+	return abcicli.NewConcurrentLocalClient(c.mtx, c.app, c.concurrency, c.sender), nil
+}
+
 //-------------------------------------------------------------------------
 // connection-synchronized local client uses a mutex per "connection" on an
 // in-process app
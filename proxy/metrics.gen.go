@@ -22,11 +22,48 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 			Buckets: []float64{.0001, .0004, .002, .009, .02, .1, .65, 2, 6, 25},
 		}, append(labels, "method", "type")).With(labelsAndValues...),
+		Outages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "outages",
+			Help:      "Number of times the connection to the application was lost, when the connections are recovered.",
+		}, labels).With(labelsAndValues...),
+		OutageDurationSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "outage_duration_seconds",
+			Help:      "Duration of the outages of the application which were recovered from.",
+
+			Buckets: []float64{1, 5, 10, 30, 60, 300},
+		}, labels).With(labelsAndValues...),
+		Connected: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "connected",
+			Help:      "Whether the node is connected to the application (1) or not (0).",
+		}, labels).With(labelsAndValues...),
+		Timeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "timeouts",
+			Help:      "Number of calls to the application which timed out.",
+		}, append(labels, "method")).With(labelsAndValues...),
+		CircuitBreakerTrips: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "circuit_breaker_trips",
+			Help:      "Number of times the circuit breaker of a connection opened, after too many consecutive timeouts.",
+		}, append(labels, "connection")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		MethodTimingSeconds: discard.NewHistogram(),
+		MethodTimingSeconds:   discard.NewHistogram(),
+		Outages:               discard.NewCounter(),
+		OutageDurationSeconds: discard.NewHistogram(),
+		Connected:             discard.NewGauge(),
+		Timeouts:              discard.NewCounter(),
+		CircuitBreakerTrips:   discard.NewCounter(),
 	}
 }
@@ -16,4 +16,17 @@ const (
 type Metrics struct {
 	// Timing for each ABCI method.
 	MethodTimingSeconds metrics.Histogram `metrics_bucketsizes:".0001,.0004,.002,.009,.02,.1,.65,2,6,25" metrics_labels:"method, type"`
+
+	// Number of times the connection to the application was lost, when the
+	// connections are recovered.
+	Outages metrics.Counter
+	// Duration of the outages of the application which were recovered from.
+	OutageDurationSeconds metrics.Histogram `metrics_bucketsizes:"1,5,10,30,60,300"`
+	// Whether the node is connected to the application (1) or not (0).
+	Connected metrics.Gauge
+	// Number of calls to the application which timed out.
+	Timeouts metrics.Counter `metrics_labels:"method"`
+	// Number of times the circuit breaker of a connection opened, after too
+	// many consecutive timeouts.
+	CircuitBreakerTrips metrics.Counter `metrics_labels:"connection"`
 }
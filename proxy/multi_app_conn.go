@@ -2,10 +2,12 @@ package proxy
 
 import (
 	"fmt"
+	"time"
 
 	abcicli "github.com/cometbft/cometbft/abci/client"
 	cmtos "github.com/cometbft/cometbft/internal/os"
 	"github.com/cometbft/cometbft/internal/service"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
 	cmtlog "github.com/cometbft/cometbft/libs/log"
 )
 
@@ -32,15 +34,15 @@ type AppConns interface {
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator ClientCreator, metrics *Metrics) AppConns {
-	return NewMultiAppConn(clientCreator, metrics)
+func NewAppConns(clientCreator ClientCreator, metrics *Metrics, options ...AppConnsOption) AppConns {
+	return NewMultiAppConn(clientCreator, metrics, options...)
 }
 
 // multiAppConn implements AppConns.
 //
 // A multiAppConn is made of a few appConns and manages their underlying abci
-// clients.
-// TODO: on app restart, clients must reboot together.
+// clients. If the recovery is enabled, see AppConnsRecovery, the clients are
+// rebooted together when the application restarts.
 type multiAppConn struct {
 	service.BaseService
 
@@ -56,15 +58,30 @@ type multiAppConn struct {
 	snapshotConnClient  abcicli.Client
 
 	clientCreator ClientCreator
+
+	// Timeouts of the calls, see timeout.go.
+	timeouts CallTimeouts
+
+	// Recovery of the connections, see recovery.go.
+	maxOutage   time.Duration
+	handshake   HandshakeFunc
+	recovering  map[string]*recoveringClient
+	recoveryMtx cmtsync.Mutex
+	recovered   chan struct{} // closed when the recovery in progress ends
+	recoveryErr error         // set if the recovery failed
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator ClientCreator, metrics *Metrics) AppConns {
+func NewMultiAppConn(clientCreator ClientCreator, metrics *Metrics, options ...AppConnsOption) AppConns {
 	multiAppConn := &multiAppConn{
 		metrics:       metrics,
 		clientCreator: clientCreator,
+		recovering:    make(map[string]*recoveringClient),
 	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
+	for _, option := range options {
+		option(multiAppConn)
+	}
 	return multiAppConn
 }
 
@@ -101,6 +118,13 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 
+	app.metrics.Connected.Set(1)
+	if app.recoveryEnabled() {
+		// Reconnect if the ABCI application crashes.
+		go app.recoverOnClientError()
+		return nil
+	}
+
 	// Kill CometBFT if the ABCI application crashes.
 	go app.killTMOnClientError()
 
@@ -113,7 +137,7 @@ func (app *multiAppConn) startQueryClient() error {
 		return fmt.Errorf("error creating ABCI client (query client): %w", err)
 	}
 	app.queryConnClient = c
-	app.queryConn = NewAppConnQuery(c, app.metrics)
+	app.queryConn = NewAppConnQuery(app.recoverable(connQuery, app.timed(connQuery, c)), app.metrics)
 	return app.startClient(c, "query")
 }
 
@@ -123,7 +147,7 @@ func (app *multiAppConn) startSnapshotClient() error {
 		return fmt.Errorf("error creating ABCI client (snapshot client): %w", err)
 	}
 	app.snapshotConnClient = c
-	app.snapshotConn = NewAppConnSnapshot(c, app.metrics)
+	app.snapshotConn = NewAppConnSnapshot(app.recoverable(connSnapshot, app.timed(connSnapshot, c)), app.metrics)
 	return app.startClient(c, "snapshot")
 }
 
@@ -133,7 +157,7 @@ func (app *multiAppConn) startMempoolClient() error {
 		return fmt.Errorf("error creating ABCI client (mempool client): %w", err)
 	}
 	app.mempoolConnClient = c
-	app.mempoolConn = NewAppConnMempool(c, app.metrics)
+	app.mempoolConn = NewAppConnMempool(app.recoverable(connMempool, app.timed(connMempool, c)), app.metrics)
 	return app.startClient(c, "mempool")
 }
 
@@ -144,7 +168,7 @@ func (app *multiAppConn) startConsensusClient() error {
 		return fmt.Errorf("error creating ABCI client (consensus client): %w", err)
 	}
 	app.consensusConnClient = c
-	app.consensusConn = NewAppConnConsensus(c, app.metrics)
+	app.consensusConn = NewAppConnConsensus(app.recoverable(connConsensus, app.timed(connConsensus, c)), app.metrics)
 	return app.startClient(c, "consensus")
 }
 
@@ -157,6 +181,8 @@ func (app *multiAppConn) startClient(c abcicli.Client, conn string) error {
 }
 
 func (app *multiAppConn) OnStop() {
+	app.recoveryMtx.Lock()
+	defer app.recoveryMtx.Unlock()
 	app.stopAllClients()
 }
 
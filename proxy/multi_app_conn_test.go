@@ -1,7 +1,9 @@
 package proxy
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -11,7 +13,14 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	abcicli "github.com/cometbft/cometbft/abci/client"
 	abcimocks "github.com/cometbft/cometbft/abci/client/mocks"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	"github.com/cometbft/cometbft/abci/server"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+	"github.com/cometbft/cometbft/internal/service"
+	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/proxy/mocks"
 )
 
@@ -94,3 +103,131 @@ func TestAppConns_Failure(t *testing.T) {
 		t.Fatal("expected process to receive SIGTERM signal")
 	}
 }
+
+// With the recovery enabled, the connections are re-established once the
+// application restarts, and the requests made meanwhile are held.
+func TestAppConns_Recovery(t *testing.T) {
+	sockPath := fmt.Sprintf("unix:///tmp/recovery_%v.sock", cmtrand.Str(6))
+	app := kvstore.NewInMemoryApplication()
+
+	startServer := func() service.Service {
+		s := server.NewSocketServer(sockPath, app)
+		s.SetLogger(log.TestingLogger().With("module", "abci-server"))
+		require.NoError(t, s.Start())
+		return s
+	}
+	s := startServer()
+
+	handshakes := make(chan struct{}, 1)
+	handshake := func(ctx context.Context, proxyApp AppConns) error {
+		_, err := proxyApp.Query().Info(ctx, InfoRequest)
+		handshakes <- struct{}{}
+		return err
+	}
+
+	appConns := NewAppConns(NewRemoteClientCreator(sockPath, SOCKET, true), NopMetrics(),
+		AppConnsRecovery(10*time.Second, handshake))
+	appConns.SetLogger(log.TestingLogger())
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	_, err := appConns.Query().Info(context.Background(), InfoRequest)
+	require.NoError(t, err)
+
+	// simulate a restart of the application
+	require.NoError(t, s.Stop())
+	time.Sleep(100 * time.Millisecond)
+	s = startServer()
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = appConns.Query().Info(ctx, InfoRequest)
+	require.NoError(t, err)
+	_, err = appConns.Mempool().CheckTx(ctx, &abci.CheckTxRequest{Tx: []byte("a=b"), Type: abci.CHECK_TX_TYPE_CHECK})
+	require.NoError(t, err)
+
+	select {
+	case <-handshakes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the handshake to be replayed")
+	}
+}
+
+// lostRequestClient loses the Info requests, as a socket client does with the
+// requests it accepts as it stops.
+type lostRequestClient struct {
+	abcicli.Client
+	received chan struct{}
+}
+
+func (c *lostRequestClient) Info(ctx context.Context, _ *abci.InfoRequest) (*abci.InfoResponse, error) {
+	c.received <- struct{}{}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// lostRequestClientCreator creates a lostRequestClient for the first query
+// connection.
+type lostRequestClientCreator struct {
+	ClientCreator
+	query abcicli.Client
+}
+
+func (cc *lostRequestClientCreator) NewABCIQueryClient() (abcicli.Client, error) {
+	if c := cc.query; c != nil {
+		cc.query = nil
+		return c, nil
+	}
+	return cc.ClientCreator.NewABCIQueryClient()
+}
+
+// A request lost along with the connection is sent again once the connections
+// are recovered, instead of waiting for a response forever.
+func TestAppConns_RecoveryLostRequest(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	lost := &lostRequestClient{
+		Client:   abcicli.NewLocalClient(nil, app),
+		received: make(chan struct{}, 1),
+	}
+	creator := &lostRequestClientCreator{ClientCreator: NewLocalClientCreator(app), query: lost}
+	appConns := NewAppConns(creator, NopMetrics(), AppConnsRecovery(10*time.Second, nil))
+	appConns.SetLogger(log.TestingLogger())
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := appConns.Query().Info(ctx, InfoRequest)
+		errCh <- err
+	}()
+	select {
+	case <-lost.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the client to receive the request")
+	}
+
+	// the connection is lost with the request
+	require.NoError(t, lost.Stop())
+
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the request to be sent again after the recovery")
+	}
+}
@@ -0,0 +1,536 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	types "github.com/cometbft/cometbft/abci/types"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/internal/service"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+)
+
+const (
+	recoveryMinBackoff = 500 * time.Millisecond
+	recoveryMaxBackoff = 10 * time.Second
+)
+
+// ErrAppConnsStopped is returned by the requests held during an outage of the
+// application, if the connections are stopped meanwhile.
+var ErrAppConnsStopped = errors.New("application connections stopped")
+
+// HandshakeFunc brings the application up to date with the node after it
+// reconnects, through the given connections, e.g. by replaying the committed
+// blocks it is missing.
+type HandshakeFunc func(ctx context.Context, proxyApp AppConns) error
+
+// AppConnsOption sets an optional parameter on the AppConns.
+type AppConnsOption func(*multiAppConn)
+
+// AppConnsRecovery makes the AppConns reconnect to the application when one of
+// the connections is lost, instead of killing the node.
+//
+// All the connections are reconnected together, with an exponential backoff,
+// and handshake is called before resuming. The requests made meanwhile are
+// held until then, and the ones which failed are sent again. If the
+// application does not come back within maxOutage, the node is killed.
+func AppConnsRecovery(maxOutage time.Duration, handshake HandshakeFunc) AppConnsOption {
+	return func(app *multiAppConn) {
+		app.maxOutage = maxOutage
+		app.handshake = handshake
+	}
+}
+
+// recoveryEnabled returns true if the connections are to be recovered.
+func (app *multiAppConn) recoveryEnabled() bool {
+	return app.maxOutage > 0
+}
+
+// recoverable wraps the client of the given connection in a recoveringClient,
+// if the recovery is enabled.
+func (app *multiAppConn) recoverable(conn string, c abcicli.Client) abcicli.Client {
+	if !app.recoveryEnabled() {
+		return c
+	}
+	rc := newRecoveringClient(app, c)
+	app.recovering[conn] = rc
+	return rc
+}
+
+// newClient creates a new client for the given connection.
+func (app *multiAppConn) newClient(conn string) (abcicli.Client, error) {
+	switch conn {
+	case connConsensus:
+		return app.clientCreator.NewABCIConsensusClient()
+	case connMempool:
+		return app.clientCreator.NewABCIMempoolClient()
+	case connQuery:
+		return app.clientCreator.NewABCIQueryClient()
+	case connSnapshot:
+		return app.clientCreator.NewABCISnapshotClient()
+	default:
+		return nil, fmt.Errorf("unknown connection %q", conn)
+	}
+}
+
+// recoverOnClientError waits for a connection to be lost and recovers all of
+// them, until the recovery fails, in which case it kills the node.
+func (app *multiAppConn) recoverOnClientError() {
+	for {
+		app.recoveryMtx.Lock()
+		clients := app.clientsLocked()
+		app.recoveryMtx.Unlock()
+
+		select {
+		case <-clients[0].Quit():
+		case <-clients[1].Quit():
+		case <-clients[2].Quit():
+		case <-clients[3].Quit():
+		case <-app.Quit():
+			return
+		}
+
+		app.recoveryMtx.Lock()
+		if app.clientsLocked() != clients && app.recoveryErr == nil {
+			// The clients were recovered meanwhile.
+			app.recoveryMtx.Unlock()
+			continue
+		}
+		recovered := app.startRecoveryLocked()
+		app.recoveryMtx.Unlock()
+
+		select {
+		case <-recovered:
+		case <-app.Quit():
+			return
+		}
+
+		app.recoveryMtx.Lock()
+		err := app.recoveryErr
+		app.recoveryMtx.Unlock()
+		if err != nil {
+			app.Logger.Error(fmt.Sprintf("Failed to reconnect to the application within %v. Please restart CometBFT",
+				app.maxOutage), "err", err)
+			if killErr := cmtos.Kill(); killErr != nil {
+				app.Logger.Error("Failed to kill this process - please do so manually", "err", killErr)
+			}
+			return
+		}
+	}
+}
+
+// clientsLocked returns the current clients of the connections.
+// recoveryMtx must be held.
+func (app *multiAppConn) clientsLocked() [4]abcicli.Client {
+	return [4]abcicli.Client{
+		app.consensusConnClient, app.mempoolConnClient,
+		app.queryConnClient, app.snapshotConnClient,
+	}
+}
+
+// startRecoveryLocked starts the recovery of the connections, unless it is
+// already in progress, and returns the channel closed when it ends.
+// recoveryMtx must be held.
+func (app *multiAppConn) startRecoveryLocked() <-chan struct{} {
+	if app.recoveryErr != nil {
+		// We gave up already.
+		closed := make(chan struct{})
+		close(closed)
+		return closed
+	}
+	if app.recovered == nil {
+		app.recovered = make(chan struct{})
+		go app.recover(app.recovered)
+	}
+	return app.recovered
+}
+
+// waitRecovery waits until the connections are recovered, after the given
+// client of rc failed. Returns an error if the recovery failed.
+func (app *multiAppConn) waitRecovery(ctx context.Context, rc *recoveringClient, failed abcicli.Client) error {
+	app.recoveryMtx.Lock()
+	if !app.IsRunning() {
+		// The client was stopped along with the connections.
+		app.recoveryMtx.Unlock()
+		return ErrAppConnsStopped
+	}
+	if rc.current() != failed {
+		// The connection was recovered already.
+		app.recoveryMtx.Unlock()
+		return nil
+	}
+	recovered := app.startRecoveryLocked()
+	app.recoveryMtx.Unlock()
+
+	select {
+	case <-recovered:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-app.Quit():
+		return ErrAppConnsStopped
+	}
+
+	app.recoveryMtx.Lock()
+	defer app.recoveryMtx.Unlock()
+	return app.recoveryErr
+}
+
+// recover reconnects to the application, retrying with an exponential backoff
+// for up to maxOutage, and closes recovered when done.
+func (app *multiAppConn) recover(recovered chan struct{}) {
+	start := time.Now()
+	app.metrics.Outages.Add(1)
+	app.metrics.Connected.Set(0)
+	app.Logger.Error("Lost connection to the application, reconnecting", "max_outage", app.maxOutage)
+
+	// Stop the clients which are still connected, as the application must
+	// reboot together with all of them.
+	app.recoveryMtx.Lock()
+	for _, c := range app.clientsLocked() {
+		if c.IsRunning() {
+			if err := c.Stop(); err != nil {
+				app.Logger.Error("Error while stopping client", "err", err)
+			}
+		}
+	}
+	app.recoveryMtx.Unlock()
+
+	var err error
+	backoff := recoveryMinBackoff
+RETRY_LOOP:
+	for {
+		if err = app.reconnect(app.maxOutage - time.Since(start)); err == nil {
+			break
+		}
+		if errors.Is(err, ErrAppConnsStopped) || time.Since(start)+backoff > app.maxOutage {
+			break
+		}
+		app.Logger.Error("Failed to reconnect to the application", "err", err, "retry_in", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-app.Quit():
+			err = ErrAppConnsStopped
+			break RETRY_LOOP
+		}
+		backoff *= 2
+		if backoff > recoveryMaxBackoff {
+			backoff = recoveryMaxBackoff
+		}
+	}
+
+	if err == nil {
+		app.metrics.Connected.Set(1)
+		app.metrics.OutageDurationSeconds.Observe(time.Since(start).Seconds())
+		app.Logger.Info("Reconnected to the application", "outage", time.Since(start))
+	}
+
+	app.recoveryMtx.Lock()
+	app.recoveryErr = err
+	app.recovered = nil
+	app.recoveryMtx.Unlock()
+	close(recovered)
+}
+
+// reconnect creates and starts new clients for all the connections, brings the
+// application up to date and, if it succeeds, swaps them in.
+func (app *multiAppConn) reconnect(timeout time.Duration) error {
+	clients := make(map[string]abcicli.Client, len(app.recovering))
+	stopClients := func() {
+		for conn, c := range clients {
+			if err := c.Stop(); err != nil {
+				app.Logger.Error("Error while stopping client", "connection", conn, "err", err)
+			}
+		}
+	}
+	for _, conn := range []string{connQuery, connSnapshot, connMempool, connConsensus} {
+		c, err := app.newClient(conn)
+		if err != nil {
+			stopClients()
+			return fmt.Errorf("error creating ABCI client (%s client): %w", conn, err)
+		}
+		if err := app.startClient(c, conn); err != nil {
+			stopClients()
+			return err
+		}
+		clients[conn] = c
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Use the new clients directly, as the requests through the recovering
+	// ones are held until we are done.
+	conns := &multiAppConn{
+		metrics:       app.metrics,
+		consensusConn: NewAppConnConsensus(clients[connConsensus], app.metrics),
+		mempoolConn:   NewAppConnMempool(clients[connMempool], app.metrics),
+		queryConn:     NewAppConnQuery(clients[connQuery], app.metrics),
+		snapshotConn:  NewAppConnSnapshot(clients[connSnapshot], app.metrics),
+	}
+	conns.BaseService = *service.NewBaseService(app.Logger, "multiAppConn", conns)
+	if app.handshake != nil {
+		if err := app.handshake(ctx, conns); err != nil {
+			stopClients()
+			return fmt.Errorf("error during handshake: %w", err)
+		}
+	}
+
+	// Send the block being executed again, so that its Commit can be retried.
+	if req := app.recovering[connConsensus].pendingFinalizeBlock(); req != nil {
+		res, err := conns.Query().Info(ctx, InfoRequest)
+		if err != nil {
+			stopClients()
+			return fmt.Errorf("error calling Info: %w", err)
+		}
+		if res.LastBlockHeight == req.Height-1 {
+			app.Logger.Info("Executing the pending block again", "height", req.Height)
+			if _, err := conns.Consensus().FinalizeBlock(ctx, req); err != nil {
+				stopClients()
+				return fmt.Errorf("error calling FinalizeBlock: %w", err)
+			}
+		}
+	}
+
+	app.recoveryMtx.Lock()
+	defer app.recoveryMtx.Unlock()
+	if !app.IsRunning() {
+		stopClients()
+		return ErrAppConnsStopped
+	}
+	app.consensusConnClient = clients[connConsensus]
+	app.mempoolConnClient = clients[connMempool]
+	app.queryConnClient = clients[connQuery]
+	app.snapshotConnClient = clients[connSnapshot]
+	for conn, rc := range app.recovering {
+		rc.setClient(app.timed(conn, clients[conn]))
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// recoveringClient is an abcicli.Client which holds the requests while the
+// connection to the application is recovered, and sends the failed ones
+// again.
+type recoveringClient struct {
+	service.BaseService
+
+	app *multiAppConn
+
+	mtx    cmtsync.Mutex
+	client abcicli.Client
+	resCb  abcicli.Callback
+	// pendingFinalize is the last FinalizeBlock request which was not followed
+	// by a Commit yet.
+	pendingFinalize *types.FinalizeBlockRequest
+}
+
+var _ abcicli.Client = (*recoveringClient)(nil)
+
+func newRecoveringClient(app *multiAppConn, client abcicli.Client) *recoveringClient {
+	rc := &recoveringClient{
+		app:    app,
+		client: client,
+	}
+	rc.BaseService = *service.NewBaseService(nil, "recoveringClient", rc)
+	return rc
+}
+
+func (rc *recoveringClient) current() abcicli.Client {
+	rc.mtx.Lock()
+	defer rc.mtx.Unlock()
+	return rc.client
+}
+
+func (rc *recoveringClient) setClient(client abcicli.Client) {
+	rc.mtx.Lock()
+	defer rc.mtx.Unlock()
+	rc.client = client
+	if rc.resCb != nil {
+		client.SetResponseCallback(rc.resCb)
+	}
+}
+
+func (rc *recoveringClient) pendingFinalizeBlock() *types.FinalizeBlockRequest {
+	rc.mtx.Lock()
+	defer rc.mtx.Unlock()
+	return rc.pendingFinalize
+}
+
+// call makes the request with the current client and, if its connection is
+// lost before the response, waits for it to be recovered and makes it again.
+func call[T any](ctx context.Context, rc *recoveringClient, fn func(abcicli.Client) (T, error)) (T, error) {
+	for {
+		client := rc.current()
+		if client.Error() == nil && client.IsRunning() {
+			if res, ok, err := callClient(ctx, client, fn); ok {
+				return res, err
+			}
+		}
+		if err := rc.app.waitRecovery(ctx, rc, client); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// callClient makes the request with client. It returns false if the
+// connection is lost before the response, without waiting for fn, which may
+// block on a request the client accepted as it stopped.
+func callClient[T any](ctx context.Context, client abcicli.Client, fn func(abcicli.Client) (T, error)) (T, bool, error) {
+	type result struct {
+		res T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res, err := fn(client)
+		done <- result{res, err}
+	}()
+
+	var zero T
+	select {
+	case r := <-done:
+		// a stopped client releases the requests in flight without a
+		// response
+		if client.Error() != nil || !client.IsRunning() {
+			return zero, false, nil
+		}
+		return r.res, true, r.err
+	case <-client.Quit():
+		return zero, false, nil
+	case <-ctx.Done():
+		return zero, true, ctx.Err()
+	}
+}
+
+// Error returns nil, as the connection errors are recovered.
+func (*recoveringClient) Error() error {
+	return nil
+}
+
+func (rc *recoveringClient) SetResponseCallback(cb abcicli.Callback) {
+	rc.mtx.Lock()
+	rc.resCb = cb
+	client := rc.client
+	rc.mtx.Unlock()
+	client.SetResponseCallback(cb)
+}
+
+func (rc *recoveringClient) Flush(ctx context.Context) error {
+	_, err := call(ctx, rc, func(c abcicli.Client) (struct{}, error) {
+		return struct{}{}, c.Flush(ctx)
+	})
+	return err
+}
+
+func (rc *recoveringClient) Echo(ctx context.Context, echo string) (*types.EchoResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.EchoResponse, error) {
+		return c.Echo(ctx, echo)
+	})
+}
+
+func (rc *recoveringClient) CheckTxAsync(ctx context.Context, req *types.CheckTxRequest) (*abcicli.ReqRes, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*abcicli.ReqRes, error) {
+		return c.CheckTxAsync(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) Info(ctx context.Context, req *types.InfoRequest) (*types.InfoResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.InfoResponse, error) {
+		return c.Info(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) Query(ctx context.Context, req *types.QueryRequest) (*types.QueryResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.QueryResponse, error) {
+		return c.Query(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) CheckTx(ctx context.Context, req *types.CheckTxRequest) (*types.CheckTxResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.CheckTxResponse, error) {
+		return c.CheckTx(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) InitChain(ctx context.Context, req *types.InitChainRequest) (*types.InitChainResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.InitChainResponse, error) {
+		return c.InitChain(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) PrepareProposal(ctx context.Context, req *types.PrepareProposalRequest) (*types.PrepareProposalResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.PrepareProposalResponse, error) {
+		return c.PrepareProposal(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) ProcessProposal(ctx context.Context, req *types.ProcessProposalRequest) (*types.ProcessProposalResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.ProcessProposalResponse, error) {
+		return c.ProcessProposal(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) ExtendVote(ctx context.Context, req *types.ExtendVoteRequest) (*types.ExtendVoteResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.ExtendVoteResponse, error) {
+		return c.ExtendVote(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) VerifyVoteExtension(ctx context.Context, req *types.VerifyVoteExtensionRequest) (*types.VerifyVoteExtensionResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.VerifyVoteExtensionResponse, error) {
+		return c.VerifyVoteExtension(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) FinalizeBlock(ctx context.Context, req *types.FinalizeBlockRequest) (*types.FinalizeBlockResponse, error) {
+	res, err := call(ctx, rc, func(c abcicli.Client) (*types.FinalizeBlockResponse, error) {
+		return c.FinalizeBlock(ctx, req)
+	})
+	if err == nil {
+		rc.mtx.Lock()
+		rc.pendingFinalize = req
+		rc.mtx.Unlock()
+	}
+	return res, err
+}
+
+func (rc *recoveringClient) Commit(ctx context.Context, req *types.CommitRequest) (*types.CommitResponse, error) {
+	res, err := call(ctx, rc, func(c abcicli.Client) (*types.CommitResponse, error) {
+		return c.Commit(ctx, req)
+	})
+	if err == nil {
+		rc.mtx.Lock()
+		rc.pendingFinalize = nil
+		rc.mtx.Unlock()
+	}
+	return res, err
+}
+
+func (rc *recoveringClient) ListSnapshots(ctx context.Context, req *types.ListSnapshotsRequest) (*types.ListSnapshotsResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.ListSnapshotsResponse, error) {
+		return c.ListSnapshots(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) OfferSnapshot(ctx context.Context, req *types.OfferSnapshotRequest) (*types.OfferSnapshotResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.OfferSnapshotResponse, error) {
+		return c.OfferSnapshot(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) LoadSnapshotChunk(ctx context.Context, req *types.LoadSnapshotChunkRequest) (*types.LoadSnapshotChunkResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.LoadSnapshotChunkResponse, error) {
+		return c.LoadSnapshotChunk(ctx, req)
+	})
+}
+
+func (rc *recoveringClient) ApplySnapshotChunk(ctx context.Context, req *types.ApplySnapshotChunkRequest) (*types.ApplySnapshotChunkResponse, error) {
+	return call(ctx, rc, func(c abcicli.Client) (*types.ApplySnapshotChunkResponse, error) {
+		return c.ApplySnapshotChunk(ctx, req)
+	})
+}
@@ -0,0 +1,373 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	types "github.com/cometbft/cometbft/abci/types"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+)
+
+// Methods lists the names of the ABCI methods, as used by the metrics and
+// CallTimeouts.
+var Methods = []string{
+	"echo", "flush", "info", "query", "check_tx", "init_chain",
+	"prepare_proposal", "process_proposal", "extend_vote",
+	"verify_vote_extension", "finalize_block", "commit", "list_snapshots",
+	"offer_snapshot", "load_snapshot_chunk", "apply_snapshot_chunk",
+}
+
+// ErrCircuitOpen is returned by the calls to the application made while the
+// circuit breaker of their connection is open.
+var ErrCircuitOpen = errors.New("circuit breaker open: the application is not responding")
+
+// ErrCallTimeout is returned when the application does not respond to a call
+// within its timeout.
+type ErrCallTimeout struct {
+	Method  string
+	Timeout time.Duration
+}
+
+func (e ErrCallTimeout) Error() string {
+	return fmt.Sprintf("ABCI method %s timed out after %v", e.Method, e.Timeout)
+}
+
+// CallTimeouts configures the timeouts of the calls to the application, and
+// the circuit breakers of the connections.
+type CallTimeouts struct {
+	// Default is the timeout of the methods not listed in Methods.
+	// 0 means no timeout.
+	Default time.Duration
+	// Methods are the timeouts of specific methods, by name, e.g. "check_tx".
+	Methods map[string]time.Duration
+	// BreakerThreshold is the number of consecutive timeouts on a connection
+	// after which its calls fail fast with ErrCircuitOpen, until one of them
+	// succeeds after BreakerCooldown. If it happens on the consensus
+	// connection, the node is halted. 0 disables the circuit breakers.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// ParseMethodTimeouts parses a comma-separated list of method=duration pairs,
+// e.g. "check_tx=5s,finalize_block=1m".
+func ParseMethodTimeouts(s string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid method timeout %q, expected method=duration", pair)
+		}
+		method = strings.TrimSpace(method)
+		if !isMethod(method) {
+			return nil, fmt.Errorf("unknown ABCI method %q, expected one of %s", method, strings.Join(Methods, ", "))
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout of method %s: %w", method, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("negative timeout of method %s", method)
+		}
+		timeouts[method] = timeout
+	}
+	return timeouts, nil
+}
+
+func isMethod(method string) bool {
+	for _, m := range Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBasic performs basic validation.
+func (t CallTimeouts) ValidateBasic() error {
+	if t.Default < 0 {
+		return errors.New("negative default timeout")
+	}
+	methods := make([]string, 0, len(t.Methods))
+	for method := range t.Methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		if !isMethod(method) {
+			return fmt.Errorf("unknown ABCI method %q", method)
+		}
+		if t.Methods[method] < 0 {
+			return fmt.Errorf("negative timeout of method %s", method)
+		}
+	}
+	if t.BreakerThreshold < 0 {
+		return errors.New("negative circuit breaker threshold")
+	}
+	if t.BreakerCooldown < 0 {
+		return errors.New("negative circuit breaker cooldown")
+	}
+	return nil
+}
+
+func (t CallTimeouts) enabled() bool {
+	if t.Default > 0 {
+		return true
+	}
+	for _, timeout := range t.Methods {
+		if timeout > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (t CallTimeouts) timeout(method string) time.Duration {
+	if timeout, ok := t.Methods[method]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// AppConnsTimeouts bounds the duration of the calls to the application, see
+// CallTimeouts.
+func AppConnsTimeouts(timeouts CallTimeouts) AppConnsOption {
+	return func(app *multiAppConn) {
+		app.timeouts = timeouts
+	}
+}
+
+// timed wraps the client of the given connection in a timeoutClient, if any
+// timeout is set.
+func (app *multiAppConn) timed(conn string, c abcicli.Client) abcicli.Client {
+	if !app.timeouts.enabled() {
+		return c
+	}
+	return &timeoutClient{
+		Client:   c,
+		app:      app,
+		conn:     conn,
+		timeouts: app.timeouts,
+	}
+}
+
+// tripped is called when the circuit breaker of the given connection opens.
+func (app *multiAppConn) tripped(conn string, err error) {
+	app.metrics.CircuitBreakerTrips.With("connection", conn).Add(1)
+	if conn != connConsensus {
+		app.Logger.Error("Application is not responding, failing the calls fast", "connection", conn,
+			"cooldown", app.timeouts.BreakerCooldown, "err", err)
+		return
+	}
+	app.Logger.Error("Application is not responding on the consensus connection. Please restart CometBFT",
+		"err", err)
+	if killErr := cmtos.Kill(); killErr != nil {
+		app.Logger.Error("Failed to kill this process - please do so manually", "err", killErr)
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// timeoutClient is an abcicli.Client which bounds the duration of the calls,
+// and fails them fast once too many in a row timed out.
+//
+// The calls which time out are abandoned, not cancelled, as the socket client
+// does not support it: they are left blocked until the application responds.
+type timeoutClient struct {
+	abcicli.Client
+
+	app      *multiAppConn
+	conn     string
+	timeouts CallTimeouts
+
+	mtx       cmtsync.Mutex
+	failures  int       // consecutive timeouts
+	openUntil time.Time // the calls fail fast until then
+}
+
+var _ abcicli.Client = (*timeoutClient)(nil)
+
+// callWithTimeout makes the call, and returns ErrCallTimeout if it does not
+// return within the timeout of the method.
+func callWithTimeout[T any](ctx context.Context, tc *timeoutClient, method string, fn func(context.Context) (T, error)) (T, error) {
+	var zero T
+	if err := tc.allow(); err != nil {
+		return zero, err
+	}
+	timeout := tc.timeouts.timeout(method)
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		res T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res, err := fn(ctx)
+		done <- result{res, err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		tc.succeeded()
+		return r.res, r.err
+	case <-timer.C:
+		err := ErrCallTimeout{Method: method, Timeout: timeout}
+		tc.timedOut(err)
+		return zero, err
+	}
+}
+
+func (tc *timeoutClient) allow() error {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+	if time.Now().Before(tc.openUntil) {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+func (tc *timeoutClient) succeeded() {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+	tc.failures = 0
+}
+
+func (tc *timeoutClient) timedOut(err ErrCallTimeout) {
+	tc.app.metrics.Timeouts.With("method", err.Method).Add(1)
+	tc.app.Logger.Error("ABCI call timed out", "connection", tc.conn, "method", err.Method, "timeout", err.Timeout)
+
+	threshold := tc.timeouts.BreakerThreshold
+	tc.mtx.Lock()
+	tc.failures++
+	// Once the threshold is reached, a single timeout after the cooldown opens
+	// the circuit again.
+	trip := threshold > 0 && tc.failures >= threshold
+	if trip {
+		tc.openUntil = time.Now().Add(tc.timeouts.BreakerCooldown)
+	}
+	tc.mtx.Unlock()
+
+	if trip {
+		tc.app.tripped(tc.conn, err)
+	}
+}
+
+func (tc *timeoutClient) Flush(ctx context.Context) error {
+	_, err := callWithTimeout(ctx, tc, "flush", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, tc.Client.Flush(ctx)
+	})
+	return err
+}
+
+func (tc *timeoutClient) Echo(ctx context.Context, echo string) (*types.EchoResponse, error) {
+	return callWithTimeout(ctx, tc, "echo", func(ctx context.Context) (*types.EchoResponse, error) {
+		return tc.Client.Echo(ctx, echo)
+	})
+}
+
+func (tc *timeoutClient) CheckTxAsync(ctx context.Context, req *types.CheckTxRequest) (*abcicli.ReqRes, error) {
+	return callWithTimeout(ctx, tc, "check_tx", func(ctx context.Context) (*abcicli.ReqRes, error) {
+		return tc.Client.CheckTxAsync(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) Info(ctx context.Context, req *types.InfoRequest) (*types.InfoResponse, error) {
+	return callWithTimeout(ctx, tc, "info", func(ctx context.Context) (*types.InfoResponse, error) {
+		return tc.Client.Info(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) Query(ctx context.Context, req *types.QueryRequest) (*types.QueryResponse, error) {
+	return callWithTimeout(ctx, tc, "query", func(ctx context.Context) (*types.QueryResponse, error) {
+		return tc.Client.Query(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) CheckTx(ctx context.Context, req *types.CheckTxRequest) (*types.CheckTxResponse, error) {
+	return callWithTimeout(ctx, tc, "check_tx", func(ctx context.Context) (*types.CheckTxResponse, error) {
+		return tc.Client.CheckTx(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) InitChain(ctx context.Context, req *types.InitChainRequest) (*types.InitChainResponse, error) {
+	return callWithTimeout(ctx, tc, "init_chain", func(ctx context.Context) (*types.InitChainResponse, error) {
+		return tc.Client.InitChain(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) PrepareProposal(ctx context.Context, req *types.PrepareProposalRequest) (*types.PrepareProposalResponse, error) {
+	return callWithTimeout(ctx, tc, "prepare_proposal", func(ctx context.Context) (*types.PrepareProposalResponse, error) {
+		return tc.Client.PrepareProposal(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) ProcessProposal(ctx context.Context, req *types.ProcessProposalRequest) (*types.ProcessProposalResponse, error) {
+	return callWithTimeout(ctx, tc, "process_proposal", func(ctx context.Context) (*types.ProcessProposalResponse, error) {
+		return tc.Client.ProcessProposal(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) ExtendVote(ctx context.Context, req *types.ExtendVoteRequest) (*types.ExtendVoteResponse, error) {
+	return callWithTimeout(ctx, tc, "extend_vote", func(ctx context.Context) (*types.ExtendVoteResponse, error) {
+		return tc.Client.ExtendVote(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) VerifyVoteExtension(ctx context.Context, req *types.VerifyVoteExtensionRequest) (*types.VerifyVoteExtensionResponse, error) {
+	return callWithTimeout(ctx, tc, "verify_vote_extension", func(ctx context.Context) (*types.VerifyVoteExtensionResponse, error) {
+		return tc.Client.VerifyVoteExtension(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) FinalizeBlock(ctx context.Context, req *types.FinalizeBlockRequest) (*types.FinalizeBlockResponse, error) {
+	return callWithTimeout(ctx, tc, "finalize_block", func(ctx context.Context) (*types.FinalizeBlockResponse, error) {
+		return tc.Client.FinalizeBlock(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) Commit(ctx context.Context, req *types.CommitRequest) (*types.CommitResponse, error) {
+	return callWithTimeout(ctx, tc, "commit", func(ctx context.Context) (*types.CommitResponse, error) {
+		return tc.Client.Commit(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) ListSnapshots(ctx context.Context, req *types.ListSnapshotsRequest) (*types.ListSnapshotsResponse, error) {
+	return callWithTimeout(ctx, tc, "list_snapshots", func(ctx context.Context) (*types.ListSnapshotsResponse, error) {
+		return tc.Client.ListSnapshots(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) OfferSnapshot(ctx context.Context, req *types.OfferSnapshotRequest) (*types.OfferSnapshotResponse, error) {
+	return callWithTimeout(ctx, tc, "offer_snapshot", func(ctx context.Context) (*types.OfferSnapshotResponse, error) {
+		return tc.Client.OfferSnapshot(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) LoadSnapshotChunk(ctx context.Context, req *types.LoadSnapshotChunkRequest) (*types.LoadSnapshotChunkResponse, error) {
+	return callWithTimeout(ctx, tc, "load_snapshot_chunk", func(ctx context.Context) (*types.LoadSnapshotChunkResponse, error) {
+		return tc.Client.LoadSnapshotChunk(ctx, req)
+	})
+}
+
+func (tc *timeoutClient) ApplySnapshotChunk(ctx context.Context, req *types.ApplySnapshotChunkRequest) (*types.ApplySnapshotChunkResponse, error) {
+	return callWithTimeout(ctx, tc, "apply_snapshot_chunk", func(ctx context.Context) (*types.ApplySnapshotChunkResponse, error) {
+		return tc.Client.ApplySnapshotChunk(ctx, req)
+	})
+}
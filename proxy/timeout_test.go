@@ -0,0 +1,75 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestParseMethodTimeouts(t *testing.T) {
+	timeouts, err := ParseMethodTimeouts("check_tx=5s, finalize_block=1m,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"check_tx":       5 * time.Second,
+		"finalize_block": time.Minute,
+	}, timeouts)
+
+	timeouts, err = ParseMethodTimeouts("")
+	require.NoError(t, err)
+	assert.Empty(t, timeouts)
+
+	for _, s := range []string{"check_tx", "check_tx=5", "checktx=5s", "check_tx=-5s"} {
+		_, err := ParseMethodTimeouts(s)
+		require.Error(t, err, s)
+	}
+}
+
+// hangingApp never responds to Info.
+type hangingApp struct {
+	abci.BaseApplication
+	release chan struct{}
+}
+
+func (app *hangingApp) Info(context.Context, *abci.InfoRequest) (*abci.InfoResponse, error) {
+	<-app.release
+	return &abci.InfoResponse{}, nil
+}
+
+func TestAppConns_Timeouts(t *testing.T) {
+	app := &hangingApp{release: make(chan struct{})}
+	appConns := NewAppConns(NewUnsyncLocalClientCreator(app), NopMetrics(),
+		AppConnsTimeouts(CallTimeouts{
+			Methods:          map[string]time.Duration{"info": 50 * time.Millisecond},
+			BreakerThreshold: 2,
+			BreakerCooldown:  time.Hour,
+		}))
+	appConns.SetLogger(log.TestingLogger())
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		close(app.release)
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// The methods without a timeout are not affected.
+	_, err := appConns.Query().Query(context.Background(), &abci.QueryRequest{})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = appConns.Query().Info(context.Background(), InfoRequest)
+		require.ErrorIs(t, err, ErrCallTimeout{Method: "info", Timeout: 50 * time.Millisecond})
+	}
+
+	// The circuit breaker is open now.
+	start := time.Now()
+	_, err = appConns.Query().Info(context.Background(), InfoRequest)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
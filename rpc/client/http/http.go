@@ -259,6 +259,23 @@ func (c *baseRPCClient) BroadcastTxCommit(
 	return result, nil
 }
 
+func (c *baseRPCClient) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeoutMs *int,
+) (*ctypes.ResultBroadcastTxCommitProof, error) {
+	result := new(ctypes.ResultBroadcastTxCommitProof)
+	params := map[string]interface{}{"tx": tx}
+	if timeoutMs != nil {
+		params["timeout_ms"] = timeoutMs
+	}
+	_, err := c.caller.Call(ctx, "broadcast_tx_commit_proof", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastTxAsync(
 	ctx context.Context,
 	tx types.Tx,
@@ -320,6 +337,15 @@ func (c *baseRPCClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.Resul
 	return result, nil
 }
 
+func (c *baseRPCClient) SimulateTx(ctx context.Context, tx types.Tx) (*ctypes.ResultSimulateTx, error) {
+	result := new(ctypes.ResultSimulateTx)
+	_, err := c.caller.Call(ctx, "simulate_tx", map[string]interface{}{"tx": tx}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	result := new(ctypes.ResultNetInfo)
 	_, err := c.caller.Call(ctx, "net_info", map[string]interface{}{}, result)
@@ -372,6 +398,38 @@ func (c *baseRPCClient) Health(ctx context.Context) (*ctypes.ResultHealth, error
 	return result, nil
 }
 
+func (c *baseRPCClient) ValidatorSigningInfo(
+	ctx context.Context,
+	address []byte,
+) (*ctypes.ResultValidatorSigningInfo, error) {
+	result := new(ctypes.ResultValidatorSigningInfo)
+	_, err := c.caller.Call(ctx, "validator_signing_info",
+		map[string]interface{}{"address": address},
+		result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) StorageInfo(ctx context.Context) (*ctypes.ResultStorageInfo, error) {
+	result := new(ctypes.ResultStorageInfo)
+	_, err := c.caller.Call(ctx, "storage_info", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) RetainHeights(ctx context.Context) (*ctypes.ResultRetainHeights, error) {
+	result := new(ctypes.ResultRetainHeights)
+	_, err := c.caller.Call(ctx, "retain_heights", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BlockchainInfo(
 	ctx context.Context,
 	minHeight,
@@ -387,6 +445,21 @@ func (c *baseRPCClient) BlockchainInfo(
 	return result, nil
 }
 
+func (c *baseRPCClient) LightBlocks(
+	ctx context.Context,
+	from,
+	to int64,
+) (*ctypes.ResultLightBlocks, error) {
+	result := new(ctypes.ResultLightBlocks)
+	_, err := c.caller.Call(ctx, "light_blocks",
+		map[string]interface{}{"from": from, "to": to},
+		result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	result := new(ctypes.ResultGenesis)
 	_, err := c.caller.Call(ctx, "genesis", map[string]interface{}{}, result)
@@ -405,6 +478,32 @@ func (c *baseRPCClient) GenesisChunked(ctx context.Context, id uint) (*ctypes.Re
 	return result, nil
 }
 
+func (c *baseRPCClient) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	result := new(ctypes.ResultSnapshots)
+	_, err := c.caller.Call(ctx, "snapshots", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) SnapshotChunk(
+	ctx context.Context,
+	height uint64,
+	format,
+	index uint32,
+	offset int,
+) (*ctypes.ResultSnapshotChunk, error) {
+	result := new(ctypes.ResultSnapshotChunk)
+	_, err := c.caller.Call(ctx, "snapshot_chunk",
+		map[string]interface{}{"height": height, "format": format, "index": index, "offset": offset},
+		result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
 	result := new(ctypes.ResultBlock)
 	params := make(map[string]interface{})
@@ -471,6 +570,38 @@ func (c *baseRPCClient) HeaderByHash(ctx context.Context, hash bytes.HexBytes) (
 	return result, nil
 }
 
+func (c *baseRPCClient) HeaderChainProof(
+	ctx context.Context,
+	height int64,
+	trustedHeight *int64,
+) (*ctypes.ResultHeaderChainProof, error) {
+	result := new(ctypes.ResultHeaderChainProof)
+	params := map[string]interface{}{
+		"height": height,
+	}
+	if trustedHeight != nil {
+		params["trusted_height"] = trustedHeight
+	}
+	_, err := c.caller.Call(ctx, "header_chain_proof", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) Attestation(ctx context.Context, height *int64) (*ctypes.ResultAttestation, error) {
+	result := new(ctypes.ResultAttestation)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "attestation", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
 	result := new(ctypes.ResultCommit)
 	params := make(map[string]interface{})
@@ -497,6 +628,25 @@ func (c *baseRPCClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctype
 	return result, nil
 }
 
+func (c *baseRPCClient) TxResultProof(
+	ctx context.Context,
+	hash []byte,
+	height *int64,
+) (*ctypes.ResultTxResultProof, error) {
+	result := new(ctypes.ResultTxResultProof)
+	params := map[string]interface{}{
+		"hash": hash,
+	}
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "tx_result_proof", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) TxSearch(
 	ctx context.Context,
 	query string,
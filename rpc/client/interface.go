@@ -41,6 +41,7 @@ type Client interface {
 	StatusClient
 	EvidenceClient
 	MempoolClient
+	SnapshotsClient
 }
 
 // ABCIClient groups together the functionality that principally affects the
@@ -57,6 +58,7 @@ type ABCIClient interface {
 
 	// Writing to abci app
 	BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
+	BroadcastTxCommitProof(ctx context.Context, tx types.Tx, timeoutMs *int) (*ctypes.ResultBroadcastTxCommitProof, error)
 	BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 	BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 }
@@ -69,9 +71,12 @@ type SignClient interface {
 	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
 	Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error)
 	HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*ctypes.ResultHeader, error)
+	HeaderChainProof(ctx context.Context, height int64, trustedHeight *int64) (*ctypes.ResultHeaderChainProof, error)
 	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+	Attestation(ctx context.Context, height *int64) (*ctypes.ResultAttestation, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error)
 	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
+	TxResultProof(ctx context.Context, hash []byte, height *int64) (*ctypes.ResultTxResultProof, error)
 
 	// TxSearch defines a method to search for a paginated set of transactions by
 	// transaction event search criteria.
@@ -98,6 +103,7 @@ type HistoryClient interface {
 	Genesis(ctx context.Context) (*ctypes.ResultGenesis, error)
 	GenesisChunked(ctx context.Context, id uint) (*ctypes.ResultGenesisChunk, error)
 	BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+	LightBlocks(ctx context.Context, from, to int64) (*ctypes.ResultLightBlocks, error)
 }
 
 // StatusClient provides access to general chain info.
@@ -113,6 +119,9 @@ type NetworkClient interface {
 	ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error)
 	ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error)
 	Health(ctx context.Context) (*ctypes.ResultHealth, error)
+	ValidatorSigningInfo(ctx context.Context, address []byte) (*ctypes.ResultValidatorSigningInfo, error)
+	StorageInfo(ctx context.Context) (*ctypes.ResultStorageInfo, error)
+	RetainHeights(ctx context.Context) (*ctypes.ResultRetainHeights, error)
 }
 
 // EventsClient is reactive, you can subscribe to any message, given the proper
@@ -137,6 +146,14 @@ type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error)
 	CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error)
+	SimulateTx(ctx context.Context, tx types.Tx) (*ctypes.ResultSimulateTx, error)
+}
+
+// SnapshotsClient provides access to the snapshots served by the node for state
+// sync.
+type SnapshotsClient interface {
+	Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error)
+	SnapshotChunk(ctx context.Context, height uint64, format, index uint32, offset int) (*ctypes.ResultSnapshotChunk, error)
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
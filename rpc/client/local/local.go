@@ -90,6 +90,14 @@ func (c *Local) BroadcastTxCommit(_ context.Context, tx types.Tx) (*ctypes.Resul
 	return c.env.BroadcastTxCommit(c.ctx, tx)
 }
 
+func (c *Local) BroadcastTxCommitProof(
+	_ context.Context,
+	tx types.Tx,
+	timeoutMs *int,
+) (*ctypes.ResultBroadcastTxCommitProof, error) {
+	return c.env.BroadcastTxCommitProof(c.ctx, tx, timeoutMs)
+}
+
 func (c *Local) BroadcastTxAsync(_ context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return c.env.BroadcastTxAsync(c.ctx, tx)
 }
@@ -99,7 +107,7 @@ func (c *Local) BroadcastTxSync(_ context.Context, tx types.Tx) (*ctypes.ResultB
 }
 
 func (c *Local) UnconfirmedTxs(_ context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
-	return c.env.UnconfirmedTxs(c.ctx, limit)
+	return c.env.UnconfirmedTxs(c.ctx, limit, "", "", "")
 }
 
 func (c *Local) NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
@@ -110,6 +118,10 @@ func (c *Local) CheckTx(_ context.Context, tx types.Tx) (*ctypes.ResultCheckTx,
 	return c.env.CheckTx(c.ctx, tx)
 }
 
+func (c *Local) SimulateTx(_ context.Context, tx types.Tx) (*ctypes.ResultSimulateTx, error) {
+	return c.env.SimulateTx(c.ctx, tx)
+}
+
 func (c *Local) NetInfo(context.Context) (*ctypes.ResultNetInfo, error) {
 	return c.env.NetInfo(c.ctx)
 }
@@ -130,6 +142,18 @@ func (c *Local) Health(context.Context) (*ctypes.ResultHealth, error) {
 	return c.env.Health(c.ctx)
 }
 
+func (c *Local) ValidatorSigningInfo(_ context.Context, address []byte) (*ctypes.ResultValidatorSigningInfo, error) {
+	return c.env.ValidatorSigningInfo(c.ctx, address)
+}
+
+func (c *Local) StorageInfo(context.Context) (*ctypes.ResultStorageInfo, error) {
+	return c.env.StorageInfo(c.ctx)
+}
+
+func (c *Local) RetainHeights(context.Context) (*ctypes.ResultRetainHeights, error) {
+	return c.env.RetainHeights(c.ctx)
+}
+
 func (c *Local) DialSeeds(_ context.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
 	return c.env.UnsafeDialSeeds(c.ctx, seeds)
 }
@@ -148,6 +172,10 @@ func (c *Local) BlockchainInfo(_ context.Context, minHeight, maxHeight int64) (*
 	return c.env.BlockchainInfo(c.ctx, minHeight, maxHeight)
 }
 
+func (c *Local) LightBlocks(_ context.Context, from, to int64) (*ctypes.ResultLightBlocks, error) {
+	return c.env.LightBlocks(c.ctx, from, to)
+}
+
 func (c *Local) Genesis(context.Context) (*ctypes.ResultGenesis, error) {
 	return c.env.Genesis(c.ctx)
 }
@@ -156,6 +184,20 @@ func (c *Local) GenesisChunked(_ context.Context, id uint) (*ctypes.ResultGenesi
 	return c.env.GenesisChunked(c.ctx, id)
 }
 
+func (c *Local) Snapshots(context.Context) (*ctypes.ResultSnapshots, error) {
+	return c.env.Snapshots(c.ctx)
+}
+
+func (c *Local) SnapshotChunk(
+	_ context.Context,
+	height uint64,
+	format,
+	index uint32,
+	offset int,
+) (*ctypes.ResultSnapshotChunk, error) {
+	return c.env.SnapshotChunk(c.ctx, height, format, index, offset)
+}
+
 func (c *Local) Block(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
 	return c.env.Block(c.ctx, height)
 }
@@ -176,10 +218,22 @@ func (c *Local) HeaderByHash(_ context.Context, hash bytes.HexBytes) (*ctypes.Re
 	return c.env.HeaderByHash(c.ctx, hash)
 }
 
+func (c *Local) HeaderChainProof(
+	_ context.Context,
+	height int64,
+	trustedHeight *int64,
+) (*ctypes.ResultHeaderChainProof, error) {
+	return c.env.HeaderChainProof(c.ctx, height, trustedHeight)
+}
+
 func (c *Local) Commit(_ context.Context, height *int64) (*ctypes.ResultCommit, error) {
 	return c.env.Commit(c.ctx, height)
 }
 
+func (c *Local) Attestation(_ context.Context, height *int64) (*ctypes.ResultAttestation, error) {
+	return c.env.Attestation(c.ctx, height)
+}
+
 func (c *Local) Validators(_ context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return c.env.Validators(c.ctx, height, page, perPage)
 }
@@ -188,6 +242,10 @@ func (c *Local) Tx(_ context.Context, hash []byte, prove bool) (*ctypes.ResultTx
 	return c.env.Tx(c.ctx, hash, prove)
 }
 
+func (c *Local) TxResultProof(_ context.Context, hash []byte, height *int64) (*ctypes.ResultTxResultProof, error) {
+	return c.env.TxResultProof(c.ctx, hash, height)
+}
+
 func (c *Local) TxSearch(
 	_ context.Context,
 	query string,
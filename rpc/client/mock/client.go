@@ -36,6 +36,7 @@ type Client struct {
 	client.EventsClient
 	client.EvidenceClient
 	client.MempoolClient
+	client.SnapshotsClient
 	service.Service
 
 	env *core.Environment
@@ -108,6 +109,14 @@ func (c Client) BroadcastTxCommit(_ context.Context, tx types.Tx) (*ctypes.Resul
 	return c.env.BroadcastTxCommit(&rpctypes.Context{}, tx)
 }
 
+func (c Client) BroadcastTxCommitProof(
+	_ context.Context,
+	tx types.Tx,
+	timeoutMs *int,
+) (*ctypes.ResultBroadcastTxCommitProof, error) {
+	return c.env.BroadcastTxCommitProof(&rpctypes.Context{}, tx, timeoutMs)
+}
+
 func (c Client) BroadcastTxAsync(_ context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return c.env.BroadcastTxAsync(&rpctypes.Context{}, tx)
 }
@@ -120,6 +129,10 @@ func (c Client) CheckTx(_ context.Context, tx types.Tx) (*ctypes.ResultCheckTx,
 	return c.env.CheckTx(&rpctypes.Context{}, tx)
 }
 
+func (c Client) SimulateTx(_ context.Context, tx types.Tx) (*ctypes.ResultSimulateTx, error) {
+	return c.env.SimulateTx(&rpctypes.Context{}, tx)
+}
+
 func (c Client) NetInfo(_ context.Context) (*ctypes.ResultNetInfo, error) {
 	return c.env.NetInfo(&rpctypes.Context{})
 }
@@ -140,6 +153,18 @@ func (c Client) Health(_ context.Context) (*ctypes.ResultHealth, error) {
 	return c.env.Health(&rpctypes.Context{})
 }
 
+func (c Client) ValidatorSigningInfo(_ context.Context, address []byte) (*ctypes.ResultValidatorSigningInfo, error) {
+	return c.env.ValidatorSigningInfo(&rpctypes.Context{}, address)
+}
+
+func (c Client) StorageInfo(_ context.Context) (*ctypes.ResultStorageInfo, error) {
+	return c.env.StorageInfo(&rpctypes.Context{})
+}
+
+func (c Client) RetainHeights(_ context.Context) (*ctypes.ResultRetainHeights, error) {
+	return c.env.RetainHeights(&rpctypes.Context{})
+}
+
 func (c Client) DialSeeds(_ context.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
 	return c.env.UnsafeDialSeeds(&rpctypes.Context{}, seeds)
 }
@@ -158,6 +183,10 @@ func (c Client) BlockchainInfo(_ context.Context, minHeight, maxHeight int64) (*
 	return c.env.BlockchainInfo(&rpctypes.Context{}, minHeight, maxHeight)
 }
 
+func (c Client) LightBlocks(_ context.Context, from, to int64) (*ctypes.ResultLightBlocks, error) {
+	return c.env.LightBlocks(&rpctypes.Context{}, from, to)
+}
+
 func (c Client) Genesis(context.Context) (*ctypes.ResultGenesis, error) {
 	return c.env.Genesis(&rpctypes.Context{})
 }
@@ -174,6 +203,22 @@ func (c Client) Commit(_ context.Context, height *int64) (*ctypes.ResultCommit,
 	return c.env.Commit(&rpctypes.Context{}, height)
 }
 
+func (c Client) HeaderChainProof(
+	_ context.Context,
+	height int64,
+	trustedHeight *int64,
+) (*ctypes.ResultHeaderChainProof, error) {
+	return c.env.HeaderChainProof(&rpctypes.Context{}, height, trustedHeight)
+}
+
+func (c Client) TxResultProof(_ context.Context, hash []byte, height *int64) (*ctypes.ResultTxResultProof, error) {
+	return c.env.TxResultProof(&rpctypes.Context{}, hash, height)
+}
+
+func (c Client) Attestation(_ context.Context, height *int64) (*ctypes.ResultAttestation, error) {
+	return c.env.Attestation(&rpctypes.Context{}, height)
+}
+
 func (c Client) Validators(_ context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return c.env.Validators(&rpctypes.Context{}, height, page, perPage)
 }
@@ -91,6 +91,29 @@ func (_m *Client) ABCIQueryWithOptions(ctx context.Context, path string, data by
 	return r0, r1
 }
 
+// Attestation provides a mock function with given fields: ctx, height
+func (_m *Client) Attestation(ctx context.Context, height *int64) (*coretypes.ResultAttestation, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultAttestation
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultAttestation); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultAttestation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Block provides a mock function with given fields: ctx, height
 func (_m *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
 	ret := _m.Called(ctx, height)
@@ -275,6 +298,29 @@ func (_m *Client) BroadcastTxCommit(_a0 context.Context, _a1 types.Tx) (*coretyp
 	return r0, r1
 }
 
+// BroadcastTxCommitProof provides a mock function with given fields: ctx, tx, timeoutMs
+func (_m *Client) BroadcastTxCommitProof(ctx context.Context, tx types.Tx, timeoutMs *int) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	ret := _m.Called(ctx, tx, timeoutMs)
+
+	var r0 *coretypes.ResultBroadcastTxCommitProof
+	if rf, ok := ret.Get(0).(func(context.Context, types.Tx, *int) *coretypes.ResultBroadcastTxCommitProof); ok {
+		r0 = rf(ctx, tx, timeoutMs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBroadcastTxCommitProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Tx, *int) error); ok {
+		r1 = rf(ctx, tx, timeoutMs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BroadcastTxSync provides a mock function with given fields: _a0, _a1
 func (_m *Client) BroadcastTxSync(_a0 context.Context, _a1 types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	ret := _m.Called(_a0, _a1)
@@ -505,6 +551,29 @@ func (_m *Client) HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*coret
 	return r0, r1
 }
 
+// HeaderChainProof provides a mock function with given fields: ctx, height, trustedHeight
+func (_m *Client) HeaderChainProof(ctx context.Context, height int64, trustedHeight *int64) (*coretypes.ResultHeaderChainProof, error) {
+	ret := _m.Called(ctx, height, trustedHeight)
+
+	var r0 *coretypes.ResultHeaderChainProof
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *int64) *coretypes.ResultHeaderChainProof); ok {
+		r0 = rf(ctx, height, trustedHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultHeaderChainProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *int64) error); ok {
+		r1 = rf(ctx, height, trustedHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Health provides a mock function with given fields: _a0
 func (_m *Client) Health(_a0 context.Context) (*coretypes.ResultHealth, error) {
 	ret := _m.Called(_a0)
@@ -542,6 +611,29 @@ func (_m *Client) IsRunning() bool {
 	return r0
 }
 
+// LightBlocks provides a mock function with given fields: ctx, from, to
+func (_m *Client) LightBlocks(ctx context.Context, from int64, to int64) (*coretypes.ResultLightBlocks, error) {
+	ret := _m.Called(ctx, from, to)
+
+	var r0 *coretypes.ResultLightBlocks
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *coretypes.ResultLightBlocks); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultLightBlocks)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetInfo provides a mock function with given fields: _a0
 func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error) {
 	ret := _m.Called(_a0)
@@ -651,11 +743,103 @@ func (_m *Client) Reset() error {
 	return r0
 }
 
+// RetainHeights provides a mock function with given fields: _a0
+func (_m *Client) RetainHeights(_a0 context.Context) (*coretypes.ResultRetainHeights, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultRetainHeights
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultRetainHeights); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultRetainHeights)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetLogger provides a mock function with given fields: _a0
 func (_m *Client) SetLogger(_a0 log.Logger) {
 	_m.Called(_a0)
 }
 
+// SimulateTx provides a mock function with given fields: _a0, _a1
+func (_m *Client) SimulateTx(_a0 context.Context, _a1 types.Tx) (*coretypes.ResultSimulateTx, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *coretypes.ResultSimulateTx
+	if rf, ok := ret.Get(0).(func(context.Context, types.Tx) *coretypes.ResultSimulateTx); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultSimulateTx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Tx) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SnapshotChunk provides a mock function with given fields: ctx, height, format, index, offset
+func (_m *Client) SnapshotChunk(ctx context.Context, height uint64, format uint32, index uint32, offset int) (*coretypes.ResultSnapshotChunk, error) {
+	ret := _m.Called(ctx, height, format, index, offset)
+
+	var r0 *coretypes.ResultSnapshotChunk
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint32, uint32, int) *coretypes.ResultSnapshotChunk); ok {
+		r0 = rf(ctx, height, format, index, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultSnapshotChunk)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint32, uint32, int) error); ok {
+		r1 = rf(ctx, height, format, index, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Snapshots provides a mock function with given fields: _a0
+func (_m *Client) Snapshots(_a0 context.Context) (*coretypes.ResultSnapshots, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultSnapshots
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultSnapshots); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultSnapshots)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields:
 func (_m *Client) Start() error {
 	ret := _m.Called()
@@ -721,6 +905,29 @@ func (_m *Client) String() string {
 	return r0
 }
 
+// StorageInfo provides a mock function with given fields: _a0
+func (_m *Client) StorageInfo(_a0 context.Context) (*coretypes.ResultStorageInfo, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultStorageInfo
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultStorageInfo); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultStorageInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Subscribe provides a mock function with given fields: ctx, subscriber, query, outCapacity
 func (_m *Client) Subscribe(ctx context.Context, subscriber string, query string, outCapacity ...int) (<-chan coretypes.ResultEvent, error) {
 	_va := make([]interface{}, len(outCapacity))
@@ -774,6 +981,29 @@ func (_m *Client) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.R
 	return r0, r1
 }
 
+// TxResultProof provides a mock function with given fields: ctx, hash, height
+func (_m *Client) TxResultProof(ctx context.Context, hash []byte, height *int64) (*coretypes.ResultTxResultProof, error) {
+	ret := _m.Called(ctx, hash, height)
+
+	var r0 *coretypes.ResultTxResultProof
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, *int64) *coretypes.ResultTxResultProof); ok {
+		r0 = rf(ctx, hash, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxResultProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, *int64) error); ok {
+		r1 = rf(ctx, hash, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TxSearch provides a mock function with given fields: ctx, query, prove, page, perPage, orderBy
 func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *int, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
 	ret := _m.Called(ctx, query, prove, page, perPage, orderBy)
@@ -848,6 +1078,29 @@ func (_m *Client) UnsubscribeAll(ctx context.Context, subscriber string) error {
 	return r0
 }
 
+// ValidatorSigningInfo provides a mock function with given fields: ctx, address
+func (_m *Client) ValidatorSigningInfo(ctx context.Context, address []byte) (*coretypes.ResultValidatorSigningInfo, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 *coretypes.ResultValidatorSigningInfo
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultValidatorSigningInfo); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidatorSigningInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Validators provides a mock function with given fields: ctx, height, page, perPage
 func (_m *Client) Validators(ctx context.Context, height *int64, page *int, perPage *int) (*coretypes.ResultValidators, error) {
 	ret := _m.Called(ctx, height, page, perPage)
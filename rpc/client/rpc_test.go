@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/abci/example/kvstore"
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
@@ -21,6 +22,7 @@ import (
 	"github.com/cometbft/cometbft/rpc/client"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	rpclocal "github.com/cometbft/cometbft/rpc/client/local"
+	"github.com/cometbft/cometbft/rpc/core"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 	rpctest "github.com/cometbft/cometbft/rpc/test"
@@ -371,6 +373,29 @@ func TestBroadcastTxCommit(t *testing.T) {
 	}
 }
 
+func TestBroadcastTxCommitProof(t *testing.T) {
+	for i, c := range GetClients() {
+		_, _, tx := MakeTxKV()
+		timeoutMs := 5000
+		bres, err := c.BroadcastTxCommitProof(context.Background(), tx, &timeoutMs)
+		require.NoError(t, err, "%d: %+v", i, err)
+		require.True(t, bres.CheckTx.IsOK())
+		require.True(t, bres.TxResult.IsOK())
+
+		// the proof is against the data hash of the block including the tx
+		block, err := c.Block(context.Background(), &bres.Height)
+		require.NoError(t, err)
+		require.EqualValues(t, tx, bres.Proof.Data)
+		require.EqualValues(t, bres.Index, bres.Proof.Proof.Index)
+		require.NoError(t, bres.Proof.Validate(block.Block.DataHash))
+	}
+
+	invalidTimeout := 0
+	_, _, tx := MakeTxKV()
+	_, err := getHTTPClient().BroadcastTxCommitProof(context.Background(), tx, &invalidTimeout)
+	require.Error(t, err)
+}
+
 func TestUnconfirmedTxs(t *testing.T) {
 	_, _, tx := MakeTxKV()
 
@@ -447,6 +472,205 @@ func TestCheckTx(t *testing.T) {
 	}
 }
 
+func TestSimulateTx(t *testing.T) {
+	mempool := node.Mempool()
+
+	for _, c := range GetClients() {
+		_, _, tx := MakeTxKV()
+
+		res, err := c.SimulateTx(context.Background(), tx)
+		require.NoError(t, err)
+		assert.Equal(t, abci.CodeTypeOK, res.Code)
+		assert.EqualValues(t, 1, res.GasWanted)
+
+		res, err = c.SimulateTx(context.Background(), types.Tx("invalid"))
+		require.NoError(t, err)
+		assert.Equal(t, kvstore.CodeTypeInvalidTxFormat, res.Code)
+
+		assert.Equal(t, 0, mempool.Size(), "mempool must be empty")
+	}
+}
+
+func TestHeaderChainProof(t *testing.T) {
+	for i, c := range GetClients() {
+		err := client.WaitForHeight(c, 3, nil)
+		require.NoError(t, err, "%d", i)
+
+		status, err := c.Status(context.Background())
+		require.NoError(t, err, "%d", i)
+		height := status.SyncInfo.LatestBlockHeight - 2
+
+		// a proof to the latest header
+		res, err := c.HeaderChainProof(context.Background(), height, nil)
+		require.NoError(t, err, "%d", i)
+		trusted, err := c.Header(context.Background(), &res.Proof.TrustedHeight)
+		require.NoError(t, err, "%d", i)
+		require.NoError(t, res.Proof.Verify(trusted.Header.Hash(), &res.Header), "%d", i)
+		assert.Equal(t, height, res.Header.Height, "%d", i)
+
+		// a proof to a given header
+		trustedHeight := height + 1
+		res, err = c.HeaderChainProof(context.Background(), height, &trustedHeight)
+		require.NoError(t, err, "%d", i)
+		trusted, err = c.Header(context.Background(), &trustedHeight)
+		require.NoError(t, err, "%d", i)
+		require.NoError(t, res.Proof.Verify(trusted.Header.Hash(), &res.Header), "%d", i)
+		assert.Len(t, res.Proof.Links, 1, "%d", i)
+
+		// the header must not be above the trusted one
+		trustedHeight = height - 1
+		_, err = c.HeaderChainProof(context.Background(), height, &trustedHeight)
+		require.Error(t, err, "%d", i)
+	}
+}
+
+func TestLightBlocks(t *testing.T) {
+	for i, c := range GetClients() {
+		err := client.WaitForHeight(c, 3, nil)
+		require.NoError(t, err, "%d", i)
+
+		status, err := c.Status(context.Background())
+		require.NoError(t, err, "%d", i)
+		chainID := status.NodeInfo.Network
+		to := status.SyncInfo.LatestBlockHeight
+		from := to - 2
+
+		res, err := c.LightBlocks(context.Background(), from, to)
+		require.NoError(t, err, "%d", i)
+		require.Len(t, res.LightBlocks, 3, "%d", i)
+		for j, lb := range res.LightBlocks {
+			assert.Equal(t, from+int64(j), lb.Height, "%d", i)
+			require.NoError(t, lb.ValidateBasic(chainID), "%d", i)
+
+			commit, err := c.Commit(context.Background(), &lb.Height)
+			require.NoError(t, err, "%d", i)
+			assert.Equal(t, commit.Header.Hash(), lb.Hash(), "%d", i)
+		}
+
+		// the range is capped
+		res, err = c.LightBlocks(context.Background(), 1, 0)
+		require.NoError(t, err, "%d", i)
+		assert.Len(t, res.LightBlocks, int(cmtmath.MinInt64(res.LastHeight, core.MaxLightBlocks)), "%d", i)
+		assert.EqualValues(t, 1, res.LightBlocks[0].Height, "%d", i)
+
+		// from must not be above to
+		_, err = c.LightBlocks(context.Background(), to, from)
+		require.Error(t, err, "%d", i)
+	}
+}
+
+func TestValidatorSigningInfo(t *testing.T) {
+	for i, c := range GetClients() {
+		err := client.WaitForHeight(c, 3, nil)
+		require.NoError(t, err, "%d", i)
+
+		status, err := c.Status(context.Background())
+		require.NoError(t, err, "%d", i)
+		address := status.ValidatorInfo.Address
+
+		// The single validator signs every block.
+		res, err := c.ValidatorSigningInfo(context.Background(), address)
+		require.NoError(t, err, "%d", i)
+		assert.Equal(t, address, res.Address, "%d", i)
+		assert.GreaterOrEqual(t, res.EndHeight, status.SyncInfo.LatestBlockHeight, "%d", i)
+		assert.Equal(t, res.EndHeight-res.StartHeight+1, res.ValidatorHeights, "%d", i)
+		assert.Zero(t, res.MissedBlocks, "%d", i)
+		assert.Equal(t, res.EndHeight, res.LastSignedHeight, "%d", i)
+
+		_, err = c.ValidatorSigningInfo(context.Background(), []byte("unknown"))
+		require.Error(t, err, "%d", i)
+	}
+}
+
+func TestStorageInfo(t *testing.T) {
+	for i, c := range GetClients() {
+		var (
+			res *ctypes.ResultStorageInfo
+			err error
+		)
+		require.Eventually(t, func() bool {
+			res, err = c.StorageInfo(context.Background())
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond, "%d", i)
+		assert.Positive(t, res.SampleInterval, "%d", i)
+
+		stores := make(map[string]ctypes.StoreInfo)
+		for _, st := range res.Stores {
+			stores[st.Store] = st
+		}
+		for _, name := range []string{"blockstore", "state", "evidence", "tx_index"} {
+			assert.Contains(t, stores, name, "%d", i)
+			// The test node keeps its databases in memory, without files.
+			assert.Zero(t, stores[name].SizeBytes, "%d", i)
+		}
+		// Sampled on start, once the state was saved.
+		assert.Positive(t, stores["state"].Keys, "%d", i)
+		assert.Positive(t, stores["state"].DataBytes, "%d", i)
+	}
+}
+
+func TestRetainHeights(t *testing.T) {
+	for i, c := range GetClients() {
+		res, err := c.RetainHeights(context.Background())
+		require.NoError(t, err, "%d", i)
+
+		sources := make([]string, 0, len(res.RetainHeights))
+		for _, h := range res.RetainHeights {
+			sources = append(sources, h.Source)
+		}
+		assert.Equal(t, []string{"application", "operator", "snapshots", "indexer"}, sources, "%d", i)
+		// The kvstore app retains all the blocks.
+		assert.Zero(t, res.RetainHeight, "%d", i)
+		assert.EqualValues(t, 1, res.Base, "%d", i)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	for i, c := range GetClients() {
+		// the kvstore app does not take snapshots
+		res, err := c.Snapshots(context.Background())
+		require.NoError(t, err, "%d", i)
+		assert.Empty(t, res.Snapshots, "%d", i)
+
+		_, err = c.SnapshotChunk(context.Background(), 1, 1, 0, 0)
+		require.Error(t, err, "%d", i)
+	}
+}
+
+func TestAttestation(t *testing.T) {
+	for i, c := range GetClients() {
+		err := client.WaitForHeight(c, 2, nil)
+		require.NoError(t, err, "%d", i)
+
+		status, err := c.Status(context.Background())
+		require.NoError(t, err, "%d", i)
+		chainID := status.NodeInfo.Network
+
+		// the latest attestation
+		res, err := c.Attestation(context.Background(), nil)
+		require.NoError(t, err, "%d", i)
+		require.NoError(t, res.Attestation.Verify(chainID, res.PubKey), "%d", i)
+		assert.Equal(t, status.ValidatorInfo.Address, res.Attestation.ValidatorAddress, "%d", i)
+
+		// an attestation at a given height is backed by the next header
+		h := res.Attestation.Height - 1
+		res, err = c.Attestation(context.Background(), &h)
+		require.NoError(t, err, "%d", i)
+		require.NoError(t, res.Attestation.Verify(chainID, res.PubKey), "%d", i)
+		assert.Equal(t, h, res.Attestation.Height, "%d", i)
+
+		block, err := c.Block(context.Background(), &h)
+		require.NoError(t, err, "%d", i)
+		assert.Equal(t, block.BlockID.Hash, res.Attestation.BlockHash, "%d", i)
+		assert.Equal(t, block.Block.Time, res.Attestation.Timestamp, "%d", i)
+
+		next := h + 1
+		header, err := c.Header(context.Background(), &next)
+		require.NoError(t, err, "%d", i)
+		assert.Equal(t, header.Header.AppHash, res.Attestation.AppHash, "%d", i)
+	}
+}
+
 func TestTx(t *testing.T) {
 	// first we broadcast a tx
 	c := getHTTPClient()
@@ -501,6 +725,45 @@ func TestTx(t *testing.T) {
 	}
 }
 
+func TestTxResultProof(t *testing.T) {
+	c := getHTTPClient()
+	_, _, tx := MakeTxKV()
+	bres, err := c.BroadcastTxCommit(context.Background(), tx)
+	require.NoError(t, err)
+	require.NoError(t, client.WaitForHeight(c, bres.Height+1, nil))
+
+	header, err := c.Header(context.Background(), &bres.Height)
+	require.NoError(t, err)
+	nextHeight := bres.Height + 1
+	next, err := c.Header(context.Background(), &nextHeight)
+	require.NoError(t, err)
+
+	anotherTxHash := types.Tx("a different tx").Hash()
+
+	for i, c := range GetClients() {
+		// the block of the transaction is looked up in the indexer
+		res, err := c.TxResultProof(context.Background(), bres.Hash, nil)
+		require.NoError(t, err, "%d", i)
+		assert.Equal(t, bres.Height, res.Height, "%d", i)
+		require.NotNil(t, res.Proof, "%d", i)
+		assert.Nil(t, res.AbsenceProof, "%d", i)
+		assert.EqualValues(t, tx, res.Proof.TxProof.Data, "%d", i)
+		assert.True(t, res.Proof.Result.IsOK(), "%d", i)
+		require.NoError(t, res.Proof.Verify(header.Header.DataHash, next.Header.LastResultsHash), "%d", i)
+
+		// another transaction is absent from the block
+		res, err = c.TxResultProof(context.Background(), anotherTxHash, &bres.Height)
+		require.NoError(t, err, "%d", i)
+		assert.Nil(t, res.Proof, "%d", i)
+		require.NotNil(t, res.AbsenceProof, "%d", i)
+		require.NoError(t, res.AbsenceProof.Verify(header.Header.DataHash, anotherTxHash), "%d", i)
+
+		// and cannot be found without a height
+		_, err = c.TxResultProof(context.Background(), anotherTxHash, nil)
+		require.Error(t, err, "%d", i)
+	}
+}
+
 func TestTxSearchWithTimeout(t *testing.T) {
 	// Get a client with a time-out of 10 secs.
 	timeoutClient := getHTTPClientWithTimeout(10)
@@ -1,8 +1,6 @@
 package core
 
 import (
-	"context"
-
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/proxy"
@@ -13,13 +11,13 @@ import (
 // ABCIQuery queries the application for some information.
 // More: https://docs.cometbft.com/main/rpc/#/ABCI/abci_query
 func (env *Environment) ABCIQuery(
-	_ *rpctypes.Context,
+	ctx *rpctypes.Context,
 	path string,
 	data bytes.HexBytes,
 	height int64,
 	prove bool,
 ) (*ctypes.ResultABCIQuery, error) {
-	resQuery, err := env.ProxyAppQuery.Query(context.TODO(), &abci.QueryRequest{
+	resQuery, err := env.ProxyAppQuery.Query(ctx.Context(), &abci.QueryRequest{
 		Path:   path,
 		Data:   data,
 		Height: height,
@@ -34,8 +32,8 @@ func (env *Environment) ABCIQuery(
 
 // ABCIInfo gets some info about the application.
 // More: https://docs.cometbft.com/main/rpc/#/ABCI/abci_info
-func (env *Environment) ABCIInfo(_ *rpctypes.Context) (*ctypes.ResultABCIInfo, error) {
-	resInfo, err := env.ProxyAppQuery.Info(context.TODO(), proxy.InfoRequest)
+func (env *Environment) ABCIInfo(ctx *rpctypes.Context) (*ctypes.ResultABCIInfo, error) {
+	resInfo, err := env.ProxyAppQuery.Info(ctx.Context(), proxy.InfoRequest)
 	if err != nil {
 		return nil, err
 	}
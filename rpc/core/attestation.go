@@ -0,0 +1,117 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Attestation gets an attestation of the block at the given height, signed by
+// the node's private validator. It states the hash of the block and the app
+// hash resulting from its execution. If no height is provided, it attests the
+// latest block the node has executed.
+//
+// Attestations only depend on committed data, so that all validators sign the
+// same bytes for a given height. A bridge or oracle can therefore collect the
+// attestations of the validators it knows until they reach the power it
+// requires.
+//
+// The endpoint is only served if rpc.attestation_enabled is set, and the
+// attestation of each height is signed once, then served from a cache.
+// More: https://docs.cometbft.com/main/rpc/#/Info/attestation
+func (env *Environment) Attestation(_ *rpctypes.Context, heightPtr *int64) (*ctypes.ResultAttestation, error) {
+	if env.PrivValidator == nil {
+		return nil, ErrNoPrivValidator
+	}
+	signer, ok := env.PrivValidator.(types.AttestationSigner)
+	if !ok {
+		return nil, ErrNoAttestations
+	}
+
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	height, err := env.getHeight(state.LastBlockHeight, heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, ErrHeightNotAvailable{Height: height, LowestHeight: env.BlockStore.Base()}
+	}
+
+	// The app hash resulting from the execution of a block is only included in
+	// the header of the next one.
+	appHash := state.AppHash
+	if height < state.LastBlockHeight {
+		nextMeta := env.BlockStore.LoadBlockMeta(height + 1)
+		if nextMeta == nil {
+			return nil, ErrHeightNotAvailable{Height: height + 1, LowestHeight: env.BlockStore.Base()}
+		}
+		appHash = nextMeta.Header.AppHash
+	}
+
+	// Attestations are signed once per height, and one at a time, so that
+	// requests can't keep the private validator, which may be a remote signer
+	// shared with consensus, busy.
+	env.attestationsMtx.Lock()
+	defer env.attestationsMtx.Unlock()
+	if res, ok := env.attestations[height]; ok {
+		return res, nil
+	}
+
+	// The key of a rotating private validator depends on the height.
+	var pubKey crypto.PubKey
+	if rotator, ok := env.PrivValidator.(types.KeyRotator); ok {
+		pubKey, err = rotator.GetPubKeyAt(height)
+	} else {
+		pubKey, err = env.PrivValidator.GetPubKey()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	attestation := types.NewAttestation(
+		height,
+		blockMeta.BlockID.Hash,
+		appHash,
+		blockMeta.Header.Time,
+		pubKey.Address(),
+	)
+	pa := attestation.ToProto()
+	if err := signer.SignAttestation(state.ChainID, pa); err != nil {
+		return nil, fmt.Errorf("can't sign attestation: %w", err)
+	}
+	attestation.Signature = pa.Signature
+
+	res := &ctypes.ResultAttestation{Attestation: *attestation, PubKey: pubKey}
+	env.cacheAttestation(res)
+	return res, nil
+}
+
+// maxCachedAttestations is the number of signed attestations kept by the
+// Environment.
+const maxCachedAttestations = 100
+
+// cacheAttestation adds res to the cache of signed attestations, evicting the
+// lowest height once the cache is full. The caller must hold attestationsMtx.
+func (env *Environment) cacheAttestation(res *ctypes.ResultAttestation) {
+	if env.attestations == nil {
+		env.attestations = make(map[int64]*ctypes.ResultAttestation)
+	}
+	if len(env.attestations) >= maxCachedAttestations {
+		var lowest int64
+		for h := range env.attestations {
+			if lowest == 0 || h < lowest {
+				lowest = h
+			}
+		}
+		delete(env.attestations, lowest)
+	}
+	env.attestations[res.Attestation.Height] = res
+}
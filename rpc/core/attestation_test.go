@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	"github.com/cometbft/cometbft/config"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/state/mocks"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// countingPV counts the attestations it signs.
+type countingPV struct {
+	types.MockPV
+	signed int
+}
+
+func (pv *countingPV) SignAttestation(chainID string, attestation *cmtproto.Attestation) error {
+	pv.signed++
+	return pv.MockPV.SignAttestation(chainID, attestation)
+}
+
+func TestAttestation(t *testing.T) {
+	stateStore := &mocks.Store{}
+	stateStore.On("Load").Return(sm.State{ChainID: "test-chain", LastBlockHeight: 10, AppHash: []byte("app")}, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	for h := int64(1); h <= 10; h++ {
+		blockStore.On("LoadBlockMeta", h).Return(&types.BlockMeta{
+			BlockID: types.BlockID{Hash: []byte{byte(h)}},
+			Header:  types.Header{Height: h, AppHash: []byte{byte(h)}},
+		})
+	}
+	pv := &countingPV{MockPV: types.NewMockPV()}
+	env := &Environment{StateStore: stateStore, BlockStore: blockStore, PrivValidator: pv}
+
+	height := int64(5)
+	res, err := env.Attestation(&rpctypes.Context{}, &height)
+	require.NoError(t, err)
+	require.NoError(t, res.Attestation.Verify("test-chain", res.PubKey))
+	assert.Equal(t, []byte{6}, []byte(res.Attestation.AppHash))
+
+	// The attestation of a height is only signed once.
+	again, err := env.Attestation(&rpctypes.Context{}, &height)
+	require.NoError(t, err)
+	assert.Equal(t, res, again)
+	assert.Equal(t, 1, pv.signed)
+
+	_, err = env.Attestation(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pv.signed)
+
+	// Private validators which don't sign attestations are rejected.
+	env.PrivValidator = types.PrivValidator(struct{ types.PrivValidator }{pv})
+	_, err = env.Attestation(&rpctypes.Context{}, nil)
+	assert.ErrorIs(t, err, ErrNoAttestations)
+}
+
+func TestAttestationRoute(t *testing.T) {
+	env := &Environment{Config: *config.DefaultRPCConfig()}
+	assert.NotContains(t, env.GetRoutes(), "attestation")
+
+	env.Config.AttestationEnabled = true
+	assert.Contains(t, env.GetRoutes(), "attestation")
+}
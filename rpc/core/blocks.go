@@ -53,6 +53,101 @@ func (env *Environment) BlockchainInfo(
 	}, nil
 }
 
+const (
+	// MaxLightBlocks is the maximum number of light blocks returned by
+	// LightBlocks.
+	MaxLightBlocks = 100
+	// MaxLightBlocksBytes is the maximum size of the light blocks returned by
+	// LightBlocks, as encoded in protobuf. At least one light block is
+	// returned, whatever its size.
+	MaxLightBlocksBytes = 8 * 1024 * 1024
+)
+
+// LightBlocks gets the light blocks (signed header and validator set) for
+// from <= height <= to, so that light clients and relayers can verify a range
+// of headers in one call.
+//
+// If to does not yet exist, light blocks up to the current height will be
+// returned. If from does not exist (due to pruning), an error is returned.
+//
+// At most MaxLightBlocks light blocks, of at most MaxLightBlocksBytes in
+// total, will be returned, in ascending order. Clients can request the
+// following ones starting from the height after the last light block
+// returned.
+//
+// More: https://docs.cometbft.com/main/rpc/#/Info/light_blocks
+func (env *Environment) LightBlocks(_ *rpctypes.Context, from, to int64) (*ctypes.ResultLightBlocks, error) {
+	lastHeight := env.BlockStore.Height()
+	if from < 0 || to < 0 {
+		return nil, fmt.Errorf("heights must be non-negative")
+	}
+	if from == 0 {
+		from = env.BlockStore.Base()
+	}
+	if to == 0 || to > lastHeight {
+		to = lastHeight
+	}
+	if from > to {
+		return nil, fmt.Errorf("from height %d can't be greater than to height %d", from, to)
+	}
+	if base := env.BlockStore.Base(); from < base {
+		return nil, ErrHeightNotAvailable{Height: from, LowestHeight: base}
+	}
+	to = cmtmath.MinInt64(to, from+MaxLightBlocks-1)
+
+	lightBlocks := make([]*types.LightBlock, 0, to-from+1)
+	size := 0
+	for height := from; height <= to; height++ {
+		lb, err := env.loadLightBlock(height, lastHeight)
+		if err != nil {
+			return nil, err
+		}
+		pb, err := lb.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		size += pb.Size()
+		if size > MaxLightBlocksBytes && len(lightBlocks) > 0 {
+			break
+		}
+		lightBlocks = append(lightBlocks, lb)
+	}
+
+	return &ctypes.ResultLightBlocks{
+		LastHeight:  lastHeight,
+		LightBlocks: lightBlocks,
+	}, nil
+}
+
+// loadLightBlock loads the light block at height. Like Commit, it uses the
+// commit seen by the node if the next block has not been committed yet.
+func (env *Environment) loadLightBlock(height, lastHeight int64) (*types.LightBlock, error) {
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, ErrHeightNotAvailable{Height: height, LowestHeight: env.BlockStore.Base()}
+	}
+
+	var commit *types.Commit
+	if height == lastHeight {
+		commit = env.BlockStore.LoadSeenCommit(height)
+	} else {
+		commit = env.BlockStore.LoadBlockCommit(height)
+	}
+	if commit == nil {
+		return nil, fmt.Errorf("commit for height %d not found", height)
+	}
+
+	validators, err := env.StateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &blockMeta.Header, Commit: commit},
+		ValidatorSet: validators,
+	}, nil
+}
+
 // error if either min or max are negative or min > max
 // if 0, use blockstore base for min, latest block height for max
 // enforce limit.
@@ -118,6 +213,50 @@ func (env *Environment) HeaderByHash(_ *rpctypes.Context, hash bytes.HexBytes) (
 	return &ctypes.ResultHeader{Header: &blockMeta.Header}, nil
 }
 
+// MaxHeaderChainProofLength is the maximum number of links of a
+// HeaderChainProof returned by the RPC. Clients can chain proofs to reach
+// older headers.
+const MaxHeaderChainProofLength = 1000
+
+// HeaderChainProof gets the header at the given height, along with a proof
+// linking it to the header at trustedHeight via the chain of LastBlockIDs.
+// If no trustedHeight is provided, the latest header is used.
+// More: https://docs.cometbft.com/main/rpc/#/Info/header_chain_proof
+func (env *Environment) HeaderChainProof(
+	_ *rpctypes.Context,
+	height int64,
+	trustedHeightPtr *int64,
+) (*ctypes.ResultHeaderChainProof, error) {
+	trustedHeight, err := env.getHeight(env.BlockStore.Height(), trustedHeightPtr)
+	if err != nil {
+		return nil, err
+	}
+	height, err = env.getHeight(trustedHeight, &height)
+	if err != nil {
+		return nil, err
+	}
+	if trustedHeight-height > MaxHeaderChainProofLength {
+		return nil, fmt.Errorf("trusted_height %d is more than %d blocks above height %d",
+			trustedHeight, MaxHeaderChainProofLength, height)
+	}
+
+	headers := make([]*types.Header, 0, trustedHeight-height+1)
+	for h := height; h <= trustedHeight; h++ {
+		blockMeta := env.BlockStore.LoadBlockMeta(h)
+		if blockMeta == nil {
+			return nil, ErrHeightNotAvailable{Height: h, LowestHeight: env.BlockStore.Base()}
+		}
+		headers = append(headers, &blockMeta.Header)
+	}
+
+	proof, err := types.NewHeaderChainProof(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultHeaderChainProof{Header: *headers[0], Proof: *proof}, nil
+}
+
 // Block gets block at a given height.
 // If no height is provided, it will fetch the latest block.
 // More: https://docs.cometbft.com/main/rpc/#/Info/block
@@ -208,7 +347,7 @@ func (env *Environment) BlockSearch(
 ) (*ctypes.ResultBlockSearch, error) {
 	// skip if block indexing is disabled
 	if _, ok := env.BlockIndexer.(*blockidxnull.BlockerIndexer); ok {
-		return nil, errors.New("block indexing is disabled")
+		return nil, ErrBlockIndexingDisabled
 	}
 
 	q, err := cmtquery.New(query)
@@ -22,7 +22,9 @@ Available endpoints:
 /health
 /net_info
 /num_unconfirmed_txs
+/retain_heights
 /status
+/storage_info
 /unsafe_flush_mempool
 /unsubscribe_all?
 
@@ -46,9 +48,11 @@ Endpoints that require arguments:
 /header_by_hash?hash=_
 /subscribe?query=_
 /tx?hash=_&prove=_
+/tx_result_proof?hash=_&height=_
 /tx_search?query=_&prove=_&page=_&per_page=_&order_by=_
 /unconfirmed_txs?limit=_
 /unsubscribe?query=_
+/validator_signing_info?address=_
 /validators?height=_&page=_&per_page=_
 ```
 */
@@ -7,14 +7,22 @@ import (
 
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/internal/blocksync"
+	cm "github.com/cometbft/cometbft/internal/consensus"
+	"github.com/cometbft/cometbft/internal/signinginfo"
 	sm "github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/state/indexer"
 	"github.com/cometbft/cometbft/internal/state/txindex"
+	"github.com/cometbft/cometbft/internal/statesync"
+	"github.com/cometbft/cometbft/internal/storageinfo"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/internal/upgrade"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/proxy"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -41,6 +49,7 @@ type Consensus interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetMsgQueues() []cm.MsgQueueStatus
 }
 
 type transport interface {
@@ -55,6 +64,7 @@ type peers interface {
 	AddPrivatePeerIDs(peerIDs []string) error
 	DialPeersAsync(peers []string) error
 	Peers() p2p.IPeerSet
+	Reactors() map[string]p2p.Reactor
 }
 
 // A reactor that transitions from block sync or state sync to consensus mode.
@@ -62,13 +72,24 @@ type syncReactor interface {
 	WaitSync() bool
 }
 
+// A reactor that restores the state from a snapshot.
+type stateSyncReactor interface {
+	Progress() (statesync.Progress, bool)
+}
+
+// A reactor that fetches and applies the blocks the node is missing.
+type blockSyncReactor interface {
+	Progress() (blocksync.Progress, bool)
+}
+
 // ----------------------------------------------
 // Environment contains objects and interfaces used by the RPC. It is expected
 // to be setup once during startup.
 type Environment struct {
 	// external, thread safe interfaces
-	ProxyAppQuery   proxy.AppConnQuery
-	ProxyAppMempool proxy.AppConnMempool
+	ProxyAppQuery    proxy.AppConnQuery
+	ProxyAppMempool  proxy.AppConnMempool
+	ProxyAppSnapshot proxy.AppConnSnapshot
 
 	// interfaces defined in types and above
 	StateStore       sm.Store
@@ -77,16 +98,25 @@ type Environment struct {
 	ConsensusState   Consensus
 	ConsensusReactor syncReactor
 	MempoolReactor   syncReactor
+	StateSyncReactor stateSyncReactor // nil if absent
+	BlockSyncReactor blockSyncReactor // nil if absent
 	P2PPeers         peers
 	P2PTransport     transport
 
 	// objects
-	PubKey       crypto.PubKey
-	GenDoc       *types.GenesisDoc // cache the genesis structure
-	TxIndexer    txindex.TxIndexer
-	BlockIndexer indexer.BlockIndexer
-	EventBus     *types.EventBus // thread safe
-	Mempool      mempl.Mempool
+	PubKey          crypto.PubKey
+	PrivValidator   types.PrivValidator // signs attestations, nil if absent
+	GenDoc          *types.GenesisDoc   // cache the genesis structure
+	TxIndexer       txindex.TxIndexer
+	BlockIndexer    indexer.BlockIndexer
+	EventBus        *types.EventBus // thread safe
+	Mempool         mempl.Mempool
+	Upgrades        *upgrade.Manager     // halts the node for an upgrade, nil if absent
+	SigningInfo     *signinginfo.Tracker // nil if disabled
+	StorageSampler  *storageinfo.Sampler // nil if disabled
+	Pruner          *sm.Pruner           // nil if absent
+	SparseRetention int64                // interval of the blocks kept below the base, 0 if none
+	DBDir           string               // directory of the databases, empty if absent
 
 	Logger log.Logger
 
@@ -94,6 +124,15 @@ type Environment struct {
 
 	// cache of chunked genesis data.
 	genChunks []string
+
+	// cache of the disk usage of the databases.
+	diskUsageMtx  cmtsync.Mutex
+	diskUsage     []ctypes.StoreDiskUsage
+	diskUsageTime time.Time
+
+	// cache of the attestations signed by the private validator, by height.
+	attestationsMtx cmtsync.Mutex
+	attestations    map[int64]*ctypes.ResultAttestation
 }
 
 //----------------------------------------------
@@ -176,22 +215,26 @@ func (env *Environment) getHeight(latestHeight int64, heightPtr *int64) (int64,
 	if heightPtr != nil {
 		height := *heightPtr
 		if height <= 0 {
-			return 0, fmt.Errorf("height must be greater than 0, but got %d", height)
+			return 0, ErrInvalidHeight{Height: height}
 		}
 		if height > latestHeight {
-			return 0, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d",
-				height, latestHeight)
+			return 0, ErrHeightExceedsChainHead{Height: height, Head: latestHeight}
 		}
 		base := env.BlockStore.Base()
-		if height < base {
-			return 0, fmt.Errorf("height %d is not available, lowest height is %d",
-				height, base)
+		if height < base && !env.sparseRetained(height) {
+			return 0, ErrHeightNotAvailable{Height: height, LowestHeight: base}
 		}
 		return height, nil
 	}
 	return latestHeight, nil
 }
 
+// sparseRetained returns true if the block at height, below the base, was kept
+// by the sparse pruning policy.
+func (env *Environment) sparseRetained(height int64) bool {
+	return sm.SparseRetained(env.SparseRetention, height) && env.BlockStore.LoadBlockMeta(height) != nil
+}
+
 func (env *Environment) latestUncommittedHeight() int64 {
 	nodeIsSyncing := env.ConsensusReactor.WaitSync()
 	if nodeIsSyncing {
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTxIndexingDisabled is returned when transaction indexing is disabled.
+	ErrTxIndexingDisabled = errors.New("transaction indexing is disabled")
+	// ErrBlockIndexingDisabled is returned when block indexing is disabled.
+	ErrBlockIndexingDisabled = errors.New("block indexing is disabled")
+	// ErrNoPrivValidator is returned when the node has no private validator to
+	// sign with.
+	ErrNoPrivValidator = errors.New("node has no private validator")
+	// ErrSnapshotsDisabled is returned when the node has no connection to the
+	// application to load snapshots from.
+	ErrSnapshotsDisabled = errors.New("snapshots are not available")
+	// ErrNoKeyRotation is returned when the node's private validator cannot
+	// rotate its key.
+	ErrNoKeyRotation = errors.New("private validator does not support key rotation")
+	// ErrNoAttestations is returned when the node's private validator cannot
+	// sign attestations.
+	ErrNoAttestations = errors.New("private validator does not support attestations")
+	// ErrNoUpgrades is returned when the node cannot halt for an upgrade.
+	ErrNoUpgrades = errors.New("upgrades are not available")
+	// ErrSigningInfoDisabled is returned when the node does not track the
+	// votes of the validators.
+	ErrSigningInfoDisabled = errors.New("validator signing info is disabled")
+	// ErrStorageInfoDisabled is returned when the node does not sample its
+	// databases.
+	ErrStorageInfoDisabled = errors.New("storage info is disabled")
+	// ErrNoPruner is returned when the node does not prune its blocks.
+	ErrNoPruner = errors.New("pruning is not available")
+)
+
+// ErrInvalidHeight is returned when the requested height is not positive.
+type ErrInvalidHeight struct {
+	Height int64
+}
+
+func (e ErrInvalidHeight) Error() string {
+	return fmt.Sprintf("height must be greater than 0, but got %d", e.Height)
+}
+
+// ErrHeightExceedsChainHead is returned when the requested height is greater
+// than the latest height known to the node.
+type ErrHeightExceedsChainHead struct {
+	Height int64
+	Head   int64
+}
+
+func (e ErrHeightExceedsChainHead) Error() string {
+	return fmt.Sprintf("height %d must be less than or equal to the current blockchain height %d",
+		e.Height, e.Head)
+}
+
+// ErrHeightNotAvailable is returned when the requested height has been pruned
+// (or was never stored, e.g. after state sync).
+type ErrHeightNotAvailable struct {
+	Height       int64
+	LowestHeight int64
+}
+
+func (e ErrHeightNotAvailable) Error() string {
+	return fmt.Sprintf("height %d is not available, lowest height is %d", e.Height, e.LowestHeight)
+}
+
+// ErrSnapshotChunkNotFound is returned when the application does not have the
+// requested snapshot chunk.
+type ErrSnapshotChunkNotFound struct {
+	Height uint64
+	Format uint32
+	Index  uint32
+}
+
+func (e ErrSnapshotChunkNotFound) Error() string {
+	return fmt.Sprintf("chunk %d of snapshot at height %d (format %d) not found", e.Index, e.Height, e.Format)
+}
+
+// ErrTxNotFound is returned when the requested transaction is not indexed.
+type ErrTxNotFound struct {
+	Hash []byte
+}
+
+func (e ErrTxNotFound) Error() string {
+	return fmt.Sprintf("tx (%X) not found", e.Hash)
+}
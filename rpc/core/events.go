@@ -42,7 +42,12 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
-	sub, err := env.EventBus.Subscribe(subCtx, addr, q, env.Config.SubscriptionBufferSize)
+	policy, err := cmtpubsub.ParseOverflowPolicy(env.Config.SubscriptionOverflowPolicy)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := env.EventBus.SubscribeWithPolicy(subCtx, addr, q, env.Config.SubscriptionBufferSize,
+		policy, env.Config.SubscriptionBlockTimeout)
 	if err != nil {
 		return nil, err
 	}
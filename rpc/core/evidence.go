@@ -28,3 +28,41 @@ func (env *Environment) BroadcastEvidence(
 	}
 	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
 }
+
+// BroadcastAttackReport broadcasts the evidence of the fork accountability
+// report produced by a light client which detected an attack.
+// More: https://docs.cometbft.com/main/rpc/#/Evidence/broadcast_attack_report
+func (env *Environment) BroadcastAttackReport(
+	_ *rpctypes.Context,
+	report *types.LightClientAttackReport,
+) (*ctypes.ResultBroadcastAttackReport, error) {
+	if report == nil {
+		return nil, errors.New("no report was provided")
+	}
+
+	if err := report.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("report.ValidateBasic failed: %w", err)
+	}
+
+	if env.GenDoc != nil && report.ChainID != env.GenDoc.ChainID {
+		return nil, fmt.Errorf("the report is for chain %q, not %q", report.ChainID, env.GenDoc.ChainID)
+	}
+
+	// The evidence against the honest provider, if any, does not verify
+	// against the chain of the node, and is rejected.
+	var (
+		result = &ctypes.ResultBroadcastAttackReport{}
+		errs   []error
+	)
+	for _, ev := range report.Evidence() {
+		if err := env.EvidencePool.AddEvidence(ev); err != nil {
+			errs = append(errs, fmt.Errorf("failed to add evidence %X: %w", ev.Hash(), err))
+			continue
+		}
+		result.Hashes = append(result.Hashes, ev.Hash())
+	}
+	if len(result.Hashes) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
@@ -1,13 +1,136 @@
 package core
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
 
-// Health gets node health. Returns empty result (200 OK) on success, no
-// response - in case of an error.
+// healthCheckTimeout is the maximum time each health check may take.
+const healthCheckTimeout = 3 * time.Second
+
+// Health checks the components of the node: consensus progressing, the
+// application and the private validator reachable, the databases writable and
+// enough peers. The node is ready if all the checks pass. Returns 200 OK
+// whether it is ready or not.
 // More: https://docs.cometbft.com/main/rpc/#/Info/health
 func (env *Environment) Health(*rpctypes.Context) (*ctypes.ResultHealth, error) {
-	return &ctypes.ResultHealth{}, nil
+	checks := []struct {
+		name  string
+		check func() error
+	}{
+		{"consensus", env.checkConsensus},
+		{"abci", env.checkABCI},
+		{"privval", env.checkPrivValidator},
+		{"db", env.checkDB},
+		{"peers", env.checkPeers},
+	}
+
+	res := &ctypes.ResultHealth{Ready: true, Checks: make([]ctypes.HealthCheck, 0, len(checks))}
+	for _, c := range checks {
+		check := ctypes.HealthCheck{Name: c.name, Healthy: true}
+		if err := c.check(); err != nil {
+			check.Healthy = false
+			check.Message = err.Error()
+			res.Ready = false
+		}
+		res.Checks = append(res.Checks, check)
+	}
+	return res, nil
+}
+
+// checkConsensus checks that the node is not syncing, and that the latest
+// block is not older than the configured maximum age.
+func (env *Environment) checkConsensus() error {
+	if env.ConsensusReactor != nil && env.ConsensusReactor.WaitSync() {
+		return errors.New("catching up")
+	}
+	if env.Config.HealthMaxBlockAge == 0 || env.BlockStore == nil {
+		return nil
+	}
+	height := env.BlockStore.Height()
+	meta := env.BlockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return errors.New("no block committed")
+	}
+	if age := time.Since(meta.Header.Time); age > env.Config.HealthMaxBlockAge {
+		return fmt.Errorf("latest block %d is %v old", height, age.Round(time.Second))
+	}
+	return nil
+}
+
+// checkABCI checks that the application answers an echo request.
+func (env *Environment) checkABCI() error {
+	if env.ProxyAppQuery == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	if _, err := env.ProxyAppQuery.Echo(ctx, "health"); err != nil {
+		return fmt.Errorf("application unreachable: %w", err)
+	}
+	return nil
+}
+
+// checkPrivValidator checks that the private validator, possibly a remote
+// signer, returns its public key.
+func (env *Environment) checkPrivValidator() error {
+	if env.PrivValidator == nil {
+		return nil
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := env.PrivValidator.GetPubKey()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("private validator unreachable: %w", err)
+		}
+		return nil
+	case <-time.After(healthCheckTimeout):
+		return fmt.Errorf("private validator unreachable: timed out after %v", healthCheckTimeout)
+	}
+}
+
+// checkDB checks that a file can be written to the directory of the
+// databases.
+func (env *Environment) checkDB() error {
+	if env.DBDir == "" {
+		return nil
+	}
+	f, err := os.CreateTemp(env.DBDir, ".health-*")
+	if err != nil {
+		return fmt.Errorf("database directory not writable: %w", err)
+	}
+	name := f.Name()
+	_, err = f.Write([]byte("health"))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if removeErr := os.Remove(name); err == nil {
+		err = removeErr
+	}
+	if err != nil {
+		return fmt.Errorf("database directory not writable: %w", err)
+	}
+	return nil
+}
+
+// checkPeers checks that the node has at least the configured minimum number
+// of peers.
+func (env *Environment) checkPeers() error {
+	if env.Config.HealthMinPeers == 0 || env.P2PPeers == nil {
+		return nil
+	}
+	if n := env.P2PPeers.Peers().Size(); n < env.Config.HealthMinPeers {
+		return fmt.Errorf("%d peers, expected at least %d", n, env.Config.HealthMinPeers)
+	}
+	return nil
 }
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/state/mocks"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestHealth(t *testing.T) {
+	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1,
+		func(n int, sw *p2p.Switch) *p2p.Switch { return sw })
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(10))
+	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{
+		Header: types.Header{Height: 10, Time: time.Now().Add(-time.Hour)},
+	})
+
+	env := &Environment{}
+	env.Logger = log.TestingLogger()
+	env.P2PPeers = sw
+	env.BlockStore = blockStore
+	env.PrivValidator = types.NewMockPV()
+	env.DBDir = t.TempDir()
+	env.Config = *cfg.DefaultRPCConfig()
+
+	res, err := env.Health(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.True(t, res.Ready)
+	assert.Equal(t, []ctypes.HealthCheck{
+		{Name: "consensus", Healthy: true},
+		{Name: "abci", Healthy: true},
+		{Name: "privval", Healthy: true},
+		{Name: "db", Healthy: true},
+		{Name: "peers", Healthy: true},
+	}, res.Checks)
+
+	env.Config.HealthMinPeers = 1
+	env.Config.HealthMaxBlockAge = time.Minute
+	env.DBDir = filepath.Join(env.DBDir, "missing")
+
+	res, err = env.Health(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.False(t, res.Ready)
+	healthy := make(map[string]bool)
+	for _, check := range res.Checks {
+		healthy[check.Name] = check.Healthy
+		if !check.Healthy {
+			assert.NotEmpty(t, check.Message, check.Name)
+		}
+	}
+	assert.Equal(t, map[string]bool{
+		"consensus": false,
+		"abci":      true,
+		"privval":   true,
+		"db":        false,
+		"peers":     false,
+	}, healthy)
+}
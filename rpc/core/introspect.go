@@ -0,0 +1,114 @@
+package core
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+const modulePath = "github.com/cometbft/cometbft/"
+
+// UnsafeIntrospect returns the depths of the internal queues of the node and
+// the numbers of goroutines by subsystem, to diagnose deadlocks and
+// backpressure. The send queues of the channels of the peers include the
+// broadcast queues of the mempool and of the consensus. Counting the
+// goroutines stops the world while their stacks are dumped.
+func (env *Environment) UnsafeIntrospect(*rpctypes.Context) (*ctypes.ResultIntrospect, error) {
+	reactorNames := make(map[byte]string)
+	for name, reactor := range env.P2PPeers.Reactors() {
+		for _, chDesc := range reactor.GetChannels() {
+			reactorNames[chDesc.ID] = name
+		}
+	}
+
+	peersList := env.P2PPeers.Peers().List()
+	peers := make([]ctypes.PeerQueues, 0, len(peersList))
+	for _, peer := range peersList {
+		status := peer.Status()
+		channels := make([]ctypes.ChannelQueue, len(status.Channels))
+		for i, ch := range status.Channels {
+			channels[i] = ctypes.ChannelQueue{
+				Reactor:    reactorNames[ch.ID],
+				ChannelID:  ch.ID,
+				Size:       ch.SendQueueSize,
+				Capacity:   ch.SendQueueCapacity,
+				RemoteBusy: ch.RemoteBusy,
+			}
+		}
+		peers = append(peers, ctypes.PeerQueues{NodeID: peer.ID(), Channels: channels})
+	}
+
+	var consensusQueues []ctypes.QueueStatus
+	if env.ConsensusState != nil {
+		for _, q := range env.ConsensusState.GetMsgQueues() {
+			consensusQueues = append(consensusQueues, ctypes.QueueStatus{
+				Name:     q.Name,
+				Size:     q.Size,
+				Capacity: q.Capacity,
+			})
+		}
+	}
+
+	total, bySubsystem := countGoroutines(goroutineStacks())
+	return &ctypes.ResultIntrospect{
+		Goroutines:            total,
+		GoroutinesBySubsystem: bySubsystem,
+		ConsensusQueues:       consensusQueues,
+		Peers:                 peers,
+	}, nil
+}
+
+// goroutineStacks returns the stacks of all the goroutines, as formatted by
+// runtime.Stack.
+func goroutineStacks() []byte {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// countGoroutines returns the number of goroutines in the dump of their
+// stacks, and their numbers by the package of the function that started them.
+// The main goroutine, started by none, is counted as "main".
+func countGoroutines(stacks []byte) (int, map[string]int) {
+	total := 0
+	bySubsystem := make(map[string]int)
+	for _, stack := range bytes.Split(bytes.TrimSpace(stacks), []byte("\n\n")) {
+		if !bytes.HasPrefix(stack, []byte("goroutine ")) {
+			continue
+		}
+		total++
+		subsystem := "main"
+		for _, line := range strings.Split(string(stack), "\n") {
+			if fn, ok := strings.CutPrefix(line, "created by "); ok {
+				subsystem = funcSubsystem(strings.Fields(fn)[0])
+				break
+			}
+		}
+		bySubsystem[subsystem]++
+	}
+	return total, bySubsystem
+}
+
+// funcSubsystem returns the package of the function, relative to the module
+// for the packages of CometBFT, and "other" for the rest.
+func funcSubsystem(fn string) string {
+	pkg, ok := strings.CutPrefix(fn, modulePath)
+	if !ok {
+		return "other"
+	}
+	// The package path ends at the first dot after the last slash, before the
+	// receiver or the function name.
+	slash := strings.LastIndex(pkg, "/") + 1
+	if dot := strings.Index(pkg[slash:], "."); dot >= 0 {
+		pkg = pkg[:slash+dot]
+	}
+	return strings.TrimPrefix(pkg, "internal/")
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountGoroutines(t *testing.T) {
+	stacks := `goroutine 1 [running]:
+main.main()
+	/app/main.go:10 +0x1d
+
+goroutine 7 [select]:
+github.com/cometbft/cometbft/p2p/conn.(*MConnection).sendRoutine(0xc000132000)
+	/app/p2p/conn/connection.go:480 +0x2b
+created by github.com/cometbft/cometbft/p2p/conn.(*MConnection).OnStart in goroutine 1
+	/app/p2p/conn/connection.go:210 +0x1f6
+
+goroutine 8 [chan receive]:
+github.com/cometbft/cometbft/internal/consensus.(*State).receiveRoutine(0xc000144000, 0x0)
+	/app/internal/consensus/state.go:800 +0x3c
+created by github.com/cometbft/cometbft/internal/consensus.(*State).OnStart in goroutine 1
+	/app/internal/consensus/state.go:397 +0x1a5
+
+goroutine 9 [IO wait]:
+net/http.(*conn).serve(0xc000150000)
+	/usr/local/go/src/net/http/server.go:2009 +0x5f4
+created by net/http.(*Server).Serve in goroutine 1
+	/usr/local/go/src/net/http/server.go:3086 +0x5cb
+
+goroutine 10 [select]:
+github.com/cometbft/cometbft/p2p/conn.(*MConnection).recvRoutine(0xc000132000)
+	/app/p2p/conn/connection.go:600 +0x2b
+created by github.com/cometbft/cometbft/p2p/conn.(*MConnection).OnStart in goroutine 1
+	/app/p2p/conn/connection.go:211 +0x236
+`
+	total, bySubsystem := countGoroutines([]byte(stacks))
+	assert.Equal(t, 5, total)
+	assert.Equal(t, map[string]int{
+		"main":      1,
+		"p2p/conn":  2,
+		"consensus": 1,
+		"other":     1,
+	}, bySubsystem)
+
+	total, bySubsystem = countGoroutines(goroutineStacks())
+	assert.Positive(t, total)
+	assert.Equal(t, 1, bySubsystem["main"])
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"fmt"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// KeyRotation gets the current public key of the node's private validator and,
+// if a key rotation is scheduled, the next public key and the first height it
+// signs.
+// More: https://docs.cometbft.com/main/rpc/#/Info/key_rotation
+func (env *Environment) KeyRotation(*rpctypes.Context) (*ctypes.ResultKeyRotation, error) {
+	rotator, err := env.keyRotator()
+	if err != nil {
+		return nil, err
+	}
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := rotator.GetPubKeyAt(state.LastBlockHeight + 1)
+	if err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+	nextPubKey, height := rotator.NextPubKey()
+	if nextPubKey != nil && height <= state.LastBlockHeight+1 {
+		// The next key signs already.
+		nextPubKey, height = nil, 0
+	}
+	return &ctypes.ResultKeyRotation{PubKey: pubKey, NextPubKey: nextPubKey, Height: height}, nil
+}
+
+// UnsafeScheduleKeyRotation generates the next key of the node's private
+// validator, which signs from the given height on. The application must
+// replace the current public key with the next one in the validator set by
+// then: the validator updates returned for a block take effect two heights
+// later.
+func (env *Environment) UnsafeScheduleKeyRotation(_ *rpctypes.Context, height int64) (*ctypes.ResultKeyRotation, error) {
+	rotator, err := env.keyRotator()
+	if err != nil {
+		return nil, err
+	}
+	if height <= 0 {
+		return nil, ErrInvalidHeight{Height: height}
+	}
+	nextPubKey, err := rotator.ScheduleKeyRotation(height)
+	if err != nil {
+		return nil, fmt.Errorf("can't schedule key rotation: %w", err)
+	}
+	pubKey, err := env.PrivValidator.GetPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+	return &ctypes.ResultKeyRotation{PubKey: pubKey, NextPubKey: nextPubKey, Height: height}, nil
+}
+
+func (env *Environment) keyRotator() (types.KeyRotator, error) {
+	if env.PrivValidator == nil {
+		return nil, ErrNoPrivValidator
+	}
+	rotator, ok := env.PrivValidator.(types.KeyRotator)
+	if !ok {
+		return nil, ErrNoKeyRotation
+	}
+	return rotator, nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	mempl "github.com/cometbft/cometbft/mempool"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	"github.com/cometbft/cometbft/types"
@@ -14,6 +15,36 @@ import (
 
 var ErrEndpointClosedCatchingUp = errors.New("endpoint is closed while node is catching up")
 
+// txError returns the TxError describing why a transaction could not be
+// added to the mempool, or err itself if unknown.
+func txError(err error) error {
+	txErr := ctypes.NewTxError(err)
+	var (
+		errTooLarge mempl.ErrTxTooLarge
+		errFull     mempl.ErrMempoolIsFull
+		errPreCheck mempl.ErrPreCheck
+		errAppConn  mempl.ErrAppConnMempool
+		errAsync    mempl.ErrCheckTxAsync
+	)
+	switch {
+	case errors.Is(err, mempl.ErrTxInCache):
+		txErr.Code = ctypes.CodeTxInCache
+	case errors.As(err, &errTooLarge):
+		txErr.Code = ctypes.CodeTxTooLarge
+	case errors.As(err, &errFull):
+		txErr.Code, txErr.Retryable = ctypes.CodeMempoolFull, true
+	case errors.As(err, &errPreCheck):
+		txErr.Code = ctypes.CodePreCheck
+	case errors.As(err, &errAppConn), errors.As(err, &errAsync):
+		txErr.Code, txErr.Retryable = ctypes.CodeAppConn, true
+	case errors.Is(err, ErrEndpointClosedCatchingUp):
+		txErr.Code, txErr.Retryable = ctypes.CodeCatchingUp, true
+	default:
+		return err
+	}
+	return txErr
+}
+
 //-----------------------------------------------------------------------------
 // NOTE: tx should be signed, but this is only checked at the app level (not by CometBFT!)
 
@@ -22,11 +53,11 @@ var ErrEndpointClosedCatchingUp = errors.New("endpoint is closed while node is c
 // More: https://docs.cometbft.com/main/rpc/#/Tx/broadcast_tx_async
 func (env *Environment) BroadcastTxAsync(_ *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	if env.MempoolReactor.WaitSync() {
-		return nil, ErrEndpointClosedCatchingUp
+		return nil, txError(ErrEndpointClosedCatchingUp)
 	}
 	_, err := env.Mempool.CheckTx(tx)
 	if err != nil {
-		return nil, err
+		return nil, txError(err)
 	}
 	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
 }
@@ -36,13 +67,13 @@ func (env *Environment) BroadcastTxAsync(_ *rpctypes.Context, tx types.Tx) (*cty
 // More: https://docs.cometbft.com/main/rpc/#/Tx/broadcast_tx_sync
 func (env *Environment) BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	if env.MempoolReactor.WaitSync() {
-		return nil, ErrEndpointClosedCatchingUp
+		return nil, txError(ErrEndpointClosedCatchingUp)
 	}
 
 	resCh := make(chan *abci.CheckTxResponse, 1)
 	reqRes, err := env.Mempool.CheckTx(tx)
 	if err != nil {
-		return nil, err
+		return nil, txError(err)
 	}
 	reqRes.SetCallback(func(res *abci.Response) {
 		select {
@@ -67,16 +98,66 @@ func (env *Environment) BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ct
 // BroadcastTxCommit returns with the responses from CheckTx and ExecTxResult.
 // More: https://docs.cometbft.com/main/rpc/#/Tx/broadcast_tx_commit
 func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	res, _, err := env.broadcastTxCommit(ctx, tx, env.Config.TimeoutBroadcastTxCommit)
+	return res, err
+}
+
+// BroadcastTxCommitProof returns with the responses from CheckTx and
+// ExecTxResult, and the Merkle proof of the inclusion of the transaction in
+// the block. The node waits for the transaction to be committed for at most
+// timeoutMs milliseconds, if specified, and at most
+// timeout_broadcast_tx_commit.
+// More: https://docs.cometbft.com/main/rpc/#/Tx/broadcast_tx_commit_proof
+func (env *Environment) BroadcastTxCommitProof(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+	timeoutMs *int,
+) (*ctypes.ResultBroadcastTxCommitProof, error) {
+	timeout := env.Config.TimeoutBroadcastTxCommit
+	if timeoutMs != nil {
+		if *timeoutMs <= 0 {
+			return nil, fmt.Errorf("timeout_ms must be positive, got %d", *timeoutMs)
+		}
+		timeout = min(timeout, time.Duration(*timeoutMs)*time.Millisecond)
+	}
+
+	res, index, err := env.broadcastTxCommit(ctx, tx, timeout)
+	if res == nil {
+		return nil, err
+	}
+	result := &ctypes.ResultBroadcastTxCommitProof{ResultBroadcastTxCommit: *res}
+	if err != nil || res.Height == 0 {
+		// the transaction was not committed
+		return result, err
+	}
+
+	block, _ := env.BlockStore.LoadBlock(res.Height)
+	if block == nil {
+		return result, fmt.Errorf("block %d including the transaction not found", res.Height)
+	}
+	result.Index = index
+	result.Proof = block.Data.Txs.Proof(int(index))
+	return result, nil
+}
+
+// broadcastTxCommit broadcasts tx and waits for it to be committed for at
+// most timeout. It returns the index of the transaction in the block along
+// with the result.
+func (env *Environment) broadcastTxCommit(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*ctypes.ResultBroadcastTxCommit, uint32, error) {
 	if env.MempoolReactor.WaitSync() {
-		return nil, ErrEndpointClosedCatchingUp
+		return nil, 0, txError(ErrEndpointClosedCatchingUp)
 	}
 
 	subscriber := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
-		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+		return nil, 0, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
 	} else if env.EventBus.NumClientSubscriptions(subscriber) >= env.Config.MaxSubscriptionsPerClient {
-		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+		return nil, 0, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
 	}
 
 	// Subscribe to tx being committed in block.
@@ -87,7 +168,7 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 	if err != nil {
 		err = fmt.Errorf("failed to subscribe to tx: %w", err)
 		env.Logger.Error("Error on broadcast_tx_commit", "err", err)
-		return nil, err
+		return nil, 0, err
 	}
 	defer func() {
 		if err := env.EventBus.Unsubscribe(context.Background(), subscriber, q); err != nil {
@@ -100,7 +181,7 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 	reqRes, err := env.Mempool.CheckTx(tx)
 	if err != nil {
 		env.Logger.Error("Error on broadcastTxCommit", "err", err)
-		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
+		return nil, 0, txError(err)
 	}
 	reqRes.SetCallback(func(res *abci.Response) {
 		select {
@@ -110,14 +191,14 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 	})
 	select {
 	case <-ctx.Context().Done():
-		return nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Context().Err())
+		return nil, 0, fmt.Errorf("broadcast confirmation not received: %w", ctx.Context().Err())
 	case checkTxRes := <-checkTxResCh:
 		if checkTxRes.Code != abci.CodeTypeOK {
 			return &ctypes.ResultBroadcastTxCommit{
 				CheckTx:  *checkTxRes,
 				TxResult: abci.ExecTxResult{},
 				Hash:     tx.Hash(),
-			}, nil
+			}, 0, nil
 		}
 
 		// Wait for the tx to be included in a block or timeout.
@@ -129,7 +210,7 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 				TxResult: txResultEvent.Result,
 				Hash:     tx.Hash(),
 				Height:   txResultEvent.Height,
-			}, nil
+			}, txResultEvent.Index, nil
 		case <-txSub.Canceled():
 			var reason string
 			if txSub.Err() == nil {
@@ -143,32 +224,50 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 				CheckTx:  *checkTxRes,
 				TxResult: abci.ExecTxResult{},
 				Hash:     tx.Hash(),
-			}, err
-		case <-time.After(env.Config.TimeoutBroadcastTxCommit):
+			}, 0, err
+		case <-time.After(timeout):
 			err = errors.New("timed out waiting for tx to be included in a block")
 			env.Logger.Error("Error on broadcastTxCommit", "err", err)
 			return &ctypes.ResultBroadcastTxCommit{
 				CheckTx:  *checkTxRes,
 				TxResult: abci.ExecTxResult{},
 				Hash:     tx.Hash(),
-			}, err
+			}, 0, err
 		}
 	}
 }
 
 // UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
-// including their number.
+// including their number. The transactions can be filtered by sender and
+// ordered by gas price, if the application reports them (see
+// mempool.TxQuery). The next transactions are returned when passing the
+// cursor of the result, if not empty.
 // More: https://docs.cometbft.com/main/rpc/#/Info/unconfirmed_txs
-func (env *Environment) UnconfirmedTxs(_ *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfirmedTxs, error) {
+func (env *Environment) UnconfirmedTxs(
+	_ *rpctypes.Context,
+	limitPtr *int,
+	sender string,
+	orderBy string,
+	cursor string,
+) (*ctypes.ResultUnconfirmedTxs, error) {
 	// reuse per_page validator
 	limit := env.validatePerPage(limitPtr)
 
-	txs := env.Mempool.ReapMaxTxs(limit)
+	txs, nextCursor, err := env.Mempool.QueryTxs(mempl.TxQuery{
+		Sender:  sender,
+		OrderBy: mempl.TxOrder(orderBy),
+		Cursor:  cursor,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return &ctypes.ResultUnconfirmedTxs{
 		Count:      len(txs),
 		Total:      env.Mempool.Size(),
 		TotalBytes: env.Mempool.SizeBytes(),
 		Txs:        txs,
+		NextCursor: nextCursor,
 	}, nil
 }
 
@@ -185,10 +284,22 @@ func (env *Environment) NumUnconfirmedTxs(*rpctypes.Context) (*ctypes.ResultUnco
 // CheckTx checks the transaction without executing it. The transaction won't
 // be added to the mempool either.
 // More: https://docs.cometbft.com/main/rpc/#/Tx/check_tx
-func (env *Environment) CheckTx(_ *rpctypes.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
-	res, err := env.ProxyAppMempool.CheckTx(context.TODO(), &abci.CheckTxRequest{Tx: tx, Type: abci.CHECK_TX_TYPE_CHECK})
+func (env *Environment) CheckTx(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
+	res, err := env.ProxyAppMempool.CheckTx(ctx.Context(), &abci.CheckTxRequest{Tx: tx, Type: abci.CHECK_TX_TYPE_CHECK})
 	if err != nil {
 		return nil, err
 	}
 	return &ctypes.ResultCheckTx{CheckTxResponse: *res}, nil
 }
+
+// SimulateTx runs the transaction in simulate mode, returning its gas
+// estimates and events. The transaction is not added to the mempool, and the
+// application discards the changes to its state.
+// More: https://docs.cometbft.com/main/rpc/#/Tx/simulate_tx
+func (env *Environment) SimulateTx(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultSimulateTx, error) {
+	res, err := env.ProxyAppMempool.CheckTx(ctx.Context(), &abci.CheckTxRequest{Tx: tx, Type: abci.CHECK_TX_TYPE_SIMULATE})
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultSimulateTx{CheckTxResponse: *res}, nil
+}
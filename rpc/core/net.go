@@ -13,6 +13,13 @@ import (
 // NetInfo returns network info.
 // More: https://docs.cometbft.com/main/rpc/#/Info/net_info
 func (env *Environment) NetInfo(*rpctypes.Context) (*ctypes.ResultNetInfo, error) {
+	reactorNames := make(map[byte]string)
+	for name, reactor := range env.P2PPeers.Reactors() {
+		for _, chDesc := range reactor.GetChannels() {
+			reactorNames[chDesc.ID] = name
+		}
+	}
+
 	peersList := env.P2PPeers.Peers().List()
 	peers := make([]ctypes.Peer, 0, len(peersList))
 	for _, peer := range peersList {
@@ -25,6 +32,7 @@ func (env *Environment) NetInfo(*rpctypes.Context) (*ctypes.ResultNetInfo, error
 			IsOutbound:       peer.IsOutbound(),
 			ConnectionStatus: peer.Status(),
 			RemoteIP:         peer.RemoteIP().String(),
+			Messages:         peerMessageStats(peer, reactorNames),
 		})
 	}
 	// TODO: Should we include PersistentPeers and Seeds in here?
@@ -38,6 +46,29 @@ func (env *Environment) NetInfo(*rpctypes.Context) (*ctypes.ResultNetInfo, error
 	}, nil
 }
 
+// peerMessageStats returns the messages exchanged with the peer, by reactor
+// and message type, if the peer counts them.
+func peerMessageStats(peer p2p.Peer, reactorNames map[byte]string) []ctypes.PeerMessageStats {
+	counter, ok := peer.(interface{ MessageStats() []p2p.MessageStats })
+	if !ok {
+		return nil
+	}
+	stats := counter.MessageStats()
+	messages := make([]ctypes.PeerMessageStats, len(stats))
+	for i, s := range stats {
+		messages[i] = ctypes.PeerMessageStats{
+			Reactor:       reactorNames[s.ChannelID],
+			ChannelID:     s.ChannelID,
+			MessageType:   s.MessageType,
+			MsgsSent:      s.MsgsSent,
+			BytesSent:     s.BytesSent,
+			MsgsReceived:  s.MsgsReceived,
+			BytesReceived: s.BytesReceived,
+		}
+	}
+	return messages
+}
+
 // UnsafeDialSeeds dials the given seeds (comma-separated id@IP:PORT).
 func (env *Environment) UnsafeDialSeeds(_ *rpctypes.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
 	if len(seeds) == 0 {
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"fmt"
+
+	sm "github.com/cometbft/cometbft/internal/state"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// RetainHeights gets the heights from which the application, the data
+// companion, the operator, the snapshots and the indexer retain the blocks.
+// The blocks are pruned up to the lowest of them.
+// More: https://docs.cometbft.com/main/rpc/#/Info/retain_heights
+func (env *Environment) RetainHeights(*rpctypes.Context) (*ctypes.ResultRetainHeights, error) {
+	if env.Pruner == nil {
+		return nil, ErrNoPruner
+	}
+	heights, err := env.Pruner.BlockRetainHeights()
+	if err != nil {
+		return nil, err
+	}
+	res := &ctypes.ResultRetainHeights{
+		RetainHeight:  sm.MinBlockRetainHeight(heights),
+		Base:          env.BlockStore.Base(),
+		RetainHeights: make([]ctypes.RetainHeight, 0, len(heights)),
+	}
+	for _, h := range heights {
+		res.RetainHeights = append(res.RetainHeights, ctypes.RetainHeight{Source: h.Source, Height: h.Height})
+	}
+	return res, nil
+}
+
+// UnsafeSetRetainHeight sets the retain height of the operator, below which
+// the blocks are not pruned whatever the other retain heights. The height
+// must be within the block store; 0 removes it.
+func (env *Environment) UnsafeSetRetainHeight(ctx *rpctypes.Context, height int64) (*ctypes.ResultRetainHeights, error) {
+	if env.Pruner == nil {
+		return nil, ErrNoPruner
+	}
+	if err := env.Pruner.SetOperatorRetainHeight(height); err != nil {
+		return nil, fmt.Errorf("can't set retain height: %w", err)
+	}
+	return env.RetainHeights(ctx)
+}
@@ -10,54 +10,93 @@ type RoutesMap map[string]*rpc.RPCFunc
 
 // Routes is a map of available routes.
 func (env *Environment) GetRoutes() RoutesMap {
-	return RoutesMap{
+	routes := RoutesMap{
 		// subscribe/unsubscribe are reserved for websocket events.
 		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query"),
 		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query"),
 		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, ""),
 
 		// info AP
-		"health":               rpc.NewRPCFunc(env.Health, ""),
-		"status":               rpc.NewRPCFunc(env.Status, ""),
-		"net_info":             rpc.NewRPCFunc(env.NetInfo, ""),
-		"blockchain":           rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
-		"genesis":              rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable()),
-		"genesis_chunked":      rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable()),
-		"block":                rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height")),
-		"block_by_hash":        rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable()),
-		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height", rpc.Cacheable("height")),
-		"commit":               rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height")),
-		"header":               rpc.NewRPCFunc(env.Header, "height", rpc.Cacheable("height")),
-		"header_by_hash":       rpc.NewRPCFunc(env.HeaderByHash, "hash", rpc.Cacheable()),
-		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx"),
-		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", rpc.Cacheable()),
-		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by"),
-		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
-		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", rpc.Cacheable("height")),
-		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, ""),
-		"consensus_state":      rpc.NewRPCFunc(env.GetConsensusState, ""),
-		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height")),
-		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit"),
-		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, ""),
+		"health":                 rpc.NewRPCFunc(env.Health, ""),
+		"status":                 rpc.NewRPCFunc(env.Status, ""),
+		"net_info":               rpc.NewRPCFunc(env.NetInfo, ""),
+		"blockchain":             rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
+		"light_blocks":           rpc.NewRPCFunc(env.LightBlocks, "from,to"),
+		"genesis":                rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable()),
+		"genesis_chunked":        rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable()),
+		"snapshots":              rpc.NewRPCFunc(env.Snapshots, ""),
+		"snapshot_chunk":         rpc.NewRPCFunc(env.SnapshotChunk, "height,format,index,offset", rpc.Cacheable()),
+		"block":                  rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height")),
+		"block_by_hash":          rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable()),
+		"block_results":          rpc.NewRPCFunc(env.BlockResults, "height", rpc.Cacheable("height")),
+		"commit":                 rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height")),
+		"header":                 rpc.NewRPCFunc(env.Header, "height", rpc.Cacheable("height")),
+		"header_by_hash":         rpc.NewRPCFunc(env.HeaderByHash, "hash", rpc.Cacheable()),
+		"header_chain_proof":     rpc.NewRPCFunc(env.HeaderChainProof, "height,trusted_height", rpc.Cacheable("trusted_height")),
+		"key_rotation":           rpc.NewRPCFunc(env.KeyRotation, ""),
+		"check_tx":               rpc.NewRPCFunc(env.CheckTx, "tx"),
+		"simulate_tx":            rpc.NewRPCFunc(env.SimulateTx, "tx"),
+		"tx":                     rpc.NewRPCFunc(env.Tx, "hash,prove", rpc.Cacheable()),
+		"tx_result_proof":        rpc.NewRPCFunc(env.TxResultProof, "hash,height", rpc.Cacheable("height")),
+		"tx_search":              rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by"),
+		"block_search":           rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
+		"validators":             rpc.NewRPCFunc(env.Validators, "height,page,per_page", rpc.Cacheable("height")),
+		"validator_signing_info": rpc.NewRPCFunc(env.ValidatorSigningInfo, "address"),
+		"storage_info":           rpc.NewRPCFunc(env.StorageInfo, ""),
+		"retain_heights":         rpc.NewRPCFunc(env.RetainHeights, ""),
+		"dump_consensus_state":   rpc.NewRPCFunc(env.DumpConsensusState, ""),
+		"consensus_state":        rpc.NewRPCFunc(env.GetConsensusState, ""),
+		"consensus_params":       rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height")),
+		"unconfirmed_txs":        rpc.NewRPCFunc(env.UnconfirmedTxs, "limit,sender,order_by,cursor"),
+		"num_unconfirmed_txs":    rpc.NewRPCFunc(env.NumUnconfirmedTxs, ""),
 
 		// tx broadcast API
-		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx"),
-		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx"),
-		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx"),
+		"broadcast_tx_commit":       rpc.NewRPCFunc(env.BroadcastTxCommit, "tx"),
+		"broadcast_tx_commit_proof": rpc.NewRPCFunc(env.BroadcastTxCommitProof, "tx,timeout_ms"),
+		"broadcast_tx_sync":         rpc.NewRPCFunc(env.BroadcastTxSync, "tx"),
+		"broadcast_tx_async":        rpc.NewRPCFunc(env.BroadcastTxAsync, "tx"),
 
 		// abci API
 		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove"),
 		"abci_info":  rpc.NewRPCFunc(env.ABCIInfo, "", rpc.Cacheable()),
 
 		// evidence API
-		"broadcast_evidence": rpc.NewRPCFunc(env.BroadcastEvidence, "evidence"),
+		"broadcast_evidence":      rpc.NewRPCFunc(env.BroadcastEvidence, "evidence"),
+		"broadcast_attack_report": rpc.NewRPCFunc(env.BroadcastAttackReport, "report"),
+	}
+	if env.Config.AttestationEnabled {
+		routes["attestation"] = rpc.NewRPCFunc(env.Attestation, "height", rpc.Cacheable("height"))
 	}
+	return routes
 }
 
 // AddUnsafeRoutes adds unsafe routes.
 func (env *Environment) AddUnsafeRoutes(routes RoutesMap) {
-	// control API
-	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds")
-	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private")
-	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "")
+	for name, fn := range env.GetAdminRoutes() {
+		routes[name] = fn
+	}
+}
+
+// GetAdminRoutes returns the unsafe routes, which are meant to be served either
+// alongside the public routes (see AddUnsafeRoutes) or on the separate admin
+// listener.
+func (env *Environment) GetAdminRoutes() RoutesMap {
+	return RoutesMap{
+		// control API
+		"dial_seeds":           rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds"),
+		"dial_peers":           rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private"),
+		"unsafe_flush_mempool": rpc.NewRPCFunc(env.UnsafeFlushMempool, ""),
+
+		// debug API
+		"introspect": rpc.NewRPCFunc(env.UnsafeIntrospect, ""),
+
+		// private validator API
+		"schedule_key_rotation": rpc.NewRPCFunc(env.UnsafeScheduleKeyRotation, "height"),
+
+		// upgrade API
+		"schedule_halt": rpc.NewRPCFunc(env.UnsafeScheduleHalt, "height"),
+
+		// pruning API
+		"set_retain_height": rpc.NewRPCFunc(env.UnsafeSetRetainHeight, "height"),
+	}
 }
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"fmt"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// ValidatorSigningInfo gets the number of blocks the validator with the given
+// address missed over the window of the last heights, i.e. the commits without
+// its vote, and the last height it signed.
+// More: https://docs.cometbft.com/main/rpc/#/Info/validator_signing_info
+func (env *Environment) ValidatorSigningInfo(
+	_ *rpctypes.Context,
+	address []byte,
+) (*ctypes.ResultValidatorSigningInfo, error) {
+	if env.SigningInfo == nil {
+		return nil, ErrSigningInfoDisabled
+	}
+	info, err := env.SigningInfo.SigningInfo(types.Address(address))
+	if err != nil {
+		return nil, fmt.Errorf("%X: %w of the last %d heights", address, err, env.SigningInfo.Window())
+	}
+	return &ctypes.ResultValidatorSigningInfo{
+		Address:          info.Address,
+		Window:           env.SigningInfo.Window(),
+		StartHeight:      info.StartHeight,
+		EndHeight:        info.EndHeight,
+		ValidatorHeights: info.ValidatorHeights,
+		MissedBlocks:     info.MissedBlocks,
+		LastSignedHeight: info.LastSignedHeight,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+const (
+	// MaxSnapshots is the maximum number of snapshots returned by Snapshots.
+	MaxSnapshots = 10
+	// SnapshotChunkPartSize is the maximum size of the part of a snapshot
+	// chunk returned by SnapshotChunk.
+	SnapshotChunkPartSize = 1024 * 1024
+)
+
+// Snapshots gets the most recent snapshots the application can serve for
+// state sync, highest first, so that nodes which can't reach any peers over
+// p2p can still state sync over RPC.
+// More: https://docs.cometbft.com/main/rpc/#/Info/snapshots
+func (env *Environment) Snapshots(ctx *rpctypes.Context) (*ctypes.ResultSnapshots, error) {
+	if env.ProxyAppSnapshot == nil {
+		return nil, ErrSnapshotsDisabled
+	}
+
+	resp, err := env.ProxyAppSnapshot.ListSnapshots(ctx.Context(), &abci.ListSnapshotsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := resp.Snapshots
+	sort.Slice(snapshots, func(i, j int) bool {
+		a, b := snapshots[i], snapshots[j]
+		return a.Height > b.Height || (a.Height == b.Height && a.Format > b.Format)
+	})
+	if len(snapshots) > MaxSnapshots {
+		snapshots = snapshots[:MaxSnapshots]
+	}
+
+	return &ctypes.ResultSnapshots{Snapshots: snapshots}, nil
+}
+
+// SnapshotChunk gets the part of a snapshot chunk starting at offset. At most
+// SnapshotChunkPartSize bytes are returned, along with the size of the whole
+// chunk, so that clients can download chunks in parts and resume interrupted
+// downloads.
+// More: https://docs.cometbft.com/main/rpc/#/Info/snapshot_chunk
+func (env *Environment) SnapshotChunk(
+	ctx *rpctypes.Context,
+	height uint64,
+	format uint32,
+	index uint32,
+	offset int,
+) (*ctypes.ResultSnapshotChunk, error) {
+	if env.ProxyAppSnapshot == nil {
+		return nil, ErrSnapshotsDisabled
+	}
+
+	resp, err := env.ProxyAppSnapshot.LoadSnapshotChunk(ctx.Context(), &abci.LoadSnapshotChunkRequest{
+		Height: height,
+		Format: format,
+		Chunk:  index,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Chunk == nil {
+		return nil, ErrSnapshotChunkNotFound{Height: height, Format: format, Index: index}
+	}
+	if offset < 0 || offset > len(resp.Chunk) {
+		return nil, fmt.Errorf("offset %d is out of range [0, %d]", offset, len(resp.Chunk))
+	}
+
+	end := offset + SnapshotChunkPartSize
+	if end > len(resp.Chunk) {
+		end = len(resp.Chunk)
+	}
+
+	return &ctypes.ResultSnapshotChunk{
+		Height: height,
+		Format: format,
+		Index:  index,
+		Offset: offset,
+		Size:   len(resp.Chunk),
+		Data:   resp.Chunk[offset:end],
+	}, nil
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+	proxymocks "github.com/cometbft/cometbft/proxy/mocks"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestSnapshots(t *testing.T) {
+	conn := &proxymocks.AppConnSnapshot{}
+	snapshots := make([]*abci.Snapshot, 0, MaxSnapshots+2)
+	for h := uint64(1); h <= MaxSnapshots+2; h++ {
+		snapshots = append(snapshots, &abci.Snapshot{Height: h, Format: 1, Chunks: 1})
+	}
+	conn.On("ListSnapshots", mock.Anything, mock.Anything).Return(&abci.ListSnapshotsResponse{Snapshots: snapshots}, nil)
+	env := &Environment{ProxyAppSnapshot: conn}
+
+	res, err := env.Snapshots(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Len(t, res.Snapshots, MaxSnapshots)
+	assert.EqualValues(t, MaxSnapshots+2, res.Snapshots[0].Height)
+	assert.EqualValues(t, 3, res.Snapshots[MaxSnapshots-1].Height)
+
+	_, err = (&Environment{}).Snapshots(&rpctypes.Context{})
+	require.ErrorIs(t, err, ErrSnapshotsDisabled)
+}
+
+func TestSnapshotChunk(t *testing.T) {
+	data := cmtrand.Bytes(SnapshotChunkPartSize + 10)
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("LoadSnapshotChunk", mock.Anything, &abci.LoadSnapshotChunkRequest{Height: 1, Format: 1, Chunk: 0}).
+		Return(&abci.LoadSnapshotChunkResponse{Chunk: data}, nil)
+	conn.On("LoadSnapshotChunk", mock.Anything, &abci.LoadSnapshotChunkRequest{Height: 1, Format: 1, Chunk: 1}).
+		Return(&abci.LoadSnapshotChunkResponse{}, nil)
+	env := &Environment{ProxyAppSnapshot: conn}
+
+	res, err := env.SnapshotChunk(&rpctypes.Context{}, 1, 1, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), res.Size)
+	assert.Equal(t, data[:SnapshotChunkPartSize], res.Data)
+
+	res, err = env.SnapshotChunk(&rpctypes.Context{}, 1, 1, 0, SnapshotChunkPartSize)
+	require.NoError(t, err)
+	assert.Equal(t, SnapshotChunkPartSize, res.Offset)
+	assert.Equal(t, data[SnapshotChunkPartSize:], res.Data)
+
+	_, err = env.SnapshotChunk(&rpctypes.Context{}, 1, 1, 0, len(data)+1)
+	require.Error(t, err)
+
+	_, err = env.SnapshotChunk(&rpctypes.Context{}, 1, 1, 1, 0)
+	require.ErrorIs(t, err, ErrSnapshotChunkNotFound{Height: 1, Format: 1, Index: 1})
+}
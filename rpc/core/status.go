@@ -1,8 +1,13 @@
 package core
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	cm "github.com/cometbft/cometbft/internal/consensus"
+	cmtos "github.com/cometbft/cometbft/internal/os"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/p2p"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
@@ -10,8 +15,13 @@ import (
 	"github.com/cometbft/cometbft/types"
 )
 
+// diskUsageCacheTTL is how long the disk usage of the databases is cached, as
+// computing it walks their directories.
+const diskUsageCacheTTL = time.Minute
+
 // Status returns CometBFT status including node info, pubkey, latest block
-// hash, app hash, block height and time.
+// hash, app hash, block height and time, the progress of the sync, the peers
+// by quality and the disk usage of the databases.
 // More: https://docs.cometbft.com/main/rpc/#/Info/status
 func (env *Environment) Status(*rpctypes.Context) (*ctypes.ResultStatus, error) {
 	var (
@@ -46,13 +56,25 @@ func (env *Environment) Status(*rpctypes.Context) (*ctypes.ResultStatus, error)
 
 	// Return the very last voting power, not the voting power of this validator
 	// during the last block.
-	var votingPower int64
-	if val := env.validatorAtHeight(env.latestUncommittedHeight()); val != nil {
-		votingPower = val.VotingPower
+	var validatorInfo ctypes.ValidatorInfo
+	if env.PubKey != nil {
+		validatorInfo = ctypes.ValidatorInfo{
+			Address: env.PubKey.Address(),
+			PubKey:  env.PubKey,
+		}
+		if val := env.validatorAtHeight(env.latestUncommittedHeight()); val != nil {
+			validatorInfo.VotingPower = val.VotingPower
+		}
+	}
+
+	// The node info is absent when inspecting a stopped node.
+	var nodeInfo p2p.DefaultNodeInfo
+	if env.P2PTransport != nil {
+		nodeInfo = env.P2PTransport.NodeInfo().(p2p.DefaultNodeInfo)
 	}
 
 	result := &ctypes.ResultStatus{
-		NodeInfo: env.P2PTransport.NodeInfo().(p2p.DefaultNodeInfo),
+		NodeInfo: nodeInfo,
 		SyncInfo: ctypes.SyncInfo{
 			LatestBlockHash:     latestBlockHash,
 			LatestAppHash:       latestAppHash,
@@ -63,17 +85,128 @@ func (env *Environment) Status(*rpctypes.Context) (*ctypes.ResultStatus, error)
 			EarliestBlockHeight: earliestBlockHeight,
 			EarliestBlockTime:   time.Unix(0, earliestBlockTimeNano),
 			CatchingUp:          env.ConsensusReactor.WaitSync(),
+			StateSync:           env.stateSyncInfo(),
+			BlockSync:           env.blockSyncInfo(),
 		},
-		ValidatorInfo: ctypes.ValidatorInfo{
-			Address:     env.PubKey.Address(),
-			PubKey:      env.PubKey,
-			VotingPower: votingPower,
-		},
+		ValidatorInfo: validatorInfo,
+		Peers:         env.peerCounts(),
+		DiskUsage:     env.dbDiskUsage(),
 	}
 
 	return result, nil
 }
 
+// stateSyncInfo returns the progress of state sync, or nil if the node did not
+// state sync.
+func (env *Environment) stateSyncInfo() *ctypes.StateSyncInfo {
+	if env.StateSyncReactor == nil {
+		return nil
+	}
+	progress, ok := env.StateSyncReactor.Progress()
+	if !ok {
+		return nil
+	}
+	info := &ctypes.StateSyncInfo{
+		Stage:          progress.Stage,
+		SnapshotHeight: progress.SnapshotHeight,
+		SnapshotFormat: progress.SnapshotFormat,
+		SnapshotHash:   progress.SnapshotHash,
+		ChunksTotal:    progress.ChunksTotal,
+		ChunksFetched:  progress.ChunksFetched,
+		ChunksApplied:  progress.ChunksApplied,
+		StartTime:      progress.StartTime,
+	}
+	if !progress.ETA.IsZero() {
+		info.ETA = &progress.ETA
+	}
+	return info
+}
+
+// blockSyncInfo returns the progress of block sync, or nil if the node did not
+// block sync.
+func (env *Environment) blockSyncInfo() *ctypes.BlockSyncInfo {
+	if env.BlockSyncReactor == nil {
+		return nil
+	}
+	progress, ok := env.BlockSyncReactor.Progress()
+	if !ok {
+		return nil
+	}
+	info := &ctypes.BlockSyncInfo{
+		StartHeight:     progress.StartHeight,
+		Height:          progress.Height,
+		MaxPeerHeight:   progress.MaxPeerHeight,
+		BlocksPerSecond: progress.BlocksPerSecond,
+		StartTime:       progress.StartTime,
+	}
+	if !progress.ETA.IsZero() {
+		info.ETA = &progress.ETA
+	}
+	return info
+}
+
+// peerCounts counts the inbound and outbound peers by quality.
+func (env *Environment) peerCounts() ctypes.PeerCounts {
+	var counts ctypes.PeerCounts
+	if env.P2PPeers == nil {
+		return counts
+	}
+	for _, peer := range env.P2PPeers.Peers().List() {
+		quality := cm.PeerQualityIdle
+		if peerState, ok := peer.Get(types.PeerStateKey).(*cm.PeerState); ok {
+			quality = peerState.Quality()
+		}
+		c := &counts.Inbound
+		if peer.IsOutbound() {
+			c = &counts.Outbound
+		}
+		switch quality {
+		case cm.PeerQualityGood:
+			c.Good++
+		case cm.PeerQualityActive:
+			c.Active++
+		default:
+			c.Idle++
+		}
+	}
+	return counts
+}
+
+// dbDiskUsage returns the disk usage of each database in DBDir, cached for
+// diskUsageCacheTTL.
+func (env *Environment) dbDiskUsage() []ctypes.StoreDiskUsage {
+	if env.DBDir == "" {
+		return nil
+	}
+	env.diskUsageMtx.Lock()
+	defer env.diskUsageMtx.Unlock()
+	if !env.diskUsageTime.IsZero() && time.Since(env.diskUsageTime) < diskUsageCacheTTL {
+		return env.diskUsage
+	}
+
+	entries, err := os.ReadDir(env.DBDir)
+	if err != nil && !os.IsNotExist(err) {
+		env.Logger.Error("Failed to read the database directory", "dir", env.DBDir, "err", err)
+	}
+	usage := make([]ctypes.StoreDiskUsage, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() || filepath.Ext(entry.Name()) != ".db" {
+			continue
+		}
+		size, err := cmtos.DirSize(filepath.Join(env.DBDir, entry.Name()))
+		if err != nil {
+			env.Logger.Error("Failed to compute the disk usage of a database", "db", entry.Name(), "err", err)
+			continue
+		}
+		usage = append(usage, ctypes.StoreDiskUsage{
+			Store: strings.TrimSuffix(entry.Name(), ".db"),
+			Bytes: size,
+		})
+	}
+	env.diskUsage, env.diskUsageTime = usage, time.Now()
+	return usage
+}
+
 func (env *Environment) validatorAtHeight(h int64) *types.Validator {
 	valsWithH, err := env.StateStore.LoadValidators(h)
 	if err != nil {
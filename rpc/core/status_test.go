@@ -0,0 +1,35 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+func TestDBDiskUsage(t *testing.T) {
+	dbDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dbDir, "blockstore.db", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dbDir, "blockstore.db", "000001.ldb"), make([]byte, 100), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dbDir, "blockstore.db", "sub", "LOG"), make([]byte, 20), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dbDir, "state.db"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dbDir, "cs.wal"), 0o755))
+	env := &Environment{DBDir: dbDir, Logger: log.NewNopLogger()}
+
+	usage := env.dbDiskUsage()
+	assert.Equal(t, []ctypes.StoreDiskUsage{
+		{Store: "blockstore", Bytes: 120},
+		{Store: "state", Bytes: 0},
+	}, usage)
+
+	// The disk usage is cached.
+	require.NoError(t, os.WriteFile(filepath.Join(dbDir, "state.db", "000001.ldb"), make([]byte, 10), 0o644))
+	assert.Equal(t, usage, env.dbDiskUsage())
+
+	assert.Nil(t, (&Environment{}).dbDiskUsage())
+}
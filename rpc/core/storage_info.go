@@ -0,0 +1,39 @@
+package core
+
+import (
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// StorageInfo gets the last sample of the usage of the databases of the node:
+// their size on disk, their number of keys and their compaction statistics.
+// More: https://docs.cometbft.com/main/rpc/#/Info/storage_info
+func (env *Environment) StorageInfo(*rpctypes.Context) (*ctypes.ResultStorageInfo, error) {
+	if env.StorageSampler == nil {
+		return nil, ErrStorageInfoDisabled
+	}
+	sample, err := env.StorageSampler.Last()
+	if err != nil {
+		return nil, err
+	}
+	stores := make([]ctypes.StoreInfo, 0, len(sample.Stores))
+	for _, st := range sample.Stores {
+		stores = append(stores, ctypes.StoreInfo{
+			Store:                st.Name,
+			SizeBytes:            st.SizeBytes,
+			Keys:                 st.Keys,
+			DataBytes:            st.DataBytes,
+			CompactionTables:     st.Compaction.Tables,
+			CompactionSeconds:    st.Compaction.Seconds,
+			CompactionReadBytes:  st.Compaction.ReadBytes,
+			CompactionWriteBytes: st.Compaction.WriteBytes,
+			Stats:                st.Stats,
+		})
+	}
+	return &ctypes.ResultStorageInfo{
+		SampledAt:      sample.Time,
+		SampleDuration: sample.Duration,
+		SampleInterval: env.StorageSampler.Interval(),
+		Stores:         stores,
+	}, nil
+}
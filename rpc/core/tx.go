@@ -2,7 +2,6 @@ package core
 
 import (
 	"errors"
-	"fmt"
 	"sort"
 
 	cmtquery "github.com/cometbft/cometbft/internal/pubsub/query"
@@ -20,7 +19,7 @@ import (
 func (env *Environment) Tx(_ *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	// if index is disabled, return error
 	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
-		return nil, fmt.Errorf("transaction indexing is disabled")
+		return nil, ErrTxIndexingDisabled
 	}
 
 	r, err := env.TxIndexer.Get(hash)
@@ -29,7 +28,7 @@ func (env *Environment) Tx(_ *rpctypes.Context, hash []byte, prove bool) (*ctype
 	}
 
 	if r == nil {
-		return nil, fmt.Errorf("tx (%X) not found", hash)
+		return nil, ErrTxNotFound{Hash: hash}
 	}
 
 	var proof types.TxProof
@@ -48,6 +47,59 @@ func (env *Environment) Tx(_ *rpctypes.Context, hash []byte, prove bool) (*ctype
 	}, nil
 }
 
+// TxResultProof gets the result of a transaction, along with the proof of the
+// transaction against the header of its block and of its result against the
+// next header. If a height is provided, and the transaction is not in the
+// block at that height, it returns the proof of its absence instead.
+// Otherwise, the block of the transaction is looked up in the tx indexer.
+// More: https://docs.cometbft.com/main/rpc/#/Info/tx_result_proof
+func (env *Environment) TxResultProof(
+	_ *rpctypes.Context,
+	hash []byte,
+	heightPtr *int64,
+) (*ctypes.ResultTxResultProof, error) {
+	if heightPtr == nil {
+		if _, ok := env.TxIndexer.(*null.TxIndex); ok {
+			return nil, ErrTxIndexingDisabled
+		}
+		r, err := env.TxIndexer.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+		if r == nil {
+			return nil, ErrTxNotFound{Hash: hash}
+		}
+		heightPtr = &r.Height
+	}
+	height, err := env.getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return nil, ErrHeightNotAvailable{Height: height, LowestHeight: env.BlockStore.Base()}
+	}
+	index := block.Txs.IndexByHash(hash)
+	if index < 0 {
+		return &ctypes.ResultTxResultProof{
+			Hash:         hash,
+			Height:       height,
+			AbsenceProof: types.NewTxAbsenceProof(block.Txs),
+		}, nil
+	}
+
+	results, err := env.StateStore.LoadFinalizeBlockResponse(height)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := types.NewTxResultProof(block.Txs, results.TxResults, index)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultTxResultProof{Hash: hash, Height: height, Proof: proof}, nil
+}
+
 // TxSearch allows you to query for multiple transactions results. It returns a
 // list of transactions (maximum ?per_page entries) and the total count.
 // More: https://docs.cometbft.com/main/rpc/#/Info/tx_search
@@ -60,7 +112,7 @@ func (env *Environment) TxSearch(
 ) (*ctypes.ResultTxSearch, error) {
 	// if index is disabled, return error
 	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
-		return nil, errors.New("transaction indexing is disabled")
+		return nil, ErrTxIndexingDisabled
 	} else if len(query) > maxQueryLength {
 		return nil, errors.New("maximum query length exceeded")
 	}
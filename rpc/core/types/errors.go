@@ -0,0 +1,80 @@
+package coretypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// CodeTxError is the JSON-RPC error code of a TxError.
+const CodeTxError = -32001
+
+// CodespaceNode is the codespace of the errors raised by the node itself, as
+// opposed to the ones of the application, which sets its own codespaces in
+// its CheckTx responses.
+const CodespaceNode = "cometbft"
+
+// Codes of the errors of CodespaceNode.
+const (
+	CodeTxInCache   uint32 = 1 // the transaction was seen already
+	CodeTxTooLarge  uint32 = 2 // the transaction exceeds the maximum size
+	CodeMempoolFull uint32 = 3 // the mempool is full
+	CodePreCheck    uint32 = 4 // the transaction failed the pre-check
+	CodeAppConn     uint32 = 5 // the application could not be reached
+	CodeCatchingUp  uint32 = 6 // the node is catching up
+)
+
+// TxError describes why a broadcast transaction was not accepted. It is
+// returned as the data of the JSON-RPC error, so that the clients can tell a
+// permanent rejection from a transient failure.
+type TxError struct {
+	Codespace string `json:"codespace"`
+	Code      uint32 `json:"code"`
+	Log       string `json:"log"`
+	// Retryable hints that broadcasting the same transaction again later may
+	// succeed.
+	Retryable bool `json:"retryable"`
+
+	err error
+}
+
+func (e TxError) Unwrap() error {
+	return e.err
+}
+
+var _ rpctypes.DataError = TxError{}
+
+// NewTxError returns a TxError of CodespaceNode wrapping err. The caller sets
+// its code and whether it is retryable.
+func NewTxError(err error) TxError {
+	return TxError{Codespace: CodespaceNode, Log: err.Error(), err: err}
+}
+
+func (e TxError) Error() string {
+	return fmt.Sprintf("tx not accepted (codespace: %q, code: %d, retryable: %t): %s",
+		e.Codespace, e.Code, e.Retryable, e.Log)
+}
+
+func (TxError) RPCErrorCode() int {
+	return CodeTxError
+}
+
+func (e TxError) RPCErrorData() interface{} {
+	return e
+}
+
+// TxErrorFromRPC returns the TxError carried by an error returned by the RPC
+// client, if any.
+func TxErrorFromRPC(err error) (*TxError, bool) {
+	var rpcErr *rpctypes.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeTxError || rpcErr.DataObject == nil {
+		return nil, false
+	}
+	txErr := &TxError{}
+	if json.Unmarshal(rpcErr.DataObject, txErr) != nil {
+		return nil, false
+	}
+	return txErr, true
+}
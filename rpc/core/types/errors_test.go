@@ -0,0 +1,36 @@
+package coretypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestTxErrorFromRPC(t *testing.T) {
+	cause := errors.New("mempool is full")
+	txErr := NewTxError(cause)
+	txErr.Code, txErr.Retryable = CodeMempoolFull, true
+	require.ErrorIs(t, txErr, cause)
+
+	// Send the error over JSON-RPC and decode it as the client does.
+	bz, err := json.Marshal(rpctypes.RPCFuncError(rpctypes.JSONRPCIntID(1), txErr))
+	require.NoError(t, err)
+	resp := &rpctypes.RPCResponse{}
+	require.NoError(t, json.Unmarshal(bz, resp))
+
+	decoded, ok := TxErrorFromRPC(fmt.Errorf("broadcast: %w", resp.Error))
+	require.True(t, ok)
+	assert.Equal(t, CodespaceNode, decoded.Codespace)
+	assert.Equal(t, CodeMempoolFull, decoded.Code)
+	assert.Equal(t, "mempool is full", decoded.Log)
+	assert.True(t, decoded.Retryable)
+
+	_, ok = TxErrorFromRPC(&rpctypes.RPCError{Code: -32603, Message: "Internal error", Data: "failure"})
+	assert.False(t, ok)
+}
@@ -18,6 +18,12 @@ type ResultBlockchainInfo struct {
 	BlockMetas []*types.BlockMeta `json:"block_metas"`
 }
 
+// List of light blocks.
+type ResultLightBlocks struct {
+	LastHeight  int64               `json:"last_height"`
+	LightBlocks []*types.LightBlock `json:"light_blocks"`
+}
+
 // Genesis file.
 type ResultGenesis struct {
 	Genesis *types.GenesisDoc `json:"genesis"`
@@ -33,6 +39,22 @@ type ResultGenesisChunk struct {
 	Data        string `json:"data"`
 }
 
+// List of snapshots available for state sync.
+type ResultSnapshots struct {
+	Snapshots []*abci.Snapshot `json:"snapshots"`
+}
+
+// ResultSnapshotChunk is a part of a snapshot chunk, starting at Offset, with
+// Size being the size of the whole chunk.
+type ResultSnapshotChunk struct {
+	Height uint64 `json:"height"`
+	Format uint32 `json:"format"`
+	Index  uint32 `json:"index"`
+	Offset int    `json:"offset"`
+	Size   int    `json:"size"`
+	Data   []byte `json:"data"`
+}
+
 // Single block (with meta).
 type ResultBlock struct {
 	BlockID types.BlockID `json:"block_id"`
@@ -44,12 +66,74 @@ type ResultHeader struct {
 	Header *types.Header `json:"header"`
 }
 
+// ResultHeaderChainProof contains a header and the proof linking it to a
+// trusted header.
+type ResultHeaderChainProof struct {
+	Header types.Header           `json:"header"`
+	Proof  types.HeaderChainProof `json:"proof"`
+}
+
 // Commit and Header.
 type ResultCommit struct {
 	types.SignedHeader `json:"signed_header"`
 	CanonicalCommit    bool `json:"canonical"`
 }
 
+// ResultAttestation contains an attestation signed by the node's validator,
+// along with the public key to verify it against.
+type ResultAttestation struct {
+	Attestation types.Attestation `json:"attestation"`
+	PubKey      crypto.PubKey     `json:"pub_key"`
+}
+
+// ResultValidatorSigningInfo contains the blocks a validator missed over the
+// window of the last heights, i.e. the commits without its vote.
+type ResultValidatorSigningInfo struct {
+	Address types.Address `json:"address"`
+	// Number of last heights over which the votes are tracked.
+	Window int64 `json:"window"`
+	// First and last heights of the window.
+	StartHeight int64 `json:"start_height"`
+	EndHeight   int64 `json:"end_height"`
+	// Number of heights of the window at which the address was a validator.
+	ValidatorHeights int64 `json:"validator_heights"`
+	MissedBlocks     int64 `json:"missed_blocks"`
+	// Last height of the window whose commit has a vote of the validator, 0
+	// if none.
+	LastSignedHeight int64 `json:"last_signed_height"`
+}
+
+// ResultKeyRotation contains the current public key of the node's validator
+// and, if a key rotation is scheduled, the next one and the first height it
+// signs.
+type ResultKeyRotation struct {
+	PubKey     crypto.PubKey `json:"pub_key"`
+	NextPubKey crypto.PubKey `json:"next_pub_key,omitempty"`
+	Height     int64         `json:"height,omitempty"`
+}
+
+// ResultScheduleHalt contains the height the node halts after, or 0 if no
+// halt is scheduled.
+type ResultScheduleHalt struct {
+	HaltHeight int64 `json:"halt_height"`
+}
+
+// ResultRetainHeights contains the heights from which each source retains the
+// blocks, and the lowest of them, up to which the blocks are pruned.
+type ResultRetainHeights struct {
+	RetainHeight  int64          `json:"retain_height"`
+	Base          int64          `json:"base"`
+	RetainHeights []RetainHeight `json:"retain_heights"`
+}
+
+// RetainHeight is the height from which a source retains the blocks. It is 0
+// if the source retains all of them, for the application and the data
+// companion, or none of them, for the others.
+type RetainHeight struct {
+	Source string `json:"source"`
+	Height int64  `json:"height"`
+}
+
 // ABCI results from a block.
 type ResultBlockResults struct {
 	Height                int64                     `json:"height"`
@@ -87,6 +171,82 @@ type SyncInfo struct {
 	EarliestBlockTime   time.Time      `json:"earliest_block_time"`
 
 	CatchingUp bool `json:"catching_up"`
+
+	StateSync *StateSyncInfo `json:"state_sync,omitempty"`
+	BlockSync *BlockSyncInfo `json:"block_sync,omitempty"`
+}
+
+// Info about the progress of state sync, if the node state synced since it
+// started.
+type StateSyncInfo struct {
+	Stage          string         `json:"stage"`
+	SnapshotHeight uint64         `json:"snapshot_height"`
+	SnapshotFormat uint32         `json:"snapshot_format"`
+	SnapshotHash   bytes.HexBytes `json:"snapshot_hash"`
+	ChunksTotal    uint32         `json:"chunks_total"`
+	ChunksFetched  uint32         `json:"chunks_fetched"`
+	ChunksApplied  uint32         `json:"chunks_applied"`
+	StartTime      time.Time      `json:"start_time"`
+	ETA            *time.Time     `json:"eta,omitempty"`
+}
+
+// Info about the progress of block sync, if the node block synced since it
+// started.
+type BlockSyncInfo struct {
+	StartHeight     int64      `json:"start_height"`
+	Height          int64      `json:"height"`
+	MaxPeerHeight   int64      `json:"max_peer_height"`
+	BlocksPerSecond float64    `json:"blocks_per_second"`
+	StartTime       time.Time  `json:"start_time"`
+	ETA             *time.Time `json:"eta,omitempty"`
+}
+
+// Numbers of inbound and outbound peers, by quality.
+type PeerCounts struct {
+	Inbound  PeerQualityCounts `json:"inbound"`
+	Outbound PeerQualityCounts `json:"outbound"`
+}
+
+// Numbers of peers by their contribution to consensus: good peers sent enough
+// useful votes or block parts to be marked as good in the address book,
+// active peers sent some, idle peers none.
+type PeerQualityCounts struct {
+	Good   int `json:"good"`
+	Active int `json:"active"`
+	Idle   int `json:"idle"`
+}
+
+// Disk usage of a database.
+type StoreDiskUsage struct {
+	Store string `json:"store"`
+	Bytes int64  `json:"bytes"`
+}
+
+// Usage of the databases of the node, sampled in the background.
+type ResultStorageInfo struct {
+	// Time of the sample, and time taken to sample the databases.
+	SampledAt      time.Time     `json:"sampled_at"`
+	SampleDuration time.Duration `json:"sample_duration"`
+	// Interval between two samples.
+	SampleInterval time.Duration `json:"sample_interval"`
+	Stores         []StoreInfo   `json:"stores"`
+}
+
+// Usage of a database.
+type StoreInfo struct {
+	Store string `json:"store"`
+	// Size of the files of the database on disk.
+	SizeBytes int64 `json:"size_bytes"`
+	// Number of keys, and total size of the keys and values.
+	Keys      int64 `json:"keys"`
+	DataBytes int64 `json:"data_bytes"`
+	// Compaction statistics, zero if the backend doesn't report them.
+	CompactionTables     int64   `json:"compaction_tables"`
+	CompactionSeconds    float64 `json:"compaction_seconds"`
+	CompactionReadBytes  int64   `json:"compaction_read_bytes"`
+	CompactionWriteBytes int64   `json:"compaction_write_bytes"`
+	// Statistics of the database backend, e.g. the leveldb properties.
+	Stats map[string]string `json:"stats"`
 }
 
 // Info about the node's validator.
@@ -101,6 +261,8 @@ type ResultStatus struct {
 	NodeInfo      p2p.DefaultNodeInfo `json:"node_info"`
 	SyncInfo      SyncInfo            `json:"sync_info"`
 	ValidatorInfo ValidatorInfo       `json:"validator_info"`
+	Peers         PeerCounts          `json:"peers"`
+	DiskUsage     []StoreDiskUsage    `json:"disk_usage"`
 }
 
 // Is TxIndexing enabled.
@@ -135,6 +297,52 @@ type Peer struct {
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	Messages         []PeerMessageStats   `json:"messages"`
+}
+
+// Messages of a type exchanged with a peer, by the reactor handling them.
+type PeerMessageStats struct {
+	Reactor       string `json:"reactor"`
+	ChannelID     byte   `json:"channel_id"`
+	MessageType   string `json:"message_type"`
+	MsgsSent      int64  `json:"msgs_sent"`
+	BytesSent     int64  `json:"bytes_sent"`
+	MsgsReceived  int64  `json:"msgs_received"`
+	BytesReceived int64  `json:"bytes_received"`
+}
+
+// Internal queue depths and goroutine counts of the node, to diagnose
+// deadlocks and backpressure.
+type ResultIntrospect struct {
+	Goroutines int `json:"goroutines"`
+	// Number of goroutines by the package that started them, relative to the
+	// module for the packages of CometBFT, and "other" for the rest.
+	GoroutinesBySubsystem map[string]int `json:"goroutines_by_subsystem"`
+	ConsensusQueues       []QueueStatus  `json:"consensus_queues"`
+	Peers                 []PeerQueues   `json:"peers"`
+}
+
+// Number of items waiting in a queue, and its capacity.
+type QueueStatus struct {
+	Name     string `json:"name"`
+	Size     int    `json:"size"`
+	Capacity int    `json:"capacity"`
+}
+
+// Send queues of the channels of the connection to a peer.
+type PeerQueues struct {
+	NodeID   p2p.ID         `json:"node_id"`
+	Channels []ChannelQueue `json:"channels"`
+}
+
+// Send queue of a channel, by the reactor handling it. RemoteBusy is set while
+// the peer reports its receive buffer for the channel as full.
+type ChannelQueue struct {
+	Reactor    string `json:"reactor"`
+	ChannelID  byte   `json:"channel_id"`
+	Size       int    `json:"size"`
+	Capacity   int    `json:"capacity"`
+	RemoteBusy bool   `json:"remote_busy"`
 }
 
 // Validators for a height.
@@ -189,11 +397,24 @@ type ResultBroadcastTxCommit struct {
 	Height   int64                `json:"height"`
 }
 
+// CheckTx and ExecTx results, and the proof of the inclusion of the tx in the
+// block.
+type ResultBroadcastTxCommitProof struct {
+	ResultBroadcastTxCommit
+	Index uint32        `json:"index"`
+	Proof types.TxProof `json:"proof"`
+}
+
 // ResultCheckTx wraps abci.CheckTxResponse.
 type ResultCheckTx struct {
 	abci.CheckTxResponse
 }
 
+// ResultSimulateTx wraps the abci.CheckTxResponse of a simulation.
+type ResultSimulateTx struct {
+	abci.CheckTxResponse
+}
+
 // Result of querying for a tx.
 type ResultTx struct {
 	Hash     bytes.HexBytes    `json:"hash"`
@@ -204,6 +425,15 @@ type ResultTx struct {
 	Proof    types.TxProof     `json:"proof,omitempty"`
 }
 
+// ResultTxResultProof contains the result of a transaction with its proof,
+// or the proof that the transaction is absent from the block at Height.
+type ResultTxResultProof struct {
+	Hash         bytes.HexBytes        `json:"hash"`
+	Height       int64                 `json:"height"`
+	Proof        *types.TxResultProof  `json:"proof,omitempty"`
+	AbsenceProof *types.TxAbsenceProof `json:"absence_proof,omitempty"`
+}
+
 // Result of searching for txs.
 type ResultTxSearch struct {
 	Txs        []*ResultTx `json:"txs"`
@@ -222,6 +452,7 @@ type ResultUnconfirmedTxs struct {
 	Total      int        `json:"total"`
 	TotalBytes int64      `json:"total_bytes"`
 	Txs        []types.Tx `json:"txs"`
+	NextCursor string     `json:"next_cursor,omitempty"`
 }
 
 // Info abci msg.
@@ -239,15 +470,33 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Result of broadcasting the evidence of an attack report.
+type ResultBroadcastAttackReport struct {
+	Hashes [][]byte `json:"hashes"`
+}
+
 // empty results.
 type (
 	ResultUnsafeFlushMempool struct{}
 	ResultUnsafeProfile      struct{}
 	ResultSubscribe          struct{}
 	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
 )
 
+// Health of the node.
+type ResultHealth struct {
+	// Ready is true if all the checks pass.
+	Ready  bool          `json:"ready"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// Result of a health check of a component of the node.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
 // Event data from a subscription.
 type ResultEvent struct {
 	Query  string              `json:"query"`
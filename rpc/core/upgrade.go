@@ -0,0 +1,25 @@
+package core
+
+import (
+	"fmt"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// UnsafeScheduleHalt schedules the node to halt once the block at the given
+// height is committed, e.g. for an upgrade. The height must be above the last
+// committed block; 0 cancels the scheduled halt.
+func (env *Environment) UnsafeScheduleHalt(_ *rpctypes.Context, height int64) (*ctypes.ResultScheduleHalt, error) {
+	if env.Upgrades == nil {
+		return nil, ErrNoUpgrades
+	}
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := env.Upgrades.SetHaltHeight(height, state.LastBlockHeight); err != nil {
+		return nil, fmt.Errorf("can't schedule halt: %w", err)
+	}
+	return &ctypes.ResultScheduleHalt{HaltHeight: height}, nil
+}
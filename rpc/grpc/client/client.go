@@ -22,6 +22,7 @@ type Client interface {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	EventLogServiceClient
 
 	// Close the connection to the server. Any subsequent requests will fail.
 	Close() error
@@ -34,6 +35,7 @@ type clientBuilder struct {
 	versionServiceEnabled      bool
 	blockServiceEnabled        bool
 	blockResultsServiceEnabled bool
+	eventLogServiceEnabled     bool
 }
 
 func newClientBuilder() *clientBuilder {
@@ -56,6 +58,7 @@ type client struct {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	EventLogServiceClient
 }
 
 // Close implements Client.
@@ -94,6 +97,18 @@ func WithBlockServiceEnabled(enabled bool) Option {
 	}
 }
 
+// WithEventLogServiceEnabled allows control of whether or not to create a
+// client for interacting with the event log service of a CometBFT node. It is
+// disabled by default, like the service itself.
+//
+// If disabled and the client attempts to access the event log service API,
+// the client will panic.
+func WithEventLogServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.eventLogServiceEnabled = enabled
+	}
+}
+
 // WithGRPCDialOption allows passing lower-level gRPC dial options through to
 // the gRPC dialer when creating the client.
 func WithGRPCDialOption(opt ggrpc.DialOption) Option {
@@ -132,10 +147,15 @@ func New(ctx context.Context, addr string, opts ...Option) (Client, error) {
 	if builder.blockResultsServiceEnabled {
 		blockResultServiceClient = newBlockResultsServiceClient(conn)
 	}
+	eventLogServiceClient := newDisabledEventLogServiceClient()
+	if builder.eventLogServiceEnabled {
+		eventLogServiceClient = newEventLogServiceClient(conn)
+	}
 	return &client{
 		conn:                      conn,
 		VersionServiceClient:      versionServiceClient,
 		BlockServiceClient:        blockServiceClient,
 		BlockResultsServiceClient: blockResultServiceClient,
+		EventLogServiceClient:     eventLogServiceClient,
 	}, nil
 }
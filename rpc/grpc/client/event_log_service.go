@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	pbeventlog "github.com/cometbft/cometbft/api/cometbft/services/event_log/v1"
+	"github.com/cosmos/gogoproto/grpc"
+)
+
+// BlockEvents contains the events emitted while finalizing a block, as
+// returned by the CometBFT EventLogService gRPC API.
+type BlockEvents struct {
+	Height              int64         `json:"height"`
+	FinalizeBlockEvents []*abci.Event `json:"finalize_block_events"`
+	TxEvents            []*TxEvents   `json:"tx_events"`
+}
+
+// TxEvents contains the events emitted by a transaction of a block.
+type TxEvents struct {
+	Hash   []byte        `json:"hash"`
+	Index  uint32        `json:"index"`
+	Events []*abci.Event `json:"events"`
+}
+
+// BlockEventsResult is sent to the output channel of SubscribeEventLog for
+// every block, or once with the error that terminated the stream.
+type BlockEventsResult struct {
+	BlockEvents *BlockEvents
+	Error       error
+}
+
+// EventLogServiceClient provides the events persisted in the event log of a
+// node.
+type EventLogServiceClient interface {
+	// SubscribeEventLog sends the events of every block from the given height
+	// to the resulting output channel, first those in the log and then those
+	// of the new blocks as they are committed. If fromHeight is 0, it starts at
+	// the oldest block in the log.
+	//
+	// Unlike GetLatestHeight, no result is ever skipped: a consumer that
+	// resumes from the height following the last one it processed receives the
+	// events of every block at least once.
+	SubscribeEventLog(ctx context.Context, fromHeight int64) (<-chan BlockEventsResult, error)
+}
+
+type eventLogServiceClient struct {
+	client pbeventlog.EventLogServiceClient
+}
+
+func newEventLogServiceClient(conn grpc.ClientConn) EventLogServiceClient {
+	return &eventLogServiceClient{
+		client: pbeventlog.NewEventLogServiceClient(conn),
+	}
+}
+
+// SubscribeEventLog implements EventLogServiceClient.
+func (c *eventLogServiceClient) SubscribeEventLog(ctx context.Context, fromHeight int64) (<-chan BlockEventsResult, error) {
+	stream, err := c.client.Subscribe(ctx, &pbeventlog.SubscribeRequest{FromHeight: fromHeight})
+	if err != nil {
+		return nil, fmt.Errorf("error subscribing to the event log from height %d: %w", fromHeight, err)
+	}
+
+	resultCh := make(chan BlockEventsResult)
+	go func() {
+		defer close(resultCh)
+		for {
+			res, err := stream.Recv()
+			var result BlockEventsResult
+			if err != nil {
+				result.Error = fmt.Errorf("error receiving block events from a stream: %w", err)
+			} else {
+				result.BlockEvents = blockEventsFromProto(res.BlockEvents)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- result:
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return resultCh, nil
+}
+
+func blockEventsFromProto(pb *pbeventlog.BlockEvents) *BlockEvents {
+	if pb == nil {
+		return &BlockEvents{}
+	}
+	txEvents := make([]*TxEvents, len(pb.TxEvents))
+	for i, tx := range pb.TxEvents {
+		txEvents[i] = &TxEvents{
+			Hash:   tx.Hash,
+			Index:  tx.Index,
+			Events: tx.Events,
+		}
+	}
+	return &BlockEvents{
+		Height:              pb.Height,
+		FinalizeBlockEvents: pb.FinalizeBlockEvents,
+		TxEvents:            txEvents,
+	}
+}
+
+type disabledEventLogServiceClient struct{}
+
+func newDisabledEventLogServiceClient() EventLogServiceClient {
+	return &disabledEventLogServiceClient{}
+}
+
+// SubscribeEventLog implements EventLogServiceClient - disabled client.
+func (*disabledEventLogServiceClient) SubscribeEventLog(context.Context, int64) (<-chan BlockEventsResult, error) {
+	panic("event log service client is disabled")
+}
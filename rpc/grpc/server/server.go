@@ -7,12 +7,15 @@ import (
 
 	pbblocksvc "github.com/cometbft/cometbft/api/cometbft/services/block/v1"
 	brs "github.com/cometbft/cometbft/api/cometbft/services/block_results/v1"
+	pbeventlogsvc "github.com/cometbft/cometbft/api/cometbft/services/event_log/v1"
 	pbversionsvc "github.com/cometbft/cometbft/api/cometbft/services/version/v1"
+	"github.com/cometbft/cometbft/internal/eventlog"
 	sm "github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/store"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/rpc/grpc/server/services/blockresultservice"
 	"github.com/cometbft/cometbft/rpc/grpc/server/services/blockservice"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/eventlogservice"
 	"github.com/cometbft/cometbft/rpc/grpc/server/services/versionservice"
 	"github.com/cometbft/cometbft/types"
 	"google.golang.org/grpc"
@@ -27,6 +30,7 @@ type serverBuilder struct {
 	versionService      pbversionsvc.VersionServiceServer
 	blockService        pbblocksvc.BlockServiceServer
 	blockResultsService brs.BlockResultsServiceServer
+	eventLogService     pbeventlogsvc.EventLogServiceServer
 	logger              log.Logger
 	grpcOpts            []grpc.ServerOption
 }
@@ -75,6 +79,14 @@ func WithBlockResultsService(bs *store.BlockStore, ss sm.Store, logger log.Logge
 	}
 }
 
+// WithEventLogService enables the event log service on the CometBFT server,
+// which streams the events persisted in the given log.
+func WithEventLogService(eventLog *eventlog.Log, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.eventLogService = eventlogservice.New(eventLog, logger)
+	}
+}
+
 // WithLogger enables logging using the given logger. If not specified, the
 // gRPC server does not log anything.
 func WithLogger(logger log.Logger) Option {
@@ -114,6 +126,10 @@ func Serve(listener net.Listener, opts ...Option) error {
 		brs.RegisterBlockResultsServiceServer(server, b.blockResultsService)
 		b.logger.Debug("Registered block results service")
 	}
+	if b.eventLogService != nil {
+		pbeventlogsvc.RegisterEventLogServiceServer(server, b.eventLogService)
+		b.logger.Debug("Registered event log service")
+	}
 	b.logger.Info("serve", "msg", fmt.Sprintf("Starting gRPC server on %s", listener.Addr()))
 	return server.Serve(b.listener)
 }
@@ -0,0 +1,75 @@
+package eventlogservice
+
+import (
+	pbeventlog "github.com/cometbft/cometbft/api/cometbft/services/event_log/v1"
+	"github.com/cometbft/cometbft/internal/eventlog"
+	"github.com/cometbft/cometbft/internal/rpctrace"
+	"github.com/cometbft/cometbft/libs/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type eventLogServiceServer struct {
+	log    *eventlog.Log
+	logger log.Logger
+}
+
+// New creates a new CometBFT event log service server.
+func New(eventLog *eventlog.Log, logger log.Logger) pbeventlog.EventLogServiceServer {
+	return &eventLogServiceServer{
+		log:    eventLog,
+		logger: logger.With("service", "EventLogService"),
+	}
+}
+
+// Subscribe implements v1.EventLogServiceServer Subscribe method.
+func (s *eventLogServiceServer) Subscribe(req *pbeventlog.SubscribeRequest, stream pbeventlog.EventLogService_SubscribeServer) error {
+	logger := s.logger.With("endpoint", "Subscribe")
+	if req.FromHeight < 0 {
+		return status.Error(codes.InvalidArgument, "Height cannot be negative")
+	}
+
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	next := req.FromHeight
+	if next == 0 {
+		next = max(s.log.Base(), 1)
+	}
+	for {
+		// Get the channel before reading the log, so that an entry appended
+		// in between is not missed.
+		appended := s.log.Appended()
+
+		entry, err := s.log.Next(next)
+		if err != nil {
+			logger.Error("Error reading the event log", "height", next, "err", err, "traceID", traceID)
+			return status.Errorf(codes.Internal, "Cannot read the event log (see logs for trace ID: %s)", traceID)
+		}
+		// The base only moves up, so an entry above the requested height with
+		// the requested height below the base means that it was pruned.
+		if base := s.log.Base(); (entry == nil || entry.Height != next) && next < base {
+			return status.Errorf(codes.OutOfRange, "Height %d was pruned from the event log, whose oldest height is %d", next, base)
+		}
+
+		if entry == nil {
+			select {
+			case <-appended:
+				continue
+			case <-s.log.Quit():
+				return status.Error(codes.Unavailable, "Event log stopped")
+			case <-stream.Context().Done():
+				return status.Error(codes.Canceled, "Subscription canceled")
+			}
+		}
+
+		if err := stream.Send(&pbeventlog.SubscribeResponse{BlockEvents: entry}); err != nil {
+			logger.Error("Failed to stream block events", "err", err, "height", entry.Height, "traceID", traceID)
+			return status.Errorf(codes.Unavailable, "Cannot send stream response (see logs for trace ID: %s)", traceID)
+		}
+		next = entry.Height + 1
+	}
+}
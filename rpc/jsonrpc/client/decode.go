@@ -48,9 +48,6 @@ func unmarshalResponseBytesArray(
 		return nil, fmt.Errorf("error unmarshalling: %w", err)
 	}
 
-	// No response error checking here as there may be a mixture of successful
-	// and unsuccessful responses.
-
 	if len(results) != len(responses) {
 		return nil, fmt.Errorf(
 			"expected %d result objects into which to inject responses, but got %d",
@@ -72,7 +69,12 @@ func unmarshalResponseBytesArray(
 		return nil, fmt.Errorf("wrong IDs: %w", err)
 	}
 
+	// There may be a mixture of successful and unsuccessful responses; the
+	// first error is returned.
 	for i := 0; i < len(responses); i++ {
+		if responses[i].Error != nil {
+			return nil, responses[i].Error
+		}
 		if err := cmtjson.Unmarshal(responses[i].Result, results[i]); err != nil {
 			return nil, fmt.Errorf("error unmarshalling #%d result: %w", i, err)
 		}
@@ -1,6 +1,9 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
@@ -8,6 +11,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
 
 func TestHTTPClientMakeHTTPDialer(t *testing.T) {
@@ -90,3 +95,29 @@ func Test_parsedURL(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestBatchResponseError(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []types.RPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reqs))
+		require.Len(t, reqs, 2)
+		resps := []types.RPCResponse{
+			types.NewRPCSuccessResponse(reqs[0].ID, struct{}{}),
+			types.RPCInternalError(reqs[1].ID, errors.New("height 5 is not available")),
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resps))
+	})
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	require.NoError(t, err)
+	batch := c.NewRequestBatch()
+	_, err = batch.Call(context.Background(), "status", nil, &struct{}{})
+	require.NoError(t, err)
+	_, err = batch.Call(context.Background(), "commit", map[string]interface{}{"height": "5"}, &struct{}{})
+	require.NoError(t, err)
+
+	_, err = batch.Send(context.Background())
+	require.ErrorContains(t, err, "height 5 is not available")
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// errUnauthorized is returned to clients which fail to authenticate.
+var errUnauthorized = errors.New("unauthorized")
+
+// Authenticator validates the bearer token presented by a client. If the token
+// is valid, it returns an identifier for the client, which is used, for
+// example, to apply per-client rate limits.
+type Authenticator func(token string) (clientID string, ok bool)
+
+// APIKeyAuthenticator returns an Authenticator accepting any of the given
+// static keys. The client identifier is derived from a hash of the key, so
+// that keys never end up in logs.
+func APIKeyAuthenticator(keys ...string) Authenticator {
+	return func(token string) (string, bool) {
+		found := 0
+		for _, key := range keys {
+			found |= subtle.ConstantTimeCompare([]byte(token), []byte(key))
+		}
+		if found != 1 {
+			return "", false
+		}
+		sum := sha256.Sum256([]byte(token))
+		return "key:" + hex.EncodeToString(sum[:4]), true
+	}
+}
+
+// JWTAuthenticator returns an Authenticator accepting JSON Web Tokens signed
+// with HMAC-SHA256 (HS256) using the given secret. The "exp" and "nbf" claims
+// are enforced if present. The client identifier is taken from the "sub"
+// claim.
+func JWTAuthenticator(secret []byte) Authenticator {
+	return func(token string) (string, bool) {
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			return "", false
+		}
+
+		var header struct {
+			Alg string `json:"alg"`
+		}
+		if err := decodeJWTSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+			return "", false
+		}
+
+		sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			return "", false
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return "", false
+		}
+
+		var claims struct {
+			Sub string `json:"sub"`
+			Exp *int64 `json:"exp"`
+			Nbf *int64 `json:"nbf"`
+		}
+		if err := decodeJWTSegment(parts[1], &claims); err != nil {
+			return "", false
+		}
+		now := time.Now().Unix()
+		if claims.Exp != nil && now >= *claims.Exp {
+			return "", false
+		}
+		if claims.Nbf != nil && now < *claims.Nbf {
+			return "", false
+		}
+		return "jwt:" + claims.Sub, true
+	}
+}
+
+func decodeJWTSegment(seg string, v interface{}) error {
+	bz, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, v)
+}
+
+type clientIDKey struct{}
+
+// ClientIDFromContext returns the identifier of the client authenticated by
+// AuthHandler, if any.
+func ClientIDFromContext(ctx context.Context) (string, bool) {
+	clientID, ok := ctx.Value(clientIDKey{}).(string)
+	return clientID, ok
+}
+
+// AuthHandler wraps an HTTP handler, rejecting all requests that do not carry
+// an "Authorization: Bearer <token>" header accepted by one of the given
+// authenticators. The identifier of the authenticated client is stored in the
+// request context (see ClientIDFromContext). If no authenticators are given,
+// handler is returned unchanged.
+func AuthHandler(handler http.Handler, auths ...Authenticator) http.Handler {
+	if len(auths) == 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := bearerToken(r); ok {
+			for _, auth := range auths {
+				if clientID, ok := auth(token); ok {
+					ctx := context.WithValue(r.Context(), clientIDKey{}, clientID)
+					handler.ServeHTTP(w, r.WithContext(ctx))
+					return
+				}
+			}
+		}
+		res := types.RPCInvalidRequestError(nil, errUnauthorized)
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		_ = WriteRPCResponseHTTPError(w, http.StatusUnauthorized, res)
+	})
+}
+
+// TokenAuthHandler wraps an HTTP handler, rejecting all requests that do not
+// carry the given token in an "Authorization: Bearer <token>" header. If
+// token is empty, handler is returned unchanged.
+func TokenAuthHandler(handler http.Handler, token string) http.Handler {
+	if token == "" {
+		return handler
+	}
+	return AuthHandler(handler, APIKeyAuthenticator(token))
+}
+
+// bearerToken extracts the token from the request's Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) < len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return "", false
+	}
+	return auth[len(prefix):], true
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenAuthHandler(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := TokenAuthHandler(ok, "secret")
+
+	testCases := []struct {
+		header string
+		code   int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer wrong", http.StatusUnauthorized},
+		{"Basic secret", http.StatusUnauthorized},
+		{"Bearer secret", http.StatusOK},
+		{"bearer secret", http.StatusOK},
+	}
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/dial_peers", nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, tc.code, rec.Code, tc.header)
+	}
+
+	// An empty token disables authentication.
+	rec := httptest.NewRecorder()
+	TokenAuthHandler(ok, "").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	auth := APIKeyAuthenticator("key1", "key2")
+
+	id1, ok := auth("key1")
+	assert.True(t, ok)
+	id2, ok := auth("key2")
+	assert.True(t, ok)
+	assert.NotEqual(t, id1, id2)
+	assert.NotContains(t, id1, "key1")
+
+	_, ok = auth("key3")
+	assert.False(t, ok)
+	_, ok = auth("")
+	assert.False(t, ok)
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	secret := []byte("secret")
+	auth := JWTAuthenticator(secret)
+	now := time.Now().Unix()
+
+	clientID, ok := auth(signJWT(t, secret, "HS256", map[string]interface{}{"sub": "alice", "exp": now + 60}))
+	assert.True(t, ok)
+	assert.Equal(t, "jwt:alice", clientID)
+
+	testCases := map[string]string{
+		"expired":      signJWT(t, secret, "HS256", map[string]interface{}{"sub": "alice", "exp": now - 1}),
+		"not yet":      signJWT(t, secret, "HS256", map[string]interface{}{"sub": "alice", "nbf": now + 60}),
+		"wrong secret": signJWT(t, []byte("other"), "HS256", map[string]interface{}{"sub": "alice"}),
+		"wrong alg":    signJWT(t, secret, "none", map[string]interface{}{"sub": "alice"}),
+		"malformed":    "a.b",
+	}
+	for name, token := range testCases {
+		_, ok := auth(token)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestAuthHandlerSetsClientID(t *testing.T) {
+	var got string
+	h := AuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = ClientIDFromContext(r.Context())
+	}), APIKeyAuthenticator("key1"), JWTAuthenticator([]byte("secret")))
+
+	req := httptest.NewRequest(http.MethodGet, "/status", nil)
+	req.Header.Set("Authorization", "Bearer "+signJWT(t, []byte("secret"), "HS256", map[string]interface{}{"sub": "bob"}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "jwt:bob", got)
+}
+
+func signJWT(t *testing.T, secret []byte, alg string, claims map[string]interface{}) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+var errShuttingDown = errors.New("the node is shutting down")
+
+// Drainer keeps track of the in-flight requests, so that they can complete
+// before the node stops.
+type Drainer struct {
+	mtx      sync.Mutex
+	draining bool
+	inFlight int
+	idle     chan struct{} // closed once draining without in-flight requests
+}
+
+// NewDrainer returns a Drainer accepting requests.
+func NewDrainer() *Drainer {
+	return &Drainer{idle: make(chan struct{})}
+}
+
+// acquire records a new in-flight request, or returns false if draining.
+func (d *Drainer) acquire() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.draining {
+		return false
+	}
+	d.inFlight++
+	return true
+}
+
+func (d *Drainer) release() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.inFlight--
+	if d.draining && d.inFlight == 0 {
+		close(d.idle)
+	}
+}
+
+// isDraining returns true once Drain is called.
+func (d *Drainer) isDraining() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.draining
+}
+
+// Drain rejects the new requests, and waits for the in-flight ones to complete
+// or ctx to be done.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mtx.Lock()
+	if !d.draining {
+		d.draining = true
+		if d.inFlight == 0 {
+			close(d.idle)
+		}
+	}
+	d.mtx.Unlock()
+
+	select {
+	case <-d.idle:
+		return nil
+	default:
+	}
+	select {
+	case <-d.idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// DrainHandler wraps an HTTP handler, tracking its in-flight requests with d
+// and rejecting the new ones with HTTP 503 once d is draining. WebSocket
+// connections are not tracked, as they last until the client disconnects.
+func DrainHandler(handler http.Handler, d *Drainer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			if d.isDraining() {
+				writeShuttingDown(w)
+				return
+			}
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if !d.acquire() {
+			writeShuttingDown(w)
+			return
+		}
+		defer d.release()
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func writeShuttingDown(w http.ResponseWriter) {
+	w.Header().Set("Connection", "close")
+	res := types.RPCServerError(nil, errShuttingDown)
+	_ = WriteRPCResponseHTTPError(w, http.StatusServiceUnavailable, res)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainHandler(t *testing.T) {
+	d := NewDrainer()
+	started, release := make(chan struct{}), make(chan struct{})
+	h := DrainHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(started)
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}), d)
+
+	do := func(path string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do("/status"))
+
+	slowCode := make(chan int, 1)
+	go func() { slowCode <- do("/slow") }()
+	<-started
+
+	// The in-flight request holds the drain.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, d.Drain(ctx), context.DeadlineExceeded)
+
+	// New requests are rejected while draining.
+	assert.Equal(t, http.StatusServiceUnavailable, do("/status"))
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-slowCode)
+	require.NoError(t, d.Drain(context.Background()))
+}
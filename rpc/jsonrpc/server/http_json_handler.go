@@ -110,7 +110,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 			returns := rpcFunc.f.Call(args)
 			result, err := unreflectResult(returns)
 			if err != nil {
-				responses = append(responses, types.RPCInternalError(request.ID, err))
+				responses = append(responses, types.RPCFuncError(request.ID, err))
 				continue
 			}
 			responses = append(responses, types.NewRPCSuccessResponse(request.ID, result))
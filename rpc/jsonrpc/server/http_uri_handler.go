@@ -57,7 +57,7 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		result, err := unreflectResult(returns)
 		if err != nil {
 			if err := WriteRPCResponseHTTPError(w, http.StatusInternalServerError,
-				types.RPCInternalError(dummyID, err)); err != nil {
+				types.RPCFuncError(dummyID, err)); err != nil {
 				logger.Error("failed to write response", "err", err)
 				return
 			}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+const (
+	// maxRateLimitBuckets is the maximum number of buckets kept. Past it, the
+	// least recently used bucket is evicted.
+	maxRateLimitBuckets = 10000
+	// rateLimitBucketIdleTime is how long a bucket must go unused before it
+	// is evicted, being full again by then.
+	rateLimitBucketIdleTime = time.Minute
+)
+
+var errRateLimited = errors.New("rate limit exceeded")
+
+// malformedRequestMethod is the method the requests whose methods cannot be
+// read are accounted to. No method can be named so, so the default limit
+// applies.
+const malformedRequestMethod = ""
+
+// RequestMethodsFunc returns the RPC methods called by r, for requests which
+// are not JSON-RPC calls, such as those of a REST facade over the RPC methods.
+// It returns false for the requests it does not recognize.
+type RequestMethodsFunc func(r *http.Request) (methods []string, ok bool)
+
+// RateLimit is a token bucket limit: Rate requests per second, with bursts of
+// up to Burst requests. A zero Rate means unlimited.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiter keeps track of request rates per client. All the calls of a
+// client share one bucket, except the calls to the methods with their own
+// limit, which have one bucket per method. The number of buckets is capped,
+// so that the memory used does not grow with the number of clients.
+type RateLimiter struct {
+	defaultLimit RateLimit
+	methodLimits map[string]RateLimit
+
+	mtx     sync.Mutex
+	buckets map[string]*list.Element // of *tokenBucket, in lru
+	lru     *list.List               // most recently used first
+	now     func() time.Time
+}
+
+// NewRateLimiter returns a RateLimiter applying defaultLimit to all the calls
+// of a client, except those to the methods listed in methodLimits. The caller
+// must make sure methodLimits only lists the methods it serves, as each of
+// them adds a bucket per client.
+func NewRateLimiter(defaultLimit RateLimit, methodLimits map[string]RateLimit) *RateLimiter {
+	if methodLimits == nil {
+		methodLimits = make(map[string]RateLimit)
+	}
+	return &RateLimiter{
+		defaultLimit: defaultLimit,
+		methodLimits: methodLimits,
+		buckets:      make(map[string]*list.Element),
+		lru:          list.New(),
+		now:          time.Now,
+	}
+}
+
+// bucketFor returns the key of the bucket of clientID charged with the calls
+// to method, and its limit.
+func (rl *RateLimiter) bucketFor(clientID, method string) (string, RateLimit) {
+	if limit, ok := rl.methodLimits[method]; ok {
+		return clientID + "|" + method, limit
+	}
+	return clientID, rl.defaultLimit
+}
+
+// Allow reports whether clientID may call all of the given methods now. If
+// so, one token per call is consumed from the buckets charged with them.
+func (rl *RateLimiter) Allow(clientID string, methods ...string) bool {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	now := rl.now()
+	rl.evictIdle(now)
+
+	// Count the tokens needed per bucket first, so that a batch is either
+	// accepted or rejected as a whole.
+	needed := make(map[string]int, 1)
+	limits := make(map[string]RateLimit, 1)
+	for _, method := range methods {
+		key, limit := rl.bucketFor(clientID, method)
+		needed[key]++
+		limits[key] = limit
+	}
+	buckets := make(map[string]*tokenBucket, len(needed))
+	for key, n := range needed {
+		limit := limits[key]
+		if limit.Rate <= 0 {
+			continue
+		}
+		b := rl.bucket(key, limit, now)
+		b.refill(limit, now)
+		if b.tokens < float64(n) {
+			return false
+		}
+		buckets[key] = b
+	}
+	for key, b := range buckets {
+		b.tokens -= float64(needed[key])
+	}
+	return true
+}
+
+// bucket returns the bucket with the given key, creating it full if needed,
+// and marks it as the most recently used one.
+func (rl *RateLimiter) bucket(key string, limit RateLimit, now time.Time) *tokenBucket {
+	if e, ok := rl.buckets[key]; ok {
+		rl.lru.MoveToFront(e)
+		return e.Value.(*tokenBucket)
+	}
+	if len(rl.buckets) >= maxRateLimitBuckets {
+		rl.remove(rl.lru.Back())
+	}
+	b := &tokenBucket{key: key, tokens: float64(limit.Burst), last: now}
+	rl.buckets[key] = rl.lru.PushFront(b)
+	return b
+}
+
+// evictIdle removes the buckets unused for rateLimitBucketIdleTime, starting
+// from the least recently used one.
+func (rl *RateLimiter) evictIdle(now time.Time) {
+	for e := rl.lru.Back(); e != nil; e = rl.lru.Back() {
+		if now.Sub(e.Value.(*tokenBucket).last) <= rateLimitBucketIdleTime {
+			return
+		}
+		rl.remove(e)
+	}
+}
+
+func (rl *RateLimiter) remove(e *list.Element) {
+	delete(rl.buckets, e.Value.(*tokenBucket).key)
+	rl.lru.Remove(e)
+}
+
+type tokenBucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) refill(limit RateLimit, now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * limit.Rate
+	if max := float64(limit.Burst); b.tokens > max {
+		b.tokens = max
+	}
+	b.last = now
+}
+
+// RateLimitHandler wraps an HTTP handler, rejecting requests exceeding the
+// limits of rl with HTTP 429. Clients are identified by their IP address, so
+// that the handler can wrap AuthHandler and throttle the failed
+// authentications too. The method is taken from the URL path or, for JSON-RPC
+// requests, from the request body (every call of a batch request counts). A request whose body
+// cannot be parsed counts as a call to no method, under the default limit.
+// The requests recognized by one of methodsFuncs are charged to the methods
+// it returns instead, so that the same limits apply to the RPC methods
+// however they are called.
+// The calls made over a websocket connection are limited by the connection,
+// see CallRateLimiter.
+func RateLimitHandler(handler http.Handler, rl *RateLimiter, methodsFuncs ...RequestMethodsFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods, err := requestMethods(r, methodsFuncs)
+		if err != nil {
+			// the JSON-RPC handler reports the error, if the request is allowed
+			methods = []string{malformedRequestMethod}
+		}
+		if !rl.Allow(requestClientID(r), methods...) {
+			w.Header().Set("Retry-After", "1")
+			res := types.RPCServerError(nil, errRateLimited)
+			_ = WriteRPCResponseHTTPError(w, http.StatusTooManyRequests, res)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func requestClientID(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// requestMethods returns the RPC methods called by r. The request body is
+// restored so that it can be read again by the next handler.
+func requestMethods(r *http.Request, methodsFuncs []RequestMethodsFunc) ([]string, error) {
+	for _, fn := range methodsFuncs {
+		if methods, ok := fn(r); ok {
+			return methods, nil
+		}
+	}
+	path := strings.Trim(r.URL.Path, "/")
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "v1"), "/")
+	if path != "" {
+		return []string{path}, nil
+	}
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	type call struct {
+		Method string `json:"method"`
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var calls []call
+		if err := json.Unmarshal(body, &calls); err != nil {
+			return nil, err
+		}
+		methods := make([]string, len(calls))
+		for i, c := range calls {
+			methods[i] = c.Method
+		}
+		return methods, nil
+	}
+	var c call
+	if err := json.Unmarshal(body, &c); err != nil {
+		return nil, err
+	}
+	return []string{c.Method}, nil
+}
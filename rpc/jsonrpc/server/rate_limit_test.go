@@ -0,0 +1,159 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	rl := NewRateLimiter(
+		RateLimit{Rate: 1, Burst: 2},
+		map[string]RateLimit{
+			"tx_search": {Rate: 1, Burst: 1},
+			"health":    {},
+		},
+	)
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+
+	// default limit
+	assert.True(t, rl.Allow("a", "status"))
+	assert.True(t, rl.Allow("a", "status"))
+	assert.False(t, rl.Allow("a", "status"))
+
+	// per-method limit
+	assert.True(t, rl.Allow("a", "tx_search"))
+	assert.False(t, rl.Allow("a", "tx_search"))
+
+	// unlimited method
+	for i := 0; i < 10; i++ {
+		assert.True(t, rl.Allow("a", "health"))
+	}
+
+	// other clients have their own buckets
+	assert.True(t, rl.Allow("b", "tx_search"))
+
+	// a batch is rejected as a whole
+	assert.False(t, rl.Allow("c", "block", "block", "block"))
+	assert.True(t, rl.Allow("c", "block", "block"))
+
+	// tokens are refilled over time
+	now = now.Add(time.Second)
+	assert.True(t, rl.Allow("a", "tx_search"))
+	assert.True(t, rl.Allow("a", "status"))
+	assert.False(t, rl.Allow("a", "status"))
+}
+
+func TestRateLimiterMethodNames(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{Rate: 1, Burst: 2}, map[string]RateLimit{"tx_search": {Rate: 1, Burst: 1}})
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+
+	// the methods without their own limit share the bucket of the client,
+	// whatever their names
+	assert.True(t, rl.Allow("a", "status"))
+	assert.True(t, rl.Allow("a", "no_such_method"))
+	assert.False(t, rl.Allow("a", "another_method"))
+	assert.True(t, rl.Allow("a", "tx_search"))
+	assert.Len(t, rl.buckets, 2)
+}
+
+func TestRateLimiterMaxBuckets(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{Rate: 1, Burst: 1}, nil)
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+
+	for i := 0; i < maxRateLimitBuckets+10; i++ {
+		require.True(t, rl.Allow(strconv.Itoa(i), "status"))
+	}
+	assert.Len(t, rl.buckets, maxRateLimitBuckets)
+	assert.Equal(t, maxRateLimitBuckets, rl.lru.Len())
+	// the least recently used buckets were evicted
+	assert.NotContains(t, rl.buckets, "0")
+	assert.False(t, rl.Allow(strconv.Itoa(maxRateLimitBuckets+9), "status"))
+
+	// the idle buckets are evicted
+	now = now.Add(2 * rateLimitBucketIdleTime)
+	assert.True(t, rl.Allow("a", "status"))
+	assert.Len(t, rl.buckets, 1)
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{}, map[string]RateLimit{"tx_search": {Rate: 1, Burst: 1}})
+	h := RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), rl)
+
+	do := func(req *http.Request) int {
+		req.RemoteAddr = "1.2.3.4:5678"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do(httptest.NewRequest(http.MethodGet, "/v1/tx_search?query=x", nil)))
+	assert.Equal(t, http.StatusTooManyRequests, do(httptest.NewRequest(http.MethodGet, "/tx_search?query=x", nil)))
+	body := `{"jsonrpc":"2.0","id":1,"method":"tx_search","params":{}}`
+	assert.Equal(t, http.StatusTooManyRequests, do(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))))
+	body = `[{"jsonrpc":"2.0","id":1,"method":"status"},{"jsonrpc":"2.0","id":2,"method":"tx_search"}]`
+	assert.Equal(t, http.StatusTooManyRequests, do(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))))
+	assert.Equal(t, http.StatusOK, do(httptest.NewRequest(http.MethodGet, "/status", nil)))
+}
+
+func TestRateLimitHandlerMalformedBody(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{Rate: 1, Burst: 2}, nil)
+	h := RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), rl)
+
+	do := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.RemoteAddr = "1.2.3.4:5678"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// malformed bodies are charged to the default limit instead of bypassing it
+	assert.Equal(t, http.StatusOK, do(`[{"jsonrpc":"2.0","id":1,"method":"status"`))
+	assert.Equal(t, http.StatusOK, do(`{`))
+	assert.Equal(t, http.StatusTooManyRequests, do(`not json`))
+	assert.Equal(t, http.StatusTooManyRequests, do(`{"jsonrpc":"2.0","id":1,"method":"status"}`))
+}
+
+func TestRateLimitHandlerMethodsFunc(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{}, map[string]RateLimit{"tx_search": {Rate: 1, Burst: 1}})
+	methodsFunc := func(r *http.Request) ([]string, bool) {
+		if !strings.HasPrefix(r.URL.Path, "/rest/") {
+			return nil, false
+		}
+		if r.URL.Path == "/rest/txs" {
+			return []string{"tx_search"}, true
+		}
+		return []string{"status"}, true
+	}
+	h := RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), rl, methodsFunc)
+
+	do := func(target string) int {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.RemoteAddr = "1.2.3.4:5678"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// the mapped requests share the bucket of the method they are mapped to
+	assert.Equal(t, http.StatusOK, do("/rest/txs?query=x"))
+	assert.Equal(t, http.StatusTooManyRequests, do("/tx_search?query=x"))
+	assert.Equal(t, http.StatusTooManyRequests, do("/rest/txs?query=x"))
+	assert.Equal(t, http.StatusOK, do("/rest/status"))
+}
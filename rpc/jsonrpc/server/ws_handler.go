@@ -1,10 +1,12 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
 	"runtime/debug"
@@ -23,6 +25,7 @@ const (
 	defaultWSWriteWait         = 10 * time.Second
 	defaultWSReadWait          = 30 * time.Second
 	defaultWSPingPeriod        = (defaultWSReadWait * 9) / 10
+	defaultWSMaxBatchSize      = 100
 )
 
 // WebsocketManager provides a WS handler for incoming connections and passes a
@@ -85,6 +88,7 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	con.clientID = requestClientID(r)
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -109,7 +113,7 @@ type wsConnection struct {
 	remoteAddr string
 	baseConn   *websocket.Conn
 	// writeChan is never closed, to allow WriteRPCResponse() to fail.
-	writeChan chan types.RPCResponse
+	writeChan chan wsResponse
 
 	// chan, which is closed when/if readRoutine errors
 	// used to abort writeRoutine
@@ -132,9 +136,17 @@ type wsConnection struct {
 	// Maximum message size.
 	readLimit int64
 
+	// Maximum number of requests in a batch.
+	maxBatchSize int
+
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// limits of the calls made over the connection, if any, and the client
+	// they are accounted to
+	rateLimiter *RateLimiter
+	clientID    string
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -158,6 +170,7 @@ func newWSConnection(
 		writeChanCapacity: defaultWSWriteChanCapacity,
 		readWait:          defaultWSReadWait,
 		pingPeriod:        defaultWSPingPeriod,
+		maxBatchSize:      defaultWSMaxBatchSize,
 		readRoutineQuit:   make(chan struct{}),
 	}
 	for _, option := range options {
@@ -216,10 +229,28 @@ func ReadLimit(readLimit int64) func(*wsConnection) {
 	}
 }
 
+// MaxBatchSize sets the maximum number of requests in a batch. Larger batches
+// are rejected as a whole. It should only be used in the constructor - not
+// Goroutine-safe.
+func MaxBatchSize(maxBatchSize int) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.maxBatchSize = maxBatchSize
+	}
+}
+
+// CallRateLimiter sets the limiter applied to each call made over the
+// connection, as RateLimitHandler does to the HTTP requests. It should only be
+// used in the constructor - not Goroutine-safe.
+func CallRateLimiter(rl *RateLimiter) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.rateLimiter = rl
+	}
+}
+
 // OnStart implements service.Service by starting the read and write routines. It
 // blocks until there's some error.
 func (wsc *wsConnection) OnStart() error {
-	wsc.writeChan = make(chan types.RPCResponse, wsc.writeChanCapacity)
+	wsc.writeChan = make(chan wsResponse, wsc.writeChanCapacity)
 
 	// Read subscriptions/unsubscriptions to events
 	go wsc.readRoutine()
@@ -256,7 +287,20 @@ func (wsc *wsConnection) WriteRPCResponse(ctx context.Context, resp types.RPCRes
 		return errors.New("connection was stopped")
 	case <-ctx.Done():
 		return ctx.Err()
-	case wsc.writeChan <- resp:
+	case wsc.writeChan <- wsResponse{responses: []types.RPCResponse{resp}}:
+		return nil
+	}
+}
+
+// writeRPCResponses pushes the responses to a batch request to the writeChan,
+// to be written as a single JSON array, and blocks until it is accepted.
+func (wsc *wsConnection) writeRPCResponses(ctx context.Context, resps []types.RPCResponse) error {
+	select {
+	case <-wsc.Quit():
+		return errors.New("connection was stopped")
+	case <-ctx.Done():
+		return ctx.Err()
+	case wsc.writeChan <- wsResponse{responses: resps, batch: true}:
 		return nil
 	}
 }
@@ -268,7 +312,7 @@ func (wsc *wsConnection) TryWriteRPCResponse(resp types.RPCResponse) bool {
 	select {
 	case <-wsc.Quit():
 		return false
-	case wsc.writeChan <- resp:
+	case wsc.writeChan <- wsResponse{responses: []types.RPCResponse{resp}}:
 		return true
 	default:
 		return false
@@ -332,69 +376,148 @@ func (wsc *wsConnection) readRoutine() {
 				return
 			}
 
-			dec := json.NewDecoder(r)
-			var request types.RPCRequest
-			err = dec.Decode(&request)
+			b, err := io.ReadAll(r)
 			if err != nil {
 				if err := wsc.WriteRPCResponse(writeCtx,
-					types.RPCParseError(fmt.Errorf("error unmarshaling request: %w", err))); err != nil {
+					types.RPCParseError(fmt.Errorf("error reading request: %w", err))); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
 				continue
 			}
 
-			// A Notification is a Request object without an "id" member.
-			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
-			if request.ID == nil {
-				wsc.Logger.Debug(
-					"WSJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
-					"req", request,
-				)
+			// Batch requests are answered with a single array containing the
+			// responses in the same order as the requests.
+			if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '[' {
+				wsc.handleBatch(writeCtx, b)
 				continue
 			}
 
-			// Now, fetch the RPCFunc and execute it.
-			rpcFunc := wsc.funcMap[request.Method]
-			if rpcFunc == nil {
-				if err := wsc.WriteRPCResponse(writeCtx, types.RPCMethodNotFoundError(request.ID)); err != nil {
+			var request types.RPCRequest
+			if err := json.Unmarshal(b, &request); err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx,
+					types.RPCParseError(fmt.Errorf("error unmarshaling request: %w", err))); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
 				continue
 			}
 
-			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
-				if err != nil {
-					if err := wsc.WriteRPCResponse(writeCtx,
-						types.RPCInternalError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					); err != nil {
-						wsc.Logger.Error("Error writing RPC response", "err", err)
-					}
-					continue
+			if request.ID != nil && !wsc.allow(request.Method) {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCServerError(request.ID, errRateLimited)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
-				args = append(args, fnArgs...)
+				continue
 			}
-
-			returns := rpcFunc.f.Call(args)
-
-			// TODO: Need to encode args/returns to string if we want to log them
-			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
-
-			result, err := unreflectResult(returns)
-			if err != nil {
-				if err := wsc.WriteRPCResponse(writeCtx, types.RPCInternalError(request.ID, err)); err != nil {
+			if res, ok := wsc.handleRequest(request); ok {
+				if err := wsc.WriteRPCResponse(writeCtx, res); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
-				continue
 			}
+		}
+	}
+}
 
-			if err := wsc.WriteRPCResponse(writeCtx, types.NewRPCSuccessResponse(request.ID, result)); err != nil {
-				wsc.Logger.Error("Error writing RPC response", "err", err)
-			}
+// handleBatch executes the requests of a batch in order and writes their
+// responses back as a single message.
+func (wsc *wsConnection) handleBatch(writeCtx context.Context, b []byte) {
+	var requests []types.RPCRequest
+	if err := json.Unmarshal(b, &requests); err != nil {
+		if err := wsc.WriteRPCResponse(writeCtx,
+			types.RPCParseError(fmt.Errorf("error unmarshaling batch request: %w", err))); err != nil {
+			wsc.Logger.Error("Error writing RPC response", "err", err)
 		}
+		return
 	}
+	if len(requests) == 0 {
+		if err := wsc.WriteRPCResponse(writeCtx,
+			types.RPCInvalidRequestError(nil, errors.New("empty batch request"))); err != nil {
+			wsc.Logger.Error("Error writing RPC response", "err", err)
+		}
+		return
+	}
+	if len(requests) > wsc.maxBatchSize {
+		err := fmt.Errorf("batch of %d requests exceeds the maximum of %d", len(requests), wsc.maxBatchSize)
+		if err := wsc.WriteRPCResponse(writeCtx, types.RPCInvalidRequestError(nil, err)); err != nil {
+			wsc.Logger.Error("Error writing RPC response", "err", err)
+		}
+		return
+	}
+
+	// Every call of the batch counts, and the batch is rejected as a whole.
+	methods := make([]string, 0, len(requests))
+	for _, request := range requests {
+		if request.ID != nil {
+			methods = append(methods, request.Method)
+		}
+	}
+	if !wsc.allow(methods...) {
+		if err := wsc.WriteRPCResponse(writeCtx, types.RPCServerError(nil, errRateLimited)); err != nil {
+			wsc.Logger.Error("Error writing RPC response", "err", err)
+		}
+		return
+	}
+
+	responses := make([]types.RPCResponse, 0, len(requests))
+	for _, request := range requests {
+		if res, ok := wsc.handleRequest(request); ok {
+			responses = append(responses, res)
+		}
+	}
+	// A batch made of notifications only gets no response.
+	if len(responses) == 0 {
+		return
+	}
+	if err := wsc.writeRPCResponses(writeCtx, responses); err != nil {
+		wsc.Logger.Error("Error writing RPC responses", "err", err)
+	}
+}
+
+// allow reports whether the client may call the given methods now.
+func (wsc *wsConnection) allow(methods ...string) bool {
+	if wsc.rateLimiter == nil || len(methods) == 0 {
+		return true
+	}
+	return wsc.rateLimiter.Allow(wsc.clientID, methods...)
+}
+
+// handleRequest executes a single request. It returns false if the request
+// is a notification, which must not be replied to.
+func (wsc *wsConnection) handleRequest(request types.RPCRequest) (types.RPCResponse, bool) {
+	// A Notification is a Request object without an "id" member.
+	// The Server MUST NOT reply to a Notification, including those that are within a batch request.
+	if request.ID == nil {
+		wsc.Logger.Debug(
+			"WSJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
+			"req", request,
+		)
+		return types.RPCResponse{}, false
+	}
+
+	// Now, fetch the RPCFunc and execute it.
+	rpcFunc := wsc.funcMap[request.Method]
+	if rpcFunc == nil {
+		return types.RPCMethodNotFoundError(request.ID), true
+	}
+
+	ctx := &types.Context{JSONReq: &request, WSConn: wsc}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+		if err != nil {
+			return types.RPCInternalError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)), true
+		}
+		args = append(args, fnArgs...)
+	}
+
+	returns := rpcFunc.f.Call(args)
+
+	// TODO: Need to encode args/returns to string if we want to log them
+	wsc.Logger.Info("WSJSONRPC", "method", request.Method)
+
+	result, err := unreflectResult(returns)
+	if err != nil {
+		return types.RPCFuncError(request.ID, err), true
+	}
+	return types.NewRPCSuccessResponse(request.ID, result), true
 }
 
 // receives on a write channel and writes out on the socket.
@@ -433,7 +556,7 @@ func (wsc *wsConnection) writeRoutine() {
 			// Use json.MarshalIndent instead of Marshal for pretty output.
 			// Pretty output not necessary, since most consumers of WS events are
 			// automated processes, not humans.
-			jsonBytes, err := json.Marshal(msg)
+			jsonBytes, err := msg.marshal()
 			if err != nil {
 				wsc.Logger.Error("Failed to marshal RPCResponse to JSON", "err", err)
 				continue
@@ -446,6 +569,20 @@ func (wsc *wsConnection) writeRoutine() {
 	}
 }
 
+// wsResponse is either a single response or the ordered responses to a
+// batch request.
+type wsResponse struct {
+	responses []types.RPCResponse
+	batch     bool
+}
+
+func (r wsResponse) marshal() ([]byte, error) {
+	if r.batch {
+		return json.Marshal(r.responses)
+	}
+	return json.Marshal(r.responses[0])
+}
+
 // All writes to the websocket must (re)set the write deadline.
 // If some writes don't set it while others do, they may timeout incorrectly
 // (https://github.com/tendermint/tendermint/issues/553)
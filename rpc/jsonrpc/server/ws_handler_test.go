@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/cometbft/cometbft/libs/log"
 	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
@@ -43,11 +44,129 @@ func TestWebsocketManagerHandler(t *testing.T) {
 	}
 }
 
-func newWSServer() *httptest.Server {
+func TestWebsocketManagerBatch(t *testing.T) {
+	s := newWSServer()
+	defer s.Close()
+
+	d := websocket.Dialer{}
+	c, dialResp, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	req1, err := types.MapToRequest(types.JSONRPCIntID(1), "c", map[string]interface{}{"s": "a", "i": 10})
+	require.NoError(t, err)
+	req2, err := types.MapToRequest(types.JSONRPCIntID(2), "unknown", map[string]interface{}{})
+	require.NoError(t, err)
+	notification, err := types.MapToRequest(nil, "c", map[string]interface{}{"s": "a", "i": 10})
+	require.NoError(t, err)
+	req3, err := types.MapToRequest(types.JSONRPCIntID(3), "c", map[string]interface{}{"s": "b", "i": 20})
+	require.NoError(t, err)
+
+	require.NoError(t, c.WriteJSON([]types.RPCRequest{req1, req2, notification, req3}))
+
+	var resps []types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resps))
+	require.Len(t, resps, 3)
+	require.Equal(t, types.JSONRPCIntID(1), resps[0].ID)
+	require.Nil(t, resps[0].Error)
+	require.Equal(t, types.JSONRPCIntID(2), resps[1].ID)
+	require.NotNil(t, resps[1].Error)
+	require.Equal(t, types.JSONRPCIntID(3), resps[2].ID)
+	require.Nil(t, resps[2].Error)
+
+	// an empty batch is an invalid request
+	require.NoError(t, c.WriteMessage(websocket.TextMessage, []byte("[]")))
+	var resp types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resp))
+	require.NotNil(t, resp.Error)
+
+	// single requests still work on the same connection
+	require.NoError(t, c.WriteJSON(req1))
+	require.NoError(t, c.ReadJSON(&resp))
+	require.Nil(t, resp.Error)
+}
+
+func TestWebsocketManagerMaxBatchSize(t *testing.T) {
+	s := newWSServer(MaxBatchSize(2))
+	defer s.Close()
+
+	d := websocket.Dialer{}
+	c, dialResp, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	call := func(id int) types.RPCRequest {
+		req, err := types.MapToRequest(types.JSONRPCIntID(id), "c", map[string]interface{}{"s": "a", "i": 10})
+		require.NoError(t, err)
+		return req
+	}
+
+	// batches larger than the maximum are rejected as a whole
+	require.NoError(t, c.WriteJSON([]types.RPCRequest{call(1), call(2), call(3)}))
+	var resp types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resp))
+	require.NotNil(t, resp.Error)
+	require.Contains(t, resp.Error.Data, "exceeds the maximum of 2")
+
+	require.NoError(t, c.WriteJSON([]types.RPCRequest{call(4), call(5)}))
+	var resps []types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resps))
+	require.Len(t, resps, 2)
+}
+
+func TestWebsocketManagerRateLimit(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{}, map[string]RateLimit{"c": {Rate: 1, Burst: 2}})
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+	s := newWSServer(CallRateLimiter(rl))
+	defer s.Close()
+
+	d := websocket.Dialer{}
+	c, dialResp, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	call := func(id int) types.RPCRequest {
+		req, err := types.MapToRequest(types.JSONRPCIntID(id), "c", map[string]interface{}{"s": "a", "i": 10})
+		require.NoError(t, err)
+		return req
+	}
+	var resp types.RPCResponse
+
+	// the calls are limited on the open connection
+	for id := 1; id <= 2; id++ {
+		require.NoError(t, c.WriteJSON(call(id)))
+		require.NoError(t, c.ReadJSON(&resp))
+		require.Nil(t, resp.Error)
+	}
+	require.NoError(t, c.WriteJSON(call(3)))
+	require.NoError(t, c.ReadJSON(&resp))
+	require.NotNil(t, resp.Error)
+	require.Contains(t, resp.Error.Data, errRateLimited.Error())
+
+	// and so are the calls of a batch, as a whole
+	rl.mtx.Lock()
+	now = now.Add(time.Second)
+	rl.mtx.Unlock()
+	require.NoError(t, c.WriteJSON([]types.RPCRequest{call(4), call(5)}))
+	require.NoError(t, c.ReadJSON(&resp))
+	require.NotNil(t, resp.Error)
+	require.Contains(t, resp.Error.Data, errRateLimited.Error())
+	require.NoError(t, c.WriteJSON([]types.RPCRequest{call(6)}))
+	var resps []types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resps))
+	require.Len(t, resps, 1)
+	require.Nil(t, resps[0].Error)
+}
+
+func newWSServer(options ...func(*wsConnection)) *httptest.Server {
 	funcMap := map[string]*RPCFunc{
 		"c": NewWSRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
 	}
-	wm := NewWebsocketManager(funcMap)
+	wm := NewWebsocketManager(funcMap, options...)
 	wm.SetLogger(log.TestingLogger())
 
 	mux := http.NewServeMux()
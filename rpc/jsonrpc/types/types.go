@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -141,6 +142,54 @@ type RPCError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Data    string `json:"data,omitempty"`
+	// DataObject is the structured data of the error, if any. It is sent as
+	// the data member instead of Data, which holds its textual form.
+	DataObject json.RawMessage `json:"-"`
+}
+
+// DataError is implemented by the errors returned by the RPC functions which
+// carry a JSON-RPC error code and structured data, see RPCFuncError.
+type DataError interface {
+	error
+	RPCErrorCode() int
+	RPCErrorData() interface{}
+}
+
+func (err RPCError) MarshalJSON() ([]byte, error) {
+	type rpcError struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data,omitempty"`
+	}
+	e := rpcError{Code: err.Code, Message: err.Message, Data: err.DataObject}
+	if e.Data == nil && err.Data != "" {
+		data, mErr := json.Marshal(err.Data)
+		if mErr != nil {
+			return nil, mErr
+		}
+		e.Data = data
+	}
+	return json.Marshal(e)
+}
+
+func (err *RPCError) UnmarshalJSON(data []byte) error {
+	e := &struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data,omitempty"`
+	}{}
+	if uErr := json.Unmarshal(data, e); uErr != nil {
+		return uErr
+	}
+	*err = RPCError{Code: e.Code, Message: e.Message}
+	if len(e.Data) == 0 || string(e.Data) == "null" {
+		return nil
+	}
+	if json.Unmarshal(e.Data, &err.Data) != nil {
+		err.Data = string(e.Data)
+		err.DataObject = e.Data
+	}
+	return nil
 }
 
 func (err RPCError) Error() string {
@@ -246,6 +295,22 @@ func RPCServerError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32000, "Server error", err.Error())
 }
 
+// RPCFuncError returns the response to an error returned by an RPC function:
+// its own code and data if it is a DataError, an internal error otherwise.
+func RPCFuncError(id jsonrpcid, err error) RPCResponse {
+	var dataErr DataError
+	if !errors.As(err, &dataErr) {
+		return RPCInternalError(id, err)
+	}
+	data, mErr := cmtjson.Marshal(dataErr.RPCErrorData())
+	if mErr != nil {
+		return RPCInternalError(id, err)
+	}
+	resp := NewRPCErrorResponse(id, dataErr.RPCErrorCode(), err.Error(), string(data))
+	resp.Error.DataObject = data
+	return resp
+}
+
 //----------------------------------------
 
 // WSRPCConnection represents a websocket connection.
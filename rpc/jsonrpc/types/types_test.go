@@ -81,3 +81,38 @@ func TestRPCError(t *testing.T) {
 			Message: "Badness",
 		}))
 }
+
+type sampleDataError struct{}
+
+func (sampleDataError) Error() string             { return "sample failure" }
+func (sampleDataError) RPCErrorCode() int         { return -32001 }
+func (sampleDataError) RPCErrorData() interface{} { return SampleResult{"hello"} }
+
+func TestRPCFuncError(t *testing.T) {
+	// An error carrying structured data is sent as is.
+	resp := RPCFuncError(JSONRPCIntID(1), fmt.Errorf("wrapped: %w", sampleDataError{}))
+	bz, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	assert.JSONEq(t,
+		`{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"wrapped: sample failure","data":{"Value":"hello"}}}`,
+		string(bz))
+
+	decoded := &RPCResponse{}
+	assert.NoError(t, json.Unmarshal(bz, decoded))
+	assert.Equal(t, -32001, decoded.Error.Code)
+	assert.JSONEq(t, `{"Value":"hello"}`, string(decoded.Error.DataObject))
+	assert.Equal(t, `{"Value":"hello"}`, decoded.Error.Data)
+
+	// Other errors are internal errors, with a textual data.
+	resp = RPCFuncError(JSONRPCIntID(1), errors.New("failure"))
+	bz, err = json.Marshal(resp)
+	assert.NoError(t, err)
+	assert.JSONEq(t,
+		`{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"Internal error","data":"failure"}}`,
+		string(bz))
+
+	decoded = &RPCResponse{}
+	assert.NoError(t, json.Unmarshal(bz, decoded))
+	assert.Equal(t, "failure", decoded.Error.Data)
+	assert.Nil(t, decoded.Error.DataObject)
+}
@@ -0,0 +1,440 @@
+// Package rest exposes the core RPC methods as a REST API with an OpenAPI
+// specification, for web clients which prefer plain HTTP resources and status
+// codes to JSON-RPC envelopes.
+//
+// The core RPC methods are not defined as protobuf services, so this facade
+// maps REST resources directly onto the methods of rpc/core.Environment
+// rather than being generated with gRPC-gateway. Responses are encoded the
+// same way as the "result" field of the JSON-RPC responses.
+//
+// All resources are served under the /rest/v1/ prefix:
+//
+//	GET  /rest/v1/health
+//	GET  /rest/v1/status
+//	GET  /rest/v1/net_info
+//	GET  /rest/v1/abci_info
+//	GET  /rest/v1/abci_query?path=_&data=_&height=_&prove=_
+//	GET  /rest/v1/blocks?query=_&page=_&per_page=_&order_by=_
+//	GET  /rest/v1/blocks/{height|latest}
+//	GET  /rest/v1/blocks/{height|latest}/header
+//	GET  /rest/v1/blocks/{height|latest}/commit
+//	GET  /rest/v1/blocks/{height|latest}/results
+//	GET  /rest/v1/validators/{height|latest}?page=_&per_page=_
+//	GET  /rest/v1/consensus_params/{height|latest}
+//	GET  /rest/v1/txs?query=_&prove=_&page=_&per_page=_&order_by=_
+//	GET  /rest/v1/txs/{hash}?prove=_
+//	POST /rest/v1/txs?mode={sync|async|commit}
+//	GET  /rest/v1/unconfirmed_txs?limit=_&sender=_&order_by=_&cursor=_
+//	GET  /rest/v1/openapi.yaml
+package rest
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/log"
+	mempl "github.com/cometbft/cometbft/mempool"
+	"github.com/cometbft/cometbft/rpc/core"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Prefix is the path prefix under which the REST API is served.
+const Prefix = "/rest/v1/"
+
+// Methods returns the core RPC method called by a REST request, so that the
+// RPC rate limits apply to the REST resources as well. Unknown resources, and
+// the specification, count as a call to no method, under the default limit.
+// It returns false for the requests outside of Prefix. It is a
+// rpcserver.RequestMethodsFunc.
+func Methods(r *http.Request) ([]string, bool) {
+	if !strings.HasPrefix(r.URL.Path, Prefix) {
+		return nil, false
+	}
+	return []string{routeMethod(r.Method, pathSegments(r), r.URL.Query().Get("mode"))}, true
+}
+
+// routeMethod returns the core RPC method served by the REST resource with
+// the given path segments, or "" if there is none.
+func routeMethod(method string, segments []string, mode string) string {
+	if method == http.MethodPost {
+		if len(segments) != 1 || segments[0] != "txs" {
+			return ""
+		}
+		switch mode {
+		case "", "sync":
+			return "broadcast_tx_sync"
+		case "async":
+			return "broadcast_tx_async"
+		case "commit":
+			return "broadcast_tx_commit"
+		}
+		return ""
+	}
+	if method != http.MethodGet {
+		return ""
+	}
+	switch {
+	case len(segments) == 1:
+		switch segments[0] {
+		case "health", "status", "net_info", "abci_info", "abci_query", "unconfirmed_txs":
+			return segments[0]
+		case "blocks":
+			return "block_search"
+		case "txs":
+			return "tx_search"
+		}
+	case len(segments) == 2:
+		switch segments[0] {
+		case "blocks":
+			return "block"
+		case "validators", "consensus_params":
+			return segments[0]
+		case "txs":
+			return "tx"
+		}
+	case len(segments) == 3 && segments[0] == "blocks":
+		switch segments[2] {
+		case "header", "commit":
+			return segments[2]
+		case "results":
+			return "block_results"
+		}
+	}
+	return ""
+}
+
+func pathSegments(r *http.Request) []string {
+	return strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/"), "/")
+}
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// errBadRequest wraps errors caused by invalid request parameters.
+type errBadRequest struct {
+	err error
+}
+
+func (e errBadRequest) Error() string { return e.err.Error() }
+
+func (e errBadRequest) Unwrap() error { return e.err }
+
+// errorResponse is the body of all non-2xx responses.
+type errorResponse struct {
+	Error   string          `json:"error"`
+	TxError *ctypes.TxError `json:"tx_error,omitempty"`
+}
+
+type handler struct {
+	env    *core.Environment
+	logger log.Logger
+}
+
+// NewHandler returns an http.Handler serving the REST API backed by env. It
+// must be registered at Prefix.
+func NewHandler(env *core.Environment, logger log.Logger) http.Handler {
+	return &handler{env: env, logger: logger}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r)
+	if r.Method == http.MethodPost {
+		if len(segments) == 1 && segments[0] == "txs" {
+			h.broadcastTx(w, r)
+			return
+		}
+		h.writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	ctx := &rpctypes.Context{HTTPReq: r}
+	q := r.URL.Query()
+	var (
+		res interface{}
+		err error
+	)
+	switch {
+	case len(segments) == 1 && segments[0] == "openapi.yaml":
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(openAPISpec)
+		return
+	case len(segments) == 1 && segments[0] == "health":
+		health, err := h.env.Health(ctx)
+		if err == nil && !health.Ready {
+			// Lets readiness probes check the status code only.
+			h.writeJSON(w, http.StatusServiceUnavailable, health)
+			return
+		}
+		h.writeResult(w, health, err)
+		return
+	case len(segments) == 1 && segments[0] == "status":
+		res, err = h.env.Status(ctx)
+	case len(segments) == 1 && segments[0] == "net_info":
+		res, err = h.env.NetInfo(ctx)
+	case len(segments) == 1 && segments[0] == "abci_info":
+		res, err = h.env.ABCIInfo(ctx)
+	case len(segments) == 1 && segments[0] == "abci_query":
+		res, err = h.abciQuery(ctx, q)
+	case len(segments) == 1 && segments[0] == "blocks":
+		res, err = h.blockSearch(ctx, q)
+	case len(segments) == 2 && segments[0] == "blocks":
+		res, err = withHeight(segments[1], func(height *int64) (interface{}, error) {
+			return h.env.Block(ctx, height)
+		})
+	case len(segments) == 3 && segments[0] == "blocks":
+		res, err = h.blockSubresource(ctx, segments[1], segments[2])
+	case len(segments) == 2 && segments[0] == "validators":
+		res, err = withHeight(segments[1], func(height *int64) (interface{}, error) {
+			page, perPage, err := pagination(q)
+			if err != nil {
+				return nil, err
+			}
+			return h.env.Validators(ctx, height, page, perPage)
+		})
+	case len(segments) == 2 && segments[0] == "consensus_params":
+		res, err = withHeight(segments[1], func(height *int64) (interface{}, error) {
+			return h.env.ConsensusParams(ctx, height)
+		})
+	case len(segments) == 1 && segments[0] == "txs":
+		res, err = h.txSearch(ctx, q)
+	case len(segments) == 2 && segments[0] == "txs":
+		res, err = h.tx(ctx, segments[1], q)
+	case len(segments) == 1 && segments[0] == "unconfirmed_txs":
+		var limit *int
+		if limit, err = optionalInt(q.Get("limit"), "limit"); err == nil {
+			res, err = h.env.UnconfirmedTxs(ctx, limit, q.Get("sender"), q.Get("order_by"), q.Get("cursor"))
+		}
+	default:
+		h.writeError(w, http.StatusNotFound, fmt.Errorf("unknown resource %s", r.URL.Path))
+		return
+	}
+
+	h.writeResult(w, res, err)
+}
+
+func (h *handler) blockSubresource(ctx *rpctypes.Context, heightStr, sub string) (interface{}, error) {
+	return withHeight(heightStr, func(height *int64) (interface{}, error) {
+		switch sub {
+		case "header":
+			return h.env.Header(ctx, height)
+		case "commit":
+			return h.env.Commit(ctx, height)
+		case "results":
+			return h.env.BlockResults(ctx, height)
+		default:
+			return nil, errNotFound{fmt.Errorf("unknown block resource %q", sub)}
+		}
+	})
+}
+
+func (h *handler) abciQuery(ctx *rpctypes.Context, q map[string][]string) (interface{}, error) {
+	data, err := hex.DecodeString(first(q, "data"))
+	if err != nil {
+		return nil, errBadRequest{fmt.Errorf("invalid data: %w", err)}
+	}
+	var height int64
+	if s := first(q, "height"); s != "" {
+		if height, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, errBadRequest{fmt.Errorf("invalid height: %w", err)}
+		}
+	}
+	prove, err := optionalBool(first(q, "prove"), "prove")
+	if err != nil {
+		return nil, err
+	}
+	return h.env.ABCIQuery(ctx, first(q, "path"), data, height, prove)
+}
+
+func (h *handler) blockSearch(ctx *rpctypes.Context, q map[string][]string) (interface{}, error) {
+	page, perPage, err := pagination(q)
+	if err != nil {
+		return nil, err
+	}
+	return h.env.BlockSearch(ctx, first(q, "query"), page, perPage, first(q, "order_by"))
+}
+
+func (h *handler) txSearch(ctx *rpctypes.Context, q map[string][]string) (interface{}, error) {
+	page, perPage, err := pagination(q)
+	if err != nil {
+		return nil, err
+	}
+	prove, err := optionalBool(first(q, "prove"), "prove")
+	if err != nil {
+		return nil, err
+	}
+	return h.env.TxSearch(ctx, first(q, "query"), prove, page, perPage, first(q, "order_by"))
+}
+
+func (h *handler) tx(ctx *rpctypes.Context, hashStr string, q map[string][]string) (interface{}, error) {
+	hash, err := hex.DecodeString(strings.TrimPrefix(hashStr, "0x"))
+	if err != nil {
+		return nil, errBadRequest{fmt.Errorf("invalid hash: %w", err)}
+	}
+	prove, err := optionalBool(first(q, "prove"), "prove")
+	if err != nil {
+		return nil, err
+	}
+	return h.env.Tx(ctx, hash, prove)
+}
+
+// broadcastTx broadcasts the raw transaction in the request body.
+func (h *handler) broadcastTx(w http.ResponseWriter, r *http.Request) {
+	tx, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Errorf("reading body: %w", err))
+		return
+	}
+	if len(tx) == 0 {
+		h.writeError(w, http.StatusBadRequest, errors.New("empty transaction"))
+		return
+	}
+
+	ctx := &rpctypes.Context{HTTPReq: r}
+	var res interface{}
+	switch mode := r.URL.Query().Get("mode"); mode {
+	case "", "sync":
+		res, err = h.env.BroadcastTxSync(ctx, types.Tx(tx))
+	case "async":
+		res, err = h.env.BroadcastTxAsync(ctx, types.Tx(tx))
+	case "commit":
+		res, err = h.env.BroadcastTxCommit(ctx, types.Tx(tx))
+	default:
+		err = errBadRequest{fmt.Errorf("unknown mode %q (use sync, async or commit)", mode)}
+	}
+	h.writeResult(w, res, err)
+}
+
+func (h *handler) writeResult(w http.ResponseWriter, res interface{}, err error) {
+	if err != nil {
+		h.writeError(w, statusCode(err), err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) writeError(w http.ResponseWriter, code int, err error) {
+	res := errorResponse{Error: err.Error()}
+	var txErr ctypes.TxError
+	if errors.As(err, &txErr) {
+		res.TxError = &txErr
+	}
+	h.writeJSON(w, code, res)
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	bz, err := cmtjson.Marshal(v)
+	if err != nil {
+		h.logger.Error("failed to marshal REST response", "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(bz); err != nil {
+		h.logger.Error("failed to write REST response", "err", err)
+	}
+}
+
+// errNotFound wraps errors caused by requesting unknown resources.
+type errNotFound struct {
+	err error
+}
+
+func (e errNotFound) Error() string { return e.err.Error() }
+
+func (e errNotFound) Unwrap() error { return e.err }
+
+// statusCode maps errors returned by rpc/core onto HTTP status codes.
+func statusCode(err error) int {
+	var (
+		badRequest   errBadRequest
+		notFound     errNotFound
+		invalid      core.ErrInvalidHeight
+		exceedsHead  core.ErrHeightExceedsChainHead
+		notAvailable core.ErrHeightNotAvailable
+		txNotFound   core.ErrTxNotFound
+		txErr        ctypes.TxError
+	)
+	switch {
+	case errors.As(err, &badRequest), errors.As(err, &invalid), errors.Is(err, mempl.ErrInvalidCursor):
+		return http.StatusBadRequest
+	case errors.As(err, &notFound), errors.As(err, &exceedsHead), errors.As(err, &txNotFound):
+		return http.StatusNotFound
+	case errors.As(err, &notAvailable):
+		return http.StatusGone
+	case errors.Is(err, core.ErrTxIndexingDisabled), errors.Is(err, core.ErrBlockIndexingDisabled):
+		return http.StatusNotImplemented
+	case errors.As(err, &txErr):
+		if txErr.Retryable {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// withHeight parses a height path segment ("latest" or a positive integer)
+// and calls fn with it.
+func withHeight(s string, fn func(*int64) (interface{}, error)) (interface{}, error) {
+	if s == "latest" {
+		return fn(nil)
+	}
+	height, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, errBadRequest{fmt.Errorf("invalid height %q", s)}
+	}
+	return fn(&height)
+}
+
+func pagination(q map[string][]string) (page, perPage *int, err error) {
+	if page, err = optionalInt(first(q, "page"), "page"); err != nil {
+		return nil, nil, err
+	}
+	if perPage, err = optionalInt(first(q, "per_page"), "per_page"); err != nil {
+		return nil, nil, err
+	}
+	return page, perPage, nil
+}
+
+func optionalInt(s, name string) (*int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, errBadRequest{fmt.Errorf("invalid %s: %w", name, err)}
+	}
+	return &i, nil
+}
+
+func optionalBool(s, name string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errBadRequest{fmt.Errorf("invalid %s: %w", name, err)}
+	}
+	return b, nil
+}
+
+func first(q map[string][]string, key string) string {
+	if vs := q[key]; len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
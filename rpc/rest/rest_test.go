@@ -0,0 +1,173 @@
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/state/mocks"
+	"github.com/cometbft/cometbft/internal/state/txindex/null"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/rpc/core"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+)
+
+func TestHandler(t *testing.T) {
+	env := &core.Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	err := env.StateStore.SaveFinalizeBlockResponse(100, &abci.FinalizeBlockResponse{
+		TxResults: []*abci.ExecTxResult{{Code: 0, Log: "ok"}},
+	})
+	require.NoError(t, err)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(100))
+	blockStore.On("Base").Return(int64(10))
+	env.BlockStore = blockStore
+	env.TxIndexer = &null.TxIndex{}
+
+	h := NewHandler(env, log.TestingLogger())
+
+	testCases := []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/rest/v1/health", http.StatusOK},
+		{http.MethodGet, "/rest/v1/openapi.yaml", http.StatusOK},
+		{http.MethodGet, "/rest/v1/blocks/100/results", http.StatusOK},
+		{http.MethodGet, "/rest/v1/blocks/latest/results", http.StatusOK},
+		{http.MethodGet, "/rest/v1/blocks/0/results", http.StatusBadRequest},
+		{http.MethodGet, "/rest/v1/blocks/abc/results", http.StatusBadRequest},
+		{http.MethodGet, "/rest/v1/blocks/101/results", http.StatusNotFound},
+		{http.MethodGet, "/rest/v1/blocks/5/results", http.StatusGone},
+		{http.MethodGet, "/rest/v1/blocks/100/unknown", http.StatusNotFound},
+		{http.MethodGet, "/rest/v1/txs/abcd", http.StatusNotImplemented},
+		{http.MethodGet, "/rest/v1/txs/xyz", http.StatusBadRequest},
+		{http.MethodGet, "/rest/v1/unknown", http.StatusNotFound},
+		{http.MethodPut, "/rest/v1/txs", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/rest/v1/txs", http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.code, rec.Code, "%s %s: %s", tc.method, tc.path, rec.Body.String())
+
+		if tc.code != http.StatusOK {
+			var res errorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.NotEmpty(t, res.Error)
+		}
+	}
+}
+
+func TestMethods(t *testing.T) {
+	testCases := []struct {
+		method string
+		target string
+		want   string
+	}{
+		{http.MethodGet, "/rest/v1/status", "status"},
+		{http.MethodGet, "/rest/v1/blocks?query=x", "block_search"},
+		{http.MethodGet, "/rest/v1/blocks/latest", "block"},
+		{http.MethodGet, "/rest/v1/blocks/10/header", "header"},
+		{http.MethodGet, "/rest/v1/blocks/10/results", "block_results"},
+		{http.MethodGet, "/rest/v1/txs?query=x", "tx_search"},
+		{http.MethodGet, "/rest/v1/txs/abcd", "tx"},
+		{http.MethodPost, "/rest/v1/txs", "broadcast_tx_sync"},
+		{http.MethodPost, "/rest/v1/txs?mode=commit", "broadcast_tx_commit"},
+		{http.MethodPost, "/rest/v1/txs?mode=x", ""},
+		{http.MethodGet, "/rest/v1/unknown", ""},
+		{http.MethodGet, "/rest/v1/openapi.yaml", ""},
+	}
+	for _, tc := range testCases {
+		methods, ok := Methods(httptest.NewRequest(tc.method, tc.target, nil))
+		assert.True(t, ok, tc.target)
+		assert.Equal(t, []string{tc.want}, methods, "%s %s", tc.method, tc.target)
+	}
+
+	_, ok := Methods(httptest.NewRequest(http.MethodGet, "/status", nil))
+	assert.False(t, ok)
+}
+
+// TestRateLimit checks that the REST resources share the rate limits of the
+// RPC methods they call.
+func TestRateLimit(t *testing.T) {
+	rl := rpcserver.NewRateLimiter(rpcserver.RateLimit{}, map[string]rpcserver.RateLimit{"tx_search": {Rate: 1, Burst: 1}})
+	h := rpcserver.RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), rl, Methods)
+
+	do := func(target string) int {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.RemoteAddr = "1.2.3.4:5678"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do("/rest/v1/txs?query=x"))
+	assert.Equal(t, http.StatusTooManyRequests, do("/rest/v1/txs?query=y"))
+	assert.Equal(t, http.StatusTooManyRequests, do("/tx_search?query=x"))
+	assert.Equal(t, http.StatusOK, do("/rest/v1/status"))
+}
+
+var (
+	specPath   = regexp.MustCompile(`^  (/\S*):$`)
+	specMethod = regexp.MustCompile(`^    (get|post|put|delete):$`)
+)
+
+// TestOpenAPISpec checks that the handler serves every operation of the
+// OpenAPI specification, so that they do not drift apart.
+func TestOpenAPISpec(t *testing.T) {
+	h := NewHandler(&core.Environment{}, log.TestingLogger())
+
+	operations := 0
+	var path string
+	scanner := bufio.NewScanner(bytes.NewReader(openAPISpec))
+	for scanner.Scan() {
+		if m := specPath.FindStringSubmatch(scanner.Text()); m != nil {
+			path = m[1]
+			continue
+		}
+		m := specMethod.FindStringSubmatch(scanner.Text())
+		if m == nil || path == "" {
+			continue
+		}
+		method := strings.ToUpper(m[1])
+		target := strings.NewReplacer("{height}", "latest", "{hash}", "00").Replace(Prefix + strings.TrimPrefix(path, "/"))
+		assert.True(t, serves(h, method, target), "%s %s is not served", method, path)
+		if path != "/openapi.yaml" {
+			methods, ok := Methods(httptest.NewRequest(method, target, nil))
+			assert.True(t, ok)
+			assert.NotEqual(t, []string{""}, methods, "%s %s is not mapped to a method", method, path)
+		}
+		operations++
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, 16, operations)
+}
+
+// serves returns whether h routes the request to the environment, which
+// panics as it is empty, rather than rejecting it.
+func serves(h http.Handler, method, target string) (served bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			served = true
+		}
+	}()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader("tx")))
+	return rec.Code != http.StatusNotFound && rec.Code != http.StatusMethodNotAllowed
+}
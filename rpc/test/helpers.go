@@ -158,7 +158,7 @@ func NewCometBFT(app abci.Application, opts *Options) *nm.Node {
 	node, err := nm.NewNode(context.Background(), config, pv, nodeKey, papp,
 		nm.DefaultGenesisDocProviderFunc(config),
 		cfg.DefaultDBProvider,
-		nm.DefaultMetricsProvider(config.Instrumentation),
+		nm.DefaultMetricsProvider(config, nodeKey.ID()),
 		logger)
 	if err != nil {
 		panic(err)
@@ -20,7 +20,7 @@ var (
 	// testnetCombinations defines global testnet options, where we generate a
 	// separate testnet for each combination (Cartesian product) of options.
 	testnetCombinations = map[string][]interface{}{
-		"topology":      {"single", "quad", "large"},
+		"topology":      {"single", "quad", "large", "sentry"},
 		"initialHeight": {0, 1000},
 		"initialState": {
 			map[string]string{},
@@ -60,6 +60,8 @@ var (
 	lightNodePerturbations = probSetChoice{
 		"upgrade": 0.3,
 	}
+	networkPartitions               = uniformChoice{false, true}
+	zones                           = uniformChoice{"N_Virginia", "London", "Frankfurt", "S_Paulo", "Tokyo", "Sydney"}
 	voteExtensionEnableHeightOffset = uniformChoice{int64(0), int64(10), int64(100)}
 	voteExtensionEnabled            = uniformChoice{true, false}
 	voteExtensionSize               = uniformChoice{uint(128), uint(512), uint(2048), uint(8192)} // TODO: define the right values depending on experiment results.
@@ -156,12 +158,15 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}, upgradeVersion st
 
 	manifest.VoteExtensionSize = voteExtensionSize.Choose(r).(uint)
 
-	var numSeeds, numValidators, numFulls, numLightClients int
+	var numSeeds, numValidators, numFulls, numLightClients, numSentries int
 	switch opt["topology"].(string) {
 	case "single":
 		numValidators = 1
 	case "quad":
 		numValidators = 4
+	case "sentry":
+		numValidators = 4
+		numSentries = 1 + r.Intn(2)
 	case "large":
 		// FIXME Networks are kept small since large ones use too much CPU.
 		numSeeds = r.Intn(2)
@@ -223,11 +228,32 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}, upgradeVersion st
 			r, e2e.ModeFull, startAt, false)
 	}
 
+	// Validators may be placed behind sentries, each validator and its
+	// sentries being in the same zone.
+	if numSentries > 0 {
+		for i := 1; i <= numValidators; i++ {
+			validator := manifest.Nodes[fmt.Sprintf("validator%02d", i)]
+			validator.Zone = zones.Choose(r).(string)
+			for j := 1; j <= numSentries; j++ {
+				name := fmt.Sprintf("sentry%02d", (i-1)*numSentries+j)
+				sentry := generateNode(r, e2e.ModeFull, 0, false)
+				sentry.Zone = validator.Zone
+				manifest.Nodes[name] = sentry
+				validator.Sentries = append(validator.Sentries, name)
+			}
+		}
+		manifest.DefaultZone = zones.Choose(r).(string)
+	}
+
 	// We now set up peer discovery for nodes. Seed nodes are fully meshed with
 	// each other, while non-seed nodes either use a set of random seeds or a
-	// set of random peers that start before themselves.
+	// set of random peers that start before themselves. Validators behind
+	// sentries only connect to them.
 	var seedNames, peerNames, lightProviders []string
 	for name, node := range manifest.Nodes {
+		if len(node.Sentries) > 0 {
+			continue
+		}
 		if node.Mode == string(e2e.ModeSeed) {
 			seedNames = append(seedNames, name)
 		} else {
@@ -267,6 +293,22 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}, upgradeVersion st
 		}
 	}
 
+	// Partition the network in two halves of the validators, along with their
+	// sentries, once all validators have joined.
+	if numValidators >= 4 && networkPartitions.Choose(r).(bool) {
+		var group []string
+		for i := 1; i <= numValidators/2; i++ {
+			name := fmt.Sprintf("validator%02d", i)
+			group = append(group, name)
+			group = append(group, manifest.Nodes[name].Sentries...)
+		}
+		manifest.Partitions = []e2e.ManifestPartition{{
+			Height:   nextStartAt + 10,
+			Duration: 10 * time.Second,
+			Groups:   [][]string{group},
+		}}
+	}
+
 	// lastly, set up the light clients
 	for i := 1; i <= numLightClients; i++ {
 		startAt := manifest.InitialHeight + 5
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,6 +31,48 @@ func TestGenerator(t *testing.T) {
 	}
 }
 
+// TestGeneratorTopologies tests that validators behind sentries are only
+// reachable through them, and that partitions cover all nodes.
+func TestGeneratorTopologies(t *testing.T) {
+	cfg := &generateConfig{
+		randSource: rand.New(rand.NewSource(randomSeed)),
+	}
+	manifests, err := Generate(cfg)
+	require.NoError(t, err)
+
+	var sentries, partitions int
+	for idx, m := range manifests {
+		infra, err := e2e.NewDockerInfrastructureData(m)
+		require.NoError(t, err)
+		testnet, err := e2e.NewTestnetFromManifest(m, filepath.Join(t.TempDir(), fmt.Sprintf("Case%04d", idx)), infra)
+		require.NoError(t, err)
+
+		for _, node := range testnet.Nodes {
+			if len(node.Sentries) == 0 {
+				continue
+			}
+			sentries++
+			assert.ElementsMatch(t, node.Sentries, node.PersistentPeers)
+			for _, peer := range testnet.Nodes {
+				if !slices.Contains(node.Sentries, peer) {
+					assert.NotContains(t, peer.PersistentPeers, node)
+					assert.NotContains(t, peer.Seeds, node)
+				}
+			}
+		}
+		for _, partition := range testnet.Partitions {
+			partitions++
+			var nodes []*e2e.Node
+			for _, group := range partition.Groups {
+				nodes = append(nodes, group...)
+			}
+			assert.ElementsMatch(t, testnet.Nodes, nodes)
+		}
+	}
+	assert.Positive(t, sentries)
+	assert.Positive(t, partitions)
+}
+
 func TestVersionFinder(t *testing.T) {
 	testCases := []struct {
 		baseVer        string
@@ -0,0 +1,96 @@
+//go:build byzantine
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	cs "github.com/cometbft/cometbft/internal/consensus"
+	"github.com/cometbft/cometbft/node"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/types"
+)
+
+// byzantineOption returns the option making the validator misbehave as
+// configured.
+func byzantineOption(cfg *Config, pv *privval.FilePV) (node.Option, error) {
+	byzantine, err := newByzantine(cfg, pv)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Running in byzantine mode", "misbehaviors", byzantine.Misbehaviors())
+	return node.ConsensusByzantine(byzantine), nil
+}
+
+// newByzantine sets up the misbehaviors of the validator from the
+// configuration and, if configured, starts the HTTP API controlling them.
+func newByzantine(cfg *Config, pv *privval.FilePV) (*cs.Byzantine, error) {
+	// the file signer refuses to double sign, so conflicting messages are
+	// signed with the same key by a mock signer
+	byzantine := cs.NewByzantine(types.NewMockPVWithParams(pv.Key.PrivKey, false, false))
+	for heightStr, misbehavior := range cfg.Misbehaviors {
+		height, err := strconv.ParseInt(heightStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid misbehavior height %q: %w", heightStr, err)
+		}
+		if err := byzantine.Set(height, cs.Misbehavior(misbehavior)); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ByzantineListen != "" {
+		srv := &http.Server{
+			Addr:              cfg.ByzantineListen,
+			Handler:           byzantineHandler(byzantine),
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("Byzantine control API failed", "err", err)
+			}
+		}()
+		logger.Info("Byzantine control API listening", "addr", cfg.ByzantineListen)
+	}
+	return byzantine, nil
+}
+
+// byzantineHandler serves the API controlling the misbehaviors of the
+// validator:
+//
+//	GET    /misbehaviors                                  lists them, by height
+//	PUT    /misbehaviors?height=10&misbehavior=equivocate sets one
+//	DELETE /misbehaviors?height=10                        clears one
+func byzantineHandler(byzantine *cs.Byzantine) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/misbehaviors", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			height, err := strconv.ParseInt(r.URL.Query().Get("height"), 10, 64)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid height: %v", err), http.StatusBadRequest)
+				return
+			}
+			switch r.Method {
+			case http.MethodPut:
+				misbehavior := cs.Misbehavior(r.URL.Query().Get("misbehavior"))
+				if err := byzantine.Set(height, misbehavior); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				logger.Info("Byzantine: misbehavior set", "height", height, "misbehavior", misbehavior)
+			case http.MethodDelete:
+				byzantine.Clear(height)
+				logger.Info("Byzantine: misbehavior cleared", "height", height)
+			default:
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(byzantine.Misbehaviors())
+	})
+	return mux
+}
@@ -0,0 +1,16 @@
+//go:build !byzantine
+
+package main
+
+import (
+	"errors"
+
+	"github.com/cometbft/cometbft/node"
+	"github.com/cometbft/cometbft/privval"
+)
+
+// byzantineOption fails, the misbehaviors being only built with the byzantine
+// tag.
+func byzantineOption(*Config, *privval.FilePV) (node.Option, error) {
+	return nil, errors.New("misbehaviors are configured, but the node was built without the byzantine tag")
+}
@@ -34,6 +34,12 @@ type Config struct {
 	VoteExtensionSize uint `toml:"vote_extension_size"`
 
 	ABCIRequestsLoggingEnabled bool `toml:"abci_requests_logging_enabled"`
+
+	// Misbehaviors of the validator, by height. Only for builtin protocols.
+	Misbehaviors map[string]string `toml:"misbehaviors"`
+	// ByzantineListen is the address of the HTTP API controlling the
+	// misbehaviors of the validator. Disabled if empty.
+	ByzantineListen string `toml:"byzantine_listen"`
 }
 
 // App extracts out the application specific configuration parameters.
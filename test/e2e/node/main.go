@@ -132,14 +132,25 @@ func startNode(cfg *Config) error {
 		nodeLogger.Info("Using default (synchronized) local client creator")
 	}
 
+	pv := privval.LoadOrGenFilePV(cmtcfg.PrivValidatorKeyFile(), cmtcfg.PrivValidatorStateFile())
+	var options []node.Option
+	if len(cfg.Misbehaviors) > 0 || cfg.ByzantineListen != "" {
+		option, err := byzantineOption(cfg, pv)
+		if err != nil {
+			return err
+		}
+		options = append(options, option)
+	}
+
 	n, err := node.NewNode(context.Background(), cmtcfg,
-		privval.LoadOrGenFilePV(cmtcfg.PrivValidatorKeyFile(), cmtcfg.PrivValidatorStateFile()),
+		pv,
 		nodeKey,
 		clientCreator,
 		node.DefaultGenesisDocProviderFunc(cmtcfg),
 		config.DefaultDBProvider,
-		node.DefaultMetricsProvider(cmtcfg.Instrumentation),
+		node.DefaultMetricsProvider(cmtcfg, nodeKey.ID()),
 		nodeLogger,
+		options...,
 	)
 	if err != nil {
 		return err
@@ -112,6 +112,24 @@ func (p Provider) Reconnect(ctx context.Context, _ string, ip string) error {
 	return execAnsible(ctx, p.Testnet.Dir, playbookFile, []string{ip})
 }
 
+func (p Provider) Partition(ctx context.Context, _ string, ip string, unreachableIPs []string) error {
+	playbook := ansiblePartitionBytes(true, unreachableIPs)
+	playbookFile := getNextPlaybookFilename()
+	if err := p.writePlaybook(playbookFile, playbook); err != nil {
+		return err
+	}
+	return execAnsible(ctx, p.Testnet.Dir, playbookFile, []string{ip})
+}
+
+func (p Provider) HealPartition(ctx context.Context, _ string, ip string, unreachableIPs []string) error {
+	playbook := ansiblePartitionBytes(false, unreachableIPs)
+	playbookFile := getNextPlaybookFilename()
+	if err := p.writePlaybook(playbookFile, playbook); err != nil {
+		return err
+	}
+	return execAnsible(ctx, p.Testnet.Dir, playbookFile, []string{ip})
+}
+
 func (p Provider) CheckUpgraded(_ context.Context, node *e2e.Node) (string, bool, error) {
 	// Upgrade not supported yet by DO provider
 	return node.Name, false, nil
@@ -191,6 +209,22 @@ func ansiblePerturbConnectionBytes(disconnect bool) string {
 	return playbook
 }
 
+func ansiblePartitionBytes(partition bool, ips []string) string {
+	partitioning := "heal partition"
+	op := "-D"
+	if partition {
+		partitioning = "partition"
+		op = "-A"
+	}
+	playbook := basePlaybook
+	for _, ip := range ips {
+		playbook = ansibleAddShellTasks(playbook, partitioning,
+			fmt.Sprintf("iptables %s INPUT -s %s -j DROP", op, ip),
+			fmt.Sprintf("iptables %s OUTPUT -d %s -j DROP", op, ip))
+	}
+	return playbook
+}
+
 // ExecCompose runs a Docker Compose command for a testnet.
 func execAnsible(ctx context.Context, dir, playbook string, nodeIPs []string, args ...string) error {
 	playbook = filepath.Join(dir, playbook)
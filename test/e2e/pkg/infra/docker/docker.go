@@ -3,8 +3,10 @@ package docker
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
@@ -60,6 +62,24 @@ func (p Provider) Reconnect(ctx context.Context, name string, _ string) error {
 	return Exec(ctx, "network", "connect", p.Testnet.Name+"_"+p.Testnet.Name, name)
 }
 
+func (p Provider) Partition(ctx context.Context, name string, _ string, unreachableIPs []string) error {
+	return p.routeBlackholes(ctx, name, "add", unreachableIPs)
+}
+
+func (p Provider) HealPartition(ctx context.Context, name string, _ string, unreachableIPs []string) error {
+	return p.routeBlackholes(ctx, name, "del", unreachableIPs)
+}
+
+// routeBlackholes adds or deletes blackhole routes to the given IPs in the
+// node's container, dropping all traffic to them.
+func (p Provider) routeBlackholes(ctx context.Context, name string, op string, ips []string) error {
+	cmds := make([]string, len(ips))
+	for i, ip := range ips {
+		cmds[i] = fmt.Sprintf("ip route %s blackhole %s", op, ip)
+	}
+	return Exec(ctx, "exec", "--privileged", name, "sh", "-c", strings.Join(cmds, " && "))
+}
+
 func (p Provider) CheckUpgraded(ctx context.Context, node *e2e.Node) (string, bool, error) {
 	testnet := node.Testnet
 	out, err := ExecComposeOutput(ctx, testnet.Dir, "ps", "-q", node.Name)
@@ -32,6 +32,14 @@ type Provider interface {
 	// This should only be called after Disconnect
 	Reconnect(ctx context.Context, name string, ip string) error
 
+	// Makes the nodes with the given IPs unreachable from the node, and
+	// vice versa.
+	Partition(ctx context.Context, name string, ip string, unreachableIPs []string) error
+
+	// Makes the nodes with the given IPs reachable again from the node.
+	// This should only be called after Partition
+	HealPartition(ctx context.Context, name string, ip string, unreachableIPs []string) error
+
 	// Returns the provider's infrastructure data
 	GetInfrastructureData() *e2e.InfrastructureData
 
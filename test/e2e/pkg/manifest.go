@@ -113,6 +113,31 @@ type Manifest struct {
 	// Default geographical zone ID for simulating latencies, assigned to nodes that don't have a
 	// specific zone assigned.
 	DefaultZone string `toml:"default_zone"`
+
+	// Partitions splits the network into groups of nodes that cannot reach
+	// each other, once the network reaches a given height, and heals it after
+	// some time:
+	//
+	// [[partition]]
+	// height = 20
+	// duration = "30s"
+	// groups = [["validator01", "validator02"], ["validator03"]]
+	//
+	// Nodes not listed in any group form one more group. Partitions are
+	// applied in order, after any perturbations.
+	Partitions []ManifestPartition `toml:"partition"`
+}
+
+// ManifestPartition represents a network partition in a testnet manifest.
+type ManifestPartition struct {
+	// Height is the height at which the network is partitioned.
+	Height int64 `toml:"height"`
+
+	// Duration is the time after which the partition is healed.
+	Duration time.Duration `toml:"duration"`
+
+	// Groups lists the names of the nodes in each group.
+	Groups [][]string `toml:"groups"`
 }
 
 // ManifestNode represents a node in a testnet manifest.
@@ -138,6 +163,13 @@ type ManifestNode struct {
 	// this relates to the providers the light client is connected to.
 	PersistentPeers []string `toml:"persistent_peers"`
 
+	// Sentries places the validator behind the given full nodes, as in a
+	// sentry node architecture: the validator only connects to its sentries,
+	// with the PEX reactor disabled, and the sentries do not gossip its
+	// address. Other nodes connect to the sentries instead of the validator.
+	// Cannot be combined with seeds or persistent peers.
+	Sentries []string `toml:"sentries"`
+
 	// Database specifies the database backend: "goleveldb", "cleveldb",
 	// "rocksdb", "boltdb", or "badgerdb". Defaults to goleveldb.
 	Database string `toml:"database"`
@@ -189,6 +221,11 @@ type ManifestNode struct {
 	// restart:    restarts the node, shutting it down with SIGTERM
 	Perturb []string `toml:"perturb"`
 
+	// Misbehaviors makes the validator misbehave at the given heights (as
+	// strings, like ValidatorUpdates): "double-sign", "conflicting-precommits",
+	// "withhold-block-parts" or "equivocate". Requires a builtin ABCI protocol.
+	Misbehaviors map[string]string `toml:"misbehaviors"`
+
 	// SendNoLoad determines if the e2e test should send load to this node.
 	// It defaults to false so unless the configured, the node will
 	// receive load.
@@ -21,6 +21,7 @@ import (
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/secp256k1"
+	cs "github.com/cometbft/cometbft/internal/consensus"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	grpcclient "github.com/cometbft/cometbft/rpc/grpc/client"
 	grpcprivileged "github.com/cometbft/cometbft/rpc/grpc/client/privileged"
@@ -100,6 +101,7 @@ type Testnet struct {
 	ExperimentalMaxGossipConnectionsToNonPersistentPeers uint
 	ABCITestsEnabled                                     bool
 	DefaultZone                                          string
+	Partitions                                           []Partition
 }
 
 // Node represents a CometBFT node in a testnet.
@@ -127,7 +129,10 @@ type Node struct {
 	EnableCompanionPruning  bool
 	Seeds                   []*Node
 	PersistentPeers         []*Node
+	Sentries                []*Node
+	SentryFor               []*Node
 	Perturbations           []Perturbation
+	Misbehaviors            map[int64]string
 	SendNoLoad              bool
 	Prometheus              bool
 	PrometheusProxyPort     uint32
@@ -249,6 +254,7 @@ func NewTestnetFromManifest(manifest Manifest, file string, ifd InfrastructureDa
 			RetainBlocks:            nodeManifest.RetainBlocks,
 			EnableCompanionPruning:  nodeManifest.EnableCompanionPruning,
 			Perturbations:           []Perturbation{},
+			Misbehaviors:            map[int64]string{},
 			SendNoLoad:              nodeManifest.SendNoLoad,
 			Prometheus:              testnet.Prometheus,
 			Zone:                    ZoneID(nodeManifest.Zone),
@@ -280,6 +286,13 @@ func NewTestnetFromManifest(manifest Manifest, file string, ifd InfrastructureDa
 		for _, p := range nodeManifest.Perturb {
 			node.Perturbations = append(node.Perturbations, Perturbation(p))
 		}
+		for heightStr, misbehavior := range nodeManifest.Misbehaviors {
+			height, err := strconv.ParseInt(heightStr, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid misbehavior height %q: %w", heightStr, err)
+			}
+			node.Misbehaviors[height] = misbehavior
+		}
 		if nodeManifest.Zone != "" {
 			node.Zone = ZoneID(nodeManifest.Zone)
 		} else if testnet.DefaultZone != "" {
@@ -289,12 +302,33 @@ func NewTestnetFromManifest(manifest Manifest, file string, ifd InfrastructureDa
 		testnet.Nodes = append(testnet.Nodes, node)
 	}
 
-	// We do a second pass to set up seeds and persistent peers, which allows graph cycles.
+	// We do a second pass to set up sentries, since they affect the default
+	// persistent peers of all nodes.
 	for _, node := range testnet.Nodes {
 		nodeManifest, ok := manifest.Nodes[node.Name]
 		if !ok {
 			return nil, fmt.Errorf("failed to look up manifest for node %q", node.Name)
 		}
+		if len(nodeManifest.Sentries) > 0 && (len(nodeManifest.Seeds) > 0 || len(nodeManifest.PersistentPeers) > 0) {
+			return nil, fmt.Errorf("node %q cannot have both sentries and seeds or persistent peers", node.Name)
+		}
+		for _, sentryName := range nodeManifest.Sentries {
+			sentry := testnet.LookupNode(sentryName)
+			if sentry == nil {
+				return nil, fmt.Errorf("unknown sentry %q for node %q", sentryName, node.Name)
+			}
+			node.Sentries = append(node.Sentries, sentry)
+			sentry.SentryFor = append(sentry.SentryFor, node)
+		}
+	}
+
+	// We do a third pass to set up seeds and persistent peers, which allows graph cycles.
+	for _, node := range testnet.Nodes {
+		nodeManifest := manifest.Nodes[node.Name]
+		if len(node.Sentries) > 0 {
+			node.PersistentPeers = slices.Clone(node.Sentries)
+			continue
+		}
 		for _, seedName := range nodeManifest.Seeds {
 			seed := testnet.LookupNode(seedName)
 			if seed == nil {
@@ -311,15 +345,56 @@ func NewTestnetFromManifest(manifest Manifest, file string, ifd InfrastructureDa
 		}
 
 		// If there are no seeds or persistent peers specified, default to persistent
-		// connections to all other nodes.
+		// connections to all other nodes, except those behind sentries.
 		if len(node.PersistentPeers) == 0 && len(node.Seeds) == 0 {
 			for _, peer := range testnet.Nodes {
-				if peer.Name == node.Name {
+				if peer.Name == node.Name || len(peer.Sentries) > 0 {
 					continue
 				}
 				node.PersistentPeers = append(node.PersistentPeers, peer)
 			}
 		}
+
+		// Sentries always connect to the validators they protect.
+		for _, validator := range node.SentryFor {
+			if !slices.Contains(node.PersistentPeers, validator) {
+				node.PersistentPeers = append(node.PersistentPeers, validator)
+			}
+		}
+	}
+
+	// Set up network partitions.
+	for i, partitionManifest := range manifest.Partitions {
+		partition := Partition{
+			Height:   partitionManifest.Height,
+			Duration: partitionManifest.Duration,
+		}
+		grouped := map[string]bool{}
+		for _, names := range partitionManifest.Groups {
+			group := []*Node{}
+			for _, name := range names {
+				node := testnet.LookupNode(name)
+				if node == nil {
+					return nil, fmt.Errorf("unknown node %q in partition %d", name, i)
+				}
+				if grouped[name] {
+					return nil, fmt.Errorf("node %q is in more than one group of partition %d", name, i)
+				}
+				grouped[name] = true
+				group = append(group, node)
+			}
+			partition.Groups = append(partition.Groups, group)
+		}
+		rest := []*Node{}
+		for _, node := range testnet.Nodes {
+			if !grouped[node.Name] {
+				rest = append(rest, node)
+			}
+		}
+		if len(rest) > 0 {
+			partition.Groups = append(partition.Groups, rest)
+		}
+		testnet.Partitions = append(testnet.Partitions, partition)
 	}
 
 	// Set up genesis validators. If not specified explicitly, use all validator nodes.
@@ -378,6 +453,11 @@ func (t Testnet) Validate() error {
 			return fmt.Errorf("invalid node %q: %w", node.Name, err)
 		}
 	}
+	for i, partition := range t.Partitions {
+		if err := partition.Validate(t); err != nil {
+			return fmt.Errorf("invalid partition %d: %w", i, err)
+		}
+	}
 	return nil
 }
 
@@ -489,6 +569,43 @@ func (n Node) Validate(testnet Testnet) error {
 		return errors.New("snapshot_interval must be less than er equal to retain_blocks")
 	}
 
+	if len(n.Misbehaviors) > 0 {
+		if n.Mode != ModeValidator {
+			return errors.New("only validators can misbehave")
+		}
+		if n.ABCIProtocol != ProtocolBuiltin && n.ABCIProtocol != ProtocolBuiltinConnSync {
+			return errors.New("misbehaving validators must use a builtin ABCI protocol")
+		}
+	}
+	for height, misbehavior := range n.Misbehaviors {
+		if height < n.Testnet.InitialHeight {
+			return fmt.Errorf("misbehavior height %d is lower than initial height %d",
+				height, n.Testnet.InitialHeight)
+		}
+		if err := cs.Misbehavior(misbehavior).ValidateBasic(); err != nil {
+			return err
+		}
+	}
+
+	if len(n.Sentries) > 0 && n.Mode != ModeValidator {
+		return errors.New("only validators can have sentries")
+	}
+	for _, sentry := range n.Sentries {
+		if sentry.Mode != ModeFull {
+			return fmt.Errorf("sentry %q must be a full node", sentry.Name)
+		}
+	}
+	if len(n.SentryFor) > 0 && len(n.Sentries) > 0 {
+		return errors.New("a sentry cannot have sentries")
+	}
+	for _, peers := range [][]*Node{n.Seeds, n.PersistentPeers} {
+		for _, peer := range peers {
+			if len(peer.Sentries) > 0 && !slices.ContainsFunc(peer.Sentries, func(s *Node) bool { return s.Name == n.Name }) {
+				return fmt.Errorf("cannot connect to %q, which is only reachable through its sentries", peer.Name)
+			}
+		}
+	}
+
 	var upgradeFound bool
 	for _, perturbation := range n.Perturbations {
 		switch perturbation {
@@ -506,6 +623,45 @@ func (n Node) Validate(testnet Testnet) error {
 	return nil
 }
 
+// Partition represents a network partition: nodes in different groups cannot
+// reach each other from the time the network reaches Height, until Duration
+// has elapsed.
+type Partition struct {
+	Height   int64
+	Duration time.Duration
+	Groups   [][]*Node
+}
+
+// Validate validates a partition.
+func (p Partition) Validate(testnet Testnet) error {
+	if p.Height < testnet.InitialHeight {
+		return fmt.Errorf("height %d is lower than initial height %d", p.Height, testnet.InitialHeight)
+	}
+	if p.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if len(p.Groups) < 2 {
+		return errors.New("a partition needs at least two groups")
+	}
+	for _, group := range p.Groups {
+		if len(group) == 0 {
+			return errors.New("groups cannot be empty")
+		}
+	}
+	return nil
+}
+
+// Unreachable returns the nodes that node cannot reach during the partition.
+func (p Partition) Unreachable(node *Node) []*Node {
+	var unreachable []*Node
+	for _, group := range p.Groups {
+		if !slices.Contains(group, node) {
+			unreachable = append(unreachable, group...)
+		}
+	}
+	return unreachable
+}
+
 // LookupNode looks up a node by name. For now, simply do a linear search.
 func (t Testnet) LookupNode(name string) *Node {
 	for _, node := range t.Nodes {
@@ -143,6 +143,15 @@ func NewCLI() *CLI {
 				}
 			}
 
+			if len(cli.testnet.Partitions) > 0 {
+				if err := Partition(cmd.Context(), cli.testnet, cli.infp); err != nil {
+					return err
+				}
+				if err := Wait(cmd.Context(), cli.testnet, 5); err != nil { // ensure the network recovers
+					return err
+				}
+			}
+
 			if cli.testnet.Evidence > 0 {
 				if err := InjectEvidence(ctx, r, cli.testnet, cli.testnet.Evidence); err != nil {
 					return err
@@ -212,6 +221,14 @@ func NewCLI() *CLI {
 		},
 	})
 
+	cli.root.AddCommand(&cobra.Command{
+		Use:   "partition",
+		Short: "Partitions the network into the groups of nodes given in the manifest",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Partition(cmd.Context(), cli.testnet, cli.infp)
+		},
+	})
+
 	cli.root.AddCommand(&cobra.Command{
 		Use:   "wait",
 		Short: "Waits for a few blocks to be produced and all nodes to catch up",
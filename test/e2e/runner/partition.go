@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
+	"github.com/cometbft/cometbft/test/e2e/pkg/infra"
+)
+
+// Partition applies the network partitions of a running testnet, in order,
+// healing each of them before applying the next one.
+func Partition(ctx context.Context, testnet *e2e.Testnet, ifp infra.Provider) error {
+	for _, partition := range testnet.Partitions {
+		if _, _, err := waitForHeight(ctx, testnet, partition.Height); err != nil {
+			return err
+		}
+		if err := PartitionNetwork(ctx, partition, ifp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PartitionNetwork partitions the network for the duration of the given
+// partition, then heals it.
+func PartitionNetwork(ctx context.Context, partition e2e.Partition, ifp infra.Provider) error {
+	logger.Info("partition", "msg", log.NewLazySprintf("Partitioning network into %d groups for %v...",
+		len(partition.Groups), partition.Duration))
+	if err := forEachPartitionedNode(ctx, partition, ifp, ifp.Partition); err != nil {
+		return err
+	}
+
+	select {
+	case <-time.After(partition.Duration):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	logger.Info("partition", "msg", "Healing network partition...")
+	return forEachPartitionedNode(ctx, partition, ifp, ifp.HealPartition)
+}
+
+// forEachPartitionedNode calls fn for each node of the partition, with the IPs
+// of the nodes it cannot reach.
+func forEachPartitionedNode(
+	ctx context.Context,
+	partition e2e.Partition,
+	ifp infra.Provider,
+	fn func(ctx context.Context, name string, ip string, unreachableIPs []string) error,
+) error {
+	for _, group := range partition.Groups {
+		for _, node := range group {
+			unreachable := partition.Unreachable(node)
+			ips := make([]string, len(unreachable))
+			for i, peer := range unreachable {
+				ips[i] = peer.InternalIP.String()
+			}
+			name, _, err := ifp.CheckUpgraded(ctx, node)
+			if err != nil {
+				return err
+			}
+			if err := fn(ctx, name, node.ExternalIP.String(), ips); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -272,6 +272,18 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 		cfg.P2P.PexReactor = false
 	}
 
+	// A validator behind sentries only connects to them, and its sentries
+	// keep its address private.
+	if len(node.Sentries) > 0 {
+		cfg.P2P.PexReactor = false
+	}
+	ids := make([]string, 0, len(node.SentryFor))
+	for _, validator := range node.SentryFor {
+		ids = append(ids, string(p2p.PubKeyToID(validator.NodeKey.PubKey())))
+	}
+	cfg.P2P.PrivatePeerIDs = strings.Join(ids, ",")
+	cfg.P2P.UnconditionalPeerIDs = strings.Join(ids, ",")
+
 	if node.Prometheus {
 		cfg.Instrumentation.Prometheus = true
 	}
@@ -329,6 +341,14 @@ func MakeAppConfig(node *e2e.Node) ([]byte, error) {
 		}
 	}
 
+	if len(node.Misbehaviors) > 0 {
+		misbehaviors := map[string]string{}
+		for height, misbehavior := range node.Misbehaviors {
+			misbehaviors[strconv.FormatInt(height, 10)] = misbehavior
+		}
+		cfg["misbehaviors"] = misbehaviors
+	}
+
 	if len(node.Testnet.ValidatorUpdates) > 0 {
 		validatorUpdates := map[string]map[string]int64{}
 		for height, validators := range node.Testnet.ValidatorUpdates {
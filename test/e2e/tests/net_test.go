@@ -1,6 +1,7 @@
 package e2e_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -44,3 +45,24 @@ func TestNet_Peers(t *testing.T) {
 		}
 	})
 }
+
+// Tests that validators behind sentries are only peered with them.
+func TestNet_Sentries(t *testing.T) {
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		if len(node.Sentries) == 0 {
+			return
+		}
+
+		client, err := node.Client()
+		require.NoError(t, err)
+		netInfo, err := client.NetInfo(ctx)
+		require.NoError(t, err)
+
+		for _, peerInfo := range netInfo.Peers {
+			require.True(t, slices.ContainsFunc(node.Sentries, func(sentry *e2e.Node) bool {
+				return sentry.Name == peerInfo.NodeInfo.Moniker
+			}), "validator %v peered with %v, which is not one of its sentries",
+				node.Name, peerInfo.NodeInfo.Moniker)
+		}
+	})
+}
@@ -0,0 +1,329 @@
+// Package network runs networks of CometBFT nodes in a single process, so
+// that ABCI applications can be tested against real consensus, mempool and
+// block sync reactors without any external infrastructure.
+//
+// Nodes communicate over an in-memory network and do not open any socket. The
+// network only stands in for the sockets: the P2P connections between nodes
+// are still encrypted and multiplexed, as over TCP.
+// They are queried and sent transactions through in-process RPC clients:
+//
+//	net, err := network.New(network.Config{
+//		Validators: 4,
+//		NewApp:     func() abci.Application { return kvstore.NewInMemoryApplication() },
+//	})
+//	if err != nil { ... }
+//	if err := net.Start(); err != nil { ... }
+//	defer net.Stop()
+//
+//	res, err := net.Nodes()[0].Client().BroadcastTxCommit(ctx, types.Tx("key=value"))
+//
+// WARNING: this package is meant for testing only.
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/node"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/rpc/client/local"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+const (
+	defaultValidators = 4
+	defaultChainID    = "test-chain"
+	validatorPower    = 10
+)
+
+// Config configures a network.
+type Config struct {
+	// Validators is the number of validators in genesis. Defaults to 4.
+	Validators int
+	// FullNodes is the number of full nodes. Defaults to 0.
+	FullNodes int
+	// NewApp returns the application of a node. It is called once per node.
+	// Required.
+	NewApp func() abci.Application
+	// ChainID is the chain ID of the network. Defaults to "test-chain".
+	ChainID string
+	// ConsensusParams are the consensus parameters in genesis. Defaults to
+	// types.DefaultConsensusParams().
+	ConsensusParams *types.ConsensusParams
+	// AppState is the application state in genesis.
+	AppState json.RawMessage
+	// Dir is the directory the nodes store their data in. Defaults to a
+	// temporary directory, removed when the network is stopped.
+	Dir string
+	// Logger logs the nodes' output. Defaults to no logging.
+	Logger log.Logger
+	// Link is the default link between nodes, e.g. to add latency. Defaults
+	// to no latency and unlimited bandwidth.
+	Link p2p.MemoryLink
+	// ConfigureNode, if set, is called with the configuration of each node
+	// before the node is created, to adjust it.
+	ConfigureNode func(name string, config *cfg.Config)
+}
+
+// Network is a network of nodes running in the current process.
+type Network struct {
+	config        Config
+	dir           string
+	removeDir     bool
+	memoryNetwork *p2p.MemoryNetwork
+	genesis       *types.GenesisDoc
+	nodes         []*Node
+}
+
+// Node is a node of a network.
+type Node struct {
+	*node.Node
+
+	// Name is the node's name, e.g. "validator01" or "full01". It is also the
+	// node's moniker.
+	Name string
+	// Validator is true if the node is a validator in genesis.
+	Validator bool
+	// App is the node's application.
+	App abci.Application
+
+	client *local.Local
+	ip     net.IP
+}
+
+// Client returns an RPC client calling the node directly.
+func (n *Node) Client() *local.Local {
+	return n.client
+}
+
+// New creates a network, without starting it.
+func New(config Config) (*Network, error) {
+	if config.NewApp == nil {
+		return nil, errors.New("NewApp is required")
+	}
+	if config.Validators < 0 || config.FullNodes < 0 {
+		return nil, errors.New("the numbers of validators and full nodes cannot be negative")
+	}
+	if config.Validators == 0 {
+		config.Validators = defaultValidators
+	}
+	if config.ChainID == "" {
+		config.ChainID = defaultChainID
+	}
+	if config.ConsensusParams == nil {
+		config.ConsensusParams = types.DefaultConsensusParams()
+	}
+	if config.Logger == nil {
+		config.Logger = log.NewNopLogger()
+	}
+
+	n := &Network{
+		config:        config,
+		dir:           config.Dir,
+		memoryNetwork: p2p.NewMemoryNetwork(),
+	}
+	n.memoryNetwork.SetDefaultLink(config.Link)
+	if n.dir == "" {
+		dir, err := os.MkdirTemp("", "cometbft-network-")
+		if err != nil {
+			return nil, err
+		}
+		n.dir, n.removeDir = dir, true
+	}
+	if err := n.setup(); err != nil {
+		n.cleanup()
+		return nil, err
+	}
+	return n, nil
+}
+
+// setup generates the configuration, keys and genesis of the nodes, then
+// creates them.
+func (n *Network) setup() error {
+	total := n.config.Validators + n.config.FullNodes
+	configs := make([]*cfg.Config, total)
+	names := make([]string, total)
+	nodeKeys := make([]*p2p.NodeKey, total)
+	pvs := make([]*privval.FilePV, total)
+	ips := make([]net.IP, total)
+
+	n.genesis = &types.GenesisDoc{
+		GenesisTime:     cmttime.Now(),
+		ChainID:         n.config.ChainID,
+		InitialHeight:   1,
+		ConsensusParams: n.config.ConsensusParams,
+		AppState:        n.config.AppState,
+	}
+	for i := 0; i < total; i++ {
+		names[i] = fmt.Sprintf("validator%02d", i+1)
+		if i >= n.config.Validators {
+			names[i] = fmt.Sprintf("full%02d", i-n.config.Validators+1)
+		}
+		config := cfg.TestConfig().SetRoot(filepath.Join(n.dir, names[i]))
+		cfg.EnsureRoot(config.RootDir)
+		configs[i] = config
+
+		nodeKeys[i] = &p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+		pvs[i] = privval.GenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+		pvs[i].Save()
+		// addresses only identify nodes on the in-memory network
+		ips[i] = net.IPv4(127, 0, byte(i/250), byte(i%250+1))
+
+		if i < n.config.Validators {
+			pubKey, err := pvs[i].GetPubKey()
+			if err != nil {
+				return err
+			}
+			n.genesis.Validators = append(n.genesis.Validators, types.GenesisValidator{
+				Address: pubKey.Address(),
+				PubKey:  pubKey,
+				Power:   validatorPower,
+				Name:    names[i],
+			})
+		}
+	}
+	if err := n.genesis.ValidateAndComplete(); err != nil {
+		return err
+	}
+
+	for i, config := range configs {
+		peers := make([]string, 0, total-1)
+		for j := range configs {
+			if j != i {
+				peers = append(peers, p2p.IDAddressString(nodeKeys[j].ID(), net.JoinHostPort(ips[j].String(), "26656")))
+			}
+		}
+		config.Moniker = names[i]
+		config.DBBackend = "memdb"
+		config.P2P.ListenAddress = "tcp://" + net.JoinHostPort(ips[i].String(), "26656")
+		config.P2P.PersistentPeers = strings.Join(peers, ",")
+		config.P2P.AllowDuplicateIP = true
+		config.P2P.AddrBookStrict = false
+		config.RPC.ListenAddress = ""
+		config.RPC.PprofListenAddress = ""
+		config.GRPC.ListenAddress = ""
+		config.GRPC.Privileged.ListenAddress = ""
+		config.Instrumentation.Prometheus = false
+		if n.config.ConfigureNode != nil {
+			n.config.ConfigureNode(names[i], config)
+		}
+		if err := n.genesis.SaveAs(config.GenesisFile()); err != nil {
+			return err
+		}
+
+		app := n.config.NewApp()
+		nd, err := node.NewNode(context.Background(), config,
+			pvs[i],
+			nodeKeys[i],
+			proxy.NewLocalClientCreator(app),
+			node.DefaultGenesisDocProviderFunc(config),
+			cfg.DefaultDBProvider,
+			node.DefaultMetricsProvider(config, nodeKeys[i].ID()),
+			n.config.Logger.With("node", names[i]),
+			node.P2PMemoryNetwork(n.memoryNetwork),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create node %s: %w", names[i], err)
+		}
+		n.nodes = append(n.nodes, &Node{
+			Node:      nd,
+			Name:      names[i],
+			Validator: i < n.config.Validators,
+			App:       app,
+			client:    local.New(nd),
+			ip:        ips[i],
+		})
+	}
+	return nil
+}
+
+// Start starts all the nodes.
+func (n *Network) Start() error {
+	for _, nd := range n.nodes {
+		if err := nd.Start(); err != nil {
+			return fmt.Errorf("failed to start node %s: %w", nd.Name, err)
+		}
+	}
+	return nil
+}
+
+// Stop stops all the running nodes, and removes their data if it was stored
+// in a temporary directory.
+func (n *Network) Stop() error {
+	var errs []error
+	for i := len(n.nodes) - 1; i >= 0; i-- {
+		if nd := n.nodes[i]; nd.IsRunning() {
+			if err := nd.Stop(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to stop node %s: %w", nd.Name, err))
+			}
+			nd.Wait()
+		}
+	}
+	n.cleanup()
+	return errors.Join(errs...)
+}
+
+func (n *Network) cleanup() {
+	if n.removeDir {
+		_ = os.RemoveAll(n.dir)
+	}
+}
+
+// Nodes returns the nodes of the network, validators first.
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+// Validators returns the validators of the network.
+func (n *Network) Validators() []*Node {
+	return n.nodes[:n.config.Validators]
+}
+
+// SetLink sets the link from one node to another, e.g. to add latency or
+// limit bandwidth. It applies to existing connections too.
+func (n *Network) SetLink(from, to *Node, link p2p.MemoryLink) {
+	n.memoryNetwork.SetLink(from.ip, to.ip, link)
+}
+
+// Genesis returns the genesis of the network.
+func (n *Network) Genesis() *types.GenesisDoc {
+	return n.genesis
+}
+
+// WaitForHeight waits until all the running nodes have committed the block at
+// height, or ctx is done.
+func (n *Network) WaitForHeight(ctx context.Context, height int64) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		done := true
+		for _, nd := range n.nodes {
+			if nd.IsRunning() && nd.BlockStore().Height() < height {
+				done = false
+				break
+			}
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for height %d: %w", height, ctx.Err())
+		}
+	}
+}
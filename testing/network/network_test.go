@@ -0,0 +1,88 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestNetwork(t *testing.T) {
+	net, err := New(Config{
+		Validators: 4,
+		FullNodes:  1,
+		NewApp:     func() abci.Application { return kvstore.NewInMemoryApplication() },
+	})
+	require.NoError(t, err)
+	require.Len(t, net.Nodes(), 5)
+	require.Len(t, net.Validators(), 4)
+	require.Len(t, net.Genesis().Validators, 4)
+
+	require.NoError(t, net.Start())
+	t.Cleanup(func() { require.NoError(t, net.Stop()) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	require.NoError(t, net.WaitForHeight(ctx, 2))
+
+	// a transaction sent to the full node is committed by the validators
+	full := net.Nodes()[4]
+	require.False(t, full.Validator)
+	res, err := full.Client().BroadcastTxCommit(ctx, types.Tx("name=satoshi"))
+	require.NoError(t, err)
+	require.True(t, res.CheckTx.IsOK())
+	require.True(t, res.TxResult.IsOK())
+
+	require.NoError(t, net.WaitForHeight(ctx, res.Height+1))
+	for _, node := range net.Nodes() {
+		query, err := node.Client().ABCIQuery(ctx, "", []byte("name"))
+		require.NoError(t, err)
+		require.Equal(t, "satoshi", string(query.Response.Value), node.Name)
+	}
+}
+
+func TestNetworkConfig(t *testing.T) {
+	_, err := New(Config{})
+	require.Error(t, err, "NewApp is required")
+
+	var configured []string
+	net, err := New(Config{
+		Validators: 1,
+		NewApp:     func() abci.Application { return kvstore.NewInMemoryApplication() },
+		ConfigureNode: func(name string, _ *cfg.Config) {
+			configured = append(configured, name)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"validator01"}, configured)
+	require.NoError(t, net.Stop())
+}
+
+func TestNetworkLinks(t *testing.T) {
+	net, err := New(Config{
+		Validators: 4,
+		NewApp:     func() abci.Application { return kvstore.NewInMemoryApplication() },
+		Link:       p2p.MemoryLink{Latency: 20 * time.Millisecond, Bandwidth: 1 << 20},
+	})
+	require.NoError(t, err)
+	// isolate the last validator behind a slow link; the others still
+	// form a quorum
+	slow := net.Validators()[3]
+	for _, node := range net.Validators()[:3] {
+		net.SetLink(node, slow, p2p.MemoryLink{Latency: time.Second})
+		net.SetLink(slow, node, p2p.MemoryLink{Latency: time.Second})
+	}
+	require.NoError(t, net.Start())
+	t.Cleanup(func() { require.NoError(t, net.Stop()) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	require.NoError(t, net.WaitForHeight(ctx, 3))
+}
@@ -0,0 +1,155 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/internal/protoio"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+)
+
+var (
+	ErrAttestationInvalidValidatorAddress = errors.New("invalid attestation validator address")
+	ErrAttestationInvalidSignature        = errors.New("invalid attestation signature")
+)
+
+// Attestation is a validator's signed statement that the block with BlockHash
+// was committed at Height and that executing it resulted in AppHash. Unlike
+// votes, attestations only depend on committed data: Timestamp is the time of
+// the block, so that all validators sign the same bytes for a given height and
+// a validator may sign the same attestation more than once.
+type Attestation struct {
+	Height           int64             `json:"height"`
+	BlockHash        cmtbytes.HexBytes `json:"block_hash"`
+	AppHash          cmtbytes.HexBytes `json:"app_hash"`
+	Timestamp        time.Time         `json:"timestamp"`
+	ValidatorAddress Address           `json:"validator_address"`
+	Signature        []byte            `json:"signature"`
+}
+
+// NewAttestation returns a new, unsigned Attestation of the given block.
+func NewAttestation(height int64, blockHash, appHash []byte, timestamp time.Time, valAddr Address) *Attestation {
+	return &Attestation{
+		Height:           height,
+		BlockHash:        blockHash,
+		AppHash:          appHash,
+		Timestamp:        timestamp,
+		ValidatorAddress: valAddr,
+	}
+}
+
+// ValidateBasic performs basic validation.
+func (a *Attestation) ValidateBasic() error {
+	if a.Height <= 0 {
+		return errors.New("non positive Height")
+	}
+	if len(a.BlockHash) == 0 {
+		return errors.New("empty BlockHash")
+	}
+	if err := ValidateHash(a.BlockHash); err != nil {
+		return fmt.Errorf("wrong BlockHash: %w", err)
+	}
+	if len(a.ValidatorAddress) != crypto.AddressSize {
+		return fmt.Errorf("expected ValidatorAddress size to be %d bytes, got %d bytes",
+			crypto.AddressSize,
+			len(a.ValidatorAddress),
+		)
+	}
+	if len(a.Signature) == 0 {
+		return errors.New("signature is missing")
+	}
+	if len(a.Signature) > MaxSignatureSize {
+		return fmt.Errorf("signature is too big (max: %d)", MaxSignatureSize)
+	}
+	return nil
+}
+
+// Verify checks that the attestation was signed by the validator with the
+// given public key.
+func (a *Attestation) Verify(chainID string, pubKey crypto.PubKey) error {
+	if !bytes.Equal(pubKey.Address(), a.ValidatorAddress) {
+		return ErrAttestationInvalidValidatorAddress
+	}
+	if !pubKey.VerifySignature(AttestationSignBytes(chainID, a.ToProto()), a.Signature) {
+		return ErrAttestationInvalidSignature
+	}
+	return nil
+}
+
+// String returns a string representation of the Attestation.
+//
+// 1. height
+// 2. first 6 bytes of block hash
+// 3. first 6 bytes of app hash
+// 4. first 6 bytes of validator address
+// 5. first 6 bytes of signature
+// 6. timestamp.
+func (a *Attestation) String() string {
+	if a == nil {
+		return "nil-Attestation"
+	}
+	return fmt.Sprintf("Attestation{%v %X %X %X %X @ %s}",
+		a.Height,
+		cmtbytes.Fingerprint(a.BlockHash),
+		cmtbytes.Fingerprint(a.AppHash),
+		cmtbytes.Fingerprint(a.ValidatorAddress),
+		cmtbytes.Fingerprint(a.Signature),
+		CanonicalTime(a.Timestamp),
+	)
+}
+
+// AttestationSignBytes returns the proto-encoding of the canonicalized
+// Attestation, for signing. Panics if the marshaling fails.
+//
+// Like the sign bytes of votes and proposals, the encoded Protobuf message is
+// varint length-prefixed (using MarshalDelimited).
+//
+// See CanonicalizeAttestation.
+func AttestationSignBytes(chainID string, a *cmtproto.Attestation) []byte {
+	pb := CanonicalizeAttestation(chainID, a)
+	bz, err := protoio.MarshalDelimited(&pb)
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// ToProto converts Attestation to protobuf.
+func (a *Attestation) ToProto() *cmtproto.Attestation {
+	if a == nil {
+		return nil
+	}
+
+	return &cmtproto.Attestation{
+		Height:           a.Height,
+		BlockHash:        a.BlockHash,
+		AppHash:          a.AppHash,
+		Timestamp:        a.Timestamp,
+		ValidatorAddress: a.ValidatorAddress,
+		Signature:        a.Signature,
+	}
+}
+
+// AttestationFromProto converts a protobuf Attestation to Attestation.
+// It returns an error if the attestation is invalid.
+func AttestationFromProto(pa *cmtproto.Attestation) (*Attestation, error) {
+	if pa == nil {
+		return nil, errors.New("nil attestation")
+	}
+
+	a := &Attestation{
+		Height:           pa.Height,
+		BlockHash:        pa.BlockHash,
+		AppHash:          pa.AppHash,
+		Timestamp:        pa.Timestamp,
+		ValidatorAddress: pa.ValidatorAddress,
+		Signature:        pa.Signature,
+	}
+
+	return a, a.ValidateBasic()
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+)
+
+func signedAttestation(t *testing.T, privVal MockPV, chainID string) *Attestation {
+	t.Helper()
+
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	stamp, err := time.Parse(TimeFormat, "2018-02-11T07:09:22.765Z")
+	require.NoError(t, err)
+
+	a := NewAttestation(12345, cmtrand.Bytes(tmhash.Size), cmtrand.Bytes(tmhash.Size), stamp, pubKey.Address())
+	pa := a.ToProto()
+	require.NoError(t, privVal.SignAttestation(chainID, pa))
+	a.Signature = pa.Signature
+
+	return a
+}
+
+func TestAttestationVerify(t *testing.T) {
+	privVal := NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	a := signedAttestation(t, privVal, "test_chain_id")
+	require.NoError(t, a.Verify("test_chain_id", pubKey))
+
+	// serialize, deserialize and verify again....
+	bs, err := proto.Marshal(a.ToProto())
+	require.NoError(t, err)
+	pa := new(cmtproto.Attestation)
+	require.NoError(t, proto.Unmarshal(bs, pa))
+	na, err := AttestationFromProto(pa)
+	require.NoError(t, err)
+	require.NoError(t, na.Verify("test_chain_id", pubKey))
+
+	assert.ErrorIs(t, a.Verify("other_chain_id", pubKey), ErrAttestationInvalidSignature)
+	assert.ErrorIs(t, a.Verify("test_chain_id", NewMockPV().PrivKey.PubKey()), ErrAttestationInvalidValidatorAddress)
+
+	a.AppHash = cmtrand.Bytes(tmhash.Size)
+	assert.ErrorIs(t, a.Verify("test_chain_id", pubKey), ErrAttestationInvalidSignature)
+}
+
+func TestAttestationSignBytesDifferFromVotes(t *testing.T) {
+	a := signedAttestation(t, NewMockPV(), "test_chain_id")
+	vote := &Vote{
+		Type:      PrecommitType,
+		Height:    a.Height,
+		BlockID:   BlockID{Hash: a.BlockHash},
+		Timestamp: a.Timestamp,
+	}
+
+	signBytes := AttestationSignBytes("test_chain_id", a.ToProto())
+	assert.NotEqual(t, VoteSignBytes("test_chain_id", vote.ToProto()), signBytes)
+
+	// the canonical vote can't be decoded from the attestation's sign bytes
+	cv := new(cmtproto.CanonicalVote)
+	assert.Error(t, proto.Unmarshal(signBytes[1:], cv))
+}
+
+func TestAttestationValidateBasic(t *testing.T) {
+	testCases := []struct {
+		testName  string
+		malleate  func(*Attestation)
+		expectErr bool
+	}{
+		{"Good Attestation", func(*Attestation) {}, false},
+		{"Zero Height", func(a *Attestation) { a.Height = 0 }, true},
+		{"Empty BlockHash", func(a *Attestation) { a.BlockHash = nil }, true},
+		{"Invalid BlockHash", func(a *Attestation) { a.BlockHash = []byte{1} }, true},
+		{"Invalid ValidatorAddress", func(a *Attestation) { a.ValidatorAddress = []byte{1} }, true},
+		{"Missing Signature", func(a *Attestation) { a.Signature = nil }, true},
+		{"Too big Signature", func(a *Attestation) { a.Signature = make([]byte, MaxSignatureSize+1) }, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			a := signedAttestation(t, NewMockPV(), "test_chain_id")
+			tc.malleate(a)
+			assert.Equal(t, tc.expectErr, a.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
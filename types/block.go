@@ -439,22 +439,57 @@ func (h *Header) Hash() cmtbytes.HexBytes {
 	if h == nil || len(h.ValidatorsHash) == 0 {
 		return nil
 	}
-	hbz, err := h.Version.Marshal()
+	fields, err := h.hashFields()
 	if err != nil {
 		return nil
 	}
+	return merkle.HashFromByteSlices(fields)
+}
 
-	pbt, err := gogotypes.StdTimeMarshal(h.Time)
+// LastBlockIDProof returns a Merkle proof of the LastBlockID field in the
+// header's hash. Since LastBlockID contains the hash of the previous header,
+// a chain of such proofs links a header to any of its successors.
+// Returns nil if the header has no hash.
+// See HeaderChainProof.
+func (h *Header) LastBlockIDProof() *merkle.Proof {
+	if h == nil || len(h.ValidatorsHash) == 0 {
+		return nil
+	}
+	fields, err := h.hashFields()
 	if err != nil {
 		return nil
 	}
+	_, proofs := merkle.ProofsFromByteSlices(fields)
+	return proofs[headerLastBlockIDIndex]
+}
+
+const (
+	// headerHashFieldsCount is the number of fields in the header's hash.
+	headerHashFieldsCount = 14
+	// headerLastBlockIDIndex is the index of LastBlockID in the header's hash
+	// fields.
+	headerLastBlockIDIndex = 4
+)
+
+// hashFields returns the encoded fields of the header, in the order they are
+// hashed.
+func (h *Header) hashFields() ([][]byte, error) {
+	hbz, err := h.Version.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	pbt, err := gogotypes.StdTimeMarshal(h.Time)
+	if err != nil {
+		return nil, err
+	}
 
 	pbbi := h.LastBlockID.ToProto()
 	bzbi, err := pbbi.Marshal()
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return merkle.HashFromByteSlices([][]byte{
+	return [][]byte{
 		hbz,
 		cdcEncode(h.ChainID),
 		cdcEncode(h.Height),
@@ -469,7 +504,7 @@ func (h *Header) Hash() cmtbytes.HexBytes {
 		cdcEncode(h.LastResultsHash),
 		cdcEncode(h.EvidenceHash),
 		cdcEncode(h.ProposerAddress),
-	})
+	}, nil
 }
 
 // StringIndented returns an indented string representation of the header.
@@ -889,6 +924,22 @@ func (commit *Commit) Size() int {
 	return len(commit.Signatures)
 }
 
+// AbsentValidators returns the addresses of the validators of vals whose
+// votes are absent from the commit. vals must be the validator set which
+// signed the commit; nil is returned if its size does not match the commit.
+func (commit *Commit) AbsentValidators(vals *ValidatorSet) []Address {
+	if vals == nil || commit.Size() != vals.Size() {
+		return nil
+	}
+	var absent []Address
+	for i, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag == BlockIDFlagAbsent {
+			absent = append(absent, vals.Validators[i].Address)
+		}
+	}
+	return absent
+}
+
 // ValidateBasic performs basic validation that doesn't involve state data.
 // Does not actually check the cryptographic signatures.
 func (commit *Commit) ValidateBasic() error {
@@ -922,17 +973,17 @@ func (commit *Commit) Hash() cmtbytes.HexBytes {
 		return nil
 	}
 	if commit.hash == nil {
-		bs := make([][]byte, len(commit.Signatures))
-		for i, commitSig := range commit.Signatures {
+		h := merkle.NewHasher()
+		for _, commitSig := range commit.Signatures {
 			pbcs := commitSig.ToProto()
 			bz, err := pbcs.Marshal()
 			if err != nil {
 				panic(err)
 			}
 
-			bs[i] = bz
+			h.AddLeaf(bz)
 		}
-		commit.hash = merkle.HashFromByteSlices(bs)
+		commit.hash = h.Root()
 	}
 	return commit.hash
 }
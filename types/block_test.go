@@ -271,6 +271,21 @@ func TestCommitValidateBasic(t *testing.T) {
 	}
 }
 
+func TestCommitAbsentValidators(t *testing.T) {
+	valSet, _ := RandValidatorSet(3, 1)
+	commit := &Commit{Signatures: []CommitSig{
+		{BlockIDFlag: BlockIDFlagCommit},
+		NewCommitSigAbsent(),
+		{BlockIDFlag: BlockIDFlagNil},
+	}}
+	require.Equal(t, []Address{valSet.Validators[1].Address}, commit.AbsentValidators(valSet))
+
+	otherValSet, _ := RandValidatorSet(2, 1)
+	require.Nil(t, commit.AbsentValidators(otherValSet))
+	require.Nil(t, commit.AbsentValidators(nil))
+	require.Nil(t, (*Commit)(nil).AbsentValidators(valSet))
+}
+
 func TestMaxCommitBytes(t *testing.T) {
 	// time is varint encoded so need to pick the max.
 	// year int, month Month, day, hour, min, sec, nsec int, loc *Location
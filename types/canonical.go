@@ -77,6 +77,18 @@ func CanonicalizeVoteExtension(chainID string, vote *cmtproto.Vote) cmtproto.Can
 	}
 }
 
+// CanonicalizeAttestation transforms the given Attestation to a
+// CanonicalAttestation, which does not contain the ValidatorAddress field.
+func CanonicalizeAttestation(chainID string, attestation *cmtproto.Attestation) cmtproto.CanonicalAttestation {
+	return cmtproto.CanonicalAttestation{
+		Height:    attestation.Height, // encoded as sfixed64
+		BlockHash: attestation.BlockHash,
+		AppHash:   attestation.AppHash,
+		Timestamp: attestation.Timestamp,
+		ChainID:   chainID,
+	}
+}
+
 // CanonicalTime can be used to stringify time in a canonical way.
 func CanonicalTime(t time.Time) string {
 	// Note that sending time over amino resets it to
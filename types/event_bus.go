@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/cometbft/cometbft/abci/types"
 	cmtpubsub "github.com/cometbft/cometbft/internal/pubsub"
@@ -36,15 +37,20 @@ type EventBus struct {
 	pubsub *cmtpubsub.Server
 }
 
-// NewEventBus returns a new event bus.
-func NewEventBus() *EventBus {
-	return NewEventBusWithBufferCapacity(defaultCapacity)
+// NewEventBus returns a new event bus, with the given options of its pubsub
+// server.
+func NewEventBus(options ...cmtpubsub.Option) *EventBus {
+	return newEventBus(append([]cmtpubsub.Option{cmtpubsub.BufferCapacity(defaultCapacity)}, options...)...)
 }
 
 // NewEventBusWithBufferCapacity returns a new event bus with the given buffer capacity.
 func NewEventBusWithBufferCapacity(cap int) *EventBus {
 	// capacity could be exposed later if needed
-	pubsub := cmtpubsub.NewServer(cmtpubsub.BufferCapacity(cap))
+	return newEventBus(cmtpubsub.BufferCapacity(cap))
+}
+
+func newEventBus(options ...cmtpubsub.Option) *EventBus {
+	pubsub := cmtpubsub.NewServer(options...)
 	b := &EventBus{pubsub: pubsub}
 	b.BaseService = *service.NewBaseService(nil, "EventBus", b)
 	return b
@@ -82,6 +88,19 @@ func (b *EventBus) Subscribe(
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeWithPolicy subscribes with a buffer of outCapacity events, handled
+// according to policy once full. See cmtpubsub.Server.SubscribeWithPolicy.
+func (b *EventBus) SubscribeWithPolicy(
+	ctx context.Context,
+	subscriber string,
+	query cmtpubsub.Query,
+	outCapacity int,
+	policy cmtpubsub.OverflowPolicy,
+	blockTimeout time.Duration,
+) (Subscription, error) {
+	return b.pubsub.SubscribeWithPolicy(ctx, subscriber, query, outCapacity, policy, blockTimeout)
+}
+
 // SubscribeUnbuffered can be used for a local consensus explorer and synchronous
 // testing. Do not use for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(
@@ -185,10 +204,43 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	events[EventTypeKey] = append(events[EventTypeKey], EventTx)
 	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", Tx(data.Tx).Hash()))
 	events[TxHeightKey] = append(events[TxHeightKey], strconv.FormatInt(data.Height, 10))
+	events[TxGasUsedKey] = append(events[TxGasUsedKey], strconv.FormatInt(data.Result.GasUsed, 10))
 
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
+func (b *EventBus) PublishEventPendingTx(data EventDataMempoolTx) error {
+	return b.publishEventMempoolTx(EventPendingTx, data)
+}
+
+func (b *EventBus) PublishEventRejectedTx(data EventDataMempoolTx) error {
+	return b.publishEventMempoolTx(EventRejectedTx, data)
+}
+
+func (b *EventBus) PublishEventEvictedTx(data EventDataMempoolTx) error {
+	return b.publishEventMempoolTx(EventEvictedTx, data)
+}
+
+// publishEventMempoolTx publishes a mempool event with the events of the
+// CheckTx response. Like PublishEventTx, it adds the predefined keys
+// (EventTypeKey, TxHashKey), overwriting existing events with the same keys.
+func (b *EventBus) publishEventMempoolTx(eventType string, data EventDataMempoolTx) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := b.validateAndStringifyEvents(data.Events, b.Logger.With("tx", data.Tx))
+
+	// add predefined compositeKeys
+	events[EventTypeKey] = append(events[EventTypeKey], eventType)
+	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", data.Tx.Hash()))
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
+func (b *EventBus) PublishEventStateSyncStatus(data EventDataStateSyncStatus) error {
+	return b.Publish(EventStateSyncStatus, data)
+}
+
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return b.Publish(EventNewRoundStep, data)
 }
@@ -225,6 +277,24 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+// PublishEventValidatorSetChanges publishes the classified validator updates,
+// tagged with the address of every changed validator (ValidatorAddressKey).
+func (b *EventBus) PublishEventValidatorSetChanges(data EventDataValidatorSetChanges) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := map[string][]string{
+		EventTypeKey: {EventValidatorSetChanges},
+	}
+	for _, changes := range [][]ValidatorChange{data.Added, data.Removed, data.Updated} {
+		for _, c := range changes {
+			events[ValidatorAddressKey] = append(events[ValidatorAddressKey], c.Address.String())
+		}
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
 // -----------------------------------------------------------------------------.
 type NopEventBus struct{}
 
@@ -269,6 +339,22 @@ func (NopEventBus) PublishEventTx(EventDataTx) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventPendingTx(EventDataMempoolTx) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventRejectedTx(EventDataMempoolTx) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventEvictedTx(EventDataMempoolTx) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventStateSyncStatus(EventDataStateSyncStatus) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventNewRoundStep(EventDataRoundState) error {
 	return nil
 }
@@ -304,3 +390,7 @@ func (NopEventBus) PublishEventLock(EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventValidatorSetChanges(EventDataValidatorSetChanges) error {
+	return nil
+}
@@ -341,6 +341,51 @@ func TestEventBusPublishEventNewEvidence(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventMempoolTx(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	data := EventDataMempoolTx{
+		Tx:        tx,
+		Code:      2,
+		Codespace: "bank",
+		Log:       "insufficient funds",
+		Events: []abci.Event{
+			{Type: "testType", Attributes: []abci.EventAttribute{{Key: "baz", Value: "1"}}},
+		},
+	}
+
+	query := fmt.Sprintf("tm.event='RejectedTx' AND tx.hash='%X' AND testType.baz=1", tx.Hash())
+	sub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.MustCompile(query))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		msg := <-sub.Out()
+		assert.Equal(t, data, msg.Data().(EventDataMempoolTx))
+		close(done)
+	}()
+
+	// not matching the query
+	err = eventBus.PublishEventPendingTx(data)
+	assert.NoError(t, err)
+	err = eventBus.PublishEventRejectedTx(data)
+	assert.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive a rejected transaction after 1 sec.")
+	}
+}
+
 func TestEventBusPublish(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -351,7 +396,7 @@ func TestEventBusPublish(t *testing.T) {
 		}
 	})
 
-	const numEventsExpected = 14
+	const numEventsExpected = 19
 
 	sub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.All, numEventsExpected)
 	require.NoError(t, err)
@@ -396,6 +441,16 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, err)
 	err = eventBus.PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates{})
 	require.NoError(t, err)
+	err = eventBus.PublishEventValidatorSetChanges(EventDataValidatorSetChanges{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventPendingTx(EventDataMempoolTx{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventRejectedTx(EventDataMempoolTx{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventEvictedTx(EventDataMempoolTx{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventStateSyncStatus(EventDataStateSyncStatus{})
+	require.NoError(t, err)
 
 	select {
 	case <-done:
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// EventSchema is the schema of the events declared by the application in its
+// InfoResponse. It gives the types of the values of the event attributes it
+// lists; the values of the other attributes are strings.
+//
+// A nil *EventSchema is valid and declares no types.
+type EventSchema struct {
+	pb *abci.EventSchema
+	// attribute types by composite key, e.g. "transfer.amount"
+	types map[string]abci.AttributeType
+}
+
+// EventSchemaFromProto returns the schema declared by pb, or nil if pb is nil.
+// It fails if an event type or an attribute key is empty or declared twice, or
+// if an attribute type is unknown.
+func EventSchemaFromProto(pb *abci.EventSchema) (*EventSchema, error) {
+	if pb == nil {
+		return nil, nil
+	}
+	s := &EventSchema{pb: pb, types: make(map[string]abci.AttributeType)}
+	eventTypes := make(map[string]struct{}, len(pb.EventTypes))
+	for _, et := range pb.EventTypes {
+		if et.Type == "" {
+			return nil, errors.New("empty event type")
+		}
+		if _, ok := eventTypes[et.Type]; ok {
+			return nil, fmt.Errorf("event type %q declared twice", et.Type)
+		}
+		eventTypes[et.Type] = struct{}{}
+		for _, attr := range et.Attributes {
+			if attr.Key == "" {
+				return nil, fmt.Errorf("empty attribute key in event type %q", et.Type)
+			}
+			if attr.Type < abci.ATTRIBUTE_TYPE_STRING || attr.Type > abci.ATTRIBUTE_TYPE_TIME {
+				return nil, fmt.Errorf("unknown type %d of attribute %s.%s", attr.Type, et.Type, attr.Key)
+			}
+			compositeKey := et.Type + "." + attr.Key
+			if _, ok := s.types[compositeKey]; ok {
+				return nil, fmt.Errorf("attribute %s declared twice", compositeKey)
+			}
+			s.types[compositeKey] = attr.Type
+		}
+	}
+	return s, nil
+}
+
+// ToProto returns the protobuf representation of the schema.
+func (s *EventSchema) ToProto() *abci.EventSchema {
+	if s == nil {
+		return nil
+	}
+	return s.pb
+}
+
+// AttributeType returns the type of the attribute with the given composite
+// key, e.g. "transfer.amount". Attributes not in the schema are strings.
+func (s *EventSchema) AttributeType(compositeKey string) abci.AttributeType {
+	if s == nil {
+		return abci.ATTRIBUTE_TYPE_STRING
+	}
+	return s.types[compositeKey]
+}
+
+// ValidateEvents checks that the values of the attributes of the events have
+// the types declared by the schema. It returns all the violations.
+func (s *EventSchema) ValidateEvents(events []abci.Event) error {
+	if s == nil {
+		return nil
+	}
+	var errs []error
+	for _, event := range events {
+		for _, attr := range event.Attributes {
+			compositeKey := event.Type + "." + attr.Key
+			if err := ValidateAttributeValue(s.AttributeType(compositeKey), attr.Value); err != nil {
+				errs = append(errs, fmt.Errorf("attribute %s: %w", compositeKey, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateAttributeValue checks that value is a valid value of type typ.
+func ValidateAttributeValue(typ abci.AttributeType, value string) error {
+	var err error
+	switch typ {
+	case abci.ATTRIBUTE_TYPE_INT:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case abci.ATTRIBUTE_TYPE_UINT:
+		_, err = strconv.ParseUint(value, 10, 64)
+	case abci.ATTRIBUTE_TYPE_BOOL:
+		if value != "true" && value != "false" {
+			err = errors.New("not true or false")
+		}
+	case abci.ATTRIBUTE_TYPE_TIME:
+		_, err = time.Parse(time.RFC3339, value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s value %q", typ, value)
+	}
+	return nil
+}
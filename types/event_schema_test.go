@@ -0,0 +1,84 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+func TestEventSchemaFromProto(t *testing.T) {
+	schema, err := EventSchemaFromProto(nil)
+	require.NoError(t, err)
+	require.Nil(t, schema)
+	// A nil schema declares no types.
+	require.Equal(t, abci.ATTRIBUTE_TYPE_STRING, schema.AttributeType("transfer.amount"))
+	require.NoError(t, schema.ValidateEvents([]abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{
+		{Key: "amount", Value: "abc"},
+	}}}))
+
+	for name, pb := range map[string]*abci.EventSchema{
+		"empty event type": {EventTypes: []abci.EventTypeSchema{{}}},
+		"duplicate event type": {EventTypes: []abci.EventTypeSchema{
+			{Type: "transfer"},
+			{Type: "transfer"},
+		}},
+		"empty attribute key": {EventTypes: []abci.EventTypeSchema{
+			{Type: "transfer", Attributes: []abci.AttributeSchema{{}}},
+		}},
+		"duplicate attribute": {EventTypes: []abci.EventTypeSchema{
+			{Type: "transfer", Attributes: []abci.AttributeSchema{{Key: "amount"}, {Key: "amount"}}},
+		}},
+		"unknown type": {EventTypes: []abci.EventTypeSchema{
+			{Type: "transfer", Attributes: []abci.AttributeSchema{{Key: "amount", Type: 7}}},
+		}},
+	} {
+		_, err := EventSchemaFromProto(pb)
+		require.Error(t, err, name)
+	}
+}
+
+func TestEventSchemaValidateEvents(t *testing.T) {
+	pb := &abci.EventSchema{EventTypes: []abci.EventTypeSchema{
+		{Type: "transfer", Attributes: []abci.AttributeSchema{
+			{Key: "amount", Type: abci.ATTRIBUTE_TYPE_INT},
+			{Key: "fee", Type: abci.ATTRIBUTE_TYPE_UINT},
+			{Key: "refund", Type: abci.ATTRIBUTE_TYPE_BOOL},
+			{Key: "memo", Type: abci.ATTRIBUTE_TYPE_STRING},
+			{Key: "expiry", Type: abci.ATTRIBUTE_TYPE_TIME},
+		}},
+	}}
+	schema, err := EventSchemaFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, pb, schema.ToProto())
+	require.Equal(t, abci.ATTRIBUTE_TYPE_UINT, schema.AttributeType("transfer.fee"))
+	require.Equal(t, abci.ATTRIBUTE_TYPE_STRING, schema.AttributeType("transfer.sender"))
+
+	event := func(key, value string) abci.Event {
+		return abci.Event{Type: "transfer", Attributes: []abci.EventAttribute{{Key: key, Value: value}}}
+	}
+	for _, e := range []abci.Event{
+		event("amount", "-10"),
+		event("fee", "18446744073709551615"),
+		event("refund", "false"),
+		event("expiry", "2024-03-01T10:00:00.5+01:00"),
+		event("memo", "anything"),
+		event("sender", "anything"),
+	} {
+		require.NoError(t, schema.ValidateEvents([]abci.Event{e}), e.String())
+	}
+
+	err = schema.ValidateEvents([]abci.Event{
+		event("amount", "1.5"),
+		event("fee", "-1"),
+		event("refund", "yes"),
+		event("amount", "9223372036854775808"),
+		event("expiry", "2024-03-01"),
+	})
+	require.ErrorContains(t, err, `attribute transfer.amount: invalid ATTRIBUTE_TYPE_INT value "1.5"`)
+	require.ErrorContains(t, err, `attribute transfer.fee: invalid ATTRIBUTE_TYPE_UINT value "-1"`)
+	require.ErrorContains(t, err, `attribute transfer.refund: invalid ATTRIBUTE_TYPE_BOOL value "yes"`)
+	require.ErrorContains(t, err, `"9223372036854775808"`)
+	require.ErrorContains(t, err, `attribute transfer.expiry: invalid ATTRIBUTE_TYPE_TIME value "2024-03-01"`)
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto"
 	cmtpubsub "github.com/cometbft/cometbft/internal/pubsub"
 	cmtquery "github.com/cometbft/cometbft/internal/pubsub/query"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 )
 
@@ -21,6 +23,7 @@ const (
 	EventNewBlockEvents      = "NewBlockEvents"
 	EventNewEvidence         = "NewEvidence"
 	EventTx                  = "Tx"
+	EventValidatorSetChanges = "ValidatorSetChanges"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
 	// Internal consensus events.
@@ -37,6 +40,18 @@ const (
 	EventValidBlock        = "ValidBlock"
 	EventVote              = "Vote"
 	EventProposalBlockPart = "ProposalBlockPart"
+
+	// Mempool events.
+	// These are triggered from the mempool, once the application checked a
+	// transaction, so that clients can track it before it's committed.
+	EventEvictedTx  = "EvictedTx"
+	EventPendingTx  = "PendingTx"
+	EventRejectedTx = "RejectedTx"
+
+	// State sync events.
+	// These are triggered when a state sync enters a new stage, so that
+	// operators can follow the restoration of a snapshot.
+	EventStateSyncStatus = "StateSyncStatus"
 )
 
 // ENCODING / DECODING
@@ -57,7 +72,10 @@ func init() {
 	cmtjson.RegisterType(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal")
 	cmtjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	cmtjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
+	cmtjson.RegisterType(EventDataValidatorSetChanges{}, "tendermint/event/ValidatorSetChanges")
 	cmtjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
+	cmtjson.RegisterType(EventDataMempoolTx{}, "tendermint/event/MempoolTx")
+	cmtjson.RegisterType(EventDataStateSyncStatus{}, "tendermint/event/StateSyncStatus")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -77,6 +95,14 @@ type EventDataNewBlockEvents struct {
 	Height int64        `json:"height"`
 	Events []abci.Event `json:"events"`
 	NumTxs int64        `json:"num_txs,string"` // Number of txs in a block
+
+	// Proposer is the address of the proposer of the block.
+	Proposer Address `json:"proposer,omitempty"`
+	// AbsentValidators are the addresses of the validators whose votes for the
+	// previous block are absent from the last commit of the block.
+	AbsentValidators []Address `json:"absent_validators,omitempty"`
+	// Size is the size of the block in bytes.
+	Size int64 `json:"size,omitempty,string"`
 }
 
 type EventDataNewEvidence struct {
@@ -127,6 +153,51 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// ValidatorChange describes how the voting power of a single validator
+// changed. PreviousPower is 0 for added validators and Power is 0 for removed
+// ones.
+type ValidatorChange struct {
+	Address       Address       `json:"address"`
+	PubKey        crypto.PubKey `json:"pub_key"`
+	Power         int64         `json:"power"`
+	PreviousPower int64         `json:"previous_power"`
+}
+
+// EventDataValidatorSetChanges is the data of the ValidatorSetChanges event.
+// It classifies the validator updates returned by the application at Height
+// against the validator set they apply to. Like all validator updates, the
+// changes take effect at Height+2.
+type EventDataValidatorSetChanges struct {
+	Height  int64             `json:"height"`
+	Added   []ValidatorChange `json:"added"`
+	Removed []ValidatorChange `json:"removed"`
+	Updated []ValidatorChange `json:"updated"`
+}
+
+// EventDataMempoolTx is the data of the mempool events. The code, codespace
+// and events are those of the application's CheckTx response. The log is
+// either the application's or the reason the mempool rejected or evicted the
+// transaction.
+type EventDataMempoolTx struct {
+	Tx        Tx           `json:"tx"`
+	Code      uint32       `json:"code"`
+	Codespace string       `json:"codespace,omitempty"`
+	Log       string       `json:"log,omitempty"`
+	Events    []abci.Event `json:"events,omitempty"`
+}
+
+// EventDataStateSyncStatus is the data of the StateSyncStatus event. The
+// snapshot fields are empty while discovering snapshots.
+type EventDataStateSyncStatus struct {
+	Stage          string            `json:"stage"`
+	SnapshotHeight uint64            `json:"snapshot_height"`
+	SnapshotFormat uint32            `json:"snapshot_format"`
+	SnapshotHash   cmtbytes.HexBytes `json:"snapshot_hash"`
+	ChunksTotal    uint32            `json:"chunks_total"`
+	ChunksFetched  uint32            `json:"chunks_fetched"`
+	ChunksApplied  uint32            `json:"chunks_applied"`
+}
+
 // PUBSUB
 
 const (
@@ -141,12 +212,32 @@ const (
 	// see EventBus#PublishEventTx.
 	TxHeightKey = "tx.height"
 
+	// TxGasUsedKey is a reserved key, used to specify the gas used by a
+	// transaction. see EventBus#PublishEventTx.
+	TxGasUsedKey = "tx.gas_used"
+
 	// BlockHeightKey is a reserved key used for indexing FinalizeBlock events.
 	BlockHeightKey = "block.height"
+
+	// BlockProposerKey, BlockAbsentValidatorKey, BlockSizeKey and
+	// BlockNumTxsKey are reserved keys, used for indexing the proposer, the
+	// validators absent from the last commit, the size in bytes and the number
+	// of transactions of the blocks.
+	// see EventDataNewBlockEvents.
+	BlockProposerKey        = "block.proposer"
+	BlockAbsentValidatorKey = "block.absent_validator"
+	BlockSizeKey            = "block.size"
+	BlockNumTxsKey          = "block.num_txs"
+
+	// ValidatorAddressKey is a reserved key, used to specify the addresses of
+	// the validators whose power changed.
+	// see EventBus#PublishEventValidatorSetChanges.
+	ValidatorAddressKey = "validator.address"
 )
 
 var (
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
+	EventQueryEvictedTx           = QueryForEvent(EventEvictedTx)
 	EventQueryLock                = QueryForEvent(EventLock)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
@@ -154,11 +245,15 @@ var (
 	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
 	EventQueryNewRound            = QueryForEvent(EventNewRound)
 	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
+	EventQueryPendingTx           = QueryForEvent(EventPendingTx)
 	EventQueryPolka               = QueryForEvent(EventPolka)
+	EventQueryRejectedTx          = QueryForEvent(EventRejectedTx)
 	EventQueryRelock              = QueryForEvent(EventRelock)
+	EventQueryStateSyncStatus     = QueryForEvent(EventStateSyncStatus)
 	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
 	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
 	EventQueryTx                  = QueryForEvent(EventTx)
+	EventQueryValidatorSetChanges = QueryForEvent(EventValidatorSetChanges)
 	EventQueryValidatorSetUpdates = QueryForEvent(EventValidatorSetUpdates)
 	EventQueryValidBlock          = QueryForEvent(EventValidBlock)
 	EventQueryVote                = QueryForEvent(EventVote)
@@ -180,8 +275,22 @@ type BlockEventPublisher interface {
 	PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	PublishEventTx(tx EventDataTx) error
 	PublishEventValidatorSetUpdates(updates EventDataValidatorSetUpdates) error
+	PublishEventValidatorSetChanges(changes EventDataValidatorSetChanges) error
 }
 
 type TxEventPublisher interface {
 	PublishEventTx(tx EventDataTx) error
 }
+
+// MempoolEventPublisher publishes the events of transactions checked by the
+// mempool.
+type MempoolEventPublisher interface {
+	PublishEventPendingTx(tx EventDataMempoolTx) error
+	PublishEventRejectedTx(tx EventDataMempoolTx) error
+	PublishEventEvictedTx(tx EventDataMempoolTx) error
+}
+
+// StateSyncEventPublisher publishes the progress of state sync.
+type StateSyncEventPublisher interface {
+	PublishEventStateSyncStatus(status EventDataStateSyncStatus) error
+}
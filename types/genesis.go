@@ -1,14 +1,17 @@
 package types
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtos "github.com/cometbft/cometbft/internal/os"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
@@ -123,15 +126,94 @@ func GenesisDocFromJSON(jsonBlob []byte) (*GenesisDoc, error) {
 	return &genDoc, err
 }
 
+// GenesisDocFromReader reads JSON data from r and unmarshalls it into a
+// GenesisDoc. It also returns the SHA256 checksum of the data read.
+//
+// Unlike GenesisDocFromJSON, it does not hold the whole document in memory:
+// the checksum is computed as the data is read, and only the app_state, which
+// is handed to the application as is, is kept as raw JSON.
+func GenesisDocFromReader(r io.Reader) (*GenesisDoc, []byte, error) {
+	hasher := tmhash.New()
+	dec := json.NewDecoder(io.TeeReader(r, hasher))
+
+	if tok, err := dec.Token(); err != nil {
+		return nil, nil, err
+	} else if tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("expected a JSON object, got %v", tok)
+	}
+	// Every field but the app_state is gathered in a smaller object, which is
+	// unmarshalled as a whole, as GenesisDocFromJSON does.
+	var (
+		fields   bytes.Buffer
+		appState json.RawMessage
+	)
+	fields.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("expected a field name, got %v", tok)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("error reading field %q: %w", key, err)
+		}
+		if key == "app_state" {
+			appState = value
+			continue
+		}
+		if fields.Len() > 1 {
+			fields.WriteByte(',')
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields.Write(keyJSON)
+		fields.WriteByte(':')
+		fields.Write(value)
+	}
+	fields.WriteByte('}')
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	// Reading up to the end of the data also completes the checksum.
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, nil, errors.New("unexpected data after the genesis doc")
+	}
+
+	genDoc := GenesisDoc{}
+	if err := cmtjson.Unmarshal(fields.Bytes(), &genDoc); err != nil {
+		return nil, nil, err
+	}
+	genDoc.AppState = appState
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, nil, err
+	}
+	return &genDoc, hasher.Sum(nil), nil
+}
+
 // GenesisDocFromFile reads JSON data from a file and unmarshalls it into a GenesisDoc.
 func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
-	jsonBlob, err := os.ReadFile(genDocFile)
+	genDoc, _, err := GenesisDocFromFileWithChecksum(genDocFile)
+	return genDoc, err
+}
+
+// GenesisDocFromFileWithChecksum reads JSON data from a file, unmarshalls it
+// into a GenesisDoc, and returns it along with the SHA256 checksum of the file.
+// The file is streamed (see GenesisDocFromReader).
+func GenesisDocFromFileWithChecksum(genDocFile string) (*GenesisDoc, []byte, error) {
+	f, err := os.Open(genDocFile)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
+		return nil, nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
 	}
-	genDoc, err := GenesisDocFromJSON(jsonBlob)
+	defer f.Close()
+	genDoc, checksum, err := GenesisDocFromReader(bufio.NewReader(f))
 	if err != nil {
-		return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
+		return nil, nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
 	}
-	return genDoc, nil
+	return genDoc, checksum, nil
 }
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmttime "github.com/cometbft/cometbft/types/time"
 )
@@ -151,6 +153,26 @@ func TestGenesisValidatorHash(t *testing.T) {
 	assert.NotEmpty(t, genDoc.ValidatorHash())
 }
 
+func TestGenesisDocFromReader(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.AppState = []byte(`{"accounts": [{"address": "abc", "balance": "100"}]}`)
+	jsonBlob, err := cmtjson.MarshalIndent(genDoc, "", "  ")
+	require.NoError(t, err)
+	jsonBlob = append(jsonBlob, '\n')
+
+	expected, err := GenesisDocFromJSON(jsonBlob)
+	require.NoError(t, err)
+	streamed, checksum, err := GenesisDocFromReader(bytes.NewReader(jsonBlob))
+	require.NoError(t, err)
+	assert.Equal(t, expected, streamed)
+	assert.Equal(t, tmhash.Sum(jsonBlob), checksum)
+
+	_, _, err = GenesisDocFromReader(bytes.NewReader(append(jsonBlob, "{}"...)))
+	require.Error(t, err, "expected an error with trailing data")
+	_, _, err = GenesisDocFromReader(bytes.NewReader(jsonBlob[:len(jsonBlob)/2]))
+	require.Error(t, err, "expected an error with truncated data")
+}
+
 func randomGenesisDoc() *GenesisDoc {
 	pubkey := ed25519.GenPrivKey().PubKey()
 	return &GenesisDoc{
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto/merkle"
+)
+
+// HeaderLink proves that a header contains LastBlockID, and thus commits to
+// the hash of the previous header.
+type HeaderLink struct {
+	LastBlockID BlockID      `json:"last_block_id"`
+	Proof       merkle.Proof `json:"proof"`
+}
+
+// HeaderChainProof links the header at Height to the header at TrustedHeight
+// via the chain of LastBlockIDs. Links[0] belongs to the header at
+// TrustedHeight and Links[len(Links)-1] to the header at Height+1, so that a
+// client which trusts the hash of a recent header can verify an old one
+// without fetching the headers, or checking the commits, in between.
+type HeaderChainProof struct {
+	Height        int64        `json:"height"`
+	TrustedHeight int64        `json:"trusted_height"`
+	Links         []HeaderLink `json:"links"`
+}
+
+// NewHeaderChainProof returns the proof linking the first of the given
+// consecutive headers to the last one.
+func NewHeaderChainProof(headers []*Header) (*HeaderChainProof, error) {
+	if len(headers) == 0 {
+		return nil, errors.New("no headers")
+	}
+
+	first, last := headers[0], headers[len(headers)-1]
+	proof := &HeaderChainProof{
+		Height:        first.Height,
+		TrustedHeight: last.Height,
+		Links:         make([]HeaderLink, 0, len(headers)-1),
+	}
+	for i := len(headers) - 1; i > 0; i-- {
+		h := headers[i]
+		if !bytes.Equal(h.LastBlockID.Hash, headers[i-1].Hash()) {
+			return nil, fmt.Errorf("header %d does not link to header %d", h.Height, headers[i-1].Height)
+		}
+		p := h.LastBlockIDProof()
+		if p == nil {
+			return nil, fmt.Errorf("header %d has no hash", h.Height)
+		}
+		proof.Links = append(proof.Links, HeaderLink{LastBlockID: h.LastBlockID, Proof: *p})
+	}
+	return proof, nil
+}
+
+// ValidateBasic performs basic validation.
+func (hcp *HeaderChainProof) ValidateBasic() error {
+	if hcp.Height <= 0 {
+		return errors.New("non positive Height")
+	}
+	if hcp.TrustedHeight < hcp.Height {
+		return fmt.Errorf("TrustedHeight %d is lower than Height %d", hcp.TrustedHeight, hcp.Height)
+	}
+	if int64(len(hcp.Links)) != hcp.TrustedHeight-hcp.Height {
+		return fmt.Errorf("expected %d links, got %d", hcp.TrustedHeight-hcp.Height, len(hcp.Links))
+	}
+	for i, link := range hcp.Links {
+		if err := link.LastBlockID.ValidateBasic(); err != nil {
+			return fmt.Errorf("wrong LastBlockID of link %d: %w", i, err)
+		}
+		if link.Proof.Index != headerLastBlockIDIndex || link.Proof.Total != headerHashFieldsCount {
+			return fmt.Errorf("link %d does not prove a LastBlockID", i)
+		}
+	}
+	return nil
+}
+
+// Verify checks that header is at Height and that the links lead from it to
+// the header with trustedHash.
+func (hcp *HeaderChainProof) Verify(trustedHash []byte, header *Header) error {
+	if err := hcp.ValidateBasic(); err != nil {
+		return err
+	}
+	if header.Height != hcp.Height {
+		return fmt.Errorf("expected header at height %d, got %d", hcp.Height, header.Height)
+	}
+
+	root := trustedHash
+	for i, link := range hcp.Links {
+		pbbi := link.LastBlockID.ToProto()
+		leaf, err := pbbi.Marshal()
+		if err != nil {
+			return err
+		}
+		if err := link.Proof.Verify(root, leaf); err != nil {
+			return fmt.Errorf("link %d (height %d): %w", i, hcp.TrustedHeight-int64(i), err)
+		}
+		root = link.LastBlockID.Hash
+	}
+
+	if hash := header.Hash(); !bytes.Equal(root, hash) {
+		return fmt.Errorf("header hash %X does not match linked hash %X", hash, root)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+)
+
+func makeHeaderChain(length int) []*Header {
+	headers := make([]*Header, length)
+	for i := range headers {
+		h := makeRandHeader()
+		h.Height = int64(i + 1)
+		if i > 0 {
+			h.LastBlockID = makeBlockID(headers[i-1].Hash(), 1, cmtrand.Bytes(tmhash.Size))
+		}
+		headers[i] = &h
+	}
+	return headers
+}
+
+func TestHeaderChainProof(t *testing.T) {
+	headers := makeHeaderChain(10)
+	trusted := headers[len(headers)-1]
+
+	for i, header := range headers {
+		proof, err := NewHeaderChainProof(headers[i:])
+		require.NoError(t, err)
+		assert.EqualValues(t, header.Height, proof.Height)
+		assert.EqualValues(t, trusted.Height, proof.TrustedHeight)
+		assert.Len(t, proof.Links, len(headers)-i-1)
+		require.NoError(t, proof.Verify(trusted.Hash(), header))
+	}
+
+	proof, err := NewHeaderChainProof(headers[2:])
+	require.NoError(t, err)
+
+	// another header at the same height
+	other := *headers[2]
+	other.AppHash = cmtrand.Bytes(tmhash.Size)
+	assert.Error(t, proof.Verify(trusted.Hash(), &other))
+
+	// another trusted header
+	assert.Error(t, proof.Verify(cmtrand.Bytes(tmhash.Size), headers[2]))
+
+	// a header at another height
+	assert.Error(t, proof.Verify(trusted.Hash(), headers[3]))
+
+	// a proof of another field
+	proof.Links[0].Proof.Index = 5
+	assert.Error(t, proof.Verify(trusted.Hash(), headers[2]))
+
+	// a missing link
+	proof, err = NewHeaderChainProof(headers[2:])
+	require.NoError(t, err)
+	proof.Links = proof.Links[1:]
+	assert.Error(t, proof.Verify(trusted.Hash(), headers[2]))
+}
+
+func TestNewHeaderChainProofNotLinked(t *testing.T) {
+	headers := makeHeaderChain(3)
+	headers[2].LastBlockID = makeBlockIDRandom()
+
+	_, err := NewHeaderChainProof(headers)
+	require.Error(t, err)
+
+	_, err = NewHeaderChainProof(nil)
+	require.Error(t, err)
+}
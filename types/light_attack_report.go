@@ -0,0 +1,91 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Types of attacks on the light client.
+const (
+	LightClientAttackEquivocation = "equivocation"
+	LightClientAttackAmnesia      = "amnesia"
+	LightClientAttackLunatic      = "lunatic"
+)
+
+// LightClientAttackReport is the fork accountability report of an attack on
+// the light client, produced when its primary and a witness serve conflicting
+// headers which both verify against its trusted header. Each provider is held
+// in turn as the source of truth, which yields the evidence against the other
+// one. It holds the diverging headers with their commits, i.e. the signatures
+// of the validators, and the validators who signed conflicting blocks.
+type LightClientAttackReport struct {
+	ChainID    string    `json:"chain_id"`
+	DetectedAt time.Time `json:"detected_at"`
+	AttackType string    `json:"attack_type"`
+
+	// Primary and Witness are the addresses of the providers.
+	Primary string `json:"primary"`
+	Witness string `json:"witness"`
+
+	// PrimaryTrace and WitnessTrace are the light blocks verified from the
+	// common block to the conflicting block served by each provider.
+	PrimaryTrace []*LightBlock `json:"primary_trace"`
+	WitnessTrace []*LightBlock `json:"witness_trace"`
+
+	// EvidenceAgainstPrimary is built holding the witness as the source of
+	// truth, and EvidenceAgainstWitness the primary, if its trace verified.
+	EvidenceAgainstPrimary *LightClientAttackEvidence `json:"evidence_against_primary"`
+	EvidenceAgainstWitness *LightClientAttackEvidence `json:"evidence_against_witness,omitempty"`
+
+	// MisbehavingValidators are the validators who signed a conflicting block.
+	MisbehavingValidators []*Validator `json:"misbehaving_validators"`
+}
+
+// Evidence returns the evidence of the report.
+func (r *LightClientAttackReport) Evidence() []Evidence {
+	evidence := []Evidence{r.EvidenceAgainstPrimary}
+	if r.EvidenceAgainstWitness != nil {
+		evidence = append(evidence, r.EvidenceAgainstWitness)
+	}
+	return evidence
+}
+
+// ValidateBasic performs basic validation of the report and its evidence.
+func (r *LightClientAttackReport) ValidateBasic() error {
+	if r.ChainID == "" {
+		return errors.New("missing chain ID")
+	}
+	switch r.AttackType {
+	case LightClientAttackEquivocation, LightClientAttackAmnesia, LightClientAttackLunatic:
+	default:
+		return fmt.Errorf("unknown attack type %q", r.AttackType)
+	}
+	if r.EvidenceAgainstPrimary == nil {
+		return errors.New("missing evidence against the primary")
+	}
+	for i, ev := range r.Evidence() {
+		if err := ev.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid evidence #%d: %w", i, err)
+		}
+	}
+	for i, val := range r.MisbehavingValidators {
+		if err := val.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid misbehaving validator #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// LightClientAttackType returns the type of the attack proven by ev, given the
+// trusted block the conflicting block of ev was checked against.
+func LightClientAttackType(ev *LightClientAttackEvidence, trusted *LightBlock) string {
+	switch {
+	case ev.ConflictingHeaderIsInvalid(trusted.Header):
+		return LightClientAttackLunatic
+	case ev.ConflictingBlock.Commit.Round != trusted.Commit.Round:
+		return LightClientAttackAmnesia
+	default:
+		return LightClientAttackEquivocation
+	}
+}
@@ -38,6 +38,7 @@ type ConsensusParams struct {
 	Validator ValidatorParams `json:"validator"`
 	Version   VersionParams   `json:"version"`
 	ABCI      ABCIParams      `json:"abci"`
+	Mempool   MempoolParams   `json:"mempool"`
 }
 
 // BlockParams define limits on the block size and gas plus minimum time
@@ -58,6 +59,9 @@ type EvidenceParams struct {
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
 	PubKeyTypes []string `json:"pub_key_types"`
+	// DisableBatchVerification makes the nodes verify the signatures of the
+	// commits and the evidence one by one instead of in batches.
+	DisableBatchVerification bool `json:"disable_batch_verification"`
 }
 
 type VersionParams struct {
@@ -70,6 +74,13 @@ type ABCIParams struct {
 	VoteExtensionsEnableHeight int64 `json:"vote_extensions_enable_height"`
 }
 
+// MempoolParams coordinate the limits of the mempools across the network, so
+// that they do not depend on every operator's local config.
+type MempoolParams struct {
+	// MaxTxBytes is the maximum size of a transaction; 0 means no limit.
+	MaxTxBytes int64 `json:"max_tx_bytes"`
+}
+
 // VoteExtensionsEnabled returns true if vote extensions are enabled at height h
 // and false otherwise.
 func (a ABCIParams) VoteExtensionsEnabled(h int64) bool {
@@ -90,6 +101,7 @@ func DefaultConsensusParams() *ConsensusParams {
 		Validator: DefaultValidatorParams(),
 		Version:   DefaultVersionParams(),
 		ABCI:      DefaultABCIParams(),
+		Mempool:   DefaultMempoolParams(),
 	}
 }
 
@@ -131,6 +143,13 @@ func DefaultABCIParams() ABCIParams {
 	}
 }
 
+func DefaultMempoolParams() MempoolParams {
+	return MempoolParams{
+		// When set to 0, only the local config limits the transactions.
+		MaxTxBytes: 0,
+	}
+}
+
 func IsValidPubkeyType(params ValidatorParams, pubkeyType string) bool {
 	for i := 0; i < len(params.PubKeyTypes); i++ {
 		if params.PubKeyTypes[i] == pubkeyType {
@@ -189,6 +208,16 @@ func (params ConsensusParams) ValidateBasic() error {
 		return fmt.Errorf("ABCI.VoteExtensionsEnableHeight cannot be negative. Got: %d", params.ABCI.VoteExtensionsEnableHeight)
 	}
 
+	if params.Mempool.MaxTxBytes < 0 {
+		return fmt.Errorf("mempool.MaxTxBytes must be non negative. Got: %d",
+			params.Mempool.MaxTxBytes)
+	}
+
+	if params.Mempool.MaxTxBytes > maxBytes {
+		return fmt.Errorf("mempool.MaxTxBytes is greater than upper bound, %d > %d",
+			params.Mempool.MaxTxBytes, maxBytes)
+	}
+
 	if len(params.Validator.PubKeyTypes) == 0 {
 		return errors.New("len(Validator.PubKeyTypes) must be greater than 0")
 	}
@@ -276,6 +305,7 @@ func (params ConsensusParams) Update(params2 *cmtproto.ConsensusParams) Consensu
 		// Copy params2.Validator.PubkeyTypes, and set result's value to the copy.
 		// This avoids having to initialize the slice to 0 values, and then write to it again.
 		res.Validator.PubKeyTypes = append([]string{}, params2.Validator.PubKeyTypes...)
+		res.Validator.DisableBatchVerification = params2.Validator.DisableBatchVerification
 	}
 	if params2.Version != nil {
 		res.Version.App = params2.Version.App
@@ -283,6 +313,9 @@ func (params ConsensusParams) Update(params2 *cmtproto.ConsensusParams) Consensu
 	if params2.Abci != nil {
 		res.ABCI.VoteExtensionsEnableHeight = params2.Abci.GetVoteExtensionsEnableHeight()
 	}
+	if params2.Mempool != nil {
+		res.Mempool.MaxTxBytes = params2.Mempool.GetMaxTxBytes()
+	}
 	return res
 }
 
@@ -298,7 +331,8 @@ func (params *ConsensusParams) ToProto() cmtproto.ConsensusParams {
 			MaxBytes:        params.Evidence.MaxBytes,
 		},
 		Validator: &cmtproto.ValidatorParams{
-			PubKeyTypes: params.Validator.PubKeyTypes,
+			PubKeyTypes:              params.Validator.PubKeyTypes,
+			DisableBatchVerification: params.Validator.DisableBatchVerification,
 		},
 		Version: &cmtproto.VersionParams{
 			App: params.Version.App,
@@ -306,6 +340,9 @@ func (params *ConsensusParams) ToProto() cmtproto.ConsensusParams {
 		Abci: &cmtproto.ABCIParams{
 			VoteExtensionsEnableHeight: params.ABCI.VoteExtensionsEnableHeight,
 		},
+		Mempool: &cmtproto.MempoolParams{
+			MaxTxBytes: params.Mempool.MaxTxBytes,
+		},
 	}
 }
 
@@ -321,7 +358,8 @@ func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams
 			MaxBytes:        pbParams.Evidence.MaxBytes,
 		},
 		Validator: ValidatorParams{
-			PubKeyTypes: pbParams.Validator.PubKeyTypes,
+			PubKeyTypes:              pbParams.Validator.PubKeyTypes,
+			DisableBatchVerification: pbParams.Validator.DisableBatchVerification,
 		},
 		Version: VersionParams{
 			App: pbParams.Version.App,
@@ -330,5 +368,8 @@ func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams
 	if pbParams.Abci != nil {
 		c.ABCI.VoteExtensionsEnableHeight = pbParams.Abci.GetVoteExtensionsEnableHeight()
 	}
+	if pbParams.Mempool != nil {
+		c.Mempool.MaxTxBytes = pbParams.Mempool.GetMaxTxBytes()
+	}
 	return c
 }
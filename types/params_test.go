@@ -153,6 +153,40 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 	assert.EqualValues(t, 1, updated.Version.App)
 }
 
+func TestConsensusParamsUpdate_Mempool(t *testing.T) {
+	params := makeParams(1000, 2, 3, 0, valEd25519, 0)
+	require.NoError(t, params.ValidateBasic())
+	assert.EqualValues(t, 0, params.Mempool.MaxTxBytes)
+
+	updated := params.Update(
+		&cmtproto.ConsensusParams{Mempool: &cmtproto.MempoolParams{MaxTxBytes: 100}})
+	assert.EqualValues(t, 100, updated.Mempool.MaxTxBytes)
+	require.NoError(t, updated.ValidateBasic())
+	assert.Equal(t, updated, ConsensusParamsFromProto(updated.ToProto()))
+
+	// The limit cannot be negative nor exceed the block size.
+	updated.Mempool.MaxTxBytes = -1
+	require.Error(t, updated.ValidateBasic())
+	updated.Mempool.MaxTxBytes = 1001
+	require.Error(t, updated.ValidateBasic())
+}
+
+func TestConsensusParamsUpdate_DisableBatchVerification(t *testing.T) {
+	params := makeParams(1, 2, 3, 0, valEd25519, 0)
+	assert.False(t, params.Validator.DisableBatchVerification)
+
+	updated := params.Update(&cmtproto.ConsensusParams{Validator: &cmtproto.ValidatorParams{
+		PubKeyTypes:              valEd25519,
+		DisableBatchVerification: true,
+	}})
+	assert.True(t, updated.Validator.DisableBatchVerification)
+	assert.Equal(t, updated, ConsensusParamsFromProto(updated.ToProto()))
+
+	// The params which don't update the validator params keep it.
+	updated = updated.Update(&cmtproto.ConsensusParams{Block: &cmtproto.BlockParams{MaxBytes: 100, MaxGas: 200}})
+	assert.True(t, updated.Validator.DisableBatchVerification)
+}
+
 func TestConsensusParamsUpdate_VoteExtensionsEnableHeight(t *testing.T) {
 	t.Run("set to height but initial height already run", func(*testing.T) {
 		initialParams := makeParams(1, 0, 2, 0, valEd25519, 1)
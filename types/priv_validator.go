@@ -20,6 +20,26 @@ type PrivValidator interface {
 	SignProposal(chainID string, proposal *cmtproto.Proposal) error
 }
 
+// AttestationSigner is implemented by the PrivValidators able to sign
+// attestations of committed blocks (see Attestation). It is optional, so that
+// the PrivValidators implemented outside CometBFT need not sign attestations.
+type AttestationSigner interface {
+	SignAttestation(chainID string, attestation *cmtproto.Attestation) error
+}
+
+// KeyRotator is implemented by the PrivValidators able to switch to a next
+// key at a scheduled height.
+type KeyRotator interface {
+	// GetPubKeyAt returns the public key signing at the given height.
+	GetPubKeyAt(height int64) (crypto.PubKey, error)
+	// NextPubKey returns the next public key and the first height it signs,
+	// or nil if no rotation is scheduled.
+	NextPubKey() (crypto.PubKey, int64)
+	// ScheduleKeyRotation generates the next key, which signs from the given
+	// height on, and returns its public key.
+	ScheduleKeyRotation(height int64) (crypto.PubKey, error)
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
@@ -115,6 +135,17 @@ func (pv MockPV) SignProposal(chainID string, proposal *cmtproto.Proposal) error
 	return nil
 }
 
+// SignAttestation implements AttestationSigner.
+func (pv MockPV) SignAttestation(chainID string, attestation *cmtproto.Attestation) error {
+	signBytes := AttestationSignBytes(chainID, attestation)
+	sig, err := pv.PrivKey.Sign(signBytes)
+	if err != nil {
+		return err
+	}
+	attestation.Signature = sig
+	return nil
+}
+
 func (pv MockPV) ExtractIntoValidator(votingPower int64) *Validator {
 	pubKey, _ := pv.GetPubKey()
 	return &Validator{
@@ -152,6 +183,11 @@ func (pv *ErroringMockPV) SignProposal(string, *cmtproto.Proposal) error {
 	return ErroringMockPVErr
 }
 
+// SignAttestation implements AttestationSigner.
+func (pv *ErroringMockPV) SignAttestation(string, *cmtproto.Attestation) error {
+	return ErroringMockPVErr
+}
+
 // NewErroringMockPV returns a MockPV that fails on each signing request. Again, for testing only.
 
 func NewErroringMockPV() *ErroringMockPV {
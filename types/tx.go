@@ -45,8 +45,12 @@ type Txs []Tx
 // Hash returns the Merkle root hash of the transaction hashes.
 // i.e. the leaves of the tree are the hashes of the txs.
 func (txs Txs) Hash() []byte {
-	hl := txs.hashList()
-	return merkle.HashFromByteSlices(hl)
+	h := merkle.NewHasher()
+	for _, tx := range txs {
+		key := tx.Key() // the hash of the tx, without allocating it
+		h.AddLeaf(key[:])
+	}
+	return h.Root()
 }
 
 // Index returns the index of this transaction in the list, or -1 if not found.
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+)
+
+// TxResultProof proves the result of a transaction: TxProof proves the
+// transaction against the DataHash of the header of its block, and
+// ResultProof proves its result, stripped of the non-deterministic fields,
+// against the LastResultsHash of the next header.
+type TxResultProof struct {
+	TxProof     TxProof           `json:"tx_proof"`
+	Result      abci.ExecTxResult `json:"result"`
+	ResultProof merkle.Proof      `json:"result_proof"`
+}
+
+// NewTxResultProof returns the proof of the result of the i-th transaction of
+// txs, given the results of all of them.
+func NewTxResultProof(txs Txs, results []*abci.ExecTxResult, i int) (*TxResultProof, error) {
+	if len(txs) != len(results) {
+		return nil, fmt.Errorf("got %d results for %d transactions", len(results), len(txs))
+	}
+	if i < 0 || i >= len(txs) {
+		return nil, fmt.Errorf("transaction %d is out of range", i)
+	}
+	abciResults := NewResults(results)
+	return &TxResultProof{
+		TxProof:     txs.Proof(i),
+		Result:      *abciResults[i],
+		ResultProof: abciResults.ProveResult(i),
+	}, nil
+}
+
+// Verify checks the transaction against dataHash, the DataHash of the header
+// of its block, and its result against lastResultsHash, the LastResultsHash
+// of the next header.
+func (trp *TxResultProof) Verify(dataHash, lastResultsHash []byte) error {
+	if err := trp.TxProof.Validate(dataHash); err != nil {
+		return fmt.Errorf("wrong transaction proof: %w", err)
+	}
+	if trp.ResultProof.Index != trp.TxProof.Proof.Index || trp.ResultProof.Total != trp.TxProof.Proof.Total {
+		return fmt.Errorf("result %d of %d does not belong to transaction %d of %d",
+			trp.ResultProof.Index, trp.ResultProof.Total, trp.TxProof.Proof.Index, trp.TxProof.Proof.Total)
+	}
+	bz, err := abci.DeterministicExecTxResult(&trp.Result).Marshal()
+	if err != nil {
+		return err
+	}
+	if err := trp.ResultProof.Verify(lastResultsHash, bz); err != nil {
+		return fmt.Errorf("wrong result proof: %w", err)
+	}
+	return nil
+}
+
+// TxAbsenceProof proves that a transaction is absent from a block with the
+// hashes of all the transactions of the block, whose Merkle root is the
+// DataHash of its header.
+type TxAbsenceProof struct {
+	TxHashes []cmtbytes.HexBytes `json:"tx_hashes"`
+}
+
+// NewTxAbsenceProof returns the proof that a transaction is absent from the
+// block of txs.
+func NewTxAbsenceProof(txs Txs) *TxAbsenceProof {
+	hashes := make([]cmtbytes.HexBytes, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash()
+	}
+	return &TxAbsenceProof{TxHashes: hashes}
+}
+
+// Verify checks that the transaction with hash txHash is absent from the
+// block whose header has dataHash.
+func (tap *TxAbsenceProof) Verify(dataHash, txHash []byte) error {
+	leaves := make([][]byte, len(tap.TxHashes))
+	for i, hash := range tap.TxHashes {
+		if len(hash) != tmhash.Size {
+			return fmt.Errorf("wrong size of transaction hash %d: %d", i, len(hash))
+		}
+		if bytes.Equal(hash, txHash) {
+			return fmt.Errorf("transaction %X is in the block at index %d", txHash, i)
+		}
+		leaves[i] = hash
+	}
+	if !bytes.Equal(merkle.HashFromByteSlices(leaves), dataHash) {
+		return errors.New("transaction hashes do not match the data hash")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+)
+
+func TestTxResultProof(t *testing.T) {
+	txs := makeTxs(5, 20)
+	results := make([]*abci.ExecTxResult, len(txs))
+	for i := range results {
+		results[i] = &abci.ExecTxResult{Code: uint32(i), Data: []byte{byte(i)}, Log: "not hashed", GasUsed: int64(i)}
+	}
+	dataHash := txs.Hash()
+	lastResultsHash := NewResults(results).Hash()
+
+	for i := range txs {
+		proof, err := NewTxResultProof(txs, results, i)
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(dataHash, lastResultsHash))
+		assert.EqualValues(t, i, proof.Result.Code)
+		assert.Empty(t, proof.Result.Log)
+	}
+
+	_, err := NewTxResultProof(txs, results[1:], 0)
+	require.Error(t, err)
+	_, err = NewTxResultProof(txs, results, len(txs))
+	require.Error(t, err)
+
+	// another result
+	proof, err := NewTxResultProof(txs, results, 2)
+	require.NoError(t, err)
+	proof.Result.Code = 1
+	assert.Error(t, proof.Verify(dataHash, lastResultsHash))
+
+	// the result of another transaction
+	other, err := NewTxResultProof(txs, results, 3)
+	require.NoError(t, err)
+	proof, err = NewTxResultProof(txs, results, 2)
+	require.NoError(t, err)
+	proof.Result, proof.ResultProof = other.Result, other.ResultProof
+	assert.Error(t, proof.Verify(dataHash, lastResultsHash))
+
+	// other headers
+	assert.Error(t, proof.Verify(cmtrand.Bytes(tmhash.Size), lastResultsHash))
+	proof, err = NewTxResultProof(txs, results, 2)
+	require.NoError(t, err)
+	assert.Error(t, proof.Verify(dataHash, cmtrand.Bytes(tmhash.Size)))
+}
+
+func TestTxAbsenceProof(t *testing.T) {
+	txs := makeTxs(5, 20)
+	dataHash := txs.Hash()
+	absent := Tx("absent").Hash()
+
+	proof := NewTxAbsenceProof(txs)
+	require.NoError(t, proof.Verify(dataHash, absent))
+	assert.Error(t, proof.Verify(dataHash, txs[3].Hash()))
+	assert.Error(t, proof.Verify(cmtrand.Bytes(tmhash.Size), absent))
+
+	// a missing transaction
+	proof.TxHashes = proof.TxHashes[1:]
+	assert.Error(t, proof.Verify(dataHash, absent))
+
+	// an empty block
+	require.NoError(t, NewTxAbsenceProof(Txs{}).Verify(Txs{}.Hash(), absent))
+}
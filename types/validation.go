@@ -3,7 +3,9 @@ package types
 import (
 	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/batch"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
@@ -12,8 +14,46 @@ import (
 
 const batchVerifyThreshold = 2
 
-func shouldBatchVerify(vals *ValidatorSet, commit *Commit) bool {
-	return len(commit.Signatures) >= batchVerifyThreshold && batch.SupportsBatchVerifier(vals.GetProposer().PubKey)
+// VerifyCommitOption sets an optional parameter of the verification of a
+// commit.
+type VerifyCommitOption func(*verifyCommitConfig)
+
+type verifyCommitConfig struct {
+	noBatch bool
+}
+
+// WithBatchVerification sets whether the signatures are verified in batches,
+// when the keys support it, which is the default. The nodes follow the
+// DisableBatchVerification validator param of the chain.
+func WithBatchVerification(enabled bool) VerifyCommitOption {
+	return func(cfg *verifyCommitConfig) {
+		cfg.noBatch = !enabled
+	}
+}
+
+// BatchVerificationEnabled returns true unless the options disable the batch
+// verification of the signatures.
+func BatchVerificationEnabled(opts ...VerifyCommitOption) bool {
+	var cfg verifyCommitConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return !cfg.noBatch
+}
+
+// shouldBatchVerify returns true if the options allow batch verification, the
+// commit has enough signatures and any of the validators has a key supporting
+// batch verification.
+func shouldBatchVerify(vals *ValidatorSet, commit *Commit, opts []VerifyCommitOption) bool {
+	if !BatchVerificationEnabled(opts...) || len(commit.Signatures) < batchVerifyThreshold {
+		return false
+	}
+	for _, val := range vals.Validators {
+		if batch.SupportsBatchVerifier(val.PubKey) {
+			return true
+		}
+	}
+	return false
 }
 
 // VerifyCommit verifies +2/3 of the set had signed the given commit.
@@ -24,7 +64,7 @@ func shouldBatchVerify(vals *ValidatorSet, commit *Commit) bool {
 // includes which validators signed. For instance, Gaia incentivizes proposers
 // with a bonus for including more than +2/3 of the signatures.
 func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
-	height int64, commit *Commit,
+	height int64, commit *Commit, opts ...VerifyCommitOption,
 ) error {
 	// run a basic validation of the arguments
 	if err := verifyBasicValsAndCommit(vals, commit, height, blockID); err != nil {
@@ -42,7 +82,7 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 	count := func(c CommitSig) bool { return c.BlockIDFlag == BlockIDFlagCommit }
 
 	// attempt to batch verify
-	if shouldBatchVerify(vals, commit) {
+	if shouldBatchVerify(vals, commit, opts) {
 		return verifyCommitBatch(chainID, vals, commit,
 			votingPowerNeeded, ignore, count, true, true)
 	}
@@ -64,8 +104,9 @@ func VerifyCommitLight(
 	blockID BlockID,
 	height int64,
 	commit *Commit,
+	opts ...VerifyCommitOption,
 ) error {
-	return verifyCommitLightInternal(chainID, vals, blockID, height, commit, false)
+	return verifyCommitLightInternal(chainID, vals, blockID, height, commit, false, opts)
 }
 
 // VerifyCommitLightAllSignatures verifies +2/3 of the set had signed the given commit.
@@ -77,8 +118,9 @@ func VerifyCommitLightAllSignatures(
 	blockID BlockID,
 	height int64,
 	commit *Commit,
+	opts ...VerifyCommitOption,
 ) error {
-	return verifyCommitLightInternal(chainID, vals, blockID, height, commit, true)
+	return verifyCommitLightInternal(chainID, vals, blockID, height, commit, true, opts)
 }
 
 func verifyCommitLightInternal(
@@ -88,6 +130,7 @@ func verifyCommitLightInternal(
 	height int64,
 	commit *Commit,
 	countAllSignatures bool,
+	opts []VerifyCommitOption,
 ) error {
 	// run a basic validation of the arguments
 	if err := verifyBasicValsAndCommit(vals, commit, height, blockID); err != nil {
@@ -104,7 +147,7 @@ func verifyCommitLightInternal(
 	count := func(c CommitSig) bool { return true }
 
 	// attempt to batch verify
-	if shouldBatchVerify(vals, commit) {
+	if shouldBatchVerify(vals, commit, opts) {
 		return verifyCommitBatch(chainID, vals, commit,
 			votingPowerNeeded, ignore, count, countAllSignatures, true)
 	}
@@ -127,8 +170,9 @@ func VerifyCommitLightTrusting(
 	vals *ValidatorSet,
 	commit *Commit,
 	trustLevel cmtmath.Fraction,
+	opts ...VerifyCommitOption,
 ) error {
-	return verifyCommitLightTrustingInternal(chainID, vals, commit, trustLevel, false)
+	return verifyCommitLightTrustingInternal(chainID, vals, commit, trustLevel, false, opts)
 }
 
 // VerifyCommitLightTrustingAllSignatures verifies that trustLevel of the validator
@@ -143,8 +187,9 @@ func VerifyCommitLightTrustingAllSignatures(
 	vals *ValidatorSet,
 	commit *Commit,
 	trustLevel cmtmath.Fraction,
+	opts ...VerifyCommitOption,
 ) error {
-	return verifyCommitLightTrustingInternal(chainID, vals, commit, trustLevel, true)
+	return verifyCommitLightTrustingInternal(chainID, vals, commit, trustLevel, true, opts)
 }
 
 func verifyCommitLightTrustingInternal(
@@ -153,6 +198,7 @@ func verifyCommitLightTrustingInternal(
 	commit *Commit,
 	trustLevel cmtmath.Fraction,
 	countAllSignatures bool,
+	opts []VerifyCommitOption,
 ) error {
 	// sanity checks
 	if vals == nil {
@@ -181,7 +227,7 @@ func verifyCommitLightTrustingInternal(
 	// attempt to batch verify commit. As the validator set doesn't necessarily
 	// correspond with the validator set that signed the block we need to look
 	// up by address rather than index.
-	if shouldBatchVerify(vals, commit) {
+	if shouldBatchVerify(vals, commit, opts) {
 		return verifyCommitBatch(chainID, vals, commit,
 			votingPowerNeeded, ignore, count, countAllSignatures, false)
 	}
@@ -209,8 +255,12 @@ func ValidateHash(h []byte) error {
 // to verifyCommitSingle in behavior, just faster iff every signature in the
 // batch is valid.
 //
+// The signatures are batched by key type, so that the validator sets mixing
+// key types are batch verified too. The signatures of the keys not supporting
+// batch verification are verified one by one.
+//
 // Note: The caller is responsible for checking to see if this routine is
-// usable via `shouldVerifyBatch(vals, commit)`.
+// usable via `shouldBatchVerify(vals, commit, opts)`.
 func verifyCommitBatch(
 	chainID string,
 	vals *ValidatorSet,
@@ -225,15 +275,16 @@ func verifyCommitBatch(
 		val                *Validator
 		valIdx             int32
 		seenVals           = make(map[int32]int, len(commit.Signatures))
-		batchSigIdxs       = make([]int, 0, len(commit.Signatures))
 		talliedVotingPower int64
+		// the batch verifiers by key type, and the indexes in
+		// commit.Signatures of the signatures added to each
+		verifiers    = make(map[string]crypto.BatchVerifier)
+		batchSigIdxs = make(map[string][]int)
 	)
-	// attempt to create a batch verifier
-	bv, ok := batch.CreateBatchVerifier(vals.GetProposer().PubKey)
 	// re-check if batch verification is supported
-	if !ok || len(commit.Signatures) < batchVerifyThreshold {
+	if len(commit.Signatures) < batchVerifyThreshold {
 		// This should *NEVER* happen.
-		return fmt.Errorf("unsupported signature algorithm or insufficient signatures for batch verification")
+		return fmt.Errorf("insufficient signatures for batch verification")
 	}
 
 	for idx, commitSig := range commit.Signatures {
@@ -264,14 +315,31 @@ func verifyCommitBatch(
 			seenVals[valIdx] = idx
 		}
 
+		if val.PubKey == nil {
+			return fmt.Errorf("validator %v has a nil PubKey at index %d", val, idx)
+		}
+
 		// Validate signature.
 		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
 
-		// add the key, sig and message to the verifier
-		if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
-			return err
+		// add the key, sig and message to the verifier of the key type, or
+		// verify the signature right away if the key type doesn't support
+		// batch verification
+		keyType := val.PubKey.Type()
+		bv, ok := verifiers[keyType]
+		if !ok {
+			if bv, ok = batch.CreateBatchVerifier(val.PubKey); ok {
+				verifiers[keyType] = bv
+			}
+		}
+		if ok {
+			if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
+				return err
+			}
+			batchSigIdxs[keyType] = append(batchSigIdxs[keyType], idx)
+		} else if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
+			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
 		}
-		batchSigIdxs = append(batchSigIdxs, idx)
 
 		// If this signature counts then add the voting power of the validator
 		// to the tally
@@ -292,29 +360,38 @@ func verifyCommitBatch(
 		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
 	}
 
-	// attempt to verify the batch.
-	ok, validSigs := bv.Verify()
-	if ok {
-		// success
-		return nil
+	// attempt to verify the batches, in the order of the key types for the
+	// first invalid signature reported to be deterministic.
+	keyTypes := make([]string, 0, len(verifiers))
+	for keyType := range verifiers {
+		keyTypes = append(keyTypes, keyType)
 	}
+	sort.Strings(keyTypes)
+	for _, keyType := range keyTypes {
+		ok, validSigs := verifiers[keyType].Verify()
+		if ok {
+			continue
+		}
 
-	// one or more of the signatures is invalid, find and return the first
-	// invalid signature.
-	for i, ok := range validSigs {
-		if !ok {
-			// go back from the batch index to the commit.Signatures index
-			idx := batchSigIdxs[i]
-			sig := commit.Signatures[idx]
-			return fmt.Errorf("wrong signature (#%d): %X", idx, sig)
+		// one or more of the signatures is invalid, find and return the first
+		// invalid signature.
+		for i, ok := range validSigs {
+			if !ok {
+				// go back from the batch index to the commit.Signatures index
+				idx := batchSigIdxs[keyType][i]
+				sig := commit.Signatures[idx]
+				return fmt.Errorf("wrong signature (#%d): %X", idx, sig)
+			}
 		}
+
+		// execution reaching here is a bug, and one of the following has
+		// happened:
+		//  * non-zero tallied voting power, empty batch (impossible?)
+		//  * bv.Verify() returned `false, []bool{true, ..., true}` (BUG)
+		return fmt.Errorf("BUG: batch verification failed with no invalid signatures")
 	}
 
-	// execution reaching here is a bug, and one of the following has
-	// happened:
-	//  * non-zero tallied voting power, empty batch (impossible?)
-	//  * bv.Verify() returned `false, []bool{true, ..., true}` (BUG)
-	return fmt.Errorf("BUG: batch verification failed with no invalid signatures")
+	return nil
 }
 
 // Single Verification
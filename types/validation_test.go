@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
 	"testing"
 	"time"
@@ -8,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/crypto/secp256k1"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 )
 
@@ -293,6 +296,55 @@ func TestValidatorSet_VerifyCommitLightTrusting(t *testing.T) {
 	}
 }
 
+func TestValidatorSet_VerifyCommit_MixedKeyTypes(t *testing.T) {
+	privVals := make([]PrivValidator, 0, 5)
+	for i := 0; i < 3; i++ {
+		privVals = append(privVals, NewMockPV())
+	}
+	for i := 0; i < 2; i++ {
+		privVals = append(privVals, NewMockPVWithParams(secp256k1.GenPrivKey(), false, false))
+	}
+	sort.Sort(PrivValidatorsByAddress(privVals))
+	valz := make([]*Validator, len(privVals))
+	for i, pv := range privVals {
+		pubKey, err := pv.GetPubKey()
+		require.NoError(t, err)
+		valz[i] = NewValidator(pubKey, 10)
+	}
+	vals := NewValidatorSet(valz)
+
+	blockID := makeBlockIDRandom()
+	voteSet := NewVoteSet("test_chain_id", 1, 0, PrecommitType, vals)
+	extCommit, err := MakeExtCommit(blockID, 1, 0, voteSet, privVals, time.Now(), false)
+	require.NoError(t, err)
+	commit := extCommit.ToCommit()
+
+	// The ed25519 signatures are batch verified, the secp256k1 ones one by one.
+	require.True(t, shouldBatchVerify(vals, commit, nil))
+	require.NoError(t, vals.VerifyCommit("test_chain_id", blockID, 1, commit))
+	require.NoError(t, vals.VerifyCommitLightTrustingAllSignatures("test_chain_id", commit,
+		cmtmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// The chain can disable the batch verification.
+	noBatch := WithBatchVerification(false)
+	require.False(t, shouldBatchVerify(vals, commit, []VerifyCommitOption{noBatch}))
+	require.NoError(t, vals.VerifyCommit("test_chain_id", blockID, 1, commit, noBatch))
+	require.NoError(t, vals.VerifyCommitLight("test_chain_id", blockID, 1, commit, noBatch))
+
+	for idx, val := range vals.Validators {
+		badCommit := *commit
+		badCommit.Signatures = append([]CommitSig(nil), commit.Signatures...)
+		sig := append([]byte(nil), commit.Signatures[idx].Signature...)
+		sig[0] ^= 0xff
+		badCommit.Signatures[idx].Signature = sig
+
+		err := vals.VerifyCommit("test_chain_id", blockID, 1, &badCommit)
+		require.ErrorContains(t, err, fmt.Sprintf("wrong signature (#%d)", idx), val.PubKey.Type())
+		err = vals.VerifyCommit("test_chain_id", blockID, 1, &badCommit, noBatch)
+		require.ErrorContains(t, err, fmt.Sprintf("wrong signature (#%d)", idx), val.PubKey.Type())
+	}
+}
+
 func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 	var (
 		blockID               = makeBlockIDRandom()
@@ -307,3 +359,47 @@ func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 		assert.Contains(t, err.Error(), "int64 overflow")
 	}
 }
+
+// The batch verification of a commit of ed25519 keys is expected to be about
+// twice as fast as the verification of its signatures one by one.
+func BenchmarkVerifyCommit(b *testing.B) {
+	for _, n := range []int{100, 1000} {
+		blockID := makeBlockIDRandom()
+		voteSet, valSet, vals := randVoteSet(1, 1, PrecommitType, n, 1, false)
+		extCommit, err := MakeExtCommit(blockID, 1, 1, voteSet, vals, time.Now(), false)
+		require.NoError(b, err)
+		commit := extCommit.ToCommit()
+		votingPowerNeeded := valSet.TotalVotingPower() * 2 / 3
+		ignore := func(c CommitSig) bool { return c.BlockIDFlag == BlockIDFlagAbsent }
+		count := func(c CommitSig) bool { return c.BlockIDFlag == BlockIDFlagCommit }
+
+		b.Run(fmt.Sprintf("batch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				err := verifyCommitBatch("test_chain_id", valSet, commit, votingPowerNeeded, ignore, count, true, true)
+				require.NoError(b, err)
+			}
+		})
+		b.Run(fmt.Sprintf("single/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				err := verifyCommitSingle("test_chain_id", valSet, commit, votingPowerNeeded, ignore, count, true, true)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func BenchmarkValidatorSet_VerifyCommitLightTrusting(b *testing.B) {
+	const n = 1000
+	blockID := makeBlockIDRandom()
+	voteSet, valSet, vals := randVoteSet(1, 1, PrecommitType, n, 1, false)
+	extCommit, err := MakeExtCommit(blockID, 1, 1, voteSet, vals, time.Now(), false)
+	require.NoError(b, err)
+	commit := extCommit.ToCommit()
+	trustLevel := cmtmath.Fraction{Numerator: 1, Denominator: 3}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := valSet.VerifyCommitLightTrustingAllSignatures("test_chain_id", commit, trustLevel)
+		require.NoError(b, err)
+	}
+}
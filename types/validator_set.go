@@ -48,6 +48,8 @@ var ErrTotalVotingPowerOverflow = fmt.Errorf("total voting power of resulting va
 //
 // NOTE: Not goroutine-safe.
 // NOTE: All get/set to validators should copy the value for safety.
+// NOTE: Validators should not be modified in place, e.g. vals.Validators[i] =
+// val, as the index of the validators by address is not updated then.
 type ValidatorSet struct {
 	// NOTE: persisted via reflect, must be exported.
 	Validators []*Validator `json:"validators"`
@@ -55,6 +57,11 @@ type ValidatorSet struct {
 
 	// cached (unexported)
 	totalVotingPower int64
+	// indexes of the validators by address, and the Validators slice they
+	// were built for. Built by the constructors, so that the lookups by
+	// address don't write to the set.
+	addressIndex map[string]int32
+	indexedVals  []*Validator
 }
 
 // NewValidatorSet initializes a ValidatorSet by copying over the values from
@@ -164,13 +171,15 @@ func (vals *ValidatorSet) RescalePriorities(diffMax int64) {
 }
 
 func (vals *ValidatorSet) incrementProposerPriority() *Validator {
+	// Find the validator with most ProposerPriority in the same pass.
+	var mostest *Validator
 	for _, val := range vals.Validators {
 		// Check for overflow for sum.
 		newPrio := safeAddClip(val.ProposerPriority, val.VotingPower)
 		val.ProposerPriority = newPrio
+		mostest = mostest.CompareProposerPriority(val)
 	}
 	// Decrement the validator with most ProposerPriority.
-	mostest := vals.getValWithMostPriority()
 	// Mind the underflow.
 	mostest.ProposerPriority = safeSubClip(mostest.ProposerPriority, vals.TotalVotingPower())
 
@@ -216,14 +225,6 @@ func computeMaxMinPriorityDiff(vals *ValidatorSet) int64 {
 	return diff
 }
 
-func (vals *ValidatorSet) getValWithMostPriority() *Validator {
-	var res *Validator
-	for _, val := range vals.Validators {
-		res = res.CompareProposerPriority(val)
-	}
-	return res
-}
-
 func (vals *ValidatorSet) shiftByAvgProposerPriority() {
 	if vals.IsNilOrEmpty() {
 		panic("empty validator set")
@@ -248,33 +249,77 @@ func validatorListCopy(valsList []*Validator) []*Validator {
 
 // Copy each validator into a new ValidatorSet.
 func (vals *ValidatorSet) Copy() *ValidatorSet {
-	return &ValidatorSet{
+	cp := &ValidatorSet{
 		Validators:       validatorListCopy(vals.Validators),
 		Proposer:         vals.Proposer,
 		totalVotingPower: vals.totalVotingPower,
 	}
+	// The copy has the validators in the same order, so it shares the index,
+	// which is never modified once built.
+	if vals.isIndexed() {
+		cp.addressIndex, cp.indexedVals = vals.addressIndex, cp.Validators
+	}
+	return cp
 }
 
-// HasAddress returns true if address given is in the validator set, false -
-// otherwise.
-func (vals *ValidatorSet) HasAddress(address []byte) bool {
-	for _, val := range vals.Validators {
+// buildAddressIndex indexes the validators by address. It must be called
+// whenever Validators is set or reordered.
+func (vals *ValidatorSet) buildAddressIndex() {
+	vals.addressIndex = make(map[string]int32, len(vals.Validators))
+	for idx, val := range vals.Validators {
+		// Keep the first of duplicate addresses, as a linear search would.
+		if _, ok := vals.addressIndex[string(val.Address)]; !ok {
+			vals.addressIndex[string(val.Address)] = int32(idx)
+		}
+	}
+	vals.indexedVals = vals.Validators
+}
+
+// isIndexed returns true if the address index was built for Validators, i.e.
+// Validators was not set since.
+func (vals *ValidatorSet) isIndexed() bool {
+	if vals.addressIndex == nil || len(vals.indexedVals) != len(vals.Validators) {
+		return false
+	}
+	return len(vals.Validators) == 0 || &vals.indexedVals[0] == &vals.Validators[0]
+}
+
+// indexOf returns the index of the validator with address, or -1 if it is
+// not in the set. It is a map lookup if the set is indexed, and a linear
+// search otherwise.
+func (vals *ValidatorSet) indexOf(address []byte) int32 {
+	if vals.isIndexed() {
+		idx, ok := vals.addressIndex[string(address)]
+		if !ok {
+			return -1
+		}
+		// The validators may have been reordered in place, e.g. sorted.
+		if bytes.Equal(vals.Validators[idx].Address, address) {
+			return idx
+		}
+	}
+	for idx, val := range vals.Validators {
 		if bytes.Equal(val.Address, address) {
-			return true
+			return int32(idx)
 		}
 	}
-	return false
+	return -1
+}
+
+// HasAddress returns true if address given is in the validator set, false -
+// otherwise.
+func (vals *ValidatorSet) HasAddress(address []byte) bool {
+	return vals.indexOf(address) >= 0
 }
 
 // GetByAddress returns an index of the validator with address and validator
 // itself (copy) if found. Otherwise, -1 and nil are returned.
 func (vals *ValidatorSet) GetByAddress(address []byte) (index int32, val *Validator) {
-	for idx, val := range vals.Validators {
-		if bytes.Equal(val.Address, address) {
-			return int32(idx), val.Copy()
-		}
+	idx := vals.indexOf(address)
+	if idx < 0 {
+		return -1, nil
 	}
-	return -1, nil
+	return idx, vals.Validators[idx].Copy()
 }
 
 // GetByIndex returns the validator's address and validator itself (copy) by
@@ -639,6 +684,7 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 	vals.shiftByAvgProposerPriority()
 
 	sort.Sort(ValidatorsByVotingPower(vals.Validators))
+	vals.buildAddressIndex()
 
 	return nil
 }
@@ -663,9 +709,9 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 // VerifyCommit verifies +2/3 of the set had signed the given commit and all
 // other signatures are valid.
 func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
-	height int64, commit *Commit,
+	height int64, commit *Commit, opts ...VerifyCommitOption,
 ) error {
-	return VerifyCommit(chainID, vals, blockID, height, commit)
+	return VerifyCommit(chainID, vals, blockID, height, commit, opts...)
 }
 
 // LIGHT CLIENT VERIFICATION METHODS
@@ -673,17 +719,17 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 // VerifyCommitLight verifies +2/3 of the set had signed the given commit.
 // It does NOT count all signatures.
 func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
-	height int64, commit *Commit,
+	height int64, commit *Commit, opts ...VerifyCommitOption,
 ) error {
-	return VerifyCommitLight(chainID, vals, blockID, height, commit)
+	return VerifyCommitLight(chainID, vals, blockID, height, commit, opts...)
 }
 
 // VerifyCommitLight verifies +2/3 of the set had signed the given commit.
 // It DOES count all signatures.
 func (vals *ValidatorSet) VerifyCommitLightAllSignatures(chainID string, blockID BlockID,
-	height int64, commit *Commit,
+	height int64, commit *Commit, opts ...VerifyCommitOption,
 ) error {
-	return VerifyCommitLightAllSignatures(chainID, vals, blockID, height, commit)
+	return VerifyCommitLightAllSignatures(chainID, vals, blockID, height, commit, opts...)
 }
 
 // VerifyCommitLightTrusting verifies that trustLevel of the validator set signed
@@ -693,8 +739,9 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(
 	chainID string,
 	commit *Commit,
 	trustLevel cmtmath.Fraction,
+	opts ...VerifyCommitOption,
 ) error {
-	return VerifyCommitLightTrusting(chainID, vals, commit, trustLevel)
+	return VerifyCommitLightTrusting(chainID, vals, commit, trustLevel, opts...)
 }
 
 // VerifyCommitLightTrusting verifies that trustLevel of the validator set signed
@@ -704,8 +751,9 @@ func (vals *ValidatorSet) VerifyCommitLightTrustingAllSignatures(
 	chainID string,
 	commit *Commit,
 	trustLevel cmtmath.Fraction,
+	opts ...VerifyCommitOption,
 ) error {
-	return VerifyCommitLightTrustingAllSignatures(chainID, vals, commit, trustLevel)
+	return VerifyCommitLightTrustingAllSignatures(chainID, vals, commit, trustLevel, opts...)
 }
 
 // findPreviousProposer reverses the compare proposer priority function to find the validator
@@ -872,6 +920,7 @@ func ValidatorSetFromProto(vp *cmtproto.ValidatorSet) (*ValidatorSet, error) {
 	// FIXME: We should look to remove TotalVotingPower from proto or add it in the validators hash
 	// so we don't have to do this
 	vals.TotalVotingPower()
+	vals.buildAddressIndex()
 
 	return vals, vals.ValidateBasic()
 }
@@ -897,6 +946,7 @@ func ValidatorSetFromExistingValidators(valz []*Validator) (*ValidatorSet, error
 	vals.Proposer = vals.findPreviousProposer()
 	vals.updateTotalVotingPower()
 	sort.Sort(ValidatorsByVotingPower(vals.Validators))
+	vals.buildAddressIndex()
 	return vals, nil
 }
 
@@ -150,6 +150,40 @@ func TestCopy(t *testing.T) {
 	}
 }
 
+func TestValidatorSetGetByAddressIndex(t *testing.T) {
+	vset := NewValidatorSet([]*Validator{
+		newValidator([]byte("foo"), 1000),
+		newValidator([]byte("bar"), 300),
+		newValidator([]byte("baz"), 330),
+	})
+	requireIndexes := func(vset *ValidatorSet) {
+		t.Helper()
+		for i, val := range vset.Validators {
+			idx, v := vset.GetByAddress(val.Address)
+			require.Equal(t, int32(i), idx)
+			require.Equal(t, val, v)
+		}
+		idx, v := vset.GetByAddress([]byte("qux"))
+		require.Equal(t, int32(-1), idx)
+		require.Nil(t, v)
+	}
+	requireIndexes(vset)
+	requireIndexes(vset.Copy())
+
+	require.NoError(t, vset.UpdateWithChangeSet([]*Validator{
+		newValidator([]byte("bar"), 0),
+		newValidator([]byte("quux"), 2000),
+	}))
+	requireIndexes(vset)
+	require.False(t, vset.HasAddress([]byte("bar")))
+
+	// The validators reordered in place or set directly are found too.
+	sort.Sort(ValidatorsByAddress(vset.Validators))
+	requireIndexes(vset)
+	vset.Validators = append([]*Validator{newValidator([]byte("corge"), 10)}, vset.Validators...)
+	requireIndexes(vset)
+}
+
 // Test that IncrementProposerPriority requires positive times.
 func TestIncrementProposerPriorityPositiveTimes(t *testing.T) {
 	vset := NewValidatorSet([]*Validator{
@@ -1578,6 +1612,38 @@ func BenchmarkUpdates(b *testing.B) {
 	}
 }
 
+func benchmarkValidatorSet(n int) *ValidatorSet {
+	vs := make([]*Validator, n)
+	for j := 0; j < n; j++ {
+		vs[j] = newValidator([]byte(fmt.Sprintf("v%d", j)), int64(j%100+1))
+	}
+	return NewValidatorSet(vs)
+}
+
+// The lookups by address must not be linear in the size of the set: they are
+// made for each signature of a commit.
+func BenchmarkValidatorSetGetByAddress(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		valSet := benchmarkValidatorSet(n)
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				valSet.GetByAddress(valSet.Validators[i%n].Address)
+			}
+		})
+	}
+}
+
+func BenchmarkValidatorSetIncrementProposerPriority(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		valSet := benchmarkValidatorSet(n)
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				valSet.IncrementProposerPriority(1)
+			}
+		})
+	}
+}
+
 func TestVerifyCommitWithInvalidProposerKey(t *testing.T) {
 	vs := &ValidatorSet{
 		Validators: []*Validator{{}, {}},
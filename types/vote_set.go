@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/internal/bits"
 	cmtsync "github.com/cometbft/cometbft/internal/sync"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
@@ -162,11 +163,26 @@ func (voteSet *VoteSet) AddVote(vote *Vote) (added bool, err error) {
 	voteSet.mtx.Lock()
 	defer voteSet.mtx.Unlock()
 
-	return voteSet.addVote(vote)
+	return voteSet.addVote(vote, nil, false)
+}
+
+// AddPreverifiedVote adds the vote as AddVote does, but skips the verification
+// of its signature if it was verified with pubKey, e.g. by a worker pool ahead
+// of time, and pubKey is the key of the validator at the vote's index. If the
+// extensions are enabled, extVerified must tell that the vote was verified with
+// VerifyVoteAndExtension, else the signatures are verified again.
+func (voteSet *VoteSet) AddPreverifiedVote(vote *Vote, pubKey crypto.PubKey, extVerified bool) (added bool, err error) {
+	if voteSet == nil {
+		panic("AddPreverifiedVote() on nil VoteSet")
+	}
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+
+	return voteSet.addVote(vote, pubKey, extVerified)
 }
 
 // NOTE: Validates as much as possible before attempting to verify the signature.
-func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
+func (voteSet *VoteSet) addVote(vote *Vote, verifiedBy crypto.PubKey, extVerified bool) (added bool, err error) {
 	if vote == nil {
 		return false, ErrVoteNil
 	}
@@ -214,14 +230,19 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 		return false, fmt.Errorf("existing vote: %v; new vote: %v: %w", existing, vote, ErrVoteNonDeterministicSignature)
 	}
 
-	// Check signature.
+	// Check signature, unless it was verified with the validator's key.
+	preverified := verifiedBy != nil && verifiedBy.Equals(val.PubKey)
 	if voteSet.extensionsEnabled {
-		if err := vote.VerifyVoteAndExtension(voteSet.chainID, val.PubKey); err != nil {
-			return false, fmt.Errorf("failed to verify extended vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
+		if !preverified || !extVerified {
+			if err := vote.VerifyVoteAndExtension(voteSet.chainID, val.PubKey); err != nil {
+				return false, fmt.Errorf("failed to verify extended vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
+			}
 		}
 	} else {
-		if err := vote.Verify(voteSet.chainID, val.PubKey); err != nil {
-			return false, fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
+		if !preverified {
+			if err := vote.Verify(voteSet.chainID, val.PubKey); err != nil {
+				return false, fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
+			}
 		}
 		if len(vote.ExtensionSignature) > 0 || len(vote.Extension) > 0 {
 			return false, fmt.Errorf("unexpected vote extension data present in vote; ext_len %d, sig_len %d",
@@ -553,6 +553,45 @@ func TestVoteSet_VoteExtensionsEnabled(t *testing.T) {
 	}
 }
 
+func TestVoteSet_AddPreverifiedVote(t *testing.T) {
+	height, round := int64(1), int32(0)
+	valSet, privValidators := RandValidatorSet(3, 10)
+	pubKey0, err := privValidators[0].GetPubKey()
+	require.NoError(t, err)
+	pubKey1, err := privValidators[1].GetPubKey()
+	require.NoError(t, err)
+
+	// The votes have invalid signatures, so only those whose verification
+	// is skipped are added.
+	vote := &Vote{
+		ValidatorAddress: pubKey0.Address(),
+		ValidatorIndex:   0,
+		Height:           height,
+		Round:            round,
+		Type:             PrecommitType,
+		Timestamp:        cmttime.Now(),
+		BlockID:          BlockID{crypto.CRandBytes(32), PartSetHeader{1, crypto.CRandBytes(32)}},
+		Signature:        crypto.CRandBytes(64),
+	}
+
+	voteSet := NewVoteSet("test_chain_id", height, round, PrecommitType, valSet)
+	added, err := voteSet.AddPreverifiedVote(vote, pubKey1, false)
+	require.ErrorIs(t, err, ErrVoteInvalidSignature)
+	require.False(t, added)
+	added, err = voteSet.AddPreverifiedVote(vote, pubKey0, false)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	vote.ExtensionSignature = crypto.CRandBytes(64)
+	voteSet = NewExtendedVoteSet("test_chain_id", height, round, PrecommitType, valSet)
+	added, err = voteSet.AddPreverifiedVote(vote, pubKey0, false)
+	require.ErrorIs(t, err, ErrVoteInvalidSignature)
+	require.False(t, added)
+	added, err = voteSet.AddPreverifiedVote(vote, pubKey0, true)
+	require.NoError(t, err)
+	require.True(t, added)
+}
+
 // NOTE: privValidators are in order
 func randVoteSet(
 	height int64,
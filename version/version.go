@@ -5,11 +5,11 @@ const (
 	// when not using git describe. It is formatted with semantic versioning.
 	CMTSemVer = "0.39.0-dev"
 	// ABCISemVer is the semantic version of the ABCI protocol.
-	ABCISemVer  = "2.0.0"
+	ABCISemVer  = "2.1.0"
 	ABCIVersion = ABCISemVer
 	// P2PProtocol versions all p2p behavior and msgs.
 	// This includes proposer selection.
-	P2PProtocol uint64 = 9
+	P2PProtocol uint64 = 10
 
 	// BlockProtocol versions all block data structures and processing.
 	// This includes validity of blocks and state updates.